@@ -65,7 +65,7 @@ func main() {
 	app.Version = version
 	app.Copyright = appCopyright
 	app.EnableBashCompletion = true
-	app.Flags = config.Flags.Cli()
+	app.Flags = append(append(config.Flags.Cli(), commands.JsonFlag), commands.RemoteFlags...)
 	app.Commands = commands.PhotoPrism
 	app.Metadata = Metadata
 
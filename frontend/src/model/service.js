@@ -56,6 +56,7 @@ export class Service extends RestModel {
       SyncUpload: false,
       SyncDownload: !config.get("readonly"),
       SyncRaw: true,
+      SyncConflict: "newest",
       CreatedAt: "",
       UpdatedAt: "",
       DeletedAt: null,
@@ -76,6 +77,12 @@ export class Service extends RestModel {
     );
   }
 
+  Authorize() {
+    return Api.get(this.getEntityResource() + "/authorize").then((response) =>
+      Promise.resolve(response.data)
+    );
+  }
+
   Upload(selection, folder) {
     if (!selection) {
       return;
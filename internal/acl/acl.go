@@ -39,7 +39,9 @@ func (acl ACL) DenyAll(resource Resource, role Role, perms Permissions) bool {
 
 // Allow checks whether the role is granted permission for the specified resource.
 func (acl ACL) Allow(resource Resource, role Role, perm Permission) bool {
-	if p, ok := acl[resource]; ok {
+	if grants, ok := CustomRole(role); ok {
+		return grants.Allow(resource, perm)
+	} else if p, ok := acl[resource]; ok {
 		return p.Allow(role, perm)
 	} else if p, ok = acl[ResourceDefault]; ok {
 		return p.Allow(role, perm)
@@ -77,3 +79,34 @@ func (acl ACL) AllowAll(resource Resource, role Role, perms Permissions) bool {
 
 	return true
 }
+
+// Privileges returns the number of permissions granted to the role across all resources.
+func (acl ACL) Privileges(role Role) (n int) {
+	if role == RoleUnknown {
+		return 0
+	}
+
+	for resource := range acl {
+		for i := range AllPermissions {
+			if acl.Allow(resource, role, AllPermissions[i]) {
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+// MorePrivileged returns the role that is granted more permissions, or the first role if both are equal.
+func (acl ACL) MorePrivileged(a, b Role) Role {
+	switch {
+	case a == RoleAdmin || b == RoleUnknown:
+		return a
+	case b == RoleAdmin || a == RoleUnknown:
+		return b
+	case acl.Privileges(b) > acl.Privileges(a):
+		return b
+	default:
+		return a
+	}
+}
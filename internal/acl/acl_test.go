@@ -105,3 +105,21 @@ func TestACL_Deny(t *testing.T) {
 		assert.True(t, Resources.Deny(ResourceAlbums, RoleVisitor, FullAccess))
 	})
 }
+
+func TestACL_MorePrivileged(t *testing.T) {
+	assert.Equal(t, RoleAdmin, Resources.MorePrivileged(RoleAdmin, RoleVisitor))
+	assert.Equal(t, RoleAdmin, Resources.MorePrivileged(RoleVisitor, RoleAdmin))
+	assert.Equal(t, RoleVisitor, Resources.MorePrivileged(RoleUnknown, RoleVisitor))
+	assert.Equal(t, RoleVisitor, Resources.MorePrivileged(RoleVisitor, RoleUnknown))
+	assert.Equal(t, RoleVisitor, Resources.MorePrivileged(RoleVisitor, RoleVisitor))
+
+	role := Role("uploader")
+
+	assert.NoError(t, SetCustomRole(role, CapabilityGrants([]Capability{CapView, CapUpload})))
+	defer DeleteCustomRole(role)
+
+	assert.Greater(t, Resources.Privileges(role), Resources.Privileges(RoleVisitor))
+	assert.Equal(t, role, Resources.MorePrivileged(RoleVisitor, role))
+	assert.Equal(t, role, Resources.MorePrivileged(role, RoleVisitor))
+	assert.Equal(t, RoleAdmin, Resources.MorePrivileged(role, RoleAdmin))
+}
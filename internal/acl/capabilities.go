@@ -0,0 +1,161 @@
+package acl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Capability represents a named set of permissions that can be assigned to custom roles.
+type Capability string
+
+// Capabilities that can be assigned to custom roles.
+const (
+	CapView    Capability = "view"
+	CapShared  Capability = "shared"
+	CapUpload  Capability = "upload"
+	CapEdit    Capability = "edit"
+	CapDelete  Capability = "delete"
+	CapAlbums  Capability = "albums"
+	CapShare   Capability = "share"
+	CapPrivate Capability = "private"
+	CapIndex   Capability = "index"
+)
+
+// Grants that allow users to browse and download library or shared content only.
+var (
+	grantBrowse = Grant{AccessLibrary: true, AccessShared: true, ActionSearch: true, ActionView: true, ActionDownload: true, ActionSubscribe: true}
+	grantShared = Grant{AccessShared: true, ActionSearch: true, ActionView: true, ActionDownload: true, ActionSubscribe: true}
+)
+
+// Capabilities maps capabilities to the permissions they grant, including event channel subscriptions.
+var Capabilities = map[Capability]RoleGrants{
+	CapView: {
+		ResourcePhotos:    grantBrowse,
+		ResourceVideos:    grantBrowse,
+		ResourceAlbums:    grantBrowse,
+		ResourceFolders:   grantBrowse,
+		ResourcePlaces:    grantBrowse,
+		ResourceCalendar:  grantBrowse,
+		ResourceMoments:   grantBrowse,
+		ResourceLabels:    grantBrowse,
+		ResourcePeople:    grantBrowse,
+		ResourceFavorites: grantBrowse,
+		ResourceFiles:     Grant{AccessLibrary: true, ActionView: true, ActionDownload: true},
+		ResourceSettings:  Grant{AccessOwn: true, ActionView: true, ActionUpdate: true},
+		ResourceUsers:     Grant{AccessOwn: true, ActionView: true, ActionUpdate: true},
+		ResourcePassword:  Grant{AccessOwn: true, ActionUpdate: true},
+		ResourceConfig:    Grant{AccessOwn: true},
+		ChannelUser:       GrantSubscribeOwn,
+		ChannelSession:    GrantSubscribeOwn,
+		ChannelNotify:     GrantSubscribeAll,
+		ChannelCount:      GrantSubscribeAll,
+		ChannelSubjects:   GrantSubscribeAll,
+	},
+	CapShared: {
+		ResourcePhotos:    grantShared,
+		ResourceVideos:    grantShared,
+		ResourceAlbums:    grantShared,
+		ResourceFolders:   grantShared,
+		ResourcePlaces:    grantShared,
+		ResourceCalendar:  grantShared,
+		ResourceMoments:   grantShared,
+		ResourceLabels:    grantShared,
+		ResourcePeople:    grantShared,
+		ResourceFavorites: grantShared,
+		ResourceFiles:     Grant{AccessShared: true, ActionView: true, ActionDownload: true},
+		ResourceSettings:  Grant{AccessOwn: true, ActionView: true, ActionUpdate: true},
+		ResourceUsers:     Grant{AccessOwn: true, ActionView: true, ActionUpdate: true},
+		ResourcePassword:  Grant{AccessOwn: true, ActionUpdate: true},
+		ResourceConfig:    Grant{AccessOwn: true},
+		ChannelUser:       GrantSubscribeOwn,
+		ChannelSession:    GrantSubscribeOwn,
+		ChannelNotify:     GrantSubscribeAll,
+		ChannelCount:      GrantSubscribeAll,
+		ChannelSubjects:   GrantSubscribeAll,
+	},
+	CapUpload: {
+		ResourcePhotos: Grant{ActionUpload: true},
+		ResourceFiles:  Grant{ActionUpload: true},
+		ResourceAlbums: Grant{ActionUpload: true},
+		ChannelUpload:  GrantSubscribeAll,
+	},
+	CapEdit: {
+		ResourcePhotos:    Grant{ActionUpdate: true, ActionRate: true, ActionReact: true},
+		ResourceVideos:    Grant{ActionUpdate: true, ActionRate: true, ActionReact: true},
+		ResourceFiles:     Grant{ActionUpdate: true},
+		ResourceLabels:    Grant{ActionUpdate: true},
+		ResourcePeople:    Grant{ActionUpdate: true},
+		ResourceFavorites: Grant{ActionUpdate: true},
+	},
+	CapDelete: {
+		ResourcePhotos: Grant{ActionDelete: true},
+		ResourceVideos: Grant{ActionDelete: true},
+		ResourceFiles:  Grant{ActionDelete: true},
+		ResourceLabels: Grant{ActionDelete: true},
+	},
+	CapAlbums: {
+		ResourceAlbums: Grant{ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionManage: true},
+	},
+	CapShare: {
+		ResourcePhotos: Grant{ActionShare: true},
+		ResourceAlbums: Grant{ActionShare: true},
+		ResourceLabels: Grant{ActionShare: true},
+		ResourceShares: Grant{AccessOwn: true, ActionSearch: true, ActionView: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionShare: true},
+	},
+	CapPrivate: {
+		ResourcePhotos: Grant{AccessPrivate: true},
+		ResourcePlaces: Grant{AccessPrivate: true},
+		ResourceAlbums: Grant{AccessPrivate: true},
+	},
+	CapIndex: {
+		ResourceFiles: Grant{AccessLibrary: true, ActionManage: true},
+		ChannelIndex:  GrantSubscribeAll,
+		ChannelImport: GrantSubscribeAll,
+	},
+}
+
+// ParseCapabilities parses a space or comma separated list of capabilities, e.g. "view upload".
+func ParseCapabilities(s string) (result []Capability, err error) {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	seen := make(map[Capability]bool, len(fields))
+
+	for _, f := range fields {
+		c := Capability(f)
+
+		if _, ok := Capabilities[c]; !ok {
+			return result, fmt.Errorf("unknown capability %s", f)
+		} else if seen[c] {
+			continue
+		}
+
+		seen[c] = true
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result, nil
+}
+
+// CapabilityGrants returns the combined permissions of the specified capabilities.
+func CapabilityGrants(caps []Capability) RoleGrants {
+	result := make(RoleGrants)
+
+	for _, c := range caps {
+		for resource, grant := range Capabilities[c] {
+			if result[resource] == nil {
+				result[resource] = make(Grant, len(grant))
+			}
+
+			for perm, allow := range grant {
+				result[resource][perm] = result[resource][perm] || allow
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,45 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCapabilities(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		caps, err := ParseCapabilities("Upload, view view")
+		assert.NoError(t, err)
+		assert.Equal(t, []Capability{CapUpload, CapView}, caps)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		caps, err := ParseCapabilities("")
+		assert.NoError(t, err)
+		assert.Empty(t, caps)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ParseCapabilities("view fly")
+		assert.Error(t, err)
+	})
+}
+
+func TestCapabilityGrants(t *testing.T) {
+	grants := CapabilityGrants([]Capability{CapView, CapEdit})
+
+	assert.True(t, grants.Allow(ResourcePhotos, ActionView))
+	assert.True(t, grants.Allow(ResourcePhotos, ActionUpdate))
+	assert.False(t, grants.Allow(ResourcePhotos, ActionDelete))
+	assert.False(t, grants.Allow(ResourceConfig, ActionView))
+	assert.True(t, grants.Allow(ResourceConfig, AccessOwn))
+	assert.True(t, grants.Allow(ChannelUser, ActionSubscribe))
+	assert.False(t, grants.Allow(ChannelUser, AccessAll))
+}
+
+func TestCapShared(t *testing.T) {
+	grants := CapabilityGrants([]Capability{CapShared})
+
+	assert.True(t, grants.Allow(ResourcePhotos, ActionSearch))
+	assert.True(t, grants.Allow(ResourcePhotos, AccessShared))
+	assert.False(t, grants.Allow(ResourcePhotos, AccessLibrary))
+	assert.False(t, grants.Allow(ResourcePhotos, AccessAll))
+}
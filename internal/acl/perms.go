@@ -24,6 +24,13 @@ const (
 	ActionSubscribe Permission = "subscribe"
 )
 
+// AllPermissions lists all permissions that can be granted, e.g. to compare the privileges of roles.
+var AllPermissions = Permissions{
+	FullAccess, AccessShared, AccessLibrary, AccessPrivate, AccessOwn, AccessAll,
+	ActionSearch, ActionView, ActionUpload, ActionCreate, ActionUpdate, ActionDownload,
+	ActionShare, ActionDelete, ActionRate, ActionReact, ActionManage, ActionSubscribe,
+}
+
 // Permission represents a single ability.
 type Permission string
 
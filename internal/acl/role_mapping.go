@@ -0,0 +1,56 @@
+package acl
+
+import (
+	"strings"
+)
+
+// RoleMap maps an external group or claim value to a role.
+type RoleMap struct {
+	Value string
+	Role  Role
+}
+
+// RoleMapping maps external group or claim values to roles in order of priority.
+type RoleMapping []RoleMap
+
+// ParseRoleMapping parses a comma separated list of value=role pairs, e.g. "photoprism-admins=admin, family=viewer".
+func ParseRoleMapping(s string) (result RoleMapping) {
+	for _, pair := range strings.Split(s, ",") {
+		value, role, found := strings.Cut(pair, "=")
+
+		if value, role = strings.TrimSpace(value), strings.TrimSpace(role); !found || value == "" || role == "" {
+			continue
+		}
+
+		result = append(result, RoleMap{Value: value, Role: Role(strings.ToLower(role))})
+	}
+
+	return result
+}
+
+// String returns the mapping as a comma separated list of value=role pairs.
+func (m RoleMapping) String() string {
+	s := make([]string, len(m))
+
+	for i := range m {
+		s[i] = m[i].Value + "=" + m[i].Role.String()
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// Match returns the first role that matches one of the values, or RoleUnknown if none does.
+// Mapped roles that do not exist, e.g. because a custom role was deleted, are skipped.
+func (m RoleMapping) Match(values []string) Role {
+	for _, rm := range m {
+		for _, v := range values {
+			if !strings.EqualFold(rm.Value, v) {
+				continue
+			} else if role := FindRole(rm.Role.String()); role != RoleUnknown {
+				return role
+			}
+		}
+	}
+
+	return RoleUnknown
+}
@@ -0,0 +1,24 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoleMapping(t *testing.T) {
+	m := ParseRoleMapping("photoprism-admins=Admin, family = visitor, invalid, =admin")
+
+	assert.Equal(t, RoleMapping{{Value: "photoprism-admins", Role: RoleAdmin}, {Value: "family", Role: RoleVisitor}}, m)
+	assert.Equal(t, "photoprism-admins=admin, family=visitor", m.String())
+	assert.Empty(t, ParseRoleMapping(""))
+}
+
+func TestRoleMapping_Match(t *testing.T) {
+	m := ParseRoleMapping("photoprism-admins=admin, family=visitor, friends=nonexistent")
+
+	assert.Equal(t, RoleAdmin, m.Match([]string{"family", "Photoprism-Admins"}))
+	assert.Equal(t, RoleVisitor, m.Match([]string{"family"}))
+	assert.Equal(t, RoleUnknown, m.Match([]string{"friends"}))
+	assert.Equal(t, RoleUnknown, m.Match(nil))
+}
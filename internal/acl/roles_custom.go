@@ -0,0 +1,127 @@
+package acl
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RoleGrants specifies the permissions of a role by resource.
+type RoleGrants map[Resource]Grant
+
+// Allow checks whether the permission is granted for the specified resource.
+func (grants RoleGrants) Allow(resource Resource, perm Permission) bool {
+	if grant, ok := grants[resource]; ok {
+		return grant.Allow(perm)
+	}
+
+	return false
+}
+
+// CustomRoleLoader returns the custom roles from persistent storage, e.g. the database.
+type CustomRoleLoader func() (map[Role]RoleGrants, error)
+
+// CustomRoleExpiration specifies how long loaded custom roles are cached before they are reloaded,
+// so that changes made by other processes, e.g. CLI commands, are applied without a restart.
+var CustomRoleExpiration = 15 * time.Second
+
+// customRoles contains the roles defined by admins in addition to the built-in roles.
+var customRoles = struct {
+	sync.RWMutex
+	roles  map[Role]RoleGrants
+	loader CustomRoleLoader
+	loaded time.Time
+}{roles: make(map[Role]RoleGrants)}
+
+// SetCustomRoleLoader sets the function used to (re)load custom roles and loads them.
+func SetCustomRoleLoader(loader CustomRoleLoader) {
+	customRoles.Lock()
+	customRoles.loader = loader
+	customRoles.loaded = time.Time{}
+	customRoles.Unlock()
+
+	loadCustomRoles()
+}
+
+// loadCustomRoles reloads the custom roles if a loader is set and the cached roles have expired.
+func loadCustomRoles() {
+	customRoles.RLock()
+	expired := customRoles.loader != nil && time.Since(customRoles.loaded) > CustomRoleExpiration
+	customRoles.RUnlock()
+
+	if !expired {
+		return
+	}
+
+	customRoles.Lock()
+	defer customRoles.Unlock()
+
+	// Another goroutine may have reloaded the roles in the meantime.
+	if customRoles.loader == nil || time.Since(customRoles.loaded) <= CustomRoleExpiration {
+		return
+	}
+
+	customRoles.loaded = time.Now()
+
+	// Keep the current roles and retry later if they cannot be loaded.
+	if roles, err := customRoles.loader(); err != nil {
+		return
+	} else if roles == nil {
+		customRoles.roles = make(map[Role]RoleGrants)
+	} else {
+		customRoles.roles = roles
+	}
+}
+
+// SetCustomRole adds or updates a custom role with the specified permissions.
+func SetCustomRole(role Role, grants RoleGrants) error {
+	if role == RoleUnknown {
+		return fmt.Errorf("role name must not be empty")
+	} else if _, builtIn := ValidRoles[role.String()]; builtIn || role == RoleDefault {
+		return fmt.Errorf("role %s is reserved", role.String())
+	}
+
+	customRoles.Lock()
+	defer customRoles.Unlock()
+
+	customRoles.roles[role] = grants
+
+	return nil
+}
+
+// DeleteCustomRole removes a custom role.
+func DeleteCustomRole(role Role) {
+	customRoles.Lock()
+	defer customRoles.Unlock()
+
+	delete(customRoles.roles, role)
+}
+
+// CustomRole returns the permissions of a custom role, if it exists.
+func CustomRole(role Role) (grants RoleGrants, ok bool) {
+	loadCustomRoles()
+
+	customRoles.RLock()
+	defer customRoles.RUnlock()
+
+	grants, ok = customRoles.roles[role]
+
+	return grants, ok
+}
+
+// IsCustomRole checks if the role has been defined by an admin.
+func IsCustomRole(role Role) bool {
+	_, ok := CustomRole(role)
+	return ok
+}
+
+// FindRole returns the built-in or custom role with the specified name, or RoleUnknown if none exists.
+func FindRole(name string) Role {
+	if role, ok := ValidRoles[name]; ok {
+		return role
+	} else if role = Role(name); IsCustomRole(role) {
+		return role
+	}
+
+	return RoleUnknown
+}
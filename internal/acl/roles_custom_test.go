@@ -0,0 +1,71 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCustomRole(t *testing.T) {
+	t.Run("Reserved", func(t *testing.T) {
+		assert.Error(t, SetCustomRole(RoleAdmin, RoleGrants{}))
+		assert.Error(t, SetCustomRole(RoleDefault, RoleGrants{}))
+		assert.Error(t, SetCustomRole(RoleUnknown, RoleGrants{}))
+	})
+	t.Run("Contributor", func(t *testing.T) {
+		role := Role("contributor")
+
+		assert.Equal(t, RoleUnknown, FindRole("contributor"))
+		assert.NoError(t, SetCustomRole(role, CapabilityGrants([]Capability{CapView, CapUpload})))
+		assert.True(t, IsCustomRole(role))
+		assert.Equal(t, role, FindRole("contributor"))
+		assert.Equal(t, RoleAdmin, FindRole("admin"))
+
+		assert.True(t, Resources.Allow(ResourcePhotos, role, ActionSearch))
+		assert.True(t, Resources.Allow(ResourceFiles, role, ActionUpload))
+		assert.False(t, Resources.Allow(ResourcePhotos, role, ActionDelete))
+		assert.False(t, Resources.Allow(ResourceConfig, role, ActionUpdate))
+		assert.True(t, Events.Allow(ChannelUpload, role, ActionSubscribe))
+
+		DeleteCustomRole(role)
+
+		assert.False(t, IsCustomRole(role))
+		assert.False(t, Resources.Allow(ResourcePhotos, role, ActionSearch))
+	})
+}
+
+func TestSetCustomRoleLoader(t *testing.T) {
+	role := Role("reloaded")
+	grants := CapabilityGrants([]Capability{CapView})
+	loaded := 0
+
+	SetCustomRoleLoader(func() (map[Role]RoleGrants, error) {
+		loaded++
+		return map[Role]RoleGrants{role: grants}, nil
+	})
+
+	defer func() {
+		customRoles.Lock()
+		customRoles.loader = nil
+		customRoles.roles = make(map[Role]RoleGrants)
+		customRoles.Unlock()
+	}()
+
+	assert.Equal(t, 1, loaded)
+	assert.True(t, IsCustomRole(role))
+	assert.Equal(t, 1, loaded)
+
+	// Roles are reloaded once they have expired, e.g. to apply changes made with CLI commands.
+	expiration := CustomRoleExpiration
+	CustomRoleExpiration = 0
+	defer func() { CustomRoleExpiration = expiration }()
+
+	SetCustomRoleLoader(func() (map[Role]RoleGrants, error) {
+		loaded++
+		return nil, nil
+	})
+
+	assert.False(t, IsCustomRole(role))
+	assert.Equal(t, RoleUnknown, FindRole("reloaded"))
+	assert.Greater(t, loaded, 2)
+}
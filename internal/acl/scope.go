@@ -0,0 +1,202 @@
+package acl
+
+import (
+	"strings"
+)
+
+// Scopes that can be granted to access tokens.
+const (
+	ScopeAll          Scope = "*"
+	ScopePhotosRead   Scope = "photos.read"
+	ScopePhotosWrite  Scope = "photos.write"
+	ScopeAlbumsManage Scope = "albums.manage"
+	ScopeSharesManage Scope = "shares.manage"
+	ScopeAdmin        Scope = "admin"
+)
+
+// ValidScopes specifies the scopes that can be granted.
+var ValidScopes = map[Scope]bool{
+	ScopeAll:          true,
+	ScopePhotosRead:   true,
+	ScopePhotosWrite:  true,
+	ScopeAlbumsManage: true,
+	ScopeSharesManage: true,
+	ScopeAdmin:        true,
+}
+
+// ReadPermissions specifies the permissions that do not allow changes.
+var ReadPermissions = Grant{
+	AccessShared:    true,
+	AccessLibrary:   true,
+	AccessPrivate:   true,
+	AccessOwn:       true,
+	AccessAll:       true,
+	ActionSearch:    true,
+	ActionView:      true,
+	ActionDownload:  true,
+	ActionSubscribe: true,
+}
+
+// scopeResources specifies the resources covered by each scope and whether changes are allowed.
+var scopeResources = map[Scope]struct {
+	Resources []Resource
+	Write     bool
+}{
+	ScopePhotosRead: {
+		Resources: []Resource{ResourcePhotos, ResourceVideos, ResourceFiles, ResourceFolders, ResourceAlbums, ResourceFavorites, ResourcePlaces, ResourceCalendar, ResourceMoments, ResourceLabels, ResourcePeople, ResourceSettings},
+		Write:     false,
+	},
+	ScopePhotosWrite: {
+		Resources: []Resource{ResourcePhotos, ResourceVideos, ResourceFiles, ResourceFavorites, ResourceLabels, ResourcePeople},
+		Write:     true,
+	},
+	ScopeAlbumsManage: {
+		Resources: []Resource{ResourceAlbums, ResourceFolders, ResourceShares},
+		Write:     true,
+	},
+	ScopeSharesManage: {
+		Resources: []Resource{ResourceShares},
+		Write:     true,
+	},
+}
+
+// Scope represents an access token scope, e.g. "photos.read".
+type Scope string
+
+// String returns the scope as string.
+func (s Scope) String() string {
+	return string(s)
+}
+
+// Valid checks if the scope is known.
+func (s Scope) Valid() bool {
+	return ValidScopes[s]
+}
+
+// Allow checks if the scope grants the permission for the specified resource.
+func (s Scope) Allow(resource Resource, perm Permission) bool {
+	switch s {
+	case ScopeAll, ScopeAdmin:
+		return true
+	}
+
+	def, ok := scopeResources[s]
+
+	if !ok || !def.Write && !ReadPermissions.Allow(perm) {
+		return false
+	}
+
+	for _, r := range def.Resources {
+		if r == resource {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Scopes represents a list of access token scopes.
+type Scopes []Scope
+
+// ParseScope parses a space or comma separated list of scopes. An empty string grants full access.
+func ParseScope(s string) Scopes {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return Scopes{ScopeAll}
+	}
+
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	result := make(Scopes, 0, len(fields))
+
+	for _, f := range fields {
+		result = append(result, Scope(f))
+	}
+
+	return result
+}
+
+// String returns the scopes as a space separated string.
+func (list Scopes) String() string {
+	s := make([]string, len(list))
+
+	for i := range list {
+		s[i] = list[i].String()
+	}
+
+	return strings.Join(s, " ")
+}
+
+// Valid checks if all scopes are known.
+func (list Scopes) Valid() bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	for _, s := range list {
+		if !s.Valid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Full checks if the scopes grant unrestricted access.
+func (list Scopes) Full() bool {
+	for _, s := range list {
+		if s == ScopeAll || s == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allow checks if any of the scopes grants the permission for the specified resource.
+func (list Scopes) Allow(resource Resource, perm Permission) bool {
+	for _, s := range list {
+		if s.Allow(resource, perm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowAny checks if any of the permissions is granted for the specified resource.
+func (list Scopes) AllowAny(resource Resource, perms Permissions) bool {
+	for _, perm := range perms {
+		if list.Allow(resource, perm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DenyAll checks if none of the permissions is granted for the specified resource.
+func (list Scopes) DenyAll(resource Resource, perms Permissions) bool {
+	return !list.AllowAny(resource, perms)
+}
+
+// Deny checks if the scopes do not grant the requested access. If any of the permissions allows
+// changes, one of these must be granted, as read permissions alone are not sufficient in this case.
+func (list Scopes) Deny(resource Resource, perms Permissions) bool {
+	writePerms := make(Permissions, 0, len(perms))
+
+	for _, perm := range perms {
+		if !ReadPermissions.Allow(perm) {
+			writePerms = append(writePerms, perm)
+		}
+	}
+
+	if len(writePerms) > 0 {
+		return list.DenyAll(resource, writePerms)
+	}
+
+	return list.DenyAll(resource, perms)
+}
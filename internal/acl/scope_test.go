@@ -0,0 +1,87 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScope(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := ParseScope("")
+		assert.Equal(t, Scopes{ScopeAll}, s)
+		assert.True(t, s.Full())
+		assert.True(t, s.Valid())
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		s := ParseScope("photos.read, Albums.Manage")
+		assert.Equal(t, Scopes{ScopePhotosRead, ScopeAlbumsManage}, s)
+		assert.Equal(t, "photos.read albums.manage", s.String())
+		assert.False(t, s.Full())
+		assert.True(t, s.Valid())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		s := ParseScope("photos.read foo")
+		assert.False(t, s.Valid())
+	})
+}
+
+func TestScopes_Allow(t *testing.T) {
+	t.Run("PhotosRead", func(t *testing.T) {
+		s := ParseScope("photos.read")
+		assert.True(t, s.Allow(ResourcePhotos, ActionView))
+		assert.True(t, s.Allow(ResourceAlbums, ActionSearch))
+		assert.True(t, s.Allow(ResourcePhotos, ActionDownload))
+		assert.False(t, s.Allow(ResourcePhotos, ActionUpdate))
+		assert.False(t, s.Allow(ResourcePhotos, FullAccess))
+		assert.False(t, s.Allow(ResourceUsers, ActionView))
+		assert.False(t, s.Allow(ResourceConfig, ActionView))
+	})
+	t.Run("PhotosWrite", func(t *testing.T) {
+		s := ParseScope("photos.write")
+		assert.True(t, s.Allow(ResourcePhotos, ActionUpdate))
+		assert.True(t, s.Allow(ResourcePhotos, ActionView))
+		assert.False(t, s.Allow(ResourceAlbums, ActionUpdate))
+	})
+	t.Run("AlbumsManage", func(t *testing.T) {
+		s := ParseScope("albums.manage")
+		assert.True(t, s.Allow(ResourceAlbums, ActionCreate))
+		assert.True(t, s.Allow(ResourceShares, ActionCreate))
+		assert.False(t, s.Allow(ResourcePhotos, ActionDelete))
+	})
+	t.Run("SharesManage", func(t *testing.T) {
+		s := ParseScope("shares.manage")
+		assert.True(t, s.Valid())
+		assert.True(t, s.Allow(ResourceShares, ActionCreate))
+		assert.True(t, s.Allow(ResourceShares, ActionDelete))
+		assert.False(t, s.Allow(ResourceAlbums, ActionUpdate))
+		assert.False(t, s.Allow(ResourcePhotos, ActionView))
+	})
+	t.Run("Admin", func(t *testing.T) {
+		s := ParseScope("admin")
+		assert.True(t, s.Allow(ResourceConfig, ActionUpdate))
+		assert.True(t, s.Allow(ResourceUsers, ActionDelete))
+	})
+	t.Run("AllowAny", func(t *testing.T) {
+		s := ParseScope("photos.read")
+		assert.True(t, s.AllowAny(ResourcePhotos, Permissions{ActionUpdate, ActionView}))
+		assert.False(t, s.AllowAny(ResourcePhotos, Permissions{ActionUpdate, ActionDelete}))
+		assert.True(t, s.DenyAll(ResourcePhotos, Permissions{ActionUpdate}))
+		assert.False(t, s.AllowAny(ResourcePhotos, Permissions{}))
+	})
+	t.Run("Deny", func(t *testing.T) {
+		read := ParseScope("photos.read")
+		assert.False(t, read.Deny(ResourcePhotos, Permissions{ActionSearch, ActionView}))
+		assert.True(t, read.Deny(ResourcePhotos, Permissions{ActionUpdate, AccessOwn}))
+		assert.True(t, read.Deny(ResourceSettings, Permissions{ActionView, ActionUpdate, ActionManage}))
+		assert.False(t, read.Deny(ResourceSettings, Permissions{AccessAll, AccessOwn}))
+
+		write := ParseScope("photos.write")
+		assert.False(t, write.Deny(ResourcePhotos, Permissions{ActionUpdate, AccessOwn}))
+		assert.True(t, write.Deny(ResourceAlbums, Permissions{ActionUpdate, AccessShared}))
+
+		albums := ParseScope("albums.manage")
+		assert.False(t, albums.Deny(ResourceAlbums, Permissions{ActionShare, AccessShared, AccessOwn}))
+		assert.False(t, ParseScope("").Deny(ResourceSettings, Permissions{ActionUpdate}))
+	})
+}
@@ -0,0 +1,65 @@
+/*
+Package aesthetic provides image quality scoring to detect blurry and poorly exposed pictures.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package aesthetic
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Quality models.
+const (
+	ModelNone      = "none"      // Quality score based on metadata only.
+	ModelBasic     = "basic"     // Sharpness and exposure measured on the image pixels.
+	ModelAesthetic = "aesthetic" // Learned aesthetic model, requires TensorFlow.
+)
+
+// Thresholds for flagging pictures.
+var (
+	BlurThreshold      float32 = 0.3  // Pictures with a lower sharpness are considered out of focus.
+	UnderexposureLimit float32 = 0.12 // Pictures with a lower mean luminance are considered underexposed.
+	OverexposureLimit  float32 = 0.88 // Pictures with a higher mean luminance are considered overexposed.
+	ClippingLimit      float32 = 0.5  // Pictures with more clipped pixels are considered poorly exposed.
+	HighScore          float32 = 0.75 // Pictures with a higher score are considered particularly good.
+)
+
+// Result represents the quality scores of an image, each ranging from 0 to 1.
+type Result struct {
+	Score     float32 `json:"score"`
+	Sharpness float32 `json:"sharpness"`
+	Exposure  float32 `json:"exposure"`
+	Clipping  float32 `json:"clipping"`
+}
+
+// Blurry tests if the image seems to be out of focus.
+func (r Result) Blurry() bool {
+	return r.Sharpness < BlurThreshold
+}
+
+// PoorlyExposed tests if the image seems to be under- or overexposed.
+func (r Result) PoorlyExposed() bool {
+	return r.Exposure < UnderexposureLimit || r.Exposure > OverexposureLimit || r.Clipping > ClippingLimit
+}
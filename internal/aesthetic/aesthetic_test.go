@@ -0,0 +1,19 @@
+package aesthetic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult_Blurry(t *testing.T) {
+	assert.True(t, Result{Sharpness: 0.1, Exposure: 0.5}.Blurry())
+	assert.False(t, Result{Sharpness: 0.8, Exposure: 0.5}.Blurry())
+}
+
+func TestResult_PoorlyExposed(t *testing.T) {
+	assert.False(t, Result{Exposure: 0.5}.PoorlyExposed())
+	assert.True(t, Result{Exposure: 0.05}.PoorlyExposed())
+	assert.True(t, Result{Exposure: 0.95}.PoorlyExposed())
+	assert.True(t, Result{Exposure: 0.5, Clipping: 0.7}.PoorlyExposed())
+}
@@ -0,0 +1,82 @@
+package aesthetic
+
+import (
+	"image"
+	"math"
+)
+
+// BlurVariance is the variance of the Laplacian at which the sharpness is 0.5,
+// based on images with a size of about 720 pixels.
+const BlurVariance = 100.0
+
+// Measure returns the sharpness and exposure of an image, as well as a basic
+// quality score that combines both.
+func Measure(img image.Image) (result Result) {
+	if img == nil {
+		return result
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	if w < 3 || h < 3 {
+		return result
+	}
+
+	// Convert to luminance values.
+	lum := make([]float64, w*h)
+
+	var sum float64
+	var clipped int
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			l := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+
+			lum[y*w+x] = l
+			sum += l
+
+			if l <= 5 || l >= 250 {
+				clipped++
+			}
+		}
+	}
+
+	n := float64(w * h)
+
+	result.Exposure = float32(sum / n / 255)
+	result.Clipping = float32(float64(clipped) / n)
+
+	// Variance of the Laplacian, a common measure for the amount of edges.
+	var lapSum, lapSq float64
+
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			v := lum[i-w] + lum[i+w] + lum[i-1] + lum[i+1] - 4*lum[i]
+			lapSum += v
+			lapSq += v * v
+		}
+	}
+
+	m := float64((w - 2) * (h - 2))
+	mean := lapSum / m
+	variance := lapSq/m - mean*mean
+
+	result.Sharpness = float32(variance / (variance + BlurVariance))
+	result.Score = BasicScore(result)
+
+	return result
+}
+
+// BasicScore returns a quality score from 0 to 1 based on the sharpness and exposure.
+func BasicScore(r Result) float32 {
+	exposure := (1 - 2*math.Abs(float64(r.Exposure)-0.5)) * (1 - float64(r.Clipping))
+
+	if exposure < 0 {
+		exposure = 0
+	}
+
+	return float32(0.6*float64(r.Sharpness) + 0.4*exposure)
+}
@@ -0,0 +1,64 @@
+package aesthetic
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(w, h int, fn func(x, y int) uint8) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetGray(x, y, color.Gray{Y: fn(x, y)})
+		}
+	}
+
+	return img
+}
+
+func TestMeasure(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, Result{}, Measure(nil))
+	})
+	t.Run("Sharp", func(t *testing.T) {
+		result := Measure(testImage(64, 64, func(x, y int) uint8 {
+			if (x/4+y/4)%2 == 0 {
+				return 40
+			}
+
+			return 200
+		}))
+
+		assert.False(t, result.Blurry())
+		assert.False(t, result.PoorlyExposed())
+		assert.Greater(t, result.Score, float32(0.5))
+	})
+	t.Run("Blurry", func(t *testing.T) {
+		result := Measure(testImage(64, 64, func(x, y int) uint8 {
+			return uint8(100 + x/2)
+		}))
+
+		assert.True(t, result.Blurry())
+		assert.False(t, result.PoorlyExposed())
+	})
+	t.Run("Underexposed", func(t *testing.T) {
+		result := Measure(testImage(64, 64, func(x, y int) uint8 {
+			return 2
+		}))
+
+		assert.True(t, result.Blurry())
+		assert.True(t, result.PoorlyExposed())
+		assert.InDelta(t, 0.0, result.Score, 0.05)
+	})
+}
+
+func TestBasicScore(t *testing.T) {
+	assert.InDelta(t, 1.0, BasicScore(Result{Sharpness: 1, Exposure: 0.5}), 0.001)
+	assert.InDelta(t, 0.4, BasicScore(Result{Sharpness: 0, Exposure: 0.5}), 0.001)
+	assert.InDelta(t, 0.6, BasicScore(Result{Sharpness: 1, Exposure: 0}), 0.001)
+	assert.InDelta(t, 0.6, BasicScore(Result{Sharpness: 1, Exposure: 0.5, Clipping: 1}), 0.001)
+}
@@ -0,0 +1,209 @@
+package aesthetic
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"os"
+	"path/filepath"
+	"sync"
+
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+	"github.com/tensorflow/tensorflow/tensorflow/go/op"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Model scores the quality of images, optionally based on a learned aesthetic model
+// that predicts the distribution of human ratings from 1 to 10.
+type Model struct {
+	name      string
+	model     *tf.SavedModel
+	modelPath string
+	modelTags []string
+	input     string
+	output    string
+	failed    bool
+	mutex     sync.Mutex
+}
+
+// New returns a new quality model, the model path is only used for the learned aesthetic model.
+func New(name, modelPath string) *Model {
+	return &Model{name: name, modelPath: modelPath, modelTags: []string{"serve"}, input: "input_1", output: "predictions/Softmax"}
+}
+
+// Name returns the quality model name.
+func (m *Model) Name() string {
+	return m.name
+}
+
+// Disabled tests if quality scoring is disabled.
+func (m *Model) Disabled() bool {
+	return m == nil || m.name == "" || m.name == ModelNone
+}
+
+// File returns the quality scores for a JPEG file.
+func (m *Model) File(fileName string) (result Result, err error) {
+	if m.Disabled() {
+		return result, fmt.Errorf("aesthetic: quality scoring is disabled")
+	} else if fs.MimeType(fileName) != fs.MimeTypeJPEG {
+		return result, fmt.Errorf("aesthetic: %s is not a jpeg file", clean.Log(filepath.Base(fileName)))
+	}
+
+	imageBuffer, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return m.Image(imageBuffer)
+}
+
+// Image returns the quality scores for a JPEG image.
+func (m *Model) Image(imageBuffer []byte) (result Result, err error) {
+	img, _, err := image.Decode(bytes.NewReader(imageBuffer))
+
+	if err != nil {
+		return result, fmt.Errorf("aesthetic: %s", err)
+	}
+
+	result = Measure(img)
+
+	if m.name != ModelAesthetic {
+		return result, nil
+	}
+
+	// Use the learned model score if available, the basic score is kept otherwise.
+	if score, err := m.predict(imageBuffer); err != nil {
+		log.Debugf("aesthetic: %s", err)
+	} else {
+		result.Score = score
+	}
+
+	return result, nil
+}
+
+// predict runs the learned model and returns the mean rating scaled to a score from 0 to 1.
+func (m *Model) predict(imageBuffer []byte) (float32, error) {
+	if err := m.loadModel(); err != nil {
+		return 0, err
+	}
+
+	tensor, err := createTensor(imageBuffer)
+
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := m.model.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			m.model.Graph.Operation(m.input).Output(0): tensor,
+		},
+		[]tf.Output{
+			m.model.Graph.Operation(m.output).Output(0),
+		},
+		nil)
+
+	if err != nil {
+		return 0, fmt.Errorf("%s (run inference)", err)
+	} else if len(output) < 1 {
+		return 0, fmt.Errorf("inference failed, no output")
+	}
+
+	return MeanScore(output[0].Value().([][]float32)[0]), nil
+}
+
+// MeanScore returns the mean of a rating distribution from 1 to n, scaled to a score from 0 to 1.
+func MeanScore(p []float32) float32 {
+	if len(p) < 2 {
+		return 0
+	}
+
+	var mean, total float32
+
+	for i, v := range p {
+		mean += float32(i+1) * v
+		total += v
+	}
+
+	if total <= 0 {
+		return 0
+	}
+
+	return (mean/total - 1) / float32(len(p)-1)
+}
+
+// loadModel loads the learned aesthetic model, if not already loaded.
+func (m *Model) loadModel() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.model != nil {
+		// Already loaded.
+		return nil
+	} else if m.failed {
+		return fmt.Errorf("model %s could not be loaded", clean.Log(filepath.Base(m.modelPath)))
+	}
+
+	log.Infof("aesthetic: loading %s", clean.Log(filepath.Base(m.modelPath)))
+
+	model, err := tf.LoadSavedModel(m.modelPath, m.modelTags, nil)
+
+	if err != nil {
+		// Don't try again, the basic score is used instead.
+		m.failed = true
+		log.Warnf("aesthetic: %s (load model)", err)
+		return err
+	}
+
+	m.model = model
+
+	return nil
+}
+
+// createTensor returns a normalized tensor with a size of 224x224 pixels for the learned model.
+func createTensor(imageBuffer []byte) (*tf.Tensor, error) {
+	tensor, err := tf.NewTensor(string(imageBuffer))
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := op.NewScope()
+	input := op.Placeholder(s, tf.String)
+
+	// Scale pixel values to [-1, 1].
+	output := op.Sub(s,
+		op.Div(s,
+			op.ResizeBilinear(s,
+				op.ExpandDims(s,
+					op.Cast(s, op.DecodeJpeg(s, input, op.DecodeJpegChannels(3)), tf.Float),
+					op.Const(s.SubScope("make_batch"), int32(0))),
+				op.Const(s.SubScope("size"), []int32{224, 224})),
+			op.Const(s.SubScope("scale"), float32(127.5))),
+		op.Const(s.SubScope("mean"), float32(1)))
+
+	graph, err := s.Finalize()
+
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := tf.NewSession(graph, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer session.Close()
+
+	normalized, err := session.Run(map[tf.Output]*tf.Tensor{input: tensor}, []tf.Output{output}, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized[0], nil
+}
@@ -0,0 +1,43 @@
+package aesthetic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var examplesPath = fs.Abs("../../assets/examples")
+
+func TestModel_File(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		m := New(ModelBasic, "")
+		result, err := m.File(examplesPath + "/chameleon_lime.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, result.Score, float32(0))
+		assert.False(t, result.PoorlyExposed())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		m := New(ModelNone, "")
+		_, err := m.File(examplesPath + "/chameleon_lime.jpg")
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		m := New(ModelBasic, "")
+		_, err := m.File(examplesPath + "/notexisting.jpg")
+		assert.Error(t, err)
+	})
+}
+
+func TestMeanScore(t *testing.T) {
+	assert.Equal(t, float32(0), MeanScore(nil))
+	assert.Equal(t, float32(0), MeanScore([]float32{0, 0, 0}))
+	assert.InDelta(t, 0.0, MeanScore([]float32{1, 0, 0, 0, 0, 0, 0, 0, 0, 0}), 0.001)
+	assert.InDelta(t, 1.0, MeanScore([]float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}), 0.001)
+	assert.InDelta(t, 0.5, MeanScore([]float32{0, 0, 0, 0, 0.5, 0.5, 0, 0, 0, 0}), 0.001)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/list"
 )
 
 var albumMutex = sync.Mutex{}
@@ -57,7 +58,15 @@ func GetAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		c.JSON(http.StatusOK, a)
+		// Hide the cover of locked albums until they are unlocked.
+		if search.AlbumLocked(a.AlbumUID, s) {
+			a.Thumb = ""
+			a.ThumbSrc = ""
+		}
+
+		AddEntityTagHeader(c, a.UpdatedAt)
+
+		c.JSON(http.StatusOK, albumResponse{Album: a, Breadcrumbs: a.Breadcrumbs()})
 	})
 }
 
@@ -79,11 +88,18 @@ func CreateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Smart albums are populated based on a search filter, e.g. "label:dog quality:4".
+		if err := validAlbumFilter(f.AlbumFilter); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
 		a := entity.NewUserAlbum(f.AlbumTitle, entity.AlbumManual, s.UserUID)
 		a.AlbumFavorite = f.AlbumFavorite
+		a.AlbumFilter = f.AlbumFilter
 
 		// Existing album?
 		if found := a.Find(); found == nil {
@@ -108,6 +124,9 @@ func CreateAlbum(router *gin.RouterGroup) {
 			}
 		}
 
+		// Add the pictures matching the filter of smart albums.
+		UpdateSmartAlbum(*a)
+
 		UpdateClientConfig()
 
 		// Update album YAML backup.
@@ -137,6 +156,12 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Don't overwrite changes that were made in the meantime.
+		if PreconditionFailed(c, a.UpdatedAt) {
+			AbortConflict(c, a.UpdatedAt, a)
+			return
+		}
+
 		f, err := form.NewAlbum(a)
 
 		if err != nil {
@@ -145,12 +170,19 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		before := f
+
 		if err := c.BindJSON(&f); err != nil {
 			log.Error(err)
 			AbortBadRequest(c)
 			return
 		}
 
+		if err = validAlbumFilter(f.AlbumFilter); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
@@ -160,11 +192,21 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		recordChange(s, entity.ChangeAlbum, uid, entity.ChangeActionUpdate, before, f)
+
+		// Update the pictures of smart albums in case the filter has changed.
+		UpdateSmartAlbum(a)
+
 		UpdateClientConfig()
 
 		// Update album YAML backup.
 		SaveAlbumAsYaml(a)
 
+		// Use the stored timestamp, which may be less precise.
+		if saved, err := query.AlbumByUID(uid); err == nil {
+			AddEntityTagHeader(c, saved.UpdatedAt)
+		}
+
 		c.JSON(http.StatusOK, a)
 	})
 }
@@ -207,6 +249,8 @@ func DeleteAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "deleted album %s"}, s.RefID, clean.Log(a.AlbumUID))
+
 		// PublishAlbumEvent(EntityDeleted, id, c)
 
 		UpdateClientConfig()
@@ -338,7 +382,7 @@ func CloneAlbums(router *gin.RouterGroup) {
 				continue
 			}
 
-			added = append(added, a.AddPhotos(photos.UIDs())...)
+			added = append(added, a.AddPhotosBy(photos.UIDs(), s.UserUID)...)
 		}
 
 		if len(added) > 0 {
@@ -359,9 +403,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 // POST /api/v1/albums/:uid/photos
 func AddPhotosToAlbum(router *gin.RouterGroup) {
 	router.POST("/albums/:uid/photos", func(c *gin.Context) {
-		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+		s, a, ownOnly := authAlbumEditor(c)
 
-		if s.Abort(c) {
+		if s == nil {
 			return
 		}
 
@@ -372,14 +416,8 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		uid := clean.UID(c.Param("uid"))
-		a, err := query.AlbumByUID(uid)
-
-		if err != nil {
-			AbortAlbumNotFound(c)
-			return
-		} else if !a.HasID() {
-			AbortAlbumNotFound(c)
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
 			return
 		} else if f.Empty() {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
@@ -395,7 +433,12 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		added := a.AddPhotos(photos.UIDs())
+		// Contributors may only add their own pictures.
+		if ownOnly {
+			photos = photos.OwnedBy(s.UserUID)
+		}
+
+		added := a.AddPhotosBy(photos.UIDs(), s.UserUID)
 
 		if len(added) > 0 {
 			if len(added) == 1 {
@@ -421,9 +464,9 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 // DELETE /api/v1/albums/:uid/photos
 func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 	router.DELETE("/albums/:uid/photos", func(c *gin.Context) {
-		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+		s, a, ownOnly := authAlbumEditor(c)
 
-		if s.Abort(c) {
+		if s == nil {
 			return
 		}
 
@@ -434,18 +477,18 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		if len(f.Photos) == 0 {
-			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
 			return
 		}
 
-		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+		// Contributors may only remove the pictures they have added.
+		if ownOnly {
+			f.Photos = list.Intersect(f.Photos, a.PhotosAddedBy(s.UserUID))
+		}
 
-		if err != nil {
-			AbortAlbumNotFound(c)
-			return
-		} else if !a.HasID() {
-			AbortAlbumNotFound(c)
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
 		}
 
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// UpdateAlbumCaption changes the album-specific caption of a picture, which is independent of the
+// photo description, so that the same picture can tell a different story in each album.
+//
+// PUT /api/v1/albums/:uid/photos/:photo/caption
+func UpdateAlbumCaption(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/photos/:photo/caption", func(c *gin.Context) {
+		s, a, ownOnly := authAlbumEditor(c)
+
+		if s == nil {
+			return
+		}
+
+		var f form.AlbumCaption
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		entry := entity.FindPhotoAlbum(clean.UID(c.Param("photo")), a.AlbumUID)
+
+		if entry == nil || entry.Hidden {
+			AbortEntityNotFound(c)
+			return
+		} else if ownOnly && entry.CreatedBy != s.UserUID {
+			// Contributors may only change the captions of the pictures they have added.
+			AbortForbidden(c)
+			return
+		} else if err := entry.SetCaption(f.Caption); err != nil {
+			log.Errorf("album: %s (update caption)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Update album YAML backup.
+		SaveAlbumAsYaml(a)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, entry)
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpdateAlbumCaption(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateAlbumCaption(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/photos/pt9jtdre2lvl0yh7/caption", `{"Caption": "Snow everywhere!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Snow everywhere!", gjson.Get(r.Body.String(), "Caption").String())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/photos/pt9jtdre2lvl0yh7/caption", `{"Caption": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("EntryNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateAlbumCaption(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/photos/pt9jtdre2lvl0xxx/caption", `{"Caption": "Test"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateAlbumCaption(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/photos/pt9jtdre2lvl0yh7/caption", `{"Caption": "Test"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateAlbumCaption(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/photos/pt9jtdre2lvl0yh7/caption", `{"Caption": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
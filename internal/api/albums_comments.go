@@ -0,0 +1,325 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/react"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// authAlbumComments checks if the current session may access the comments and reactions on the album specified
+// in the request path. Guests also need a share link with the required permission, which is returned.
+func authAlbumComments(c *gin.Context, perm uint) (s *entity.Session, a entity.Album, link *entity.Link) {
+	s = Auth(c, acl.ResourceAlbums, acl.ActionView)
+
+	if s.Abort(c) {
+		return nil, a, nil
+	}
+
+	a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+	if err != nil || !a.HasID() {
+		AbortAlbumNotFound(c)
+		return nil, a, nil
+	} else if search.AlbumLocked(a.AlbumUID, s) {
+		AbortForbidden(c)
+		return nil, a, nil
+	}
+
+	if s.IsRegistered() {
+		if acl.Resources.Allow(acl.ResourceAlbums, s.User().AclRole(), acl.AccessAll) || a.IsOwner(s.UserUID) || s.HasShare(a.AlbumUID) {
+			return s, a, nil
+		}
+	} else if perm == entity.PermComment {
+		link = s.CommentLink(a.AlbumUID)
+	} else {
+		link = s.ReactLink(a.AlbumUID)
+	}
+
+	if link == nil {
+		AbortForbidden(c)
+		return nil, a, nil
+	}
+
+	return s, a, link
+}
+
+// canModerate checks if the session may hide and delete the comments of others on the album,
+// which is the case for its owner and users who may manage all albums.
+func canModerate(s *entity.Session, a entity.Album) bool {
+	if s.NotRegistered() {
+		return false
+	}
+
+	return a.IsOwner(s.UserUID) || acl.Resources.Allow(acl.ResourceAlbums, s.User().AclRole(), acl.ActionManage)
+}
+
+// albumPhotoUID returns the UID of the photo specified in the request if it is part of the album,
+// or an empty string if it is not. The result is ok if no photo was specified.
+func albumPhotoUID(a entity.Album, photoUid string) (uid string, ok bool) {
+	if photoUid == "" {
+		return "", true
+	} else if uid = clean.UID(photoUid); uid == "" {
+		return "", false
+	} else if m := entity.FindPhotoAlbum(uid, a.AlbumUID); m == nil || m.Hidden {
+		return "", false
+	}
+
+	return uid, true
+}
+
+// GetAlbumComments returns the comments on an album and the pictures in it, with the oldest comments first.
+// Hidden comments are only returned to users who may moderate them.
+//
+// GET /api/v1/albums/:uid/comments
+//
+// Parameters:
+//
+//	photo: string Only returns the comments on the picture with this UID
+//	count: int Maximum number of results, default is 1000
+//	offset: int Result offset
+func GetAlbumComments(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/comments", func(c *gin.Context) {
+		s, a, _ := authAlbumComments(c, entity.PermReact)
+
+		if s == nil {
+			return
+		}
+
+		photoUid, ok := albumPhotoUID(a, c.Query("photo"))
+
+		if !ok {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 || limit > 1000 {
+			limit = 1000
+		}
+
+		results, err := query.AlbumComments(a.AlbumUID, photoUid, canModerate(s, a), limit, offset)
+
+		if err != nil {
+			log.Errorf("comments: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// AddAlbumComment adds a comment on an album or a picture in it, so that family and friends
+// can talk about the pictures shared with them.
+//
+// POST /api/v1/albums/:uid/comments
+func AddAlbumComment(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/comments", func(c *gin.Context) {
+		if get.Config().ReadOnly() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		s, a, link := authAlbumComments(c, entity.PermComment)
+
+		if s == nil {
+			return
+		}
+
+		var f form.Comment
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		photoUid, ok := albumPhotoUID(a, f.PhotoUID)
+
+		if !ok {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		m := entity.NewComment(a.AlbumUID, photoUid, f.Text).SetAuthor(s, link, f.Name)
+
+		if m.Empty() {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.Create(); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "album %s", "comment %s", "created"}, s.RefID, clean.Log(a.AlbumUID), clean.Log(m.CommentUID))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// ModerateAlbumComment lets the album owner hide or show a comment.
+//
+// PUT /api/v1/albums/:uid/comments/:comment
+func ModerateAlbumComment(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/comments/:comment", func(c *gin.Context) {
+		s, a, _ := authAlbumComments(c, entity.PermComment)
+
+		if s == nil {
+			return
+		} else if !canModerate(s, a) {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindComment(clean.UID(c.Param("comment")))
+
+		if m == nil || m.AlbumUID != a.AlbumUID {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var f form.CommentModerate
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.SetHidden(f.Hidden); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		if f.Hidden {
+			event.AuditInfo([]string{ClientIP(c), "session %s", "album %s", "comment %s", "hidden"}, s.RefID, clean.Log(a.AlbumUID), clean.Log(m.CommentUID))
+		} else {
+			event.AuditInfo([]string{ClientIP(c), "session %s", "album %s", "comment %s", "shown"}, s.RefID, clean.Log(a.AlbumUID), clean.Log(m.CommentUID))
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteAlbumComment removes a comment. Authors may delete their own comments,
+// and the album owner may delete any comment on the album.
+//
+// DELETE /api/v1/albums/:uid/comments/:comment
+func DeleteAlbumComment(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/comments/:comment", func(c *gin.Context) {
+		s, a, _ := authAlbumComments(c, entity.PermComment)
+
+		if s == nil {
+			return
+		}
+
+		m := entity.FindComment(clean.UID(c.Param("comment")))
+
+		if m == nil || m.AlbumUID != a.AlbumUID {
+			AbortEntityNotFound(c)
+			return
+		} else if !m.IsAuthor(s) && !canModerate(s, a) {
+			AbortForbidden(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "album %s", "comment %s", "deleted"}, s.RefID, clean.Log(a.AlbumUID), clean.Log(m.CommentUID))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetAlbumReactions returns the reactions to an album and the pictures in it.
+//
+// GET /api/v1/albums/:uid/reactions
+func GetAlbumReactions(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/reactions", func(c *gin.Context) {
+		s, a, _ := authAlbumComments(c, entity.PermReact)
+
+		if s == nil {
+			return
+		}
+
+		results, err := query.AlbumReactions(a.AlbumUID)
+
+		if err != nil {
+			log.Errorf("reactions: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// ReactToAlbum sets the reaction of the current user or guest to an album or a picture in it.
+// An empty or unknown reaction removes the previous reaction.
+//
+// POST /api/v1/albums/:uid/reactions
+func ReactToAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/reactions", func(c *gin.Context) {
+		if get.Config().ReadOnly() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		s, a, _ := authAlbumComments(c, entity.PermReact)
+
+		if s == nil {
+			return
+		}
+
+		var f form.Reaction
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		uid, ok := albumPhotoUID(a, f.PhotoUID)
+
+		if !ok {
+			AbortEntityNotFound(c)
+			return
+		} else if uid == "" {
+			uid = a.AlbumUID
+		}
+
+		emo := react.Find(f.Reaction)
+
+		if err := entity.SetReaction(uid, entity.AuthorRef(s), emo); err != nil {
+			log.Errorf("reactions: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"UID": uid, "Reaction": emo.String()})
+	})
+}
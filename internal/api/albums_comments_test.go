@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAddAlbumComment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumComment(router)
+		GetAlbumComments(router)
+		ModerateAlbumComment(router)
+		DeleteAlbumComment(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": "What a great trip!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		assert.Equal(t, "What a great trip!", gjson.Get(r.Body.String(), "Text").String())
+		assert.NotEmpty(t, uid)
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), uid)
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/comments/"+uid, `{"Hidden": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Hidden").Bool())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/comments/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/comments/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": "  "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("PhotoNotInAlbum", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"PhotoUID": "pt9jtdre2lvl0y11", "Text": "Hello"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/comments", `{"Text": "Hello"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestReactToAlbum(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ReactToAlbum(router)
+		GetAlbumReactions(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/reactions", `{"Reaction": "party"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "🎉", gjson.Get(r.Body.String(), "Reaction").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/reactions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "🎉")
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/reactions", `{"Reaction": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Reaction").String())
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ReactToAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/reactions", `{"Reaction": "love"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// authAlbumOwner checks if the current session may manage who has access to the album specified in the
+// request path. Only the album owner and users with access to all albums may invite others, so that
+// permission to share content does not allow managing the albums of other users.
+func authAlbumOwner(c *gin.Context, perm acl.Permission) (*entity.Session, entity.Album) {
+	s := AuthAny(c, acl.ResourceAlbums, acl.Permissions{acl.ActionShare, acl.AccessShared, acl.AccessOwn})
+
+	if s.Abort(c) {
+		return nil, entity.Album{}
+	}
+
+	a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+	role := s.User().AclRole()
+
+	if err != nil || !a.HasID() {
+		AbortAlbumNotFound(c)
+		return nil, a
+	} else if acl.Resources.Allow(acl.ResourceAlbums, role, acl.AccessAll) && acl.Resources.Allow(acl.ResourceShares, role, perm) {
+		return s, a
+	} else if s.NotRegistered() || !a.IsOwner(s.UserUID) {
+		AbortForbidden(c)
+		return nil, a
+	}
+
+	return s, a
+}
+
+// authAlbumEditor checks if the current session may add or remove pictures in the album specified in the
+// request path. Users without the required permission may change the albums they own, and contributors
+// may only change the entries they have added themselves, in which case ownOnly is true.
+func authAlbumEditor(c *gin.Context) (s *entity.Session, a entity.Album, ownOnly bool) {
+	s = AuthAny(c, acl.ResourceAlbums, acl.Permissions{acl.ActionUpdate, acl.AccessShared, acl.AccessOwn})
+
+	if s.Abort(c) {
+		return nil, a, false
+	}
+
+	a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+	if err != nil || !a.HasID() {
+		AbortAlbumNotFound(c)
+		return nil, a, false
+	} else if acl.Resources.Allow(acl.ResourceAlbums, s.User().AclRole(), acl.ActionUpdate) {
+		return s, a, false
+	} else if s.NotRegistered() {
+		AbortForbidden(c)
+		return nil, a, false
+	} else if a.IsOwner(s.UserUID) {
+		return s, a, false
+	} else if len(s.User().ContributeUIDs([]string{a.AlbumUID})) == 0 {
+		AbortForbidden(c)
+		return nil, a, false
+	}
+
+	return s, a, true
+}
+
+// GetAlbumContributors returns the users who added pictures to an album and how many.
+//
+// GET /api/v1/albums/:uid/contributors
+func GetAlbumContributors(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/contributors", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		results, err := query.AlbumContributors(a.AlbumUID)
+
+		if err != nil {
+			log.Errorf("album: %s (find contributors)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
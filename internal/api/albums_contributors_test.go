@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAlbumContributors(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumContributors(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba9/contributors")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumContributors(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/contributors")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SetAlbumCover sets a picture as album cover, optionally cropped to the specified area.
+//
+// PUT /api/v1/albums/:uid/cover
+func SetAlbumCover(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/cover", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.AlbumCover
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		photoUid := clean.UID(f.Photo)
+
+		// Manual albums may only use their own pictures as cover.
+		if a.IsDefault() {
+			if entry := entity.FindPhotoAlbum(photoUid, a.AlbumUID); entry == nil || entry.Hidden {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		file, err := entity.PrimaryFile(photoUid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		if err = a.SetCover(file, crop.NewArea("cover", f.X, f.Y, f.W, f.H)); err != nil {
+			log.Errorf("album: %s (set cover)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		RemoveFromAlbumCoverCache(a.AlbumUID)
+
+		// Update album YAML backup.
+		SaveAlbumAsYaml(a)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
+// ResetAlbumCover removes a manually set album cover so that it is selected automatically again.
+//
+// DELETE /api/v1/albums/:uid/cover
+func ResetAlbumCover(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/cover", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		if err = a.ResetCover(); err != nil {
+			log.Errorf("album: %s (reset cover)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Select a new cover automatically.
+		RemoveFromAlbumCoverCache(a.AlbumUID)
+		entity.FlushAlbumCache()
+
+		if found, err := query.AlbumByUID(a.AlbumUID); err == nil {
+			a = found
+		}
+
+		// Update album YAML backup.
+		SaveAlbumAsYaml(a)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
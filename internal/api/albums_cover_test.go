@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSetAlbumCover(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetAlbumCover(router)
+		ResetAlbumCover(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{"Photo": "pt9jtdre2lvl0yh7", "X": 0.1, "Y": 0.1, "W": 0.5, "H": 0.5}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.SrcManual, gjson.Get(r.Body.String(), "ThumbSrc").String())
+		assert.Contains(t, gjson.Get(r.Body.String(), "Thumb").String(), "-")
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/cover")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "ThumbSrc").String())
+	})
+	t.Run("NotInAlbum", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetAlbumCover(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{"Photo": "pt9jtdre2lvl0xxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetAlbumCover(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/cover", `{"Photo": "pt9jtdre2lvl0yh7"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetAlbumCover(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{"Photo": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestResetAlbumCover(t *testing.T) {
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResetAlbumCover(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/albums/xxx/cover")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// shareGrant returns the JSON representation of a share grant.
+func shareGrant(m *entity.UserShare) gin.H {
+	result := gin.H{
+		"ShareUID":  m.ShareUID,
+		"Grant":     m.GrantName(),
+		"ExpiresAt": m.ExpiresAt,
+		"CreatedAt": m.CreatedAt,
+	}
+
+	if rnd.IsUID(m.UserUID, entity.GroupUID) {
+		result["GroupUID"] = m.UserUID
+	} else {
+		result["UserUID"] = m.UserUID
+	}
+
+	return result
+}
+
+// GetAlbumGrants returns the users and groups with direct access to an album or folder.
+//
+// GET /api/v1/albums/:uid/grants
+func GetAlbumGrants(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/grants", func(c *gin.Context) {
+		s, a := authAlbumOwner(c, acl.ActionView)
+
+		if s == nil {
+			return
+		}
+
+		shares := entity.FindShareGrants(a.AlbumUID)
+		result := make([]gin.H, len(shares))
+
+		for i := range shares {
+			result[i] = shareGrant(&shares[i])
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GrantAlbumAccess grants a user or group direct view or contribute access to an album or folder.
+//
+// POST /api/v1/albums/:uid/grants
+func GrantAlbumAccess(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/grants", func(c *gin.Context) {
+		s, a := authAlbumOwner(c, acl.ActionCreate)
+
+		if s == nil {
+			return
+		}
+
+		var f form.ShareGrant
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		perm, err := entity.GrantPerm(clean.TypeLower(f.Grant))
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		// Find the user or group to grant access to.
+		var uid string
+
+		if f.GroupUID != "" {
+			if g := entity.FindGroupByUID(clean.UID(f.GroupUID)); g == nil {
+				AbortEntityNotFound(c)
+				return
+			} else {
+				uid = g.GroupUID
+			}
+		} else if u := entity.FindUserByUID(clean.UID(f.UserUID)); u == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		} else {
+			uid = u.UserUID
+		}
+
+		share, err := entity.GrantShare(uid, a.AlbumUID, perm, f.ExpiresAt)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "granted %s access to album %s", "%s"}, s.RefID, share.GrantName(), a.AlbumUID, uid)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, shareGrant(share))
+	})
+}
+
+// RevokeAlbumAccess removes the direct access of a user or group to an album or folder.
+//
+// DELETE /api/v1/albums/:uid/grants/:user
+func RevokeAlbumAccess(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/grants/:user", func(c *gin.Context) {
+		s, a := authAlbumOwner(c, acl.ActionDelete)
+
+		if s == nil {
+			return
+		}
+
+		userUid := clean.UID(c.Param("user"))
+
+		if err := entity.RevokeShare(userUid, a.AlbumUID); err != nil {
+			log.Errorf("share: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "revoked access to album %s", "%s"}, s.RefID, a.AlbumUID, clean.Log(userUid))
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestAlbumGrants(t *testing.T) {
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumGrants(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/grants")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidGrant", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GrantAlbumAccess(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/grants", `{"UserUID": "uqxc08w3d0ej2283", "Grant": "own"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("UserNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GrantAlbumAccess(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/grants", `{"UserUID": "uqxc08w3d0ej9999", "Grant": "view"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("GrantAndRevoke", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GrantAlbumAccess(router)
+		GetAlbumGrants(router)
+		RevokeAlbumAccess(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/grants", `{"UserUID": "uqxc08w3d0ej2283", "Grant": "contribute"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "ShareUID").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/grants")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "at9lxuqxpogaaba8")
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/grants/uqxc08w3d0ej2283")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NotOwner", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		GrantAlbumAccess(router)
+		GetAlbumGrants(router)
+		RevokeAlbumAccess(router)
+
+		sessId, cleanup := AuthenticateRoleUser(t, app, router, "sharer", "view share")
+		defer cleanup()
+
+		// Permission to share content does not allow managing access to albums of other users.
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/grants", `{"UserUID": "uqxc08w3d0ej2283", "Grant": "view"}`, sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/grants", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/grants/uqxc08w3d0ej2283", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// lockedAlbum returns the album specified in the request path and the submitted PIN, or aborts the request.
+func lockedAlbum(c *gin.Context) (a entity.Album, f form.AlbumLock, ok bool) {
+	var err error
+
+	if a, err = query.AlbumByUID(clean.UID(c.Param("uid"))); err != nil {
+		AbortAlbumNotFound(c)
+		return a, f, false
+	} else if err = c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return a, f, false
+	}
+
+	return a, f, true
+}
+
+// LockAlbum protects an album with a PIN or password so that its contents are hidden until it is unlocked.
+//
+// POST /api/v1/albums/:uid/lock
+func LockAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/lock", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, f, ok := lockedAlbum(c)
+
+		if !ok {
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		if err := a.Lock(f.PIN); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		// Unlock the album for the current session so that it remains accessible.
+		if err := s.UnlockAlbum(a.AlbumUID); err != nil {
+			log.Warnf("album: %s (unlock)", err)
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "locked album %s"}, s.RefID, clean.Log(a.AlbumUID))
+
+		SaveAlbumAsYaml(a)
+		UpdateClientConfig()
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
+// RemoveAlbumLock permanently removes the lock from an album.
+//
+// DELETE /api/v1/albums/:uid/lock
+func RemoveAlbumLock(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/lock", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Limit the number of failed attempts to guess the PIN.
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		a, f, ok := lockedAlbum(c)
+
+		if !ok {
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		if a.InvalidPIN(f.PIN) {
+			limiter.Login.Reserve(ClientIP(c))
+			event.AuditWarn([]string{ClientIP(c), "session %s", "remove lock from album %s", "invalid pin"}, s.RefID, clean.Log(a.AlbumUID))
+			Abort(c, http.StatusUnauthorized, i18n.ErrInvalidPassword)
+			return
+		} else if err := a.RemoveLock(f.PIN); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "removed lock from album %s"}, s.RefID, clean.Log(a.AlbumUID))
+
+		SaveAlbumAsYaml(a)
+		UpdateClientConfig()
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
+// UnlockAlbum shows the contents of a locked album for the remaining duration of the current session.
+//
+// POST /api/v1/albums/:uid/unlock
+func UnlockAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/unlock", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Limit the number of failed attempts to guess the PIN.
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		a, f, ok := lockedAlbum(c)
+
+		if !ok {
+			return
+		} else if !a.Locked() {
+			c.JSON(http.StatusOK, a)
+			return
+		}
+
+		if a.InvalidPIN(f.PIN) {
+			limiter.Login.Reserve(ClientIP(c))
+			event.AuditWarn([]string{ClientIP(c), "session %s", "unlock album %s", "invalid pin"}, s.RefID, clean.Log(a.AlbumUID))
+			Abort(c, http.StatusUnauthorized, i18n.ErrInvalidPassword)
+			return
+		} else if err := s.UnlockAlbum(a.AlbumUID); err != nil {
+			log.Errorf("album: %s (unlock)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "unlocked album %s"}, s.RefID, clean.Log(a.AlbumUID))
+
+		c.JSON(http.StatusOK, a)
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestLockAlbum(t *testing.T) {
+	t.Run("LockAndUnlock", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		LockAlbum(router)
+		UnlockAlbum(router)
+		RemoveAlbumLock(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at6axuzitogaaiax/lock", `{"PIN": "1234"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Locked").Bool())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/at6axuzitogaaiax/unlock", `{"PIN": "0000"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/at6axuzitogaaiax/unlock", `{"PIN": "1234"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, "DELETE", "/api/v1/albums/at6axuzitogaaiax/lock", `{"PIN": "1234"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "Locked").Bool())
+	})
+	t.Run("ShortPIN", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		LockAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at6axuzitogaaiax/lock", `{"PIN": "1"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		LockAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/lock", `{"PIN": "1234"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUnlockAlbum(t *testing.T) {
+	t.Run("NotLocked", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UnlockAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/unlock", `{"PIN": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UnlockAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/unlock", `{"PIN": 1234`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
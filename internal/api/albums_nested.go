@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// albumResponse represents an album with the breadcrumb path of its parent albums.
+type albumResponse struct {
+	entity.Album
+	Breadcrumbs entity.AlbumCrumbs `json:"Breadcrumbs"`
+}
+
+// MoveAlbum moves an album into another album, or to the top level if no parent UID is specified.
+//
+// PUT /api/v1/albums/:uid/parent
+func MoveAlbum(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/parent", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		var f form.AlbumParent
+
+		if err = c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		if err = a.SetParent(clean.UID(f.ParentUID)); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		UpdateClientConfig()
+		SaveAlbumAsYaml(a)
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, albumResponse{Album: a, Breadcrumbs: a.Breadcrumbs()})
+	})
+}
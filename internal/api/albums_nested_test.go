@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMoveAlbum(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MoveAlbum(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/parent", `{"ParentUID": "at9lxuqxpogaaba8"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "ParentUID").String())
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "Breadcrumbs.0.UID").String())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/parent", `{"ParentUID": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "ParentUID").String())
+	})
+	t.Run("OwnParent", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MoveAlbum(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/parent", `{"ParentUID": "at9lxuqxpogaaba9"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MoveAlbum(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/parent", `{"ParentUID": ""}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
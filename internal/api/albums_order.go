@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SortAlbumPhotos persists a custom order of the pictures in an album, e.g. after drag and drop.
+// The specified pictures are moved to the top, and the album sort order is set to "custom".
+//
+// PUT /api/v1/albums/:uid/order
+func SortAlbumPhotos(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/order", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		var f form.AlbumOrder
+
+		if err = c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		changed, err := a.SetPhotoOrder(f.Photos)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		log.Debugf("album: changed the position of %d pictures in %s", len(changed), a.AlbumUID)
+
+		SaveAlbumAsYaml(a)
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "album": a, "changed": len(changed)})
+	})
+}
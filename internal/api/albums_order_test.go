@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestSortAlbumPhotos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SortAlbumPhotos(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/order", `{"photos": ["pt9jtdre2lvl0yh8", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "custom", gjson.Get(r.Body.String(), "album.Order").String())
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SortAlbumPhotos(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/order", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SortAlbumPhotos(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/order", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SortAlbumPhotos(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/order", `{"photos": ["pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
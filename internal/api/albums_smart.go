@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// validAlbumFilter checks if the filter of a smart album can be parsed as photo search form.
+func validAlbumFilter(filter string) error {
+	if filter == "" {
+		return nil
+	}
+
+	return form.Unserialize(&form.SearchPhotos{}, filter)
+}
+
+// UpdateSmartAlbums updates the smart album entries of the specified pictures after they have been changed.
+func UpdateSmartAlbums(photoUIDs ...string) {
+	if len(photoUIDs) == 0 {
+		return
+	} else if err := query.UpdateSmartAlbums(photoUIDs...); err != nil {
+		log.Warnf("albums: %s (update smart albums)", err)
+	}
+}
+
+// UpdateSmartAlbum adds all pictures matching the album filter and removes those that no longer match.
+func UpdateSmartAlbum(a entity.Album) {
+	if !a.IsSmart() {
+		return
+	} else if added, removed, err := query.UpdateSmartAlbum(a, nil); err != nil {
+		log.Warnf("albums: %s (update smart album %s)", err, a.AlbumUID)
+	} else if added > 0 || removed > 0 {
+		log.Infof("albums: added %d and removed %d pictures in smart album %s", added, removed, a.AlbumUID)
+	}
+}
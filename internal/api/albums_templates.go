@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetAlbumTemplates returns the built-in album templates.
+//
+// GET /api/v1/albums/templates
+func GetAlbumTemplates(router *gin.RouterGroup) {
+	router.GET("/albums/templates", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.DefaultAlbumTemplates)
+	})
+}
+
+// CreateTemplateAlbum creates an album from a built-in template, or from a custom title pattern,
+// filter, and sort order. Patterns are expanded with the specified date, or the current date.
+//
+// POST /api/v1/albums/templates
+func CreateTemplateAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/templates", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.AlbumTemplate
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Use a built-in template unless a custom title pattern is specified.
+		t := entity.AlbumTemplate{Name: clean.TypeLower(f.Name), Title: f.Title, Filter: f.Filter, Order: clean.TypeLower(f.Order)}
+
+		if t.Title == "" {
+			if found, err := entity.FindAlbumTemplate(t.Name); err != nil {
+				Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+				return
+			} else {
+				t = found
+			}
+		} else if t.Name == "" {
+			t.Name = "custom"
+		}
+
+		date := time.Now()
+
+		if f.Date != "" {
+			if d, err := time.Parse("2006-01-02", f.Date); err != nil {
+				Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+				return
+			} else {
+				date = d
+			}
+		}
+
+		if err := validAlbumFilter(t.Expand(t.Filter, date)); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		a, changed, err := t.CreateAlbum(date, s.UserUID)
+
+		if err != nil {
+			log.Errorf("album: %s (create from template)", err)
+			AbortSaveFailed(c)
+			return
+		} else if changed {
+			// Add the pictures matching the filter.
+			UpdateSmartAlbum(*a)
+
+			UpdateClientConfig()
+
+			// Update album YAML backup.
+			SaveAlbumAsYaml(*a)
+		}
+
+		c.JSON(http.StatusOK, a)
+	})
+}
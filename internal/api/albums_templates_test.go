@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumTemplates(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetAlbumTemplates(router)
+	r := PerformRequest(app, "GET", "/api/v1/albums/templates")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "monthly", gjson.Get(r.Body.String(), "0.Name").String())
+}
+
+func TestCreateTemplateAlbum(t *testing.T) {
+	t.Run("Monthly", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTemplateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/templates", `{"Name": "monthly", "Date": "2016-11-01"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "November 2016", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "public:true year:2016 month:11", gjson.Get(r.Body.String(), "Filter").String())
+	})
+	t.Run("Custom", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTemplateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/templates", `{"Title": "Dogs {year}", "Filter": "label:dog year:{year}", "Order": "newest", "Date": "2020-06-01"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Dogs 2020", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "newest", gjson.Get(r.Body.String(), "Order").String())
+	})
+	t.Run("UnknownTemplate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTemplateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/templates", `{"Name": "weekly"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidDate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTemplateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/templates", `{"Name": "monthly", "Date": "tomorrow"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -5,12 +5,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/get"
 )
 
 // UpdateClientConfig publishes updated client configuration values over the websocket connections.
 func UpdateClientConfig() {
+	// Counts may have changed.
+	config.FlushClientCounts()
+
 	event.Publish("config.updated", event.Data{"config": get.Config().ClientUser(false)})
 }
 
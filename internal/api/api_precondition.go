@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// EntityTag returns the entity tag for the specified update time. Timestamps are compared with
+// second precision, since this is what the database stores.
+func EntityTag(updatedAt time.Time) string {
+	return fmt.Sprintf(`"%d"`, updatedAt.UTC().Unix())
+}
+
+// AddEntityTagHeader adds an ETag header, so that clients can send it back in an If-Match header
+// to make sure they don't overwrite changes made by someone else in the meantime.
+func AddEntityTagHeader(c *gin.Context, updatedAt time.Time) {
+	c.Header("ETag", EntityTag(updatedAt))
+}
+
+// PreconditionFailed checks the If-Match and If-Unmodified-Since request headers and returns true
+// if the entity has been changed since the client has fetched it. Requests without these headers
+// are not checked.
+func PreconditionFailed(c *gin.Context, updatedAt time.Time) bool {
+	if match := strings.TrimSpace(c.GetHeader("If-Match")); match != "" && match != "*" {
+		current := EntityTag(updatedAt)
+
+		for _, tag := range strings.Split(match, ",") {
+			if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == current {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if since := c.GetHeader("If-Unmodified-Since"); since == "" {
+		return false
+	} else if t, err := http.ParseTime(since); err != nil {
+		return false
+	} else {
+		return updatedAt.UTC().Truncate(time.Second).After(t.UTC())
+	}
+}
+
+// conflictResponse represents a conflict error with the current server state.
+type conflictResponse struct {
+	i18n.Response
+	Data interface{} `json:"data"`
+}
+
+// AbortConflict aborts with status code 409 and returns the current server state,
+// so that clients can show the changes and let the user resolve the conflict.
+func AbortConflict(c *gin.Context, updatedAt time.Time, current interface{}) {
+	resp := i18n.NewResponse(http.StatusConflict, i18n.ErrConflict)
+
+	log.Debugf("api-v1: abort %s with code %d (%s)", clean.Log(c.FullPath()), http.StatusConflict, resp.LowerString())
+
+	AddEntityTagHeader(c, updatedAt)
+
+	c.AbortWithStatusJSON(http.StatusConflict, conflictResponse{Response: resp, Data: current})
+}
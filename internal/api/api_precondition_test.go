@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestEntityTag(t *testing.T) {
+	updatedAt := time.Date(2023, 5, 1, 12, 30, 15, 500, time.UTC)
+	assert.Equal(t, `"1682944215"`, EntityTag(updatedAt))
+}
+
+func TestPreconditionFailed(t *testing.T) {
+	updatedAt := time.Date(2023, 5, 1, 12, 30, 15, 500, time.UTC)
+
+	check := func(key, value string) bool {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("PUT", "/api/v1/photos/pt9jtdre2lvl0y12", nil)
+
+		if key != "" {
+			c.Request.Header.Set(key, value)
+		}
+
+		return PreconditionFailed(c, updatedAt)
+	}
+
+	assert.False(t, check("", ""))
+	assert.False(t, check("If-Match", `"1682944215"`))
+	assert.False(t, check("If-Match", `W/"1682944215"`))
+	assert.False(t, check("If-Match", `"1", "1682944215"`))
+	assert.False(t, check("If-Match", "*"))
+	assert.True(t, check("If-Match", `"1682944214"`))
+	assert.False(t, check("If-Unmodified-Since", "Mon, 01 May 2023 12:30:15 GMT"))
+	assert.True(t, check("If-Unmodified-Since", "Mon, 01 May 2023 12:30:14 GMT"))
+	assert.False(t, check("If-Unmodified-Since", "invalid"))
+}
+
+func TestUpdatePhotoConflict(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetPhoto(router)
+	UpdatePhoto(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13")
+	assert.Equal(t, http.StatusOK, r.Code)
+	etag := r.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	put := func(match, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PUT", "/api/v1/photos/pt9jtdre2lvl0y13", strings.NewReader(body))
+		req.Header.Set("If-Match", match)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	r = put(`"1"`, `{"Title": "Conflict"}`)
+	assert.Equal(t, http.StatusConflict, r.Code)
+	assert.Equal(t, etag, r.Header().Get("ETag"))
+	assert.Equal(t, "pt9jtdre2lvl0y13", gjson.Get(r.Body.String(), "data.UID").String())
+
+	r = put(etag, `{"Title": "No Conflict"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "No Conflict", gjson.Get(r.Body.String(), "Title").String())
+	assert.NotEmpty(t, r.Header().Get("ETag"))
+}
+
+func TestUpdateAlbumConflict(t *testing.T) {
+	app, router, _ := NewApiTest()
+	UpdateAlbum(router)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/albums/at9lxuqxpogaaba7", strings.NewReader(`{"Title": "Conflict"}`))
+	req.Header.Set("If-Unmodified-Since", "Mon, 01 Jan 2001 00:00:00 GMT")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "at9lxuqxpogaaba7", gjson.Get(w.Body.String(), "data.UID").String())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/server/limiter"
 )
 
 func TestMain(m *testing.M) {
@@ -23,6 +24,9 @@ func TestMain(m *testing.M) {
 	c := config.TestConfig()
 	get.SetConfig(c)
 
+	// Increase the login rate limit, as many tests intentionally fail to authenticate.
+	limiter.Login = limiter.NewLimit(1, 10000)
+
 	code := m.Run()
 
 	_ = c.CloseDb()
@@ -36,6 +36,14 @@ var wsAuth = struct {
 	user: make(map[string]entity.User),
 }
 
+// wsTopics maps connection IDs to explicit topic subscriptions, if any.
+var wsTopics = struct {
+	subs  map[string]event.Subscriptions
+	mutex sync.RWMutex
+}{
+	subs: make(map[string]event.Subscriptions),
+}
+
 // wsConnection upgrades the HTTP server connection to the WebSocket protocol.
 var wsConnection = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
@@ -45,10 +53,18 @@ var wsConnection = websocket.Upgrader{
 
 // clientInfo represents information provided by the WebSocket client.
 type clientInfo struct {
-	SessionID string `json:"session"`
-	CssUri    string `json:"css"`
-	JsUri     string `json:"js"`
-	Version   string `json:"version"`
+	SessionID   string        `json:"session"`
+	CssUri      string        `json:"css"`
+	JsUri       string        `json:"js"`
+	Version     string        `json:"version"`
+	Subscribe   []clientTopic `json:"subscribe,omitempty"`
+	Unsubscribe []string      `json:"unsubscribe,omitempty"`
+}
+
+// clientTopic represents a topic subscription with an optional filter expression, e.g. "UID:as6sg6bxpogaaba7".
+type clientTopic struct {
+	Topic  string `json:"topic"`
+	Filter string `json:"filter,omitempty"`
 }
 
 // WebSocket registers the /ws endpoint for establishing websocket connections.
@@ -122,7 +138,13 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 		if err := json.Unmarshal(m, &info); err != nil {
 			// Do nothing.
 		} else {
-			if s := Session(info.SessionID); s != nil {
+			if len(info.Subscribe) > 0 || len(info.Unsubscribe) > 0 {
+				wsUpdateTopics(connId, info.Subscribe, info.Unsubscribe)
+			}
+
+			if info.SessionID == "" {
+				// Topic subscription messages do not include a session id.
+			} else if s := Session(info.SessionID); s != nil {
 				wsAuth.mutex.Lock()
 				wsAuth.sid[connId] = s.ID
 				wsAuth.rid[connId] = s.RefID
@@ -175,6 +197,10 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		delete(wsAuth.rid, connId)
 		delete(wsAuth.user, connId)
 		wsAuth.mutex.Unlock()
+
+		wsTopics.mutex.Lock()
+		delete(wsTopics.subs, connId)
+		wsTopics.mutex.Unlock()
 	}()
 
 	for {
@@ -208,6 +234,11 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 			ev := msg.Topic()
 			ch := strings.Split(ev, ".")
 
+			// Skip messages that do not match the client's topic subscriptions, if any.
+			if !wsMatchTopics(connId, wsTopic(ch), msg.Fields) {
+				continue
+			}
+
 			// Send the message only to authorized recipients.
 			switch len(ch) {
 			case 2:
@@ -229,6 +260,50 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 	}
 }
 
+// wsTopic returns the topic name without the user or session channel prefix.
+func wsTopic(ch []string) string {
+	if len(ch) == 4 {
+		return strings.Join(ch[2:4], ".")
+	}
+
+	return strings.Join(ch, ".")
+}
+
+// wsUpdateTopics updates the topic subscriptions of a WebSocket connection.
+func wsUpdateTopics(connId string, subscribe []clientTopic, unsubscribe []string) {
+	wsTopics.mutex.Lock()
+	defer wsTopics.mutex.Unlock()
+
+	subs, ok := wsTopics.subs[connId]
+
+	if !ok {
+		subs = event.NewSubscriptions()
+	}
+
+	for _, t := range subscribe {
+		subs.Subscribe(t.Topic, t.Filter)
+	}
+
+	for _, topic := range unsubscribe {
+		subs.Unsubscribe(topic)
+	}
+
+	// Receive all events again if there are no remaining subscriptions.
+	if subs.Empty() {
+		delete(wsTopics.subs, connId)
+	} else {
+		wsTopics.subs[connId] = subs
+	}
+}
+
+// wsMatchTopics checks if a message should be sent based on the connection's topic subscriptions.
+func wsMatchTopics(connId, topic string, data event.Data) bool {
+	wsTopics.mutex.RLock()
+	defer wsTopics.mutex.RUnlock()
+
+	return wsTopics.subs[connId].Match(topic, data)
+}
+
 // wsSendMessage sends a message to the WebSocket client.
 func wsSendMessage(topic string, data interface{}, ws *websocket.Conn, writeMutex *sync.Mutex) {
 	if topic == "" || ws == nil || writeMutex == nil {
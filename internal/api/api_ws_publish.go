@@ -19,6 +19,11 @@ const (
 
 // PublishPhotoEvent publishes updated photo data after changes have been made.
 func PublishPhotoEvent(ev EntityEvent, uid string, c *gin.Context) {
+	// Changed pictures may no longer match the filter of smart albums, or match it now.
+	if ev == EntityUpdated {
+		UpdateSmartAlbums(uid)
+	}
+
 	if result, _, err := search.Photos(form.SearchPhotos{UID: uid, Merged: true}); err != nil {
 		event.AuditErr([]string{ClientIP(c), "session %s", "%s photo %s", "%s"}, SessionID(c), string(ev), uid, err)
 	} else {
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/event"
 )
 
 func TestWebsocket(t *testing.T) {
@@ -22,3 +24,19 @@ func TestWebsocket(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestWsTopics(t *testing.T) {
+	connId := "7f8c3e7a-2b1d-4c8f-9a61-2a7e0d35a1b2"
+
+	assert.True(t, wsMatchTopics(connId, "photos.updated", event.Data{}))
+
+	wsUpdateTopics(connId, []clientTopic{{Topic: "albums.updated", Filter: "UID:as6sg6bxpogaaba7"}}, nil)
+
+	assert.False(t, wsMatchTopics(connId, "photos.updated", event.Data{}))
+	assert.True(t, wsMatchTopics(connId, "albums.updated", event.Data{"entities": []event.Data{{"UID": "as6sg6bxpogaaba7"}}}))
+	assert.False(t, wsMatchTopics(connId, "albums.updated", event.Data{"entities": []event.Data{{"UID": "as6sg6bxpogaaba8"}}}))
+
+	wsUpdateTopics(connId, nil, []string{"albums.updated"})
+
+	assert.True(t, wsMatchTopics(connId, "photos.updated", event.Data{}))
+}
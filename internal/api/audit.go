@@ -0,0 +1,187 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	auditDefaultCount = 100
+	auditFormatJSONL  = "jsonl"
+	auditFormatCSV    = "csv"
+)
+
+// auditSearchForm binds the audit log search form from the request query.
+func auditSearchForm(c *gin.Context) (f form.SearchAudit, err error) {
+	err = c.MustBindWith(&f, binding.Form)
+	return f, err
+}
+
+// SearchAudit searches the audit log and returns the results as JSON.
+//
+// GET /api/v1/audit
+//
+// Query:
+//
+//	q:      message search string (optional)
+//	level:  log level, e.g. "info", "warning", or "error" (optional)
+//	ip:     client IP address (optional)
+//	user:   user name or UID (optional)
+//	since:  start date, e.g. "2023-01-31" (optional)
+//	until:  end date, inclusive (optional)
+//	count:  maximum number of results (optional)
+//	offset: result offset (optional)
+func SearchAudit(router *gin.RouterGroup) {
+	router.GET("/audit", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s := Auth(c, acl.ResourceLogs, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		f, err := auditSearchForm(c)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Count <= 0 {
+			f.Count = auditDefaultCount
+		}
+
+		// Find and return matching events.
+		if resp, err := query.AuditEvents(f); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		} else {
+			AddCountHeader(c, len(resp))
+			AddLimitHeader(c, f.Count)
+			AddOffsetHeader(c, f.Offset)
+
+			c.JSON(http.StatusOK, resp)
+		}
+	})
+}
+
+// ExportAudit exports the audit log as JSON Lines or CSV file for compliance purposes.
+//
+// GET /api/v1/audit/export
+//
+// Query:
+//
+//	format: "jsonl" (default) or "csv"
+//
+// All other parameters are the same as for searching the audit log.
+func ExportAudit(router *gin.RouterGroup) {
+	router.GET("/audit/export", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s := Auth(c, acl.ResourceLogs, acl.ActionDownload)
+
+		if s.Abort(c) {
+			return
+		}
+
+		f, err := auditSearchForm(c)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+
+		if format == "" {
+			format = auditFormatJSONL
+		} else if format != auditFormatJSONL && format != auditFormatCSV {
+			AbortBadRequest(c)
+			return
+		}
+
+		results, err := query.AuditEvents(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "exported %d audit events as %s"}, s.RefID, len(results), format)
+
+		AddDownloadHeader(c, fmt.Sprintf("audit-%s.%s", time.Now().UTC().Format("20060102-150405"), format))
+
+		if format == auditFormatCSV {
+			AddContentTypeHeader(c, "text/csv; charset=utf-8")
+			c.Status(http.StatusOK)
+
+			if err = writeAuditCSV(c, results); err != nil {
+				log.Errorf("audit: %s (export)", err)
+			}
+		} else {
+			AddContentTypeHeader(c, "application/x-ndjson")
+			c.Status(http.StatusOK)
+
+			if err = writeAuditJSONL(c, results); err != nil {
+				log.Errorf("audit: %s (export)", err)
+			}
+		}
+	})
+}
+
+// writeAuditJSONL writes the audit events as JSON Lines, one event per line.
+func writeAuditJSONL(c *gin.Context, results entity.AuditEvents) error {
+	enc := json.NewEncoder(c.Writer)
+
+	for i := range results {
+		if err := enc.Encode(results[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeAuditCSV writes the audit events as comma-separated values with a header row.
+func writeAuditCSV(c *gin.Context, results entity.AuditEvents) error {
+	w := csv.NewWriter(c.Writer)
+
+	if err := w.Write([]string{"ID", "Time", "Level", "ClientIP", "SessionID", "UserUID", "UserName", "Message"}); err != nil {
+		return err
+	}
+
+	for _, m := range results {
+		row := []string{
+			strconv.FormatUint(uint64(m.ID), 10),
+			m.EventTime.UTC().Format(time.RFC3339),
+			m.EventLevel,
+			m.ClientIP,
+			m.SessionRef,
+			m.UserUID,
+			m.UserName,
+			m.Message,
+		}
+
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
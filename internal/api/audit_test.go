@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAudit(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchAudit(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit?count=10&level=warning")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("InvalidDate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchAudit(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit?since=yesterday")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestExportAudit(t *testing.T) {
+	t.Run("JSONL", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportAudit(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit/export")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/x-ndjson", r.Header().Get("Content-Type"))
+		assert.True(t, strings.HasSuffix(r.Header().Get("Content-Disposition"), ".jsonl"))
+	})
+	t.Run("CSV", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportAudit(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit/export?format=csv&since=2020-01-01")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, strings.HasPrefix(r.Body.String(), "ID,Time,Level,ClientIP,SessionID,UserUID,UserName,Message"))
+	})
+	t.Run("InvalidFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportAudit(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit/export?format=xml")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
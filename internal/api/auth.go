@@ -13,6 +13,7 @@ func Auth(c *gin.Context, resource acl.Resource, grant acl.Permission) *entity.S
 }
 
 // AuthAny checks if at least one permission allows access and returns the session in this case.
+// Access tokens must be granted one of the requested write permissions, if any.
 func AuthAny(c *gin.Context, resource acl.Resource, grants acl.Permissions) (s *entity.Session) {
 	// Get client IP address and session ID, if any.
 	ip := ClientIP(c)
@@ -33,8 +34,11 @@ func AuthAny(c *gin.Context, resource acl.Resource, grants acl.Permissions) (s *
 	} else if acl.Resources.DenyAll(resource, s.User().AclRole(), grants) {
 		event.AuditErr([]string{ip, "session %s", "%s %s as %s", "denied"}, s.RefID, grants.String(), string(resource), s.User().AclRole().String())
 		return entity.SessionStatusForbidden()
+	} else if scope := s.Scope(); scope.Deny(resource, grants) {
+		event.AuditErr([]string{ip, "session %s", "%s %s with scope %s", "denied"}, s.RefID, grants.String(), string(resource), scope.String())
+		return entity.SessionStatusForbidden()
 	} else {
-		event.AuditInfo([]string{ip, "session %s", "%s %s as %s", "granted"}, s.RefID, grants.String(), string(resource), s.User().AclRole().String())
+		event.AuditDebug([]string{ip, "session %s", "%s %s as %s", "granted"}, s.RefID, grants.String(), string(resource), s.User().AclRole().String())
 		return s
 	}
 }
@@ -3,10 +3,13 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/query"
@@ -64,7 +67,18 @@ func Shares(router *gin.RouterGroup) {
 		}
 
 		uri := conf.BaseUri(path.Join("/library/albums", uid, shared))
+		values := gin.H{"shared": gin.H{"token": token, "uri": uri}, "config": clientConfig}
+
+		// Add oEmbed and feed discovery links if the album can be embedded.
+		if link, _, ok := embedLink(token, shared); ok {
+			shareUrl := link.Url(conf.SiteUrl())
+			values["embed"] = gin.H{
+				"oembed": conf.SiteUrl() + strings.TrimPrefix(config.ApiUri, "/") + "/oembed?url=" + url.QueryEscape(shareUrl),
+				"json":   shareUrl + "/feed.json",
+				"atom":   shareUrl + "/feed.atom",
+			}
+		}
 
-		c.HTML(http.StatusOK, "share.gohtml", gin.H{"shared": gin.H{"token": token, "uri": uri}, "config": clientConfig})
+		c.HTML(http.StatusOK, "share.gohtml", values)
 	})
 }
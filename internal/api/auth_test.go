@@ -4,9 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"testing"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/session"
 )
@@ -60,3 +62,33 @@ func AuthenticatedRequestWithBody(r http.Handler, method, path, body string, ses
 
 	return w
 }
+
+// AuthenticateRoleUser creates a user with a custom role granting the specified capabilities, e.g. "view share",
+// and returns a valid session id. Call the returned function to delete the user and role after the test.
+func AuthenticateRoleUser(t *testing.T, app *gin.Engine, router *gin.RouterGroup, name, perms string) (sessId string, cleanup func()) {
+	role, err := entity.NewRole(name, perms)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = role.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	user := entity.NewUser()
+	user.UserName = name
+	user.UserRole = role.RoleName
+	user.CanLogin = true
+
+	if err = user.Create(); err != nil {
+		t.Fatal(err)
+	} else if err = user.SetPassword(name + "Passwd123!"); err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup = func() {
+		_ = user.Delete()
+		_ = role.Delete()
+	}
+
+	return AuthenticateUser(app, router, name, name+"Passwd123!"), cleanup
+}
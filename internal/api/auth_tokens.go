@@ -4,21 +4,35 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
-// InvalidPreviewToken checks if the token found in the request is valid for image thumbnails and video streams.
-func InvalidPreviewToken(c *gin.Context) bool {
+// PreviewToken returns the preview token found in the request path or query.
+func PreviewToken(c *gin.Context) string {
 	token := clean.UrlToken(c.Param("token"))
 
 	if token == "" {
 		token = clean.UrlToken(c.Query("t"))
 	}
 
-	return entity.InvalidPreviewToken(token)
+	return token
+}
+
+// InvalidPreviewToken checks if the token found in the request is valid for image thumbnails and video streams.
+func InvalidPreviewToken(c *gin.Context) bool {
+	return entity.InvalidPreviewToken(PreviewToken(c))
 }
 
 // InvalidDownloadToken checks if the token found in the request is valid for file downloads.
 func InvalidDownloadToken(c *gin.Context) bool {
-	return entity.InvalidDownloadToken(clean.UrlToken(c.Query("t")))
+	token := clean.UrlToken(c.Query("t"))
+
+	return entity.InvalidDownloadToken(token) || entity.NoDownloadToken(token)
+}
+
+// LockedFile checks if the requested file is in a locked album that has not been unlocked
+// in the session the token belongs to.
+func LockedFile(token, fileHash string) bool {
+	return search.FileLocked(fileHash, entity.TokenSession(token))
 }
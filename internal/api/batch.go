@@ -39,6 +39,11 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -78,8 +83,12 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 
 		UpdateClientConfig()
 
+		UpdateSmartAlbums(f.Photos...)
+
 		event.EntitiesArchived("photos", f.Photos)
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "archived %s"}, s.RefID, english.Plural(len(f.Photos), "photo", "photos"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
 	})
 }
@@ -102,6 +111,11 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -140,8 +154,12 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 
 		UpdateClientConfig()
 
+		UpdateSmartAlbums(f.Photos...)
+
 		event.EntitiesRestored("photos", f.Photos)
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "restored %s"}, s.RefID, english.Plural(len(f.Photos), "photo", "photos"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionRestored))
 	})
 }
@@ -164,6 +182,11 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -192,8 +215,12 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 
 		UpdateClientConfig()
 
+		UpdateSmartAlbums(f.Photos...)
+
 		event.EntitiesUpdated("photos", approved)
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "approved %s"}, s.RefID, english.Plural(len(approved), "photo", "photos"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionApproved))
 	})
 }
@@ -235,6 +262,8 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 
 		event.EntitiesDeleted("albums", f.Albums)
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "deleted %s"}, s.RefID, english.Plural(len(f.Albums), "album", "albums"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgAlbumsDeleted))
 	})
 }
@@ -257,6 +286,11 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -280,6 +314,8 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 				SavePhotoAsYaml(p)
 			}
 
+			UpdateSmartAlbums(f.Photos...)
+
 			event.EntitiesUpdated("photos", photos)
 		}
 
@@ -287,6 +323,8 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 
 		FlushCoverCache()
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "changed private flag of %s"}, s.RefID, english.Plural(len(f.Photos), "photo", "photos"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionProtected))
 	})
 }
@@ -332,6 +370,8 @@ func BatchLabelsDelete(router *gin.RouterGroup) {
 
 		event.EntitiesDeleted("labels", f.Labels)
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "deleted %s"}, s.RefID, english.Plural(len(f.Labels), "label", "labels"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgLabelsDeleted))
 	})
 }
@@ -361,6 +401,11 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -384,7 +429,7 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		// Delete photos.
 		for _, p := range photos {
 			// Report file deletion.
-			event.AuditWarn([]string{ClientIP(c), s.UserName, "delete", path.Join(p.PhotoPath, p.PhotoName+"*")})
+			event.AuditWarn([]string{ClientIP(c), "session %s", "delete %s"}, s.RefID, clean.Log(path.Join(p.PhotoPath, p.PhotoName+"*")))
 
 			// Remove all related files from storage.
 			n, err := photoprism.DeletePhoto(p, true, true)
@@ -412,6 +457,8 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 			event.EntitiesDeleted("photos", deleted.UIDs())
 		}
 
+		event.AuditInfo([]string{ClientIP(c), "session %s", "permanently deleted %s"}, s.RefID, english.Plural(len(deleted), "photo", "photos"))
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPermanentlyDeleted))
 	})
 }
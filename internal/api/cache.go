@@ -13,6 +13,9 @@ import (
 // CoverMaxAge specifies the number of seconds to cache album covers.
 var CoverMaxAge thumb.MaxAge = 3600 // 1 hour
 
+// MapTileMaxAge specifies the number of seconds to cache map tiles.
+var MapTileMaxAge thumb.MaxAge = 86400 // 1 day
+
 type ThumbCache struct {
 	FileName  string
 	ShareName string
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// errNothingToUndo is returned if there is no edit that can be undone.
+var errNothingToUndo = errors.New("nothing to undo")
+
+// recordChange stores the previous and new values of the changed form fields, so that the edit can be undone.
+func recordChange(s *entity.Session, entityType, entityUID, action string, before, after interface{}) {
+	change := entity.NewChange(entityType, entityUID, action, before, after)
+
+	if change == nil {
+		return
+	} else if s != nil {
+		change.SetUser(s.User())
+	}
+
+	if err := change.Create(); err != nil {
+		log.Warnf("%s: %s (record change)", entityType, err)
+	}
+}
+
+// GetPhotoChanges returns the recorded metadata changes of a photo, with the most recent changes first.
+//
+// GET /api/v1/photos/:uid/changes
+func GetPhotoChanges(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/changes", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		results, err := query.EntityChanges(entity.ChangePhoto, uid, 0, 0)
+
+		if err != nil {
+			log.Errorf("photo: %s (changes)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// undoPhotoChange restores the previous values of the most recent photo edit that has not been undone yet.
+func undoPhotoChange(s *entity.Session, m entity.Photo) (p entity.Photo, err error) {
+	uid := m.PhotoUID
+	change := entity.FindLatestChange(entity.ChangePhoto, uid)
+
+	if change == nil {
+		return p, errNothingToUndo
+	}
+
+	before, err := form.NewPhoto(m)
+
+	if err != nil {
+		return p, err
+	}
+
+	f := before
+
+	if err = change.Revert(&f); err != nil {
+		return p, err
+	} else if err = entity.SavePhotoForm(m, f); err != nil {
+		return p, err
+	} else if err = change.SetUndone(); err != nil {
+		return p, err
+	}
+
+	recordChange(s, entity.ChangePhoto, uid, entity.ChangeActionUndo, before, f)
+
+	if before.PhotoPrivate != f.PhotoPrivate {
+		FlushCoverCache()
+	}
+
+	if p, err = query.PhotoPreloadByUID(uid); err != nil {
+		return p, err
+	}
+
+	SavePhotoAsYaml(p)
+
+	return p, nil
+}
+
+// UndoPhotoChange restores the previous values of the most recent photo edit and returns the photo as JSON.
+//
+// POST /api/v1/photos/:uid/undo
+func UndoPhotoChange(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/undo", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		m, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := undoPhotoChange(s, m)
+
+		if errors.Is(err, errNothingToUndo) {
+			AbortNotFound(c)
+			return
+		} else if err != nil {
+			log.Errorf("photo: %s (undo)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, p)
+	})
+}
+
+// BatchPhotosUndo restores the previous values of the most recent edit of multiple photos,
+// e.g. after a batch edit has overwritten their titles.
+//
+// POST /api/v1/batch/photos/undo
+func BatchPhotosUndo(router *gin.RouterGroup) {
+	router.POST("/batch/photos/undo", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: undoing the most recent edit of %s", clean.Log(f.String()))
+
+		var photos entity.Photos
+		var undone []string
+
+		for _, uid := range f.Photos {
+			if m, err := query.PhotoByUID(uid); err != nil {
+				continue
+			} else if p, err := undoPhotoChange(s, m); errors.Is(err, errNothingToUndo) {
+				continue
+			} else if err != nil {
+				log.Errorf("photo: %s (undo %s)", err, clean.Log(uid))
+			} else {
+				photos = append(photos, p)
+				undone = append(undone, uid)
+			}
+		}
+
+		if len(undone) > 0 {
+			UpdateSmartAlbums(undone...)
+
+			event.EntitiesUpdated("photos", photos)
+
+			UpdateClientConfig()
+
+			event.AuditInfo([]string{ClientIP(c), "session %s", "undid the most recent edit of %s"}, s.RefID, english.Plural(len(undone), "photo", "photos"))
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
+
+// UndoAlbumChange restores the previous values of the most recent album edit and returns the album as JSON.
+//
+// POST /api/v1/albums/:uid/undo
+func UndoAlbumChange(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/undo", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		change := entity.FindLatestChange(entity.ChangeAlbum, uid)
+
+		if change == nil {
+			AbortNotFound(c)
+			return
+		}
+
+		before, err := form.NewAlbum(a)
+
+		if err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		f := before
+
+		if err = change.Revert(&f); err != nil {
+			log.Errorf("album: %s (undo)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
+		if err = a.SaveForm(f); err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		} else if err = change.SetUndone(); err != nil {
+			log.Errorf("album: %s (undo)", err)
+		}
+
+		recordChange(s, entity.ChangeAlbum, uid, entity.ChangeActionUndo, before, f)
+
+		// Update the pictures of smart albums in case the filter has changed.
+		UpdateSmartAlbum(a)
+
+		UpdateClientConfig()
+
+		// Update album YAML backup.
+		SaveAlbumAsYaml(a)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
+// UndoSubjectChange restores the previous values of the most recent subject edit and returns the subject as JSON.
+//
+// POST /api/v1/subjects/:uid/undo
+func UndoSubjectChange(router *gin.RouterGroup) {
+	router.POST("/subjects/:uid/undo", func(c *gin.Context) {
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		s := Auth(c, acl.ResourcePeople, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		m := entity.FindSubject(uid)
+
+		if m == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		change := entity.FindLatestChange(entity.ChangeSubject, uid)
+
+		if change == nil {
+			AbortNotFound(c)
+			return
+		}
+
+		before, err := form.NewSubject(*m)
+
+		if err != nil {
+			log.Errorf("subject: %s (new form)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		f := before
+
+		if err = change.Revert(&f); err != nil {
+			log.Errorf("subject: %s (undo)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		if _, err = m.SaveForm(f); err != nil {
+			log.Errorf("subject: %s", err)
+			AbortSaveFailed(c)
+			return
+		} else if err = change.SetUndone(); err != nil {
+			log.Errorf("subject: %s (undo)", err)
+		}
+
+		recordChange(s, entity.ChangeSubject, uid, entity.ChangeActionUndo, before, f)
+
+		if m.IsPerson() {
+			event.SuccessMsg(i18n.MsgPersonSaved)
+		} else {
+			event.SuccessMsg(i18n.MsgSubjectSaved)
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
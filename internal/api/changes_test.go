@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUndoPhotoChange(t *testing.T) {
+	app, router, _ := NewApiTest()
+	UpdatePhoto(router)
+	GetPhotoChanges(router)
+	UndoPhotoChange(router)
+
+	r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y12", `{"Title": "Undo Me"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "Undo Me", gjson.Get(r.Body.String(), "Title").String())
+
+	r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12/changes")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "update", gjson.Get(r.Body.String(), "0.Action").String())
+	assert.Equal(t, "Undo Me", gjson.Get(r.Body.String(), "0.After.Title").String())
+	previous := gjson.Get(r.Body.String(), "0.Before.Title").String()
+
+	r = PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/undo")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, previous, gjson.Get(r.Body.String(), "Title").String())
+
+	r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12/changes")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "undo", gjson.Get(r.Body.String(), "0.Action").String())
+	assert.True(t, gjson.Get(r.Body.String(), "1.UndoneAt").Exists())
+
+	t.Run("NothingToUndo", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/xxx/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestBatchPhotosUndo(t *testing.T) {
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosUndo(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/undo", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosUndo(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/undo", `{"photos": ["pt9jtdre2lvl0y12", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestUndoAlbumChange(t *testing.T) {
+	t.Run("NothingToUndo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UndoAlbumChange(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UndoAlbumChange(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/xxx/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUndoSubjectChange(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UndoSubjectChange(router)
+		r := PerformRequest(app, "POST", "/api/v1/subjects/xxx/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// StartCleanUp removes orphaned index entries, sidecar and thumbnail files.
+//
+// POST /api/v1/cleanup
+func StartCleanUp(router *gin.RouterGroup) {
+	router.POST("/cleanup", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if !get.Config().Settings().Features.Library {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.CleanUpOptions
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		thumbs, orphans, sidecars, err := get.CleanUp().Start(photoprism.CleanUpOptions{Dry: f.Dry})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		var msg string
+
+		if f.Dry {
+			msg = fmt.Sprintf("Dry run, would remove %s and %s", english.Plural(orphans, "index entry", "index entries"), english.Plural(thumbs, "cache file", "cache files"))
+		} else {
+			msg = fmt.Sprintf("Removed %s and %s", english.Plural(orphans, "index entry", "index entries"), english.Plural(thumbs+sidecars, "file", "files"))
+			UpdateClientConfig()
+		}
+
+		log.Infof("cleanup: completed in %s", time.Since(start))
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartCleanUp(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		StartCleanUp(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/cleanup", `{"dry": true}`)
+
+		var resp i18n.Response
+
+		if err := json.Unmarshal(r.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, resp.Success())
+		assert.Contains(t, resp.Msg, "Dry run")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		StartCleanUp(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/cleanup", "xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
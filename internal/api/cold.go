@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// restoreFile makes sure an original file that was moved to cold storage is available again.
+// If the restore takes longer, e.g. from S3 Glacier, the request is aborted with status 202
+// so that the client can try again later, and false is returned.
+func restoreFile(c *gin.Context, f *entity.File) bool {
+	if ready, err := coldFile(f); err != nil || ready {
+		return true
+	}
+
+	Abort(c, http.StatusAccepted, i18n.MsgRestoreRequested)
+
+	return false
+}
+
+// coldFile requests a restore if the original file was moved to cold storage
+// and returns true if it is available.
+func coldFile(f *entity.File) (bool, error) {
+	ready, err := get.Cold().RestoreFile(f)
+
+	if err != nil {
+		log.Errorf("cold: %s while restoring %s", err, clean.Log(f.FileName))
+	}
+
+	return ready, err
+}
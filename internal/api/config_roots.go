@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+)
+
+// OriginalsRootInfo represents an originals storage root with its free and total disk space in bytes.
+type OriginalsRootInfo struct {
+	Name     string `json:"Name"`
+	Main     bool   `json:"Main"`
+	ReadOnly bool   `json:"ReadOnly"`
+	Free     uint64 `json:"Free"`
+	Used     uint64 `json:"Used"`
+	Total    uint64 `json:"Total"`
+	Error    string `json:"Error,omitempty"`
+}
+
+// GetConfigRoots returns the originals storage roots, including their read-only flag and free space.
+//
+// GET /api/v1/config/roots
+func GetConfigRoots(router *gin.RouterGroup) {
+	router.GET("/config/roots", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceConfig, acl.AccessAll)
+		conf := get.Config()
+
+		// Abort if permission was not granted.
+		if s.Invalid() || conf.Public() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		roots := conf.OriginalsRoots()
+		result := make([]OriginalsRootInfo, 0, len(roots))
+
+		for _, r := range roots {
+			info := OriginalsRootInfo{Name: r.Name, Main: r.Main(), ReadOnly: r.ReadOnly}
+
+			if space, err := r.Space(); err != nil {
+				info.Error = err.Error()
+			} else {
+				info.Free = space.Free
+				info.Used = space.Used()
+				info.Total = space.Total
+			}
+
+			result = append(result, info)
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
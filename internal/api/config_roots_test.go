@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigRoots(t *testing.T) {
+	t.Run("unauthorised", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetConfigRoots(router)
+		r := PerformRequest(app, "GET", "/api/v1/config/roots")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -50,6 +51,12 @@ func AlbumCover(router *gin.RouterGroup) {
 			return
 		}
 
+		// Don't show the covers of locked albums.
+		if a, err := entity.CachedAlbumByUID(uid); err == nil && a.Locked() {
+			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			return
+		}
+
 		cache := get.CoverCache()
 		cacheKey := CacheKey(albumCover, uid, string(thumbName))
 
@@ -84,6 +91,7 @@ func AlbumCover(router *gin.RouterGroup) {
 		}
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fetchFile(fileName)
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("%s: found no original for %s", albumCover, clean.Log(fileName))
@@ -197,6 +205,7 @@ func LabelCover(router *gin.RouterGroup) {
 		}
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fetchFile(fileName)
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("%s: file %s is missing", labelCover, clean.Log(f.FileName))
@@ -2,12 +2,14 @@ package api
 
 import (
 	"archive/zip"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -41,6 +43,10 @@ func DownloadAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if a.Locked() {
+			// Locked albums cannot be downloaded, as download tokens are not bound to a session.
+			AbortForbidden(c)
+			return
 		}
 
 		files, err := search.AlbumPhotos(a, 10000, true)
@@ -59,6 +65,9 @@ func DownloadAlbum(router *gin.RouterGroup) {
 
 		var aliases = make(map[string]int)
 
+		// Pictures shared with links that have watermarks enabled are watermarked.
+		link := watermarkLink(clean.UrlToken(c.Query("t")))
+
 		for _, file := range files {
 			if file.FileHash == "" {
 				log.Warnf("download: empty file hash, skipped %s", clean.Log(file.FileName))
@@ -83,7 +92,24 @@ func DownloadAlbum(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
+			if ready, _ := coldFile(&entity.File{ID: file.FileID, FileRoot: file.FileRoot, FileName: file.FileName}); !ready {
+				log.Infof("download: skipped %s, which is being restored from cold storage", clean.Log(file.FileName))
+				continue
+			}
+
+			fetchFile(fileName)
+
 			if fs.FileExists(fileName) {
+				if wm, err := watermarkFileName(link, fileName, file.FileHash, file.FileOrientation); errors.Is(err, errWatermarkUnsupported) {
+					log.Debugf("download: skipped %s, which cannot be watermarked", clean.Log(file.FileName))
+					continue
+				} else if err != nil {
+					log.Errorf("download: %s (watermark %s)", err, clean.Log(file.FileName))
+					continue
+				} else if wm != fileName {
+					fileName, alias = wm, fs.StripExt(alias)+fs.ExtJPEG
+				}
+
 				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
 					log.Errorf("download: failed adding %s to album zip (%s)", clean.Log(file.FileName), err)
 					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
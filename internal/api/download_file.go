@@ -49,6 +49,12 @@ func GetDownload(router *gin.RouterGroup) {
 
 		fileHash := clean.Token(c.Param("hash"))
 
+		// Don't allow downloading files in locked albums.
+		if LockedFile(clean.UrlToken(c.Query("t")), fileHash) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		f, err := query.FileByHash(fileHash)
 
 		if err != nil {
@@ -56,7 +62,13 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Originals in cold storage must be restored first.
+		if !restoreFile(c, f) {
+			return
+		}
+
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fetchFile(fileName)
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("download: file %s is missing", clean.Log(f.FileName))
@@ -68,6 +80,24 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
+		// Remove GPS metadata if the picture was taken in a privacy zone.
+		fileName, cleanup, err := privacyFileName(fileName, f.Photo)
+
+		if err != nil {
+			log.Errorf("download: %s", err)
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		defer cleanup()
+
+		// Draw a watermark if the file was shared with a link that has watermarks enabled.
+		if fileName, err = watermarkFileName(watermarkLink(clean.UrlToken(c.Query("t"))), fileName, f.FileHash, f.FileOrientation); err != nil {
+			log.Errorf("download: %s", err)
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		sendFile(c, fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
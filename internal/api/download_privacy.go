@@ -0,0 +1,29 @@
+package api
+
+import (
+	"os"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// privacyFileName returns the name of the file that may be downloaded, which is a copy without GPS metadata
+// if the picture was taken in a privacy zone, and a function that removes the copy after the download.
+func privacyFileName(fileName string, p *entity.Photo) (string, func(), error) {
+	if len(entity.PrivacyZones) == 0 {
+		return fileName, func() {}, nil
+	}
+
+	if p == nil || !entity.PrivacyFenced(float64(p.PhotoLat), float64(p.PhotoLng)) {
+		return fileName, func() {}, nil
+	}
+
+	copyName, err := photoprism.PrivacyCopy(get.Config(), fileName)
+
+	if err != nil {
+		return "", func() {}, err
+	}
+
+	return copyName, func() { logError("download", os.Remove(copyName)) }, nil
+}
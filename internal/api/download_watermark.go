@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// errWatermarkUnsupported is returned if a watermark is required, but cannot be drawn on the file.
+var errWatermarkUnsupported = errors.New("watermark required, but not supported for this file type")
+
+// watermarkLink returns the share link whose watermark must be applied to images requested with
+// the preview or download token, or nil if no watermark is configured or required.
+func watermarkLink(token string) *entity.Link {
+	if get.Config().Watermark().Empty() {
+		return nil
+	}
+
+	return entity.WatermarkLink(token)
+}
+
+// watermarkFileName returns the name of a watermarked copy of the image file if the share link has
+// watermarks enabled, or the original file name otherwise. Watermarked copies are cached per share token.
+func watermarkFileName(link *entity.Link, fileName, cacheName string, orientation int) (string, error) {
+	if link == nil {
+		return fileName, nil
+	}
+
+	conf := get.Config()
+	w := conf.Watermark()
+
+	if w.Empty() {
+		return fileName, nil
+	}
+
+	// Only JPEG and PNG images can be watermarked.
+	if t := fs.FileType(fileName); t != fs.ImageJPEG && t != fs.ImagePNG {
+		return "", errWatermarkUnsupported
+	}
+
+	return w.FromFile(fileName, w.FileName(watermarkCachePath(link), cacheName), orientation)
+}
+
+// watermarkCachePath returns the cache path for watermarked images shared with the link.
+func watermarkCachePath(link *entity.Link) string {
+	return filepath.Join(get.Config().ThumbCachePath(), "watermarks", clean.Token(link.LinkToken))
+}
+
+// removeWatermarks removes the watermarked images cached for the link.
+func removeWatermarks(link *entity.Link) {
+	if link == nil || clean.Token(link.LinkToken) == "" {
+		return
+	}
+
+	if err := os.RemoveAll(watermarkCachePath(link)); err != nil {
+		log.Warnf("share: %s (remove watermarks)", err)
+	}
+}
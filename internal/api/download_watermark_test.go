@@ -0,0 +1,53 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+)
+
+func TestWatermarkFileName(t *testing.T) {
+	fileName := "testdata/example.jpg"
+	link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	link.Watermark = true
+
+	t.Run("NoLink", func(t *testing.T) {
+		result, err := watermarkFileName(nil, fileName, "abc", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, result)
+	})
+	t.Run("NotConfigured", func(t *testing.T) {
+		result, err := watermarkFileName(&link, fileName, "abc", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, result)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		conf := get.Config()
+		conf.Options().WatermarkText = "© Example"
+		defer func() { conf.Options().WatermarkText = "" }()
+
+		_, err := watermarkFileName(&link, "testdata/example.mp4", "abc", 0)
+
+		assert.ErrorIs(t, err, errWatermarkUnsupported)
+	})
+}
+
+func TestRemoveWatermarks(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	dir := watermarkCachePath(&link)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	removeWatermarks(&link)
+	removeWatermarks(nil)
+
+	assert.NoDirExists(t, dir)
+}
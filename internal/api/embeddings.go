@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ExportEmbeddings exports embedding vectors as JSON Lines file, e.g. for use in external ML pipelines.
+//
+// GET /api/v1/embeddings/export
+//
+// Query:
+//
+//	model: embedding model name, exports all photo embeddings if empty (optional)
+func ExportEmbeddings(router *gin.RouterGroup) {
+	router.GET("/embeddings/export", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		model := txt.Clip(c.Query("model"), txt.ClipSlug)
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "exporting embeddings"}, s.RefID)
+
+		AddDownloadHeader(c, fmt.Sprintf("embeddings-%s.jsonl", time.Now().UTC().Format("20060102-150405")))
+		AddContentTypeHeader(c, "application/x-ndjson")
+		c.Status(http.StatusOK)
+
+		if _, err := photoprism.ExportEmbeddings(c.Writer, model); err != nil {
+			log.Errorf("embeddings: %s (export)", err)
+		}
+	})
+}
+
+// ImportEmbeddings adds externally computed embeddings and labels from a JSON Lines request body.
+//
+// POST /api/v1/embeddings/import
+func ImportEmbeddings(router *gin.RouterGroup) {
+	router.POST("/embeddings/import", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		result, err := photoprism.ImportEmbeddings(c.Request.Body)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "imported %d embeddings and %d labels"}, s.RefID, result.Embeddings, result.Labels)
+
+		c.JSON(http.StatusOK, gin.H{"embeddings": result.Embeddings, "labels": result.Labels, "skipped": result.Skipped})
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportEmbeddings(t *testing.T) {
+	t.Run("Faces", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportEmbeddings(router)
+		r := PerformRequest(app, "GET", "/api/v1/embeddings/export?model=facenet")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/x-ndjson", r.Header().Get("Content-Type"))
+		assert.True(t, strings.HasSuffix(r.Header().Get("Content-Disposition"), ".jsonl"))
+		assert.Contains(t, r.Body.String(), `"marker_uid"`)
+	})
+}
+
+func TestImportEmbeddings(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ImportEmbeddings(router)
+		body := `{"photo_uid":"pt9jtdre2lvl0yh8","model":"api-test","embedding":[0.5,0.25]}` + "\n" + `{"photo_uid":"pt9jtdre2lvl0yh0"}`
+		r := PerformRequestWithBody(app, "POST", "/api/v1/embeddings/import", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var result map[string]int
+
+		if err := json.Unmarshal(r.Body.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result["embeddings"])
+		assert.Equal(t, 1, result["skipped"])
+	})
+}
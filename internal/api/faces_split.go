@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SplitFace moves the selected markers of a wrongly merged face cluster to a new cluster.
+//
+// POST /api/v1/faces/:id/split
+//
+// Parameters:
+//
+//	id: string Face ID
+func SplitFace(router *gin.RouterGroup) {
+	router.POST("/faces/:id/split", func(c *gin.Context) {
+		// Abort if another update is running.
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		s := Auth(c, acl.ResourcePeople, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.FaceSplit
+
+		if err := c.BindJSON(&f); err != nil || len(f.Markers) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.FindFace(clean.Token(c.Param("id")))
+
+		if m == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrFaceNotFound)
+			return
+		}
+
+		markers := make(entity.Markers, 0, len(f.Markers))
+
+		for _, uid := range f.Markers {
+			if marker, err := query.MarkerByUID(clean.UID(uid)); err != nil {
+				AbortEntityNotFound(c)
+				return
+			} else {
+				markers = append(markers, *marker)
+			}
+		}
+
+		// Assign the new cluster to an existing or new subject?
+		var subj *entity.Subject
+
+		if f.SubjUID != "" {
+			if subj = entity.FindSubject(clean.UID(f.SubjUID)); subj == nil {
+				Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+				return
+			}
+		} else if name := clean.Name(f.Name); name != "" {
+			subjType := entity.SubjPerson
+
+			if m.IsPet() {
+				subjType = entity.SubjPet
+			}
+
+			if subj = entity.FirstOrCreateSubject(entity.NewSubject(name, subjType, entity.SrcManual)); subj == nil {
+				AbortSaveFailed(c)
+				return
+			}
+		}
+
+		result, err := m.Split(markers, subj)
+
+		if err != nil {
+			log.Errorf("faces: %s (split)", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		if err := query.UpdateSubjectCovers(); err != nil {
+			log.Errorf("faces: %s (update covers)", err)
+		}
+
+		if err := entity.UpdateSubjectCounts(); err != nil {
+			log.Errorf("faces: %s (update counts)", err)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFace(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SplitFace(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/xxx/split", `{"Markers": ["mt9k3pw1wowuy888"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NoMarkers", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SplitFace(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/PN6QO5INYTUSAATOFL43LL2ABAV5ACZK/split", `{"Markers": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -78,6 +78,11 @@ func DeleteFile(router *gin.RouterGroup) {
 			log.Infof("files: deleted %s", clean.Log(baseName))
 		}
 
+		// Remove file from object storage, if configured.
+		if err = get.Storage().Remove(fileName); err != nil {
+			log.Errorf("storage: %s (delete %s)", err, clean.Log(baseName))
+		}
+
 		// Remove file from index.
 		if err = file.Delete(true); err != nil {
 			log.Errorf("files: %s (delete %s from index)", err, clean.Log(baseName))
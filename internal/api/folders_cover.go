@@ -93,6 +93,7 @@ func FolderCover(router *gin.RouterGroup) {
 		}
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fetchFile(fileName)
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("%s: could not find original for %s", folderCover, fileName)
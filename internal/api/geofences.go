@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetGeofences returns all geofences.
+//
+// GET /api/v1/geofences
+func GetGeofences(router *gin.RouterGroup) {
+	router.GET("/geofences", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		result, err := entity.FindGeofences()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// CreateGeofence adds a named area with a label and/or album, e.g. "Cottage", which is assigned
+// automatically to pictures taken inside when they are indexed.
+//
+// POST /api/v1/geofences
+func CreateGeofence(router *gin.RouterGroup) {
+	router.POST("/geofences", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Geofence
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewGeofence(f.FenceName, f.FenceLat, f.FenceLng, f.FenceRadius)
+		m.FenceLabel = f.FenceLabel
+		m.FenceAlbum = f.FenceAlbum
+		m.CreatedBy = s.UserUID
+
+		if err := m.SetPolygon(f.FencePolygon); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		} else if err = m.Create(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "geofence %s", "created"}, s.RefID, clean.Log(m.FenceName))
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UpdateGeofence changes the name, area, label, or album of a geofence.
+//
+// PUT /api/v1/geofences/:id
+func UpdateGeofence(router *gin.RouterGroup) {
+	router.PUT("/geofences/:id", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGeofence(txt.UInt(clean.Token(c.Param("id"))))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Initialize form.
+		f, err := form.NewGeofence(*m)
+
+		if err != nil {
+			log.Errorf("geofence: %s (new form)", err)
+			AbortSaveFailed(c)
+			return
+		} else if err = c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err = m.SaveForm(f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "geofence %s", "updated"}, s.RefID, clean.Log(m.FenceName))
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteGeofence removes a geofence. Labels and albums that have already been assigned are kept.
+//
+// DELETE /api/v1/geofences/:id
+func DeleteGeofence(router *gin.RouterGroup) {
+	router.DELETE("/geofences/:id", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGeofence(txt.UInt(clean.Token(c.Param("id"))))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("geofence: %s (delete)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "geofence %s", "deleted"}, s.RefID, clean.Log(m.FenceName))
+		event.SuccessMsg(i18n.MsgPermanentlyDeleted)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
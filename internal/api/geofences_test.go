@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGeofences(t *testing.T) {
+	app, router, _ := NewApiTest()
+
+	GetGeofences(router)
+	CreateGeofence(router)
+	UpdateGeofence(router)
+	DeleteGeofence(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/geofences", `{"Name": "Cottage", "Lat": 61.4978, "Lng": 23.7610, "Radius": 300, "Album": "Cottage"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	id := gjson.Get(r.Body.String(), "ID").Int()
+	assert.NotEmpty(t, id)
+	assert.Equal(t, "cottage", gjson.Get(r.Body.String(), "Slug").String())
+
+	r = PerformRequest(app, "GET", "/api/v1/geofences")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Contains(t, r.Body.String(), "Cottage")
+
+	r = PerformRequestWithBody(app, "PUT", fmt.Sprintf("/api/v1/geofences/%d", id), `{"Polygon": "61.49,23.75;61.50,23.75;61.50,23.77;61.49,23.77", "Label": "Summer"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "Cottage", gjson.Get(r.Body.String(), "Name").String())
+	assert.Equal(t, "Summer", gjson.Get(r.Body.String(), "Label").String())
+	assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Radius").Int())
+
+	r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/geofences/%d", id))
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/geofences/%d", id))
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
+
+func TestCreateGeofence(t *testing.T) {
+	t.Run("NoAction", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateGeofence(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geofences", `{"Name": "Cottage", "Lat": 61.4978, "Lng": 23.7610}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidPolygon", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateGeofence(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geofences", `{"Name": "Cottage", "Polygon": "61.49,23.75;61.50", "Album": "Cottage"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
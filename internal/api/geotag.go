@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/track"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GeotagMaxSize is the maximum size of a track file in bytes.
+const GeotagMaxSize = 64 * 1024 * 1024
+
+// Geotag sets the location of pictures without GPS coordinates based on a GPX or KML track file in the request body.
+//
+// POST /api/v1/geotag
+//
+// Query:
+//
+//	offset: duration added to the camera time, e.g. 2m if the camera clock was two minutes behind (optional)
+//	tz: camera clock time zone for pictures without a known time zone, e.g. Europe/Berlin (optional)
+//	gap: maximum duration between two track points, e.g. 5m (optional)
+//	sidecar: update YAML sidecar files if true (optional)
+func Geotag(router *gin.RouterGroup) {
+	router.POST("/geotag", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		opt := photoprism.GeotagOptions{
+			TimeZone: txt.Clip(c.Query("tz"), txt.ClipSlug),
+			Sidecar:  txt.Bool(c.Query("sidecar")),
+		}
+
+		var err error
+
+		if opt.Offset, err = geotagDuration(c.Query("offset")); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if opt.MaxGap, err = geotagDuration(c.Query("gap")); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		data, err := io.ReadAll(io.LimitReader(c.Request.Body, GeotagMaxSize))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		tracks, err := track.Parse(data)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		result, err := photoprism.Geotag(get.Config(), tracks, opt)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "geotagged %d pictures"}, s.RefID, result.Tagged)
+
+		c.JSON(http.StatusOK, gin.H{"points": tracks.Points(), "tagged": result.Tagged, "skipped": result.Skipped})
+	})
+}
+
+// geotagDuration parses an optional duration like "2m" or "-30s".
+func geotagDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	} else if d, err := time.ParseDuration(s); err != nil {
+		return 0, fmt.Errorf("invalid duration")
+	} else {
+		return d, nil
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotag(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Geotag(router)
+
+		data, err := os.ReadFile("../track/testdata/hike.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geotag?offset=1m&tz=Europe/Berlin", string(data))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var result map[string]int
+
+		if err = json.Unmarshal(r.Body.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 5, result["points"])
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Geotag(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geotag?offset=foo", "<gpx></gpx>")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Geotag(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geotag", `{"type": "FeatureCollection"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SearchGroups returns all user groups.
+//
+// GET /api/v1/groups
+func SearchGroups(router *gin.RouterGroup) {
+	router.GET("/groups", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindGroups())
+	})
+}
+
+// GetGroup returns a user group including the UIDs of its members.
+//
+// GET /api/v1/groups/:uid
+func GetGroup(router *gin.RouterGroup) {
+	router.GET("/groups/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGroupByUID(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"group": m, "members": m.MemberUIDs()})
+	})
+}
+
+// CreateGroup creates a new user group.
+//
+// POST /api/v1/groups
+func CreateGroup(router *gin.RouterGroup) {
+	router.POST("/groups", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Group
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewGroup(f.GroupName)
+
+		if f.GroupTitle != "" {
+			m.GroupTitle = clean.Name(f.GroupTitle)
+		}
+
+		m.SetRole(f.GroupRole)
+		m.Description = clean.Clip(f.Description, 512)
+
+		if err := m.Create(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "created group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UpdateGroup updates a user group and assigns its role to the members.
+//
+// PUT /api/v1/groups/:uid
+func UpdateGroup(router *gin.RouterGroup) {
+	router.PUT("/groups/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGroupByUID(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		f := form.Group{GroupTitle: m.GroupTitle, GroupRole: m.GroupRole, Description: m.Description}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m.GroupTitle = clean.Name(f.GroupTitle)
+		m.SetRole(f.GroupRole)
+		m.Description = clean.Clip(f.Description, 512)
+
+		if err := m.Save(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "updated group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteGroup deletes a user group including its memberships and shares.
+//
+// DELETE /api/v1/groups/:uid
+func DeleteGroup(router *gin.RouterGroup) {
+	router.DELETE("/groups/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGroupByUID(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("groups: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "deleted group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// AddGroupMembers adds users to a group.
+//
+// POST /api/v1/groups/:uid/members
+func AddGroupMembers(router *gin.RouterGroup) {
+	router.POST("/groups/:uid/members", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGroupByUID(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var f form.GroupMembers
+
+		if err := c.BindJSON(&f); err != nil || len(f.Users) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		if _, err := m.AddMembers(f.Users); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "updated members of group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		c.JSON(http.StatusOK, gin.H{"group": m, "members": m.MemberUIDs()})
+	})
+}
+
+// RemoveGroupMembers removes users from a group.
+//
+// DELETE /api/v1/groups/:uid/members
+func RemoveGroupMembers(router *gin.RouterGroup) {
+	router.DELETE("/groups/:uid/members", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceUsers, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindGroupByUID(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var f form.GroupMembers
+
+		if err := c.BindJSON(&f); err != nil || len(f.Users) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.RemoveMembers(f.Users); err != nil {
+			log.Errorf("groups: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "updated members of group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		c.JSON(http.StatusOK, gin.H{"group": m, "members": m.MemberUIDs()})
+	})
+}
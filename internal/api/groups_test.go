@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGroups(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetGroup(router)
+		r := PerformRequest(app, "GET", "/api/v1/groups/gxxxxxxxxxxxxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateGroup(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/groups", `{"Name": "Friends", "Role": "foo"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Role").String())
+	})
+	t.Run("CreateAndDelete", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchGroups(router)
+		CreateGroup(router)
+		UpdateGroup(router)
+		AddGroupMembers(router)
+		RemoveGroupMembers(router)
+		DeleteGroup(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/groups", `{"Name": "Grandparents"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		assert.NotEmpty(t, uid)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/groups", `{"Name": "Grandparents"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequestWithBody(app, "PUT", fmt.Sprintf("/api/v1/groups/%s", uid), `{"Title": "Grandma & Grandpa"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Grandma & Grandpa", gjson.Get(r.Body.String(), "Title").String())
+
+		r = PerformRequestWithBody(app, "POST", fmt.Sprintf("/api/v1/groups/%s/members", uid), `{"users": ["uqxc08w3d0ej2283"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "uqxc08w3d0ej2283", gjson.Get(r.Body.String(), "members.0").String())
+
+		r = PerformRequestWithBody(app, "DELETE", fmt.Sprintf("/api/v1/groups/%s/members", uid), `{"users": ["uqxc08w3d0ej2283"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "group.MemberCount").Int())
+
+		r = PerformRequest(app, "GET", "/api/v1/groups")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), uid)
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/groups/%s", uid))
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetJobs returns the state of all background workers, including failed and queued jobs.
+//
+// GET /api/v1/jobs
+func GetJobs(router *gin.RouterGroup) {
+	router.GET("/jobs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceConfig, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		jobs := workers.Jobs()
+
+		AddCountHeader(c, len(jobs))
+
+		c.JSON(http.StatusOK, jobs)
+	})
+}
+
+// PauseJob pauses a background worker, or all workers if the name is "all".
+//
+// POST /api/v1/jobs/:name/pause
+func PauseJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:name/pause", func(c *gin.Context) {
+		updateJob(c, workers.PauseJob)
+	})
+}
+
+// ResumeJob resumes a paused background worker, or all workers if the name is "all".
+//
+// POST /api/v1/jobs/:name/resume
+func ResumeJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:name/resume", func(c *gin.Context) {
+		updateJob(c, workers.ResumeJob)
+	})
+}
+
+// CancelJob cancels the current run of a background worker, or of all workers if the name is "all".
+//
+// POST /api/v1/jobs/:name/cancel
+func CancelJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:name/cancel", func(c *gin.Context) {
+		updateJob(c, workers.CancelJob)
+	})
+}
+
+// updateJob checks the permissions, applies the change to the worker specified in the request,
+// and returns the updated state of all workers.
+func updateJob(c *gin.Context, update func(name string) error) {
+	s := Auth(c, acl.ResourceConfig, acl.ActionManage)
+
+	if s.Abort(c) {
+		return
+	}
+
+	if err := update(clean.TypeLower(c.Param("name"))); err != nil {
+		AbortNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, workers.Jobs())
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestGetJobs(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetJobs(router)
+	r := PerformRequest(app, "GET", "/api/v1/jobs")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, int64(len(mutex.Workers)), gjson.Get(r.Body.String(), "#").Int())
+	assert.Equal(t, "albums", gjson.Get(r.Body.String(), "0.Name").String())
+}
+
+func TestPauseJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PauseJob(router)
+		ResumeJob(router)
+		r := PerformRequest(app, "POST", "/api/v1/jobs/backup/pause")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, mutex.BackupWorker.Paused())
+		r = PerformRequest(app, "POST", "/api/v1/jobs/backup/resume")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, mutex.BackupWorker.Paused())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PauseJob(router)
+		r := PerformRequest(app, "POST", "/api/v1/jobs/foo/pause")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCancelJob(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CancelJob(router)
+	r := PerformRequest(app, "POST", "/api/v1/jobs/index/cancel")
+	assert.Equal(t, http.StatusOK, r.Code)
+}
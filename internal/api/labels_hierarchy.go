@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetLabelParents returns the parent labels of a label.
+//
+// GET /api/v1/labels/:uid/parents
+func GetLabelParents(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/parents", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Parents())
+	})
+}
+
+// UpdateLabelParent moves a label to a different parent, or to the top level if no parent is specified,
+// so that searching for the parent also finds pictures with this label.
+//
+// PUT /api/v1/labels/:uid/parent
+func UpdateLabelParent(router *gin.RouterGroup) {
+	router.PUT("/labels/:uid/parent", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.LabelParent
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		m, err := query.LabelByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		var parent *entity.Label
+
+		if f.Parent != "" {
+			if p, err := query.LabelByUID(clean.UID(f.Parent)); err != nil {
+				Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+				return
+			} else {
+				parent = &p
+			}
+		}
+
+		if err = m.SetParent(parent); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		PublishLabelEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, m.Parents())
+	})
+}
+
+// RebuildLabelHierarchy rebuilds the label closure table used to find pictures with more specific labels.
+//
+// POST /api/v1/labels/hierarchy
+func RebuildLabelHierarchy(router *gin.RouterGroup) {
+	router.POST("/labels/hierarchy", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		count, err := entity.RebuildLabelClosure()
+
+		if err != nil {
+			log.Errorf("labels: %s (rebuild hierarchy)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		log.Infof("labels: rebuilt hierarchy with %d relationships", count)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "count": count})
+	})
+}
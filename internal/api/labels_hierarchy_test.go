@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpdateLabelParent(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelParent(router)
+		GetLabelParents(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c5/parent", `{"Parent": "lt9k3pw1wowuy3c2"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "lt9k3pw1wowuy3c2", gjson.Get(r.Body.String(), "0.UID").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c5/parents")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c5/parent", `{"Parent": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelParent(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c5/parent", `{"Parent": "lt9k3pw1wowuy3c5"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("LabelNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelParent(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/xxx/parent", `{"Parent": ""}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("ParentNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelParent(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c5/parent", `{"Parent": "lt9k3pw1wowuxxxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestRebuildLabelHierarchy(t *testing.T) {
+	app, router, _ := NewApiTest()
+	RebuildLabelHierarchy(router)
+
+	r := PerformRequest(app, "POST", "/api/v1/labels/hierarchy")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Greater(t, gjson.Get(r.Body.String(), "count").Int(), int64(0))
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetLabelsReview returns automatically assigned photo labels with a low confidence so that they can be reviewed.
+//
+// GET /api/v1/labels/review
+//
+// Parameters:
+//
+//	count: int Maximum number of results, default is 100
+//	offset: int Result offset
+//	confidence: float Minimum confidence of labels that don't need to be reviewed, see label-confidence option
+func GetLabelsReview(router *gin.RouterGroup) {
+	router.GET("/labels/review", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+		confidence := get.Config().LabelConfidence()
+
+		if limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		if f := txt.Float(c.Query("confidence")); f > 0 && f <= 1 {
+			confidence = float32(f)
+		}
+
+		labels, err := query.ReviewLabels(limit, offset, confidence)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(labels))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, labels)
+	})
+}
+
+// UpdateLabelsReview confirms or rejects multiple automatically assigned photo labels at once. The feedback
+// is used to adjust the minimum confidence of each label, so that fewer wrong labels are assigned in the future.
+//
+// POST /api/v1/labels/review
+func UpdateLabelsReview(router *gin.RouterGroup) {
+	router.POST("/labels/review", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.LabelsReview
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Confirm)+len(f.Reject) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		confirmed, rejected := 0, 0
+		updated := make(map[string]bool)
+
+		for _, item := range f.Confirm {
+			if label, err := reviewPhotoLabel(item); err != nil {
+				log.Debugf("labels: %s (find label to confirm)", err)
+			} else if err = label.Confirm(); err != nil {
+				log.Errorf("labels: %s (confirm label)", err)
+			} else {
+				confirmed++
+				updated[clean.UID(item.PhotoUID)] = true
+			}
+		}
+
+		// Rejected labels raise the label threshold, so that similar matches are skipped when indexing.
+		for _, item := range f.Reject {
+			if label, err := reviewPhotoLabel(item); err != nil {
+				log.Debugf("labels: %s (find label to reject)", err)
+			} else if err = label.Reject(); err != nil {
+				log.Errorf("labels: %s (reject label)", err)
+			} else {
+				rejected++
+				uid := clean.UID(item.PhotoUID)
+				updated[uid] = true
+
+				if p, err := query.PhotoByUID(uid); err == nil && label.Label != nil {
+					logError("labels", p.RemoveKeyword(label.Label.LabelName))
+				}
+			}
+		}
+
+		for uid := range updated {
+			if p, err := query.PhotoPreloadByUID(uid); err != nil {
+				log.Debugf("labels: %s (find photo)", err)
+			} else if err = p.SaveLabels(); err != nil {
+				log.Errorf("labels: %s (update photo)", err)
+			} else {
+				PublishPhotoEvent(EntityUpdated, uid, c)
+			}
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "reviewed labels", "%d confirmed", "%d rejected"}, s.RefID, confirmed, rejected)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, gin.H{"confirmed": confirmed, "rejected": rejected})
+	})
+}
+
+// reviewPhotoLabel returns the photo label referenced by the review item.
+func reviewPhotoLabel(item form.LabelsReviewItem) (entity.PhotoLabel, error) {
+	photo, err := query.PhotoByUID(clean.UID(item.PhotoUID))
+
+	if err != nil {
+		return entity.PhotoLabel{}, err
+	}
+
+	label, err := query.LabelByUID(clean.UID(item.LabelUID))
+
+	if err != nil {
+		return entity.PhotoLabel{}, err
+	}
+
+	return query.PhotoLabel(photo.ID, label.ID)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLabelsReview(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelsReview(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/review?count=10&confidence=0.7")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestUpdateLabelsReview(t *testing.T) {
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelsReview(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/review", `{"Confirm": [], "Reject": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelsReview(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/review", `{"Reject": [{"PhotoUID": "pt9jtdre2lvl0y99", "LabelUID": "lt9k3pw1wowuy3c2"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"rejected":0`)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -35,9 +36,25 @@ func UpdateLink(c *gin.Context) {
 
 	link := entity.FindLink(clean.Token(c.Param("link")))
 
+	// Remove cached watermarked images, as the token or watermark setting may change.
+	removeWatermarks(link)
+
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.LinkExpiresAt = f.ExpiresAt
+	link.NoDownload = f.NoDownload
+	link.Watermark = f.Watermark
+	link.SetComment(f.CanComment)
+	link.SetReact(f.CanReact)
+	link.SetUpload(f.CanUpload)
+	link.UploadReview = f.UploadReview
+	link.UploadLimit = f.UploadLimit
+	link.SetUploadTypes(f.UploadTypes)
+
+	if f.Comment != "" {
+		link.Comment = txt.Clip(f.Comment, 512)
+	}
 
 	if f.LinkToken != "" {
 		link.LinkToken = strings.TrimSpace(strings.ToLower(f.LinkToken))
@@ -80,6 +97,11 @@ func DeleteLink(c *gin.Context) {
 		return
 	}
 
+	event.AuditInfo([]string{ClientIP(c), "session %s", "deleted share link for %s"}, s.RefID, clean.Log(link.ShareUID))
+
+	// Remove cached watermarked images.
+	removeWatermarks(link)
+
 	UpdateClientConfig()
 
 	PublishAlbumEvent(EntityUpdated, link.ShareUID, c)
@@ -112,11 +134,26 @@ func CreateLink(c *gin.Context) {
 		return
 	}
 
+	createLink(c, s, uid, f)
+}
+
+// createLink adds a new share link for the entity with the specified UID and returns it as JSON.
+func createLink(c *gin.Context, s *entity.Session, uid string, f form.Link) {
 	link := entity.NewUserLink(uid, s.UserUID)
 
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.LinkExpiresAt = f.ExpiresAt
+	link.NoDownload = f.NoDownload
+	link.Watermark = f.Watermark
+	link.SetComment(f.CanComment)
+	link.SetReact(f.CanReact)
+	link.SetUpload(f.CanUpload)
+	link.UploadReview = f.UploadReview
+	link.UploadLimit = f.UploadLimit
+	link.SetUploadTypes(f.UploadTypes)
+	link.Comment = txt.Clip(f.Comment, 512)
 
 	if f.Password != "" {
 		if err := link.SetPassword(f.Password); err != nil {
@@ -130,6 +167,8 @@ func CreateLink(c *gin.Context) {
 		return
 	}
 
+	event.AuditInfo([]string{ClientIP(c), "session %s", "created share link for %s"}, s.RefID, clean.Log(link.ShareUID))
+
 	UpdateClientConfig()
 
 	PublishAlbumEvent(EntityUpdated, link.ShareUID, c)
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mail"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SendAlbumLink sends an album share link to the email addresses specified in the request.
+//
+// POST /api/v1/albums/:uid/links/:link/email
+func SendAlbumLink(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/links/:link/email", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.MailEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		link := entity.FindLink(clean.Token(c.Param("link")))
+
+		if link == nil || link.ShareUID != a.AlbumUID || link.Expired() {
+			Abort(c, http.StatusNotFound, i18n.ErrInvalidLink)
+			return
+		}
+
+		var f form.LinkEmail
+
+		if err = c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		recipients := f.Recipients()
+
+		if len(recipients) == 0 || len(recipients) > form.LinkEmailLimit {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidEmail)
+			return
+		}
+
+		data := conf.MailData()
+		data.Title = a.AlbumTitle
+		data.Message = txt.Clip(f.Message, txt.ClipShortText)
+		data.Url = link.Url(conf.SiteUrl())
+
+		if u := s.User(); u != nil && u.IsRegistered() {
+			data.Sender = u.FullName()
+		}
+
+		if expires := link.ExpiresAt(); expires != nil {
+			data.Expires = expires.Format("January 2, 2006")
+		}
+
+		// Send a separate email to each recipient so that addresses are not disclosed.
+		mailer := conf.Mailer()
+
+		for _, to := range recipients {
+			msg, err := mail.Render(mail.TemplateShare, data, to)
+
+			if err == nil {
+				err = mailer.Send(msg)
+			}
+
+			if err != nil {
+				log.Errorf("share: %s", err)
+				Abort(c, http.StatusBadGateway, i18n.ErrEmailNotSent)
+				return
+			}
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "sent share link for %s to %s"}, s.RefID, clean.Log(a.AlbumUID), english.Plural(len(recipients), "recipient", "recipients"))
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgEmailSent))
+	})
+}
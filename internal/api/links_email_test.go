@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendAlbumLink(t *testing.T) {
+	t.Run("MailDisabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SendAlbumLink(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/links/sqn2xpryd1ob7gtf/email", `{"Emails": ["jane@example.com"]}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidLink", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().SmtpHost = "localhost"
+		conf.Options().SmtpFrom = "photoprism@example.com"
+		defer func() { conf.Options().SmtpHost, conf.Options().SmtpFrom = "", "" }()
+
+		SendAlbumLink(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/links/sqn2xpryd1ob7gtf/email", `{"Emails": ["jane@example.com"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidEmail", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().SmtpHost = "localhost"
+		conf.Options().SmtpFrom = "photoprism@example.com"
+		defer func() { conf.Options().SmtpHost, conf.Options().SmtpFrom = "", "" }()
+
+		SendAlbumLink(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/links/sqn2xpryd1ob7gtf/email", `{"Emails": ["invalid"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// linkScope limits the search to the share links created by the current user, unless the role may manage
+// the links of all users. It aborts and returns false if the session isn't associated with a user.
+func linkScope(c *gin.Context, s *entity.Session, f *form.SearchLinks) bool {
+	if acl.Resources.Allow(acl.ResourceShares, s.User().AclRole(), acl.AccessAll) {
+		f.CreatedBy = ""
+		return true
+	} else if s.UserUID == "" {
+		AbortForbidden(c)
+		return false
+	}
+
+	f.CreatedBy = s.UserUID
+
+	return true
+}
+
+// findLink returns the share link specified in the request path, or aborts and returns nil if it
+// does not exist or was created by another user and the role may not manage the links of all users.
+func findLink(c *gin.Context, s *entity.Session) *entity.Link {
+	link := entity.FindLink(clean.UID(c.Param("link")))
+
+	if link == nil {
+		AbortEntityNotFound(c)
+		return nil
+	} else if acl.Resources.Allow(acl.ResourceShares, s.User().AclRole(), acl.AccessAll) {
+		return link
+	} else if s.UserUID == "" || link.CreatedBy != s.UserUID {
+		AbortForbidden(c)
+		return nil
+	}
+
+	return link
+}
+
+// albumVisible checks if the album is owned by or shared with the current user, or if the role has access
+// to all albums in the library.
+func albumVisible(s *entity.Session, a entity.Album) bool {
+	if acl.Resources.AllowAny(acl.ResourceAlbums, s.User().AclRole(), acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
+		return true
+	} else if s.NotRegistered() {
+		return false
+	} else if a.IsOwner(s.UserUID) {
+		return true
+	}
+
+	return list.Contains(s.SharedUIDs(), a.AlbumUID)
+}
+
+// SearchLinks finds share links, so that scripts using an access token with the "shares.manage"
+// scope can, for example, check which albums have been published to a digital photo frame.
+//
+// GET /api/v1/links
+//
+// Parameters:
+//
+//	q: string Matches the link comment, slug, or token
+//	share: string Album UID or slug
+//	expired: bool Only returns expired links
+//	before: date Only returns links created before this date, e.g. 2023-01-31
+//	count: int Maximum number of results, default is 100
+//	offset: int Result offset
+func SearchLinks(router *gin.RouterGroup) {
+	router.GET("/links", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceShares, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.SearchLinks
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if !linkScope(c, s, &f) {
+			return
+		}
+
+		if f.Count <= 0 || f.Count > 1000 {
+			f.Count = 100
+		}
+
+		results, err := search.Links(f)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// GetLink returns a share link as JSON.
+//
+// GET /api/v1/links/:link
+func GetLink(router *gin.RouterGroup) {
+	router.GET("/links/:link", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceShares, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		link := findLink(c, s)
+
+		if link == nil {
+			return
+		}
+
+		c.JSON(http.StatusOK, link)
+	})
+}
+
+// CreateShareLink adds a new share link for the album specified in the request body.
+//
+// POST /api/v1/links
+func CreateShareLink(router *gin.RouterGroup) {
+	router.POST("/links", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceShares, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Link
+
+		if err := c.BindJSON(&f); err != nil {
+			log.Debugf("share: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// The role must allow sharing albums, even if the access token scope only covers share links.
+		if !acl.Resources.Allow(acl.ResourceAlbums, s.User().AclRole(), acl.ActionShare) {
+			AbortForbidden(c)
+			return
+		}
+
+		uid := clean.UID(f.ShareUID)
+
+		if uid == "" {
+			AbortBadRequest(c)
+			return
+		} else if a, err := query.AlbumByUID(uid); err != nil || !a.HasID() {
+			AbortAlbumNotFound(c)
+			return
+		} else if !albumVisible(s, a) {
+			AbortForbidden(c)
+			return
+		}
+
+		createLink(c, s, uid, f)
+	})
+}
+
+// UpdateShareLink updates a share link and returns it as JSON.
+//
+// PUT /api/v1/links/:link
+func UpdateShareLink(router *gin.RouterGroup) {
+	router.PUT("/links/:link", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceShares, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if findLink(c, s) == nil {
+			return
+		}
+
+		UpdateLink(c)
+	})
+}
+
+// DeleteShareLink deletes a share link.
+//
+// DELETE /api/v1/links/:link
+func DeleteShareLink(router *gin.RouterGroup) {
+	router.DELETE("/links/:link", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceShares, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if findLink(c, s) == nil {
+			return
+		}
+
+		DeleteLink(c)
+	})
+}
+
+// RevokeShareLinks deletes all share links that match the search parameters, e.g. to revoke
+// the links to albums that are no longer shown on a digital photo frame. At least one of the
+// parameters supported by SearchLinks, except count and offset, must be specified.
+//
+// DELETE /api/v1/links
+func RevokeShareLinks(router *gin.RouterGroup) {
+	router.DELETE("/links", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceShares, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.SearchLinks
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if !f.Filtered() {
+			AbortBadRequest(c)
+			return
+		} else if !linkScope(c, s, &f) {
+			return
+		}
+
+		// Revoke all matching links.
+		f.Count = 0
+		f.Offset = 0
+
+		links, err := search.Links(f)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		deleted := entity.Links{}
+		shares := make(map[string]bool)
+
+		for i := range links {
+			link := &links[i]
+
+			if err = link.Delete(); err != nil {
+				log.Errorf("share: %s (revoke link %s)", err, link.String())
+				continue
+			}
+
+			removeWatermarks(link)
+			deleted = append(deleted, *link)
+			shares[link.ShareUID] = true
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "revoked %d share links"}, s.RefID, len(deleted))
+
+		if len(deleted) > 0 {
+			UpdateClientConfig()
+		}
+
+		for uid := range shares {
+			PublishAlbumEvent(EntityUpdated, uid, c)
+		}
+
+		AddCountHeader(c, len(deleted))
+
+		c.JSON(http.StatusOK, deleted)
+	})
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSearchLinks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchLinks(router)
+		r := PerformRequest(app, "GET", "/api/v1/links?share=at9lxuqxpogaaba8&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "1jxf3jfn2k")
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchLinks(router)
+		r := PerformRequest(app, "GET", "/api/v1/links?before=yesterday")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetLink(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLink(router)
+		r := PerformRequest(app, "GET", "/api/v1/links/sqn2xpryd1ob7gtf")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "ShareUID").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLink(router)
+		r := PerformRequest(app, "GET", "/api/v1/links/sqn2xpryd1ob0xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCreateShareLink(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateShareLink(router)
+		UpdateShareLink(router)
+		DeleteShareLink(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"ShareUID": "at9lxuqxpogaaba7", "Expires": 86400, "Comment": "photo-frame"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		assert.NotEmpty(t, uid)
+		assert.Equal(t, "photo-frame", gjson.Get(r.Body.String(), "Comment").String())
+		assert.Equal(t, int64(86400), gjson.Get(r.Body.String(), "Expires").Int())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/links/"+uid, `{"Expires": 3600}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(3600), gjson.Get(r.Body.String(), "Expires").Int())
+		assert.Equal(t, "photo-frame", gjson.Get(r.Body.String(), "Comment").String())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/links/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/links/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateShareLink(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"ShareUID": "at9lxuqxpogaxxxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("MissingShareUID", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateShareLink(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"Expires": 0}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRevokeShareLinks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateShareLink(router)
+		RevokeShareLinks(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"ShareUID": "at9lxuqxpogaaba7", "Comment": "revoke-test"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequest(app, "DELETE", "/api/v1/links?q=revoke-test")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "1", r.Header().Get("X-Count"))
+		assert.Contains(t, r.Body.String(), uid)
+	})
+	t.Run("NoFilter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RevokeShareLinks(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/links")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestShareLinks_Owner(t *testing.T) {
+	app, router, conf := NewApiTest()
+	conf.SetAuthMode(config.AuthModePasswd)
+	defer conf.SetAuthMode(config.AuthModePublic)
+
+	SearchLinks(router)
+	GetLink(router)
+	CreateShareLink(router)
+	UpdateShareLink(router)
+	DeleteShareLink(router)
+	RevokeShareLinks(router)
+
+	sessId, cleanup := AuthenticateRoleUser(t, app, router, "linker", "shared share")
+	defer cleanup()
+
+	// Links created by other users cannot be viewed, changed, or deleted.
+	other := entity.LinkFixtures["1jxf3jfn2k"]
+
+	r := AuthenticatedRequest(app, "GET", "/api/v1/links/"+other.LinkUID, sessId)
+	assert.Equal(t, http.StatusForbidden, r.Code)
+
+	r = AuthenticatedRequestWithBody(app, "PUT", "/api/v1/links/"+other.LinkUID, `{"Expires": 3600}`, sessId)
+	assert.Equal(t, http.StatusForbidden, r.Code)
+
+	r = AuthenticatedRequest(app, "DELETE", "/api/v1/links/"+other.LinkUID, sessId)
+	assert.Equal(t, http.StatusForbidden, r.Code)
+
+	r = AuthenticatedRequest(app, "DELETE", "/api/v1/links?share=at9lxuqxpogaaba8", sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "0", r.Header().Get("X-Count"))
+	assert.NotNil(t, entity.FindLink(other.LinkUID))
+
+	// Albums that are neither owned by nor shared with the user cannot be shared.
+	r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/links", `{"ShareUID": "at9lxuqxpogaaba7"}`, sessId)
+	assert.Equal(t, http.StatusForbidden, r.Code)
+
+	// Only links created by the user are found.
+	r = AuthenticatedRequest(app, "GET", "/api/v1/links", sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "0", r.Header().Get("X-Count"))
+
+	// Albums owned by the user can be shared.
+	album := entity.NewUserAlbum("Linker Album", entity.AlbumManual, entity.FindUserByName("linker").UserUID)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer album.DeletePermanently()
+
+	r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/links", `{"ShareUID": "`+album.AlbumUID+`"}`, sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	r = AuthenticatedRequest(app, "GET", "/api/v1/links", sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "1", r.Header().Get("X-Count"))
+
+	r = AuthenticatedRequest(app, "GET", "/api/v1/links/"+uid, sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = AuthenticatedRequest(app, "DELETE", "/api/v1/links/"+uid, sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+}
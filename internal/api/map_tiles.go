@@ -0,0 +1,105 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/tiles"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TileJSON represents map tile source metadata, as specified on https://github.com/mapbox/tilejson-spec.
+type TileJSON struct {
+	TileJSON string   `json:"tilejson"`
+	Tiles    []string `json:"tiles"`
+	tiles.Info
+}
+
+// GetMapTilesInfo returns the metadata of the local map tile source, so that maps can be displayed
+// without calling an external tile provider.
+//
+// GET /api/v1/tiles/:token/tile.json
+func GetMapTilesInfo(router *gin.RouterGroup) {
+	router.GET("/tiles/:token/tile.json", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortForbidden(c)
+			return
+		}
+
+		src := get.MapTiles()
+
+		if src == nil {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		conf := get.Config()
+		token := clean.UrlToken(c.Param("token"))
+		tileUrl := strings.TrimRight(conf.SiteUrl(), "/") + conf.ApiUri() + "/tiles/" + token + "/{z}/{x}/{y}." + src.Info().Format
+
+		c.JSON(http.StatusOK, TileJSON{TileJSON: "2.2.0", Tiles: []string{tileUrl}, Info: src.Info()})
+	})
+}
+
+// GetMapTile returns a map tile from the local MBTiles file or tile cache.
+//
+// GET /api/v1/tiles/:token/:z/:x/:y
+//
+// Parameters:
+//
+//	token: string security token (see config)
+//	z: int zoom level
+//	x: int tile column
+//	y: int tile row, optionally followed by the format extension, e.g. 12.pbf
+func GetMapTile(router *gin.RouterGroup) {
+	router.GET("/tiles/:token/:z/:x/:y", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortForbidden(c)
+			return
+		}
+
+		src := get.MapTiles()
+
+		if src == nil {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		yParam := c.Param("y")
+
+		z, errZ := strconv.Atoi(c.Param("z"))
+		x, errX := strconv.Atoi(c.Param("x"))
+		y, errY := strconv.Atoi(strings.TrimSuffix(yParam, filepath.Ext(yParam)))
+
+		if errZ != nil || errX != nil || errY != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		tile, err := src.Tile(z, x, y)
+
+		if err == tiles.ErrNotFound {
+			// Missing tiles are expected, e.g. above the ocean.
+			AddCacheHeader(c, MapTileMaxAge, thumb.CachePublic)
+			c.Status(http.StatusNoContent)
+			return
+		} else if err != nil {
+			log.Debugf("maps: %s", err)
+			AbortNotFound(c)
+			return
+		}
+
+		if tile.Gzip() {
+			c.Header("Content-Encoding", "gzip")
+		}
+
+		AddCacheHeader(c, MapTileMaxAge, thumb.CachePublic)
+		c.Data(http.StatusOK, tile.ContentType(), tile.Data)
+	})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/get"
+)
+
+func TestGetMapTilesInfo(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		GetMapTilesInfo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/tiles/"+conf.PreviewToken()+"/tile.json")
+
+		// Map tiles are not configured for testing.
+		assert.Nil(t, get.MapTiles())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMapTilesInfo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/tiles/xxx/tile.json")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestGetMapTile(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMapTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/tiles/xxx/1/0/0.pbf")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
@@ -16,6 +17,8 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // Checks if background worker runs less than once per hour.
@@ -68,6 +71,110 @@ func findFileMarker(c *gin.Context) (file *entity.File, marker *entity.Marker, e
 	return file, marker, nil
 }
 
+// CreateMarker adds a face marker for a manually selected area, e.g. if the face was not detected,
+// and assigns it to an existing or new person.
+//
+// POST /api/v1/markers
+func CreateMarker(router *gin.RouterGroup) {
+	router.POST("/markers", func(c *gin.Context) {
+		// Abort if workers runs less than once per hour.
+		if wakeupIntervalTooHigh(c) {
+			return
+		}
+
+		// Abort if another update is running.
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Check feature flags.
+		conf := get.Config()
+		if !conf.Settings().Features.People {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.MarkerCreate
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		file, err := query.FileByUID(clean.UID(f.FileUID))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Find or create subject.
+		var subj *entity.Subject
+
+		if f.SubjUID != "" {
+			if subj = entity.FindSubject(clean.UID(f.SubjUID)); subj == nil {
+				Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+				return
+			}
+		} else if name := clean.Name(f.Name); name == "" {
+			AbortBadRequest(c)
+			return
+		} else if subj = entity.FirstOrCreateSubject(entity.NewSubject(name, entity.SubjPerson, entity.SrcManual)); subj == nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		area := crop.NewArea("face", f.X, f.Y, f.W, f.H)
+
+		marker, err := get.Faces().AddMarker(get.FaceNet(), file, area, subj)
+
+		if err != nil {
+			log.Errorf("faces: %s (create marker)", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		if res, err := get.Faces().Optimize(); err != nil {
+			log.Errorf("faces: %s (optimize)", err)
+		} else if res.Merged > 0 {
+			log.Infof("faces: merged %s", english.Plural(res.Merged, "cluster", "clusters"))
+		}
+
+		if err := query.UpdateSubjectCovers(); err != nil {
+			log.Errorf("faces: %s (update covers)", err)
+		}
+
+		if err := entity.UpdateSubjectCounts(); err != nil {
+			log.Errorf("faces: %s (update counts)", err)
+		}
+
+		// Update photo metadata.
+		if !file.FilePrimary {
+			log.Infof("faces: skipped updating photo for non-primary file")
+		} else if p, err := query.PhotoByUID(file.PhotoUID); err != nil {
+			log.Errorf("faces: %s (find photo))", err)
+		} else if err := p.UpdateAndSaveTitle(); err != nil {
+			log.Errorf("faces: %s (update photo title)", err)
+		} else {
+			// Notify clients.
+			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, marker)
+	})
+}
+
 // UpdateMarker updates an existing file marker e.g. representing a face.
 //
 // PUT /api/v1/markers/:marker_uid
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetMarkersReview returns automatically matched markers with a low confidence so that they can be reviewed.
+//
+// GET /api/v1/markers/review
+//
+// Parameters:
+//
+//	count: int Maximum number of results, default is 100
+//	offset: int Result offset
+//	dist: float Minimum distance to the matching face, default is face.MatchDist
+func GetMarkersReview(router *gin.RouterGroup) {
+	router.GET("/markers/review", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+		dist := face.MatchDist
+
+		if limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		if d := txt.Float(c.Query("dist")); d > 0 {
+			dist = d
+		}
+
+		markers, err := query.ReviewMarkers(limit, offset, dist)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(markers))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, markers)
+	})
+}
+
+// UpdateMarkersReview confirms or rejects multiple automatically matched markers at once.
+//
+// POST /api/v1/markers/review
+func UpdateMarkersReview(router *gin.RouterGroup) {
+	router.POST("/markers/review", func(c *gin.Context) {
+		// Abort if workers runs less than once per hour.
+		if wakeupIntervalTooHigh(c) {
+			return
+		}
+
+		// Abort if another update is running.
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		s := Auth(c, acl.ResourcePeople, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.MarkersReview
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Confirm)+len(f.Reject) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		confirmed, rejected := 0, 0
+
+		for _, uid := range f.Confirm {
+			if marker, err := query.MarkerByUID(clean.UID(uid)); err != nil {
+				log.Debugf("faces: %s (find marker to confirm)", err)
+			} else if err = marker.ConfirmSubject(); err != nil {
+				log.Errorf("faces: %s (confirm marker subject)", err)
+			} else {
+				confirmed++
+			}
+		}
+
+		// Rejected matches are reported as collisions, so the face radius is reduced accordingly.
+		for _, uid := range f.Reject {
+			if marker, err := query.MarkerByUID(clean.UID(uid)); err != nil {
+				log.Debugf("faces: %s (find marker to reject)", err)
+			} else if err = marker.ClearSubject(entity.SrcManual); err != nil {
+				log.Errorf("faces: %s (clear marker subject)", err)
+			} else {
+				rejected++
+			}
+		}
+
+		if err := query.UpdateSubjectCovers(); err != nil {
+			log.Errorf("faces: %s (update covers)", err)
+		}
+
+		if err := entity.UpdateSubjectCounts(); err != nil {
+			log.Errorf("faces: %s (update counts)", err)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, gin.H{"confirmed": confirmed, "rejected": rejected})
+	})
+}
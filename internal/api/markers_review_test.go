@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarkersReview(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMarkersReview(router)
+		r := PerformRequest(app, "GET", "/api/v1/markers/review?count=10&dist=0.9")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestUpdateMarkersReview(t *testing.T) {
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateMarkersReview(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers/review", `{"Confirm": [], "Reject": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
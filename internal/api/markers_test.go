@@ -12,6 +12,27 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 )
 
+func TestCreateMarker(t *testing.T) {
+	t.Run("FileNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateMarker(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers", `{"FileUID": "ft2es39w45bnlxxx", "X": 0.1, "Y": 0.1, "W": 0.2, "H": 0.2, "Name": "Jane Doe"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("SubjectNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateMarker(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers", `{"FileUID": "ft2es39w45bnlqdw", "X": 0.1, "Y": 0.1, "W": 0.2, "H": 0.2, "SubjUID": "jqy1y111h1njxxxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NameMissing", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateMarker(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers", `{"FileUID": "ft2es39w45bnlqdw", "X": 0.1, "Y": 0.1, "W": 0.2, "H": 0.2}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestUpdateMarker(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		app, router, _ := NewApiTest()
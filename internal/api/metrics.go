@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+)
+
+// GetMetrics returns metrics in the Prometheus text exposition format, see
+// https://prometheus.io/docs/instrumenting/exposition_formats/.
+//
+// GET /api/v1/metrics
+func GetMetrics(router *gin.RouterGroup) {
+	router.GET("/metrics", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceConfig, acl.AccessAll)
+
+		// Abort if permission was not granted.
+		if s.Invalid() {
+			AbortForbidden(c)
+			return
+		}
+
+		conf := get.Config()
+		disks := conf.DiskUsage()
+
+		var b bytes.Buffer
+
+		gauge := func(name, help string) {
+			fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		}
+
+		gauge("photoprism_disk_free_bytes", "Free disk space of the storage path in bytes.")
+		for _, d := range disks {
+			fmt.Fprintf(&b, "photoprism_disk_free_bytes{path=%q} %d\n", d.Name, d.Free)
+		}
+
+		gauge("photoprism_disk_used_bytes", "Used disk space of the storage path in bytes.")
+		for _, d := range disks {
+			fmt.Fprintf(&b, "photoprism_disk_used_bytes{path=%q} %d\n", d.Name, d.Used)
+		}
+
+		gauge("photoprism_disk_total_bytes", "Total disk space of the storage path in bytes.")
+		for _, d := range disks {
+			fmt.Fprintf(&b, "photoprism_disk_total_bytes{path=%q} %d\n", d.Name, d.Total)
+		}
+
+		gauge("photoprism_disk_min_free_bytes", "Minimum free disk space in bytes, 0 if disabled.")
+		fmt.Fprintf(&b, "photoprism_disk_min_free_bytes %d\n", conf.MinFreeBytes())
+
+		gauge("photoprism_disk_space_low", "Indicates if indexing, imports, transcoding, and thumbnail generation have been paused.")
+		if conf.DiskSpaceLow() {
+			b.WriteString("photoprism_disk_space_low 1\n")
+		} else {
+			b.WriteString("photoprism_disk_space_low 0\n")
+		}
+
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", b.Bytes())
+	})
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetrics(t *testing.T) {
+	t.Run("Disk", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMetrics(router)
+		r := PerformRequest(app, "GET", "/api/v1/metrics")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "# TYPE photoprism_disk_free_bytes gauge")
+		assert.Contains(t, r.Body.String(), `photoprism_disk_free_bytes{path="originals"}`)
+		assert.Contains(t, r.Body.String(), "photoprism_disk_space_low ")
+	})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/migrate"
+)
+
+// MigrationStatus represents the status of a database schema migration.
+type MigrationStatus struct {
+	migrate.Migration
+	Status string `json:"Status"`
+}
+
+// GetMigrations returns the status of database schema migrations,
+// including the number of statements executed so far.
+//
+// GET /api/v1/migrations
+func GetMigrations(router *gin.RouterGroup) {
+	router.GET("/migrations", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceConfig, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		status, err := migrate.Status(get.Config().Db(), nil)
+
+		if err != nil {
+			log.Errorf("migrations: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		result := make([]MigrationStatus, len(status))
+
+		for i := range status {
+			result[i] = MigrationStatus{Migration: status[i], Status: status[i].Info()}
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
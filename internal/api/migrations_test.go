@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetMigrations(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetMigrations(router)
+	r := PerformRequest(app, "GET", "/api/v1/migrations")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.True(t, gjson.Get(r.Body.String(), "#").Int() > 0)
+	assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.Status").String())
+}
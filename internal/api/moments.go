@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// UpdateMoments creates albums of special moments, trips, and places based on the current settings
+// without the need to index the library again.
+//
+// POST /api/v1/moments
+//
+// Query:
+//
+//	rebuild: removes generated albums that no longer match the settings if true (optional)
+func UpdateMoments(router *gin.RouterGroup) {
+	router.POST("/moments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceMoments, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		w := get.Moments()
+
+		var err error
+
+		if txt.Bool(c.Query("rebuild")) {
+			err = w.Rebuild()
+		} else {
+			err = w.Start()
+		}
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "moments", "updated"}, s.RefID)
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateMoments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		UpdateMoments(router)
+
+		r := PerformRequest(app, "POST", "/api/v1/moments")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
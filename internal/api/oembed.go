@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/feed"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// parseShareUrl returns the token and shared album UID or slug of a share link URL,
+// e.g. "https://photos.example.com/s/1jxf3jfn2k/holiday".
+func parseShareUrl(s string) (token, shared string) {
+	u, err := url.Parse(strings.TrimSpace(s))
+
+	if err != nil {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "s" {
+			return parts[i+1], parts[i+2]
+		}
+	}
+
+	return "", ""
+}
+
+// GetOEmbed returns an oEmbed response for a share link URL, so that shared albums
+// can be embedded in blogs and other websites, see https://oembed.com/.
+//
+// GET /api/v1/oembed?url=...
+//
+// Parameters:
+//
+//	url: string share link URL
+//	maxwidth: int maximum embed width in pixels (optional)
+//	maxheight: int maximum embed height in pixels (optional)
+//	format: string response format (only json is supported)
+func GetOEmbed(router *gin.RouterGroup) {
+	router.GET("/oembed", func(c *gin.Context) {
+		if format := c.Query("format"); format != "" && format != "json" {
+			c.AbortWithStatus(http.StatusNotImplemented)
+			return
+		}
+
+		token, shared := parseShareUrl(c.Query("url"))
+
+		if token == "" || shared == "" {
+			AbortNotFound(c)
+			return
+		}
+
+		link, album, ok := embedLink(token, shared)
+
+		if !ok {
+			AbortNotFound(c)
+			return
+		}
+
+		conf := get.Config()
+		shareUrl := link.Url(conf.SiteUrl())
+
+		maxWidth, _ := strconv.Atoi(c.Query("maxwidth"))
+		maxHeight, _ := strconv.Atoi(c.Query("maxheight"))
+		width, height := feed.EmbedSize(maxWidth, maxHeight)
+
+		result := feed.NewOEmbed(album.AlbumTitle, shareUrl+"/embed", width, height)
+		result.ProviderName = conf.SiteTitle()
+		result.ProviderUrl = conf.SiteUrl()
+		result.AuthorName = conf.SiteAuthor()
+		result.CacheAge = int(CoverMaxAge)
+
+		// Use the most recently added public picture as thumbnail, as the album cover may be private.
+		if photos, err := embedPhotos(link, "", 1); err == nil && len(photos) > 0 {
+			size := thumb.Sizes[thumb.Tile500]
+			result.ThumbnailUrl = embedThumbUrl(shareUrl, photos[0].FileHash, size.Name)
+			result.ThumbnailWidth = size.Width
+			result.ThumbnailHeight = size.Height
+		}
+
+		AddCoverCacheHeader(c)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
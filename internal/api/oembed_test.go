@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseShareUrl(t *testing.T) {
+	token, shared := parseShareUrl("https://photos.example.com/s/1jxf3jfn2k/holiday-2030")
+	assert.Equal(t, "1jxf3jfn2k", token)
+	assert.Equal(t, "holiday-2030", shared)
+
+	token, shared = parseShareUrl("https://photos.example.com/photos/s/1jxf3jfn2k/holiday-2030/")
+	assert.Equal(t, "1jxf3jfn2k", token)
+	assert.Equal(t, "holiday-2030", shared)
+
+	token, shared = parseShareUrl("https://photos.example.com/s/1jxf3jfn2k")
+	assert.Equal(t, "", token)
+	assert.Equal(t, "", shared)
+}
+
+func TestGetOEmbed(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetOEmbed(router)
+		r := PerformRequest(app, "GET", "/api/v1/oembed?maxwidth=400&url="+url.QueryEscape("https://photos.example.com/s/1jxf3jfn2k/holiday-2030"))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "rich", gjson.Get(r.Body.String(), "type").String())
+		assert.Equal(t, int64(400), gjson.Get(r.Body.String(), "width").Int())
+		assert.Contains(t, gjson.Get(r.Body.String(), "html").String(), "/s/1jxf3jfn2k/holiday-2030/embed")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetOEmbed(router)
+		r := PerformRequest(app, "GET", "/api/v1/oembed?url="+url.QueryEscape("https://photos.example.com/s/xxx/holiday-2030"))
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FormatNotSupported", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetOEmbed(router)
+		r := PerformRequest(app, "GET", "/api/v1/oembed?format=xml&url="+url.QueryEscape("https://photos.example.com/s/1jxf3jfn2k/holiday-2030"))
+		assert.Equal(t, http.StatusNotImplemented, r.Code)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -54,8 +55,19 @@ func GetPhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if search.PhotoLocked(p.PhotoUID, s) {
+			// Photos in locked albums are not visible until the album is unlocked.
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Hide the exact position if the picture was taken in a privacy zone.
+		if s.PrivacyFenced() {
+			p.ApplyPrivacyFence()
 		}
 
+		AddEntityTagHeader(c, p.UpdatedAt)
+
 		c.IndentedJSON(http.StatusOK, p)
 	})
 }
@@ -79,6 +91,21 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		// Don't overwrite changes that were made in the meantime.
+		if PreconditionFailed(c, m.UpdatedAt) {
+			if p, err := query.PhotoPreloadByUID(uid); err != nil {
+				AbortEntityNotFound(c)
+			} else {
+				if s.PrivacyFenced() {
+					p.ApplyPrivacyFence()
+				}
+
+				AbortConflict(c, p.UpdatedAt, p)
+			}
+
+			return
+		}
+
 		// 1) Init form with model values
 		f, err := form.NewPhoto(m)
 
@@ -87,6 +114,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		before := f
+
 		// 2) Update form with values from request
 		if err := c.BindJSON(&f); err != nil {
 			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
@@ -101,6 +130,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			FlushCoverCache()
 		}
 
+		recordChange(s, entity.ChangePhoto, uid, entity.ChangeActionUpdate, before, f)
+
 		PublishPhotoEvent(EntityUpdated, uid, c)
 
 		event.SuccessMsg(i18n.MsgChangesSaved)
@@ -116,6 +147,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 
 		UpdateClientConfig()
 
+		AddEntityTagHeader(c, p.UpdatedAt)
+
 		c.JSON(http.StatusOK, p)
 	})
 }
@@ -140,6 +173,7 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 		}
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fetchFile(fileName)
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("photo: file %s is missing", clean.Log(f.FileName))
@@ -151,7 +185,25 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
+		// Remove GPS metadata if the picture was taken in a privacy zone.
+		fileName, cleanup, err := privacyFileName(fileName, f.Photo)
+
+		if err != nil {
+			log.Errorf("photo: %s", err)
+			c.Data(http.StatusForbidden, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		defer cleanup()
+
+		// Draw a watermark if the picture was shared with a link that has watermarks enabled.
+		if fileName, err = watermarkFileName(watermarkLink(clean.UrlToken(c.Query("t"))), fileName, f.FileHash, f.FileOrientation); err != nil {
+			log.Errorf("photo: %s", err)
+			c.Data(http.StatusForbidden, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		sendFile(c, fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
 
@@ -1,8 +1,6 @@
 package api
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 
@@ -62,6 +60,15 @@ func SearchPhotos(router *gin.RouterGroup) {
 			return
 		}
 
+		// Return cached results, if any.
+		key := searchCacheKey("photos", f, s)
+
+		if cached, ok := cachedSearch(key); ok {
+			sendSearch(c, cached, f, s)
+			prefetchThumbs(f, s, cached.Count)
+			return
+		}
+
 		// Find matching pictures.
 		result, count, err := search.UserPhotos(f, s)
 
@@ -72,14 +79,13 @@ func SearchPhotos(router *gin.RouterGroup) {
 			return
 		}
 
-		// Add response headers.
-		AddCountHeader(c, count)
-		AddLimitHeader(c, f.Count)
-		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c, s)
-
 		// Return as JSON.
-		c.JSON(http.StatusOK, result)
+		if entry, err := cacheSearch(key, result, count); err != nil {
+			AbortUnexpected(c)
+		} else {
+			sendSearch(c, entry, f, s)
+			prefetchThumbs(f, s, count)
+		}
 	}
 
 	// viewHandler returns a photo viewer formatted result.
@@ -91,6 +97,15 @@ func SearchPhotos(router *gin.RouterGroup) {
 			return
 		}
 
+		// Return cached results, if any.
+		key := searchCacheKey("view", f, s)
+
+		if cached, ok := cachedSearch(key); ok {
+			sendSearch(c, cached, f, s)
+			prefetchThumbs(f, s, cached.Count)
+			return
+		}
+
 		conf := get.Config()
 
 		result, count, err := search.UserPhotosViewerResults(f, s, conf.ContentUri(), conf.ApiUri(), s.PreviewToken, s.DownloadToken)
@@ -101,14 +116,13 @@ func SearchPhotos(router *gin.RouterGroup) {
 			return
 		}
 
-		// Add response headers.
-		AddCountHeader(c, count)
-		AddLimitHeader(c, f.Count)
-		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c, s)
-
 		// Return as JSON.
-		c.JSON(http.StatusOK, result)
+		if entry, err := cacheSearch(key, result, count); err != nil {
+			AbortUnexpected(c)
+		} else {
+			sendSearch(c, entry, f, s)
+			prefetchThumbs(f, s, count)
+		}
 	}
 
 	// Register route handlers.
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// SearchCacheTTL specifies how long search results are cached if no related event is published,
+// so that changes made by background workers are eventually reflected.
+var SearchCacheTTL = 10 * time.Minute
+
+// SearchCacheMaxResults specifies up to which offset results are cached, so that only the
+// first pages of frequently used views such as recent pictures, favorites, and albums are cached.
+var SearchCacheMaxResults = 1000
+
+// SearchCacheMaxItems specifies the maximum number of cached search responses.
+var SearchCacheMaxItems = 1000
+
+// SearchCacheTopics are the event topics that invalidate cached search results.
+var SearchCacheTopics = []string{
+	"photos.*",
+	"albums.*",
+	"labels.*",
+	"subjects.*",
+	"people.*",
+	"user.*.*.*",
+	"count.*",
+	"config.updated",
+	"index.completed",
+	"import.completed",
+	"upload.completed",
+}
+
+var searchCache = gc.New(SearchCacheTTL, 5*time.Minute)
+var searchCacheOnce sync.Once
+
+// searchCacheGen is part of each cache key, so that results of searches that were
+// running while the cache was flushed are not used.
+var searchCacheGen uint64
+
+// searchCacheEntry represents a serialized search response.
+type searchCacheEntry struct {
+	Data  []byte
+	Count int
+	ETag  string
+}
+
+// FlushSearchCache removes all cached search results.
+func FlushSearchCache() {
+	atomic.AddUint64(&searchCacheGen, 1)
+	searchCache.Flush()
+}
+
+// watchSearchCache flushes cached search results when related entities change. Since events
+// are shared between instances when using Redis, this also works across multiple replicas.
+func watchSearchCache() {
+	s := event.Subscribe(SearchCacheTopics...)
+
+	go func() {
+		for range s.Receiver {
+			FlushSearchCache()
+		}
+	}()
+}
+
+// searchCacheKey returns the cache key for a photo search, or an empty string if the results should not be cached.
+func searchCacheKey(view string, f form.SearchPhotos, s *entity.Session) string {
+	if f.Query != "" || f.Count <= 0 || f.Offset+f.Count > SearchCacheMaxResults {
+		return ""
+	}
+
+	key := search.PhotosCacheKey(f, s)
+
+	if key == "" {
+		return ""
+	}
+
+	// Viewer results contain the session tokens.
+	return strings.Join([]string{view, strconv.FormatUint(atomic.LoadUint64(&searchCacheGen), 10), key, s.PreviewToken, s.DownloadToken}, ":")
+}
+
+// cachedSearch returns the cached response for the key, if any.
+func cachedSearch(key string) (entry searchCacheEntry, ok bool) {
+	searchCacheOnce.Do(watchSearchCache)
+
+	if key == "" {
+		return entry, false
+	} else if cacheData, found := searchCache.Get(key); found {
+		return cacheData.(searchCacheEntry), true
+	}
+
+	return entry, false
+}
+
+// cacheSearch serializes the search results and adds them to the cache if the key is not empty.
+func cacheSearch(key string, result interface{}, count int) (entry searchCacheEntry, err error) {
+	if entry.Data, err = json.Marshal(result); err != nil {
+		return entry, err
+	}
+
+	hash := sha1.Sum(entry.Data)
+
+	entry.Count = count
+	entry.ETag = `"` + hex.EncodeToString(hash[:]) + `"`
+
+	if key != "" && searchCache.ItemCount() < SearchCacheMaxItems {
+		searchCache.SetDefault(key, entry)
+	}
+
+	return entry, nil
+}
+
+// sendSearch sends a serialized search response, or status 304 if the client already has it.
+func sendSearch(c *gin.Context, entry searchCacheEntry, f form.SearchPhotos, s *entity.Session) {
+	// Add response headers.
+	AddCountHeader(c, entry.Count)
+	AddLimitHeader(c, f.Count)
+	AddOffsetHeader(c, f.Offset)
+	AddTokenHeaders(c, s)
+
+	c.Header("ETag", entry.ETag)
+
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == entry.ETag {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", entry.Data)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestSearchCache(t *testing.T) {
+	app, router, _ := NewApiTest()
+	SearchPhotos(router)
+
+	FlushSearchCache()
+
+	t.Run("Cached", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&order=newest")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, 1, searchCache.ItemCount())
+
+		etag := r.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		cached := PerformRequest(app, "GET", "/api/v1/photos?count=10&order=newest")
+
+		assert.Equal(t, http.StatusOK, cached.Code)
+		assert.Equal(t, etag, cached.Header().Get("ETag"))
+		assert.Equal(t, r.Header().Get("X-Count"), cached.Header().Get("X-Count"))
+		assert.Equal(t, r.Body.String(), cached.Body.String())
+		assert.Equal(t, 1, searchCache.ItemCount())
+
+		// Check conditional request.
+		req, _ := http.NewRequest("GET", "/api/v1/photos?count=10&order=newest", nil)
+		req.Header.Set("If-None-Match", etag)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+	t.Run("ViewFormat", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/view?count=10&order=newest")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, 2, searchCache.ItemCount())
+	})
+	t.Run("NotCached", func(t *testing.T) {
+		PerformRequest(app, "GET", "/api/v1/photos?count=10&q=foo")
+		PerformRequest(app, "GET", "/api/v1/photos?count=10&offset=5000")
+
+		assert.Equal(t, 2, searchCache.ItemCount())
+	})
+	t.Run("Invalidated", func(t *testing.T) {
+		event.Publish("photos.updated", event.Data{})
+
+		assert.Eventually(t, func() bool { return searchCache.ItemCount() == 0 }, time.Second, 10*time.Millisecond)
+	})
+}
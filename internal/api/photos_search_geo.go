@@ -19,6 +19,7 @@ import (
 // See form.SearchPhotosGeo for supported search params and data types.
 //
 // GET /api/v1/geo
+// GET /api/v1/geo/clusters?bbox=west,south,east,north&zoom=level
 func SearchGeo(router *gin.RouterGroup) {
 	handler := func(c *gin.Context) {
 		s := AuthAny(c, acl.ResourcePlaces, acl.Permissions{acl.ActionSearch, acl.ActionView, acl.AccessShared})
@@ -53,6 +54,30 @@ func SearchGeo(router *gin.RouterGroup) {
 			f.Quality = 3
 		}
 
+		// Aggregate matching pictures into clusters?
+		if clean.Token(c.Param("format")) == "clusters" {
+			clusters, err := search.UserPhotosGeoClusters(f, s)
+
+			if err != nil {
+				event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePlaces), "clusters", "%s"}, s.RefID, err)
+				AbortBadRequest(c)
+				return
+			}
+
+			resp, err := clusters.GeoJSON()
+
+			if err != nil {
+				c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
+				return
+			}
+
+			AddCountHeader(c, clusters.Photos())
+			AddTokenHeaders(c, s)
+
+			c.Data(http.StatusOK, "application/json", resp)
+			return
+		}
+
 		// Find matching pictures.
 		photos, err := search.UserPhotosGeo(f, s)
 
@@ -26,4 +26,23 @@ func TestSearchGeo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 		t.Logf("response: %s", r.Body.String())
 	})
+	t.Run("Clusters", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchGeo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/clusters?zoom=3&bbox=-180,-90,180,90")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "FeatureCollection")
+	})
+	t.Run("ClustersInvalidBBox", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchGeo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/clusters?bbox=13.1,52.3")
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
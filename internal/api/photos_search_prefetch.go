@@ -0,0 +1,66 @@
+package api
+
+import (
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// PrefetchMaxResults specifies the maximum number of results for which thumbnails are created in advance per request.
+var PrefetchMaxResults = 1000
+
+// prefetchCache remembers which result pages have recently been prefetched, so that repeated
+// requests, e.g. when a user scrolls back and forth, do not run the same search queries again.
+var prefetchCache = gc.New(15*time.Minute, 5*time.Minute)
+
+// prefetchThumbs creates missing thumbnails for the next result pages in the background,
+// so that they can be displayed without delay when the user continues scrolling.
+func prefetchThumbs(f form.SearchPhotos, s *entity.Session, count int) {
+	// Skip if this is the last page or prefetching is disabled.
+	if f.Count <= 0 || count < f.Count {
+		return
+	}
+
+	pages := get.Config().ThumbPrefetch()
+	w := get.Prefetch()
+
+	if pages <= 0 || !w.Enabled() {
+		return
+	}
+
+	// Search the next pages.
+	f.Offset += f.Count
+	f.Count *= pages
+
+	if f.Count > PrefetchMaxResults {
+		f.Count = PrefetchMaxResults
+	}
+
+	key := search.PhotosCacheKey(f, s)
+
+	if key == "" {
+		return
+	} else if _, found := prefetchCache.Get(key); found {
+		return
+	}
+
+	prefetchCache.SetDefault(key, true)
+
+	go func() {
+		results, _, err := search.UserPhotos(f, s)
+
+		if err != nil {
+			log.Debugf("thumbs: %s (prefetch)", err)
+			return
+		}
+
+		for _, r := range results {
+			w.Add(r.FileRoot, r.FileName, r.FileHash)
+		}
+	}()
+}
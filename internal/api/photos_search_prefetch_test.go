@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/get"
+)
+
+func TestPrefetchThumbs(t *testing.T) {
+	app, router, conf := NewApiTest()
+	SearchPhotos(router)
+
+	opt := *conf.Options()
+
+	defer func() {
+		conf.Options().ThumbPrefetch = opt.ThumbPrefetch
+		conf.Options().ThumbPrefetchWorkers = opt.ThumbPrefetchWorkers
+	}()
+
+	prefetchCache.Flush()
+
+	t.Run("Disabled", func(t *testing.T) {
+		conf.Options().ThumbPrefetch = 0
+
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=2&offset=0&order=oldest")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, get.Prefetch().Enabled())
+		assert.Equal(t, 0, prefetchCache.ItemCount())
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		conf.Options().ThumbPrefetch = 2
+		conf.Options().ThumbPrefetchWorkers = 1
+
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=2&offset=0&order=oldest")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, get.Prefetch().Enabled())
+		assert.Equal(t, 1, prefetchCache.ItemCount())
+
+		// Pages are only prefetched once.
+		PerformRequest(app, "GET", "/api/v1/photos?count=2&offset=0&order=oldest")
+		assert.Equal(t, 1, prefetchCache.ItemCount())
+	})
+	t.Run("LastPage", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=2&offset=100000&order=oldest")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, 1, prefetchCache.ItemCount())
+	})
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPlaceAliases returns all custom place names.
+//
+// GET /api/v1/places/aliases
+func GetPlaceAliases(router *gin.RouterGroup) {
+	router.GET("/places/aliases", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		result, err := entity.FindPlaceAliases()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// CreatePlaceAlias adds a custom name for a coordinate area, e.g. "Grandma's House", which is displayed
+// and searched instead of the reverse-geocoded place label.
+//
+// POST /api/v1/places/aliases
+func CreatePlaceAlias(router *gin.RouterGroup) {
+	router.POST("/places/aliases", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.PlaceAlias
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewPlaceAlias(f.AliasName, f.AliasLat, f.AliasLng, f.AliasRadius)
+		m.CreatedBy = s.UserUID
+
+		if err := m.Create(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "place alias %s", "created"}, s.RefID, clean.Log(m.AliasName))
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UpdatePlaceAlias changes the name, position, or radius of a custom place.
+//
+// PUT /api/v1/places/aliases/:id
+func UpdatePlaceAlias(router *gin.RouterGroup) {
+	router.PUT("/places/aliases/:id", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindPlaceAlias(txt.UInt(clean.Token(c.Param("id"))))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Initialize form.
+		f, err := form.NewPlaceAlias(*m)
+
+		if err != nil {
+			log.Errorf("places: %s (new form)", err)
+			AbortSaveFailed(c)
+			return
+		} else if err = c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err = m.SaveForm(f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "place alias %s", "updated"}, s.RefID, clean.Log(m.AliasName))
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeletePlaceAlias removes a custom place, so that the reverse-geocoded place label is used again.
+//
+// DELETE /api/v1/places/aliases/:id
+func DeletePlaceAlias(router *gin.RouterGroup) {
+	router.DELETE("/places/aliases/:id", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindPlaceAlias(txt.UInt(clean.Token(c.Param("id"))))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("places: %s (delete alias)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "place alias %s", "deleted"}, s.RefID, clean.Log(m.AliasName))
+		event.SuccessMsg(i18n.MsgPermanentlyDeleted)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
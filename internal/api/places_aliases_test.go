@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestPlaceAliases(t *testing.T) {
+	app, router, _ := NewApiTest()
+
+	GetPlaceAliases(router)
+	CreatePlaceAlias(router)
+	UpdatePlaceAlias(router)
+	DeletePlaceAlias(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/places/aliases", `{"Name": "Grandma's House", "Lat": 52.5208, "Lng": 13.4094, "Radius": 150}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	id := gjson.Get(r.Body.String(), "ID").Int()
+	assert.NotEmpty(t, id)
+	assert.Equal(t, "grandmas-house", gjson.Get(r.Body.String(), "Slug").String())
+
+	r = PerformRequest(app, "GET", "/api/v1/places/aliases")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Contains(t, r.Body.String(), "Grandma's House")
+
+	r = PerformRequestWithBody(app, "PUT", fmt.Sprintf("/api/v1/places/aliases/%d", id), `{"Name": "Home"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "Home", gjson.Get(r.Body.String(), "Name").String())
+	assert.Equal(t, int64(150), gjson.Get(r.Body.String(), "Radius").Int())
+
+	r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/places/aliases/%d", id))
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/places/aliases/%d", id))
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
+
+func TestCreatePlaceAlias(t *testing.T) {
+	t.Run("InvalidPosition", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreatePlaceAlias(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/places/aliases", `{"Name": "Nowhere"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
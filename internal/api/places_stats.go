@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// GetTravelCountries returns the number of pictures, days visited, and first and last visit per country,
+// so that a "countries visited" page can be displayed. See form.SearchPhotosGeo for supported filters.
+//
+// GET /api/v1/places/countries
+func GetTravelCountries(router *gin.RouterGroup) {
+	router.GET("/places/countries", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		f, ok := placesStatsForm(c, s)
+
+		if !ok {
+			return
+		}
+
+		result, err := search.TravelCountries(f, s)
+
+		if err != nil {
+			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePlaces), "countries", "%s"}, s.RefID, err)
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetTravelRegions returns the number of pictures, days visited, and first and last visit per state or region,
+// e.g. of a single country if specified with the country param.
+//
+// GET /api/v1/places/regions
+func GetTravelRegions(router *gin.RouterGroup) {
+	router.GET("/places/regions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		f, ok := placesStatsForm(c, s)
+
+		if !ok {
+			return
+		}
+
+		result, err := search.TravelRegions(f, s)
+
+		if err != nil {
+			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePlaces), "regions", "%s"}, s.RefID, err)
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetHeatmap returns the density of pictures as a grid of [lat, lng, count] points for rendering a heatmap.
+// The grid size depends on the zoom level, and the bbox param limits the points to the visible map area.
+//
+// GET /api/v1/places/heatmap?bbox=west,south,east,north&zoom=level
+func GetHeatmap(router *gin.RouterGroup) {
+	router.GET("/places/heatmap", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		f, ok := placesStatsForm(c, s)
+
+		if !ok {
+			return
+		}
+
+		result, err := search.UserPhotosHeatmap(f, s)
+
+		if err != nil {
+			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePlaces), "heatmap", "%s"}, s.RefID, err)
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, result.Photos)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// placesStatsForm returns the search form for place statistics, or aborts the request if it is invalid.
+func placesStatsForm(c *gin.Context, s *entity.Session) (f form.SearchPhotosGeo, ok bool) {
+	if err := c.MustBindWith(&f, binding.Form); err != nil {
+		event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePlaces), "form invalid", "%s"}, s.RefID, err)
+		AbortBadRequest(c)
+		return f, false
+	}
+
+	settings := get.Config().Settings()
+
+	// Ignore private flag if feature is disabled.
+	if !settings.Features.Private {
+		f.Public = false
+	}
+
+	// Exclude pictures in review if the user cannot manage them.
+	if f.Scope == "" &&
+		settings.Features.Review &&
+		acl.Resources.Deny(acl.ResourcePhotos, s.User().AclRole(), acl.ActionManage) {
+		f.Quality = 3
+	}
+
+	return f, true
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetTravelCountries(t *testing.T) {
+	app, router, _ := NewApiTest()
+
+	GetTravelCountries(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/places/countries")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.True(t, gjson.Get(r.Body.String(), "#").Int() > 0)
+	assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.Name").String())
+}
+
+func TestGetTravelRegions(t *testing.T) {
+	app, router, _ := NewApiTest()
+
+	GetTravelRegions(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/places/regions?country=de")
+	assert.Equal(t, http.StatusOK, r.Code)
+}
+
+func TestGetHeatmap(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetHeatmap(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/places/heatmap?zoom=2")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Max").Int() > 0)
+	})
+	t.Run("InvalidBBox", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetHeatmap(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/places/heatmap?bbox=1,2")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// StartPurge updates missing files, photo counts, and album covers, e.g. when started remotely from the CLI.
+//
+// POST /api/v1/purge
+func StartPurge(router *gin.RouterGroup) {
+	router.POST("/purge", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if !get.Config().Settings().Features.Library {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.PurgeOptions
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		opt := photoprism.PurgeOptions{
+			Path:  filepath.Clean(f.Path),
+			Dry:   f.Dry,
+			Hard:  f.Hard,
+			Force: true,
+		}
+
+		if opt.Path == "." || opt.Path == "/" {
+			opt.Path = ""
+		}
+
+		files, photos, _, err := get.Purge().Start(opt)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		var msg string
+
+		if f.Dry {
+			msg = fmt.Sprintf("Dry run, would purge %s and %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"))
+		} else {
+			msg = i18n.Msg(i18n.MsgRemovedFilesAndPhotos, len(files), len(photos))
+			RemoveFromFolderCache(entity.RootOriginals)
+			UpdateClientConfig()
+		}
+
+		log.Infof("purge: completed in %s", time.Since(start))
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetSchedulerTasks returns the scheduled background tasks with their next run times.
+//
+// GET /api/v1/scheduler
+func GetSchedulerTasks(router *gin.RouterGroup) {
+	router.GET("/scheduler", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceConfig, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		tasks := []workers.TaskInfo{}
+
+		if scheduler := workers.GetScheduler(); scheduler != nil {
+			tasks = scheduler.Tasks()
+		}
+
+		AddCountHeader(c, len(tasks))
+
+		c.JSON(http.StatusOK, tasks)
+	})
+}
+
+// RunSchedulerTask runs a scheduled background task now.
+//
+// POST /api/v1/scheduler/:task/run
+func RunSchedulerTask(router *gin.RouterGroup) {
+	router.POST("/scheduler/:task/run", func(c *gin.Context) {
+		updateSchedulerTask(c, func(scheduler *workers.Scheduler, name string) error {
+			return scheduler.Trigger(name)
+		})
+	})
+}
+
+// SkipSchedulerTask skips the next scheduled run of a background task.
+//
+// POST /api/v1/scheduler/:task/skip
+func SkipSchedulerTask(router *gin.RouterGroup) {
+	router.POST("/scheduler/:task/skip", func(c *gin.Context) {
+		updateSchedulerTask(c, func(scheduler *workers.Scheduler, name string) error {
+			return scheduler.Skip(name, true)
+		})
+	})
+}
+
+// UnskipSchedulerTask cancels a request to skip the next scheduled run of a background task.
+//
+// DELETE /api/v1/scheduler/:task/skip
+func UnskipSchedulerTask(router *gin.RouterGroup) {
+	router.DELETE("/scheduler/:task/skip", func(c *gin.Context) {
+		updateSchedulerTask(c, func(scheduler *workers.Scheduler, name string) error {
+			return scheduler.Skip(name, false)
+		})
+	})
+}
+
+// updateSchedulerTask checks the permissions and applies the change to the task specified in the request.
+func updateSchedulerTask(c *gin.Context, update func(scheduler *workers.Scheduler, name string) error) {
+	s := Auth(c, acl.ResourceConfig, acl.ActionManage)
+
+	if s.Abort(c) {
+		return
+	}
+
+	name := clean.TypeLower(c.Param("task"))
+	scheduler := workers.GetScheduler()
+
+	if scheduler == nil {
+		AbortNotFound(c)
+		return
+	} else if err := update(scheduler, name); err != nil {
+		AbortNotFound(c)
+		return
+	}
+
+	for _, task := range scheduler.Tasks() {
+		if task.Name == name {
+			c.JSON(http.StatusOK, task)
+			return
+		}
+	}
+
+	AbortNotFound(c)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetSchedulerTasks(t *testing.T) {
+	t.Run("NotStarted", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSchedulerTasks(router)
+		r := PerformRequest(app, "GET", "/api/v1/scheduler")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+}
+
+func TestRunSchedulerTask(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RunSchedulerTask(router)
+		r := PerformRequest(app, "POST", "/api/v1/scheduler/index/run")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestSkipSchedulerTask(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SkipSchedulerTask(router)
+		r := PerformRequest(app, "POST", "/api/v1/scheduler/backup/skip")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
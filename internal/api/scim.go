@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SCIM 2.0 provisioning for identity providers such as Okta or Azure AD, see RFC 7644.
+// Clients authenticate with a Bearer access token of an admin account.
+const (
+	scimPath         = "/scim/v2"
+	scimContentType  = "application/scim+json"
+	scimDefaultCount = 100
+	scimMaxCount     = 1000
+)
+
+// scimJSON sends a SCIM response with the specified status code.
+func scimJSON(c *gin.Context, code int, obj interface{}) {
+	c.Header("Content-Type", scimContentType)
+	c.JSON(code, obj)
+}
+
+// scimAbort aborts the request with a SCIM error response.
+func scimAbort(c *gin.Context, code int, scimType string, err error) {
+	resp := form.ScimError{
+		Schemas:  []string{form.ScimSchemaError},
+		Status:   fmt.Sprintf("%d", code),
+		ScimType: scimType,
+	}
+
+	if err != nil {
+		resp.Detail = err.Error()
+		log.Debugf("scim: %s", resp.Detail)
+	}
+
+	c.Header("Content-Type", scimContentType)
+	c.AbortWithStatusJSON(code, resp)
+}
+
+// scimSaveError aborts with status 409 if a unique value already exists and with status 400 otherwise.
+func scimSaveError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "already exists") {
+		scimAbort(c, http.StatusConflict, "uniqueness", err)
+	} else {
+		scimAbort(c, http.StatusBadRequest, "invalidValue", err)
+	}
+}
+
+// scimAuth checks if the request is authorized to manage users and groups.
+func scimAuth(c *gin.Context) *entity.Session {
+	return Auth(c, acl.ResourceUsers, acl.AccessAll)
+}
+
+// scimPage returns the 1-based start index and the number of resources to return.
+func scimPage(c *gin.Context) (startIndex, count int) {
+	if startIndex = txt.Int(c.Query("startIndex")); startIndex < 1 {
+		startIndex = 1
+	}
+
+	if c.Query("count") == "" {
+		count = scimDefaultCount
+	} else if count = txt.Int(c.Query("count")); count < 0 {
+		count = 0
+	} else if count > scimMaxCount {
+		count = scimMaxCount
+	}
+
+	return startIndex, count
+}
+
+// scimLocation returns the location URI of a SCIM resource.
+func scimLocation(resourceType, id string) string {
+	return fmt.Sprintf("%s%s/%s/%s", get.Config().ApiUri(), scimPath, resourceType, id)
+}
+
+// GetScimConfig returns the SCIM service provider configuration.
+//
+// GET /api/v1/scim/v2/ServiceProviderConfig
+func GetScimConfig(router *gin.RouterGroup) {
+	router.GET(scimPath+"/ServiceProviderConfig", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		supported := func(s bool) gin.H { return gin.H{"supported": s} }
+
+		scimJSON(c, http.StatusOK, gin.H{
+			"schemas":        []string{form.ScimSchemaSPConfig},
+			"patch":          supported(true),
+			"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+			"filter":         gin.H{"supported": true, "maxResults": scimMaxCount},
+			"changePassword": supported(false),
+			"sort":           supported(false),
+			"etag":           supported(false),
+			"authenticationSchemes": []gin.H{{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication with an access token of an admin account.",
+				"primary":     true,
+			}},
+			"meta": form.ScimMeta{ResourceType: "ServiceProviderConfig"},
+		})
+	})
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// scimGroup returns the SCIM representation of a user group.
+func scimGroup(m *entity.Group) form.ScimGroup {
+	result := form.ScimGroup{
+		Schemas:     []string{form.ScimSchemaGroup},
+		ID:          m.GroupUID,
+		DisplayName: m.GroupTitle,
+		Members:     []form.ScimValue{},
+		Meta: &form.ScimMeta{
+			ResourceType: "Group",
+			Created:      &m.CreatedAt,
+			LastModified: &m.UpdatedAt,
+			Location:     scimLocation("Groups", m.GroupUID),
+		},
+	}
+
+	for _, uid := range m.MemberUIDs() {
+		result.Members = append(result.Members, form.ScimValue{Value: uid, Ref: scimLocation("Users", uid)})
+	}
+
+	return result
+}
+
+// scimFindGroup returns the group with the UID specified in the request path or aborts with status 404.
+func scimFindGroup(c *gin.Context) *entity.Group {
+	m := entity.FindGroupByUID(clean.UID(c.Param("id")))
+
+	if m == nil {
+		scimAbort(c, http.StatusNotFound, "", fmt.Errorf("group not found"))
+		return nil
+	}
+
+	return m
+}
+
+// scimSetMembers replaces the members of a group.
+func scimSetMembers(m *entity.Group, uids []string) error {
+	keep := make(map[string]bool, len(uids))
+
+	for _, uid := range uids {
+		keep[clean.UID(uid)] = true
+	}
+
+	var remove []string
+
+	for _, uid := range m.MemberUIDs() {
+		if !keep[uid] {
+			remove = append(remove, uid)
+		}
+	}
+
+	if err := m.RemoveMembers(remove); err != nil {
+		return err
+	}
+
+	_, err := m.AddMembers(uids)
+
+	return err
+}
+
+// scimPatchGroup applies a single SCIM PATCH operation to a group.
+func scimPatchGroup(m *entity.Group, op form.ScimOperation) error {
+	attr := op.Attribute()
+
+	// Apply attribute map if no path is specified, e.g. {"displayName": "Family"}.
+	if attr == "" {
+		for name, value := range op.Values() {
+			if err := scimPatchGroup(m, form.ScimOperation{Op: op.Op, Path: name, Value: value}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	switch attr {
+	case "displayname":
+		if s, ok := form.ScimString(op.Value); !ok || clean.Name(s) == "" {
+			return fmt.Errorf("invalid value for %s", attr)
+		} else {
+			m.GroupTitle = clean.Name(s)
+			return m.Save()
+		}
+	case "members":
+		values, _ := form.ScimMultiValues(op.Value)
+		uids := form.ScimValues(values)
+
+		switch op.Name() {
+		case "add":
+			_, err := m.AddMembers(uids)
+			return err
+		case "remove":
+			if uid := op.FilterValue(); uid != "" {
+				return m.RemoveMembers([]string{uid})
+			} else if len(uids) > 0 {
+				return m.RemoveMembers(uids)
+			}
+
+			return m.RemoveMembers(m.MemberUIDs())
+		case "replace":
+			return scimSetMembers(m, uids)
+		}
+	}
+
+	// Other attributes are not supported and will be ignored.
+	return nil
+}
+
+// SearchScimGroups returns the provisioned user groups.
+//
+// GET /api/v1/scim/v2/Groups
+//
+// Query:
+//
+//	filter:     equality filter on displayName or id (optional)
+//	startIndex: 1-based index of the first result (optional)
+//	count:      maximum number of results (optional)
+func SearchScimGroups(router *gin.RouterGroup) {
+	router.GET(scimPath+"/Groups", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		attr, value := form.ParseScimFilter(c.Query("filter"))
+
+		switch {
+		case c.Query("filter") == "":
+		case attr == "displayname", attr == "id":
+		default:
+			scimAbort(c, http.StatusBadRequest, "invalidFilter", fmt.Errorf("unsupported filter"))
+			return
+		}
+
+		startIndex, count := scimPage(c)
+
+		var matches entity.Groups
+
+		for _, g := range entity.FindGroups() {
+			switch attr {
+			case "displayname":
+				if !strings.EqualFold(g.GroupTitle, value) && g.GroupName != clean.Handle(value) {
+					continue
+				}
+			case "id":
+				if g.GroupUID != value {
+					continue
+				}
+			}
+
+			matches = append(matches, g)
+		}
+
+		resources := make([]form.ScimGroup, 0, count)
+
+		for i := startIndex - 1; i < len(matches) && len(resources) < count; i++ {
+			resources = append(resources, scimGroup(&matches[i]))
+		}
+
+		scimJSON(c, http.StatusOK, form.NewScimList(resources, len(matches), startIndex, len(resources)))
+	})
+}
+
+// GetScimGroup returns a provisioned user group.
+//
+// GET /api/v1/scim/v2/Groups/:id
+func GetScimGroup(router *gin.RouterGroup) {
+	router.GET(scimPath+"/Groups/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if m := scimFindGroup(c); m != nil {
+			scimJSON(c, http.StatusOK, scimGroup(m))
+		}
+	})
+}
+
+// CreateScimGroup provisions a new user group.
+//
+// POST /api/v1/scim/v2/Groups
+func CreateScimGroup(router *gin.RouterGroup) {
+	router.POST(scimPath+"/Groups", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.ScimGroup
+
+		if err := c.BindJSON(&f); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidSyntax", err)
+			return
+		}
+
+		m := entity.NewGroup(f.DisplayName)
+
+		if err := m.Create(); err != nil {
+			scimSaveError(c, err)
+			return
+		} else if _, err = m.AddMembers(f.MemberUIDs()); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidValue", err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "created group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		scimJSON(c, http.StatusCreated, scimGroup(m))
+	})
+}
+
+// UpdateScimGroup replaces the name and members of a provisioned user group.
+//
+// PUT /api/v1/scim/v2/Groups/:id
+func UpdateScimGroup(router *gin.RouterGroup) {
+	router.PUT(scimPath+"/Groups/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := scimFindGroup(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.ScimGroup
+
+		if err := c.BindJSON(&f); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidSyntax", err)
+			return
+		}
+
+		if title := clean.Name(f.DisplayName); title != "" {
+			m.GroupTitle = title
+		}
+
+		if err := m.Save(); err != nil {
+			scimSaveError(c, err)
+			return
+		} else if err = scimSetMembers(m, f.MemberUIDs()); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidValue", err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "updated group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		scimJSON(c, http.StatusOK, scimGroup(m))
+	})
+}
+
+// PatchScimGroup changes the name or members of a provisioned user group.
+//
+// PATCH /api/v1/scim/v2/Groups/:id
+func PatchScimGroup(router *gin.RouterGroup) {
+	router.PATCH(scimPath+"/Groups/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := scimFindGroup(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.ScimPatch
+
+		if err := c.BindJSON(&f); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidSyntax", err)
+			return
+		}
+
+		for _, op := range f.Operations {
+			if err := scimPatchGroup(m, op); err != nil {
+				scimAbort(c, http.StatusBadRequest, "invalidValue", err)
+				return
+			}
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "updated group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		scimJSON(c, http.StatusOK, scimGroup(m))
+	})
+}
+
+// DeleteScimGroup deletes a provisioned user group including its memberships and shares.
+//
+// DELETE /api/v1/scim/v2/Groups/:id
+func DeleteScimGroup(router *gin.RouterGroup) {
+	router.DELETE(scimPath+"/Groups/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := scimFindGroup(c)
+
+		if m == nil {
+			return
+		} else if err := m.Delete(); err != nil {
+			scimAbort(c, http.StatusBadRequest, "mutability", err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "deleted group %s"}, s.RefID, clean.Log(m.GroupName))
+
+		c.Status(http.StatusNoContent)
+	})
+}
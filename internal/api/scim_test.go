@@ -0,0 +1,126 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestScimUsers(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetScimUser(router)
+		r := PerformRequest(app, "GET", "/api/v1/scim/v2/Users/uxxxxxxxxxxxxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "404", gjson.Get(r.Body.String(), "status").String())
+	})
+	t.Run("Filter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchScimUsers(router)
+		r := PerformRequest(app, "GET", `/api/v1/scim/v2/Users?filter=userName%20eq%20%22alice%22`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "totalResults").Int())
+		assert.Equal(t, "alice", gjson.Get(r.Body.String(), "Resources.0.userName").String())
+
+		r = PerformRequest(app, "GET", `/api/v1/scim/v2/Users?filter=userName%20sw%20%22a%22`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Lifecycle", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateScimUser(router)
+		PatchScimUser(router)
+		UpdateScimUser(router)
+		DeleteScimUser(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/scim/v2/Users", `{"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"], "userName": "scim.jane", "externalId": "00u1abc", "name": {"givenName": "Jane", "familyName": "Doe"}, "emails": [{"value": "scim.jane@example.com", "primary": true}], "roles": [{"value": "visitor"}]}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+		uid := gjson.Get(r.Body.String(), "id").String()
+		assert.Equal(t, "Jane Doe", gjson.Get(r.Body.String(), "displayName").String())
+		assert.Equal(t, "00u1abc", gjson.Get(r.Body.String(), "externalId").String())
+		assert.True(t, gjson.Get(r.Body.String(), "active").Bool())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/scim/v2/Users", `{"userName": "scim.jane"}`)
+		assert.Equal(t, http.StatusConflict, r.Code)
+
+		r = PerformRequestWithBody(app, "PATCH", fmt.Sprintf("/api/v1/scim/v2/Users/%s", uid), `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"], "Operations": [{"op": "Replace", "value": {"active": "False"}}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "active").Bool())
+
+		r = PerformRequestWithBody(app, "PUT", fmt.Sprintf("/api/v1/scim/v2/Users/%s", uid), `{"userName": "scim.jane", "displayName": "Jane Smith", "active": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Jane Smith", gjson.Get(r.Body.String(), "displayName").String())
+		assert.True(t, gjson.Get(r.Body.String(), "active").Bool())
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/scim/v2/Users/%s", uid))
+		assert.Equal(t, http.StatusNoContent, r.Code)
+	})
+	t.Run("DefaultRole", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateScimUser(router)
+		UpdateScimUser(router)
+		DeleteScimUser(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/scim/v2/Users", `{"userName": "scim.joe", "externalId": "00u1def"}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+		uid := gjson.Get(r.Body.String(), "id").String()
+		assert.Equal(t, "visitor", gjson.Get(r.Body.String(), "roles.0.value").String())
+
+		r = PerformRequestWithBody(app, "PUT", fmt.Sprintf("/api/v1/scim/v2/Users/%s", uid), `{"userName": "scim.joe", "roles": [{"value": "admin"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "admin", gjson.Get(r.Body.String(), "roles.0.value").String())
+
+		// The role must not change if no roles are specified.
+		r = PerformRequestWithBody(app, "PUT", fmt.Sprintf("/api/v1/scim/v2/Users/%s", uid), `{"userName": "scim.joe", "displayName": "Joe"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "admin", gjson.Get(r.Body.String(), "roles.0.value").String())
+
+		// The externalId must not disable password authentication.
+		if m := entity.FindUserByUID(uid); m == nil {
+			t.Fatal("user not found")
+		} else {
+			assert.Equal(t, "00u1def", m.ExternalID())
+			assert.False(t, m.HasProvider(authn.ProviderOIDC))
+		}
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/scim/v2/Users/%s", uid))
+		assert.Equal(t, http.StatusNoContent, r.Code)
+	})
+}
+
+func TestScimGroups(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetScimGroup(router)
+		r := PerformRequest(app, "GET", "/api/v1/scim/v2/Groups/gxxxxxxxxxxxxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Lifecycle", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateScimGroup(router)
+		SearchScimGroups(router)
+		PatchScimGroup(router)
+		DeleteScimGroup(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/scim/v2/Groups", `{"displayName": "Photo Club", "members": [{"value": "uqxc08w3d0ej2283"}]}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+		uid := gjson.Get(r.Body.String(), "id").String()
+		assert.Equal(t, "uqxc08w3d0ej2283", gjson.Get(r.Body.String(), "members.0.value").String())
+
+		r = PerformRequest(app, "GET", `/api/v1/scim/v2/Groups?filter=displayName%20eq%20%22Photo%20Club%22`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, uid, gjson.Get(r.Body.String(), "Resources.0.id").String())
+
+		r = PerformRequestWithBody(app, "PATCH", fmt.Sprintf("/api/v1/scim/v2/Groups/%s", uid), `{"Operations": [{"op": "remove", "path": "members[value eq \"uqxc08w3d0ej2283\"]"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "members.#").Int())
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/scim/v2/Groups/%s", uid))
+		assert.Equal(t, http.StatusNoContent, r.Code)
+	})
+}
@@ -0,0 +1,386 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// scimUser returns the SCIM representation of a user account.
+func scimUser(m *entity.User) form.ScimUser {
+	active := m.Active()
+
+	result := form.ScimUser{
+		Schemas:     []string{form.ScimSchemaUser},
+		ID:          m.UserUID,
+		ExternalID:  m.ExternalID(),
+		UserName:    m.Username(),
+		Name:        &form.ScimName{Formatted: m.FullName()},
+		DisplayName: m.FullName(),
+		Active:      &active,
+		Meta: &form.ScimMeta{
+			ResourceType: "User",
+			Created:      &m.CreatedAt,
+			LastModified: &m.UpdatedAt,
+			Location:     scimLocation("Users", m.UserUID),
+		},
+	}
+
+	if email := m.Email(); email != "" {
+		result.Emails = []form.ScimValue{{Value: email, Type: "work", Primary: true}}
+	}
+
+	if role := m.AclRole(); role != acl.RoleUnknown {
+		result.Roles = []form.ScimValue{{Value: role.String(), Primary: true}}
+	}
+
+	for _, g := range entity.FindUserGroups(m.UserUID) {
+		result.Groups = append(result.Groups, form.ScimValue{Value: g.GroupUID, Display: g.GroupTitle, Ref: scimLocation("Groups", g.GroupUID)})
+	}
+
+	return result
+}
+
+// scimSetUser applies the values of a SCIM user resource to the account.
+func scimSetUser(m *entity.User, f form.ScimUser) error {
+	if name := clean.Username(f.UserName); name != m.Username() {
+		if err := m.SetUsername(name); err != nil {
+			return err
+		}
+	}
+
+	// Keep the current role if none is specified.
+	if f.Role() == "" {
+		// Do nothing.
+	} else if role := acl.FindRole(clean.Role(f.Role())); role == acl.RoleUnknown {
+		return fmt.Errorf("role %s is invalid", clean.LogQuote(f.Role()))
+	} else if !m.SuperAdmin {
+		m.UserRole = role.String()
+	}
+
+	m.UserEmail = clean.Email(f.Email())
+	m.DisplayName = clean.Name(f.FullName())
+	m.SetExternalID(f.ExternalID)
+
+	if f.Active != nil {
+		m.CanLogin = *f.Active
+	}
+
+	return m.Validate()
+}
+
+// scimPatchUser applies a single attribute change of a SCIM PATCH operation to the account.
+func scimPatchUser(m *entity.User, attr string, value json.RawMessage) error {
+	switch attr {
+	case "active":
+		if active, ok := form.ScimBool(value); !ok {
+			return fmt.Errorf("invalid value for %s", attr)
+		} else {
+			m.CanLogin = active
+		}
+	case "username":
+		if s, ok := form.ScimString(value); !ok {
+			return fmt.Errorf("invalid value for %s", attr)
+		} else if err := m.SetUsername(s); err != nil {
+			return err
+		}
+	case "displayname", "name.formatted":
+		if s, ok := form.ScimString(value); ok {
+			m.DisplayName = clean.Name(s)
+		}
+	case "name":
+		var name form.ScimName
+
+		if err := json.Unmarshal(value, &name); err == nil && name.String() != "" {
+			m.DisplayName = clean.Name(name.String())
+		}
+	case "externalid":
+		if s, ok := form.ScimString(value); ok {
+			m.SetExternalID(s)
+		}
+	case "emails":
+		if s, ok := form.ScimString(value); ok {
+			m.UserEmail = clean.Email(s)
+		} else if values, ok := form.ScimMultiValues(value); ok {
+			m.UserEmail = clean.Email((&form.ScimUser{Emails: values}).Email())
+		}
+	case "roles":
+		var role string
+
+		if s, ok := form.ScimString(value); ok {
+			role = s
+		} else if values, ok := form.ScimMultiValues(value); ok {
+			role = (&form.ScimUser{Roles: values}).Role()
+		}
+
+		if r := acl.FindRole(clean.Role(role)); r == acl.RoleUnknown {
+			return fmt.Errorf("role %s is invalid", clean.LogQuote(role))
+		} else if !m.SuperAdmin {
+			m.UserRole = r.String()
+		}
+	}
+
+	// Other attributes are not supported and will be ignored.
+	return nil
+}
+
+// scimFindUser returns the user account with the UID specified in the request path or aborts with status 404.
+func scimFindUser(c *gin.Context) *entity.User {
+	m := entity.FindUserByUID(clean.UID(c.Param("id")))
+
+	if m == nil || m.ID <= 0 || m.Deleted() {
+		scimAbort(c, http.StatusNotFound, "", fmt.Errorf("user not found"))
+		return nil
+	}
+
+	return m
+}
+
+// scimSaveUser saves the account and invalidates its sessions if it has been deactivated.
+func scimSaveUser(m *entity.User, wasActive bool) error {
+	if m.ID == 1 && !m.CanLogin {
+		return fmt.Errorf("cannot deactivate system user")
+	} else if err := m.Save(); err != nil {
+		return err
+	}
+
+	if wasActive && !m.Active() {
+		return m.SetActive(false)
+	}
+
+	entity.FlushUserSessionCache(m.UserUID)
+
+	return nil
+}
+
+// SearchScimUsers returns the provisioned user accounts.
+//
+// GET /api/v1/scim/v2/Users
+//
+// Query:
+//
+//	filter:     equality filter on userName, externalId, or emails (optional)
+//	startIndex: 1-based index of the first result (optional)
+//	count:      maximum number of results (optional)
+func SearchScimUsers(router *gin.RouterGroup) {
+	router.GET(scimPath+"/Users", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		attr, value := form.ParseScimFilter(c.Query("filter"))
+
+		if c.Query("filter") != "" && attr == "" {
+			scimAbort(c, http.StatusBadRequest, "invalidFilter", fmt.Errorf("unsupported filter"))
+			return
+		}
+
+		startIndex, count := scimPage(c)
+
+		users, total, err := entity.ScimUsers(attr, value, startIndex-1, count)
+
+		if err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidFilter", err)
+			return
+		}
+
+		resources := make([]form.ScimUser, 0, len(users))
+
+		for i := range users {
+			resources = append(resources, scimUser(&users[i]))
+		}
+
+		scimJSON(c, http.StatusOK, form.NewScimList(resources, total, startIndex, len(resources)))
+	})
+}
+
+// GetScimUser returns a provisioned user account.
+//
+// GET /api/v1/scim/v2/Users/:id
+func GetScimUser(router *gin.RouterGroup) {
+	router.GET(scimPath+"/Users/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if m := scimFindUser(c); m != nil {
+			scimJSON(c, http.StatusOK, scimUser(m))
+		}
+	})
+}
+
+// CreateScimUser provisions a new user account.
+//
+// POST /api/v1/scim/v2/Users
+func CreateScimUser(router *gin.RouterGroup) {
+	router.POST(scimPath+"/Users", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.ScimUser
+
+		if err := c.BindJSON(&f); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidSyntax", err)
+			return
+		}
+
+		// Provisioned users get the least privileged role unless another role is specified.
+		m := entity.NewUser()
+		m.UserRole = acl.RoleVisitor.String()
+		m.CanLogin = true
+
+		if err := scimSetUser(m, f); err != nil {
+			scimSaveError(c, err)
+			return
+		} else if err = m.Create(); err != nil {
+			scimSaveError(c, err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "created user %s"}, s.RefID, clean.LogQuote(m.Username()))
+
+		scimJSON(c, http.StatusCreated, scimUser(m))
+	})
+}
+
+// UpdateScimUser replaces the attributes of a provisioned user account.
+//
+// PUT /api/v1/scim/v2/Users/:id
+func UpdateScimUser(router *gin.RouterGroup) {
+	router.PUT(scimPath+"/Users/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := scimFindUser(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.ScimUser
+
+		if err := c.BindJSON(&f); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidSyntax", err)
+			return
+		}
+
+		wasActive := m.Active()
+
+		if err := scimSetUser(m, f); err != nil {
+			scimSaveError(c, err)
+			return
+		} else if err = scimSaveUser(m, wasActive); err != nil {
+			scimSaveError(c, err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "updated user %s"}, s.RefID, clean.LogQuote(m.Username()))
+
+		scimJSON(c, http.StatusOK, scimUser(m))
+	})
+}
+
+// PatchScimUser changes individual attributes of a provisioned user account, e.g. to deactivate it.
+//
+// PATCH /api/v1/scim/v2/Users/:id
+func PatchScimUser(router *gin.RouterGroup) {
+	router.PATCH(scimPath+"/Users/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := scimFindUser(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.ScimPatch
+
+		if err := c.BindJSON(&f); err != nil {
+			scimAbort(c, http.StatusBadRequest, "invalidSyntax", err)
+			return
+		}
+
+		wasActive := m.Active()
+
+		for _, op := range f.Operations {
+			if op.Name() == "remove" {
+				continue
+			} else if attr := op.Attribute(); attr != "" {
+				if err := scimPatchUser(m, attr, op.Value); err != nil {
+					scimAbort(c, http.StatusBadRequest, "invalidValue", err)
+					return
+				}
+			} else {
+				for attr, value := range op.Values() {
+					if err := scimPatchUser(m, attr, value); err != nil {
+						scimAbort(c, http.StatusBadRequest, "invalidValue", err)
+						return
+					}
+				}
+			}
+		}
+
+		if err := m.Validate(); err != nil {
+			scimSaveError(c, err)
+			return
+		} else if err = scimSaveUser(m, wasActive); err != nil {
+			scimSaveError(c, err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "updated user %s"}, s.RefID, clean.LogQuote(m.Username()))
+
+		scimJSON(c, http.StatusOK, scimUser(m))
+	})
+}
+
+// DeleteScimUser deletes a provisioned user account.
+//
+// DELETE /api/v1/scim/v2/Users/:id
+func DeleteScimUser(router *gin.RouterGroup) {
+	router.DELETE(scimPath+"/Users/:id", func(c *gin.Context) {
+		s := scimAuth(c)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := scimFindUser(c)
+
+		if m == nil {
+			return
+		} else if s.UserUID == m.UserUID {
+			scimAbort(c, http.StatusBadRequest, "mutability", fmt.Errorf("cannot delete own account"))
+			return
+		} else if err := m.Delete(); err != nil {
+			scimAbort(c, http.StatusBadRequest, "mutability", err)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "scim", "deleted user %s"}, s.RefID, clean.LogQuote(m.Username()))
+
+		c.Status(http.StatusNoContent)
+	})
+}
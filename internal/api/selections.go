@@ -0,0 +1,210 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ExpandSelection adds the photos of a server-side selection set to the form, if one is specified.
+func ExpandSelection(s *entity.Session, f *form.Selection) error {
+	if f == nil || f.Set == "" {
+		return nil
+	} else if s == nil || s.NotRegistered() {
+		return fmt.Errorf("selection sets require a registered user")
+	}
+
+	set := entity.FindSelection(s.UserUID, f.Set)
+
+	if set == nil {
+		return fmt.Errorf("selection set %s not found", clean.Log(f.Set))
+	}
+
+	uids, err := set.PhotoUIDs(0, 0)
+
+	if err != nil {
+		return err
+	}
+
+	f.Photos = append(f.Photos, uids...)
+
+	return nil
+}
+
+// SearchSelections returns the selection sets of the current user.
+//
+// GET /api/v1/selections
+func SearchSelections(router *gin.RouterGroup) {
+	router.GET("/selections", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		} else if s.NotRegistered() {
+			AbortForbidden(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindSelections(s.UserUID))
+	})
+}
+
+// GetSelection returns a selection set including the selected photo UIDs.
+//
+// GET /api/v1/selections/:name
+//
+// Query:
+//
+//	count:  maximum number of photo UIDs to return (optional)
+//	offset: number of photo UIDs to skip (optional)
+func GetSelection(router *gin.RouterGroup) {
+	router.GET("/selections/:name", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		} else if s.NotRegistered() {
+			AbortForbidden(c)
+			return
+		}
+
+		set := entity.FindSelection(s.UserUID, c.Param("name"))
+
+		if set == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		uids, err := set.PhotoUIDs(txt.Int(c.Query("count")), txt.Int(c.Query("offset")))
+
+		if err != nil {
+			log.Errorf("selection: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"selection": set, "photos": uids})
+	})
+}
+
+// AddToSelection adds photos to a selection set and creates it if needed.
+//
+// POST /api/v1/selections/:name/photos
+func AddToSelection(router *gin.RouterGroup) {
+	router.POST("/selections/:name/photos", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		} else if s.NotRegistered() {
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		set, err := entity.FirstOrCreateSelection(s.UserUID, c.Param("name"))
+
+		if err != nil {
+			log.Errorf("selection: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		if err = set.AddPhotos(f.Photos); err != nil {
+			log.Errorf("selection: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, set)
+	})
+}
+
+// RemoveFromSelection removes photos from a selection set.
+//
+// DELETE /api/v1/selections/:name/photos
+func RemoveFromSelection(router *gin.RouterGroup) {
+	router.DELETE("/selections/:name/photos", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		} else if s.NotRegistered() {
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		set := entity.FindSelection(s.UserUID, c.Param("name"))
+
+		if set == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := set.RemovePhotos(f.Photos); err != nil {
+			log.Errorf("selection: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, set)
+	})
+}
+
+// DeleteSelection deletes a selection set.
+//
+// DELETE /api/v1/selections/:name
+func DeleteSelection(router *gin.RouterGroup) {
+	router.DELETE("/selections/:name", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		} else if s.NotRegistered() {
+			AbortForbidden(c)
+			return
+		}
+
+		set := entity.FindSelection(s.UserUID, c.Param("name"))
+
+		if set == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := set.Delete(); err != nil {
+			log.Errorf("selection: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestSearchSelections(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSelections(router)
+		r := PerformRequest(app, "GET", "/api/v1/selections")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestAddToSelection(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddToSelection(router)
+		GetSelection(router)
+		DeleteSelection(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/selections/test/photos", `{"photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/selections/test")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/selections/test")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddToSelection(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/selections/test/photos", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetSelection(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSelection(router)
+		r := PerformRequest(app, "GET", "/api/v1/selections/xxx-not-found")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestExpandSelection(t *testing.T) {
+	t.Run("NoSet", func(t *testing.T) {
+		f := form.Selection{Photos: []string{"pt9jtdre2lvl0yh7"}}
+		assert.NoError(t, ExpandSelection(nil, &f))
+		assert.Len(t, f.Photos, 1)
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		f := form.Selection{Set: "default"}
+		assert.Error(t, ExpandSelection(nil, &f))
+	})
+}
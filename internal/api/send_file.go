@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// sendFile sends a local file with support for range and conditional requests, so that clients can seek
+// in videos and resume large downloads. If downloadName is not empty, the file is sent as attachment.
+// Unlike gin.Context.File, the content is passed to the connection with sendfile() if the platform and
+// response writer support it, instead of being copied through a buffer in user space.
+func sendFile(c *gin.Context, fileName, downloadName string) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		log.Errorf("send: failed to open %s", clean.Log(filepath.Base(fileName)))
+		AbortNotFound(c)
+		return
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil || info.IsDir() {
+		log.Errorf("send: %s is not a regular file", clean.Log(filepath.Base(fileName)))
+		AbortNotFound(c)
+		return
+	}
+
+	if downloadName != "" {
+		c.Header("Content-Disposition", attachmentHeader(downloadName))
+	}
+
+	// A strong validator is needed for "If-Range" requests with entity tags.
+	if c.Writer.Header().Get("ETag") == "" {
+		c.Header("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	}
+
+	http.ServeContent(sendfileWriter{c.Writer}, c.Request, fileName, info.ModTime(), f)
+}
+
+// attachmentHeader returns a "Content-Disposition" header value for downloading a file with the specified name.
+func attachmentHeader(fileName string) string {
+	for _, r := range fileName {
+		if r > 127 {
+			return `attachment; filename*=UTF-8''` + url.PathEscape(fileName)
+		}
+	}
+
+	return `attachment; filename="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(fileName) + `"`
+}
+
+// sendfileWriter implements io.ReaderFrom for the gin response writer, which hides this interface
+// of the connection it wraps. It is used by io.Copy to transfer file contents with sendfile().
+type sendfileWriter struct {
+	gin.ResponseWriter
+}
+
+// ReadFrom writes the response headers and then reads data from r until EOF.
+func (w sendfileWriter) ReadFrom(r io.Reader) (n int64, err error) {
+	if u, ok := w.ResponseWriter.(interface{ Unwrap() http.ResponseWriter }); ok {
+		if rf, ok := u.Unwrap().(io.ReaderFrom); ok {
+			w.WriteHeaderNow()
+			return rf.ReadFrom(r)
+		}
+	}
+
+	// Fall back to a regular copy, e.g. if the response is compressed.
+	return io.Copy(w.ResponseWriter, r)
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSendFileTest returns a test router that serves the specified file.
+func newSendFileTest(fileName string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	app := gin.New()
+	app.GET("/file", func(c *gin.Context) {
+		sendFile(c, fileName, c.Query("download"))
+	})
+	app.GET("/gin", func(c *gin.Context) {
+		c.File(fileName)
+	})
+
+	return app
+}
+
+// newSendFileData creates a file with random content for testing.
+func newSendFileData(t testing.TB, size int) (fileName string, data []byte) {
+	data = make([]byte, size)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	fileName = filepath.Join(t.TempDir(), "video.mp4")
+
+	if err := os.WriteFile(fileName, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName, data
+}
+
+func TestSendFile(t *testing.T) {
+	fileName, data := newSendFileData(t, 100000)
+	app := newSendFileTest(fileName)
+
+	t.Run("Ok", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/file")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "video/mp4", r.Header().Get("Content-Type"))
+		assert.Equal(t, "bytes", r.Header().Get("Accept-Ranges"))
+		assert.NotEmpty(t, r.Header().Get("ETag"))
+		assert.NotEmpty(t, r.Header().Get("Last-Modified"))
+		assert.Empty(t, r.Header().Get("Content-Disposition"))
+		assert.True(t, bytes.Equal(data, r.Body.Bytes()))
+	})
+	t.Run("Download", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/file?download=Bridge%202023.mp4")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `attachment; filename="Bridge 2023.mp4"`, r.Header().Get("Content-Disposition"))
+	})
+	t.Run("Range", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/file", nil)
+		req.Header.Set("Range", "bytes=1000-1999")
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "bytes 1000-1999/100000", w.Header().Get("Content-Range"))
+		assert.True(t, bytes.Equal(data[1000:2000], w.Body.Bytes()))
+	})
+	t.Run("IfRange", func(t *testing.T) {
+		etag := PerformRequest(app, "GET", "/file").Header().Get("ETag")
+
+		req, _ := http.NewRequest("GET", "/file", nil)
+		req.Header.Set("Range", "bytes=0-99")
+		req.Header.Set("If-Range", etag)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, 100, w.Body.Len())
+
+		// The whole file must be sent if it has changed.
+		req.Header.Set("If-Range", `"changed"`)
+
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, len(data), w.Body.Len())
+	})
+	t.Run("IfNoneMatch", func(t *testing.T) {
+		etag := PerformRequest(app, "GET", "/file").Header().Get("ETag")
+
+		req, _ := http.NewRequest("GET", "/file", nil)
+		req.Header.Set("If-None-Match", etag)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+	})
+	t.Run("Sendfile", func(t *testing.T) {
+		server := httptest.NewServer(app)
+		defer server.Close()
+
+		req, _ := http.NewRequest("GET", server.URL+"/file", nil)
+		req.Header.Set("Range", "bytes=50000-")
+
+		resp, err := http.DefaultClient.Do(req)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.True(t, bytes.Equal(data[50000:], body))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(newSendFileTest(filepath.Join(t.TempDir(), "missing.mp4")), "GET", "/file")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAttachmentHeader(t *testing.T) {
+	assert.Equal(t, `attachment; filename="bridge.jpg"`, attachmentHeader("bridge.jpg"))
+	assert.Equal(t, `attachment; filename="say \"cheese\".jpg"`, attachmentHeader(`say "cheese".jpg`))
+	assert.Equal(t, `attachment; filename*=UTF-8''Br%C3%BCcke.jpg`, attachmentHeader("Brücke.jpg"))
+}
+
+// BenchmarkSendFile compares the throughput of sendFile with gin.Context.File when downloading
+// a large video over a local connection. On Linux, sendFile passes the content to the socket
+// with sendfile(), which avoids copying it through user space:
+//
+//	go test -run=^$ -bench=SendFile ./internal/api
+func BenchmarkSendFile(b *testing.B) {
+	fileName, _ := newSendFileData(b, 256<<20)
+	server := httptest.NewServer(newSendFileTest(fileName))
+
+	defer server.Close()
+
+	download := func(b *testing.B, path string) {
+		b.SetBytes(256 << 20)
+
+		for i := 0; i < b.N; i++ {
+			resp, err := http.Get(server.URL + path)
+
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}
+
+	b.Run("SendFile", func(b *testing.B) {
+		download(b, "/file")
+	})
+	b.Run("GinFile", func(b *testing.B) {
+		download(b, "/gin")
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // Namespaces for caching and logs.
@@ -105,6 +106,49 @@ func GetServiceFolders(router *gin.RouterGroup) {
 	})
 }
 
+// GetServiceJournal returns the changes that were synced with an account, most recent first.
+//
+// GET /api/v1/services/:id/journal
+func GetServiceJournal(router *gin.RouterGroup) {
+	router.GET("/services/:id/journal", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceServices, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m, err := query.AccountByID(clean.IdUint(c.Param("id")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := entity.FindSyncJournal(m.ID, limit, offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
 // AddService creates a new remote account configuration.
 //
 // POST /api/v1/services
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/gphotos"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// gphotosStates maps OAuth state tokens to account IDs until the authorization is complete.
+var gphotosStates = gc.New(15*time.Minute, 5*time.Minute)
+
+// AuthorizeService returns the URL of the Google consent page to authorize access to a Google Photos account.
+//
+// GET /api/v1/services/:id/authorize
+func AuthorizeService(router *gin.RouterGroup) {
+	router.GET("/services/:id/authorize", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceServices, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		} else if !conf.GPhotosEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		m, err := query.AccountByID(clean.IdUint(c.Param("id")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		} else if m.AccType != remote.ServiceGPhotos {
+			AbortBadRequest(c)
+			return
+		}
+
+		state := rnd.Base62(32)
+		gphotosStates.SetDefault(state, m.ID)
+
+		c.JSON(http.StatusOK, gin.H{"url": gphotos.AuthURL(conf.GPhotosClientID(), conf.GPhotosRedirectUrl(), state)})
+	})
+}
+
+// GPhotosCallback stores the OAuth refresh token of a Google Photos account after the user has granted access.
+// The request does not need to be authenticated, as the state token was created by AuthorizeService.
+//
+// GET /api/v1/oauth/gphotos
+func GPhotosCallback(router *gin.RouterGroup) {
+	router.GET("/oauth/gphotos", func(c *gin.Context) {
+		conf := get.Config()
+		redirectUri := conf.BaseUri("/library/settings/services")
+
+		state := clean.Token(c.Query("state"))
+		found, ok := gphotosStates.Get(state)
+
+		if state == "" || !ok {
+			AbortForbidden(c)
+			return
+		}
+
+		gphotosStates.Delete(state)
+
+		m, err := query.AccountByID(found.(uint))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		if e := c.Query("error"); e != "" {
+			log.Warnf("gphotos: access to %s was not granted (%s)", clean.Log(m.AccName), clean.Log(e))
+			c.Redirect(http.StatusTemporaryRedirect, redirectUri)
+			return
+		}
+
+		token, err := gphotos.Exchange(conf.GPhotosClientID(), conf.GPhotosClientSecret(), conf.GPhotosRedirectUrl(), c.Query("code"))
+
+		if err == nil && token.RefreshToken == "" {
+			err = fmt.Errorf("gphotos: missing refresh token")
+		}
+
+		if err != nil {
+			log.Error(err)
+
+			if updateErr := m.Updates(entity.Values{"acc_error": err.Error()}); updateErr != nil {
+				log.Errorf("gphotos: %s", updateErr)
+			}
+
+			c.Redirect(http.StatusTemporaryRedirect, redirectUri)
+			return
+		}
+
+		if err = m.Updates(entity.Values{"acc_key": token.RefreshToken, "acc_error": "", "acc_errors": 0}); err != nil {
+			log.Errorf("gphotos: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("gphotos: authorized access to %s", clean.Log(m.AccName))
+
+		if m.AccSync {
+			workers.RunSync(conf)
+		}
+
+		c.Redirect(http.StatusTemporaryRedirect, redirectUri)
+	})
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/remote"
+)
+
+func TestAuthorizeService(t *testing.T) {
+	t.Run("FeatureDisabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AuthorizeService(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/1000000/authorize")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrFeatureDisabled), val.String())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AuthorizeService(router)
+
+		conf.Options().GPhotosClientID = "1234.apps.googleusercontent.com"
+		conf.Options().GPhotosClientSecret = "secret"
+
+		defer func() {
+			conf.Options().GPhotosClientID = ""
+			conf.Options().GPhotosClientSecret = ""
+		}()
+
+		m := entity.Service{AccName: "Google Photos", AccType: remote.ServiceGPhotos, AccURL: "https://photoslibrary.googleapis.com/"}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.Db().Delete(&m)
+
+		r := PerformRequest(app, "GET", fmt.Sprintf("/api/v1/services/%d/authorize", m.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "url").String(), "state=")
+	})
+}
+
+func TestGPhotosCallback(t *testing.T) {
+	t.Run("InvalidState", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GPhotosCallback(router)
+		r := PerformRequest(app, "GET", "/api/v1/oauth/gphotos?state=invalid&code=1234")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Denied", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GPhotosCallback(router)
+		gphotosStates.SetDefault("denied", uint(1000000))
+		r := PerformRequest(app, "GET", "/api/v1/oauth/gphotos?state=denied&error=access_denied")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+
+		_, found := gphotosStates.Get("denied")
+		assert.False(t, found)
+	})
+}
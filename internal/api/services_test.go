@@ -50,6 +50,24 @@ func TestGetServiceFolders(t *testing.T) {
 	})
 }
 
+func TestGetServiceJournal(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetServiceJournal(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/1000000/journal?count=10")
+		assert.True(t, gjson.Valid(r.Body.String()))
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetServiceJournal(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/999000/journal")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestCreateService(t *testing.T) {
 	t.Run("BadRequest", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
@@ -48,6 +49,7 @@ func UploadToService(router *gin.RouterGroup) {
 
 		// Find files to share.
 		selection := query.ShareSelection(m.ShareOriginals())
+		selection.Locked = search.LockedAlbums(s)
 		files, err := query.SelectedFiles(f.Selection, selection)
 
 		if err != nil {
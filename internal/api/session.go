@@ -1,6 +1,8 @@
 package api
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -17,7 +19,16 @@ func SessionID(c *gin.Context) (sessId string) {
 	}
 
 	// Get the authentication token from the HTTP headers.
-	return clean.ID(c.GetHeader(session.Header))
+	if sessId = clean.ID(c.GetHeader(session.Header)); sessId != "" {
+		return sessId
+	}
+
+	// Access tokens may also be passed as bearer token.
+	if auth := c.GetHeader(session.AuthHeader); strings.HasPrefix(auth, session.AuthBearer) {
+		return clean.ID(strings.TrimPrefix(auth, session.AuthBearer))
+	}
+
+	return ""
 }
 
 // Session finds the client session for the given ID or returns nil otherwise.
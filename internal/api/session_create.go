@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
@@ -82,20 +83,19 @@ func CreateSession(router *gin.RouterGroup) {
 		// Add session id to response headers.
 		AddSessionHeader(c, sess.ID)
 
-		// Get config values for the UI.
-		clientConfig := conf.ClientSession(sess)
-
-		// User information, session data, and client config values.
-		data := gin.H{
-			"status":   "ok",
-			"id":       sess.ID,
-			"provider": sess.AuthProvider,
-			"user":     sess.User(),
-			"data":     sess.Data(),
-			"config":   clientConfig,
-		}
-
 		// Send JSON response.
-		c.JSON(sess.HttpStatus(), data)
+		c.JSON(sess.HttpStatus(), sessionResponse(sess, conf))
 	})
 }
+
+// sessionResponse returns the user information, session data, and client config values of a new session.
+func sessionResponse(sess *entity.Session, conf *config.Config) gin.H {
+	return gin.H{
+		"status":   "ok",
+		"id":       sess.ID,
+		"provider": sess.AuthProvider,
+		"user":     sess.User(),
+		"data":     sess.Data(),
+		"config":   conf.ClientSession(sess),
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/oidc"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// oidcLogin contains the nonce and PKCE code verifier of a pending OpenID Connect login.
+type oidcLogin struct {
+	Nonce    string
+	Verifier string
+}
+
+// oidcStates maps OAuth state tokens to pending logins until the user is redirected back by the identity provider.
+var oidcStates = gc.New(15*time.Minute, 5*time.Minute)
+
+// oidcClient returns a new OpenID Connect client for the configured identity provider.
+func oidcClient(conf *config.Config) (*oidc.Client, error) {
+	return oidc.NewClient(conf.OIDCUri(), conf.OIDCClient(), conf.OIDCSecret(), conf.OIDCRedirectUrl(), conf.OIDCInsecure())
+}
+
+// OIDCLogin redirects the user to the login page of the OpenID Connect identity provider.
+//
+// GET /api/v1/oidc/login
+func OIDCLogin(router *gin.RouterGroup) {
+	router.GET("/oidc/login", func(c *gin.Context) {
+		conf := get.Config()
+
+		if !conf.OIDCEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Check limit for failed auth requests (max. 10 per minute).
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		client, err := oidcClient(conf)
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		verifier, err := oidc.NewVerifier()
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		state := rnd.Base62(32)
+		login := oidcLogin{Nonce: rnd.Base62(32), Verifier: verifier}
+		oidcStates.SetDefault(state, login)
+
+		c.Redirect(http.StatusTemporaryRedirect, client.AuthURL(state, login.Nonce, login.Verifier))
+	})
+}
+
+// OIDCRedirect creates a new client session for the user after a successful login with the identity provider.
+// Users are assigned a role based on the configured OpenID Connect claim and are created on first login if enabled.
+//
+// GET /api/v1/oidc/redirect
+func OIDCRedirect(router *gin.RouterGroup) {
+	router.GET("/oidc/redirect", func(c *gin.Context) {
+		conf := get.Config()
+
+		if !conf.OIDCEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Check limit for failed auth requests (max. 10 per minute).
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		// denied rejects the login attempt, reserves a failed login for the client, and redirects to the login page.
+		denied := func(messages []string, args ...interface{}) {
+			limiter.Login.Reserve(ClientIP(c))
+			event.AuditWarn(append([]string{ClientIP(c), "oidc login"}, messages...), args...)
+			c.Redirect(http.StatusTemporaryRedirect, conf.LoginUri())
+		}
+
+		state := clean.Token(c.Query("state"))
+		found, ok := oidcStates.Get(state)
+
+		if state == "" || !ok {
+			denied([]string{"invalid state"})
+			return
+		}
+
+		// State tokens can only be used once.
+		oidcStates.Delete(state)
+		login := found.(oidcLogin)
+
+		if e := c.Query("error"); e != "" {
+			denied([]string{"%s"}, clean.Log(e))
+			return
+		}
+
+		client, err := oidcClient(conf)
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		token, err := client.Exchange(c.Query("code"), login.Verifier)
+
+		if err != nil {
+			denied([]string{"%s"}, err)
+			return
+		}
+
+		claims, err := client.Claims(token, login.Nonce)
+
+		if err != nil {
+			denied([]string{"%s"}, err)
+			return
+		}
+
+		user, err := entity.OidcUser(claims, conf.OIDCRole(claims), conf.OIDCRegister())
+
+		if err != nil {
+			denied([]string{"subject %s", "%s"}, clean.LogQuote(claims.String("sub")), err)
+			return
+		} else if !user.CanLogIn() {
+			denied([]string{"login as %s", "account disabled"}, clean.LogQuote(user.Username()))
+			return
+		}
+
+		// Create new session.
+		sess := get.Session().New(c).SetUser(user).SetProvider(authn.ProviderOIDC)
+		user.UpdateLoginTime()
+
+		if sess, err = get.Session().Save(sess); err != nil {
+			event.AuditErr([]string{ClientIP(c), "%s"}, err)
+			AbortUnexpected(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "login as %s with %s", "succeeded"}, sess.RefID, clean.LogQuote(user.Username()), authn.ProviderOIDC.Pretty())
+		event.LoginInfo(ClientIP(c), "api", user.Username(), sess.UserAgent)
+
+		// Pass the session id to the web app, which cannot read the response headers of a redirect.
+		c.Header("Cache-Control", "no-store")
+		c.HTML(http.StatusOK, "login.gohtml", gin.H{
+			"session": gin.H{"id": sess.ID, "uri": conf.BaseUri("/library/")},
+			"config":  conf.ClientPublic(),
+		})
+	})
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+// oidcTestProvider returns a dummy identity provider that issues ID tokens with the nonce of the last login request.
+func oidcTestProvider(t *testing.T, subject string, nonce *string) *httptest.Server {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(gin.H{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/auth",
+			"token_endpoint":         srv.URL + "/token",
+			"userinfo_endpoint":      srv.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "code" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		payload, _ := json.Marshal(gin.H{
+			"iss":                srv.URL,
+			"sub":                subject,
+			"aud":                "photoprism",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"nonce":              *nonce,
+			"preferred_username": "oidc.tester",
+			"name":               "OIDC Tester",
+		})
+
+		_ = json.NewEncoder(w).Encode(gin.H{
+			"access_token": "access",
+			"id_token":     "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2ln",
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(gin.H{"sub": subject, "groups": []string{"photoprism-admins"}})
+	})
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestOIDCLogin(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCLogin(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestOIDCRedirect(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCRedirect(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/redirect?state=foo&code=code")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Login", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		app.LoadHTMLFiles(conf.TemplateFiles()...)
+		OIDCLogin(router)
+		OIDCRedirect(router)
+
+		var nonce string
+		srv := oidcTestProvider(t, "oidc-api-test", &nonce)
+
+		conf.SetAuthMode(config.AuthModePasswd)
+		conf.Options().OIDCUri = srv.URL
+		conf.Options().OIDCClient = "photoprism"
+		conf.Options().OIDCSecret = "secret"
+		conf.Options().OIDCInsecure = true
+		conf.Options().OIDCRoleMapping = "photoprism-admins=admin"
+
+		defer func() {
+			conf.SetAuthMode(config.AuthModePublic)
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+			conf.Options().OIDCSecret = ""
+			conf.Options().OIDCInsecure = false
+			conf.Options().OIDCRoleMapping = ""
+			conf.Options().OIDCRegister = false
+		}()
+
+		login := func() (state string) {
+			r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/login")
+			assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+
+			u, err := url.Parse(r.Header().Get("Location"))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, srv.URL+"/auth", u.Scheme+"://"+u.Host+u.Path)
+			assert.Equal(t, conf.OIDCRedirectUrl(), u.Query().Get("redirect_uri"))
+
+			nonce = u.Query().Get("nonce")
+
+			return u.Query().Get("state")
+		}
+
+		// Accounts are not created unless registration is enabled.
+		r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/redirect?code=code&state="+login())
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+		assert.Equal(t, conf.LoginUri(), r.Header().Get("Location"))
+		assert.Nil(t, entity.FindOidcUser("oidc-api-test"))
+
+		// State tokens cannot be used without a login request.
+		r = PerformRequest(app, http.MethodGet, "/api/v1/oidc/redirect?code=code&state=foo")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+
+		conf.Options().OIDCRegister = true
+		state := login()
+
+		r = PerformRequest(app, http.MethodGet, "/api/v1/oidc/redirect?code=code&state="+state)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "session_id")
+
+		user := entity.FindOidcUser("oidc-api-test")
+
+		if user == nil {
+			t.Fatal("user not found")
+		}
+
+		assert.Equal(t, "oidc.tester", user.Username())
+		assert.Equal(t, "OIDC Tester", user.DisplayName)
+		assert.Equal(t, acl.RoleAdmin, user.AclRole())
+		assert.True(t, user.HasProvider(authn.ProviderOIDC))
+
+		// State tokens can only be used once.
+		r = PerformRequest(app, http.MethodGet, "/api/v1/oidc/redirect?code=code&state="+state)
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+	})
+}
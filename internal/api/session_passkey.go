@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/webauthn"
+)
+
+// PasskeyLoginOptions returns the options for a passwordless login with navigator.credentials.get().
+//
+// POST /api/v1/session/passkey/options
+func PasskeyLoginOptions(router *gin.RouterGroup) {
+	router.POST("/session/passkey/options", func(c *gin.Context) {
+		conf := get.Config()
+
+		if conf.DisablePasskeys() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Check limit for failed auth requests (max. 10 per minute).
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		challenge, err := entity.NewPasskeyChallenge("")
+
+		if err != nil {
+			log.Errorf("passkeys: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		// Discoverable credentials do not require a list of allowed credentials.
+		c.JSON(http.StatusOK, webauthn.NewRequestOptions(challenge, conf.PasskeyRPID(), nil, true))
+	})
+}
+
+// CreatePasskeySession verifies the response of navigator.credentials.get() and creates
+// a new client session if the login was successful.
+//
+// POST /api/v1/session/passkey
+func CreatePasskeySession(router *gin.RouterGroup) {
+	router.POST("/session/passkey", func(c *gin.Context) {
+		conf := get.Config()
+
+		if conf.DisablePasskeys() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Check limit for failed auth requests (max. 10 per minute).
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		var f form.PasskeyLogin
+
+		if err := c.BindJSON(&f); err != nil {
+			event.AuditWarn([]string{ClientIP(c), "create session", "invalid request", "%s"}, err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// denied rejects the login attempt and reserves a failed login for the client.
+		denied := func(messages []string, args ...interface{}) {
+			limiter.Login.Reserve(ClientIP(c))
+			event.AuditWarn(append([]string{ClientIP(c), "passkey login"}, messages...), args...)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
+		}
+
+		challenge := f.Credential.Challenge()
+
+		if !entity.RedeemPasskeyChallenge(challenge, "") {
+			denied([]string{"invalid challenge"})
+			return
+		}
+
+		passkey := entity.FindPasskey(f.Credential.CredentialID())
+
+		if passkey == nil {
+			denied([]string{"unknown passkey"})
+			return
+		}
+
+		user := entity.FindUserByUID(passkey.UserUID)
+
+		// The user handle of discoverable credentials must refer to the passkey owner.
+		if user == nil || f.Credential.UserHandle() != "" && f.Credential.UserHandle() != user.UserUID {
+			denied([]string{"account not found"})
+			return
+		} else if !user.CanLogIn() {
+			denied([]string{"login as %s", "account disabled"}, clean.LogQuote(user.Username()))
+			return
+		}
+
+		signCount, err := f.Credential.Verify(challenge, conf.PasskeyOrigin(), conf.PasskeyRPID(), true, passkey.Credential())
+
+		if err != nil {
+			denied([]string{"login as %s", "%s"}, clean.LogQuote(user.Username()), err)
+			return
+		} else if err = passkey.UpdateUsage(signCount); err != nil {
+			log.Errorf("passkeys: %s", err)
+		}
+
+		// Create new session.
+		sess := get.Session().New(c).SetUser(user).SetProvider(authn.ProviderLocal).SetMethod(authn.MethodPasskey)
+		user.UpdateLoginTime()
+
+		if sess, err = get.Session().Save(sess); err != nil {
+			event.AuditErr([]string{ClientIP(c), "%s"}, err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "login as %s with passkey", "succeeded"}, sess.RefID, clean.LogQuote(user.Username()))
+		event.LoginInfo(ClientIP(c), "api", user.Username(), sess.UserAgent)
+
+		// Add session id to response headers.
+		AddSessionHeader(c, sess.ID)
+
+		c.JSON(http.StatusOK, sessionResponse(sess, conf))
+	})
+}
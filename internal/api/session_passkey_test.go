@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasskeyLoginOptions(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PasskeyLoginOptions(router)
+		r := PerformRequest(app, http.MethodPost, "/api/v1/session/passkey/options")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestCreatePasskeySession(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePasskeySession(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session/passkey", `{"credential": {}}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
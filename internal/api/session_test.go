@@ -8,6 +8,7 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/session"
@@ -92,6 +93,30 @@ func TestCreateSession(t *testing.T) {
 		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "admin", "password": "photoprism", "token": "1jxf3jfn2k"}`)
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("VisitorLinkPassword", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+
+		if err := link.SetPassword("Sh4reLinkPasswd"); err != nil {
+			t.Fatal(err)
+		} else if err = link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		CreateSession(router)
+
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", form.AsJson(form.Login{AuthToken: link.LinkToken}))
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrInvalidCredentials), gjson.Get(r.Body.String(), "error").String())
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", form.AsJson(form.Login{AuthToken: link.LinkToken, Password: "Sh4reLinkPasswd"}))
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
 	t.Run("AdminInvalidPassword", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		conf.SetAuthMode(config.AuthModePasswd)
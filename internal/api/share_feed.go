@@ -0,0 +1,299 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/feed"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+// ShareFeedLimit is the maximum number of pictures included in feeds and embedded galleries.
+const ShareFeedLimit = 100
+
+// shareThumbSizes lists the thumbnail sizes that can be requested for embedded galleries and feeds.
+var shareThumbSizes = map[thumb.Name]bool{
+	thumb.Tile500: true,
+	thumb.Fit720:  true,
+	thumb.Fit1280: true,
+}
+
+// embedLink returns the album share link of the request if it can be embedded, i.e. it is
+// valid, not password protected, and the shared album exists.
+func embedLink(token, shared string) (link entity.Link, album entity.Album, ok bool) {
+	links := entity.FindValidLinks(clean.Token(token), clean.Token(shared))
+
+	if len(links) < 1 {
+		log.Debugf("share: invalid token or slug (embed)")
+		return link, album, false
+	}
+
+	link = links[0]
+
+	if link.HasPassword {
+		log.Debugf("share: %s is password protected and cannot be embedded", link.String())
+		return link, album, false
+	}
+
+	album, err := query.AlbumByUID(link.ShareUID)
+
+	if err != nil {
+		return link, album, false
+	}
+
+	return link, album, true
+}
+
+// embedPhotos returns the public pictures of a shared album, with the most recently added first.
+func embedPhotos(link entity.Link, hash string, count int) (search.PhotoResults, error) {
+	var f form.SearchPhotos
+
+	// Embedded galleries may only contain public content.
+	f.Album = link.ShareUID
+	f.Hash = hash
+	f.Public = true
+	f.Private = false
+	f.Hidden = false
+	f.Archived = false
+	f.Review = false
+	f.Primary = true
+	f.Count = count
+	f.Order = sortby.Added
+
+	if err := f.ParseQueryString(); err != nil {
+		return nil, err
+	}
+
+	results, _, err := search.Photos(f)
+
+	return results, err
+}
+
+// embedThumbUrl returns the URL of a thumbnail in an embedded gallery or feed.
+func embedThumbUrl(shareUrl, fileHash string, size thumb.Name) string {
+	return fmt.Sprintf("%s/t/%s/%s", shareUrl, fileHash, size)
+}
+
+// shareFeed returns a feed with the public pictures of a shared album.
+func shareFeed(link entity.Link, album entity.Album, feedUrl string) (*feed.Feed, error) {
+	conf := get.Config()
+	shareUrl := link.Url(conf.SiteUrl())
+
+	results, err := embedPhotos(link, "", ShareFeedLimit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := feed.New(album.AlbumTitle, shareUrl, feedUrl)
+	f.Description = album.AlbumDescription
+
+	// Use the most recently added public picture as icon, as the album cover may be private.
+	if len(results) > 0 {
+		f.Icon = embedThumbUrl(shareUrl, results[0].FileHash, thumb.Tile500)
+	}
+
+	if author := conf.SiteAuthor(); author != "" {
+		f.Authors = []feed.Author{{Name: author, Url: conf.SiteUrl()}}
+	}
+
+	for _, p := range results {
+		image := embedThumbUrl(shareUrl, p.FileHash, thumb.Fit1280)
+
+		f.Add(feed.Item{
+			ID:            fmt.Sprintf("%s#%s", shareUrl, p.PhotoUID),
+			Url:           shareUrl,
+			Title:         p.PhotoTitle,
+			Summary:       p.PhotoDescription,
+			ContentHtml:   fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(image), html.EscapeString(p.PhotoTitle)),
+			Image:         image,
+			DatePublished: p.TakenAt,
+			DateModified:  p.UpdatedAt,
+		})
+	}
+
+	return f, nil
+}
+
+// ShareFeedJson returns the public pictures of a shared album as JSON Feed.
+//
+// GET /s/:token/:shared/feed.json
+func ShareFeedJson(router *gin.RouterGroup) {
+	router.GET("/:token/:shared/feed.json", func(c *gin.Context) {
+		link, album, ok := embedLink(c.Param("token"), c.Param("shared"))
+
+		if !ok {
+			AbortNotFound(c)
+			return
+		}
+
+		f, err := shareFeed(link, album, link.Url(get.Config().SiteUrl())+"/feed.json")
+
+		if err != nil {
+			log.Errorf("share: %s (feed)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCoverCacheHeader(c)
+
+		c.Header("Content-Type", "application/feed+json; charset=utf-8")
+		c.JSON(http.StatusOK, f)
+	})
+}
+
+// ShareFeedAtom returns the public pictures of a shared album as Atom feed.
+//
+// GET /s/:token/:shared/feed.atom
+func ShareFeedAtom(router *gin.RouterGroup) {
+	router.GET("/:token/:shared/feed.atom", func(c *gin.Context) {
+		link, album, ok := embedLink(c.Param("token"), c.Param("shared"))
+
+		if !ok {
+			AbortNotFound(c)
+			return
+		}
+
+		f, err := shareFeed(link, album, link.Url(get.Config().SiteUrl())+"/feed.atom")
+
+		if err != nil {
+			log.Errorf("share: %s (feed)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		data, err := f.AtomXML()
+
+		if err != nil {
+			log.Errorf("share: %s (atom)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCoverCacheHeader(c)
+
+		c.Data(http.StatusOK, "application/atom+xml; charset=utf-8", data)
+	})
+}
+
+// ShareEmbed renders a lightweight gallery of a shared album that can be embedded in other websites.
+//
+// GET /s/:token/:shared/embed
+func ShareEmbed(router *gin.RouterGroup) {
+	router.GET("/:token/:shared/embed", func(c *gin.Context) {
+		conf := get.Config()
+		link, album, ok := embedLink(c.Param("token"), c.Param("shared"))
+
+		if !ok {
+			AbortNotFound(c)
+			return
+		}
+
+		results, err := embedPhotos(link, "", ShareFeedLimit)
+
+		if err != nil {
+			log.Errorf("share: %s (embed)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		shareUrl := link.Url(conf.SiteUrl())
+		photos := make([]gin.H, 0, len(results))
+
+		for _, p := range results {
+			photos = append(photos, gin.H{
+				"title": p.PhotoTitle,
+				"thumb": embedThumbUrl(shareUrl, p.FileHash, thumb.Tile500),
+			})
+		}
+
+		// Allow the gallery to be displayed in frames on other websites.
+		c.Header("Content-Security-Policy", "frame-ancestors *;")
+		c.Writer.Header().Del("X-Frame-Options")
+
+		AddCoverCacheHeader(c)
+
+		c.HTML(http.StatusOK, "embed.gohtml", gin.H{
+			"title":  album.AlbumTitle,
+			"url":    shareUrl,
+			"site":   conf.SiteTitle(),
+			"photos": photos,
+		})
+	})
+}
+
+// ShareThumb returns a thumbnail of a public picture in a shared album, so that embedded
+// galleries and feeds do not require a preview token.
+//
+// GET /s/:token/:shared/t/:thumb/:size
+func ShareThumb(router *gin.RouterGroup) {
+	router.GET("/:token/:shared/t/:thumb/:size", func(c *gin.Context) {
+		conf := get.Config()
+		link, _, ok := embedLink(c.Param("token"), c.Param("shared"))
+
+		if !ok {
+			c.Data(http.StatusNotFound, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		fileHash := clean.Token(c.Param("thumb"))
+		sizeName := thumb.Name(clean.Token(c.Param("size")))
+		size, found := thumb.Sizes[sizeName]
+
+		if fileHash == "" || !found || !shareThumbSizes[sizeName] {
+			c.Data(http.StatusBadRequest, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		// The file must belong to a public picture in the shared album.
+		results, err := embedPhotos(link, fileHash, 1)
+
+		if err != nil || len(results) == 0 {
+			c.Data(http.StatusNotFound, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		p := results[0]
+		fileName := photoprism.FileName(p.FileRoot, p.FileName)
+
+		if fileName, err = fs.Resolve(fileName); err != nil {
+			log.Errorf("share: file %s is missing (thumb)", clean.Log(p.FileName))
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		var thumbName string
+
+		if conf.ThumbUncached() || size.Uncached() {
+			thumbName, err = size.FromFile(fileName, p.FileHash, conf.ThumbCachePath(), p.FileOrientation)
+		} else {
+			thumbName, err = size.FromCache(fileName, p.FileHash, conf.ThumbCachePath())
+		}
+
+		if err != nil {
+			log.Errorf("share: %s (thumb)", err)
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		var watermark *entity.Link
+
+		if link.Watermark && !conf.Watermark().Empty() {
+			watermark = &link
+		}
+
+		sendThumb(c, watermark, thumbName, "", false)
+	})
+}
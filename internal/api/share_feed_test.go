@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/feed"
+)
+
+func TestShareFeedJson(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareFeedJson(router)
+		r := PerformRequest(app, "GET", "/api/v1/1jxf3jfn2k/holiday-2030/feed.json")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, feed.Version, gjson.Get(r.Body.String(), "version").String())
+		assert.Contains(t, gjson.Get(r.Body.String(), "home_page_url").String(), "s/1jxf3jfn2k/holiday-2030")
+		assert.True(t, gjson.Get(r.Body.String(), "items").IsArray())
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareFeedJson(router)
+		r := PerformRequest(app, "GET", "/api/v1/xxx/holiday-2030/feed.json")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestShareFeedAtom(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareFeedAtom(router)
+		r := PerformRequest(app, "GET", "/api/v1/1jxf3jfn2k/holiday-2030/feed.atom")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareFeedAtom(router)
+		r := PerformRequest(app, "GET", "/api/v1/xxx/holiday-2030/feed.atom")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestShareThumb(t *testing.T) {
+	t.Run("InvalidSize", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/1jxf3jfn2k/holiday-2030/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/fit_7680")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotShared", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/1jxf3jfn2k/holiday-2030/t/0000000000000000000000000000000000000000/tile_500")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/xxx/holiday-2030/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/tile_500")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -32,12 +32,17 @@ func SharePreview(router *gin.RouterGroup) {
 
 		token := clean.Token(c.Param("token"))
 		shared := clean.Token(c.Param("shared"))
-		links := entity.FindLinks(token, shared)
+		links := entity.FindValidLinks(token, shared)
 
 		if len(links) != 1 {
 			log.Warn("share: invalid token (preview)")
 			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 			return
+		} else if links[0].HasPassword {
+			// Don't reveal the content of password protected links.
+			log.Debugf("share: %s is password protected (preview)", links[0].String())
+			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
+			return
 		}
 
 		thumbPath := path.Join(conf.ThumbCachePath(), "share")
@@ -2,11 +2,15 @@ package api
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestGetPreview(t *testing.T) {
@@ -26,4 +30,66 @@ func TestGetPreview(t *testing.T) {
 		r := PerformRequest(app, "GET", "api/v1/s/xxx/st9lxuqxpogaaba7/preview")
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("Cached", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		cached := sharePreviewFixture(t, conf, "at9lxuqxpogaaba8")
+		defer os.Remove(cached)
+
+		SharePreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/1jxf3jfn2k/at9lxuqxpogaaba8/preview")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Password", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		cached := sharePreviewFixture(t, conf, "at9lxuqxpogaaba8")
+		defer os.Remove(cached)
+
+		link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+
+		if err := link.SetPassword("Sh4reLinkPasswd"); err != nil {
+			t.Fatal(err)
+		} else if err = link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		SharePreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/"+link.LinkToken+"/at9lxuqxpogaaba8/preview")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+		assert.Equal(t, conf.SitePreview(), r.Header().Get("Location"))
+	})
+	t.Run("Expired", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		cached := sharePreviewFixture(t, conf, "at9lxuqxpogaaba8")
+		defer os.Remove(cached)
+
+		link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+		link.MaxViews = 1
+		link.LinkViews = 1
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		SharePreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/"+link.LinkToken+"/at9lxuqxpogaaba8/preview")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+		assert.Equal(t, conf.SitePreview(), r.Header().Get("Location"))
+	})
+}
+
+// sharePreviewFixture creates a cached preview image that is returned for valid links.
+func sharePreviewFixture(t *testing.T, conf *config.Config, shared string) string {
+	fileName := filepath.Join(conf.ThumbCachePath(), "share", shared+".jpg")
+
+	if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(fileName, []byte("preview"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
 }
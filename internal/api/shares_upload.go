@@ -0,0 +1,411 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ShareUploadFile represents a file uploaded by a guest.
+type ShareUploadFile struct {
+	Name string `json:"Name"`
+	Size int64  `json:"Size"`
+}
+
+// ShareUpload represents files uploaded by a guest that are waiting for review.
+type ShareUpload struct {
+	Token     string            `json:"Token"`
+	Files     []ShareUploadFile `json:"Files"`
+	Size      int64             `json:"Size"`
+	CreatedAt time.Time         `json:"CreatedAt"`
+}
+
+// UploadShareFiles lets guests without an account upload pictures to an album with a share link
+// that allows uploads, e.g. to collect the photos taken at a wedding. Depending on the link settings,
+// the files are either added to the album right away or kept until they have been reviewed.
+//
+// POST /api/v1/shares/:token/upload
+func UploadShareFiles(router *gin.RouterGroup) {
+	router.POST("/shares/:token/upload", func(c *gin.Context) {
+		conf := get.Config()
+
+		// Abort in read-only mode or when the upload feature is disabled.
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		// Limit the number of failed attempts to guess the token or password.
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		token := clean.ShareToken(c.Param("token"))
+		link := entity.FindValidLinks(token, "").UploadLink()
+
+		if link == nil {
+			limiter.Login.Reserve(ClientIP(c))
+			event.AuditWarn([]string{ClientIP(c), "share token %s", "upload files", "invalid link"}, clean.LogQuote(token))
+			Abort(c, http.StatusNotFound, i18n.ErrInvalidLink)
+			return
+		}
+
+		a, err := query.AlbumByUID(link.ShareUID)
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		var password string
+
+		if values := f.Value["password"]; len(values) > 0 {
+			password = values[0]
+		}
+
+		if link.InvalidPassword(password) {
+			limiter.Login.Reserve(ClientIP(c))
+			event.AuditWarn([]string{ClientIP(c), "link %s", "upload files", "invalid password"}, clean.Log(link.RefID))
+			Abort(c, http.StatusUnauthorized, i18n.ErrInvalidPassword)
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		// Check the size and type limits of the share link.
+		var uploadSize int64
+
+		for _, file := range files {
+			if !link.AllowUpload(file.Filename, file.Size) {
+				event.AuditWarn([]string{ClientIP(c), "link %s", "upload files", "%s is not allowed"}, clean.Log(link.RefID), clean.Log(filepath.Base(file.Filename)))
+
+				if limit := link.UploadLimitBytes(); limit > 0 && file.Size > limit {
+					Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+				} else {
+					Abort(c, http.StatusUnsupportedMediaType, i18n.ErrUnsupportedFormat)
+				}
+
+				return
+			}
+
+			uploadSize += file.Size
+		}
+
+		// Reject the upload if it would exceed the storage quota of the link owner.
+		if owner := entity.FindUserByUID(link.CreatedBy); owner != nil && owner.QuotaExceeded(uploadSize) {
+			event.AuditWarn([]string{ClientIP(c), "link %s", "upload files", "storage quota exceeded"}, clean.Log(link.RefID))
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrQuotaExceeded)
+			return
+		}
+
+		start := time.Now()
+		uploadPath, err := conf.ShareUploadPath(a.AlbumUID, rnd.GenerateToken(10))
+
+		if err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		var uploads []string
+
+		// Save uploaded files.
+		for _, file := range files {
+			fileName := filepath.Base(file.Filename)
+			filePath := path.Join(uploadPath, fileName)
+
+			if err = c.SaveUploadedFile(file, filePath); err != nil {
+				log.Errorf("upload: failed saving file %s", clean.Log(fileName))
+				logError("upload", os.RemoveAll(uploadPath))
+				Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+				return
+			}
+
+			uploads = append(uploads, filePath)
+		}
+
+		// Check if uploaded files are safe.
+		if !conf.UploadNSFW() && removeOffensiveUploads(uploads) {
+			logError("upload", os.RemoveAll(uploadPath))
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "link %s", "uploaded %s to album %s"}, clean.Log(link.RefID), english.Plural(len(uploads), "file", "files"), clean.Log(a.AlbumUID))
+
+		// Keep the files until they have been reviewed?
+		if link.UploadReview {
+			log.Infof("upload: %s waiting for review in %s [%s]", english.Plural(len(uploads), "file", "files"), clean.Log(a.AlbumUID), time.Since(start))
+			c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUploadPendingReview))
+			return
+		}
+
+		importShareUpload(c, a, uploadPath, link.CreatedBy)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUploadProcessed))
+	})
+}
+
+// importShareUpload imports files uploaded by guests and adds them to the shared album.
+func importShareUpload(c *gin.Context, a entity.Album, uploadPath, userUid string) {
+	conf := get.Config()
+	start := time.Now()
+
+	// Get destination folder.
+	destFolder := conf.ImportDest()
+
+	if user := entity.FindUserByUID(userUid); user != nil && user.GetUploadPath() != "" {
+		destFolder = user.GetUploadPath()
+	}
+
+	opt := photoprism.ImportOptionsUpload(uploadPath, destFolder)
+	opt.Albums = []string{a.AlbumUID}
+
+	// Set user UID if known.
+	if rnd.IsUID(userUid, entity.UserUID) {
+		opt.UID = userUid
+	}
+
+	// Start import.
+	imported := get.Import().Start(opt)
+
+	// Delete remaining files, e.g. duplicates.
+	if err := os.RemoveAll(uploadPath); err != nil {
+		log.Errorf("upload: failed deleting folder %s: %s", clean.Log(uploadPath), err)
+	}
+
+	// Update moments if files have been imported.
+	if n := len(imported); n == 0 {
+		log.Infof("upload: no new files imported")
+	} else {
+		log.Infof("upload: imported %s to album %s [%s]", english.Plural(n, "file", "files"), clean.Log(a.AlbumUID), time.Since(start))
+
+		if moments := get.Moments(); moments == nil {
+			log.Warnf("upload: moments service not set - possible bug")
+		} else if err := moments.Start(); err != nil {
+			log.Warnf("moments: %s", err)
+		}
+	}
+
+	PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+	// Update the user interface.
+	UpdateClientConfig()
+
+	// Update album, label, and subject cover thumbs.
+	if err := query.UpdateCovers(); err != nil {
+		log.Warnf("upload: %s (update covers)", err)
+	}
+}
+
+// shareUploads returns the files uploaded by guests that are waiting for review.
+func shareUploads(albumUid string) (result []ShareUpload, err error) {
+	result = []ShareUpload{}
+
+	dir, err := get.Config().ShareUploadPath(albumUid, "")
+
+	if err != nil {
+		return result, err
+	}
+
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		upload := ShareUpload{Token: entry.Name(), Files: []ShareUploadFile{}}
+
+		if info, infoErr := entry.Info(); infoErr == nil {
+			upload.CreatedAt = info.ModTime().UTC()
+		}
+
+		files, readErr := os.ReadDir(filepath.Join(dir, entry.Name()))
+
+		if readErr != nil {
+			log.Warnf("upload: %s", readErr)
+			continue
+		}
+
+		for _, file := range files {
+			if info, infoErr := file.Info(); infoErr == nil && info.Mode().IsRegular() {
+				upload.Files = append(upload.Files, ShareUploadFile{Name: file.Name(), Size: info.Size()})
+				upload.Size += info.Size()
+			}
+		}
+
+		if len(upload.Files) > 0 {
+			result = append(result, upload)
+		}
+	}
+
+	// Show the oldest uploads first.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// shareUploadPath returns the folder of a pending guest upload, or aborts the request.
+func shareUploadPath(c *gin.Context) (a entity.Album, uploadPath string, ok bool) {
+	a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+	if err != nil {
+		AbortAlbumNotFound(c)
+		return a, "", false
+	}
+
+	token := clean.Token(c.Param("token"))
+
+	if token == "" {
+		AbortBadRequest(c)
+		return a, "", false
+	}
+
+	dir, err := get.Config().ShareUploadPath(a.AlbumUID, "")
+
+	if err != nil {
+		AbortUnexpected(c)
+		return a, "", false
+	}
+
+	if uploadPath = filepath.Join(dir, token); !fs.PathExists(uploadPath) {
+		AbortNotFound(c)
+		return a, "", false
+	}
+
+	return a, uploadPath, true
+}
+
+// GetShareUploads returns the files uploaded by guests with a share link that are waiting for review.
+//
+// GET /api/v1/albums/:uid/uploads
+func GetShareUploads(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/uploads", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		results, err := shareUploads(a.AlbumUID)
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// ApproveShareUpload adds files uploaded by guests to the shared album after they have been reviewed.
+//
+// POST /api/v1/albums/:uid/uploads/:token
+func ApproveShareUpload(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/uploads/:token", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Import {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		a, uploadPath, ok := shareUploadPath(c)
+
+		if !ok {
+			return
+		}
+
+		event.InfoMsg(i18n.MsgProcessingUpload)
+
+		importShareUpload(c, a, uploadPath, s.UserUID)
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "approved guest upload %s for album %s"}, s.RefID, clean.Log(filepath.Base(uploadPath)), clean.Log(a.AlbumUID))
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUploadProcessed))
+	})
+}
+
+// RejectShareUpload deletes files uploaded by guests that should not be added to the shared album.
+//
+// DELETE /api/v1/albums/:uid/uploads/:token
+func RejectShareUpload(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/uploads/:token", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		a, uploadPath, ok := shareUploadPath(c)
+
+		if !ok {
+			return
+		}
+
+		if err := os.RemoveAll(uploadPath); err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusInternalServerError, i18n.ErrDeleteFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "rejected guest upload %s for album %s"}, s.RefID, clean.Log(filepath.Base(uploadPath)), clean.Log(a.AlbumUID))
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgFileDeleted))
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+func TestUploadShareFiles(t *testing.T) {
+	t.Run("InvalidLink", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadShareFiles(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/shares/xxx/upload", "{foo:123}")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrInvalidLink), gjson.Get(r.Body.String(), "error").String())
+	})
+	t.Run("UploadNotAllowed", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadShareFiles(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/shares/4jxf3jfn2k/upload", "{foo:123}")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetShareUploads(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		dir, err := conf.ShareUploadPath("at9lxuqxpogaaba8", "test1234")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Dir(dir))
+
+		if err = os.WriteFile(filepath.Join(dir, "IMG_1234.jpg"), []byte("test"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		GetShareUploads(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/albums/at9lxuqxpogaaba8/uploads")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "test1234", gjson.Get(r.Body.String(), "0.Token").String())
+		assert.Equal(t, "IMG_1234.jpg", gjson.Get(r.Body.String(), "0.Files.0.Name").String())
+		assert.Equal(t, int64(4), gjson.Get(r.Body.String(), "0.Size").Int())
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetShareUploads(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/albums/at9lxuqxpogaxxxx/uploads")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestRejectShareUpload(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		dir, err := get.Config().ShareUploadPath("at9lxuqxpogaaba8", "reject12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Dir(dir))
+
+		RejectShareUpload(router)
+		r := PerformRequest(app, http.MethodDelete, "/api/v1/albums/at9lxuqxpogaaba8/uploads/reject12")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NoDirExists(t, dir)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RejectShareUpload(router)
+		r := PerformRequest(app, http.MethodDelete, "/api/v1/albums/at9lxuqxpogaaba8/uploads/missing1")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
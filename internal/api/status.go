@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/get"
 )
 
 // GetStatus reports if the server is operational.
@@ -14,3 +16,20 @@ func GetStatus(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, gin.H{"status": "operational"})
 	})
 }
+
+// GetReady reports if the server is ready, which is not the case while the
+// TensorFlow models are being loaded in the background after startup.
+//
+// GET /api/v1/ready
+func GetReady(router *gin.RouterGroup) {
+	router.GET("/ready", func(c *gin.Context) {
+		models := get.ModelStatus()
+
+		if loading := get.ModelsLoading(); len(loading) > 0 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "loading", "models": models})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "models": models})
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/get"
+)
+
+// GetDiskStatus reports the free disk space of the storage paths and if background jobs have been paused.
+//
+// GET /api/v1/status/disk
+func GetDiskStatus(router *gin.RouterGroup) {
+	router.GET("/status/disk", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceConfig, acl.AccessAll)
+		conf := get.Config()
+
+		// Abort if permission was not granted.
+		if s.Invalid() || conf.Public() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"low":     conf.DiskSpaceLow(),
+			"minFree": conf.MinFreeBytes(),
+			"paths":   conf.DiskUsage(),
+		})
+	})
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDiskStatus(t *testing.T) {
+	t.Run("unauthorised", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDiskStatus(router)
+		r := PerformRequest(app, "GET", "/api/v1/status/disk")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
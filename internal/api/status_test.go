@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/get"
 )
 
 func TestGetStatus(t *testing.T) {
@@ -18,3 +20,18 @@ func TestGetStatus(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestGetReady(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetReady(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/ready")
+
+	if len(get.ModelsLoading()) > 0 {
+		assert.Equal(t, "loading", gjson.Get(r.Body.String(), "status").String())
+		assert.Equal(t, http.StatusServiceUnavailable, r.Code)
+	} else {
+		assert.Equal(t, "ready", gjson.Get(r.Body.String(), "status").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	}
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// fetchFile downloads a file from the object storage if it is missing in the local cache,
+// so that originals and sidecar files do not need to be kept on the server.
+func fetchFile(fileName string) {
+	if err := get.Storage().Fetch(fileName); err != nil && err != vfs.ErrNotFound {
+		log.Errorf("storage: %s while downloading %s", err, clean.Log(filepath.Base(fileName)))
+	}
+}
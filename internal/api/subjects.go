@@ -63,6 +63,7 @@ func UpdateSubject(router *gin.RouterGroup) {
 
 		// Initialize form.
 		f, err := form.NewSubject(*m)
+		before := f
 
 		if err != nil {
 			log.Errorf("subject: %s (new form)", err)
@@ -80,6 +81,8 @@ func UpdateSubject(router *gin.RouterGroup) {
 			AbortSaveFailed(c)
 			return
 		} else if changed {
+			recordChange(s, entity.ChangeSubject, uid, entity.ChangeActionUpdate, before, f)
+
 			if m.IsPerson() {
 				event.SuccessMsg(i18n.MsgPersonSaved)
 			} else {
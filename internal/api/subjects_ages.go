@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetSubjectAges returns the number of pictures per age of a subject with a known date of birth.
+//
+// GET /api/v1/subjects/:uid/ages
+func GetSubjectAges(router *gin.RouterGroup) {
+	router.GET("/subjects/:uid/ages", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		subj := entity.FindSubject(clean.UID(c.Param("uid")))
+
+		if subj == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		results, err := query.SubjectAgeGroups(subj.SubjUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubjectAges(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectAges(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqy1y111h1njaaac/ages")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectAges(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqy1y111h1njxxxx/ages")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
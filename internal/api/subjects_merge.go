@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// MergeSubjects merges a subject into the target subject, e.g. if two people are the same person.
+//
+// POST /api/v1/subjects/:uid/merge
+//
+// Parameters:
+//
+//	uid: string Subject UID
+func MergeSubjects(router *gin.RouterGroup) {
+	router.POST("/subjects/:uid/merge", func(c *gin.Context) {
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		s := Auth(c, acl.ResourcePeople, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.SubjectMerge
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		m := entity.FindSubject(uid)
+		target := entity.FindSubject(clean.UID(f.Target))
+
+		if m == nil || target == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		} else if m.SubjUID == target.SubjUID || m.SubjType != target.SubjType {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.MergeWith(target); err != nil {
+			log.Errorf("subject: %s (merge)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Merge face clusters that now belong to the same subject.
+		if res, err := get.Faces().Optimize(); err != nil {
+			log.Errorf("faces: %s (optimize)", err)
+		} else if res.Merged > 0 {
+			log.Infof("faces: merged %s", english.Plural(res.Merged, "cluster", "clusters"))
+		}
+
+		if err := query.UpdateSubjectCovers(); err != nil {
+			log.Errorf("faces: %s (update covers)", err)
+		}
+
+		if err := entity.UpdateSubjectCounts(); err != nil {
+			log.Errorf("faces: %s (update counts)", err)
+		}
+
+		PublishSubjectEvent(EntityUpdated, target.SubjUID, c)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, entity.FindSubject(target.SubjUID))
+	})
+}
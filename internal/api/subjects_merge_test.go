@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSubjects(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MergeSubjects(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/xxx1y111h1njaaaa/merge", `{"Target": "jqy3y652h8njw0sx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("SameSubject", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MergeSubjects(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqy3y652h8njw0sx/merge", `{"Target": "jqy3y652h8njw0sx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MergeSubjects(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqy3y652h8njw0sx/merge", `{"Target": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
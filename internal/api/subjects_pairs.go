@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// peoplePairs responds with the number of pictures people appear in together, optionally limited to a subject.
+func peoplePairs(c *gin.Context, subjUID string) {
+	limit := txt.Int(c.Query("count"))
+	offset := txt.Int(c.Query("offset"))
+	minCount := txt.Int(c.Query("min"))
+
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	results, err := query.PeoplePairs(subjUID, minCount, limit, offset)
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+		return
+	}
+
+	AddCountHeader(c, len(results))
+	AddLimitHeader(c, limit)
+	AddOffsetHeader(c, offset)
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetPeoplePairs returns people who appear together in pictures, e.g. for a relationship graph.
+//
+// GET /api/v1/subjects/pairs
+//
+// Parameters:
+//
+//	count: int Maximum number of results, default is 100
+//	offset: int Result offset
+//	min: int Minimum number of shared pictures, default is 1
+func GetPeoplePairs(router *gin.RouterGroup) {
+	router.GET("/subjects/pairs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		peoplePairs(c, "")
+	})
+}
+
+// GetSubjectPairs returns the people who appear together with a subject.
+//
+// GET /api/v1/subjects/:uid/pairs
+func GetSubjectPairs(router *gin.RouterGroup) {
+	router.GET("/subjects/:uid/pairs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		subj := entity.FindSubject(clean.UID(c.Param("uid")))
+
+		if subj == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		peoplePairs(c, subj.SubjUID)
+	})
+}
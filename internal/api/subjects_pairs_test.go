@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPeoplePairs(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPeoplePairs(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/pairs?count=10&min=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestGetSubjectPairs(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectPairs(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqy1y111h1njaaac/pairs")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectPairs(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqy1y111h1njxxxx/pairs")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
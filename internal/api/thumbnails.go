@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -37,8 +38,15 @@ func GetThumb(router *gin.RouterGroup) {
 		start := time.Now()
 		conf := get.Config()
 		download := c.Query("download") != ""
+		link := watermarkLink(PreviewToken(c))
 		fileHash, cropArea := crop.ParseThumb(clean.Token(c.Param("thumb")))
 
+		// Don't show thumbnails of photos in locked albums.
+		if LockedFile(PreviewToken(c), fileHash) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		// Is cropped thumbnail?
 		if cropArea != "" {
 			cropName := crop.Name(clean.Token(c.Param("size")))
@@ -63,15 +71,7 @@ func GetThumb(router *gin.RouterGroup) {
 				return
 			}
 
-			// Add HTTP cache header.
-			AddImmutableCacheHeader(c)
-
-			if download {
-				c.FileAttachment(fileName, cropName.Jpeg())
-			} else {
-				c.File(fileName)
-			}
-
+			sendThumb(c, link, fileName, cropName.Jpeg(), download)
 			return
 		}
 
@@ -109,26 +109,14 @@ func GetThumb(router *gin.RouterGroup) {
 				return
 			}
 
-			// Add HTTP cache header.
-			AddImmutableCacheHeader(c)
-
-			if download {
-				c.FileAttachment(cached.FileName, cached.ShareName)
-			} else {
-				c.File(cached.FileName)
-			}
-
+			sendThumb(c, link, cached.FileName, cached.ShareName, download)
 			return
 		}
 
 		// Return existing thumbs straight away.
 		if !download {
 			if fileName, err := size.ResolvedName(fileHash, conf.ThumbCachePath()); err == nil {
-				// Add HTTP cache header.
-				AddImmutableCacheHeader(c)
-
-				// Return requested content.
-				c.File(fileName)
+				sendThumb(c, link, fileName, "", false)
 				return
 			}
 		}
@@ -158,6 +146,7 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fetchFile(fileName)
 
 		if fileName, err = fs.Resolve(fileName); err != nil {
 			log.Errorf("%s: file %s is missing", logPrefix, clean.Log(f.FileName))
@@ -187,11 +176,14 @@ func GetThumb(router *gin.RouterGroup) {
 		if size.ExceedsLimit() && !download {
 			log.Debugf("%s: using original, size exceeds limit (width %d, height %d)", logPrefix, size.Width, size.Height)
 
-			// Add HTTP cache header.
-			AddImmutableCacheHeader(c)
+			// Watermarked copies of originals are cached by file hash, as their names may not be unique.
+			if fileName, err = watermarkFileName(link, fileName, f.FileHash, f.FileOrientation); err != nil {
+				log.Errorf("%s: %s (watermark)", logPrefix, err)
+				c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+				return
+			}
 
-			// Return requested content.
-			c.File(fileName)
+			sendThumb(c, nil, fileName, "", false)
 			return
 		}
 
@@ -220,14 +212,30 @@ func GetThumb(router *gin.RouterGroup) {
 		cache.SetDefault(cacheKey, ThumbCache{thumbName, f.ShareBase(0)})
 		log.Debugf("cached %s [%s]", cacheKey, time.Since(start))
 
-		// Add HTTP cache header.
-		AddImmutableCacheHeader(c)
+		sendThumb(c, link, thumbName, f.DownloadName(DownloadName(c), 0), download)
+	})
+}
+
+// sendThumb sends the thumbnail file with an immutable cache header, and draws a watermark
+// on it first if the share link has watermarks enabled.
+func sendThumb(c *gin.Context, link *entity.Link, fileName, downloadName string, download bool) {
+	if link != nil {
+		var err error
 
-		// Return requested content.
-		if download {
-			c.FileAttachment(thumbName, f.DownloadName(DownloadName(c), 0))
-		} else {
-			c.File(thumbName)
+		if fileName, err = watermarkFileName(link, fileName, filepath.Base(fileName), 0); err != nil {
+			log.Errorf("thumb: %s (watermark)", err)
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
 		}
-	})
+	}
+
+	// Add HTTP cache header.
+	AddImmutableCacheHeader(c)
+
+	// Return requested content.
+	if download {
+		c.FileAttachment(fileName, downloadName)
+	} else {
+		c.File(fileName)
+	}
 }
@@ -7,9 +7,53 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestGetThumb(t *testing.T) {
+	t.Run("LockedAlbum", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetThumb(router)
+		GetDownload(router)
+
+		sess := entity.SessionFixtures.Pointer("alice")
+		fileHash := entity.FileFixtures.Pointer("exampleDNGFile.dng").FileHash
+		token := "locktest"
+
+		entity.PreviewToken.Set(token, sess.ID)
+		entity.DownloadToken.Set(token, sess.ID)
+
+		a := entity.NewUserAlbum("Locked Thumbs", entity.AlbumManual, sess.UserUID)
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			entity.PreviewToken.Unset(token)
+			entity.DownloadToken.Unset(token)
+			assert.NoError(t, entity.UnscopedDb().Delete(entity.PhotoAlbum{}, "album_uid = ?", a.AlbumUID).Error)
+			assert.NoError(t, a.DeletePermanently())
+		}()
+
+		a.AddPhotos([]string{entity.PhotoFixtures.Pointer("Photo01").PhotoUID})
+
+		if err := a.Lock("1234"); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/t/"+fileHash+"/"+token+"/tile_500")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/dl/"+fileHash+"?t="+token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		// Locks do not apply to other users.
+		entity.PreviewToken.Set(token, entity.SessionFixtures.Pointer("bob").ID)
+
+		r = PerformRequest(app, "GET", "/api/v1/t/"+fileHash+"/"+token+"/tile_500")
+		assert.NotEqual(t, http.StatusForbidden, r.Code)
+	})
 	t.Run("InvalidType", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetThumb(router)
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GetTrash returns the number and size of archived pictures by user. Users who
+// are not allowed to access all pictures only see their own trash.
+//
+// GET /api/v1/trash
+func GetTrash(router *gin.RouterGroup) {
+	router.GET("/trash", func(c *gin.Context) {
+		s := AuthAny(c, acl.ResourcePhotos, acl.Permissions{acl.AccessAll, acl.AccessOwn})
+
+		if s.Abort(c) {
+			return
+		}
+
+		userUID := ""
+
+		if acl.Resources.Deny(acl.ResourcePhotos, s.User().AclRole(), acl.AccessAll) {
+			userUID = s.User().UserUID
+		}
+
+		result, err := query.UsersTrashUsage(userUID)
+
+		if err != nil {
+			log.Errorf("trash: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ExportUserData streams a zip archive with the originals, sidecar files, metadata, albums, and shares of
+// a user account, e.g. for data portability requests. See photoprism.UserExportReadme for the layout.
+//
+// GET /api/v1/users/:uid/export
+func ExportUserData(router *gin.RouterGroup) {
+	router.GET("/users/:uid/export", func(c *gin.Context) {
+		s, u := authUserOrManager(c)
+
+		if u == nil {
+			return
+		}
+
+		zipFileName := photoprism.UserExportName(u)
+
+		AddDownloadHeader(c, zipFileName)
+		AddContentTypeHeader(c, "application/zip")
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "user %s", "export data"}, s.RefID, clean.Log(u.UserUID))
+
+		// Errors cannot be reported to the client once the archive is being streamed.
+		if _, err := photoprism.ExportUser(get.Config(), c.Writer, u); err != nil {
+			log.Errorf("export: %s (%s)", err, clean.Log(zipFileName))
+		}
+	})
+}
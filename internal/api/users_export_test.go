@@ -0,0 +1,58 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+func TestExportUserData(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportUserData(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/users/uqxetse3cy5eo9z2/export")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Alice", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		ExportUserData(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		r := AuthenticatedRequest(app, http.MethodGet, "/api/v1/users/uqxetse3cy5eo9z2/export", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "photoprism-alice-")
+
+		zr, err := zip.NewReader(bytes.NewReader(r.Body.Bytes()), int64(r.Body.Len()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := make([]string, len(zr.File))
+
+		for i, f := range zr.File {
+			names[i] = f.Name
+		}
+
+		assert.Contains(t, names, photoprism.UserExportReadmeFile)
+		assert.Contains(t, names, photoprism.UserExportAccountFile)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		ExportUserData(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := AuthenticatedRequest(app, http.MethodGet, "/api/v1/users/uqxetse3cy5eo9z2/export", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
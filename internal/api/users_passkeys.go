@@ -0,0 +1,173 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/webauthn"
+)
+
+// authPasskeyOwner checks if the current session may manage the passkeys of the user specified in the request path.
+func authPasskeyOwner(c *gin.Context) *entity.Session {
+	if get.Config().DisablePasskeys() {
+		AbortFeatureDisabled(c)
+		return nil
+	}
+
+	s := AuthAny(c, acl.ResourceUsers, acl.Permissions{acl.AccessOwn, acl.ActionUpdate})
+
+	if s.Abort(c) {
+		return nil
+	}
+
+	// Users may only manage their own passkeys, and scoped access tokens cannot be used.
+	if uid := clean.UID(c.Param("uid")); s.User().UserUID != uid || s.NotRegistered() {
+		AbortForbidden(c)
+		return nil
+	} else if s.IsAccessToken() {
+		event.AuditErr([]string{ClientIP(c), "session %s", "manage passkeys", "access tokens not allowed"}, s.RefID)
+		AbortForbidden(c)
+		return nil
+	}
+
+	return s
+}
+
+// SearchUserPasskeys returns the passkeys registered by the currently authenticated user.
+//
+// GET /api/v1/users/:uid/passkeys
+func SearchUserPasskeys(router *gin.RouterGroup) {
+	router.GET("/users/:uid/passkeys", func(c *gin.Context) {
+		s := authPasskeyOwner(c)
+
+		if s == nil {
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindPasskeys(s.UserUID))
+	})
+}
+
+// UserPasskeyOptions returns the options for registering a new passkey with navigator.credentials.create().
+//
+// POST /api/v1/users/:uid/passkeys/options
+func UserPasskeyOptions(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passkeys/options", func(c *gin.Context) {
+		s := authPasskeyOwner(c)
+
+		if s == nil {
+			return
+		}
+
+		conf := get.Config()
+		u := s.User()
+
+		challenge, err := entity.NewPasskeyChallenge(u.UserUID)
+
+		if err != nil {
+			log.Errorf("passkeys: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		// Prevent registering the same authenticator twice.
+		passkeys := entity.FindPasskeys(u.UserUID)
+		exclude := make([]webauthn.Descriptor, len(passkeys))
+
+		for i := range passkeys {
+			exclude[i] = passkeys[i].Descriptor()
+		}
+
+		c.JSON(http.StatusOK, webauthn.NewCreationOptions(
+			challenge,
+			webauthn.RelyingParty{ID: conf.PasskeyRPID(), Name: conf.SiteTitle()},
+			webauthn.User{ID: webauthn.Encode([]byte(u.UserUID)), Name: u.Username(), DisplayName: u.FullName()},
+			exclude,
+			true,
+		))
+	})
+}
+
+// CreateUserPasskey verifies the response of navigator.credentials.create() and registers the new passkey.
+//
+// POST /api/v1/users/:uid/passkeys
+func CreateUserPasskey(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passkeys", func(c *gin.Context) {
+		s := authPasskeyOwner(c)
+
+		if s == nil {
+			return
+		}
+
+		var f form.Passkey
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		conf := get.Config()
+		challenge := f.Credential.Challenge()
+
+		if !entity.RedeemPasskeyChallenge(challenge, s.UserUID) {
+			event.AuditWarn([]string{ClientIP(c), "session %s", "register passkey", "invalid challenge"}, s.RefID)
+			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
+			return
+		}
+
+		cred, err := f.Credential.Verify(challenge, conf.PasskeyOrigin(), conf.PasskeyRPID(), true)
+
+		if err != nil {
+			event.AuditWarn([]string{ClientIP(c), "session %s", "register passkey", "%s"}, s.RefID, err)
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		m := entity.NewPasskey(s.UserUID, f.Name, cred)
+
+		if err = m.Create(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "registered passkey %s"}, s.RefID, clean.Log(m.PasskeyName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteUserPasskey removes a passkey of the currently authenticated user.
+//
+// DELETE /api/v1/users/:uid/passkeys/:id
+func DeleteUserPasskey(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/passkeys/:id", func(c *gin.Context) {
+		s := authPasskeyOwner(c)
+
+		if s == nil {
+			return
+		}
+
+		m := entity.FindPasskey(c.Param("id"))
+
+		if m == nil || m.UserUID != s.UserUID {
+			AbortEntityNotFound(c)
+			return
+		} else if err := m.Delete(); err != nil {
+			log.Errorf("passkeys: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "deleted passkey %s"}, s.RefID, clean.Log(m.PasskeyName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
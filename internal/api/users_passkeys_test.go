@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserPasskeyOptions(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UserPasskeyOptions(router)
+		r := PerformRequest(app, http.MethodPost, "/api/v1/users/uqxetse3cy5eo9z2/passkeys/options")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestSearchUserPasskeys(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchUserPasskeys(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/users/uqxetse3cy5eo9z2/passkeys")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
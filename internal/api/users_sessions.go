@@ -0,0 +1,158 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// userSession represents a session in the list of active sessions of a user.
+type userSession struct {
+	entity.Session
+	IdToken string `json:"IdToken,omitempty"`
+	Current bool   `json:"Current"`
+}
+
+// authUserOrManager checks if the current session may manage the account of the user specified in the request path
+// and returns the user, which is the case for the user themselves and for admins.
+func authUserOrManager(c *gin.Context) (*entity.Session, *entity.User) {
+	if get.Config().Public() {
+		AbortForbidden(c)
+		return nil, nil
+	}
+
+	s := AuthAny(c, acl.ResourceUsers, acl.Permissions{acl.AccessOwn, acl.ActionUpdate})
+
+	if s.Abort(c) {
+		return nil, nil
+	} else if !s.Scope().Full() || s.NotRegistered() {
+		AbortForbidden(c)
+		return nil, nil
+	}
+
+	uid := clean.UID(c.Param("uid"))
+
+	if s.User().UserUID == uid {
+		return s, s.User()
+	} else if !acl.Resources.AllowAll(acl.ResourceUsers, s.User().AclRole(), acl.Permissions{acl.AccessAll, acl.ActionManage}) {
+		AbortForbidden(c)
+		return nil, nil
+	} else if u := entity.FindUserByUID(uid); u == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		return nil, nil
+	} else {
+		return s, u
+	}
+}
+
+// SearchUserSessions returns the active sessions and access tokens of a user.
+//
+// GET /api/v1/users/:uid/sessions
+func SearchUserSessions(router *gin.RouterGroup) {
+	router.GET("/users/:uid/sessions", func(c *gin.Context) {
+		s, u := authUserOrManager(c)
+
+		if u == nil {
+			return
+		}
+
+		sessions := entity.FindUserSessions(u.UserUID)
+		result := make([]userSession, len(sessions))
+
+		for i := range sessions {
+			result[i] = userSession{Session: sessions[i], Current: sessions[i].ID == s.ID}
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// UpdateUserSession changes the client name of a user session.
+//
+// PUT /api/v1/users/:uid/sessions/:id
+func UpdateUserSession(router *gin.RouterGroup) {
+	router.PUT("/users/:uid/sessions/:id", func(c *gin.Context) {
+		s, u := authUserOrManager(c)
+
+		if u == nil {
+			return
+		}
+
+		m := entity.FindUserSession(u.UserUID, clean.ID(c.Param("id")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		f := form.Session{ClientName: m.ClientName}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if err = m.SetClientName(f.ClientName); err != nil {
+			log.Errorf("sessions: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "renamed session %s"}, s.RefID, m.RefID)
+
+		c.JSON(http.StatusOK, userSession{Session: *m, Current: m.ID == s.ID})
+	})
+}
+
+// DeleteUserSession revokes a session or access token of a user.
+//
+// DELETE /api/v1/users/:uid/sessions/:id
+func DeleteUserSession(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions/:id", func(c *gin.Context) {
+		s, u := authUserOrManager(c)
+
+		if u == nil {
+			return
+		}
+
+		m := entity.FindUserSession(u.UserUID, clean.ID(c.Param("id")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if err := m.Delete(); err != nil {
+			log.Errorf("sessions: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "revoked session %s of %s"}, s.RefID, m.RefID, clean.LogQuote(u.Username()))
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": m.RefID})
+	})
+}
+
+// DeleteUserSessions revokes all sessions and access tokens of a user, except the current session.
+//
+// DELETE /api/v1/users/:uid/sessions
+func DeleteUserSessions(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions", func(c *gin.Context) {
+		s, u := authUserOrManager(c)
+
+		if u == nil {
+			return
+		}
+
+		deleted := u.DeleteSessions([]string{s.ID})
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "revoked %d sessions of %s"}, s.RefID, deleted, clean.LogQuote(u.Username()))
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "deleted": deleted})
+	})
+}
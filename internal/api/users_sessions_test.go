@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUserSessions(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchUserSessions(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/users/uqxetse3cy5eo9z2/sessions")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUserSessions(router)
+		r := PerformRequest(app, http.MethodDelete, "/api/v1/users/uqxetse3cy5eo9z2/sessions")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// CreateUserAccessToken creates a new access token with limited scope for the currently authenticated user,
+// e.g. a read-only token for a digital photo frame.
+//
+// POST /api/v1/users/:uid/tokens
+func CreateUserAccessToken(router *gin.RouterGroup) {
+	router.POST("/users/:uid/tokens", func(c *gin.Context) {
+		conf := get.Config()
+
+		if conf.Public() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		s := AuthAny(c, acl.ResourceUsers, acl.Permissions{acl.AccessOwn, acl.ActionUpdate})
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Users may only create tokens for their own account, and scoped tokens cannot create new tokens.
+		if uid := clean.UID(c.Param("uid")); s.User().UserUID != uid || s.NotRegistered() {
+			AbortForbidden(c)
+			return
+		} else if !s.Scope().Full() {
+			event.AuditErr([]string{ClientIP(c), "session %s", "create access token", "scope %s", "denied"}, s.RefID, s.Scope().String())
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.AccessToken
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		scope := acl.ParseScope(f.Scope)
+
+		if !scope.Valid() || f.ExpiresIn < 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
+			return
+		}
+
+		token := entity.NewAccessToken(s.User(), f.ClientName, scope, f.ExpiresIn)
+		token.SetClientIP(ClientIP(c))
+
+		if err := token.Create(); err != nil {
+			log.Errorf("auth: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "created access token %s", "scope %s"}, s.RefID, token.RefID, scope.String())
+
+		c.JSON(http.StatusOK, gin.H{
+			"access_token": token.ID,
+			"token_type":   "Bearer",
+			"id":           token.RefID,
+			"name":         token.ClientName,
+			"scope":        scope.String(),
+			"expires_in":   f.ExpiresIn,
+		})
+	})
+}
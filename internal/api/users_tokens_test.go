@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestCreateUserAccessToken(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUserAccessToken(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/users/uqxetse3cy5eo9z2/tokens", `{"scope": "photos.read"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreateUserAccessToken(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/users/uqxc08w3d0ej2283/tokens", `{"scope": "photos.read"}`, sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidScope", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreateUserAccessToken(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/users/uqxetse3cy5eo9z2/tokens", `{"scope": "photos.foo"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("ReadOnly", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreateUserAccessToken(router)
+		SearchAlbums(router)
+		CreateAlbum(router)
+		AddPhotosToAlbum(router)
+		GrantAlbumAccess(router)
+		SaveSettings(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		r := AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/users/uqxetse3cy5eo9z2/tokens", form.AsJson(form.AccessToken{
+			ClientName: "Photo Frame",
+			Scope:      "photos.read",
+		}), sessId)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "photos.read", gjson.Get(r.Body.String(), "scope").String())
+		assert.Equal(t, "Photo Frame", gjson.Get(r.Body.String(), "name").String())
+
+		token := gjson.Get(r.Body.String(), "access_token").String()
+		assert.NotEmpty(t, token)
+
+		r = AuthenticatedRequest(app, http.MethodGet, "/api/v1/albums?count=10", token)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/albums", `{"Title": "Frame"}`, token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		// Read permissions included in the request do not allow changes.
+		r = AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/settings", `{"ui":{"language": "de"}}`, token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/albums/at9lxuqxpogaaba9/photos", `{"photos": ["ps6sg6be2lvl0y12"]}`, token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/albums/at9lxuqxpogaaba9/grants", `{"user": "uqxc08w3d0ej2283"}`, token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		// Scoped tokens cannot be used to create new tokens.
+		r = AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/users/uqxetse3cy5eo9z2/tokens", `{"scope": "admin"}`, token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
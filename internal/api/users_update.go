@@ -7,6 +7,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -64,6 +65,9 @@ func UpdateUser(router *gin.RouterGroup) {
 			f.CanLogin = true
 		}
 
+		// Remember the previous role so that changes can be audited.
+		prevRole := m.AclRole()
+
 		// Save model with values from form.
 		if err = m.SaveForm(f, isPrivileged); err != nil {
 			log.Error(err)
@@ -81,6 +85,12 @@ func UpdateUser(router *gin.RouterGroup) {
 			return
 		}
 
+		if role := m.AclRole(); role != prevRole {
+			event.AuditInfo([]string{ClientIP(c), "session %s", "changed role of %s from %s to %s"}, s.RefID, clean.LogQuote(m.Username()), prevRole.String(), role.String())
+		} else {
+			event.AuditInfo([]string{ClientIP(c), "session %s", "updated account %s"}, s.RefID, clean.LogQuote(m.Username()))
+		}
+
 		c.JSON(http.StatusOK, m)
 	})
 }
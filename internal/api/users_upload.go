@@ -62,12 +62,25 @@ func UploadUserFiles(router *gin.RouterGroup) {
 			return
 		}
 
-		// Publish upload start event.
-		event.Publish("upload.start", event.Data{"uid": s.UserUID, "time": start})
-
 		files := f.File["files"]
 		uploaded := len(files)
 
+		// Reject the upload if it would exceed the storage quota of the user.
+		var uploadSize int64
+
+		for _, file := range files {
+			uploadSize += file.Size
+		}
+
+		if s.User().QuotaExceeded(uploadSize) {
+			event.AuditWarn([]string{ClientIP(c), "session %s", "upload files", "storage quota exceeded"}, s.RefID)
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrQuotaExceeded)
+			return
+		}
+
+		// Publish upload start event.
+		event.Publish("upload.start", event.Data{"uid": s.UserUID, "time": start})
+
 		var uploads []string
 
 		// Compose upload path.
@@ -97,48 +110,56 @@ func UploadUserFiles(router *gin.RouterGroup) {
 		}
 
 		// Check if uploaded file is safe.
-		if !conf.UploadNSFW() {
-			nd := get.NsfwDetector()
+		if !conf.UploadNSFW() && removeOffensiveUploads(uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		elapsed := int(time.Since(start).Seconds())
 
-			containsNSFW := false
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
 
-			for _, filename := range uploads {
-				labels, err := nd.File(filename)
+		log.Info(msg)
 
-				if err != nil {
-					log.Debug(err)
-					continue
-				}
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
 
-				if labels.IsSafe() {
-					continue
-				}
+// removeOffensiveUploads checks if any of the uploaded files might be offensive,
+// in which case all of them are deleted.
+func removeOffensiveUploads(uploads []string) bool {
+	nd := get.NsfwDetector()
 
-				log.Infof("nsfw: %s might be offensive", clean.Log(filename))
+	containsNSFW := false
 
-				containsNSFW = true
-			}
+	for _, filename := range uploads {
+		labels, err := nd.File(filename)
 
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", clean.Log(filename))
-					}
-				}
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
 
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+		if labels.IsSafe() {
+			continue
 		}
 
-		elapsed := int(time.Since(start).Seconds())
+		log.Infof("nsfw: %s might be offensive", clean.Log(filename))
 
-		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
+		containsNSFW = true
+	}
 
-		log.Info(msg)
+	if !containsNSFW {
+		return false
+	}
 
-		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
-	})
+	for _, filename := range uploads {
+		if err := os.Remove(filename); err != nil {
+			log.Errorf("nsfw: could not delete %s", clean.Log(filename))
+		}
+	}
+
+	return true
 }
 
 // ProcessUserUpload triggers processing once all files have been uploaded.
@@ -196,10 +217,15 @@ func ProcessUserUpload(router *gin.RouterGroup) {
 		opt := photoprism.ImportOptionsUpload(uploadPath, destFolder)
 
 		// Add imported files to albums if allowed.
-		if len(f.Albums) > 0 &&
-			acl.Resources.AllowAny(acl.ResourceAlbums, s.User().AclRole(), acl.Permissions{acl.ActionCreate, acl.ActionUpload}) {
+		if len(f.Albums) == 0 {
+			// Do nothing.
+		} else if acl.Resources.AllowAny(acl.ResourceAlbums, s.User().AclRole(), acl.Permissions{acl.ActionCreate, acl.ActionUpload}) {
 			log.Debugf("upload: adding files to album %s", clean.Log(strings.Join(f.Albums, " and ")))
 			opt.Albums = f.Albums
+		} else if albums := s.User().ContributeUIDs(f.Albums); len(albums) > 0 {
+			// Users may add files to albums shared with them for contribution.
+			log.Debugf("upload: adding files to shared album %s", clean.Log(strings.Join(albums, " and ")))
+			opt.Albums = albums
 		}
 
 		// Set user UID if known.
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserUsage returns the storage quota and usage of a user account.
+//
+// GET /api/v1/users/:uid/usage
+func GetUserUsage(router *gin.RouterGroup) {
+	router.GET("/users/:uid/usage", func(c *gin.Context) {
+		_, u := authUserOrManager(c)
+
+		if u == nil {
+			return
+		}
+
+		usage, err := u.Usage()
+
+		if err != nil {
+			log.Errorf("users: %s (get storage usage)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, usage)
+	})
+}
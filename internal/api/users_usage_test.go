@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserUsage(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserUsage(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/users/uqxetse3cy5eo9z2/usage")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
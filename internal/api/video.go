@@ -32,6 +32,12 @@ func GetVideo(router *gin.RouterGroup) {
 		fileHash := clean.Token(c.Param("hash"))
 		formatName := clean.Token(c.Param("format"))
 
+		// Don't stream videos in locked albums.
+		if LockedFile(PreviewToken(c), fileHash) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		format, ok := video.Types[formatName]
 
 		if !ok {
@@ -64,8 +70,14 @@ func GetVideo(router *gin.RouterGroup) {
 			return
 		}
 
+		// Originals in cold storage must be restored first.
+		if !restoreFile(c, f) {
+			return
+		}
+
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 		fileBitrate := f.Bitrate()
+		fetchFile(fileName)
 
 		// File format supported by the client/browser?
 		supported := f.FileCodec != "" && f.FileCodec == string(format.Codec) || format.Codec == video.UnknownCodec && f.FileType == string(format.File)
@@ -115,9 +127,9 @@ func GetVideo(router *gin.RouterGroup) {
 
 		// Return requested content.
 		if c.Query("download") != "" {
-			c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
+			sendFile(c, fileName, f.DownloadName(DownloadName(c), 0))
 		} else {
-			c.File(fileName)
+			sendFile(c, fileName, "")
 		}
 
 		return
@@ -2,6 +2,7 @@ package api
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,11 +15,13 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -40,6 +43,9 @@ func ZipCreate(router *gin.RouterGroup) {
 		if !conf.Settings().Features.Download {
 			AbortFeatureDisabled(c)
 			return
+		} else if s.NoDownload() {
+			AbortForbidden(c)
+			return
 		}
 
 		var f form.Selection
@@ -50,6 +56,11 @@ func ZipCreate(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ExpandSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrNoItemsSelected)
+			return
+		}
+
 		if f.Empty() {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -64,6 +75,9 @@ func ZipCreate(router *gin.RouterGroup) {
 			selection = query.DownloadSelection(dl.MediaRaw, dl.MediaSidecar, dl.Originals)
 		}
 
+		// Pictures in locked albums cannot be downloaded until the album is unlocked.
+		selection.Locked = search.LockedAlbums(s)
+
 		// Find files to download.
 		files, err := query.SelectedFiles(f, selection)
 
@@ -105,6 +119,13 @@ func ZipCreate(router *gin.RouterGroup) {
 
 		var aliases = make(map[string]int)
 
+		// Pictures shared with links that have watermarks enabled are watermarked.
+		var link *entity.Link
+
+		if !conf.Watermark().Empty() {
+			link = s.WatermarkLink()
+		}
+
 		// Add files to zip.
 		for _, file := range files {
 			fileName := photoprism.FileName(file.FileRoot, file.FileName)
@@ -117,8 +138,46 @@ func ZipCreate(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
+			if ready, _ := coldFile(&file); !ready {
+				log.Infof("zip: skipped %s, which is being restored from cold storage", clean.Log(file.FileName))
+				continue
+			}
+
+			fetchFile(fileName)
+
 			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
+				photo := file.Photo
+
+				// Find photo to check if it was taken in a privacy zone.
+				if photo == nil && len(entity.PrivacyZones) > 0 {
+					photo = entity.FindPhoto(entity.Photo{ID: file.PhotoID})
+				}
+
+				// Remove GPS metadata if the picture was taken in a privacy zone.
+				zipFile, cleanup, err := privacyFileName(fileName, photo)
+
+				if err != nil {
+					log.Errorf("zip: %s", err)
+					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
+					return
+				}
+
+				if wm, err := watermarkFileName(link, zipFile, file.FileHash, file.FileOrientation); errors.Is(err, errWatermarkUnsupported) {
+					log.Debugf("zip: skipped %s, which cannot be watermarked", clean.Log(file.FileName))
+					cleanup()
+					continue
+				} else if err != nil {
+					log.Errorf("zip: %s (watermark %s)", err, clean.Log(file.FileName))
+					cleanup()
+					continue
+				} else if wm != zipFile {
+					zipFile, alias = wm, fs.StripExt(alias)+fs.ExtJPEG
+				}
+
+				err = addFileToZip(zipWriter, zipFile, alias)
+				cleanup()
+
+				if err != nil {
 					log.Errorf("zip: failed adding %s to zip (%s)", clean.Log(file.FileName), err)
 					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
 					return
@@ -176,7 +235,7 @@ func ZipDownload(router *gin.RouterGroup) {
 
 		log.Debugf("zip: submitting %s", clean.Log(zipBaseName))
 
-		c.FileAttachment(zipFileName, zipBaseName)
+		sendFile(c, zipFileName, zipBaseName)
 	})
 }
 
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestZip(t *testing.T) {
@@ -13,6 +15,33 @@ func TestZip(t *testing.T) {
 	ZipCreate(router)
 	ZipDownload(router)
 
+	t.Run("LockedAlbum", func(t *testing.T) {
+		a := entity.NewUserAlbum("Locked Zip", entity.AlbumManual, entity.Admin.UserUID)
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			assert.NoError(t, entity.UnscopedDb().Delete(entity.PhotoAlbum{}, "album_uid = ?", a.AlbumUID).Error)
+			assert.NoError(t, a.DeletePermanently())
+		}()
+
+		a.AddPhotos([]string{"pt9jtdre2lvl0y12"})
+
+		if err := a.Lock("1234"); err != nil {
+			t.Fatal(err)
+		}
+
+		// Pictures in locked albums cannot be downloaded.
+		r := PerformRequestWithBody(app, "POST", "/api/v1/zip", `{"albums": ["`+a.AlbumUID+`"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/zip", `{"photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		assert.NoError(t, a.RemoveLock("1234"))
+	})
 	t.Run("Download", func(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/zip", `{"photos": ["pt9jtdre2lvl0y12", "pt9jtdre2lvl0y11"]}`)
 		message := gjson.Get(r.Body.String(), "message")
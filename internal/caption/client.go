@@ -0,0 +1,128 @@
+package caption
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Timeout is the time limit for caption requests, since models may be slow without a GPU.
+var Timeout = 60 * time.Second
+
+// MinConfidence is the minimum confidence of captions that are used as descriptions.
+var MinConfidence float32 = 0.5
+
+// Result represents a caption generated by the model.
+type Result struct {
+	Text       string  `json:"caption"`
+	Confidence float32 `json:"confidence"`
+}
+
+// Caption returns the sanitized caption text, or an empty string if the confidence is too low.
+func (r Result) Caption() string {
+	if r.Confidence > 0 && r.Confidence < MinConfidence {
+		return ""
+	}
+
+	return Clean(r.Text)
+}
+
+// Clean normalizes whitespace, capitalizes the first letter, and clips the caption if it is too long.
+func Clean(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	if s == "" {
+		return ""
+	}
+
+	return txt.Clip(txt.UpperFirst(s), txt.ClipLongText)
+}
+
+// Client generates captions with a captioning model served over HTTP on the local network,
+// e.g. a BLIP container running next to PhotoPrism.
+//
+// Images are sent as JPEG in the POST request body, and the service is expected to respond
+// with a JSON object like {"caption": "a dog running on a beach at sunset", "confidence": 0.82}.
+type Client struct {
+	serviceUrl string
+}
+
+// New returns a new caption client for the specified service URL.
+func New(serviceUrl string) *Client {
+	return &Client{serviceUrl: serviceUrl}
+}
+
+// Init validates the service URL.
+func (c *Client) Init() error {
+	if u, err := url.Parse(c.serviceUrl); err != nil {
+		return fmt.Errorf("caption: invalid service url (%s)", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("caption: invalid service url %s", clean.Log(c.serviceUrl))
+	}
+
+	return nil
+}
+
+// File returns a caption for a jpeg media file.
+func (c *Client) File(fileName string) (result Result, err error) {
+	img, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return c.Image(img)
+}
+
+// Image returns a caption for a jpeg image.
+func (c *Client) Image(img []byte) (result Result, err error) {
+	if len(img) == 0 {
+		return result, fmt.Errorf("caption: image is empty")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.serviceUrl, bytes.NewReader(img))
+
+	if err != nil {
+		return result, fmt.Errorf("caption: %s (create request)", err)
+	}
+
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: Timeout}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return result, fmt.Errorf("caption: %s (request)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("caption: service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return result, fmt.Errorf("caption: %s (read response)", err)
+	}
+
+	if err = json.Unmarshal(body, &result); err != nil {
+		return result, fmt.Errorf("caption: %s (parse response)", err)
+	}
+
+	log.Tracef("caption: generated %s", clean.Log(result.Text))
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package caption
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Init(t *testing.T) {
+	assert.NoError(t, New("http://localhost:5000/caption").Init())
+	assert.Error(t, New("localhost").Init())
+	assert.Error(t, New("ftp://localhost/").Init())
+}
+
+func TestClient_Image(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+			_, _ = w.Write([]byte(`{"caption": "a dog running on a beach  at sunset", "confidence": 0.82}`))
+		}))
+
+		defer srv.Close()
+
+		result, err := New(srv.URL).Image([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, float32(0.82), result.Confidence)
+		assert.Equal(t, "A dog running on a beach at sunset", result.Caption())
+	})
+	t.Run("Error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		defer srv.Close()
+
+		_, err := New(srv.URL).Image([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := New("http://localhost:5000/caption").Image(nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestResult_Caption(t *testing.T) {
+	assert.Equal(t, "A cat on a sofa", Result{Text: " a cat on a sofa "}.Caption())
+	assert.Equal(t, "A cat on a sofa", Result{Text: "a cat on a sofa", Confidence: 0.9}.Caption())
+	assert.Equal(t, "", Result{Text: "a cat on a sofa", Confidence: 0.2}.Caption())
+	assert.Equal(t, "", Result{}.Caption())
+}
+
+func TestClean(t *testing.T) {
+	assert.Equal(t, "", Clean("  "))
+	assert.Equal(t, "Two people walking", Clean("two  people\twalking"))
+}
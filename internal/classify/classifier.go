@@ -0,0 +1,75 @@
+package classify
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Supported image classification backends.
+const (
+	BackendTensorFlow = "tensorflow"
+	BackendRemote     = "remote"
+)
+
+// Classifier represents an image classification backend.
+type Classifier interface {
+	Init() error
+	File(filename string) (Labels, error)
+	Labels(img []byte) (Labels, error)
+}
+
+// Prediction represents a label name and its probability as returned by a model.
+type Prediction struct {
+	Label       string  `json:"label"`
+	Probability float32 `json:"probability"`
+}
+
+// Predictions represents a list of model predictions.
+type Predictions []Prediction
+
+// Labels returns the best 5 labels (if enough high probability labels) after applying the label rules.
+func (p Predictions) Labels() Labels {
+	var result Labels
+
+	for _, prediction := range p {
+		// discard labels with low probabilities
+		if prediction.Probability < 0.1 {
+			continue
+		}
+
+		labelText := strings.ToLower(prediction.Label)
+
+		rule, _ := Rules.Find(labelText)
+
+		// discard labels that don't met the threshold
+		if prediction.Probability < rule.Threshold {
+			continue
+		}
+
+		// Get rule label name instead of the model label name if it exists
+		if rule.Label != "" {
+			labelText = rule.Label
+		}
+
+		labelText = strings.TrimSpace(labelText)
+
+		if labelText == "" {
+			continue
+		}
+
+		uncertainty := 100 - int(math.Round(float64(prediction.Probability*100)))
+
+		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories, Confidence: prediction.Probability})
+	}
+
+	// Sort by probability
+	sort.Sort(result)
+
+	// Return the best labels only.
+	if l := len(result); l < 5 {
+		return result[:l]
+	} else {
+		return result[:5]
+	}
+}
@@ -7,4 +7,6 @@ const (
 	SrcLocation = "location"
 	SrcImage    = "image"
 	SrcKeyword  = "keyword"
+	SrcLandmark = "landmark"
+	SrcRule     = "rule"
 )
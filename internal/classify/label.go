@@ -13,6 +13,7 @@ type Label struct {
 	Uncertainty int      `json:"uncertainty"` // >= 0
 	Priority    int      `json:"priority"`    // >= 0
 	Categories  []string `json:"categories"`  // List of similar labels
+	Confidence  float32  `json:"confidence"`  // Raw model confidence, if known
 }
 
 // LocationLabel returns a new location label.
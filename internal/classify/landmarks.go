@@ -0,0 +1,105 @@
+package classify
+
+import (
+	"strings"
+)
+
+// Landmark label defaults.
+const (
+	LandmarkCategory = "landmark"
+	LandmarkPriority = 3
+)
+
+// LandmarkThreshold is the minimum probability of recognized landmarks.
+var LandmarkThreshold float32 = 0.5
+
+// Landmarks recognizes famous buildings, monuments, and other points of interest with a backend that
+// uses a model trained on landmarks, e.g. a remote inference service. Matches are returned as place
+// labels with a high priority, so they can be used for titles even if the location is unknown.
+type Landmarks struct {
+	backend Classifier
+}
+
+// NewLandmarks returns a new landmark recognition stage based on the specified backend.
+func NewLandmarks(backend Classifier) *Landmarks {
+	return &Landmarks{backend: backend}
+}
+
+// Init initializes the backend.
+func (l *Landmarks) Init() error {
+	if l.backend == nil {
+		return nil
+	}
+
+	return l.backend.Init()
+}
+
+// File returns the landmarks recognized in a jpeg media file.
+func (l *Landmarks) File(filename string) (result Labels, err error) {
+	if l.backend == nil {
+		return result, nil
+	}
+
+	labels, err := l.backend.File(filename)
+
+	if err != nil {
+		return result, err
+	}
+
+	return LandmarkLabels(labels), nil
+}
+
+// Labels returns the landmarks recognized in a jpeg image.
+func (l *Landmarks) Labels(img []byte) (result Labels, err error) {
+	if l.backend == nil {
+		return result, nil
+	}
+
+	labels, err := l.backend.Labels(img)
+
+	if err != nil {
+		return result, err
+	}
+
+	return LandmarkLabels(labels), nil
+}
+
+// LandmarkLabels converts the labels returned by a landmark model into place labels,
+// skipping those below the threshold.
+func LandmarkLabels(labels Labels) (result Labels) {
+	maxUncertainty := 100 - int(LandmarkThreshold*100)
+
+	for _, label := range labels {
+		name := strings.TrimSpace(label.Name)
+
+		if name == "" || label.Uncertainty > maxUncertainty {
+			continue
+		}
+
+		categories := append([]string{LandmarkCategory}, label.Categories...)
+
+		result = append(result, Label{
+			Name:        name,
+			Source:      SrcLandmark,
+			Uncertainty: label.Uncertainty,
+			Priority:    LandmarkPriority,
+			Categories:  categories,
+		})
+	}
+
+	return result
+}
+
+// Landmark returns the recognized landmark with the lowest uncertainty, if any.
+func (l Labels) Landmark() (result Label, ok bool) {
+	for _, label := range l {
+		if label.Source != SrcLandmark || label.Name == "" {
+			continue
+		} else if !ok || label.Uncertainty < result.Uncertainty {
+			result = label
+			ok = true
+		}
+	}
+
+	return result, ok
+}
@@ -0,0 +1,85 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type landmarksBackend struct {
+	labels Labels
+}
+
+func (b landmarksBackend) Init() error {
+	return nil
+}
+
+func (b landmarksBackend) File(filename string) (Labels, error) {
+	return b.labels, nil
+}
+
+func (b landmarksBackend) Labels(img []byte) (Labels, error) {
+	return b.labels, nil
+}
+
+func TestLandmarkLabels(t *testing.T) {
+	labels := Labels{
+		{Name: "eiffel tower", Source: SrcImage, Uncertainty: 10},
+		{Name: "big ben", Source: SrcImage, Uncertainty: 70},
+		{Name: " ", Source: SrcImage, Uncertainty: 0},
+	}
+
+	result := LandmarkLabels(labels)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "eiffel tower", result[0].Name)
+		assert.Equal(t, SrcLandmark, result[0].Source)
+		assert.Equal(t, LandmarkPriority, result[0].Priority)
+		assert.Equal(t, []string{LandmarkCategory}, result[0].Categories)
+	}
+}
+
+func TestLandmarks_File(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		l := NewLandmarks(landmarksBackend{labels: Labels{{Name: "brandenburg gate", Uncertainty: 25}}})
+
+		assert.NoError(t, l.Init())
+
+		result, err := l.File("landmark.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "brandenburg gate", result[0].Name)
+			assert.Equal(t, SrcLandmark, result[0].Source)
+		}
+	})
+	t.Run("NoBackend", func(t *testing.T) {
+		result, err := NewLandmarks(nil).Labels([]byte("foo"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestLabels_Landmark(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		labels := Labels{
+			{Name: "tower", Source: SrcImage, Uncertainty: 5},
+			{Name: "big ben", Source: SrcLandmark, Uncertainty: 40},
+			{Name: "eiffel tower", Source: SrcLandmark, Uncertainty: 20},
+		}
+
+		result, ok := labels.Landmark()
+
+		assert.True(t, ok)
+		assert.Equal(t, "eiffel tower", result.Name)
+	})
+	t.Run("None", func(t *testing.T) {
+		_, ok := Labels{{Name: "tower", Source: SrcImage}}.Landmark()
+
+		assert.False(t, ok)
+	})
+}
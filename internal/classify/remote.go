@@ -0,0 +1,125 @@
+package classify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// RemoteTimeout is the time limit for remote classification requests.
+var RemoteTimeout = 30 * time.Second
+
+// Remote classifies images with a remote HTTP inference service, e.g. to use newer models without recompiling.
+//
+// Images are sent as JPEG in the POST request body, and the service is expected to respond with
+// a JSON object like {"predictions": [{"label": "cat", "probability": 0.87}]}. The predictions are
+// then filtered with the same label rules as those of the built-in model.
+type Remote struct {
+	serviceUrl string
+	key        string
+	disabled   bool
+}
+
+// RemoteResponse represents the response of a remote classification service.
+type RemoteResponse struct {
+	Predictions Predictions `json:"predictions"`
+}
+
+// NewRemote returns a new remote classification backend.
+func NewRemote(serviceUrl, key string, disabled bool) *Remote {
+	return &Remote{serviceUrl: serviceUrl, key: key, disabled: disabled}
+}
+
+// Init validates the service URL, if not disabled.
+func (r *Remote) Init() error {
+	if r.disabled {
+		return nil
+	}
+
+	if u, err := url.Parse(r.serviceUrl); err != nil {
+		return fmt.Errorf("classify: invalid service url (%s)", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("classify: invalid service url %s", clean.Log(r.serviceUrl))
+	}
+
+	return nil
+}
+
+// File returns matching labels for a jpeg media file.
+func (r *Remote) File(filename string) (result Labels, err error) {
+	if r.disabled {
+		return result, nil
+	}
+
+	imageBuffer, err := os.ReadFile(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Labels(imageBuffer)
+}
+
+// Labels returns matching labels for a jpeg image.
+func (r *Remote) Labels(img []byte) (result Labels, err error) {
+	if r.disabled {
+		return result, nil
+	} else if len(img) == 0 {
+		return result, fmt.Errorf("classify: image is empty")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.serviceUrl, bytes.NewReader(img))
+
+	if err != nil {
+		return result, fmt.Errorf("classify: %s (create request)", err)
+	}
+
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Accept", "application/json")
+
+	// Add access key?
+	if r.key != "" {
+		req.Header.Set("Authorization", "Bearer "+r.key)
+	}
+
+	client := &http.Client{Timeout: RemoteTimeout}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return result, fmt.Errorf("classify: %s (remote request)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("classify: remote service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return result, fmt.Errorf("classify: %s (read response)", err)
+	}
+
+	var res RemoteResponse
+
+	if err = json.Unmarshal(body, &res); err != nil {
+		return result, fmt.Errorf("classify: %s (parse response)", err)
+	}
+
+	result = res.Predictions.Labels()
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
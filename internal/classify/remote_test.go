@@ -0,0 +1,56 @@
+package classify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemote_Init(t *testing.T) {
+	assert.NoError(t, NewRemote("http://localhost:8080/classify", "", false).Init())
+	assert.NoError(t, NewRemote("", "", true).Init())
+	assert.Error(t, NewRemote("localhost", "", false).Init())
+	assert.Error(t, NewRemote("ftp://localhost/", "", false).Init())
+}
+
+func TestRemote_Labels(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"predictions": [{"label": "tabby cat", "probability": 0.9}, {"label": "chair", "probability": 0.05}]}`))
+		}))
+
+		defer srv.Close()
+
+		result, err := NewRemote(srv.URL, "secret", false).Labels([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "cat", result[0].Name)
+		assert.Equal(t, 10, result[0].Uncertainty)
+	})
+	t.Run("Error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+		defer srv.Close()
+
+		_, err := NewRemote(srv.URL, "", false).Labels([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		result, err := NewRemote("", "", true).Labels([]byte("jpeg"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -0,0 +1,164 @@
+package classify
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// VocabularyRule maps a classifier label or keyword to a custom label and categories.
+type VocabularyRule struct {
+	Label      string   `yaml:"label"`
+	Threshold  float32  `yaml:"threshold,omitempty"`
+	Priority   int      `yaml:"priority,omitempty"`
+	Categories []string `yaml:"categories,omitempty"`
+}
+
+// Vocabulary represents user-provided label rules and keyword mappings, e.g. loaded from a YAML file like:
+//
+//	labels:
+//	  comic book:
+//	    label: comics
+//	    categories: [books]
+//	keywords:
+//	  cosplay:
+//	    label: conventions
+type Vocabulary struct {
+	Labels   map[string]VocabularyRule `yaml:"labels"`
+	Keywords map[string]VocabularyRule `yaml:"keywords"`
+}
+
+var vocabulary *Vocabulary
+var vocabularyMutex = sync.RWMutex{}
+
+// LoadVocabulary loads custom label rules and keyword mappings from a YAML file.
+func LoadVocabulary(fileName string) (*Vocabulary, error) {
+	if !fs.FileExists(fileName) {
+		return nil, fmt.Errorf("vocabulary file %s not found", clean.Log(fileName))
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Vocabulary{}
+
+	if err = yaml.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+
+	result.Labels = normalizeVocabulary(result.Labels)
+	result.Keywords = normalizeVocabulary(result.Keywords)
+
+	return result, nil
+}
+
+// normalizeVocabulary returns the rules with lowercase keys, skipping empty keys and labels.
+func normalizeVocabulary(rules map[string]VocabularyRule) map[string]VocabularyRule {
+	result := make(map[string]VocabularyRule, len(rules))
+
+	for key, rule := range rules {
+		key = strings.ToLower(strings.TrimSpace(key))
+		rule.Label = strings.ToLower(strings.TrimSpace(rule.Label))
+
+		if key == "" || rule.Label == "" {
+			continue
+		}
+
+		for i := range rule.Categories {
+			rule.Categories[i] = strings.ToLower(strings.TrimSpace(rule.Categories[i]))
+		}
+
+		result[key] = rule
+	}
+
+	return result
+}
+
+// SetVocabulary merges the custom label rules into the default Rules and enables the keyword mappings.
+func SetVocabulary(v *Vocabulary) {
+	vocabularyMutex.Lock()
+	defer vocabularyMutex.Unlock()
+
+	if v != nil {
+		v.Apply(Rules)
+	}
+
+	vocabulary = v
+}
+
+// Apply merges the custom label rules into the specified rules, replacing existing rules with the same name.
+func (v *Vocabulary) Apply(rules LabelRules) {
+	if v == nil || rules == nil {
+		return
+	}
+
+	for name, custom := range v.Labels {
+		rule, _ := rules.Find(name)
+
+		rule.Label = custom.Label
+		rule.Priority = custom.Priority
+		rule.Categories = custom.Categories
+
+		if custom.Threshold > 0 {
+			rule.Threshold = custom.Threshold
+		}
+
+		rules[name] = rule
+	}
+}
+
+// KeywordLabels returns the labels of all keyword mappings found in the specified texts, e.g. keywords,
+// titles or file names. Keywords match case-insensitively anywhere in the text.
+func (v *Vocabulary) KeywordLabels(texts ...string) (result Labels) {
+	if v == nil || len(v.Keywords) == 0 {
+		return result
+	}
+
+	s := strings.ToLower(strings.Join(texts, " "))
+
+	if strings.TrimSpace(s) == "" {
+		return result
+	}
+
+	found := make(map[string]bool)
+
+	for keyword, rule := range v.Keywords {
+		if found[rule.Label] || !strings.Contains(s, keyword) {
+			continue
+		}
+
+		found[rule.Label] = true
+
+		result = append(result, Label{
+			Name:        rule.Label,
+			Source:      SrcRule,
+			Uncertainty: 0,
+			Priority:    rule.Priority,
+			Categories:  rule.Categories,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// VocabularyLabels returns the labels of matching keyword mappings, if a custom vocabulary was set.
+func VocabularyLabels(texts ...string) Labels {
+	vocabularyMutex.RLock()
+	defer vocabularyMutex.RUnlock()
+
+	return vocabulary.KeywordLabels(texts...)
+}
@@ -0,0 +1,109 @@
+package classify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testVocabulary = `labels:
+  Comic Book:
+    label: Comics
+    priority: 2
+    categories:
+      - Books
+keywords:
+  cosplay:
+    label: conventions
+    categories:
+      - event
+  comic-con:
+    label: conventions
+  "":
+    label: empty
+`
+
+func testVocabularyFile(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "labels.yml")
+
+	if err := os.WriteFile(fileName, []byte(testVocabulary), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestLoadVocabulary(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		v, err := LoadVocabulary(testVocabularyFile(t))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, v.Labels, 1)
+		assert.Len(t, v.Keywords, 2)
+		assert.Equal(t, "comics", v.Labels["comic book"].Label)
+		assert.Equal(t, []string{"books"}, v.Labels["comic book"].Categories)
+		assert.Equal(t, "conventions", v.Keywords["cosplay"].Label)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		v, err := LoadVocabulary("testdata/missing.yml")
+
+		assert.Error(t, err)
+		assert.Nil(t, v)
+	})
+}
+
+func TestVocabulary_Apply(t *testing.T) {
+	v, err := LoadVocabulary(testVocabularyFile(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := LabelRules{
+		"comic book": {
+			Label:      "",
+			Threshold:  0.3,
+			Priority:   -1,
+			Categories: []string{},
+		},
+	}
+
+	v.Apply(rules)
+
+	rule, ok := rules.Find("comic book")
+
+	assert.True(t, ok)
+	assert.Equal(t, "comics", rule.Label)
+	assert.Equal(t, float32(0.3), rule.Threshold)
+	assert.Equal(t, 2, rule.Priority)
+	assert.Equal(t, []string{"books"}, rule.Categories)
+}
+
+func TestVocabulary_KeywordLabels(t *testing.T) {
+	v, err := LoadVocabulary(testVocabularyFile(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		result := v.KeywordLabels("Cosplayers at the Comic-Con", "2019/IMG_1234.jpg")
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "conventions", result[0].Name)
+		assert.Equal(t, SrcRule, result[0].Source)
+		assert.Equal(t, "Conventions", result[0].Title())
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		assert.Empty(t, v.KeywordLabels("cat, dog", ""))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var empty *Vocabulary
+		assert.Empty(t, empty.KeywordLabels("cosplay"))
+	})
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// AltitudeCommand configures the command name, flags, and action.
+var AltitudeCommand = cli.Command{
+	Name:   "altitude",
+	Usage:  "Sets the missing altitude of pictures with GPS coordinates based on the elevation data in the DEM path",
+	Flags:  altitudeFlags,
+	Action: altitudeAction,
+}
+
+var altitudeFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "sidecar, s",
+		Usage: "update YAML sidecar files",
+	},
+}
+
+// altitudeAction sets missing altitudes based on offline elevation data.
+func altitudeAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	result, err := photoprism.BackfillAltitude(conf, photoprism.AltitudeOptions{Sidecar: ctx.Bool("sidecar")})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("altitude: %s updated, completed in %s", english.Plural(result.Updated, "picture", "pictures"), time.Since(start))
+
+	return nil
+}
@@ -1,19 +1,15 @@
 package commands
 
 import (
-	"bytes"
 	"context"
 	"errors"
-	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
-	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -28,7 +24,7 @@ const backupDescription = "A user-defined filename or - for stdout can be passed
 var BackupCommand = cli.Command{
 	Name:        "backup",
 	Description: backupDescription,
-	Usage:       "Creates an index backup and optionally album YAML files organized by type",
+	Usage:       "Creates an index backup and optionally album YAML files organized by type and people YAML files",
 	ArgsUsage:   "[filename]",
 	Flags:       backupFlags,
 	Action:      backupAction,
@@ -47,6 +43,10 @@ var backupFlags = []cli.Flag{
 		Name:  "albums-path",
 		Usage: "custom album files `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "people, p",
+		Usage: "create people YAML files",
+	},
 	cli.BoolFlag{
 		Name:  "index, i",
 		Usage: "create index backup",
@@ -55,6 +55,25 @@ var backupFlags = []cli.Flag{
 		Name:  "index-path",
 		Usage: "custom index backup `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "remote, r",
+		Usage: "upload backup to the configured object storage bucket or WebDAV folder",
+	},
+	cli.BoolFlag{
+		Name:  "originals, o",
+		Usage: "upload new and changed originals to the configured object storage bucket or WebDAV folder, requires -r",
+	},
+}
+
+// BackupResult represents the result of a backup in JSON output mode.
+type BackupResult struct {
+	IndexFile  string                            `json:"IndexFile,omitempty"`
+	AlbumsPath string                            `json:"AlbumsPath,omitempty"`
+	Albums     int                               `json:"Albums"`
+	People     int                               `json:"People"`
+	Uploaded   []string                          `json:"Uploaded,omitempty"`
+	Originals  *photoprism.BackupOriginalsResult `json:"Originals,omitempty"`
+	Duration   string                            `json:"Duration"`
 }
 
 // backupAction creates a database backup.
@@ -67,8 +86,10 @@ func backupAction(ctx *cli.Context) error {
 	albumsPath := ctx.String("albums-path")
 
 	backupAlbums := ctx.Bool("albums") || albumsPath != ""
+	backupPeople := ctx.Bool("people")
+	backupOriginals := ctx.Bool("originals") && ctx.Bool("remote")
 
-	if !backupIndex && !backupAlbums {
+	if !backupIndex && !backupAlbums && !backupPeople && !backupOriginals {
 		return cli.ShowSubcommandHelp(ctx)
 	}
 
@@ -86,6 +107,8 @@ func backupAction(ctx *cli.Context) error {
 	conf.RegisterDb()
 	defer conf.Shutdown()
 
+	var result BackupResult
+
 	if backupIndex {
 		// If empty, use default backup file name.
 		if indexFileName == "" {
@@ -97,71 +120,19 @@ func backupAction(ctx *cli.Context) error {
 				indexPath = filepath.Join(conf.BackupPath(), conf.DatabaseDriver())
 			}
 
-			backupFile := time.Now().UTC().Format("2006-01-02") + ".sql"
-			indexFileName = filepath.Join(indexPath, backupFile)
-		}
-
-		if indexFileName != "-" {
-			if _, err := os.Stat(indexFileName); err == nil && !ctx.Bool("force") {
-				return fmt.Errorf("%s already exists", clean.Log(indexFileName))
-			} else if err == nil {
-				log.Warnf("replacing existing backup")
-			}
-
-			// Create backup directory if not exists.
-			if dir := filepath.Dir(indexFileName); dir != "." {
-				if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
-					return err
-				}
-			}
-		}
-
-		var cmd *exec.Cmd
-
-		switch conf.DatabaseDriver() {
-		case config.MySQL, config.MariaDB:
-			cmd = exec.Command(
-				conf.MysqldumpBin(),
-				"--protocol", "tcp",
-				"-h", conf.DatabaseHost(),
-				"-P", conf.DatabasePortString(),
-				"-u", conf.DatabaseUser(),
-				"-p"+conf.DatabasePassword(),
-				conf.DatabaseName(),
-			)
-		case config.SQLite3:
-			cmd = exec.Command(
-				conf.SqliteBin(),
-				conf.DatabaseFile(),
-				".dump",
-			)
-		default:
-			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
+			indexFileName = photoprism.BackupIndexFileName(indexPath)
 		}
 
-		// Write to stdout or file.
-		var f *os.File
-		if indexFileName == "-" {
-			log.Infof("writing backup to stdout")
-			f = os.Stdout
-		} else if f, err = os.OpenFile(indexFileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile); err != nil {
-			return fmt.Errorf("failed to create %s: %s", clean.Log(indexFileName), err)
-		} else {
-			log.Infof("writing backup to %s", clean.Log(indexFileName))
-			defer f.Close()
+		if err = photoprism.BackupIndex(conf, indexFileName, ctx.Bool("force")); err != nil {
+			return err
 		}
 
-		var stderr bytes.Buffer
-		cmd.Stderr = &stderr
-		cmd.Stdout = f
-
-		// Log exact command for debugging in trace mode.
-		log.Trace(cmd.String())
+		result.IndexFile = indexFileName
 
-		// Run backup command.
-		if err := cmd.Run(); err != nil {
-			if stderr.String() != "" {
-				return errors.New(stderr.String())
+		// Delete old backups, unless a custom file name was specified.
+		if ctx.Args().First() == "" {
+			if _, err = photoprism.RotateIndexBackups(indexPath, conf.BackupRetain()); err != nil {
+				log.Warnf("%s (rotate backups)", err)
 			}
 		}
 	}
@@ -180,13 +151,64 @@ func backupAction(ctx *cli.Context) error {
 		if count, err := photoprism.BackupAlbums(albumsPath, true); err != nil {
 			return err
 		} else {
+			result.AlbumsPath = albumsPath
+			result.Albums = count
 			log.Infof("created %s", english.Plural(count, "YAML album file", "YAML album files"))
 		}
 	}
 
+	if backupPeople {
+		log.Infof("saving people in %s", clean.Log(conf.PeoplePath()))
+
+		if count, err := photoprism.BackupPeople(conf.PeoplePath(), true); err != nil {
+			return err
+		} else {
+			result.People = count
+			log.Infof("created %s", english.Plural(count, "YAML people file", "YAML people files"))
+		}
+	}
+
+	if ctx.Bool("remote") {
+		get.SetConfig(conf)
+
+		remote := get.RemoteBackup()
+
+		if remote == nil {
+			return errors.New("no object storage bucket or WebDAV folder configured for backups")
+		}
+
+		uploadName := result.IndexFile
+
+		if uploadName == "-" {
+			uploadName = ""
+		}
+
+		if result.Uploaded, err = remote.Upload(uploadName, backupAlbums || backupPeople); err != nil {
+			return err
+		}
+
+		if backupOriginals {
+			if originals, err := remote.BackupOriginals(); err != nil {
+				return err
+			} else {
+				result.Originals = &originals
+			}
+		}
+
+		if _, err = remote.Rotate(conf.BackupRetain()); err != nil {
+			log.Warnf("%s (rotate remote backups)", err)
+		}
+	}
+
 	elapsed := time.Since(start)
 
 	log.Infof("completed in %s", elapsed)
 
+	// Don't mix the result with the backup if it is written to stdout.
+	if JsonOutput(ctx) && indexFileName != "-" {
+		result.Duration = elapsed.String()
+		return printJson(result)
+	}
+
 	return nil
 }
@@ -2,11 +2,13 @@ package commands
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 )
@@ -21,13 +23,17 @@ var CleanUpCommand = cli.Command{
 
 var cleanUpFlags = []cli.Flag{
 	cli.BoolFlag{
-		Name:  "dry",
-		Usage: "dry run, don't actually remove anything",
+		Name:  "dry-run, dry",
+		Usage: "dry run, only show which index entries and files would be removed",
 	},
 }
 
 // cleanUpAction removes orphaned index entries, sidecar and thumbnail files.
 func cleanUpAction(ctx *cli.Context) error {
+	if Remote(ctx) {
+		return remoteAction(ctx, http.MethodPost, "cleanup", form.CleanUpOptions{Dry: ctx.Bool("dry")})
+	}
+
 	cleanupStart := time.Now()
 
 	conf, err := InitConfig(ctx)
@@ -53,8 +59,10 @@ func cleanUpAction(ctx *cli.Context) error {
 	}
 
 	// Start cleanup worker.
-	if thumbnails, _, sidecars, err := w.Start(opt); err != nil {
+	if thumbnails, orphans, sidecars, err := w.Start(opt); err != nil {
 		return err
+	} else if opt.Dry {
+		log.Infof("dry run: would remove %s and %s in %s", english.Plural(orphans, "index entry", "index entries"), english.Plural(thumbnails, "cache file", "cache files"), time.Since(cleanupStart))
 	} else if total := thumbnails + sidecars; total > 0 {
 		log.Infof("removed %s in %s", english.Plural(total, "file", "files"), time.Since(cleanupStart))
 	}
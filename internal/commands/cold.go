@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// ColdCommand configures the cold storage subcommands.
+var ColdCommand = cli.Command{
+	Name:  "cold",
+	Usage: "Cold storage subcommands for archiving old originals",
+	Subcommands: []cli.Command{
+		ColdListCommand,
+		ColdArchiveCommand,
+		ColdRestoreCommand,
+	},
+}
+
+// ColdListCommand configures the command name, flags, and action.
+var ColdListCommand = cli.Command{
+	Name:      "ls",
+	Usage:     "Lists originals in cold storage",
+	ArgsUsage: "[sub-folder]",
+	Flags: append([]cli.Flag{cli.BoolFlag{
+		Name:  "restoring, r",
+		Usage: "only show files in the restore queue",
+	}}, report.CliFlags...),
+	Action: coldListAction,
+}
+
+// ColdArchiveCommand configures the command name, flags, and action.
+var ColdArchiveCommand = cli.Command{
+	Name:      "archive",
+	Usage:     "Moves originals to cold storage, keeping thumbnails and metadata online",
+	ArgsUsage: "[sub-folder]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "before, b",
+			Usage: "only archive pictures taken before `DATE`, e.g. 2015-01-01",
+		},
+		cli.BoolFlag{
+			Name:  "dry",
+			Usage: "dry run, only show which files would be archived",
+		},
+	},
+	Action: coldArchiveAction,
+}
+
+// ColdRestoreCommand configures the command name, flags, and action.
+var ColdRestoreCommand = cli.Command{
+	Name:      "restore",
+	Usage:     "Restores originals from cold storage, or requests a restore if they are in S3 Glacier",
+	ArgsUsage: "[sub-folder]",
+	Action:    coldRestoreAction,
+}
+
+// coldWorker returns the cold storage worker, or an error if it is not configured.
+func coldWorker(conf *config.Config) (*photoprism.Cold, error) {
+	conf.MigrateDb(false, nil)
+
+	if w := get.Cold(); w != nil {
+		return w, nil
+	}
+
+	return nil, fmt.Errorf("cold storage is not configured")
+}
+
+// coldListAction lists originals in cold storage.
+func coldListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		var status string
+
+		if ctx.Bool("restoring") {
+			status = entity.FileColdRestoring
+		}
+
+		files, err := query.ColdFiles(status, ctx.Args().First(), 0, 0)
+
+		if err != nil {
+			return err
+		}
+
+		cols := []string{"File Name", "Size", "Status", "Archived", "Error"}
+		rows := make([][]string, len(files))
+
+		for i, f := range files {
+			rows[i] = []string{
+				f.FileName,
+				humanize.Bytes(uint64(f.FileSize)),
+				f.Status,
+				f.CreatedAt.Format("2006-01-02 15:04:05"),
+				f.Error,
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// coldArchiveAction moves originals to cold storage.
+func coldArchiveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		w, err := coldWorker(conf)
+
+		if err != nil {
+			return err
+		}
+
+		opt := photoprism.ColdOptions{
+			Path: strings.TrimSpace(ctx.Args().First()),
+			Dry:  ctx.Bool("dry"),
+		}
+
+		if before := strings.TrimSpace(ctx.String("before")); before != "" {
+			if opt.Before, err = time.Parse("2006-01-02", before); err != nil {
+				return fmt.Errorf("invalid date %s, use the format YYYY-MM-DD", clean.Log(before))
+			}
+		}
+
+		log.Infof("cold: archiving originals in %s", w.Backend())
+
+		result, err := w.Archive(opt)
+
+		if err != nil {
+			return err
+		}
+
+		if opt.Dry {
+			log.Infof("cold: %s with %s would be archived [%s]", english.Plural(result.Files, "file", "files"), humanize.Bytes(uint64(result.Size)), time.Since(start))
+		} else {
+			log.Infof("cold: archived %s with %s, %d failed [%s]", english.Plural(result.Files, "file", "files"), humanize.Bytes(uint64(result.Size)), result.Failed, time.Since(start))
+		}
+
+		return nil
+	})
+}
+
+// coldRestoreAction restores originals from cold storage.
+func coldRestoreAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		w, err := coldWorker(conf)
+
+		if err != nil {
+			return err
+		}
+
+		files, err := query.ColdFiles("", ctx.Args().First(), 0, 0)
+
+		if err != nil {
+			return err
+		}
+
+		var restored, pending, failed int
+
+		for i := range files {
+			if ok, err := w.Restore(&files[i]); err != nil {
+				log.Errorf("cold: %s while restoring %s", err, clean.Log(files[i].FileName))
+				failed++
+			} else if ok {
+				restored++
+			} else {
+				pending++
+			}
+		}
+
+		log.Infof("cold: restored %s, %d pending, %d failed [%s]", english.Plural(restored, "file", "files"), pending, failed, time.Since(start))
+
+		return nil
+	})
+}
@@ -44,10 +44,15 @@ var PhotoPrism = []cli.Command{
 	StartCommand,
 	StopCommand,
 	StatusCommand,
+	DoctorCommand,
 	IndexCommand,
 	ImportCommand,
 	CopyCommand,
 	FacesCommand,
+	EmbeddingsCommand,
+	NSFWCommand,
+	GeotagCommand,
+	AltitudeCommand,
 	PlacesCommand,
 	PurgeCommand,
 	CleanUpCommand,
@@ -57,11 +62,19 @@ var PhotoPrism = []cli.Command{
 	ThumbsCommand,
 	MigrateCommand,
 	MigrationsCommand,
+	MigrateDbCommand,
 	BackupCommand,
 	RestoreCommand,
+	ExportCommand,
+	StorageCommand,
+	DedupCommand,
+	ColdCommand,
+	WorkerCommand,
 	ResetCommand,
 	PasswdCommand,
+	EmailCommand,
 	UsersCommand,
+	RolesCommand,
 	ShowCommand,
 	VersionCommand,
 	ShowConfigCommand,
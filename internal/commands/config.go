@@ -10,6 +10,13 @@ import (
 // InitConfig initializes the command config.
 var InitConfig = func(ctx *cli.Context) (*config.Config, error) {
 	c := config.NewConfig(ctx)
+	initJsonOutput(ctx)
+
+	// Prevent commands without remote support from running locally by mistake.
+	if Remote(ctx) {
+		return c, ErrRemoteUnsupported
+	}
+
 	get.SetConfig(c)
 	return c, c.Init()
 }
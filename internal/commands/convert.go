@@ -6,11 +6,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
+	"github.com/photoprism/photoprism/pkg/media"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // ConvertCommand configures the command name, flags, and action.
@@ -23,6 +31,10 @@ var ConvertCommand = cli.Command{
 			Name:  "ext, e",
 			Usage: "only process files with the specified extensions, e.g. mp4",
 		},
+		cli.StringFlag{
+			Name:  "filter",
+			Usage: "only process originals that match the search `FILTER`, e.g. \"type:heic year:2020\"",
+		},
 		cli.BoolFlag{
 			Name:  "force, f",
 			Usage: "replace existing JPEG files in the sidecar folder",
@@ -60,10 +72,29 @@ func convertAction(ctx *cli.Context) error {
 		convertPath = filepath.Join(convertPath, subPath)
 	}
 
-	log.Infof("converting originals in %s", clean.Log(convertPath))
-
 	w := get.Convert()
 
+	// Convert a filtered subset of indexed originals only?
+	if filter := strings.TrimSpace(ctx.String("filter")); filter != "" {
+		fileNames, err := convertFilterFiles(conf, filter, subPath, ctx.StringSlice("ext"))
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("converting %s matching %s", english.Plural(len(fileNames), "original", "originals"), clean.Log(filter))
+
+		if _, err = w.StartFiles(fileNames, ctx.Bool("force")); err != nil {
+			log.Error(err)
+		}
+
+		log.Infof("completed in %s", time.Since(start))
+
+		return nil
+	}
+
+	log.Infof("converting originals in %s", clean.Log(convertPath))
+
 	// Start file conversion.
 	if err := w.Start(convertPath, ctx.StringSlice("ext"), ctx.Bool("force")); err != nil {
 		log.Error(err)
@@ -75,3 +106,68 @@ func convertAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// convertFilterFiles returns the absolute names of the indexed originals that match the search filter.
+// In addition to the media types supported by search, the "type" filter accepts file types like heic.
+func convertFilterFiles(conf *config.Config, filter, subPath string, ext []string) (fileNames []string, err error) {
+	f := form.NewSearchPhotos(filter)
+
+	if err = f.ParseQueryString(); err != nil {
+		return fileNames, err
+	}
+
+	// Separate file types from media types, which are filtered by the search.
+	var mediaTypes, fileTypes []string
+
+	knownTypes := fs.Extensions.Types(true)
+
+	for _, t := range strings.Split(strings.ToLower(f.Type), txt.Or) {
+		if t = strings.TrimSpace(t); t == "" {
+			continue
+		} else if _, ok := knownTypes[fs.Type(t)]; ok && !media.Type(t).Main() {
+			fileTypes = append(fileTypes, t)
+		} else {
+			mediaTypes = append(mediaTypes, t)
+		}
+	}
+
+	f.Type = strings.Join(mediaTypes, txt.Or)
+	f.Count = search.MaxResults
+	f.Merged = false
+
+	photos, _, err := search.Photos(f)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	photoUIDs := make([]string, 0, len(photos))
+	found := make(map[string]bool, len(photos))
+
+	for _, p := range photos {
+		if !found[p.PhotoUID] {
+			found[p.PhotoUID] = true
+			photoUIDs = append(photoUIDs, p.PhotoUID)
+		}
+	}
+
+	files, err := query.OriginalFiles(photoUIDs, fileTypes)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	subPath = strings.Trim(filepath.ToSlash(subPath), "/")
+
+	for _, file := range files {
+		if subPath != "" && file.FileName != subPath && !strings.HasPrefix(file.FileName, subPath+"/") {
+			continue
+		} else if list.Excludes(ext, fs.NormalizedExt(file.FileName)) {
+			continue
+		}
+
+		fileNames = append(fileNames, filepath.Join(conf.OriginalsPath(), file.FileName))
+	}
+
+	return fileNames, nil
+}
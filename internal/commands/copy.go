@@ -26,12 +26,20 @@ var CopyCommand = cli.Command{
 			Name:  "dest, d",
 			Usage: "relative originals `PATH` to which the files should be imported",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only show which files would be copied or deleted",
+		},
 	},
 	Action: copyAction,
 }
 
 // copyAction copies photos to originals path. Default import path is used if no path argument provided
 func copyAction(ctx *cli.Context) error {
+	if Remote(ctx) {
+		return remoteImportAction(ctx, false)
+	}
+
 	start := time.Now()
 
 	conf, err := InitConfig(ctx)
@@ -81,12 +89,24 @@ func copyAction(ctx *cli.Context) error {
 
 	w := get.Import()
 	opt := photoprism.ImportOptionsCopy(sourcePath, destFolder)
+	opt.Dry = ctx.Bool("dry-run")
 
-	w.Start(opt)
+	done := w.Start(opt)
 
 	elapsed := time.Since(start)
 
 	log.Infof("completed in %s", elapsed)
 
+	if JsonOutput(ctx) {
+		return printJson(ImportResult{
+			Source:   sourcePath,
+			Dest:     filepath.Join(conf.OriginalsPath(), destFolder),
+			Found:    len(done),
+			Imported: done.Processed(),
+			Dry:      opt.Dry,
+			Duration: elapsed.String(),
+		})
+	}
+
 	return nil
 }
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// DedupCommand configures the command name, flags, and action.
+var DedupCommand = cli.Command{
+	Name:   "dedup",
+	Usage:  "Stores existing originals by content hash and replaces identical files with hard links",
+	Flags:  dedupFlags,
+	Action: dedupAction,
+}
+
+var dedupFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, don't actually change anything",
+	},
+}
+
+// dedupAction converts existing originals to the content-addressed storage layout.
+func dedupAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := InitConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if !conf.OriginalsHashLayout() {
+		return fmt.Errorf("originals layout must be set to hash, read-only and write-once mode must be disabled")
+	}
+
+	dry := ctx.Bool("dry")
+
+	var linked, duplicates, failed int
+	var saved int64
+
+	for _, r := range conf.OriginalsRoots() {
+		if r.ReadOnly {
+			log.Infof("dedup: skipped read-only originals root %s", clean.Log(r.Name))
+			continue
+		}
+
+		rootPath := r.Path
+		rootName := r.Name
+		objectsPath := r.ObjectsPath()
+
+		err = filepath.Walk(rootPath, func(fileName string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			} else if info.IsDir() {
+				// Skip hidden folders, including the objects path.
+				if fileName != rootPath && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+
+				return nil
+			} else if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), ".") {
+				return nil
+			}
+
+			relName := filepath.Join(rootName, fs.RelName(fileName, rootPath))
+			hash := fs.Hash(fileName)
+
+			if hash == "" {
+				failed++
+				log.Warnf("dedup: failed to hash %s", clean.Log(relName))
+				return nil
+			}
+
+			obj := fs.ObjectName(objectsPath, hash)
+
+			if objInfo, statErr := os.Stat(obj); statErr == nil {
+				if os.SameFile(info, objInfo) {
+					return nil
+				}
+
+				duplicates++
+				saved += info.Size()
+			}
+
+			if dry {
+				log.Infof("dedup: %s would be linked", clean.Log(relName))
+			} else if err = fs.LinkObject(fileName, fileName, objectsPath, hash, false); err != nil {
+				failed++
+				log.Errorf("dedup: %s (link %s)", err, clean.Log(relName))
+				return nil
+			} else {
+				log.Debugf("dedup: linked %s", clean.Log(relName))
+			}
+
+			linked++
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("dedup: linked %s, found %s, %s saved, %d failed [%s]", english.Plural(linked, "file", "files"), english.Plural(duplicates, "duplicate", "duplicates"), humanize.Bytes(uint64(saved)), failed, time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,322 @@
+package commands
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/migrate"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// Doctor finding status values.
+const (
+	DoctorOK      = "OK"
+	DoctorWarning = "Warning"
+	DoctorError   = "Error"
+)
+
+// DoctorCommand configures the command name, flags, and action.
+var DoctorCommand = cli.Command{
+	Name:   "doctor",
+	Usage:  "Checks the database, dependencies, storage permissions and config for common problems",
+	Flags:  report.CliFlags,
+	Action: doctorAction,
+}
+
+// DoctorFinding represents the result of a single diagnostic check.
+type DoctorFinding struct {
+	Check   string
+	Status  string
+	Details string
+}
+
+// DoctorFindings represents a list of diagnostic check results.
+type DoctorFindings []DoctorFinding
+
+// Add appends a check result.
+func (f *DoctorFindings) Add(check, status, details string, args ...interface{}) {
+	if len(args) > 0 {
+		details = fmt.Sprintf(details, args...)
+	}
+
+	*f = append(*f, DoctorFinding{Check: check, Status: status, Details: details})
+}
+
+// Count returns the number of findings with the specified status.
+func (f DoctorFindings) Count(status string) (n int) {
+	for _, finding := range f {
+		if finding.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Report returns the findings as report rows and columns.
+func (f DoctorFindings) Report() (rows [][]string, cols []string) {
+	cols = []string{"Check", "Status", "Details"}
+	rows = make([][]string, len(f))
+
+	for i, finding := range f {
+		rows[i] = []string{finding.Check, finding.Status, finding.Details}
+	}
+
+	return rows, cols
+}
+
+// doctorAction runs diagnostic checks and displays actionable findings.
+func doctorAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+	initJsonOutput(ctx)
+	get.SetConfig(conf)
+
+	// Only log fatal errors, since problems are reported as findings.
+	conf.SetLogLevel(logrus.FatalLevel)
+
+	var findings DoctorFindings
+
+	if err := conf.Init(); err != nil {
+		findings.Add("Config", DoctorError, "%s", strings.TrimPrefix(err.Error(), "config: "))
+	} else {
+		findings.Add("Config", DoctorOK, "initialized %s", clean.Log(conf.Version()))
+	}
+
+	defer conf.Shutdown()
+
+	doctorDatabase(conf, &findings)
+	doctorTools(conf, &findings)
+	doctorStorage(conf, &findings)
+	doctorSettings(conf, &findings)
+	doctorTLS(conf, &findings)
+
+	rows, cols := findings.Report()
+	result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s\n", result)
+
+	if n := findings.Count(DoctorError); n > 0 {
+		return fmt.Errorf("doctor: found %s, see details above", english.Plural(n, "problem", "problems"))
+	}
+
+	return nil
+}
+
+// doctorDatabase checks the database connection and the status of schema migrations.
+func doctorDatabase(conf *config.Config, findings *DoctorFindings) {
+	if !conf.DbConnected() {
+		findings.Add("Database", DoctorError, "cannot connect to %s, check the database server and credentials", conf.DatabaseDriver())
+		return
+	}
+
+	if err := conf.Db().DB().Ping(); err != nil {
+		findings.Add("Database", DoctorError, "%s server not responding (%s)", conf.DatabaseDriver(), err)
+		return
+	}
+
+	findings.Add("Database", DoctorOK, "connected to %s", conf.DatabaseDriver())
+
+	status, err := migrate.Status(conf.Db(), nil)
+
+	if err != nil {
+		findings.Add("Migrations", DoctorError, "%s", err)
+		return
+	}
+
+	var failed, pending []string
+
+	for _, m := range status {
+		if m.Error != "" {
+			failed = append(failed, m.ID)
+		} else if !m.Finished() {
+			pending = append(pending, m.ID)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		findings.Add("Migrations", DoctorError, "%s failed, run 'photoprism migrations run -f' to retry", strings.Join(failed, ", "))
+	case len(pending) > 0:
+		findings.Add("Migrations", DoctorWarning, "%s pending, run 'photoprism migrations run' to complete", english.Plural(len(pending), "migration", "migrations"))
+	default:
+		findings.Add("Migrations", DoctorOK, "%s completed", english.Plural(len(status), "migration", "migrations"))
+	}
+}
+
+// doctorTools checks if external dependencies are available and reports their versions.
+func doctorTools(conf *config.Config, findings *DoctorFindings) {
+	tools := []struct {
+		Name    string
+		Bin     string
+		Enabled bool
+		Flag    string
+		Arg     string
+	}{
+		{"FFmpeg", conf.FFmpegBin(), conf.FFmpegEnabled(), "disable-ffmpeg", "-version"},
+		{"ExifTool", conf.ExifToolBin(), conf.ExifToolEnabled(), "disable-exiftool", "-ver"},
+		{"Darktable", conf.DarktableBin(), conf.DarktableEnabled(), "disable-darktable", "--version"},
+	}
+
+	for _, t := range tools {
+		if !t.Enabled {
+			findings.Add(t.Name, DoctorOK, "disabled")
+		} else if t.Bin == "" {
+			findings.Add(t.Name, DoctorWarning, "not found, install it or set --%s", t.Flag)
+		} else if version, err := toolVersion(t.Bin, t.Arg); err != nil {
+			findings.Add(t.Name, DoctorError, "%s failed to run (%s)", clean.Log(t.Bin), err)
+		} else {
+			findings.Add(t.Name, DoctorOK, "%s", version)
+		}
+	}
+}
+
+// toolVersion runs the command with the version argument and returns the first line of its output.
+func toolVersion(bin, arg string) (string, error) {
+	c, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(c, bin, arg).Output()
+
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(string(out))
+
+	if i := strings.Index(version, "\n"); i > 0 {
+		version = strings.TrimSpace(version[:i])
+	}
+
+	return version, nil
+}
+
+// doctorStorage checks if the storage folders exist and have the required permissions.
+func doctorStorage(conf *config.Config, findings *DoctorFindings) {
+	paths := []struct {
+		Name     string
+		Path     string
+		Writable bool
+	}{
+		{"Originals", conf.OriginalsPath(), !conf.ReadOnly()},
+		{"Import", conf.ImportPath(), !conf.ReadOnly()},
+		{"Storage", conf.StoragePath(), true},
+		{"Sidecar", conf.SidecarPath(), conf.SidecarWritable()},
+		{"Cache", conf.CachePath(), true},
+		{"Thumbnails", conf.ThumbCachePath(), true},
+		{"Temp", conf.TempPath(), true},
+	}
+
+	for _, p := range paths {
+		if p.Path == "" {
+			if p.Name == "Import" {
+				continue
+			}
+
+			findings.Add(p.Name, DoctorError, "path not configured")
+		} else if !fs.PathExists(p.Path) {
+			findings.Add(p.Name, DoctorError, "%s not found, create it or fix the path", clean.Log(p.Path))
+		} else if p.Writable && !fs.PathWritable(p.Path) {
+			findings.Add(p.Name, DoctorError, "%s is not writable, check the owner and permissions", clean.Log(p.Path))
+		} else {
+			findings.Add(p.Name, DoctorOK, "%s", clean.Log(p.Path))
+		}
+	}
+
+	minFree := conf.MinFreeBytes()
+
+	for _, d := range conf.DiskUsage() {
+		if d.Error != "" || d.ReadOnly || d.Total == 0 {
+			continue
+		} else if d.Low {
+			findings.Add("Disk Space", DoctorError, "%s has only %s free, less than the minimum of %s", d.Name, humanize.IBytes(d.Free), humanize.IBytes(minFree))
+		} else if d.Free < d.Total/20 {
+			findings.Add("Disk Space", DoctorWarning, "%s has only %s free", d.Name, humanize.IBytes(d.Free))
+		}
+	}
+}
+
+// doctorSettings checks the config for insecure or problematic values.
+func doctorSettings(conf *config.Config, findings *DoctorFindings) {
+	if conf.Public() {
+		findings.Add("Authentication", DoctorWarning, "disabled in public mode, don't expose this instance to the Internet")
+	} else if conf.AdminPassword() == "" {
+		findings.Add("Authentication", DoctorWarning, "no admin password configured, set it with 'photoprism passwd'")
+	} else {
+		findings.Add("Authentication", DoctorOK, "%s mode", conf.AuthMode())
+	}
+
+	if config.TotalMem > 0 && config.TotalMem < config.MinMem {
+		findings.Add("Memory", DoctorWarning, "only %s detected, at least %s is recommended", humanize.IBytes(config.TotalMem), humanize.IBytes(config.MinMem))
+	} else if config.TotalMem > 0 {
+		findings.Add("Memory", DoctorOK, "%s detected", humanize.IBytes(config.TotalMem))
+	}
+
+	if conf.Unsafe() {
+		findings.Add("Unsafe Mode", DoctorWarning, "enabled, database compatibility checks are skipped")
+	}
+}
+
+// doctorTLS checks the HTTPS configuration and the validity of the TLS certificate.
+func doctorTLS(conf *config.Config, findings *DoctorFindings) {
+	if !conf.SiteHttps() {
+		findings.Add("TLS", DoctorOK, "not used, site URL is %s", clean.Log(conf.SiteUrl()))
+		return
+	}
+
+	certName, keyName := conf.TLSCert(), conf.TLSKey()
+
+	if certName == "" || keyName == "" {
+		if conf.TLSEmail() != "" {
+			findings.Add("TLS", DoctorOK, "automatic HTTPS via Let's Encrypt for %s", clean.Log(conf.SiteDomain()))
+		} else {
+			findings.Add("TLS", DoctorWarning, "no certificate found in %s, make sure a reverse proxy terminates HTTPS", clean.Log(conf.CertificatesPath()))
+		}
+
+		return
+	}
+
+	cert, err := tls.LoadX509KeyPair(certName, keyName)
+
+	if err != nil {
+		findings.Add("TLS", DoctorError, "invalid certificate or key (%s)", err)
+		return
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+
+	if err != nil {
+		findings.Add("TLS", DoctorError, "invalid certificate (%s)", err)
+		return
+	}
+
+	switch expires := time.Until(leaf.NotAfter); {
+	case expires <= 0:
+		findings.Add("TLS", DoctorError, "certificate expired on %s", leaf.NotAfter.Format("2006-01-02"))
+	case expires < 30*24*time.Hour:
+		findings.Add("TLS", DoctorWarning, "certificate expires on %s, renew it soon", leaf.NotAfter.Format("2006-01-02"))
+	default:
+		if err = leaf.VerifyHostname(conf.SiteDomain()); err != nil {
+			findings.Add("TLS", DoctorWarning, "%s", err)
+		} else {
+			findings.Add("TLS", DoctorOK, "certificate valid until %s", leaf.NotAfter.Format("2006-01-02"))
+		}
+	}
+}
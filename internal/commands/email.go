@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/mail"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// EmailCommand configures the email subcommands.
+var EmailCommand = cli.Command{
+	Name:  "email",
+	Usage: "Outgoing email subcommands",
+	Subcommands: []cli.Command{
+		EmailTestCommand,
+	},
+}
+
+// EmailTestCommand configures the command name, flags, and action.
+var EmailTestCommand = cli.Command{
+	Name:      "test",
+	Usage:     "Sends a test email to check the outgoing mail server settings",
+	ArgsUsage: "[email]",
+	Action:    emailTestAction,
+}
+
+// emailTestAction sends a test email to the address specified as command argument.
+func emailTestAction(ctx *cli.Context) error {
+	to := clean.Email(ctx.Args().First())
+
+	if to == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := InitConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if !conf.MailEnabled() {
+		return fmt.Errorf("outgoing mail server and sender address must be configured first")
+	}
+
+	msg, err := mail.Render(mail.TemplateTest, conf.MailData(), to)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("sending test email to %s via %s", clean.Log(to), clean.Log(conf.MailOptions().Addr()))
+
+	if err = conf.Mailer().Send(msg); err != nil {
+		return err
+	}
+
+	log.Infof("test email has been sent")
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// EmbeddingsCommand configures the command name, flags, and action.
+var EmbeddingsCommand = cli.Command{
+	Name:  "embeddings",
+	Usage: "Embedding export and import subcommands for external ML pipelines",
+	Subcommands: []cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Exports embedding vectors as JSON Lines",
+			ArgsUsage: "[filename]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "model, m",
+					Usage: "embedding model `NAME`, exports all photo embeddings if empty",
+					Value: face.ActiveModel.Name,
+				},
+			},
+			Action: embeddingsExportAction,
+		},
+		{
+			Name:      "import",
+			Usage:     "Imports externally computed embeddings and labels from JSON Lines",
+			ArgsUsage: "[filename]",
+			Action:    embeddingsImportAction,
+		},
+	},
+}
+
+// embeddingsExportAction exports embedding vectors to a file or stdout.
+func embeddingsExportAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	var w io.Writer = os.Stdout
+
+	if fileName := ctx.Args().First(); fileName != "" && fileName != "-" {
+		f, err := os.Create(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	count, err := photoprism.ExportEmbeddings(w, ctx.String("model"))
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("exported %s [%s]", english.Plural(count, "embedding", "embeddings"), time.Since(start))
+
+	return nil
+}
+
+// embeddingsImportAction imports embeddings and labels from a file or stdin.
+func embeddingsImportAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	var r io.Reader = os.Stdin
+
+	if fileName := ctx.Args().First(); fileName != "" && fileName != "-" {
+		f, err := os.Open(fileName)
+
+		if err != nil {
+			return fmt.Errorf("%s not found", clean.Log(fileName))
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	result, err := photoprism.ImportEmbeddings(r)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("imported %s and %s, skipped %d [%s]",
+		english.Plural(result.Embeddings, "embedding", "embeddings"),
+		english.Plural(result.Labels, "label", "labels"),
+		result.Skipped, time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ExportCommand configures the command name, flags, and action.
+var ExportCommand = cli.Command{
+	Name:  "export",
+	Usage: "Exports all data of a user account as zip archive or an album as static HTML gallery",
+	Description: "User data archives contain the originals, sidecar files, picture metadata, albums, shares, comments, and reactions of the user. A README.txt file in the archive describes the layout.\n\n" +
+		"Album galleries contain the public pictures of the album as resized images, an index.html file, and a map of the picture locations if raster map tiles are available. They can be viewed offline in any web browser.",
+	ArgsUsage: "[filename.zip | folder]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "user account `NAME` or UID",
+		},
+		cli.StringFlag{
+			Name:  "album, a",
+			Usage: "album `UID` or slug to export as static HTML gallery",
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "replace existing files",
+		},
+	},
+	Action: exportAction,
+}
+
+// exportAction exports the data of a user account to a zip archive or an album as static HTML gallery.
+func exportAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		if ctx.String("album") != "" {
+			return exportGallery(ctx, conf)
+		}
+
+		name := clean.Username(ctx.String("user"))
+
+		if name == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		user := entity.FindUserByName(name)
+
+		if user == nil {
+			user = entity.FindUserByUID(clean.UID(ctx.String("user")))
+		}
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", clean.LogQuote(name))
+		}
+
+		fileName := strings.TrimSpace(ctx.Args().First())
+
+		if fileName == "" {
+			fileName = photoprism.UserExportName(user)
+		}
+
+		out := os.Stdout
+
+		if fileName != "-" {
+			if fs.FileExists(fileName) && !ctx.Bool("force") {
+				return fmt.Errorf("%s already exists", clean.Log(fileName))
+			}
+
+			f, err := os.OpenFile(fileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0o600)
+
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+
+			out = f
+		}
+
+		result, err := photoprism.ExportUser(conf, out, user)
+
+		if err != nil {
+			if fileName != "-" {
+				_ = os.Remove(fileName)
+			}
+
+			return err
+		} else if fileName == "-" {
+			return nil
+		}
+
+		log.Infof("exported %d originals, %d sidecar files, %d pictures, and %d albums to %s [%s]",
+			result.Originals, result.Sidecars, result.Photos, result.Albums, clean.Log(fileName), humanize.Bytes(uint64(result.Size)))
+
+		if result.Missing > 0 {
+			log.Warnf("%d files could not be found and are marked as missing in %s", result.Missing, photoprism.UserExportFilesFile)
+		}
+
+		return nil
+	})
+}
+
+// exportGallery exports an album as static HTML gallery.
+func exportGallery(ctx *cli.Context, conf *config.Config) error {
+	get.SetConfig(conf)
+
+	album, err := query.AlbumByUID(clean.UID(ctx.String("album")))
+
+	if err != nil {
+		if a := entity.FindAlbumBySlug(txt.Slug(ctx.String("album")), entity.AlbumManual); a != nil {
+			album = *a
+		} else {
+			return fmt.Errorf("album %s not found", clean.LogQuote(ctx.String("album")))
+		}
+	}
+
+	dest := strings.TrimSpace(ctx.Args().First())
+
+	if dest == "" {
+		dest = album.AlbumSlug
+	}
+
+	if fs.PathExists(dest) && !fs.DirIsEmpty(dest) && !ctx.Bool("force") {
+		return fmt.Errorf("%s is not empty", clean.Log(dest))
+	}
+
+	result, err := photoprism.ExportGallery(conf, &album, dest, get.MapTiles())
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("exported %d pictures of %s to %s", result.Photos, clean.Log(album.Title()), clean.Log(filepath.Join(dest, photoprism.GalleryIndex)))
+
+	if result.Skipped > 0 {
+		log.Warnf("%d pictures could not be exported", result.Skipped)
+	}
+
+	return nil
+}
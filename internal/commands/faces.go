@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -11,11 +12,13 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // FacesCommand configures the command name, flags, and action.
@@ -36,6 +39,10 @@ var FacesCommand = cli.Command{
 					Name:  "fix, f",
 					Usage: "fix discovered issues",
 				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "only show which markers, faces, and people would be changed, even if --fix is set",
+				},
 			},
 			Action: facesAuditAction,
 		},
@@ -72,6 +79,39 @@ var FacesCommand = cli.Command{
 			Usage:  "Optimizes face clusters",
 			Action: facesOptimizeAction,
 		},
+		{
+			Name:   "migrate",
+			Usage:  "Recomputes face embeddings with the configured model, keeping confirmed names",
+			Action: facesMigrateAction,
+		},
+		{
+			Name:  "thresholds",
+			Usage: "Shows how many markers would be added or removed with different detection thresholds",
+			Flags: append([]cli.Flag{
+				cli.IntFlag{
+					Name:  "size",
+					Usage: "minimum face `PIXELS` (20-10000), defaults to the configured value",
+				},
+				cli.Float64Flag{
+					Name:  "score",
+					Usage: "minimum face quality `SCORE` (1-100), defaults to the configured value",
+				},
+				cli.IntFlag{
+					Name:  "overlap",
+					Usage: "face area overlap threshold in `PERCENT` (1-100), defaults to the configured value",
+				},
+				cli.IntFlag{
+					Name:  "sample, s",
+					Usage: "number of `FILES` to detect faces in for estimating new markers",
+					Value: 100,
+				},
+				cli.BoolFlag{
+					Name:  "apply",
+					Usage: "flag markers that do not meet the thresholds as invalid",
+				},
+			}, report.CliFlags...),
+			Action: facesThresholdsAction,
+		},
 	},
 }
 
@@ -123,7 +163,7 @@ func facesAuditAction(ctx *cli.Context) error {
 
 	w := get.Faces()
 
-	if err := w.Audit(ctx.Bool("fix")); err != nil {
+	if err := w.Audit(ctx.Bool("fix") && !ctx.Bool("dry-run")); err != nil {
 		return err
 	} else {
 		elapsed := time.Since(start)
@@ -344,3 +384,104 @@ func facesOptimizeAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// facesMigrateAction recomputes face embeddings after the face model has been changed.
+func facesMigrateAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	get.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	log.Infof("migrating faces to %s", clean.Log(conf.FaceModel()))
+
+	w := get.Faces()
+
+	if res, err := w.Migrate(get.FaceNet()); err != nil {
+		return err
+	} else {
+		elapsed := time.Since(start)
+
+		log.Infof("migrated %s, %d failed, in %s", english.Plural(res.Updated, "marker", "markers"), res.Failed, elapsed)
+	}
+
+	return nil
+}
+
+// facesThresholdsAction shows how many markers would be added or removed with different face detection thresholds.
+func facesThresholdsAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	get.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	t := face.Thresholds{Size: conf.FaceSize(), Score: conf.FaceScore(), Overlap: conf.FaceOverlap()}
+
+	if ctx.IsSet("size") {
+		t.Size = ctx.Int("size")
+	}
+
+	if ctx.IsSet("score") {
+		t.Score = ctx.Float64("score")
+	}
+
+	if ctx.IsSet("overlap") {
+		t.Overlap = ctx.Int("overlap")
+	}
+
+	w := get.Faces()
+
+	res, err := w.Thresholds(t, ctx.Int("sample"), ctx.Bool("apply"))
+
+	if err != nil {
+		return err
+	}
+
+	cols := []string{"Name", "Value"}
+	rows := [][]string{
+		{"Size", fmt.Sprintf("%d", t.Size)},
+		{"Score", fmt.Sprintf("%f", t.Score)},
+		{"Overlap", fmt.Sprintf("%d", t.Overlap)},
+		{"Markers", fmt.Sprintf("%d", res.Markers)},
+		{"Removed", fmt.Sprintf("%d", res.Removed)},
+		{"Sampled Files", fmt.Sprintf("%d", res.Sampled)},
+		{"Sampled New Faces", fmt.Sprintf("%d", res.Found)},
+		{"Added (Estimate)", fmt.Sprintf("%d", res.Added)},
+		{"Applied", report.Bool(res.Applied, report.Yes, report.No)},
+	}
+
+	result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	fmt.Printf("\n%s\n", result)
+
+	if err != nil {
+		return err
+	}
+
+	if !res.Applied {
+		log.Infof("dry run, use --apply to flag %s as invalid", english.Plural(res.Removed, "marker", "markers"))
+	}
+
+	log.Infof("completed in %s", time.Since(start))
+
+	return nil
+}
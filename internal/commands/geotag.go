@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/track"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GeotagCommand configures the command name, flags, and action.
+var GeotagCommand = cli.Command{
+	Name:      "geotag",
+	Usage:     "Sets the location of pictures without GPS coordinates based on GPX or KML track files",
+	ArgsUsage: "[filename.gpx] ...",
+	Flags:     geotagFlags,
+	Action:    geotagAction,
+}
+
+var geotagFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "offset, o",
+		Usage: "`DURATION` added to the camera time, e.g. 2m if the camera clock was two minutes behind",
+	},
+	cli.StringFlag{
+		Name:  "tz",
+		Usage: "camera clock time zone `NAME`, e.g. Europe/Berlin, for pictures without a known time zone (default: UTC)",
+	},
+	cli.DurationFlag{
+		Name:  "gap",
+		Usage: "maximum `DURATION` between two track points",
+		Value: track.MaxGap,
+	},
+	cli.BoolFlag{
+		Name:  "sidecar, s",
+		Usage: "update YAML sidecar files",
+	},
+}
+
+// geotagAction geotags pictures based on the specified track files.
+func geotagAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	if ctx.NArg() == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	var tracks track.Tracks
+
+	for _, fileName := range ctx.Args() {
+		t, err := track.Read(fileName)
+
+		if err != nil {
+			return fmt.Errorf("%s in %s", err, clean.Log(fileName))
+		}
+
+		tracks = append(tracks, t...)
+	}
+
+	log.Infof("geotag: found %s in %s", english.Plural(tracks.Points(), "track point", "track points"), english.Plural(ctx.NArg(), "file", "files"))
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	opt := photoprism.GeotagOptions{
+		Offset:   ctx.Duration("offset"),
+		TimeZone: ctx.String("tz"),
+		MaxGap:   ctx.Duration("gap"),
+		Sidecar:  ctx.Bool("sidecar"),
+	}
+
+	result, err := photoprism.Geotag(conf, tracks, opt)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("geotag: %s tagged, completed in %s", english.Plural(result.Tagged, "picture", "pictures"), time.Since(start))
+
+	return nil
+}
@@ -3,6 +3,8 @@ package commands
 import (
 	"context"
 	"errors"
+	"net/http"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -26,12 +29,30 @@ var ImportCommand = cli.Command{
 			Name:  "dest, d",
 			Usage: "relative originals `PATH` to which the files should be imported",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only show which files would be moved or deleted",
+		},
 	},
 	Action: importAction,
 }
 
+// ImportResult represents the result of an import in JSON output mode.
+type ImportResult struct {
+	Source   string `json:"Source"`
+	Dest     string `json:"Dest"`
+	Found    int    `json:"Found"`
+	Imported int    `json:"Imported"`
+	Dry      bool   `json:"Dry"`
+	Duration string `json:"Duration"`
+}
+
 // importAction moves photos to originals path. Default import path is used if no path argument provided
 func importAction(ctx *cli.Context) error {
+	if Remote(ctx) {
+		return remoteImportAction(ctx, true)
+	}
+
 	start := time.Now()
 
 	conf, err := InitConfig(ctx)
@@ -81,12 +102,35 @@ func importAction(ctx *cli.Context) error {
 
 	w := get.Import()
 	opt := photoprism.ImportOptionsMove(sourcePath, destFolder)
+	opt.Dry = ctx.Bool("dry-run")
 
-	w.Start(opt)
+	done := w.Start(opt)
 
 	elapsed := time.Since(start)
 
 	log.Infof("completed in %s", elapsed)
 
+	if JsonOutput(ctx) {
+		return printJson(ImportResult{
+			Source:   sourcePath,
+			Dest:     filepath.Join(conf.OriginalsPath(), destFolder),
+			Found:    len(done),
+			Imported: done.Processed(),
+			Dry:      opt.Dry,
+			Duration: elapsed.String(),
+		})
+	}
+
 	return nil
 }
+
+// remoteImportAction imports files from a subfolder of the import path on a remote instance.
+func remoteImportAction(ctx *cli.Context, move bool) error {
+	if ctx.Bool("dry-run") {
+		return errors.New("dry run is not supported for remote imports")
+	}
+
+	subFolder := clean.UserPath(ctx.Args().First())
+
+	return remoteAction(ctx, http.MethodPost, path.Join("import", subFolder), form.ImportOptions{Move: move})
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -39,8 +41,23 @@ var indexFlags = []cli.Flag{
 	},
 }
 
+// IndexResult represents the result of indexing in JSON output mode.
+type IndexResult struct {
+	Path         string `json:"Path"`
+	Found        int    `json:"Found"`
+	Indexed      int    `json:"Indexed"`
+	PurgedFiles  int    `json:"PurgedFiles"`
+	PurgedPhotos int    `json:"PurgedPhotos"`
+	CleanedUp    int    `json:"CleanedUp"`
+	Duration     string `json:"Duration"`
+}
+
 // indexAction indexes all photos in originals directory (photo library)
 func indexAction(ctx *cli.Context) error {
+	if Remote(ctx) {
+		return remoteIndexAction(ctx)
+	}
+
 	start := time.Now()
 
 	conf, err := InitConfig(ctx)
@@ -71,6 +88,8 @@ func indexAction(ctx *cli.Context) error {
 	var found fs.Done
 	var indexed int
 
+	result := IndexResult{Path: filepath.Join(conf.OriginalsPath(), subPath)}
+
 	if w := get.Index(); w != nil {
 		indexStart := time.Now()
 		convert := conf.Settings().Index.Convert && conf.SidecarWritable()
@@ -91,8 +110,13 @@ func indexAction(ctx *cli.Context) error {
 
 		if files, photos, updated, err := w.Start(opt); err != nil {
 			log.Error(err)
-		} else if updated > 0 {
-			log.Infof("purge: removed %s and %s [%s]", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"), time.Since(purgeStart))
+		} else {
+			result.PurgedFiles = len(files)
+			result.PurgedPhotos = len(photos)
+
+			if updated > 0 {
+				log.Infof("purge: removed %s and %s [%s]", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"), time.Since(purgeStart))
+			}
 		}
 	}
 
@@ -107,8 +131,8 @@ func indexAction(ctx *cli.Context) error {
 		// Start cleanup worker.
 		if thumbnails, _, sidecars, err := w.Start(opt); err != nil {
 			return err
-		} else if total := thumbnails + sidecars; total > 0 {
-			log.Infof("cleanup: removed %s in total [%s]", english.Plural(total, "file", "files"), time.Since(cleanupStart))
+		} else if result.CleanedUp = thumbnails + sidecars; result.CleanedUp > 0 {
+			log.Infof("cleanup: removed %s in total [%s]", english.Plural(result.CleanedUp, "file", "files"), time.Since(cleanupStart))
 		}
 	}
 
@@ -116,5 +140,29 @@ func indexAction(ctx *cli.Context) error {
 
 	log.Infof("indexed %s in %s", english.Plural(len(found), "file", "files"), elapsed)
 
+	if JsonOutput(ctx) {
+		result.Found = len(found)
+		result.Indexed = indexed
+		result.Duration = elapsed.String()
+
+		return printJson(result)
+	}
+
 	return nil
 }
+
+// remoteIndexAction indexes originals on a remote instance.
+func remoteIndexAction(ctx *cli.Context) error {
+	f := form.IndexOptions{
+		Path:   strings.TrimSpace(ctx.Args().First()),
+		Rescan: ctx.Bool("force"),
+	}
+
+	if err := remoteAction(ctx, http.MethodPost, "index", f); err != nil {
+		return err
+	} else if !ctx.Bool("cleanup") {
+		return nil
+	}
+
+	return remoteAction(ctx, http.MethodPost, "cleanup", form.CleanUpOptions{})
+}
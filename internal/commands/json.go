@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// JsonFlag enables machine-readable JSON output for all commands when used as global flag.
+var JsonFlag = cli.BoolFlag{
+	Name:  "json",
+	Usage: "output results as JSON and log progress as JSON lines to stderr",
+}
+
+// JsonOutput checks if the command results should be printed as JSON.
+func JsonOutput(ctx *cli.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	return ctx.Bool("json") || ctx.GlobalBool("json")
+}
+
+// initJsonOutput changes the log format to JSON, so that progress messages can be parsed as well.
+func initJsonOutput(ctx *cli.Context) {
+	if JsonOutput(ctx) {
+		log.SetFormatter(&logrus.JSONFormatter{})
+	}
+}
+
+// printJson writes the command result as indented JSON to stdout.
+func printJson(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(data))
+
+	return nil
+}
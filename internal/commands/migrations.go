@@ -83,13 +83,13 @@ func migrationsStatusAction(ctx *cli.Context) error {
 	}
 
 	// Report columns.
-	cols := []string{"ID", "Dialect", "Stage", "Started At", "Finished At", "Status"}
+	cols := []string{"ID", "Dialect", "Stage", "Started At", "Finished At", "Progress", "Status"}
 
 	// Report rows.
 	rows := make([][]string, 0, len(status))
 
 	for _, m := range status {
-		var stage, started, finished, info string
+		var stage, started, finished string
 
 		if m.Stage == "" {
 			stage = "main"
@@ -109,19 +109,7 @@ func migrationsStatusAction(ctx *cli.Context) error {
 			finished = "-"
 		}
 
-		if m.Error != "" {
-			info = m.Error
-		} else if m.Finished() {
-			info = "OK"
-		} else if m.StartedAt.IsZero() {
-			info = "-"
-		} else if m.Repeat(false) {
-			info = "Repeat"
-		} else {
-			info = "Running?"
-		}
-
-		rows = append(rows, []string{m.ID, m.Dialect, stage, started, finished, info})
+		rows = append(rows, []string{m.ID, m.Dialect, stage, started, finished, m.Progress(), m.Info()})
 	}
 
 	// Display report.
@@ -13,9 +13,17 @@ import (
 var MomentsCommand = cli.Command{
 	Name:   "moments",
 	Usage:  "Creates albums of special moments, trips, and places",
+	Flags:  momentsFlags,
 	Action: momentsAction,
 }
 
+var momentsFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "rebuild, r",
+		Usage: "remove generated albums that no longer match the current settings",
+	},
+}
+
 // momentsAction creates albums of special moments, trips, and places.
 func momentsAction(ctx *cli.Context) error {
 	start := time.Now()
@@ -38,7 +46,13 @@ func momentsAction(ctx *cli.Context) error {
 
 	w := get.Moments()
 
-	if err := w.Start(); err != nil {
+	if ctx.Bool("rebuild") {
+		err = w.Rebuild()
+	} else {
+		err = w.Start()
+	}
+
+	if err != nil {
 		return err
 	} else {
 		elapsed := time.Since(start)
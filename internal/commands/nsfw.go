@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// NSFWCommand configures the command name, flags, and action.
+var NSFWCommand = cli.Command{
+	Name:   "nsfw",
+	Usage:  "Runs the detection of offensive content again on existing pictures",
+	Flags:  nsfwFlags,
+	Action: nsfwAction,
+}
+
+var nsfwFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "private, p",
+		Usage: "flag pictures above the threshold as private",
+	},
+}
+
+// nsfwAction updates the offensive content scores of all pictures.
+func nsfwAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	log.Infof("nsfw: using threshold %.2f", conf.NSFWThreshold())
+
+	result, err := photoprism.DetectNSFW(conf, get.NsfwDetector(), ctx.Bool("private"))
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("nsfw: %d pictures flagged, completed in %s", result.Flagged, time.Since(start))
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mail"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
@@ -27,6 +28,10 @@ var PasswdCommand = cli.Command{
 			Name:  "show, s",
 			Usage: "show bcrypt password hash",
 		},
+		cli.BoolFlag{
+			Name:  "email, m",
+			Usage: "reset the password to a random one and send it to the user's email address",
+		},
 	},
 	Action: passwdAction,
 }
@@ -67,6 +72,27 @@ func passwdAction(ctx *cli.Context) error {
 		return fmt.Errorf("user %s has been deleted", clean.LogQuote(id))
 	}
 
+	// Reset password and send it by email?
+	if ctx.Bool("email") {
+		if !conf.MailEnabled() {
+			return fmt.Errorf("outgoing mail server and sender address must be configured to send emails")
+		} else if m.Email() == "" {
+			return fmt.Errorf("user %s has no email address", clean.LogQuote(m.Username()))
+		}
+
+		newPassword := randomPassword()
+
+		if err = m.SetPassword(newPassword); err != nil {
+			return err
+		} else if err = sendUserEmail(conf, mail.TemplatePasswordReset, m, newPassword); err != nil {
+			return fmt.Errorf("password has been reset, but the email could not be sent: %s", err)
+		}
+
+		log.Infof("password for %s has been reset and sent to %s\n", clean.Log(m.Username()), clean.Log(m.Email()))
+
+		return nil
+	}
+
 	log.Infof("please enter a new password for %s (minimum %d characters)\n", clean.Log(m.Username()), entity.PasswordLength)
 
 	newPassword := getPassword("New Password: ")
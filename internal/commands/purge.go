@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -29,13 +31,21 @@ var purgeFlags = []cli.Flag{
 		Usage: "permanently remove from index",
 	},
 	cli.BoolFlag{
-		Name:  "dry",
-		Usage: "dry run, don't actually remove anything",
+		Name:  "dry-run, dry",
+		Usage: "dry run, only show which files and photos would be purged",
 	},
 }
 
 // purgeAction removes missing files from search results
 func purgeAction(ctx *cli.Context) error {
+	if Remote(ctx) {
+		return remoteAction(ctx, http.MethodPost, "purge", form.PurgeOptions{
+			Path: strings.TrimSpace(ctx.Args().First()),
+			Hard: ctx.Bool("hard"),
+			Dry:  ctx.Bool("dry"),
+		})
+	}
+
 	start := time.Now()
 
 	conf, err := InitConfig(ctx)
@@ -74,6 +84,8 @@ func purgeAction(ctx *cli.Context) error {
 
 	if files, photos, updated, err := w.Start(opt); err != nil {
 		return err
+	} else if opt.Dry {
+		log.Infof("dry run: would purge %s and %s in %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"), time.Since(start))
 	} else if updated > 0 {
 		log.Infof("purged %s and %s in %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"), time.Since(start))
 	} else {
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ErrRemoteUnsupported is returned if a command cannot be run on a remote instance.
+var ErrRemoteUnsupported = errors.New("command cannot be run on a remote instance, omit --server to run it locally")
+
+// RemoteFlags allow running supported commands on another instance over its API when used as global flags.
+var RemoteFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "server",
+		Usage:  "run supported commands on a remote instance with the specified base `URL`, e.g. https://photos.example.com",
+		EnvVar: "PHOTOPRISM_SERVER",
+	},
+	cli.StringFlag{
+		Name:   "token",
+		Usage:  "access `TOKEN` for running commands on a remote instance",
+		EnvVar: "PHOTOPRISM_TOKEN",
+	},
+}
+
+// Remote checks if the command should be run on a remote instance.
+func Remote(ctx *cli.Context) bool {
+	return RemoteServer(ctx) != ""
+}
+
+// RemoteServer returns the base URL of the remote instance, if any.
+func RemoteServer(ctx *cli.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	return strings.TrimRight(strings.TrimSpace(ctx.GlobalString("server")), "/")
+}
+
+// RemoteClient performs API requests on a remote instance.
+type RemoteClient struct {
+	Server string
+	Token  string
+	client *http.Client
+}
+
+// NewRemoteClient returns a new API client for the remote instance specified with the global --server flag.
+func NewRemoteClient(ctx *cli.Context) (*RemoteClient, error) {
+	server := RemoteServer(ctx)
+
+	if u, err := url.Parse(server); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid server url %s", clean.Log(server))
+	}
+
+	initJsonOutput(ctx)
+
+	// Indexing and imports may take a long time, so there is no overall request timeout.
+	return &RemoteClient{
+		Server: server,
+		Token:  strings.TrimSpace(ctx.GlobalString("token")),
+		client: &http.Client{Timeout: 0},
+	}, nil
+}
+
+// Url returns the absolute URL of the API endpoint.
+func (c *RemoteClient) Url(endpoint string) string {
+	return c.Server + "/api/v1/" + strings.TrimLeft(endpoint, "/")
+}
+
+// Request sends an API request with an optional JSON body and returns the response message.
+func (c *RemoteClient) Request(method, endpoint string, body interface{}) (resp i18n.Response, err error) {
+	var reader io.Reader
+
+	if body != nil {
+		data, jsonErr := json.Marshal(body)
+
+		if jsonErr != nil {
+			return resp, jsonErr
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.Url(endpoint), reader)
+
+	if err != nil {
+		return resp, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.Token != "" {
+		req.Header.Set(session.AuthHeader, session.AuthBearer+c.Token)
+	}
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return resp, fmt.Errorf("cannot connect to %s", clean.Log(c.Server))
+	}
+
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return resp, err
+	}
+
+	// Responses other than JSON are only reported with their status code.
+	if len(data) > 0 && json.Unmarshal(data, &resp) != nil {
+		resp = i18n.Response{}
+	}
+
+	if resp.Code == 0 {
+		resp.Code = res.StatusCode
+	}
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized:
+		return resp, fmt.Errorf("%s requires a valid access token, see --token", clean.Log(c.Server))
+	case res.StatusCode >= 400 && resp.Err != "":
+		return resp, fmt.Errorf("%s (status %d)", resp.Err, res.StatusCode)
+	case res.StatusCode >= 400:
+		return resp, fmt.Errorf("%s returned status %d", clean.Log(c.Server), res.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// RemoteResult represents the result of a remote command in JSON output mode.
+type RemoteResult struct {
+	Server   string `json:"Server"`
+	Code     int    `json:"Code"`
+	Message  string `json:"Message"`
+	Duration string `json:"Duration"`
+}
+
+// remoteAction runs a command on the remote instance and displays the result.
+func remoteAction(ctx *cli.Context, method, endpoint string, body interface{}) error {
+	start := time.Now()
+
+	c, err := NewRemoteClient(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("remote: sending request to %s", clean.Log(c.Url(endpoint)))
+
+	resp, err := c.Request(method, endpoint, body)
+
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	if JsonOutput(ctx) {
+		return printJson(RemoteResult{
+			Server:   c.Server,
+			Code:     resp.Code,
+			Message:  resp.String(),
+			Duration: elapsed.String(),
+		})
+	}
+
+	if msg := resp.String(); msg != "" {
+		log.Infof("remote: %s", msg)
+	}
+
+	log.Infof("completed in %s", elapsed)
+
+	return nil
+}
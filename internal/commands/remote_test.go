@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteClient_Url(t *testing.T) {
+	c := &RemoteClient{Server: "https://photos.example.com"}
+
+	assert.Equal(t, "https://photos.example.com/api/v1/index", c.Url("index"))
+	assert.Equal(t, "https://photos.example.com/api/v1/import/foo", c.Url("/import/foo"))
+}
+
+func TestRemoteClient_Request(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":401,"error":"Unauthorized"}`))
+		case r.URL.Path == "/api/v1/index" && r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"code":200,"message":"Indexing completed in 3 s"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":403,"error":"Permission denied"}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		c := &RemoteClient{Server: srv.URL, Token: "secret", client: srv.Client()}
+		resp, err := c.Request(http.MethodPost, "index", map[string]bool{"rescan": true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "Indexing completed in 3 s", resp.String())
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		c := &RemoteClient{Server: srv.URL, Token: "secret", client: srv.Client()}
+		_, err := c.Request(http.MethodPost, "purge", nil)
+
+		assert.EqualError(t, err, "Permission denied (status 403)")
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		c := &RemoteClient{Server: srv.URL, client: srv.Client()}
+		_, err := c.Request(http.MethodPost, "index", nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "--token")
+	})
+}
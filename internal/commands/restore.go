@@ -3,6 +3,7 @@ package commands
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -20,23 +21,27 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 const restoreDescription = "A user-defined filename or - for stdin can be passed as the first argument. " +
 	"The -i parameter can be omitted in this case.\n" +
-	"   The index backup and album file paths are automatically detected if not specified explicitly."
+	"   With -r, the filename refers to a backup in the configured object storage bucket or WebDAV folder.\n" +
+	"   The index backup and album file paths are automatically detected if not specified explicitly.\n" +
+	"   Existing albums and people are skipped, unless --merge is used to restore missing pictures and empty fields.\n" +
+	"   Use --dry to preview the changes to albums and people without applying them."
 
 // RestoreCommand configures the command name, flags, and action.
 var RestoreCommand = cli.Command{
 	Name:        "restore",
 	Description: restoreDescription,
-	Usage:       "Restores the index from a backup and optionally albums from YAML files",
+	Usage:       "Restores the index from a backup and optionally albums and people from YAML files",
 	ArgsUsage:   "[filename]",
 	Flags:       restoreFlags,
 	Action:      restoreAction,
 }
 
-var restoreFlags = []cli.Flag{
+var restoreFlags = append([]cli.Flag{
 	cli.BoolFlag{
 		Name:  "force, f",
 		Usage: "replace existing index",
@@ -49,6 +54,18 @@ var restoreFlags = []cli.Flag{
 		Name:  "albums-path",
 		Usage: "custom album files `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "people, p",
+		Usage: "restore people from YAML files",
+	},
+	cli.BoolFlag{
+		Name:  "merge",
+		Usage: "merge album and people files into existing ones instead of skipping them",
+	},
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, only show the changes to albums and people",
+	},
 	cli.BoolFlag{
 		Name:  "index, i",
 		Usage: "restore index from backup",
@@ -57,7 +74,19 @@ var restoreFlags = []cli.Flag{
 		Name:  "index-path",
 		Usage: "custom index backup `PATH`",
 	},
-}
+	cli.BoolFlag{
+		Name:  "remote, r",
+		Usage: "download the latest or specified backup from the configured object storage bucket or WebDAV folder",
+	},
+	cli.BoolFlag{
+		Name:  "sidecar, s",
+		Usage: "restore picture YAML files in the sidecar folder from the remote backup, requires -r",
+	},
+	cli.BoolFlag{
+		Name:  "originals, o",
+		Usage: "restore missing originals from the latest remote snapshot, requires -r",
+	},
+}, report.CliFlags...)
 
 // restoreAction restores a database backup.
 func restoreAction(ctx *cli.Context) error {
@@ -68,11 +97,20 @@ func restoreAction(ctx *cli.Context) error {
 
 	albumsPath := ctx.String("albums-path")
 	restoreAlbums := ctx.Bool("albums") || albumsPath != ""
+	restorePeople := ctx.Bool("people")
+	restoreSidecar := ctx.Bool("sidecar") && ctx.Bool("remote")
+	restoreOriginals := ctx.Bool("originals") && ctx.Bool("remote")
 
-	if !restoreIndex && !restoreAlbums {
+	if !restoreIndex && !restoreAlbums && !restorePeople && !restoreSidecar && !restoreOriginals {
 		return cli.ShowSubcommandHelp(ctx)
 	}
 
+	opt := photoprism.RestoreOptions{Merge: ctx.Bool("merge"), Dry: ctx.Bool("dry")}
+
+	if opt.Dry && (restoreIndex || ctx.Bool("remote")) {
+		return errors.New("dry runs are only supported for local album and people files")
+	}
+
 	start := time.Now()
 
 	conf, err := InitConfig(ctx)
@@ -87,6 +125,56 @@ func restoreAction(ctx *cli.Context) error {
 	conf.RegisterDb()
 	defer conf.Shutdown()
 
+	// Download the backup files first if they should be restored from a remote storage.
+	if ctx.Bool("remote") {
+		get.SetConfig(conf)
+
+		remote := get.RemoteBackup()
+
+		if remote == nil {
+			return errors.New("no object storage bucket or WebDAV folder configured for backups")
+		}
+
+		if restoreIndex {
+			if indexFileName, err = remote.DownloadIndex(indexFileName); err != nil {
+				return err
+			}
+		}
+
+		// Download only the YAML files that should be restored.
+		var folders []string
+
+		if restoreAlbums {
+			folders = append(folders, photoprism.BackupAlbumsFolder)
+			albumsPath = conf.AlbumsPath()
+		}
+
+		if restorePeople {
+			folders = append(folders, photoprism.BackupPeopleFolder)
+		}
+
+		if restoreSidecar {
+			folders = append(folders, photoprism.BackupSidecarFolder)
+		}
+
+		if len(folders) > 0 {
+			if count, err := remote.DownloadYaml("", folders...); err != nil {
+				return err
+			} else {
+				log.Infof("downloaded %s", english.Plural(count, "YAML file", "YAML files"))
+			}
+		}
+
+		// Restore the originals before the index, so that the files exist when the index is restored.
+		if restoreOriginals {
+			if res, err := remote.RestoreOriginals("", conf.OriginalsPath(), ctx.Bool("force")); err != nil {
+				return err
+			} else if res.Failed > 0 {
+				log.Warnf("%s could not be restored", english.Plural(res.Failed, "original", "originals"))
+			}
+		}
+	}
+
 	if restoreIndex {
 		// If empty, use default backup file name.
 		if indexFileName == "" {
@@ -138,6 +226,16 @@ func restoreAction(ctx *cli.Context) error {
 				"-f",
 				conf.DatabaseName(),
 			)
+		case config.Postgres:
+			cmd = exec.Command(
+				conf.PsqlBin(),
+				"-q",
+				"-h", conf.DatabaseHost(),
+				"-p", conf.DatabasePortString(),
+				"-U", conf.DatabaseUser(),
+				"-d", conf.DatabaseName(),
+			)
+			cmd.Env = append(os.Environ(), "PGPASSWORD="+conf.DatabasePassword())
 		case config.SQLite3:
 			log.Infoln("dropping existing tables")
 			tables.Drop(conf.Db())
@@ -194,9 +292,11 @@ func restoreAction(ctx *cli.Context) error {
 
 	conf.InitDb()
 
-	if restoreAlbums {
-		get.SetConfig(conf)
+	get.SetConfig(conf)
+
+	var changes photoprism.RestoreChanges
 
+	if restoreAlbums {
 		if albumsPath == "" {
 			albumsPath = conf.AlbumsPath()
 		}
@@ -206,17 +306,67 @@ func restoreAction(ctx *cli.Context) error {
 		} else {
 			log.Infof("restoring albums from %s", clean.Log(albumsPath))
 
-			if count, err := photoprism.RestoreAlbums(albumsPath, true); err != nil {
+			if res, err := photoprism.RestoreAlbumFiles(albumsPath, opt); err != nil {
+				return err
+			} else {
+				changes = append(changes, res...)
+			}
+		}
+	}
+
+	if restorePeople {
+		if !fs.PathExists(conf.PeoplePath()) {
+			log.Warnf("people files path %s not found", clean.Log(conf.PeoplePath()))
+		} else {
+			log.Infof("restoring people from %s", clean.Log(conf.PeoplePath()))
+
+			if res, err := photoprism.RestorePeopleFiles(conf.PeoplePath(), opt); err != nil {
 				return err
 			} else {
-				log.Infof("restored %s from YAML files", english.Plural(count, "album", "albums"))
+				changes = append(changes, res...)
 			}
 		}
 	}
 
+	if len(changes) > 0 {
+		if err = printRestoreChanges(ctx, changes, opt.Dry); err != nil {
+			return err
+		}
+	}
+
 	elapsed := time.Since(start)
 
 	log.Infof("restored in %s", elapsed)
 
 	return nil
 }
+
+// printRestoreChanges shows the changes made, or that would be made in a dry run, when restoring YAML files.
+func printRestoreChanges(ctx *cli.Context, changes photoprism.RestoreChanges, dry bool) error {
+	cols := []string{"Type", "UID", "Name", "Action", "Details"}
+	rows := make([][]string, len(changes))
+
+	for i, c := range changes {
+		action := c.Action
+
+		if dry && (action == photoprism.RestoreAdded || action == photoprism.RestoreMerged) {
+			action = "would be " + action
+		}
+
+		rows[i] = []string{c.Type, c.UID, c.Name, action, c.Details}
+	}
+
+	result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s\n", result)
+
+	log.Infof("%d added, %d merged, %d skipped, %d failed",
+		changes.Count(photoprism.RestoreAdded), changes.Count(photoprism.RestoreMerged),
+		changes.Count(photoprism.RestoreSkipped), changes.Count(photoprism.RestoreFailed))
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// RolePermsUsage returns the usage hint for the role permissions flag.
+func RolePermsUsage() string {
+	caps := make([]string, 0, len(acl.Capabilities))
+
+	for c := range acl.Capabilities {
+		caps = append(caps, string(c))
+	}
+
+	sort.Strings(caps)
+
+	return fmt.Sprintf("granted `PERMISSIONS` separated by spaces: %s", strings.Join(caps, ", "))
+}
+
+// RolesCommand configures the role management subcommands.
+var RolesCommand = cli.Command{
+	Name:    "roles",
+	Aliases: []string{"role"},
+	Usage:   "Custom user role subcommands",
+	Subcommands: []cli.Command{
+		RolesListCommand,
+		RolesAddCommand,
+		RolesModCommand,
+		RolesRemoveCommand,
+	},
+}
+
+// RoleFlags specifies the add and modify role command flags.
+var RoleFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "perms, p",
+		Usage: RolePermsUsage(),
+	},
+	cli.StringFlag{
+		Name:  "title, t",
+		Usage: "role `TITLE` for display in the interface",
+	},
+	cli.StringFlag{
+		Name:  "description, d",
+		Usage: "role `DESCRIPTION`",
+	},
+}
+
+// RolesListCommand configures the command name, flags, and action.
+var RolesListCommand = cli.Command{
+	Name:   "ls",
+	Usage:  "Displays custom user roles",
+	Flags:  report.CliFlags,
+	Action: rolesListAction,
+}
+
+// RolesAddCommand configures the command name, flags, and action.
+var RolesAddCommand = cli.Command{
+	Name:      "add",
+	Usage:     "Adds a custom user role",
+	ArgsUsage: "[name]",
+	Flags:     RoleFlags,
+	Action:    rolesAddAction,
+}
+
+// RolesModCommand configures the command name, flags, and action.
+var RolesModCommand = cli.Command{
+	Name:      "mod",
+	Usage:     "Modifies the permissions of a custom user role",
+	ArgsUsage: "[name]",
+	Flags:     RoleFlags,
+	Action:    rolesModAction,
+}
+
+// RolesRemoveCommand configures the command name, flags, and action.
+var RolesRemoveCommand = cli.Command{
+	Name:      "rm",
+	Usage:     "Removes a custom user role",
+	ArgsUsage: "[name]",
+	Action:    rolesRemoveAction,
+}
+
+// rolesListAction displays custom user roles.
+func rolesListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		cols := []string{"Name", "Title", "Permissions", "Users", "Description"}
+
+		roles := entity.FindRoles()
+		rows := make([][]string, len(roles))
+
+		log.Infof("found %s", english.Plural(len(roles), "role", "roles"))
+
+		for i, role := range roles {
+			rows[i] = []string{
+				role.RoleName,
+				role.RoleTitle,
+				role.RolePerms,
+				fmt.Sprintf("%d", role.Users()),
+				role.Description,
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// rolesAddAction adds a custom user role.
+func rolesAddAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		name := clean.Role(ctx.Args().First())
+
+		if name == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := entity.NewRole(name, ctx.String("perms"))
+
+		if err != nil {
+			return err
+		}
+
+		if title := clean.Name(ctx.String("title")); title != "" {
+			m.RoleTitle = title
+		}
+
+		m.Description = clean.Clip(ctx.String("description"), 512)
+
+		if err = m.Create(); err != nil {
+			return err
+		}
+
+		log.Infof("role %s has been created with permissions %s", clean.LogQuote(m.RoleName), clean.LogQuote(m.RolePerms))
+
+		return nil
+	})
+}
+
+// rolesModAction modifies a custom user role.
+func rolesModAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		name := clean.Role(ctx.Args().First())
+
+		if name == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.FindRole(name)
+
+		if m == nil {
+			return fmt.Errorf("role %s not found", clean.LogQuote(name))
+		}
+
+		if ctx.IsSet("perms") {
+			if err := m.SetPerms(ctx.String("perms")); err != nil {
+				return err
+			}
+		}
+
+		if ctx.IsSet("title") {
+			m.RoleTitle = clean.Name(ctx.String("title"))
+		}
+
+		if ctx.IsSet("description") {
+			m.Description = clean.Clip(ctx.String("description"), 512)
+		}
+
+		if err := m.Save(); err != nil {
+			return err
+		}
+
+		log.Infof("role %s has been updated", clean.LogQuote(m.RoleName))
+
+		return nil
+	})
+}
+
+// rolesRemoveAction deletes a custom user role.
+func rolesRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		name := clean.Role(ctx.Args().First())
+
+		if name == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.FindRole(name)
+
+		if m == nil {
+			return fmt.Errorf("role %s not found", clean.LogQuote(name))
+		}
+
+		users := m.Users()
+
+		if err := m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("role %s has been deleted, %s without role", clean.LogQuote(m.RoleName), english.Plural(users, "user", "users"))
+
+		return nil
+	})
+}
@@ -30,8 +30,8 @@ func showConfigOptionsAction(ctx *cli.Context) error {
 
 	rows, cols := config.Flags.Report()
 
-	// CSV Export?
-	if ctx.Bool("csv") || ctx.Bool("tsv") {
+	// CSV or JSON Export?
+	if ctx.Bool("csv") || ctx.Bool("tsv") || JsonOutput(ctx) {
 		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
 
 		fmt.Println(result)
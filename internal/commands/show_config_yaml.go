@@ -25,8 +25,8 @@ func showConfigYamlAction(ctx *cli.Context) error {
 
 	rows, cols := conf.Options().Report()
 
-	// CSV Export?
-	if ctx.Bool("csv") || ctx.Bool("tsv") {
+	// CSV or JSON Export?
+	if ctx.Bool("csv") || ctx.Bool("tsv") || JsonOutput(ctx) {
 		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
 
 		fmt.Println(result)
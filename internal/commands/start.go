@@ -13,6 +13,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/auto"
+	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/server"
@@ -80,8 +81,10 @@ func startAction(ctx *cli.Context) error {
 	// Pass this context down the chain.
 	cctx, cancel := context.WithCancel(context.Background())
 
-	// Initialize the index database.
+	// Initialize the index database, and show a status page while the schema is being migrated.
+	stopMigrations := server.StartMigrations(conf)
 	conf.InitDb()
+	stopMigrations()
 
 	// Check if the daemon is running, if not, initialize the daemon.
 	dctx := new(daemon.Context)
@@ -120,6 +123,9 @@ func startAction(ctx *cli.Context) error {
 	// Start web server.
 	go server.Start(cctx, conf)
 
+	// Load models in the background, so that the server does not have to wait for them.
+	get.WarmupModels()
+
 	if count, err := photoprism.RestoreAlbums(conf.AlbumsPath(), false); err != nil {
 		log.Errorf("restore: %s", err)
 	} else if count > 0 {
@@ -32,7 +32,18 @@ func statusAction(ctx *cli.Context) error {
 	// interrupt reading of the Response.Body.
 	client := &http.Client{Timeout: 10 * time.Second}
 
-	url := fmt.Sprintf("http://%s:%d/api/v1/status", conf.HttpHost(), conf.HttpPort())
+	server := fmt.Sprintf("%s:%d", conf.HttpHost(), conf.HttpPort())
+	url := fmt.Sprintf("http://%s/api/v1/status", server)
+
+	// Check the status of a remote instance?
+	if Remote(ctx) {
+		if c, err := NewRemoteClient(ctx); err != nil {
+			return err
+		} else {
+			server = c.Server
+			url = c.Url("status")
+		}
+	}
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 
@@ -43,9 +54,9 @@ func statusAction(ctx *cli.Context) error {
 	var status string
 
 	if resp, err := client.Do(req); err != nil {
-		return fmt.Errorf("cannot connect to %s:%d", conf.HttpHost(), conf.HttpPort())
+		return fmt.Errorf("cannot connect to %s", server)
 	} else if resp.StatusCode != 200 {
-		return fmt.Errorf("server running at %s:%d, bad status %d\n", conf.HttpHost(), conf.HttpPort(), resp.StatusCode)
+		return fmt.Errorf("server running at %s, bad status %d\n", server, resp.StatusCode)
 	} else if body, err := io.ReadAll(resp.Body); err != nil {
 		return err
 	} else {
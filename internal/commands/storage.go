@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+// StorageCommand configures the object storage subcommands.
+var StorageCommand = cli.Command{
+	Name:  "storage",
+	Usage: "Object storage subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "push",
+			Usage:  "Uploads new and changed originals, sidecar files, and thumbnails to the object storage",
+			Action: storagePushAction,
+		},
+		{
+			Name:   "pull",
+			Usage:  "Downloads originals, sidecar files, and thumbnails that are missing locally, e.g. in a new container",
+			Action: storagePullAction,
+		},
+	},
+}
+
+// storagePushAction uploads local files to the object storage.
+func storagePushAction(ctx *cli.Context) error {
+	return storageAction(ctx, "uploaded", (*vfs.Mirror).Push)
+}
+
+// storagePullAction downloads missing files from the object storage.
+func storagePullAction(ctx *cli.Context) error {
+	return storageAction(ctx, "downloaded", (*vfs.Mirror).Pull)
+}
+
+// storageAction transfers files between the local directories and the object storage.
+func storageAction(ctx *cli.Context, action string, transfer func(*vfs.Mirror) (vfs.MirrorResult, error)) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	get.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	mirror := get.Storage()
+
+	if mirror == nil {
+		return fmt.Errorf("object storage is not configured")
+	}
+
+	result, err := transfer(mirror)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("storage: %s %s, skipped %d, %d failed [%s]", action, english.Plural(result.Transferred, "file", "files"), result.Skipped, result.Failed, time.Since(start))
+
+	return nil
+}
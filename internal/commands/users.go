@@ -4,6 +4,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 // Usage hints for the user management subcommands.
@@ -11,10 +12,13 @@ const (
 	UserNameUsage     = "full `NAME` for display in the interface"
 	UserEmailUsage    = "unique `EMAIL` address of the user"
 	UserPasswordUsage = "`PASSWORD` for local authentication"
-	UserRoleUsage     = "user role `NAME` (leave blank for default)"
+	UserRoleUsage     = "user role `NAME`, e.g. admin or a custom role (leave blank for default)"
 	UserAdminUsage    = "make user super admin with full access"
 	UserNoLoginUsage  = "disable login on the web interface"
 	UserWebDAVUsage   = "allow to sync files via WebDAV"
+	UserQuotaUsage    = "storage `QUOTA` for originals in MB (0 for unlimited)"
+	UserNSFWUsage     = "`POLICY` for pictures that MAY be offensive (show, blur, hide)"
+	UserInviteUsage   = "send an invitation email with the login details (generates a random password if none is specified)"
 )
 
 // UsersCommand configures the user management subcommands.
@@ -30,6 +34,8 @@ var UsersCommand = cli.Command{
 		UsersModCommand,
 		UsersRemoveCommand,
 		UsersResetCommand,
+		UsersExportCommand,
+		UsersImportCommand,
 	},
 }
 
@@ -64,4 +70,34 @@ var UserFlags = []cli.Flag{
 		Name:  "webdav, w",
 		Usage: UserWebDAVUsage,
 	},
+	cli.IntFlag{
+		Name:  "quota, q",
+		Usage: UserQuotaUsage,
+	},
+	cli.StringFlag{
+		Name:  "nsfw",
+		Usage: UserNSFWUsage,
+	},
+}
+
+// UserResult represents the result of a user management command in JSON output mode.
+type UserResult struct {
+	UID      string `json:"UID"`
+	Username string `json:"Username"`
+	Role     string `json:"Role"`
+	Action   string `json:"Action"`
+}
+
+// printUserResult prints the user account and the action performed as JSON, if enabled.
+func printUserResult(ctx *cli.Context, m *entity.User, action string) error {
+	if m == nil || !JsonOutput(ctx) {
+		return nil
+	}
+
+	return printJson(UserResult{
+		UID:      m.UID(),
+		Username: m.Username(),
+		Role:     m.AclRole().String(),
+		Action:   action,
+	})
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mail"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
@@ -18,8 +19,11 @@ var UsersAddCommand = cli.Command{
 	Name:      "add",
 	Usage:     "Adds a new user account",
 	ArgsUsage: "[username]",
-	Flags:     UserFlags,
-	Action:    usersAddAction,
+	Flags: append(UserFlags[:len(UserFlags):len(UserFlags)], cli.BoolFlag{
+		Name:  "invite",
+		Usage: UserInviteUsage,
+	}),
+	Action: usersAddAction,
 }
 
 // usersAddAction adds a new user account.
@@ -29,9 +33,18 @@ func usersAddAction(ctx *cli.Context) error {
 
 		frm := form.NewUserFromCli(ctx)
 
+		// Send an invitation email?
+		invite := ctx.Bool("invite")
+
+		if invite && !conf.MailEnabled() {
+			return fmt.Errorf("outgoing mail server and sender address must be configured to send invitations")
+		} else if invite && frm.Password == "" {
+			frm.Password = randomPassword()
+		}
+
 		interactive := true
 
-		if frm.UserName != "" && frm.Password != "" {
+		if frm.UserName != "" && frm.Password != "" || JsonOutput(ctx) {
 			log.Debugf("user will be added in non-interactive mode")
 			interactive = false
 		}
@@ -54,7 +67,7 @@ func usersAddAction(ctx *cli.Context) error {
 		if frm.UserName == "" {
 			return fmt.Errorf("username is required")
 		} else if m := entity.FindUserByName(frm.UserName); m != nil {
-			if !m.Deleted() {
+			if !m.Deleted() || JsonOutput(ctx) {
 				return fmt.Errorf("user already exists")
 			}
 
@@ -73,7 +86,7 @@ func usersAddAction(ctx *cli.Context) error {
 
 			log.Infof("user %s has been restored", m.String())
 
-			return nil
+			return printUserResult(ctx, m, "restored")
 		}
 
 		if interactive && frm.UserEmail == "" {
@@ -90,7 +103,11 @@ func usersAddAction(ctx *cli.Context) error {
 			frm.UserEmail = clean.Email(res)
 		}
 
-		if interactive && len(ctx.String("password")) < entity.PasswordLength {
+		if invite && frm.UserEmail == "" {
+			return fmt.Errorf("email is required to send an invitation")
+		}
+
+		if interactive && len(frm.Password) < entity.PasswordLength {
 			validate := func(input string) error {
 				if len(input) < entity.PasswordLength {
 					return fmt.Errorf("password must have at least %d characters", entity.PasswordLength)
@@ -132,6 +149,16 @@ func usersAddAction(ctx *cli.Context) error {
 			return err
 		}
 
-		return nil
+		m := entity.FindUserByName(frm.UserName)
+
+		if invite {
+			if err := sendUserEmail(conf, mail.TemplateInvite, m, frm.Password); err != nil {
+				return fmt.Errorf("user has been added, but the invitation could not be sent: %s", err)
+			}
+
+			log.Infof("invitation has been sent to %s", clean.Log(frm.UserEmail))
+		}
+
+		return printUserResult(ctx, m, "added")
 	})
 }
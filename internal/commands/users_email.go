@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mail"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// randomPassword returns a random password that meets the minimum password length.
+func randomPassword() string {
+	length := 12
+
+	if entity.PasswordLength > length {
+		length = entity.PasswordLength
+	}
+
+	return rnd.Base62(length)
+}
+
+// sendUserEmail renders the email template with the account details and sends it to the user.
+func sendUserEmail(conf *config.Config, template string, m *entity.User, password string) error {
+	if m == nil {
+		return fmt.Errorf("user not found")
+	} else if m.Email() == "" {
+		return fmt.Errorf("user %s has no email address", clean.LogQuote(m.Username()))
+	}
+
+	data := conf.MailData()
+	data.Name = m.FullName()
+	data.Username = m.Username()
+	data.Password = password
+
+	msg, err := mail.Render(template, data, m.Email())
+
+	if err != nil {
+		return err
+	}
+
+	return conf.Mailer().Send(msg)
+}
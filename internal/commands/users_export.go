@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// UsersExportCommand configures the command name, flags, and action.
+var UsersExportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "Exports user accounts to a CSV or JSON file, excluding passwords",
+	ArgsUsage: "[filename.csv|filename.json]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "csv, c",
+			Usage: "export as comma separated values",
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "replace existing files",
+		},
+	},
+	Action: usersExportAction,
+}
+
+// usersExportAction exports user accounts, e.g. to migrate them to another instance.
+func usersExportAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		fileName := strings.TrimSpace(ctx.Args().First())
+
+		if fileName == "" {
+			fileName = "-"
+		}
+
+		// Use CSV if requested or indicated by the file extension, JSON otherwise.
+		asCsv := ctx.Bool("csv") || strings.EqualFold(filepath.Ext(fileName), ".csv")
+
+		users := query.RegisteredUsers()
+		records := make(UserRecords, 0, len(users))
+
+		for i := range users {
+			if users[i].Deleted() {
+				continue
+			}
+
+			records = append(records, NewUserRecord(&users[i]))
+		}
+
+		out := os.Stdout
+
+		if fileName != "-" {
+			if fs.FileExists(fileName) && !ctx.Bool("force") {
+				return fmt.Errorf("%s already exists", clean.Log(fileName))
+			}
+
+			f, err := os.OpenFile(fileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0o600)
+
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+
+			out = f
+		}
+
+		var err error
+
+		if asCsv {
+			err = records.WriteCSV(out)
+		} else {
+			err = records.WriteJSON(out)
+		}
+
+		if err != nil {
+			return err
+		} else if fileName != "-" {
+			log.Infof("exported %s to %s", english.Plural(len(records), "user", "users"), clean.Log(fileName))
+		}
+
+		return nil
+	})
+}
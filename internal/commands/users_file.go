@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// UserRecordCols specifies the CSV columns of exported and imported user accounts.
+var UserRecordCols = []string{"Username", "DisplayName", "Email", "Role", "AuthProvider", "SuperAdmin", "CanLogin", "WebDAV", "BasePath", "UploadPath", "Quota", "NSFWPolicy", "Password"}
+
+// UserRecord represents a user account in an export or import file.
+type UserRecord struct {
+	Username     string `json:"Username"`
+	DisplayName  string `json:"DisplayName,omitempty"`
+	Email        string `json:"Email,omitempty"`
+	Role         string `json:"Role,omitempty"`
+	AuthProvider string `json:"AuthProvider,omitempty"`
+	SuperAdmin   bool   `json:"SuperAdmin"`
+	CanLogin     bool   `json:"CanLogin"`
+	WebDAV       bool   `json:"WebDAV"`
+	BasePath     string `json:"BasePath,omitempty"`
+	UploadPath   string `json:"UploadPath,omitempty"`
+	Quota        int    `json:"Quota,omitempty"`
+	NSFWPolicy   string `json:"NSFWPolicy,omitempty"`
+	Password     string `json:"Password,omitempty"`
+}
+
+// NewUserRecord creates a user record from an existing account. Passwords are never exported.
+func NewUserRecord(m *entity.User) UserRecord {
+	return UserRecord{
+		Username:     m.Username(),
+		DisplayName:  m.DisplayName,
+		Email:        m.UserEmail,
+		Role:         m.AclRole().String(),
+		AuthProvider: m.AuthProvider,
+		SuperAdmin:   m.SuperAdmin,
+		CanLogin:     m.CanLogin,
+		WebDAV:       m.WebDAV,
+		BasePath:     m.BasePath,
+		UploadPath:   m.UploadPath,
+		Quota:        m.UserQuota,
+		NSFWPolicy:   m.Settings().NSFWPolicy,
+	}
+}
+
+// Form returns a user form with the record values. Like the add command, the admin role is the default.
+func (r UserRecord) Form() form.User {
+	role := clean.Role(r.Role)
+
+	if role == "" {
+		role = acl.RoleAdmin.String()
+	}
+
+	return form.User{
+		UserName:     clean.Username(r.Username),
+		AuthProvider: clean.TypeLower(r.AuthProvider),
+		UserEmail:    clean.Email(r.Email),
+		DisplayName:  clean.Name(r.DisplayName),
+		UserRole:     role,
+		SuperAdmin:   r.SuperAdmin,
+		CanLogin:     r.CanLogin,
+		WebDAV:       r.WebDAV,
+		BasePath:     clean.UserPath(r.BasePath),
+		UploadPath:   clean.UserPath(r.UploadPath),
+		UserQuota:    r.Quota,
+		NSFWPolicy:   clean.TypeLower(r.NSFWPolicy),
+		Password:     clean.Password(r.Password),
+	}
+}
+
+// UnmarshalJSON parses a record from JSON and allows login unless it is explicitly disabled.
+func (r *UserRecord) UnmarshalJSON(data []byte) error {
+	type record UserRecord
+
+	result := record{CanLogin: true}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	*r = UserRecord(result)
+
+	return nil
+}
+
+// Row returns the record values in the order of UserRecordCols.
+func (r UserRecord) Row() []string {
+	return []string{
+		r.Username,
+		r.DisplayName,
+		r.Email,
+		r.Role,
+		r.AuthProvider,
+		strconv.FormatBool(r.SuperAdmin),
+		strconv.FormatBool(r.CanLogin),
+		strconv.FormatBool(r.WebDAV),
+		r.BasePath,
+		r.UploadPath,
+		strconv.Itoa(r.Quota),
+		r.NSFWPolicy,
+		r.Password,
+	}
+}
+
+// UserRecords represents a list of user records.
+type UserRecords []UserRecord
+
+// WriteCSV writes the records as comma separated values with a header row.
+func (records UserRecords) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(UserRecordCols); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if err := writer.Write(r.Row()); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteJSON writes the records as indented JSON array.
+func (records UserRecords) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(records)
+}
+
+// ReadUserRecords reads user records from a CSV or JSON file, "-" reads from stdin.
+func ReadUserRecords(fileName string) (records UserRecords, err error) {
+	var data []byte
+
+	if fileName == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fileName)
+	}
+
+	if err != nil {
+		return records, err
+	}
+
+	// Detect the format based on the content, since stdin has no file extension.
+	if content := bytes.TrimSpace(data); len(content) > 0 && (content[0] == '[' || content[0] == '{') {
+		if content[0] == '{' {
+			content = append(append([]byte("["), content...), ']')
+		}
+
+		if err = json.Unmarshal(content, &records); err != nil {
+			return records, fmt.Errorf("invalid JSON in %s (%s)", clean.Log(fileName), err)
+		}
+
+		return records, nil
+	}
+
+	return parseUserRecordsCSV(data)
+}
+
+// parseUserRecordsCSV parses comma or semicolon separated values with a header row.
+func parseUserRecordsCSV(data []byte) (records UserRecords, err error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	// Support semicolon separated values, e.g. as exported by spreadsheet apps.
+	if i := bytes.IndexByte(data, '\n'); i > 0 && bytes.Count(data[:i], []byte(";")) > bytes.Count(data[:i], []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	rows, err := reader.ReadAll()
+
+	if err != nil {
+		return records, err
+	} else if len(rows) == 0 {
+		return records, nil
+	}
+
+	cols := make(map[string]int, len(rows[0]))
+
+	for i, col := range rows[0] {
+		cols[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	if _, ok := cols["username"]; !ok {
+		return records, fmt.Errorf("missing Username column")
+	}
+
+	value := func(row []string, col string) string {
+		if i, ok := cols[strings.ToLower(col)]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+
+		return ""
+	}
+
+	for _, row := range rows[1:] {
+		r := UserRecord{
+			Username:     value(row, "Username"),
+			DisplayName:  value(row, "DisplayName"),
+			Email:        value(row, "Email"),
+			Role:         value(row, "Role"),
+			AuthProvider: value(row, "AuthProvider"),
+			SuperAdmin:   txt.Bool(value(row, "SuperAdmin")),
+			CanLogin:     true,
+			WebDAV:       txt.Bool(value(row, "WebDAV")),
+			BasePath:     value(row, "BasePath"),
+			UploadPath:   value(row, "UploadPath"),
+			Quota:        txt.Int(value(row, "Quota")),
+			NSFWPolicy:   value(row, "NSFWPolicy"),
+			Password:     value(row, "Password"),
+		}
+
+		// Allow login unless explicitly disabled.
+		if s := value(row, "CanLogin"); s != "" {
+			r.CanLogin = txt.Bool(s)
+		}
+
+		records = append(records, r)
+	}
+
+	return records, nil
+}
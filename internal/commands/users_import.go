@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// UsersImportCommand configures the command name, flags, and action.
+var UsersImportCommand = cli.Command{
+	Name:      "import",
+	Usage:     "Creates or updates user accounts from a CSV or JSON file",
+	ArgsUsage: "[filename]",
+	Flags: append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "update, u",
+			Usage: "update existing accounts instead of skipping them",
+		},
+		cli.BoolFlag{
+			Name:  "dry",
+			Usage: "dry run, don't actually change anything",
+		},
+	}, report.CliFlags...),
+	Action: usersImportAction,
+}
+
+// usersImportAction creates user accounts in bulk, e.g. for a family or classroom.
+func usersImportAction(ctx *cli.Context) error {
+	fileName := strings.TrimSpace(ctx.Args().First())
+
+	if fileName == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	records, err := ReadUserRecords(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		update := ctx.Bool("update")
+		dry := ctx.Bool("dry")
+
+		var added, updated, failed int
+
+		cols := []string{"Username", "Status", "Password", "Details"}
+		rows := make([][]string, 0, len(records))
+
+		for _, r := range records {
+			frm := r.Form()
+			name := frm.Username()
+			status, password, details := "", "", ""
+
+			if name == "" {
+				failed++
+				rows = append(rows, []string{r.Username, "failed", "", "invalid username"})
+				continue
+			}
+
+			m := entity.FindUserByName(name)
+
+			switch {
+			case m != nil && !update:
+				status, details = "skipped", "already exists"
+			case m != nil && m.ID == 1 && !frm.SuperAdmin:
+				failed++
+				status, details = "failed", "the initial admin account must remain super admin"
+			case m != nil:
+				if dry {
+					status = "would be updated"
+				} else if err = importUser(m, frm, frm.Password); err != nil {
+					failed++
+					status, details = "failed", err.Error()
+				} else {
+					updated++
+					status = "updated"
+				}
+			default:
+				// Generate a random password if none was specified.
+				if len(frm.Password) < entity.PasswordLength {
+					if frm.Password != "" {
+						details = fmt.Sprintf("password shorter than %d characters replaced", entity.PasswordLength)
+					}
+
+					frm.Password = rnd.GeneratePasswd()
+					password = frm.Password
+				}
+
+				if dry {
+					status, password = "would be added", ""
+				} else if err = entity.AddUser(frm); err != nil {
+					failed++
+					status, password, details = "failed", "", err.Error()
+				} else if m = entity.FindUserByName(name); m == nil {
+					failed++
+					status, password, details = "failed", "", "not found after adding"
+				} else if err = importUser(m, frm, ""); err != nil {
+					failed++
+					status, details = "failed", err.Error()
+				} else {
+					added++
+					status = "added"
+				}
+			}
+
+			rows = append(rows, []string{name, status, password, details})
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%s\n", result)
+
+		log.Infof("added %s, updated %s, %d failed", english.Plural(added, "user", "users"), english.Plural(updated, "user", "users"), failed)
+
+		if failed > 0 {
+			return fmt.Errorf("failed to import %s", english.Plural(failed, "user", "users"))
+		}
+
+		return nil
+	})
+}
+
+// importUser updates an existing account with the imported values, including quota and NSFW policy,
+// and changes the password if one was specified.
+func importUser(m *entity.User, frm form.User, password string) error {
+	m.DeletedAt = nil
+	m.SetFormValues(frm)
+	m.SetQuota(frm.UserQuota)
+
+	if frm.NSFWPolicy != "" {
+		m.SetNSFWPolicy(frm.NSFWPolicy)
+	}
+
+	if err := m.Validate(); err != nil {
+		return err
+	} else if err = m.Save(); err != nil {
+		return err
+	}
+
+	if len(password) >= entity.PasswordLength {
+		if err := m.SetPassword(password); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("users: imported %s", clean.LogQuote(m.Username()))
+
+	return nil
+}
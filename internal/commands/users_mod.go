@@ -47,7 +47,9 @@ func usersModAction(ctx *cli.Context) error {
 		}
 
 		// Check if account exists but is deleted.
-		if m.Deleted() {
+		if m.Deleted() && JsonOutput(ctx) {
+			return fmt.Errorf("user %s has been deleted", clean.LogQuote(id))
+		} else if m.Deleted() {
 			prompt := promptui.Prompt{
 				Label:     fmt.Sprintf("Restore user %s?", m.String()),
 				IsConfirm: true,
@@ -84,6 +86,6 @@ func usersModAction(ctx *cli.Context) error {
 
 		log.Infof("user %s has been updated", m.String())
 
-		return nil
+		return printUserResult(ctx, m, "updated")
 	})
 }
@@ -53,7 +53,9 @@ func usersRemoveAction(ctx *cli.Context) error {
 			return fmt.Errorf("user %s has already been deleted", clean.LogQuote(id))
 		}
 
-		if !ctx.Bool("force") {
+		if !ctx.Bool("force") && JsonOutput(ctx) {
+			return fmt.Errorf("confirmation required, use --force to delete user %s", clean.LogQuote(id))
+		} else if !ctx.Bool("force") {
 			actionPrompt := promptui.Prompt{
 				Label:     fmt.Sprintf("Delete user %s?", m.String()),
 				IsConfirm: true,
@@ -71,6 +73,6 @@ func usersRemoveAction(ctx *cli.Context) error {
 
 		log.Infof("user %s has been deleted", m.String())
 
-		return nil
+		return printUserResult(ctx, m, "deleted")
 	})
 }
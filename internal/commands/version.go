@@ -19,6 +19,10 @@ var VersionCommand = cli.Command{
 func versionAction(ctx *cli.Context) error {
 	conf := config.NewConfig(ctx)
 
+	if JsonOutput(ctx) {
+		return printJson(map[string]string{"Version": conf.Version()})
+	}
+
 	fmt.Println(conf.Version())
 
 	return nil
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// WorkerCommand configures the command name, flags, and action.
+var WorkerCommand = cli.Command{
+	Name:  "worker",
+	Usage: "Runs as worker node that executes CPU-heavy jobs queued by the main instance",
+	Description: "Worker nodes must be connected to the same database as the main instance, which must be started with --offload-jobs." +
+		" Originals, sidecar, and cache folders must be shared, e.g. using NFS, so that they can be accessed with the same configured paths.",
+	Flags:  workerFlags,
+	Action: workerAction,
+}
+
+var workerFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "jobs, j",
+		Usage: "job `TYPES` to execute, separated by commas",
+		Value: strings.Join(entity.NodeJobTypes, ","),
+	},
+	cli.DurationFlag{
+		Name:  "interval, i",
+		Usage: "`DURATION` to wait before checking the queue again when it is empty",
+		Value: 5 * time.Second,
+	},
+}
+
+// workerAction executes queued jobs until the worker node is stopped.
+func workerAction(ctx *cli.Context) error {
+	jobTypes, err := workerJobTypes(ctx.String("jobs"))
+
+	if err != nil {
+		return err
+	}
+
+	conf, err := InitConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	var index *photoprism.Index
+
+	// Only load the classification models if needed.
+	if list.Contains(jobTypes, entity.NodeJobLabels) {
+		index = get.Index()
+	}
+
+	w := photoprism.NewNodeWorker(conf, index, get.Convert())
+
+	// Remove completed jobs from previous days.
+	if n, err := entity.PurgeNodeJobs(time.Now().Add(-24 * time.Hour)); err != nil {
+		log.Warnf("worker: %s (purge completed jobs)", err)
+	} else if n > 0 {
+		log.Debugf("worker: removed %d completed jobs", n)
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	interval := ctx.Duration("interval")
+
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	log.Infof("worker: node %s is executing %s jobs", clean.Log(conf.NodeName()), strings.Join(jobTypes, ", "))
+
+	for {
+		select {
+		case <-quit:
+			log.Infof("worker: shutting down")
+			return nil
+		default:
+		}
+
+		if found, err := w.Next(jobTypes); err != nil {
+			log.Errorf("worker: %s", err)
+		} else if found {
+			continue
+		}
+
+		select {
+		case <-quit:
+			log.Infof("worker: shutting down")
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// workerJobTypes parses and validates a comma-separated list of job types.
+func workerJobTypes(s string) (result []string, err error) {
+	for _, t := range strings.Split(s, ",") {
+		if t = clean.TypeLower(t); t == "" {
+			continue
+		} else if !list.Contains(entity.NodeJobTypes, t) {
+			return nil, fmt.Errorf("unknown job type %s, supported types are %s", clean.Log(t), strings.Join(entity.NodeJobTypes, ", "))
+		} else if !list.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no job types specified")
+	}
+
+	return result, nil
+}
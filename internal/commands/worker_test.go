@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerJobTypes(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result, err := workerJobTypes("thumbs,transcode,labels")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"thumbs", "transcode", "labels"}, result)
+	})
+	t.Run("Duplicates", func(t *testing.T) {
+		result, err := workerJobTypes(" Thumbs, thumbs ,")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"thumbs"}, result)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := workerJobTypes("thumbs,faces")
+
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := workerJobTypes("")
+
+		assert.Error(t, err)
+	})
+}
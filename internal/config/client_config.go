@@ -79,6 +79,7 @@ type ClientConfig struct {
 	Membership       string              `json:"membership"`
 	Customer         string              `json:"customer"`
 	MapKey           string              `json:"mapKey"`
+	MapTiles         bool                `json:"mapTiles"`
 	DownloadToken    string              `json:"downloadToken,omitempty"`
 	PreviewToken     string              `json:"previewToken,omitempty"`
 	Disable          ClientDisable       `json:"disable"`
@@ -111,6 +112,7 @@ type Years []int
 // ClientDisable represents disabled client features a user cannot turn back on.
 type ClientDisable struct {
 	WebDAV         bool `json:"webdav"`
+	Passkeys       bool `json:"passkeys"`
 	Settings       bool `json:"settings"`
 	Places         bool `json:"places"`
 	Backups        bool `json:"backups"`
@@ -230,6 +232,7 @@ func (c *Config) ClientPublic() ClientConfig {
 		ACL:      acl.Resources.Grants(acl.RoleUnknown),
 		Disable: ClientDisable{
 			WebDAV:         true,
+			Passkeys:       c.DisablePasskeys(),
 			Settings:       c.DisableSettings(),
 			Places:         c.DisablePlaces(),
 			Backups:        true,
@@ -319,6 +322,7 @@ func (c *Config) ClientShare() ClientConfig {
 		ACL:      acl.Resources.Grants(acl.RoleVisitor),
 		Disable: ClientDisable{
 			WebDAV:         c.DisableWebDAV(),
+			Passkeys:       c.DisablePasskeys(),
 			Settings:       c.DisableSettings(),
 			Places:         c.DisablePlaces(),
 			Backups:        true,
@@ -390,6 +394,7 @@ func (c *Config) ClientShare() ClientConfig {
 		Membership:       c.Hub().Membership(),
 		Customer:         c.Hub().Customer(),
 		MapKey:           c.Hub().MapKey(),
+		MapTiles:         c.MapTilesEnabled(),
 		DownloadToken:    c.DownloadToken(),
 		PreviewToken:     c.PreviewToken(),
 		ManifestUri:      c.ClientManifestUri(),
@@ -414,6 +419,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		Settings: s,
 		Disable: ClientDisable{
 			WebDAV:         c.DisableWebDAV(),
+			Passkeys:       c.DisablePasskeys(),
 			Settings:       c.DisableSettings(),
 			Places:         c.DisablePlaces(),
 			Backups:        c.DisableBackups(),
@@ -486,6 +492,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		Membership:       c.Hub().Membership(),
 		Customer:         c.Hub().Customer(),
 		MapKey:           c.Hub().MapKey(),
+		MapTiles:         c.MapTilesEnabled(),
 		DownloadToken:    c.DownloadToken(),
 		PreviewToken:     c.PreviewToken(),
 		ManifestUri:      c.ClientManifestUri(),
@@ -494,8 +501,6 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		Ext:              ClientExt(c, ClientUser),
 	}
 
-	hidePrivate := c.Settings().Features.Private
-
 	c.Db().
 		Table("photos").
 		Select("photo_uid, cell_id, photo_lat, photo_lng, taken_at").
@@ -504,99 +509,14 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		Limit(1).Offset(0).
 		Take(&cfg.Pos)
 
-	c.Db().
-		Table("cameras").
-		Where("camera_slug <> 'zz' AND camera_slug <> ''").
-		Select("COUNT(*) AS cameras").
-		Take(&cfg.Count)
-
-	c.Db().
-		Table("lenses").
-		Where("lens_slug <> 'zz' AND lens_slug <> ''").
-		Select("COUNT(*) AS lenses").
-		Take(&cfg.Count)
-
-	if hidePrivate {
-		c.Db().
-			Table("photos").
-			Select("SUM(photo_type = 'video' AND photo_quality > -1 AND photo_private = 0) AS videos, " +
-				"SUM(photo_type = 'live' AND photo_quality > -1 AND photo_private = 0) AS live, " +
-				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','animated','vector','raw') AND photo_private = 0 AND photo_quality > -1) AS photos, " +
-				"SUM(photo_type IN ('image','live','animated','vector','raw') AND photo_quality < 3 AND photo_quality > -1 AND photo_private = 0) AS review, " +
-				"SUM(photo_favorite = 1 AND photo_private = 0 AND photo_quality > -1) AS favorites, " +
-				"SUM(photo_private = 1 AND photo_quality > -1) AS private").
-			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
-			Where("deleted_at IS NULL").
-			Take(&cfg.Count)
-	} else {
-		c.Db().
-			Table("photos").
-			Select("SUM(photo_type = 'video' AND photo_quality > -1) AS videos, " +
-				"SUM(photo_type = 'live' AND photo_quality > -1) AS live, " +
-				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','animated') AND photo_quality > -1) AS photos, " +
-				"SUM(photo_type IN ('image','raw','live','animated') AND photo_quality < 3 AND photo_quality > -1) AS review, " +
-				"SUM(photo_favorite = 1 AND photo_quality > -1) AS favorites, " +
-				"0 AS private").
-			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
-			Where("deleted_at IS NULL").
-			Take(&cfg.Count)
-	}
-
-	// Calculate total count.
-	cfg.Count.All = cfg.Count.Photos + cfg.Count.Live + cfg.Count.Videos
-
-	// Exclude pictures in review from total count.
-	if c.Settings().Features.Review {
-		cfg.Count.All = cfg.Count.All - cfg.Count.Review
-	}
-
-	c.Db().
-		Table("labels").
-		Select("MAX(photo_count) AS label_max_photos, COUNT(*) AS labels").
-		Where("photo_count > 0").
-		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 OR label_favorite = 1)").
-		Take(&cfg.Count)
-
-	if hidePrivate {
-		c.Db().
-			Table("albums").
-			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders, "+
-				"SUM(album_type = ? AND album_private = 1) AS private_albums, SUM(album_type = ? AND album_private = 1) AS private_moments, SUM(album_type = ? AND album_private = 1) AS private_months, SUM(album_type = ? AND album_private = 1) AS private_states, SUM(album_type = ? AND album_private = 1) AS private_folders",
-				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder, entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
-			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.photo_private = 0 AND photos.deleted_at IS NULL))").
-			Take(&cfg.Count)
-	} else {
-		c.Db().
-			Table("albums").
-			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
-			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
-			Take(&cfg.Count)
-	}
-
-	c.Db().
-		Table("files").
-		Select("COUNT(*) AS files").
-		Where("file_missing = 0 AND file_root = ? AND deleted_at IS NULL", entity.RootOriginals).
-		Take(&cfg.Count)
-
-	c.Db().
-		Table("countries").
-		Select("(COUNT(*) - 1) AS countries").
-		Take(&cfg.Count)
-
-	c.Db().
-		Table("places").
-		Select("SUM(photo_count > 0) AS places").
-		Where("id <> 'zz'").
-		Take(&cfg.Count)
+	// Sidebar counts are cached, as they are expensive to calculate with large libraries.
+	cfg.Count = c.ClientCounts()
 
 	c.Db().
 		Order("country_slug").
 		Find(&cfg.Countries)
 
 	// People are subjects with type person.
-	cfg.Count.People, _ = query.PeopleCount()
 	cfg.People, _ = query.People()
 
 	c.Db().
@@ -652,6 +572,11 @@ func (c *Config) ClientRole(role acl.Role) ClientConfig {
 func (c *Config) ClientSession(sess *entity.Session) (cfg ClientConfig) {
 	if sess.User().IsVisitor() {
 		cfg = c.ClientShare()
+
+		// Hide download options if the share links used to create the session have downloads disabled.
+		if sess.NoDownload() {
+			cfg.Settings.Features.Download = false
+		}
 	} else if sess.User().IsRegistered() {
 		cfg = c.ClientUser(false).ApplyACL(acl.Resources, sess.User().AclRole())
 		cfg.Settings = c.SessionSettings(sess)
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// ClientCountsTTL specifies how long cached counts are used before they are recalculated,
+// so that changes without a related event are eventually reflected.
+var ClientCountsTTL = 15 * time.Minute
+
+// ClientCountsTopics are the event topics that invalidate cached counts.
+var ClientCountsTopics = []string{
+	"count.*",
+	"photos.*",
+	"albums.*",
+	"labels.*",
+	"subjects.*",
+	"people.*",
+	"index.completed",
+	"import.completed",
+	"upload.completed",
+}
+
+// clientCountsKey represents the settings that affect the counts.
+type clientCountsKey struct {
+	private bool
+	review  bool
+}
+
+// clientCountsEntry represents cached counts and the time at which they were calculated.
+type clientCountsEntry struct {
+	counts  ClientCounts
+	created time.Time
+}
+
+var clientCounts = make(map[clientCountsKey]clientCountsEntry)
+var clientCountsMutex = sync.Mutex{}
+var clientCountsOnce = sync.Once{}
+
+// FlushClientCounts removes all cached counts, so that they are recalculated when needed.
+func FlushClientCounts() {
+	clientCountsMutex.Lock()
+	defer clientCountsMutex.Unlock()
+
+	clientCounts = make(map[clientCountsKey]clientCountsEntry)
+}
+
+// watchClientCounts flushes the cached counts when related entities change.
+func watchClientCounts() {
+	s := event.Subscribe(ClientCountsTopics...)
+
+	for range s.Receiver {
+		FlushClientCounts()
+	}
+}
+
+// ClientCounts returns the photo, video, and album counts for the client UI, using cached values if possible.
+func (c *Config) ClientCounts() ClientCounts {
+	clientCountsOnce.Do(func() {
+		go watchClientCounts()
+	})
+
+	key := clientCountsKey{
+		private: c.Settings().Features.Private,
+		review:  c.Settings().Features.Review,
+	}
+
+	clientCountsMutex.Lock()
+	cached, ok := clientCounts[key]
+	clientCountsMutex.Unlock()
+
+	if ok && time.Since(cached.created) < ClientCountsTTL {
+		return cached.counts
+	}
+
+	counts := c.countClient(key)
+
+	clientCountsMutex.Lock()
+	clientCounts[key] = clientCountsEntry{counts: counts, created: time.Now()}
+	clientCountsMutex.Unlock()
+
+	return counts
+}
+
+// countClient calculates the counts for the client UI.
+func (c *Config) countClient(key clientCountsKey) (counts ClientCounts) {
+	c.Db().
+		Table("cameras").
+		Where("camera_slug <> 'zz' AND camera_slug <> ''").
+		Select("COUNT(*) AS cameras").
+		Take(&counts)
+
+	c.Db().
+		Table("lenses").
+		Where("lens_slug <> 'zz' AND lens_slug <> ''").
+		Select("COUNT(*) AS lenses").
+		Take(&counts)
+
+	if key.private {
+		c.Db().
+			Table("photos").
+			Select("SUM(photo_type = 'video' AND photo_quality > -1 AND photo_private = 0) AS videos, " +
+				"SUM(photo_type = 'live' AND photo_quality > -1 AND photo_private = 0) AS live, " +
+				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','animated','vector','raw') AND photo_private = 0 AND photo_quality > -1) AS photos, " +
+				"SUM(photo_type IN ('image','live','animated','vector','raw') AND photo_quality < 3 AND photo_quality > -1 AND photo_private = 0) AS review, " +
+				"SUM(photo_favorite = 1 AND photo_private = 0 AND photo_quality > -1) AS favorites, " +
+				"SUM(photo_private = 1 AND photo_quality > -1) AS private").
+			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+			Where("deleted_at IS NULL").
+			Take(&counts)
+	} else {
+		c.Db().
+			Table("photos").
+			Select("SUM(photo_type = 'video' AND photo_quality > -1) AS videos, " +
+				"SUM(photo_type = 'live' AND photo_quality > -1) AS live, " +
+				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','animated') AND photo_quality > -1) AS photos, " +
+				"SUM(photo_type IN ('image','raw','live','animated') AND photo_quality < 3 AND photo_quality > -1) AS review, " +
+				"SUM(photo_favorite = 1 AND photo_quality > -1) AS favorites, " +
+				"0 AS private").
+			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+			Where("deleted_at IS NULL").
+			Take(&counts)
+	}
+
+	// Calculate total count.
+	counts.All = counts.Photos + counts.Live + counts.Videos
+
+	// Exclude pictures in review from total count.
+	if key.review {
+		counts.All = counts.All - counts.Review
+	}
+
+	c.Db().
+		Table("labels").
+		Select("MAX(photo_count) AS label_max_photos, COUNT(*) AS labels").
+		Where("photo_count > 0").
+		Where("deleted_at IS NULL").
+		Where("(label_priority >= 0 OR label_favorite = 1)").
+		Take(&counts)
+
+	if key.private {
+		c.Db().
+			Table("albums").
+			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders, "+
+				"SUM(album_type = ? AND album_private = 1) AS private_albums, SUM(album_type = ? AND album_private = 1) AS private_moments, SUM(album_type = ? AND album_private = 1) AS private_months, SUM(album_type = ? AND album_private = 1) AS private_states, SUM(album_type = ? AND album_private = 1) AS private_folders",
+				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder, entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.photo_private = 0 AND photos.deleted_at IS NULL))").
+			Take(&counts)
+	} else {
+		c.Db().
+			Table("albums").
+			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
+			Take(&counts)
+	}
+
+	c.Db().
+		Table("files").
+		Select("COUNT(*) AS files").
+		Where("file_missing = 0 AND file_root = ? AND deleted_at IS NULL", entity.RootOriginals).
+		Take(&counts)
+
+	c.Db().
+		Table("countries").
+		Select("(COUNT(*) - 1) AS countries").
+		Take(&counts)
+
+	c.Db().
+		Table("places").
+		Select("SUM(photo_count > 0) AS places").
+		Where("id <> 'zz'").
+		Take(&counts)
+
+	// People are subjects with type person.
+	counts.People, _ = query.PeopleCount()
+
+	return counts
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ClientCounts(t *testing.T) {
+	c := TestConfig()
+
+	FlushClientCounts()
+
+	counts := c.ClientCounts()
+
+	assert.GreaterOrEqual(t, counts.All, 0)
+	assert.Len(t, clientCounts, 1)
+
+	// Cached counts are returned until they are flushed.
+	clientCountsMutex.Lock()
+	for key, entry := range clientCounts {
+		entry.counts.Photos = -1
+		clientCounts[key] = entry
+	}
+	clientCountsMutex.Unlock()
+
+	assert.Equal(t, -1, c.ClientCounts().Photos)
+
+	FlushClientCounts()
+
+	assert.Len(t, clientCounts, 0)
+	assert.Equal(t, counts, c.ClientCounts())
+}
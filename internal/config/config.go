@@ -32,9 +32,14 @@ import (
 	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/remote/smb"
+	"github.com/photoprism/photoprism/internal/semantic"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/vfs"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
+	"github.com/photoprism/photoprism/pkg/redis"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
@@ -51,6 +56,8 @@ type Config struct {
 	options  *Options
 	settings *customize.Settings
 	db       *gorm.DB
+	replica  *gorm.DB
+	redis    *redis.Client
 	hub      *hub.Config
 	token    string
 	serial   string
@@ -180,6 +187,7 @@ func (c *Config) Propagate() {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.MemoryBudget = thumb.Bytes(c.WorkersMemory()) * Megabyte
 	thumb.CacheMaxAge = c.HttpCacheMaxAge()
 	thumb.CachePublic = c.HttpCachePublic()
 
@@ -190,6 +198,17 @@ func (c *Config) Propagate() {
 	// Set minimum password length.
 	entity.PasswordLength = c.PasswordLength()
 
+	// Disable password login for users with passkeys, if configured.
+	entity.PasskeyOnly = c.PasskeyOnly()
+
+	// Set the threshold and default policy for pictures that may be offensive.
+	entity.NSFWThreshold = c.NSFWThreshold()
+	entity.DefaultNSFWPolicy = c.NSFWPolicy()
+
+	// Set privacy zones in which the position of pictures is hidden from shares and downloads.
+	entity.PrivacyZones = c.PrivacyZones()
+	entity.PrivacyFence = c.PrivacyFence()
+
 	// Set path for user assets.
 	entity.UsersPath = c.UsersPath()
 
@@ -199,13 +218,48 @@ func (c *Config) Propagate() {
 	entity.CheckTokens = !c.Public()
 
 	// Set face recognition parameters.
+	face.SizeThreshold = c.FaceSize()
 	face.ScoreThreshold = c.FaceScore()
 	face.OverlapThreshold = c.FaceOverlap()
+	face.OverlapThresholdFloor = face.OverlapThreshold - 1
 	face.ClusterScoreThreshold = c.FaceClusterScore()
 	face.ClusterSizeThreshold = c.FaceClusterSize()
 	face.ClusterCore = c.FaceClusterCore()
 	face.ClusterDist = c.FaceClusterDist()
 	face.MatchDist = c.FaceMatchDist()
+	face.AgeBucket = c.FaceAgeBucket()
+	face.AgeTolerance = c.FaceAgeTolerance()
+
+	// Set Samba client command for syncing with network shares.
+	smb.Bin = c.SmbClientBin()
+
+	// Protect existing originals from modification and deletion, if enabled.
+	if c.OriginalsWriteOnce() {
+		var dirs []string
+
+		for _, r := range c.OriginalsRoots() {
+			dirs = append(dirs, r.Path)
+		}
+
+		vfs.WriteOnce(dirs, c.OriginalsWriteOnceExcept())
+	} else {
+		vfs.WriteOnce(nil, nil)
+	}
+
+	// Set semantic search parameters.
+	semantic.ServiceUrl = c.SemanticUrl()
+	semantic.Model = c.SemanticModel()
+
+	if m, ok := face.FindModel(c.FaceModel()); ok {
+		face.ActiveModel = m
+	}
+
+	// Load the cascade for detecting animal faces if installed.
+	if c.DisablePets() {
+		// Do nothing.
+	} else if err := face.LoadPetCascade(c.PetCascadePath()); err != nil {
+		log.Warnf("config: %s (load pet cascade)", err)
+	}
 
 	// Set default theme and locale.
 	customize.DefaultTheme = c.DefaultTheme()
@@ -223,6 +277,8 @@ func (c *Config) Init() error {
 		return err
 	}
 
+	c.initOriginalsRoots()
+
 	if err := c.initSerial(); err != nil {
 		return err
 	}
@@ -274,11 +330,14 @@ func (c *Config) Init() error {
 
 	c.initSettings()
 	c.initHub()
+	c.initVocabulary()
 
 	c.Propagate()
 
 	if err := c.connectDb(); err != nil {
 		return err
+	} else if err = c.connectRedis(); err != nil {
+		return err
 	} else if !c.Sponsor() {
 		log.Info(MsgSponsor)
 		log.Info(MsgSignUp)
@@ -656,6 +715,8 @@ func (c *Config) SetLogLevel(level logrus.Level) {
 func (c *Config) Shutdown() {
 	mutex.CancelAll()
 
+	c.closeRedis()
+
 	if err := c.CloseDb(); err != nil {
 		log.Errorf("could not close database connection: %s", err)
 	} else {
@@ -748,6 +809,19 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// AlbumTemplates returns the names of the templates from which recurring albums are created automatically.
+func (c *Config) AlbumTemplates() (result []string) {
+	result = []string{}
+
+	for _, name := range strings.Split(c.options.AlbumTemplates, ",") {
+		if name = clean.TypeLower(name); name != "" && !list.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
 // GeoApi returns the preferred geocoding api (places, or none).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
@@ -775,6 +849,21 @@ func (c *Config) OriginalsByteLimit() int64 {
 	}
 }
 
+// OriginalsLayout returns the storage layout of originals, either "default" or "hash".
+func (c *Config) OriginalsLayout() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.OriginalsLayout)) {
+	case "hash", "content", "dedup":
+		return "hash"
+	default:
+		return "default"
+	}
+}
+
+// OriginalsHashLayout checks if originals are stored by content hash, with hard links for the file names in the library.
+func (c *Config) OriginalsHashLayout() bool {
+	return c.OriginalsLayout() == "hash" && !c.ReadOnly() && !c.OriginalsWriteOnce()
+}
+
 // ResolutionLimit returns the maximum resolution of originals in megapixels (width x height).
 func (c *Config) ResolutionLimit() int {
 	result := c.options.ResolutionLimit
@@ -2,6 +2,7 @@ package config
 
 import (
 	"regexp"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -116,6 +117,15 @@ func (c *Config) LoginUri() string {
 	return c.options.LoginUri
 }
 
+// LoginUrl returns the absolute login page URL, e.g. for including it in emails.
+func (c *Config) LoginUrl() string {
+	if strings.HasPrefix(c.options.LoginUri, "http://") || strings.HasPrefix(c.options.LoginUri, "https://") {
+		return c.options.LoginUri
+	}
+
+	return c.SiteUrl() + "library/login"
+}
+
 // RegisterUri returns the user registration URI.
 func (c *Config) RegisterUri() string {
 	if c.Public() {
@@ -51,6 +51,14 @@ func TestLoginUri(t *testing.T) {
 	assert.Equal(t, "/library/login", c.LoginUri())
 }
 
+func TestLoginUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.SiteUrl()+"library/login", c.LoginUrl())
+	c.options.LoginUri = "https://auth.example.com/login"
+	assert.Equal(t, "https://auth.example.com/login", c.LoginUrl())
+	c.options.LoginUri = ""
+}
+
 func TestRegisterUri(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, "", c.RegisterUri())
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+// BackupBucket returns the object storage bucket name for uploading backups, if any.
+func (c *Config) BackupBucket() string {
+	return strings.TrimSpace(c.options.BackupBucket)
+}
+
+// BackupS3Options returns the connection settings of the backup bucket, which uses
+// the same endpoint and credentials as the object storage.
+func (c *Config) BackupS3Options() vfs.S3Options {
+	opt := c.S3Options()
+
+	opt.Bucket = c.BackupBucket()
+	opt.Prefix = ""
+
+	return opt
+}
+
+// BackupWebDAV returns the WebDAV folder URL for uploading backups, if any.
+func (c *Config) BackupWebDAV() string {
+	return strings.TrimSpace(c.options.BackupWebDAV)
+}
+
+// BackupWebDAVRedacted returns the WebDAV folder URL with the password replaced by "xxxxx".
+func (c *Config) BackupWebDAVRedacted() string {
+	s := c.BackupWebDAV()
+
+	if u, err := url.Parse(s); err == nil {
+		return u.Redacted()
+	}
+
+	return s
+}
+
+// BackupPassphrase returns the passphrase for encrypting uploaded backups, if any.
+func (c *Config) BackupPassphrase() string {
+	return c.options.BackupPassphrase
+}
+
+// BackupEncrypt checks if backups should be encrypted before they are uploaded.
+func (c *Config) BackupEncrypt() bool {
+	return c.BackupPassphrase() != ""
+}
+
+// BackupRetain returns the number of index backups and YAML archives to keep, or 0 to keep all.
+func (c *Config) BackupRetain() int {
+	if c.options.BackupRetain < 0 {
+		return 0
+	}
+
+	return c.options.BackupRetain
+}
+
+// BackupRemote checks if backups should be uploaded to an object storage bucket or WebDAV server.
+func (c *Config) BackupRemote() bool {
+	return c.BackupBucket() != "" || c.BackupWebDAV() != ""
+}
+
+// BackupOriginals checks if originals should be uploaded incrementally as part of scheduled backups.
+func (c *Config) BackupOriginals() bool {
+	return c.options.BackupOriginals && c.BackupRemote()
+}
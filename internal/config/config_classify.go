@@ -0,0 +1,106 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ClassifyBackend returns the name of the image classification backend.
+func (c *Config) ClassifyBackend() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.ClassifyBackend)) {
+	case classify.BackendRemote:
+		if c.ClassifyUrl() != "" {
+			return classify.BackendRemote
+		}
+
+		return classify.BackendTensorFlow
+	default:
+		return classify.BackendTensorFlow
+	}
+}
+
+// ClassifyUrl returns the remote image classification service URL, if any.
+func (c *Config) ClassifyUrl() string {
+	return strings.TrimSpace(c.options.ClassifyUrl)
+}
+
+// ClassifyKey returns the remote image classification service access key, if any.
+func (c *Config) ClassifyKey() string {
+	return strings.TrimSpace(c.options.ClassifyKey)
+}
+
+// LandmarksUrl returns the remote landmark recognition service URL, unless classification has been disabled.
+func (c *Config) LandmarksUrl() string {
+	if c.options.DisableClassification {
+		return ""
+	}
+
+	return strings.TrimSpace(c.options.LandmarksUrl)
+}
+
+// CaptionUrl returns the image captioning service URL, unless classification has been disabled.
+func (c *Config) CaptionUrl() string {
+	if c.options.DisableClassification {
+		return ""
+	}
+
+	return strings.TrimSpace(c.options.CaptionUrl)
+}
+
+// LabelConfidence returns the minimum confidence of automatically assigned labels that do not need to be reviewed.
+func (c *Config) LabelConfidence() float32 {
+	if c.options.LabelConfidence <= 0 || c.options.LabelConfidence > 1 {
+		return DefaultLabelConfidence
+	}
+
+	return float32(c.options.LabelConfidence)
+}
+
+// LabelRulesYaml returns the custom label rules and keyword mappings YAML filename, if any.
+func (c *Config) LabelRulesYaml() string {
+	if c.options.LabelRules != "" {
+		return fs.Abs(c.options.LabelRules)
+	} else if fileName := filepath.Join(c.ConfigPath(), "labels.yml"); fs.FileExists(fileName) {
+		return fileName
+	}
+
+	return ""
+}
+
+// initVocabulary loads the custom label rules and keyword mappings, if any.
+func (c *Config) initVocabulary() {
+	fileName := c.LabelRulesYaml()
+
+	if fileName == "" {
+		return
+	}
+
+	if v, err := classify.LoadVocabulary(fileName); err != nil {
+		log.Errorf("config: %s (load label rules)", err)
+	} else {
+		classify.SetVocabulary(v)
+		log.Infof("config: loaded %d label rules and %d keyword mappings from %s", len(v.Labels), len(v.Keywords), clean.Log(filepath.Base(fileName)))
+	}
+}
+
+// SemanticUrl returns the embedding model service URL for semantic search, unless classification has been disabled.
+func (c *Config) SemanticUrl() string {
+	if c.options.DisableClassification {
+		return ""
+	}
+
+	return strings.TrimSpace(c.options.SemanticUrl)
+}
+
+// SemanticModel returns the name under which image embeddings for semantic search are stored.
+func (c *Config) SemanticModel() string {
+	if m := clean.TypeLower(c.options.SemanticModel); m != "" {
+		return m
+	}
+
+	return "clip"
+}
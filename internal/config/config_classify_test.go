@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+func TestConfig_ClassifyBackend(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, classify.BackendTensorFlow, c.ClassifyBackend())
+
+	c.options.ClassifyBackend = "Remote"
+	assert.Equal(t, classify.BackendTensorFlow, c.ClassifyBackend())
+
+	c.options.ClassifyUrl = "http://localhost:8080/classify"
+	assert.Equal(t, classify.BackendRemote, c.ClassifyBackend())
+	assert.Equal(t, "http://localhost:8080/classify", c.ClassifyUrl())
+
+	c.options.ClassifyBackend = "foo"
+	assert.Equal(t, classify.BackendTensorFlow, c.ClassifyBackend())
+
+	c.options.ClassifyBackend = ""
+	c.options.ClassifyUrl = ""
+}
+
+func TestConfig_ClassifyKey(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ClassifyKey())
+	c.options.ClassifyKey = " secret "
+	assert.Equal(t, "secret", c.ClassifyKey())
+	c.options.ClassifyKey = ""
+}
+
+func TestConfig_LandmarksUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.LandmarksUrl())
+	c.options.LandmarksUrl = " http://localhost:8080/landmarks "
+	assert.Equal(t, "http://localhost:8080/landmarks", c.LandmarksUrl())
+	c.options.DisableClassification = true
+	assert.Equal(t, "", c.LandmarksUrl())
+	c.options.DisableClassification = false
+	c.options.LandmarksUrl = ""
+}
+
+func TestConfig_CaptionUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.CaptionUrl())
+	c.options.CaptionUrl = "http://localhost:5000/caption"
+	assert.Equal(t, "http://localhost:5000/caption", c.CaptionUrl())
+	c.options.DisableClassification = true
+	assert.Equal(t, "", c.CaptionUrl())
+	c.options.DisableClassification = false
+	c.options.CaptionUrl = ""
+}
+
+func TestConfig_LabelConfidence(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultLabelConfidence, c.LabelConfidence())
+	c.options.LabelConfidence = 0.8
+	assert.Equal(t, float32(0.8), c.LabelConfidence())
+	c.options.LabelConfidence = 2
+	assert.Equal(t, DefaultLabelConfidence, c.LabelConfidence())
+	c.options.LabelConfidence = 0
+}
+
+func TestConfig_LabelRulesYaml(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.LabelRulesYaml())
+	c.options.LabelRules = "/etc/photoprism/labels.yml"
+	assert.Equal(t, "/etc/photoprism/labels.yml", c.LabelRulesYaml())
+	c.options.LabelRules = ""
+}
+
+func TestConfig_SemanticUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.SemanticUrl())
+	c.options.SemanticUrl = "http://clip:5000/embed"
+	assert.Equal(t, "http://clip:5000/embed", c.SemanticUrl())
+	c.options.DisableClassification = true
+	assert.Equal(t, "", c.SemanticUrl())
+	c.options.DisableClassification = false
+	c.options.SemanticUrl = ""
+}
+
+func TestConfig_SemanticModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "clip", c.SemanticModel())
+	c.options.SemanticModel = "OpenCLIP "
+	assert.Equal(t, "openclip", c.SemanticModel())
+	c.options.SemanticModel = ""
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ColdPath returns the cold storage path for archived originals, or an empty string if not configured.
+func (c *Config) ColdPath() string {
+	if c.options.ColdPath == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.ColdPath)
+}
+
+// ColdBucket returns the object storage bucket name for archived originals.
+func (c *Config) ColdBucket() string {
+	return strings.TrimSpace(c.options.ColdBucket)
+}
+
+// ColdStorageClass returns the S3 storage class of archived originals.
+func (c *Config) ColdStorageClass() string {
+	if c.options.ColdStorageClass == "" {
+		return "GLACIER"
+	}
+
+	return strings.ToUpper(strings.TrimSpace(c.options.ColdStorageClass))
+}
+
+// ColdRestoreDays returns the number of days for which restored copies of archived originals are kept in S3.
+func (c *Config) ColdRestoreDays() int {
+	if c.options.ColdRestoreDays <= 0 {
+		return vfs.S3RestoreDays
+	}
+
+	return c.options.ColdRestoreDays
+}
+
+// ColdEnabled checks if old originals can be archived in cold storage.
+func (c *Config) ColdEnabled() bool {
+	return c.ColdBucket() != "" || c.ColdPath() != ""
+}
+
+// ColdS3Options returns the connection settings of the cold storage bucket, which uses
+// the same endpoint and credentials as the object storage.
+func (c *Config) ColdS3Options() vfs.S3Options {
+	opt := c.S3Options()
+
+	opt.Bucket = c.ColdBucket()
+	opt.Prefix = ""
+	opt.StorageClass = c.ColdStorageClass()
+	opt.RestoreDays = c.ColdRestoreDays()
+
+	return opt
+}
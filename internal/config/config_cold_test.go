@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestConfig_Cold(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ColdPath())
+	assert.Equal(t, "", c.ColdBucket())
+	assert.Equal(t, "GLACIER", c.ColdStorageClass())
+	assert.Equal(t, vfs.S3RestoreDays, c.ColdRestoreDays())
+	assert.False(t, c.ColdEnabled())
+
+	c.options.ColdPath = "/mnt/archive"
+	assert.Equal(t, "/mnt/archive", c.ColdPath())
+	assert.True(t, c.ColdEnabled())
+
+	c.options.ColdPath = ""
+	c.options.ColdBucket = "archive"
+	c.options.ColdStorageClass = "deep_archive"
+	c.options.ColdRestoreDays = 3
+	c.options.S3Prefix = "library"
+	c.options.S3AccessKey = "access"
+	c.options.S3SecretKey = "secret"
+
+	assert.True(t, c.ColdEnabled())
+
+	opt := c.ColdS3Options()
+
+	assert.Equal(t, "archive", opt.Bucket)
+	assert.Equal(t, "", opt.Prefix)
+	assert.Equal(t, "DEEP_ARCHIVE", opt.StorageClass)
+	assert.Equal(t, 3, opt.RestoreDays)
+	assert.Equal(t, "access", opt.AccessKey)
+}
@@ -56,5 +56,14 @@ const DefaultSessionMaxAge = UnixWeek * 2
 // DefaultSessionTimeout is the default session timeout time in seconds.
 const DefaultSessionTimeout = UnixWeek
 
+// DefaultOIDCRoleClaim is the default OpenID Connect claim that contains the groups of a user.
+const DefaultOIDCRoleClaim = "groups"
+
 const Essentials = "essentials"
 const Plus = "plus"
+
+// DefaultRateBurst is the default maximum number of API requests a client can send at once.
+const DefaultRateBurst = 100
+
+// DefaultLabelConfidence is the default minimum confidence of labels that do not need to be reviewed.
+const DefaultLabelConfidence float32 = 0.6
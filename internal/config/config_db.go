@@ -22,7 +22,6 @@ import (
 )
 
 // SQL Databases.
-// TODO: PostgresSQL support requires upgrading GORM, so generic column data types can be used.
 const (
 	MySQL    = "mysql"
 	MariaDB  = "mariadb"
@@ -41,6 +40,8 @@ func (c *Config) DatabaseDriver() string {
 	switch strings.ToLower(c.options.DatabaseDriver) {
 	case MySQL, MariaDB:
 		c.options.DatabaseDriver = MySQL
+	case Postgres, "postgresql", "pgsql", "pg":
+		c.options.DatabaseDriver = Postgres
 	case SQLite3, "sqlite", "sqllite", "test", "file", "":
 		c.options.DatabaseDriver = SQLite3
 	case "tidb":
@@ -81,10 +82,10 @@ func (c *Config) DatabaseDsn() string {
 		case Postgres:
 			return fmt.Sprintf(
 				"user=%s password=%s dbname=%s host=%s port=%d sslmode=disable TimeZone=UTC",
-				c.DatabaseUser(),
-				c.DatabasePassword(),
-				c.DatabaseName(),
-				c.DatabaseHost(),
+				QuoteDsnValue(c.DatabaseUser()),
+				QuoteDsnValue(c.DatabasePassword()),
+				QuoteDsnValue(c.DatabaseName()),
+				QuoteDsnValue(c.DatabaseHost()),
 				c.DatabasePort(),
 			)
 		case SQLite3:
@@ -146,7 +147,11 @@ func (c *Config) DatabaseHost() string {
 
 // DatabasePort the database server port.
 func (c *Config) DatabasePort() int {
-	const defaultPort = 3306
+	defaultPort := 3306
+
+	if c.DatabaseDriver() == Postgres {
+		defaultPort = 5432
+	}
 
 	if server := c.DatabaseServer(); server == "" {
 		return 0
@@ -209,6 +214,26 @@ func (c *Config) DatabasePassword() string {
 	return c.options.DatabasePassword
 }
 
+// DatabaseReplica returns the optional data source name (DSN) of a read-only database replica.
+func (c *Config) DatabaseReplica() string {
+	if c.DatabaseDriver() == SQLite3 {
+		return ""
+	}
+
+	return strings.TrimSpace(c.options.DatabaseReplica)
+}
+
+// DatabaseReplicaServer returns the server of the read-only database replica, if any.
+func (c *Config) DatabaseReplicaServer() string {
+	if dsn := c.DatabaseReplica(); dsn == "" {
+		return ""
+	} else if server := NewDSN(dsn).Server; server != "" {
+		return server
+	}
+
+	return "localhost"
+}
+
 // DatabaseConns returns the maximum number of open connections to the database.
 func (c *Config) DatabaseConns() int {
 	limit := c.options.DatabaseConns
@@ -248,8 +273,30 @@ func (c *Config) Db() *gorm.DB {
 	return c.db
 }
 
+// ReadDb returns the read-only replica connection for search queries, or the primary connection if there is none.
+func (c *Config) ReadDb() *gorm.DB {
+	if c.replica != nil {
+		return c.replica
+	}
+
+	return c.Db()
+}
+
+// DbConnected checks if a database connection has been established.
+func (c *Config) DbConnected() bool {
+	return c.db != nil
+}
+
 // CloseDb closes the db connection (if any).
 func (c *Config) CloseDb() error {
+	if c.replica != nil {
+		if err := c.replica.Close(); err == nil {
+			c.replica = nil
+		} else {
+			return err
+		}
+	}
+
 	if c.db != nil {
 		if err := c.db.Close(); err == nil {
 			c.db = nil
@@ -267,7 +314,7 @@ func (c *Config) SetDbOptions() {
 	case MySQL, MariaDB:
 		c.Db().Set("gorm:table_options", "ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci")
 	case Postgres:
-		// Ignore for now.
+		// Not required as the database encoding is set when it is created.
 	case SQLite3:
 		// Not required as unicode is default.
 	}
@@ -304,6 +351,7 @@ func (c *Config) MigrateDb(runFailed bool, ids []string) {
 	}
 
 	go entity.Error{}.LogEvents()
+	go entity.AuditEvent{}.LogEvents()
 }
 
 // InitTestDb drops all tables in the currently configured database and re-creates them.
@@ -317,6 +365,7 @@ func (c *Config) InitTestDb() {
 	}
 
 	go entity.Error{}.LogEvents()
+	go entity.AuditEvent{}.LogEvents()
 }
 
 // connectDb checks the database server version.
@@ -336,6 +385,18 @@ func (c *Config) checkDb(db *gorm.DB) error {
 		} else if sub := txt.UInt(v[1]); sub < 5 || sub == 5 && txt.UInt(v[2]) < 12 {
 			return fmt.Errorf("config: MariaDB %s is not supported, see https://docs.photoprism.app/getting-started/#databases", res.Value)
 		}
+	case Postgres:
+		type Res struct {
+			ServerVersion string `gorm:"column:server_version;"`
+		}
+		var res Res
+		if err := db.Raw("SHOW server_version").Scan(&res).Error; err != nil {
+			return nil
+		} else if v := strings.Split(res.ServerVersion, "."); len(v) < 1 || v[0] == "" {
+			log.Warnf("config: unknown database server version")
+		} else if major := txt.UInt(v[0]); major < 12 {
+			return fmt.Errorf("config: PostgreSQL %s is not supported, see https://docs.photoprism.app/getting-started/#databases", res.ServerVersion)
+		}
 	}
 
 	return nil
@@ -360,10 +421,10 @@ func (c *Config) connectDb() error {
 	}
 
 	// Open database connection.
-	db, err := gorm.Open(dbDriver, dbDsn)
+	db, err := entity.OpenDb(dbDriver, dbDsn)
 	if err != nil || db == nil {
 		for i := 1; i <= 12; i++ {
-			db, err = gorm.Open(dbDriver, dbDsn)
+			db, err = entity.OpenDb(dbDriver, dbDsn)
 
 			if db != nil && err == nil {
 				break
@@ -398,6 +459,23 @@ func (c *Config) connectDb() error {
 	// Ok.
 	c.db = db
 
+	// Connect to read-only replica, if configured.
+	if replicaDsn := c.DatabaseReplica(); replicaDsn == "" {
+		// Not configured.
+	} else if replica, replicaErr := entity.OpenDb(dbDriver, replicaDsn); replicaErr != nil || replica == nil {
+		log.Warnf("config: failed to connect to database replica, using primary for search queries (%s)", replicaErr)
+	} else {
+		replica.LogMode(false)
+		replica.SetLogger(log)
+		replica.DB().SetMaxOpenConns(c.DatabaseConns())
+		replica.DB().SetMaxIdleConns(c.DatabaseConnsIdle())
+		replica.DB().SetConnMaxLifetime(time.Hour)
+
+		log.Infof("config: using database replica %s for search queries", clean.Log(c.DatabaseReplicaServer()))
+
+		c.replica = replica
+	}
+
 	return nil
 }
 
@@ -11,6 +11,11 @@ func TestConfig_DatabaseDriver(t *testing.T) {
 
 	driver := c.DatabaseDriver()
 	assert.Equal(t, SQLite3, driver)
+
+	c.options.DatabaseDriver = "PostgreSQL"
+	assert.Equal(t, Postgres, c.DatabaseDriver())
+	c.options.DatabaseDriver = "pg"
+	assert.Equal(t, Postgres, c.DatabaseDriver())
 }
 
 func TestConfig_ParseDatabaseDsn(t *testing.T) {
@@ -63,6 +68,16 @@ func TestConfig_DatabasePort(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
 	assert.Equal(t, 0, c.DatabasePort())
+
+	c.options.DatabaseDriver = MySQL
+	c.options.DatabaseServer = "localhost"
+	assert.Equal(t, 3306, c.DatabasePort())
+
+	c.options.DatabaseDriver = Postgres
+	assert.Equal(t, 5432, c.DatabasePort())
+
+	c.options.DatabaseServer = "localhost:5433"
+	assert.Equal(t, 5433, c.DatabasePort())
 }
 
 func TestConfig_DatabasePortString(t *testing.T) {
@@ -100,13 +115,41 @@ func TestConfig_DatabaseDsn(t *testing.T) {
 	c.options.DatabaseDriver = "tidb"
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
 	c.options.DatabaseDriver = "Postgres"
-	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
+	assert.Equal(t, "user='photoprism' password='' dbname='photoprism' host='localhost' port=5432 sslmode=disable TimeZone=UTC", c.DatabaseDsn())
+	c.options.DatabasePassword = `it's a \secret`
+	assert.Equal(t, `user='photoprism' password='it\'s a \\secret' dbname='photoprism' host='localhost' port=5432 sslmode=disable TimeZone=UTC`, c.DatabaseDsn())
+	assert.Equal(t, `it's a \secret`, NewDSN(c.DatabaseDsn()).Password)
+	c.options.DatabasePassword = ""
 	c.options.DatabaseDriver = "SQLite"
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
 	c.options.DatabaseDriver = ""
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
 }
 
+func TestConfig_DatabaseReplica(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.DatabaseReplica())
+	assert.Equal(t, "", c.DatabaseReplicaServer())
+
+	c.options.DatabaseReplica = "photoprism:secret@tcp(replica:3306)/photoprism?parseTime=true"
+	assert.Equal(t, "", c.DatabaseReplica())
+
+	c.options.DatabaseDriver = MySQL
+	assert.Equal(t, "photoprism:secret@tcp(replica:3306)/photoprism?parseTime=true", c.DatabaseReplica())
+	assert.Equal(t, "replica:3306", c.DatabaseReplicaServer())
+
+	c.options.DatabaseDriver = SQLite3
+	c.options.DatabaseReplica = ""
+}
+
+func TestConfig_ReadDb(t *testing.T) {
+	c := TestConfig()
+
+	assert.Nil(t, c.replica)
+	assert.Equal(t, c.Db(), c.ReadDb())
+}
+
 func TestConfig_DatabaseFile(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// DiskCheckInterval specifies how long the result of a free disk space check is cached.
+var DiskCheckInterval = 30 * time.Second
+
+var diskMutex = sync.Mutex{}
+var diskChecked time.Time
+var diskLow bool
+
+// DiskUsage represents the disk space of a storage path in bytes.
+type DiskUsage struct {
+	Name     string `json:"Name"`
+	Path     string `json:"-"`
+	ReadOnly bool   `json:"ReadOnly"`
+	Free     uint64 `json:"Free"`
+	Used     uint64 `json:"Used"`
+	Total    uint64 `json:"Total"`
+	Low      bool   `json:"Low"`
+	Error    string `json:"Error,omitempty"`
+}
+
+// MinFreeSpace returns the minimum free disk space in MB, or -1 if disabled.
+func (c *Config) MinFreeSpace() int {
+	if c.options.MinFreeSpace <= 0 {
+		return -1
+	}
+
+	return c.options.MinFreeSpace
+}
+
+// MinFreeBytes returns the minimum free disk space in bytes, or 0 if disabled.
+func (c *Config) MinFreeBytes() uint64 {
+	if mb := c.MinFreeSpace(); mb <= 0 {
+		return 0
+	} else {
+		return uint64(mb) * 1024 * 1024
+	}
+}
+
+// DiskUsage returns the disk space of the originals, sidecar, and cache paths.
+func (c *Config) DiskUsage() (result []DiskUsage) {
+	minFree := c.MinFreeBytes()
+
+	for _, r := range c.OriginalsRoots() {
+		name := "originals"
+
+		if !r.Main() {
+			name = filepath.Join(name, r.Name)
+		}
+
+		result = append(result, DiskUsage{Name: name, Path: r.Path, ReadOnly: r.ReadOnly})
+	}
+
+	result = append(result,
+		DiskUsage{Name: "sidecar", Path: c.SidecarPath(), ReadOnly: !c.SidecarWritable()},
+		DiskUsage{Name: "cache", Path: c.CachePath()},
+	)
+
+	for i := range result {
+		if space, err := fs.Space(result[i].Path); err != nil {
+			result[i].Error = err.Error()
+		} else {
+			result[i].Free = space.Free
+			result[i].Used = space.Used()
+			result[i].Total = space.Total
+			result[i].Low = !result[i].ReadOnly && space.Free < minFree
+		}
+	}
+
+	return result
+}
+
+// DiskSpaceLow checks if the free disk space of a writable storage path is below the configured minimum,
+// so that indexing, imports, transcoding, and thumbnail generation should be paused.
+func (c *Config) DiskSpaceLow() bool {
+	if c.MinFreeBytes() == 0 {
+		return false
+	}
+
+	diskMutex.Lock()
+	defer diskMutex.Unlock()
+
+	if time.Since(diskChecked) < DiskCheckInterval {
+		return diskLow
+	}
+
+	var names []string
+
+	for _, d := range c.DiskUsage() {
+		if d.Low {
+			names = append(names, d.Name)
+		}
+	}
+
+	low := len(names) > 0
+
+	if low && !diskLow {
+		log.Warnf("config: less than %s of free disk space left in %s, indexing, imports, transcoding, and thumbnail generation have been paused", humanize.IBytes(c.MinFreeBytes()), clean.Log(strings.Join(names, ", ")))
+	} else if !low && diskLow {
+		log.Infof("config: enough free disk space available, indexing, imports, transcoding, and thumbnail generation resumed")
+	}
+
+	diskChecked = time.Now()
+	diskLow = low
+
+	return diskLow
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_MinFreeSpace(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, -1, c.MinFreeSpace())
+	c.options.MinFreeSpace = 500
+	assert.Equal(t, 500, c.MinFreeSpace())
+	assert.Equal(t, uint64(524288000), c.MinFreeBytes())
+	c.options.MinFreeSpace = -1
+	assert.Equal(t, -1, c.MinFreeSpace())
+	assert.Equal(t, uint64(0), c.MinFreeBytes())
+	c.options.MinFreeSpace = 0
+	assert.Equal(t, -1, c.MinFreeSpace())
+}
+
+func TestConfig_DiskUsage(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	result := c.DiskUsage()
+
+	assert.Len(t, result, 3)
+	assert.Equal(t, "originals", result[0].Name)
+	assert.Equal(t, "sidecar", result[1].Name)
+	assert.Equal(t, "cache", result[2].Name)
+}
+
+func TestConfig_DiskSpaceLow(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	defer func() {
+		diskChecked = time.Time{}
+		diskLow = false
+	}()
+
+	c.options.MinFreeSpace = -1
+	assert.False(t, c.DiskSpaceLow())
+
+	c.options.MinFreeSpace = 1
+	diskChecked = time.Time{}
+	assert.False(t, c.DiskSpaceLow())
+
+	// Requires more free space than any disk has, but the result is cached.
+	c.options.MinFreeSpace = 1000000000
+	assert.False(t, c.DiskSpaceLow())
+	diskChecked = time.Time{}
+	assert.True(t, c.DiskSpaceLow())
+}
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/photoprism/photoprism/internal/face"
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
 
 // FaceSize returns the face size threshold in pixels.
 func (c *Config) FaceSize() int {
@@ -73,3 +77,50 @@ func (c *Config) FaceMatchDist() float64 {
 
 	return c.options.FaceMatchDist
 }
+
+// FaceModel returns the name of the model used for computing face embeddings.
+func (c *Config) FaceModel() string {
+	if m, ok := face.FindModel(c.options.FaceModel); ok {
+		return m.Name
+	}
+
+	return face.FaceNet.Name
+}
+
+// FaceModelPath returns the path of the model used for computing face embeddings.
+func (c *Config) FaceModelPath() string {
+	if m, ok := face.FindModel(c.FaceModel()); ok {
+		return filepath.Join(c.AssetsPath(), m.Path)
+	}
+
+	return c.FaceNetModelPath()
+}
+
+// FaceKeyframes returns the number of still images sampled from videos for face recognition, 0 if disabled.
+func (c *Config) FaceKeyframes() int {
+	if c.DisableFaces() || !c.FFmpegEnabled() || c.options.FaceKeyframes <= 0 {
+		return 0
+	} else if c.options.FaceKeyframes > 50 {
+		return 50
+	}
+
+	return c.options.FaceKeyframes
+}
+
+// FaceAgeBucket returns the number of years covered by age-progression face clusters, 0 if disabled.
+func (c *Config) FaceAgeBucket() int {
+	if c.options.FaceAgeBucket < 0 || c.options.FaceAgeBucket > 100 {
+		return face.AgeBucket
+	}
+
+	return c.options.FaceAgeBucket
+}
+
+// FaceAgeTolerance returns the additional offset distance when matching faces with age-progression clusters.
+func (c *Config) FaceAgeTolerance() float64 {
+	if c.options.FaceAgeTolerance < 0 || c.options.FaceAgeTolerance > 0.3 {
+		return face.AgeTolerance
+	}
+
+	return c.options.FaceAgeTolerance
+}
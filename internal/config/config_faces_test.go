@@ -1,9 +1,12 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
 )
 
 func TestConfig_FaceSize(t *testing.T) {
@@ -77,3 +80,51 @@ func TestConfig_FaceMatchDist(t *testing.T) {
 	c.options.FaceMatchDist = 0.01
 	assert.Equal(t, 0.46, c.FaceMatchDist())
 }
+
+func TestConfig_FaceModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, "facenet", c.FaceModel())
+	assert.True(t, strings.HasSuffix(c.FaceModelPath(), "/facenet"))
+	c.options.FaceModel = "unknown"
+	assert.Equal(t, "facenet", c.FaceModel())
+	c.options.FaceModel = "FaceNet"
+	assert.Equal(t, "facenet", c.FaceModel())
+}
+
+func TestConfig_FaceKeyframes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.FaceKeyframes = 0
+	assert.Equal(t, 0, c.FaceKeyframes())
+
+	if c.DisableFaces() || !c.FFmpegEnabled() {
+		c.options.FaceKeyframes = 5
+		assert.Equal(t, 0, c.FaceKeyframes())
+	} else {
+		c.options.FaceKeyframes = 5
+		assert.Equal(t, 5, c.FaceKeyframes())
+		c.options.FaceKeyframes = 100
+		assert.Equal(t, 50, c.FaceKeyframes())
+	}
+}
+
+func TestConfig_FaceAgeBucket(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.FaceAgeBucket = 0
+	assert.Equal(t, 0, c.FaceAgeBucket())
+	c.options.FaceAgeBucket = 5
+	assert.Equal(t, 5, c.FaceAgeBucket())
+	c.options.FaceAgeBucket = 101
+	assert.Equal(t, face.AgeBucket, c.FaceAgeBucket())
+	c.options.FaceAgeBucket = -1
+	assert.Equal(t, face.AgeBucket, c.FaceAgeBucket())
+}
+
+func TestConfig_FaceAgeTolerance(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.FaceAgeTolerance = 0.1
+	assert.Equal(t, 0.1, c.FaceAgeTolerance())
+	c.options.FaceAgeTolerance = 0.5
+	assert.Equal(t, face.AgeTolerance, c.FaceAgeTolerance())
+	c.options.FaceAgeTolerance = -0.1
+	assert.Equal(t, face.AgeTolerance, c.FaceAgeTolerance())
+}
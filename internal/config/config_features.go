@@ -1,5 +1,10 @@
 package config
 
+import (
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
 var Sponsor = Env(EnvDemo, EnvSponsor, EnvTest)
 
 // DisableSettings checks if users should not be allowed to change settings.
@@ -30,6 +35,15 @@ func (c *Config) DisableWebDAV() bool {
 	return c.options.DisableWebDAV
 }
 
+// DisablePasskeys checks if passwordless login with passkeys should be disabled.
+func (c *Config) DisablePasskeys() bool {
+	if c.Public() || c.Demo() {
+		return true
+	}
+
+	return c.options.DisablePasskeys
+}
+
 // DisablePlaces checks if geocoding and maps should be disabled.
 func (c *Config) DisablePlaces() bool {
 	return c.options.DisablePlaces
@@ -69,13 +83,35 @@ func (c *Config) DisableFaces() bool {
 	return false
 }
 
+// DisablePets checks if pet and animal face detection is disabled, e.g. because no cascade file is installed.
+func (c *Config) DisablePets() bool {
+	if c.DisableFaces() || c.options.DisablePets {
+		return true
+	}
+
+	return !fs.FileExists(c.PetCascadePath())
+}
+
 // DisableClassification checks if image classification is disabled.
 func (c *Config) DisableClassification() bool {
-	if c.DisableTensorFlow() || c.options.DisableClassification {
+	if c.options.DisableClassification {
 		return true
+	} else if c.ClassifyBackend() == classify.BackendRemote {
+		return false
 	}
 
-	return false
+	return c.DisableTensorFlow()
+}
+
+// DisableOCR checks if text recognition with Tesseract is disabled.
+func (c *Config) DisableOCR() bool {
+	if c.options.DisableOCR {
+		return true
+	} else if c.TesseractBin() == "" {
+		c.options.DisableOCR = true
+	}
+
+	return c.options.DisableOCR
 }
 
 // DisableFFmpeg checks if FFmpeg is disabled for video transcoding.
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,6 +80,16 @@ func TestConfig_DisableFaces(t *testing.T) {
 	assert.False(t, c.DisableFaces())
 }
 
+func TestConfig_DisablePets(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	// No pet cascade installed.
+	assert.True(t, c.DisablePets())
+	assert.True(t, strings.HasSuffix(c.PetCascadePath(), "/pets/cascade"))
+	c.options.DisablePets = true
+	assert.True(t, c.DisablePets())
+	c.options.DisablePets = false
+}
+
 func TestConfig_DisableClassification(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.False(t, c.DisableClassification())
@@ -288,7 +288,18 @@ func (c *Config) OriginalsPath() string {
 
 // OriginalsDeletable checks if originals can be deleted.
 func (c *Config) OriginalsDeletable() bool {
-	return !c.ReadOnly() && fs.Writable(c.OriginalsPath()) && c.Settings().Features.Delete
+	return !c.ReadOnly() && !c.OriginalsWriteOnce() && fs.Writable(c.OriginalsPath()) && c.Settings().Features.Delete
+}
+
+// OriginalsWriteOnce checks if existing originals are protected from modification and deletion.
+func (c *Config) OriginalsWriteOnce() bool {
+	return c.options.OriginalsWriteOnce
+}
+
+// OriginalsWriteOnceExcept returns the paths excluded from write-once protection, e.g. if
+// the sidecar or cache path is located inside the originals path.
+func (c *Config) OriginalsWriteOnceExcept() []string {
+	return []string{c.SidecarPath(), c.CachePath(), c.ImportPath(), c.TempPath()}
 }
 
 // ImportPath returns the import directory.
@@ -306,6 +317,11 @@ func (c *Config) ImportDest() string {
 	return clean.UserPath(c.options.ImportDest)
 }
 
+// DEMPath returns the optional directory with SRTM height files for setting missing GPS altitudes.
+func (c *Config) DEMPath() string {
+	return fs.Abs(c.options.DEMPath)
+}
+
 // SidecarPath returns the storage path for generated sidecar files (relative or absolute).
 func (c *Config) SidecarPath() string {
 	if c.options.SidecarPath == "" {
@@ -375,6 +391,22 @@ func (c *Config) UserUploadPath(userUid, token string) (string, error) {
 	return dir, nil
 }
 
+// ShareUploadPath returns the folder in which files uploaded by guests with a share link are kept until
+// they have been reviewed, or the parent folder of all pending uploads if no token is specified.
+func (c *Config) ShareUploadPath(shareUid, token string) (string, error) {
+	if !rnd.IsUID(shareUid, 0) {
+		return "", fmt.Errorf("invalid uid")
+	}
+
+	dir := filepath.Join(c.StoragePath(), "shares", shareUid, clean.Token(token))
+
+	if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
 // TempPath returns the cached temporary directory name e.g. for uploads and downloads.
 func (c *Config) TempPath() string {
 	// Return cached value?
@@ -601,6 +633,16 @@ func (c *Config) MysqldumpBin() string {
 	return findBin("", "mysqldump")
 }
 
+// PsqlBin returns the psql executable file name.
+func (c *Config) PsqlBin() string {
+	return findBin("", "psql")
+}
+
+// PgDumpBin returns the pg_dump executable file name.
+func (c *Config) PgDumpBin() string {
+	return findBin("", "pg_dump")
+}
+
 // SqliteBin returns the sqlite executable file name.
 func (c *Config) SqliteBin() string {
 	return findBin("", "sqlite3")
@@ -611,7 +653,17 @@ func (c *Config) AlbumsPath() string {
 	return filepath.Join(c.StoragePath(), "albums")
 }
 
+// PeoplePath returns the storage path for people YAML files.
+func (c *Config) PeoplePath() string {
+	return filepath.Join(c.StoragePath(), "people")
+}
+
 // OriginalsAlbumsPath returns the optional album YAML file path inside originals.
 func (c *Config) OriginalsAlbumsPath() string {
 	return filepath.Join(c.OriginalsPath(), "albums")
 }
+
+// OriginalsObjectsPath returns the path of the content-addressed originals storage, which must be on the same file system.
+func (c *Config) OriginalsObjectsPath() string {
+	return filepath.Join(c.OriginalsPath(), fs.HiddenPath, "objects")
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -69,6 +70,25 @@ func TestConfig_UserUploadPath(t *testing.T) {
 	}
 }
 
+func TestConfig_ShareUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	if dir, err := c.ShareUploadPath("", ""); err == nil {
+		t.Error("error expected")
+	} else {
+		assert.Equal(t, "", dir)
+	}
+	if dir, err := c.ShareUploadPath("as6sg6bxpogaaba7", ""); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Contains(t, dir, "shares/as6sg6bxpogaaba7")
+	}
+	if dir, err := c.ShareUploadPath("as6sg6bxpogaaba7", "foo"); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Contains(t, dir, "shares/as6sg6bxpogaaba7/foo")
+	}
+}
+
 func TestConfig_SidecarPathIsAbs(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -178,12 +198,24 @@ func TestConfig_AlbumsPath(t *testing.T) {
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/albums", c.AlbumsPath())
 }
 
+func TestConfig_PeoplePath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.StoragePath(), "people"), c.PeoplePath())
+}
+
 func TestConfig_OriginalsAlbumsPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/originals/albums", c.OriginalsAlbumsPath())
 }
 
+func TestConfig_OriginalsObjectsPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/originals/.photoprism/objects", c.OriginalsObjectsPath())
+}
+
 func TestConfig_CreateDirectories(t *testing.T) {
 	t.Run("no error", func(t *testing.T) {
 		testConfigMutex.Lock()
@@ -399,6 +431,24 @@ func TestConfig_OriginalsDeletable(t *testing.T) {
 	assert.True(t, c.OriginalsDeletable())
 }
 
+func TestConfig_OriginalsWriteOnce(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.Settings().Features.Delete = true
+	c.options.ReadOnly = false
+
+	assert.False(t, c.OriginalsWriteOnce())
+	assert.True(t, c.OriginalsDeletable())
+
+	c.options.OriginalsWriteOnce = true
+
+	assert.True(t, c.OriginalsWriteOnce())
+	assert.False(t, c.OriginalsDeletable())
+	assert.Contains(t, c.OriginalsWriteOnceExcept(), c.SidecarPath())
+
+	c.options.OriginalsWriteOnce = false
+}
+
 func TestConfig_ImportPath2(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/import", c.ImportPath())
@@ -454,3 +504,11 @@ func TestConfig_SettingsYamlDefaults(t *testing.T) {
 	assert.NotEqual(t, c.SettingsYaml(), name1)
 	assert.NotEqual(t, c.SettingsYaml(), name3)
 }
+
+func TestConfig_DEMPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, "", c.DEMPath())
+	c.options.DEMPath = "/srv/dem"
+	assert.Equal(t, "/srv/dem", c.DEMPath())
+	c.options.DEMPath = ""
+}
@@ -0,0 +1,73 @@
+package config
+
+// RateLimit returns the maximum number of API requests per second and client, or 0 if rate limiting is disabled.
+func (c *Config) RateLimit() float64 {
+	if c.options.RateLimit < 0 {
+		return 0
+	}
+
+	return c.options.RateLimit
+}
+
+// RateBurst returns the maximum number of API requests a client can send at once.
+func (c *Config) RateBurst() int {
+	if c.options.RateBurst <= 0 {
+		return DefaultRateBurst
+	}
+
+	return c.options.RateBurst
+}
+
+// RateLimitSearch returns the maximum number of search requests per second and client.
+func (c *Config) RateLimitSearch() float64 {
+	if c.options.RateLimitSearch <= 0 {
+		return c.RateLimit()
+	}
+
+	return c.options.RateLimitSearch
+}
+
+// RateBurstSearch returns the maximum number of search requests a client can send at once.
+func (c *Config) RateBurstSearch() int {
+	if c.options.RateBurstSearch <= 0 {
+		return c.RateBurst()
+	}
+
+	return c.options.RateBurstSearch
+}
+
+// RateLimitThumbs returns the maximum number of thumbnail requests per second and client.
+func (c *Config) RateLimitThumbs() float64 {
+	if c.options.RateLimitThumbs <= 0 {
+		return c.RateLimit()
+	}
+
+	return c.options.RateLimitThumbs
+}
+
+// RateBurstThumbs returns the maximum number of thumbnail requests a client can send at once.
+func (c *Config) RateBurstThumbs() int {
+	if c.options.RateBurstThumbs <= 0 {
+		return c.RateBurst()
+	}
+
+	return c.options.RateBurstThumbs
+}
+
+// RateLimitAuth returns the maximum number of authentication requests per second and client.
+func (c *Config) RateLimitAuth() float64 {
+	if c.options.RateLimitAuth <= 0 {
+		return c.RateLimit()
+	}
+
+	return c.options.RateLimitAuth
+}
+
+// RateBurstAuth returns the maximum number of authentication requests a client can send at once.
+func (c *Config) RateBurstAuth() int {
+	if c.options.RateBurstAuth <= 0 {
+		return c.RateBurst()
+	}
+
+	return c.options.RateBurstAuth
+}
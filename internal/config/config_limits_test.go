@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_RateLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, float64(0), c.RateLimit())
+	assert.Equal(t, DefaultRateBurst, c.RateBurst())
+	assert.Equal(t, float64(0), c.RateLimitSearch())
+	assert.Equal(t, DefaultRateBurst, c.RateBurstSearch())
+
+	c.options.RateLimit = 10
+	c.options.RateBurst = 20
+	c.options.RateLimitThumbs = 100
+	c.options.RateBurstThumbs = 500
+
+	assert.Equal(t, float64(10), c.RateLimit())
+	assert.Equal(t, 20, c.RateBurst())
+	assert.Equal(t, float64(10), c.RateLimitSearch())
+	assert.Equal(t, 20, c.RateBurstSearch())
+	assert.Equal(t, float64(100), c.RateLimitThumbs())
+	assert.Equal(t, 500, c.RateBurstThumbs())
+	assert.Equal(t, float64(10), c.RateLimitAuth())
+	assert.Equal(t, 20, c.RateBurstAuth())
+
+	c.options.RateLimit = -1
+
+	assert.Equal(t, float64(0), c.RateLimit())
+	assert.Equal(t, float64(0), c.RateLimitAuth())
+
+	c.options.RateLimit = 0
+	c.options.RateBurst = 0
+	c.options.RateLimitThumbs = 0
+	c.options.RateBurstThumbs = 0
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	netmail "net/mail"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/mail"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SmtpHost returns the outgoing mail server hostname, or an empty string if sending emails is disabled.
+func (c *Config) SmtpHost() string {
+	return strings.TrimSpace(c.options.SmtpHost)
+}
+
+// SmtpPort returns the outgoing mail server port.
+func (c *Config) SmtpPort() int {
+	if c.options.SmtpPort <= 0 || c.options.SmtpPort > 65535 {
+		return 587
+	}
+
+	return c.options.SmtpPort
+}
+
+// SmtpUser returns the outgoing mail server username.
+func (c *Config) SmtpUser() string {
+	return strings.TrimSpace(c.options.SmtpUser)
+}
+
+// SmtpPassword returns the outgoing mail server password.
+func (c *Config) SmtpPassword() string {
+	return c.options.SmtpPassword
+}
+
+// SmtpFrom returns the sender address of outgoing emails, e.g. "PhotoPrism <photos@example.com>".
+// If no sender is configured, the username is used if it is an email address.
+func (c *Config) SmtpFrom() string {
+	from := strings.TrimSpace(c.options.SmtpFrom)
+
+	if from == "" {
+		from = clean.Email(c.SmtpUser())
+	}
+
+	if from == "" {
+		return ""
+	}
+
+	addr, err := netmail.ParseAddress(from)
+
+	if err != nil {
+		log.Warnf("config: invalid sender address %s", clean.Log(from))
+		return ""
+	} else if addr.Name == "" {
+		addr.Name = c.SiteTitle()
+	}
+
+	return addr.String()
+}
+
+// SmtpSecurity returns the outgoing mail server connection security (starttls, tls, none).
+func (c *Config) SmtpSecurity() string {
+	switch s := strings.ToLower(strings.TrimSpace(c.options.SmtpSecurity)); s {
+	case mail.SecurityTLS, mail.SecurityNone:
+		return s
+	default:
+		return mail.SecurityStartTLS
+	}
+}
+
+// AlertEmail returns the email addresses that are notified when a scheduled backup fails.
+func (c *Config) AlertEmail() []string {
+	var result []string
+
+	for _, s := range strings.Split(c.options.AlertEmail, ",") {
+		if email := clean.Email(s); email != "" {
+			result = append(result, email)
+		}
+	}
+
+	return result
+}
+
+// MailOptions returns the outgoing mail server settings.
+func (c *Config) MailOptions() mail.Options {
+	return mail.Options{
+		Host:     c.SmtpHost(),
+		Port:     c.SmtpPort(),
+		Username: c.SmtpUser(),
+		Password: c.SmtpPassword(),
+		From:     c.SmtpFrom(),
+		Security: c.SmtpSecurity(),
+	}
+}
+
+// MailEnabled checks if an outgoing mail server has been configured.
+func (c *Config) MailEnabled() bool {
+	return c.SmtpHost() != "" && c.SmtpFrom() != ""
+}
+
+// Mailer returns a new mailer for sending emails, see MailEnabled.
+func (c *Config) Mailer() *mail.Mailer {
+	return mail.New(c.MailOptions())
+}
+
+// MailData returns the default email template values of this instance.
+func (c *Config) MailData() mail.Data {
+	return mail.Data{
+		SiteTitle: c.SiteTitle(),
+		SiteUrl:   c.SiteUrl(),
+		Url:       c.LoginUrl(),
+	}
+}
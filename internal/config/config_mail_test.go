@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/mail"
+)
+
+func TestConfig_SmtpHost(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.SmtpHost())
+	assert.False(t, c.MailEnabled())
+	c.options.SmtpHost = " mail.example.com "
+	assert.Equal(t, "mail.example.com", c.SmtpHost())
+	c.options.SmtpHost = ""
+}
+
+func TestConfig_SmtpPort(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 587, c.SmtpPort())
+	c.options.SmtpPort = 465
+	assert.Equal(t, 465, c.SmtpPort())
+	c.options.SmtpPort = -1
+	assert.Equal(t, 587, c.SmtpPort())
+	c.options.SmtpPort = 0
+}
+
+func TestConfig_SmtpFrom(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.SmtpFrom())
+	c.options.SmtpUser = "Photos@Example.com"
+	assert.Equal(t, "\""+c.SiteTitle()+"\" <photos@example.com>", c.SmtpFrom())
+	c.options.SmtpFrom = "Jane Doe <jane@example.com>"
+	assert.Equal(t, "\"Jane Doe\" <jane@example.com>", c.SmtpFrom())
+	c.options.SmtpFrom = "invalid"
+	assert.Equal(t, "", c.SmtpFrom())
+	c.options.SmtpFrom = ""
+	c.options.SmtpUser = ""
+}
+
+func TestConfig_SmtpSecurity(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, mail.SecurityStartTLS, c.SmtpSecurity())
+	c.options.SmtpSecurity = "TLS"
+	assert.Equal(t, mail.SecurityTLS, c.SmtpSecurity())
+	c.options.SmtpSecurity = "none"
+	assert.Equal(t, mail.SecurityNone, c.SmtpSecurity())
+	c.options.SmtpSecurity = "invalid"
+	assert.Equal(t, mail.SecurityStartTLS, c.SmtpSecurity())
+	c.options.SmtpSecurity = ""
+}
+
+func TestConfig_AlertEmail(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.AlertEmail())
+	c.options.AlertEmail = "admin@example.com, invalid, Backup@Example.com"
+	assert.Equal(t, []string{"admin@example.com", "backup@example.com"}, c.AlertEmail())
+	c.options.AlertEmail = ""
+}
+
+func TestConfig_MailOptions(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.SmtpHost = "mail.example.com"
+	c.options.SmtpFrom = "photos@example.com"
+
+	opt := c.MailOptions()
+
+	assert.Equal(t, "mail.example.com", opt.Host)
+	assert.Equal(t, 587, opt.Port)
+	assert.Equal(t, mail.SecurityStartTLS, opt.Security)
+	assert.True(t, c.MailEnabled())
+	assert.True(t, c.Mailer().Enabled())
+
+	c.options.SmtpHost = ""
+	c.options.SmtpFrom = ""
+}
+
+func TestConfig_MailData(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	data := c.MailData()
+
+	assert.Equal(t, c.SiteTitle(), data.SiteTitle)
+	assert.Equal(t, c.SiteUrl(), data.SiteUrl)
+	assert.Equal(t, c.LoginUrl(), data.Url)
+}
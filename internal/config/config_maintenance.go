@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow returns the daily time range in which heavy background workers may run, e.g. "22:00-06:00",
+// or an empty string if they may run at any time.
+func (c *Config) MaintenanceWindow() string {
+	from, to, ok := parseWindow(c.options.MaintenanceWindow)
+
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", from/60, from%60, to/60, to%60)
+}
+
+// InMaintenanceWindow checks if heavy background workers may run at the specified local time.
+func (c *Config) InMaintenanceWindow(t time.Time) bool {
+	from, to, ok := parseWindow(c.options.MaintenanceWindow)
+
+	if !ok || from == to {
+		return true
+	}
+
+	now := t.Hour()*60 + t.Minute()
+
+	// The window may span midnight, e.g. 22:00-06:00.
+	if from < to {
+		return now >= from && now < to
+	}
+
+	return now >= from || now < to
+}
+
+// MaxLoad returns the system load average per CPU core above which heavy background workers pause, or 0 if disabled.
+func (c *Config) MaxLoad() float64 {
+	if c.options.MaxLoad <= 0 {
+		return 0
+	}
+
+	return c.options.MaxLoad
+}
+
+// MaxRequests returns the number of concurrent API requests above which heavy background workers pause, or 0 if disabled.
+func (c *Config) MaxRequests() int {
+	if c.options.MaxRequests <= 0 {
+		return 0
+	}
+
+	return c.options.MaxRequests
+}
+
+// parseWindow parses a time range like "22:00-06:00" and returns its start and end in minutes after midnight.
+func parseWindow(s string) (from, to int, ok bool) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return 0, 0, false
+	}
+
+	i := strings.Index(s, "-")
+
+	if i < 0 {
+		return 0, 0, false
+	}
+
+	if from, ok = parseClock(s[:i]); !ok {
+		return 0, 0, false
+	} else if to, ok = parseClock(s[i+1:]); !ok {
+		return 0, 0, false
+	}
+
+	return from, to, true
+}
+
+// parseClock parses a time of day like "6", "06:00" or "24:00" and returns the minutes after midnight.
+func parseClock(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	h, m := s, "0"
+
+	if i := strings.Index(s, ":"); i >= 0 {
+		h, m = s[:i], s[i+1:]
+	}
+
+	hours, err := strconv.Atoi(h)
+
+	if err != nil || hours < 0 || hours > 24 {
+		return 0, false
+	}
+
+	minutes, err := strconv.Atoi(m)
+
+	if err != nil || minutes < 0 || minutes > 59 || hours == 24 && minutes > 0 {
+		return 0, false
+	}
+
+	return (hours*60 + minutes) % (24 * 60), true
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_MaintenanceWindow(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MaintenanceWindow())
+	assert.True(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)))
+
+	c.options.MaintenanceWindow = "22:00-6"
+	assert.Equal(t, "22:00-06:00", c.MaintenanceWindow())
+	assert.True(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 23, 30, 0, 0, time.Local)))
+	assert.True(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 5, 59, 0, 0, time.Local)))
+	assert.False(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 6, 0, 0, 0, time.Local)))
+	assert.False(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)))
+
+	c.options.MaintenanceWindow = "01:30-04:00"
+	assert.True(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 1, 30, 0, 0, time.Local)))
+	assert.False(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 4, 0, 0, 0, time.Local)))
+
+	c.options.MaintenanceWindow = "25:00-04:00"
+	assert.Equal(t, "", c.MaintenanceWindow())
+	assert.True(t, c.InMaintenanceWindow(time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)))
+
+	c.options.MaintenanceWindow = ""
+}
+
+func TestConfig_MaxLoad(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0.0, c.MaxLoad())
+	c.options.MaxLoad = 1.5
+	assert.Equal(t, 1.5, c.MaxLoad())
+	c.options.MaxLoad = -1
+	assert.Equal(t, 0.0, c.MaxLoad())
+	c.options.MaxLoad = 0
+}
+
+func TestConfig_MaxRequests(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.MaxRequests())
+	c.options.MaxRequests = 8
+	assert.Equal(t, 8, c.MaxRequests())
+	c.options.MaxRequests = 0
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MapTiles returns the optional MBTiles file with map tiles for the places map.
+func (c *Config) MapTiles() string {
+	return fs.Abs(c.options.MapTiles)
+}
+
+// MapTilesUrl returns the optional tile server URL template whose tiles are cached.
+func (c *Config) MapTilesUrl() string {
+	return strings.TrimSpace(c.options.MapTilesUrl)
+}
+
+// MapTilesCachePath returns the cache path for map tiles downloaded from the tile server.
+func (c *Config) MapTilesCachePath() string {
+	return filepath.Join(c.CachePath(), "tiles")
+}
+
+// MapTilesEnabled checks if map tiles are served locally instead of using an external tile provider.
+func (c *Config) MapTilesEnabled() bool {
+	return c.MapTiles() != "" || c.MapTilesUrl() != ""
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_MapTiles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MapTiles())
+	assert.Equal(t, "", c.MapTilesUrl())
+	assert.False(t, c.MapTilesEnabled())
+	assert.Contains(t, c.MapTilesCachePath(), "/cache/tiles")
+
+	c.options.MapTiles = "/srv/maps/world.mbtiles"
+	assert.Equal(t, "/srv/maps/world.mbtiles", c.MapTiles())
+	assert.True(t, c.MapTilesEnabled())
+	c.options.MapTiles = ""
+
+	c.options.MapTilesUrl = " https://tile.openstreetmap.org/{z}/{x}/{y}.png "
+	assert.Equal(t, "https://tile.openstreetmap.org/{z}/{x}/{y}.png", c.MapTilesUrl())
+	assert.True(t, c.MapTilesEnabled())
+	c.options.MapTilesUrl = ""
+}
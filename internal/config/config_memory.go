@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CgroupMemoryFiles contain the memory limit of the container the application is running in, if any.
+var CgroupMemoryFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// MinWorkersMemory is the smallest memory budget in MB for decoding images.
+const MinWorkersMemory = 128
+
+// WorkersMemory returns the memory budget in MB for decoding images, or 0 if it is unlimited.
+// By default, half of the memory available to the container or system is used.
+func (c *Config) WorkersMemory() int {
+	if c.options.WorkersMemory < 0 {
+		return 0
+	} else if c.options.WorkersMemory > 0 {
+		if c.options.WorkersMemory < MinWorkersMemory {
+			return MinWorkersMemory
+		}
+
+		return c.options.WorkersMemory
+	}
+
+	if available := AvailableMem(); available > 0 {
+		if result := int(available / 2 / Megabyte); result > MinWorkersMemory {
+			return result
+		}
+
+		return MinWorkersMemory
+	}
+
+	return 0
+}
+
+// AvailableMem returns the memory in bytes available to the application, which is the total memory
+// of the system, unless a lower container memory limit has been set.
+func AvailableMem() uint64 {
+	result := TotalMem
+
+	for _, fileName := range CgroupMemoryFiles {
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			continue
+		}
+
+		// Values like "max" mean that there is no limit.
+		if limit, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil && limit > 0 && (result == 0 || limit < result) {
+			result = limit
+		}
+
+		break
+	}
+
+	return result
+}
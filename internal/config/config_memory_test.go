@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_WorkersMemory(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	files := CgroupMemoryFiles
+	defer func() { CgroupMemoryFiles = files }()
+
+	CgroupMemoryFiles = nil
+
+	if TotalMem > 2*MinWorkersMemory*Megabyte {
+		assert.Equal(t, int(TotalMem/2/Megabyte), c.WorkersMemory())
+	}
+
+	c.options.WorkersMemory = -1
+	assert.Equal(t, 0, c.WorkersMemory())
+	c.options.WorkersMemory = 100
+	assert.Equal(t, MinWorkersMemory, c.WorkersMemory())
+	c.options.WorkersMemory = 2048
+	assert.Equal(t, 2048, c.WorkersMemory())
+}
+
+func TestAvailableMem(t *testing.T) {
+	files := CgroupMemoryFiles
+	defer func() { CgroupMemoryFiles = files }()
+
+	dir := t.TempDir()
+
+	t.Run("NoLimit", func(t *testing.T) {
+		fileName := filepath.Join(dir, "memory.max")
+
+		if err := os.WriteFile(fileName, []byte("max\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		CgroupMemoryFiles = []string{fileName}
+
+		assert.Equal(t, TotalMem, AvailableMem())
+	})
+	t.Run("Limit", func(t *testing.T) {
+		fileName := filepath.Join(dir, "memory.limit_in_bytes")
+
+		if err := os.WriteFile(fileName, []byte("536870912\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		CgroupMemoryFiles = []string{filepath.Join(dir, "missing"), fileName}
+
+		assert.Equal(t, uint64(536870912), AvailableMem())
+	})
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// OffloadJobs checks if CPU-heavy jobs should be queued for worker nodes instead of being executed locally.
+// This requires a database server, since worker nodes must have access to the same database.
+func (c *Config) OffloadJobs() bool {
+	return c.options.OffloadJobs && c.DatabaseDriver() != SQLite3
+}
+
+// NodeName returns the name of this node in the job queue.
+func (c *Config) NodeName() string {
+	if name := clean.TypeLower(c.options.NodeName); name != "" {
+		return name
+	} else if name, err := os.Hostname(); err == nil && strings.TrimSpace(name) != "" {
+		return clean.TypeLower(name)
+	}
+
+	return "node"
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OffloadJobs(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OffloadJobs())
+
+	// Worker nodes cannot share SQLite databases.
+	c.options.OffloadJobs = true
+	assert.False(t, c.OffloadJobs())
+	c.options.OffloadJobs = false
+}
+
+func TestConfig_NodeName(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.NotEmpty(t, c.NodeName())
+	c.options.NodeName = "Desktop"
+	assert.Equal(t, "desktop", c.NodeName())
+	c.options.NodeName = ""
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/nsfw"
+)
+
+// NSFWThreshold returns the minimum score above which pictures are considered to be offensive.
+func (c *Config) NSFWThreshold() float32 {
+	if c.options.NSFWThreshold < 0.5 || c.options.NSFWThreshold > 1 {
+		return nsfw.ThresholdHigh
+	}
+
+	return float32(c.options.NSFWThreshold)
+}
+
+// NSFWPolicy returns the default policy for pictures that might be offensive.
+func (c *Config) NSFWPolicy() string {
+	if p := entity.ParseNSFWPolicy(c.options.NSFWPolicy); p != "" {
+		return p
+	}
+
+	return entity.NSFWShow
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/nsfw"
+)
+
+func TestConfig_NSFWThreshold(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, float32(nsfw.ThresholdHigh), c.NSFWThreshold())
+	c.options.NSFWThreshold = 0.85
+	assert.Equal(t, float32(0.85), c.NSFWThreshold())
+	c.options.NSFWThreshold = 0.1
+	assert.Equal(t, float32(nsfw.ThresholdHigh), c.NSFWThreshold())
+	c.options.NSFWThreshold = 0
+}
+
+func TestConfig_NSFWPolicy(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, entity.NSFWShow, c.NSFWPolicy())
+	c.options.NSFWPolicy = "Hide"
+	assert.Equal(t, entity.NSFWHide, c.NSFWPolicy())
+	c.options.NSFWPolicy = "foo"
+	assert.Equal(t, entity.NSFWShow, c.NSFWPolicy())
+	c.options.NSFWPolicy = ""
+}
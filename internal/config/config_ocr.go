@@ -0,0 +1,15 @@
+package config
+
+import (
+	"github.com/photoprism/photoprism/internal/ocr"
+)
+
+// TesseractBin returns the Tesseract executable file name.
+func (c *Config) TesseractBin() string {
+	return findBin(c.options.TesseractBin, "tesseract")
+}
+
+// OcrLanguages returns the Tesseract text recognition languages, e.g. "eng+deu".
+func (c *Config) OcrLanguages() string {
+	return ocr.Languages(c.options.OcrLanguages)
+}
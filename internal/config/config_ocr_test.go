@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OcrLanguages(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, "eng", c.OcrLanguages())
+
+	c.options.OcrLanguages = "eng, deu"
+	assert.Equal(t, "eng+deu", c.OcrLanguages())
+
+	c.options.OcrLanguages = ""
+	assert.Equal(t, "eng", c.OcrLanguages())
+}
+
+func TestConfig_DisableOCR(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.TesseractBin = "/usr/local/bin/tesseract-missing"
+	assert.True(t, c.DisableOCR())
+
+	c.options.DisableOCR = false
+	c.options.TesseractBin = ""
+}
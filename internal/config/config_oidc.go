@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// OIDCUri returns the OpenID Connect issuer URI of the identity provider.
+func (c *Config) OIDCUri() string {
+	return strings.TrimRight(strings.TrimSpace(c.options.OIDCUri), "/")
+}
+
+// OIDCClient returns the OpenID Connect client ID.
+func (c *Config) OIDCClient() string {
+	return strings.TrimSpace(c.options.OIDCClient)
+}
+
+// OIDCSecret returns the OpenID Connect client secret.
+func (c *Config) OIDCSecret() string {
+	return strings.TrimSpace(c.options.OIDCSecret)
+}
+
+// OIDCInsecure checks if identity providers without a valid HTTPS certificate are allowed.
+func (c *Config) OIDCInsecure() bool {
+	return c.options.OIDCInsecure
+}
+
+// OIDCEnabled checks if users can log in with an OpenID Connect identity provider.
+func (c *Config) OIDCEnabled() bool {
+	return c.OIDCUri() != "" && c.OIDCClient() != "" && !c.Public() && !c.Demo()
+}
+
+// OIDCRedirectUrl returns the OpenID Connect redirect URL that must be registered with the identity provider.
+func (c *Config) OIDCRedirectUrl() string {
+	return c.SiteUrl() + strings.TrimLeft(ApiUri, "/") + "/oidc/redirect"
+}
+
+// OIDCRoleClaim returns the name of the OpenID Connect claim that contains the groups or roles of a user.
+func (c *Config) OIDCRoleClaim() string {
+	if s := strings.TrimSpace(c.options.OIDCRoleClaim); s != "" {
+		return s
+	}
+
+	return DefaultOIDCRoleClaim
+}
+
+// OIDCRoleMapping returns the mapping of OpenID Connect claim values to user roles.
+func (c *Config) OIDCRoleMapping() acl.RoleMapping {
+	return acl.ParseRoleMapping(c.options.OIDCRoleMapping)
+}
+
+// OIDCDefaultRole returns the role assigned to OpenID Connect users if no claim value matches.
+func (c *Config) OIDCDefaultRole() acl.Role {
+	return acl.FindRole(clean.Role(c.options.OIDCDefaultRole))
+}
+
+// OIDCRegister checks if accounts should be created automatically for new OpenID Connect users.
+func (c *Config) OIDCRegister() bool {
+	return c.options.OIDCRegister
+}
+
+// OIDCRole returns the user role based on the OpenID Connect claims, or acl.RoleUnknown if the login should be denied.
+func (c *Config) OIDCRole(claims authn.Claims) acl.Role {
+	if role := c.OIDCRoleMapping().Match(claims.Values(c.OIDCRoleClaim())); role != acl.RoleUnknown {
+		return role
+	}
+
+	return c.OIDCDefaultRole()
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestConfig_OIDCRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultOIDCRoleClaim, c.OIDCRoleClaim())
+	assert.Equal(t, acl.RoleUnknown, c.OIDCDefaultRole())
+	assert.False(t, c.OIDCRegister())
+
+	claims := authn.Claims{"groups": []interface{}{"family", "photoprism-admins"}, "realm_access": map[string]interface{}{"roles": []interface{}{"guest"}}}
+
+	assert.Equal(t, acl.RoleUnknown, c.OIDCRole(claims))
+
+	c.options.OIDCRoleMapping = "photoprism-admins=admin"
+	assert.Equal(t, acl.RoleAdmin, c.OIDCRole(claims))
+
+	c.options.OIDCRoleClaim = "realm_access.roles"
+	c.options.OIDCRoleMapping = "guest=visitor"
+	assert.Equal(t, "realm_access.roles", c.OIDCRoleClaim())
+	assert.Equal(t, acl.RoleVisitor, c.OIDCRole(claims))
+
+	c.options.OIDCRoleMapping = ""
+	c.options.OIDCDefaultRole = "Admin"
+	assert.Equal(t, acl.RoleAdmin, c.OIDCRole(claims))
+
+	c.options.OIDCRoleClaim = ""
+	c.options.OIDCDefaultRole = ""
+}
+
+func TestConfig_OIDCEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCEnabled())
+	assert.Equal(t, "", c.OIDCUri())
+	assert.False(t, c.OIDCInsecure())
+	assert.Equal(t, "http://photoprism.me:2342/api/v1/oidc/redirect", c.OIDCRedirectUrl())
+
+	c.SetAuthMode(AuthModePasswd)
+	c.options.OIDCUri = "https://keycloak.localssl.dev/auth/realms/master/"
+	c.options.OIDCClient = " photoprism-develop "
+
+	assert.Equal(t, "https://keycloak.localssl.dev/auth/realms/master", c.OIDCUri())
+	assert.Equal(t, "photoprism-develop", c.OIDCClient())
+	assert.True(t, c.OIDCEnabled())
+
+	c.options.OIDCUri = ""
+	c.options.OIDCClient = ""
+	c.SetAuthMode(AuthModePublic)
+}
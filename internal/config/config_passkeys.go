@@ -0,0 +1,27 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PasskeyOnly checks if password login should be disabled for users who have registered a passkey.
+func (c *Config) PasskeyOnly() bool {
+	return c.options.PasskeyOnly && !c.DisablePasskeys()
+}
+
+// PasskeyRPID returns the WebAuthn relying party id, i.e. the site domain passkeys are bound to.
+func (c *Config) PasskeyRPID() string {
+	return c.SiteDomain()
+}
+
+// PasskeyOrigin returns the origin from which passkey ceremonies must be performed, e.g. "https://photos.example.com".
+func (c *Config) PasskeyOrigin() string {
+	u, err := url.Parse(c.SiteUrl())
+
+	if err != nil {
+		return strings.TrimRight(c.SiteUrl(), "/")
+	}
+
+	return u.Scheme + "://" + u.Host
+}
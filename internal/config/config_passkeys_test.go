@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Passkeys(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DisablePasskeys())
+	assert.False(t, c.PasskeyOnly())
+
+	c.options.PasskeyOnly = true
+	assert.True(t, c.PasskeyOnly())
+
+	c.options.DisablePasskeys = true
+	assert.True(t, c.DisablePasskeys())
+	assert.False(t, c.PasskeyOnly())
+
+	c.options.PasskeyOnly = false
+	c.options.DisablePasskeys = false
+}
+
+func TestConfig_PasskeyOrigin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.SiteDomain(), c.PasskeyRPID())
+	assert.Equal(t, "http://photoprism.me:2342", c.PasskeyOrigin())
+
+	c.options.SiteUrl = "https://photos.example.com/library/"
+	assert.Equal(t, "photos.example.com", c.PasskeyRPID())
+	assert.Equal(t, "https://photos.example.com", c.PasskeyOrigin())
+
+	c.options.SiteUrl = ""
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// PrivacyZones returns the areas like homes in which the exact position of pictures is hidden from
+// shares, downloads, and public APIs.
+func (c *Config) PrivacyZones() geo.Zones {
+	zones, err := geo.ParseZones(strings.Split(c.options.PrivacyZones, ";"))
+
+	if err != nil {
+		log.Warnf("config: %s", err)
+	}
+
+	return zones
+}
+
+// PrivacyZonesString returns the privacy zones as a string for use in reports.
+func (c *Config) PrivacyZonesString() string {
+	zones := c.PrivacyZones()
+	result := make([]string, len(zones))
+
+	for i, z := range zones {
+		result[i] = z.String()
+	}
+
+	return strings.Join(result, ";")
+}
+
+// PrivacyFence returns the mode for hiding coordinates in privacy zones, either fuzz or strip.
+func (c *Config) PrivacyFence() string {
+	if m := entity.ParsePrivacyFence(c.options.PrivacyFence); m != "" {
+		return m
+	}
+
+	return entity.PrivacyFuzz
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestConfig_PrivacyZones(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.PrivacyZones())
+	assert.Equal(t, "", c.PrivacyZonesString())
+
+	c.options.PrivacyZones = "52.5208,13.4094,500; 48.1351,11.5820"
+
+	assert.Len(t, c.PrivacyZones(), 2)
+	assert.Equal(t, "52.520800,13.409400,500;48.135100,11.582000,250", c.PrivacyZonesString())
+
+	c.options.PrivacyZones = "52.5208,13.4094,500;foo"
+
+	assert.Len(t, c.PrivacyZones(), 1)
+
+	c.options.PrivacyZones = ""
+}
+
+func TestConfig_PrivacyFence(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, entity.PrivacyFuzz, c.PrivacyFence())
+	c.options.PrivacyFence = "Strip"
+	assert.Equal(t, entity.PrivacyStrip, c.PrivacyFence())
+	c.options.PrivacyFence = "foo"
+	assert.Equal(t, entity.PrivacyFuzz, c.PrivacyFence())
+	c.options.PrivacyFence = ""
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/aesthetic"
+)
+
+// QualityModel returns the image quality model name, the learned aesthetic model requires TensorFlow.
+func (c *Config) QualityModel() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.QualityModel)) {
+	case aesthetic.ModelAesthetic:
+		if c.DisableTensorFlow() {
+			return aesthetic.ModelBasic
+		}
+
+		return aesthetic.ModelAesthetic
+	case aesthetic.ModelBasic:
+		return aesthetic.ModelBasic
+	default:
+		return aesthetic.ModelNone
+	}
+}
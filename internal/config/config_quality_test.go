@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/aesthetic"
+)
+
+func TestConfig_QualityModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, aesthetic.ModelNone, c.QualityModel())
+
+	c.options.QualityModel = "Basic"
+	assert.Equal(t, aesthetic.ModelBasic, c.QualityModel())
+
+	c.options.QualityModel = "aesthetic"
+	c.options.DisableTensorFlow = true
+	assert.Equal(t, aesthetic.ModelBasic, c.QualityModel())
+
+	c.options.DisableTensorFlow = false
+	assert.Equal(t, aesthetic.ModelAesthetic, c.QualityModel())
+
+	c.options.QualityModel = "foo"
+	assert.Equal(t, aesthetic.ModelNone, c.QualityModel())
+
+	c.options.QualityModel = ""
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/redis"
+)
+
+// RedisPrefix is prepended to the names of keys and channels on the Redis server.
+var RedisPrefix = "photoprism:"
+
+// RedisRetryInterval specifies how long to wait before reconnecting to the Redis server.
+var RedisRetryInterval = 5 * time.Second
+
+// RedisUrl returns the Redis server URL for sharing sessions, caches, and events between multiple instances, if any.
+func (c *Config) RedisUrl() string {
+	return strings.TrimSpace(c.options.RedisUrl)
+}
+
+// RedisUrlRedacted returns the Redis server URL with the password replaced by "xxxxx".
+func (c *Config) RedisUrlRedacted() string {
+	s := c.RedisUrl()
+
+	if u, err := url.Parse(s); err == nil {
+		return u.Redacted()
+	}
+
+	return s
+}
+
+// RedisEnabled checks if a Redis server should be used to run multiple instances behind a load balancer.
+func (c *Config) RedisEnabled() bool {
+	return c.RedisUrl() != ""
+}
+
+// Redis returns the Redis client, or nil if no server has been configured.
+func (c *Config) Redis() *redis.Client {
+	return c.redis
+}
+
+// connectRedis connects to the Redis server, if configured, and uses it to share sessions,
+// cached entities, and events with other instances.
+func (c *Config) connectRedis() (err error) {
+	if c.redis != nil || !c.RedisEnabled() {
+		return nil
+	}
+
+	client, err := redis.New(c.RedisUrl())
+
+	if err != nil {
+		return fmt.Errorf("config: invalid redis url (%s)", err)
+	}
+
+	client.Prefix = RedisPrefix
+
+	// Wait for the server to become available.
+	for i := 1; i <= 12; i++ {
+		if err = client.Ping(); err == nil {
+			break
+		}
+
+		log.Warnf("config: %s (connect to redis server)", err)
+		time.Sleep(RedisRetryInterval)
+	}
+
+	if err != nil {
+		return fmt.Errorf("config: failed to connect to redis server %s", client.Addr)
+	}
+
+	c.redis = client
+
+	entity.SetSharedCache(client)
+
+	go relayEvents(client)
+
+	log.Infof("config: sharing sessions, caches, and events through redis server %s", client.Addr)
+
+	return nil
+}
+
+// closeRedis stops sharing events and closes the Redis server connections.
+func (c *Config) closeRedis() {
+	if c.redis == nil {
+		return
+	}
+
+	event.DisableRelay()
+	entity.SetSharedCache(nil)
+
+	_ = c.redis.Close()
+
+	c.redis = nil
+}
+
+// relayEvents sends events to other instances and publishes their events to local subscribers,
+// so that connected clients are notified regardless of which instance they are connected to.
+func relayEvents(client *redis.Client) {
+	queue := event.EnableRelay()
+
+	go func() {
+		for data := range queue {
+			if err := client.Publish(event.RelayChannel, data); err != nil {
+				log.Warnf("config: %s (publish event)", err)
+			}
+		}
+	}()
+
+	for !client.Closed() {
+		sub, err := client.Subscribe(event.RelayChannel)
+
+		if client.Closed() {
+			return
+		} else if err != nil {
+			log.Warnf("config: %s (subscribe to events)", err)
+			time.Sleep(RedisRetryInterval)
+			continue
+		}
+
+		for {
+			msg, err := sub.Receive()
+
+			if err != nil {
+				break
+			} else if err = event.Receive(msg.Data); err != nil {
+				log.Debugf("config: %s (receive event)", err)
+			}
+		}
+
+		_ = sub.Close()
+
+		if client.Closed() {
+			return
+		}
+
+		log.Debugf("config: reconnecting to redis server")
+		time.Sleep(RedisRetryInterval)
+	}
+}
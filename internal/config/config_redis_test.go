@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Redis(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.RedisUrl())
+	assert.Equal(t, "", c.RedisUrlRedacted())
+	assert.False(t, c.RedisEnabled())
+	assert.Nil(t, c.Redis())
+	assert.NoError(t, c.connectRedis())
+
+	c.options.RedisUrl = " redis://:insecure@redis:6379/1 "
+
+	assert.Equal(t, "redis://:insecure@redis:6379/1", c.RedisUrl())
+	assert.Equal(t, "redis://:xxxxx@redis:6379/1", c.RedisUrlRedacted())
+	assert.True(t, c.RedisEnabled())
+
+	c.closeRedis()
+}
+
+func TestConfig_ConnectRedis(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("InvalidUrl", func(t *testing.T) {
+		c.options.RedisUrl = "http://redis:6379"
+
+		assert.Error(t, c.connectRedis())
+		assert.Nil(t, c.Redis())
+	})
+	t.Run("Unavailable", func(t *testing.T) {
+		interval := RedisRetryInterval
+		RedisRetryInterval = time.Millisecond
+
+		defer func() { RedisRetryInterval = interval }()
+
+		c.options.RedisUrl = "redis://127.0.0.1:1"
+
+		assert.Error(t, c.connectRedis())
+		assert.Nil(t, c.Redis())
+	})
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"strings"
+)
+
+// SmbClientBin returns the Samba client executable file name.
+func (c *Config) SmbClientBin() string {
+	return findBin(c.options.SmbClientBin, "smbclient")
+}
+
+// GPhotosClientID returns the Google OAuth client ID for syncing with Google Photos.
+func (c *Config) GPhotosClientID() string {
+	return strings.TrimSpace(c.options.GPhotosClientID)
+}
+
+// GPhotosClientSecret returns the Google OAuth client secret for syncing with Google Photos.
+func (c *Config) GPhotosClientSecret() string {
+	return strings.TrimSpace(c.options.GPhotosClientSecret)
+}
+
+// GPhotosEnabled checks if Google Photos accounts can be authorized.
+func (c *Config) GPhotosEnabled() bool {
+	return c.GPhotosClientID() != "" && c.GPhotosClientSecret() != ""
+}
+
+// GPhotosRedirectUrl returns the OAuth redirect URL that must be registered for the Google client ID.
+func (c *Config) GPhotosRedirectUrl() string {
+	return c.SiteUrl() + strings.TrimLeft(ApiUri, "/") + "/oauth/gphotos"
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SmbClientBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.SmbClientBin(), "smbclient")
+
+	c.options.SmbClientBin = "/usr/local/bin/smbclient-missing"
+	assert.Equal(t, "", c.SmbClientBin())
+}
+
+func TestConfig_GPhotos(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.GPhotosEnabled())
+
+	c.options.GPhotosClientID = " 1234.apps.googleusercontent.com "
+	c.options.GPhotosClientSecret = "secret"
+
+	assert.Equal(t, "1234.apps.googleusercontent.com", c.GPhotosClientID())
+	assert.Equal(t, "secret", c.GPhotosClientSecret())
+	assert.True(t, c.GPhotosEnabled())
+	assert.Equal(t, c.SiteUrl()+"api/v1/oauth/gphotos", c.GPhotosRedirectUrl())
+
+	c.options.GPhotosClientID = ""
+	c.options.GPhotosClientSecret = ""
+}
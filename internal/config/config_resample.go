@@ -64,6 +64,29 @@ func (c *Config) ThumbUncached() bool {
 	return c.options.ThumbUncached
 }
 
+// ThumbPrefetch returns the number of result pages for which missing thumbnails are created in advance (0-10).
+func (c *Config) ThumbPrefetch() int {
+	if c.options.ThumbPrefetch < 0 {
+		return 0
+	} else if c.options.ThumbPrefetch > 10 {
+		return 10
+	}
+
+	return c.options.ThumbPrefetch
+}
+
+// ThumbPrefetchWorkers returns the maximum number of workers that create thumbnails in advance,
+// or 0 if prefetching is disabled. It cannot exceed the number of indexing workers.
+func (c *Config) ThumbPrefetchWorkers() int {
+	if c.ThumbPrefetch() == 0 || c.options.ThumbPrefetchWorkers <= 0 {
+		return 0
+	} else if workers := c.Workers(); c.options.ThumbPrefetchWorkers > workers {
+		return workers
+	}
+
+	return c.options.ThumbPrefetchWorkers
+}
+
 // ThumbSizePrecached returns the pre-cached thumbnail size limit in pixels (720-7680).
 func (c *Config) ThumbSizePrecached() int {
 	size := c.options.ThumbSize
@@ -63,6 +63,25 @@ func TestConfig_ThumbSizeUncached(t *testing.T) {
 	assert.False(t, c.ThumbUncached())
 }
 
+func TestConfig_ThumbPrefetch(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.ThumbPrefetch())
+	assert.Equal(t, 0, c.ThumbPrefetchWorkers())
+	c.options.ThumbPrefetchWorkers = 1
+	assert.Equal(t, 0, c.ThumbPrefetchWorkers())
+	c.options.ThumbPrefetch = 2
+	assert.Equal(t, 2, c.ThumbPrefetch())
+	assert.Equal(t, 1, c.ThumbPrefetchWorkers())
+	c.options.ThumbPrefetchWorkers = 1000
+	assert.Equal(t, c.Workers(), c.ThumbPrefetchWorkers())
+	c.options.ThumbPrefetch = 11
+	assert.Equal(t, 10, c.ThumbPrefetch())
+	c.options.ThumbPrefetch = -1
+	assert.Equal(t, 0, c.ThumbPrefetch())
+	assert.Equal(t, 0, c.ThumbPrefetchWorkers())
+}
+
 func TestConfig_ThumbSize(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
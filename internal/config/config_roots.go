@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// OriginalsRoot represents a storage location for originals. Additional roots are
+// mounted as top-level folders in the originals path, so they can be indexed and served.
+type OriginalsRoot struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+// Main checks if this is the main originals path.
+func (r OriginalsRoot) Main() bool {
+	return r.Name == ""
+}
+
+// Space returns the free and total space of the file system.
+func (r OriginalsRoot) Space() (fs.DiskSpace, error) {
+	return fs.Space(r.Path)
+}
+
+// ObjectsPath returns the path of the content-addressed storage, see Config.OriginalsHashLayout.
+func (r OriginalsRoot) ObjectsPath() string {
+	return filepath.Join(r.Path, fs.HiddenPath, "objects")
+}
+
+// ParseOriginalsRoot parses an originals root in the format [NAME=]PATH[:ro].
+func ParseOriginalsRoot(s string) (r OriginalsRoot, err error) {
+	s = strings.TrimSpace(s)
+
+	if l := len(s); l > 3 && strings.EqualFold(s[l-3:], ":ro") {
+		r.ReadOnly = true
+		s = s[:l-3]
+	} else if l > 3 && strings.EqualFold(s[l-3:], ":rw") {
+		s = s[:l-3]
+	}
+
+	if i := strings.Index(s, "="); i > 0 {
+		r.Name = strings.TrimSpace(s[:i])
+		s = s[i+1:]
+	}
+
+	if s = strings.TrimSpace(s); s == "" {
+		return r, fmt.Errorf("originals root path is empty")
+	}
+
+	r.Path = fs.Abs(s)
+
+	if r.Name == "" {
+		r.Name = filepath.Base(r.Path)
+	}
+
+	if r.Name == "" || strings.HasPrefix(r.Name, ".") || strings.ContainsAny(r.Name, "/\\") {
+		return r, fmt.Errorf("invalid originals root name %s", clean.Log(r.Name))
+	}
+
+	return r, nil
+}
+
+// OriginalsRootsString returns the additional originals roots as string.
+func (c *Config) OriginalsRootsString() string {
+	return strings.Join(c.options.OriginalsRoots, ", ")
+}
+
+// OriginalsRoots returns all originals storage roots, starting with the main originals path.
+func (c *Config) OriginalsRoots() []OriginalsRoot {
+	roots, _ := c.originalsRoots()
+	return roots
+}
+
+// originalsRoots returns all valid originals roots and the errors of invalid roots.
+func (c *Config) originalsRoots() (roots []OriginalsRoot, errs []error) {
+	originalsPath := c.OriginalsPath()
+
+	roots = []OriginalsRoot{{Path: originalsPath, ReadOnly: c.ReadOnly()}}
+	names := make(map[string]bool, len(c.options.OriginalsRoots))
+
+	for _, s := range c.options.OriginalsRoots {
+		r, err := ParseOriginalsRoot(s)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		} else if names[r.Name] {
+			errs = append(errs, fmt.Errorf("originals root name %s is not unique", clean.Log(r.Name)))
+			continue
+		} else if r.Path == originalsPath || strings.HasPrefix(r.Path, originalsPath+string(os.PathSeparator)) {
+			errs = append(errs, fmt.Errorf("originals root %s must not be inside the originals path", clean.Log(r.Name)))
+			continue
+		}
+
+		names[r.Name] = true
+		r.ReadOnly = r.ReadOnly || c.ReadOnly()
+		roots = append(roots, r)
+	}
+
+	return roots, errs
+}
+
+// OriginalsRoot returns the originals root that contains the specified file, which
+// may be an absolute file name or relative to the originals path.
+func (c *Config) OriginalsRoot(fileName string) OriginalsRoot {
+	roots := c.OriginalsRoots()
+
+	if len(roots) > 1 {
+		relName := strings.TrimLeft(filepath.ToSlash(fs.RelName(fileName, c.OriginalsPath())), "/")
+
+		for _, r := range roots[1:] {
+			if relName == r.Name || strings.HasPrefix(relName, r.Name+"/") {
+				return r
+			} else if fileName == r.Path || strings.HasPrefix(fileName, r.Path+string(os.PathSeparator)) {
+				return r
+			}
+		}
+	}
+
+	return roots[0]
+}
+
+// OriginalsWritable checks if the specified file or folder in originals may be changed.
+func (c *Config) OriginalsWritable(fileName string) bool {
+	return !c.OriginalsRoot(fileName).ReadOnly
+}
+
+// initOriginalsRoots mounts additional originals roots as top-level folders by creating symbolic links.
+func (c *Config) initOriginalsRoots() {
+	roots, errs := c.originalsRoots()
+
+	for _, err := range errs {
+		log.Warnf("config: %s", err)
+	}
+
+	for _, r := range roots[1:] {
+		link := filepath.Join(c.OriginalsPath(), r.Name)
+		target, err := os.Stat(r.Path)
+
+		if err != nil || !target.IsDir() {
+			log.Warnf("config: originals root %s not found", clean.Log(r.Path))
+		} else if info, err := os.Lstat(link); err != nil {
+			if err = os.Symlink(r.Path, link); err != nil {
+				log.Warnf("config: failed to mount originals root %s (%s)", clean.Log(r.Name), err)
+			} else {
+				log.Infof("config: mounted originals root %s", clean.Log(r.Name))
+			}
+		} else if mounted, err := os.Stat(link); info.Mode()&os.ModeSymlink == 0 || err != nil || !os.SameFile(mounted, target) {
+			log.Warnf("config: cannot mount originals root %s, because %s already exists", clean.Log(r.Name), clean.Log(link))
+		}
+	}
+}
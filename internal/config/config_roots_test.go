@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOriginalsRoot(t *testing.T) {
+	t.Run("Path", func(t *testing.T) {
+		r, err := ParseOriginalsRoot("/photos/archive")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", r.Name)
+		assert.Equal(t, "/photos/archive", r.Path)
+		assert.False(t, r.ReadOnly)
+		assert.False(t, r.Main())
+		assert.Equal(t, "/photos/archive/.photoprism/objects", r.ObjectsPath())
+	})
+	t.Run("NameReadOnly", func(t *testing.T) {
+		r, err := ParseOriginalsRoot(" ssd=/mnt/fast:RO ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ssd", r.Name)
+		assert.Equal(t, "/mnt/fast", r.Path)
+		assert.True(t, r.ReadOnly)
+	})
+	t.Run("ReadWrite", func(t *testing.T) {
+		r, err := ParseOriginalsRoot("/mnt/fast:rw")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fast", r.Name)
+		assert.False(t, r.ReadOnly)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseOriginalsRoot("name=")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := ParseOriginalsRoot(".hidden=/mnt/fast")
+		assert.Error(t, err)
+		_, err = ParseOriginalsRoot("/")
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_OriginalsRoots(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Len(t, c.OriginalsRoots(), 1)
+	assert.True(t, c.OriginalsRoots()[0].Main())
+	assert.Equal(t, "", c.OriginalsRootsString())
+
+	c.options.OriginalsRoots = []string{"/photos/archive:ro", "ssd=/mnt/ssd", "/mnt/archive", filepath.Join(c.OriginalsPath(), "foo")}
+
+	roots, errs := c.originalsRoots()
+
+	assert.Len(t, roots, 3)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "/photos/archive:ro, ssd=/mnt/ssd, /mnt/archive, "+filepath.Join(c.OriginalsPath(), "foo"), c.OriginalsRootsString())
+
+	t.Run("OriginalsRoot", func(t *testing.T) {
+		assert.True(t, c.OriginalsRoot("2020/IMG_1234.jpg").Main())
+		assert.True(t, c.OriginalsRoot(filepath.Join(c.OriginalsPath(), "archived/IMG_1234.jpg")).Main())
+		assert.Equal(t, "archive", c.OriginalsRoot("archive/2020/IMG_1234.jpg").Name)
+		assert.Equal(t, "archive", c.OriginalsRoot(filepath.Join(c.OriginalsPath(), "archive")).Name)
+		assert.Equal(t, "archive", c.OriginalsRoot("/photos/archive/IMG_1234.jpg").Name)
+		assert.Equal(t, "ssd", c.OriginalsRoot("/ssd/2020").Name)
+	})
+	t.Run("OriginalsWritable", func(t *testing.T) {
+		assert.True(t, c.OriginalsWritable("2020/IMG_1234.jpg"))
+		assert.False(t, c.OriginalsWritable("archive/2020/IMG_1234.jpg"))
+		assert.True(t, c.OriginalsWritable("ssd/2020/IMG_1234.jpg"))
+	})
+}
+
+func TestConfig_initOriginalsRoots(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "archive")
+	originals := filepath.Join(dir, "originals")
+
+	assert.NoError(t, os.MkdirAll(archive, 0o755))
+	assert.NoError(t, os.MkdirAll(originals, 0o755))
+
+	c.options.OriginalsPath = originals
+	c.options.OriginalsRoots = []string{archive, "missing=" + filepath.Join(dir, "missing")}
+	c.initOriginalsRoots()
+
+	link, err := filepath.EvalSymlinks(filepath.Join(originals, "archive"))
+	assert.NoError(t, err)
+
+	target, err := filepath.EvalSymlinks(archive)
+	assert.NoError(t, err)
+
+	assert.Equal(t, target, link)
+	assert.False(t, fileExists(filepath.Join(originals, "missing")))
+
+	// Mounting again must not fail.
+	c.initOriginalsRoots()
+}
+
+func fileExists(fileName string) bool {
+	_, err := os.Lstat(fileName)
+	return err == nil
+}
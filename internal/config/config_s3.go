@@ -0,0 +1,83 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+// S3Endpoint returns the host name and port of an S3-compatible object storage like MinIO,
+// or an empty string if the files should be stored in AWS S3.
+func (c *Config) S3Endpoint() string {
+	return strings.TrimRight(strings.TrimSpace(c.options.S3Endpoint), "/")
+}
+
+// S3Region returns the object storage region.
+func (c *Config) S3Region() string {
+	if c.options.S3Region == "" {
+		return vfs.S3DefaultRegion
+	}
+
+	return strings.TrimSpace(c.options.S3Region)
+}
+
+// S3Bucket returns the object storage bucket name.
+func (c *Config) S3Bucket() string {
+	return strings.TrimSpace(c.options.S3Bucket)
+}
+
+// S3Prefix returns the object key prefix, so that a bucket can be shared with other applications.
+func (c *Config) S3Prefix() string {
+	return vfs.Key(c.options.S3Prefix)
+}
+
+// S3AccessKey returns the object storage access key.
+func (c *Config) S3AccessKey() string {
+	return strings.TrimSpace(c.options.S3AccessKey)
+}
+
+// S3SecretKey returns the object storage secret key.
+func (c *Config) S3SecretKey() string {
+	return strings.TrimSpace(c.options.S3SecretKey)
+}
+
+// S3Insecure checks if the object storage should be accessed via HTTP instead of HTTPS.
+func (c *Config) S3Insecure() bool {
+	return c.options.S3Insecure
+}
+
+// S3CacheLimit returns the maximum size of locally cached thumbnails in MB, or -1 if it is unlimited.
+func (c *Config) S3CacheLimit() int {
+	if c.options.S3CacheLimit <= 0 {
+		return -1
+	}
+
+	return c.options.S3CacheLimit
+}
+
+// S3CacheByteLimit returns the maximum size of locally cached thumbnails in bytes, or -1 if it is unlimited.
+func (c *Config) S3CacheByteLimit() int64 {
+	if result := c.S3CacheLimit(); result <= 0 {
+		return -1
+	} else {
+		return int64(result) * 1024 * 1024
+	}
+}
+
+// S3Enabled checks if originals, sidecar files, and thumbnails should be stored in an object storage.
+func (c *Config) S3Enabled() bool {
+	return c.S3Bucket() != ""
+}
+
+// S3Options returns the object storage connection settings.
+func (c *Config) S3Options() vfs.S3Options {
+	return vfs.S3Options{
+		Endpoint:  c.S3Endpoint(),
+		Region:    c.S3Region(),
+		Bucket:    c.S3Bucket(),
+		Prefix:    c.S3Prefix(),
+		AccessKey: c.S3AccessKey(),
+		SecretKey: c.S3SecretKey(),
+		Insecure:  c.S3Insecure(),
+	}
+}
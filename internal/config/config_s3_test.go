@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestConfig_S3(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.S3Endpoint())
+	assert.Equal(t, vfs.S3DefaultRegion, c.S3Region())
+	assert.Equal(t, "", c.S3Bucket())
+	assert.Equal(t, "", c.S3Prefix())
+	assert.False(t, c.S3Insecure())
+	assert.False(t, c.S3Enabled())
+
+	c.options.S3Endpoint = " minio:9000/ "
+	c.options.S3Region = "eu-central-1"
+	c.options.S3Bucket = "photos"
+	c.options.S3Prefix = "/library/"
+	c.options.S3AccessKey = "access"
+	c.options.S3SecretKey = "secret"
+	c.options.S3Insecure = true
+
+	assert.True(t, c.S3Enabled())
+	assert.Equal(t, vfs.S3Options{
+		Endpoint:  "minio:9000",
+		Region:    "eu-central-1",
+		Bucket:    "photos",
+		Prefix:    "library",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Insecure:  true,
+	}, c.S3Options())
+
+	c.options.S3Endpoint = ""
+	c.options.S3Region = ""
+	c.options.S3Bucket = ""
+	c.options.S3Prefix = ""
+	c.options.S3AccessKey = ""
+	c.options.S3SecretKey = ""
+	c.options.S3Insecure = false
+}
+
+func TestConfig_S3CacheLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, -1, c.S3CacheLimit())
+	assert.Equal(t, int64(-1), c.S3CacheByteLimit())
+
+	c.options.S3CacheLimit = 500
+
+	assert.Equal(t, 500, c.S3CacheLimit())
+	assert.Equal(t, int64(500*1024*1024), c.S3CacheByteLimit())
+
+	c.options.S3CacheLimit = 0
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/cron"
+)
+
+// ScheduleOff disables a scheduled task that otherwise runs by default.
+const ScheduleOff = "off"
+
+// schedule returns the trimmed cron expression, the default if none is set,
+// or an empty string if the task has been disabled.
+func schedule(s, defaultSchedule string) string {
+	s = strings.TrimSpace(s)
+
+	switch strings.ToLower(s) {
+	case "":
+		return defaultSchedule
+	case ScheduleOff, "false", "none", "-1":
+		return ""
+	}
+
+	return s
+}
+
+// WakeupSchedule returns the cron expression for background workers that run at the wakeup interval,
+// or an empty string if they have been disabled.
+func (c *Config) WakeupSchedule() string {
+	if interval := c.WakeupInterval(); interval <= 0 {
+		return ""
+	} else {
+		return cron.Every(interval).String()
+	}
+}
+
+// IndexSchedule returns the cron expression for regularly indexing all originals, if any.
+func (c *Config) IndexSchedule() string {
+	return schedule(c.options.IndexSchedule, "")
+}
+
+// BackupSchedule returns the cron expression for regularly creating backups, if any.
+func (c *Config) BackupSchedule() string {
+	if c.DisableBackups() {
+		return ""
+	}
+
+	return schedule(c.options.BackupSchedule, "")
+}
+
+// CleanupSchedule returns the cron expression for regularly removing orphaned index entries and files, if any.
+func (c *Config) CleanupSchedule() string {
+	return schedule(c.options.CleanupSchedule, "")
+}
+
+// SyncSchedule returns the cron expression for syncing with remote services, the wakeup interval by default.
+func (c *Config) SyncSchedule() string {
+	return schedule(c.options.SyncSchedule, c.WakeupSchedule())
+}
+
+// FacesSchedule returns the cron expression for face recognition. If empty,
+// face recognition runs together with the metadata worker.
+func (c *Config) FacesSchedule() string {
+	if c.DisableFaces() {
+		return ""
+	}
+
+	return schedule(c.options.FacesSchedule, "")
+}
+
+// TrashSchedule returns the cron expression for permanently deleting expired archived pictures,
+// or an empty string if no retention period is configured.
+func (c *Config) TrashSchedule() string {
+	if c.TrashDays() <= 0 || c.ReadOnly() {
+		return ""
+	}
+
+	return schedule(c.options.TrashSchedule, "@daily")
+}
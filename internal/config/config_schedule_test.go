@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	assert.Equal(t, "@daily", schedule(" @daily ", ""))
+	assert.Equal(t, "@every 15m0s", schedule("", "@every 15m0s"))
+	assert.Equal(t, "", schedule("off", "@every 15m0s"))
+	assert.Equal(t, "", schedule("-1", "@hourly"))
+}
+
+func TestConfig_IndexSchedule(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.IndexSchedule())
+	c.options.IndexSchedule = "0 3 * * *"
+	assert.Equal(t, "0 3 * * *", c.IndexSchedule())
+	c.options.IndexSchedule = ""
+}
+
+func TestConfig_SyncSchedule(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.WakeupSchedule(), c.SyncSchedule())
+	c.options.SyncSchedule = ScheduleOff
+	assert.Equal(t, "", c.SyncSchedule())
+	c.options.SyncSchedule = ""
+}
+
+func TestConfig_WakeupSchedule(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "@every 1h34m9s", c.WakeupSchedule())
+}
+
+func TestConfig_TrashSchedule(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.TrashSchedule())
+	c.options.TrashDays = 30
+	assert.Equal(t, "@daily", c.TrashSchedule())
+	c.options.TrashSchedule = "0 4 * * *"
+	assert.Equal(t, "0 4 * * *", c.TrashSchedule())
+	c.options.TrashSchedule = ScheduleOff
+	assert.Equal(t, "", c.TrashSchedule())
+	c.options.TrashSchedule = ""
+	c.options.TrashDays = 0
+}
@@ -21,6 +21,16 @@ func (c *Config) NSFWModelPath() string {
 	return filepath.Join(c.AssetsPath(), "nsfw")
 }
 
+// AestheticModelPath returns the learned aesthetic quality model path.
+func (c *Config) AestheticModelPath() string {
+	return filepath.Join(c.AssetsPath(), "aesthetic")
+}
+
+// PetCascadePath returns the path of the Pigo cascade file for detecting animal faces.
+func (c *Config) PetCascadePath() string {
+	return filepath.Join(c.AssetsPath(), "pets", "cascade")
+}
+
 // FaceNetModelPath returns the FaceNet model path.
 func (c *Config) FaceNetModelPath() string {
 	return filepath.Join(c.AssetsPath(), "facenet")
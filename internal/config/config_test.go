@@ -254,6 +254,12 @@ func TestConfig_NSFWModelPath(t *testing.T) {
 	assert.Contains(t, c.NSFWModelPath(), "/assets/nsfw")
 }
 
+func TestConfig_AestheticModelPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.AestheticModelPath(), "/assets/aesthetic")
+}
+
 func TestConfig_FaceNetModelPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -354,6 +360,15 @@ func TestConfig_AutoImport(t *testing.T) {
 	assert.Equal(t, 2*time.Hour, c.AutoImport())
 }
 
+func TestConfig_AlbumTemplates(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, []string{}, c.AlbumTemplates())
+	c.options.AlbumTemplates = " Monthly, on-this-day,monthly,"
+	assert.Equal(t, []string{"monthly", "on-this-day"}, c.AlbumTemplates())
+	c.options.AlbumTemplates = ""
+}
+
 func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -378,6 +393,24 @@ func TestConfig_OriginalsByteLimit(t *testing.T) {
 	assert.Equal(t, int64(838860800), c.OriginalsByteLimit())
 }
 
+func TestConfig_OriginalsLayout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "default", c.OriginalsLayout())
+	assert.False(t, c.OriginalsHashLayout())
+	c.options.OriginalsLayout = " Hash "
+	assert.Equal(t, "hash", c.OriginalsLayout())
+	assert.True(t, c.OriginalsHashLayout())
+	c.options.ReadOnly = true
+	assert.False(t, c.OriginalsHashLayout())
+	c.options.ReadOnly = false
+	c.options.OriginalsWriteOnce = true
+	assert.False(t, c.OriginalsHashLayout())
+	c.options.OriginalsWriteOnce = false
+	c.options.OriginalsLayout = "foo"
+	assert.Equal(t, "default", c.OriginalsLayout())
+}
+
 func TestConfig_ResolutionLimit(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// TrashDays returns the number of days after which archived pictures are permanently deleted, 0 if disabled.
+func (c *Config) TrashDays() int {
+	if c.options.TrashDays < 0 {
+		return 0
+	}
+
+	return c.options.TrashDays
+}
+
+// TrashRetention returns the retention period for archived pictures, 0 if they are kept forever.
+func (c *Config) TrashRetention() time.Duration {
+	return time.Duration(c.TrashDays()) * 24 * time.Hour
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TrashDays(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.TrashDays())
+	assert.Equal(t, time.Duration(0), c.TrashRetention())
+	c.options.TrashDays = -1
+	assert.Equal(t, 0, c.TrashDays())
+	c.options.TrashDays = 30
+	assert.Equal(t, 30, c.TrashDays())
+	assert.Equal(t, 720*time.Hour, c.TrashRetention())
+	c.options.TrashDays = 0
+}
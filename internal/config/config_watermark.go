@@ -0,0 +1,63 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// WatermarkText returns the watermark text for pictures shared with links that have watermarks enabled.
+func (c *Config) WatermarkText() string {
+	return strings.TrimSpace(c.options.WatermarkText)
+}
+
+// WatermarkLogo returns the absolute watermark logo file name, or an empty string if it does not exist.
+func (c *Config) WatermarkLogo() string {
+	if c.options.WatermarkLogo == "" {
+		return ""
+	}
+
+	fileName := fs.Abs(c.options.WatermarkLogo)
+
+	if !fs.FileExists(fileName) {
+		log.Warnf("config: watermark logo %s not found", clean.Log(fileName))
+		return ""
+	}
+
+	return fileName
+}
+
+// WatermarkPosition returns the watermark position, see thumb.WatermarkPositions.
+func (c *Config) WatermarkPosition() string {
+	pos := strings.ToLower(strings.TrimSpace(c.options.WatermarkPosition))
+
+	if !list.Contains(thumb.WatermarkPositions, pos) {
+		return thumb.WatermarkBottomRight
+	}
+
+	return pos
+}
+
+// WatermarkOpacity returns the watermark opacity in percent (1-100).
+func (c *Config) WatermarkOpacity() int {
+	if c.options.WatermarkOpacity <= 0 {
+		return thumb.DefaultWatermarkOpacity
+	} else if c.options.WatermarkOpacity > 100 {
+		return 100
+	}
+
+	return c.options.WatermarkOpacity
+}
+
+// Watermark returns the watermark that is applied to images shared with links that have watermarks enabled.
+func (c *Config) Watermark() thumb.Watermark {
+	return thumb.Watermark{
+		Text:     c.WatermarkText(),
+		Logo:     c.WatermarkLogo(),
+		Position: c.WatermarkPosition(),
+		Opacity:  c.WatermarkOpacity(),
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+func TestConfig_WatermarkText(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.WatermarkText())
+	c.options.WatermarkText = " © Example "
+	assert.Equal(t, "© Example", c.WatermarkText())
+	c.options.WatermarkText = ""
+}
+
+func TestConfig_WatermarkLogo(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.WatermarkLogo())
+	c.options.WatermarkLogo = "testdata/missing.png"
+	assert.Equal(t, "", c.WatermarkLogo())
+	c.options.WatermarkLogo = ""
+}
+
+func TestConfig_WatermarkPosition(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.WatermarkBottomRight, c.WatermarkPosition())
+	c.options.WatermarkPosition = "Top-Left"
+	assert.Equal(t, thumb.WatermarkTopLeft, c.WatermarkPosition())
+	c.options.WatermarkPosition = "invalid"
+	assert.Equal(t, thumb.WatermarkBottomRight, c.WatermarkPosition())
+	c.options.WatermarkPosition = ""
+}
+
+func TestConfig_WatermarkOpacity(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.DefaultWatermarkOpacity, c.WatermarkOpacity())
+	c.options.WatermarkOpacity = 80
+	assert.Equal(t, 80, c.WatermarkOpacity())
+	c.options.WatermarkOpacity = 200
+	assert.Equal(t, 100, c.WatermarkOpacity())
+	c.options.WatermarkOpacity = 0
+}
+
+func TestConfig_Watermark(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, c.Watermark().Empty())
+	c.options.WatermarkText = "© Example"
+	assert.False(t, c.Watermark().Empty())
+	assert.Equal(t, "© Example", c.Watermark().Text)
+	c.options.WatermarkText = ""
+}
@@ -1,6 +1,9 @@
 package config
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // dsnPattern is a regular expression matching a database DSN string.
 var dsnPattern = regexp.MustCompile(
@@ -9,6 +12,9 @@ var dsnPattern = regexp.MustCompile(
 		`\/(?P<name>.*?)` +
 		`(?:\?(?P<params>[^\?]*))?$`)
 
+// keyValuePattern matches a PostgreSQL connection string like "host=localhost dbname=photoprism".
+var keyValuePattern = regexp.MustCompile(`^\s*\w+\s*=`)
+
 // DSN represents parts of a data source name.
 type DSN struct {
 	Driver   string
@@ -31,6 +37,9 @@ func NewDSN(dsn string) DSN {
 func (d *DSN) Parse(dsn string) {
 	if dsn == "" {
 		return
+	} else if keyValuePattern.MatchString(dsn) {
+		d.parseKeyValues(dsn)
+		return
 	}
 
 	matches := dsnPattern.FindStringSubmatch(dsn)
@@ -60,3 +69,96 @@ func (d *DSN) Parse(dsn string) {
 		d.Net = ""
 	}
 }
+
+// parseKeyValues parses a data source name string with space separated key=value pairs.
+func (d *DSN) parseKeyValues(dsn string) {
+	var host, port string
+	var params []string
+
+	for _, pair := range splitKeyValues(dsn) {
+		key, val, _ := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+
+		switch strings.ToLower(key) {
+		case "user":
+			d.User = unquoteDsnValue(val)
+		case "password":
+			d.Password = unquoteDsnValue(val)
+		case "host":
+			host = unquoteDsnValue(val)
+		case "port":
+			port = unquoteDsnValue(val)
+		case "dbname":
+			d.Name = unquoteDsnValue(val)
+		default:
+			params = append(params, pair)
+		}
+	}
+
+	if port != "" {
+		d.Server = host + ":" + port
+	} else {
+		d.Server = host
+	}
+
+	d.Params = strings.Join(params, " ")
+}
+
+// QuoteDsnValue quotes a value for use in a PostgreSQL key/value connection string,
+// escaping single quotes and backslashes as required by libpq.
+func QuoteDsnValue(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// unquoteDsnValue removes the quotes and escape characters from a key/value connection string value.
+func unquoteDsnValue(s string) string {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// splitKeyValues splits a key/value connection string into pairs, keeping quoted values intact.
+func splitKeyValues(dsn string) (pairs []string) {
+	var b strings.Builder
+	var quoted bool
+
+	for i := 0; i < len(dsn); i++ {
+		c := dsn[i]
+
+		switch {
+		case c == '\\' && quoted && i+1 < len(dsn):
+			b.WriteByte(c)
+			i++
+			c = dsn[i]
+		case c == '\'':
+			quoted = !quoted
+		case (c == ' ' || c == '\t' || c == '\n') && !quoted:
+			if b.Len() > 0 {
+				pairs = append(pairs, b.String())
+				b.Reset()
+			}
+
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	if b.Len() > 0 {
+		pairs = append(pairs, b.String())
+	}
+
+	return pairs
+}
@@ -33,6 +33,15 @@ type Options struct {
 	AdminPassword         string        `yaml:"AdminPassword" json:"-" flag:"admin-password"`
 	SessionMaxAge         int64         `yaml:"SessionMaxAge" json:"-" flag:"session-maxage"`
 	SessionTimeout        int64         `yaml:"SessionTimeout" json:"-" flag:"session-timeout"`
+	OIDCUri               string        `yaml:"OIDCUri" json:"-" flag:"oidc-uri"`
+	OIDCClient            string        `yaml:"OIDCClient" json:"-" flag:"oidc-client"`
+	OIDCSecret            string        `yaml:"OIDCSecret" json:"-" flag:"oidc-secret"`
+	OIDCInsecure          bool          `yaml:"OIDCInsecure" json:"-" flag:"oidc-insecure"`
+	OIDCRoleClaim         string        `yaml:"OIDCRoleClaim" json:"-" flag:"oidc-role-claim"`
+	OIDCRoleMapping       string        `yaml:"OIDCRoleMapping" json:"-" flag:"oidc-role-mapping"`
+	OIDCDefaultRole       string        `yaml:"OIDCDefaultRole" json:"-" flag:"oidc-default-role"`
+	OIDCRegister          bool          `yaml:"OIDCRegister" json:"-" flag:"oidc-register"`
+	PasskeyOnly           bool          `yaml:"PasskeyOnly" json:"-" flag:"passkey-only"`
 	LogLevel              string        `yaml:"LogLevel" json:"-" flag:"log-level"`
 	Prod                  bool          `yaml:"Prod" json:"Prod" flag:"prod"`
 	Debug                 bool          `yaml:"Debug" json:"Debug" flag:"debug"`
@@ -45,6 +54,10 @@ type Options struct {
 	DefaultsYaml          string        `json:"-" yaml:"-" flag:"defaults-yaml"`
 	OriginalsPath         string        `yaml:"OriginalsPath" json:"-" flag:"originals-path"`
 	OriginalsLimit        int           `yaml:"OriginalsLimit" json:"OriginalsLimit" flag:"originals-limit"`
+	OriginalsLayout       string        `yaml:"OriginalsLayout" json:"OriginalsLayout" flag:"originals-layout"`
+	OriginalsRoots        []string      `yaml:"OriginalsRoots" json:"-" flag:"originals-root"`
+	OriginalsWriteOnce    bool          `yaml:"OriginalsWriteOnce" json:"OriginalsWriteOnce" flag:"originals-write-once"`
+	MinFreeSpace          int           `yaml:"MinFreeSpace" json:"MinFreeSpace" flag:"min-free-space"`
 	ResolutionLimit       int           `yaml:"ResolutionLimit" json:"ResolutionLimit" flag:"resolution-limit"`
 	UsersPath             string        `yaml:"UsersPath" json:"-" flag:"users-path"`
 	StoragePath           string        `yaml:"StoragePath" json:"-" flag:"storage-path"`
@@ -56,20 +69,54 @@ type Options struct {
 	AssetsPath            string        `yaml:"AssetsPath" json:"-" flag:"assets-path"`
 	CustomAssetsPath      string        `yaml:"-" json:"-" flag:"custom-assets-path"`
 	TempPath              string        `yaml:"TempPath" json:"-" flag:"temp-path"`
+	S3Endpoint            string        `yaml:"S3Endpoint" json:"-" flag:"s3-endpoint"`
+	S3Region              string        `yaml:"S3Region" json:"-" flag:"s3-region"`
+	S3Bucket              string        `yaml:"S3Bucket" json:"-" flag:"s3-bucket"`
+	S3Prefix              string        `yaml:"S3Prefix" json:"-" flag:"s3-prefix"`
+	S3AccessKey           string        `yaml:"S3AccessKey" json:"-" flag:"s3-access-key"`
+	S3SecretKey           string        `yaml:"S3SecretKey" json:"-" flag:"s3-secret-key"`
+	S3Insecure            bool          `yaml:"S3Insecure" json:"-" flag:"s3-insecure"`
+	S3CacheLimit          int           `yaml:"S3CacheLimit" json:"-" flag:"s3-cache-limit"`
+	ColdPath              string        `yaml:"ColdPath" json:"-" flag:"cold-path"`
+	ColdBucket            string        `yaml:"ColdBucket" json:"-" flag:"cold-bucket"`
+	ColdStorageClass      string        `yaml:"ColdStorageClass" json:"-" flag:"cold-storage-class"`
+	ColdRestoreDays       int           `yaml:"ColdRestoreDays" json:"-" flag:"cold-restore-days"`
+	BackupBucket          string        `yaml:"BackupBucket" json:"-" flag:"backup-bucket"`
+	BackupWebDAV          string        `yaml:"BackupWebDAV" json:"-" flag:"backup-webdav"`
+	BackupPassphrase      string        `yaml:"BackupPassphrase" json:"-" flag:"backup-passphrase"`
+	BackupRetain          int           `yaml:"BackupRetain" json:"BackupRetain" flag:"backup-retain"`
+	BackupOriginals       bool          `yaml:"BackupOriginals" json:"BackupOriginals" flag:"backup-originals"`
 	Workers               int           `yaml:"Workers" json:"Workers" flag:"workers"`
+	WorkersMemory         int           `yaml:"WorkersMemory" json:"WorkersMemory" flag:"workers-memory"`
 	WakeupInterval        time.Duration `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex             int           `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport            int           `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	IndexSchedule         string        `yaml:"IndexSchedule" json:"IndexSchedule" flag:"index-schedule"`
+	BackupSchedule        string        `yaml:"BackupSchedule" json:"BackupSchedule" flag:"backup-schedule"`
+	CleanupSchedule       string        `yaml:"CleanupSchedule" json:"CleanupSchedule" flag:"cleanup-schedule"`
+	SyncSchedule          string        `yaml:"SyncSchedule" json:"SyncSchedule" flag:"sync-schedule"`
+	FacesSchedule         string        `yaml:"FacesSchedule" json:"FacesSchedule" flag:"faces-schedule"`
+	TrashDays             int           `yaml:"TrashDays" json:"TrashDays" flag:"trash-days"`
+	TrashSchedule         string        `yaml:"TrashSchedule" json:"TrashSchedule" flag:"trash-schedule"`
+	MaintenanceWindow     string        `yaml:"MaintenanceWindow" json:"MaintenanceWindow" flag:"maintenance-window"`
+	MaxLoad               float64       `yaml:"MaxLoad" json:"MaxLoad" flag:"max-load"`
+	MaxRequests           int           `yaml:"MaxRequests" json:"MaxRequests" flag:"max-requests"`
+	OffloadJobs           bool          `yaml:"OffloadJobs" json:"OffloadJobs" flag:"offload-jobs"`
+	NodeName              string        `yaml:"NodeName" json:"NodeName" flag:"node-name"`
+	AlbumTemplates        string        `yaml:"AlbumTemplates" json:"AlbumTemplates" flag:"album-templates"`
 	ReadOnly              bool          `yaml:"ReadOnly" json:"ReadOnly" flag:"read-only"`
 	Experimental          bool          `yaml:"Experimental" json:"Experimental" flag:"experimental"`
 	DisableSettings       bool          `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
 	DisableRestart        bool          `yaml:"DisableRestart" json:"-" flag:"disable-restart"`
 	DisableBackups        bool          `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
 	DisableWebDAV         bool          `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
+	DisablePasskeys       bool          `yaml:"DisablePasskeys" json:"DisablePasskeys" flag:"disable-passkeys"`
 	DisablePlaces         bool          `yaml:"DisablePlaces" json:"DisablePlaces" flag:"disable-places"`
 	DisableTensorFlow     bool          `yaml:"DisableTensorFlow" json:"DisableTensorFlow" flag:"disable-tensorflow"`
 	DisableFaces          bool          `yaml:"DisableFaces" json:"DisableFaces" flag:"disable-faces"`
+	DisablePets           bool          `yaml:"DisablePets" json:"DisablePets" flag:"disable-pets"`
 	DisableClassification bool          `yaml:"DisableClassification" json:"DisableClassification" flag:"disable-classification"`
+	DisableOCR            bool          `yaml:"DisableOCR" json:"DisableOCR" flag:"disable-ocr"`
 	DisableFFmpeg         bool          `yaml:"DisableFFmpeg" json:"DisableFFmpeg" flag:"disable-ffmpeg"`
 	DisableExifTool       bool          `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
 	DisableSips           bool          `yaml:"DisableSips" json:"DisableSips" flag:"disable-sips"`
@@ -84,6 +131,24 @@ type Options struct {
 	ExifBruteForce        bool          `yaml:"ExifBruteForce" json:"ExifBruteForce" flag:"exif-bruteforce"`
 	DetectNSFW            bool          `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW            bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	NSFWThreshold         float64       `yaml:"NSFWThreshold" json:"NSFWThreshold" flag:"nsfw-threshold"`
+	NSFWPolicy            string        `yaml:"NSFWPolicy" json:"NSFWPolicy" flag:"nsfw-policy"`
+	PrivacyZones          string        `yaml:"PrivacyZones" json:"-" flag:"privacy-zones"`
+	PrivacyFence          string        `yaml:"PrivacyFence" json:"PrivacyFence" flag:"privacy-fence"`
+	DEMPath               string        `yaml:"DEMPath" json:"-" flag:"dem-path"`
+	MapTiles              string        `yaml:"MapTiles" json:"-" flag:"map-tiles"`
+	MapTilesUrl           string        `yaml:"MapTilesUrl" json:"-" flag:"map-tiles-url"`
+	ClassifyBackend       string        `yaml:"ClassifyBackend" json:"ClassifyBackend" flag:"classify-backend"`
+	ClassifyUrl           string        `yaml:"ClassifyUrl" json:"-" flag:"classify-url"`
+	ClassifyKey           string        `yaml:"ClassifyKey" json:"-" flag:"classify-key"`
+	LandmarksUrl          string        `yaml:"LandmarksUrl" json:"-" flag:"landmarks-url"`
+	CaptionUrl            string        `yaml:"CaptionUrl" json:"-" flag:"caption-url"`
+	LabelConfidence       float64       `yaml:"LabelConfidence" json:"LabelConfidence" flag:"label-confidence"`
+	LabelRules            string        `yaml:"LabelRules" json:"LabelRules" flag:"label-rules"`
+	SemanticUrl           string        `yaml:"SemanticUrl" json:"-" flag:"semantic-url"`
+	SemanticModel         string        `yaml:"SemanticModel" json:"SemanticModel" flag:"semantic-model"`
+	OcrLanguages          string        `yaml:"OcrLanguages" json:"OcrLanguages" flag:"ocr-languages"`
+	QualityModel          string        `yaml:"QualityModel" json:"QualityModel" flag:"quality-model"`
 	DefaultTheme          string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
 	DefaultLocale         string        `yaml:"DefaultLocale" json:"DefaultLocale" flag:"default-locale"`
 	AppName               string        `yaml:"AppName" json:"AppName" flag:"app-name"`
@@ -101,6 +166,13 @@ type Options struct {
 	SiteCaption           string        `yaml:"SiteCaption" json:"SiteCaption" flag:"site-caption"`
 	SiteDescription       string        `yaml:"SiteDescription" json:"SiteDescription" flag:"site-description"`
 	SitePreview           string        `yaml:"SitePreview" json:"SitePreview" flag:"site-preview"`
+	SmtpHost              string        `yaml:"SmtpHost" json:"SmtpHost" flag:"smtp-host"`
+	SmtpPort              int           `yaml:"SmtpPort" json:"SmtpPort" flag:"smtp-port"`
+	SmtpUser              string        `yaml:"SmtpUser" json:"SmtpUser" flag:"smtp-user"`
+	SmtpPassword          string        `yaml:"SmtpPassword" json:"-" flag:"smtp-password"`
+	SmtpFrom              string        `yaml:"SmtpFrom" json:"SmtpFrom" flag:"smtp-from"`
+	SmtpSecurity          string        `yaml:"SmtpSecurity" json:"SmtpSecurity" flag:"smtp-security"`
+	AlertEmail            string        `yaml:"AlertEmail" json:"AlertEmail" flag:"alert-email"`
 	HttpsProxy            string        `yaml:"HttpsProxy" json:"HttpsProxy" flag:"https-proxy"`
 	HttpsProxyInsecure    bool          `yaml:"HttpsProxyInsecure" json:"HttpsProxyInsecure" flag:"https-proxy-insecure"`
 	TrustedProxies        []string      `yaml:"TrustedProxies" json:"-" flag:"trusted-proxy"`
@@ -116,14 +188,24 @@ type Options struct {
 	HttpCachePublic       bool          `yaml:"HttpCachePublic" json:"HttpCachePublic" flag:"http-cache-public"`
 	HttpHost              string        `yaml:"HttpHost" json:"-" flag:"http-host"`
 	HttpPort              int           `yaml:"HttpPort" json:"-" flag:"http-port"`
+	RateLimit             float64       `yaml:"RateLimit" json:"-" flag:"rate-limit"`
+	RateBurst             int           `yaml:"RateBurst" json:"-" flag:"rate-burst"`
+	RateLimitSearch       float64       `yaml:"RateLimitSearch" json:"-" flag:"rate-limit-search"`
+	RateBurstSearch       int           `yaml:"RateBurstSearch" json:"-" flag:"rate-burst-search"`
+	RateLimitThumbs       float64       `yaml:"RateLimitThumbs" json:"-" flag:"rate-limit-thumbs"`
+	RateBurstThumbs       int           `yaml:"RateBurstThumbs" json:"-" flag:"rate-burst-thumbs"`
+	RateLimitAuth         float64       `yaml:"RateLimitAuth" json:"-" flag:"rate-limit-auth"`
+	RateBurstAuth         int           `yaml:"RateBurstAuth" json:"-" flag:"rate-burst-auth"`
 	DatabaseDriver        string        `yaml:"DatabaseDriver" json:"-" flag:"database-driver"`
 	DatabaseDsn           string        `yaml:"DatabaseDsn" json:"-" flag:"database-dsn"`
 	DatabaseName          string        `yaml:"DatabaseName" json:"-" flag:"database-name"`
 	DatabaseServer        string        `yaml:"DatabaseServer" json:"-" flag:"database-server"`
 	DatabaseUser          string        `yaml:"DatabaseUser" json:"-" flag:"database-user"`
 	DatabasePassword      string        `yaml:"DatabasePassword" json:"-" flag:"database-password"`
+	DatabaseReplica       string        `yaml:"DatabaseReplica" json:"-" flag:"database-replica"`
 	DatabaseConns         int           `yaml:"DatabaseConns" json:"-" flag:"database-conns"`
 	DatabaseConnsIdle     int           `yaml:"DatabaseConnsIdle" json:"-" flag:"database-conns-idle"`
+	RedisUrl              string        `yaml:"RedisUrl" json:"-" flag:"redis-url"`
 	SipsBin               string        `yaml:"SipsBin" json:"-" flag:"sips-bin"`
 	SipsBlacklist         string        `yaml:"SipsBlacklist" json:"-" flag:"sips-blacklist"`
 	FFmpegBin             string        `yaml:"FFmpegBin" json:"-" flag:"ffmpeg-bin"`
@@ -141,6 +223,10 @@ type Options struct {
 	ImageMagickBin        string        `yaml:"ImageMagickBin" json:"-" flag:"imagemagick-bin"`
 	ImageMagickBlacklist  string        `yaml:"ImageMagickBlacklist" json:"-" flag:"imagemagick-blacklist"`
 	HeifConvertBin        string        `yaml:"HeifConvertBin" json:"-" flag:"heifconvert-bin"`
+	TesseractBin          string        `yaml:"TesseractBin" json:"-" flag:"tesseract-bin"`
+	SmbClientBin          string        `yaml:"SmbClientBin" json:"-" flag:"smbclient-bin"`
+	GPhotosClientID       string        `yaml:"GPhotosClientID" json:"-" flag:"gphotos-client-id"`
+	GPhotosClientSecret   string        `yaml:"GPhotosClientSecret" json:"-" flag:"gphotos-client-secret"`
 	RsvgConvertBin        string        `yaml:"RsvgConvertBin" json:"-" flag:"rsvgconvert-bin"`
 	DownloadToken         string        `yaml:"DownloadToken" json:"-" flag:"download-token"`
 	PreviewToken          string        `yaml:"PreviewToken" json:"-" flag:"preview-token"`
@@ -149,17 +235,27 @@ type Options struct {
 	ThumbSize             int           `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
 	ThumbSizeUncached     int           `yaml:"ThumbSizeUncached" json:"ThumbSizeUncached" flag:"thumb-size-uncached"`
 	ThumbUncached         bool          `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
+	ThumbPrefetch         int           `yaml:"ThumbPrefetch" json:"ThumbPrefetch" flag:"thumb-prefetch"`
+	ThumbPrefetchWorkers  int           `yaml:"ThumbPrefetchWorkers" json:"ThumbPrefetchWorkers" flag:"thumb-prefetch-workers"`
 	JpegQuality           string        `yaml:"JpegQuality" json:"JpegQuality" flag:"jpeg-quality"`
 	JpegSize              int           `yaml:"JpegSize" json:"JpegSize" flag:"jpeg-size"`
 	PngSize               int           `yaml:"PngSize" json:"PngSize" flag:"png-size"`
-	FaceSize              int           `yaml:"-" json:"-" flag:"face-size"`
-	FaceScore             float64       `yaml:"-" json:"-" flag:"face-score"`
-	FaceOverlap           int           `yaml:"-" json:"-" flag:"face-overlap"`
+	WatermarkText         string        `yaml:"WatermarkText" json:"WatermarkText" flag:"watermark-text"`
+	WatermarkLogo         string        `yaml:"WatermarkLogo" json:"WatermarkLogo" flag:"watermark-logo"`
+	WatermarkPosition     string        `yaml:"WatermarkPosition" json:"WatermarkPosition" flag:"watermark-position"`
+	WatermarkOpacity      int           `yaml:"WatermarkOpacity" json:"WatermarkOpacity" flag:"watermark-opacity"`
+	FaceSize              int           `yaml:"FaceSize" json:"FaceSize" flag:"face-size"`
+	FaceScore             float64       `yaml:"FaceScore" json:"FaceScore" flag:"face-score"`
+	FaceOverlap           int           `yaml:"FaceOverlap" json:"FaceOverlap" flag:"face-overlap"`
 	FaceClusterSize       int           `yaml:"-" json:"-" flag:"face-cluster-size"`
 	FaceClusterScore      int           `yaml:"-" json:"-" flag:"face-cluster-score"`
 	FaceClusterCore       int           `yaml:"-" json:"-" flag:"face-cluster-core"`
 	FaceClusterDist       float64       `yaml:"-" json:"-" flag:"face-cluster-dist"`
 	FaceMatchDist         float64       `yaml:"-" json:"-" flag:"face-match-dist"`
+	FaceModel             string        `yaml:"-" json:"-" flag:"face-model"`
+	FaceKeyframes         int           `yaml:"-" json:"-" flag:"face-keyframes"`
+	FaceAgeBucket         int           `yaml:"-" json:"-" flag:"face-age-bucket"`
+	FaceAgeTolerance      float64       `yaml:"-" json:"-" flag:"face-age-tolerance"`
 	PIDFilename           string        `yaml:"PIDFilename" json:"-" flag:"pid-filename"`
 	LogFilename           string        `yaml:"LogFilename" json:"-" flag:"log-filename"`
 	DetachServer          bool          `yaml:"DetachServer" json:"-" flag:"detach-server"`
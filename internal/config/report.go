@@ -27,6 +27,15 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"public", fmt.Sprintf("%t", c.Public())},
 		{"session-maxage", fmt.Sprintf("%d", c.SessionMaxAge())},
 		{"session-timeout", fmt.Sprintf("%d", c.SessionTimeout())},
+		{"oidc-uri", c.OIDCUri()},
+		{"oidc-client", c.OIDCClient()},
+		{"oidc-secret", strings.Repeat("*", utf8.RuneCountInString(c.OIDCSecret()))},
+		{"oidc-insecure", fmt.Sprintf("%t", c.OIDCInsecure())},
+		{"oidc-role-claim", c.OIDCRoleClaim()},
+		{"oidc-role-mapping", c.OIDCRoleMapping().String()},
+		{"oidc-default-role", c.OIDCDefaultRole().String()},
+		{"oidc-register", fmt.Sprintf("%t", c.OIDCRegister())},
+		{"passkey-only", fmt.Sprintf("%t", c.PasskeyOnly())},
 		{"login-uri", c.LoginUri()},
 		{"register-uri", c.RegisterUri()},
 		{"password-length", fmt.Sprintf("%d", c.PasswordLength())},
@@ -55,6 +64,10 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// Originals.
 		{"originals-path", c.OriginalsPath()},
 		{"originals-limit", fmt.Sprintf("%d", c.OriginalsLimit())},
+		{"originals-layout", c.OriginalsLayout()},
+		{"originals-root", c.OriginalsRootsString()},
+		{"originals-write-once", fmt.Sprintf("%t", c.OriginalsWriteOnce())},
+		{"min-free-space", fmt.Sprintf("%d", c.MinFreeSpace())},
 		{"resolution-limit", fmt.Sprintf("%d", c.ResolutionLimit())},
 		{"users-path", c.UsersPath()},
 		{"users-originals-path", c.UsersOriginalsPath()},
@@ -77,23 +90,57 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"img-path", c.ImgPath()},
 		{"templates-path", c.TemplatesPath()},
 		{"temp-path", c.TempPath()},
+		{"s3-endpoint", c.S3Endpoint()},
+		{"s3-region", c.S3Region()},
+		{"s3-bucket", c.S3Bucket()},
+		{"s3-prefix", c.S3Prefix()},
+		{"s3-access-key", c.S3AccessKey()},
+		{"s3-secret-key", strings.Repeat("*", utf8.RuneCountInString(c.S3SecretKey()))},
+		{"s3-insecure", fmt.Sprintf("%t", c.S3Insecure())},
+		{"s3-cache-limit", fmt.Sprintf("%d", c.S3CacheLimit())},
+		{"cold-path", c.ColdPath()},
+		{"cold-bucket", c.ColdBucket()},
+		{"cold-storage-class", c.ColdStorageClass()},
+		{"cold-restore-days", fmt.Sprintf("%d", c.ColdRestoreDays())},
+		{"backup-bucket", c.BackupBucket()},
+		{"backup-webdav", c.BackupWebDAVRedacted()},
+		{"backup-passphrase", strings.Repeat("*", utf8.RuneCountInString(c.BackupPassphrase()))},
+		{"backup-retain", fmt.Sprintf("%d", c.BackupRetain())},
+		{"backup-originals", fmt.Sprintf("%t", c.BackupOriginals())},
 
 		// Workers.
 		{"workers", fmt.Sprintf("%d", c.Workers())},
+		{"workers-memory", fmt.Sprintf("%d", c.WorkersMemory())},
 		{"wakeup-interval", c.WakeupInterval().String()},
 		{"auto-index", fmt.Sprintf("%d", c.AutoIndex()/time.Second)},
 		{"auto-import", fmt.Sprintf("%d", c.AutoImport()/time.Second)},
+		{"index-schedule", c.IndexSchedule()},
+		{"backup-schedule", c.BackupSchedule()},
+		{"cleanup-schedule", c.CleanupSchedule()},
+		{"sync-schedule", c.SyncSchedule()},
+		{"faces-schedule", c.FacesSchedule()},
+		{"trash-days", fmt.Sprintf("%d", c.TrashDays())},
+		{"trash-schedule", c.TrashSchedule()},
+		{"maintenance-window", c.MaintenanceWindow()},
+		{"max-load", fmt.Sprintf("%.2f", c.MaxLoad())},
+		{"max-requests", fmt.Sprintf("%d", c.MaxRequests())},
+		{"offload-jobs", fmt.Sprintf("%t", c.OffloadJobs())},
+		{"node-name", c.NodeName()},
+		{"album-templates", strings.Join(c.AlbumTemplates(), ",")},
 
 		// Feature Flags.
 		{"read-only", fmt.Sprintf("%t", c.ReadOnly())},
 		{"experimental", fmt.Sprintf("%t", c.Experimental())},
 		{"disable-webdav", fmt.Sprintf("%t", c.DisableWebDAV())},
+		{"disable-passkeys", fmt.Sprintf("%t", c.DisablePasskeys())},
 		{"disable-settings", fmt.Sprintf("%t", c.DisableSettings())},
 		{"disable-places", fmt.Sprintf("%t", c.DisablePlaces())},
 		{"disable-backups", fmt.Sprintf("%t", c.DisableBackups())},
 		{"disable-tensorflow", fmt.Sprintf("%t", c.DisableTensorFlow())},
 		{"disable-faces", fmt.Sprintf("%t", c.DisableFaces())},
+		{"disable-pets", fmt.Sprintf("%t", c.DisablePets())},
 		{"disable-classification", fmt.Sprintf("%t", c.DisableClassification())},
+		{"disable-ocr", fmt.Sprintf("%t", c.DisableOCR())},
 		{"disable-sips", fmt.Sprintf("%t", c.DisableSips())},
 		{"disable-ffmpeg", fmt.Sprintf("%t", c.DisableFFmpeg())},
 		{"disable-exiftool", fmt.Sprintf("%t", c.DisableExifTool())},
@@ -113,6 +160,23 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// TensorFlow.
 		{"detect-nsfw", fmt.Sprintf("%t", c.DetectNSFW())},
 		{"upload-nsfw", fmt.Sprintf("%t", c.UploadNSFW())},
+		{"nsfw-threshold", fmt.Sprintf("%f", c.NSFWThreshold())},
+		{"nsfw-policy", c.NSFWPolicy()},
+		{"privacy-zones", c.PrivacyZonesString()},
+		{"privacy-fence", c.PrivacyFence()},
+		{"dem-path", c.DEMPath()},
+		{"map-tiles", c.MapTiles()},
+		{"map-tiles-url", c.MapTilesUrl()},
+		{"classify-backend", c.ClassifyBackend()},
+		{"classify-url", c.ClassifyUrl()},
+		{"landmarks-url", c.LandmarksUrl()},
+		{"caption-url", c.CaptionUrl()},
+		{"label-confidence", fmt.Sprintf("%f", c.LabelConfidence())},
+		{"label-rules", c.LabelRulesYaml()},
+		{"semantic-url", c.SemanticUrl()},
+		{"semantic-model", c.SemanticModel()},
+		{"ocr-languages", c.OcrLanguages()},
+		{"quality-model", c.QualityModel()},
 		{"tensorflow-version", c.TensorFlowVersion()},
 		{"tensorflow-model-path", c.TensorFlowModelPath()},
 
@@ -138,6 +202,13 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"site-caption", c.SiteCaption()},
 		{"site-description", c.SiteDescription()},
 		{"site-preview", c.SitePreview()},
+		{"smtp-host", c.SmtpHost()},
+		{"smtp-port", fmt.Sprintf("%d", c.SmtpPort())},
+		{"smtp-user", c.SmtpUser()},
+		{"smtp-password", strings.Repeat("*", utf8.RuneCountInString(c.SmtpPassword()))},
+		{"smtp-from", c.SmtpFrom()},
+		{"smtp-security", c.SmtpSecurity()},
+		{"alert-email", strings.Join(c.AlertEmail(), ", ")},
 
 		// URIs.
 		{"base-uri", c.BaseUri("/")},
@@ -164,6 +235,14 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"http-cache-public", fmt.Sprintf("%t", c.HttpCachePublic())},
 		{"http-host", c.HttpHost()},
 		{"http-port", fmt.Sprintf("%d", c.HttpPort())},
+		{"rate-limit", fmt.Sprintf("%f", c.RateLimit())},
+		{"rate-burst", fmt.Sprintf("%d", c.RateBurst())},
+		{"rate-limit-search", fmt.Sprintf("%f", c.RateLimitSearch())},
+		{"rate-burst-search", fmt.Sprintf("%d", c.RateBurstSearch())},
+		{"rate-limit-thumbs", fmt.Sprintf("%f", c.RateLimitThumbs())},
+		{"rate-burst-thumbs", fmt.Sprintf("%d", c.RateBurstThumbs())},
+		{"rate-limit-auth", fmt.Sprintf("%f", c.RateLimitAuth())},
+		{"rate-burst-auth", fmt.Sprintf("%d", c.RateBurstAuth())},
 
 		// Database.
 		{"database-driver", c.DatabaseDriver()},
@@ -173,8 +252,10 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"database-port", c.DatabasePortString()},
 		{"database-user", c.DatabaseUser()},
 		{"database-password", strings.Repeat("*", utf8.RuneCountInString(c.DatabasePassword()))},
+		{"database-replica", c.DatabaseReplicaServer()},
 		{"database-conns", fmt.Sprintf("%d", c.DatabaseConns())},
 		{"database-conns-idle", fmt.Sprintf("%d", c.DatabaseConnsIdle())},
+		{"redis-url", c.RedisUrlRedacted()},
 
 		// File Converters.
 		{"sips-bin", c.SipsBin()},
@@ -194,6 +275,10 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"imagemagick-bin", c.ImageMagickBin()},
 		{"imagemagick-blacklist", c.ImageMagickBlacklist()},
 		{"heifconvert-bin", c.HeifConvertBin()},
+		{"tesseract-bin", c.TesseractBin()},
+		{"smbclient-bin", c.SmbClientBin()},
+		{"gphotos-client-id", c.GPhotosClientID()},
+		{"gphotos-client-secret", strings.Repeat("*", utf8.RuneCountInString(c.GPhotosClientSecret()))},
 		{"rsvgconvert-bin", c.RsvgConvertBin()},
 		{"jpegxldecoder-bin", c.JpegXLDecoderBin()},
 
@@ -205,9 +290,15 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"thumb-size", fmt.Sprintf("%d", c.ThumbSizePrecached())},
 		{"thumb-size-uncached", fmt.Sprintf("%d", c.ThumbSizeUncached())},
 		{"thumb-uncached", fmt.Sprintf("%t", c.ThumbUncached())},
+		{"thumb-prefetch", fmt.Sprintf("%d", c.ThumbPrefetch())},
+		{"thumb-prefetch-workers", fmt.Sprintf("%d", c.ThumbPrefetchWorkers())},
 		{"jpeg-quality", fmt.Sprintf("%d", c.JpegQuality())},
 		{"jpeg-size", fmt.Sprintf("%d", c.JpegSize())},
 		{"png-size", fmt.Sprintf("%d", c.PngSize())},
+		{"watermark-text", c.WatermarkText()},
+		{"watermark-logo", c.WatermarkLogo()},
+		{"watermark-position", c.WatermarkPosition()},
+		{"watermark-opacity", fmt.Sprintf("%d", c.WatermarkOpacity())},
 
 		// Facial Recognition.
 		{"face-size", fmt.Sprintf("%d", c.FaceSize())},
@@ -218,6 +309,10 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"face-cluster-core", fmt.Sprintf("%d", c.FaceClusterCore())},
 		{"face-cluster-dist", fmt.Sprintf("%f", c.FaceClusterDist())},
 		{"face-match-dist", fmt.Sprintf("%f", c.FaceMatchDist())},
+		{"face-model", c.FaceModel()},
+		{"face-keyframes", fmt.Sprintf("%d", c.FaceKeyframes())},
+		{"face-age-bucket", fmt.Sprintf("%d", c.FaceAgeBucket())},
+		{"face-age-tolerance", fmt.Sprintf("%f", c.FaceAgeTolerance())},
 
 		// Daemon Mode.
 		{"pid-filename", c.PIDFilename()},
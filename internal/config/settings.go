@@ -55,6 +55,10 @@ func (c *Config) Settings() *customize.Settings {
 		c.settings.Features.Import = false
 	}
 
+	if c.OriginalsWriteOnce() {
+		c.settings.Features.Delete = false
+	}
+
 	return c.settings
 }
 
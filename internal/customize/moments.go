@@ -0,0 +1,11 @@
+package customize
+
+// MomentsSettings represents settings for generating moments and calendar albums.
+type MomentsSettings struct {
+	Threshold int  `json:"threshold" yaml:"Threshold"`
+	Calendar  bool `json:"calendar" yaml:"Calendar"`
+	Countries bool `json:"countries" yaml:"Countries"`
+	States    bool `json:"states" yaml:"States"`
+	Labels    bool `json:"labels" yaml:"Labels"`
+	Trips     bool `json:"trips" yaml:"Trips"`
+}
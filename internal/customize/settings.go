@@ -23,6 +23,7 @@ type Settings struct {
 	Features  FeatureSettings  `json:"features" yaml:"Features"`
 	Import    ImportSettings   `json:"import" yaml:"Import"`
 	Index     IndexSettings    `json:"index" yaml:"Index"`
+	Moments   MomentsSettings  `json:"moments" yaml:"Moments"`
 	Stack     StackSettings    `json:"stack" yaml:"Stack"`
 	Share     ShareSettings    `json:"share" yaml:"Share"`
 	Download  DownloadSettings `json:"download" yaml:"Download"`
@@ -87,6 +88,15 @@ func NewSettings(theme, lang string) *Settings {
 			Path:    RootPath,
 			Rescan:  false,
 			Convert: true,
+			OCR:     false,
+		},
+		Moments: MomentsSettings{
+			Threshold: 0,
+			Calendar:  true,
+			Countries: true,
+			States:    true,
+			Labels:    true,
+			Trips:     true,
 		},
 		Stack: StackSettings{
 			UUID: true,
@@ -13,6 +13,12 @@ func TestNewDefaultSettings(t *testing.T) {
 	assert.IsType(t, new(Settings), s)
 	assert.Equal(t, DefaultTheme, s.UI.Theme)
 	assert.Equal(t, DefaultLocale, s.UI.Language)
+	assert.Equal(t, 0, s.Moments.Threshold)
+	assert.True(t, s.Moments.Calendar)
+	assert.True(t, s.Moments.Countries)
+	assert.True(t, s.Moments.States)
+	assert.True(t, s.Moments.Labels)
+	assert.True(t, s.Moments.Trips)
 }
 
 func TestNewSettings(t *testing.T) {
@@ -0,0 +1,81 @@
+package dem
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MaxTiles is the number of height files that are kept in memory.
+var MaxTiles = 16
+
+// Dataset represents a directory with SRTM height files, e.g. downloaded for the regions in which
+// pictures were taken. Tiles are loaded on demand, so the dataset may be incomplete.
+type Dataset struct {
+	path  string
+	mutex sync.Mutex
+	tiles map[string]*Tile
+	order []string
+}
+
+// NewDataset returns a new dataset for the specified directory.
+func NewDataset(path string) *Dataset {
+	return &Dataset{
+		path:  path,
+		tiles: make(map[string]*Tile, MaxTiles),
+	}
+}
+
+// Path returns the dataset directory.
+func (d *Dataset) Path() string {
+	return d.path
+}
+
+// Exists tests if the dataset directory exists.
+func (d *Dataset) Exists() bool {
+	return d.path != "" && fs.PathExists(d.path)
+}
+
+// Elevation returns the elevation in meters at the coordinates, or false if there is no data.
+func (d *Dataset) Elevation(lat, lng float64) (float64, bool) {
+	if lat == 0 && lng == 0 || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return 0, false
+	}
+
+	t := d.tile(TileName(lat, lng))
+
+	return t.Elevation(lat, lng)
+}
+
+// tile returns the cached height file with the specified name, or nil if it does not exist.
+func (d *Dataset) tile(name string) *Tile {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if t, ok := d.tiles[name]; ok {
+		return t
+	}
+
+	var t *Tile
+	var err error
+
+	// Tiles are often missing, e.g. above the ocean, so that this is not an error.
+	if fileName := filepath.Join(d.path, name); fs.FileExists(fileName) {
+		if t, err = ReadTile(fileName); err != nil {
+			log.Warnf("dem: %s in %s", err, clean.Log(name))
+		}
+	}
+
+	// Remove the oldest tile if the cache is full.
+	if len(d.order) >= MaxTiles && len(d.order) > 0 {
+		delete(d.tiles, d.order[0])
+		d.order = d.order[1:]
+	}
+
+	d.tiles[name] = t
+	d.order = append(d.order, name)
+
+	return t
+}
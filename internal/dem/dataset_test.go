@@ -0,0 +1,52 @@
+package dem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataset_Elevation(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTile(t, dir, "N47E011.hgt", []int16{2000, 2400, 0, 400})
+
+	d := NewDataset(dir)
+
+	assert.True(t, d.Exists())
+	assert.Equal(t, dir, d.Path())
+
+	t.Run("Found", func(t *testing.T) {
+		alt, ok := d.Elevation(47.5, 11.5)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 1200.0, alt, 0.001)
+	})
+	t.Run("MissingTile", func(t *testing.T) {
+		_, ok := d.Elevation(52.5, 13.4)
+		assert.False(t, ok)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, ok := d.Elevation(0, 0)
+		assert.False(t, ok)
+	})
+	t.Run("Cache", func(t *testing.T) {
+		maxTiles := MaxTiles
+		MaxTiles = 2
+		defer func() { MaxTiles = maxTiles }()
+
+		d := NewDataset(dir)
+
+		d.Elevation(47.5, 11.5)
+		d.Elevation(52.5, 13.4)
+		d.Elevation(-33.9, 18.4)
+
+		assert.Len(t, d.tiles, 2)
+		assert.Equal(t, []string{"N52E013.hgt", "S34E018.hgt"}, d.order)
+	})
+}
+
+func TestDataset_Exists(t *testing.T) {
+	assert.False(t, NewDataset("").Exists())
+	assert.False(t, NewDataset("/does/not/exist").Exists())
+}
@@ -0,0 +1,155 @@
+package dem
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Void is the value of samples without elevation data.
+const Void = -32768
+
+// Tile represents an SRTM height file that covers one degree of latitude and longitude,
+// with rows of big-endian 16-bit samples from north to south.
+type Tile struct {
+	Lat     int
+	Lng     int
+	Size    int
+	Samples []int16
+}
+
+// TileName returns the height file name for the tile that contains the coordinates, e.g. "N47E011.hgt".
+func TileName(lat, lng float64) string {
+	latDeg, lngDeg := int(math.Floor(lat)), int(math.Floor(lng))
+
+	ns, ew := "N", "E"
+
+	if latDeg < 0 {
+		ns, latDeg = "S", -latDeg
+	}
+
+	if lngDeg < 0 {
+		ew, lngDeg = "W", -lngDeg
+	}
+
+	return fmt.Sprintf("%s%02d%s%03d.hgt", ns, latDeg, ew, lngDeg)
+}
+
+// ParseTileName returns the latitude and longitude of the south-west corner of a tile based on its file name.
+func ParseTileName(fileName string) (lat, lng int, err error) {
+	name := strings.ToUpper(strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName)))
+
+	var ns, ew byte
+
+	if len(name) != 7 {
+		return 0, 0, fmt.Errorf("invalid tile name %s", name)
+	} else if _, err = fmt.Sscanf(name, "%c%02d%c%03d", &ns, &lat, &ew, &lng); err != nil {
+		return 0, 0, fmt.Errorf("invalid tile name %s", name)
+	}
+
+	switch ns {
+	case 'N':
+	case 'S':
+		lat = -lat
+	default:
+		return 0, 0, fmt.Errorf("invalid tile name %s", name)
+	}
+
+	switch ew {
+	case 'E':
+	case 'W':
+		lng = -lng
+	default:
+		return 0, 0, fmt.Errorf("invalid tile name %s", name)
+	}
+
+	if lat < -90 || lat >= 90 || lng < -180 || lng >= 180 {
+		return 0, 0, fmt.Errorf("invalid tile name %s", name)
+	}
+
+	return lat, lng, nil
+}
+
+// ReadTile reads an SRTM height file with 1 or 3 arc-second resolution, i.e. 3601x3601 or 1201x1201 samples.
+func ReadTile(fileName string) (*Tile, error) {
+	lat, lng, err := ParseTileName(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	size := int(math.Sqrt(float64(len(data) / 2)))
+
+	if size < 2 || size*size*2 != len(data) {
+		return nil, fmt.Errorf("invalid file size %d", len(data))
+	}
+
+	t := &Tile{Lat: lat, Lng: lng, Size: size, Samples: make([]int16, size*size)}
+
+	for i := range t.Samples {
+		t.Samples[i] = int16(binary.BigEndian.Uint16(data[i*2:]))
+	}
+
+	return t, nil
+}
+
+// Elevation returns the interpolated elevation in meters at the coordinates,
+// or false if they are outside the tile or there is no data.
+func (t *Tile) Elevation(lat, lng float64) (float64, bool) {
+	if t == nil || t.Size < 2 {
+		return 0, false
+	}
+
+	n := float64(t.Size - 1)
+	y := (float64(t.Lat+1) - lat) * n
+	x := (lng - float64(t.Lng)) * n
+
+	if y < 0 || y > n || x < 0 || x > n {
+		return 0, false
+	}
+
+	row, col := int(y), int(x)
+
+	if row >= t.Size-1 {
+		row = t.Size - 2
+	}
+
+	if col >= t.Size-1 {
+		col = t.Size - 2
+	}
+
+	dy, dx := y-float64(row), x-float64(col)
+
+	// Bilinear interpolation of the surrounding samples, ignoring voids.
+	var sum, weight float64
+
+	for _, s := range []struct {
+		r, c int
+		w    float64
+	}{
+		{row, col, (1 - dy) * (1 - dx)},
+		{row, col + 1, (1 - dy) * dx},
+		{row + 1, col, dy * (1 - dx)},
+		{row + 1, col + 1, dy * dx},
+	} {
+		if v := t.Samples[s.r*t.Size+s.c]; v != Void && s.w > 0 {
+			sum += float64(v) * s.w
+			weight += s.w
+		}
+	}
+
+	if weight == 0 {
+		return 0, false
+	}
+
+	return sum / weight, true
+}
@@ -0,0 +1,131 @@
+package dem
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTile creates a height file with the specified samples, from north to south.
+func writeTile(t *testing.T, dir, name string, samples []int16) string {
+	data := make([]byte, len(samples)*2)
+
+	for i, v := range samples {
+		binary.BigEndian.PutUint16(data[i*2:], uint16(v))
+	}
+
+	fileName := filepath.Join(dir, name)
+
+	if err := os.WriteFile(fileName, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestTileName(t *testing.T) {
+	assert.Equal(t, "N47E011.hgt", TileName(47.4, 11.1))
+	assert.Equal(t, "N00E000.hgt", TileName(0.5, 0.5))
+	assert.Equal(t, "S34E018.hgt", TileName(-33.9, 18.4))
+	assert.Equal(t, "N34W119.hgt", TileName(34.05, -118.25))
+	assert.Equal(t, "S01W001.hgt", TileName(-0.5, -0.5))
+}
+
+func TestParseTileName(t *testing.T) {
+	t.Run("North East", func(t *testing.T) {
+		lat, lng, err := ParseTileName("/data/dem/N47E011.hgt")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 47, lat)
+		assert.Equal(t, 11, lng)
+	})
+	t.Run("South West", func(t *testing.T) {
+		lat, lng, err := ParseTileName("s34w119.HGT")
+
+		assert.NoError(t, err)
+		assert.Equal(t, -34, lat)
+		assert.Equal(t, -119, lng)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, err := ParseTileName("X47E011.hgt")
+		assert.Error(t, err)
+
+		_, _, err = ParseTileName("N47E11.hgt")
+		assert.Error(t, err)
+
+		_, _, err = ParseTileName("N95E011.hgt")
+		assert.Error(t, err)
+	})
+}
+
+func TestReadTile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("Success", func(t *testing.T) {
+		tile, err := ReadTile(writeTile(t, dir, "N47E011.hgt", []int16{100, 200, 300, 400}))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 47, tile.Lat)
+		assert.Equal(t, 11, tile.Lng)
+		assert.Equal(t, 2, tile.Size)
+		assert.Equal(t, []int16{100, 200, 300, 400}, tile.Samples)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		_, err := ReadTile(writeTile(t, dir, "N48E011.hgt", []int16{100, 200, 300}))
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadTile(filepath.Join(dir, "N49E011.hgt"))
+		assert.Error(t, err)
+	})
+}
+
+func TestTile_Elevation(t *testing.T) {
+	// 3x3 samples with a spacing of half a degree, from north to south.
+	tile := &Tile{Lat: 47, Lng: 11, Size: 3, Samples: []int16{
+		2000, 2200, 2400,
+		1000, 1200, Void,
+		0, 200, 400,
+	}}
+
+	t.Run("Corners", func(t *testing.T) {
+		alt, ok := tile.Elevation(48, 11)
+		assert.True(t, ok)
+		assert.Equal(t, 2000.0, alt)
+
+		alt, ok = tile.Elevation(47, 12)
+		assert.True(t, ok)
+		assert.Equal(t, 400.0, alt)
+	})
+	t.Run("Interpolated", func(t *testing.T) {
+		alt, ok := tile.Elevation(47.75, 11.25)
+		assert.True(t, ok)
+		assert.InDelta(t, 1600.0, alt, 0.001)
+	})
+	t.Run("Void", func(t *testing.T) {
+		alt, ok := tile.Elevation(47.5, 12)
+		assert.False(t, ok)
+		assert.Equal(t, 0.0, alt)
+
+		// Voids are ignored when interpolating.
+		alt, ok = tile.Elevation(47.5, 11.75)
+		assert.True(t, ok)
+		assert.InDelta(t, 1200.0, alt, 0.001)
+	})
+	t.Run("Outside", func(t *testing.T) {
+		_, ok := tile.Elevation(46.5, 11.5)
+		assert.False(t, ok)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var empty *Tile
+
+		_, ok := empty.Elevation(47.5, 11.5)
+		assert.False(t, ok)
+	})
+}
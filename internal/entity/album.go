@@ -34,7 +34,7 @@ type Albums []Album
 type Album struct {
 	ID               uint        `gorm:"primary_key" json:"ID" yaml:"-"`
 	AlbumUID         string      `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
-	ParentUID        string      `gorm:"type:VARBINARY(42);default:'';" json:"ParentUID,omitempty" yaml:"ParentUID,omitempty"`
+	ParentUID        string      `gorm:"type:VARBINARY(42);index;default:'';" json:"ParentUID,omitempty" yaml:"ParentUID,omitempty"`
 	AlbumSlug        string      `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug"`
 	AlbumPath        string      `gorm:"type:VARCHAR(1024);index;" json:"Path,omitempty" yaml:"Path,omitempty"`
 	AlbumType        string      `gorm:"type:VARBINARY(8);default:'album';" json:"Type" yaml:"Type,omitempty"`
@@ -54,6 +54,7 @@ type Album struct {
 	AlbumDay         int         `gorm:"index:idx_albums_ymd;" json:"Day" yaml:"Day,omitempty"`
 	AlbumFavorite    bool        `json:"Favorite" yaml:"Favorite,omitempty"`
 	AlbumPrivate     bool        `json:"Private" yaml:"Private,omitempty"`
+	AlbumLocked      bool        `json:"Locked" yaml:"Locked,omitempty"`
 	Thumb            string      `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
 	ThumbSrc         string      `gorm:"type:VARBINARY(8);default:'';" json:"ThumbSrc,omitempty" yaml:"ThumbSrc,omitempty"`
 	CreatedBy        string      `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
@@ -120,9 +121,9 @@ func AddPhotoToUserAlbums(photoUid string, albums []string, userUid string) (err
 		}
 
 		if albumUid != "" {
-			entry := PhotoAlbum{AlbumUID: albumUid, PhotoUID: photoUid, Hidden: false}
+			entry := PhotoAlbum{AlbumUID: albumUid, PhotoUID: photoUid, Order: nextPhotoOrder(albumUid), Hidden: false, CreatedBy: userUid}
 
-			if err = entry.Save(); err != nil {
+			if err = entry.Add(); err != nil {
 				log.Errorf("album: %s (add photo %s to albums)", err.Error(), photoUid)
 			}
 		}
@@ -352,7 +353,7 @@ func FindAlbum(find Album) *Album {
 		if UnscopedDb().First(&m, "album_uid = ?", find.AlbumUID).Error != nil {
 			return nil
 		} else if m.AlbumUID != "" {
-			albumCache.SetDefault(m.AlbumUID, m)
+			CacheAlbum(m)
 			return &m
 		}
 	}
@@ -373,7 +374,7 @@ func FindAlbum(find Album) *Album {
 			stmt = stmt.Where("album_slug = ?", find.AlbumSlug)
 		}
 	} else {
-		stmt = stmt.Where("album_slug = ? OR album_title LIKE ?", find.AlbumSlug, find.AlbumTitle)
+		stmt = stmt.Where(fmt.Sprintf("album_slug = ? OR album_title %s ?", LikeOp()), find.AlbumSlug, find.AlbumTitle)
 	}
 
 	// Filter by creator if the album has not been published yet.
@@ -388,7 +389,7 @@ func FindAlbum(find Album) *Album {
 
 	// Cache result.
 	if m.AlbumUID != "" {
-		albumCache.SetDefault(m.AlbumUID, m)
+		CacheAlbum(m)
 	}
 
 	return &m
@@ -447,6 +448,11 @@ func (m *Album) IsDefault() bool {
 	return m.AlbumType == AlbumManual
 }
 
+// IsSmart checks if the album is a manually created album whose pictures are defined by a search filter.
+func (m *Album) IsSmart() bool {
+	return m.AlbumType == AlbumManual && m.AlbumFilter != ""
+}
+
 // SetTitle changes the album name.
 func (m *Album) SetTitle(title string) *Album {
 	title = strings.Trim(title, "_&|{}<>: \n\r\t\\")
@@ -711,6 +717,8 @@ func (m *Album) DeletePermanently() error {
 
 	if err := UnscopedDb().Delete(m).Error; err != nil {
 		return err
+	} else if err = m.MoveChildrenUp(); err != nil {
+		log.Errorf("album: %s (move sub-albums)", err)
 	}
 
 	if !wasDeleted {
@@ -718,6 +726,10 @@ func (m *Album) DeletePermanently() error {
 		event.EntitiesDeleted("albums", []string{m.AlbumUID})
 	}
 
+	if err := DeleteAlbumComments(m.AlbumUID); err != nil {
+		log.Errorf("album: %s (delete comments)", err)
+	}
+
 	return DeleteShareLinks(m.AlbumUID)
 }
 
@@ -770,21 +782,30 @@ func (m *Album) ZipName() string {
 
 // AddPhotos adds photos to an existing album.
 func (m *Album) AddPhotos(UIDs []string) (added PhotoAlbums) {
+	return m.AddPhotosBy(UIDs, OwnerUnknown)
+}
+
+// AddPhotosBy adds photos to an existing album and records the user who added them.
+func (m *Album) AddPhotosBy(UIDs []string, userUid string) (added PhotoAlbums) {
 	if !m.HasID() {
 		return added
 	}
 
+	// Append added pictures to the end so that a custom order is preserved.
+	order := m.NextPhotoOrder()
+
 	for _, uid := range UIDs {
 		if !rnd.IsUID(uid, PhotoUID) {
 			continue
 		}
 
-		entry := PhotoAlbum{AlbumUID: m.AlbumUID, PhotoUID: uid, Hidden: false}
+		entry := PhotoAlbum{AlbumUID: m.AlbumUID, PhotoUID: uid, Order: order, Hidden: false, CreatedBy: userUid}
 
-		if err := entry.Save(); err != nil {
+		if err := entry.Add(); err != nil {
 			log.Errorf("album: %s (add to album %s)", err.Error(), m)
 		} else {
 			added = append(added, entry)
+			order++
 		}
 	}
 
@@ -10,11 +10,30 @@ import (
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
-var albumCache = gc.New(15*time.Minute, 15*time.Minute)
+var albumCacheExpiration = 15 * time.Minute
+var albumCache = gc.New(albumCacheExpiration, 15*time.Minute)
+
+// albumCachePrefix is the key prefix of albums in the shared cache.
+const albumCachePrefix = "album:"
 
 // FlushAlbumCache resets the album cache.
 func FlushAlbumCache() {
 	albumCache.Flush()
+
+	if c := shared(); c != nil {
+		flushShared(c, albumCachePrefix)
+	}
+}
+
+// CacheAlbum adds an album to the cache if its UID is not empty.
+func CacheAlbum(m Album) {
+	if m.AlbumUID == "" {
+		return
+	} else if c := shared(); c != nil {
+		setShared(c, albumCachePrefix+m.AlbumUID, m, albumCacheExpiration)
+	} else {
+		albumCache.SetDefault(m.AlbumUID, m)
+	}
 }
 
 // CachedAlbumByUID returns an existing album or nil if not found.
@@ -25,7 +44,12 @@ func CachedAlbumByUID(uid string) (m Album, err error) {
 	}
 
 	// Cached?
-	if cacheData, ok := albumCache.Get(uid); ok {
+	if c := shared(); c != nil {
+		if getShared(c, albumCachePrefix+uid, &m) {
+			log.Tracef("album: cache hit for %s", uid)
+			return m, nil
+		}
+	} else if cacheData, ok := albumCache.Get(uid); ok {
 		log.Tracef("album: cache hit for %s", uid)
 		return cacheData.(Album), nil
 	}
@@ -38,7 +62,7 @@ func CachedAlbumByUID(uid string) (m Album, err error) {
 	} else if r.Error != nil {
 		return m, r.Error
 	} else {
-		albumCache.SetDefault(m.AlbumUID, m)
+		CacheAlbum(m)
 		return m, nil
 	}
 }
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// IsOwner checks if the album was created by the specified user.
+func (m *Album) IsOwner(userUid string) bool {
+	return userUid != "" && m.CreatedBy == userUid
+}
+
+// PhotosAddedBy returns the UIDs of the pictures the specified user has added to the album.
+func (m *Album) PhotosAddedBy(userUid string) (photoUIDs []string) {
+	if !m.HasID() || rnd.InvalidUID(userUid, UserUID) {
+		return photoUIDs
+	}
+
+	if err := UnscopedDb().Model(PhotoAlbum{}).
+		Where("album_uid = ? AND created_by = ? AND hidden = 0", m.AlbumUID, userUid).
+		Pluck("photo_uid", &photoUIDs).Error; err != nil {
+		log.Errorf("album: %s (find pictures added by %s)", err, userUid)
+	}
+
+	return photoUIDs
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbum_IsOwner(t *testing.T) {
+	album := Album{CreatedBy: "uqxetse3cy5eo9z2"}
+
+	assert.True(t, album.IsOwner("uqxetse3cy5eo9z2"))
+	assert.False(t, album.IsOwner("uqxc08w3d0ej2283"))
+	assert.False(t, (&Album{}).IsOwner(""))
+}
+
+func TestAlbum_PhotosAddedBy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := NewAlbum("Contributors", AlbumManual)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		added := album.AddPhotosBy([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, "uqxc08w3d0ej2283")
+		album.AddPhotos([]string{"pt9jtdre2lvl0y11"})
+
+		assert.Len(t, added, 2)
+		assert.Equal(t, "uqxc08w3d0ej2283", added[0].CreatedBy)
+		assert.ElementsMatch(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, album.PhotosAddedBy("uqxc08w3d0ej2283"))
+		assert.Empty(t, album.PhotosAddedBy("uqxetse3cy5eo9z2"))
+
+		if err := album.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("InvalidUser", func(t *testing.T) {
+		album := AlbumFixtures.Get("holiday-2030")
+		assert.Empty(t, album.PhotosAddedBy("invalid"))
+	})
+}
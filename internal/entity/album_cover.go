@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/crop"
+)
+
+// HasCustomCover checks if the album cover has been set manually.
+func (m *Album) HasCustomCover() bool {
+	return m.ThumbSrc == SrcManual && m.Thumb != ""
+}
+
+// SetCover sets the specified file as album cover, optionally cropped to the specified area.
+func (m *Album) SetCover(file *File, area crop.Area) error {
+	if !m.HasID() {
+		return fmt.Errorf("album does not exist")
+	} else if file == nil || file.FileHash == "" {
+		return fmt.Errorf("file has no hash")
+	}
+
+	if area.W > 0 && area.H > 0 {
+		m.Thumb = area.Thumb(file.FileHash)
+	} else {
+		m.Thumb = file.FileHash
+	}
+
+	m.ThumbSrc = SrcManual
+
+	return m.Updates(Values{"Thumb": m.Thumb, "ThumbSrc": m.ThumbSrc})
+}
+
+// ResetCover removes a manually set album cover so that it is selected automatically again.
+func (m *Album) ResetCover() error {
+	if !m.HasID() {
+		return fmt.Errorf("album does not exist")
+	}
+
+	m.ThumbSrc = SrcAuto
+
+	return m.Updates(Values{"ThumbSrc": m.ThumbSrc})
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/crop"
+)
+
+func TestAlbum_SetCover(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := NewAlbum("Custom Cover", AlbumManual)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := FileFixtures.Pointer("exampleFileName.jpg")
+
+		assert.False(t, album.HasCustomCover())
+
+		if err := album.SetCover(file, crop.Area{}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, album.HasCustomCover())
+		assert.Equal(t, file.FileHash, album.Thumb)
+		assert.Equal(t, SrcManual, album.ThumbSrc)
+
+		if err := album.SetCover(file, crop.NewArea("cover", 0.25, 0.25, 0.5, 0.5)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, strings.HasPrefix(album.Thumb, file.FileHash+"-"))
+		assert.Equal(t, 40, crop.IsCroppedThumb(album.Thumb))
+
+		if err := album.ResetCover(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, album.HasCustomCover())
+		assert.Equal(t, SrcAuto, album.ThumbSrc)
+
+		if err := album.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NoHash", func(t *testing.T) {
+		album := AlbumFixtures.Get("christmas2030")
+		assert.Error(t, album.SetCover(&File{}, crop.Area{}))
+		assert.Error(t, album.SetCover(nil, crop.Area{}))
+	})
+	t.Run("NotSaved", func(t *testing.T) {
+		album := Album{}
+		assert.Error(t, album.SetCover(FileFixtures.Pointer("exampleFileName.jpg"), crop.Area{}))
+		assert.Error(t, album.ResetCover())
+	})
+}
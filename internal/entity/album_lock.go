@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// AlbumPINLength specifies the minimum length of the PIN or password required to unlock an album.
+var AlbumPINLength = 4
+
+// Locked checks if the album contents are hidden until it is unlocked.
+func (m *Album) Locked() bool {
+	return m.AlbumLocked
+}
+
+// Lock protects the album with a PIN or password so that its contents are hidden until it is unlocked.
+func (m *Album) Lock(pin string) error {
+	pin = clean.Password(pin)
+
+	if !m.HasID() {
+		return fmt.Errorf("album does not exist")
+	} else if !m.IsDefault() {
+		return fmt.Errorf("only manually created albums can be locked")
+	} else if m.AlbumLocked {
+		return fmt.Errorf("album is already locked")
+	} else if len(pin) < AlbumPINLength {
+		return fmt.Errorf("pin must have at least %d characters", AlbumPINLength)
+	}
+
+	pw := NewPassword(m.AlbumUID, pin, false)
+
+	if pw.IsEmpty() {
+		return fmt.Errorf("invalid pin")
+	} else if err := pw.Save(); err != nil {
+		return err
+	} else if err = m.Update("AlbumLocked", true); err != nil {
+		return err
+	}
+
+	m.AlbumLocked = true
+	FlushAlbumCache()
+
+	return nil
+}
+
+// InvalidPIN checks if the PIN or password provided to unlock the album is invalid.
+func (m *Album) InvalidPIN(pin string) bool {
+	if !m.AlbumLocked {
+		return false
+	}
+
+	pw := FindPassword(m.AlbumUID)
+
+	if pw == nil {
+		return true
+	}
+
+	return pw.IsWrong(clean.Password(pin))
+}
+
+// RemoveLock permanently removes the lock if the PIN or password is valid.
+func (m *Album) RemoveLock(pin string) error {
+	if !m.AlbumLocked {
+		return nil
+	} else if m.InvalidPIN(pin) {
+		return fmt.Errorf("invalid pin")
+	} else if err := m.Update("AlbumLocked", false); err != nil {
+		return err
+	} else if err = UnscopedDb().Delete(Password{}, "uid = ?", m.AlbumUID).Error; err != nil {
+		return err
+	}
+
+	m.AlbumLocked = false
+	FlushAlbumCache()
+
+	return nil
+}
+
+// LockedAlbumUIDs returns the UIDs of the locked albums owned by the specified user.
+func LockedAlbumUIDs(userUid string) (uids UIDs) {
+	if userUid == "" {
+		return uids
+	}
+
+	if err := UnscopedDb().Model(&Album{}).
+		Where("album_locked = ? AND created_by = ?", true, userUid).
+		Pluck("album_uid", &uids).Error; err != nil {
+		log.Errorf("album: %s (find locked)", err)
+	}
+
+	return uids
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbum_Lock(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewUserAlbum("Locked Album", AlbumManual, UserFixtures.Pointer("alice").UserUID)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.Locked())
+		assert.False(t, m.InvalidPIN(""))
+		assert.Error(t, m.Lock("123"))
+
+		if err := m.Lock("1234"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Locked())
+		assert.Error(t, m.Lock("1234"))
+		assert.True(t, m.InvalidPIN("4321"))
+		assert.False(t, m.InvalidPIN("1234"))
+		assert.Contains(t, LockedAlbumUIDs(m.CreatedBy), m.AlbumUID)
+		assert.NotContains(t, LockedAlbumUIDs(UserFixtures.Pointer("bob").UserUID), m.AlbumUID)
+		assert.Empty(t, LockedAlbumUIDs(""))
+
+		assert.Error(t, m.RemoveLock("4321"))
+
+		if err := m.RemoveLock("1234"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.Locked())
+		assert.NotContains(t, LockedAlbumUIDs(m.CreatedBy), m.AlbumUID)
+		assert.Nil(t, FindPassword(m.AlbumUID))
+
+		assert.NoError(t, m.DeletePermanently())
+	})
+	t.Run("Folder", func(t *testing.T) {
+		m := AlbumFixtures.Get("april-1990")
+		assert.Error(t, m.Lock("1234"))
+	})
+	t.Run("NotSaved", func(t *testing.T) {
+		m := NewAlbum("Unsaved Album", AlbumManual)
+		assert.Error(t, m.Lock("1234"))
+	})
+}
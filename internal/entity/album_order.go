@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+// NextPhotoOrder returns the position of a picture appended to the album.
+func (m *Album) NextPhotoOrder() int {
+	if !m.HasID() {
+		return 1
+	}
+
+	return nextPhotoOrder(m.AlbumUID)
+}
+
+// nextPhotoOrder returns the position of a picture appended to the album with the specified UID.
+func nextPhotoOrder(albumUid string) int {
+	var result struct {
+		Max int
+	}
+
+	db := UnscopedDb()
+
+	if err := db.Model(PhotoAlbum{}).
+		Select(fmt.Sprintf("COALESCE(MAX(%s), 0) AS max", db.Dialect().Quote("order"))).
+		Where("album_uid = ?", albumUid).
+		Scan(&result).Error; err != nil {
+		log.Errorf("album: %s (find next position in %s)", err, clean.Log(albumUid))
+		return 1
+	}
+
+	return result.Max + 1
+}
+
+// SetPhotoOrder moves the specified pictures to the top of the album in the given order and switches the
+// album to custom sort order. Pictures that are not specified keep their relative position after them.
+func (m *Album) SetPhotoOrder(photoUIDs []string) (changed PhotoAlbums, err error) {
+	if !m.HasID() {
+		return changed, fmt.Errorf("album does not exist")
+	} else if !m.IsDefault() {
+		return changed, fmt.Errorf("only manually created albums can have a custom order")
+	}
+
+	var entries PhotoAlbums
+
+	db := UnscopedDb()
+
+	if err = db.Where("album_uid = ?", m.AlbumUID).
+		Order(fmt.Sprintf("%s, created_at, photo_uid", db.Dialect().Quote("order"))).
+		Find(&entries).Error; err != nil {
+		return changed, err
+	}
+
+	index := make(map[string]int, len(entries))
+
+	for i := range entries {
+		index[entries[i].PhotoUID] = i
+	}
+
+	// Sort the specified pictures first, followed by the remaining ones.
+	sorted := make(PhotoAlbums, 0, len(entries))
+	moved := make(map[string]bool, len(photoUIDs))
+
+	for _, uid := range photoUIDs {
+		if !rnd.IsUID(uid, PhotoUID) || moved[uid] {
+			continue
+		} else if i, ok := index[uid]; ok {
+			sorted = append(sorted, entries[i])
+			moved[uid] = true
+		}
+	}
+
+	for i := range entries {
+		if !moved[entries[i].PhotoUID] {
+			sorted = append(sorted, entries[i])
+		}
+	}
+
+	// Only update the entries whose position has changed.
+	for i := range sorted {
+		if sorted[i].Order == i+1 {
+			continue
+		}
+
+		sorted[i].Order = i + 1
+
+		if err = UnscopedDb().Model(PhotoAlbum{}).
+			Where("album_uid = ? AND photo_uid = ?", m.AlbumUID, sorted[i].PhotoUID).
+			UpdateColumn("order", sorted[i].Order).Error; err != nil {
+			return changed, err
+		}
+
+		changed = append(changed, sorted[i])
+	}
+
+	if m.AlbumOrder != sortby.Custom {
+		return changed, m.Update("AlbumOrder", sortby.Custom)
+	}
+
+	return changed, nil
+}
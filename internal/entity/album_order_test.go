@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+func TestAlbum_NextPhotoOrder(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		album := Album{}
+		assert.Equal(t, 1, album.NextPhotoOrder())
+	})
+	t.Run("Fixture", func(t *testing.T) {
+		album := AlbumFixtures.Get("holiday-2030")
+		assert.GreaterOrEqual(t, album.NextPhotoOrder(), 2)
+	})
+}
+
+func TestAlbum_SetPhotoOrder(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := NewAlbum("Custom Order", AlbumManual)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		added := album.AddPhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8", "pt9jtdre2lvl0y11"})
+
+		assert.Len(t, added, 3)
+		assert.Equal(t, 1, added[0].Order)
+		assert.Equal(t, 3, added[2].Order)
+
+		changed, err := album.SetPhotoOrder([]string{"pt9jtdre2lvl0y11", "invalid", "pt9jtdre2lvl0y11"})
+
+		assert.NoError(t, err)
+		assert.Len(t, changed, 3)
+		assert.Equal(t, sortby.Custom, album.AlbumOrder)
+
+		var entries PhotoAlbums
+
+		if err = Db().Where("album_uid = ?", album.AlbumUID).Order("`order`").Find(&entries).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, entries, 3)
+		assert.Equal(t, "pt9jtdre2lvl0y11", entries[0].PhotoUID)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", entries[1].PhotoUID)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", entries[2].PhotoUID)
+
+		// Nothing changes if the order stays the same.
+		changed, err = album.SetPhotoOrder([]string{"pt9jtdre2lvl0y11"})
+
+		assert.NoError(t, err)
+		assert.Len(t, changed, 0)
+
+		if err = album.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NotManual", func(t *testing.T) {
+		album := AlbumFixtures.Get("april-1990")
+		_, err := album.SetPhotoOrder([]string{"pt9jtdre2lvl0yh7"})
+		assert.Error(t, err)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		album := Album{}
+		_, err := album.SetPhotoOrder([]string{"pt9jtdre2lvl0yh7"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// AlbumMaxDepth specifies the maximum nesting depth of albums.
+var AlbumMaxDepth = 16
+
+// AlbumCrumb represents a parent album in the breadcrumb path of a nested album.
+type AlbumCrumb struct {
+	UID   string `json:"UID"`
+	Slug  string `json:"Slug"`
+	Title string `json:"Title"`
+}
+
+// AlbumCrumbs represents the breadcrumb path of a nested album, starting with the top-level album.
+type AlbumCrumbs []AlbumCrumb
+
+// HasParent checks if the album is nested in another album.
+func (m *Album) HasParent() bool {
+	return m.ParentUID != ""
+}
+
+// Parent returns the parent album, or nil if it is a top-level album.
+func (m *Album) Parent() *Album {
+	if !m.HasParent() {
+		return nil
+	}
+
+	return FindAlbum(Album{AlbumUID: m.ParentUID})
+}
+
+// Children returns the albums that are nested directly in this album.
+func (m *Album) Children() (result Albums) {
+	if m.AlbumUID == "" {
+		return result
+	}
+
+	if err := Db().Where("parent_uid = ? AND album_type = ?", m.AlbumUID, AlbumManual).
+		Order("album_title, album_uid").Find(&result).Error; err != nil {
+		log.Errorf("album: %s (find children)", err)
+	}
+
+	return result
+}
+
+// Breadcrumbs returns the path of parent albums, starting with the top-level album.
+func (m *Album) Breadcrumbs() AlbumCrumbs {
+	result := AlbumCrumbs{}
+	parentUid := m.ParentUID
+
+	for depth := 0; parentUid != "" && depth < AlbumMaxDepth; depth++ {
+		parent := FindAlbum(Album{AlbumUID: parentUid})
+
+		if parent == nil || parent.AlbumUID == m.AlbumUID {
+			break
+		}
+
+		result = append(AlbumCrumbs{{UID: parent.AlbumUID, Slug: parent.AlbumSlug, Title: parent.AlbumTitle}}, result...)
+		parentUid = parent.ParentUID
+	}
+
+	return result
+}
+
+// SetParent moves the album into another album, or to the top level if the parent UID is empty.
+func (m *Album) SetParent(parentUid string) error {
+	if !m.HasID() {
+		return fmt.Errorf("album does not exist")
+	} else if !m.IsDefault() {
+		return fmt.Errorf("only manually created albums can be nested")
+	}
+
+	if parentUid == "" {
+		// Move to top level.
+	} else if rnd.InvalidUID(parentUid, AlbumUID) {
+		return fmt.Errorf("invalid parent album uid")
+	} else if parentUid == m.AlbumUID {
+		return fmt.Errorf("album cannot be its own parent")
+	} else if parent := FindAlbum(Album{AlbumUID: parentUid}); parent == nil {
+		return fmt.Errorf("parent album not found")
+	} else if parent.Deleted() {
+		return fmt.Errorf("parent album has been deleted")
+	} else if !parent.IsDefault() {
+		return fmt.Errorf("parent must be a manually created album")
+	} else if crumbs := parent.Breadcrumbs(); len(crumbs)+2 > AlbumMaxDepth {
+		return fmt.Errorf("albums cannot be nested more than %d levels deep", AlbumMaxDepth)
+	} else {
+		// Prevent cycles by making sure the album is not an ancestor of the new parent.
+		for _, crumb := range crumbs {
+			if crumb.UID == m.AlbumUID {
+				return fmt.Errorf("album cannot be moved into one of its sub-albums")
+			}
+		}
+	}
+
+	if err := m.Update("ParentUID", parentUid); err != nil {
+		return err
+	}
+
+	m.ParentUID = parentUid
+	FlushAlbumCache()
+
+	return nil
+}
+
+// MoveChildrenUp moves the albums nested in this album to its parent, e.g. before it is deleted.
+func (m *Album) MoveChildrenUp() error {
+	if m.AlbumUID == "" {
+		return nil
+	}
+
+	err := UnscopedDb().Model(&Album{}).Where("parent_uid = ?", m.AlbumUID).UpdateColumn("parent_uid", m.ParentUID).Error
+
+	FlushAlbumCache()
+
+	return err
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbum_SetParent(t *testing.T) {
+	parent := NewAlbum("Parent Album", AlbumManual)
+	child := NewAlbum("Child Album", AlbumManual)
+	grandchild := NewAlbum("Grandchild Album", AlbumManual)
+
+	for _, m := range []*Album{parent, child, grandchild} {
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, child.SetParent(parent.AlbumUID))
+		assert.NoError(t, grandchild.SetParent(child.AlbumUID))
+
+		assert.True(t, grandchild.HasParent())
+		assert.Equal(t, child.AlbumUID, grandchild.Parent().AlbumUID)
+
+		crumbs := grandchild.Breadcrumbs()
+
+		if assert.Len(t, crumbs, 2) {
+			assert.Equal(t, parent.AlbumUID, crumbs[0].UID)
+			assert.Equal(t, child.AlbumUID, crumbs[1].UID)
+			assert.Equal(t, "Child Album", crumbs[1].Title)
+		}
+
+		children := parent.Children()
+
+		if assert.Len(t, children, 1) {
+			assert.Equal(t, child.AlbumUID, children[0].AlbumUID)
+		}
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		assert.Error(t, parent.SetParent(grandchild.AlbumUID))
+		assert.Error(t, parent.SetParent(parent.AlbumUID))
+	})
+	t.Run("InvalidParent", func(t *testing.T) {
+		assert.Error(t, child.SetParent("xxx"))
+		assert.Error(t, child.SetParent("at9lxuqxpogaaxxx"))
+		assert.Error(t, child.SetParent(AlbumFixtures.Get("april-1990").AlbumUID))
+	})
+	t.Run("SoftDelete", func(t *testing.T) {
+		assert.NoError(t, child.Delete())
+		assert.Equal(t, child.AlbumUID, FindAlbum(Album{AlbumUID: grandchild.AlbumUID}).ParentUID)
+	})
+	t.Run("MoveChildrenUp", func(t *testing.T) {
+		assert.NoError(t, child.DeletePermanently())
+		assert.Equal(t, parent.AlbumUID, FindAlbum(Album{AlbumUID: grandchild.AlbumUID}).ParentUID)
+	})
+	t.Run("TopLevel", func(t *testing.T) {
+		assert.NoError(t, grandchild.SetParent(""))
+		assert.False(t, grandchild.HasParent())
+		assert.Nil(t, grandchild.Parent())
+		assert.Empty(t, grandchild.Breadcrumbs())
+	})
+
+	assert.NoError(t, grandchild.DeletePermanently())
+	assert.NoError(t, parent.DeletePermanently())
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/sortby"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Names of the built-in album templates.
+const (
+	TemplateMonthly   = "monthly"
+	TemplateOnThisDay = "on-this-day"
+)
+
+// AlbumTemplate specifies the title pattern, filter, and sort order of albums created from it.
+// Patterns may contain the {year}, {month}, {day}, and {monthName} placeholders.
+type AlbumTemplate struct {
+	Name      string `json:"Name"`
+	Title     string `json:"Title"`
+	Filter    string `json:"Filter"`
+	Order     string `json:"Order"`
+	Recurring bool   `json:"Recurring"`
+}
+
+// AlbumTemplates represents a list of album templates.
+type AlbumTemplates []AlbumTemplate
+
+// DefaultAlbumTemplates contains the built-in album templates.
+var DefaultAlbumTemplates = AlbumTemplates{
+	{
+		Name:   TemplateMonthly,
+		Title:  "{monthName} {year}",
+		Filter: "public:true year:{year} month:{month}",
+		Order:  sortby.Oldest,
+	},
+	{
+		Name:      TemplateOnThisDay,
+		Title:     "On This Day",
+		Filter:    "public:true month:{month} day:{day}",
+		Order:     sortby.Newest,
+		Recurring: true,
+	},
+}
+
+// FindAlbumTemplate returns the built-in album template with the specified name.
+func FindAlbumTemplate(name string) (AlbumTemplate, error) {
+	name = clean.TypeLower(name)
+
+	for _, t := range DefaultAlbumTemplates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+
+	return AlbumTemplate{}, fmt.Errorf("unknown album template %s", clean.LogQuote(name))
+}
+
+// Expand replaces the placeholders in the pattern with the values of the specified date.
+func (t AlbumTemplate) Expand(pattern string, date time.Time) string {
+	return strings.NewReplacer(
+		"{year}", strconv.Itoa(date.Year()),
+		"{month}", strconv.Itoa(int(date.Month())),
+		"{day}", strconv.Itoa(date.Day()),
+		"{monthName}", date.Month().String(),
+	).Replace(pattern)
+}
+
+// NewAlbum returns a new album for the specified date, which must still be created in the database.
+func (t AlbumTemplate) NewAlbum(date time.Time, userUid string) *Album {
+	m := NewUserAlbum(txt.Clip(t.Expand(t.Title, date), txt.ClipDefault), AlbumManual, userUid)
+
+	m.AlbumFilter = t.Expand(t.Filter, date)
+	m.AlbumTemplate = t.Name
+
+	if t.Order != "" {
+		m.AlbumOrder = t.Order
+	}
+
+	return m
+}
+
+// FindRecurringAlbum returns the album that was previously created from a recurring template, if any.
+func FindRecurringAlbum(templateName string) *Album {
+	m := &Album{}
+
+	if templateName == "" {
+		return nil
+	} else if err := UnscopedDb().
+		Where("album_type = ? AND album_template = ? AND deleted_at IS NULL", AlbumManual, templateName).
+		First(m).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// CreateAlbum creates an album for the specified date unless it already exists. Recurring albums,
+// such as "On This Day", are updated instead of creating a new one each time. The changed result
+// is true if the album has been created or updated.
+func (t AlbumTemplate) CreateAlbum(date time.Time, userUid string) (m *Album, changed bool, err error) {
+	if t.Name == "" || t.Title == "" {
+		return nil, false, fmt.Errorf("album template must have a name and title")
+	}
+
+	m = t.NewAlbum(date, userUid)
+
+	if t.Recurring {
+		if found := FindRecurringAlbum(t.Name); found == nil {
+			// Create new album.
+		} else if found.AlbumFilter == m.AlbumFilter && found.AlbumTitle == m.AlbumTitle {
+			return found, false, nil
+		} else if err = found.Updates(Values{"album_title": m.AlbumTitle, "album_filter": m.AlbumFilter}); err != nil {
+			return found, false, err
+		} else {
+			found.AlbumTitle = m.AlbumTitle
+			found.AlbumFilter = m.AlbumFilter
+			return found, true, nil
+		}
+	} else if found := m.Find(); found != nil {
+		return found, false, nil
+	}
+
+	if err = m.Create(); err != nil {
+		return nil, false, err
+	}
+
+	return m, true, nil
+}
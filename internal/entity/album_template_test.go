@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+func TestFindAlbumTemplate(t *testing.T) {
+	t.Run("Monthly", func(t *testing.T) {
+		result, err := FindAlbumTemplate(" Monthly")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TemplateMonthly, result.Name)
+		assert.False(t, result.Recurring)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := FindAlbumTemplate("weekly")
+		assert.Error(t, err)
+	})
+}
+
+func TestAlbumTemplate_Expand(t *testing.T) {
+	tpl := AlbumTemplate{}
+	date := time.Date(2021, 9, 5, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "September 2021", tpl.Expand("{monthName} {year}", date))
+	assert.Equal(t, "year:2021 month:9 day:5", tpl.Expand("year:{year} month:{month} day:{day}", date))
+}
+
+func TestAlbumTemplate_NewAlbum(t *testing.T) {
+	tpl, _ := FindAlbumTemplate(TemplateMonthly)
+	m := tpl.NewAlbum(time.Date(2021, 9, 5, 12, 0, 0, 0, time.UTC), "uqxetse3cy5eo9z2")
+
+	assert.Equal(t, "September 2021", m.AlbumTitle)
+	assert.Equal(t, "public:true year:2021 month:9", m.AlbumFilter)
+	assert.Equal(t, TemplateMonthly, m.AlbumTemplate)
+	assert.Equal(t, sortby.Oldest, m.AlbumOrder)
+	assert.Equal(t, AlbumManual, m.AlbumType)
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.CreatedBy)
+}
+
+func TestAlbumTemplate_CreateAlbum(t *testing.T) {
+	t.Run("Recurring", func(t *testing.T) {
+		tpl, _ := FindAlbumTemplate(TemplateOnThisDay)
+
+		m, changed, err := tpl.CreateAlbum(time.Date(2021, 9, 5, 12, 0, 0, 0, time.UTC), OwnerUnknown)
+
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "public:true month:9 day:5", m.AlbumFilter)
+
+		// The same album should be updated on the next day.
+		next, changed, err := tpl.CreateAlbum(time.Date(2021, 9, 6, 12, 0, 0, 0, time.UTC), OwnerUnknown)
+
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, m.AlbumUID, next.AlbumUID)
+		assert.Equal(t, "public:true month:9 day:6", next.AlbumFilter)
+
+		// Nothing changes on the same day.
+		_, changed, err = tpl.CreateAlbum(time.Date(2021, 9, 6, 18, 0, 0, 0, time.UTC), OwnerUnknown)
+
+		assert.NoError(t, err)
+		assert.False(t, changed)
+
+		if err = next.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Monthly", func(t *testing.T) {
+		tpl, _ := FindAlbumTemplate(TemplateMonthly)
+		date := time.Date(2019, 2, 1, 12, 0, 0, 0, time.UTC)
+
+		m, changed, err := tpl.CreateAlbum(date, OwnerUnknown)
+
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "February 2019", m.AlbumTitle)
+
+		// Existing albums are not created again.
+		found, changed, err := tpl.CreateAlbum(date, OwnerUnknown)
+
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, m.AlbumUID, found.AlbumUID)
+
+		if err = m.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NoTitle", func(t *testing.T) {
+		_, _, err := AlbumTemplate{Name: "custom"}.CreateAlbum(time.Now(), OwnerUnknown)
+		assert.Error(t, err)
+	})
+}
@@ -584,3 +584,18 @@ func TestFindFolderAlbum(t *testing.T) {
 		}
 	})
 }
+
+func TestAlbum_IsSmart(t *testing.T) {
+	t.Run("Manual", func(t *testing.T) {
+		album := AlbumFixtures.Get("christmas2030")
+		assert.False(t, album.IsSmart())
+	})
+	t.Run("Filter", func(t *testing.T) {
+		album := Album{AlbumType: AlbumManual, AlbumFilter: "label:dog quality:4"}
+		assert.True(t, album.IsSmart())
+	})
+	t.Run("Moment", func(t *testing.T) {
+		album := Album{AlbumType: AlbumMoment, AlbumFilter: "public:true year:2016"}
+		assert.False(t, album.IsSmart())
+	})
+}
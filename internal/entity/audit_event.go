@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// AuditEvent represents a security-relevant event, e.g. a login, a permission change, or a deletion.
+// Audit events are append-only and cannot be changed or deleted through the API.
+type AuditEvent struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"ID"`
+	EventTime  time.Time `sql:"index" json:"Time" yaml:"Time"`
+	EventLevel string    `gorm:"type:VARBINARY(32);index;" json:"Level" yaml:"Level"`
+	ClientIP   string    `gorm:"size:64;column:client_ip;index;" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	SessionRef string    `gorm:"type:VARBINARY(16);default:'';" json:"SessionID" yaml:"SessionID,omitempty"`
+	UserUID    string    `gorm:"type:VARBINARY(42);index;default:'';" json:"UserUID" yaml:"UserUID,omitempty"`
+	UserName   string    `gorm:"size:64;default:'';" json:"UserName" yaml:"UserName,omitempty"`
+	Message    string    `gorm:"type:VARBINARY(2048);" json:"Message" yaml:"Message"`
+}
+
+// AuditEvents represents a list of audit events.
+type AuditEvents []AuditEvent
+
+// TableName returns the entity table name.
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// NewAuditEvent creates a new audit event based on the published event data.
+func NewAuditEvent(data event.Data) *AuditEvent {
+	m := &AuditEvent{EventTime: TimeStamp()}
+
+	if val, ok := data["time"].(time.Time); ok {
+		m.EventTime = val.UTC()
+	}
+
+	if val, ok := data["level"].(string); ok {
+		m.EventLevel = val
+	}
+
+	if val, ok := data["message"].(string); ok {
+		m.Message = txt.Clip(val, 2048)
+	}
+
+	if val, ok := data["ip"].(string); ok {
+		m.ClientIP = txt.Clip(val, 64)
+	}
+
+	// Add the session user, if any.
+	if val, ok := data["session"].(string); ok {
+		m.SessionRef = txt.Clip(val, 16)
+
+		if s := FindSessionByRefID(m.SessionRef); s != nil {
+			m.UserUID = s.UserUID
+			m.UserName = s.UserName
+		}
+	}
+
+	return m
+}
+
+// Create inserts a new audit event into the database.
+func (m *AuditEvent) Create() error {
+	return Db().Create(m).Error
+}
+
+// LogEvents stores published audit events with log level info or higher in the database.
+func (AuditEvent) LogEvents() {
+	s := event.Subscribe("audit.*")
+
+	defer func() {
+		event.Unsubscribe(s)
+	}()
+
+	for msg := range s.Receiver {
+		level, ok := msg.Fields["level"].(string)
+
+		if !ok {
+			continue
+		}
+
+		if logLevel, err := logrus.ParseLevel(level); err != nil || logLevel > logrus.InfoLevel {
+			continue
+		}
+
+		if err := NewAuditEvent(msg.Fields).Create(); err != nil {
+			log.Errorf("audit: %s", err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	now := time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	m := NewAuditEvent(event.Data{
+		"time":    now,
+		"level":   "info",
+		"message": "192.168.1.2 › session sessxkkcabcd › deleted album 'Holiday'",
+		"ip":      "192.168.1.2",
+		"session": "sessxkkcabcd",
+	})
+
+	assert.Equal(t, now, m.EventTime)
+	assert.Equal(t, "info", m.EventLevel)
+	assert.Equal(t, "192.168.1.2", m.ClientIP)
+	assert.Equal(t, "sessxkkcabcd", m.SessionRef)
+	assert.Contains(t, m.Message, "deleted album")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+}
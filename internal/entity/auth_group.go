@@ -0,0 +1,288 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+const (
+	GroupUID = byte('g')
+)
+
+// Groups represents a list of user groups.
+type Groups []Group
+
+// Group represents a group of users, e.g. "Grandparents", so that shares and roles can be assigned to all members.
+type Group struct {
+	GroupUID    string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	GroupName   string    `gorm:"size:64;unique_index;" json:"Name" yaml:"Name"`
+	GroupTitle  string    `gorm:"size:200;" json:"Title" yaml:"Title,omitempty"`
+	GroupRole   string    `gorm:"size:64;default:'';" json:"Role" yaml:"Role,omitempty"`
+	Description string    `gorm:"size:512;" json:"Description" yaml:"Description,omitempty"`
+	MemberCount int       `gorm:"-" json:"MemberCount" yaml:"-"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Group) TableName() string {
+	return "auth_groups"
+}
+
+// GroupMember represents the membership of a user in a group.
+type GroupMember struct {
+	GroupUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"GroupUID" yaml:"GroupUID"`
+	UserUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index;" json:"UserUID" yaml:"UserUID"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (GroupMember) TableName() string {
+	return "auth_groups_users"
+}
+
+// NewGroup returns a new user group.
+func NewGroup(name string) *Group {
+	return &Group{
+		GroupUID:   rnd.GenerateUID(GroupUID),
+		GroupName:  clean.Handle(name),
+		GroupTitle: clean.Name(name),
+	}
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Group) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUnique(m.GroupUID, GroupUID) {
+		return nil
+	}
+
+	return scope.SetColumn("GroupUID", rnd.GenerateUID(GroupUID))
+}
+
+// FindGroupByUID returns the group with the specified UID or nil if it does not exist.
+func FindGroupByUID(uid string) *Group {
+	if rnd.InvalidUID(uid, GroupUID) {
+		return nil
+	}
+
+	m := &Group{}
+
+	if Db().Where("group_uid = ?", uid).First(m).Error != nil {
+		return nil
+	}
+
+	m.MemberCount = m.Count()
+
+	return m
+}
+
+// FindGroupByName returns the group with the specified name or nil if it does not exist.
+func FindGroupByName(name string) *Group {
+	if name = clean.Handle(name); name == "" {
+		return nil
+	}
+
+	m := &Group{}
+
+	if Db().Where("group_name = ?", name).First(m).Error != nil {
+		return nil
+	}
+
+	m.MemberCount = m.Count()
+
+	return m
+}
+
+// FindGroups returns all user groups.
+func FindGroups() (result Groups) {
+	result = Groups{}
+
+	if err := Db().Order("group_name").Find(&result).Error; err != nil {
+		log.Errorf("groups: %s", err)
+	}
+
+	for i := range result {
+		result[i].MemberCount = result[i].Count()
+	}
+
+	return result
+}
+
+// FindUserGroups returns the groups the user is a member of.
+func FindUserGroups(userUid string) (result Groups) {
+	result = Groups{}
+
+	if rnd.InvalidUID(userUid, UserUID) {
+		return result
+	}
+
+	if err := Db().Where("group_uid IN (SELECT group_uid FROM auth_groups_users WHERE user_uid = ?)", userUid).
+		Order("group_name").Find(&result).Error; err != nil {
+		log.Errorf("groups: %s", err)
+	}
+
+	return result
+}
+
+// Validate checks if the group name and role are valid.
+func (m *Group) Validate() error {
+	if m.GroupName == "" {
+		return fmt.Errorf("group name must not be empty")
+	} else if m.GroupRole != "" && acl.FindRole(m.GroupRole) == acl.RoleUnknown {
+		return fmt.Errorf("role %s is invalid", clean.LogQuote(m.GroupRole))
+	}
+
+	return nil
+}
+
+// SetRole updates the role that is assigned to all group members.
+func (m *Group) SetRole(role string) *Group {
+	m.GroupRole = acl.FindRole(clean.Role(role)).String()
+	return m
+}
+
+// Create inserts a new group into the database.
+func (m *Group) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	} else if FindGroupByName(m.GroupName) != nil {
+		return fmt.Errorf("group %s already exists", clean.LogQuote(m.GroupName))
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates the group, so that its role applies to the members.
+func (m *Group) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	} else if err = Db().Save(m).Error; err != nil {
+		return err
+	}
+
+	m.RefreshMembers()
+
+	return nil
+}
+
+// Delete removes the group including memberships and shares.
+func (m *Group) Delete() error {
+	if m.GroupUID == "" {
+		return fmt.Errorf("empty group uid")
+	}
+
+	members := m.MemberUIDs()
+
+	if err := UnscopedDb().Delete(GroupMember{}, "group_uid = ?", m.GroupUID).Error; err != nil {
+		return err
+	} else if err = UnscopedDb().Delete(UserShare{}, "user_uid = ?", m.GroupUID).Error; err != nil {
+		return err
+	}
+
+	for _, uid := range members {
+		FlushUserSessionCache(uid)
+	}
+
+	return UnscopedDb().Delete(m, "group_uid = ?", m.GroupUID).Error
+}
+
+// MemberUIDs returns the UIDs of the group members.
+func (m *Group) MemberUIDs() (result UIDs) {
+	result = UIDs{}
+
+	if err := UnscopedDb().Model(GroupMember{}).Where("group_uid = ?", m.GroupUID).
+		Order("user_uid").Pluck("user_uid", &result).Error; err != nil {
+		log.Errorf("groups: %s", err)
+	}
+
+	return result
+}
+
+// Count returns the number of group members.
+func (m *Group) Count() (count int) {
+	if err := UnscopedDb().Model(GroupMember{}).Where("group_uid = ?", m.GroupUID).Count(&count).Error; err != nil {
+		log.Errorf("groups: %s", err)
+	}
+
+	return count
+}
+
+// AddMembers adds the specified users to the group, so that the group role applies to them, if any.
+func (m *Group) AddMembers(userUids []string) (added int, err error) {
+	for _, uid := range userUids {
+		if u := FindUserByUID(clean.UID(uid)); u == nil {
+			return added, fmt.Errorf("user %s not found", clean.Log(uid))
+		} else if found := UnscopedDb().Where("group_uid = ? AND user_uid = ?", m.GroupUID, u.UserUID).
+			First(&GroupMember{}).Error; found == nil {
+			continue
+		} else if err = UnscopedDb().Create(&GroupMember{GroupUID: m.GroupUID, UserUID: u.UserUID, CreatedAt: TimeStamp()}).Error; err != nil {
+			return added, err
+		}
+
+		added++
+	}
+
+	m.MemberCount = m.Count()
+	m.RefreshMembers()
+
+	return added, nil
+}
+
+// RemoveMembers removes the specified users from the group.
+func (m *Group) RemoveMembers(userUids []string) error {
+	if len(userUids) == 0 {
+		return nil
+	}
+
+	if err := UnscopedDb().Delete(GroupMember{}, "group_uid = ? AND user_uid IN (?)", m.GroupUID, userUids).Error; err != nil {
+		return err
+	}
+
+	for _, uid := range userUids {
+		FlushUserSessionCache(uid)
+	}
+
+	m.MemberCount = m.Count()
+
+	return nil
+}
+
+// RefreshMembers flushes the cached sessions of all members, so that changes to the group role
+// take effect immediately.
+func (m *Group) RefreshMembers() {
+	for _, uid := range m.MemberUIDs() {
+		FlushUserSessionCache(uid)
+	}
+}
+
+// UserGroupRole returns the role assigned to the user by group memberships, or acl.RoleUnknown if there is none.
+// The role is not stored with the user, so that it no longer applies when the membership ends. If the groups
+// have different roles, the most privileged role is returned.
+func UserGroupRole(userUid string) acl.Role {
+	if rnd.InvalidUID(userUid, UserUID) {
+		return acl.RoleUnknown
+	}
+
+	var roles []string
+
+	if err := UnscopedDb().Model(&Group{}).
+		Where("group_role <> '' AND group_uid IN (SELECT group_uid FROM auth_groups_users WHERE user_uid = ?)", userUid).
+		Order("group_name").Pluck("group_role", &roles).Error; err != nil {
+		log.Errorf("groups: %s", err)
+		return acl.RoleUnknown
+	}
+
+	result := acl.RoleUnknown
+
+	for _, r := range roles {
+		result = acl.Resources.MorePrivileged(result, acl.FindRole(r))
+	}
+
+	return result
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestNewGroup(t *testing.T) {
+	m := NewGroup("Grandparents")
+
+	assert.True(t, rnd.IsUID(m.GroupUID, GroupUID))
+	assert.Equal(t, "grandparents", m.GroupName)
+	assert.Equal(t, "Grandparents", m.GroupTitle)
+	assert.NoError(t, m.Validate())
+
+	m.GroupRole = "foo"
+	assert.Error(t, m.Validate())
+
+	m.SetRole("Admin")
+	assert.Equal(t, acl.RoleAdmin.String(), m.GroupRole)
+}
+
+func TestGroup_Members(t *testing.T) {
+	m := NewGroup("Family Members")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Error(t, m.Create())
+	assert.NotNil(t, FindGroupByName("family.members"))
+
+	bob := UserFixtures.Pointer("bob")
+
+	added, err := m.AddMembers([]string{bob.UserUID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	added, err = m.AddMembers([]string{bob.UserUID})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	_, err = m.AddMembers([]string{"uqxc08w3d0ej9999"})
+	assert.Error(t, err)
+
+	assert.Equal(t, 1, m.Count())
+	assert.Equal(t, UIDs{bob.UserUID}, m.MemberUIDs())
+	assert.Equal(t, m.GroupUID, FindUserGroups(bob.UserUID)[0].GroupUID)
+
+	t.Run("Shares", func(t *testing.T) {
+		if _, err = GrantShare(m.GroupUID, "at9lxuqxpogaaba7", PermView, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, FindUserShares(bob.UserUID).Contains("at9lxuqxpogaaba7"))
+		assert.NoError(t, RevokeShare(m.GroupUID, "at9lxuqxpogaaba7"))
+		assert.False(t, FindUserShares(bob.UserUID).Contains("at9lxuqxpogaaba7"))
+	})
+
+	assert.NoError(t, m.RemoveMembers([]string{bob.UserUID}))
+	assert.Equal(t, 0, m.Count())
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindGroupByUID(m.GroupUID))
+}
+
+func TestUserGroupRole(t *testing.T) {
+	bob := UserFixtures.Pointer("bob")
+	jens := UserFixtures.Pointer("unauthorized")
+
+	visitors := NewGroup("Role Visitors").SetRole(acl.RoleVisitor.String())
+	admins := NewGroup("Role Admins").SetRole(acl.RoleAdmin.String())
+
+	for _, g := range []*Group{visitors, admins} {
+		if err := g.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, acl.RoleUnknown, UserGroupRole(bob.UserUID))
+	assert.Equal(t, acl.RoleUnknown, UserGroupRole("invalid"))
+	assert.Equal(t, acl.RoleUnknown, FindUserByUID(jens.UserUID).AclRole())
+
+	if _, err := visitors.AddMembers([]string{bob.UserUID, jens.UserUID}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, acl.RoleVisitor, UserGroupRole(bob.UserUID))
+	assert.Equal(t, acl.RoleVisitor, FindUserByUID(jens.UserUID).AclRole())
+
+	// Group roles do not reduce the privileges of a user.
+	assert.Equal(t, acl.RoleAdmin, FindUserByUID(bob.UserUID).AclRole())
+
+	// The most privileged role takes precedence, regardless of the group name.
+	if _, err := admins.AddMembers([]string{jens.UserUID}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, acl.RoleAdmin, UserGroupRole(jens.UserUID))
+	assert.Equal(t, acl.RoleAdmin, FindUserByUID(jens.UserUID).AclRole())
+
+	// The group role no longer applies when the membership ends.
+	assert.NoError(t, admins.RemoveMembers([]string{jens.UserUID}))
+	assert.Equal(t, acl.RoleVisitor, FindUserByUID(jens.UserUID).AclRole())
+	assert.NoError(t, visitors.Delete())
+
+	u := FindUserByUID(jens.UserUID)
+
+	assert.Equal(t, "", u.GroupRole)
+	assert.Equal(t, acl.RoleUnknown, u.AclRole())
+	assert.NoError(t, admins.Delete())
+}
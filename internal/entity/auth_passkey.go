@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/webauthn"
+)
+
+// passkeyChallenges caches pending registration and login challenges until they are used or expire.
+var passkeyChallengeExpiration = 5 * time.Minute
+var passkeyChallenges = gc.New(passkeyChallengeExpiration, 10*time.Minute)
+
+// passkeyChallengePrefix is the key prefix of challenges in the shared cache.
+const passkeyChallengePrefix = "passkey-challenge:"
+
+// Passkeys represents a list of passkeys.
+type Passkeys []Passkey
+
+// Passkey represents a WebAuthn credential that can be used for passwordless login.
+type Passkey struct {
+	PasskeyID      string     `gorm:"type:VARBINARY(255);primary_key;auto_increment:false;" json:"ID" yaml:"ID"`
+	UserUID        string     `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID"`
+	PasskeyName    string     `gorm:"size:160;" json:"Name" yaml:"Name"`
+	PublicKey      []byte     `gorm:"type:BLOB;" json:"-" yaml:"-"`
+	Algorithm      int        `json:"Algorithm" yaml:"Algorithm"`
+	SignCount      uint32     `json:"-" yaml:"-"`
+	AAGUID         string     `gorm:"type:VARBINARY(32);default:'';" json:"AAGUID" yaml:"AAGUID,omitempty"`
+	Transports     string     `gorm:"type:VARBINARY(255);default:'';" json:"Transports" yaml:"Transports,omitempty"`
+	BackupEligible bool       `json:"BackupEligible" yaml:"BackupEligible,omitempty"`
+	LastUsedAt     *time.Time `json:"LastUsedAt" yaml:"LastUsedAt,omitempty"`
+	CreatedAt      time.Time  `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt      time.Time  `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// TableName returns the entity table name.
+func (Passkey) TableName() string {
+	return "auth_passkeys"
+}
+
+// NewPasskey returns a new passkey for the user based on a verified credential.
+func NewPasskey(userUid, name string, cred *webauthn.Credential) *Passkey {
+	if name = clean.Name(name); name == "" {
+		name = "Passkey"
+	}
+
+	return &Passkey{
+		PasskeyID:      cred.ID,
+		UserUID:        userUid,
+		PasskeyName:    clean.Clip(name, 160),
+		PublicKey:      cred.PublicKey,
+		Algorithm:      cred.Algorithm,
+		SignCount:      cred.SignCount,
+		AAGUID:         hex.EncodeToString(cred.AAGUID),
+		Transports:     clean.Clip(strings.Join(cred.Transports, ","), 255),
+		BackupEligible: cred.BackupEligible,
+	}
+}
+
+// FindPasskey returns the passkey with the specified credential id or nil if it was not found.
+func FindPasskey(id string) *Passkey {
+	if id == "" || len(id) > 255 {
+		return nil
+	}
+
+	m := &Passkey{}
+
+	if err := Db().Where("passkey_id = ?", id).First(m).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindPasskeys returns the passkeys registered by the user.
+func FindPasskeys(userUid string) (result Passkeys) {
+	result = Passkeys{}
+
+	if rnd.InvalidUID(userUid, UserUID) {
+		return result
+	}
+
+	if err := Db().Where("user_uid = ?", userUid).Order("created_at").Find(&result).Error; err != nil {
+		log.Errorf("passkeys: %s", err)
+	}
+
+	return result
+}
+
+// HasPasskeys checks if the user has registered at least one passkey.
+func HasPasskeys(userUid string) bool {
+	if rnd.InvalidUID(userUid, UserUID) {
+		return false
+	}
+
+	var count int
+
+	if err := Db().Model(&Passkey{}).Where("user_uid = ?", userUid).Count(&count).Error; err != nil {
+		log.Errorf("passkeys: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// Create inserts a new record into the database.
+func (m *Passkey) Create() error {
+	if m.PasskeyID == "" || len(m.PasskeyID) > 255 {
+		return fmt.Errorf("invalid credential id")
+	} else if rnd.InvalidUID(m.UserUID, UserUID) {
+		return fmt.Errorf("invalid user uid")
+	} else if FindPasskey(m.PasskeyID) != nil {
+		return fmt.Errorf("passkey already exists")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Delete permanently deletes the passkey.
+func (m *Passkey) Delete() error {
+	if m.PasskeyID == "" {
+		return fmt.Errorf("empty credential id")
+	}
+
+	return UnscopedDb().Delete(m, "passkey_id = ?", m.PasskeyID).Error
+}
+
+// Credential returns the stored credential for verifying logins.
+func (m *Passkey) Credential() webauthn.Credential {
+	return webauthn.Credential{
+		ID:        m.PasskeyID,
+		PublicKey: m.PublicKey,
+		Algorithm: m.Algorithm,
+		SignCount: m.SignCount,
+	}
+}
+
+// Descriptor returns a reference to the passkey, e.g. to prevent registering the same authenticator twice.
+func (m *Passkey) Descriptor() webauthn.Descriptor {
+	d := webauthn.Descriptor{Type: "public-key", ID: m.PasskeyID}
+
+	if m.Transports != "" {
+		d.Transports = strings.Split(m.Transports, ",")
+	}
+
+	return d
+}
+
+// UpdateUsage updates the signature counter and the last used timestamp after a successful login.
+func (m *Passkey) UpdateUsage(signCount uint32) error {
+	now := TimeStamp()
+	m.SignCount = signCount
+	m.LastUsedAt = &now
+
+	return UnscopedDb().Model(m).UpdateColumns(Values{"sign_count": m.SignCount, "last_used_at": m.LastUsedAt}).Error
+}
+
+// NewPasskeyChallenge creates a new challenge for a registration or login ceremony. The user uid
+// is only specified for registrations, since logins with discoverable credentials are not bound to a user.
+func NewPasskeyChallenge(userUid string) (string, error) {
+	challenge, err := webauthn.NewChallenge()
+
+	if err != nil {
+		return "", err
+	}
+
+	if c := shared(); c != nil {
+		setShared(c, passkeyChallengePrefix+challenge, userUid, passkeyChallengeExpiration)
+	} else {
+		passkeyChallenges.SetDefault(challenge, userUid)
+	}
+
+	return challenge, nil
+}
+
+// RedeemPasskeyChallenge removes the challenge from the cache and returns true if it was issued for the user.
+func RedeemPasskeyChallenge(challenge, userUid string) bool {
+	if challenge == "" {
+		return false
+	}
+
+	// Challenges can only be used once.
+	if c := shared(); c != nil {
+		var cached string
+
+		if !getShared(c, passkeyChallengePrefix+challenge, &cached) {
+			return false
+		}
+
+		deleteShared(c, passkeyChallengePrefix+challenge)
+
+		return cached == userUid
+	}
+
+	cached, found := passkeyChallenges.Get(challenge)
+
+	if !found {
+		return false
+	}
+
+	passkeyChallenges.Delete(challenge)
+
+	return cached.(string) == userUid
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/webauthn"
+)
+
+func TestPasskey(t *testing.T) {
+	cred := &webauthn.Credential{
+		ID:         "Y3JlZGVudGlhbC0xMjM0",
+		PublicKey:  []byte{0x30, 0x59},
+		Algorithm:  webauthn.AlgES256,
+		AAGUID:     make([]byte, 16),
+		Transports: []string{"internal", "hybrid"},
+	}
+
+	m := NewPasskey("uqxetse3cy5eo9z2", "", cred)
+	assert.Equal(t, "Passkey", m.PasskeyName)
+	assert.Equal(t, []string{"internal", "hybrid"}, m.Descriptor().Transports)
+
+	assert.False(t, HasPasskeys("uqxetse3cy5eo9z2"))
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Error(t, m.Create())
+	assert.True(t, HasPasskeys("uqxetse3cy5eo9z2"))
+	assert.Len(t, FindPasskeys("uqxetse3cy5eo9z2"), 1)
+
+	assert.NoError(t, m.UpdateUsage(7))
+	assert.Equal(t, uint32(7), FindPasskey(m.PasskeyID).Credential().SignCount)
+
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindPasskey(m.PasskeyID))
+}
+
+func TestPasskeyChallenge(t *testing.T) {
+	challenge, err := NewPasskeyChallenge("uqxetse3cy5eo9z2")
+	assert.NoError(t, err)
+
+	assert.False(t, RedeemPasskeyChallenge("", ""))
+	assert.False(t, RedeemPasskeyChallenge("foo", ""))
+	assert.True(t, RedeemPasskeyChallenge(challenge, "uqxetse3cy5eo9z2"))
+	assert.False(t, RedeemPasskeyChallenge(challenge, "uqxetse3cy5eo9z2"))
+
+	challenge, _ = NewPasskeyChallenge("")
+	assert.False(t, RedeemPasskeyChallenge(challenge, "uqxetse3cy5eo9z2"))
+}
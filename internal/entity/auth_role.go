@@ -0,0 +1,200 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+func init() {
+	onReady = append(onReady, initRoles)
+}
+
+// Roles represents a list of custom user roles.
+type Roles []Role
+
+// Role represents a custom user role defined by an admin, in addition to the built-in roles.
+type Role struct {
+	RoleName    string    `gorm:"type:VARBINARY(64);primary_key;auto_increment:false;" json:"Name" yaml:"Name"`
+	RoleTitle   string    `gorm:"size:64;" json:"Title" yaml:"Title,omitempty"`
+	RolePerms   string    `gorm:"type:VARBINARY(512);" json:"Permissions" yaml:"Permissions,omitempty"`
+	Description string    `gorm:"size:512;" json:"Description" yaml:"Description,omitempty"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Role) TableName() string {
+	return "auth_roles"
+}
+
+// NewRole returns a new custom role with the specified permissions, e.g. "view upload".
+func NewRole(name, perms string) (*Role, error) {
+	m := &Role{RoleName: clean.Role(name)}
+
+	if err := m.SetPerms(perms); err != nil {
+		return m, err
+	}
+
+	m.RoleTitle = acl.Role(m.RoleName).Pretty()
+
+	return m, m.Validate()
+}
+
+// initRoles registers the custom roles stored in the database and reloads them when
+// they have expired, so that changes made by other processes are applied as well.
+func initRoles() {
+	acl.SetCustomRoleLoader(loadRoles)
+}
+
+// loadRoles returns the permissions of the valid custom roles stored in the database.
+func loadRoles() (map[acl.Role]acl.RoleGrants, error) {
+	var result Roles
+
+	if err := Db().Order("role_name").Find(&result).Error; err != nil {
+		log.Errorf("roles: %s (load)", err)
+		return nil, err
+	}
+
+	roles := make(map[acl.Role]acl.RoleGrants, len(result))
+
+	for _, m := range result {
+		if err := m.Validate(); err != nil {
+			log.Warnf("roles: %s (load)", err)
+		} else {
+			roles[m.AclRole()] = acl.CapabilityGrants(m.Capabilities())
+		}
+	}
+
+	return roles, nil
+}
+
+// FindRole returns the custom role with the specified name or nil if it does not exist.
+func FindRole(name string) *Role {
+	if name = clean.Role(name); name == "" {
+		return nil
+	}
+
+	m := &Role{}
+
+	if Db().Where("role_name = ?", name).First(m).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindRoles returns all custom roles.
+func FindRoles() (result Roles) {
+	result = Roles{}
+
+	if err := Db().Order("role_name").Find(&result).Error; err != nil {
+		log.Errorf("roles: %s", err)
+	}
+
+	return result
+}
+
+// AclRole returns the role for ACL permission checks.
+func (m *Role) AclRole() acl.Role {
+	return acl.Role(m.RoleName)
+}
+
+// Capabilities returns the capabilities granted to the role.
+func (m *Role) Capabilities() []acl.Capability {
+	caps, _ := acl.ParseCapabilities(m.RolePerms)
+	return caps
+}
+
+// SetPerms updates the capabilities granted to the role, e.g. "view upload edit".
+func (m *Role) SetPerms(perms string) error {
+	caps, err := acl.ParseCapabilities(perms)
+
+	if err != nil {
+		return err
+	}
+
+	s := make([]string, len(caps))
+
+	for i := range caps {
+		s[i] = string(caps[i])
+	}
+
+	m.RolePerms = strings.Join(s, " ")
+
+	return nil
+}
+
+// Validate checks if the role name and permissions are valid.
+func (m *Role) Validate() error {
+	if m.RoleName == "" {
+		return fmt.Errorf("role name must not be empty")
+	} else if _, builtIn := acl.ValidRoles[m.RoleName]; builtIn || m.AclRole() == acl.RoleDefault {
+		return fmt.Errorf("role %s is reserved", clean.LogQuote(m.RoleName))
+	} else if _, err := acl.ParseCapabilities(m.RolePerms); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Register makes the role available for ACL permission checks.
+func (m *Role) Register() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return acl.SetCustomRole(m.AclRole(), acl.CapabilityGrants(m.Capabilities()))
+}
+
+// Create inserts a new role into the database and registers it.
+func (m *Role) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	} else if FindRole(m.RoleName) != nil {
+		return fmt.Errorf("role %s already exists", clean.LogQuote(m.RoleName))
+	} else if err = Db().Create(m).Error; err != nil {
+		return err
+	}
+
+	return m.Register()
+}
+
+// Save updates the role in the database and registers the new permissions.
+func (m *Role) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	} else if err = Db().Save(m).Error; err != nil {
+		return err
+	}
+
+	return m.Register()
+}
+
+// Delete removes the role. Users who had the role assigned are left without a role and cannot log in.
+func (m *Role) Delete() error {
+	if m.RoleName == "" {
+		return fmt.Errorf("empty role name")
+	}
+
+	if err := UnscopedDb().Model(&User{}).Where("user_role = ?", m.RoleName).
+		UpdateColumn("user_role", acl.RoleUnknown.String()).Error; err != nil {
+		return err
+	}
+
+	acl.DeleteCustomRole(m.AclRole())
+
+	return UnscopedDb().Delete(m, "role_name = ?", m.RoleName).Error
+}
+
+// Users returns the number of users with the role assigned.
+func (m *Role) Users() (count int) {
+	if err := Db().Model(&User{}).Where("user_role = ?", m.RoleName).Count(&count).Error; err != nil {
+		log.Errorf("roles: %s", err)
+	}
+
+	return count
+}
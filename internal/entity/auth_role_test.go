@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+func TestNewRole(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m, err := NewRole("Contributor", "upload view")
+		assert.NoError(t, err)
+		assert.Equal(t, "contributor", m.RoleName)
+		assert.Equal(t, "Contributor", m.RoleTitle)
+		assert.Equal(t, "upload view", m.RolePerms)
+		assert.Equal(t, []acl.Capability{acl.CapUpload, acl.CapView}, m.Capabilities())
+	})
+	t.Run("Reserved", func(t *testing.T) {
+		_, err := NewRole("admin", "view")
+		assert.Error(t, err)
+	})
+	t.Run("UnknownPermission", func(t *testing.T) {
+		_, err := NewRole("editor", "view fly")
+		assert.Error(t, err)
+	})
+}
+
+func TestRole_Create(t *testing.T) {
+	m, err := NewRole("uploader", "view upload")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, m.Create())
+	assert.Error(t, m.Create())
+	assert.True(t, acl.IsCustomRole("uploader"))
+	assert.NotNil(t, FindRole("Uploader"))
+
+	u := NewUser()
+	u.UserName = "uploader"
+	u.SetRole("uploader")
+	assert.Equal(t, acl.Role("uploader"), u.AclRole())
+	assert.True(t, u.HasRole("uploader"))
+
+	assert.True(t, acl.Resources.Allow(acl.ResourceFiles, u.AclRole(), acl.ActionUpload))
+	assert.True(t, acl.Resources.Allow(acl.ResourceConfig, u.AclRole(), acl.AccessOwn))
+	assert.False(t, acl.Resources.Allow(acl.ResourcePhotos, u.AclRole(), acl.ActionDelete))
+
+	assert.NoError(t, m.SetPerms("view delete"))
+	assert.NoError(t, m.Save())
+	assert.True(t, acl.Resources.Allow(acl.ResourcePhotos, u.AclRole(), acl.ActionDelete))
+
+	assert.NoError(t, m.Delete())
+	assert.False(t, acl.IsCustomRole("uploader"))
+	assert.Nil(t, FindRole("uploader"))
+}
+
+func TestRole_Reload(t *testing.T) {
+	expiration := acl.CustomRoleExpiration
+	acl.CustomRoleExpiration = 0
+	defer func() { acl.CustomRoleExpiration = expiration }()
+
+	m, err := NewRole("reviewer", "view")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Roles added by other processes, e.g. CLI commands, are loaded from the database.
+	assert.False(t, acl.IsCustomRole("reviewer"))
+	assert.NoError(t, Db().Create(m).Error)
+	assert.True(t, acl.IsCustomRole("reviewer"))
+	assert.True(t, acl.Resources.Allow(acl.ResourcePhotos, m.AclRole(), acl.ActionSearch))
+
+	assert.NoError(t, UnscopedDb().Delete(m, "role_name = ?", m.RoleName).Error)
+	assert.False(t, acl.IsCustomRole("reviewer"))
+	assert.Equal(t, acl.RoleUnknown, acl.FindRole("reviewer"))
+}
@@ -33,6 +33,7 @@ type Session struct {
 	ClientIP      string          `gorm:"size:64;column:client_ip;index" json:"ClientIP" yaml:"ClientIP,omitempty"`
 	UserUID       string          `gorm:"type:VARBINARY(42);index;default:'';" json:"UserUID" yaml:"UserUID,omitempty"`
 	UserName      string          `gorm:"size:64;index;" json:"UserName" yaml:"UserName,omitempty"`
+	ClientName    string          `gorm:"size:200;default:'';" json:"ClientName" yaml:"ClientName,omitempty"`
 	user          *User           `gorm:"-"`
 	AuthProvider  string          `gorm:"type:VARBINARY(128);default:'';" json:"AuthProvider" yaml:"AuthProvider,omitempty"`
 	AuthMethod    string          `gorm:"type:VARBINARY(128);default:'';" json:"AuthMethod" yaml:"AuthMethod,omitempty"`
@@ -437,6 +438,16 @@ func (m *Session) NotRegistered() bool {
 	return !m.IsRegistered()
 }
 
+// PrivacyFenced checks if the coordinates of pictures taken in privacy zones must be fuzzed or stripped,
+// which is the case for visitors and other sessions without a registered user account.
+func (m *Session) PrivacyFenced() bool {
+	if m == nil || len(PrivacyZones) == 0 {
+		return false
+	}
+
+	return m.IsVisitor() || m.NotRegistered()
+}
+
 // NoShares checks if the session has no shares yet.
 func (m *Session) NoShares() bool {
 	return !m.HasShares()
@@ -486,6 +497,78 @@ func (m *Session) RedeemToken(token string) (n int) {
 	}
 }
 
+// NoDownload checks if the session must not download originals because
+// the share links it was created with have downloads disabled.
+func (m *Session) NoDownload() bool {
+	if user := m.User(); user.IsRegistered() {
+		return false
+	} else if data := m.Data(); data == nil {
+		return false
+	} else {
+		return data.NoDownload()
+	}
+}
+
+// WatermarkLink returns the share link whose watermark must be applied to images
+// shown in the session, or nil if none of its share links have watermarks enabled.
+func (m *Session) WatermarkLink() *Link {
+	if user := m.User(); user.IsRegistered() {
+		return nil
+	} else if data := m.Data(); data == nil {
+		return nil
+	} else {
+		return data.WatermarkLink()
+	}
+}
+
+// CommentLink returns the share link that allows the visitor to comment on the shared entity, or nil if there is none.
+func (m *Session) CommentLink(shareUid string) *Link {
+	if user := m.User(); user.IsRegistered() {
+		return nil
+	} else if data := m.Data(); data == nil {
+		return nil
+	} else {
+		return data.CommentLink(shareUid)
+	}
+}
+
+// ReactLink returns the share link that allows the visitor to react to the shared entity, or nil if there is none.
+func (m *Session) ReactLink(shareUid string) *Link {
+	if user := m.User(); user.IsRegistered() {
+		return nil
+	} else if data := m.Data(); data == nil {
+		return nil
+	} else {
+		return data.ReactLink(shareUid)
+	}
+}
+
+// UnlockedUIDs returns the UIDs of the locked albums that have been unlocked in this session.
+func (m *Session) UnlockedUIDs() UIDs {
+	if data := m.Data(); data == nil {
+		return UIDs{}
+	} else {
+		return data.Unlocked
+	}
+}
+
+// HasUnlocked checks if the specified locked album has been unlocked in this session.
+func (m *Session) HasUnlocked(uid string) bool {
+	if data := m.Data(); data == nil {
+		return false
+	} else {
+		return data.HasUnlocked(uid)
+	}
+}
+
+// UnlockAlbum unlocks the specified album for the remaining duration of the session.
+func (m *Session) UnlockAlbum(uid string) error {
+	data := m.Data()
+	data.Unlock(uid)
+
+	return m.SetData(data).Save()
+}
+
 // ExpiresAt returns the time when the session expires.
 func (m *Session) ExpiresAt() time.Time {
 	if m.SessExpires <= 0 {
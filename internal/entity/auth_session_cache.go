@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	gc "github.com/patrickmn/go-cache"
@@ -15,6 +16,12 @@ import (
 var sessionCacheExpiration = 15 * time.Minute
 var sessionCache = gc.New(sessionCacheExpiration, 5*time.Minute)
 
+// Key prefixes of sessions in the shared cache.
+const (
+	sessionCachePrefix     = "session:"
+	userSessionCachePrefix = "user-session:"
+)
+
 // FindSession returns an existing session or nil if not found.
 func FindSession(id string) (*Session, error) {
 	found := &Session{}
@@ -25,8 +32,8 @@ func FindSession(id string) (*Session, error) {
 	}
 
 	// Find the session in the cache with a fallback to the database.
-	if cacheData, ok := sessionCache.Get(id); ok && cacheData != nil {
-		if cached := cacheData.(*Session); !cached.Expired() {
+	if cached, ok := cachedSession(id); ok {
+		if !cached.Expired() {
 			cached.LastActive = UnixTime()
 			return cached, nil
 		} else if err := cached.Delete(); err != nil {
@@ -49,9 +56,29 @@ func FindSession(id string) (*Session, error) {
 	return found, fmt.Errorf("expired")
 }
 
+// cachedSession returns the cached session with the specified id, if any.
+func cachedSession(id string) (*Session, bool) {
+	if c := shared(); c != nil {
+		cached := &Session{}
+
+		if getShared(c, sessionCachePrefix+id, cached) && cached.ID == id {
+			return cached, true
+		}
+	} else if cacheData, ok := sessionCache.Get(id); ok && cacheData != nil {
+		return cacheData.(*Session), true
+	}
+
+	return nil, false
+}
+
 // FlushSessionCache resets the session cache.
 func FlushSessionCache() {
 	sessionCache.Flush()
+
+	if c := shared(); c != nil {
+		flushShared(c, sessionCachePrefix)
+		flushShared(c, userSessionCachePrefix)
+	}
 }
 
 // CacheSession adds a session to the cache if its ID is valid.
@@ -74,7 +101,21 @@ func CacheSession(s *Session, d time.Duration) {
 		DownloadToken.Set(s.DownloadToken, s.ID)
 	}
 
-	sessionCache.Set(s.ID, s, d)
+	c := shared()
+
+	if c == nil {
+		sessionCache.Set(s.ID, s, d)
+		return
+	}
+
+	setShared(c, sessionCachePrefix+s.ID, s, d)
+
+	// Remember the cached sessions of each user, so that they can be flushed when permissions change.
+	if s.UserUID != "" {
+		if err := c.Set(userSessionCachePrefix+s.UserUID+":"+s.ID, []byte(s.ID), d); err != nil {
+			log.Warnf("cache: %s (set)", err)
+		}
+	}
 }
 
 // DeleteSession permanently deletes a session.
@@ -104,5 +145,38 @@ func DeleteFromSessionCache(id string) {
 		return
 	}
 
-	sessionCache.Delete(id)
+	if c := shared(); c != nil {
+		deleteShared(c, sessionCachePrefix+id)
+	} else {
+		sessionCache.Delete(id)
+	}
+}
+
+// FlushUserSessionCache removes the cached sessions of a user, e.g. after permissions have changed.
+func FlushUserSessionCache(userUid string) {
+	if userUid == "" {
+		return
+	}
+
+	if c := shared(); c != nil {
+		prefix := userSessionCachePrefix + userUid + ":"
+		keys, err := c.Keys(prefix)
+
+		if err != nil {
+			log.Warnf("cache: %s (flush user sessions)", err)
+			return
+		}
+
+		for _, key := range keys {
+			deleteShared(c, sessionCachePrefix+strings.TrimPrefix(key, prefix), key)
+		}
+
+		return
+	}
+
+	for id, item := range sessionCache.Items() {
+		if s, ok := item.Object.(*Session); ok && s.UserUID == userUid {
+			sessionCache.Delete(id)
+		}
+	}
 }
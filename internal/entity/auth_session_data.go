@@ -19,8 +19,9 @@ func (u UIDs) Join(s string) string {
 
 // SessionData represents User Session data.
 type SessionData struct {
-	Tokens []string `json:"tokens"` // Share Tokens.
-	Shares UIDs     `json:"shares"` // Share UIDs.
+	Tokens   []string `json:"tokens"`             // Share Tokens.
+	Shares   UIDs     `json:"shares"`             // Share UIDs.
+	Unlocked UIDs     `json:"unlocked,omitempty"` // Unlocked album UIDs.
 }
 
 // NewSessionData creates a new session data struct and returns a pointer to it.
@@ -95,6 +96,58 @@ func (data SessionData) HasShare(uid string) bool {
 	return false
 }
 
+// NoDownload checks if downloading originals has been disabled for any of the share links redeemed in the session.
+func (data SessionData) NoDownload() bool {
+	for _, token := range data.Tokens {
+		if FindValidLinks(token, "").NoDownload() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WatermarkLink returns the first share link redeemed in the session that has watermarks enabled, or nil if there is none.
+func (data SessionData) WatermarkLink() *Link {
+	for _, token := range data.Tokens {
+		if link := FindValidLinks(token, "").WatermarkLink(); link != nil {
+			return link
+		}
+	}
+
+	return nil
+}
+
+// CommentLink returns the first share link for the entity redeemed in the session that allows guest comments, or nil if there is none.
+func (data SessionData) CommentLink(shareUid string) *Link {
+	if shareUid == "" {
+		return nil
+	}
+
+	for _, token := range data.Tokens {
+		if link := FindValidLinks(token, shareUid).CommentLink(); link != nil {
+			return link
+		}
+	}
+
+	return nil
+}
+
+// ReactLink returns the first share link for the entity redeemed in the session that allows guest reactions, or nil if there is none.
+func (data SessionData) ReactLink(shareUid string) *Link {
+	if shareUid == "" {
+		return nil
+	}
+
+	for _, token := range data.Tokens {
+		if link := FindValidLinks(token, shareUid).ReactLink(); link != nil {
+			return link
+		}
+	}
+
+	return nil
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (data SessionData) SharedUIDs() UIDs {
 	if len(data.Tokens) > 0 && len(data.Shares) == 0 {
@@ -103,3 +156,27 @@ func (data SessionData) SharedUIDs() UIDs {
 
 	return data.Shares
 }
+
+// HasUnlocked checks if the specified locked album has been unlocked.
+func (data SessionData) HasUnlocked(uid string) bool {
+	if uid == "" {
+		return false
+	}
+
+	for _, unlocked := range data.Unlocked {
+		if unlocked == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unlock adds the specified album to the list of unlocked albums.
+func (data *SessionData) Unlock(uid string) {
+	if uid == "" || data.HasUnlocked(uid) {
+		return
+	}
+
+	data.Unlocked = append(data.Unlocked, uid)
+}
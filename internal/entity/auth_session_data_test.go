@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
 func TestUIDs_String(t *testing.T) {
@@ -21,3 +23,31 @@ func TestData_HasShare(t *testing.T) {
 	assert.True(t, data.HasShare("def444"))
 	assert.False(t, data.HasShare("xxx"))
 }
+
+func TestData_Unlock(t *testing.T) {
+	data := SessionData{}
+	assert.False(t, data.HasUnlocked("as6sg6bxpogaaba7"))
+	data.Unlock("as6sg6bxpogaaba7")
+	data.Unlock("as6sg6bxpogaaba7")
+	assert.True(t, data.HasUnlocked("as6sg6bxpogaaba7"))
+	assert.False(t, data.HasUnlocked(""))
+	assert.Len(t, data.Unlocked, 1)
+}
+
+func TestData_NoDownload(t *testing.T) {
+	t.Run("Allowed", func(t *testing.T) {
+		data := SessionData{Tokens: []string{"1jxf3jfn2k"}}
+		assert.False(t, data.NoDownload())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
+		link.NoDownload = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		data := SessionData{Tokens: []string{"1jxf3jfn2k", link.LinkToken}}
+		assert.True(t, data.NoDownload())
+	})
+}
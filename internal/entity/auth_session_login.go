@@ -66,6 +66,14 @@ func AuthLocal(user *User, f form.Login, m *Session) (err error) {
 			m.Status = http.StatusUnauthorized
 		}
 		return i18n.Error(i18n.ErrInvalidCredentials)
+	} else if PasskeyOnly && HasPasskeys(user.UserUID) {
+		message := "password login disabled, passkey required"
+		if m != nil {
+			event.AuditWarn([]string{m.IP(), "session %s", "login as %s", message}, m.RefID, clean.LogQuote(name))
+			event.LoginError(m.IP(), "api", name, m.UserAgent, message)
+			m.Status = http.StatusUnauthorized
+		}
+		return i18n.Error(i18n.ErrInvalidCredentials)
 	}
 
 	// Password valid?
@@ -115,6 +123,15 @@ func (m *Session) LogIn(f form.Login, c *gin.Context) (err error) {
 	if f.HasToken() {
 		user = m.User()
 
+		// Check the password if the share link is protected, unless it was used to log in.
+		if !f.HasCredentials() && FindValidLinks(f.AuthToken, "").InvalidPassword(f.Password) {
+			limiter.Login.Reserve(m.IP())
+			event.AuditWarn([]string{m.IP(), "session %s", "share token %s", "invalid password"}, m.RefID, clean.LogQuote(f.AuthToken))
+			event.LoginError(m.IP(), "api", "", m.UserAgent, "invalid share link password")
+			m.Status = http.StatusUnauthorized
+			return i18n.Error(i18n.ErrInvalidPassword)
+		}
+
 		// Redeem token.
 		if user.IsRegistered() {
 			if shares := user.RedeemToken(f.AuthToken); shares == 0 {
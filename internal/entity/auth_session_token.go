@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// NewAccessToken creates a long-lived session for use by apps and devices, e.g. a digital photo frame.
+// The token never expires if maxAge is 0 and has no inactivity timeout.
+func NewAccessToken(u *User, clientName string, scope acl.Scopes, maxAge int64) *Session {
+	m := NewSession(maxAge, 0).SetUser(u).SetScope(scope)
+	m.SetMethod(authn.MethodAccessToken)
+	m.ClientName = clean.Name(clientName)
+
+	return m
+}
+
+// Method returns the authentication method.
+func (m *Session) Method() authn.MethodType {
+	return authn.Method(m.AuthMethod)
+}
+
+// SetMethod updates the session's authentication method.
+func (m *Session) SetMethod(method authn.MethodType) *Session {
+	if method == "" {
+		return m
+	}
+
+	m.AuthMethod = method.String()
+
+	return m
+}
+
+// IsAccessToken checks if the session was created as an access token.
+func (m *Session) IsAccessToken() bool {
+	return m.Method() == authn.MethodAccessToken
+}
+
+// Scope returns the access scopes granted to the session. An empty scope grants full access.
+func (m *Session) Scope() acl.Scopes {
+	return acl.ParseScope(m.AuthScope)
+}
+
+// SetScope restricts the session to the specified access scopes.
+func (m *Session) SetScope(scope acl.Scopes) *Session {
+	if scope.Full() {
+		m.AuthScope = ""
+	} else {
+		m.AuthScope = clean.Clip(scope.String(), 1024)
+	}
+
+	return m
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestNewAccessToken(t *testing.T) {
+	t.Run("ReadOnly", func(t *testing.T) {
+		m := NewAccessToken(UserFixtures.Pointer("alice"), "Photo Frame", acl.ParseScope("photos.read"), 0)
+
+		assert.True(t, rnd.IsSessionID(m.ID))
+		assert.Equal(t, "Photo Frame", m.ClientName)
+		assert.Equal(t, "photos.read", m.AuthScope)
+		assert.Equal(t, authn.MethodAccessToken, m.Method())
+		assert.True(t, m.IsAccessToken())
+		assert.True(t, m.ExpiresAt().IsZero())
+		assert.Equal(t, int64(0), m.SessTimeout)
+		assert.Equal(t, UserFixtures.Pointer("alice").UserUID, m.UserUID)
+		assert.False(t, m.Scope().Full())
+	})
+	t.Run("FullAccess", func(t *testing.T) {
+		m := NewAccessToken(UserFixtures.Pointer("alice"), "Backup", acl.ParseScope("admin"), UnixDay)
+
+		assert.Equal(t, "", m.AuthScope)
+		assert.True(t, m.Scope().Full())
+		assert.False(t, m.ExpiresAt().IsZero())
+	})
+}
+
+func TestSession_SetMethod(t *testing.T) {
+	m := NewSession(UnixDay, UnixHour)
+
+	assert.False(t, m.IsAccessToken())
+	assert.Equal(t, authn.MethodDefault, authn.MethodType(m.Method().String()))
+
+	m.SetMethod(authn.MethodUnknown)
+	assert.Equal(t, "", m.AuthMethod)
+
+	m.SetMethod(authn.MethodSession)
+	assert.Equal(t, "session", m.AuthMethod)
+}
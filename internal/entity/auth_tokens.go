@@ -21,6 +21,56 @@ func InvalidDownloadToken(t string) bool {
 	return CheckTokens && DownloadToken.Missing(t)
 }
 
+// tokenSession returns the session a preview or download token belongs to, or nil if there is none.
+func tokenSession(tokens *StringMap, t string) *Session {
+	id := tokens.Get(t)
+
+	if id == "" || id == TokenConfig {
+		return nil
+	}
+
+	if s, err := FindSession(id); err != nil {
+		return nil
+	} else {
+		return s
+	}
+}
+
+// NoDownloadToken checks if the download token belongs to a session that must not download originals,
+// e.g. because the share link it was created with has downloads disabled.
+func NoDownloadToken(t string) bool {
+	if !CheckTokens {
+		return false
+	}
+
+	if s := tokenSession(DownloadToken, t); s == nil {
+		return false
+	} else {
+		return s.NoDownload()
+	}
+}
+
+// TokenSession returns the session the preview or download token belongs to, or nil if there is none.
+func TokenSession(t string) *Session {
+	if s := tokenSession(PreviewToken, t); s != nil {
+		return s
+	}
+
+	return tokenSession(DownloadToken, t)
+}
+
+// WatermarkLink returns the share link whose watermark must be applied to images requested
+// with the preview or download token, or nil if no watermark is required.
+func WatermarkLink(t string) *Link {
+	s := TokenSession(t)
+
+	if s == nil {
+		return nil
+	}
+
+	return s.WatermarkLink()
+}
+
 // InvalidPreviewToken checks if the preview token is unknown.
 func InvalidPreviewToken(t string) bool {
 	return CheckTokens && PreviewToken.Missing(t) && DownloadToken.Missing(t)
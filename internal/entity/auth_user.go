@@ -34,6 +34,9 @@ var UsernameLength = 1
 // PasswordLength specifies the minimum length of the password in characters.
 var PasswordLength = 4
 
+// PasskeyOnly disables password login for users who have registered a passkey.
+var PasskeyOnly = false
+
 // UsersPath is the relative path for user assets.
 var UsersPath = "users"
 
@@ -52,6 +55,7 @@ type User struct {
 	UserEmail     string        `gorm:"size:255;index;" json:"Email" yaml:"Email,omitempty"`
 	BackupEmail   string        `gorm:"size:255;" json:"BackupEmail,omitempty" yaml:"BackupEmail,omitempty"`
 	UserRole      string        `gorm:"size:64;default:'';" json:"Role" yaml:"Role,omitempty"`
+	GroupRole     string        `gorm:"-" json:"GroupRole,omitempty" yaml:"-"`
 	UserAttr      string        `gorm:"size:1024;" json:"Attr" yaml:"Attr,omitempty"`
 	SuperAdmin    bool          `json:"SuperAdmin" yaml:"SuperAdmin,omitempty"`
 	CanLogin      bool          `json:"CanLogin" yaml:"CanLogin,omitempty"`
@@ -60,6 +64,7 @@ type User struct {
 	WebDAV        bool          `gorm:"column:webdav;" json:"WebDAV" yaml:"WebDAV,omitempty"`
 	BasePath      string        `gorm:"type:VARBINARY(1024);" json:"BasePath" yaml:"BasePath,omitempty"`
 	UploadPath    string        `gorm:"type:VARBINARY(1024);" json:"UploadPath" yaml:"UploadPath,omitempty"`
+	UserQuota     int           `gorm:"default:0;" json:"Quota" yaml:"Quota,omitempty"`
 	CanInvite     bool          `json:"CanInvite" yaml:"CanInvite,omitempty"`
 	InviteToken   string        `gorm:"type:VARBINARY(64);index;" json:"-" yaml:"-"`
 	InvitedBy     string        `gorm:"size:64;" json:"-" yaml:"-"`
@@ -312,6 +317,7 @@ func (m *User) Deleted() bool {
 func (m *User) LoadRelated() *User {
 	m.Settings()
 	m.Details()
+	m.GroupRole = UserGroupRole(m.UserUID).String()
 
 	return m
 }
@@ -620,7 +626,7 @@ func (m *User) SetRole(role string) *User {
 	case "", "0", "false", "nil", "null", "nan":
 		m.UserRole = acl.RoleUnknown.String()
 	default:
-		m.UserRole = acl.ValidRoles[role].String()
+		m.UserRole = acl.FindRole(role).String()
 	}
 
 	return m
@@ -628,13 +634,18 @@ func (m *User) SetRole(role string) *User {
 
 // HasRole checks the user role specified as string.
 func (m *User) HasRole(role string) bool {
-	return m.AclRole().String() == acl.ValidRoles[clean.Role(role)].String()
+	return m.AclRole().String() == acl.FindRole(clean.Role(role)).String()
 }
 
 // AclRole returns the user role for ACL permission checks.
 func (m *User) AclRole() acl.Role {
 	role := clean.Role(m.UserRole)
 
+	// Group memberships may grant more privileges, but never reduce them.
+	if m.GroupRole != "" {
+		role = acl.Resources.MorePrivileged(acl.FindRole(role), acl.FindRole(clean.Role(m.GroupRole))).String()
+	}
+
 	switch {
 	case m.SuperAdmin:
 		return acl.RoleAdmin
@@ -643,7 +654,7 @@ func (m *User) AclRole() acl.Role {
 	case m.UserName == "":
 		return acl.RoleVisitor
 	default:
-		return acl.ValidRoles[role]
+		return acl.FindRole(role)
 	}
 }
 
@@ -828,7 +839,7 @@ func (m *User) Validate() (err error) {
 	}
 
 	// Validate user role.
-	if acl.ValidRoles[m.UserRole] == "" {
+	if acl.FindRole(m.UserRole) == acl.RoleUnknown {
 		return fmt.Errorf("role %s is invalid", clean.LogQuote(m.UserRole))
 	}
 
@@ -1057,6 +1068,11 @@ func (m *User) SaveForm(f form.User, updateRights bool) error {
 		m.SetProvider(f.Provider())
 		m.SetBasePath(f.BasePath)
 		m.SetUploadPath(f.UploadPath)
+		m.SetQuota(f.UserQuota)
+
+		if f.NSFWPolicy != "" {
+			m.SetNSFWPolicy(f.NSFWPolicy)
+		}
 	}
 
 	// Ensure super admins never have a non-admin role.
@@ -56,6 +56,16 @@ func (m *User) SetValuesFromCli(ctx *cli.Context) error {
 		m.SetUploadPath(frm.UploadPath)
 	}
 
+	// Storage quota in MB.
+	if ctx.IsSet("quota") {
+		m.SetQuota(frm.UserQuota)
+	}
+
+	// Policy for pictures that may be offensive.
+	if ctx.IsSet("nsfw") {
+		m.SetNSFWPolicy(frm.NSFWPolicy)
+	}
+
 	return m.Validate()
 }
 
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity/legacy"
@@ -49,7 +50,7 @@ func FindLegacyUsers(search string) legacy.Users {
 	} else if rnd.IsUID(search, UserUID) {
 		stmt = stmt.Where("user_uid = ?", search)
 	} else if search != "" {
-		stmt = stmt.Where("user_name LIKE ? OR primary_email LIKE ? OR full_name LIKE ?", search+"%", search+"%", search+"%")
+		stmt = stmt.Where(fmt.Sprintf("user_name %[1]s ? OR primary_email %[1]s ? OR full_name %[1]s ?", LikeOp()), search+"%", search+"%", search+"%")
 	} else {
 		stmt = stmt.Where("id > 0")
 	}
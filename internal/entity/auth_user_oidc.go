@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// FindOidcUser returns the user with the specified OpenID Connect subject or nil if it was not found.
+func FindOidcUser(subject string) *User {
+	if subject == "" {
+		return nil
+	}
+
+	m := &User{}
+
+	// Users are matched by subject only, so existing local accounts cannot be taken over by an identity provider.
+	if err := UnscopedDb().
+		Where("auth_provider = ? AND auth_id = ?", authn.ProviderOIDC.String(), subject).
+		First(m).Error; err != nil {
+		return nil
+	}
+
+	return m.LoadRelated()
+}
+
+// OidcUser returns the user account for the specified OpenID Connect claims, updates its role, email and display name,
+// and creates a new account on first login if register is true.
+func OidcUser(claims authn.Claims, role acl.Role, register bool) (*User, error) {
+	subject := claims.String("sub")
+
+	if subject == "" {
+		return nil, fmt.Errorf("subject claim is missing")
+	} else if role == acl.RoleUnknown {
+		return nil, fmt.Errorf("no role assigned")
+	}
+
+	email := clean.Email(claims.String("email"))
+	displayName := clean.Name(claims.String("name"))
+
+	// Update existing account.
+	if m := FindOidcUser(subject); m != nil {
+		if m.Deleted() {
+			return nil, fmt.Errorf("account %s has been deleted", clean.LogQuote(m.Username()))
+		}
+
+		values := Values{}
+
+		if !m.SuperAdmin && m.UserRole != role.String() {
+			m.UserRole = role.String()
+			values["UserRole"] = m.UserRole
+		}
+
+		if email != "" && m.UserEmail != email {
+			m.UserEmail = email
+			values["UserEmail"] = m.UserEmail
+		}
+
+		if displayName != "" && m.DisplayName != displayName {
+			m.DisplayName = displayName
+			values["DisplayName"] = m.DisplayName
+		}
+
+		if len(values) == 0 {
+			return m, nil
+		} else if err := m.Updates(values); err != nil {
+			return m, err
+		}
+
+		FlushUserSessionCache(m.UserUID)
+
+		return m, nil
+	} else if !register {
+		return nil, fmt.Errorf("account for subject %s does not exist", clean.LogQuote(subject))
+	}
+
+	// Create new account.
+	m := NewUser()
+	m.SetProvider(authn.ProviderOIDC)
+	m.AuthID = subject
+	m.UserEmail = email
+	m.DisplayName = displayName
+	m.UserRole = role.String()
+	m.CanLogin = true
+
+	if err := m.SetUsername(oidcUsername(claims)); err != nil {
+		return nil, err
+	} else if err = m.Validate(); err != nil {
+		return nil, err
+	} else if err = m.Create(); err != nil {
+		return nil, err
+	}
+
+	event.AuditInfo([]string{"user %s", "created", "provider %s"}, clean.LogQuote(m.Username()), authn.ProviderOIDC.Pretty())
+
+	return m, nil
+}
+
+// oidcUsername returns the preferred username based on the OpenID Connect claims.
+func oidcUsername(claims authn.Claims) string {
+	for _, name := range []string{"preferred_username", "nickname", "email", "sub"} {
+		if s := clean.Username(claims.String(name)); s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestOidcUser(t *testing.T) {
+	claims := authn.Claims{
+		"sub":                "248289761001",
+		"preferred_username": "jane.oidc",
+		"email":              "jane.oidc@example.com",
+		"name":               "Jane Doe",
+	}
+
+	t.Run("NoSubject", func(t *testing.T) {
+		m, err := OidcUser(authn.Claims{"preferred_username": "jane.oidc"}, acl.RoleAdmin, true)
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("NoRole", func(t *testing.T) {
+		m, err := OidcUser(claims, acl.RoleUnknown, true)
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("NotRegistered", func(t *testing.T) {
+		m, err := OidcUser(claims, acl.RoleVisitor, false)
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("Register", func(t *testing.T) {
+		m, err := OidcUser(claims, acl.RoleVisitor, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "jane.oidc", m.Username())
+		assert.Equal(t, "jane.oidc@example.com", m.Email())
+		assert.Equal(t, "Jane Doe", m.DisplayName)
+		assert.Equal(t, acl.RoleVisitor, m.AclRole())
+		assert.True(t, m.HasProvider(authn.ProviderOIDC))
+		assert.True(t, m.CanLogin)
+	})
+	t.Run("Update", func(t *testing.T) {
+		claims["name"] = "Jane Smith"
+
+		m, err := OidcUser(claims, acl.RoleAdmin, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Jane Smith", m.DisplayName)
+		assert.Equal(t, acl.RoleAdmin, m.AclRole())
+		assert.Equal(t, m.UserUID, FindOidcUser("248289761001").UserUID)
+	})
+	t.Run("UsernameTaken", func(t *testing.T) {
+		m, err := OidcUser(authn.Claims{"sub": "248289761002", "preferred_username": "alice"}, acl.RoleVisitor, true)
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+}
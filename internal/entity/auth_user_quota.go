@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"fmt"
+)
+
+// UserUsage represents the storage usage of a user account in bytes.
+type UserUsage struct {
+	UserUID  string `json:"UID"`
+	Quota    int64  `json:"Quota"`
+	Used     int64  `json:"Used"`
+	Files    int    `json:"Files"`
+	Exceeded bool   `json:"Exceeded"`
+}
+
+// SetQuota sets the storage quota for originals in MB, 0 means unlimited.
+func (m *User) SetQuota(megabytes int) *User {
+	if megabytes < 0 {
+		megabytes = 0
+	}
+
+	m.UserQuota = megabytes
+
+	return m
+}
+
+// HasQuota checks if the storage usage of this user is limited.
+func (m *User) HasQuota() bool {
+	return m.UserQuota > 0
+}
+
+// QuotaBytes returns the storage quota in bytes, 0 means unlimited.
+func (m *User) QuotaBytes() int64 {
+	if m.UserQuota <= 0 {
+		return 0
+	}
+
+	return int64(m.UserQuota) * 1024 * 1024
+}
+
+// StorageUsage returns the size and number of the originals added by this user.
+func (m *User) StorageUsage() (used int64, files int, err error) {
+	if m.UserUID == "" {
+		return 0, 0, fmt.Errorf("user uid is missing")
+	}
+
+	var result struct {
+		Used  int64
+		Files int
+	}
+
+	err = UnscopedDb().Table(File{}.TableName()).
+		Select("COALESCE(SUM(files.file_size), 0) AS used, COUNT(*) AS files").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Where("photos.created_by = ? AND files.file_root = ?", m.UserUID, RootOriginals).
+		Where("files.file_missing = 0 AND files.deleted_at IS NULL").
+		Scan(&result).Error
+
+	return result.Used, result.Files, err
+}
+
+// Usage returns the storage quota and usage of this user.
+func (m *User) Usage() (result UserUsage, err error) {
+	result.UserUID = m.UserUID
+	result.Quota = m.QuotaBytes()
+
+	if result.Used, result.Files, err = m.StorageUsage(); err != nil {
+		return result, err
+	}
+
+	result.Exceeded = m.HasQuota() && result.Used >= result.Quota
+
+	return result, nil
+}
+
+// QuotaExceeded checks if adding the number of bytes would exceed the storage quota of this user.
+func (m *User) QuotaExceeded(size int64) bool {
+	if !m.HasQuota() {
+		return false
+	}
+
+	used, _, err := m.StorageUsage()
+
+	if err != nil {
+		log.Warnf("user: %s (get storage usage)", err)
+		return false
+	}
+
+	return used+size > m.QuotaBytes()
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_SetQuota(t *testing.T) {
+	m := NewUser()
+
+	assert.False(t, m.HasQuota())
+	assert.Equal(t, int64(0), m.QuotaBytes())
+
+	m.SetQuota(100)
+
+	assert.True(t, m.HasQuota())
+	assert.Equal(t, int64(100*1024*1024), m.QuotaBytes())
+
+	m.SetQuota(-1)
+
+	assert.False(t, m.HasQuota())
+	assert.Equal(t, 0, m.UserQuota)
+}
+
+func TestUser_Usage(t *testing.T) {
+	t.Run("Alice", func(t *testing.T) {
+		m := UserFixtures.Pointer("alice")
+
+		usage, err := m.Usage()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.UserUID, usage.UserUID)
+		assert.GreaterOrEqual(t, usage.Used, int64(0))
+		assert.False(t, usage.Exceeded)
+	})
+	t.Run("QuotaExceeded", func(t *testing.T) {
+		m := *UserFixtures.Pointer("alice")
+
+		assert.False(t, m.QuotaExceeded(1024*1024*1024))
+
+		m.SetQuota(1)
+
+		assert.True(t, m.QuotaExceeded(2*1024*1024))
+	})
+	t.Run("NoUID", func(t *testing.T) {
+		m := &User{}
+
+		_, err := m.Usage()
+
+		assert.Error(t, err)
+	})
+}
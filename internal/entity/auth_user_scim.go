@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ScimUsers returns registered users matching an optional SCIM equality filter, together with the total number of matches.
+func ScimUsers(attr, value string, offset, limit int) (result Users, total int, err error) {
+	result = Users{}
+	stmt := Db().Model(&User{}).Where("id > 0")
+
+	switch attr {
+	case "":
+	case "username":
+		stmt = stmt.Where("user_name = ?", clean.Username(value))
+	case "externalid":
+		stmt = stmt.Where("auth_id <> '' AND auth_id = ?", value)
+	case "emails", "emails.value":
+		stmt = stmt.Where("user_email = ?", clean.Email(value))
+	case "id":
+		stmt = stmt.Where("user_uid = ?", clean.UID(value))
+	default:
+		return result, 0, fmt.Errorf("unsupported filter attribute %s", clean.Log(attr))
+	}
+
+	if err = stmt.Count(&total).Error; err != nil {
+		return result, 0, err
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit)
+	}
+
+	if offset > 0 {
+		stmt = stmt.Offset(offset)
+	}
+
+	err = stmt.Order("user_name, id").Find(&result).Error
+
+	return result, total, err
+}
+
+// SetExternalID sets the identity provider's unique user id. The authentication provider remains unchanged,
+// so that users can still log in with a password unless an admin changes it.
+func (m *User) SetExternalID(id string) *User {
+	if id = clean.Clip(id, 255); id == "" {
+		return m
+	}
+
+	m.AuthID = id
+
+	return m
+}
+
+// ExternalID returns the identity provider's unique user id, if any.
+func (m *User) ExternalID() string {
+	return m.AuthID
+}
+
+// Active checks if the account has not been deactivated or deleted.
+func (m *User) Active() bool {
+	return (m.CanLogin || m.SuperAdmin) && !m.Deleted()
+}
+
+// SetActive activates or deactivates the account and invalidates all sessions when it is deactivated.
+func (m *User) SetActive(active bool) error {
+	if m.ID <= 1 && !active {
+		return fmt.Errorf("cannot deactivate system user")
+	}
+
+	m.CanLogin = active
+
+	if err := m.Updates(Values{"CanLogin": m.CanLogin}); err != nil {
+		return err
+	}
+
+	if !active {
+		m.DeleteSessions([]string{""})
+	}
+
+	FlushUserSessionCache(m.UserUID)
+
+	return nil
+}
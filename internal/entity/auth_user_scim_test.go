@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestScimUsers(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, total, err := ScimUsers("", "", 0, 0)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, total, 1)
+		assert.Len(t, result, total)
+	})
+	t.Run("UserName", func(t *testing.T) {
+		result, total, err := ScimUsers("username", "alice", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, "alice", result[0].Username())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, _, err := ScimUsers("foo", "bar", 0, 10)
+		assert.Error(t, err)
+	})
+}
+
+func TestUser_SetExternalID(t *testing.T) {
+	m := NewUser()
+	m.SetProvider(authn.ProviderLocal)
+	assert.Equal(t, "", m.ExternalID())
+
+	m.SetExternalID("00u1abc")
+	assert.Equal(t, "00u1abc", m.ExternalID())
+
+	// The authentication provider must not change, so that password login remains possible.
+	assert.True(t, m.HasProvider(authn.ProviderLocal))
+}
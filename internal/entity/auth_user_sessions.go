@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// FindUserSessions returns the active sessions and access tokens of a user, most recently used first.
+func FindUserSessions(userUid string) (result Sessions) {
+	result = Sessions{}
+
+	if rnd.InvalidUID(userUid, UserUID) {
+		return result
+	}
+
+	found := Sessions{}
+
+	if err := Db().Where("user_uid = ? AND (sess_expires = 0 OR sess_expires > ?)", userUid, UnixTime()).
+		Order("last_active DESC, created_at DESC").Find(&found).Error; err != nil {
+		log.Errorf("sessions: %s", err)
+		return result
+	}
+
+	// Skip sessions that have timed out due to inactivity.
+	for _, s := range found {
+		if !s.Expired() {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// FindUserSession returns the user session with the specified ref ID or nil if it was not found.
+func FindUserSession(userUid, refId string) *Session {
+	if m := FindSessionByRefID(refId); m == nil || m.UserUID != userUid || m.UserUID == "" {
+		return nil
+	} else {
+		return m
+	}
+}
+
+// SetClientName changes the name of the client, so that users can recognize their sessions.
+func (m *Session) SetClientName(name string) error {
+	if m.ID == "" {
+		return fmt.Errorf("empty session id")
+	}
+
+	m.ClientName = clean.Clip(clean.Name(name), 200)
+
+	if err := m.Updates(Values{"ClientName": m.ClientName}); err != nil {
+		return err
+	}
+
+	DeleteFromSessionCache(m.ID)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUserSessions(t *testing.T) {
+	alice := UserFixtures.Pointer("alice")
+
+	m := NewSession(UnixDay, UnixHour).SetUser(alice)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, s := range FindUserSessions(alice.UserUID) {
+		if s.RefID == m.RefID {
+			found = true
+		}
+	}
+
+	assert.True(t, found)
+	assert.NotNil(t, FindUserSession(alice.UserUID, m.RefID))
+	assert.Nil(t, FindUserSession(UserFixtures.Pointer("bob").UserUID, m.RefID))
+	assert.Len(t, FindUserSessions("foo"), 0)
+
+	assert.NoError(t, m.SetClientName("Jane's Laptop"))
+	assert.Equal(t, "Jane's Laptop", FindSessionByRefID(m.RefID).ClientName)
+
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindUserSession(alice.UserUID, m.RefID))
+}
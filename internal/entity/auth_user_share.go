@@ -118,8 +118,9 @@ func FindUserShares(userUid string) UserShares {
 		return found
 	}
 
-	// Find matching record.
-	if err := UnscopedDb().Find(&found, "user_uid = ? AND (expires_at IS NULL OR expires_at > ?)", userUid, TimeStamp()).Error; err != nil {
+	// Find matching records, including shares with groups the user is a member of.
+	if err := UnscopedDb().Find(&found, "(user_uid = ? OR user_uid IN (SELECT group_uid FROM auth_groups_users WHERE user_uid = ?)) AND (expires_at IS NULL OR expires_at > ?)",
+		userUid, userUid, TimeStamp()).Error; err != nil {
 		event.AuditWarn([]string{"user %s", "find shares", "%s"}, clean.Log(userUid), err)
 		return nil
 	}
@@ -129,7 +130,7 @@ func FindUserShares(userUid string) UserShares {
 
 // HasID tests if the entity has a valid uid.
 func (m *UserShare) HasID() bool {
-	return rnd.IsUID(m.UserUID, UserUID) && rnd.IsUID(m.ShareUID, 0)
+	return (rnd.IsUID(m.UserUID, UserUID) || rnd.IsUID(m.UserUID, GroupUID)) && rnd.IsUID(m.ShareUID, 0)
 }
 
 // Create inserts a new record into the database.
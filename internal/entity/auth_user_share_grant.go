@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Share grant permissions that can be assigned to users.
+const (
+	GrantView       = "view"
+	GrantContribute = "contribute"
+)
+
+// GrantPerm returns the share permission flags for the specified grant name.
+func GrantPerm(grant string) (uint, error) {
+	switch grant {
+	case "", GrantView:
+		return PermView, nil
+	case GrantContribute:
+		return PermView | PermUpload, nil
+	default:
+		return PermNone, fmt.Errorf("unknown grant %s", grant)
+	}
+}
+
+// GrantName returns the name of the grant that corresponds to the share permissions.
+func (m *UserShare) GrantName() string {
+	if m.CanContribute() {
+		return GrantContribute
+	}
+
+	return GrantView
+}
+
+// CanContribute checks if the user may add content, e.g. upload photos to a shared album.
+func (m *UserShare) CanContribute() bool {
+	return m.Perm&(PermUpload|PermAll) != 0
+}
+
+// FindShareGrants returns the users and groups with direct access to the shared album or folder.
+func FindShareGrants(shareUid string) UserShares {
+	found := UserShares{}
+
+	if !rnd.IsUID(shareUid, AlbumUID) {
+		return found
+	}
+
+	if err := UnscopedDb().Where("share_uid = ?", shareUid).Order("created_at").Find(&found).Error; err != nil {
+		log.Errorf("share: %s", err)
+	}
+
+	return found
+}
+
+// GrantShare grants a user or group direct access to an album or folder. Existing grants are updated.
+func GrantShare(userUid, shareUid string, perm uint, expires *time.Time) (*UserShare, error) {
+	if rnd.InvalidUID(userUid, UserUID) && rnd.InvalidUID(userUid, GroupUID) {
+		return nil, fmt.Errorf("invalid user or group uid")
+	} else if !rnd.IsUID(shareUid, AlbumUID) {
+		return nil, fmt.Errorf("invalid album uid")
+	}
+
+	m := FindUserShare(UserShare{UserUID: userUid, ShareUID: shareUid})
+
+	if m == nil {
+		m = NewUserShare(userUid, shareUid, perm, expires)
+
+		if err := m.Create(); err != nil {
+			return nil, err
+		}
+	} else if err := m.Updates(Values{"perm": perm, "expires_at": expires, "updated_at": TimeStamp()}); err != nil {
+		return nil, err
+	} else {
+		m.Perm = perm
+		m.ExpiresAt = expires
+	}
+
+	flushShareSessions(userUid)
+
+	return m, nil
+}
+
+// RevokeShare removes the direct access of a user or group to an album or folder.
+func RevokeShare(userUid, shareUid string) error {
+	if rnd.InvalidUID(userUid, UserUID) && rnd.InvalidUID(userUid, GroupUID) {
+		return fmt.Errorf("invalid user or group uid")
+	}
+
+	if err := UnscopedDb().Delete(UserShare{}, "user_uid = ? AND share_uid = ?", userUid, shareUid).Error; err != nil {
+		return err
+	}
+
+	flushShareSessions(userUid)
+
+	return nil
+}
+
+// flushShareSessions removes the cached sessions of the user or group members so that changes take effect.
+func flushShareSessions(uid string) {
+	if rnd.IsUID(uid, GroupUID) {
+		for _, memberUid := range (&Group{GroupUID: uid}).MemberUIDs() {
+			FlushUserSessionCache(memberUid)
+		}
+	} else {
+		FlushUserSessionCache(uid)
+	}
+}
+
+// SharedFolderPaths returns the paths of the folders among the specified shared UIDs.
+func SharedFolderPaths(uids UIDs) (paths []string) {
+	if len(uids) == 0 {
+		return paths
+	}
+
+	if err := UnscopedDb().Model(Album{}).
+		Where("album_uid IN (?) AND album_type = ? AND album_path <> '' AND deleted_at IS NULL", uids, AlbumFolder).
+		Pluck("album_path", &paths).Error; err != nil {
+		log.Errorf("share: %s", err)
+	}
+
+	return paths
+}
+
+// ContributeUIDs returns the album UIDs to which the user may contribute content.
+func (m *User) ContributeUIDs(uids []string) (result UIDs) {
+	result = UIDs{}
+
+	if m.SharedUIDs(); m.UserShares.Empty() {
+		return result
+	}
+
+	for _, uid := range uids {
+		for _, share := range m.UserShares {
+			if share.ShareUID == uid && share.CanContribute() {
+				result = append(result, uid)
+				break
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantPerm(t *testing.T) {
+	perm, err := GrantPerm("view")
+	assert.NoError(t, err)
+	assert.Equal(t, PermView, perm)
+
+	perm, err = GrantPerm("contribute")
+	assert.NoError(t, err)
+	assert.Equal(t, PermView|PermUpload, perm)
+
+	_, err = GrantPerm("own")
+	assert.Error(t, err)
+}
+
+func TestGrantShare(t *testing.T) {
+	user := UserFixtures.Pointer("bob")
+	folderUid := "at1lxuqipogaaba1"
+
+	t.Run("InvalidUser", func(t *testing.T) {
+		_, err := GrantShare("xxx", folderUid, PermView, nil)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidAlbum", func(t *testing.T) {
+		_, err := GrantShare(user.UserUID, "xxx", PermView, nil)
+		assert.Error(t, err)
+	})
+	t.Run("Folder", func(t *testing.T) {
+		m, err := GrantShare(user.UserUID, folderUid, PermView, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.CanContribute())
+		assert.Equal(t, GrantView, m.GrantName())
+		assert.Contains(t, FindShareGrants(folderUid).UIDs(), folderUid)
+		assert.Equal(t, []string{"1990/04"}, SharedFolderPaths(UIDs{folderUid}))
+
+		m, err = GrantShare(user.UserUID, folderUid, PermView|PermUpload, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.CanContribute())
+		assert.Equal(t, GrantContribute, m.GrantName())
+
+		u := FindUserByUID(user.UserUID)
+		assert.Equal(t, UIDs{folderUid}, u.ContributeUIDs([]string{folderUid, "at9lxuqxpogaaba7"}))
+
+		assert.NoError(t, RevokeShare(user.UserUID, folderUid))
+		assert.Nil(t, FindUserShare(UserShare{UserUID: user.UserUID, ShareUID: folderUid}))
+	})
+}
@@ -0,0 +1,176 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Entity types for which changes are recorded.
+const (
+	ChangePhoto   = "photo"
+	ChangeAlbum   = "album"
+	ChangeSubject = "subject"
+)
+
+// Change actions.
+const (
+	ChangeActionUpdate = "update"
+	ChangeActionUndo   = "undo"
+)
+
+// Change represents a metadata edit with the previous and new values of the changed fields,
+// so that the edit can be reviewed and undone.
+type Change struct {
+	ID         uint            `gorm:"primary_key" json:"ID" yaml:"ID"`
+	EntityType string          `gorm:"type:VARBINARY(32);index:idx_changes_entity;" json:"EntityType" yaml:"EntityType"`
+	EntityUID  string          `gorm:"type:VARBINARY(42);index:idx_changes_entity;" json:"EntityUID" yaml:"EntityUID"`
+	Action     string          `gorm:"type:VARBINARY(16);default:'';" json:"Action" yaml:"Action"`
+	UserUID    string          `gorm:"type:VARBINARY(42);index;default:'';" json:"UserUID" yaml:"UserUID,omitempty"`
+	UserName   string          `gorm:"size:64;default:'';" json:"UserName" yaml:"UserName,omitempty"`
+	Before     json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"Before" yaml:"Before,omitempty"`
+	After      json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"After" yaml:"After,omitempty"`
+	CreatedAt  time.Time       `json:"CreatedAt" yaml:"CreatedAt"`
+	UndoneAt   *time.Time      `sql:"index" json:"UndoneAt" yaml:"UndoneAt,omitempty"`
+}
+
+// Changes represents a list of changes.
+type Changes []Change
+
+// TableName returns the entity table name.
+func (Change) TableName() string {
+	return "changes"
+}
+
+// NewChange compares the JSON representation of the forms passed and returns a change with the values
+// of the fields that differ, or nil if nothing has changed.
+func NewChange(entityType, entityUID, action string, before, after interface{}) *Change {
+	b, err := jsonFields(before)
+
+	if err != nil {
+		log.Warnf("change: %s (before)", err)
+		return nil
+	}
+
+	a, err := jsonFields(after)
+
+	if err != nil {
+		log.Warnf("change: %s (after)", err)
+		return nil
+	}
+
+	changedBefore := make(map[string]json.RawMessage)
+	changedAfter := make(map[string]json.RawMessage)
+
+	for k, v := range a {
+		if prev, ok := b[k]; !ok || !bytes.Equal(prev, v) {
+			changedBefore[k] = prev
+			changedAfter[k] = v
+		}
+	}
+
+	if len(changedAfter) == 0 {
+		return nil
+	}
+
+	m := &Change{
+		EntityType: entityType,
+		EntityUID:  entityUID,
+		Action:     action,
+		CreatedAt:  TimeStamp(),
+	}
+
+	m.Before, _ = json.Marshal(changedBefore)
+	m.After, _ = json.Marshal(changedAfter)
+
+	return m
+}
+
+// jsonFields returns the JSON encoded values of a struct by field name.
+func jsonFields(v interface{}) (result map[string]json.RawMessage, err error) {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &result)
+
+	return result, err
+}
+
+// SetUser sets the user who made the change.
+func (m *Change) SetUser(u *User) *Change {
+	if m == nil || u == nil {
+		return m
+	}
+
+	m.UserUID = u.UserUID
+	m.UserName = u.UserName
+
+	return m
+}
+
+// Fields returns the sorted names of the changed fields.
+func (m *Change) Fields() (fields []string) {
+	if m == nil {
+		return fields
+	}
+
+	values := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(m.After, &values); err != nil {
+		return fields
+	}
+
+	for k := range values {
+		fields = append(fields, k)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// Revert applies the previous values to the form passed, which must be a pointer.
+func (m *Change) Revert(f interface{}) error {
+	return json.Unmarshal(m.Before, f)
+}
+
+// Undone checks if the change has been undone.
+func (m *Change) Undone() bool {
+	return m.UndoneAt != nil
+}
+
+// Create inserts a new change into the database.
+func (m *Change) Create() error {
+	return Db().Create(m).Error
+}
+
+// SetUndone marks the change as undone.
+func (m *Change) SetUndone() error {
+	undoneAt := TimeStamp()
+
+	if err := Db().Model(m).UpdateColumn("undone_at", undoneAt).Error; err != nil {
+		return err
+	}
+
+	m.UndoneAt = &undoneAt
+
+	return nil
+}
+
+// FindLatestChange returns the most recent edit of an entity that has not been undone yet, or nil if none was found.
+func FindLatestChange(entityType, entityUID string) *Change {
+	m := Change{}
+
+	if err := Db().
+		Where("entity_type = ? AND entity_uid = ?", entityType, entityUID).
+		Where("action = ? AND undone_at IS NULL", ChangeActionUpdate).
+		Order("id DESC").First(&m).Error; err != nil {
+		return nil
+	}
+
+	return &m
+}
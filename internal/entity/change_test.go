@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestNewChange(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		before := form.Photo{PhotoTitle: "Old Title", PhotoDescription: "Same"}
+		after := form.Photo{PhotoTitle: "New Title", PhotoDescription: "Same"}
+
+		m := NewChange(ChangePhoto, "ps6sg6be2lvl0yh7", ChangeActionUpdate, before, after)
+
+		if m == nil {
+			t.Fatal("change must not be nil")
+		}
+
+		assert.Equal(t, ChangePhoto, m.EntityType)
+		assert.Equal(t, "ps6sg6be2lvl0yh7", m.EntityUID)
+		assert.Equal(t, ChangeActionUpdate, m.Action)
+		assert.Equal(t, []string{"Title"}, m.Fields())
+		assert.JSONEq(t, `{"Title":"Old Title"}`, string(m.Before))
+		assert.JSONEq(t, `{"Title":"New Title"}`, string(m.After))
+		assert.False(t, m.Undone())
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		f := form.Photo{PhotoTitle: "Title"}
+		assert.Nil(t, NewChange(ChangePhoto, "ps6sg6be2lvl0yh7", ChangeActionUpdate, f, f))
+	})
+}
+
+func TestChange_Revert(t *testing.T) {
+	before := form.Photo{PhotoTitle: "Old Title", PhotoFavorite: true}
+	after := form.Photo{PhotoTitle: "New Title", PhotoDescription: "Edited"}
+
+	m := NewChange(ChangePhoto, "ps6sg6be2lvl0yh7", ChangeActionUpdate, before, after)
+
+	f := form.Photo{PhotoTitle: "New Title", PhotoDescription: "Edited", PhotoCountry: "de"}
+
+	assert.NoError(t, m.Revert(&f))
+	assert.Equal(t, "Old Title", f.PhotoTitle)
+	assert.Equal(t, "", f.PhotoDescription)
+	assert.True(t, f.PhotoFavorite)
+	assert.Equal(t, "de", f.PhotoCountry)
+}
+
+func TestChange_SetUser(t *testing.T) {
+	m := &Change{}
+	m.SetUser(&Admin)
+	assert.Equal(t, Admin.UserUID, m.UserUID)
+	assert.Equal(t, Admin.UserName, m.UserName)
+
+	var empty *Change
+	assert.Nil(t, empty.SetUser(&Admin))
+	assert.Empty(t, empty.Fields())
+}
+
+func TestFindLatestChange(t *testing.T) {
+	before := form.Subject{SubjName: "Undo Before"}
+	after := form.Subject{SubjName: "Undo After"}
+
+	m := NewChange(ChangeSubject, "js6sg6b2h8njw0sx", ChangeActionUpdate, before, after)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindLatestChange(ChangeSubject, "js6sg6b2h8njw0sx")
+
+	if found == nil {
+		t.Fatal("change not found")
+	}
+
+	assert.Equal(t, m.ID, found.ID)
+	assert.NoError(t, found.SetUndone())
+	assert.True(t, found.Undone())
+	assert.Nil(t, FindLatestChange(ChangeSubject, "js6sg6b2h8njw0sx"))
+}
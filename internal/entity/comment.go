@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CommentUID is the comment UID prefix.
+const CommentUID = byte('k')
+
+// CommentMaxLength is the maximum length of a comment in characters.
+const CommentMaxLength = 2048
+
+// CommentNameLength is the maximum length of the author name displayed for guests.
+const CommentNameLength = 64
+
+// CommentGuestName is the author name displayed for guests who did not enter a name.
+const CommentGuestName = "Guest"
+
+// Comment represents a comment on a shared album or a picture in it, written either
+// by a registered user or by a guest with a share link that allows comments.
+type Comment struct {
+	CommentUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	AlbumUID    string    `gorm:"type:VARBINARY(42);index;" json:"AlbumUID" yaml:"AlbumUID"`
+	PhotoUID    string    `gorm:"type:VARBINARY(42);index;default:'';" json:"PhotoUID" yaml:"PhotoUID,omitempty"`
+	LinkUID     string    `gorm:"type:VARBINARY(42);default:'';" json:"LinkUID,omitempty" yaml:"LinkUID,omitempty"`
+	UserUID     string    `gorm:"type:VARBINARY(42);index;default:'';" json:"UserUID,omitempty" yaml:"UserUID,omitempty"`
+	AuthorRef   string    `gorm:"type:VARBINARY(42);default:'';" json:"-" yaml:"-"`
+	AuthorName  string    `gorm:"size:64;default:'';" json:"AuthorName" yaml:"AuthorName,omitempty"`
+	CommentText string    `gorm:"size:2048;" json:"Text" yaml:"Text,omitempty"`
+	Hidden      bool      `json:"Hidden" yaml:"Hidden,omitempty"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// Comments represents a list of comments.
+type Comments []Comment
+
+// TableName returns the entity table name.
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Comment) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUnique(m.CommentUID, CommentUID) {
+		return nil
+	}
+
+	return scope.SetColumn("CommentUID", rnd.GenerateUID(CommentUID))
+}
+
+// NewComment returns a new comment on an album or, if a photo UID is specified, a picture in it.
+func NewComment(albumUid, photoUid, text string) *Comment {
+	return &Comment{
+		AlbumUID:    albumUid,
+		PhotoUID:    photoUid,
+		CommentText: txt.Clip(strings.TrimSpace(text), CommentMaxLength),
+	}
+}
+
+// AuthorRef returns the UID of the registered user or, for guests, the ref ID of the session,
+// so that comments and reactions can be attributed to their author.
+func AuthorRef(s *Session) string {
+	if s == nil {
+		return ""
+	} else if user := s.User(); user.IsRegistered() {
+		return user.UserUID
+	}
+
+	return s.RefID
+}
+
+// SetAuthor sets the author of the comment based on the session, so that registered users and guests
+// can later delete their own comments. Guests may choose the name that is displayed.
+func (m *Comment) SetAuthor(s *Session, link *Link, name string) *Comment {
+	if s == nil {
+		return m
+	}
+
+	m.AuthorRef = AuthorRef(s)
+
+	if user := s.User(); user.IsRegistered() {
+		m.UserUID = user.UserUID
+		m.AuthorName = txt.Clip(user.FullName(), CommentNameLength)
+		return m
+	}
+
+	if link != nil {
+		m.LinkUID = link.LinkUID
+	}
+
+	if name = txt.Clip(clean.Name(name), CommentNameLength); name != "" {
+		m.AuthorName = name
+	} else {
+		m.AuthorName = CommentGuestName
+	}
+
+	return m
+}
+
+// IsAuthor checks if the comment was written in the session or by the user it belongs to.
+func (m *Comment) IsAuthor(s *Session) bool {
+	if m == nil || m.AuthorRef == "" {
+		return false
+	}
+
+	return m.AuthorRef == AuthorRef(s)
+}
+
+// Empty checks if the comment has no text.
+func (m *Comment) Empty() bool {
+	return m.CommentText == ""
+}
+
+// Create inserts a new comment into the database.
+func (m *Comment) Create() error {
+	return Db().Create(m).Error
+}
+
+// SetHidden hides or shows the comment, e.g. when it has been moderated by the album owner.
+func (m *Comment) SetHidden(hidden bool) error {
+	if err := Db().Model(m).UpdateColumns(Values{"hidden": hidden, "updated_at": TimeStamp()}).Error; err != nil {
+		return err
+	}
+
+	m.Hidden = hidden
+
+	return nil
+}
+
+// Delete permanently removes the comment.
+func (m *Comment) Delete() error {
+	if m.CommentUID == "" {
+		return nil
+	}
+
+	return Db().Delete(m).Error
+}
+
+// FindComment returns the comment with the specified UID, or nil if it was not found.
+func FindComment(uid string) *Comment {
+	if rnd.InvalidUID(uid, CommentUID) {
+		return nil
+	}
+
+	m := Comment{}
+
+	if Db().Where("comment_uid = ?", uid).First(&m).RecordNotFound() {
+		return nil
+	}
+
+	return &m
+}
+
+// DeleteAlbumComments permanently removes all comments on an album.
+func DeleteAlbumComments(albumUid string) error {
+	if albumUid == "" {
+		return nil
+	}
+
+	return Db().Where("album_uid = ?", albumUid).Delete(&Comment{}).Error
+}
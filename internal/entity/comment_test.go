@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestNewComment(t *testing.T) {
+	m := NewComment("at9lxuqxpogaaba8", "ps6sg6be2lvl0yh7", "  Great picture!  ")
+
+	assert.Equal(t, "at9lxuqxpogaaba8", m.AlbumUID)
+	assert.Equal(t, "ps6sg6be2lvl0yh7", m.PhotoUID)
+	assert.Equal(t, "Great picture!", m.CommentText)
+	assert.False(t, m.Empty())
+
+	assert.LessOrEqual(t, len([]rune(NewComment("at9lxuqxpogaaba8", "", strings.Repeat("a", 3000)).CommentText)), CommentMaxLength)
+	assert.True(t, NewComment("at9lxuqxpogaaba8", "", "  ").Empty())
+}
+
+func TestComment_SetAuthor(t *testing.T) {
+	t.Run("Guest", func(t *testing.T) {
+		s := &Session{RefID: "sessxkkcabcd"}
+		link := LinkFixtures["1jxf3jfn2k"]
+		m := NewComment("at9lxuqxpogaaba8", "", "Hello").SetAuthor(s, &link, "")
+
+		assert.Equal(t, "", m.UserUID)
+		assert.Equal(t, s.RefID, m.AuthorRef)
+		assert.Equal(t, link.LinkUID, m.LinkUID)
+		assert.Equal(t, CommentGuestName, m.AuthorName)
+		assert.True(t, m.IsAuthor(s))
+		assert.False(t, m.IsAuthor(&Session{RefID: "sessxkkcefgh"}))
+
+		m.SetAuthor(s, &link, "Aunt Mary")
+		assert.Equal(t, "Aunt Mary", m.AuthorName)
+	})
+	t.Run("User", func(t *testing.T) {
+		s := SessionFixtures.Pointer("alice")
+		m := NewComment("at9lxuqxpogaaba8", "", "Hello").SetAuthor(s, nil, "Ignored")
+
+		assert.Equal(t, s.UserUID, m.UserUID)
+		assert.Equal(t, s.UserUID, m.AuthorRef)
+		assert.NotEqual(t, "Ignored", m.AuthorName)
+		assert.True(t, m.IsAuthor(s))
+	})
+}
+
+func TestComment_Create(t *testing.T) {
+	m := NewComment("at9lxuqxpogaaba8", "", "Looks like fun!")
+	m.AuthorRef = "sessxkkcabcd"
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, rnd.IsUID(m.CommentUID, CommentUID))
+
+	found := FindComment(m.CommentUID)
+
+	if found == nil {
+		t.Fatal("comment expected")
+	}
+
+	assert.Equal(t, "Looks like fun!", found.CommentText)
+
+	if err := found.SetHidden(true); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, FindComment(m.CommentUID).Hidden)
+
+	if err := found.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindComment(m.CommentUID))
+}
+
+func TestDeleteAlbumComments(t *testing.T) {
+	m := NewComment("at9lxuqxpogaaba8", "ps6sg6be2lvl0yh7", "Happy birthday!")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, FindComment(m.CommentUID))
+
+	if err := DeleteAlbumComments("at9lxuqxpogaaba8"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindComment(m.CommentUID))
+}
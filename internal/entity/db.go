@@ -22,6 +22,18 @@ func Db() *gorm.DB {
 	return dbConn.Db()
 }
 
+// ReadDb returns the *gorm.DB connection for read-only queries, which
+// may be a database replica and therefore slightly behind the primary.
+func ReadDb() *gorm.DB {
+	if dbConn == nil {
+		return nil
+	} else if r, ok := dbConn.(GormReplica); ok {
+		return r.ReadDb()
+	}
+
+	return dbConn.Db()
+}
+
 // UnscopedDb returns an unscoped *gorm.DB connection
 // that returns all records including deleted records.
 func UnscopedDb() *gorm.DB {
@@ -13,6 +13,7 @@ import (
 // Supported test databases.
 const (
 	MySQL           = "mysql"
+	Postgres        = "postgres"
 	SQLite3         = "sqlite3"
 	SQLiteTestDB    = ".test.db"
 	SQLiteMemoryDSN = ":memory:?cache=shared"
@@ -26,6 +27,11 @@ type Gorm interface {
 	Db() *gorm.DB
 }
 
+// GormReplica is an optional interface for connection providers with a read-only database replica.
+type GormReplica interface {
+	ReadDb() *gorm.DB
+}
+
 // DbConn is a gorm.DB connection provider.
 type DbConn struct {
 	Driver string
@@ -46,14 +52,23 @@ func (g *DbConn) Db() *gorm.DB {
 	return g.db
 }
 
+// OpenDb opens a new database connection with the specified driver and data source name.
+func OpenDb(driver, dsn string) (*gorm.DB, error) {
+	if driver == Postgres {
+		return gorm.Open(Postgres, PostgresDriver, dsn)
+	}
+
+	return gorm.Open(driver, dsn)
+}
+
 // Open creates a new gorm db connection.
 func (g *DbConn) Open() {
-	db, err := gorm.Open(g.Driver, g.Dsn)
+	db, err := OpenDb(g.Driver, g.Dsn)
 
 	if err != nil || db == nil {
 		for i := 1; i <= 12; i++ {
 			fmt.Printf("gorm.Open(%s, %s) %d\n", g.Driver, g.Dsn, i)
-			db, err = gorm.Open(g.Driver, g.Dsn)
+			db, err = OpenDb(g.Driver, g.Dsn)
 
 			if db != nil && err == nil {
 				break
@@ -97,6 +112,16 @@ func DbDialect() string {
 	return Db().Dialect().GetName()
 }
 
+// LikeOp returns the operator for case-insensitive pattern matching, as LIKE is case-sensitive in PostgreSQL,
+// but not in MariaDB with the default collation and SQLite.
+func LikeOp() string {
+	if IsDialect(Postgres) {
+		return "ILIKE"
+	}
+
+	return "LIKE"
+}
+
 // SetDbProvider sets the Gorm database connection provider.
 func SetDbProvider(conn Gorm) {
 	dbConn = conn
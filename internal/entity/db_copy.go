@@ -0,0 +1,295 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/migrate"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// CopyBatchSize is the maximum number of values in a single insert statement when copying tables,
+// which must not exceed the default SQLite limit.
+var CopyBatchSize = 999
+
+// CopyResult represents the row counts of a table that was copied to another database.
+type CopyResult struct {
+	Table   string `json:"Table"`
+	Source  int    `json:"Source"`
+	Copied  int    `json:"Copied"`
+	Skipped int    `json:"Skipped"`
+	Target  int    `json:"Target"`
+}
+
+// Ok checks if all valid rows have been copied.
+func (r CopyResult) Ok() bool {
+	return r.Source == r.Copied+r.Skipped && r.Copied == r.Target
+}
+
+// CopyResults represents the results of copying tables to another database.
+type CopyResults []CopyResult
+
+// Ok checks if all rows of all tables have been copied.
+func (r CopyResults) Ok() bool {
+	for _, res := range r {
+		if !res.Ok() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Names returns the sorted table names, excluding the schema migration tables, which depend on the database
+// dialect and are therefore created by the migrations of the target database itself.
+func (list Tables) Names() (names []string) {
+	for name := range list {
+		if name == (migrate.Migration{}).TableName() || name == (migrate.Version{}).TableName() {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// RowCounts returns the number of rows in each table, or -1 if the table does not exist.
+func (list Tables) RowCounts(db *gorm.DB) map[string]int {
+	counts := make(map[string]int, len(list))
+
+	for _, name := range list.Names() {
+		counts[name] = -1
+
+		if db.HasTable(name) {
+			var count int
+
+			if err := db.Table(name).Count(&count).Error; err == nil {
+				counts[name] = count
+			}
+		}
+	}
+
+	return counts
+}
+
+// CopyTo copies all rows from the specified database to the target database, which must use the same
+// schema. Existing rows in the target tables are replaced and rows are inserted as they are, without calling
+// model hooks. The number of rows in the target tables is compared with the source afterwards and auto
+// increment sequences are updated if needed.
+func (list Tables) CopyTo(src, dest *gorm.DB) (results CopyResults, err error) {
+	for _, name := range list.Names() {
+		res, err := copyTable(src, dest, name, list[name])
+
+		results = append(results, res)
+
+		if err != nil {
+			return results, fmt.Errorf("%s (copy %s)", err, clean.Log(name))
+		}
+
+		if err = fixSequence(dest, name, list[name]); err != nil {
+			return results, fmt.Errorf("%s (update %s sequence)", err, clean.Log(name))
+		}
+	}
+
+	return results, nil
+}
+
+// copyTable copies the rows of a single table in batches.
+func copyTable(src, dest *gorm.DB, name string, model interface{}) (res CopyResult, err error) {
+	res.Table = name
+
+	// Skip tables that do not exist in the source database yet.
+	if !src.HasTable(name) {
+		log.Debugf("migrate: skipped %s, table not found", clean.Log(name))
+		return res, nil
+	} else if err = src.Table(name).Count(&res.Source).Error; err != nil {
+		return res, err
+	}
+
+	modelType := reflect.TypeOf(model).Elem()
+	scope := dest.NewScope(model)
+
+	var cols []string
+
+	for _, f := range scope.Fields() {
+		if f.IsNormal && !f.IsIgnored {
+			cols = append(cols, scope.Quote(f.DBName))
+		}
+	}
+
+	if len(cols) == 0 {
+		return res, fmt.Errorf("no columns found")
+	}
+
+	// Insert multiple rows per statement, but do not exceed the maximum number of values.
+	batchRows := CopyBatchSize / len(cols)
+
+	if batchRows < 1 {
+		batchRows = 1
+	}
+
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?,", len(cols)), ",") + ")"
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", scope.Quote(name), strings.Join(cols, ","))
+
+	rows, err := src.Unscoped().Table(name).Rows()
+
+	if err != nil {
+		return res, err
+	}
+
+	defer rows.Close()
+
+	tx := dest.Begin()
+
+	// Remove rows that may have been added by schema migrations.
+	if err = tx.Exec(fmt.Sprintf("DELETE FROM %s", scope.Quote(name))).Error; err != nil {
+		tx.Rollback()
+		return res, err
+	}
+
+	var values []interface{}
+	var batch int
+
+	flush := func() error {
+		if batch == 0 {
+			return nil
+		}
+
+		sql := stmt + strings.TrimSuffix(strings.Repeat(placeholders+",", batch), ",")
+
+		if err := tx.Exec(sql, values...).Error; err != nil {
+			return err
+		}
+
+		res.Copied += batch
+		values, batch = values[:0], 0
+
+		return nil
+	}
+
+	for rows.Next() {
+		row := reflect.New(modelType).Interface()
+
+		if err = src.ScanRows(rows, row); err != nil {
+			tx.Rollback()
+			return res, err
+		}
+
+		rowScope := dest.NewScope(row)
+
+		// Skip invalid rows without primary key, which SQLite allows for non-integer keys.
+		if blankPrimaryKey(rowScope) {
+			res.Skipped++
+			continue
+		}
+
+		for _, f := range rowScope.Fields() {
+			if f.IsNormal && !f.IsIgnored {
+				values = append(values, copyValue(f.Field))
+			}
+		}
+
+		if batch++; batch >= batchRows {
+			if err = flush(); err != nil {
+				tx.Rollback()
+				return res, err
+			}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		tx.Rollback()
+		return res, err
+	} else if err = flush(); err != nil {
+		tx.Rollback()
+		return res, err
+	} else if err = tx.Commit().Error; err != nil {
+		return res, err
+	}
+
+	// Check if all rows have been copied.
+	if err = dest.Table(name).Count(&res.Target).Error; err != nil {
+		return res, err
+	}
+
+	if res.Skipped > 0 {
+		log.Warnf("migrate: skipped %d rows without primary key in %s", res.Skipped, clean.Log(name))
+	}
+
+	log.Debugf("migrate: copied %d of %d rows from %s", res.Copied, res.Source, clean.Log(name))
+
+	return res, nil
+}
+
+// blankPrimaryKey checks if all primary key fields of a row are blank.
+func blankPrimaryKey(scope *gorm.Scope) bool {
+	fields := scope.PrimaryFields()
+
+	for _, f := range fields {
+		if !f.IsBlank {
+			return false
+		}
+	}
+
+	return len(fields) > 0
+}
+
+// copyValue returns the database value of a struct field, so that it is not expanded into a list of values.
+func copyValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	} else if valuer, ok := v.Interface().(driver.Valuer); ok {
+		if val, err := valuer.Value(); err == nil {
+			return val
+		}
+	} else if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return v.Bytes()
+	}
+
+	return v.Interface()
+}
+
+// fixSequence updates the auto increment sequence of a table after rows have been inserted with an explicit ID,
+// so that new rows don't get an ID that already exists. SQLite updates the sequence automatically.
+func fixSequence(db *gorm.DB, name string, model interface{}) error {
+	scope := db.NewScope(model)
+	fields := scope.PrimaryFields()
+
+	if len(fields) != 1 {
+		return nil
+	} else if v, ok := fields[0].TagSettingsGet("AUTO_INCREMENT"); ok && strings.EqualFold(v, "false") {
+		return nil
+	}
+
+	switch fields[0].Field.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+
+	col := fields[0].DBName
+
+	switch db.Dialect().GetName() {
+	case MySQL:
+		var next struct{ Next int64 }
+
+		if err := db.Raw(fmt.Sprintf("SELECT COALESCE(MAX(%s), 0) + 1 AS next FROM %s", scope.Quote(col), scope.Quote(name))).Scan(&next).Error; err != nil {
+			return err
+		}
+
+		return db.Exec(fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = %d", scope.Quote(name), next.Next)).Error
+	case Postgres:
+		return db.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE(MAX(%s), 1), MAX(%s) IS NOT NULL) FROM %s",
+			name, col, scope.Quote(col), scope.Quote(col), scope.Quote(name))).Error
+	}
+
+	return nil
+}
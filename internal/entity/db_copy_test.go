@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/migrate"
+)
+
+func TestTables_Names(t *testing.T) {
+	names := Entities.Names()
+
+	assert.Contains(t, names, Photo{}.TableName())
+	assert.NotContains(t, names, migrate.Migration{}.TableName())
+	assert.NotContains(t, names, migrate.Version{}.TableName())
+	assert.IsIncreasing(t, names)
+}
+
+func TestTables_CopyTo(t *testing.T) {
+	dest, err := OpenDb(SQLite3, filepath.Join(t.TempDir(), "copy.db"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer dest.Close()
+
+	dest.LogMode(false)
+
+	Entities.Migrate(dest, migrate.Opt(true, false, nil))
+
+	results, err := Entities.CopyTo(Db(), dest)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, len(Entities.Names()))
+	assert.True(t, results.Ok())
+
+	counts := Entities.RowCounts(dest)
+
+	for _, res := range results {
+		assert.Equal(t, res.Copied, counts[res.Table], res.Table)
+	}
+
+	// Check that values are copied without changes.
+	fixture := PhotoFixtures.Get("19800101_000002_D640C559")
+	photo := Photo{}
+
+	if err = dest.Unscoped().Where("photo_uid = ?", fixture.PhotoUID).First(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fixture.ID, photo.ID)
+	assert.Equal(t, fixture.PhotoTitle, photo.PhotoTitle)
+	assert.Equal(t, fixture.TakenAt.UTC(), photo.TakenAt.UTC())
+
+	// New rows must not reuse existing IDs.
+	label := NewLabel("Copy Test", 0)
+
+	if err = dest.Create(label).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, label.ID, LabelFixtures.Get("landscape").ID)
+}
+
+func TestCopyResults_Ok(t *testing.T) {
+	assert.True(t, CopyResults{{Table: "photos", Source: 2, Copied: 2, Target: 2}}.Ok())
+	assert.False(t, CopyResults{{Table: "photos", Source: 2, Copied: 2, Target: 2}, {Table: "files", Source: 3, Copied: 2, Target: 2}}.Ok())
+}
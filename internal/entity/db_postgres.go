@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	"github.com/lib/pq"
+)
+
+// PostgresDriver is the name of the database/sql driver that adapts queries written for MariaDB and SQLite to PostgreSQL.
+const PostgresDriver = "photoprism-postgres"
+
+// postgresBase is the type of the default PostgreSQL dialect provided by GORM.
+var postgresBase reflect.Type
+
+func init() {
+	if base, ok := gorm.GetDialect(Postgres); ok {
+		postgresBase = reflect.TypeOf(base).Elem()
+		gorm.RegisterDialect(Postgres, &postgresDialect{})
+	}
+
+	sql.Register(PostgresDriver, postgresDriver{})
+}
+
+// postgresTypes maps the MariaDB column types used in entity tags to their PostgreSQL equivalents.
+var postgresTypes = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`(?i)^VARBINARY\b`), "VARCHAR"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)?BLOB\b`), "BYTEA"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)TEXT\b`), "TEXT"},
+	{regexp.MustCompile(`(?i)^DATETIME\b`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^DOUBLE(\s+PRECISION)?\b`), "DOUBLE PRECISION"},
+	{regexp.MustCompile(`(?i)^numeric\b`), "DOUBLE PRECISION"},
+	{regexp.MustCompile(`(?i)^FLOAT\b`), "REAL"},
+	// Boolean values are stored as integers, so that existing queries like "photo_private = 0" remain valid.
+	{regexp.MustCompile(`(?i)^boolean\b`), "SMALLINT"},
+	{regexp.MustCompile(`(?i)\bDEFAULT\s+false\b`), "DEFAULT 0"},
+	{regexp.MustCompile(`(?i)\bDEFAULT\s+true\b`), "DEFAULT 1"},
+}
+
+// PostgresType returns the PostgreSQL column type for the specified MariaDB column type.
+func PostgresType(s string) string {
+	s = strings.TrimSpace(s)
+
+	for _, t := range postgresTypes {
+		s = t.pattern.ReplaceAllString(s, t.replace)
+	}
+
+	return s
+}
+
+// postgresDialect extends the default PostgreSQL dialect with support for the types used in entity tags.
+type postgresDialect struct {
+	gorm.Dialect
+}
+
+// SetDB initializes the default dialect, since GORM creates a new zero value for each connection.
+func (d *postgresDialect) SetDB(db gorm.SQLCommon) {
+	d.Dialect = reflect.New(postgresBase).Interface().(gorm.Dialect)
+	d.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the PostgreSQL column type of the field.
+func (d *postgresDialect) DataTypeOf(field *gorm.StructField) string {
+	return PostgresType(d.Dialect.DataTypeOf(field))
+}
+
+// postgresDriver wraps the PostgreSQL driver to adapt arguments.
+type postgresDriver struct{}
+
+// Open returns a new connection to the database.
+func (postgresDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := pq.Open(dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresConn{Conn: conn}, nil
+}
+
+// postgresConn represents a PostgreSQL connection that adapts arguments.
+type postgresConn struct {
+	driver.Conn
+}
+
+// PrepareContext returns a prepared statement.
+func (c *postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if conn, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return conn.PrepareContext(ctx, query)
+	}
+
+	return c.Conn.Prepare(query)
+}
+
+// QueryContext executes a query that may return rows.
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if conn, ok := c.Conn.(driver.QueryerContext); ok {
+		return conn.QueryContext(ctx, query, args)
+	}
+
+	return nil, driver.ErrSkip
+}
+
+// ExecContext executes a query that doesn't return rows.
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if conn, ok := c.Conn.(driver.ExecerContext); ok {
+		return conn.ExecContext(ctx, query, args)
+	}
+
+	return nil, driver.ErrSkip
+}
+
+// BeginTx starts a transaction.
+func (c *postgresConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if conn, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return conn.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+// Ping checks if the connection is still alive.
+func (c *postgresConn) Ping(ctx context.Context) error {
+	if conn, ok := c.Conn.(driver.Pinger); ok {
+		return conn.Ping(ctx)
+	}
+
+	return nil
+}
+
+// CheckNamedValue converts boolean arguments to integers, since boolean values are stored as SMALLINT.
+func (c *postgresConn) CheckNamedValue(nv *driver.NamedValue) (err error) {
+	if nv.Value, err = driver.DefaultParameterConverter.ConvertValue(nv.Value); err != nil {
+		return err
+	}
+
+	if b, ok := nv.Value.(bool); ok {
+		if b {
+			nv.Value = int64(1)
+		} else {
+			nv.Value = int64(0)
+		}
+	}
+
+	return nil
+}
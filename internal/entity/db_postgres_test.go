@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresType(t *testing.T) {
+	assert.Equal(t, "VARCHAR(42)", PostgresType("VARBINARY(42)"))
+	assert.Equal(t, "VARCHAR(255) NOT NULL", PostgresType("VARBINARY(255) NOT NULL"))
+	assert.Equal(t, "BYTEA", PostgresType("BLOB"))
+	assert.Equal(t, "BYTEA", PostgresType("MEDIUMBLOB"))
+	assert.Equal(t, "TEXT", PostgresType("LONGTEXT"))
+	assert.Equal(t, "TEXT", PostgresType("MEDIUMTEXT"))
+	assert.Equal(t, "TIMESTAMP", PostgresType("DATETIME"))
+	assert.Equal(t, "DOUBLE PRECISION", PostgresType("DOUBLE"))
+	assert.Equal(t, "DOUBLE PRECISION", PostgresType("numeric"))
+	assert.Equal(t, "REAL", PostgresType("FLOAT"))
+	assert.Equal(t, "SMALLINT", PostgresType("boolean"))
+	assert.Equal(t, "SMALLINT DEFAULT 0", PostgresType("boolean DEFAULT false"))
+	assert.Equal(t, "SMALLINT DEFAULT 1", PostgresType("boolean DEFAULT true"))
+	assert.Equal(t, "VARCHAR(160)", PostgresType("VARCHAR(160)"))
+	assert.Equal(t, "bigint", PostgresType(" bigint "))
+}
+
+func TestLikeOp(t *testing.T) {
+	if IsDialect(Postgres) {
+		assert.Equal(t, "ILIKE", LikeOp())
+	} else {
+		assert.Equal(t, "LIKE", LikeOp())
+	}
+}
+
+func TestPostgresDialect(t *testing.T) {
+	dialect, ok := gorm.GetDialect(Postgres)
+
+	assert.True(t, ok)
+	assert.IsType(t, &postgresDialect{}, dialect)
+}
+
+func TestPostgresConn_CheckNamedValue(t *testing.T) {
+	c := &postgresConn{}
+
+	t.Run("True", func(t *testing.T) {
+		v := &driver.NamedValue{Value: true}
+		assert.NoError(t, c.CheckNamedValue(v))
+		assert.Equal(t, int64(1), v.Value)
+	})
+	t.Run("False", func(t *testing.T) {
+		v := &driver.NamedValue{Value: false}
+		assert.NoError(t, c.CheckNamedValue(v))
+		assert.Equal(t, int64(0), v.Value)
+	})
+	t.Run("String", func(t *testing.T) {
+		v := &driver.NamedValue{Value: "foo"}
+		assert.NoError(t, c.CheckNamedValue(v))
+		assert.Equal(t, "foo", v.Value)
+	})
+	t.Run("Int", func(t *testing.T) {
+		v := &driver.NamedValue{Value: 42}
+		assert.NoError(t, c.CheckNamedValue(v))
+		assert.Equal(t, int64(42), v.Value)
+	})
+}
@@ -27,6 +27,8 @@ type Details struct {
 	LicenseSrc   string    `gorm:"type:VARBINARY(8);" json:"LicenseSrc" yaml:"LicenseSrc,omitempty"`
 	Software     string    `gorm:"type:VARCHAR(1024);" json:"Software" yaml:"Software,omitempty"`
 	SoftwareSrc  string    `gorm:"type:VARBINARY(8);" json:"SoftwareSrc" yaml:"SoftwareSrc,omitempty"`
+	Text         string    `gorm:"type:VARCHAR(4096);" json:"Text" yaml:"Text,omitempty"`
+	TextSrc      string    `gorm:"type:VARBINARY(8);" json:"TextSrc" yaml:"TextSrc,omitempty"`
 	CreatedAt    time.Time `yaml:"-"`
 	UpdatedAt    time.Time `yaml:"-"`
 }
@@ -116,6 +118,11 @@ func (m *Details) NoSoftware() bool {
 	return m.Software == ""
 }
 
+// NoText tests if the photo has no recognized Text.
+func (m *Details) NoText() bool {
+	return m.Text == ""
+}
+
 // HasKeywords tests if the photo has a Keywords.
 func (m *Details) HasKeywords() bool {
 	return !m.NoKeywords()
@@ -151,6 +158,11 @@ func (m *Details) HasSoftware() bool {
 	return !m.NoSoftware()
 }
 
+// HasText tests if the photo has a recognized Text.
+func (m *Details) HasText() bool {
+	return !m.NoText()
+}
+
 // SetKeywords updates the photo details field.
 func (m *Details) SetKeywords(data, src string) {
 	val := txt.Clip(data, txt.ClipText)
@@ -270,3 +282,19 @@ func (m *Details) SetSoftware(data, src string) {
 	m.Software = val
 	m.SoftwareSrc = src
 }
+
+// SetText updates the text recognized in the photo, e.g. on scans, screenshots, and signs.
+func (m *Details) SetText(data, src string) {
+	val := txt.Clip(data, txt.ClipLongText)
+
+	if val == "" {
+		return
+	}
+
+	if (SrcPriority[src] < SrcPriority[m.TextSrc]) && m.HasText() {
+		return
+	}
+
+	m.Text = val
+	m.TextSrc = src
+}
@@ -358,3 +358,28 @@ func TestDetails_SetSoftware(t *testing.T) {
 		assert.Equal(t, "new", description.Software)
 	})
 }
+
+func TestDetails_SetText(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: ""}
+		assert.False(t, details.HasText())
+
+		details.SetText("", SrcOCR)
+		assert.False(t, details.HasText())
+	})
+	t.Run("NoPriority", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: "old", TextSrc: SrcManual}
+		assert.Equal(t, "old", details.Text)
+
+		details.SetText("new", SrcOCR)
+		assert.Equal(t, "old", details.Text)
+	})
+	t.Run("NewValue", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: "old", TextSrc: SrcOCR}
+		assert.Equal(t, "old", details.Text)
+
+		details.SetText("NO PARKING", SrcOCR)
+		assert.Equal(t, "NO PARKING", details.Text)
+		assert.Equal(t, SrcOCR, details.TextSrc)
+	})
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sync"
+	"time"
+)
+
+// SharedCache represents an external cache, such as a Redis server, that is shared by all instances,
+// so that sessions and cached entities remain valid when running multiple replicas behind a load balancer.
+type SharedCache interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, expiration time.Duration) error
+	Del(keys ...string) error
+	Keys(prefix string) ([]string, error)
+	DelPrefix(prefix string) error
+}
+
+var sharedCache SharedCache
+var sharedCacheMutex sync.RWMutex
+
+// SetSharedCache stores sessions and cached entities in an external cache instead of
+// the local memory, or disables the external cache if nil is passed.
+func SetSharedCache(c SharedCache) {
+	sharedCacheMutex.Lock()
+	sharedCache = c
+	sharedCacheMutex.Unlock()
+
+	// Remove locally cached values.
+	sessionCache.Flush()
+	albumCache.Flush()
+	passkeyChallenges.Flush()
+}
+
+// shared returns the external cache, or nil if none is configured.
+func shared() SharedCache {
+	sharedCacheMutex.RLock()
+	defer sharedCacheMutex.RUnlock()
+
+	return sharedCache
+}
+
+// getShared decodes a value from the external cache and returns true if it was found.
+// Keys are not logged, since they may contain session IDs.
+func getShared(c SharedCache, key string, v interface{}) bool {
+	data, err := c.Get(key)
+
+	if err != nil {
+		log.Warnf("cache: %s (get)", err)
+		return false
+	} else if len(data) == 0 {
+		return false
+	} else if err = gob.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		log.Warnf("cache: %s (decode)", err)
+		return false
+	}
+
+	return true
+}
+
+// setShared encodes a value and adds it to the external cache.
+func setShared(c SharedCache, key string, v interface{}, expiration time.Duration) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		log.Warnf("cache: %s (encode)", err)
+	} else if err = c.Set(key, buf.Bytes(), expiration); err != nil {
+		log.Warnf("cache: %s (set)", err)
+	}
+}
+
+// deleteShared removes keys from the external cache.
+func deleteShared(c SharedCache, keys ...string) {
+	if err := c.Del(keys...); err != nil {
+		log.Warnf("cache: %s (delete)", err)
+	}
+}
+
+// flushShared removes all keys with the specified prefix from the external cache.
+func flushShared(c SharedCache, prefix string) {
+	if err := c.DelPrefix(prefix); err != nil {
+		log.Warnf("cache: %s (flush %s)", err, prefix)
+	}
+}
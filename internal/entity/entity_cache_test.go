@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSharedCache implements SharedCache with an in-memory map.
+type testSharedCache struct {
+	values map[string][]byte
+	mutex  sync.Mutex
+}
+
+func newTestSharedCache() *testSharedCache {
+	return &testSharedCache{values: make(map[string][]byte)}
+}
+
+func (c *testSharedCache) Get(key string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.values[key], nil
+}
+
+func (c *testSharedCache) Set(key string, value []byte, expiration time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[key] = value
+
+	return nil
+}
+
+func (c *testSharedCache) Del(keys ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+
+	return nil
+}
+
+func (c *testSharedCache) Keys(prefix string) (keys []string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func (c *testSharedCache) DelPrefix(prefix string) error {
+	keys, _ := c.Keys(prefix)
+	return c.Del(keys...)
+}
+
+func TestSetSharedCache(t *testing.T) {
+	c := newTestSharedCache()
+
+	SetSharedCache(c)
+
+	defer SetSharedCache(nil)
+
+	t.Run("Session", func(t *testing.T) {
+		id := "69be27ac5ca305b394046a83f6fda18167ca3d3f2dbe7ac0"
+		key := sessionCachePrefix + id
+
+		result, err := FindSession(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, UserFixtures.Pointer("alice").UserUID, result.UserUID)
+		assert.Equal(t, 0, sessionCache.ItemCount())
+		assert.NotEmpty(t, c.values[key])
+		assert.NotEmpty(t, c.values[userSessionCachePrefix+result.UserUID+":"+id])
+
+		cached, ok := cachedSession(id)
+
+		assert.True(t, ok)
+		assert.Equal(t, result.ID, cached.ID)
+		assert.Equal(t, result.UserName, cached.UserName)
+		assert.Equal(t, result.AuthScope, cached.AuthScope)
+
+		FlushUserSessionCache(result.UserUID)
+
+		assert.Empty(t, c.values[key])
+		assert.Empty(t, c.values[userSessionCachePrefix+result.UserUID+":"+id])
+
+		result.Cache()
+
+		assert.NotEmpty(t, c.values[key])
+
+		result.ClearCache()
+
+		assert.Empty(t, c.values[key])
+
+		result.Cache()
+		FlushSessionCache()
+
+		assert.Empty(t, c.values)
+	})
+	t.Run("Album", func(t *testing.T) {
+		result, err := CachedAlbumByUID("at9lxuqxpogaaba7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "christmas-2030", result.AlbumSlug)
+		assert.Equal(t, 0, albumCache.ItemCount())
+		assert.NotEmpty(t, c.values[albumCachePrefix+"at9lxuqxpogaaba7"])
+
+		if cached, err := CachedAlbumByUID("at9lxuqxpogaaba7"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, result.AlbumSlug, cached.AlbumSlug)
+			assert.Equal(t, result.CreatedAt.UTC(), cached.CreatedAt.UTC())
+		}
+
+		FlushAlbumCache()
+
+		assert.Empty(t, c.values[albumCachePrefix+"at9lxuqxpogaaba7"])
+	})
+	t.Run("PasskeyChallenge", func(t *testing.T) {
+		challenge, err := NewPasskeyChallenge("uqxetse3cy5eo9z2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, passkeyChallenges.ItemCount())
+		assert.True(t, RedeemPasskeyChallenge(challenge, "uqxetse3cy5eo9z2"))
+		assert.False(t, RedeemPasskeyChallenge(challenge, "uqxetse3cy5eo9z2"))
+
+		login, err := NewPasskeyChallenge("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, RedeemPasskeyChallenge(login, ""))
+	})
+}
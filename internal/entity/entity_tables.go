@@ -22,17 +22,27 @@ var Entities = Tables{
 	UserDetails{}.TableName():       &UserDetails{},
 	UserSettings{}.TableName():      &UserSettings{},
 	Session{}.TableName():           &Session{},
+	Passkey{}.TableName():           &Passkey{},
+	AuditEvent{}.TableName():        &AuditEvent{},
+	Change{}.TableName():            &Change{},
+	Role{}.TableName():              &Role{},
+	Group{}.TableName():             &Group{},
+	GroupMember{}.TableName():       &GroupMember{},
 	Service{}.TableName():           &Service{},
 	Folder{}.TableName():            &Folder{},
 	Duplicate{}.TableName():         &Duplicate{},
 	File{}.TableName():              &File{},
 	FileShare{}.TableName():         &FileShare{},
 	FileSync{}.TableName():          &FileSync{},
+	FileCold{}.TableName():          &FileCold{},
+	SyncJournal{}.TableName():       &SyncJournal{},
 	Photo{}.TableName():             &Photo{},
 	PhotoUser{}.TableName():         &PhotoUser{},
 	Details{}.TableName():           &Details{},
 	Place{}.TableName():             &Place{},
 	Cell{}.TableName():              &Cell{},
+	PlaceAlias{}.TableName():        &PlaceAlias{},
+	Geofence{}.TableName():          &Geofence{},
 	Camera{}.TableName():            &Camera{},
 	Lens{}.TableName():              &Lens{},
 	Country{}.TableName():           &Country{},
@@ -41,15 +51,21 @@ var Entities = Tables{
 	PhotoAlbum{}.TableName():        &PhotoAlbum{},
 	Label{}.TableName():             &Label{},
 	Category{}.TableName():          &Category{},
+	LabelClosure{}.TableName():      &LabelClosure{},
 	PhotoLabel{}.TableName():        &PhotoLabel{},
 	Keyword{}.TableName():           &Keyword{},
 	PhotoKeyword{}.TableName():      &PhotoKeyword{},
+	PhotoEmbedding{}.TableName():    &PhotoEmbedding{},
 	Link{}.TableName():              &Link{},
+	Comment{}.TableName():           &Comment{},
 	Subject{}.TableName():           &Subject{},
 	Face{}.TableName():              &Face{},
 	Marker{}.TableName():            &Marker{},
 	Reaction{}.TableName():          &Reaction{},
 	UserShare{}.TableName():         &UserShare{},
+	Selection{}.TableName():         &Selection{},
+	SelectionPhoto{}.TableName():    &SelectionPhoto{},
+	NodeJob{}.TableName():           &NodeJob{},
 }
 
 // WaitForMigration waits for the database migration to be successful.
@@ -22,7 +22,9 @@ type Face struct {
 	ID              string          `gorm:"type:VARBINARY(64);primary_key;auto_increment:false;" json:"ID" yaml:"ID"`
 	FaceSrc         string          `gorm:"type:VARBINARY(8);" json:"Src" yaml:"Src,omitempty"`
 	FaceKind        int             `json:"Kind" yaml:"Kind,omitempty"`
+	FaceModel       string          `gorm:"type:VARBINARY(64);default:'facenet';" json:"Model" yaml:"Model,omitempty"`
 	FaceHidden      bool            `json:"Hidden" yaml:"Hidden,omitempty"`
+	FaceYear        int             `gorm:"default:0;" json:"Year" yaml:"Year,omitempty"`
 	SubjUID         string          `gorm:"type:VARBINARY(42);index;default:'';" json:"SubjUID" yaml:"SubjUID,omitempty"`
 	Samples         int             `json:"Samples" yaml:"Samples,omitempty"`
 	SampleRadius    float64         `json:"SampleRadius" yaml:"SampleRadius,omitempty"`
@@ -72,9 +74,18 @@ func (m *Face) MatchId(f Face) string {
 
 // SkipMatching checks whether the face should be skipped when matching.
 func (m *Face) SkipMatching() bool {
+	if m.IsPet() {
+		return m.Embedding().Ignored()
+	}
+
 	return m.FaceKind > 1 || m.Embedding().SkipMatching()
 }
 
+// IsPet tests if this is the face of a pet or other animal.
+func (m *Face) IsPet() bool {
+	return m.FaceKind == int(face.PetFace)
+}
+
 // SetEmbeddings assigns face embeddings.
 func (m *Face) SetEmbeddings(embeddings face.Embeddings) (err error) {
 	if len(embeddings) == 0 {
@@ -83,10 +94,12 @@ func (m *Face) SetEmbeddings(embeddings face.Embeddings) (err error) {
 
 	m.embedding, m.SampleRadius, m.Samples = face.EmbeddingsMidpoint(embeddings)
 
-	if len(m.embedding) != len(face.NullEmbedding) {
+	if !face.ActiveModel.Valid(m.embedding) {
 		return fmt.Errorf("embedding has invalid number of values")
 	}
 
+	m.FaceModel = face.ActiveModel.Name
+
 	// Limit sample radius to reduce false positives.
 	if m.SampleRadius > 0.35 {
 		m.SampleRadius = 0.35
@@ -159,7 +172,7 @@ func (m *Face) Match(embeddings face.Embeddings) (match bool, dist float64) {
 	case dist < 0:
 		// Should never happen.
 		return false, dist
-	case dist > (m.SampleRadius + face.MatchDist):
+	case dist > (m.SampleRadius + face.MatchDist + m.AgeTolerance()):
 		// Too far.
 		return false, dist
 	case m.CollisionRadius > 0.1 && dist > m.CollisionRadius:
@@ -171,6 +184,15 @@ func (m *Face) Match(embeddings face.Embeddings) (match bool, dist float64) {
 	return true, dist
 }
 
+// AgeTolerance returns the extra match distance for faces that represent a subject at a certain age.
+func (m *Face) AgeTolerance() float64 {
+	if m.FaceYear > 0 {
+		return face.AgeTolerance
+	}
+
+	return 0
+}
+
 // ResolveCollision resolves a collision with a different subject's face.
 func (m *Face) ResolveCollision(embeddings face.Embeddings) (resolved bool, err error) {
 	if m.SubjUID == "" {
@@ -228,7 +250,13 @@ func (m *Face) ReviseMatches() (revised Markers, err error) {
 
 	var matches Markers
 
-	if err := Db().Where("face_id = ?", m.ID).Where("marker_type = ?", MarkerFace).
+	markerType := MarkerFace
+
+	if m.IsPet() {
+		markerType = MarkerPet
+	}
+
+	if err := Db().Where("face_id = ?", m.ID).Where("marker_type = ?", markerType).
 		Find(&matches).Error; err != nil {
 		log.Debugf("faces: found no matching markers for conflict resolution (%s)", err)
 		return revised, err
@@ -252,8 +280,14 @@ func (m *Face) ReviseMatches() (revised Markers, err error) {
 func (m *Face) MatchMarkers(faceIds []string) error {
 	var markers Markers
 
+	markerType := MarkerFace
+
+	if m.IsPet() {
+		markerType = MarkerPet
+	}
+
 	err := Db().
-		Where("marker_invalid = 0 AND marker_type = ? AND face_id IN (?)", MarkerFace, faceIds).
+		Where("marker_invalid = 0 AND marker_type = ? AND face_id IN (?)", markerType, faceIds).
 		Find(&markers).Error
 
 	if err != nil {
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Split moves the specified markers to a new face cluster, which is assigned to the subject if not nil.
+// The match radius of this face is reduced so that the markers are not matched with it again, which avoids
+// having to run a full audit after correcting a wrongly merged cluster.
+func (m *Face) Split(markers Markers, subj *Subject) (result *Face, err error) {
+	if m.ID == "" {
+		return nil, fmt.Errorf("empty face id")
+	} else if len(markers) == 0 {
+		return nil, fmt.Errorf("no markers selected")
+	}
+
+	var embeddings face.Embeddings
+
+	for _, marker := range markers {
+		if marker.FaceID != m.ID {
+			return nil, fmt.Errorf("marker %s does not belong to face %s", clean.Log(marker.MarkerUID), clean.Log(m.ID))
+		} else if emb := marker.Embeddings(); emb.Empty() {
+			return nil, fmt.Errorf("marker %s has no embeddings", clean.Log(marker.MarkerUID))
+		} else {
+			embeddings = append(embeddings, emb...)
+		}
+	}
+
+	subjUID, subjSrc, name := "", SrcAuto, ""
+
+	if subj != nil {
+		subjUID, subjSrc, name = subj.SubjUID, SrcManual, subj.SubjName
+	}
+
+	result = NewFace(subjUID, subjSrc, embeddings)
+
+	if result.ID == "" {
+		return nil, fmt.Errorf("failed creating face cluster")
+	} else if result.ID == m.ID {
+		return nil, fmt.Errorf("cannot split face %s without remaining markers", clean.Log(m.ID))
+	} else if m.IsPet() {
+		result.FaceKind = int(face.PetFace)
+	}
+
+	if result = FirstOrCreateFace(result); result == nil {
+		return nil, fmt.Errorf("failed saving face cluster")
+	}
+
+	for _, marker := range markers {
+		_, dist := result.Match(marker.Embeddings())
+
+		if dist < 0 {
+			dist = 0
+		}
+
+		if err = marker.Updates(Values{
+			"FaceID":       result.ID,
+			"FaceDist":     dist,
+			"SubjUID":      subjUID,
+			"SubjSrc":      subjSrc,
+			"MarkerName":   name,
+			"MarkerReview": false,
+			"MatchedAt":    TimePointer(),
+		}); err != nil {
+			return result, err
+		}
+
+		// Make sure the marker is not matched with this face again.
+		if _, err = m.ResolveCollision(marker.Embeddings()); err != nil {
+			log.Warnf("faces: %s (split %s)", err, clean.Log(m.ID))
+		}
+	}
+
+	if err = m.RefreshPhotos(); err != nil {
+		return result, err
+	}
+
+	return result, result.RefreshPhotos()
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFace_Split(t *testing.T) {
+	t.Run("EmptyID", func(t *testing.T) {
+		m := Face{}
+
+		result, err := m.Split(Markers{MarkerFixtures.Get("1000003-4")}, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("NoMarkers", func(t *testing.T) {
+		m := FaceFixtures.Get("john-doe")
+
+		result, err := m.Split(Markers{}, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("OtherFace", func(t *testing.T) {
+		m := FaceFixtures.Get("john-doe")
+		marker := MarkerFixtures.Get("1000003-4")
+		marker.FaceID = "XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+		result, err := m.Split(Markers{marker}, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestMarker_ConfirmSubject(t *testing.T) {
+	t.Run("NoSubject", func(t *testing.T) {
+		m := Marker{MarkerUID: "mt9k3pw1wowu1000"}
+
+		assert.Error(t, m.ConfirmSubject())
+	})
+}
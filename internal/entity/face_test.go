@@ -141,6 +141,17 @@ func TestNewFace(t *testing.T) {
 	})
 }
 
+func TestFace_AgeTolerance(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		m := Face{ID: "A123"}
+		assert.Equal(t, 0.0, m.AgeTolerance())
+	})
+	t.Run("Year", func(t *testing.T) {
+		m := Face{ID: "A123", FaceYear: 1980}
+		assert.Equal(t, face.AgeTolerance, m.AgeTolerance())
+	})
+}
+
 func TestFace_MatchId(t *testing.T) {
 	t.Run("A123-B456", func(t *testing.T) {
 		f1 := Face{ID: "A123"}
@@ -297,3 +308,12 @@ func TestFace_HideAndShow(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestFace_IsPet(t *testing.T) {
+	m := NewFace("", SrcAuto, face.RandomEmbeddings(2, face.RegularFace))
+	assert.False(t, m.IsPet())
+	assert.False(t, m.SkipMatching())
+	m.FaceKind = int(face.PetFace)
+	assert.True(t, m.IsPet())
+	assert.False(t, m.SkipMatching())
+}
@@ -59,3 +59,14 @@ func DeleteOrphanFaces() (count int, err error) {
 
 	return len(orphans), orphans.Delete()
 }
+
+// Match tests if the embeddings match any of the faces.
+func (f Faces) Match(embeddings face.Embeddings) bool {
+	for i := range f {
+		if ok, _ := f[i].Match(embeddings); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
 )
 
 func TestFaces_Embeddings(t *testing.T) {
@@ -31,3 +33,12 @@ func TestDeleteOrphanFaces(t *testing.T) {
 		}
 	})
 }
+
+func TestFaces_Match(t *testing.T) {
+	m := FaceFixtures.Get("joe-biden")
+	faces := Faces{m}
+
+	assert.True(t, faces.Match(face.Embeddings{m.Embedding()}))
+	assert.False(t, faces.Match(face.Embeddings{}))
+	assert.False(t, Faces{}.Match(face.Embeddings{m.Embedding()}))
+}
@@ -149,6 +149,18 @@ func (m File) RegenerateIndex() {
 		Log("files", "regenerate time_index",
 			Db().Exec("UPDATE files SET time_index = CASE WHEN media_id IS NOT NULL AND photo_taken_at IS NOT NULL THEN ((100000000000000 - strftime('%Y%m%d%H%M%S', photo_taken_at)) || '-' || media_id) ELSE NULL END WHERE ?",
 				updateWhere).Error)
+	case Postgres:
+		Log("files", "regenerate photo_taken_at",
+			Db().Exec("UPDATE files SET photo_taken_at = p.taken_at_local FROM ? p WHERE p.id = files.photo_id AND ?",
+				gorm.Expr(photosTable), updateWhere).Error)
+
+		Log("files", "regenerate media_id",
+			Db().Exec("UPDATE files SET media_id = CASE WHEN file_missing = 0 AND deleted_at IS NULL THEN ((10000000000 - photo_id) || '-' || (1 + file_sidecar - file_primary) || '-' || file_uid) ELSE NULL END WHERE ?",
+				updateWhere).Error)
+
+		Log("files", "regenerate time_index",
+			Db().Exec("UPDATE files SET time_index = CASE WHEN media_id IS NOT NULL AND photo_taken_at IS NOT NULL THEN ((100000000000000 - CAST(to_char(photo_taken_at, 'YYYYMMDDHH24MISS') AS BIGINT)) || '-' || media_id) ELSE NULL END WHERE ?",
+				updateWhere).Error)
 	default:
 		log.Warnf("sql: unsupported dialect %s", DbDialect())
 	}
@@ -757,6 +769,58 @@ func (m *File) AddFace(f face.Face, subjUid string) {
 	}
 }
 
+// AddKeyframeFaces adds face markers found in a still image sampled from a video file at the specified offset.
+func (m *File) AddKeyframeFaces(faces face.Faces, thumbHash string, offset time.Duration) {
+	sort.Slice(faces, func(i, j int) bool {
+		return faces[i].Size() > faces[j].Size()
+	})
+
+	for _, f := range faces {
+		// Only add faces with exactly one embedding so that they can be compared and clustered.
+		if !f.Embeddings.One() {
+			continue
+		}
+
+		marker := NewKeyframeMarker(f, *m, thumbHash, offset)
+
+		// Failed creating new marker?
+		if marker == nil {
+			continue
+		}
+
+		// Append marker if it doesn't conflict with an existing marker.
+		if markers := m.Markers(); !markers.Contains(*marker) {
+			markers.AppendWithEmbedding(*marker)
+		}
+	}
+}
+
+// AddPets adds animal face markers to the file.
+func (m *File) AddPets(faces face.Faces) {
+	sort.Slice(faces, func(i, j int) bool {
+		return faces[i].Size() > faces[j].Size()
+	})
+
+	for _, f := range faces {
+		// Only add faces with exactly one embedding so that they can be compared and clustered.
+		if !f.Embeddings.One() {
+			continue
+		}
+
+		marker := NewPetMarker(f, *m, "")
+
+		// Failed creating new marker?
+		if marker == nil {
+			continue
+		}
+
+		// Append marker if it doesn't conflict with an existing marker.
+		if markers := m.Markers(); !markers.Contains(*marker) {
+			markers.AppendWithEmbedding(*marker)
+		}
+	}
+}
+
 // ValidFaceCount returns the number of valid face markers.
 func (m *File) ValidFaceCount() (c int) {
 	return ValidFaceCount(m.FileUID)
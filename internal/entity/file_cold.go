@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Cold storage states.
+const (
+	FileColdArchived  = "archived"
+	FileColdRestoring = "restoring"
+)
+
+// FileCold represents an original file that was moved to cold storage, e.g. S3 Glacier or an external disk.
+// Thumbnails and metadata remain available, while the file itself must be restored before it can be downloaded.
+type FileCold struct {
+	FileID      uint       `gorm:"primary_key;auto_increment:false;" json:"FileID" yaml:"FileID"`
+	FileName    string     `gorm:"type:VARBINARY(1024);" json:"FileName" yaml:"FileName"`
+	FileSize    int64      `json:"FileSize" yaml:"FileSize"`
+	StorageKey  string     `gorm:"type:VARBINARY(1024);" json:"StorageKey" yaml:"StorageKey"`
+	Status      string     `gorm:"type:VARBINARY(16);index;" json:"Status" yaml:"Status"`
+	Error       string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	RequestedAt *time.Time `json:"RequestedAt" yaml:"RequestedAt,omitempty"`
+	CreatedAt   time.Time  `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt   time.Time  `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// FileColds represents a list of archived files.
+type FileColds []FileCold
+
+// TableName returns the entity table name.
+func (FileCold) TableName() string {
+	return "files_cold"
+}
+
+// NewFileCold returns a new cold storage entry for the original file.
+func NewFileCold(file *File, storageKey string) *FileCold {
+	return &FileCold{
+		FileID:     file.ID,
+		FileName:   file.FileName,
+		FileSize:   file.FileSize,
+		StorageKey: storageKey,
+		Status:     FileColdArchived,
+	}
+}
+
+// FindFileCold returns the cold storage entry of the file, or nil if it is stored online.
+func FindFileCold(fileID uint) *FileCold {
+	if fileID == 0 {
+		return nil
+	}
+
+	m := FileCold{}
+
+	if err := Db().Where("file_id = ?", fileID).First(&m).Error; err != nil {
+		return nil
+	}
+
+	return &m
+}
+
+// Create inserts a new row to the database.
+func (m *FileCold) Create() error {
+	return Db().Create(m).Error
+}
+
+// Updates multiple columns in the database.
+func (m *FileCold) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// Delete removes the entry after the file has been restored.
+func (m *FileCold) Delete() error {
+	return UnscopedDb().Where("file_id = ?", m.FileID).Delete(FileCold{}).Error
+}
+
+// Restoring checks if a restore has been requested.
+func (m *FileCold) Restoring() bool {
+	return m.Status == FileColdRestoring
+}
+
+// RequestRestore adds the file to the restore queue.
+func (m *FileCold) RequestRestore() error {
+	if m.Restoring() {
+		return nil
+	}
+
+	now := TimeStamp()
+
+	m.Status = FileColdRestoring
+	m.RequestedAt = &now
+
+	return m.Updates(Values{"Status": m.Status, "RequestedAt": m.RequestedAt})
+}
+
+// SetError remembers the last error that occurred while restoring the file.
+func (m *FileCold) SetError(err error) error {
+	if err == nil {
+		m.Error = ""
+	} else {
+		m.Error = txt.Clip(err.Error(), 512)
+	}
+
+	return m.Updates(Values{"Error": m.Error})
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCold_TableName(t *testing.T) {
+	assert.Equal(t, "files_cold", FileCold{}.TableName())
+}
+
+func TestNewFileCold(t *testing.T) {
+	m := NewFileCold(&File{ID: 123, FileName: "2015/photo.jpg", FileSize: 1024}, "originals/2015/photo.jpg")
+
+	assert.Equal(t, uint(123), m.FileID)
+	assert.Equal(t, "2015/photo.jpg", m.FileName)
+	assert.Equal(t, int64(1024), m.FileSize)
+	assert.Equal(t, "originals/2015/photo.jpg", m.StorageKey)
+	assert.Equal(t, FileColdArchived, m.Status)
+	assert.False(t, m.Restoring())
+}
+
+func TestFileCold_RequestRestore(t *testing.T) {
+	m := NewFileCold(&File{ID: 1234500, FileName: "2015/restore.jpg", FileSize: 1024}, "originals/2015/restore.jpg")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, m.RequestRestore())
+	assert.True(t, m.Restoring())
+	assert.NotNil(t, m.RequestedAt)
+
+	assert.NoError(t, m.SetError(errors.New("restore failed")))
+
+	found := FindFileCold(m.FileID)
+
+	if found == nil {
+		t.Fatal("entry should exist")
+	}
+
+	assert.Equal(t, FileColdRestoring, found.Status)
+	assert.Equal(t, "restore failed", found.Error)
+
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindFileCold(m.FileID))
+	assert.Nil(t, FindFileCold(0))
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"os"
 	"time"
 )
 
@@ -10,15 +11,21 @@ const (
 	FileSyncExists     = "exists"
 	FileSyncDownloaded = "downloaded"
 	FileSyncUploaded   = "uploaded"
+	FileSyncModified   = "modified"
 )
 
 // FileSync represents a one-to-many relation between File and Account for syncing with remote services.
+// LocalName, LocalDate, and LocalSize describe the originals file as it was last synced, so that local
+// changes can be detected in two-way sync.
 type FileSync struct {
 	RemoteName string `gorm:"primary_key;auto_increment:false;type:VARBINARY(255)"`
 	ServiceID  uint   `gorm:"primary_key;auto_increment:false"`
 	FileID     uint   `gorm:"index;"`
 	RemoteDate time.Time
 	RemoteSize int64
+	LocalName  string `gorm:"type:VARBINARY(1024);"`
+	LocalDate  time.Time
+	LocalSize  int64
 	Status     string `gorm:"type:VARBINARY(16);"`
 	Error      string `gorm:"type:VARBINARY(512);"`
 	Errors     int
@@ -64,6 +71,11 @@ func (m *FileSync) Create() error {
 	return Db().Create(m).Error
 }
 
+// Delete removes the record from the database.
+func (m *FileSync) Delete() error {
+	return UnscopedDb().Where("service_id = ? AND remote_name = ?", m.ServiceID, m.RemoteName).Delete(FileSync{}).Error
+}
+
 // FirstOrCreateFileSync returns the existing row, inserts a new row or nil in case of errors.
 func FirstOrCreateFileSync(m *FileSync) *FileSync {
 	result := FileSync{}
@@ -77,3 +89,41 @@ func FirstOrCreateFileSync(m *FileSync) *FileSync {
 
 	return m
 }
+
+// Rename changes the remote file name, e.g. after the file was moved on the remote server.
+func (m *FileSync) Rename(remoteName string) error {
+	if remoteName == "" || remoteName == m.RemoteName {
+		return nil
+	}
+
+	if err := UnscopedDb().Model(FileSync{}).
+		Where("service_id = ? AND remote_name = ?", m.ServiceID, m.RemoteName).
+		UpdateColumn("remote_name", remoteName).Error; err != nil {
+		return err
+	}
+
+	m.RemoteName = remoteName
+
+	return nil
+}
+
+// SetLocal remembers the state of the originals file as it was synced.
+func (m *FileSync) SetLocal(fileName string, info os.FileInfo) {
+	m.LocalName = fileName
+	m.LocalDate = info.ModTime().UTC().Truncate(time.Second)
+	m.LocalSize = info.Size()
+}
+
+// LocalChanged tests if the originals file was changed since it was last synced.
+func (m *FileSync) LocalChanged(info os.FileInfo) bool {
+	if m.LocalName == "" {
+		return false
+	}
+
+	return info.Size() != m.LocalSize || !info.ModTime().UTC().Truncate(time.Second).Equal(m.LocalDate.UTC())
+}
+
+// RemoteChanged tests if the remote file was changed since it was last synced.
+func (m *FileSync) RemoteChanged(date time.Time, size int64) bool {
+	return size != m.RemoteSize || !date.Equal(m.RemoteDate)
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -104,3 +106,53 @@ func TestFileSync_Save(t *testing.T) {
 		assert.True(t, afterDate.After(initialDate))
 	})
 }
+
+func TestFileSync_Rename(t *testing.T) {
+	fileSync := NewFileSync(123, "/Photos/before-rename.jpg")
+
+	if err := fileSync.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fileSync.Rename("/Photos/after-rename.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "/Photos/after-rename.jpg", fileSync.RemoteName)
+
+	result := FileSync{}
+
+	if err := Db().Where("service_id = ? AND remote_name = ?", 123, "/Photos/after-rename.jpg").First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, FileSyncNew, result.Status)
+}
+
+func TestFileSync_LocalChanged(t *testing.T) {
+	info, err := os.Stat("testdata")
+
+	if err != nil {
+		t.Skip(err)
+	}
+
+	fileSync := NewFileSync(123, "/testdata")
+	assert.False(t, fileSync.LocalChanged(info))
+
+	fileSync.SetLocal("testdata", info)
+	assert.Equal(t, "testdata", fileSync.LocalName)
+	assert.Equal(t, info.Size(), fileSync.LocalSize)
+	assert.False(t, fileSync.LocalChanged(info))
+
+	fileSync.LocalSize++
+	assert.True(t, fileSync.LocalChanged(info))
+}
+
+func TestFileSync_RemoteChanged(t *testing.T) {
+	date := time.Date(2023, 5, 14, 12, 0, 0, 0, time.UTC)
+	fileSync := &FileSync{RemoteDate: date, RemoteSize: 1024}
+
+	assert.False(t, fileSync.RemoteChanged(date, 1024))
+	assert.True(t, fileSync.RemoteChanged(date, 1025))
+	assert.True(t, fileSync.RemoteChanged(date.Add(time.Second), 1024))
+}
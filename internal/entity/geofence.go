@@ -0,0 +1,337 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GeofenceRadius is the default radius of a geofence in meters.
+var GeofenceRadius = 250
+
+// GeofenceMaxRadius is the maximum radius of a geofence in meters.
+var GeofenceMaxRadius = 50000
+
+var geofences Geofences
+var geofencesLoaded bool
+var geofencesMutex = sync.RWMutex{}
+
+// Geofence represents a named area, either a polygon or a radius around a position, in which pictures
+// automatically get a label and/or are added to an album when they are indexed.
+type Geofence struct {
+	ID           uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	FenceName    string    `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	FenceSlug    string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"-"`
+	FenceLat     float64   `gorm:"type:DOUBLE;" json:"Lat" yaml:"Lat,omitempty"`
+	FenceLng     float64   `gorm:"type:DOUBLE;" json:"Lng" yaml:"Lng,omitempty"`
+	FenceRadius  int       `json:"Radius" yaml:"Radius,omitempty"`
+	FencePolygon string    `gorm:"type:VARBINARY(4096);" json:"Polygon" yaml:"Polygon,omitempty"`
+	FenceLabel   string    `gorm:"type:VARCHAR(160);" json:"Label" yaml:"Label,omitempty"`
+	FenceAlbum   string    `gorm:"type:VARCHAR(160);" json:"Album" yaml:"Album,omitempty"`
+	CreatedBy    string    `gorm:"type:VARBINARY(42);" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt    time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt    time.Time `json:"UpdatedAt" yaml:"-"`
+	polygon      geo.Polygon
+}
+
+// Geofences represents a list of geofences.
+type Geofences []Geofence
+
+// TableName returns the entity table name.
+func (Geofence) TableName() string {
+	return "geofences"
+}
+
+// NewGeofence returns a new geofence with the specified name, center, and radius in meters.
+func NewGeofence(name string, lat, lng float64, radius int) *Geofence {
+	m := &Geofence{
+		FenceLat:    lat,
+		FenceLng:    lng,
+		FenceRadius: radius,
+	}
+
+	m.SetName(name)
+
+	return m
+}
+
+// FindGeofence returns the geofence with the specified ID, or nil if it does not exist.
+func FindGeofence(id uint) *Geofence {
+	if id == 0 {
+		return nil
+	}
+
+	m := Geofence{}
+
+	if Db().First(&m, "id = ?", id).Error != nil {
+		return nil
+	}
+
+	return &m
+}
+
+// SetName changes the geofence name and slug.
+func (m *Geofence) SetName(name string) {
+	if name = clean.Name(name); name == "" {
+		return
+	}
+
+	m.FenceName = txt.Clip(name, txt.ClipName)
+	m.FenceSlug = txt.Slug(m.FenceName)
+}
+
+// SetPolygon changes the area to a polygon in the format "lat,lng;lat,lng;lat,lng", or to the radius
+// around the position if the string is empty.
+func (m *Geofence) SetPolygon(s string) error {
+	if s = strings.TrimSpace(s); s == "" {
+		m.FencePolygon = ""
+		m.polygon = nil
+		return nil
+	}
+
+	p, err := geo.ParsePolygon(s)
+
+	if err != nil {
+		return err
+	}
+
+	m.FencePolygon = p.String()
+	m.polygon = p
+
+	return nil
+}
+
+// Polygon returns the parsed polygon, or nil if the geofence is a radius around a position.
+func (m *Geofence) Polygon() geo.Polygon {
+	if m.FencePolygon == "" {
+		return nil
+	} else if m.polygon == nil {
+		m.polygon, _ = geo.ParsePolygon(m.FencePolygon)
+	}
+
+	return m.polygon
+}
+
+// Validate returns an error if the geofence has no name, area, or action.
+func (m *Geofence) Validate() error {
+	if m.FenceName == "" || m.FenceSlug == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+
+	m.FenceLabel = clean.Name(m.FenceLabel)
+	m.FenceAlbum = clean.Name(m.FenceAlbum)
+
+	if m.FenceLabel == "" && m.FenceAlbum == "" {
+		return fmt.Errorf("label or album must not be empty")
+	}
+
+	if m.FencePolygon != "" {
+		if err := m.SetPolygon(m.FencePolygon); err != nil {
+			return err
+		}
+
+		// Use the center of the bounding box as position.
+		latMin, latMax, lngMin, lngMax := m.polygon.Bounds()
+		m.FenceLat, m.FenceLng, m.FenceRadius = (latMin+latMax)/2, (lngMin+lngMax)/2, 0
+
+		return nil
+	}
+
+	if m.FenceLat < -90 || m.FenceLat > 90 || m.FenceLng < -180 || m.FenceLng > 180 || m.FenceLat == 0 && m.FenceLng == 0 {
+		return fmt.Errorf("invalid position")
+	}
+
+	if m.FenceRadius <= 0 {
+		m.FenceRadius = GeofenceRadius
+	} else if m.FenceRadius > GeofenceMaxRadius {
+		return fmt.Errorf("radius must not exceed %d meters", GeofenceMaxRadius)
+	}
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *Geofence) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer FlushGeofences()
+
+	return Db().Create(m).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *Geofence) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer FlushGeofences()
+
+	return Db().Save(m).Error
+}
+
+// SaveForm updates the geofence from form values.
+func (m *Geofence) SaveForm(f form.Geofence) error {
+	m.SetName(f.FenceName)
+	m.FenceLat = f.FenceLat
+	m.FenceLng = f.FenceLng
+	m.FenceRadius = f.FenceRadius
+	m.FenceLabel = f.FenceLabel
+	m.FenceAlbum = f.FenceAlbum
+
+	if err := m.SetPolygon(f.FencePolygon); err != nil {
+		return err
+	}
+
+	return m.Save()
+}
+
+// Delete removes the geofence from the database.
+func (m *Geofence) Delete() error {
+	if m.ID == 0 {
+		return fmt.Errorf("id must not be empty")
+	}
+
+	defer FlushGeofences()
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// Contains tests if the coordinates are within the geofence.
+func (m *Geofence) Contains(lat, lng float64) bool {
+	if lat == 0 && lng == 0 {
+		return false
+	} else if p := m.Polygon(); p != nil {
+		return p.Contains(lat, lng)
+	} else if m.FenceRadius <= 0 {
+		return false
+	}
+
+	return geo.Zone{Lat: m.FenceLat, Lng: m.FenceLng, Radius: float64(m.FenceRadius)}.Contains(lat, lng)
+}
+
+// Label returns the label that is added to pictures taken in the geofence, if any.
+func (m *Geofence) Label() (classify.Label, bool) {
+	if m.FenceLabel == "" {
+		return classify.Label{}, false
+	}
+
+	return classify.Label{Name: m.FenceLabel, Source: classify.SrcLocation, Uncertainty: 0, Priority: 0}, true
+}
+
+// AddPhoto adds the picture to the geofence album, which is created if needed. Pictures that have
+// been removed from the album are not added again.
+func (m *Geofence) AddPhoto(photoUid string) error {
+	if m.FenceAlbum == "" || photoUid == "" {
+		return nil
+	}
+
+	albumUid := m.FenceAlbum
+
+	if !rnd.IsUID(albumUid, AlbumUID) {
+		a := NewUserAlbum(m.FenceAlbum, AlbumManual, m.CreatedBy)
+
+		if found := a.Find(); found != nil {
+			albumUid = found.AlbumUID
+		} else if err := a.Create(); err != nil {
+			return err
+		} else {
+			albumUid = a.AlbumUID
+		}
+	}
+
+	if err := UnscopedDb().Where("photo_uid = ? AND album_uid = ?", photoUid, albumUid).First(&PhotoAlbum{}).Error; err == nil {
+		return nil
+	}
+
+	entry := PhotoAlbum{AlbumUID: albumUid, PhotoUID: photoUid, Order: nextPhotoOrder(albumUid), CreatedBy: m.CreatedBy}
+
+	return entry.Create()
+}
+
+// At returns the geofences that contain the coordinates.
+func (list Geofences) At(lat, lng float64) (result Geofences) {
+	for i := range list {
+		if list[i].Contains(lat, lng) {
+			result = append(result, list[i])
+		}
+	}
+
+	return result
+}
+
+// Labels returns the labels that are added to pictures taken in the geofences.
+func (list Geofences) Labels() (result classify.Labels) {
+	for i := range list {
+		if l, ok := list[i].Label(); ok {
+			result = append(result, l)
+		}
+	}
+
+	return result
+}
+
+// AddPhoto adds the picture to the albums of the geofences.
+func (list Geofences) AddPhoto(photoUid string) {
+	for i := range list {
+		if err := list[i].AddPhoto(photoUid); err != nil {
+			log.Errorf("geofence: %s (add photo %s to %s)", err, clean.Log(photoUid), clean.Log(list[i].FenceName))
+		}
+	}
+}
+
+// FindGeofences returns all geofences sorted by name.
+func FindGeofences() (result Geofences, err error) {
+	err = Db().Order("fence_name, id").Find(&result).Error
+
+	return result, err
+}
+
+// CachedGeofences returns all geofences from the cache, which is loaded from the database if needed.
+func CachedGeofences() Geofences {
+	geofencesMutex.RLock()
+
+	if geofencesLoaded {
+		defer geofencesMutex.RUnlock()
+		return geofences
+	}
+
+	geofencesMutex.RUnlock()
+
+	geofencesMutex.Lock()
+	defer geofencesMutex.Unlock()
+
+	if result, err := FindGeofences(); err != nil {
+		log.Warnf("geofence: %s (find)", err)
+		return Geofences{}
+	} else {
+		// Parse polygons in advance, as the cached geofences are shared.
+		for i := range result {
+			result[i].Polygon()
+		}
+
+		geofences = result
+		geofencesLoaded = true
+	}
+
+	return geofences
+}
+
+// FlushGeofences resets the geofences cache.
+func FlushGeofences() {
+	geofencesMutex.Lock()
+	defer geofencesMutex.Unlock()
+
+	geofences = nil
+	geofencesLoaded = false
+}
@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestNewGeofence(t *testing.T) {
+	m := NewGeofence("  Cottage ", 61.4978, 23.7610, 0)
+	m.FenceAlbum = "Cottage"
+
+	assert.Equal(t, "Cottage", m.FenceName)
+	assert.Equal(t, "cottage", m.FenceSlug)
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, GeofenceRadius, m.FenceRadius)
+}
+
+func TestGeofence_Validate(t *testing.T) {
+	t.Run("NoAction", func(t *testing.T) {
+		assert.Error(t, NewGeofence("Cottage", 61.4978, 23.7610, 100).Validate())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		m := NewGeofence("", 61.4978, 23.7610, 100)
+		m.FenceLabel = "Summer"
+		assert.Error(t, m.Validate())
+
+		m = NewGeofence("Cottage", 0, 0, 100)
+		m.FenceLabel = "Summer"
+		assert.Error(t, m.Validate())
+
+		m = NewGeofence("Cottage", 61.4978, 23.7610, GeofenceMaxRadius+1)
+		m.FenceLabel = "Summer"
+		assert.Error(t, m.Validate())
+	})
+	t.Run("Polygon", func(t *testing.T) {
+		m := NewGeofence("Cottage", 0, 0, 100)
+		m.FenceLabel = "Summer"
+
+		assert.NoError(t, m.SetPolygon("61.49,23.75;61.50,23.75;61.50,23.77;61.49,23.77"))
+		assert.NoError(t, m.Validate())
+		assert.InDelta(t, 61.495, m.FenceLat, 0.0001)
+		assert.InDelta(t, 23.76, m.FenceLng, 0.0001)
+		assert.Equal(t, 0, m.FenceRadius)
+	})
+}
+
+func TestGeofence_SetPolygon(t *testing.T) {
+	m := NewGeofence("Cottage", 61.4978, 23.7610, 100)
+
+	assert.Error(t, m.SetPolygon("61.49,23.75;61.50,23.75"))
+	assert.Equal(t, "", m.FencePolygon)
+	assert.NoError(t, m.SetPolygon("61.49,23.75;61.50,23.75;61.50,23.77"))
+	assert.Equal(t, "61.490000,23.750000;61.500000,23.750000;61.500000,23.770000", m.FencePolygon)
+	assert.Len(t, m.Polygon(), 3)
+	assert.NoError(t, m.SetPolygon(""))
+	assert.Nil(t, m.Polygon())
+}
+
+func TestGeofence_Contains(t *testing.T) {
+	t.Run("Radius", func(t *testing.T) {
+		m := NewGeofence("Cottage", 61.4978, 23.7610, 300)
+
+		assert.True(t, m.Contains(61.4978, 23.7610))
+		assert.True(t, m.Contains(61.4990, 23.7620))
+		assert.False(t, m.Contains(61.5100, 23.7610))
+		assert.False(t, m.Contains(0, 0))
+	})
+	t.Run("Polygon", func(t *testing.T) {
+		m := &Geofence{FenceName: "Cottage", FencePolygon: "61.49,23.75;61.50,23.75;61.50,23.77;61.49,23.77"}
+
+		assert.True(t, m.Contains(61.495, 23.76))
+		assert.False(t, m.Contains(61.4978, 23.78))
+	})
+}
+
+func TestGeofences_At(t *testing.T) {
+	cottage := NewGeofence("Cottage", 61.4978, 23.7610, 300)
+	cottage.FenceAlbum = "Cottage"
+
+	lake := NewGeofence("Lake", 61.4978, 23.7610, 5000)
+	lake.FenceLabel = "Lake"
+
+	list := Geofences{*cottage, *lake}
+
+	assert.Len(t, list.At(61.4978, 23.7610), 2)
+	assert.Len(t, list.At(61.5100, 23.7610), 1)
+	assert.Len(t, list.At(48.1351, 11.5820), 0)
+
+	labels := list.At(61.4978, 23.7610).Labels()
+
+	if assert.Len(t, labels, 1) {
+		assert.Equal(t, "Lake", labels[0].Name)
+		assert.Equal(t, classify.SrcLocation, labels[0].Source)
+	}
+}
+
+func TestGeofence_Save(t *testing.T) {
+	m := NewGeofence("Cottage", 61.4978, 23.7610, 300)
+	m.FenceAlbum = "Cottage"
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, CachedGeofences().At(61.4978, 23.7610), 1)
+
+	if err := m.SaveForm(form.Geofence{FenceName: "Summer Cottage", FenceLat: 61.4978, FenceLng: 23.7610, FenceLabel: "Summer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindGeofence(m.ID)
+
+	if assert.NotNil(t, found) {
+		assert.Equal(t, "summer-cottage", found.FenceSlug)
+		assert.Equal(t, "Summer", found.FenceLabel)
+		assert.Equal(t, "", found.FenceAlbum)
+	}
+
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindGeofence(m.ID))
+	assert.Len(t, CachedGeofences().At(61.4978, 23.7610), 0)
+}
+
+func TestGeofence_AddPhoto(t *testing.T) {
+	m := NewGeofence("Cottage", 61.4978, 23.7610, 300)
+	m.FenceAlbum = "Geofence Test Album"
+
+	photo := PhotoFixtures.Get("19800101_000002_D640C559")
+
+	assert.NoError(t, m.AddPhoto(photo.PhotoUID))
+
+	a := NewAlbum("Geofence Test Album", AlbumManual).Find()
+
+	if assert.NotNil(t, a) {
+		var entry PhotoAlbum
+
+		assert.NoError(t, UnscopedDb().Where("photo_uid = ? AND album_uid = ?", photo.PhotoUID, a.AlbumUID).First(&entry).Error)
+
+		// Removed pictures are not added again.
+		assert.NoError(t, UnscopedDb().Model(&entry).UpdateColumn("hidden", true).Error)
+		assert.NoError(t, m.AddPhoto(photo.PhotoUID))
+		assert.NoError(t, UnscopedDb().Where("photo_uid = ? AND album_uid = ?", photo.PhotoUID, a.AlbumUID).First(&entry).Error)
+		assert.True(t, entry.Hidden)
+	}
+}
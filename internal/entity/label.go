@@ -32,6 +32,7 @@ type Label struct {
 	LabelName        string     `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
 	LabelPriority    int        `json:"Priority" yaml:"Priority,omitempty"`
 	LabelFavorite    bool       `json:"Favorite" yaml:"Favorite,omitempty"`
+	LabelThreshold   float32    `gorm:"type:FLOAT;" json:"Threshold" yaml:"Threshold,omitempty"`
 	LabelDescription string     `gorm:"type:VARCHAR(2048);" json:"Description" yaml:"Description,omitempty"`
 	LabelNotes       string     `gorm:"type:VARCHAR(1024);" json:"Notes" yaml:"Notes,omitempty"`
 	LabelCategories  []*Label   `gorm:"many2many:categories;association_jointable_foreignkey:category_id" json:"-" yaml:"-"`
@@ -100,6 +101,7 @@ func (m *Label) Create() error {
 // Delete removes the label from the database.
 func (m *Label) Delete() error {
 	Db().Where("label_id = ? OR category_id = ?", m.ID, m.ID).Delete(&Category{})
+	Db().Where("label_id = ? OR ancestor_id = ?", m.ID, m.ID).Delete(&LabelClosure{})
 	Db().Where("label_id = ?", m.ID).Delete(&PhotoLabel{})
 	return Db().Delete(m).Error
 }
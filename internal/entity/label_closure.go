@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"fmt"
+	"sync"
+)
+
+var labelClosureMutex = sync.Mutex{}
+
+// LabelClosure represents the relationship between a label and one of its ancestors, e.g. Animal → Dog → Beagle,
+// so that searching for a label also finds pictures with more specific labels. Each label is its own ancestor
+// with depth 0.
+type LabelClosure struct {
+	AncestorID uint `gorm:"primary_key;auto_increment:false"`
+	LabelID    uint `gorm:"primary_key;auto_increment:false;index"`
+	Depth      int  `json:"Depth"`
+}
+
+// TableName returns the entity table name.
+func (LabelClosure) TableName() string {
+	return "labels_closure"
+}
+
+// labelParents returns the parent label IDs by label ID, as stored in the categories table.
+func labelParents() (result map[uint][]uint, err error) {
+	var categories []Category
+
+	if err = UnscopedDb().Select("label_id, category_id").Find(&categories).Error; err != nil {
+		return result, err
+	}
+
+	result = make(map[uint][]uint, len(categories))
+
+	for _, c := range categories {
+		if c.LabelID != c.CategoryID {
+			result[c.LabelID] = append(result[c.LabelID], c.CategoryID)
+		}
+	}
+
+	return result, nil
+}
+
+// RebuildLabelClosure rebuilds the label closure table based on the parent categories of all labels.
+func RebuildLabelClosure() (count int, err error) {
+	labelClosureMutex.Lock()
+	defer labelClosureMutex.Unlock()
+
+	parents, err := labelParents()
+
+	if err != nil {
+		return count, err
+	}
+
+	var ids []uint
+
+	if err = UnscopedDb().Model(&Label{}).Pluck("id", &ids).Error; err != nil {
+		return count, err
+	}
+
+	tx := UnscopedDb().Begin()
+
+	if err = tx.Delete(&LabelClosure{}).Error; err != nil {
+		tx.Rollback()
+		return count, err
+	}
+
+	for _, id := range ids {
+		// Walk up the hierarchy, ignoring cycles.
+		depth := map[uint]int{id: 0}
+		queue := []uint{id}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for _, parent := range parents[current] {
+				if _, found := depth[parent]; !found {
+					depth[parent] = depth[current] + 1
+					queue = append(queue, parent)
+				}
+			}
+		}
+
+		for ancestor, d := range depth {
+			if err = tx.Create(&LabelClosure{AncestorID: ancestor, LabelID: id, Depth: d}).Error; err != nil {
+				tx.Rollback()
+				return count, err
+			}
+
+			count++
+		}
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// LabelDescendantIDs returns the IDs of the specified labels and all their descendants.
+func LabelDescendantIDs(ids []uint) (result []uint) {
+	result = append(result, ids...)
+
+	if len(ids) == 0 {
+		return result
+	}
+
+	var descendants []uint
+
+	if err := UnscopedDb().Model(&LabelClosure{}).
+		Where("ancestor_id IN (?) AND depth > 0", ids).
+		Pluck("DISTINCT label_id", &descendants).Error; err != nil {
+		log.Errorf("label: %s (find descendants)", err)
+		return result
+	}
+
+	return append(result, descendants...)
+}
+
+// Parents returns the parent labels.
+func (m *Label) Parents() (result Labels) {
+	result = Labels{}
+
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := UnscopedDb().Where("id IN (SELECT category_id FROM categories WHERE label_id = ? AND category_id <> ?)", m.ID, m.ID).
+		Order("label_name").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find parents)", err)
+	}
+
+	return result
+}
+
+// IsAncestorOf checks if the label is a direct or indirect parent of the specified label.
+func (m *Label) IsAncestorOf(labelId uint) bool {
+	if m.ID == 0 || labelId == 0 || labelId == m.ID {
+		return false
+	}
+
+	parents, err := labelParents()
+
+	if err != nil {
+		log.Errorf("label: %s (find parents)", err)
+		return false
+	}
+
+	visited := map[uint]bool{labelId: true}
+	queue := []uint{labelId}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, parent := range parents[current] {
+			if parent == m.ID {
+				return true
+			} else if !visited[parent] {
+				visited[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	return false
+}
+
+// SetParent replaces the parent labels with the specified label, or removes them if it is nil,
+// and then rebuilds the label closure table.
+func (m *Label) SetParent(parent *Label) error {
+	if m.ID == 0 {
+		return fmt.Errorf("label does not exist")
+	} else if parent != nil && parent.ID == 0 {
+		return fmt.Errorf("parent label does not exist")
+	} else if parent != nil && (parent.ID == m.ID || m.IsAncestorOf(parent.ID)) {
+		return fmt.Errorf("parent must not be the label itself or one of its descendants")
+	}
+
+	labelCategoriesMutex.Lock()
+
+	if err := UnscopedDb().Where("label_id = ?", m.ID).Delete(&Category{}).Error; err != nil {
+		labelCategoriesMutex.Unlock()
+		return err
+	} else if parent == nil {
+		// Remove parents only.
+	} else if err = UnscopedDb().Create(&Category{LabelID: m.ID, CategoryID: parent.ID}).Error; err != nil {
+		labelCategoriesMutex.Unlock()
+		return err
+	}
+
+	labelCategoriesMutex.Unlock()
+
+	_, err := RebuildLabelClosure()
+
+	return err
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel_SetParent(t *testing.T) {
+	animal := FirstOrCreateLabel(NewLabel("Closure Animal", 0))
+	dog := FirstOrCreateLabel(NewLabel("Closure Dog", 0))
+	beagle := FirstOrCreateLabel(NewLabel("Closure Beagle", 0))
+
+	t.Run("Hierarchy", func(t *testing.T) {
+		if err := dog.SetParent(animal); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := beagle.SetParent(dog); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, animal.IsAncestorOf(beagle.ID))
+		assert.False(t, beagle.IsAncestorOf(animal.ID))
+
+		if parents := beagle.Parents(); assert.Len(t, parents, 1) {
+			assert.Equal(t, dog.ID, parents[0].ID)
+		}
+
+		ids := LabelDescendantIDs([]uint{animal.ID})
+
+		assert.Contains(t, ids, animal.ID)
+		assert.Contains(t, ids, dog.ID)
+		assert.Contains(t, ids, beagle.ID)
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		assert.Error(t, animal.SetParent(beagle))
+		assert.Error(t, animal.SetParent(animal))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		if err := dog.SetParent(nil); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, dog.Parents(), 0)
+
+		ids := LabelDescendantIDs([]uint{animal.ID})
+
+		assert.Contains(t, ids, animal.ID)
+		assert.NotContains(t, ids, beagle.ID)
+	})
+	t.Run("NotSaved", func(t *testing.T) {
+		label := Label{}
+		assert.Error(t, label.SetParent(animal))
+		assert.Error(t, dog.SetParent(&Label{}))
+	})
+}
+
+func TestRebuildLabelClosure(t *testing.T) {
+	if count, err := RebuildLabelClosure(); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.GreaterOrEqual(t, count, len(LabelFixtures))
+	}
+
+	flower := LabelFixtures.Get("flower")
+	landscape := LabelFixtures.Get("landscape")
+
+	assert.Contains(t, LabelDescendantIDs([]uint{landscape.ID}), flower.ID)
+}
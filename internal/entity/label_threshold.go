@@ -0,0 +1,42 @@
+package entity
+
+// Label threshold calibration defaults.
+var (
+	LabelThresholdStep float32 = 0.05
+	LabelThresholdMax  float32 = 0.95
+)
+
+// Accept tests if a label with the specified model confidence may be assigned automatically.
+func (m *Label) Accept(confidence float32) bool {
+	return confidence <= 0 || confidence >= m.LabelThreshold
+}
+
+// Calibrate adjusts the minimum confidence of automatically assigned labels based on review feedback:
+// rejected labels raise the threshold above their confidence, while confirmed labels lower it if needed.
+func (m *Label) Calibrate(confidence float32, confirmed bool) error {
+	threshold := m.LabelThreshold
+
+	if confidence <= 0 {
+		return nil
+	} else if confirmed && confidence < threshold {
+		threshold = confidence
+	} else if !confirmed && confidence+LabelThresholdStep > threshold {
+		threshold = confidence + LabelThresholdStep
+	}
+
+	if threshold > LabelThresholdMax {
+		threshold = LabelThresholdMax
+	}
+
+	if threshold == m.LabelThreshold {
+		return nil
+	}
+
+	m.LabelThreshold = threshold
+
+	if m.ID == 0 {
+		return nil
+	}
+
+	return m.Update("LabelThreshold", threshold)
+}
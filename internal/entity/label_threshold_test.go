@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel_Accept(t *testing.T) {
+	m := Label{LabelThreshold: 0.5}
+
+	assert.True(t, m.Accept(0))
+	assert.True(t, m.Accept(0.5))
+	assert.True(t, m.Accept(0.9))
+	assert.False(t, m.Accept(0.4))
+}
+
+func TestLabel_Calibrate(t *testing.T) {
+	t.Run("Reject", func(t *testing.T) {
+		m := Label{}
+
+		assert.NoError(t, m.Calibrate(0.4, false))
+		assert.InEpsilon(t, 0.45, m.LabelThreshold, 0.001)
+
+		assert.NoError(t, m.Calibrate(0.3, false))
+		assert.InEpsilon(t, 0.45, m.LabelThreshold, 0.001)
+
+		assert.NoError(t, m.Calibrate(0.99, false))
+		assert.Equal(t, LabelThresholdMax, m.LabelThreshold)
+	})
+	t.Run("Confirm", func(t *testing.T) {
+		m := Label{LabelThreshold: 0.6}
+
+		assert.NoError(t, m.Calibrate(0.7, true))
+		assert.Equal(t, float32(0.6), m.LabelThreshold)
+
+		assert.NoError(t, m.Calibrate(0.5, true))
+		assert.Equal(t, float32(0.5), m.LabelThreshold)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		m := Label{LabelThreshold: 0.6}
+
+		assert.NoError(t, m.Calibrate(0, false))
+		assert.Equal(t, float32(0.6), m.LabelThreshold)
+	})
+}
+
+func TestPhotoLabel_ModelConfidence(t *testing.T) {
+	assert.Equal(t, float32(0.83), (&PhotoLabel{Uncertainty: 20, Confidence: 0.83}).ModelConfidence())
+	assert.Equal(t, float32(0.8), (&PhotoLabel{Uncertainty: 20}).ModelConfidence())
+	assert.Equal(t, float32(0), (&PhotoLabel{Uncertainty: 100}).ModelConfidence())
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -22,20 +23,27 @@ type Links []Link
 
 // Link represents a link to share content.
 type Link struct {
-	LinkUID     string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
-	ShareUID    string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"ShareUID" yaml:"ShareUID"`
-	ShareSlug   string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
-	LinkToken   string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
-	LinkExpires int       `json:"Expires" yaml:"Expires,omitempty"`
-	LinkViews   uint      `json:"Views" yaml:"-"`
-	MaxViews    uint      `json:"MaxViews" yaml:"-"`
-	HasPassword bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
-	Comment     string    `gorm:"size:512;" json:"Comment,omitempty" yaml:"Comment,omitempty"`
-	Perm        uint      `json:"Perm,omitempty" yaml:"Perm,omitempty"`
-	RefID       string    `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
-	CreatedBy   string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
-	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
-	ModifiedAt  time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
+	LinkUID       string     `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
+	ShareUID      string     `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"ShareUID" yaml:"ShareUID"`
+	ShareSlug     string     `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
+	LinkToken     string     `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
+	LinkExpires   int        `json:"Expires" yaml:"Expires,omitempty"`
+	LinkExpiresAt *time.Time `json:"ExpiresAt" yaml:"ExpiresAt,omitempty"`
+	LinkViews     uint       `json:"Views" yaml:"-"`
+	MaxViews      uint       `json:"MaxViews" yaml:"-"`
+	HasPassword   bool       `json:"HasPassword" yaml:"HasPassword,omitempty"`
+	NoDownload    bool       `json:"NoDownload" yaml:"NoDownload,omitempty"`
+	Watermark     bool       `json:"Watermark" yaml:"Watermark,omitempty"`
+	UploadReview  bool       `json:"UploadReview" yaml:"UploadReview,omitempty"`
+	UploadLimit   int        `json:"UploadLimit" yaml:"UploadLimit,omitempty"`
+	UploadTypes   string     `gorm:"type:VARBINARY(255);" json:"UploadTypes" yaml:"UploadTypes,omitempty"`
+	Comment       string     `gorm:"size:512;" json:"Comment,omitempty" yaml:"Comment,omitempty"`
+	Perm          uint       `json:"Perm,omitempty" yaml:"Perm,omitempty"`
+	RefID         string     `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
+	CreatedBy     string     `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt     time.Time  `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
+	ModifiedAt    time.Time  `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
+	AccessedAt    *time.Time `deepcopier:"skip" json:"AccessedAt" yaml:"-"`
 }
 
 // TableName returns the entity table name.
@@ -78,11 +86,17 @@ func NewUserLink(shareUid, userUid string) Link {
 	return result
 }
 
-// Redeem increases the number of link visitors by one.
+// Redeem increases the number of link visitors by one and updates the time of last access.
 func (m *Link) Redeem() *Link {
+	accessedAt := TimeStamp()
+
 	m.LinkViews += 1
+	m.AccessedAt = &accessedAt
 
-	if err := Db().Model(m).UpdateColumn("link_views", gorm.Expr("link_views + 1")).Error; err != nil {
+	if err := Db().Model(m).UpdateColumns(Values{
+		"link_views":  gorm.Expr("link_views + 1"),
+		"accessed_at": accessedAt,
+	}).Error; err != nil {
 		event.AuditWarn([]string{"link %s", "failed to update view counter"}, clean.Log(m.RefID), err)
 	}
 
@@ -92,11 +106,15 @@ func (m *Link) Redeem() *Link {
 // ExpiresAt returns the time when the share link expires or nil if it never expires.
 func (m *Link) ExpiresAt() *time.Time {
 	if m.LinkExpires <= 0 {
-		return nil
+		return m.LinkExpiresAt
 	}
 
-	expires := TimeStamp()
-	expires = m.ModifiedAt.Add(Seconds(m.LinkExpires))
+	expires := m.ModifiedAt.Add(Seconds(m.LinkExpires))
+
+	// Use the fixed expiration date if it is earlier.
+	if m.LinkExpiresAt != nil && m.LinkExpiresAt.Before(expires) {
+		return m.LinkExpiresAt
+	}
 
 	return &expires
 }
@@ -238,6 +256,39 @@ func FindLinks(token, shared string) (found Links) {
 	return found
 }
 
+// InvalidPassword checks if the password provided is invalid for any of the links.
+func (m Links) InvalidPassword(password string) bool {
+	for i := range m {
+		if m[i].InvalidPassword(password) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NoDownload checks if downloading originals has been disabled for any of the links.
+func (m Links) NoDownload() bool {
+	for i := range m {
+		if m[i].NoDownload {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WatermarkLink returns the first link that has watermarks enabled, or nil if there is none.
+func (m Links) WatermarkLink() *Link {
+	for i := range m {
+		if m[i].Watermark {
+			return &m[i]
+		}
+	}
+
+	return nil
+}
+
 // FindValidLinks returns a slice of non-expired links for a token and share UID (at least one must be provided).
 func FindValidLinks(token, shared string) (found Links) {
 	found = Links{}
@@ -253,6 +304,17 @@ func FindValidLinks(token, shared string) (found Links) {
 	return found
 }
 
+// Url returns the absolute share link URL based on the site URL, e.g. for sending it by email.
+func (m *Link) Url(siteUrl string) string {
+	shared := m.ShareSlug
+
+	if shared == "" {
+		shared = m.ShareUID
+	}
+
+	return fmt.Sprintf("%ss/%s/%s", strings.TrimRight(siteUrl, "/")+"/", m.LinkToken, shared)
+}
+
 // String returns a human-readable identifier for use in logs.
 func (m *Link) String() string {
 	return clean.Log(m.LinkUID)
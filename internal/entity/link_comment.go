@@ -0,0 +1,52 @@
+package entity
+
+// CanComment checks if guests may comment on the pictures shared with the link.
+func (m *Link) CanComment() bool {
+	return m.Perm&PermComment != 0
+}
+
+// SetComment allows or disallows guest comments with the share link.
+func (m *Link) SetComment(allow bool) {
+	if allow {
+		m.Perm |= PermComment
+	} else {
+		m.Perm &^= PermComment
+	}
+}
+
+// CanReact checks if guests may react to the pictures shared with the link, which is also
+// possible if they may comment on them.
+func (m *Link) CanReact() bool {
+	return m.Perm&(PermReact|PermComment) != 0
+}
+
+// SetReact allows or disallows guest reactions with the share link.
+func (m *Link) SetReact(allow bool) {
+	if allow {
+		m.Perm |= PermReact
+	} else {
+		m.Perm &^= PermReact
+	}
+}
+
+// CommentLink returns the first link that allows guest comments, or nil if there is none.
+func (m Links) CommentLink() *Link {
+	for i := range m {
+		if m[i].CanComment() {
+			return &m[i]
+		}
+	}
+
+	return nil
+}
+
+// ReactLink returns the first link that allows guest reactions, or nil if there is none.
+func (m Links) ReactLink() *Link {
+	for i := range m {
+		if m[i].CanReact() {
+			return &m[i]
+		}
+	}
+
+	return nil
+}
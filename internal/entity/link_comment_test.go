@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink_SetComment(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.False(t, link.CanComment())
+	assert.False(t, link.CanReact())
+	link.SetComment(true)
+	assert.True(t, link.CanComment())
+	assert.True(t, link.CanReact())
+	link.SetComment(false)
+	assert.False(t, link.CanComment())
+	assert.False(t, link.CanReact())
+}
+
+func TestLink_SetReact(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	link.SetReact(true)
+	assert.True(t, link.CanReact())
+	assert.False(t, link.CanComment())
+	link.SetReact(false)
+	assert.False(t, link.CanReact())
+}
+
+func TestLinks_CommentLink(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.Nil(t, Links{}.CommentLink())
+	assert.Nil(t, Links{link}.CommentLink())
+
+	link.SetReact(true)
+
+	assert.Nil(t, Links{link}.CommentLink())
+	assert.NotNil(t, Links{link}.ReactLink())
+
+	link.SetComment(true)
+
+	if result := (Links{NewLink("at9lxuqxpogaaba7", false, false), link}).CommentLink(); result == nil {
+		t.Fatal("link expected")
+	} else {
+		assert.Equal(t, link.LinkUID, result.LinkUID)
+	}
+}
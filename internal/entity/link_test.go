@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,34 @@ func TestLink_Expired(t *testing.T) {
 	assert.True(t, link.Expired())
 }
 
+func TestLink_ExpiresAt(t *testing.T) {
+	t.Run("Never", func(t *testing.T) {
+		link := NewLink("st9lxuqxpogaaba1", false, false)
+		assert.Nil(t, link.ExpiresAt())
+	})
+	t.Run("Date", func(t *testing.T) {
+		link := NewLink("st9lxuqxpogaaba1", false, false)
+		expires := TimeStamp().Add(-1 * time.Hour)
+		link.LinkExpiresAt = &expires
+
+		assert.Equal(t, &expires, link.ExpiresAt())
+		assert.True(t, link.Expired())
+	})
+	t.Run("Earliest", func(t *testing.T) {
+		link := NewLink("st9lxuqxpogaaba1", false, false)
+		expires := link.ModifiedAt.Add(Day)
+		link.LinkExpires = 60 * 60
+		link.LinkExpiresAt = &expires
+
+		assert.Equal(t, link.ModifiedAt.Add(time.Hour), *link.ExpiresAt())
+
+		link.LinkExpires = 60 * 60 * 48
+
+		assert.Equal(t, expires, *link.ExpiresAt())
+		assert.False(t, link.Expired())
+	})
+}
+
 func TestLink_Redeem(t *testing.T) {
 	link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
 
@@ -59,6 +88,14 @@ func TestLink_Redeem(t *testing.T) {
 	link.Redeem()
 
 	assert.Equal(t, uint(2), link.LinkViews)
+	assert.NotNil(t, link.AccessedAt)
+
+	if found := FindLink(link.LinkUID); found == nil {
+		t.Fatal("link not found")
+	} else {
+		assert.Equal(t, uint(2), found.LinkViews)
+		assert.NotNil(t, found.AccessedAt)
+	}
 }
 
 func TestLink_SetSlug(t *testing.T) {
@@ -103,6 +140,46 @@ func TestLink_InvalidPassword(t *testing.T) {
 	})
 }
 
+func TestLinks_InvalidPassword(t *testing.T) {
+	protected := NewLink("dhfjfkl", false, false)
+
+	if err := protected.SetPassword("Sh4reLinkPasswd"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, Links{}.InvalidPassword(""))
+	assert.False(t, Links{NewLink("dhfjfkm", false, false)}.InvalidPassword(""))
+	assert.True(t, Links{protected}.InvalidPassword(""))
+	assert.True(t, Links{protected}.InvalidPassword("wrong"))
+	assert.False(t, Links{protected}.InvalidPassword("Sh4reLinkPasswd"))
+}
+
+func TestLinks_NoDownload(t *testing.T) {
+	link := NewLink("st9lxuqxpogaaba1", false, false)
+
+	assert.False(t, Links{}.NoDownload())
+	assert.False(t, Links{link}.NoDownload())
+
+	link.NoDownload = true
+
+	assert.True(t, Links{NewLink("st9lxuqxpogaaba2", false, false), link}.NoDownload())
+}
+
+func TestLinks_WatermarkLink(t *testing.T) {
+	link := NewLink("st9lxuqxpogaaba1", false, false)
+
+	assert.Nil(t, Links{}.WatermarkLink())
+	assert.Nil(t, Links{link}.WatermarkLink())
+
+	link.Watermark = true
+
+	if result := (Links{NewLink("st9lxuqxpogaaba2", false, false), link}).WatermarkLink(); result == nil {
+		t.Fatal("link expected")
+	} else {
+		assert.Equal(t, link.LinkUID, result.LinkUID)
+	}
+}
+
 func TestLink_Save(t *testing.T) {
 	t.Run("invalid share uid", func(t *testing.T) {
 		link := NewLink("dhfjfjh", false, false)
@@ -186,6 +263,17 @@ func TestFindValidLinksLinks(t *testing.T) {
 	})
 }
 
+func TestLink_Url(t *testing.T) {
+	t.Run("Slug", func(t *testing.T) {
+		link := Link{ShareUID: "at9lxuqxpogaaba8", ShareSlug: "holiday", LinkToken: "1jxf3jfn2k"}
+		assert.Equal(t, "https://photos.example.com/s/1jxf3jfn2k/holiday", link.Url("https://photos.example.com/"))
+	})
+	t.Run("NoSlug", func(t *testing.T) {
+		link := Link{ShareUID: "at9lxuqxpogaaba8", LinkToken: "1jxf3jfn2k"}
+		assert.Equal(t, "https://photos.example.com/s/1jxf3jfn2k/at9lxuqxpogaaba8", link.Url("https://photos.example.com"))
+	})
+}
+
 func TestLink_String(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		link := NewLink("jhgko", false, false)
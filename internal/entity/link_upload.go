@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// CanUpload checks if guests may upload files with the share link, e.g. to collect the pictures taken at a wedding.
+func (m *Link) CanUpload() bool {
+	return m.Perm&PermUpload != 0
+}
+
+// SetUpload allows or disallows guest uploads with the share link.
+func (m *Link) SetUpload(allow bool) {
+	if allow {
+		m.Perm |= PermUpload
+	} else {
+		m.Perm &^= PermUpload
+	}
+}
+
+// SetUploadTypes sets the file extensions that guests may upload, e.g. "jpg, heic, mp4".
+func (m *Link) SetUploadTypes(s string) {
+	var types []string
+
+	for _, ext := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == ';' }) {
+		if ext = fs.TrimExt(ext); ext != "" {
+			types = append(types, ext)
+		}
+	}
+
+	m.UploadTypes = strings.Join(types, ",")
+}
+
+// UploadLimitBytes returns the maximum size of a file uploaded by guests in bytes, or 0 if there is no limit.
+func (m *Link) UploadLimitBytes() int64 {
+	if m.UploadLimit <= 0 {
+		return 0
+	}
+
+	return int64(m.UploadLimit) * 1024 * 1024
+}
+
+// AllowUpload checks if a guest may upload the file with the share link,
+// based on its type and the configured size and type limits.
+func (m *Link) AllowUpload(fileName string, size int64) bool {
+	if !m.CanUpload() || fileName == "" {
+		return false
+	} else if limit := m.UploadLimitBytes(); limit > 0 && size > limit {
+		return false
+	} else if !media.MainFile(fileName) {
+		return false
+	} else if m.UploadTypes == "" {
+		return true
+	}
+
+	ext := fs.TrimExt(filepath.Ext(fileName))
+
+	for _, t := range strings.Split(m.UploadTypes, ",") {
+		if t == ext {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UploadLink returns the first link that allows guest uploads, or nil if there is none.
+func (m Links) UploadLink() *Link {
+	for i := range m {
+		if m[i].CanUpload() {
+			return &m[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink_SetUpload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.False(t, link.CanUpload())
+	link.SetUpload(true)
+	assert.True(t, link.CanUpload())
+	link.SetUpload(false)
+	assert.False(t, link.CanUpload())
+}
+
+func TestLink_SetUploadTypes(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	link.SetUploadTypes(".JPG, heic;mp4 ")
+	assert.Equal(t, "jpg,heic,mp4", link.UploadTypes)
+	link.SetUploadTypes("")
+	assert.Equal(t, "", link.UploadTypes)
+}
+
+func TestLink_UploadLimitBytes(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.Equal(t, int64(0), link.UploadLimitBytes())
+	link.UploadLimit = 5
+	assert.Equal(t, int64(5*1024*1024), link.UploadLimitBytes())
+}
+
+func TestLink_AllowUpload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.False(t, link.AllowUpload("IMG_1234.jpg", 1000))
+
+	link.SetUpload(true)
+
+	assert.True(t, link.AllowUpload("IMG_1234.jpg", 1000))
+	assert.True(t, link.AllowUpload("VID_1234.MP4", 1000))
+	assert.False(t, link.AllowUpload("IMG_1234.xmp", 1000))
+	assert.False(t, link.AllowUpload("notes.txt", 1000))
+	assert.False(t, link.AllowUpload("", 1000))
+
+	link.UploadLimit = 1
+
+	assert.True(t, link.AllowUpload("IMG_1234.jpg", 1024*1024))
+	assert.False(t, link.AllowUpload("IMG_1234.jpg", 1024*1024+1))
+
+	link.SetUploadTypes("jpg")
+
+	assert.True(t, link.AllowUpload("IMG_1234.JPG", 1000))
+	assert.False(t, link.AllowUpload("VID_1234.mp4", 1000))
+}
+
+func TestLinks_UploadLink(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.Nil(t, Links{}.UploadLink())
+	assert.Nil(t, Links{link}.UploadLink())
+
+	link.SetUpload(true)
+
+	if result := (Links{NewLink("at9lxuqxpogaaba7", false, false), link}).UploadLink(); result == nil {
+		t.Fatal("link expected")
+	} else {
+		assert.Equal(t, link.LinkUID, result.LinkUID)
+	}
+}
@@ -20,38 +20,41 @@ import (
 const (
 	MarkerUnknown = ""
 	MarkerFace    = "face"  // MarkerType for faces (implemented).
+	MarkerPet     = "pet"   // MarkerType for pets and other animals.
 	MarkerLabel   = "label" // MarkerType for labels (todo).
 )
 
 // Marker represents an image marker point.
 type Marker struct {
-	MarkerUID      string          `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
-	FileUID        string          `gorm:"type:VARBINARY(42);index;default:'';" json:"FileUID" yaml:"FileUID"`
-	MarkerType     string          `gorm:"type:VARBINARY(8);default:'';" json:"Type" yaml:"Type"`
-	MarkerSrc      string          `gorm:"type:VARBINARY(8);default:'';" json:"Src" yaml:"Src,omitempty"`
-	MarkerName     string          `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name,omitempty"`
-	MarkerReview   bool            `json:"Review" yaml:"Review,omitempty"`
-	MarkerInvalid  bool            `json:"Invalid" yaml:"Invalid,omitempty"`
-	SubjUID        string          `gorm:"type:VARBINARY(42);index:idx_markers_subj_uid_src;" json:"SubjUID" yaml:"SubjUID,omitempty"`
-	SubjSrc        string          `gorm:"type:VARBINARY(8);index:idx_markers_subj_uid_src;default:'';" json:"SubjSrc" yaml:"SubjSrc,omitempty"`
-	subject        *Subject        `gorm:"foreignkey:SubjUID;association_foreignkey:SubjUID;association_autoupdate:false;association_autocreate:false;association_save_reference:false"`
-	FaceID         string          `gorm:"type:VARBINARY(64);index;" json:"FaceID" yaml:"FaceID,omitempty"`
-	FaceDist       float64         `gorm:"default:-1;" json:"FaceDist" yaml:"FaceDist,omitempty"`
-	face           *Face           `gorm:"foreignkey:FaceID;association_foreignkey:ID;association_autoupdate:false;association_autocreate:false;association_save_reference:false"`
-	EmbeddingsJSON json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"EmbeddingsJSON,omitempty"`
-	embeddings     face.Embeddings `gorm:"-"`
-	LandmarksJSON  json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"LandmarksJSON,omitempty"`
-	X              float32         `gorm:"type:FLOAT;" json:"X" yaml:"X,omitempty"`
-	Y              float32         `gorm:"type:FLOAT;" json:"Y" yaml:"Y,omitempty"`
-	W              float32         `gorm:"type:FLOAT;" json:"W" yaml:"W,omitempty"`
-	H              float32         `gorm:"type:FLOAT;" json:"H" yaml:"H,omitempty"`
-	Q              int             `json:"Q" yaml:"Q,omitempty"`
-	Size           int             `gorm:"default:-1;" json:"Size" yaml:"Size,omitempty"`
-	Score          int             `gorm:"type:SMALLINT;" json:"Score" yaml:"Score,omitempty"`
-	Thumb          string          `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
-	MatchedAt      *time.Time      `sql:"index" json:"MatchedAt" yaml:"MatchedAt,omitempty"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	MarkerUID       string          `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	FileUID         string          `gorm:"type:VARBINARY(42);index;default:'';" json:"FileUID" yaml:"FileUID"`
+	MarkerType      string          `gorm:"type:VARBINARY(8);default:'';" json:"Type" yaml:"Type"`
+	MarkerSrc       string          `gorm:"type:VARBINARY(8);default:'';" json:"Src" yaml:"Src,omitempty"`
+	MarkerName      string          `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name,omitempty"`
+	MarkerReview    bool            `json:"Review" yaml:"Review,omitempty"`
+	MarkerInvalid   bool            `json:"Invalid" yaml:"Invalid,omitempty"`
+	SubjUID         string          `gorm:"type:VARBINARY(42);index:idx_markers_subj_uid_src;" json:"SubjUID" yaml:"SubjUID,omitempty"`
+	SubjSrc         string          `gorm:"type:VARBINARY(8);index:idx_markers_subj_uid_src;default:'';" json:"SubjSrc" yaml:"SubjSrc,omitempty"`
+	subject         *Subject        `gorm:"foreignkey:SubjUID;association_foreignkey:SubjUID;association_autoupdate:false;association_autocreate:false;association_save_reference:false"`
+	FaceID          string          `gorm:"type:VARBINARY(64);index;" json:"FaceID" yaml:"FaceID,omitempty"`
+	FaceDist        float64         `gorm:"default:-1;" json:"FaceDist" yaml:"FaceDist,omitempty"`
+	face            *Face           `gorm:"foreignkey:FaceID;association_foreignkey:ID;association_autoupdate:false;association_autocreate:false;association_save_reference:false"`
+	EmbeddingsJSON  json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"EmbeddingsJSON,omitempty"`
+	embeddings      face.Embeddings `gorm:"-"`
+	EmbeddingsModel string          `gorm:"type:VARBINARY(64);default:'facenet';" json:"EmbeddingsModel" yaml:"EmbeddingsModel,omitempty"`
+	LandmarksJSON   json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"LandmarksJSON,omitempty"`
+	X               float32         `gorm:"type:FLOAT;" json:"X" yaml:"X,omitempty"`
+	Y               float32         `gorm:"type:FLOAT;" json:"Y" yaml:"Y,omitempty"`
+	W               float32         `gorm:"type:FLOAT;" json:"W" yaml:"W,omitempty"`
+	H               float32         `gorm:"type:FLOAT;" json:"H" yaml:"H,omitempty"`
+	Q               int             `json:"Q" yaml:"Q,omitempty"`
+	Size            int             `gorm:"default:-1;" json:"Size" yaml:"Size,omitempty"`
+	Score           int             `gorm:"type:SMALLINT;" json:"Score" yaml:"Score,omitempty"`
+	Thumb           string          `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
+	MarkerTime      float64         `gorm:"default:0;" json:"Time" yaml:"Time,omitempty"`
+	MatchedAt       *time.Time      `sql:"index" json:"MatchedAt" yaml:"MatchedAt,omitempty"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // TableName returns the entity table name.
@@ -112,10 +115,49 @@ func NewFaceMarker(f face.Face, file File, subjUid string) *Marker {
 	return m
 }
 
+// NewPetMarker creates a new marker for an animal face.
+func NewPetMarker(f face.Face, file File, subjUid string) *Marker {
+	m := NewMarker(file, f.CropArea(), subjUid, SrcImage, MarkerPet, f.Size(), f.Score)
+
+	// Failed creating new marker?
+	if m == nil {
+		return nil
+	}
+
+	m.SetEmbeddings(f.Embeddings)
+
+	return m
+}
+
+// NewKeyframeMarker creates a new face marker for a still image sampled from a video at the specified offset.
+// The thumb hash refers to the cached still image, so that the face can be cropped from it.
+func NewKeyframeMarker(f face.Face, file File, thumbHash string, offset time.Duration) *Marker {
+	if thumbHash == "" {
+		log.Errorf("markers: keyframe hash is empty - possible bug")
+		return nil
+	}
+
+	file.FileHash = thumbHash
+
+	m := NewMarker(file, f.CropArea(), "", SrcVideo, MarkerFace, f.Size(), f.Score)
+
+	// Failed creating new marker?
+	if m == nil {
+		return nil
+	}
+
+	m.MarkerTime = offset.Seconds()
+	m.SetEmbeddings(f.Embeddings)
+	m.LandmarksJSON = f.RelativeLandmarksJSON()
+
+	return m
+}
+
 // SetEmbeddings assigns new face emebddings to the marker.
 func (m *Marker) SetEmbeddings(e face.Embeddings) {
 	m.embeddings = e
 	m.EmbeddingsJSON = e.JSON()
+	m.EmbeddingsModel = face.ActiveModel.Name
 }
 
 // UpdateFile sets the file uid and thumb and updates the index if the marker already exists.
@@ -125,7 +167,8 @@ func (m *Marker) UpdateFile(file *File) (updated bool) {
 		updated = true
 	}
 
-	if file.FileHash != "" && !strings.HasPrefix(m.Thumb, file.FileHash) {
+	// Keyframe markers refer to a still image sampled from the video.
+	if file.FileHash != "" && !m.IsKeyframe() && !strings.HasPrefix(m.Thumb, file.FileHash) {
 		m.Thumb = crop.NewArea("crop", m.X, m.Y, m.W, m.H).Thumb(file.FileHash)
 		updated = true
 	}
@@ -224,8 +267,10 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 		return false, fmt.Errorf("face is nil")
 	}
 
-	if m.MarkerType != MarkerFace {
+	if !m.Recognizable() {
 		return false, fmt.Errorf("not a face marker")
+	} else if f.IsPet() != m.IsPet() {
+		return false, fmt.Errorf("face and marker type do not match")
 	}
 
 	// Any reason we don't want to set a new face for this marker?
@@ -314,7 +359,7 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 // SyncSubject maintains the marker subject relationship.
 func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 	// Face marker? If not, return.
-	if m.MarkerType != MarkerFace {
+	if !m.Recognizable() {
 		return nil
 	}
 
@@ -367,7 +412,7 @@ func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 
 // InvalidArea tests if the marker area is invalid or out of range.
 func (m *Marker) InvalidArea() error {
-	if m.MarkerType != MarkerFace {
+	if !m.Recognizable() {
 		return nil
 	}
 
@@ -435,7 +480,7 @@ func (m *Marker) Subject() (subj *Subject) {
 
 	// Create subject?
 	if m.SubjSrc != SrcAuto && m.MarkerName != "" && m.SubjUID == "" {
-		if subj = NewSubject(m.MarkerName, SubjPerson, m.SubjSrc); subj == nil {
+		if subj = NewSubject(m.MarkerName, m.SubjType(), m.SubjSrc); subj == nil {
 			log.Errorf("faces: marker %s has invalid subject %s", clean.Log(m.MarkerUID), clean.Log(m.MarkerName))
 			return nil
 		} else if subj = FirstOrCreateSubject(subj); subj == nil {
@@ -490,6 +535,15 @@ func (m *Marker) ClearSubject(src string) error {
 	return nil
 }
 
+// ConfirmSubject confirms an automatically matched subject, so that it is no longer changed by face matching.
+func (m *Marker) ConfirmSubject() error {
+	if m.SubjUID == "" {
+		return fmt.Errorf("marker %s has no subject", clean.Log(m.MarkerUID))
+	}
+
+	return m.Updates(Values{"SubjSrc": SrcManual, "MarkerReview": false})
+}
+
 // Face returns a matching face entity if possible.
 func (m *Marker) Face() (f *Face) {
 	if m.MarkerUID == "" {
@@ -516,7 +570,11 @@ func (m *Marker) Face() (f *Face) {
 		} else if f = NewFace(m.SubjUID, m.SubjSrc, emb); f == nil {
 			log.Warnf("faces: failed assigning face to marker %s", clean.Log(m.MarkerUID))
 			return nil
-		} else if f.SkipMatching() {
+		} else if m.IsPet() {
+			f.FaceKind = int(face.PetFace)
+		}
+
+		if f.SkipMatching() {
 			log.Infof("faces: skipped matching marker %s, embedding %s not distinct enough", clean.Log(m.MarkerUID), f.ID)
 		} else if f = FirstOrCreateFace(f); f == nil {
 			log.Warnf("faces: failed matching marker %s with subject %s", clean.Log(m.MarkerUID), SubjNames.Log(m.SubjUID))
@@ -663,6 +721,35 @@ func (m *Marker) DetectedFace() bool {
 	return m.MarkerType == MarkerFace && m.MarkerSrc == SrcImage
 }
 
+// IsKeyframe tests if the marker was detected in a still image sampled from a video.
+func (m *Marker) IsKeyframe() bool {
+	return m.MarkerSrc == SrcVideo
+}
+
+// IsPet tests if the marker is the face of a pet or other animal.
+func (m *Marker) IsPet() bool {
+	return m.MarkerType == MarkerPet
+}
+
+// DetectedPet tests if the marker is an automatically detected animal face.
+func (m *Marker) DetectedPet() bool {
+	return m.IsPet() && m.MarkerSrc == SrcImage
+}
+
+// Recognizable tests if the marker type supports clustering and matching, i.e. faces of people and pets.
+func (m *Marker) Recognizable() bool {
+	return m.MarkerType == MarkerFace || m.MarkerType == MarkerPet
+}
+
+// SubjType returns the type of subject the marker may be assigned to.
+func (m *Marker) SubjType() string {
+	if m.IsPet() {
+		return SubjPet
+	}
+
+	return SubjPerson
+}
+
 // Uncertainty returns the detection uncertainty based on the score in percent.
 func (m *Marker) Uncertainty() int {
 	switch {
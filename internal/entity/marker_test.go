@@ -1,9 +1,12 @@
 package entity
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/stretchr/testify/assert"
 )
@@ -58,6 +61,63 @@ func TestNewMarker(t *testing.T) {
 	assert.Equal(t, MarkerLabel, m.MarkerType)
 }
 
+func TestNewPetMarker(t *testing.T) {
+	f := face.Face{
+		Rows:       480,
+		Cols:       720,
+		Score:      45,
+		Area:       face.NewArea("face", 250, 200, 10),
+		Embeddings: face.RandomEmbeddings(1, face.RegularFace),
+	}
+
+	m := NewPetMarker(f, FileFixtures.Get("exampleFileName.jpg"), "")
+
+	assert.Equal(t, MarkerPet, m.MarkerType)
+	assert.Equal(t, SrcImage, m.MarkerSrc)
+	assert.True(t, m.IsPet())
+	assert.True(t, m.DetectedPet())
+	assert.False(t, m.DetectedFace())
+	assert.True(t, m.Recognizable())
+	assert.Equal(t, SubjPet, m.SubjType())
+	assert.Equal(t, face.ActiveModel.Name, m.EmbeddingsModel)
+	assert.Equal(t, 1, Markers{*m}.DetectedPetCount())
+	assert.Equal(t, 0, Markers{*m}.DetectedFaceCount())
+}
+
+func TestNewKeyframeMarker(t *testing.T) {
+	f := face.Face{
+		Rows:       480,
+		Cols:       720,
+		Score:      45,
+		Area:       face.NewArea("face", 250, 200, 10),
+		Embeddings: face.RandomEmbeddings(1, face.RegularFace),
+	}
+
+	file := FileFixtures.Get("exampleFileName.jpg")
+	hash := "2a7c9e2f3b1d4e5f6a7b8c9d0e1f2a3b4c5d6e7f"
+
+	m := NewKeyframeMarker(f, file, hash, 12500*time.Millisecond)
+
+	assert.Equal(t, MarkerFace, m.MarkerType)
+	assert.Equal(t, SrcVideo, m.MarkerSrc)
+	assert.Equal(t, file.FileUID, m.FileUID)
+	assert.True(t, strings.HasPrefix(m.Thumb, hash))
+	assert.Equal(t, 12.5, m.MarkerTime)
+	assert.True(t, m.IsKeyframe())
+	assert.False(t, m.DetectedFace())
+	assert.Equal(t, 1, Markers{*m}.KeyframeFaceCount(12500*time.Millisecond))
+	assert.Equal(t, 0, Markers{*m}.KeyframeFaceCount(time.Second))
+	assert.False(t, m.UpdateFile(&file))
+	assert.True(t, strings.HasPrefix(m.Thumb, hash))
+	assert.Nil(t, NewKeyframeMarker(f, file, "", time.Second))
+}
+
+func TestMarker_SubjType(t *testing.T) {
+	assert.Equal(t, SubjPerson, (&Marker{MarkerType: MarkerFace}).SubjType())
+	assert.Equal(t, SubjPet, (&Marker{MarkerType: MarkerPet}).SubjType())
+	assert.False(t, (&Marker{MarkerType: MarkerLabel}).Recognizable())
+}
+
 func TestMarker_SetName(t *testing.T) {
 	t.Run("InvalidName", func(t *testing.T) {
 		m := MarkerFixtures.Get("actress-a-1")
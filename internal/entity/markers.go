@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/face"
@@ -43,10 +44,13 @@ func (m Markers) Unsaved() bool {
 	return false
 }
 
-// Contains returns true if a marker at the same position already exists.
+// Contains returns true if a marker at the same position and video time already exists.
 func (m Markers) Contains(other Marker) bool {
 	for i := range m {
-		if m[i].OverlapPercent(other) > face.OverlapThreshold {
+		// Markers of different video keyframes do not overlap.
+		if m[i].MarkerTime != other.MarkerTime {
+			continue
+		} else if m[i].OverlapPercent(other) > face.OverlapThreshold {
 			return true
 		}
 	}
@@ -65,6 +69,28 @@ func (m Markers) DetectedFaceCount() (count int) {
 	return count
 }
 
+// KeyframeFaceCount returns the number of face markers detected in the video keyframe at the specified offset.
+func (m Markers) KeyframeFaceCount(offset time.Duration) (count int) {
+	for i := range m {
+		if m[i].IsKeyframe() && m[i].MarkerType == MarkerFace && m[i].MarkerTime == offset.Seconds() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// DetectedPetCount returns the number of automatically detected animal face markers.
+func (m Markers) DetectedPetCount() (count int) {
+	for i := range m {
+		if m[i].DetectedPet() {
+			count++
+		}
+	}
+
+	return count
+}
+
 // ValidFaceCount returns the number of valid face markers.
 func (m Markers) ValidFaceCount() (count int) {
 	for i := range m {
@@ -34,6 +34,19 @@ func TestMarkers_Contains(t *testing.T) {
 		assert.True(t, m.Contains(m1))
 		assert.False(t, m.Contains(m3))
 	})
+	t.Run("Keyframes", func(t *testing.T) {
+		m1 := *NewMarker(FileFixtures.Get("exampleFileName.jpg"), cropArea1, "", SrcVideo, MarkerFace, 100, 65)
+		m2 := *NewMarker(FileFixtures.Get("exampleFileName.jpg"), cropArea2, "", SrcVideo, MarkerFace, 100, 65)
+
+		m1.MarkerTime = 3
+		m2.MarkerTime = 3
+
+		assert.True(t, Markers{m2}.Contains(m1))
+
+		m2.MarkerTime = 6
+
+		assert.False(t, Markers{m2}.Contains(m1))
+	})
 	t.Run("Conflicting", func(t *testing.T) {
 		file := File{FileUID: "", FileHash: "cca7c46a4d39e933c30805e546028fe3eab361b5"}
 
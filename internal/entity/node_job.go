@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Node job types that can be offloaded to worker nodes.
+const (
+	NodeJobThumbs    = "thumbs"
+	NodeJobTranscode = "transcode"
+	NodeJobLabels    = "labels"
+)
+
+// NodeJobTypes lists all node job types.
+var NodeJobTypes = []string{NodeJobThumbs, NodeJobTranscode, NodeJobLabels}
+
+// Node job states.
+const (
+	NodeJobQueued  = "queued"
+	NodeJobRunning = "running"
+	NodeJobDone    = "done"
+	NodeJobFailed  = "failed"
+)
+
+// NodeJob represents a CPU-heavy job in the shared database queue that is executed by a worker node.
+type NodeJob struct {
+	ID         uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	JobType    string     `gorm:"type:VARBINARY(16);index;" json:"JobType" yaml:"JobType"`
+	FileRoot   string     `gorm:"type:VARBINARY(16);default:'/';" json:"FileRoot" yaml:"FileRoot"`
+	FileName   string     `gorm:"type:VARBINARY(1024);" json:"FileName" yaml:"FileName"`
+	PhotoUID   string     `gorm:"type:VARBINARY(42);" json:"PhotoUID" yaml:"PhotoUID,omitempty"`
+	JobForce   bool       `json:"JobForce" yaml:"JobForce,omitempty"`
+	Status     string     `gorm:"type:VARBINARY(16);index;" json:"Status" yaml:"Status"`
+	NodeName   string     `gorm:"type:VARCHAR(64);" json:"NodeName" yaml:"NodeName,omitempty"`
+	Error      string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	StartedAt  *time.Time `json:"StartedAt" yaml:"StartedAt,omitempty"`
+	FinishedAt *time.Time `json:"FinishedAt" yaml:"FinishedAt,omitempty"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// NodeJobs represents a list of node jobs.
+type NodeJobs []NodeJob
+
+// TableName returns the entity table name.
+func (NodeJob) TableName() string {
+	return "node_jobs"
+}
+
+// NewNodeJob returns a new queued job for the file.
+func NewNodeJob(jobType, fileRoot, fileName string, force bool) *NodeJob {
+	return &NodeJob{
+		JobType:  jobType,
+		FileRoot: fileRoot,
+		FileName: fileName,
+		JobForce: force,
+		Status:   NodeJobQueued,
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *NodeJob) Create() error {
+	return Db().Create(m).Error
+}
+
+// Updates multiple columns in the database.
+func (m *NodeJob) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// ClaimNodeJob assigns the oldest queued job of the specified types to the node and returns it,
+// or nil if there is none. Jobs already claimed by other nodes are skipped.
+func ClaimNodeJob(nodeName string, jobTypes []string) (*NodeJob, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		m := NodeJob{}
+
+		if err := Db().Where("status = ? AND job_type IN (?)", NodeJobQueued, jobTypes).
+			Order("id").First(&m).Error; err == gorm.ErrRecordNotFound {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		now := TimeStamp()
+
+		// Only one node can change the status, so concurrent claims of the same job fail.
+		res := UnscopedDb().Model(&NodeJob{}).
+			Where("id = ? AND status = ?", m.ID, NodeJobQueued).
+			UpdateColumns(Values{"Status": NodeJobRunning, "NodeName": txt.Clip(nodeName, 64), "StartedAt": now})
+
+		if res.Error != nil {
+			return nil, res.Error
+		} else if res.RowsAffected == 1 {
+			m.Status = NodeJobRunning
+			m.NodeName = txt.Clip(nodeName, 64)
+			m.StartedAt = &now
+			return &m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Finish marks the job as done, or as failed if an error is passed.
+func (m *NodeJob) Finish(err error) error {
+	now := TimeStamp()
+
+	m.FinishedAt = &now
+
+	if err == nil {
+		m.Status = NodeJobDone
+		m.Error = ""
+	} else {
+		m.Status = NodeJobFailed
+		m.Error = txt.Clip(err.Error(), 512)
+	}
+
+	return m.Updates(Values{"Status": m.Status, "Error": m.Error, "FinishedAt": m.FinishedAt})
+}
+
+// PurgeNodeJobs removes finished jobs that are older than the specified time.
+func PurgeNodeJobs(before time.Time) (int64, error) {
+	res := UnscopedDb().Where("status = ? AND finished_at < ?", NodeJobDone, before).Delete(NodeJob{})
+
+	return res.RowsAffected, res.Error
+}
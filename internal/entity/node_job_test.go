@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeJob_TableName(t *testing.T) {
+	assert.Equal(t, "node_jobs", NodeJob{}.TableName())
+}
+
+func TestNewNodeJob(t *testing.T) {
+	m := NewNodeJob(NodeJobThumbs, RootOriginals, "2015/photo.jpg", true)
+
+	assert.Equal(t, NodeJobThumbs, m.JobType)
+	assert.Equal(t, RootOriginals, m.FileRoot)
+	assert.Equal(t, "2015/photo.jpg", m.FileName)
+	assert.True(t, m.JobForce)
+	assert.Equal(t, NodeJobQueued, m.Status)
+}
+
+func TestClaimNodeJob(t *testing.T) {
+	m := NewNodeJob(NodeJobTranscode, RootOriginals, "2015/video.mov", false)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := ClaimNodeJob("desktop", []string{NodeJobTranscode})
+
+	if err != nil {
+		t.Fatal(err)
+	} else if job == nil {
+		t.Fatal("job should not be nil")
+	}
+
+	assert.Equal(t, m.ID, job.ID)
+	assert.Equal(t, NodeJobRunning, job.Status)
+	assert.Equal(t, "desktop", job.NodeName)
+	assert.NotNil(t, job.StartedAt)
+
+	// Jobs can only be claimed once.
+	if other, err := ClaimNodeJob("laptop", []string{NodeJobTranscode}); err != nil {
+		t.Fatal(err)
+	} else if other != nil {
+		assert.NotEqual(t, m.ID, other.ID)
+	}
+
+	assert.NoError(t, job.Finish(errors.New("ffmpeg failed")))
+	assert.Equal(t, NodeJobFailed, job.Status)
+	assert.Equal(t, "ffmpeg failed", job.Error)
+	assert.NotNil(t, job.FinishedAt)
+}
+
+func TestClaimNodeJob_None(t *testing.T) {
+	job, err := ClaimNodeJob("desktop", []string{"unknown"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
@@ -45,6 +45,19 @@ func (m Photos) UIDs() []string {
 	return result
 }
 
+// OwnedBy returns the photos created by the specified user.
+func (m Photos) OwnedBy(userUid string) Photos {
+	result := make(Photos, 0, len(m))
+
+	for i := range m {
+		if userUid != "" && m[i].CreatedBy == userUid {
+			result = append(result, m[i])
+		}
+	}
+
+	return result
+}
+
 // MapKey returns a key referencing time and location for indexing.
 func MapKey(takenAt time.Time, cellId string) string {
 	return path.Join(strconv.FormatInt(takenAt.Unix(), 36), cellId)
@@ -80,6 +93,9 @@ type Photo struct {
 	PhotoAltitude    int           `json:"Altitude" yaml:"Altitude,omitempty"`
 	PhotoLat         float32       `gorm:"type:FLOAT;index;" json:"Lat" yaml:"Lat,omitempty"`
 	PhotoLng         float32       `gorm:"type:FLOAT;index;" json:"Lng" yaml:"Lng,omitempty"`
+	PhotoLight       string        `gorm:"type:VARBINARY(16);" json:"Light,omitempty" yaml:"-"`
+	PhotoSun         string        `gorm:"type:VARBINARY(16);" json:"Sun,omitempty" yaml:"-"`
+	PhotoMoon        string        `gorm:"type:VARBINARY(16);" json:"Moon,omitempty" yaml:"-"`
 	PhotoCountry     string        `gorm:"type:VARBINARY(2);index:idx_photos_country_year_month;default:'zz'" json:"Country" yaml:"-"`
 	PhotoYear        int           `gorm:"index:idx_photos_ymd;index:idx_photos_country_year_month;" json:"Year" yaml:"Year"`
 	PhotoMonth       int           `gorm:"index:idx_photos_ymd;index:idx_photos_country_year_month;" json:"Month" yaml:"Month"`
@@ -89,6 +105,10 @@ type Photo struct {
 	PhotoFNumber     float32       `gorm:"type:FLOAT;" json:"FNumber" yaml:"FNumber,omitempty"`
 	PhotoFocalLength int           `json:"FocalLength" yaml:"FocalLength,omitempty"`
 	PhotoQuality     int           `gorm:"type:SMALLINT" json:"Quality" yaml:"Quality,omitempty"`
+	PhotoScore       float32       `gorm:"type:FLOAT;" json:"Score" yaml:"Score,omitempty"`
+	PhotoBlurry      bool          `json:"Blurry" yaml:"Blurry,omitempty"`
+	PhotoBadExposure bool          `json:"BadExposure" yaml:"BadExposure,omitempty"`
+	PhotoNSFW        float32       `gorm:"type:FLOAT;" json:"NSFW" yaml:"NSFW,omitempty"`
 	PhotoFaces       int           `json:"Faces,omitempty" yaml:"Faces,omitempty"`
 	PhotoResolution  int           `gorm:"type:SMALLINT" json:"Resolution" yaml:"-"`
 	PhotoDuration    time.Duration `json:"Duration,omitempty" yaml:"Duration,omitempty"`
@@ -461,6 +481,7 @@ func (m *Photo) IndexKeywords() error {
 	keywords = append(keywords, txt.Words(details.Keywords)...)
 	keywords = append(keywords, txt.Keywords(details.Subject)...)
 	keywords = append(keywords, txt.Keywords(details.Artist)...)
+	keywords = append(keywords, txt.Keywords(details.Text)...)
 
 	keywords = txt.UniqueWords(keywords)
 
@@ -597,11 +618,19 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 			continue
 		}
 
+		if classifyLabel.Source == SrcImage && !labelEntity.Accept(classifyLabel.Confidence) {
+			log.Debugf("index: skipping label %s below threshold (%s)", clean.Log(classifyLabel.Title()), m)
+			continue
+		}
+
 		if err := labelEntity.UpdateClassify(classifyLabel); err != nil {
 			log.Errorf("index: failed updating label %s (%s)", clean.Log(classifyLabel.Title()), err)
 		}
 
-		photoLabel := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, labelEntity.ID, classifyLabel.Uncertainty, classifyLabel.Source))
+		newLabel := NewPhotoLabel(m.ID, labelEntity.ID, classifyLabel.Uncertainty, classifyLabel.Source)
+		newLabel.Confidence = classifyLabel.Confidence
+
+		photoLabel := FirstOrCreatePhotoLabel(newLabel)
 
 		if photoLabel == nil {
 			log.Errorf("index: photo-label %d should not be nil - possible bug (%s)", labelEntity.ID, m)
@@ -611,6 +640,7 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 		if photoLabel.Uncertainty > classifyLabel.Uncertainty && photoLabel.Uncertainty < 100 {
 			if err := photoLabel.Updates(map[string]interface{}{
 				"Uncertainty": classifyLabel.Uncertainty,
+				"Confidence":  classifyLabel.Confidence,
 				"LabelSrc":    classifyLabel.Source,
 			}); err != nil {
 				log.Errorf("index: %s", err)
@@ -637,6 +667,18 @@ func (m *Photo) SetDescription(desc, source string) {
 	m.DescriptionSrc = source
 }
 
+// SetCaption sets an automatically generated description, unless the description has been set
+// from another source, e.g. by a user who may also have removed it on purpose.
+func (m *Photo) SetCaption(caption string) {
+	if m.DescriptionSrc != SrcAuto && m.DescriptionSrc != SrcCaption {
+		return
+	} else if m.HasDescription() && m.DescriptionSrc == SrcAuto {
+		return
+	}
+
+	m.SetDescription(caption, SrcCaption)
+}
+
 // SetCamera updates the camera.
 func (m *Photo) SetCamera(camera *Camera, source string) {
 	if camera == nil {
@@ -796,6 +838,10 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("index: %s (remove labels)", logErr)
 	}
 
+	if logErr := UnscopedDb().Delete(PhotoEmbedding{}, "photo_uid = ?", m.PhotoUID).Error; logErr != nil {
+		log.Errorf("index: %s (remove embeddings)", logErr)
+	}
+
 	if logErr := UnscopedDb().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID).Error; logErr != nil {
 		log.Errorf("index: %s (remove albums)", logErr)
 	}
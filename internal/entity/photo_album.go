@@ -2,6 +2,8 @@ package entity
 
 import (
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 type PhotoAlbums []PhotoAlbum
@@ -11,8 +13,10 @@ type PhotoAlbum struct {
 	PhotoUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"PhotoUID" yaml:"UID"`
 	AlbumUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"AlbumUID" yaml:"-"`
 	Order     int       `json:"Order" yaml:"Order,omitempty"`
+	Caption   string    `gorm:"type:VARCHAR(2048);" json:"Caption,omitempty" yaml:"Caption,omitempty"`
 	Hidden    bool      `json:"Hidden" yaml:"Hidden,omitempty"`
 	Missing   bool      `json:"Missing" yaml:"Missing,omitempty"`
+	CreatedBy string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
 	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
 	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
 	Photo     *Photo    `gorm:"PRELOAD:false" yaml:"-"`
@@ -44,6 +48,55 @@ func (m *PhotoAlbum) Save() error {
 	return Db().Save(m).Error
 }
 
+// Add inserts the entry or, if it already exists, makes it visible again without changing
+// album-specific values such as the position and caption.
+func (m *PhotoAlbum) Add() error {
+	found := PhotoAlbum{}
+
+	if err := UnscopedDb().Where("photo_uid = ? AND album_uid = ?", m.PhotoUID, m.AlbumUID).First(&found).Error; err != nil {
+		return m.Create()
+	} else if found.Hidden {
+		// Entries that have been removed before are added again like new ones.
+		values := Values{"hidden": false, "missing": false, "order": m.Order, "created_by": m.CreatedBy, "updated_at": TimeStamp()}
+
+		if err = UnscopedDb().Model(&found).UpdateColumns(values).Error; err != nil {
+			return err
+		}
+
+		found.Hidden, found.Missing, found.Order, found.CreatedBy = false, false, m.Order, m.CreatedBy
+	} else if found.Missing {
+		if err = UnscopedDb().Model(&found).UpdateColumns(Values{"missing": false, "updated_at": TimeStamp()}).Error; err != nil {
+			return err
+		}
+
+		found.Missing = false
+	}
+
+	*m = found
+
+	return nil
+}
+
+// SetCaption changes the album-specific caption of the entry.
+func (m *PhotoAlbum) SetCaption(caption string) error {
+	m.Caption = txt.Clip(caption, txt.ClipText)
+
+	return UnscopedDb().Model(m).UpdateColumns(Values{"caption": m.Caption, "updated_at": TimeStamp()}).Error
+}
+
+// FindPhotoAlbum returns the album entry of a picture or nil if it was not found.
+func FindPhotoAlbum(photoUid, albumUid string) *PhotoAlbum {
+	m := &PhotoAlbum{}
+
+	if photoUid == "" || albumUid == "" {
+		return nil
+	} else if err := UnscopedDb().Where("photo_uid = ? AND album_uid = ?", photoUid, albumUid).First(m).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
 // FirstOrCreatePhotoAlbum returns the existing row, inserts a new row or nil in case of errors.
 func FirstOrCreatePhotoAlbum(m *PhotoAlbum) *PhotoAlbum {
 	result := PhotoAlbum{}
@@ -69,3 +69,64 @@ func TestPhotoAlbum_Save(t *testing.T) {
 		}
 	})
 }
+
+func TestFindPhotoAlbum(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindPhotoAlbum("pt9jtdre2lvl0yh7", "at9lxuqxpogaaba8")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "at9lxuqxpogaaba8", m.AlbumUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindPhotoAlbum("pt9jtdre2lvl0yh7", "at9lxuqxpogaaxxx"))
+		assert.Nil(t, FindPhotoAlbum("", ""))
+	})
+}
+
+func TestPhotoAlbum_Add(t *testing.T) {
+	album := NewAlbum("Add Entries", AlbumManual)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := PhotoAlbum{PhotoUID: "pt9jtdre2lvl0yh7", AlbumUID: album.AlbumUID, Order: 1}
+
+	if err := entry.Add(); err != nil {
+		t.Fatal(err)
+	} else if err = entry.SetCaption("  Our first day at the beach  "); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Our first day at the beach", entry.Caption)
+
+	// Adding the picture again keeps its position and caption.
+	again := PhotoAlbum{PhotoUID: "pt9jtdre2lvl0yh7", AlbumUID: album.AlbumUID, Order: 5}
+
+	if err := again.Add(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, again.Order)
+	assert.Equal(t, "Our first day at the beach", again.Caption)
+
+	// Removed pictures are added like new ones.
+	album.RemovePhotos([]string{"pt9jtdre2lvl0yh7"})
+
+	restored := PhotoAlbum{PhotoUID: "pt9jtdre2lvl0yh7", AlbumUID: album.AlbumUID, Order: 5}
+
+	if err := restored.Add(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, restored.Hidden)
+	assert.Equal(t, 5, restored.Order)
+	assert.Equal(t, "", restored.Caption)
+
+	if err := album.DeletePermanently(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/pkg/astro"
+)
+
+// UpdateCelestial updates the natural light, e.g. golden hour, and the moon phase based on when
+// and where the picture was taken. The light requires a known time zone and GPS position.
+func (m *Photo) UpdateCelestial() {
+	if m.TakenSrc == SrcAuto || m.TakenAt.IsZero() {
+		m.PhotoLight, m.PhotoSun, m.PhotoMoon = "", "", ""
+		return
+	}
+
+	m.PhotoMoon = astro.Moon(m.TakenAt).Name()
+
+	if m.TimeZone == "" || !m.HasLatLng() {
+		m.PhotoLight, m.PhotoSun = "", ""
+		return
+	}
+
+	sun := astro.Sun(m.TakenAt.UTC(), float64(m.PhotoLat), float64(m.PhotoLng))
+
+	m.PhotoLight = sun.Light()
+	m.PhotoSun = sun.Direction()
+}
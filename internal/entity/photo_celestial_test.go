@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/astro"
+)
+
+func TestPhoto_UpdateCelestial(t *testing.T) {
+	t.Run("Sunset", func(t *testing.T) {
+		m := Photo{
+			TakenAt:  time.Date(2023, 6, 21, 19, 33, 0, 0, time.UTC),
+			TakenSrc: SrcMeta,
+			TimeZone: "Europe/Berlin",
+			PhotoLat: 52.52,
+			PhotoLng: 13.405,
+		}
+
+		m.UpdateCelestial()
+
+		assert.Equal(t, astro.LightGoldenHour, m.PhotoLight)
+		assert.Equal(t, astro.SunSetting, m.PhotoSun)
+		assert.Equal(t, astro.MoonWaxingCrescent, m.PhotoMoon)
+	})
+	t.Run("NoLocation", func(t *testing.T) {
+		m := Photo{
+			TakenAt:    time.Date(2023, 8, 31, 1, 35, 0, 0, time.UTC),
+			TakenSrc:   SrcMeta,
+			TimeZone:   "Europe/Berlin",
+			PhotoLight: astro.LightDay,
+		}
+
+		m.UpdateCelestial()
+
+		assert.Equal(t, "", m.PhotoLight)
+		assert.Equal(t, "", m.PhotoSun)
+		assert.Equal(t, astro.MoonFull, m.PhotoMoon)
+	})
+	t.Run("UnknownTime", func(t *testing.T) {
+		m := Photo{
+			TakenAt:   time.Date(2023, 8, 31, 1, 35, 0, 0, time.UTC),
+			TakenSrc:  SrcAuto,
+			PhotoLat:  52.52,
+			PhotoLng:  13.405,
+			PhotoMoon: astro.MoonNew,
+		}
+
+		m.UpdateCelestial()
+
+		assert.Equal(t, "", m.PhotoLight)
+		assert.Equal(t, "", m.PhotoMoon)
+	})
+}
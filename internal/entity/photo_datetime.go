@@ -121,6 +121,9 @@ func (m *Photo) UpdateDateFields() {
 		m.PhotoDay = m.TakenAtLocal.Day()
 	}
 
+	// Update light and moon phase, which depend on the date.
+	m.UpdateCelestial()
+
 	// Update photo_taken_at column in related files.
 	Log("photo", "update date fields",
 		UnscopedDb().Model(File{}).
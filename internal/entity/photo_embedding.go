@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// PhotoEmbedding represents a vector that was computed for a photo by a model, e.g. in an external
+// machine learning pipeline, so that it can be stored and exported without changing the schema.
+type PhotoEmbedding struct {
+	PhotoUID      string          `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"PhotoUID" yaml:"PhotoUID"`
+	Model         string          `gorm:"type:VARBINARY(64);primary_key;auto_increment:false" json:"Model" yaml:"Model"`
+	Dimensions    int             `json:"Dimensions" yaml:"Dimensions"`
+	EmbeddingJSON json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"EmbeddingJSON,omitempty"`
+	CreatedAt     time.Time       `json:"CreatedAt" yaml:"-"`
+	UpdatedAt     time.Time       `json:"UpdatedAt" yaml:"-"`
+}
+
+// PhotoEmbeddings represents a list of photo embeddings.
+type PhotoEmbeddings []PhotoEmbedding
+
+// TableName returns the entity table name.
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// NewPhotoEmbedding returns a new photo embedding for the specified model.
+func NewPhotoEmbedding(photoUID, model string, embedding []float64) *PhotoEmbedding {
+	m := &PhotoEmbedding{
+		PhotoUID: photoUID,
+		Model:    strings.ToLower(strings.TrimSpace(model)),
+	}
+
+	m.SetEmbedding(embedding)
+
+	return m
+}
+
+// SetEmbedding updates the embedding vector and its number of dimensions.
+func (m *PhotoEmbedding) SetEmbedding(embedding []float64) {
+	m.Dimensions = len(embedding)
+
+	if len(embedding) == 0 {
+		m.EmbeddingJSON = json.RawMessage{}
+	} else if data, err := json.Marshal(embedding); err != nil {
+		log.Errorf("embedding: %s", err)
+	} else {
+		m.EmbeddingJSON = data
+	}
+}
+
+// Embedding returns the embedding vector.
+func (m *PhotoEmbedding) Embedding() (result []float64) {
+	if len(m.EmbeddingJSON) == 0 {
+		return result
+	} else if err := json.Unmarshal(m.EmbeddingJSON, &result); err != nil {
+		log.Errorf("embedding: %s", err)
+	}
+
+	return result
+}
+
+// Validate checks the photo uid, model name, and the number of dimensions.
+func (m *PhotoEmbedding) Validate() error {
+	if !rnd.IsUID(m.PhotoUID, PhotoUID) {
+		return fmt.Errorf("invalid photo uid")
+	} else if m.Model == "" || len(m.Model) > 64 {
+		return fmt.Errorf("invalid model name")
+	} else if m.Dimensions == 0 {
+		return fmt.Errorf("empty embedding")
+	}
+
+	return nil
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *PhotoEmbedding) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return UnscopedDb().Save(m).Error
+}
+
+// FindPhotoEmbedding returns the embedding of a photo computed with the specified model, if any.
+func FindPhotoEmbedding(photoUID, model string) *PhotoEmbedding {
+	m := &PhotoEmbedding{}
+
+	if err := UnscopedDb().Where("photo_uid = ? AND model = ?", photoUID, strings.ToLower(model)).First(m).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
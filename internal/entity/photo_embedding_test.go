@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPhotoEmbedding(t *testing.T) {
+	m := NewPhotoEmbedding("pt9jtdre2lvl0yh8", " CLIP ", []float64{0.1, -0.2, 0.3})
+
+	assert.Equal(t, "pt9jtdre2lvl0yh8", m.PhotoUID)
+	assert.Equal(t, "clip", m.Model)
+	assert.Equal(t, 3, m.Dimensions)
+	assert.Equal(t, []float64{0.1, -0.2, 0.3}, m.Embedding())
+}
+
+func TestPhotoEmbedding_Validate(t *testing.T) {
+	assert.NoError(t, NewPhotoEmbedding("pt9jtdre2lvl0yh8", "clip", []float64{1}).Validate())
+	assert.Error(t, NewPhotoEmbedding("xxx", "clip", []float64{1}).Validate())
+	assert.Error(t, NewPhotoEmbedding("pt9jtdre2lvl0yh8", "", []float64{1}).Validate())
+	assert.Error(t, NewPhotoEmbedding("pt9jtdre2lvl0yh8", "clip", nil).Validate())
+}
+
+func TestPhotoEmbedding_Save(t *testing.T) {
+	m := NewPhotoEmbedding("pt9jtdre2lvl0yh8", "test-save", []float64{0.5, 0.5})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.SetEmbedding([]float64{0.25, 0.5, 0.75})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if found := FindPhotoEmbedding("pt9jtdre2lvl0yh8", "test-save"); found == nil {
+		t.Fatal("embedding not found")
+	} else {
+		assert.Equal(t, 3, found.Dimensions)
+		assert.Equal(t, []float64{0.25, 0.5, 0.75}, found.Embedding())
+	}
+
+	assert.Nil(t, FindPhotoEmbedding("pt9jtdre2lvl0yh8", "unknown"))
+}
@@ -9,12 +9,13 @@ type PhotoLabels []PhotoLabel
 // PhotoLabel represents the many-to-many relation between Photo and label.
 // Labels are weighted by uncertainty (100 - confidence)
 type PhotoLabel struct {
-	PhotoID     uint   `gorm:"primary_key;auto_increment:false"`
-	LabelID     uint   `gorm:"primary_key;auto_increment:false;index"`
-	LabelSrc    string `gorm:"type:VARBINARY(8);"`
-	Uncertainty int    `gorm:"type:SMALLINT"`
-	Photo       *Photo `gorm:"PRELOAD:false"`
-	Label       *Label `gorm:"PRELOAD:true"`
+	PhotoID     uint    `gorm:"primary_key;auto_increment:false"`
+	LabelID     uint    `gorm:"primary_key;auto_increment:false;index"`
+	LabelSrc    string  `gorm:"type:VARBINARY(8);"`
+	Uncertainty int     `gorm:"type:SMALLINT"`
+	Confidence  float32 `gorm:"type:FLOAT;"`
+	Photo       *Photo  `gorm:"PRELOAD:false"`
+	Label       *Label  `gorm:"PRELOAD:true"`
 }
 
 // TableName returns the entity table name.
@@ -96,7 +97,53 @@ func (m *PhotoLabel) ClassifyLabel() classify.Label {
 		Source:      m.LabelSrc,
 		Uncertainty: m.Uncertainty,
 		Priority:    m.Label.LabelPriority,
+		Confidence:  m.Confidence,
 	}
 
 	return result
 }
+
+// ModelConfidence returns the classifier confidence, or an estimate based on the uncertainty if unknown.
+func (m *PhotoLabel) ModelConfidence() float32 {
+	if m.Confidence > 0 {
+		return m.Confidence
+	} else if m.Uncertainty >= 100 {
+		return 0
+	}
+
+	return float32(100-m.Uncertainty) / 100
+}
+
+// Confirm marks the label as correct and lowers the label threshold if needed.
+func (m *PhotoLabel) Confirm() error {
+	confidence := m.ModelConfidence()
+
+	if err := m.Updates(Values{"Uncertainty": 0}); err != nil {
+		return err
+	}
+
+	m.Uncertainty = 0
+
+	if m.Label == nil {
+		return nil
+	}
+
+	return m.Label.Calibrate(confidence, true)
+}
+
+// Reject marks the label as incorrect and raises the label threshold above its confidence.
+func (m *PhotoLabel) Reject() error {
+	confidence := m.ModelConfidence()
+
+	if err := m.Updates(Values{"Uncertainty": 100}); err != nil {
+		return err
+	}
+
+	m.Uncertainty = 100
+
+	if m.Label == nil {
+		return nil
+	}
+
+	return m.Label.Calibrate(confidence, false)
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/nsfw"
+)
+
+// NSFW content policies that control how pictures that might be offensive are presented to a user.
+const (
+	NSFWShow = "show"
+	NSFWBlur = "blur"
+	NSFWHide = "hide"
+)
+
+// NSFWThreshold is the minimum score above which pictures are considered to be not safe for work.
+var NSFWThreshold float32 = nsfw.ThresholdHigh
+
+// DefaultNSFWPolicy is the policy for users who have not been assigned a policy.
+var DefaultNSFWPolicy = NSFWShow
+
+// ParseNSFWPolicy returns the normalized policy name, or an empty string if it is not supported.
+func ParseNSFWPolicy(s string) string {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case NSFWShow, NSFWBlur, NSFWHide:
+		return s
+	default:
+		return ""
+	}
+}
+
+// SetNSFW updates the score that indicates how likely the picture is not safe for work.
+func (m *Photo) SetNSFW(score float32) {
+	if score < 0 {
+		score = 0
+	} else if score > 1 {
+		score = 1
+	}
+
+	m.PhotoNSFW = score
+}
+
+// IsNSFW checks if the picture might be offensive based on the configured threshold.
+func (m *Photo) IsNSFW() bool {
+	return m.PhotoNSFW > NSFWThreshold
+}
+
+// NSFWPolicy returns the policy for pictures that might be offensive.
+func (m *User) NSFWPolicy() string {
+	if m == nil || m.UserSettings == nil {
+		return DefaultNSFWPolicy
+	} else if p := ParseNSFWPolicy(m.UserSettings.NSFWPolicy); p != "" {
+		return p
+	}
+
+	return DefaultNSFWPolicy
+}
+
+// SetNSFWPolicy changes the policy for pictures that might be offensive, an empty string resets it to the default.
+func (m *User) SetNSFWPolicy(policy string) *User {
+	m.Settings().NSFWPolicy = ParseNSFWPolicy(policy)
+
+	return m
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNSFWPolicy(t *testing.T) {
+	assert.Equal(t, NSFWShow, ParseNSFWPolicy("show"))
+	assert.Equal(t, NSFWBlur, ParseNSFWPolicy(" Blur "))
+	assert.Equal(t, NSFWHide, ParseNSFWPolicy("HIDE"))
+	assert.Equal(t, "", ParseNSFWPolicy("foo"))
+	assert.Equal(t, "", ParseNSFWPolicy(""))
+}
+
+func TestPhoto_SetNSFW(t *testing.T) {
+	m := Photo{}
+
+	m.SetNSFW(0.99)
+	assert.Equal(t, float32(0.99), m.PhotoNSFW)
+	assert.True(t, m.IsNSFW())
+
+	m.SetNSFW(0.5)
+	assert.False(t, m.IsNSFW())
+
+	m.SetNSFW(-1)
+	assert.Equal(t, float32(0), m.PhotoNSFW)
+
+	m.SetNSFW(2)
+	assert.Equal(t, float32(1), m.PhotoNSFW)
+}
+
+func TestUser_NSFWPolicy(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		var m *User
+		assert.Equal(t, DefaultNSFWPolicy, m.NSFWPolicy())
+		assert.Equal(t, DefaultNSFWPolicy, (&User{}).NSFWPolicy())
+	})
+	t.Run("Blur", func(t *testing.T) {
+		m := &User{}
+		m.SetNSFWPolicy("blur")
+		assert.Equal(t, NSFWBlur, m.NSFWPolicy())
+		m.SetNSFWPolicy("invalid")
+		assert.Equal(t, "", m.Settings().NSFWPolicy)
+		assert.Equal(t, DefaultNSFWPolicy, m.NSFWPolicy())
+	})
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// Privacy fence modes that control how the coordinates of pictures taken in a privacy zone are shared.
+const (
+	PrivacyFuzz  = "fuzz"
+	PrivacyStrip = "strip"
+)
+
+// PrivacyZones are areas like the home of a user in which pictures should not reveal their exact position
+// in shares, downloads, and public APIs.
+var PrivacyZones geo.Zones
+
+// PrivacyFence specifies whether coordinates in privacy zones are fuzzed or stripped.
+var PrivacyFence = PrivacyFuzz
+
+// PrivacyFuzzLevel is the S2 cell level to which fuzzed coordinates are rounded, with cells at
+// level 13 being roughly one kilometer wide.
+var PrivacyFuzzLevel = 13
+
+// ParsePrivacyFence returns the normalized privacy fence mode, or an empty string if it is not supported.
+func ParsePrivacyFence(s string) string {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case PrivacyFuzz, PrivacyStrip:
+		return s
+	default:
+		return ""
+	}
+}
+
+// PrivacyFenced tests if the coordinates are within a privacy zone.
+func PrivacyFenced(lat, lng float64) bool {
+	return len(PrivacyZones) > 0 && PrivacyZones.Contains(lat, lng)
+}
+
+// PrivacyPosition returns the coordinates and S2 cell ID that may be shared instead of the exact position
+// of a picture taken in a privacy zone. Stripped positions are returned as zero values with an unknown cell.
+func PrivacyPosition(lat, lng float64) (fencedLat, fencedLng float64, cellID string) {
+	if PrivacyFence == PrivacyStrip {
+		return 0, 0, UnknownID
+	}
+
+	cellID = s2.Prefix(s2.TokenLevel(lat, lng, PrivacyFuzzLevel))
+	fencedLat, fencedLng = s2.LatLng(cellID)
+
+	return fencedLat, fencedLng, cellID
+}
+
+// ApplyPrivacyFence fuzzes or strips the position if the picture was taken in a privacy zone.
+// It must only be used for data returned to clients, as the modified photo must not be saved.
+func (m *Photo) ApplyPrivacyFence() bool {
+	if !PrivacyFenced(float64(m.PhotoLat), float64(m.PhotoLng)) {
+		return false
+	}
+
+	lat, lng, cellID := PrivacyPosition(float64(m.PhotoLat), float64(m.PhotoLng))
+
+	m.PhotoLat = float32(lat)
+	m.PhotoLng = float32(lng)
+	m.PhotoAltitude = 0
+	m.CellID = cellID
+	m.CellAccuracy = 0
+
+	// Keep the place, but remove details like the street name.
+	m.Cell = &Cell{ID: cellID, PlaceID: m.PlaceID, Place: m.Place}
+
+	return true
+}
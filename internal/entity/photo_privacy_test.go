@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestParsePrivacyFence(t *testing.T) {
+	assert.Equal(t, PrivacyFuzz, ParsePrivacyFence(" Fuzz"))
+	assert.Equal(t, PrivacyStrip, ParsePrivacyFence("strip"))
+	assert.Equal(t, "", ParsePrivacyFence("blur"))
+}
+
+func TestPrivacyPosition(t *testing.T) {
+	t.Run("Fuzz", func(t *testing.T) {
+		lat, lng, cellID := PrivacyPosition(52.5208, 13.4094)
+
+		assert.InDelta(t, 52.5151, lat, 0.0001)
+		assert.InDelta(t, 13.4117, lng, 0.0001)
+		assert.Equal(t, "s2:47a84e24", cellID)
+
+		// Nearby positions must result in the same coordinates.
+		lat1, lng1, _ := PrivacyPosition(52.5150, 13.4115)
+		lat2, lng2, _ := PrivacyPosition(52.5152, 13.4118)
+		assert.Equal(t, lat1, lat2)
+		assert.Equal(t, lng1, lng2)
+	})
+	t.Run("Strip", func(t *testing.T) {
+		PrivacyFence = PrivacyStrip
+		defer func() { PrivacyFence = PrivacyFuzz }()
+
+		lat, lng, cellID := PrivacyPosition(52.5208, 13.4094)
+
+		assert.Equal(t, 0.0, lat)
+		assert.Equal(t, 0.0, lng)
+		assert.Equal(t, UnknownID, cellID)
+	})
+}
+
+func TestPhoto_ApplyPrivacyFence(t *testing.T) {
+	PrivacyZones = geo.Zones{{Lat: 52.5208, Lng: 13.4094, Radius: 500}}
+	defer func() { PrivacyZones = nil }()
+
+	t.Run("Fenced", func(t *testing.T) {
+		m := Photo{PhotoLat: 52.5209, PhotoLng: 13.4095, PhotoAltitude: 35, CellID: "s2:47a851e1a3b4", CellAccuracy: 5, PlaceID: "de:HFqPHxa2Hsol"}
+
+		assert.True(t, m.ApplyPrivacyFence())
+		assert.NotEqual(t, float32(52.5209), m.PhotoLat)
+		assert.Equal(t, 0, m.PhotoAltitude)
+		assert.Equal(t, 0, m.CellAccuracy)
+		assert.Equal(t, m.CellID, m.Cell.ID)
+		assert.Equal(t, "", m.Cell.CellStreet)
+		assert.Equal(t, "de:HFqPHxa2Hsol", m.PlaceID)
+	})
+	t.Run("Outside", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.1351, PhotoLng: 11.5820}
+
+		assert.False(t, m.ApplyPrivacyFence())
+		assert.Equal(t, float32(48.1351), m.PhotoLat)
+	})
+}
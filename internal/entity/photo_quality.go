@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/aesthetic"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -61,6 +62,17 @@ func (m *Photo) QualityScore() (score int) {
 		score++
 	}
 
+	// Use the image quality score, if any, so that out-of-focus and poorly exposed shots can be reviewed.
+	if m.PhotoBlurry || m.PhotoBadExposure {
+		score -= 2
+	} else if m.PhotoScore >= aesthetic.HighScore {
+		score++
+	}
+
+	if score < 0 {
+		score = 0
+	}
+
 	if score < 3 && (m.PhotoType != MediaImage || m.EditedAt != nil) {
 		score = 3
 	}
@@ -78,3 +90,16 @@ func (m *Photo) UpdateQuality() error {
 
 	return m.Update("PhotoQuality", m.PhotoQuality)
 }
+
+// SetScore sets the image quality score and flags the photo as blurry or poorly exposed.
+func (m *Photo) SetScore(score float32, blurry, badExposure bool) {
+	if score < 0 {
+		score = 0
+	} else if score > 1 {
+		score = 1
+	}
+
+	m.PhotoScore = score
+	m.PhotoBlurry = blurry
+	m.PhotoBadExposure = badExposure
+}
@@ -22,6 +22,35 @@ func TestPhoto_QualityScore(t *testing.T) {
 	t.Run("PhotoFixturePhoto15 - description with blacklist", func(t *testing.T) {
 		assert.Equal(t, 2, PhotoFixtures.Pointer("Photo15").QualityScore())
 	})
+	t.Run("HighScore", func(t *testing.T) {
+		p := &Photo{PhotoFavorite: true, PhotoScore: 0.9}
+		assert.Equal(t, 6, p.QualityScore())
+	})
+	t.Run("Blurry", func(t *testing.T) {
+		p := &Photo{PhotoFavorite: true, PhotoScore: 0.9, PhotoBlurry: true}
+		assert.Equal(t, 3, p.QualityScore())
+	})
+	t.Run("BadExposure", func(t *testing.T) {
+		p := &Photo{PhotoType: MediaImage, PhotoBadExposure: true}
+		assert.Equal(t, 0, p.QualityScore())
+	})
+}
+
+func TestPhoto_SetScore(t *testing.T) {
+	p := &Photo{}
+
+	p.SetScore(0.5, true, false)
+	assert.Equal(t, float32(0.5), p.PhotoScore)
+	assert.True(t, p.PhotoBlurry)
+	assert.False(t, p.PhotoBadExposure)
+
+	p.SetScore(1.5, false, true)
+	assert.Equal(t, float32(1), p.PhotoScore)
+	assert.False(t, p.PhotoBlurry)
+	assert.True(t, p.PhotoBadExposure)
+
+	p.SetScore(-1, false, false)
+	assert.Equal(t, float32(0), p.PhotoScore)
 }
 
 func TestPhoto_UpdateQuality(t *testing.T) {
@@ -274,6 +274,28 @@ func TestPhoto_SetDescription(t *testing.T) {
 	})
 }
 
+func TestPhoto_SetCaption(t *testing.T) {
+	t.Run("NoDescription", func(t *testing.T) {
+		m := Photo{}
+		m.SetCaption("A dog running on a beach")
+		assert.Equal(t, "A dog running on a beach", m.PhotoDescription)
+		assert.Equal(t, SrcCaption, m.DescriptionSrc)
+		m.SetCaption("A dog on a beach")
+		assert.Equal(t, "A dog on a beach", m.PhotoDescription)
+	})
+	t.Run("Manual", func(t *testing.T) {
+		m := Photo{PhotoDescription: "Bello at the beach", DescriptionSrc: SrcManual}
+		m.SetCaption("A dog running on a beach")
+		assert.Equal(t, "Bello at the beach", m.PhotoDescription)
+		assert.Equal(t, SrcManual, m.DescriptionSrc)
+	})
+	t.Run("Removed", func(t *testing.T) {
+		m := Photo{DescriptionSrc: SrcManual}
+		m.SetCaption("A dog running on a beach")
+		assert.Equal(t, "", m.PhotoDescription)
+	})
+}
+
 func TestPhoto_Delete(t *testing.T) {
 	t.Run("NotPermanent", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo16")
@@ -822,3 +844,10 @@ func TestPhoto_MapKey(t *testing.T) {
 	m := &Photo{TakenAt: time.Date(2016, 11, 11, 9, 7, 18, 0, time.UTC), CellID: "abc236"}
 	assert.Equal(t, "ogh006/abc236", m.MapKey())
 }
+
+func TestPhotos_OwnedBy(t *testing.T) {
+	photos := Photos{{PhotoUID: "pt9jtdre2lvl0yh7", CreatedBy: "uqxetse3cy5eo9z2"}, {PhotoUID: "pt9jtdre2lvl0yh8", CreatedBy: "uqxc08w3d0ej2283"}, {PhotoUID: "pt9jtdre2lvl0y11"}}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0yh8"}, photos.OwnedBy("uqxc08w3d0ej2283").UIDs())
+	assert.Empty(t, photos.OwnedBy(""))
+}
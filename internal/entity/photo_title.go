@@ -144,6 +144,14 @@ func (m *Photo) UpdateTitle(labels classify.Labels) error {
 			} else {
 				m.SetTitle(names, SrcAuto)
 			}
+		} else if landmark, ok := labels.Landmark(); ok {
+			log.Debugf("photo: %s title based on landmark %s", m.String(), clean.Log(landmark.Name))
+
+			if m.TakenSrc != SrcAuto {
+				m.SetTitle(fmt.Sprintf("%s / %s", landmark.Title(), m.TakenAt.Format("2006")), SrcAuto)
+			} else {
+				m.SetTitle(landmark.Title(), SrcAuto)
+			}
 		} else if fileTitle == "" && len(labels) > 0 && labels[0].Priority >= -1 && labels[0].Uncertainty <= 85 && labels[0].Name != "" {
 			if m.TakenSrc != SrcAuto {
 				m.SetTitle(fmt.Sprintf("%s / %s", txt.Title(labels[0].Name), m.TakenAt.Format("2006")), SrcAuto)
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/stretchr/testify/assert"
@@ -201,6 +202,18 @@ func TestPhoto_UpdateTitle(t *testing.T) {
 		}
 		assert.Equal(t, "Unknown", m.PhotoTitle)
 	})
+	t.Run("no location landmark", func(t *testing.T) {
+		m := Photo{TakenAt: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC), TakenSrc: SrcMeta}
+		classifyLabels := classify.Labels{
+			{Name: "tower", Uncertainty: 20, Source: SrcImage, Priority: 0},
+			{Name: "eiffel tower", Uncertainty: 30, Source: SrcLandmark, Priority: classify.LandmarkPriority},
+		}
+		err := m.UpdateTitle(classifyLabels)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Eiffel Tower / 2019", m.PhotoTitle)
+	})
 	t.Run("OnePerson", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo10")
 
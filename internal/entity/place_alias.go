@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PlaceAliasRadius is the default radius of a custom place in meters.
+var PlaceAliasRadius = 100
+
+// PlaceAliasMaxRadius is the maximum radius of a custom place in meters.
+var PlaceAliasMaxRadius = 50000
+
+// Meters per degree of latitude.
+const metersPerDeg = 111320.0
+
+var placeAliases PlaceAliases
+var placeAliasesLoaded bool
+var placeAliasesMutex = sync.RWMutex{}
+
+// PlaceAlias represents a custom name for a coordinate area like "Grandma's House", which takes
+// precedence over the reverse-geocoded place label when pictures are displayed and searched.
+type PlaceAlias struct {
+	ID          uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	AliasName   string    `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	AliasSlug   string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"-"`
+	AliasLat    float64   `gorm:"type:DOUBLE;" json:"Lat" yaml:"Lat"`
+	AliasLng    float64   `gorm:"type:DOUBLE;" json:"Lng" yaml:"Lng"`
+	AliasRadius int       `json:"Radius" yaml:"Radius"`
+	CreatedBy   string    `gorm:"type:VARBINARY(42);" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// PlaceAliases represents a list of custom places.
+type PlaceAliases []PlaceAlias
+
+// TableName returns the entity table name.
+func (PlaceAlias) TableName() string {
+	return "places_aliases"
+}
+
+// NewPlaceAlias returns a new custom place with the specified name, center, and radius in meters.
+func NewPlaceAlias(name string, lat, lng float64, radius int) *PlaceAlias {
+	m := &PlaceAlias{
+		AliasLat:    lat,
+		AliasLng:    lng,
+		AliasRadius: radius,
+	}
+
+	m.SetName(name)
+
+	return m
+}
+
+// FindPlaceAlias returns the custom place with the specified ID, or nil if it does not exist.
+func FindPlaceAlias(id uint) *PlaceAlias {
+	if id == 0 {
+		return nil
+	}
+
+	m := PlaceAlias{}
+
+	if Db().First(&m, "id = ?", id).Error != nil {
+		return nil
+	}
+
+	return &m
+}
+
+// SetName changes the custom place name and slug.
+func (m *PlaceAlias) SetName(name string) {
+	if name = clean.Name(name); name == "" {
+		return
+	}
+
+	m.AliasName = txt.Clip(name, txt.ClipName)
+	m.AliasSlug = txt.Slug(m.AliasName)
+}
+
+// Validate returns an error if the custom place has no name or an invalid position or radius.
+func (m *PlaceAlias) Validate() error {
+	if m.AliasName == "" || m.AliasSlug == "" {
+		return fmt.Errorf("name must not be empty")
+	} else if m.AliasLat < -90 || m.AliasLat > 90 || m.AliasLng < -180 || m.AliasLng > 180 || m.AliasLat == 0 && m.AliasLng == 0 {
+		return fmt.Errorf("invalid position")
+	}
+
+	if m.AliasRadius <= 0 {
+		m.AliasRadius = PlaceAliasRadius
+	} else if m.AliasRadius > PlaceAliasMaxRadius {
+		return fmt.Errorf("radius must not exceed %d meters", PlaceAliasMaxRadius)
+	}
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *PlaceAlias) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer FlushPlaceAliases()
+
+	return Db().Create(m).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *PlaceAlias) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer FlushPlaceAliases()
+
+	return Db().Save(m).Error
+}
+
+// SaveForm updates the custom place from form values.
+func (m *PlaceAlias) SaveForm(f form.PlaceAlias) error {
+	m.SetName(f.AliasName)
+	m.AliasLat = f.AliasLat
+	m.AliasLng = f.AliasLng
+	m.AliasRadius = f.AliasRadius
+
+	return m.Save()
+}
+
+// Delete removes the custom place from the database.
+func (m *PlaceAlias) Delete() error {
+	if m.ID == 0 {
+		return fmt.Errorf("id must not be empty")
+	}
+
+	defer FlushPlaceAliases()
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// Bounds returns the minimum and maximum coordinates of the area, which extends by the radius
+// to the north, south, east, and west of its center.
+func (m PlaceAlias) Bounds() (latMin, latMax, lngMin, lngMax float64) {
+	var lng float64
+
+	lat := float64(m.AliasRadius) / metersPerDeg
+
+	// Degrees of longitude become shorter towards the poles.
+	if c := math.Cos(m.AliasLat * math.Pi / 180); c > 0.01 {
+		lng = lat / c
+	} else {
+		lng = 180
+	}
+
+	return m.AliasLat - lat, m.AliasLat + lat, m.AliasLng - lng, m.AliasLng + lng
+}
+
+// Contains tests if the coordinates are within the area.
+func (m PlaceAlias) Contains(lat, lng float64) bool {
+	if lat == 0 && lng == 0 {
+		return false
+	}
+
+	latMin, latMax, lngMin, lngMax := m.Bounds()
+
+	return lat >= latMin && lat <= latMax && lng >= lngMin && lng <= lngMax
+}
+
+// At returns the custom place with the smallest area that contains the coordinates, or nil if there is none.
+func (list PlaceAliases) At(lat, lng float64) (result *PlaceAlias) {
+	for i := range list {
+		if !list[i].Contains(lat, lng) {
+			continue
+		} else if result == nil || list[i].AliasRadius < result.AliasRadius {
+			result = &list[i]
+		}
+	}
+
+	return result
+}
+
+// Named returns the custom places whose name contains the specified string, ignoring case.
+func (list PlaceAliases) Named(name string) (result PlaceAliases) {
+	if name = strings.ToLower(strings.TrimSpace(name)); name == "" {
+		return result
+	}
+
+	slug := txt.Slug(name)
+
+	for _, m := range list {
+		if strings.Contains(strings.ToLower(m.AliasName), name) || slug != "" && strings.Contains(m.AliasSlug, slug) {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+// FindPlaceAliases returns all custom places sorted by name.
+func FindPlaceAliases() (result PlaceAliases, err error) {
+	err = Db().Order("alias_name, id").Find(&result).Error
+
+	return result, err
+}
+
+// CachedPlaceAliases returns all custom places from the cache, which is loaded from the database if needed.
+func CachedPlaceAliases() PlaceAliases {
+	placeAliasesMutex.RLock()
+
+	if placeAliasesLoaded {
+		defer placeAliasesMutex.RUnlock()
+		return placeAliases
+	}
+
+	placeAliasesMutex.RUnlock()
+
+	placeAliasesMutex.Lock()
+	defer placeAliasesMutex.Unlock()
+
+	if result, err := FindPlaceAliases(); err != nil {
+		log.Warnf("places: %s (find aliases)", err)
+		return PlaceAliases{}
+	} else {
+		placeAliases = result
+		placeAliasesLoaded = true
+	}
+
+	return placeAliases
+}
+
+// FlushPlaceAliases resets the custom places cache.
+func FlushPlaceAliases() {
+	placeAliasesMutex.Lock()
+	defer placeAliasesMutex.Unlock()
+
+	placeAliases = nil
+	placeAliasesLoaded = false
+}
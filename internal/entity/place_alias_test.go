@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestNewPlaceAlias(t *testing.T) {
+	m := NewPlaceAlias("  Grandma's House ", 52.5208, 13.4094, 0)
+
+	assert.Equal(t, "Grandma's House", m.AliasName)
+	assert.Equal(t, "grandmas-house", m.AliasSlug)
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, PlaceAliasRadius, m.AliasRadius)
+}
+
+func TestPlaceAlias_Validate(t *testing.T) {
+	assert.Error(t, NewPlaceAlias("", 52.5208, 13.4094, 100).Validate())
+	assert.Error(t, NewPlaceAlias("Home", 0, 0, 100).Validate())
+	assert.Error(t, NewPlaceAlias("Home", 91, 13.4094, 100).Validate())
+	assert.Error(t, NewPlaceAlias("Home", 52.5208, 13.4094, PlaceAliasMaxRadius+1).Validate())
+}
+
+func TestPlaceAlias_Contains(t *testing.T) {
+	m := NewPlaceAlias("Grandma's House", 52.5208, 13.4094, 100)
+
+	assert.True(t, m.Contains(52.5208, 13.4094))
+	assert.True(t, m.Contains(52.5215, 13.4100))
+	assert.False(t, m.Contains(52.5230, 13.4094))
+	assert.False(t, m.Contains(52.5208, 13.4120))
+	assert.False(t, m.Contains(0, 0))
+}
+
+func TestPlaceAliases_At(t *testing.T) {
+	list := PlaceAliases{
+		*NewPlaceAlias("Berlin Mitte", 52.5208, 13.4094, 2000),
+		*NewPlaceAlias("Grandma's House", 52.5208, 13.4094, 100),
+	}
+
+	assert.Equal(t, "Grandma's House", list.At(52.5209, 13.4095).AliasName)
+	assert.Equal(t, "Berlin Mitte", list.At(52.5300, 13.4094).AliasName)
+	assert.Nil(t, list.At(48.1351, 11.5820))
+}
+
+func TestPlaceAliases_Named(t *testing.T) {
+	list := PlaceAliases{
+		*NewPlaceAlias("Berlin Mitte", 52.5208, 13.4094, 2000),
+		*NewPlaceAlias("Grandma's House", 52.5208, 13.4094, 100),
+	}
+
+	assert.Len(t, list.Named("grandma"), 1)
+	assert.Len(t, list.Named("Grandma's House"), 1)
+	assert.Len(t, list.Named("grandmas-house"), 1)
+	assert.Len(t, list.Named("Berlin"), 1)
+	assert.Len(t, list.Named(""), 0)
+}
+
+func TestPlaceAlias_Create(t *testing.T) {
+	m := NewPlaceAlias("Grandma's House", 52.5208, 13.4094, 150)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+	assert.Equal(t, "Grandma's House", CachedPlaceAliases().At(52.5208, 13.4094).AliasName)
+
+	if err := m.SaveForm(form.PlaceAlias{AliasName: "Home", AliasLat: 52.5208, AliasLng: 13.4094, AliasRadius: 50}); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindPlaceAlias(m.ID)
+
+	if found == nil {
+		t.Fatal("custom place not found")
+	}
+
+	assert.Equal(t, "Home", found.AliasName)
+	assert.Equal(t, 50, found.AliasRadius)
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindPlaceAlias(m.ID))
+	assert.Nil(t, CachedPlaceAliases().At(52.5208, 13.4094))
+}
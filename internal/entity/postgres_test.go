@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/migrate"
+)
+
+func TestPostgres(t *testing.T) {
+	dbDsn := os.Getenv("PHOTOPRISM_TEST_DSN_POSTGRES")
+
+	if dbDsn == "" {
+		t.Skip("skipping PostgreSQL test: PHOTOPRISM_TEST_DSN_POSTGRES is not set")
+	}
+
+	dbDriver := Postgres
+	db, err := OpenDb(dbDriver, dbDsn)
+
+	if err != nil || db == nil {
+		for i := 1; i <= 5; i++ {
+			db, err = OpenDb(dbDriver, dbDsn)
+
+			if db != nil && err == nil {
+				break
+			}
+
+			time.Sleep(5 * time.Second)
+		}
+
+		if err != nil || db == nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer db.Close()
+
+	db.LogMode(false)
+
+	DeprecatedTables.Drop(db)
+	Entities.Drop(db)
+
+	// First migration.
+	Entities.Migrate(db, migrate.Opt(true, false, nil))
+	Entities.WaitForMigration(db)
+
+	// Second migration.
+	Entities.Migrate(db, migrate.Opt(true, false, nil))
+	Entities.WaitForMigration(db)
+
+	t.Run("Migrations", func(t *testing.T) {
+		for _, m := range migrate.DialectPostgres {
+			var found migrate.Migration
+
+			if err = db.Where("id = ?", m.ID).First(&found).Error; err != nil {
+				t.Errorf("migration %s not found: %s", m.ID, err)
+			} else {
+				assert.NotNil(t, found.FinishedAt)
+				assert.Empty(t, found.Error)
+			}
+		}
+	})
+	t.Run("Indexes", func(t *testing.T) {
+		var count int
+
+		for _, name := range []string{"idx_files_search_media", "idx_files_search_timeline", "idx_files_missing_root", "idx_files_file_uid_photo"} {
+			if err = db.Raw("SELECT COUNT(*) FROM pg_indexes WHERE indexname = ?", name).Row().Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, 1, count, name)
+		}
+	})
+	t.Run("QuotedOrder", func(t *testing.T) {
+		if err = db.Create(&PhotoAlbum{PhotoUID: "pt9jtdre2lvl0yh0", AlbumUID: "at9lxuqxpogaaba0", Order: 3}).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		var result struct {
+			Max int
+		}
+
+		err = db.Model(PhotoAlbum{}).
+			Select(fmt.Sprintf("COALESCE(MAX(%s), 0) AS max", db.Dialect().Quote("order"))).
+			Where("album_uid = ?", "at9lxuqxpogaaba0").
+			Scan(&result).Error
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Max)
+	})
+}
@@ -18,6 +18,9 @@ type Reaction struct {
 	ReactedAt *time.Time `sql:"index" json:"ReactedAt,omitempty" yaml:"ReactedAt,omitempty"`
 }
 
+// Reactions represents a list of reactions.
+type Reactions []Reaction
+
 // TableName returns the entity table name.
 func (Reaction) TableName() string {
 	return "reactions"
@@ -132,3 +135,19 @@ func (m *Reaction) Delete() error {
 
 	return err
 }
+
+// SetReaction replaces the previous reactions of a user to the content with the specified UID,
+// or removes them if the emoji is unknown. Guests are identified by the ref ID of their session.
+func SetReaction(uid, userUid string, emo react.Emoji) error {
+	if uid == "" || userUid == "" {
+		return fmt.Errorf("reaction invalid")
+	}
+
+	if err := Db().Delete(&Reaction{}, "uid = ? AND user_uid = ?", uid, userUid).Error; err != nil {
+		return err
+	} else if emo.Unknown() {
+		return nil
+	}
+
+	return NewReaction(uid, userUid).React(emo).Create()
+}
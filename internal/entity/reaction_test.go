@@ -41,3 +41,29 @@ func TestFindReaction(t *testing.T) {
 		}
 	})
 }
+
+func TestSetReaction(t *testing.T) {
+	photoUID := PhotoFixtures.Get("Photo04").PhotoUID
+	guestRef := "sessxkkcabcd"
+
+	if err := SetReaction(photoUID, guestRef, react.Party); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetReaction(photoUID, guestRef, react.Like); err != nil {
+		t.Fatal(err)
+	}
+
+	if m := FindReaction(photoUID, guestRef); m == nil {
+		t.Fatal("result must not be nil")
+	} else {
+		assert.Equal(t, react.Like, m.Emoji())
+	}
+
+	if err := SetReaction(photoUID, guestRef, react.Unknown); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindReaction(photoUID, guestRef))
+	assert.Error(t, SetReaction("", guestRef, react.Like))
+}
@@ -0,0 +1,273 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+const (
+	SelectionUID      = byte('c')
+	SelectionDefault  = "default"
+	selectionBatchLen = 500
+)
+
+// Selections represents a list of selection sets.
+type Selections []Selection
+
+// Selection represents a named set of photos selected by a user, e.g. for bulk operations from multiple devices.
+type Selection struct {
+	SelectionUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	UserUID       string    `gorm:"type:VARBINARY(42);unique_index:idx_selections_user_name;" json:"UserUID" yaml:"UserUID"`
+	SelectionName string    `gorm:"type:VARBINARY(64);unique_index:idx_selections_user_name;" json:"Name" yaml:"Name"`
+	PhotoCount    int       `gorm:"default:0;" json:"PhotoCount" yaml:"-"`
+	CreatedAt     time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// TableName returns the entity table name.
+func (Selection) TableName() string {
+	return "selections"
+}
+
+// SelectionPhoto represents a photo that belongs to a selection set.
+type SelectionPhoto struct {
+	SelectionUID string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"SelectionUID" yaml:"SelectionUID"`
+	PhotoUID     string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index;" json:"PhotoUID" yaml:"PhotoUID"`
+}
+
+// TableName returns the entity table name.
+func (SelectionPhoto) TableName() string {
+	return "selections_photos"
+}
+
+// SelectionName returns a normalized selection set name.
+func SelectionName(name string) string {
+	if name = clean.TypeLower(name); name == "" {
+		return SelectionDefault
+	}
+
+	return name
+}
+
+// NewSelection returns a new selection set for the specified user.
+func NewSelection(userUid, name string) *Selection {
+	return &Selection{
+		SelectionUID:  rnd.GenerateUID(SelectionUID),
+		UserUID:       userUid,
+		SelectionName: SelectionName(name),
+	}
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Selection) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUnique(m.SelectionUID, SelectionUID) {
+		return nil
+	}
+
+	return scope.SetColumn("SelectionUID", rnd.GenerateUID(SelectionUID))
+}
+
+// FindSelection returns the user's selection set with the specified name or nil if it does not exist.
+func FindSelection(userUid, name string) *Selection {
+	if rnd.InvalidUID(userUid, UserUID) {
+		return nil
+	}
+
+	m := &Selection{}
+
+	if Db().Where("user_uid = ? AND selection_name = ?", userUid, SelectionName(name)).First(m).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FirstOrCreateSelection returns the user's selection set with the specified name and creates it if needed.
+func FirstOrCreateSelection(userUid, name string) (*Selection, error) {
+	if rnd.InvalidUID(userUid, UserUID) {
+		return nil, fmt.Errorf("invalid user uid")
+	}
+
+	if m := FindSelection(userUid, name); m != nil {
+		return m, nil
+	}
+
+	m := NewSelection(userUid, name)
+
+	if err := m.Create(); err != nil {
+		// Return the existing set in case it has been created concurrently.
+		if found := FindSelection(userUid, name); found != nil {
+			return found, nil
+		}
+
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FindSelections returns all selection sets of the specified user.
+func FindSelections(userUid string) (result Selections) {
+	result = Selections{}
+
+	if rnd.InvalidUID(userUid, UserUID) {
+		return result
+	}
+
+	if err := Db().Where("user_uid = ?", userUid).Order("selection_name").Find(&result).Error; err != nil {
+		log.Errorf("selection: %s", err)
+	}
+
+	return result
+}
+
+// Create inserts a new record into the database.
+func (m *Selection) Create() error {
+	return Db().Create(m).Error
+}
+
+// Delete permanently deletes the selection set including the selected photo references.
+func (m *Selection) Delete() error {
+	if m.SelectionUID == "" {
+		return fmt.Errorf("empty selection uid")
+	}
+
+	if err := m.Clear(); err != nil {
+		return err
+	}
+
+	return UnscopedDb().Delete(m, "selection_uid = ?", m.SelectionUID).Error
+}
+
+// AddPhotos adds the specified photo UIDs. Existing references are ignored.
+func (m *Selection) AddPhotos(uids []string) (err error) {
+	if m.SelectionUID == "" {
+		return fmt.Errorf("empty selection uid")
+	}
+
+	var insert, conflict string
+
+	switch DbDialect() {
+	case MySQL:
+		insert = "INSERT IGNORE INTO selections_photos (selection_uid, photo_uid) VALUES "
+	case SQLite3:
+		insert = "INSERT OR IGNORE INTO selections_photos (selection_uid, photo_uid) VALUES "
+	case Postgres:
+		insert = "INSERT INTO selections_photos (selection_uid, photo_uid) VALUES "
+		conflict = " ON CONFLICT DO NOTHING"
+	default:
+		return fmt.Errorf("unsupported sql dialect %s", DbDialect())
+	}
+
+	// Insert references in batches to support very large selections.
+	for _, batch := range selectionBatches(uids) {
+		values := make([]interface{}, 0, len(batch)*2)
+
+		for _, uid := range batch {
+			values = append(values, m.SelectionUID, uid)
+		}
+
+		stmt := insert + strings.TrimSuffix(strings.Repeat("(?, ?), ", len(batch)), ", ") + conflict
+
+		if err = UnscopedDb().Exec(stmt, values...).Error; err != nil {
+			return err
+		}
+	}
+
+	return m.UpdateCount()
+}
+
+// RemovePhotos removes the specified photo UIDs.
+func (m *Selection) RemovePhotos(uids []string) (err error) {
+	if m.SelectionUID == "" {
+		return fmt.Errorf("empty selection uid")
+	}
+
+	for _, batch := range selectionBatches(uids) {
+		if err = UnscopedDb().Delete(SelectionPhoto{}, "selection_uid = ? AND photo_uid IN (?)", m.SelectionUID, batch).Error; err != nil {
+			return err
+		}
+	}
+
+	return m.UpdateCount()
+}
+
+// Clear removes all photos from the selection set.
+func (m *Selection) Clear() error {
+	if m.SelectionUID == "" {
+		return fmt.Errorf("empty selection uid")
+	}
+
+	if err := UnscopedDb().Delete(SelectionPhoto{}, "selection_uid = ?", m.SelectionUID).Error; err != nil {
+		return err
+	}
+
+	return m.UpdateCount()
+}
+
+// PhotoUIDs returns the UIDs of the selected photos.
+func (m *Selection) PhotoUIDs(limit, offset int) (result UIDs, err error) {
+	result = UIDs{}
+
+	if m.SelectionUID == "" {
+		return result, fmt.Errorf("empty selection uid")
+	}
+
+	q := UnscopedDb().Model(SelectionPhoto{}).
+		Where("selection_uid = ?", m.SelectionUID).
+		Order("photo_uid")
+
+	if limit > 0 {
+		q = q.Limit(limit).Offset(offset)
+	}
+
+	err = q.Pluck("photo_uid", &result).Error
+
+	return result, err
+}
+
+// UpdateCount updates the number of selected photos.
+func (m *Selection) UpdateCount() error {
+	var count int
+
+	if err := UnscopedDb().Model(SelectionPhoto{}).Where("selection_uid = ?", m.SelectionUID).Count(&count).Error; err != nil {
+		return err
+	}
+
+	m.PhotoCount = count
+	m.UpdatedAt = TimeStamp()
+
+	return UnscopedDb().Model(m).UpdateColumns(Values{"photo_count": m.PhotoCount, "updated_at": m.UpdatedAt}).Error
+}
+
+// selectionBatches splits the UIDs into valid, unique batches.
+func selectionBatches(uids []string) (batches [][]string) {
+	batch := make([]string, 0, selectionBatchLen)
+	seen := make(map[string]bool, len(uids))
+
+	for _, uid := range uids {
+		if uid = clean.UID(uid); rnd.InvalidUID(uid, PhotoUID) || seen[uid] {
+			continue
+		}
+
+		seen[uid] = true
+		batch = append(batch, uid)
+
+		if len(batch) == selectionBatchLen {
+			batches = append(batches, batch)
+			batch = make([]string, 0, selectionBatchLen)
+		}
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectionName(t *testing.T) {
+	assert.Equal(t, SelectionDefault, SelectionName(""))
+	assert.Equal(t, "holiday", SelectionName("Holiday"))
+}
+
+func TestFirstOrCreateSelection(t *testing.T) {
+	userUid := UserFixtures.Pointer("alice").UserUID
+
+	t.Run("Success", func(t *testing.T) {
+		m, err := FirstOrCreateSelection(userUid, "Holiday")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "holiday", m.SelectionName)
+		assert.Equal(t, userUid, m.UserUID)
+
+		found := FindSelection(userUid, "holiday")
+
+		if found == nil {
+			t.Fatal("result must not be nil")
+		}
+
+		assert.Equal(t, m.SelectionUID, found.SelectionUID)
+		assert.NotEmpty(t, FindSelections(userUid))
+	})
+	t.Run("InvalidUser", func(t *testing.T) {
+		m, err := FirstOrCreateSelection("foo", "Holiday")
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+}
+
+func TestSelection_AddPhotos(t *testing.T) {
+	userUid := UserFixtures.Pointer("alice").UserUID
+	photo1 := PhotoFixtures.Get("Photo01").PhotoUID
+	photo2 := PhotoFixtures.Get("Photo02").PhotoUID
+
+	m, err := FirstOrCreateSelection(userUid, "TestSelection_AddPhotos")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.AddPhotos([]string{photo1, photo2, photo1, "foo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, m.PhotoCount)
+
+	if uids, err := m.PhotoUIDs(0, 0); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.ElementsMatch(t, UIDs{photo1, photo2}, uids)
+	}
+
+	if uids, err := m.PhotoUIDs(1, 0); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Len(t, uids, 1)
+	}
+
+	if err = m.RemovePhotos([]string{photo1}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, m.PhotoCount)
+
+	if err = m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindSelection(userUid, "TestSelection_AddPhotos"))
+}
@@ -3,13 +3,17 @@ package entity
 import (
 	"database/sql"
 	"fmt"
+	"path"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/internal/remote/smb"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -22,6 +26,12 @@ const (
 	SyncStatusSynced   = "synced"
 )
 
+// Conflict policies for files that were changed locally and remotely since they were last synced.
+const (
+	SyncConflictNewest = "newest"
+	SyncConflictKeep   = "keep"
+)
+
 type Services []Service
 
 // Service represents a remote service, e.g. for uploading, downloading or syncing media files.
@@ -31,6 +41,7 @@ type Services []Service
 // - AccErrors holds the number of connection errors since the last reset.
 // - AccShare enables manual upload, see SharePath, ShareSize, and ShareExpires.
 // - AccSync enables automatic file synchronization, see SyncDownload and SyncUpload.
+// - SyncConflict specifies how files are synced that were changed on both sides, options: newest, keep.
 // - RetryLimit specifies the number of retry attempts, a negative value disables the limit.
 type Service struct {
 	ID            uint   `gorm:"primary_key"`
@@ -58,6 +69,7 @@ type Service struct {
 	SyncDownload  bool
 	SyncFilenames bool
 	SyncRaw       bool
+	SyncConflict  string     `gorm:"type:VARBINARY(16);"`
 	CreatedAt     time.Time  `deepcopier:"skip"`
 	UpdatedAt     time.Time  `deepcopier:"skip"`
 	DeletedAt     *time.Time `deepcopier:"skip" sql:"index"`
@@ -139,17 +151,33 @@ func (m *Service) SaveForm(form form.Service) error {
 		return err
 	}
 
-	// TODO: Support for other remote services in addition to WebDAV.
-	if m.AccType != remote.ServiceWebDAV {
+	// TODO: Support for other remote services in addition to WebDAV, SFTP, SMB, and Google Photos.
+	switch m.AccType {
+	case remote.ServiceWebDAV:
+	case remote.ServiceSFTP, remote.ServiceSMB:
+		m.AccShare = false   // Disable manual upload.
+		m.SyncUpload = false // Only downloads are supported.
+	case remote.ServiceGPhotos:
+		m.AccShare = false      // Disable manual upload.
+		m.SyncUpload = false    // Only downloads are supported.
+		m.SyncFilenames = false // Remote file names contain the media item ID.
+	default:
 		m.AccShare = false // Disable manual upload.
 		m.AccSync = false  // Disable background sync.
 	}
 
-	// Prevent two-way sync, see https://github.com/photoprism/photoprism/issues/1785
-	if m.SyncUpload && m.SyncDownload {
+	// Two-way sync requires that remote file names are kept, see https://github.com/photoprism/photoprism/issues/1785
+	if m.SyncUpload && m.SyncDownload && (m.AccType != remote.ServiceWebDAV || !m.SyncFilenames) {
 		m.SyncUpload = false
 	}
 
+	// Let the newest version win if no supported conflict policy is specified.
+	switch m.SyncConflict {
+	case SyncConflictNewest, SyncConflictKeep:
+	default:
+		m.SyncConflict = SyncConflictNewest
+	}
+
 	// Set default manual upload folder if empty.
 	if m.SharePath == "" {
 		m.SharePath = "/"
@@ -190,14 +218,46 @@ func (m *Service) Delete() error {
 	return Db().Delete(m).Error
 }
 
+// PinHostKey stores the fingerprint of the server host key if none is set yet, so that
+// it is verified when connecting again.
+func (m *Service) PinHostKey(fingerprint string) error {
+	if m.AccKey != "" || fingerprint == "" {
+		return nil
+	}
+
+	m.AccKey = fingerprint
+
+	return m.Update("AccKey", m.AccKey)
+}
+
 // Directories returns a list of directories or albums in an account.
 func (m *Service) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
+	switch m.AccType {
+	case remote.ServiceWebDAV:
 		var client *webdav.Client
 		if client, err = webdav.NewClient(m.AccURL, m.AccUser, m.AccPass, webdav.Timeout(m.AccTimeout)); err != nil {
 			return result, err
 		}
 
+		result, err = client.Directories("/", true, 0)
+	case remote.ServiceSFTP:
+		// The account key contains the SHA256 fingerprint of the server host key, if any.
+		var client *sftp.Client
+		if client, err = sftp.NewClient(m.AccURL, m.AccUser, m.AccPass, m.AccKey, webdav.Durations[webdav.Timeout(m.AccTimeout)]); err != nil {
+			return result, err
+		}
+
+		defer client.Close()
+
+		Log("service", "pin host key", m.PinHostKey(client.HostKey()))
+
+		result, err = client.Directories("/", true, webdav.MaxRequestDuration)
+	case remote.ServiceSMB:
+		var client *smb.Client
+		if client, err = smb.NewClient(m.AccURL, m.AccUser, m.AccPass, webdav.Durations[webdav.Timeout(m.AccTimeout)]); err != nil {
+			return result, err
+		}
+
 		result, err = client.Directories("/", true, 0)
 	}
 
@@ -236,3 +296,24 @@ func (m *Service) Create() error {
 func (m *Service) ShareOriginals() bool {
 	return m.ShareSize == ""
 }
+
+// SyncTwoWay tests if local and remote changes are synced in both directions.
+func (m *Service) SyncTwoWay() bool {
+	return m.SyncUpload && m.SyncDownload && m.SyncFilenames
+}
+
+// SyncRemoteName returns the remote name of a file in the originals folder.
+func (m *Service) SyncRemoteName(fileName string) string {
+	return path.Join("/", m.SyncPath, fileName)
+}
+
+// SyncLocalName returns the originals file name of a remote file, relative to the sync folder.
+func (m *Service) SyncLocalName(remoteName string) string {
+	remoteName = path.Join("/", remoteName)
+
+	if dir := path.Join("/", m.SyncPath); dir != "/" && strings.HasPrefix(remoteName, dir+"/") {
+		remoteName = strings.TrimPrefix(remoteName, dir)
+	}
+
+	return strings.TrimPrefix(remoteName, "/")
+}
@@ -91,6 +91,68 @@ func TestService_SaveForm(t *testing.T) {
 		assert.Equal(t, "NewOwner", model.AccOwner)
 		assert.Equal(t, "new.com", model.AccURL)
 	})
+	t.Run("TwoWay", func(t *testing.T) {
+		account := Service{AccName: "TwoWay", AccURL: "http://dummy-webdav/", AccType: "webdav", SyncPath: "/Photos",
+			SyncUpload: true, SyncDownload: true, SyncFilenames: true, SyncConflict: "invalid"}
+
+		accountForm, err := form.NewService(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, model.SyncUpload)
+		assert.True(t, model.SyncDownload)
+		assert.True(t, model.SyncTwoWay())
+		assert.Equal(t, SyncConflictNewest, model.SyncConflict)
+
+		accountForm.SyncFilenames = false
+
+		if err = model.SaveForm(accountForm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, model.SyncUpload)
+		assert.False(t, model.SyncTwoWay())
+	})
+	t.Run("SFTP", func(t *testing.T) {
+		account := Service{AccName: "Camera", AccURL: "sftp://camera.local/upload", AccType: "sftp", AccShare: true,
+			AccSync: true, SyncUpload: true, SyncDownload: true}
+
+		accountForm, err := form.NewService(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, model.AccSync)
+		assert.True(t, model.SyncDownload)
+		assert.False(t, model.AccShare)
+		assert.False(t, model.SyncUpload)
+	})
+}
+
+func TestService_SyncRemoteName(t *testing.T) {
+	assert.Equal(t, "/Photos/2023/photo.jpg", (&Service{SyncPath: "/Photos"}).SyncRemoteName("2023/photo.jpg"))
+	assert.Equal(t, "/2023/photo.jpg", (&Service{SyncPath: ""}).SyncRemoteName("2023/photo.jpg"))
+}
+
+func TestService_SyncLocalName(t *testing.T) {
+	assert.Equal(t, "2023/photo.jpg", (&Service{SyncPath: "/Photos"}).SyncLocalName("/Photos/2023/photo.jpg"))
+	assert.Equal(t, "2023/photo.jpg", (&Service{SyncPath: "/"}).SyncLocalName("/2023/photo.jpg"))
+	assert.Equal(t, "PhotosArchive/photo.jpg", (&Service{SyncPath: "/Photos"}).SyncLocalName("/PhotosArchive/photo.jpg"))
 }
 
 func TestService_Delete(t *testing.T) {
@@ -229,6 +291,43 @@ func TestService_Update(t *testing.T) {
 	})
 }
 
+func TestService_PinHostKey(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		account := Service{AccName: "PinHostKey", AccURL: "sftp://camera.local/upload", AccType: "sftp"}
+
+		accountForm, err := form.NewService(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", model.AccKey)
+		assert.NoError(t, model.PinHostKey(""))
+		assert.Equal(t, "", model.AccKey)
+		assert.NoError(t, model.PinHostKey("SHA256:first"))
+		assert.Equal(t, "SHA256:first", model.AccKey)
+
+		// The key is not replaced once it has been pinned.
+		assert.NoError(t, model.PinHostKey("SHA256:second"))
+		assert.Equal(t, "SHA256:first", model.AccKey)
+
+		found := &Service{}
+
+		if err = UnscopedDb().First(found, model.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "SHA256:first", found.AccKey)
+		assert.NoError(t, model.Delete())
+	})
+}
+
 // TODO fails on mariadb
 func TestService_Save(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
@@ -9,13 +9,20 @@ const (
 	SrcAuto     = ""                   // Prio 1
 	SrcDefault  = "default"            // Prio 1
 	SrcEstimate = "estimate"           // Prio 2
+	SrcOCR      = "ocr"                // Prio 2
+	SrcCaption  = "caption"            // Prio 2
 	SrcName     = "name"               // Prio 4
 	SrcYaml     = "yaml"               // Prio 8
 	SrcLDAP     = "ldap"               // Prio 8
 	SrcLocation = classify.SrcLocation // Prio 8
 	SrcMarker   = "marker"             // Prio 8
 	SrcImage    = classify.SrcImage    // Prio 8
+	SrcLandmark = classify.SrcLandmark // Prio 8
+	SrcRule     = classify.SrcRule     // Prio 8
+	SrcImport   = "import"             // Prio 8
+	SrcVideo    = "video"              // Prio 8
 	SrcKeyword  = classify.SrcKeyword  // Prio 16
+	SrcTrack    = "track"              // Prio 16
 	SrcMeta     = "meta"               // Prio 16
 	SrcXmp      = "xmp"                // Prio 32
 	SrcManual   = "manual"             // Prio 64
@@ -36,13 +43,20 @@ var SrcPriority = Priorities{
 	SrcAuto:     1,
 	SrcDefault:  1,
 	SrcEstimate: 2,
+	SrcOCR:      2,
+	SrcCaption:  2,
 	SrcName:     4,
 	SrcYaml:     8,
 	SrcLDAP:     8,
 	SrcLocation: 8,
 	SrcMarker:   8,
 	SrcImage:    8,
+	SrcLandmark: 8,
+	SrcRule:     8,
+	SrcImport:   8,
+	SrcVideo:    8,
 	SrcKeyword:  16,
+	SrcTrack:    16,
 	SrcMeta:     16,
 	SrcXmp:      32,
 	SrcManual:   64,
@@ -19,26 +19,30 @@ var subjectMutex = sync.Mutex{}
 
 // Subject represents a named photo subject, typically a person.
 type Subject struct {
-	SubjUID      string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
-	SubjType     string     `gorm:"type:VARBINARY(8);default:'';" json:"Type,omitempty" yaml:"Type,omitempty"`
-	SubjSrc      string     `gorm:"type:VARBINARY(8);default:'';" json:"Src,omitempty" yaml:"Src,omitempty"`
-	SubjSlug     string     `gorm:"type:VARBINARY(160);index;default:'';" json:"Slug" yaml:"-"`
-	SubjName     string     `gorm:"size:160;unique_index;default:'';" json:"Name" yaml:"Name"`
-	SubjAlias    string     `gorm:"size:160;default:'';" json:"Alias" yaml:"Alias"`
-	SubjAbout    string     `gorm:"size:512;" json:"About" yaml:"About,omitempty"`
-	SubjBio      string     `gorm:"size:2048;" json:"Bio" yaml:"Bio,omitempty"`
-	SubjNotes    string     `gorm:"size:1024;" json:"Notes,omitempty" yaml:"Notes,omitempty"`
-	SubjFavorite bool       `gorm:"default:false;" json:"Favorite" yaml:"Favorite,omitempty"`
-	SubjHidden   bool       `gorm:"default:false;" json:"Hidden" yaml:"Hidden,omitempty"`
-	SubjPrivate  bool       `gorm:"default:false;" json:"Private" yaml:"Private,omitempty"`
-	SubjExcluded bool       `gorm:"default:false;" json:"Excluded" yaml:"Excluded,omitempty"`
-	FileCount    int        `gorm:"default:0;" json:"FileCount" yaml:"-"`
-	PhotoCount   int        `gorm:"default:0;" json:"PhotoCount" yaml:"-"`
-	Thumb        string     `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
-	ThumbSrc     string     `gorm:"type:VARBINARY(8);default:'';" json:"ThumbSrc,omitempty" yaml:"ThumbSrc,omitempty"`
-	CreatedAt    time.Time  `json:"CreatedAt" yaml:"-"`
-	UpdatedAt    time.Time  `json:"UpdatedAt" yaml:"-"`
-	DeletedAt    *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
+	SubjUID        string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	SubjType       string     `gorm:"type:VARBINARY(8);default:'';" json:"Type,omitempty" yaml:"Type,omitempty"`
+	SubjSrc        string     `gorm:"type:VARBINARY(8);default:'';" json:"Src,omitempty" yaml:"Src,omitempty"`
+	SubjSlug       string     `gorm:"type:VARBINARY(160);index;default:'';" json:"Slug" yaml:"-"`
+	SubjName       string     `gorm:"size:160;unique_index;default:'';" json:"Name" yaml:"Name"`
+	SubjAlias      string     `gorm:"size:160;default:'';" json:"Alias" yaml:"Alias"`
+	SubjAbout      string     `gorm:"size:512;" json:"About" yaml:"About,omitempty"`
+	SubjBio        string     `gorm:"size:2048;" json:"Bio" yaml:"Bio,omitempty"`
+	SubjNotes      string     `gorm:"size:1024;" json:"Notes,omitempty" yaml:"Notes,omitempty"`
+	SubjFavorite   bool       `gorm:"default:false;" json:"Favorite" yaml:"Favorite,omitempty"`
+	SubjHidden     bool       `gorm:"default:false;" json:"Hidden" yaml:"Hidden,omitempty"`
+	SubjPrivate    bool       `gorm:"default:false;" json:"Private" yaml:"Private,omitempty"`
+	SubjExcluded   bool       `gorm:"default:false;" json:"Excluded" yaml:"Excluded,omitempty"`
+	SubjIgnored    bool       `gorm:"default:false;" json:"Ignored" yaml:"Ignored,omitempty"`
+	SubjBirthYear  int        `gorm:"default:0;" json:"BirthYear" yaml:"BirthYear,omitempty"`
+	SubjBirthMonth int        `gorm:"default:0;" json:"BirthMonth" yaml:"BirthMonth,omitempty"`
+	SubjBirthDay   int        `gorm:"default:0;" json:"BirthDay" yaml:"BirthDay,omitempty"`
+	FileCount      int        `gorm:"default:0;" json:"FileCount" yaml:"-"`
+	PhotoCount     int        `gorm:"default:0;" json:"PhotoCount" yaml:"-"`
+	Thumb          string     `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
+	ThumbSrc       string     `gorm:"type:VARBINARY(8);default:'';" json:"ThumbSrc,omitempty" yaml:"ThumbSrc,omitempty"`
+	CreatedAt      time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt      time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt      *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
 }
 
 // TableName returns the entity table name.
@@ -244,7 +248,7 @@ func FindSubjectByName(name string) *Subject {
 
 	switch uid {
 	case "":
-		if err := UnscopedDb().Where("subj_name LIKE ?", name).First(&result).Error; err != nil {
+		if err := UnscopedDb().Where(fmt.Sprintf("subj_name %s ?", LikeOp()), name).First(&result).Error; err != nil {
 			log.Debugf("subject: %s not found by name", clean.Log(name))
 			return nil
 		}
@@ -299,7 +303,7 @@ func (m *Subject) SetName(name string) error {
 
 // Visible tests if the subject is generally visible and not hidden in any way.
 func (m *Subject) Visible() bool {
-	return m.DeletedAt == nil && !m.SubjHidden && !m.SubjExcluded && !m.SubjPrivate
+	return m.DeletedAt == nil && !m.SubjHidden && !m.SubjExcluded && !m.SubjPrivate && !m.SubjIgnored
 }
 
 // SaveForm updates the subject from form values.
@@ -325,11 +329,21 @@ func (m *Subject) SaveForm(f form.Subject) (changed bool, err error) {
 		changed = true
 	}
 
+	// Change date of birth?
+	if m.SubjBirthYear != f.SubjBirthYear || m.SubjBirthMonth != f.SubjBirthMonth || m.SubjBirthDay != f.SubjBirthDay {
+		m.SetBirthday(f.SubjBirthYear, f.SubjBirthMonth, f.SubjBirthDay)
+		changed = true
+	}
+
+	// Ignore subject?
+	ignoredChanged := m.SubjIgnored != f.SubjIgnored
+
 	// Change visibility?
-	if m.SubjHidden != f.SubjHidden || m.SubjPrivate != f.SubjPrivate || m.SubjExcluded != f.SubjExcluded {
+	if m.SubjHidden != f.SubjHidden || m.SubjPrivate != f.SubjPrivate || m.SubjExcluded != f.SubjExcluded || ignoredChanged {
 		m.SubjHidden = f.SubjHidden
 		m.SubjPrivate = f.SubjPrivate
 		m.SubjExcluded = f.SubjExcluded
+		m.SubjIgnored = f.SubjIgnored
 
 		// Update counter.
 		if !m.IsPerson() {
@@ -350,10 +364,14 @@ func (m *Subject) SaveForm(f form.Subject) (changed bool, err error) {
 	// Update index?
 	if changed {
 		values := Values{
-			"SubjFavorite": m.SubjFavorite,
-			"SubjHidden":   m.SubjHidden,
-			"SubjPrivate":  m.SubjPrivate,
-			"SubjExcluded": m.SubjExcluded,
+			"SubjFavorite":   m.SubjFavorite,
+			"SubjHidden":     m.SubjHidden,
+			"SubjPrivate":    m.SubjPrivate,
+			"SubjExcluded":   m.SubjExcluded,
+			"SubjIgnored":    m.SubjIgnored,
+			"SubjBirthYear":  m.SubjBirthYear,
+			"SubjBirthMonth": m.SubjBirthMonth,
+			"SubjBirthDay":   m.SubjBirthDay,
 		}
 
 		if err := m.Updates(values); err == nil {
@@ -363,6 +381,11 @@ func (m *Subject) SaveForm(f form.Subject) (changed bool, err error) {
 				event.EntitiesUpdated("people", []*Person{m.Person()})
 			}
 
+			// Hide or show the faces of ignored subjects.
+			if ignoredChanged {
+				return true, m.HideFaces(m.SubjIgnored)
+			}
+
 			return true, nil
 		} else {
 			return false, err
@@ -372,6 +395,18 @@ func (m *Subject) SaveForm(f form.Subject) (changed bool, err error) {
 	return false, nil
 }
 
+// HideFaces hides or shows the face clusters of this subject, e.g. so that the faces of ignored subjects
+// neither resurface in the People view nor as new clusters.
+func (m *Subject) HideFaces(hidden bool) error {
+	if m.SubjUID == "" {
+		return fmt.Errorf("subject uid is empty")
+	}
+
+	UpdateFaces.Store(true)
+
+	return Db().Model(&Face{}).Where("subj_uid = ?", m.SubjUID).UpdateColumn("face_hidden", hidden).Error
+}
+
 // UpdateName changes and saves the subject's name in the index.
 func (m *Subject) UpdateName(name string) (*Subject, error) {
 	if err := m.SetName(name); err != nil {
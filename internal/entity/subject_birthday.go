@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BirthYearMin is the earliest supported year of birth.
+const BirthYearMin = 1800
+
+// HasBirthday tests if the year of birth is known.
+func (m *Subject) HasBirthday() bool {
+	return m.SubjBirthYear > 0
+}
+
+// SetBirthday sets the date of birth, where unknown or invalid values are set to 0.
+func (m *Subject) SetBirthday(year, month, day int) {
+	if year < BirthYearMin || year > time.Now().Year() {
+		year, month, day = 0, 0, 0
+	} else if month < txt.MonthMin || month > txt.MonthMax {
+		month, day = 0, 0
+	} else if day < txt.DayMin || day > txt.DayMax {
+		day = 0
+	}
+
+	m.SubjBirthYear = year
+	m.SubjBirthMonth = month
+	m.SubjBirthDay = day
+}
+
+// AgeAt returns the age in years at the specified time, or -1 if unknown.
+func (m *Subject) AgeAt(t time.Time) int {
+	if !m.HasBirthday() || t.IsZero() {
+		return -1
+	}
+
+	age := t.Year() - m.SubjBirthYear
+
+	// Birthday not reached yet?
+	if m.SubjBirthMonth <= 0 {
+		// Month unknown.
+	} else if month := int(t.Month()); month < m.SubjBirthMonth || month == m.SubjBirthMonth && t.Day() < m.SubjBirthDay {
+		age--
+	}
+
+	if age < 0 {
+		return -1
+	}
+
+	return age
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubject_SetBirthday(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m := Subject{}
+		m.SetBirthday(2015, 7, 21)
+		assert.True(t, m.HasBirthday())
+		assert.Equal(t, 2015, m.SubjBirthYear)
+		assert.Equal(t, 7, m.SubjBirthMonth)
+		assert.Equal(t, 21, m.SubjBirthDay)
+	})
+	t.Run("YearOnly", func(t *testing.T) {
+		m := Subject{}
+		m.SetBirthday(1950, 13, 5)
+		assert.Equal(t, 1950, m.SubjBirthYear)
+		assert.Equal(t, 0, m.SubjBirthMonth)
+		assert.Equal(t, 0, m.SubjBirthDay)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		m := Subject{}
+		m.SetBirthday(1700, 1, 1)
+		assert.False(t, m.HasBirthday())
+		assert.Equal(t, 0, m.SubjBirthMonth)
+	})
+}
+
+func TestSubject_AgeAt(t *testing.T) {
+	m := Subject{}
+	assert.Equal(t, -1, m.AgeAt(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	m.SetBirthday(2015, 7, 21)
+	assert.Equal(t, 4, m.AgeAt(time.Date(2020, 7, 20, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 5, m.AgeAt(time.Date(2020, 7, 21, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, m.AgeAt(time.Date(2015, 8, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -1, m.AgeAt(time.Date(2014, 8, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -1, m.AgeAt(time.Time{}))
+
+	m.SetBirthday(2015, 0, 0)
+	assert.Equal(t, 5, m.AgeAt(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
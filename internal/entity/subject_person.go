@@ -8,6 +8,7 @@ import (
 
 const (
 	SubjPerson = "person" // SubjType for people.
+	SubjPet    = "pet"    // SubjType for pets and other animals.
 )
 
 // People represents a list of people.
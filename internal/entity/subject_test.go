@@ -293,6 +293,80 @@ func TestSubject_SaveForm(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+	t.Run("Ignored", func(t *testing.T) {
+		subj := NewSubject("Ignored Stranger", SubjPerson, SrcManual)
+
+		if err := subj.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		subjForm, err := form.NewSubject(subj)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		subjForm.SubjIgnored = true
+
+		if changed, err := subj.SaveForm(subjForm); err != nil {
+			t.Fatal(err)
+		} else if !changed {
+			t.Fatal("subject must be changed")
+		}
+
+		assert.True(t, subj.SubjIgnored)
+		assert.False(t, subj.Visible())
+
+		if err := subj.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Birthday", func(t *testing.T) {
+		subj := NewSubject("Birthday Child", SubjPerson, SrcManual)
+
+		if err := subj.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		subjForm, err := form.NewSubject(subj)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		subjForm.SubjBirthYear = 2017
+		subjForm.SubjBirthMonth = 3
+
+		if changed, err := subj.SaveForm(subjForm); err != nil {
+			t.Fatal(err)
+		} else if !changed {
+			t.Fatal("subject must be changed")
+		}
+
+		if found := FindSubject(subj.SubjUID); found == nil {
+			t.Fatal("subject not found")
+		} else {
+			assert.Equal(t, 2017, found.SubjBirthYear)
+			assert.Equal(t, 3, found.SubjBirthMonth)
+			assert.Equal(t, 0, found.SubjBirthDay)
+		}
+
+		if err := subj.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestSubject_HideFaces(t *testing.T) {
+	t.Run("EmptyUID", func(t *testing.T) {
+		subj := Subject{}
+		assert.Error(t, subj.HideFaces(true))
+	})
+	t.Run("NoFaces", func(t *testing.T) {
+		subj := SubjectFixtures.Get("dangling")
+		assert.NoError(t, subj.HideFaces(true))
+		assert.NoError(t, subj.HideFaces(false))
+	})
 }
 
 func TestSubject_UpdateName(t *testing.T) {
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"gopkg.in/yaml.v2"
+)
+
+var subjectYamlMutex = sync.Mutex{}
+
+// Yaml returns subject data as YAML string.
+func (m *Subject) Yaml() ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+// SaveAsYaml saves subject data as YAML file.
+func (m *Subject) SaveAsYaml(fileName string) error {
+	data, err := m.Yaml()
+
+	if err != nil {
+		return err
+	}
+
+	// Make sure directory exists.
+	if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	subjectYamlMutex.Lock()
+	defer subjectYamlMutex.Unlock()
+
+	// Write YAML data to file.
+	return os.WriteFile(fileName, data, fs.ModeFile)
+}
+
+// LoadFromYaml loads subject data from a YAML file.
+func (m *Subject) LoadFromYaml(fileName string) error {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(data, m)
+}
+
+// YamlFileName returns the YAML file name.
+func (m *Subject) YamlFileName(peoplePath string) string {
+	return filepath.Join(peoplePath, m.SubjUID+fs.ExtYAML)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubject_Yaml(t *testing.T) {
+	m := SubjectFixtures.Get("john-doe")
+
+	result, err := m.Yaml()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(result), "Name: John Doe")
+	assert.NotContains(t, string(result), "FileCount")
+}
+
+func TestSubject_SaveAsYaml(t *testing.T) {
+	m := SubjectFixtures.Get("jane-doe")
+
+	fileName := m.YamlFileName("testdata/people")
+
+	assert.Equal(t, "testdata/people/"+m.SubjUID+".yml", fileName)
+
+	if err := m.SaveAsYaml(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll("testdata/people")
+
+	other := Subject{}
+
+	if err := other.LoadFromYaml(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, m.SubjUID, other.SubjUID)
+	assert.Equal(t, m.SubjName, other.SubjName)
+	assert.Equal(t, m.SubjType, other.SubjType)
+}
+
+func TestSubject_LoadFromYaml(t *testing.T) {
+	m := Subject{}
+
+	assert.Error(t, m.LoadFromYaml("testdata/people/missing.yml"))
+}
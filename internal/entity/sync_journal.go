@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Sync journal actions.
+const (
+	SyncActionDownload = "download"
+	SyncActionUpload   = "upload"
+	SyncActionRename   = "rename"
+	SyncActionConflict = "conflict"
+)
+
+// SyncJournalMaxAge is the time after which journal entries are deleted.
+var SyncJournalMaxAge = 90 * 24 * time.Hour
+
+// SyncJournal represents a change that was synced between the originals folder and a remote account.
+type SyncJournal struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	ServiceID  uint      `gorm:"index;" json:"ServiceID" yaml:"ServiceID"`
+	Action     string    `gorm:"type:VARBINARY(16);" json:"Action" yaml:"Action"`
+	RemoteName string    `gorm:"type:VARBINARY(1024);" json:"RemoteName" yaml:"RemoteName,omitempty"`
+	LocalName  string    `gorm:"type:VARBINARY(1024);" json:"LocalName" yaml:"LocalName,omitempty"`
+	Message    string    `gorm:"type:VARCHAR(512);" json:"Message" yaml:"Message,omitempty"`
+	CreatedAt  time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// SyncJournals represents a list of sync journal entries.
+type SyncJournals []SyncJournal
+
+// TableName returns the entity table name.
+func (SyncJournal) TableName() string {
+	return "sync_journal"
+}
+
+// NewSyncJournal returns a new journal entry for the specified account.
+func NewSyncJournal(serviceID uint, action, remoteName, localName, message string) *SyncJournal {
+	return &SyncJournal{
+		ServiceID:  serviceID,
+		Action:     action,
+		RemoteName: remoteName,
+		LocalName:  localName,
+		Message:    txt.Clip(message, 512),
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *SyncJournal) Create() error {
+	return Db().Create(m).Error
+}
+
+// AddSyncJournal adds an entry to the sync journal of the specified account and logs errors.
+func AddSyncJournal(serviceID uint, action, remoteName, localName, message string) {
+	if err := NewSyncJournal(serviceID, action, remoteName, localName, message).Create(); err != nil {
+		log.Errorf("sync: %s while adding %s to journal", err, clean.Log(remoteName))
+	}
+}
+
+// FindSyncJournal returns the journal entries of the specified account, most recent first.
+func FindSyncJournal(serviceID uint, limit, offset int) (result SyncJournals, err error) {
+	s := Db().Where("service_id = ?", serviceID).Order("id DESC")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(offset)
+	}
+
+	err = s.Find(&result).Error
+
+	return result, err
+}
+
+// PruneSyncJournal deletes journal entries of the specified account that are older than SyncJournalMaxAge.
+func PruneSyncJournal(serviceID uint) error {
+	return UnscopedDb().Where("service_id = ? AND created_at < ?", serviceID, time.Now().Add(-1*SyncJournalMaxAge)).
+		Delete(SyncJournal{}).Error
+}
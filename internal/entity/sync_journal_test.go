@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncJournal_TableName(t *testing.T) {
+	assert.Equal(t, "sync_journal", SyncJournal{}.TableName())
+}
+
+func TestNewSyncJournal(t *testing.T) {
+	m := NewSyncJournal(123, SyncActionRename, "/Photos/new.jpg", "new.jpg", strings.Repeat("x", 600))
+	assert.Equal(t, uint(123), m.ServiceID)
+	assert.Equal(t, SyncActionRename, m.Action)
+	assert.Equal(t, "/Photos/new.jpg", m.RemoteName)
+	assert.Equal(t, "new.jpg", m.LocalName)
+	assert.Equal(t, 512, len(m.Message))
+}
+
+func TestFindSyncJournal(t *testing.T) {
+	AddSyncJournal(1000050, SyncActionDownload, "/first.jpg", "first.jpg", "")
+	AddSyncJournal(1000050, SyncActionUpload, "/second.jpg", "second.jpg", "")
+
+	t.Run("All", func(t *testing.T) {
+		result, err := FindSyncJournal(1000050, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, "/second.jpg", result[0].RemoteName)
+		assert.Equal(t, SyncActionUpload, result[0].Action)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		result, err := FindSyncJournal(1000050, 1, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "/first.jpg", result[0].RemoteName)
+	})
+	t.Run("Prune", func(t *testing.T) {
+		assert.NoError(t, PruneSyncJournal(1000050))
+
+		result, err := FindSyncJournal(1000050, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+	})
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -34,15 +35,41 @@ func Audit(level logrus.Level, ev []string, args ...interface{}) {
 
 	// Publish event if log level is info or higher.
 	if level <= logrus.InfoLevel {
-		Publish(
-			"audit."+level.String(),
-			Data{
-				"time":    TimeStamp(),
-				"level":   level.String(),
-				"message": message,
-			},
-		)
+		data := Data{
+			"time":    TimeStamp(),
+			"level":   level.String(),
+			"message": message,
+		}
+
+		// Add client IP and session ref ID for filtering, if specified.
+		if ip := AuditIP(ev); ip != "" {
+			data["ip"] = ip
+		}
+
+		if ref := AuditSession(ev, args...); ref != "" {
+			data["session"] = ref
+		}
+
+		Publish("audit."+level.String(), data)
+	}
+}
+
+// AuditIP returns the client IP address if it is the first element of the audit event.
+func AuditIP(ev []string) string {
+	if len(ev) == 0 || net.ParseIP(ev[0]) == nil {
+		return ""
 	}
+
+	return ev[0]
+}
+
+// AuditSession returns the session ref ID if the event has the form [ip, "session %s", ...].
+func AuditSession(ev []string, args ...interface{}) string {
+	if len(ev) < 2 || len(args) == 0 || ev[1] != "session %s" || AuditIP(ev) == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", args[0])
 }
 
 func AuditErr(ev []string, args ...interface{}) {
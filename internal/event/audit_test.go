@@ -20,3 +20,17 @@ func TestFormat(t *testing.T) {
 
 	t.Log(result)
 }
+
+func TestAuditIP(t *testing.T) {
+	assert.Equal(t, "", AuditIP(nil))
+	assert.Equal(t, "", AuditIP([]string{"user", "michael"}))
+	assert.Equal(t, "192.168.1.2", AuditIP([]string{"192.168.1.2", "session %s", "created"}))
+	assert.Equal(t, "::1", AuditIP([]string{"::1", "login as %s"}))
+}
+
+func TestAuditSession(t *testing.T) {
+	assert.Equal(t, "sessxkkcabcd", AuditSession([]string{"192.168.1.2", "session %s", "created"}, "sessxkkcabcd"))
+	assert.Equal(t, "", AuditSession([]string{"192.168.1.2", "session %s", "created"}))
+	assert.Equal(t, "", AuditSession([]string{"192.168.1.2", "login as %s"}, "'michael'"))
+	assert.Equal(t, "", AuditSession([]string{"session %s", "created"}, "sessxkkcabcd"))
+}
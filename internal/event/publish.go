@@ -8,7 +8,7 @@ import (
 
 // Publish publishes a message to all subscribers.
 func Publish(event string, data Data) {
-	SharedHub().Publish(Message{
+	publish(Message{
 		Name:   event,
 		Fields: data,
 	})
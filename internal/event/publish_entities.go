@@ -19,7 +19,7 @@ func PublishEntities(channel, ev string, entities interface{}) {
 	if channel == "" || ev == "" || entities == nil {
 		return
 	}
-	SharedHub().Publish(Message{
+	publish(Message{
 		Name: strings.Join([]string{channel, ev}, "."),
 		Fields: Data{
 			"entities": entities,
@@ -36,7 +36,7 @@ func PublishUserEntities(channel, ev string, entities interface{}, userUid strin
 		return
 	}
 
-	SharedHub().Publish(Message{
+	publish(Message{
 		Name: strings.Join([]string{"user", userUid, channel, ev}, "."),
 		Fields: Data{
 			"entities": entities,
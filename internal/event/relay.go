@@ -0,0 +1,111 @@
+package event
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// RelayChannel is the name of the pub/sub channel through which events are shared between instances.
+const RelayChannel = "events"
+
+// relayCap specifies the maximum number of queued events before new events are dropped.
+var relayCap = 1000
+
+// relayID uniquely identifies this instance, so that it ignores its own events.
+var relayID = rnd.Base36(16)
+
+var relayQueue chan []byte
+var relayMutex sync.RWMutex
+
+// relayMessage represents an event that is shared with other instances.
+type relayMessage struct {
+	Origin string `json:"origin"`
+	Name   string `json:"name"`
+	Fields Data   `json:"fields,omitempty"`
+}
+
+// EnableRelay returns a queue of encoded events that should be sent to other instances,
+// e.g. through a Redis server, so that their clients are notified as well.
+func EnableRelay() <-chan []byte {
+	relayMutex.Lock()
+	defer relayMutex.Unlock()
+
+	if relayQueue == nil {
+		relayQueue = make(chan []byte, relayCap)
+	}
+
+	return relayQueue
+}
+
+// DisableRelay stops sending events to other instances.
+func DisableRelay() {
+	relayMutex.Lock()
+	defer relayMutex.Unlock()
+
+	if relayQueue != nil {
+		close(relayQueue)
+		relayQueue = nil
+	}
+}
+
+// Relayed checks if events with the specified topic are sent to other instances. Log and audit
+// events are not shared since each instance already stores and displays its own messages.
+func Relayed(topic string) bool {
+	switch ch, _ := Topic(topic); ch {
+	case "", "log", "audit":
+		return false
+	default:
+		return true
+	}
+}
+
+// Receive publishes an encoded event that was sent by another instance to local subscribers.
+func Receive(data []byte) error {
+	var msg relayMessage
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	} else if msg.Origin == relayID || !Relayed(msg.Name) {
+		return nil
+	}
+
+	SharedHub().Publish(Message{
+		Name:   msg.Name,
+		Fields: msg.Fields,
+	})
+
+	return nil
+}
+
+// publish sends a message to local subscribers and queues it for other instances, if enabled.
+func publish(msg Message) {
+	SharedHub().Publish(msg)
+
+	if strings.TrimSpace(msg.Name) == "" || !Relayed(msg.Name) {
+		return
+	}
+
+	relayMutex.RLock()
+	defer relayMutex.RUnlock()
+
+	if relayQueue == nil {
+		return
+	}
+
+	data, err := json.Marshal(relayMessage{Origin: relayID, Name: msg.Name, Fields: msg.Fields})
+
+	if err != nil {
+		Log.Debugf("event: %s (relay %s)", err, msg.Name)
+		return
+	}
+
+	// Drop the event if the queue is full, so that publishing never blocks.
+	select {
+	case relayQueue <- data:
+	default:
+		Log.Debugf("event: relay queue is full, dropped %s", msg.Name)
+	}
+}
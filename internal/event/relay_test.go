@@ -0,0 +1,71 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayed(t *testing.T) {
+	assert.True(t, Relayed("photos.updated"))
+	assert.True(t, Relayed("user.uqxetse3cy5eo9z2.albums.created"))
+	assert.True(t, Relayed("notify.info"))
+	assert.False(t, Relayed("log.info"))
+	assert.False(t, Relayed("audit.info"))
+	assert.False(t, Relayed(""))
+}
+
+func TestRelay(t *testing.T) {
+	queue := EnableRelay()
+
+	defer DisableRelay()
+
+	t.Run("Publish", func(t *testing.T) {
+		Publish("albums.updated", Data{"uid": "as6sg6bxpogaaba7"})
+
+		data := <-queue
+
+		assert.Contains(t, string(data), `"name":"albums.updated"`)
+		assert.Contains(t, string(data), `"uid":"as6sg6bxpogaaba7"`)
+	})
+	t.Run("NotRelayed", func(t *testing.T) {
+		Publish("log.info", Data{"message": "foo"})
+		Publish("notify.info", Data{"message": "bar"})
+
+		data := <-queue
+
+		assert.Contains(t, string(data), `"name":"notify.info"`)
+	})
+	t.Run("OwnEvent", func(t *testing.T) {
+		s := Subscribe("albums.deleted")
+		defer Unsubscribe(s)
+
+		Publish("albums.deleted", Data{"uid": "as6sg6bxpogaaba8"})
+
+		// Events sent by this instance are ignored.
+		assert.NoError(t, Receive(<-queue))
+
+		msg := <-s.Receiver
+
+		assert.Equal(t, "as6sg6bxpogaaba8", msg.Fields["uid"])
+		assert.Empty(t, s.Receiver)
+	})
+	t.Run("Receive", func(t *testing.T) {
+		s := Subscribe("albums.created")
+		defer Unsubscribe(s)
+
+		assert.NoError(t, Receive([]byte(`{"origin":"other","name":"albums.created","fields":{"uid":"as6sg6bxpogaaba9"}}`)))
+
+		msg := <-s.Receiver
+
+		assert.Equal(t, "albums.created", msg.Name)
+		assert.Equal(t, "as6sg6bxpogaaba9", msg.Fields["uid"])
+
+		// Received events are not sent back.
+		assert.Empty(t, queue)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Error(t, Receive([]byte("foo")))
+		assert.NoError(t, Receive([]byte(`{"origin":"other","name":"log.error"}`)))
+	})
+}
@@ -0,0 +1,172 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TopicWildcard matches any topic segment.
+const TopicWildcard = "*"
+
+// Filter represents a field filter expression for topic subscriptions, e.g. "UID:as6sg6bxpogaaba7".
+type Filter map[string]string
+
+// ParseFilter parses a filter expression with space separated key:value pairs.
+func ParseFilter(s string) Filter {
+	result := make(Filter)
+
+	for _, pair := range strings.Fields(s) {
+		if k, v, found := strings.Cut(pair, ":"); !found || k == "" || v == "" {
+			continue
+		} else {
+			result[strings.ToLower(k)] = v
+		}
+	}
+
+	return result
+}
+
+// String returns the filter as expression string.
+func (f Filter) String() string {
+	s := make([]string, 0, len(f))
+
+	for k, v := range f {
+		s = append(s, k+":"+v)
+	}
+
+	return strings.Join(s, " ")
+}
+
+// Match checks if the message data matches the filter. A filter matches if all of its
+// values are found either in the message data or in one of the published entities.
+func (f Filter) Match(data Data) bool {
+	if len(f) == 0 {
+		return true
+	} else if len(data) == 0 {
+		return false
+	}
+
+	// Check message fields first.
+	if fields := lowerKeys(data); f.matchFields(fields) {
+		return true
+	}
+
+	// Check published entities, if any.
+	entities, ok := data["entities"]
+
+	if !ok || entities == nil {
+		return false
+	}
+
+	j, err := json.Marshal(entities)
+
+	if err != nil {
+		return false
+	}
+
+	var list []map[string]interface{}
+
+	if err = json.Unmarshal(j, &list); err != nil {
+		return false
+	}
+
+	for _, fields := range list {
+		if f.matchFields(lowerKeys(fields)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchFields checks if all filter values are found in the fields map with lowercase keys.
+func (f Filter) matchFields(fields map[string]interface{}) bool {
+	for k, v := range f {
+		if val, ok := fields[k]; !ok || val == nil {
+			return false
+		} else if !strings.EqualFold(fmt.Sprintf("%v", val), v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lowerKeys returns a copy of the map with lowercase keys.
+func lowerKeys(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		result[strings.ToLower(k)] = v
+	}
+
+	return result
+}
+
+// MatchTopic checks if the topic matches the pattern, which may contain wildcards.
+func MatchTopic(pattern, topic string) bool {
+	if pattern == "" || topic == "" {
+		return false
+	} else if pattern == topic || pattern == TopicWildcard {
+		return true
+	}
+
+	p := strings.Split(pattern, TopicSep)
+	t := strings.Split(topic, TopicSep)
+
+	if len(p) != len(t) {
+		return false
+	}
+
+	for i := range p {
+		if p[i] != TopicWildcard && p[i] != t[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Subscriptions maps topic patterns to optional filters.
+type Subscriptions map[string]Filter
+
+// NewSubscriptions creates a new, empty subscription list.
+func NewSubscriptions() Subscriptions {
+	return make(Subscriptions)
+}
+
+// Subscribe adds a topic subscription with an optional filter expression.
+func (s Subscriptions) Subscribe(topic, filter string) {
+	if topic = strings.TrimSpace(topic); topic == "" {
+		return
+	}
+
+	s[topic] = ParseFilter(filter)
+}
+
+// Unsubscribe removes a topic subscription.
+func (s Subscriptions) Unsubscribe(topic string) {
+	delete(s, strings.TrimSpace(topic))
+}
+
+// Empty checks if there are no subscriptions.
+func (s Subscriptions) Empty() bool {
+	return len(s) == 0
+}
+
+// Match checks if a message with the topic and data should be delivered.
+// If there are no explicit subscriptions, all messages are delivered.
+func (s Subscriptions) Match(topic string, data Data) bool {
+	if s.Empty() {
+		return true
+	}
+
+	for pattern, filter := range s {
+		if MatchTopic(pattern, topic) && filter.Match(data) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, Filter{}, ParseFilter(""))
+	})
+	t.Run("UID", func(t *testing.T) {
+		assert.Equal(t, Filter{"uid": "as6sg6bxpogaaba7"}, ParseFilter("UID:as6sg6bxpogaaba7"))
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		assert.Equal(t, Filter{"uid": "as6sg6bxpogaaba7", "type": "album"}, ParseFilter("UID:as6sg6bxpogaaba7  Type:album foo"))
+	})
+}
+
+func TestFilter_Match(t *testing.T) {
+	type entity struct {
+		UID   string
+		Title string
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.True(t, Filter{}.Match(Data{"id": 13}))
+	})
+	t.Run("NoData", func(t *testing.T) {
+		assert.False(t, ParseFilter("uid:123").Match(Data{}))
+	})
+	t.Run("Field", func(t *testing.T) {
+		assert.True(t, ParseFilter("uid:123").Match(Data{"uid": 123}))
+		assert.False(t, ParseFilter("uid:123").Match(Data{"uid": 456}))
+	})
+	t.Run("Entities", func(t *testing.T) {
+		data := Data{"entities": []entity{{UID: "as6sg6bxpogaaba7", Title: "Christmas"}, {UID: "as6sg6bxpogaaba8", Title: "Holiday"}}}
+		assert.True(t, ParseFilter("UID:as6sg6bxpogaaba8").Match(data))
+		assert.True(t, ParseFilter("uid:as6sg6bxpogaaba7 title:christmas").Match(data))
+		assert.False(t, ParseFilter("uid:as6sg6bxpogaaba7 title:holiday").Match(data))
+		assert.False(t, ParseFilter("uid:as6sg6bxpogaaba9").Match(data))
+	})
+}
+
+func TestMatchTopic(t *testing.T) {
+	assert.True(t, MatchTopic("photos.updated", "photos.updated"))
+	assert.True(t, MatchTopic("photos.*", "photos.updated"))
+	assert.True(t, MatchTopic("*", "photos.updated"))
+	assert.False(t, MatchTopic("albums.*", "photos.updated"))
+	assert.False(t, MatchTopic("photos.*", "photos.updated.now"))
+	assert.False(t, MatchTopic("", "photos.updated"))
+}
+
+func TestSubscriptions_Match(t *testing.T) {
+	s := NewSubscriptions()
+
+	assert.True(t, s.Empty())
+	assert.True(t, s.Match("photos.updated", Data{}))
+
+	s.Subscribe("albums.updated", "UID:as6sg6bxpogaaba7")
+	s.Subscribe("notify.*", "")
+
+	assert.False(t, s.Empty())
+	assert.True(t, s.Match("notify.info", Data{"message": "foo"}))
+	assert.True(t, s.Match("albums.updated", Data{"entities": []Data{{"UID": "as6sg6bxpogaaba7"}}}))
+	assert.False(t, s.Match("albums.updated", Data{"entities": []Data{{"UID": "as6sg6bxpogaaba8"}}}))
+	assert.False(t, s.Match("photos.updated", Data{}))
+
+	s.Unsubscribe("notify.*")
+
+	assert.False(t, s.Match("notify.info", Data{"message": "foo"}))
+}
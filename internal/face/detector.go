@@ -60,33 +60,54 @@ var (
 
 // Detector struct contains Pigo face detector general settings.
 type Detector struct {
+	classifier   *pigo.Pigo
 	minSize      int
 	angle        float64
 	shiftFactor  float64
 	scaleFactor  float64
 	iouThreshold float64
 	perturb      int
+	thresholds   Thresholds
 }
 
 // Detect runs the detection algorithm over the provided source image.
 func Detect(fileName string, findLandmarks bool, minSize int) (faces Faces, err error) {
+	t := DefaultThresholds()
+	t.Size = minSize
+
+	return detect(classifier, fileName, findLandmarks, t)
+}
+
+// DetectWithThresholds runs the detection algorithm with custom thresholds, e.g. to preview their effect.
+func DetectWithThresholds(fileName string, t Thresholds) (faces Faces, err error) {
+	return detect(classifier, fileName, false, t)
+}
+
+// detect runs the detection algorithm with the specified cascade classifier and thresholds.
+func detect(c *pigo.Pigo, fileName string, findLandmarks bool, t Thresholds) (faces Faces, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			log.Errorf("faces: %s (panic)\nstack: %s", r, debug.Stack())
 		}
 	}()
 
-	if minSize < 20 {
-		minSize = 20
+	if t.Size < 20 {
+		t.Size = 20
+	}
+
+	if c == nil {
+		return faces, fmt.Errorf("faces: classifier not initialized")
 	}
 
 	d := &Detector{
-		minSize:      minSize,
+		classifier:   c,
+		minSize:      t.Size,
 		angle:        0.0,
 		shiftFactor:  0.1,
 		scaleFactor:  1.1,
-		iouThreshold: float64(OverlapThresholdFloor) / 100,
+		iouThreshold: float64(t.Overlap-1) / 100,
 		perturb:      63,
+		thresholds:   t,
 	}
 
 	if !fs.FileExists(fileName) {
@@ -166,10 +187,10 @@ func (d *Detector) Detect(fileName string) (faces []pigo.Detection, params pigo.
 
 	// Run the classifier over the obtained leaf nodes and return the Face results.
 	// The result contains quadruplets representing the row, column, scale and Face score.
-	faces = classifier.RunCascade(params, d.angle)
+	faces = d.classifier.RunCascade(params, d.angle)
 
 	// Calculate the intersection over union (IoU) of two clusters.
-	faces = classifier.ClusterDetections(faces, d.iouThreshold)
+	faces = d.classifier.ClusterDetections(faces, d.iouThreshold)
 
 	return faces, params, nil
 }
@@ -183,7 +204,7 @@ func (d *Detector) Faces(det []pigo.Detection, params pigo.CascadeParams, findLa
 
 	for _, face := range det {
 		// Skip result if quality is too low.
-		if face.Q < QualityThreshold(face.Scale) {
+		if face.Q < d.thresholds.Quality(face.Scale) {
 			continue
 		}
 
@@ -0,0 +1,70 @@
+package face
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	pigo "github.com/esimov/pigo/core"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+var (
+	petClassifier *pigo.Pigo
+	petCascade    string
+	petMutex      = sync.RWMutex{}
+)
+
+// LoadPetCascade loads a Pigo cascade file for detecting animal faces, e.g. of cats and dogs.
+func LoadPetCascade(fileName string) error {
+	petMutex.Lock()
+	defer petMutex.Unlock()
+
+	// Already loaded?
+	if petClassifier != nil && petCascade == fileName {
+		return nil
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	c, err := pigo.NewPigo().Unpack(data)
+
+	if err != nil {
+		return fmt.Errorf("faces: %s in %s", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	petClassifier = c
+	petCascade = fileName
+
+	return nil
+}
+
+// PetDetection tests if a cascade for detecting animal faces has been loaded.
+func PetDetection() bool {
+	petMutex.RLock()
+	defer petMutex.RUnlock()
+
+	return petClassifier != nil
+}
+
+// DetectPets runs the animal face detection algorithm over the provided source image.
+func DetectPets(fileName string, minSize int) (faces Faces, err error) {
+	petMutex.RLock()
+	c := petClassifier
+	petMutex.RUnlock()
+
+	if c == nil {
+		return faces, fmt.Errorf("faces: pet detection is disabled")
+	}
+
+	t := DefaultThresholds()
+	t.Size = minSize
+
+	return detect(c, fileName, false, t)
+}
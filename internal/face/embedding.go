@@ -59,7 +59,11 @@ func (m Embedding) Dist(other Embedding) float64 {
 
 // Magnitude returns the face embedding vector length (magnitude).
 func (m Embedding) Magnitude() float64 {
-	return m.Dist(NullEmbedding)
+	if len(m) == 0 {
+		return -1
+	}
+
+	return clusters.EuclideanDist(m, make(Embedding, len(m)))
 }
 
 // JSON returns the face embedding as JSON bytes.
@@ -87,7 +87,8 @@ func (embeddings Embeddings) Float64() [][]float64 {
 // Contains tests if another embeddings is contained within a radius.
 func (embeddings Embeddings) Contains(other Embedding, radius float64) bool {
 	for _, e := range embeddings {
-		if d := e.Dist(other); d < radius {
+		// Negative distances indicate embeddings of a different model.
+		if d := e.Dist(other); d >= 0 && d < radius {
 			return true
 		}
 	}
@@ -100,7 +101,7 @@ func (embeddings Embeddings) Dist(other Embedding) (dist float64) {
 	dist = -1
 
 	for _, e := range embeddings {
-		if d := e.Dist(other); d < dist || dist < 0 {
+		if d := e.Dist(other); d >= 0 && (d < dist || dist < 0) {
 			dist = d
 		}
 	}
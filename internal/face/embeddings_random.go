@@ -12,6 +12,7 @@ const (
 	KidsFace
 	IgnoredFace
 	AmbiguousFace
+	PetFace
 )
 
 var r = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -0,0 +1,69 @@
+package face
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/crop"
+)
+
+// Model describes a TensorFlow model for computing face embeddings.
+type Model struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Dimensions int       `json:"dimensions"`
+	Size       crop.Size `json:"-"`
+	Input      string    `json:"-"`
+	Phase      string    `json:"-"`
+	Output     string    `json:"-"`
+	Tags       []string  `json:"-"`
+}
+
+// FaceNet is the default face embedding model.
+var FaceNet = Model{
+	Name:       "facenet",
+	Path:       "facenet",
+	Dimensions: 512,
+	Size:       CropSize,
+	Input:      "input",
+	Phase:      "phase_train",
+	Output:     "embeddings",
+	Tags:       []string{"serve"},
+}
+
+// ActiveModel is the model used for computing new face embeddings.
+var ActiveModel = FaceNet
+
+var modelsMutex = sync.RWMutex{}
+
+// Models contains the supported face embedding models by name.
+var Models = map[string]Model{
+	FaceNet.Name: FaceNet,
+}
+
+// RegisterModel adds a face embedding model so it can be selected in the config.
+func RegisterModel(m Model) {
+	if m.Name == "" || m.Dimensions <= 0 {
+		return
+	}
+
+	modelsMutex.Lock()
+	defer modelsMutex.Unlock()
+
+	Models[strings.ToLower(m.Name)] = m
+}
+
+// FindModel returns the face embedding model with the specified name, if registered.
+func FindModel(name string) (m Model, ok bool) {
+	modelsMutex.RLock()
+	defer modelsMutex.RUnlock()
+
+	m, ok = Models[strings.ToLower(strings.TrimSpace(name))]
+
+	return m, ok
+}
+
+// Valid tests if the embedding has the number of dimensions expected by the model.
+func (m Model) Valid(e Embedding) bool {
+	return len(e) > 0 && len(e) == m.Dimensions
+}
@@ -0,0 +1,54 @@
+package face
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindModel(t *testing.T) {
+	t.Run("FaceNet", func(t *testing.T) {
+		m, ok := FindModel(" FaceNet ")
+		assert.True(t, ok)
+		assert.Equal(t, "facenet", m.Name)
+		assert.Equal(t, 512, m.Dimensions)
+		assert.Equal(t, 160, m.Size.Width)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, ok := FindModel("unknown")
+		assert.False(t, ok)
+	})
+}
+
+func TestRegisterModel(t *testing.T) {
+	RegisterModel(Model{Name: "Test128", Path: "test128", Dimensions: 128, Size: CropSize})
+	defer func() {
+		modelsMutex.Lock()
+		delete(Models, "test128")
+		modelsMutex.Unlock()
+	}()
+
+	m, ok := FindModel("test128")
+	assert.True(t, ok)
+	assert.Equal(t, 128, m.Dimensions)
+
+	RegisterModel(Model{Name: "invalid"})
+	_, ok = FindModel("invalid")
+	assert.False(t, ok)
+}
+
+func TestModel_Valid(t *testing.T) {
+	assert.True(t, FaceNet.Valid(make(Embedding, 512)))
+	assert.False(t, FaceNet.Valid(make(Embedding, 128)))
+	assert.False(t, FaceNet.Valid(Embedding{}))
+}
+
+func TestEmbeddings_OtherModel(t *testing.T) {
+	other := make(Embedding, 128)
+
+	assert.False(t, KidsEmbeddings.Contains(other, 100))
+	assert.False(t, IgnoredEmbeddings.Contains(other, 100))
+	assert.Equal(t, -1.0, IgnoredEmbeddings.Dist(other))
+	assert.Equal(t, RegularFace, other.Kind())
+	assert.Equal(t, 0.0, other.Magnitude())
+}
@@ -14,20 +14,29 @@ import (
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
-// Net is a wrapper for the TensorFlow Facenet model.
+// Net is a wrapper for the TensorFlow face embedding model, e.g. FaceNet.
 type Net struct {
 	model     *tf.SavedModel
 	modelPath string
 	cachePath string
 	disabled  bool
-	modelName string
-	modelTags []string
+	info      Model
 	mutex     sync.Mutex
 }
 
-// NewNet returns a new TensorFlow Facenet instance.
+// NewNet returns a new TensorFlow instance for the active face embedding model.
 func NewNet(modelPath, cachePath string, disabled bool) *Net {
-	return &Net{modelPath: modelPath, cachePath: cachePath, disabled: disabled, modelTags: []string{"serve"}}
+	return NewModelNet(ActiveModel, modelPath, cachePath, disabled)
+}
+
+// NewModelNet returns a new TensorFlow instance for the specified face embedding model.
+func NewModelNet(info Model, modelPath, cachePath string, disabled bool) *Net {
+	return &Net{modelPath: modelPath, cachePath: cachePath, disabled: disabled, info: info}
+}
+
+// Model returns the face embedding model.
+func (t *Net) Model() Model {
+	return t.info
 }
 
 // Detect runs the detection and facenet algorithms over the provided source image.
@@ -38,6 +47,22 @@ func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int)
 		return faces, err
 	}
 
+	return t.addEmbeddings(fileName, faces, cacheCrop, expected)
+}
+
+// DetectPets runs the animal face detection and embedding algorithms over the provided source image.
+func (t *Net) DetectPets(fileName string, minSize int, cacheCrop bool, expected int) (faces Faces, err error) {
+	faces, err = DetectPets(fileName, minSize)
+
+	if err != nil {
+		return faces, err
+	}
+
+	return t.addEmbeddings(fileName, faces, cacheCrop, expected)
+}
+
+// addEmbeddings computes the embeddings of detected faces, unless the expected number of faces was found.
+func (t *Net) addEmbeddings(fileName string, faces Faces, cacheCrop bool, expected int) (Faces, error) {
 	// Skip FaceNet?
 	if t.disabled {
 		return faces, nil
@@ -45,9 +70,7 @@ func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int)
 		return faces, nil
 	}
 
-	err = t.loadModel()
-
-	if err != nil {
+	if err := t.loadModel(); err != nil {
 		return faces, err
 	}
 
@@ -56,7 +79,7 @@ func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int)
 			continue
 		}
 
-		if img, err := crop.ImageFromThumb(fileName, f.CropArea(), CropSize, cacheCrop); err != nil {
+		if img, err := crop.ImageFromThumb(fileName, f.CropArea(), t.info.Size, cacheCrop); err != nil {
 			log.Errorf("faces: failed to decode image: %s", err)
 		} else if embeddings := t.getEmbeddings(img); !embeddings.Empty() {
 			faces[i].Embeddings = embeddings
@@ -66,6 +89,39 @@ func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int)
 	return faces, nil
 }
 
+// Embeddings computes the face embeddings for the specified area of a thumbnail image,
+// e.g. to update existing face markers after the model has been changed.
+func (t *Net) Embeddings(fileName string, area crop.Area, cacheCrop bool) (Embeddings, error) {
+	if t.disabled {
+		return Embeddings{}, fmt.Errorf("face recognition is disabled")
+	} else if area.Empty() {
+		return Embeddings{}, fmt.Errorf("face area is empty")
+	} else if err := t.loadModel(); err != nil {
+		return Embeddings{}, err
+	}
+
+	img, err := crop.ImageFromThumb(fileName, area, t.info.Size, cacheCrop)
+
+	if err != nil {
+		return Embeddings{}, err
+	}
+
+	if embeddings := t.getEmbeddings(img); !embeddings.Empty() {
+		return embeddings, nil
+	}
+
+	return Embeddings{}, fmt.Errorf("no embeddings found")
+}
+
+// Init loads the TensorFlow model, unless face recognition is disabled.
+func (t *Net) Init() error {
+	if t.disabled {
+		return nil
+	}
+
+	return t.loadModel()
+}
+
 // ModelLoaded tests if the TensorFlow model is loaded.
 func (t *Net) ModelLoaded() bool {
 	return t.model != nil
@@ -85,7 +141,7 @@ func (t *Net) loadModel() error {
 	log.Infof("faces: loading %s", clean.Log(filepath.Base(modelPath)))
 
 	// Load model
-	model, err := tf.LoadSavedModel(modelPath, t.modelTags, nil)
+	model, err := tf.LoadSavedModel(modelPath, t.info.Tags, nil)
 
 	if err != nil {
 		return err
@@ -98,7 +154,7 @@ func (t *Net) loadModel() error {
 
 // getEmbeddings returns the face embeddings for an image.
 func (t *Net) getEmbeddings(img image.Image) Embeddings {
-	tensor, err := imageToTensor(img, CropSize.Width, CropSize.Height)
+	tensor, err := imageToTensor(img, t.info.Size.Width, t.info.Size.Height)
 
 	if err != nil {
 		log.Errorf("faces: failed to convert image to tensor: %s", err)
@@ -106,15 +162,21 @@ func (t *Net) getEmbeddings(img image.Image) Embeddings {
 
 	// TODO: pre-whiten image as in facenet
 
-	trainPhaseBoolTensor, err := tf.NewTensor(false)
+	inputs := map[tf.Output]*tf.Tensor{
+		t.model.Graph.Operation(t.info.Input).Output(0): tensor,
+	}
+
+	// Some models, e.g. FaceNet, require the training phase flag to be set.
+	if t.info.Phase != "" {
+		if trainPhaseBoolTensor, err := tf.NewTensor(false); err == nil {
+			inputs[t.model.Graph.Operation(t.info.Phase).Output(0)] = trainPhaseBoolTensor
+		}
+	}
 
 	output, err := t.model.Session.Run(
-		map[tf.Output]*tf.Tensor{
-			t.model.Graph.Operation("input").Output(0):       tensor,
-			t.model.Graph.Operation("phase_train").Output(0): trainPhaseBoolTensor,
-		},
+		inputs,
 		[]tf.Output{
-			t.model.Graph.Operation("embeddings").Output(0),
+			t.model.Graph.Operation(t.info.Output).Output(0),
 		},
 		nil)
 
@@ -15,10 +15,34 @@ var ClusterDist = 0.64                           // Similarity distance threshol
 var MatchDist = 0.46                             // Dist offset threshold for matching new faces with clusters.
 var ClusterCore = 4                              // Min number of faces forming a cluster core.
 var SampleThreshold = 2 * ClusterCore            // Threshold for automatic clustering to start.
+var KeyframesDefault = 5                         // Default number of still images sampled from videos.
+var AgeBucket = 10                               // Number of years covered by age-progression face clusters, 0 to disable.
+var AgeTolerance = 0.05                          // Extra match distance for age-progression face clusters.
+
+// Thresholds represents the face detection thresholds.
+type Thresholds struct {
+	Size    int     // Min face size in pixels.
+	Score   float64 // Min face score.
+	Overlap int     // Face area overlap threshold in percent.
+}
+
+// DefaultThresholds returns the currently configured face detection thresholds.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Size:    SizeThreshold,
+		Score:   ScoreThreshold,
+		Overlap: OverlapThreshold,
+	}
+}
 
 // QualityThreshold returns the scale adjusted quality score threshold.
 func QualityThreshold(scale int) (score float32) {
-	score = float32(ScoreThreshold)
+	return DefaultThresholds().Quality(scale)
+}
+
+// Quality returns the scale adjusted quality score threshold.
+func (t Thresholds) Quality(scale int) (score float32) {
+	score = float32(t.Score)
 
 	// Smaller faces require higher quality.
 	switch {
@@ -29,3 +29,14 @@ func TestQualityThreshold(t *testing.T) {
 		assert.Equal(t, float32(9), QualityThreshold(250))
 	})
 }
+
+func TestThresholds_Quality(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, QualityThreshold(45), DefaultThresholds().Quality(45))
+	})
+	t.Run("Custom", func(t *testing.T) {
+		th := Thresholds{Size: 30, Score: 20, Overlap: 50}
+		assert.Equal(t, float32(29), th.Quality(45))
+		assert.Equal(t, float32(20), th.Quality(250))
+	})
+}
@@ -0,0 +1,123 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// AtomNamespace is the Atom XML namespace.
+const AtomNamespace = "http://www.w3.org/2005/Atom"
+
+// AtomLink represents an Atom link element.
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// AtomText represents an Atom text construct.
+type AtomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+// AtomAuthor represents an Atom author element.
+type AtomAuthor struct {
+	Name string `xml:"name"`
+	Uri  string `xml:"uri,omitempty"`
+}
+
+// AtomEntry represents an Atom entry element.
+type AtomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published,omitempty"`
+	Links     []AtomLink `xml:"link"`
+	Summary   *AtomText  `xml:"summary,omitempty"`
+	Content   *AtomText  `xml:"content,omitempty"`
+}
+
+// Atom represents an Atom feed, see RFC 4287.
+type Atom struct {
+	XMLName  xml.Name     `xml:"feed"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Links    []AtomLink   `xml:"link"`
+	Authors  []AtomAuthor `xml:"author"`
+	Icon     string       `xml:"icon,omitempty"`
+	Entries  []AtomEntry  `xml:"entry"`
+}
+
+// Atom returns the feed as Atom document.
+func (f *Feed) Atom() *Atom {
+	result := &Atom{
+		Xmlns:    AtomNamespace,
+		ID:       f.FeedUrl,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.Updated().Format(time.RFC3339),
+		Icon:     f.Icon,
+		Entries:  make([]AtomEntry, 0, len(f.Items)),
+	}
+
+	if f.FeedUrl != "" {
+		result.Links = append(result.Links, AtomLink{Href: f.FeedUrl, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	if f.HomePageUrl != "" {
+		result.Links = append(result.Links, AtomLink{Href: f.HomePageUrl, Rel: "alternate", Type: "text/html"})
+	}
+
+	// Each Atom feed must have an author.
+	for _, a := range f.Authors {
+		result.Authors = append(result.Authors, AtomAuthor{Name: a.Name, Uri: a.Url})
+	}
+
+	if len(result.Authors) == 0 {
+		result.Authors = []AtomAuthor{{Name: f.Title}}
+	}
+
+	for _, item := range f.Items {
+		entry := AtomEntry{
+			ID:        item.ID,
+			Title:     item.Title,
+			Updated:   item.DateModified.UTC().Format(time.RFC3339),
+			Published: item.DatePublished.UTC().Format(time.RFC3339),
+		}
+
+		if item.Url != "" {
+			entry.Links = append(entry.Links, AtomLink{Href: item.Url, Rel: "alternate", Type: "text/html"})
+		}
+
+		if item.Image != "" {
+			entry.Links = append(entry.Links, AtomLink{Href: item.Image, Rel: "enclosure", Type: "image/jpeg"})
+		}
+
+		if item.Summary != "" {
+			entry.Summary = &AtomText{Type: "text", Body: item.Summary}
+		}
+
+		if item.ContentHtml != "" {
+			entry.Content = &AtomText{Type: "html", Body: item.ContentHtml}
+		}
+
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return result
+}
+
+// AtomXML returns the feed as Atom XML document.
+func (f *Feed) AtomXML() ([]byte, error) {
+	data, err := xml.MarshalIndent(f.Atom(), "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
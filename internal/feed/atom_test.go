@@ -0,0 +1,37 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeed_AtomXML(t *testing.T) {
+	f := New("Holiday & Friends", "https://photos.example.com/s/abc/holiday", "https://photos.example.com/s/abc/holiday/feed.atom")
+
+	f.Add(Item{
+		ID:            "https://photos.example.com/s/abc/holiday#pt9jtdre2lvl0yh7",
+		Url:           "https://photos.example.com/s/abc/holiday",
+		Title:         "Beach",
+		ContentHtml:   `<img src="https://photos.example.com/s/abc/holiday/t/123/fit_1280" alt="Beach">`,
+		Image:         "https://photos.example.com/s/abc/holiday/t/123/fit_1280",
+		DatePublished: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+	})
+
+	data, err := f.AtomXML()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := string(data)
+
+	assert.Contains(t, result, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, result, `<title>Holiday &amp; Friends</title>`)
+	assert.Contains(t, result, `<updated>2023-05-01T10:00:00Z</updated>`)
+	assert.Contains(t, result, `<link href="https://photos.example.com/s/abc/holiday/feed.atom" rel="self" type="application/atom+xml"></link>`)
+	assert.Contains(t, result, `<name>Holiday &amp; Friends</name>`)
+	assert.Contains(t, result, `rel="enclosure" type="image/jpeg"`)
+	assert.Contains(t, result, `<content type="html">&lt;img src=`)
+}
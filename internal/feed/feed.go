@@ -0,0 +1,97 @@
+/*
+Package feed provides JSON Feed, Atom, and oEmbed documents so that shared albums can be embedded in other websites.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package feed
+
+import (
+	"time"
+)
+
+// Version is the JSON Feed version URL.
+const Version = "https://jsonfeed.org/version/1.1"
+
+// Author represents a feed author.
+type Author struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+}
+
+// Item represents a feed item, e.g. a shared picture.
+type Item struct {
+	ID            string    `json:"id"`
+	Url           string    `json:"url,omitempty"`
+	Title         string    `json:"title,omitempty"`
+	ContentHtml   string    `json:"content_html,omitempty"`
+	Summary       string    `json:"summary,omitempty"`
+	Image         string    `json:"image,omitempty"`
+	DatePublished time.Time `json:"date_published"`
+	DateModified  time.Time `json:"date_modified"`
+}
+
+// Feed represents a JSON Feed, see https://www.jsonfeed.org/version/1.1/.
+type Feed struct {
+	Version     string   `json:"version"`
+	Title       string   `json:"title"`
+	HomePageUrl string   `json:"home_page_url,omitempty"`
+	FeedUrl     string   `json:"feed_url,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Authors     []Author `json:"authors,omitempty"`
+	Items       []Item   `json:"items"`
+}
+
+// New returns a new feed without items.
+func New(title, homePageUrl, feedUrl string) *Feed {
+	return &Feed{
+		Version:     Version,
+		Title:       title,
+		HomePageUrl: homePageUrl,
+		FeedUrl:     feedUrl,
+		Items:       []Item{},
+	}
+}
+
+// Add adds an item to the feed.
+func (f *Feed) Add(item Item) {
+	if item.DateModified.IsZero() {
+		item.DateModified = item.DatePublished
+	}
+
+	f.Items = append(f.Items, item)
+}
+
+// Updated returns the time of the most recent change of the feed items.
+func (f *Feed) Updated() (updated time.Time) {
+	for _, item := range f.Items {
+		if item.DateModified.After(updated) {
+			updated = item.DateModified
+		}
+	}
+
+	if updated.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+
+	return updated.UTC()
+}
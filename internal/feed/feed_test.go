@@ -0,0 +1,30 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	f := New("Holiday", "https://photos.example.com/s/abc/holiday", "https://photos.example.com/s/abc/holiday/feed.json")
+
+	assert.Equal(t, Version, f.Version)
+	assert.Equal(t, "Holiday", f.Title)
+	assert.NotNil(t, f.Items)
+	assert.Equal(t, time.Unix(0, 0).UTC(), f.Updated())
+}
+
+func TestFeed_Add(t *testing.T) {
+	f := New("Holiday", "", "")
+	published := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	modified := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	f.Add(Item{ID: "1", DatePublished: published})
+	f.Add(Item{ID: "2", DatePublished: published, DateModified: modified})
+
+	assert.Len(t, f.Items, 2)
+	assert.Equal(t, published, f.Items[0].DateModified)
+	assert.Equal(t, modified, f.Updated())
+}
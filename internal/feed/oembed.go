@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"fmt"
+	"html"
+)
+
+// OEmbedVersion is the oEmbed specification version, see https://oembed.com/.
+const OEmbedVersion = "1.0"
+
+// Default size of embedded galleries in pixels.
+const (
+	EmbedWidth  = 800
+	EmbedHeight = 600
+)
+
+// OEmbed represents an oEmbed response.
+type OEmbed struct {
+	Type            string `json:"type"`
+	Version         string `json:"version"`
+	Title           string `json:"title,omitempty"`
+	AuthorName      string `json:"author_name,omitempty"`
+	AuthorUrl       string `json:"author_url,omitempty"`
+	ProviderName    string `json:"provider_name,omitempty"`
+	ProviderUrl     string `json:"provider_url,omitempty"`
+	CacheAge        int    `json:"cache_age,omitempty"`
+	ThumbnailUrl    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+	Html            string `json:"html"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+}
+
+// EmbedSize returns the largest default embed size that fits the maximum width and height (0 for no limit).
+func EmbedSize(maxWidth, maxHeight int) (width, height int) {
+	width, height = EmbedWidth, EmbedHeight
+
+	if maxWidth > 0 && width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	}
+
+	if maxHeight > 0 && height > maxHeight {
+		width = width * maxHeight / height
+		height = maxHeight
+	}
+
+	return width, height
+}
+
+// NewOEmbed returns a rich oEmbed response that embeds the page in an iframe.
+func NewOEmbed(title, embedUrl string, width, height int) *OEmbed {
+	return &OEmbed{
+		Type:    "rich",
+		Version: OEmbedVersion,
+		Title:   title,
+		Html: fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" title="%s" style="border:0" loading="lazy" allowfullscreen></iframe>`,
+			html.EscapeString(embedUrl), width, height, html.EscapeString(title)),
+		Width:  width,
+		Height: height,
+	}
+}
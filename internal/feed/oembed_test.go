@@ -0,0 +1,36 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedSize(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		w, h := EmbedSize(0, 0)
+		assert.Equal(t, EmbedWidth, w)
+		assert.Equal(t, EmbedHeight, h)
+	})
+	t.Run("MaxWidth", func(t *testing.T) {
+		w, h := EmbedSize(400, 0)
+		assert.Equal(t, 400, w)
+		assert.Equal(t, 300, h)
+	})
+	t.Run("MaxHeight", func(t *testing.T) {
+		w, h := EmbedSize(1000, 150)
+		assert.Equal(t, 200, w)
+		assert.Equal(t, 150, h)
+	})
+}
+
+func TestNewOEmbed(t *testing.T) {
+	result := NewOEmbed(`Holiday "2023"`, "https://photos.example.com/s/abc/holiday/embed?a=1&b=2", 400, 300)
+
+	assert.Equal(t, "rich", result.Type)
+	assert.Equal(t, OEmbedVersion, result.Version)
+	assert.Equal(t, 400, result.Width)
+	assert.Equal(t, 300, result.Height)
+	assert.Contains(t, result.Html, `src="https://photos.example.com/s/abc/holiday/embed?a=1&amp;b=2"`)
+	assert.Contains(t, result.Html, `title="Holiday &#34;2023&#34;"`)
+}
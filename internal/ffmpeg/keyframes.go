@@ -0,0 +1,50 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"time"
+)
+
+// KeyframeOffsets returns evenly spaced time offsets for sampling the specified number of still images
+// from a video, skipping the very beginning and end in case there is an intro or fade effect.
+func KeyframeOffsets(d time.Duration, n int) (result []time.Duration) {
+	if d <= 0 || n <= 0 {
+		return result
+	}
+
+	// Sample a single frame from very short videos.
+	if d < time.Second {
+		return []time.Duration{0}
+	}
+
+	margin := d / 20
+	step := (d - 2*margin) / time.Duration(n)
+
+	result = make([]time.Duration, 0, n)
+
+	for i := 0; i < n; i++ {
+		offset := (margin + step/2 + step*time.Duration(i)).Truncate(time.Millisecond)
+
+		if len(result) > 0 && offset <= result[len(result)-1] {
+			continue
+		}
+
+		result = append(result, offset)
+	}
+
+	return result
+}
+
+// TimeOffset formats a duration as time offset string, e.g. for use with the -ss parameter.
+func TimeOffset(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+	ms := (d % time.Second) / time.Millisecond
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, ms)
+}
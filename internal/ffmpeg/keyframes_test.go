@@ -0,0 +1,40 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyframeOffsets(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, KeyframeOffsets(0, 5))
+		assert.Empty(t, KeyframeOffsets(time.Minute, 0))
+	})
+	t.Run("Short", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{0}, KeyframeOffsets(500*time.Millisecond, 5))
+	})
+	t.Run("Minute", func(t *testing.T) {
+		result := KeyframeOffsets(time.Minute, 3)
+
+		assert.Equal(t, []time.Duration{12 * time.Second, 30 * time.Second, 48 * time.Second}, result)
+	})
+	t.Run("Ordered", func(t *testing.T) {
+		result := KeyframeOffsets(2*time.Second, 50)
+
+		assert.NotEmpty(t, result)
+
+		for i := 1; i < len(result); i++ {
+			assert.Greater(t, result[i], result[i-1])
+			assert.Less(t, result[i], 2*time.Second)
+		}
+	})
+}
+
+func TestTimeOffset(t *testing.T) {
+	assert.Equal(t, "00:00:00.000", TimeOffset(0))
+	assert.Equal(t, "00:00:00.000", TimeOffset(-time.Second))
+	assert.Equal(t, "00:00:03.250", TimeOffset(3250*time.Millisecond))
+	assert.Equal(t, "01:02:03.004", TimeOffset(time.Hour+2*time.Minute+3*time.Second+4*time.Millisecond))
+}
@@ -0,0 +1,8 @@
+package form
+
+// AccessToken represents an access token request form.
+type AccessToken struct {
+	ClientName string `json:"name"`
+	Scope      string `json:"scope"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// AlbumCaption represents a form for changing the album-specific caption of a picture.
+type AlbumCaption struct {
+	Caption string `json:"Caption"`
+}
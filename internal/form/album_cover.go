@@ -0,0 +1,11 @@
+package form
+
+// AlbumCover represents a form for setting a picture as album cover, optionally cropped to the area
+// specified by its relative position and size.
+type AlbumCover struct {
+	Photo string  `json:"Photo"`
+	X     float32 `json:"X"`
+	Y     float32 `json:"Y"`
+	W     float32 `json:"W"`
+	H     float32 `json:"H"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// AlbumLock represents a form for locking and unlocking albums with a PIN or password.
+type AlbumLock struct {
+	PIN string `json:"PIN"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// AlbumOrder represents a form for changing the custom order of pictures in an album.
+type AlbumOrder struct {
+	Photos []string `json:"photos"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// AlbumParent represents a form for moving an album into another album.
+type AlbumParent struct {
+	ParentUID string `json:"ParentUID"`
+}
@@ -0,0 +1,10 @@
+package form
+
+// AlbumTemplate represents a form for creating an album from a built-in or custom template.
+type AlbumTemplate struct {
+	Name   string `json:"Name"`
+	Title  string `json:"Title"`
+	Filter string `json:"Filter"`
+	Order  string `json:"Order"`
+	Date   string `json:"Date"`
+}
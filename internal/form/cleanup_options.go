@@ -0,0 +1,5 @@
+package form
+
+type CleanUpOptions struct {
+	Dry bool `json:"dry"`
+}
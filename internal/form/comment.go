@@ -0,0 +1,19 @@
+package form
+
+// Comment represents a form for commenting on a shared album or a picture in it.
+type Comment struct {
+	PhotoUID string `json:"PhotoUID"`
+	Text     string `json:"Text"`
+	Name     string `json:"Name"`
+}
+
+// CommentModerate represents a form for hiding or showing a comment.
+type CommentModerate struct {
+	Hidden bool `json:"Hidden"`
+}
+
+// Reaction represents a form for reacting to a shared album or a picture in it.
+type Reaction struct {
+	PhotoUID string `json:"PhotoUID"`
+	Reaction string `json:"Reaction"`
+}
@@ -0,0 +1,9 @@
+package form
+
+// FaceSplit represents a form for moving selected markers from a face cluster to a new cluster,
+// optionally assigned to an existing subject or a new one with the specified name.
+type FaceSplit struct {
+	Markers []string `json:"Markers"`
+	SubjUID string   `json:"SubjUID"`
+	Name    string   `json:"Name"`
+}
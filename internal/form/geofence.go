@@ -0,0 +1,21 @@
+package form
+
+import "github.com/ulule/deepcopier"
+
+// Geofence represents a geofence edit form.
+type Geofence struct {
+	FenceName    string  `json:"Name"`
+	FenceLat     float64 `json:"Lat"`
+	FenceLng     float64 `json:"Lng"`
+	FenceRadius  int     `json:"Radius"`
+	FencePolygon string  `json:"Polygon"`
+	FenceLabel   string  `json:"Label"`
+	FenceAlbum   string  `json:"Album"`
+}
+
+// NewGeofence creates a new geofence form with values from the model.
+func NewGeofence(m interface{}) (f Geofence, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
@@ -0,0 +1,14 @@
+package form
+
+// Group represents a user group form.
+type Group struct {
+	GroupName   string `json:"Name"`
+	GroupTitle  string `json:"Title"`
+	GroupRole   string `json:"Role"`
+	Description string `json:"Description"`
+}
+
+// GroupMembers represents a list of users to add to or remove from a group.
+type GroupMembers struct {
+	Users []string `json:"users"`
+}
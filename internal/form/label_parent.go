@@ -0,0 +1,6 @@
+package form
+
+// LabelParent represents a form for changing the parent of a label, e.g. Dog for Beagle.
+type LabelParent struct {
+	Parent string `json:"Parent"`
+}
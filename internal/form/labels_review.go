@@ -0,0 +1,13 @@
+package form
+
+// LabelsReviewItem references an automatically assigned photo label.
+type LabelsReviewItem struct {
+	PhotoUID string `json:"PhotoUID"`
+	LabelUID string `json:"LabelUID"`
+}
+
+// LabelsReview represents photo labels to be confirmed or rejected.
+type LabelsReview struct {
+	Confirm []LabelsReviewItem `json:"Confirm"`
+	Reject  []LabelsReviewItem `json:"Reject"`
+}
@@ -1,12 +1,24 @@
 package form
 
+import "time"
+
 // Link represents a link sharing form.
 type Link struct {
-	Password    string `json:"Password"`
-	ShareSlug   string `json:"Slug"`
-	LinkToken   string `json:"Token"`
-	LinkExpires int    `json:"Expires"`
-	MaxViews    uint   `json:"MaxViews"`
-	CanComment  bool   `json:"CanComment"`
-	CanEdit     bool   `json:"CanEdit"`
+	ShareUID     string     `json:"ShareUID"`
+	Password     string     `json:"Password"`
+	ShareSlug    string     `json:"Slug"`
+	LinkToken    string     `json:"Token"`
+	LinkExpires  int        `json:"Expires"`
+	ExpiresAt    *time.Time `json:"ExpiresAt"`
+	MaxViews     uint       `json:"MaxViews"`
+	NoDownload   bool       `json:"NoDownload"`
+	Watermark    bool       `json:"Watermark"`
+	CanComment   bool       `json:"CanComment"`
+	CanReact     bool       `json:"CanReact"`
+	CanEdit      bool       `json:"CanEdit"`
+	CanUpload    bool       `json:"CanUpload"`
+	UploadReview bool       `json:"UploadReview"`
+	UploadLimit  int        `json:"UploadLimit"`
+	UploadTypes  string     `json:"UploadTypes"`
+	Comment      string     `json:"Comment"`
 }
@@ -0,0 +1,26 @@
+package form
+
+import (
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// LinkEmailLimit is the maximum number of recipients a share link can be sent to at once.
+const LinkEmailLimit = 25
+
+// LinkEmail represents a form for sending a share link by email.
+type LinkEmail struct {
+	Emails  []string `json:"Emails"`
+	Message string   `json:"Message"`
+}
+
+// Recipients returns the valid and unique recipient email addresses.
+func (f *LinkEmail) Recipients() (result []string) {
+	for _, s := range f.Emails {
+		if email := clean.Email(s); email != "" && !list.Contains(result, email) {
+			result = append(result, email)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,18 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkEmail_Recipients(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f := LinkEmail{Emails: []string{"Jane@Example.com", " john@example.com ", "jane@example.com"}}
+		assert.Equal(t, []string{"jane@example.com", "john@example.com"}, f.Recipients())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		f := LinkEmail{Emails: []string{"", "invalid"}}
+		assert.Empty(t, f.Recipients())
+	})
+}
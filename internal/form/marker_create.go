@@ -0,0 +1,12 @@
+package form
+
+// MarkerCreate represents a form for adding a face marker for a manually selected image area.
+type MarkerCreate struct {
+	FileUID string  `json:"FileUID"`
+	X       float32 `json:"X"`
+	Y       float32 `json:"Y"`
+	W       float32 `json:"W"`
+	H       float32 `json:"H"`
+	SubjUID string  `json:"SubjUID"`
+	Name    string  `json:"Name"`
+}
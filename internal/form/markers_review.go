@@ -0,0 +1,7 @@
+package form
+
+// MarkersReview represents a form for confirming or rejecting multiple automatically matched markers at once.
+type MarkersReview struct {
+	Confirm []string `json:"Confirm"`
+	Reject  []string `json:"Reject"`
+}
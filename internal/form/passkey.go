@@ -0,0 +1,14 @@
+package form
+
+import "github.com/photoprism/photoprism/pkg/webauthn"
+
+// Passkey represents a passkey registration request.
+type Passkey struct {
+	Name       string                        `json:"name"`
+	Credential webauthn.RegistrationResponse `json:"credential"`
+}
+
+// PasskeyLogin represents a passwordless login request with a passkey.
+type PasskeyLogin struct {
+	Credential webauthn.AssertionResponse `json:"credential"`
+}
@@ -0,0 +1,18 @@
+package form
+
+import "github.com/ulule/deepcopier"
+
+// PlaceAlias represents a custom place edit form.
+type PlaceAlias struct {
+	AliasName   string  `json:"Name"`
+	AliasLat    float64 `json:"Lat"`
+	AliasLng    float64 `json:"Lng"`
+	AliasRadius int     `json:"Radius"`
+}
+
+// NewPlaceAlias creates a new custom place form with values from the model.
+func NewPlaceAlias(m interface{}) (f PlaceAlias, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
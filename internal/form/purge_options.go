@@ -0,0 +1,7 @@
+package form
+
+type PurgeOptions struct {
+	Path string `json:"path"`
+	Hard bool   `json:"hard"`
+	Dry  bool   `json:"dry"`
+}
@@ -0,0 +1,272 @@
+package form
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// SCIM 2.0 schema URNs, see RFC 7643 and RFC 7644.
+const (
+	ScimSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaList         = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatch        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ScimSchemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+// ScimMeta represents the metadata of a SCIM resource.
+type ScimMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// ScimName represents the name components of a SCIM user.
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// String returns the formatted name.
+func (n *ScimName) String() string {
+	if n == nil {
+		return ""
+	} else if n.Formatted != "" {
+		return n.Formatted
+	}
+
+	return strings.TrimSpace(n.GivenName + " " + n.FamilyName)
+}
+
+// ScimValue represents a multi-valued SCIM attribute such as an email, role, group, or member.
+type ScimValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// ScimUser represents a SCIM user resource.
+type ScimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *ScimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []ScimValue `json:"emails,omitempty"`
+	Roles       []ScimValue `json:"roles,omitempty"`
+	Groups      []ScimValue `json:"groups,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Meta        *ScimMeta   `json:"meta,omitempty"`
+}
+
+// Email returns the primary email address, or the first one if none is marked as primary.
+func (f *ScimUser) Email() string {
+	for _, e := range f.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+
+	if len(f.Emails) > 0 {
+		return f.Emails[0].Value
+	}
+
+	return ""
+}
+
+// Role returns the first role value, if any.
+func (f *ScimUser) Role() string {
+	if len(f.Roles) > 0 {
+		return f.Roles[0].Value
+	}
+
+	return ""
+}
+
+// FullName returns the display name, falling back to the formatted name.
+func (f *ScimUser) FullName() string {
+	if f.DisplayName != "" {
+		return f.DisplayName
+	}
+
+	return f.Name.String()
+}
+
+// ScimGroup represents a SCIM group resource.
+type ScimGroup struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []ScimValue `json:"members,omitempty"`
+	Meta        *ScimMeta   `json:"meta,omitempty"`
+}
+
+// MemberUIDs returns the member values.
+func (f *ScimGroup) MemberUIDs() []string {
+	return ScimValues(f.Members)
+}
+
+// ScimValues returns the values of a multi-valued attribute.
+func ScimValues(values []ScimValue) []string {
+	result := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v.Value != "" {
+			result = append(result, v.Value)
+		}
+	}
+
+	return result
+}
+
+// ScimPatch represents a SCIM PATCH request.
+type ScimPatch struct {
+	Schemas    []string        `json:"schemas"`
+	Operations []ScimOperation `json:"Operations"`
+}
+
+// ScimOperation represents a single SCIM PATCH operation.
+type ScimOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Name returns the normalized operation name, i.e. "add", "remove", or "replace".
+func (op ScimOperation) Name() string {
+	return strings.ToLower(strings.TrimSpace(op.Op))
+}
+
+// Attribute returns the lowercase attribute name the operation applies to, without value filter.
+func (op ScimOperation) Attribute() string {
+	if i := strings.IndexRune(op.Path, '['); i >= 0 {
+		return strings.ToLower(strings.TrimSpace(op.Path[:i]))
+	}
+
+	return strings.ToLower(strings.TrimSpace(op.Path))
+}
+
+// FilterValue returns the value of a path filter like `members[value eq "uid"]`, if any.
+func (op ScimOperation) FilterValue() string {
+	start := strings.IndexRune(op.Path, '[')
+	end := strings.LastIndex(op.Path, "]")
+
+	if start < 0 || end <= start {
+		return ""
+	}
+
+	_, value := ParseScimFilter(op.Path[start+1 : end])
+
+	return value
+}
+
+// Values returns the operation value as attribute map if no path is specified, e.g. {"active": false}.
+func (op ScimOperation) Values() map[string]json.RawMessage {
+	result := make(map[string]json.RawMessage)
+
+	if len(op.Value) == 0 {
+		return result
+	}
+
+	values := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(op.Value, &values); err != nil {
+		return result
+	}
+
+	// Attribute names are case-insensitive.
+	for k, v := range values {
+		result[strings.ToLower(k)] = v
+	}
+
+	return result
+}
+
+// ScimString decodes a string value.
+func ScimString(data json.RawMessage) (s string, ok bool) {
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", false
+	}
+
+	return s, true
+}
+
+// ScimBool decodes a boolean value, which some identity providers send as string.
+func ScimBool(data json.RawMessage) (b bool, ok bool) {
+	if err := json.Unmarshal(data, &b); err == nil {
+		return b, true
+	} else if s, isString := ScimString(data); isString {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// ScimMultiValues decodes a multi-valued attribute, either as list or single object.
+func ScimMultiValues(data json.RawMessage) (result []ScimValue, ok bool) {
+	if err := json.Unmarshal(data, &result); err == nil {
+		return result, true
+	}
+
+	var v ScimValue
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+
+	return []ScimValue{v}, true
+}
+
+// ParseScimFilter parses a simple equality filter like `userName eq "jane"` and returns the lowercase
+// attribute name and value. Other filter expressions are not supported and return empty strings.
+func ParseScimFilter(filter string) (attr, value string) {
+	fields := strings.SplitN(strings.TrimSpace(filter), " ", 3)
+
+	if len(fields) != 3 || !strings.EqualFold(fields[1], "eq") {
+		return "", ""
+	}
+
+	return strings.ToLower(fields[0]), strings.Trim(strings.TrimSpace(fields[2]), `"`)
+}
+
+// ScimList represents a SCIM list response.
+type ScimList struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// NewScimList creates a new SCIM list response.
+func NewScimList(resources interface{}, total, startIndex, count int) ScimList {
+	return ScimList{
+		Schemas:      []string{ScimSchemaList},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: count,
+		Resources:    resources,
+	}
+}
+
+// ScimError represents a SCIM error response.
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
@@ -0,0 +1,67 @@
+package form
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScimFilter(t *testing.T) {
+	t.Run("UserName", func(t *testing.T) {
+		attr, value := ParseScimFilter(`userName eq "jane.doe"`)
+		assert.Equal(t, "username", attr)
+		assert.Equal(t, "jane.doe", value)
+	})
+	t.Run("Spaces", func(t *testing.T) {
+		attr, value := ParseScimFilter(`displayName EQ "Photo Club"`)
+		assert.Equal(t, "displayname", attr)
+		assert.Equal(t, "Photo Club", value)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		attr, value := ParseScimFilter(`userName sw "j"`)
+		assert.Equal(t, "", attr)
+		assert.Equal(t, "", value)
+	})
+}
+
+func TestScimUser(t *testing.T) {
+	var f ScimUser
+
+	if err := json.Unmarshal([]byte(`{"userName": "jane", "name": {"givenName": "Jane", "familyName": "Doe"}, "emails": [{"value": "jane@home.com"}, {"value": "jane@example.com", "primary": true}], "roles": [{"value": "admin"}]}`), &f); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "jane@example.com", f.Email())
+	assert.Equal(t, "admin", f.Role())
+	assert.Equal(t, "Jane Doe", f.FullName())
+	assert.Nil(t, f.Active)
+}
+
+func TestScimOperation(t *testing.T) {
+	t.Run("Filter", func(t *testing.T) {
+		op := ScimOperation{Op: "Remove", Path: `members[value eq "uqxc08w3d0ej2283"]`}
+		assert.Equal(t, "remove", op.Name())
+		assert.Equal(t, "members", op.Attribute())
+		assert.Equal(t, "uqxc08w3d0ej2283", op.FilterValue())
+	})
+	t.Run("Values", func(t *testing.T) {
+		op := ScimOperation{Op: "Replace", Value: json.RawMessage(`{"active": "False", "displayName": "Jane"}`)}
+		values := op.Values()
+		assert.Equal(t, "", op.Attribute())
+		assert.Len(t, values, 2)
+
+		active, ok := ScimBool(values["active"])
+		assert.True(t, ok)
+		assert.False(t, active)
+
+		name, ok := ScimString(values["displayname"])
+		assert.True(t, ok)
+		assert.Equal(t, "Jane", name)
+	})
+	t.Run("MultiValues", func(t *testing.T) {
+		values, ok := ScimMultiValues(json.RawMessage(`{"value": "uqxc08w3d0ej2283"}`))
+		assert.True(t, ok)
+		assert.Equal(t, []string{"uqxc08w3d0ej2283"}, ScimValues(values))
+	})
+}
@@ -7,6 +7,7 @@ type SearchAlbums struct {
 	Type     string `form:"type"`
 	Location string `form:"location"`
 	Category string `form:"category"`
+	Parent   string `form:"parent" example:"parent:root" notes:"Parent Album UID, or root for top-level albums"`
 	Slug     string `form:"slug"`
 	Title    string `form:"title"`
 	Country  string `json:"country"`
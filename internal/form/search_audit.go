@@ -0,0 +1,27 @@
+package form
+
+import "time"
+
+// SearchAudit represents an audit log search form.
+type SearchAudit struct {
+	Query  string    `form:"q"`
+	Level  string    `form:"level"`
+	IP     string    `form:"ip"`
+	User   string    `form:"user"`
+	Since  time.Time `form:"since" time_format:"2006-01-02"`
+	Until  time.Time `form:"until" time_format:"2006-01-02"`
+	Count  int       `form:"count" serialize:"-"`
+	Offset int       `form:"offset" serialize:"-"`
+}
+
+func (f *SearchAudit) GetQuery() string {
+	return f.Query
+}
+
+func (f *SearchAudit) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *SearchAudit) ParseQueryString() error {
+	return ParseQueryString(f)
+}
@@ -0,0 +1,31 @@
+package form
+
+import "time"
+
+// SearchLinks represents a share link search form.
+type SearchLinks struct {
+	Query     string    `form:"q"`
+	Share     string    `form:"share"`
+	Expired   bool      `form:"expired"`
+	Before    time.Time `form:"before" time_format:"2006-01-02" time_utc:"1"`
+	CreatedBy string    `form:"-" serialize:"-"`
+	Count     int       `form:"count" serialize:"-"`
+	Offset    int       `form:"offset" serialize:"-"`
+}
+
+func (f *SearchLinks) GetQuery() string {
+	return f.Query
+}
+
+func (f *SearchLinks) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *SearchLinks) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+// Filtered checks if the search is limited by any of the filters.
+func (f *SearchLinks) Filtered() bool {
+	return f.Query != "" || f.Share != "" || f.Expired || !f.Before.IsZero()
+}
@@ -33,6 +33,7 @@ type SearchPhotos struct {
 	Live      bool      `form:"live" notes:"Finds Live Photos and short videos"`
 	Scan      bool      `form:"scan" notes:"Finds scanned images and documents"`
 	Panorama  bool      `form:"panorama" notes:"Finds pictures with an aspect ratio > 1.9:1"`
+	Blurry    bool      `form:"blurry" notes:"Finds pictures that seem to be out of focus or poorly exposed"`
 	Portrait  bool      `form:"portrait" notes:"Finds pictures in portrait format"`
 	Landscape bool      `form:"landscape" notes:"Finds pictures in landscape format"`
 	Square    bool      `form:"square" notes:"Finds images with an aspect ratio of 1:1"`
@@ -52,12 +53,17 @@ type SearchPhotos struct {
 	Diff      uint32    `form:"diff" notes:"Differential Perceptual Hash (000000-FFFFFF)"`
 	Mono      bool      `form:"mono" notes:"Finds pictures with few or no colors"`
 	Geo       bool      `form:"geo" notes:"Finds pictures with GPS location"`
-	Keywords  string    `form:"keywords"  example:"keywords:\"buffalo&water\"" notes:"Keywords, can be combined with & and |"`                                                                                        // Filter by keyword(s)
-	Label     string    `form:"label" example:"label:cat|dog" notes:"Label Name, OR search with |"`                                                                                                                   // Label name
-	Category  string    `form:"category"  notes:"Location Category Name"`                                                                                                                                             // Moments
-	Country   string    `form:"country" example:"country:\"de|us\"" notes:"Country Code, OR search with |"`                                                                                                           // Moments
-	State     string    `form:"state" example:"state:\"Baden-Württemberg\"" notes:"Name of State (Location), OR search with |"`                                                                                       // Moments
-	City      string    `form:"city" example:"city:\"Berlin\"" notes:"Name of City (Location), OR search with |"`                                                                                                     // Moments
+	Keywords  string    `form:"keywords"  example:"keywords:\"buffalo&water\"" notes:"Keywords, can be combined with & and |"` // Filter by keyword(s)
+	Semantic  string    `form:"semantic" example:"semantic:\"birthday cake with candles\"" notes:"Describes what is shown in the pictures, requires an embedding model"`
+	Label     string    `form:"label" example:"label:cat|dog" notes:"Label Name, OR search with |"`                             // Label name
+	Category  string    `form:"category"  notes:"Location Category Name"`                                                       // Moments
+	Country   string    `form:"country" example:"country:\"de|us\"" notes:"Country Code, OR search with |"`                     // Moments
+	State     string    `form:"state" example:"state:\"Baden-Württemberg\"" notes:"Name of State (Location), OR search with |"` // Moments
+	City      string    `form:"city" example:"city:\"Berlin\"" notes:"Name of City (Location), OR search with |"`               // Moments
+	Place     string    `form:"place" example:"place:\"Grandma's House\"" notes:"Custom Place Name or Place Label, OR search with |"`
+	Alt       string    `form:"alt" example:"alt:1000-2500" notes:"Altitude in meters, e.g. >1500 or 1000-2500"`
+	Light     string    `form:"light" example:"light:goldenhour|sunset" notes:"Natural Light (day, goldenhour, bluehour, night, sunrise, sunset), OR search with |"`
+	Moon      string    `form:"moon" example:"moon:full" notes:"Moon Phase (new, waxing-crescent, first-quarter, waxing-gibbous, full, waning-gibbous, last-quarter, waning-crescent), OR search with |"`
 	Year      string    `form:"year" example:"year:1990|2003" notes:"Year Number, OR search with |"`                                                                                                                  // Moments
 	Month     string    `form:"month" example:"month:7|10" notes:"Month (1-12), OR search with |"`                                                                                                                    // Moments
 	Day       string    `form:"day" example:"day:3|13" notes:"Day of Month (1-31), OR search with |"`                                                                                                                 // Moments
@@ -65,12 +71,14 @@ type SearchPhotos struct {
 	Faces     string    `form:"faces" example:"faces:yes faces:3" notes:"Minimum number of Faces (yes = 1)"`                                                                                                          // Find or exclude faces if detected.
 	Subject   string    `form:"subject" example:"subject:\"Jane Doe & John Doe\"" notes:"Alias for person"`                                                                                                           // UIDs
 	Person    string    `form:"person" example:"person:\"Jane Doe & John Doe\"" notes:"Subject Names, exact matches, can be combined with & and |"`                                                                   // Alias for Subject
+	Age       string    `form:"age" example:"age:<5" notes:"Age of the people shown in years, requires a date of birth, can be combined with person"`                                                                 // Age of subjects
 	Subjects  string    `form:"subjects" example:"subjects:\"Jane & John\"" notes:"Alias for people"`                                                                                                                 // People names
 	People    string    `form:"people" example:"people:\"Jane & John\"" notes:"Subject Names, can be combined with & and |"`                                                                                          // Alias for Subjects
 	Album     string    `form:"album" example:"album:berlin" notes:"Album UID or Name, supports * wildcards"`                                                                                                         // Album UIDs or name
 	Albums    string    `form:"albums" example:"albums:\"South Africa & Birds\"" notes:"Album Names, can be combined with & and |"`                                                                                   // Multi search with and/or
 	Color     string    `form:"color" example:"color:\"red|blue\"" notes:"Color Name (purple, magenta, pink, red, orange, gold, yellow, lime, green, teal, cyan, blue, brown, white, grey, black), OR search with |"` // Main color
 	Quality   int       `form:"quality" notes:"Quality Score (0-7)"`                                                                                                                                                  // Photo quality score
+	Score     string    `form:"score" example:"score:<0.3" notes:"Image Quality Score (0-1), e.g. 0.5-1, requires a quality model"`                                                                                   // Image quality score
 	Review    bool      `form:"review" notes:"Finds pictures in review"`                                                                                                                                              // Find photos in review
 	Camera    string    `form:"camera" example:"camera:canon" notes:"Camera Make/Model Name"`                                                                                                                         // Camera UID or name
 	Lens      string    `form:"lens" example:"lens:ef24" notes:"Lens Make/Model Name"`                                                                                                                                // Lens UID or name
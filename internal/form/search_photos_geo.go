@@ -31,6 +31,7 @@ type SearchPhotosGeo struct {
 	Live      bool      `form:"live"`
 	Scan      bool      `form:"scan"`
 	Panorama  bool      `form:"panorama"`
+	Blurry    bool      `form:"blurry"`
 	Portrait  bool      `form:"portrait"`
 	Landscape bool      `form:"landscape"`
 	Square    bool      `form:"square"`
@@ -39,6 +40,7 @@ type SearchPhotosGeo struct {
 	Private   bool      `form:"private"`
 	Review    bool      `form:"review"`
 	Quality   int       `form:"quality"`
+	Score     string    `form:"score"`
 	Face      string    `form:"face" notes:"Face ID, yes, no, new, or kind"`
 	Faces     string    `form:"faces"` // Find or exclude faces if detected.
 	Subject   string    `form:"subject"`
@@ -47,6 +49,8 @@ type SearchPhotosGeo struct {
 	S2        string    `form:"s2"`
 	Olc       string    `form:"olc"`
 	Dist      uint      `form:"dist"`
+	BBox      string    `form:"bbox" example:"bbox:13.1,52.3,13.7,52.7" notes:"Bounding Box (west,south,east,north)"`
+	Zoom      int       `form:"zoom" serialize:"-" notes:"Map Zoom Level (0-20) for clustering"`
 	Person    string    `form:"person"`   // Alias for Subject
 	Subjects  string    `form:"subjects"` // Text
 	People    string    `form:"people"`   // Alias for Subjects
@@ -58,6 +62,10 @@ type SearchPhotosGeo struct {
 	Country   string    `form:"country"`
 	State     string    `form:"state"` // Moments
 	City      string    `form:"city"`
+	Place     string    `form:"place"`
+	Alt       string    `form:"alt"`
+	Light     string    `form:"light"`
+	Moon      string    `form:"moon"`
 	Year      string    `form:"year"`  // Moments
 	Month     string    `form:"month"` // Moments
 	Day       string    `form:"day"`   // Moments
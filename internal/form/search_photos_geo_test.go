@@ -30,6 +30,17 @@ func TestSearchPhotosGeo(t *testing.T) {
 
 		assert.Equal(t, "ii3e4567-e89b-hdgtr", form.ID)
 	})
+	t.Run("bbox", func(t *testing.T) {
+		form := &SearchPhotosGeo{Query: "bbox:\"13.1,52.3,13.7,52.7\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "13.1,52.3,13.7,52.7", form.BBox)
+	})
 	t.Run("aliases", func(t *testing.T) {
 		form := &SearchPhotosGeo{Query: "people:\"Jens & Mander\" folder:Foo person:Bar"}
 
@@ -51,6 +51,53 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Foo Bar", form.Keywords)
 	})
+	t.Run("semantic", func(t *testing.T) {
+		form := &SearchPhotos{Query: "semantic:\"birthday cake with candles\" year:2019"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "birthday cake with candles", form.Semantic)
+		assert.Equal(t, "2019", form.Year)
+	})
+	t.Run("place", func(t *testing.T) {
+		form := &SearchPhotos{Query: "place:\"Grandma's House|Berlin\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Grandma's House|Berlin", form.Place)
+	})
+	t.Run("alt", func(t *testing.T) {
+		form := &SearchPhotos{Query: "alt:1000-2500 mountains"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "1000-2500", form.Alt)
+		assert.Equal(t, "mountains", form.Query)
+	})
+	t.Run("light", func(t *testing.T) {
+		form := &SearchPhotos{Query: "light:goldenhour|sunset moon:full"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "goldenhour|sunset", form.Light)
+		assert.Equal(t, "full", form.Moon)
+	})
 	t.Run("and query", func(t *testing.T) {
 		form := &SearchPhotos{Query: "\"Jens & Mander\" title:\"Tübingen\""}
 
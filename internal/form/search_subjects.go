@@ -11,6 +11,7 @@ type SearchSubjects struct {
 	Favorite string `form:"favorite"`
 	Private  string `form:"private"`
 	Excluded string `form:"excluded"`
+	Ignored  string `form:"ignored"`
 	Files    int    `form:"files"`
 	Photos   int    `form:"photos"`
 	Count    int    `form:"count" binding:"required" serialize:"-"`
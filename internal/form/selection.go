@@ -9,6 +9,7 @@ type Selection struct {
 	Labels   []string `json:"labels"`
 	Places   []string `json:"places"`
 	Subjects []string `json:"subjects"`
+	Set      string   `json:"set,omitempty"`
 }
 
 func (f Selection) Empty() bool {
@@ -25,6 +26,8 @@ func (f Selection) Empty() bool {
 		return false
 	case len(f.Subjects) > 0:
 		return false
+	case f.Set != "":
+		return false
 	}
 
 	return true
@@ -32,6 +32,10 @@ func TestSelection_Empty(t *testing.T) {
 		assert.Equal(t, false, sel.Empty())
 		assert.Equal(t, []string{"jqzkpo13j8ngpgv4", "jqzkq8j10hj39sxp"}, sel.Subjects)
 	})
+	t.Run("not empty set", func(t *testing.T) {
+		sel := Selection{Photos: []string{}, Set: "default"}
+		assert.Equal(t, false, sel.Empty())
+	})
 	t.Run("empty", func(t *testing.T) {
 		sel := Selection{Photos: []string{}, Albums: []string{}, Labels: []string{}}
 		assert.Equal(t, true, sel.Empty())
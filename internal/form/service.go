@@ -29,6 +29,7 @@ type Service struct {
 	SyncDownload  bool   `json:"SyncDownload"`
 	SyncFilenames bool   `json:"SyncFilenames"`
 	SyncRaw       bool   `json:"SyncRaw"`
+	SyncConflict  string `json:"SyncConflict"` // Conflict policy for two-way sync: newest, keep
 }
 
 // NewService creates a new service form.
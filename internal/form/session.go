@@ -0,0 +1,6 @@
+package form
+
+// Session represents a form for changing the properties of a user session, e.g. its client name.
+type Session struct {
+	ClientName string `json:"ClientName"`
+}
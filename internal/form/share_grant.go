@@ -0,0 +1,11 @@
+package form
+
+import "time"
+
+// ShareGrant represents a request to grant a user or group direct access to an album or folder.
+type ShareGrant struct {
+	UserUID   string     `json:"UserUID"`
+	GroupUID  string     `json:"GroupUID"`
+	Grant     string     `json:"Grant"`
+	ExpiresAt *time.Time `json:"ExpiresAt,omitempty"`
+}
@@ -4,15 +4,19 @@ import "github.com/ulule/deepcopier"
 
 // Subject represents an image subject edit form.
 type Subject struct {
-	SubjName     string `json:"Name"`
-	SubjAlias    string `json:"Alias"`
-	SubjAbout    string `json:"About"`
-	SubjBio      string `json:"Bio"`
-	SubjNotes    string `json:"Notes"`
-	SubjFavorite bool   `json:"Favorite"`
-	SubjHidden   bool   `json:"Hidden"`
-	SubjPrivate  bool   `json:"Private"`
-	SubjExcluded bool   `json:"Excluded"`
+	SubjName       string `json:"Name"`
+	SubjAlias      string `json:"Alias"`
+	SubjAbout      string `json:"About"`
+	SubjBio        string `json:"Bio"`
+	SubjNotes      string `json:"Notes"`
+	SubjFavorite   bool   `json:"Favorite"`
+	SubjHidden     bool   `json:"Hidden"`
+	SubjPrivate    bool   `json:"Private"`
+	SubjExcluded   bool   `json:"Excluded"`
+	SubjIgnored    bool   `json:"Ignored"`
+	SubjBirthYear  int    `json:"BirthYear"`
+	SubjBirthMonth int    `json:"BirthMonth"`
+	SubjBirthDay   int    `json:"BirthDay"`
 }
 
 func NewSubject(m interface{}) (f Subject, err error) {
@@ -0,0 +1,6 @@
+package form
+
+// SubjectMerge represents a form for merging a subject into another, e.g. two people who are the same person.
+type SubjectMerge struct {
+	Target string `json:"Target"`
+}
@@ -20,6 +20,8 @@ type User struct {
 	UserAttr     string       `json:"Attr,omitempty" yaml:"Attr,omitempty"`
 	BasePath     string       `json:"BasePath,omitempty" yaml:"BasePath,omitempty"`
 	UploadPath   string       `json:"UploadPath,omitempty" yaml:"UploadPath,omitempty"`
+	UserQuota    int          `json:"Quota,omitempty" yaml:"Quota,omitempty"`
+	NSFWPolicy   string       `json:"NSFWPolicy,omitempty" yaml:"NSFWPolicy,omitempty"`
 	Password     string       `json:"Password,omitempty" yaml:"Password,omitempty"`
 	UserDetails  *UserDetails `json:"Details,omitempty"`
 }
@@ -38,6 +40,8 @@ func NewUserFromCli(ctx *cli.Context) User {
 		UserAttr:     clean.Attr(ctx.String("attr")),
 		BasePath:     clean.UserPath(ctx.String("base-path")),
 		UploadPath:   clean.UserPath(ctx.String("upload-path")),
+		UserQuota:    ctx.Int("quota"),
+		NSFWPolicy:   clean.TypeLower(ctx.String("nsfw")),
 		Password:     clean.Password(ctx.String("password")),
 	}
 }
@@ -0,0 +1,42 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+var onceRemoteBackup sync.Once
+
+func initRemoteBackup() {
+	c := Config()
+
+	if !c.BackupRemote() {
+		return
+	}
+
+	var backend vfs.Backend
+	var err error
+
+	if c.BackupBucket() != "" {
+		backend, err = vfs.NewS3(c.BackupS3Options())
+	} else {
+		backend, err = vfs.NewWebDAV(c.BackupWebDAV())
+	}
+
+	if err != nil {
+		log.Errorf("backup: %s", err)
+		return
+	}
+
+	services.RemoteBackup = photoprism.NewRemoteBackup(c, backend)
+}
+
+// RemoteBackup returns the worker for uploading backups to an object storage bucket or WebDAV folder,
+// or nil if not configured.
+func RemoteBackup() *photoprism.RemoteBackup {
+	onceRemoteBackup.Do(initRemoteBackup)
+
+	return services.RemoteBackup
+}
@@ -9,10 +9,15 @@ import (
 var onceClassify sync.Once
 
 func initClassify() {
-	services.Classify = classify.New(Config().AssetsPath(), Config().DisableClassification())
+	switch Config().ClassifyBackend() {
+	case classify.BackendRemote:
+		services.Classify = classify.NewRemote(Config().ClassifyUrl(), Config().ClassifyKey(), Config().DisableClassification())
+	default:
+		services.Classify = classify.New(Config().AssetsPath(), Config().DisableClassification())
+	}
 }
 
-func Classify() *classify.TensorFlow {
+func Classify() classify.Classifier {
 	onceClassify.Do(initClassify)
 
 	return services.Classify
@@ -0,0 +1,41 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+var onceCold sync.Once
+
+func initCold() {
+	c := Config()
+
+	if !c.ColdEnabled() {
+		return
+	}
+
+	var backend vfs.Backend
+	var err error
+
+	if c.ColdBucket() != "" {
+		backend, err = vfs.NewS3(c.ColdS3Options())
+	} else {
+		backend, err = vfs.NewLocal(c.ColdPath())
+	}
+
+	if err != nil {
+		log.Errorf("cold: %s", err)
+		return
+	}
+
+	services.Cold = photoprism.NewCold(c, backend)
+}
+
+// Cold returns the cold storage worker for archiving old originals, or nil if not configured.
+func Cold() *photoprism.Cold {
+	onceCold.Do(initCold)
+
+	return services.Cold
+}
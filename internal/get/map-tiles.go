@@ -0,0 +1,26 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/tiles"
+)
+
+var onceMapTiles sync.Once
+
+func initMapTiles() {
+	c := Config()
+
+	if src, err := tiles.New(c.MapTiles(), c.MapTilesUrl(), c.MapTilesCachePath()); err != nil {
+		log.Errorf("maps: %s", err)
+	} else {
+		services.MapTiles = src
+	}
+}
+
+// MapTiles returns the local map tile source, or nil if map tiles are not served locally.
+func MapTiles() tiles.Source {
+	onceMapTiles.Do(initMapTiles)
+
+	return services.MapTiles
+}
@@ -0,0 +1,114 @@
+package get
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+// Model loading states.
+const (
+	ModelLoading = "loading"
+	ModelReady   = "ready"
+	ModelFailed  = "failed"
+)
+
+// modelLoader loads a model and returns an error if it is not available.
+type modelLoader func() error
+
+var models = struct {
+	status map[string]string
+	mutex  sync.RWMutex
+}{status: make(map[string]string)}
+
+// WarmupModels loads the enabled TensorFlow models in parallel and returns immediately, so that the
+// server can respond to requests while they are being loaded. Models are otherwise loaded on first use.
+func WarmupModels() {
+	loaders := enabledModels()
+
+	models.mutex.Lock()
+
+	for name := range loaders {
+		if _, found := models.status[name]; !found {
+			models.status[name] = ModelLoading
+		} else {
+			delete(loaders, name)
+		}
+	}
+
+	models.mutex.Unlock()
+
+	for name, load := range loaders {
+		go warmupModel(name, load)
+	}
+}
+
+// warmupModel loads a model and updates its status.
+func warmupModel(name string, load modelLoader) {
+	start := time.Now()
+	status := ModelReady
+
+	if err := load(); err != nil {
+		log.Errorf("models: failed to load %s (%s)", name, err)
+		status = ModelFailed
+	} else {
+		log.Debugf("models: loaded %s [%s]", name, time.Since(start))
+	}
+
+	models.mutex.Lock()
+	models.status[name] = status
+	models.mutex.Unlock()
+}
+
+// enabledModels returns the loaders of models that are enabled in the config.
+func enabledModels() map[string]modelLoader {
+	c := Config()
+	result := make(map[string]modelLoader)
+
+	if c.DisableTensorFlow() {
+		return result
+	}
+
+	if !c.DisableClassification() && c.ClassifyBackend() != classify.BackendRemote {
+		result["classify"] = func() error { return Classify().Init() }
+	}
+
+	if c.DetectNSFW() || !c.UploadNSFW() {
+		result["nsfw"] = func() error { return NsfwDetector().Init() }
+	}
+
+	if !c.DisableFaces() {
+		result["faces"] = func() error { return FaceNet().Init() }
+	}
+
+	return result
+}
+
+// ModelStatus returns the loading status of models that are warmed up in the background.
+func ModelStatus() map[string]string {
+	models.mutex.RLock()
+	defer models.mutex.RUnlock()
+
+	result := make(map[string]string, len(models.status))
+
+	for name, status := range models.status {
+		result[name] = status
+	}
+
+	return result
+}
+
+// ModelsLoading returns the names of models that are still being loaded.
+func ModelsLoading() (names []string) {
+	for name, status := range ModelStatus() {
+		if status == ModelLoading {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
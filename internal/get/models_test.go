@@ -0,0 +1,38 @@
+package get
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmupModels(t *testing.T) {
+	defer func() {
+		models.mutex.Lock()
+		delete(models.status, "test-ready")
+		delete(models.status, "test-failed")
+		models.mutex.Unlock()
+	}()
+
+	WarmupModels()
+
+	warmupModel("test-ready", func() error { return nil })
+	warmupModel("test-failed", func() error { return errors.New("model not found") })
+
+	status := ModelStatus()
+
+	assert.Equal(t, ModelReady, status["test-ready"])
+	assert.Equal(t, ModelFailed, status["test-failed"])
+	assert.NotContains(t, ModelsLoading(), "test-ready")
+}
+
+func TestEnabledModels(t *testing.T) {
+	result := enabledModels()
+
+	if Config().DisableTensorFlow() {
+		assert.Empty(t, result)
+	} else {
+		assert.Contains(t, result, "classify")
+	}
+}
@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var oncePrefetch sync.Once
+
+func initPrefetch() {
+	services.Prefetch = photoprism.NewPrefetch(Config())
+}
+
+func Prefetch() *photoprism.Prefetch {
+	oncePrefetch.Do(initPrefetch)
+
+	return services.Prefetch
+}
@@ -27,37 +27,47 @@ package get
 import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/internal/tiles"
+	"github.com/photoprism/photoprism/internal/vfs"
 
 	gc "github.com/patrickmn/go-cache"
 )
 
+var log = event.Log
+
 var conf *config.Config
 
 var services struct {
-	FolderCache *gc.Cache
-	CoverCache  *gc.Cache
-	ThumbCache  *gc.Cache
-	Classify    *classify.TensorFlow
-	Convert     *photoprism.Convert
-	Files       *photoprism.Files
-	Photos      *photoprism.Photos
-	Import      *photoprism.Import
-	Index       *photoprism.Index
-	Moments     *photoprism.Moments
-	Faces       *photoprism.Faces
-	Places      *photoprism.Places
-	Purge       *photoprism.Purge
-	CleanUp     *photoprism.CleanUp
-	Nsfw        *nsfw.Detector
-	FaceNet     *face.Net
-	Query       *query.Query
-	Thumbs      *photoprism.Thumbs
-	Session     *session.Session
+	FolderCache  *gc.Cache
+	CoverCache   *gc.Cache
+	ThumbCache   *gc.Cache
+	Classify     classify.Classifier
+	Convert      *photoprism.Convert
+	Files        *photoprism.Files
+	Photos       *photoprism.Photos
+	Import       *photoprism.Import
+	Index        *photoprism.Index
+	Moments      *photoprism.Moments
+	Faces        *photoprism.Faces
+	Places       *photoprism.Places
+	Purge        *photoprism.Purge
+	CleanUp      *photoprism.CleanUp
+	Nsfw         *nsfw.Detector
+	FaceNet      *face.Net
+	Query        *query.Query
+	Thumbs       *photoprism.Thumbs
+	Prefetch     *photoprism.Prefetch
+	Session      *session.Session
+	MapTiles     tiles.Source
+	Storage      *vfs.Mirror
+	Cold         *photoprism.Cold
+	RemoteBackup *photoprism.RemoteBackup
 }
 
 func SetConfig(c *config.Config) {
@@ -68,6 +78,9 @@ func SetConfig(c *config.Config) {
 	conf = c
 
 	photoprism.SetConfig(c)
+
+	// Initialize the object storage mirror, so that deleted files are also removed from it.
+	Storage()
 }
 
 func Config() *config.Config {
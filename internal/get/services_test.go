@@ -69,6 +69,22 @@ func TestResample(t *testing.T) {
 	assert.IsType(t, &photoprism.Thumbs{}, Thumbs())
 }
 
+func TestPrefetch(t *testing.T) {
+	assert.IsType(t, &photoprism.Prefetch{}, Prefetch())
+}
+
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestMapTiles(t *testing.T) {
+	assert.Nil(t, MapTiles())
+}
+
+func TestStorage(t *testing.T) {
+	assert.Nil(t, Storage())
+}
+
+func TestCold(t *testing.T) {
+	assert.Nil(t, Cold())
+}
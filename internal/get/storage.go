@@ -0,0 +1,41 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+var onceStorage sync.Once
+
+func initStorage() {
+	c := Config()
+
+	if !c.S3Enabled() {
+		return
+	}
+
+	backend, err := vfs.NewS3(c.S3Options())
+
+	if err != nil {
+		log.Errorf("storage: %s", err)
+		return
+	}
+
+	services.Storage = vfs.NewMirror(backend,
+		vfs.Root{Name: vfs.RootOriginals, Path: c.OriginalsPath()},
+		vfs.Root{Name: vfs.RootSidecar, Path: c.SidecarPath()},
+		vfs.Root{Name: vfs.RootThumbs, Path: c.ThumbCachePath(), Cache: true},
+	)
+
+	photoprism.SetStorage(services.Storage)
+}
+
+// Storage returns the object storage mirror of originals, sidecar files, and thumbnails,
+// or nil if files are only stored locally.
+func Storage() *vfs.Mirror {
+	onceStorage.Do(initStorage)
+
+	return services.Storage
+}
@@ -42,6 +42,10 @@ const (
 	ErrBusy
 	ErrWakeupInterval
 	ErrAccountConnect
+	ErrQuotaExceeded
+	ErrConflict
+	ErrInvalidEmail
+	ErrEmailNotSent
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -87,6 +91,9 @@ const (
 	MsgZipCreatedIn
 	MsgPermanentlyDeleted
 	MsgRestored
+	MsgRestoreRequested
+	MsgUploadPendingReview
+	MsgEmailSent
 )
 
 var Messages = MessageMap{
@@ -132,6 +139,10 @@ var Messages = MessageMap{
 	ErrBusy:               gettext("Busy, please try again later"),
 	ErrWakeupInterval:     gettext("The wakeup interval is %s, but must be 1h or less"),
 	ErrAccountConnect:     gettext("Your account could not be connected"),
+	ErrQuotaExceeded:      gettext("Storage quota exceeded"),
+	ErrConflict:           gettext("Changed by someone else in the meantime"),
+	ErrInvalidEmail:       gettext("Invalid email address"),
+	ErrEmailNotSent:       gettext("Email could not be sent, please try again later"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -178,4 +189,7 @@ var Messages = MessageMap{
 	MsgZipCreatedIn:          gettext("Zip created in %d s"),
 	MsgPermanentlyDeleted:    gettext("Permanently deleted"),
 	MsgRestored:              gettext("%s has been restored"),
+	MsgRestoreRequested:      gettext("File is being restored from cold storage, please try again later"),
+	MsgUploadPendingReview:   gettext("Upload has been received and is waiting for review"),
+	MsgEmailSent:             gettext("Email has been sent"),
 }
@@ -0,0 +1,184 @@
+package mail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Connection security options.
+const (
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+// Timeout limits the time for connecting to the mail server and sending a message.
+var Timeout = 30 * time.Second
+
+// Options represents the outgoing mail server settings.
+type Options struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Security string
+}
+
+// Enabled checks if an outgoing mail server has been configured.
+func (o Options) Enabled() bool {
+	return o.Host != ""
+}
+
+// Addr returns the mail server address including the port.
+func (o Options) Addr() string {
+	port := o.Port
+
+	if port <= 0 {
+		switch o.Security {
+		case SecurityTLS:
+			port = 465
+		case SecurityNone:
+			port = 25
+		default:
+			port = 587
+		}
+	}
+
+	return net.JoinHostPort(o.Host, strconv.Itoa(port))
+}
+
+// Mailer sends emails via SMTP.
+type Mailer struct {
+	opt Options
+}
+
+// New returns a new mailer with the specified options.
+func New(opt Options) *Mailer {
+	return &Mailer{opt: opt}
+}
+
+// Enabled checks if emails can be sent.
+func (m *Mailer) Enabled() bool {
+	return m != nil && m.opt.Enabled()
+}
+
+// Send sends the message to its recipients.
+func (m *Mailer) Send(msg Message) error {
+	if !m.Enabled() {
+		return ErrDisabled
+	} else if m.opt.From == "" {
+		return ErrNoSender
+	} else if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	sender, err := netmail.ParseAddress(m.opt.From)
+
+	if err != nil {
+		return fmt.Errorf("mail: invalid sender address %s", clean.Log(m.opt.From))
+	}
+
+	recipients := make([]string, len(msg.To))
+
+	for i, to := range msg.To {
+		if addr, err := netmail.ParseAddress(to); err != nil {
+			return fmt.Errorf("mail: invalid recipient address %s", clean.Log(to))
+		} else {
+			recipients[i] = addr.Address
+		}
+	}
+
+	c, err := m.client()
+
+	if err != nil {
+		return err
+	}
+
+	defer c.Close()
+
+	if err = c.Mail(sender.Address); err != nil {
+		return fmt.Errorf("mail: %s (sender)", err)
+	}
+
+	for _, to := range recipients {
+		if err = c.Rcpt(to); err != nil {
+			return fmt.Errorf("mail: %s (recipient %s)", err, clean.Log(to))
+		}
+	}
+
+	w, err := c.Data()
+
+	if err != nil {
+		return fmt.Errorf("mail: %s (data)", err)
+	}
+
+	if _, err = w.Write(msg.Bytes(m.opt.From)); err != nil {
+		return fmt.Errorf("mail: %s (write)", err)
+	} else if err = w.Close(); err != nil {
+		return fmt.Errorf("mail: %s (send)", err)
+	}
+
+	log.Debugf("mail: sent %s to %s", clean.Log(msg.Subject), clean.Log(strings.Join(msg.To, ", ")))
+
+	return c.Quit()
+}
+
+// client connects and authenticates to the mail server.
+func (m *Mailer) client() (*smtp.Client, error) {
+	addr := m.opt.Addr()
+	dialer := &net.Dialer{Timeout: Timeout}
+	tlsConfig := &tls.Config{ServerName: m.opt.Host}
+
+	var conn net.Conn
+	var err error
+
+	if m.opt.Security == SecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("mail: %s (connect)", err)
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(Timeout))
+
+	c, err := smtp.NewClient(conn, m.opt.Host)
+
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("mail: %s (connect)", err)
+	}
+
+	// Upgrade the connection to TLS, unless it is already encrypted or security is disabled.
+	if m.opt.Security != SecurityTLS && m.opt.Security != SecurityNone {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			_ = c.Close()
+			return nil, fmt.Errorf("mail: %s does not support starttls", clean.Log(m.opt.Host))
+		} else if err = c.StartTLS(tlsConfig); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("mail: %s (starttls)", err)
+		}
+	}
+
+	if m.opt.Username != "" {
+		auth := smtp.PlainAuth("", m.opt.Username, m.opt.Password, m.opt.Host)
+
+		if err = c.Auth(auth); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("mail: %s (auth)", err)
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,135 @@
+package mail
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer starts a minimal SMTP server that accepts a single message and returns it on the channel.
+func testServer(t *testing.T) (Options, chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make(chan string, 1)
+
+	go func() {
+		defer l.Close()
+
+		conn, err := l.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+
+		var data strings.Builder
+
+		for {
+			line, err := r.ReadString('\n')
+
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				data.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 Go ahead")
+
+				for {
+					line, err = r.ReadString('\n')
+
+					if err != nil || line == ".\r\n" {
+						break
+					}
+
+					data.WriteString(line)
+				}
+
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				result <- data.String()
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+
+	return Options{Host: host, Port: portNum, From: "PhotoPrism <photoprism@example.com>", Security: SecurityNone}, result
+}
+
+func TestOptions_Enabled(t *testing.T) {
+	assert.False(t, Options{}.Enabled())
+	assert.True(t, Options{Host: "mail.example.com"}.Enabled())
+}
+
+func TestOptions_Addr(t *testing.T) {
+	assert.Equal(t, "mail.example.com:587", Options{Host: "mail.example.com"}.Addr())
+	assert.Equal(t, "mail.example.com:465", Options{Host: "mail.example.com", Security: SecurityTLS}.Addr())
+	assert.Equal(t, "mail.example.com:25", Options{Host: "mail.example.com", Security: SecurityNone}.Addr())
+	assert.Equal(t, "mail.example.com:2525", Options{Host: "mail.example.com", Port: 2525}.Addr())
+}
+
+func TestMailer_Send(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		opt, result := testServer(t)
+
+		err := New(opt).Send(NewMessage("Hello", "Test message", "Jane Doe <jane@example.com>"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := <-result
+
+		assert.Contains(t, data, "MAIL FROM:<photoprism@example.com>")
+		assert.Contains(t, data, "RCPT TO:<jane@example.com>")
+		assert.Contains(t, data, "Subject: Hello\r\n")
+		assert.Contains(t, data, "Test message")
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Equal(t, ErrDisabled, New(Options{}).Send(NewMessage("Hello", "Test", "jane@example.com")))
+	})
+	t.Run("NoSender", func(t *testing.T) {
+		assert.Equal(t, ErrNoSender, New(Options{Host: "localhost"}).Send(NewMessage("Hello", "Test", "jane@example.com")))
+	})
+	t.Run("NoRecipients", func(t *testing.T) {
+		assert.Equal(t, ErrNoRecipients, New(Options{Host: "localhost", From: "photoprism@example.com"}).Send(NewMessage("Hello", "Test")))
+	})
+	t.Run("InvalidRecipient", func(t *testing.T) {
+		assert.Error(t, New(Options{Host: "localhost", From: "photoprism@example.com"}).Send(NewMessage("Hello", "Test", "invalid")))
+	})
+	t.Run("StartTLSUnsupported", func(t *testing.T) {
+		opt, _ := testServer(t)
+		opt.Security = SecurityStartTLS
+
+		err := New(opt).Send(NewMessage("Hello", "Test", "jane@example.com"))
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "does not support starttls")
+		}
+	})
+}
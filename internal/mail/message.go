@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Message represents a plain text email.
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+}
+
+// NewMessage returns a new message for the recipients.
+func NewMessage(subject, text string, to ...string) Message {
+	return Message{To: to, Subject: subject, Text: text}
+}
+
+// Bytes returns the message including its headers, encoded for sending it via SMTP.
+func (msg Message) Bytes(from string) []byte {
+	var buf bytes.Buffer
+
+	domain := "localhost"
+
+	if addr, err := netmail.ParseAddress(from); err == nil {
+		if i := strings.LastIndex(addr.Address, "@"); i > 0 {
+			domain = addr.Address[i+1:]
+		}
+	}
+
+	header := [][2]string{
+		{"From", from},
+		{"To", strings.Join(msg.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s.%s@%s>", rnd.Base36(16), rnd.Base36(8), domain)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+
+	for _, h := range header {
+		buf.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	_, _ = w.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(msg.Text, "\r\n", "\n"), "\n", "\r\n")))
+	_ = w.Close()
+
+	return buf.Bytes()
+}
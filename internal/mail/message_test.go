@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := NewMessage("Grüße", "Line 1\nLine 2", "jane@example.com", "john@example.com")
+	result := string(msg.Bytes("PhotoPrism <photoprism@example.com>"))
+
+	assert.Contains(t, result, "From: PhotoPrism <photoprism@example.com>\r\n")
+	assert.Contains(t, result, "To: jane@example.com, john@example.com\r\n")
+	assert.Contains(t, result, "Subject: =?utf-8?q?Gr=C3=BC=C3=9Fe?=\r\n")
+	assert.Contains(t, result, "@example.com>\r\n")
+	assert.Contains(t, result, "Content-Transfer-Encoding: quoted-printable\r\n")
+	assert.Contains(t, result, "\r\n\r\nLine 1\r\nLine 2")
+}
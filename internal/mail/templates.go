@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template names.
+const (
+	TemplateTest          = "test"
+	TemplateInvite        = "invite"
+	TemplateShare         = "share"
+	TemplatePasswordReset = "password-reset"
+	TemplateBackupFailed  = "backup-failed"
+)
+
+//go:embed templates/*.txt
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.txt"))
+
+// Data represents the values that can be used in email templates.
+type Data struct {
+	SiteTitle string // Title of the instance.
+	SiteUrl   string // Public URL of the instance.
+	Name      string // Display name of the recipient.
+	Username  string // Username of the recipient.
+	Password  string // Initial or new password of the recipient.
+	Sender    string // Display name of the user who sent the email.
+	Title     string // Title of the shared content.
+	Message   string // Personal message from the sender.
+	Url       string // Link to share or sign in.
+	Expires   string // Expiration date of the link.
+	Error     string // Error message of a failed task.
+}
+
+// Render renders the template with the data and returns a message for the recipients.
+// The first line of each template contains the subject, followed by an empty line and the text.
+func Render(name string, data Data, to ...string) (Message, error) {
+	var buf bytes.Buffer
+
+	if err := templates.ExecuteTemplate(&buf, name+".txt", data); err != nil {
+		return Message{}, fmt.Errorf("mail: %s (render %s)", err, name)
+	}
+
+	parts := strings.SplitN(buf.String(), "\n", 2)
+	subject := strings.TrimSpace(strings.TrimPrefix(parts[0], "Subject:"))
+
+	var text string
+
+	if len(parts) > 1 {
+		text = strings.TrimSpace(parts[1]) + "\n"
+	}
+
+	return NewMessage(subject, text, to...), nil
+}
@@ -0,0 +1,82 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	data := Data{SiteTitle: "PhotoPrism", SiteUrl: "https://photos.example.com/"}
+
+	t.Run("Test", func(t *testing.T) {
+		msg, err := Render(TemplateTest, data, "jane@example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"jane@example.com"}, msg.To)
+		assert.Equal(t, "Test email from PhotoPrism", msg.Subject)
+		assert.Contains(t, msg.Text, "https://photos.example.com/")
+	})
+	t.Run("Invite", func(t *testing.T) {
+		d := data
+		d.Name, d.Username, d.Password, d.Url = "Jane", "jane", "secret123", "https://photos.example.com/library/login"
+
+		msg, err := Render(TemplateInvite, d, "jane@example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "You have been invited to PhotoPrism", msg.Subject)
+		assert.Contains(t, msg.Text, "Hello Jane,")
+		assert.Contains(t, msg.Text, "Username: jane\nPassword: secret123\n")
+	})
+	t.Run("Share", func(t *testing.T) {
+		d := data
+		d.Sender, d.Title, d.Url, d.Message = "John", "Holiday", "https://photos.example.com/s/abc/holiday", "Enjoy!"
+
+		msg, err := Render(TemplateShare, d, "jane@example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "John shared Holiday", msg.Subject)
+		assert.Contains(t, msg.Text, "John has shared \"Holiday\" with you on PhotoPrism.\n\nEnjoy!\n")
+		assert.NotContains(t, msg.Text, "expires")
+	})
+	t.Run("PasswordReset", func(t *testing.T) {
+		d := data
+		d.Username, d.Password = "jane", "secret123"
+
+		msg, err := Render(TemplatePasswordReset, d, "jane@example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Your PhotoPrism password has been reset", msg.Subject)
+		assert.Contains(t, msg.Text, "New password: secret123")
+	})
+	t.Run("BackupFailed", func(t *testing.T) {
+		d := data
+		d.Error = "disk full"
+
+		msg, err := Render(TemplateBackupFailed, d, "admin@example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Backup failed on PhotoPrism", msg.Subject)
+		assert.Contains(t, msg.Text, "disk full")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Render("missing", data)
+
+		assert.Error(t, err)
+	})
+}
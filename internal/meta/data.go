@@ -63,6 +63,7 @@ type Data struct {
 	Orientation   int           `meta:"-"`
 	Rotation      int           `meta:"Rotation"`
 	Views         int           `meta:"-"`
+	Favorite      bool          `meta:"-"`
 	Albums        []string      `meta:"-"`
 	Error         error         `meta:"-"`
 	json          map[string]string
@@ -10,13 +10,15 @@ import (
 )
 
 type GPhoto struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Views       int    `json:"imageViews,string"`
-	Geo         GGeo   `json:"geoData"`
-	TakenAt     GTime  `json:"photoTakenTime"`
-	CreatedAt   GTime  `json:"creationTime"`
-	UpdatedAt   GTime  `json:"modificationTime"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Views       int      `json:"imageViews,string"`
+	Geo         GGeo     `json:"geoData"`
+	TakenAt     GTime    `json:"photoTakenTime"`
+	CreatedAt   GTime    `json:"creationTime"`
+	UpdatedAt   GTime    `json:"modificationTime"`
+	Favorited   bool     `json:"favorited"`
+	Albums      []string `json:"albums,omitempty"`
 }
 
 func (m GPhoto) SanitizedTitle() string {
@@ -114,6 +116,17 @@ func (data *Data) GPhoto(jsonData []byte) (err error) {
 		data.Views = p.Views
 	}
 
+	if p.Favorited {
+		data.Favorite = true
+	}
+
+	// Album titles are not part of Google Takeout archives, but added by the Google Photos API sync.
+	for _, album := range p.Albums {
+		if album = SanitizeString(album); album != "" {
+			data.Albums = append(data.Albums, album)
+		}
+	}
+
 	if p.TakenAt.Exists() {
 		if data.TakenAt.IsZero() {
 			data.TakenAt = p.TakenAt.Time()
@@ -505,6 +505,19 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, 0, data.Views)
 	})
 
+	t.Run("gphotos-api.json", func(t *testing.T) {
+		data, err := JSON("testdata/gphotos-api.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Sunset at the beach", data.Description)
+		assert.Equal(t, "2020-06-13 17:30:00 +0000 UTC", data.TakenAt.String())
+		assert.True(t, data.Favorite)
+		assert.Equal(t, []string{"Summer 2020", "Best of"}, data.Albums)
+	})
+
 	t.Run("gphotos-album.json", func(t *testing.T) {
 		data, err := JSON("testdata/gphotos-album.json", "")
 
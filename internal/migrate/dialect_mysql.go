@@ -165,4 +165,10 @@ var DialectMySQL = Migrations{
 		Stage:      "main",
 		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
 	},
+	{
+		ID:         "20230415-000001",
+		Dialect:    "mysql",
+		Stage:      "main",
+		Statements: []string{"CREATE OR REPLACE INDEX idx_photos_labels_label_photo ON photos_labels (label_id, uncertainty, photo_id);", "CREATE OR REPLACE INDEX idx_markers_subj_uid_file ON markers (subj_uid, marker_invalid, file_uid);", "CREATE OR REPLACE INDEX idx_markers_face_id_file ON markers (face_id, marker_invalid, file_uid);", "CREATE OR REPLACE INDEX idx_files_file_uid_photo ON files (file_uid, photo_id);"},
+	},
 }
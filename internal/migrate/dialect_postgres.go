@@ -0,0 +1,42 @@
+package migrate
+
+// Generated code, do not edit.
+
+var DialectPostgres = Migrations{
+	{
+		ID:         "20220329-081000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_media ON files (media_id);"},
+	},
+	{
+		ID:         "20220329-091000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_timeline ON files (time_index);"},
+	},
+	{
+		ID:         "20220421-200000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_missing_root ON files (file_missing, file_root);"},
+	},
+	{
+		ID:         "20230309-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE auth_users SET auth_provider = 'local' WHERE id = 1;", "UPDATE auth_users SET auth_provider = 'none' WHERE id = -1;", "UPDATE auth_users SET auth_provider = 'token' WHERE id = -2;", "UPDATE auth_users SET auth_provider = 'default' WHERE auth_provider = '' OR auth_provider = 'password' OR auth_provider IS NULL;"},
+	},
+	{
+		ID:         "20230313-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
+	},
+	{
+		ID:         "20230415-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_photos_labels_label_photo ON photos_labels (label_id, uncertainty, photo_id);", "CREATE INDEX IF NOT EXISTS idx_markers_subj_uid_file ON markers (subj_uid, marker_invalid, file_uid);", "CREATE INDEX IF NOT EXISTS idx_markers_face_id_file ON markers (face_id, marker_invalid, file_uid);", "CREATE INDEX IF NOT EXISTS idx_files_file_uid_photo ON files (file_uid, photo_id);"},
+	},
+}
@@ -93,4 +93,10 @@ var DialectSQLite3 = Migrations{
 		Stage:      "main",
 		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
 	},
+	{
+		ID:         "20230415-000001",
+		Dialect:    "sqlite3",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_photos_labels_label_photo ON photos_labels (label_id, uncertainty, photo_id);", "CREATE INDEX IF NOT EXISTS idx_markers_subj_uid_file ON markers (subj_uid, marker_invalid, file_uid);", "CREATE INDEX IF NOT EXISTS idx_markers_face_id_file ON markers (face_id, marker_invalid, file_uid);", "CREATE INDEX IF NOT EXISTS idx_files_file_uid_photo ON files (file_uid, photo_id);"},
+	},
 }
@@ -2,11 +2,17 @@ package migrate
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/jinzhu/gorm"
 )
 
+// BatchPrefix marks statements that are repeated until they no longer affect any rows, so that large data
+// migrations can be split into smaller transactions, e.g. by using a LIMIT clause.
+const BatchPrefix = "-- batch"
+
 // Migration represents a database schema migration.
 type Migration struct {
 	ID         string     `gorm:"size:16;primary_key;auto_increment:false;" json:"ID" yaml:"ID"`
@@ -14,6 +20,7 @@ type Migration struct {
 	Stage      string     `gorm:"size:16;" json:"Stage" yaml:"Stage,omitempty"`
 	Error      string     `gorm:"size:255;" json:"Error" yaml:"Error,omitempty"`
 	Source     string     `gorm:"size:16;" json:"Source" yaml:"Source,omitempty"`
+	Executed   int        `gorm:"default:0;" json:"Executed" yaml:"Executed,omitempty"`
 	Statements []string   `gorm:"-" json:"Statements" yaml:"Statements,omitempty"`
 	StartedAt  time.Time  `json:"StartedAt" yaml:"StartedAt,omitempty"`
 	FinishedAt *time.Time `json:"FinishedAt" yaml:"FinishedAt,omitempty"`
@@ -85,6 +92,33 @@ func (m *Migration) Repeat(runFailed bool) bool {
 	return m.RunDuration().Minutes() >= 60
 }
 
+// Info returns a short status description for reports.
+func (m *Migration) Info() string {
+	switch {
+	case m.Error != "":
+		return m.Error
+	case m.Finished():
+		return "OK"
+	case m.StartedAt.IsZero():
+		return "-"
+	case m.Repeat(false):
+		return "Repeat"
+	default:
+		return "Running?"
+	}
+}
+
+// Progress returns the number of executed statements and the total number of statements as string.
+func (m *Migration) Progress() string {
+	if len(m.Statements) == 0 {
+		return "-"
+	} else if m.Finished() {
+		return fmt.Sprintf("%d/%d", len(m.Statements), len(m.Statements))
+	}
+
+	return fmt.Sprintf("%d/%d", m.Executed, len(m.Statements))
+}
+
 // Fail marks the migration as failed by adding an error message and removing the FinishedAt timestamp.
 func (m *Migration) Fail(err error, db *gorm.DB) {
 	if err == nil || db == nil {
@@ -114,24 +148,67 @@ func (m *Migration) Finish(db *gorm.DB) error {
 	return db.Model(m).Updates(Values{"FinishedAt": m.FinishedAt, "Error": m.Error}).Error
 }
 
-// Execute runs the migration.
+// Execute runs the migration, starting with the first statement that has not been executed yet.
 func (m *Migration) Execute(db *gorm.DB) error {
 	if db == nil {
 		return fmt.Errorf("db is nil")
 	}
 
-	for _, s := range m.Statements { //  ADD
-		if err := db.Exec(s).Error; err != nil {
+	for i, s := range m.Statements {
+		// Skip statements executed in a previous run.
+		if i < m.Executed {
+			continue
+		}
+
+		updateProgress(func(p *Progress) {
+			p.Statement = i + 1
+		})
+
+		if err := m.execute(db, s); err != nil {
+			return err
+		}
+
+		// Remember progress so that the migration can be resumed if it is interrupted.
+		m.Executed = i + 1
+
+		if err := db.Model(m).Updates(Values{"Executed": m.Executed}).Error; err != nil {
+			log.Warnf("migrate: %s (update %s progress)", err, m.ID)
+		}
+	}
+
+	return nil
+}
+
+// execute runs a single statement, and repeats it if it is a batch statement.
+func (m *Migration) execute(db *gorm.DB, s string) error {
+	batch := IsBatch(s)
+
+	for {
+		res := db.Exec(s)
+
+		if err := res.Error; err != nil {
 			// Log the errors triggered by ALTER and DROP statements
 			// and otherwise ignore them, since some databases do not
 			// support "IF EXISTS".
 			if IgnoreErr.Matches(s, err.Error()) {
 				log.Tracef("migrate: ignored %s", err)
-			} else {
-				return err
+				return nil
 			}
+
+			return err
+		} else if !batch || res.RowsAffected <= 0 {
+			return nil
 		}
+
+		updateProgress(func(p *Progress) {
+			p.Rows += res.RowsAffected
+		})
+
+		log.Debugf("migrate: %s updated %s", m.ID, english.Plural(int(res.RowsAffected), "row", "rows"))
 	}
+}
 
-	return nil
+// IsBatch checks if the statement should be repeated until it no longer affects any rows.
+func IsBatch(s string) bool {
+	return strings.HasPrefix(strings.TrimSpace(s), BatchPrefix)
 }
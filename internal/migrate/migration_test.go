@@ -0,0 +1,105 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBatch(t *testing.T) {
+	assert.True(t, IsBatch("-- batch\nUPDATE photos SET photo_title = '' WHERE photo_title IS NULL LIMIT 1000;"))
+	assert.True(t, IsBatch("  -- batch\nUPDATE photos SET photo_title = '';"))
+	assert.False(t, IsBatch("UPDATE photos SET photo_title = '';"))
+	assert.False(t, IsBatch(""))
+}
+
+func TestMigration_Info(t *testing.T) {
+	finished := time.Now().UTC()
+
+	assert.Equal(t, "-", (&Migration{}).Info())
+	assert.Equal(t, "OK", (&Migration{StartedAt: finished, FinishedAt: &finished}).Info())
+	assert.Equal(t, "failed", (&Migration{StartedAt: finished, Error: "failed"}).Info())
+	assert.Equal(t, "Running?", (&Migration{StartedAt: finished}).Info())
+	assert.Equal(t, "Repeat", (&Migration{StartedAt: finished.Add(-2 * time.Hour)}).Info())
+}
+
+func TestMigration_Progress(t *testing.T) {
+	finished := time.Now().UTC()
+
+	assert.Equal(t, "-", (&Migration{}).Progress())
+	assert.Equal(t, "1/3", (&Migration{Statements: []string{"a", "b", "c"}, Executed: 1}).Progress())
+	assert.Equal(t, "3/3", (&Migration{Statements: []string{"a", "b", "c"}, FinishedAt: &finished}).Progress())
+}
+
+func TestMigration_Execute(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if err = db.AutoMigrate(&Migration{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, done INTEGER DEFAULT 0)")
+
+	for i := 1; i <= 5; i++ {
+		db.Exec("INSERT INTO items (id) VALUES (?)", i)
+	}
+
+	t.Run("Batch", func(t *testing.T) {
+		m := &Migration{
+			ID:      "20230101-000000",
+			Dialect: "sqlite3",
+			Statements: []string{
+				"-- batch\nUPDATE items SET done = 1 WHERE id IN (SELECT id FROM items WHERE done = 0 LIMIT 2);",
+			},
+		}
+
+		assert.NoError(t, db.Create(m).Error)
+		assert.NoError(t, m.Execute(db))
+		assert.Equal(t, 1, m.Executed)
+
+		count := 0
+		db.Table("items").Where("done = 0").Count(&count)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("Resume", func(t *testing.T) {
+		m := &Migration{
+			ID:      "20230101-000001",
+			Dialect: "sqlite3",
+			Statements: []string{
+				"UPDATE items SET done = 2;",
+				"UPDATE items SET done = done + 1 WHERE id = 1;",
+				"UPDATE items SET done = 5 WHERE invalid = 1;",
+			},
+		}
+
+		assert.NoError(t, db.Create(m).Error)
+		assert.Error(t, m.Execute(db))
+		assert.Equal(t, 2, m.Executed)
+
+		m.Fail(errors.New("invalid column"), db)
+
+		found := Migration{}
+		assert.NoError(t, db.First(&found, "id = ?", m.ID).Error)
+		assert.Equal(t, 2, found.Executed)
+		assert.Equal(t, "invalid column", found.Error)
+
+		// Resume with the last statement.
+		m.Statements[2] = "UPDATE items SET done = 5 WHERE id = 2;"
+		assert.NoError(t, m.Execute(db))
+		assert.Equal(t, 3, m.Executed)
+
+		var done []int
+		db.Table("items").Order("id").Pluck("done", &done)
+		assert.Equal(t, []int{3, 5, 2, 2, 2}, done)
+	})
+}
@@ -79,15 +79,14 @@ func (m *Migrations) Start(db *gorm.DB, opt Options) {
 		log.Tracef("migrate: found %s", english.Plural(len(executed), stage+" migration", stage+" migrations"))
 	}
 
-	// Run migrations.
+	// Find migrations to run.
+	pending := make(Migrations, 0, len(*m))
+
 	for _, migration := range *m {
 		if migration.Skip(opt) {
 			continue
 		}
 
-		start := time.Now()
-		migration.StartedAt = start.UTC().Truncate(time.Second)
-
 		// Excluded?
 		if list.Excludes(opt.Migrations, migration.ID) {
 			log.Tracef("migrate: %s skipped", migration.ID)
@@ -101,12 +100,56 @@ func (m *Migrations) Start(db *gorm.DB, opt Options) {
 				log.Debugf("migrate: %s skipped", migration.ID)
 				continue
 			}
-		} else if err := db.Create(migration).Error; err != nil {
-			// Should not happen.
-			log.Warnf("migrate: creating %s failed with %s [%s]", migration.ID, err, time.Since(start))
+
+			// Resume unfinished migrations with the first statement that has not been executed yet.
+			if !done.Finished() && done.Executed < len(migration.Statements) {
+				migration.Executed = done.Executed
+			}
+		}
+
+		pending = append(pending, migration)
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	updateProgress(func(p *Progress) {
+		*p = Progress{Running: true, Stage: opt.StageName(), Total: len(pending), StartedAt: time.Now().UTC()}
+	})
+
+	defer updateProgress(func(p *Progress) {
+		p.Running = false
+		p.Done = len(pending)
+	})
+
+	// Run migrations.
+	for i, migration := range pending {
+		start := time.Now()
+		migration.StartedAt = start.UTC().Truncate(time.Second)
+
+		updateProgress(func(p *Progress) {
+			p.ID = migration.ID
+			p.Statement = migration.Executed
+			p.Statements = len(migration.Statements)
+			p.Done = i
+		})
+
+		if _, ok := executed[migration.ID]; !ok {
+			if err := db.Create(&migration).Error; err != nil {
+				// Should not happen.
+				log.Warnf("migrate: creating %s failed with %s [%s]", migration.ID, err, time.Since(start))
+				continue
+			}
+		} else if err := db.Model(&migration).Updates(Values{"StartedAt": migration.StartedAt, "Executed": migration.Executed}).Error; err != nil {
+			log.Warnf("migrate: updating %s failed with %s [%s]", migration.ID, err, time.Since(start))
 			continue
 		}
 
+		if migration.Executed > 0 {
+			log.Infof("migrate: resuming %s with statement %d of %d", migration.ID, migration.Executed+1, len(migration.Statements))
+		}
+
 		// Run migration.
 		if err := migration.Execute(db); err != nil {
 			migration.Fail(err, db)
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_files_missing_root ON files (file_missing, file_root);
\ No newline at end of file
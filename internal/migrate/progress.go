@@ -0,0 +1,43 @@
+package migrate
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress represents the progress of the schema migrations that are currently running.
+type Progress struct {
+	Running    bool      `json:"Running"`
+	Stage      string    `json:"Stage,omitempty"`
+	ID         string    `json:"ID,omitempty"`
+	Statement  int       `json:"Statement"`
+	Statements int       `json:"Statements"`
+	Rows       int64     `json:"Rows"`
+	Done       int       `json:"Done"`
+	Total      int       `json:"Total"`
+	StartedAt  time.Time `json:"StartedAt,omitempty"`
+}
+
+var progress = Progress{}
+var progressMutex = sync.Mutex{}
+
+// Current returns the progress of the schema migrations that are currently running.
+func Current() Progress {
+	progressMutex.Lock()
+	defer progressMutex.Unlock()
+
+	return progress
+}
+
+// Running checks if schema migrations are currently running.
+func Running() bool {
+	return Current().Running
+}
+
+// updateProgress changes the current progress with the function passed.
+func updateProgress(f func(p *Progress)) {
+	progressMutex.Lock()
+	defer progressMutex.Unlock()
+
+	f(&progress)
+}
@@ -29,10 +29,11 @@ func Run(db *gorm.DB, opt Options) (err error) {
 	}
 
 	// Run migrations for dialect.
-	if migrations, ok := Dialects[name]; ok && len(migrations) > 0 {
-		migrations.Start(db, opt)
-		return nil
-	} else {
+	if migrations, ok := Dialects[name]; !ok {
 		return fmt.Errorf("migrate: no migrations found for %s", name)
+	} else if len(migrations) > 0 {
+		migrations.Start(db, opt)
 	}
+
+	return nil
 }
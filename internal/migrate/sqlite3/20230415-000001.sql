@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_photos_labels_label_photo ON photos_labels (label_id, uncertainty, photo_id);
+CREATE INDEX IF NOT EXISTS idx_markers_subj_uid_file ON markers (subj_uid, marker_invalid, file_uid);
+CREATE INDEX IF NOT EXISTS idx_markers_face_id_file ON markers (face_id, marker_invalid, file_uid);
+CREATE INDEX IF NOT EXISTS idx_files_file_uid_photo ON files (file_uid, photo_id);
@@ -59,6 +59,7 @@ func Status(db *gorm.DB, ids []string) (status Migrations, err error) {
 			migration.Stage = done.Stage
 			migration.Error = done.Error
 			migration.Source = done.Source
+			migration.Executed = done.Executed
 			migration.StartedAt = done.StartedAt
 			migration.FinishedAt = done.FinishedAt
 			status = append(status, migration)
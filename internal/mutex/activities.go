@@ -2,12 +2,17 @@ package mutex
 
 // Activities that can be started and stopped.
 var (
-	MainWorker   = Activity{}
-	SyncWorker   = Activity{}
-	ShareWorker  = Activity{}
-	MetaWorker   = Activity{}
-	FacesWorker  = Activity{}
-	UpdatePeople = Activity{}
+	MainWorker    = Activity{}
+	SyncWorker    = Activity{}
+	ShareWorker   = Activity{}
+	MetaWorker    = Activity{}
+	FacesWorker   = Activity{}
+	AlbumsWorker  = Activity{}
+	StorageWorker = Activity{}
+	ColdWorker    = Activity{}
+	BackupWorker  = Activity{}
+	TrashWorker   = Activity{}
+	UpdatePeople  = Activity{}
 )
 
 // CancelAll requests to stop all activities.
@@ -18,9 +23,29 @@ func CancelAll() {
 	ShareWorker.Cancel()
 	MetaWorker.Cancel()
 	FacesWorker.Cancel()
+	AlbumsWorker.Cancel()
+	StorageWorker.Cancel()
+	ColdWorker.Cancel()
+	BackupWorker.Cancel()
+	TrashWorker.Cancel()
 }
 
 // IndexWorkersRunning checks if a worker is currently running.
 func IndexWorkersRunning() bool {
 	return MainWorker.Running() || SyncWorker.Running() || ShareWorker.Running() || MetaWorker.Running() || FacesWorker.Running()
 }
+
+// Workers maps the names of background workers to their activities.
+var Workers = map[string]*Activity{
+	"index":   &MainWorker,
+	"sync":    &SyncWorker,
+	"share":   &ShareWorker,
+	"meta":    &MetaWorker,
+	"faces":   &FacesWorker,
+	"albums":  &AlbumsWorker,
+	"storage": &StorageWorker,
+	"cold":    &ColdWorker,
+	"backup":  &BackupWorker,
+	"trash":   &TrashWorker,
+	"people":  &UpdatePeople,
+}
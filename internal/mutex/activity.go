@@ -3,15 +3,33 @@ package mutex
 import (
 	"errors"
 	"sync"
+	"time"
 )
 
 // Activity represents work that can be started and stopped.
 type Activity struct {
 	busy     bool
 	canceled bool
+	paused   bool
+	started  time.Time
+	duration time.Duration
+	runs     int
+	err      string
+	cond     *sync.Cond
 	mutex    sync.Mutex
 }
 
+// ActivityInfo represents the current state of an Activity.
+type ActivityInfo struct {
+	Running  bool          `json:"Running"`
+	Canceled bool          `json:"Canceled"`
+	Paused   bool          `json:"Paused"`
+	Started  time.Time     `json:"Started,omitempty"`
+	Duration time.Duration `json:"Duration"`
+	Runs     int           `json:"Runs"`
+	Error    string        `json:"Error,omitempty"`
+}
+
 // Running checks if the Activity is currently running.
 func (b *Activity) Running() bool {
 	b.mutex.Lock()
@@ -35,6 +53,8 @@ func (b *Activity) Start() error {
 
 	b.busy = true
 	b.canceled = false
+	b.started = time.Now()
+	b.err = ""
 
 	return nil
 }
@@ -44,6 +64,11 @@ func (b *Activity) Stop() {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
+	if b.busy {
+		b.duration = time.Since(b.started)
+		b.runs++
+	}
+
 	b.busy = false
 	b.canceled = false
 }
@@ -56,6 +81,11 @@ func (b *Activity) Cancel() {
 	if b.busy {
 		b.canceled = true
 	}
+
+	// Wake up paused workers so that they can stop.
+	if b.cond != nil {
+		b.cond.Broadcast()
+	}
 }
 
 // Canceled marks the Activity as stopped.
@@ -65,3 +95,81 @@ func (b *Activity) Canceled() bool {
 
 	return b.canceled
 }
+
+// Pause requests to pause the Activity until it is resumed.
+func (b *Activity) Pause() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.paused = true
+}
+
+// Resume continues a paused Activity.
+func (b *Activity) Resume() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.paused = false
+
+	if b.cond != nil {
+		b.cond.Broadcast()
+	}
+}
+
+// Paused checks if the Activity has been paused.
+func (b *Activity) Paused() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.paused
+}
+
+// Wait blocks while the Activity is paused and returns false if it has been canceled.
+func (b *Activity) Wait() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.paused && b.cond == nil {
+		b.cond = sync.NewCond(&b.mutex)
+	}
+
+	for b.paused && !b.canceled {
+		b.cond.Wait()
+	}
+
+	return !b.canceled
+}
+
+// Fail reports an error that occurred during the last run.
+func (b *Activity) Fail(err error) {
+	if err == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.err = err.Error()
+}
+
+// Info returns the current state of the Activity.
+func (b *Activity) Info() ActivityInfo {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	info := ActivityInfo{
+		Running:  b.busy,
+		Canceled: b.canceled,
+		Paused:   b.paused,
+		Started:  b.started,
+		Duration: b.duration,
+		Runs:     b.runs,
+		Error:    b.err,
+	}
+
+	if b.busy {
+		info.Duration = time.Since(b.started)
+	}
+
+	return info
+}
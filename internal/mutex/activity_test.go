@@ -1,7 +1,9 @@
 package mutex
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -39,3 +41,60 @@ func TestActivity_Start(t *testing.T) {
 		assert.Nil(t, b.Start())
 	})
 }
+
+func TestActivity_Pause(t *testing.T) {
+	b := Activity{}
+
+	assert.Nil(t, b.Start())
+	assert.True(t, b.Wait())
+
+	b.Pause()
+	assert.True(t, b.Paused())
+
+	done := make(chan bool)
+
+	go func() {
+		done <- b.Wait()
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("paused activity should wait")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	b.Resume()
+	assert.True(t, <-done)
+	assert.False(t, b.Paused())
+
+	b.Pause()
+
+	go func() {
+		done <- b.Wait()
+	}()
+
+	b.Cancel()
+	assert.False(t, <-done)
+	b.Stop()
+	b.Resume()
+}
+
+func TestActivity_Info(t *testing.T) {
+	b := Activity{}
+
+	assert.Nil(t, b.Start())
+	assert.True(t, b.Info().Running)
+	b.Fail(errors.New("disk full"))
+	b.Stop()
+
+	info := b.Info()
+
+	assert.False(t, info.Running)
+	assert.Equal(t, 1, info.Runs)
+	assert.Equal(t, "disk full", info.Error)
+	assert.False(t, info.Started.IsZero())
+
+	assert.Nil(t, b.Start())
+	assert.Equal(t, "", b.Info().Error)
+	b.Stop()
+}
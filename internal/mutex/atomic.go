@@ -7,3 +7,6 @@ import (
 // Restart signals that the application should be restarted,
 // e.g. after an update or a config changes.
 var Restart = atomic.Bool{}
+
+// Requests counts the API requests that are currently being processed.
+var Requests = atomic.Int32{}
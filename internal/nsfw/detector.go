@@ -27,6 +27,11 @@ func New(modelPath string) *Detector {
 	return &Detector{modelPath: modelPath, modelTags: []string{"serve"}}
 }
 
+// Init loads the TensorFlow model, so that it does not need to be loaded on first use.
+func (t *Detector) Init() error {
+	return t.loadModel()
+}
+
 // File returns matching labels for a jpeg media file.
 func (t *Detector) File(filename string) (result Labels, err error) {
 	if fs.MimeType(filename) != "image/jpeg" {
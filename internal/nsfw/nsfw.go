@@ -61,3 +61,22 @@ func (l *Labels) NSFW(threshold float32) bool {
 
 	return false
 }
+
+// Score returns the highest probability of offensive content, or 0 if the image is probably neutral.
+func (l *Labels) Score() float32 {
+	if l.Neutral > 0.25 {
+		return 0
+	}
+
+	score := l.Porn
+
+	if l.Sexy > score {
+		score = l.Sexy
+	}
+
+	if l.Hentai > score {
+		score = l.Hentai
+	}
+
+	return score
+}
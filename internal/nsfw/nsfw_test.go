@@ -121,3 +121,17 @@ func TestNSFW(t *testing.T) {
 	assert.Equal(t, false, drawing.NSFW(ThresholdHigh))
 	assert.Equal(t, true, max.NSFW(ThresholdHigh))
 }
+
+func TestLabels_Score(t *testing.T) {
+	porn := Labels{0, 0, 0.11, 0.88, 0}
+	sexy := Labels{0, 0, 0.2, 0.59, 0.98}
+	drawing := Labels{0.999, 0, 0, 0, 0}
+	hentai := Labels{0, 0.80, 0.2, 0, 0}
+	neutral := Labels{0, 0, 0.9, 0.99, 0}
+
+	assert.Equal(t, float32(0.88), porn.Score())
+	assert.Equal(t, float32(0.98), sexy.Score())
+	assert.Equal(t, float32(0), drawing.Score())
+	assert.Equal(t, float32(0.80), hentai.Score())
+	assert.Equal(t, float32(0), neutral.Score())
+}
@@ -0,0 +1,100 @@
+package ocr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// DefaultLanguages are the Tesseract languages used if none are configured.
+const DefaultLanguages = "eng"
+
+// Tesseract extracts text from images with the Tesseract command-line tool.
+type Tesseract struct {
+	bin       string
+	languages string
+	env       []string
+}
+
+// Languages normalizes a list of Tesseract language codes, which are separated by "+", e.g. "eng+deu".
+func Languages(s string) string {
+	s = strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == '+' || r == ',' || r == ' '
+	}), "+")
+
+	if s == "" {
+		return DefaultLanguages
+	}
+
+	return s
+}
+
+// NewTesseract returns a new Tesseract text extractor for the specified languages.
+func NewTesseract(bin, languages, homePath string) *Tesseract {
+	t := &Tesseract{bin: bin, languages: Languages(languages)}
+
+	if homePath != "" {
+		t.env = []string{fmt.Sprintf("HOME=%s", homePath)}
+	}
+
+	return t
+}
+
+// Languages returns the Tesseract language codes.
+func (t *Tesseract) Languages() string {
+	return t.languages
+}
+
+// Command returns the command for extracting text from the specified image file.
+func (t *Tesseract) Command(fileName string) (*exec.Cmd, error) {
+	if t.bin == "" {
+		return nil, fmt.Errorf("tesseract is not available")
+	} else if fileName == "" {
+		return nil, fmt.Errorf("empty input filename")
+	}
+
+	cmd := exec.Command(t.bin, fileName, "stdout", "-l", t.languages)
+
+	if len(t.env) > 0 {
+		cmd.Env = t.env
+	}
+
+	return cmd, nil
+}
+
+// File returns the cleaned up text found in the specified image file.
+func (t *Tesseract) File(fileName string) (string, error) {
+	if !fs.FileExists(fileName) {
+		return "", fmt.Errorf("file %s not found", clean.Log(filepath.Base(fileName)))
+	}
+
+	cmd, err := t.Command(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	if err = cmd.Run(); err != nil {
+		if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
+			return "", errors.New(errStr)
+		}
+
+		return "", err
+	}
+
+	return Clean(stdout.String()), nil
+}
@@ -0,0 +1,50 @@
+package ocr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguages(t *testing.T) {
+	assert.Equal(t, DefaultLanguages, Languages(""))
+	assert.Equal(t, "deu", Languages(" deu "))
+	assert.Equal(t, "eng+deu+fra", Languages("eng,deu fra"))
+}
+
+func TestNewTesseract(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, DefaultLanguages, NewTesseract("tesseract", "", "").Languages())
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		assert.Equal(t, "eng+deu+fra", NewTesseract("tesseract", "eng, deu+ fra", "").Languages())
+	})
+}
+
+func TestTesseract_Command(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := NewTesseract("/usr/bin/tesseract", "eng+deu", "/tmp/cache").Command("/photos/sign.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/usr/bin/tesseract /photos/sign.jpg stdout -l eng+deu", cmd.String())
+		assert.Equal(t, []string{"HOME=/tmp/cache"}, cmd.Env)
+	})
+	t.Run("NoBin", func(t *testing.T) {
+		_, err := NewTesseract("", "", "").Command("/photos/sign.jpg")
+		assert.Error(t, err)
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		_, err := NewTesseract("tesseract", "", "").Command("")
+		assert.Error(t, err)
+	})
+}
+
+func TestTesseract_File(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewTesseract("tesseract", "", "").File("testdata/missing.jpg")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package ocr
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// MinLineLength is the minimum number of letters and digits a line must contain to be kept.
+const MinLineLength = 3
+
+// Clean removes noise from recognized text, such as empty lines and lines that consist mostly of
+// symbols, collapses white space, and clips the result to the maximum text length.
+func Clean(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	var lines []string
+
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == '\r' || r == '\f' }) {
+		line = strings.Join(strings.Fields(line), " ")
+
+		if ValidLine(line) {
+			lines = append(lines, line)
+		}
+	}
+
+	return txt.Clip(strings.Join(lines, "\n"), txt.ClipLongText)
+}
+
+// ValidLine tests if a line of recognized text contains enough letters and digits to be meaningful.
+func ValidLine(line string) bool {
+	var alnum, other int
+
+	for _, r := range line {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			alnum++
+		case unicode.IsSpace(r):
+		default:
+			other++
+		}
+	}
+
+	return alnum >= MinLineLength && alnum > other
+}
@@ -0,0 +1,35 @@
+package ocr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+func TestClean(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", Clean(""))
+	})
+	t.Run("Sign", func(t *testing.T) {
+		assert.Equal(t, "NO PARKING\nTow-away zone", Clean("  NO   PARKING \n\n Tow-away\tzone\n\f"))
+	})
+	t.Run("Noise", func(t *testing.T) {
+		assert.Equal(t, "Invoice 2023-01", Clean("|| ~ ,\n  Invoice 2023-01\n—\n_-=_ a\n"))
+	})
+	t.Run("Clip", func(t *testing.T) {
+		result := Clean(strings.Repeat("lorem ipsum ", 1000))
+		assert.LessOrEqual(t, len([]rune(result)), txt.ClipLongText)
+	})
+}
+
+func TestValidLine(t *testing.T) {
+	assert.True(t, ValidLine("Exit"))
+	assert.True(t, ValidLine("Tel: 555-1234"))
+	assert.True(t, ValidLine("Straße"))
+	assert.False(t, ValidLine("ab"))
+	assert.False(t, ValidLine("|/ \\ ~~ ab!"))
+	assert.False(t, ValidLine(""))
+}
@@ -3,11 +3,15 @@ package photoprism
 import (
 	"path/filepath"
 	"regexp"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
 )
 
 // BackupAlbums creates a YAML file backup of all albums.
@@ -64,16 +68,7 @@ func RestoreAlbums(backupPath string, force bool) (count int, result error) {
 		return count, nil
 	}
 
-	if !fs.PathExists(backupPath) {
-		backupPath = c.AlbumsPath()
-	}
-
-	albums, err := filepath.Glob(regexp.QuoteMeta(backupPath) + "/**/*.yml")
-
-	if oAlbums, oErr := filepath.Glob(regexp.QuoteMeta(c.OriginalsAlbumsPath()) + "/**/*.yml"); oErr == nil {
-		err = nil
-		albums = append(albums, oAlbums...)
-	}
+	albums, err := albumYamlFiles(backupPath)
 
 	if err != nil {
 		return count, err
@@ -102,3 +97,154 @@ func RestoreAlbums(backupPath string, force bool) (count int, result error) {
 
 	return count, result
 }
+
+// RestoreAlbumFiles restores album YAML file backups and returns the changes. Existing albums are skipped
+// unless opt.Merge is set, in which case missing pictures and empty fields are restored from the backup.
+// With opt.Dry, the changes are reported without updating the index.
+func RestoreAlbumFiles(backupPath string, opt RestoreOptions) (changes RestoreChanges, err error) {
+	albums, err := albumYamlFiles(backupPath)
+
+	if err != nil {
+		return changes, err
+	}
+
+	for _, fileName := range albums {
+		changes = append(changes, restoreAlbumFile(fileName, opt))
+	}
+
+	return changes, nil
+}
+
+// albumYamlFiles returns the album YAML files in the backup path and the originals folder.
+func albumYamlFiles(backupPath string) ([]string, error) {
+	c := Config()
+
+	if !fs.PathExists(backupPath) {
+		backupPath = c.AlbumsPath()
+	}
+
+	albums, err := filepath.Glob(regexp.QuoteMeta(backupPath) + "/**/*.yml")
+
+	if oAlbums, oErr := filepath.Glob(regexp.QuoteMeta(c.OriginalsAlbumsPath()) + "/**/*.yml"); oErr == nil {
+		err = nil
+		albums = append(albums, oAlbums...)
+	}
+
+	return albums, err
+}
+
+// restoreAlbumFile restores a single album YAML file.
+func restoreAlbumFile(fileName string, opt RestoreOptions) (change RestoreChange) {
+	a := entity.Album{}
+
+	if err := a.LoadFromYaml(fileName); err != nil {
+		return RestoreChange{Name: filepath.Base(fileName), Action: RestoreFailed, Details: err.Error()}
+	}
+
+	change = RestoreChange{Type: a.AlbumType, UID: a.AlbumUID, Name: a.AlbumTitle}
+
+	if a.AlbumType == "" || len(a.Photos) == 0 && a.AlbumFilter == "" {
+		change.Action, change.Details = RestoreSkipped, "empty"
+		return change
+	}
+
+	found := a.Find()
+
+	if found == nil {
+		change.Action, change.Details = RestoreAdded, english.Plural(len(a.Photos), "picture", "pictures")
+
+		if opt.Dry {
+			return change
+		} else if err := a.Create(); err != nil {
+			change.Action, change.Details = RestoreFailed, err.Error()
+		}
+
+		return change
+	}
+
+	change.UID, change.Name = found.AlbumUID, found.AlbumTitle
+
+	if !opt.Merge {
+		change.Action, change.Details = RestoreSkipped, "already exists"
+		return change
+	}
+
+	// Restore empty fields.
+	values := entity.Values{}
+	var details []string
+
+	for _, f := range []struct{ name, col, current, backup string }{
+		{"caption", "album_caption", found.AlbumCaption, a.AlbumCaption},
+		{"description", "album_description", found.AlbumDescription, a.AlbumDescription},
+		{"notes", "album_notes", found.AlbumNotes, a.AlbumNotes},
+		{"location", "album_location", found.AlbumLocation, a.AlbumLocation},
+		{"category", "album_category", found.AlbumCategory, a.AlbumCategory},
+	} {
+		if f.current == "" && f.backup != "" {
+			values[f.col] = f.backup
+			details = append(details, f.name)
+		}
+	}
+
+	// Restore pictures that are missing in the album. Pictures that have been removed
+	// from the album since the backup was created are not added again.
+	var missing entity.PhotoAlbums
+
+	if found.AlbumType == entity.AlbumManual {
+		var uids, existing, photos []string
+
+		for _, p := range a.Photos {
+			if !p.Hidden {
+				uids = append(uids, p.PhotoUID)
+			}
+		}
+
+		if len(uids) > 0 {
+			if err := entity.UnscopedDb().Model(&entity.PhotoAlbum{}).Where("album_uid = ?", found.AlbumUID).Pluck("photo_uid", &existing).Error; err != nil {
+				change.Action, change.Details = RestoreFailed, err.Error()
+				return change
+			} else if err = entity.UnscopedDb().Model(&entity.Photo{}).Where("photo_uid IN (?)", uids).Pluck("photo_uid", &photos).Error; err != nil {
+				change.Action, change.Details = RestoreFailed, err.Error()
+				return change
+			}
+		}
+
+		for _, p := range a.Photos {
+			if !p.Hidden && list.Contains(photos, p.PhotoUID) && !list.Contains(existing, p.PhotoUID) {
+				p.AlbumUID = found.AlbumUID
+				missing = append(missing, p)
+			}
+		}
+
+		if len(missing) > 0 {
+			details = append(details, english.Plural(len(missing), "picture", "pictures"))
+		}
+	}
+
+	if len(details) == 0 {
+		change.Action, change.Details = RestoreSkipped, "no changes"
+		return change
+	}
+
+	change.Action, change.Details = RestoreMerged, strings.Join(details, ", ")
+
+	if opt.Dry {
+		return change
+	}
+
+	if len(values) > 0 {
+		if err := found.Updates(values); err != nil {
+			change.Action, change.Details = RestoreFailed, err.Error()
+			return change
+		}
+	}
+
+	for i := range missing {
+		if err := missing[i].Create(); err != nil {
+			change.Action, change.Details = RestoreFailed, err.Error()
+			return change
+		}
+	}
+
+	return change
+}
@@ -0,0 +1,110 @@
+package photoprism
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/dem"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// AltitudeOptions represents options for setting missing altitudes from elevation data.
+type AltitudeOptions struct {
+	Sidecar bool // Update YAML sidecar files.
+}
+
+// AltitudeResult represents the outcome of BackfillAltitude().
+type AltitudeResult struct {
+	Updated int
+	Skipped int
+}
+
+// BackfillAltitude sets the altitude of pictures with GPS coordinates but no altitude based on the
+// offline elevation data in the configured DEM path, e.g. for filtering hiking pictures by elevation.
+func BackfillAltitude(conf *config.Config, opt AltitudeOptions) (result AltitudeResult, err error) {
+	if conf == nil {
+		return result, fmt.Errorf("config is nil")
+	}
+
+	data := dem.NewDataset(conf.DEMPath())
+
+	if data.Path() == "" {
+		return result, fmt.Errorf("dem path not configured")
+	} else if !data.Exists() {
+		return result, fmt.Errorf("dem path %s not found", clean.Log(data.Path()))
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	start := time.Now()
+	limit := 500
+
+	for {
+		// Updated pictures no longer match, so skipped pictures are excluded with the offset.
+		photos, err := query.PhotosWithoutAltitude(limit, result.Skipped)
+
+		if err != nil {
+			return result, err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		for i := range photos {
+			if mutex.MainWorker.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			p := &photos[i]
+
+			if alt, ok := data.Elevation(float64(p.PhotoLat), float64(p.PhotoLng)); !ok || math.Round(alt) == 0 {
+				result.Skipped++
+			} else if err := altitudePhoto(conf, p, int(math.Round(alt)), opt.Sidecar); err != nil {
+				log.Warnf("altitude: %s in %s", err, p.String())
+				result.Skipped++
+			} else {
+				log.Debugf("altitude: %s is at %d m", p.String(), p.PhotoAltitude)
+				result.Updated++
+			}
+		}
+	}
+
+	log.Infof("altitude: updated %s, %d skipped [%s]", english.Plural(result.Updated, "picture", "pictures"), result.Skipped, time.Since(start))
+
+	return result, nil
+}
+
+// altitudePhoto sets the photo altitude in meters and saves it.
+func altitudePhoto(conf *config.Config, p *entity.Photo, altitude int, sidecar bool) error {
+	if err := p.Update("PhotoAltitude", altitude); err != nil {
+		return err
+	}
+
+	p.PhotoAltitude = altitude
+
+	if !sidecar {
+		return nil
+	}
+
+	// Update YAML sidecar file.
+	fileName := p.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+
+	if err := p.SaveAsYaml(fileName); err != nil {
+		log.Errorf("altitude: %s (update yaml)", err)
+	} else {
+		log.Debugf("altitude: updated yaml file %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	return nil
+}
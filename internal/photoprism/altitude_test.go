@@ -0,0 +1,47 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestBackfillAltitude(t *testing.T) {
+	t.Run("NoConfig", func(t *testing.T) {
+		_, err := BackfillAltitude(nil, AltitudeOptions{})
+
+		assert.Error(t, err)
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		conf := config.TestConfig()
+		conf.Options().DEMPath = ""
+
+		_, err := BackfillAltitude(conf, AltitudeOptions{})
+
+		assert.EqualError(t, err, "dem path not configured")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		conf := config.TestConfig()
+		conf.Options().DEMPath = "/does/not/exist"
+		defer func() { conf.Options().DEMPath = "" }()
+
+		_, err := BackfillAltitude(conf, AltitudeOptions{})
+
+		assert.Error(t, err)
+	})
+	t.Run("EmptyDataset", func(t *testing.T) {
+		conf := config.TestConfig()
+		conf.Options().DEMPath = t.TempDir()
+		defer func() { conf.Options().DEMPath = "" }()
+
+		result, err := BackfillAltitude(conf, AltitudeOptions{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, result.Updated)
+	})
+}
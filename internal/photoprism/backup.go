@@ -0,0 +1,132 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// BackupIndexFileName returns the default index backup file name for the current day.
+func BackupIndexFileName(backupPath string) string {
+	return filepath.Join(backupPath, time.Now().UTC().Format("2006-01-02")+".sql")
+}
+
+// RotateIndexBackups deletes the oldest index backup files in the specified folder,
+// so that no more than the specified number is kept.
+func RotateIndexBackups(backupPath string, retain int) (deleted int, err error) {
+	if retain <= 0 {
+		return 0, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), "*.sql"))
+
+	if err != nil || len(matches) <= retain {
+		return 0, err
+	}
+
+	// Backup file names start with the date, so they can be sorted by name.
+	sort.Strings(matches)
+
+	for _, fileName := range matches[:len(matches)-retain] {
+		if err = os.Remove(fileName); err != nil {
+			log.Warnf("backup: %s (delete %s)", err, clean.Log(filepath.Base(fileName)))
+			continue
+		}
+
+		log.Infof("backup: deleted %s", clean.Log(filepath.Base(fileName)))
+		deleted++
+	}
+
+	return deleted, err
+}
+
+// BackupIndex creates an index database backup in the specified file, or writes it to stdout if the name is "-".
+func BackupIndex(conf *config.Config, fileName string, force bool) (err error) {
+	if fileName != "-" {
+		if _, err = os.Stat(fileName); err == nil && !force {
+			return fmt.Errorf("%s already exists", clean.Log(fileName))
+		} else if err == nil {
+			log.Warnf("replacing existing backup")
+		}
+
+		// Create backup directory if not exists.
+		if dir := filepath.Dir(fileName); dir != "." {
+			if err = os.MkdirAll(dir, fs.ModeDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	var cmd *exec.Cmd
+
+	switch conf.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		cmd = exec.Command(
+			conf.MysqldumpBin(),
+			"--protocol", "tcp",
+			"-h", conf.DatabaseHost(),
+			"-P", conf.DatabasePortString(),
+			"-u", conf.DatabaseUser(),
+			"-p"+conf.DatabasePassword(),
+			conf.DatabaseName(),
+		)
+	case config.Postgres:
+		cmd = exec.Command(
+			conf.PgDumpBin(),
+			"--clean",
+			"--if-exists",
+			"--no-owner",
+			"-h", conf.DatabaseHost(),
+			"-p", conf.DatabasePortString(),
+			"-U", conf.DatabaseUser(),
+			conf.DatabaseName(),
+		)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+conf.DatabasePassword())
+	case config.SQLite3:
+		cmd = exec.Command(
+			conf.SqliteBin(),
+			conf.DatabaseFile(),
+			".dump",
+		)
+	default:
+		return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
+	}
+
+	// Write to stdout or file.
+	var f *os.File
+	if fileName == "-" {
+		log.Infof("writing backup to stdout")
+		f = os.Stdout
+	} else if f, err = os.OpenFile(fileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile); err != nil {
+		return fmt.Errorf("failed to create %s: %s", clean.Log(fileName), err)
+	} else {
+		log.Infof("writing backup to %s", clean.Log(fileName))
+		defer f.Close()
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = f
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run backup command.
+	if err = cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return errors.New(stderr.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,340 @@
+package photoprism
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/crypt"
+	ppfs "github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Remote folders for incremental originals backups. File contents are stored only once in the data
+// folder, with their SHA1 hash as key, and each snapshot lists the files with their hashes.
+const (
+	backupOriginalsFolder = "originals"
+	backupDataFolder      = backupOriginalsFolder + "/data"
+	backupSnapshotsFolder = backupOriginalsFolder + "/snapshots"
+)
+
+// BackupSnapshot represents the state of the originals folder at the time of a backup.
+type BackupSnapshot struct {
+	Created time.Time            `json:"Created"`
+	Files   []BackupSnapshotFile `json:"Files"`
+}
+
+// BackupSnapshotFile represents a file in a snapshot.
+type BackupSnapshotFile struct {
+	Name    string    `json:"Name"`
+	Hash    string    `json:"Hash"`
+	Size    int64     `json:"Size"`
+	ModTime time.Time `json:"ModTime"`
+}
+
+// BackupOriginalsResult represents the number of files in a snapshot and how many of them were uploaded.
+type BackupOriginalsResult struct {
+	Snapshot string `json:"Snapshot"`
+	Files    int    `json:"Files"`
+	Uploaded int    `json:"Uploaded"`
+	Size     int64  `json:"Size"`
+	Failed   int    `json:"Failed"`
+}
+
+// RestoreOriginalsResult represents the number of restored and skipped files.
+type RestoreOriginalsResult struct {
+	Snapshot string `json:"Snapshot"`
+	Restored int    `json:"Restored"`
+	Skipped  int    `json:"Skipped"`
+	Failed   int    `json:"Failed"`
+}
+
+// dataKey returns the remote key of the file contents with the specified hash.
+func dataKey(hash string) string {
+	return vfs.Key(backupDataFolder, hash[:2], hash)
+}
+
+// dataHashes returns the hashes of the file contents that have already been uploaded.
+func (w *RemoteBackup) dataHashes() (map[string]string, error) {
+	files, err := w.backend.List(backupDataFolder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(files))
+
+	for _, f := range files {
+		result[strings.TrimSuffix(path.Base(f.Key), crypt.Ext)] = f.Key
+	}
+
+	return result, nil
+}
+
+// Snapshot downloads the snapshot with the specified name, or the latest snapshot if the name is empty.
+func (w *RemoteBackup) Snapshot(name string) (key string, result BackupSnapshot, err error) {
+	if w == nil || w.backend == nil {
+		return key, result, errors.New("remote backup is not configured")
+	}
+
+	if key, err = w.latest(backupSnapshotsFolder, name); err != nil {
+		return key, result, err
+	}
+
+	tmp, err := os.CreateTemp("", "photoprism-snapshot-*.json")
+
+	if err != nil {
+		return key, result, err
+	}
+
+	fileName := tmp.Name()
+	_ = tmp.Close()
+
+	defer os.Remove(fileName)
+
+	if err = w.get(key, fileName); err != nil {
+		return key, result, err
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return key, result, err
+	} else if err = json.Unmarshal(data, &result); err != nil {
+		return key, result, fmt.Errorf("invalid snapshot %s (%s)", clean.Log(key), err)
+	}
+
+	return key, result, nil
+}
+
+// BackupOriginals uploads the originals that have been added or changed since the last backup and creates
+// a new snapshot. Files whose size and modification time did not change are not hashed again, so that
+// backups of large libraries are fast and do not upload unchanged data.
+func (w *RemoteBackup) BackupOriginals() (result BackupOriginalsResult, err error) {
+	if w == nil || w.backend == nil {
+		return result, errors.New("remote backup is not configured")
+	}
+
+	// Reuse the hashes from the latest snapshot if the files have not changed.
+	previous := make(map[string]BackupSnapshotFile)
+
+	if _, snapshot, err := w.Snapshot(""); err == nil {
+		for _, f := range snapshot.Files {
+			previous[f.Name] = f
+		}
+	}
+
+	uploaded, err := w.dataHashes()
+
+	if err != nil {
+		return result, err
+	}
+
+	originalsPath := w.conf.OriginalsPath()
+	snapshot := BackupSnapshot{Created: time.Now().UTC()}
+
+	err = filepath.WalkDir(originalsPath, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if mutex.BackupWorker.Canceled() {
+			return errors.New("canceled")
+		} else if fileName != originalsPath && ppfs.FileNameHidden(d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(originalsPath, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		file := BackupSnapshotFile{Name: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime().UTC()}
+
+		if prev, ok := previous[file.Name]; ok && prev.Size == file.Size && prev.ModTime.Equal(file.ModTime) {
+			file.Hash = prev.Hash
+		} else if file.Hash = ppfs.Hash(fileName); file.Hash == "" {
+			log.Warnf("backup: failed to read %s", clean.Log(file.Name))
+			result.Failed++
+			return nil
+		}
+
+		if _, ok := uploaded[file.Hash]; !ok {
+			key, err := w.put(fileName, dataKey(file.Hash))
+
+			if err != nil {
+				log.Warnf("backup: %s", err)
+				result.Failed++
+				return nil
+			}
+
+			uploaded[file.Hash] = key
+			result.Uploaded++
+			result.Size += file.Size
+		}
+
+		snapshot.Files = append(snapshot.Files, file)
+
+		return nil
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Files = len(snapshot.Files)
+
+	// Save the list of files as new snapshot.
+	data, err := json.Marshal(snapshot)
+
+	if err != nil {
+		return result, err
+	}
+
+	tmp, err := os.CreateTemp("", "photoprism-snapshot-*.json")
+
+	if err != nil {
+		return result, err
+	}
+
+	fileName := tmp.Name()
+
+	defer os.Remove(fileName)
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return result, err
+	} else if err = tmp.Close(); err != nil {
+		return result, err
+	}
+
+	if result.Snapshot, err = w.put(fileName, vfs.Key(backupSnapshotsFolder, snapshot.Created.Format("2006-01-02T150405Z")+".json")); err != nil {
+		return result, err
+	}
+
+	log.Infof("backup: uploaded %d of %d originals to %s", result.Uploaded, result.Files, clean.Log(w.backend.String()))
+
+	return result, nil
+}
+
+// RestoreOriginals downloads the files in the specified snapshot, or the latest snapshot if the name is empty,
+// to the destination folder. Existing files are only replaced if force is true and their contents differ.
+func (w *RemoteBackup) RestoreOriginals(name, dest string, force bool) (result RestoreOriginalsResult, err error) {
+	key, snapshot, err := w.Snapshot(name)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Snapshot = key
+
+	uploaded, err := w.dataHashes()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range snapshot.Files {
+		if file.Name == "" || file.Hash == "" || strings.Contains(file.Name, "..") {
+			continue
+		}
+
+		fileName := filepath.Join(dest, filepath.FromSlash(file.Name))
+
+		if ppfs.FileExists(fileName) && (!force || ppfs.Hash(fileName) == file.Hash) {
+			result.Skipped++
+			continue
+		}
+
+		contentKey, ok := uploaded[file.Hash]
+
+		if !ok {
+			log.Warnf("restore: contents of %s not found", clean.Log(file.Name))
+			result.Failed++
+			continue
+		}
+
+		if err = w.get(contentKey, fileName); err != nil {
+			log.Warnf("restore: %s", err)
+			result.Failed++
+			continue
+		}
+
+		// Restore the modification time, so that the file is not considered as changed.
+		if err = os.Chtimes(fileName, file.ModTime, file.ModTime); err != nil {
+			log.Debugf("restore: %s", err)
+		}
+
+		result.Restored++
+	}
+
+	log.Infof("restore: restored %d of %d originals from %s", result.Restored, len(snapshot.Files), clean.Log(key))
+
+	return result, nil
+}
+
+// pruneOriginals deletes file contents that are no longer part of any snapshot.
+func (w *RemoteBackup) pruneOriginals() (deleted int, err error) {
+	keys, err := w.Backups(backupSnapshotsFolder)
+
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+
+	used := make(map[string]bool)
+
+	for _, key := range keys {
+		_, snapshot, err := w.Snapshot(path.Base(key))
+
+		// Don't delete anything if a snapshot cannot be read.
+		if err != nil {
+			return 0, err
+		}
+
+		for _, f := range snapshot.Files {
+			used[f.Hash] = true
+		}
+	}
+
+	uploaded, err := w.dataHashes()
+
+	if err != nil {
+		return 0, err
+	}
+
+	for hash, key := range uploaded {
+		if used[hash] {
+			continue
+		} else if err = w.backend.Remove(key); err != nil {
+			log.Warnf("backup: %s (delete %s)", err, clean.Log(key))
+			continue
+		}
+
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Infof("backup: deleted %d files that are no longer part of a snapshot", deleted)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,107 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestRemoteBackup_BackupOriginals(t *testing.T) {
+	conf := config.TestConfig()
+	originalsPath := conf.OriginalsPath()
+	dir := t.TempDir()
+
+	conf.Options().OriginalsPath = dir
+	defer func() { conf.Options().OriginalsPath = originalsPath }()
+
+	backend, err := vfs.NewLocal(t.TempDir())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewRemoteBackup(conf, backend)
+
+	files := map[string]string{
+		"2023/05/a.jpg":    "jpeg",
+		"2023/05/copy.jpg": "jpeg",
+		"b.heic":           "heic",
+		".hidden/c.jpg":    "hidden",
+	}
+
+	for name, data := range files {
+		fileName := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err = os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Initial", func(t *testing.T) {
+		result, err := w.BackupOriginals()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Files)
+		assert.Equal(t, 2, result.Uploaded)
+		assert.Equal(t, 0, result.Failed)
+	})
+	t.Run("Incremental", func(t *testing.T) {
+		// Snapshot names have a resolution of one second.
+		time.Sleep(time.Second)
+
+		if err = os.WriteFile(filepath.Join(dir, "b.heic"), []byte("changed"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := w.BackupOriginals()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Files)
+		assert.Equal(t, 1, result.Uploaded)
+
+		keys, err := w.Backups(backupSnapshotsFolder)
+
+		assert.NoError(t, err)
+		assert.Len(t, keys, 2)
+	})
+	t.Run("Rotate", func(t *testing.T) {
+		deleted, err := w.Rotate(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		hashes, err := w.dataHashes()
+
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 2)
+	})
+	t.Run("Restore", func(t *testing.T) {
+		dest := t.TempDir()
+
+		result, err := w.RestoreOriginals("", dest, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Restored)
+		assert.Equal(t, 0, result.Failed)
+
+		data, err := os.ReadFile(filepath.Join(dest, "b.heic"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "changed", string(data))
+		assert.NoFileExists(t, filepath.Join(dest, ".hidden", "c.jpg"))
+
+		result, err = w.RestoreOriginals("", dest, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Restored)
+		assert.Equal(t, 3, result.Skipped)
+	})
+}
@@ -0,0 +1,448 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/crypt"
+	ppfs "github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// BackupYamlFolder is the remote folder that contains the YAML file archives.
+const BackupYamlFolder = "yaml"
+
+// Folders in YAML file archives.
+const (
+	BackupAlbumsFolder  = "albums"
+	BackupPeopleFolder  = "people"
+	BackupSidecarFolder = "sidecar"
+)
+
+// RemoteBackup uploads index backups and YAML files to a storage backend, e.g. an S3 bucket or a WebDAV
+// folder, deletes old backups, and downloads them for restoring. If a passphrase is configured, files are
+// encrypted before they are uploaded, so that the storage provider cannot read them.
+type RemoteBackup struct {
+	conf    *config.Config
+	backend vfs.Backend
+}
+
+// NewRemoteBackup returns a new remote backup worker.
+func NewRemoteBackup(conf *config.Config, backend vfs.Backend) *RemoteBackup {
+	return &RemoteBackup{conf: conf, backend: backend}
+}
+
+// Backend returns the remote storage backend, or nil if not configured.
+func (w *RemoteBackup) Backend() vfs.Backend {
+	if w == nil {
+		return nil
+	}
+
+	return w.backend
+}
+
+// IndexFolder returns the remote folder that contains the index backups of the current database type.
+func (w *RemoteBackup) IndexFolder() string {
+	return w.conf.DatabaseDriver()
+}
+
+// Upload uploads the index backup file, if not empty, and optionally an archive with the album, people, and sidecar
+// YAML files. It returns the keys of the uploaded files.
+func (w *RemoteBackup) Upload(indexFileName string, yaml bool) (keys []string, err error) {
+	if w == nil || w.backend == nil {
+		return keys, errors.New("remote backup is not configured")
+	}
+
+	if indexFileName != "" {
+		key := vfs.Key(w.IndexFolder(), filepath.Base(indexFileName))
+
+		if key, err = w.put(indexFileName, key); err != nil {
+			return keys, err
+		}
+
+		log.Infof("backup: uploaded %s to %s", clean.Log(key), clean.Log(w.backend.String()))
+		keys = append(keys, key)
+	}
+
+	if !yaml {
+		return keys, nil
+	}
+
+	tmp, err := os.CreateTemp("", "photoprism-yaml-*.zip")
+
+	if err != nil {
+		return keys, err
+	}
+
+	zipName := tmp.Name()
+	_ = tmp.Close()
+
+	defer os.Remove(zipName)
+
+	if count, err := BackupYamlArchive(w.conf, zipName); err != nil {
+		return keys, err
+	} else if count == 0 {
+		log.Debugf("backup: no yaml files found")
+		return keys, nil
+	}
+
+	key := vfs.Key(BackupYamlFolder, time.Now().UTC().Format("2006-01-02")+".zip")
+
+	if key, err = w.put(zipName, key); err != nil {
+		return keys, err
+	}
+
+	log.Infof("backup: uploaded %s to %s", clean.Log(key), clean.Log(w.backend.String()))
+
+	return append(keys, key), nil
+}
+
+// put uploads a local file, encrypting it first if a passphrase is configured, and returns the key.
+func (w *RemoteBackup) put(fileName, key string) (string, error) {
+	if w.conf.BackupEncrypt() {
+		tmp, err := os.CreateTemp("", "photoprism-*"+crypt.Ext)
+
+		if err != nil {
+			return key, err
+		}
+
+		encrypted := tmp.Name()
+		_ = tmp.Close()
+
+		if err = crypt.EncryptFile(fileName, encrypted, w.conf.BackupPassphrase()); err != nil {
+			return key, fmt.Errorf("failed to encrypt %s (%s)", clean.Log(filepath.Base(fileName)), err)
+		}
+
+		defer os.Remove(encrypted)
+
+		fileName = encrypted
+		key += crypt.Ext
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return key, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return key, err
+	}
+
+	if err = w.backend.Put(key, f, info.Size()); err != nil {
+		return key, fmt.Errorf("failed to upload %s (%s)", clean.Log(key), err)
+	}
+
+	log.Debugf("backup: uploaded %s to %s", clean.Log(key), clean.Log(w.backend.String()))
+
+	return key, nil
+}
+
+// Backups returns the keys of the remote backups in the specified folder, with the latest backup first.
+func (w *RemoteBackup) Backups(folder string) ([]string, error) {
+	if w == nil || w.backend == nil {
+		return nil, errors.New("remote backup is not configured")
+	}
+
+	files, err := w.backend.List(folder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for _, f := range files {
+		name := strings.TrimSuffix(path.Base(f.Key), crypt.Ext)
+
+		// Ignore nested folders and other files.
+		if path.Dir(f.Key) != vfs.Key(folder) {
+			continue
+		} else if ext := path.Ext(name); ext != ".sql" && ext != ".zip" && ext != ".json" {
+			continue
+		}
+
+		keys = append(keys, f.Key)
+	}
+
+	// Backup file names start with the date, so they can be sorted by name.
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	return keys, nil
+}
+
+// Rotate deletes the oldest remote backups, so that no more than the specified number is kept.
+func (w *RemoteBackup) Rotate(retain int) (deleted int, err error) {
+	if retain <= 0 {
+		return 0, nil
+	}
+
+	for _, folder := range []string{w.IndexFolder(), BackupYamlFolder, backupSnapshotsFolder} {
+		keys, listErr := w.Backups(folder)
+
+		if listErr != nil {
+			return deleted, listErr
+		} else if len(keys) <= retain {
+			continue
+		}
+
+		for _, key := range keys[retain:] {
+			if err = w.backend.Remove(key); err != nil {
+				log.Warnf("backup: %s (delete %s)", err, clean.Log(key))
+				continue
+			}
+
+			log.Infof("backup: deleted %s", clean.Log(key))
+			deleted++
+		}
+	}
+
+	// Delete original files that are no longer part of a snapshot.
+	if pruned, pruneErr := w.pruneOriginals(); pruneErr != nil {
+		return deleted, pruneErr
+	} else {
+		deleted += pruned
+	}
+
+	return deleted, err
+}
+
+// latest returns the key of the specified backup in the folder, or the key of the latest backup if the name is empty.
+func (w *RemoteBackup) latest(folder, name string) (string, error) {
+	if name != "" {
+		return vfs.Key(folder, path.Base(name)), nil
+	}
+
+	keys, err := w.Backups(folder)
+
+	if err != nil {
+		return "", err
+	} else if len(keys) == 0 {
+		return "", fmt.Errorf("no backups found in %s", clean.Log(folder))
+	}
+
+	return keys[0], nil
+}
+
+// get downloads the remote file to the local file name and decrypts it if needed.
+func (w *RemoteBackup) get(key, fileName string) error {
+	r, err := w.backend.Open(key)
+
+	if err != nil {
+		return fmt.Errorf("failed to download %s (%s)", clean.Log(key), err)
+	}
+
+	defer r.Close()
+
+	if !strings.HasSuffix(key, crypt.Ext) {
+		return vfs.WriteFile(fileName, r)
+	} else if !w.conf.BackupEncrypt() {
+		return fmt.Errorf("passphrase required to decrypt %s", clean.Log(key))
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(crypt.Decrypt(pw, r, w.conf.BackupPassphrase()))
+	}()
+
+	if err = vfs.WriteFile(fileName, pr); err != nil {
+		_ = pr.CloseWithError(err)
+		return fmt.Errorf("failed to decrypt %s (%s)", clean.Log(key), err)
+	}
+
+	return nil
+}
+
+// DownloadIndex downloads the index backup with the specified name, or the latest index backup if the name is
+// empty, to the local index backup folder and returns the local file name.
+func (w *RemoteBackup) DownloadIndex(name string) (fileName string, err error) {
+	if w == nil || w.backend == nil {
+		return "", errors.New("remote backup is not configured")
+	}
+
+	key, err := w.latest(w.IndexFolder(), name)
+
+	if err != nil {
+		return "", err
+	}
+
+	fileName = filepath.Join(w.conf.BackupPath(), w.conf.DatabaseDriver(), strings.TrimSuffix(path.Base(key), crypt.Ext))
+
+	log.Infof("restore: downloading %s", clean.Log(key))
+
+	if err = w.get(key, fileName); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// DownloadYaml downloads the YAML file archive with the specified name, or the latest archive if the name is empty,
+// and extracts the album, people, and sidecar files, or only those in the specified archive folders. It returns the
+// number of extracted files.
+func (w *RemoteBackup) DownloadYaml(name string, folders ...string) (count int, err error) {
+	if w == nil || w.backend == nil {
+		return 0, errors.New("remote backup is not configured")
+	}
+
+	key, err := w.latest(BackupYamlFolder, name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp("", "photoprism-yaml-*.zip")
+
+	if err != nil {
+		return 0, err
+	}
+
+	zipName := tmp.Name()
+	_ = tmp.Close()
+
+	defer os.Remove(zipName)
+
+	log.Infof("restore: downloading %s", clean.Log(key))
+
+	if err = w.get(key, zipName); err != nil {
+		return 0, err
+	}
+
+	return RestoreYamlArchive(w.conf, zipName, folders...)
+}
+
+// BackupYamlArchive creates a zip archive with the album and people YAML files and, if sidecar files are stored
+// in a separate folder, the picture YAML files. It returns the number of files in the archive.
+func BackupYamlArchive(conf *config.Config, fileName string) (count int, err error) {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer f.Close()
+
+	zipWriter := zip.NewWriter(f)
+
+	folders := map[string]string{BackupAlbumsFolder: conf.AlbumsPath(), BackupPeopleFolder: conf.PeoplePath()}
+
+	if conf.SidecarPathIsAbs() {
+		folders[BackupSidecarFolder] = conf.SidecarPath()
+	}
+
+	for folder, dir := range folders {
+		if !ppfs.PathExists(dir) {
+			continue
+		}
+
+		err = filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if d.IsDir() || !strings.EqualFold(filepath.Ext(name), ppfs.ExtYAML) {
+				return nil
+			}
+
+			rel, err := filepath.Rel(dir, name)
+
+			if err != nil {
+				return err
+			}
+
+			w, err := zipWriter.Create(path.Join(folder, filepath.ToSlash(rel)))
+
+			if err != nil {
+				return err
+			}
+
+			src, err := os.Open(name)
+
+			if err != nil {
+				return err
+			}
+
+			defer src.Close()
+
+			if _, err = io.Copy(w, src); err != nil {
+				return err
+			}
+
+			count++
+
+			return nil
+		})
+
+		if err != nil {
+			_ = zipWriter.Close()
+			return count, err
+		}
+	}
+
+	return count, zipWriter.Close()
+}
+
+// RestoreYamlArchive extracts the album, people, and sidecar YAML files from an archive created with BackupYamlArchive,
+// or only those in the specified archive folders. It returns the number of extracted files.
+func RestoreYamlArchive(conf *config.Config, fileName string, folders ...string) (count int, err error) {
+	r, err := zip.OpenReader(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer r.Close()
+
+	for _, f := range r.File {
+		// Skip directories and potentially malicious file names containing "..".
+		if f.FileInfo().IsDir() || strings.Contains(f.Name, "..") {
+			continue
+		}
+
+		var dest string
+
+		if len(folders) > 0 && !list.Contains(folders, strings.SplitN(f.Name, "/", 2)[0]) {
+			continue
+		} else if rel := strings.TrimPrefix(f.Name, BackupAlbumsFolder+"/"); rel != f.Name {
+			dest = filepath.Join(conf.AlbumsPath(), filepath.FromSlash(rel))
+		} else if rel = strings.TrimPrefix(f.Name, BackupPeopleFolder+"/"); rel != f.Name {
+			dest = filepath.Join(conf.PeoplePath(), filepath.FromSlash(rel))
+		} else if rel = strings.TrimPrefix(f.Name, BackupSidecarFolder+"/"); rel != f.Name && conf.SidecarWritable() {
+			dest = filepath.Join(conf.SidecarPath(), filepath.FromSlash(rel))
+		} else {
+			continue
+		}
+
+		src, err := f.Open()
+
+		if err != nil {
+			return count, err
+		}
+
+		err = vfs.WriteFile(dest, src)
+		_ = src.Close()
+
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
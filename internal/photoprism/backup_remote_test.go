@@ -0,0 +1,153 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestRemoteBackup(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		var w *RemoteBackup
+
+		_, err := w.Upload("", true)
+
+		assert.EqualError(t, err, "remote backup is not configured")
+		assert.Nil(t, w.Backend())
+	})
+	t.Run("Encrypted", func(t *testing.T) {
+		conf := config.TestConfig()
+		conf.Options().BackupPassphrase = "correct horse battery staple"
+		defer func() { conf.Options().BackupPassphrase = "" }()
+
+		backend, err := vfs.NewLocal(t.TempDir())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := NewRemoteBackup(conf, backend)
+		dir := t.TempDir()
+
+		for _, name := range []string{"2023-04-01.sql", "2023-04-02.sql", "2023-04-03.sql"} {
+			fileName := filepath.Join(dir, name)
+
+			if err = os.WriteFile(fileName, []byte("-- "+name), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			keys, err := w.Upload(fileName, false)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{vfs.Key(w.IndexFolder(), name+".enc")}, keys)
+		}
+
+		// The provider must not be able to read the uploaded files.
+		r, err := backend.Open(vfs.Key(w.IndexFolder(), "2023-04-03.sql.enc"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := make([]byte, 64)
+		n, _ := r.Read(data)
+		r.Close()
+
+		assert.False(t, strings.Contains(string(data[:n]), "2023-04-03.sql"))
+
+		deleted, err := w.Rotate(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		keys, err := w.Backups(w.IndexFolder())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{vfs.Key(w.IndexFolder(), "2023-04-03.sql.enc"), vfs.Key(w.IndexFolder(), "2023-04-02.sql.enc")}, keys)
+
+		fileName, err := w.DownloadIndex("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		assert.Equal(t, "2023-04-03.sql", filepath.Base(fileName))
+
+		data, err = os.ReadFile(fileName)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "-- 2023-04-03.sql", string(data))
+
+		conf.Options().BackupPassphrase = "wrong"
+
+		_, err = w.DownloadIndex("2023-04-02.sql.enc")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRotateIndexBackups(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"2023-04-01.sql", "2023-04-02.sql", "2023-04-03.sql", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("-- "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := RotateIndexBackups(dir, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = RotateIndexBackups(dir, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.NoFileExists(t, filepath.Join(dir, "2023-04-01.sql"))
+	assert.FileExists(t, filepath.Join(dir, "2023-04-02.sql"))
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+}
+
+func TestBackupYamlArchive(t *testing.T) {
+	conf := config.TestConfig()
+	zipName := filepath.Join(t.TempDir(), "yaml.zip")
+	albumFile := filepath.Join(conf.AlbumsPath(), "album", "backup-test.yml")
+
+	if err := os.MkdirAll(filepath.Dir(albumFile), 0o755); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(albumFile, []byte("Title: Backup Test\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := BackupYamlArchive(conf, zipName)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+
+	if err = os.Remove(albumFile); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restore people files only.
+	restored, err := RestoreYamlArchive(conf, zipName, BackupPeopleFolder)
+
+	assert.NoError(t, err)
+	assert.NoFileExists(t, albumFile)
+
+	restored, err = RestoreYamlArchive(conf, zipName)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, restored, 1)
+	assert.FileExists(t, albumFile)
+
+	_ = os.Remove(albumFile)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/dustin/go-humanize/english"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -96,7 +97,11 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, sidecars i
 		}
 	}
 
-	log.Infof("cleanup: removed %s and %s [%s]", english.Plural(orphans, "index entry", "index entries"), english.Plural(sidecars, "sidecar file", "sidecar files"), time.Since(cleanupStart))
+	if opt.Dry {
+		log.Infof("cleanup: would remove %s [%s]", english.Plural(orphans, "index entry", "index entries"), time.Since(cleanupStart))
+	} else {
+		log.Infof("cleanup: removed %s and %s [%s]", english.Plural(orphans, "index entry", "index entries"), english.Plural(sidecars, "sidecar file", "sidecar files"), time.Since(cleanupStart))
+	}
 
 	// Remove orphan index entries.
 	if opt.Dry {
@@ -116,6 +121,11 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, sidecars i
 	// Remove orphaned media and thumbnail cache files.
 	thumbs, err = w.Cache(opt)
 
+	// Remove stored originals that are no longer linked to a file name, see fs.LinkObject.
+	if _, objErr := w.Objects(opt); objErr != nil {
+		log.Errorf("cleanup: %s (remove unused originals)", objErr)
+	}
+
 	// Only update counts if anything was deleted.
 	if len(deleted) > 0 {
 		// Update precalculated photo and file counts.
@@ -184,7 +194,7 @@ func (w *CleanUp) Cache(opt CleanUpOptions) (deleted int, err error) {
 				// Do nothing.
 			} else if opt.Dry {
 				deleted++
-				log.Debugf("cleanup: %s would be removed", logName)
+				log.Infof("cleanup: %s would be removed from cache", logName)
 			} else if err := os.Remove(fileName); err != nil {
 				log.Warnf("cleanup: %s in %s", err, logName)
 			} else {
@@ -196,11 +206,43 @@ func (w *CleanUp) Cache(opt CleanUpOptions) (deleted int, err error) {
 		})
 	}
 
-	log.Infof("cleanup: removed %s from cache [%s]", english.Plural(deleted, "file", "files"), time.Since(cleanupStart))
+	if opt.Dry {
+		log.Infof("cleanup: would remove %s from cache [%s]", english.Plural(deleted, "file", "files"), time.Since(cleanupStart))
+	} else {
+		log.Infof("cleanup: removed %s from cache [%s]", english.Plural(deleted, "file", "files"), time.Since(cleanupStart))
+	}
 
 	return deleted, err
 }
 
+// Objects removes originals stored by content hash that are no longer linked to a file name in the library.
+func (w *CleanUp) Objects(opt CleanUpOptions) (removed int, err error) {
+	if opt.Dry || !w.conf.OriginalsHashLayout() {
+		return 0, nil
+	}
+
+	cleanupStart := time.Now()
+
+	var size int64
+
+	for _, r := range w.conf.OriginalsRoots() {
+		if r.ReadOnly {
+			continue
+		} else if n, freed, pruneErr := fs.PruneObjects(r.ObjectsPath()); pruneErr != nil {
+			err = pruneErr
+		} else {
+			removed += n
+			size += freed
+		}
+	}
+
+	if removed > 0 {
+		log.Infof("cleanup: removed %s from originals, %s freed [%s]", english.Plural(removed, "unused file", "unused files"), humanize.Bytes(uint64(size)), time.Since(cleanupStart))
+	}
+
+	return removed, err
+}
+
 // Cancel stops the current operation.
 func (w *CleanUp) Cancel() {
 	mutex.MainWorker.Cancel()
@@ -0,0 +1,244 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Cold moves old originals to a cheaper storage backend, e.g. S3 Glacier or an external disk,
+// and restores them on demand. Thumbnails and metadata are not affected.
+type Cold struct {
+	conf    *config.Config
+	backend vfs.Backend
+}
+
+// NewCold returns a new cold storage worker.
+func NewCold(conf *config.Config, backend vfs.Backend) *Cold {
+	return &Cold{conf: conf, backend: backend}
+}
+
+// Backend returns the cold storage backend, or nil if not configured.
+func (w *Cold) Backend() vfs.Backend {
+	if w == nil {
+		return nil
+	}
+
+	return w.backend
+}
+
+// fileName returns the absolute originals file name.
+func (w *Cold) fileName(fileName string) string {
+	return filepath.Join(w.conf.OriginalsPath(), filepath.FromSlash(fileName))
+}
+
+// Archive moves the selected originals to cold storage.
+func (w *Cold) Archive(opt ColdOptions) (result ColdResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cold: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w == nil || w.backend == nil {
+		return result, errors.New("cold storage is not configured")
+	} else if w.conf.ReadOnly() {
+		return result, errors.New("originals are read-only")
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	var afterID uint
+
+	for {
+		files, err := query.ColdCandidates(opt.Path, opt.Before, afterID, 1000)
+
+		if err != nil {
+			return result, err
+		} else if len(files) == 0 {
+			break
+		}
+
+		for i := range files {
+			if mutex.MainWorker.Canceled() {
+				return result, errors.New("canceled")
+			}
+
+			file := &files[i]
+			afterID = file.ID
+
+			info, err := os.Stat(w.fileName(file.FileName))
+
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			} else if opt.Dry {
+				log.Infof("cold: %s would be archived", clean.Log(file.FileName))
+				result.Files++
+				result.Size += info.Size()
+				continue
+			}
+
+			if err = w.archive(file, info); err != nil {
+				log.Errorf("cold: %s while archiving %s", err, clean.Log(file.FileName))
+				result.Failed++
+			} else {
+				log.Infof("cold: archived %s", clean.Log(file.FileName))
+				result.Files++
+				result.Size += info.Size()
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// archive uploads a single original file to cold storage and removes the local copy.
+func (w *Cold) archive(file *entity.File, info os.FileInfo) error {
+	fileName := w.fileName(file.FileName)
+	key := vfs.Key(vfs.RootOriginals, file.FileName)
+
+	// Originals cannot be moved to cold storage in write-once mode.
+	if err := vfs.CheckWrite(fileName); err != nil {
+		return err
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	err = w.backend.Put(key, f, info.Size())
+	_ = f.Close()
+
+	if err != nil {
+		return err
+	}
+
+	// Make sure the file was stored completely before removing it.
+	if stored, err := w.backend.Stat(key); err != nil {
+		return err
+	} else if stored.Size != info.Size() {
+		return fmt.Errorf("size mismatch, %d instead of %d bytes stored", stored.Size, info.Size())
+	}
+
+	m := entity.NewFileCold(file, key)
+	m.FileSize = info.Size()
+
+	if err = m.Create(); err != nil {
+		return err
+	}
+
+	return vfs.Remove(fileName)
+}
+
+// RestoreFile makes sure the original file is available and returns true if it can be read.
+// If it has been archived in a backend that requires a restore, e.g. S3 Glacier, the file
+// is added to the restore queue and false is returned until the restore is complete.
+func (w *Cold) RestoreFile(file *entity.File) (bool, error) {
+	if w == nil || file == nil || file.FileRoot != entity.RootOriginals {
+		return true, nil
+	} else if m := entity.FindFileCold(file.ID); m == nil {
+		return true, nil
+	} else {
+		return w.Restore(m)
+	}
+}
+
+// Restore downloads an archived file to the originals folder and returns true once it is available.
+func (w *Cold) Restore(m *entity.FileCold) (bool, error) {
+	if w == nil || w.backend == nil {
+		return false, errors.New("cold storage is not configured")
+	}
+
+	fileName := w.fileName(m.FileName)
+
+	// Nothing to do if the file has been restored otherwise, e.g. from a backup.
+	if fs.FileExists(fileName) {
+		return true, m.Delete()
+	}
+
+	if r, ok := w.backend.(vfs.Restorer); ok {
+		if ready, err := r.Restore(m.StorageKey); err != nil {
+			return false, w.failed(m, err)
+		} else if !ready {
+			return false, m.RequestRestore()
+		}
+	}
+
+	rc, err := w.backend.Open(m.StorageKey)
+
+	if err != nil {
+		return false, w.failed(m, err)
+	}
+
+	defer rc.Close()
+
+	if err = vfs.WriteFile(fileName, rc); err != nil {
+		return false, w.failed(m, err)
+	} else if info, err := os.Stat(fileName); err != nil {
+		return false, w.failed(m, err)
+	} else if info.Size() != m.FileSize {
+		_ = os.Remove(fileName)
+		return false, w.failed(m, fmt.Errorf("size mismatch, %d instead of %d bytes restored", info.Size(), m.FileSize))
+	}
+
+	log.Infof("cold: restored %s", clean.Log(m.FileName))
+
+	return true, m.Delete()
+}
+
+// failed remembers the error that occurred while restoring the file and returns it.
+func (w *Cold) failed(m *entity.FileCold, err error) error {
+	if updateErr := m.SetError(err); updateErr != nil {
+		log.Errorf("cold: %s", updateErr)
+	}
+
+	return err
+}
+
+// RestorePending processes the restore queue.
+func (w *Cold) RestorePending() (result ColdResult, err error) {
+	if w == nil || w.backend == nil {
+		return result, nil
+	}
+
+	files, err := query.ColdFiles(entity.FileColdRestoring, "", 0, 0)
+
+	if err != nil {
+		return result, err
+	}
+
+	for i := range files {
+		if mutex.ColdWorker.Canceled() {
+			return result, errors.New("canceled")
+		}
+
+		m := &files[i]
+
+		if restored, err := w.Restore(m); err != nil {
+			log.Warnf("cold: %s while restoring %s", err, clean.Log(m.FileName))
+			result.Failed++
+		} else if restored {
+			result.Files++
+			result.Size += m.FileSize
+		}
+	}
+
+	return result, nil
+}
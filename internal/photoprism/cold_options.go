@@ -0,0 +1,17 @@
+package photoprism
+
+import "time"
+
+// ColdOptions represents the selection of originals to be moved to cold storage.
+type ColdOptions struct {
+	Path   string
+	Before time.Time
+	Dry    bool
+}
+
+// ColdResult represents the number of files that were archived or restored.
+type ColdResult struct {
+	Files  int
+	Size   int64
+	Failed int
+}
@@ -0,0 +1,64 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestCold_Archive(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		var w *Cold
+
+		_, err := w.Archive(ColdOptions{})
+
+		assert.EqualError(t, err, "cold storage is not configured")
+		assert.Nil(t, w.Backend())
+	})
+}
+
+func TestCold_RestoreFile(t *testing.T) {
+	conf := config.TestConfig()
+
+	backend, err := vfs.NewLocal(t.TempDir())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewCold(conf, backend)
+
+	t.Run("Online", func(t *testing.T) {
+		ready, err := w.RestoreFile(&entity.File{ID: 1234567, FileRoot: entity.RootOriginals, FileName: "online.jpg"})
+
+		assert.NoError(t, err)
+		assert.True(t, ready)
+	})
+	t.Run("Archived", func(t *testing.T) {
+		file := &entity.File{ID: 1234568, FileRoot: entity.RootOriginals, FileName: "cold-test/restore.jpg", FileSize: 4}
+		key := vfs.Key(vfs.RootOriginals, file.FileName)
+		fileName := filepath.Join(conf.OriginalsPath(), "cold-test", "restore.jpg")
+
+		defer os.RemoveAll(filepath.Dir(fileName))
+
+		if err = backend.Put(key, strings.NewReader("jpeg"), 4); err != nil {
+			t.Fatal(err)
+		} else if err = entity.NewFileCold(file, key).Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		ready, err := w.RestoreFile(file)
+
+		assert.NoError(t, err)
+		assert.True(t, ready)
+		assert.FileExists(t, fileName)
+		assert.Nil(t, entity.FindFileCold(file.ID))
+	})
+}
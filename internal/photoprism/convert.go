@@ -89,7 +89,7 @@ func (c *Convert) Start(dir string, ext []string, force bool) (err error) {
 				}
 			}()
 
-			if mutex.MainWorker.Canceled() {
+			if !mutex.MainWorker.Wait() {
 				return errors.New("canceled")
 			}
 
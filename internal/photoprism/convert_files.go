@@ -0,0 +1,75 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// StartFiles converts the specified original files, e.g. a subset selected with a search filter.
+func (c *Convert) StartFiles(fileNames []string, force bool) (converted int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("convert: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return converted, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	jobs := make(chan ConvertJob)
+
+	// Start a fixed number of goroutines to convert files.
+	var wg sync.WaitGroup
+	var numWorkers = c.conf.Workers()
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			ConvertWorker(jobs)
+			wg.Done()
+		}()
+	}
+
+	done := make(map[string]bool, len(fileNames))
+
+	for _, fileName := range fileNames {
+		if !mutex.MainWorker.Wait() {
+			err = errors.New("canceled")
+			break
+		} else if done[fileName] {
+			continue
+		}
+
+		done[fileName] = true
+
+		f, fileErr := NewMediaFile(fileName)
+
+		if fileErr != nil {
+			log.Warnf("convert: %s", clean.Log(fileErr.Error()))
+			continue
+		} else if f.Empty() || f.IsPreviewImage() || !f.IsMedia() {
+			continue
+		}
+
+		jobs <- ConvertJob{
+			force:   force,
+			file:    f,
+			convert: c,
+		}
+
+		converted++
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return converted, err
+}
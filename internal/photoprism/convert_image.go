@@ -14,6 +14,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/vfs"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -169,6 +170,9 @@ func (c *Convert) ToImage(f *MediaFile, force bool) (*MediaFile, error) {
 			break
 		} else if res := out.Bytes(); len(res) < 512 || !mimetype.Detect(res).Is(expectedMime) {
 			continue
+		} else if err = vfs.CheckWrite(imageName); err != nil {
+			log.Tracef("convert: %s (%s)", err, filepath.Base(cmd.Path))
+			break
 		} else if err = os.WriteFile(imageName, res, fs.ModeFile); err != nil {
 			log.Tracef("convert: %s (%s)", err, filepath.Base(cmd.Path))
 			continue
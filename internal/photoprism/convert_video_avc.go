@@ -39,6 +39,8 @@ func (c *Convert) ToAvc(f *MediaFile, encoder ffmpeg.AvcEncoder, noMutex, force
 
 	if !c.conf.SidecarWritable() {
 		return nil, fmt.Errorf("convert: transcoding disabled in read-only mode (%s)", f.RootRelName())
+	} else if c.conf.DiskSpaceLow() {
+		return nil, fmt.Errorf("convert: transcoding paused because there is not enough free disk space (%s)", f.RootRelName())
 	}
 
 	fileName := f.RelName(c.conf.OriginalsPath())
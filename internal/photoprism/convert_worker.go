@@ -3,6 +3,7 @@ package photoprism
 import (
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
@@ -32,6 +33,8 @@ func ConvertWorker(jobs <-chan ConvertJob) {
 				logError(err, job)
 			} else if metaData := job.file.MetaData(); metaData.CodecAvc() {
 				continue
+			} else if OffloadJob(entity.NodeJobTranscode, job.file, "", false) {
+				continue
 			} else if _, err := job.convert.ToAvc(job.file, job.convert.conf.FFmpegEncoder(), false, false); err != nil {
 				logError(err, job)
 			}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/vfs"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -25,6 +26,8 @@ func DeletePhoto(p entity.Photo, mediaFiles bool, originals bool) (numFiles int,
 	}
 
 	// Remove sidecar backup.
+	removeStored(yamlFileName)
+
 	if !fs.FileExists(yamlFileName) {
 		return numFiles, nil
 	} else if err := os.Remove(yamlFileName); err != nil {
@@ -55,10 +58,20 @@ func DeleteFiles(files entity.Files, originals bool) (numFiles int) {
 			log.Tracef("files: %s", err)
 		}
 
+		// Remove files from the object storage, if configured, as they may no longer exist locally.
+		if storage != nil {
+			removeStoredPrefix(filepath.Join(Config().SidecarPath(), fs.RelPrefix(fileName, Config().OriginalsPath(), false)) + ".")
+
+			if file.FileRoot != entity.RootOriginals || originals && Config().OriginalsWritable(file.FileName) {
+				removeStored(fileName)
+				removeStored(fileName + ".json")
+			}
+		}
+
 		// Remove original JSON sidecar file, if any.
-		if jsonFile := f.FileName() + ".json"; !originals && f.Root() == entity.RootOriginals || !fs.FileExists(jsonFile) {
+		if jsonFile := f.FileName() + ".json"; !originals && f.Root() == entity.RootOriginals || !fs.FileExists(jsonFile) || f.Root() == entity.RootOriginals && !Config().OriginalsWritable(jsonFile) {
 			// Do nothing.
-		} else if err = os.Remove(jsonFile); err != nil {
+		} else if err = vfs.Remove(jsonFile); err != nil {
 			log.Warnf("files: failed deleting sidecar %s", clean.Log(filepath.Base(jsonFile)))
 		} else {
 			numFiles++
@@ -92,6 +105,9 @@ func DeleteFiles(files entity.Files, originals bool) (numFiles int) {
 		} else if !originals && f.Root() == entity.RootOriginals {
 			log.Debugf("files: skipped deleting %s", clean.Log(relName))
 			continue
+		} else if f.Root() == entity.RootOriginals && !Config().OriginalsWritable(relName) {
+			log.Infof("files: skipped deleting %s, storage is read-only", clean.Log(relName))
+			continue
 		} else if err = f.Remove(); err != nil {
 			log.Errorf("files: failed deleting %s", clean.Log(relName))
 		} else {
@@ -0,0 +1,184 @@
+package photoprism
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// EmbeddingsMaxLineSize is the maximum size of a line in an embeddings JSON Lines file.
+const EmbeddingsMaxLineSize = 16 * 1024 * 1024
+
+// EmbeddingLabel represents a label in an embeddings JSON Lines file.
+type EmbeddingLabel struct {
+	Name        string `json:"name"`
+	Uncertainty int    `json:"uncertainty,omitempty"`
+}
+
+// EmbeddingRecord represents a line in an embeddings JSON Lines file.
+type EmbeddingRecord struct {
+	PhotoUID  string           `json:"photo_uid"`
+	MarkerUID string           `json:"marker_uid,omitempty"`
+	Model     string           `json:"model,omitempty"`
+	Embedding []float64        `json:"embedding,omitempty"`
+	Labels    []EmbeddingLabel `json:"labels,omitempty"`
+}
+
+// EmbeddingsImportResult represents the outcome of ImportEmbeddings().
+type EmbeddingsImportResult struct {
+	Embeddings int
+	Labels     int
+	Skipped    int
+}
+
+// ExportEmbeddings writes embeddings computed with the specified model as JSON Lines, one vector per line.
+// Face embedding models export one line per face marker, all other models one line per photo.
+func ExportEmbeddings(w io.Writer, model string) (count int, err error) {
+	enc := json.NewEncoder(w)
+	limit := 1000
+
+	if m, ok := face.FindModel(model); ok {
+		for offset := 0; ; offset += limit {
+			markers, err := query.MarkerEmbeddingsByModel(m.Name, limit, offset)
+
+			if err != nil {
+				return count, err
+			} else if len(markers) == 0 {
+				break
+			}
+
+			for _, marker := range markers {
+				rec := EmbeddingRecord{
+					PhotoUID:  marker.PhotoUID,
+					MarkerUID: marker.MarkerUID,
+					Model:     marker.EmbeddingsModel,
+					Embedding: marker.Embedding(),
+				}
+
+				if err = enc.Encode(rec); err != nil {
+					return count, err
+				}
+
+				count++
+			}
+		}
+
+		return count, nil
+	}
+
+	for offset := 0; ; offset += limit {
+		embeddings, err := query.PhotoEmbeddings(model, limit, offset)
+
+		if err != nil {
+			return count, err
+		} else if len(embeddings) == 0 {
+			break
+		}
+
+		for i := range embeddings {
+			rec := EmbeddingRecord{
+				PhotoUID:  embeddings[i].PhotoUID,
+				Model:     embeddings[i].Model,
+				Embedding: embeddings[i].Embedding(),
+			}
+
+			if err = enc.Encode(rec); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ImportEmbeddings reads externally computed embeddings and labels from JSON Lines and adds them to
+// the matching photos. Face embeddings cannot be imported, as they are managed by face recognition.
+func ImportEmbeddings(r io.Reader) (result EmbeddingsImportResult, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), EmbeddingsMaxLineSize)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		data := strings.TrimSpace(scanner.Text())
+
+		if data == "" {
+			continue
+		}
+
+		var rec EmbeddingRecord
+
+		if err = json.Unmarshal([]byte(data), &rec); err != nil {
+			log.Warnf("embeddings: %s in line %d (import)", err, line)
+			result.Skipped++
+			continue
+		}
+
+		embeddings, labels, err := importEmbeddingRecord(rec)
+
+		if err != nil {
+			log.Warnf("embeddings: %s in line %d (import)", err, line)
+			result.Skipped++
+			continue
+		}
+
+		result.Embeddings += embeddings
+		result.Labels += labels
+	}
+
+	return result, scanner.Err()
+}
+
+// importEmbeddingRecord adds the embedding and labels of a single record to the matching photo.
+func importEmbeddingRecord(rec EmbeddingRecord) (embeddings, labels int, err error) {
+	if len(rec.Embedding) == 0 && len(rec.Labels) == 0 {
+		return 0, 0, fmt.Errorf("no embedding or labels")
+	}
+
+	photo, err := query.PhotoByUID(rec.PhotoUID)
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("photo %s not found", clean.Log(rec.PhotoUID))
+	}
+
+	if len(rec.Embedding) > 0 {
+		if _, ok := face.FindModel(rec.Model); ok {
+			return 0, 0, fmt.Errorf("face embeddings cannot be imported")
+		} else if err = entity.NewPhotoEmbedding(photo.PhotoUID, rec.Model, rec.Embedding).Save(); err != nil {
+			return 0, 0, err
+		}
+
+		embeddings++
+	}
+
+	if len(rec.Labels) > 0 {
+		var list classify.Labels
+
+		for _, l := range rec.Labels {
+			if name := strings.TrimSpace(l.Name); name == "" {
+				continue
+			} else if l.Uncertainty < 0 || l.Uncertainty > 100 {
+				return embeddings, 0, fmt.Errorf("invalid uncertainty for label %s", clean.Log(name))
+			} else {
+				list = append(list, classify.Label{Name: name, Source: entity.SrcImport, Uncertainty: l.Uncertainty})
+			}
+		}
+
+		photo.AddLabels(list)
+		labels += len(list)
+	}
+
+	return embeddings, labels, nil
+}
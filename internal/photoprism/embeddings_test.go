@@ -0,0 +1,80 @@
+package photoprism
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+func TestExportEmbeddings(t *testing.T) {
+	t.Run("Faces", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		count, err := ExportEmbeddings(&buf, face.FaceNet.Name)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, count, 1)
+
+		var rec EmbeddingRecord
+
+		line := strings.SplitN(buf.String(), "\n", 2)[0]
+
+		if err = json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, rec.PhotoUID)
+		assert.NotEmpty(t, rec.MarkerUID)
+		assert.NotEmpty(t, rec.Embedding)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		count, err := ExportEmbeddings(&buf, "unknown-model")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "", buf.String())
+	})
+}
+
+func TestImportEmbeddings(t *testing.T) {
+	data := strings.Join([]string{
+		`{"photo_uid":"pt9jtdre2lvl0yh8","model":"import-test","embedding":[0.1,0.2,0.3],"labels":[{"name":"Lighthouse","uncertainty":20}]}`,
+		``,
+		`{"photo_uid":"pt9jtdre2lvl0yh8","model":"facenet","embedding":[0.1]}`,
+		`{"photo_uid":"pt9jtdre2lvl0y99","model":"import-test","embedding":[0.1]}`,
+		`{"photo_uid":"pt9jtdre2lvl0yh8"}`,
+		`not json`,
+	}, "\n")
+
+	result, err := ImportEmbeddings(strings.NewReader(data))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, EmbeddingsImportResult{Embeddings: 1, Labels: 1, Skipped: 4}, result)
+
+	var buf bytes.Buffer
+
+	count, err := ExportEmbeddings(&buf, "import-test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, count)
+	assert.Contains(t, buf.String(), `"embedding":[0.1,0.2,0.3]`)
+}
@@ -0,0 +1,273 @@
+package photoprism
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"image/png"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/tiles"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Folders and files of static album galleries.
+const (
+	GalleryIndex  = "index.html"
+	GalleryMap    = "map.png"
+	GalleryPages  = "photos"
+	GalleryImages = "images"
+	GalleryThumbs = "thumbs"
+)
+
+// GalleryMaxPhotos is the maximum number of pictures in a static album gallery.
+var GalleryMaxPhotos = 10000
+
+// Image sizes of static album galleries.
+var (
+	GalleryThumbSize = thumb.Tile500
+	GalleryImageSize = thumb.Fit1920
+	GalleryMapWidth  = 1200
+	GalleryMapHeight = 600
+)
+
+//go:embed gallery/*.gohtml
+var galleryFiles embed.FS
+
+var galleryTemplates = template.Must(template.ParseFS(galleryFiles, "gallery/*.gohtml"))
+
+// GalleryResult represents the contents of a static album gallery.
+type GalleryResult struct {
+	Photos  int
+	Skipped int
+	Map     bool
+}
+
+// galleryData represents the values that can be used in gallery templates.
+type galleryData struct {
+	Title       string
+	Description string
+	Location    string
+	Exported    string
+	Map         string
+	Attribution string
+	Photos      []galleryPhoto
+	Photo       galleryPhoto
+}
+
+// galleryPhoto represents a picture in gallery templates.
+type galleryPhoto struct {
+	Title       string
+	Description string
+	Taken       string
+	Place       string
+	Video       bool
+	Page        string
+	Thumb       string
+	Image       string
+	Prev        string
+	Next        string
+}
+
+// ExportGallery renders the public pictures in an album as a self-contained static HTML gallery with resized
+// images and, if a raster tile source is available, a map snapshot of the picture locations. The gallery can
+// be viewed offline in any web browser, e.g. from a USB stick.
+func ExportGallery(conf *config.Config, album *entity.Album, dest string, mapTiles tiles.Source) (result GalleryResult, err error) {
+	if album == nil || album.AlbumUID == "" {
+		return result, fmt.Errorf("album not found")
+	}
+
+	frm := form.SearchPhotos{
+		Album:   album.AlbumUID,
+		Filter:  album.AlbumFilter,
+		Primary: true,
+		Public:  true,
+		Count:   GalleryMaxPhotos,
+	}
+
+	if err = frm.ParseQueryString(); err != nil {
+		return result, err
+	}
+
+	photos, _, err := search.Photos(frm)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, dir := range []string{GalleryPages, GalleryImages, GalleryThumbs} {
+		if err = os.MkdirAll(filepath.Join(dest, dir), fs.ModeDir); err != nil {
+			return result, err
+		}
+	}
+
+	thumbSize := thumb.Sizes[GalleryThumbSize]
+	imageSize := galleryImageSize()
+
+	var items []galleryPhoto
+	var points []tiles.Point
+
+	for _, p := range photos {
+		fileName := filepath.Join(conf.OriginalsPath(), p.FileName)
+
+		if p.FileRoot == entity.RootSidecar {
+			fileName = filepath.Join(conf.SidecarPath(), p.FileName)
+		}
+
+		name := fmt.Sprintf("%04d.jpg", len(items)+1)
+
+		if err := galleryImage(thumbSize, fileName, p.FileHash, conf.ThumbCachePath(), p.FileOrientation, filepath.Join(dest, GalleryThumbs, name)); err != nil {
+			log.Warnf("gallery: %s in %s", err, clean.Log(p.FileName))
+			result.Skipped++
+			continue
+		} else if err := galleryImage(imageSize, fileName, p.FileHash, conf.ThumbCachePath(), p.FileOrientation, filepath.Join(dest, GalleryImages, name)); err != nil {
+			log.Warnf("gallery: %s in %s", err, clean.Log(p.FileName))
+			result.Skipped++
+			continue
+		}
+
+		item := galleryPhoto{
+			Title:       p.PhotoTitle,
+			Description: p.PhotoDescription,
+			Taken:       p.TakenAtLocal.Format("January 2, 2006"),
+			Video:       p.PhotoType == entity.MediaVideo || p.PhotoType == entity.MediaLive,
+			Page:        path.Join(GalleryPages, fs.StripExt(name)+".html"),
+			Thumb:       path.Join(GalleryThumbs, name),
+			Image:       path.Join("..", GalleryImages, name),
+		}
+
+		if item.Title == "" {
+			item.Title = item.Taken
+		}
+
+		if p.PlaceID != "" && p.PlaceID != entity.UnknownID {
+			item.Place = p.PlaceLabel
+		}
+
+		if p.PhotoLat != 0 || p.PhotoLng != 0 {
+			points = append(points, tiles.Point{Lat: float64(p.PhotoLat), Lng: float64(p.PhotoLng)})
+		}
+
+		items = append(items, item)
+	}
+
+	// Link pictures to the previous and next picture.
+	for i := range items {
+		if i > 0 {
+			items[i].Prev = path.Base(items[i-1].Page)
+		}
+
+		if i < len(items)-1 {
+			items[i].Next = path.Base(items[i+1].Page)
+		}
+	}
+
+	data := galleryData{
+		Title:       album.Title(),
+		Description: album.AlbumDescription,
+		Location:    album.AlbumLocation,
+		Exported:    time.Now().Format("January 2, 2006"),
+		Photos:      items,
+	}
+
+	// Render map snapshot.
+	if mapTiles == nil || len(points) == 0 {
+		// Do nothing.
+	} else if err := galleryMapImage(mapTiles, points, filepath.Join(dest, GalleryMap)); err != nil {
+		log.Warnf("gallery: %s (map)", err)
+	} else {
+		data.Map = GalleryMap
+		data.Attribution = mapTiles.Info().Attribution
+		result.Map = true
+	}
+
+	// Render HTML pages.
+	for _, item := range items {
+		data.Photo = item
+
+		if err = galleryPage("photo.gohtml", filepath.Join(dest, filepath.FromSlash(item.Page)), data); err != nil {
+			return result, err
+		}
+	}
+
+	data.Photo = galleryPhoto{}
+
+	if err = galleryPage("index.gohtml", filepath.Join(dest, GalleryIndex), data); err != nil {
+		return result, err
+	}
+
+	result.Photos = len(items)
+
+	log.Infof("gallery: exported %d pictures of %s to %s", result.Photos, clean.Log(album.Title()), clean.Log(dest))
+
+	return result, nil
+}
+
+// galleryImageSize returns the largest image size that does not exceed the configured thumbnail size limit.
+func galleryImageSize() thumb.Size {
+	for _, name := range []thumb.Name{GalleryImageSize, thumb.Fit1280, thumb.Fit720} {
+		if size := thumb.Sizes[name]; !size.ExceedsLimit() {
+			return size
+		}
+	}
+
+	return thumb.Sizes[thumb.Fit720]
+}
+
+// galleryImage creates a thumbnail with the specified size, if needed, and copies it to the gallery folder.
+func galleryImage(size thumb.Size, fileName, fileHash, thumbPath string, orientation int, dest string) error {
+	thumbName, err := size.FromFile(fileName, fileHash, thumbPath, orientation)
+
+	if err != nil {
+		return err
+	}
+
+	return fs.Copy(thumbName, dest)
+}
+
+// galleryMapImage renders a map snapshot with the picture locations as PNG file.
+func galleryMapImage(src tiles.Source, points []tiles.Point, fileName string) error {
+	img, err := tiles.Snapshot(src, points, GalleryMapWidth, GalleryMapHeight)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if err = png.Encode(f, img); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// galleryPage renders a gallery template to an HTML file.
+func galleryPage(name, fileName string, data galleryData) error {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if err = galleryTemplates.ExecuteTemplate(f, name, data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%s (render %s)", err, name)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,139 @@
+package photoprism
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/tiles"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// galleryTestTiles returns empty raster tiles.
+type galleryTestTiles struct{}
+
+func (galleryTestTiles) Info() tiles.Info {
+	return tiles.Info{Name: "test", Format: tiles.FormatPng, MaxZoom: 18, Attribution: "Test Maps"}
+}
+
+func (galleryTestTiles) Tile(z, x, y int) (*tiles.Tile, error) {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, tiles.TileSize, tiles.TileSize))); err != nil {
+		return nil, err
+	}
+
+	return &tiles.Tile{Data: buf.Bytes(), Format: tiles.FormatPng}, nil
+}
+
+func TestExportGallery(t *testing.T) {
+	conf := Config()
+
+	// Add an album with one picture.
+	fileName := filepath.Join(conf.OriginalsPath(), "gallery-test", "photo.jpg")
+
+	if err := fs.Copy("testdata/2015-02-04.jpg", fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	photo := entity.NewPhoto(false)
+	photo.PhotoPath = "gallery-test"
+	photo.PhotoName = "photo"
+	photo.PhotoTitle = "Lighthouse"
+	photo.PhotoLat = 52.5200
+	photo.PhotoLng = 13.4050
+	photo.PhotoQuality = 3
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer photo.DeletePermanently()
+
+	file := entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileName:    "gallery-test/photo.jpg",
+		FileRoot:    entity.RootOriginals,
+		FileHash:    fs.Hash(fileName),
+		FileType:    fs.ImageJPEG.String(),
+		FilePrimary: true,
+		FileWidth:   331,
+		FileHeight:  331,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	album := entity.NewAlbum("Gallery Test", entity.AlbumManual)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(album)
+
+	album.AddPhotos([]string{photo.PhotoUID})
+
+	t.Run("WithMap", func(t *testing.T) {
+		dest := t.TempDir()
+
+		result, err := ExportGallery(conf, album, dest, galleryTestTiles{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.Photos)
+		assert.True(t, result.Map)
+		assert.FileExists(t, filepath.Join(dest, GalleryMap))
+		assert.FileExists(t, filepath.Join(dest, GalleryThumbs, "0001.jpg"))
+		assert.FileExists(t, filepath.Join(dest, GalleryImages, "0001.jpg"))
+
+		index, err := os.ReadFile(filepath.Join(dest, GalleryIndex))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(index), "<title>Gallery Test</title>")
+		assert.Contains(t, string(index), `href="photos/0001.html"`)
+		assert.Contains(t, string(index), `src="map.png"`)
+		assert.Contains(t, string(index), "Map data Test Maps")
+
+		page, err := os.ReadFile(filepath.Join(dest, GalleryPages, "0001.html"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(page), "<h2>Lighthouse</h2>")
+		assert.Contains(t, string(page), `src="../images/0001.jpg"`)
+	})
+	t.Run("WithoutMap", func(t *testing.T) {
+		dest := t.TempDir()
+
+		result, err := ExportGallery(conf, album, dest, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.Photos)
+		assert.False(t, result.Map)
+		assert.NoFileExists(t, filepath.Join(dest, GalleryMap))
+	})
+	t.Run("NoAlbum", func(t *testing.T) {
+		_, err := ExportGallery(conf, nil, t.TempDir(), nil)
+		assert.Error(t, err)
+	})
+}
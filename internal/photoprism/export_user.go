@@ -0,0 +1,300 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Files and folders in user data export archives, see UserExportReadme for a description.
+const (
+	UserExportReadmeFile    = "README.txt"
+	UserExportAccountFile   = "account.json"
+	UserExportFilesFile     = "files.json"
+	UserExportSharesFile    = "shares.json"
+	UserExportCommentsFile  = "comments.json"
+	UserExportReactionsFile = "reactions.json"
+	UserExportOriginals     = "originals"
+	UserExportSidecar       = "sidecar"
+	UserExportMetadata      = "metadata"
+	UserExportAlbums        = "albums"
+)
+
+// UserExportReadme describes the layout of user data export archives.
+const UserExportReadme = `PhotoPrism User Data Export
+===========================
+
+This archive contains the data of the user account %s, exported on %s.
+
+  README.txt      This file.
+  account.json    User account, profile, and settings. Passwords and access tokens are not included.
+  files.json      List of all files, with the name in this archive, file and photo UID, SHA1 hash,
+                  size, and media type. Files that could not be found in storage are marked as missing.
+  originals/      Original pictures and videos added by the user, in their original folder structure.
+  sidecar/        Sidecar files of these pictures, e.g. JPEGs converted from RAW images.
+  metadata/       Picture metadata such as title, caption, location, and labels as YAML files,
+                  in the same folder structure as the originals.
+  albums/         Albums created by the user as YAML files, grouped by album type.
+  shares.json     Share links created by the user.
+  comments.json   Comments written by the user on shared albums.
+  reactions.json  Reactions of the user to albums, pictures, and people.
+
+JSON and YAML files use UTF-8 encoding. Times are in UTC unless a time zone is specified.
+`
+
+// UserExportFile represents a file in user data export archives.
+type UserExportFile struct {
+	Name     string `json:"Name"`
+	UID      string `json:"UID"`
+	PhotoUID string `json:"PhotoUID"`
+	Root     string `json:"Root"`
+	FileName string `json:"FileName"`
+	Hash     string `json:"Hash"`
+	Size     int64  `json:"Size"`
+	Mime     string `json:"Mime,omitempty"`
+	Missing  bool   `json:"Missing,omitempty"`
+}
+
+// UserExportResult represents the contents of a user data export archive.
+type UserExportResult struct {
+	Originals int   `json:"Originals"`
+	Sidecars  int   `json:"Sidecars"`
+	Photos    int   `json:"Photos"`
+	Albums    int   `json:"Albums"`
+	Shares    int   `json:"Shares"`
+	Comments  int   `json:"Comments"`
+	Reactions int   `json:"Reactions"`
+	Missing   int   `json:"Missing"`
+	Size      int64 `json:"Size"`
+}
+
+// UserExportName returns the default file name of a user data export archive.
+func UserExportName(user *entity.User) string {
+	return fmt.Sprintf("photoprism-%s-%s.zip", clean.TypeLower(user.Username()), time.Now().Format("20060102"))
+}
+
+// ExportUser writes a zip archive with the original files, sidecar files, picture metadata, albums,
+// share links, comments and reactions of the user to w, e.g. to handle data portability requests.
+func ExportUser(conf *config.Config, w io.Writer, user *entity.User) (result UserExportResult, err error) {
+	if user == nil || user.UserUID == "" {
+		return result, errors.New("user not found")
+	}
+
+	zipWriter := zip.NewWriter(w)
+
+	// Add a description of the archive layout.
+	readme := fmt.Sprintf(UserExportReadme, user.Username(), time.Now().UTC().Format(time.RFC3339))
+
+	if err = zipData(zipWriter, UserExportReadmeFile, []byte(readme)); err != nil {
+		return result, err
+	}
+
+	if err = zipJson(zipWriter, UserExportAccountFile, user); err != nil {
+		return result, err
+	}
+
+	// Add original and sidecar files.
+	files, err := query.UserFiles(user.UserUID)
+
+	if err != nil {
+		return result, err
+	}
+
+	list := make([]UserExportFile, 0, len(files))
+
+	for _, f := range files {
+		var folder, fileName string
+
+		switch f.FileRoot {
+		case entity.RootOriginals:
+			folder, fileName = UserExportOriginals, filepath.Join(conf.OriginalsPath(), f.FileName)
+		case entity.RootSidecar:
+			folder, fileName = UserExportSidecar, filepath.Join(conf.SidecarPath(), f.FileName)
+		default:
+			continue
+		}
+
+		item := UserExportFile{
+			Name:     path.Join(folder, f.FileName),
+			UID:      f.FileUID,
+			PhotoUID: f.PhotoUID,
+			Root:     f.FileRoot,
+			FileName: f.FileName,
+			Hash:     f.FileHash,
+			Size:     f.FileSize,
+			Mime:     f.FileMime,
+		}
+
+		if f.FileMissing || !fs.FileExists(fileName) {
+			log.Warnf("export: %s is missing", clean.Log(f.FileName))
+			item.Missing = true
+			result.Missing++
+		} else if err = zipFile(zipWriter, item.Name, fileName); err != nil {
+			return result, fmt.Errorf("failed to add %s (%s)", clean.Log(f.FileName), err)
+		} else if folder == UserExportOriginals {
+			result.Originals++
+			result.Size += f.FileSize
+		} else {
+			result.Sidecars++
+			result.Size += f.FileSize
+		}
+
+		list = append(list, item)
+	}
+
+	if err = zipJson(zipWriter, UserExportFilesFile, list); err != nil {
+		return result, err
+	}
+
+	// Add picture metadata.
+	photos, err := query.UserPhotos(user.UserUID)
+
+	if err != nil {
+		return result, err
+	}
+
+	for i := range photos {
+		data, err := photos[i].Yaml()
+
+		if err != nil {
+			return result, err
+		}
+
+		name := path.Join(UserExportMetadata, photos[i].PhotoPath, photos[i].PhotoName+fs.ExtYAML)
+
+		if err = zipData(zipWriter, name, data); err != nil {
+			return result, err
+		}
+
+		result.Photos++
+	}
+
+	// Add albums.
+	albums, err := query.UserAlbums(user.UserUID)
+
+	if err != nil {
+		return result, err
+	}
+
+	for i := range albums {
+		data, err := albums[i].Yaml()
+
+		if err != nil {
+			return result, err
+		}
+
+		name := path.Join(UserExportAlbums, albums[i].AlbumType, albums[i].AlbumUID+fs.ExtYAML)
+
+		if err = zipData(zipWriter, name, data); err != nil {
+			return result, err
+		}
+
+		result.Albums++
+	}
+
+	// Add share links, comments, and reactions.
+	if links, err := query.UserLinks(user.UserUID); err != nil {
+		return result, err
+	} else if err = zipJson(zipWriter, UserExportSharesFile, links); err != nil {
+		return result, err
+	} else {
+		result.Shares = len(links)
+	}
+
+	if comments, err := query.UserComments(user.UserUID); err != nil {
+		return result, err
+	} else if err = zipJson(zipWriter, UserExportCommentsFile, comments); err != nil {
+		return result, err
+	} else {
+		result.Comments = len(comments)
+	}
+
+	if reactions, err := query.UserReactions(user.UserUID); err != nil {
+		return result, err
+	} else if err = zipJson(zipWriter, UserExportReactionsFile, reactions); err != nil {
+		return result, err
+	} else {
+		result.Reactions = len(reactions)
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		return result, err
+	}
+
+	log.Infof("export: exported %d originals, %d pictures and %d albums of %s", result.Originals, result.Photos, result.Albums, clean.Log(user.Username()))
+
+	return result, nil
+}
+
+// zipJson adds the value as indented JSON file to the zip archive.
+func zipJson(zipWriter *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return zipData(zipWriter, name, data)
+}
+
+// zipData adds a file with the data to the zip archive.
+func zipData(zipWriter *zip.Writer, name string, data []byte) error {
+	w, err := zipWriter.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// zipFile adds an existing file to the zip archive and keeps its modification time.
+func zipFile(zipWriter *zip.Writer, name, fileName string) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+
+	if err != nil {
+		return err
+	}
+
+	header.Name = strings.TrimPrefix(name, "/")
+	header.Method = zip.Deflate
+
+	w, err := zipWriter.CreateHeader(header)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, f)
+
+	return err
+}
@@ -0,0 +1,156 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestUserExportName(t *testing.T) {
+	assert.Regexp(t, `^photoprism-alice-\d{8}\.zip$`, UserExportName(entity.UserFixtures.Pointer("alice")))
+}
+
+func TestExportUser(t *testing.T) {
+	conf := Config()
+
+	user := entity.NewUser()
+	user.UserName = "export-test"
+	user.DisplayName = "Export Test"
+
+	if err := user.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(user)
+
+	// Add a picture with an original file.
+	fileName := filepath.Join(conf.OriginalsPath(), "export-test", "photo.jpg")
+
+	if err := fs.Copy("testdata/2015-02-04.jpg", fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	photo := entity.NewUserPhoto(false, user.UserUID)
+	photo.PhotoPath = "export-test"
+	photo.PhotoName = "photo"
+	photo.PhotoTitle = "Export Test"
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer photo.DeletePermanently()
+
+	file := entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileName:    "export-test/photo.jpg",
+		FileRoot:    entity.RootOriginals,
+		FileHash:    fs.Hash(fileName),
+		FileSize:    info.Size(),
+		FilePrimary: true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := entity.File{
+		PhotoID:  photo.ID,
+		PhotoUID: photo.PhotoUID,
+		FileName: "export-test/missing.jpg",
+		FileRoot: entity.RootOriginals,
+		FileHash: "0000000000000000000000000000000000000000",
+	}
+
+	if err := missing.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	album := entity.NewUserAlbum("Export Test", entity.AlbumManual, user.UserUID)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(album)
+
+	var buf bytes.Buffer
+
+	result, err := ExportUser(conf, &buf, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, result.Originals)
+	assert.Equal(t, 1, result.Missing)
+	assert.Equal(t, 1, result.Photos)
+	assert.Equal(t, 1, result.Albums)
+	assert.Equal(t, file.FileSize, result.Size)
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents := make(map[string][]byte)
+
+	for _, f := range r.File {
+		rc, err := f.Open()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contents[f.Name], _ = io.ReadAll(rc)
+		_ = rc.Close()
+	}
+
+	assert.Contains(t, string(contents[UserExportReadmeFile]), "export-test")
+	assert.Contains(t, string(contents[UserExportAccountFile]), `"DisplayName": "Export Test"`)
+	assert.NotContains(t, string(contents[UserExportAccountFile]), "Token")
+	assert.Len(t, contents["originals/export-test/photo.jpg"], int(file.FileSize))
+	assert.Contains(t, string(contents["metadata/export-test/photo.yml"]), "Title: Export Test")
+	assert.Contains(t, string(contents["albums/album/"+album.AlbumUID+".yml"]), "Title: Export Test")
+	assert.Contains(t, contents, UserExportSharesFile)
+	assert.Contains(t, contents, UserExportCommentsFile)
+	assert.Contains(t, contents, UserExportReactionsFile)
+
+	var files []UserExportFile
+
+	if err = json.Unmarshal(contents[UserExportFilesFile], &files); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, files, 2) {
+		assert.Equal(t, "originals/export-test/missing.jpg", files[0].Name)
+		assert.True(t, files[0].Missing)
+		assert.Equal(t, "originals/export-test/photo.jpg", files[1].Name)
+		assert.Equal(t, file.FileHash, files[1].Hash)
+		assert.False(t, files[1].Missing)
+	}
+
+	t.Run("NoUser", func(t *testing.T) {
+		_, err := ExportUser(conf, io.Discard, nil)
+		assert.Error(t, err)
+	})
+}
@@ -105,8 +105,21 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("faces: found no clusters to be merged [%s]", time.Since(start))
 	}
 
+	// Add separate clusters for subjects whose appearance changed over time.
+	start = time.Now()
+	if n, err := w.Ages(); err != nil {
+		log.Errorf("faces: %s (age progression)", err)
+	} else if n > 0 {
+		log.Debugf("faces: added %d age progression clusters [%s]", n, time.Since(start))
+	}
+
 	var added entity.Faces
 
+	// Wait for the maintenance window and a low system load before clustering and matching, if requested.
+	if !mutex.FacesWorker.Wait() || opt.Throttle && !Throttle(w.conf, "faces", w.Canceled) {
+		return fmt.Errorf("canceled")
+	}
+
 	// Cluster existing face embeddings.
 	start = time.Now()
 	if added, err = w.Cluster(opt); err != nil {
@@ -132,6 +145,18 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("faces: updated %s, recognized %s, %d unknown [%s]", english.Plural(int(matches.Updated), "marker", "markers"), english.Plural(int(matches.Recognized), "face", "faces"), matches.Unknown, time.Since(start))
 	}
 
+	// Cluster and match pets and other animals.
+	if !mutex.FacesWorker.Wait() || opt.Throttle && !Throttle(w.conf, "faces", w.Canceled) {
+		return fmt.Errorf("canceled")
+	} else if !w.PetsDisabled() {
+		start = time.Now()
+		if added, pets, err := w.Pets(opt); err != nil {
+			log.Errorf("faces: %s (pets)", err)
+		} else if len(added) > 0 || pets.Updated > 0 {
+			log.Infof("faces: added %d pet clusters, updated %s [%s]", len(added), english.Plural(int(pets.Updated), "pet marker", "pet markers"), time.Since(start))
+		}
+	}
+
 	// Remove unused people.
 	start = time.Now()
 	if count, err := entity.DeleteOrphanPeople(); err != nil {
@@ -0,0 +1,108 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// faceAgeBucket represents the confirmed face samples of a subject within a range of years.
+type faceAgeBucket struct {
+	Year       int
+	Markers    []string
+	Embeddings face.Embeddings
+}
+
+// AgeYear returns the first year of the age-progression bucket for the specified year.
+func AgeYear(year, bucket int) int {
+	if year <= 0 || bucket <= 0 {
+		return 0
+	}
+
+	return year - year%bucket
+}
+
+// Ages adds separate face clusters per time period for subjects with confirmed faces across multiple periods,
+// so that childhood and adult pictures of the same person can both be recognized.
+func (w *Faces) Ages() (added int, err error) {
+	if w.Disabled() {
+		return added, fmt.Errorf("face recognition is disabled")
+	} else if face.AgeBucket <= 0 {
+		return added, nil
+	}
+
+	samples, err := query.FaceAgeSamples()
+
+	if err != nil {
+		return added, err
+	}
+
+	// Group samples by subject and time period.
+	subjects := make(map[string]map[int]*faceAgeBucket)
+
+	for _, s := range samples {
+		embeddings := s.Embeddings()
+
+		if embeddings.Empty() {
+			continue
+		}
+
+		year := AgeYear(s.PhotoYear, face.AgeBucket)
+
+		if subjects[s.SubjUID] == nil {
+			subjects[s.SubjUID] = make(map[int]*faceAgeBucket)
+		}
+
+		b := subjects[s.SubjUID][year]
+
+		if b == nil {
+			b = &faceAgeBucket{Year: year}
+			subjects[s.SubjUID][year] = b
+		}
+
+		b.Markers = append(b.Markers, s.MarkerUID)
+		b.Embeddings = append(b.Embeddings, embeddings[0])
+	}
+
+	for subjUID, buckets := range subjects {
+		// Subjects with confirmed faces from a single period are not affected.
+		if len(buckets) < 2 {
+			continue
+		}
+
+		for _, b := range buckets {
+			if w.Canceled() {
+				return added, fmt.Errorf("worker canceled")
+			}
+
+			f := entity.NewFace(subjUID, entity.SrcManual, b.Embeddings)
+			f.FaceYear = b.Year
+
+			if f.SkipMatching() {
+				log.Debugf("faces: skipped %d cluster for subject %s, embedding not distinct enough", b.Year, entity.SubjNames.Log(subjUID))
+				continue
+			} else if err := f.Create(); err == nil {
+				added++
+				log.Debugf("faces: added %d cluster %s for subject %s, radius %f", b.Year, f.ID, entity.SubjNames.Log(subjUID), f.SampleRadius)
+			} else if err := f.Updates(entity.Values{"UpdatedAt": entity.TimeStamp()}); err != nil {
+				log.Errorf("faces: %s (update %d cluster)", err, b.Year)
+				continue
+			}
+
+			// Previous clusters of these markers are removed as orphans.
+			if _, err := query.SetMarkerFace(b.Markers, f.ID); err != nil {
+				log.Errorf("faces: %s (assign markers to %d cluster)", err, b.Year)
+			}
+		}
+	}
+
+	if added > 0 {
+		log.Infof("faces: added %s for age progression", english.Plural(added, "cluster", "clusters"))
+	}
+
+	return added, nil
+}
@@ -0,0 +1,26 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestAgeYear(t *testing.T) {
+	assert.Equal(t, 1980, AgeYear(1987, 10))
+	assert.Equal(t, 2020, AgeYear(2020, 10))
+	assert.Equal(t, 2015, AgeYear(2018, 5))
+	assert.Equal(t, 0, AgeYear(0, 10))
+	assert.Equal(t, 0, AgeYear(1987, 0))
+}
+
+func TestFaces_Ages(t *testing.T) {
+	c := config.TestConfig()
+	m := NewFaces(c)
+
+	if _, err := m.Ages(); err != nil {
+		t.Fatal(err)
+	}
+}
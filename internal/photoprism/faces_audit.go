@@ -33,6 +33,10 @@ func (w *Faces) Audit(fix bool) (err error) {
 	if n := len(invalidSubj); n == 0 {
 		log.Infof("faces: found no invalid marker subjects")
 	} else if !fix {
+		for _, m := range invalidSubj {
+			log.Infof("faces: marker %s has non-existent subject %s, subject would be removed", m.MarkerUID, clean.Log(m.SubjUID))
+		}
+
 		log.Infof("faces: %s with non-existent subjects", english.Plural(n, "marker", "markers"))
 	} else if removed, err := query.RemoveNonExistentMarkerSubjects(); err != nil {
 		log.Errorf("faces: %s (remove orphan subjects)", err)
@@ -44,6 +48,10 @@ func (w *Faces) Audit(fix bool) (err error) {
 	if n := len(invalidFaces); n == 0 {
 		log.Infof("faces: found no invalid marker faces")
 	} else if !fix {
+		for _, m := range invalidFaces {
+			log.Infof("faces: marker %s has non-existent face %s, face would be removed", m.MarkerUID, clean.Log(m.FaceID))
+		}
+
 		log.Infof("faces: %s with non-existent faces", english.Plural(n, "marker", "markers"))
 	} else if removed, err := query.RemoveNonExistentMarkerFaces(); err != nil {
 		log.Errorf("faces: %s (remove orphan embeddings)", err)
@@ -169,12 +177,19 @@ func (w *Faces) Audit(fix bool) (err error) {
 		}
 	}
 
+	orphanFaces := 0
+	orphanPeople := 0
+
 	// Find and fix orphan face clusters.
 	if orphans, err := entity.OrphanFaces(); err != nil {
 		log.Errorf("faces: %s while finding orphan face clusters", err)
 	} else if l := len(orphans); l == 0 {
 		log.Infof("faces: found no orphan face clusters")
-	} else if !fix {
+	} else if orphanFaces = l; !fix {
+		for _, f := range orphans {
+			log.Infof("faces: orphan face cluster %s would be removed", f.ID)
+		}
+
 		log.Infof("faces: found %s", english.Plural(l, "orphan face cluster", "orphan face clusters"))
 	} else if err := orphans.Delete(); err != nil {
 		log.Errorf("faces: failed removing %s: %s", english.Plural(l, "orphan face cluster", "orphan face clusters"), err)
@@ -187,7 +202,11 @@ func (w *Faces) Audit(fix bool) (err error) {
 		log.Errorf("faces: %s while finding orphan people", err)
 	} else if l := len(orphans); l == 0 {
 		log.Infof("faces: found no orphan people")
-	} else if !fix {
+	} else if orphanPeople = l; !fix {
+		for _, m := range orphans {
+			log.Infof("faces: orphan person %s (%s) would be removed", clean.Log(m.SubjName), m.SubjUID)
+		}
+
 		log.Infof("faces: found %s", english.Plural(l, "orphan person", "orphan people"))
 	} else if err := orphans.Delete(); err != nil {
 		log.Errorf("faces: failed fixing %s: %s", english.Plural(l, "orphan person", "orphan people"), err)
@@ -195,5 +214,14 @@ func (w *Faces) Audit(fix bool) (err error) {
 		log.Infof("faces: removed %s", english.Plural(l, "orphan person", "orphan people"))
 	}
 
+	// Summarize the issues found.
+	if !fix {
+		log.Infof("faces: found %s with invalid references, %s, %s, and %s",
+			english.Plural(len(invalidSubj)+len(invalidFaces), "marker", "markers"),
+			english.Plural(conflicts, "ambiguous subject", "ambiguous subjects"),
+			english.Plural(orphanFaces, "orphan face cluster", "orphan face clusters"),
+			english.Plural(orphanPeople, "orphan person", "orphan people"))
+	}
+
 	return nil
 }
@@ -60,6 +60,13 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 			results[i] = face.Embeddings{}
 		}
 
+		// Clusters that match ignored faces are hidden, so that they don't resurface.
+		ignored, ignoredErr := query.IgnoredFaces()
+
+		if ignoredErr != nil {
+			log.Warnf("faces: %s (find ignored)", ignoredErr)
+		}
+
 		guesses := c.Guesses()
 
 		for i, n := range guesses {
@@ -71,11 +78,20 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 		}
 
 		for _, cluster := range results {
-			if f := entity.NewFace("", entity.SrcAuto, cluster); f == nil {
+			f := entity.NewFace("", entity.SrcAuto, cluster)
+
+			if f == nil {
 				log.Errorf("faces: face should not be nil - possible bug")
+				continue
 			} else if f.SkipMatching() {
 				log.Infof("faces: skipped cluster %s, embedding not distinct enough", f.ID)
-			} else if err := f.Create(); err == nil {
+				continue
+			} else if ignored.Match(cluster) {
+				log.Debugf("faces: cluster %s matches an ignored face and will be hidden", f.ID)
+				f.FaceHidden = true
+			}
+
+			if err := f.Create(); err == nil {
 				added = append(added, *f)
 				log.Debugf("faces: added cluster %s based on %s, radius %f", f.ID, english.Plural(f.Samples, "sample", "samples"), f.SampleRadius)
 			} else if err := f.Updates(entity.Values{"UpdatedAt": entity.TimeStamp()}); err != nil {
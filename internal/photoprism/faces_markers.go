@@ -0,0 +1,105 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// AddMarker creates a face marker for an area selected manually, e.g. if the detector missed the face,
+// and assigns it to the subject, so that matching faces in other pictures can be recognized.
+func (w *Faces) AddMarker(net *face.Net, file *entity.File, area crop.Area, subj *entity.Subject) (*entity.Marker, error) {
+	if w.Disabled() {
+		return nil, fmt.Errorf("face recognition is disabled")
+	} else if net == nil {
+		return nil, fmt.Errorf("face model not initialized")
+	} else if file == nil || file.FileUID == "" {
+		return nil, fmt.Errorf("file required")
+	} else if subj == nil || subj.SubjUID == "" {
+		return nil, fmt.Errorf("subject required")
+	} else if area.Empty() {
+		return nil, fmt.Errorf("empty area")
+	}
+
+	embeddings, err := w.areaEmbeddings(net, file, area)
+
+	if err != nil {
+		return nil, err
+	} else if !embeddings.One() {
+		return nil, fmt.Errorf("face embedding could not be computed")
+	}
+
+	// Face size in pixels based on the original image width.
+	size := int(area.W * float32(file.FileWidth))
+
+	marker := entity.NewMarker(*file, area, subj.SubjUID, entity.SrcManual, entity.MarkerFace, size, 100)
+
+	if marker == nil {
+		return nil, fmt.Errorf("failed creating marker")
+	}
+
+	marker.SubjSrc = entity.SrcManual
+	marker.MarkerName = subj.SubjName
+	marker.SetEmbeddings(embeddings)
+
+	if markers := file.Markers(); markers.Contains(*marker) {
+		return nil, fmt.Errorf("marker already exists")
+	} else if err = marker.Create(); err != nil {
+		return nil, err
+	}
+
+	// Create a face cluster for the subject and match it with existing markers.
+	if err = marker.SyncSubject(true); err != nil {
+		return marker, err
+	} else if err = marker.Save(); err != nil {
+		return marker, err
+	}
+
+	if _, err = file.UpdatePhotoFaceCount(); err != nil {
+		log.Warnf("faces: %s (update face count)", err)
+	}
+
+	return marker, nil
+}
+
+// areaEmbeddings computes the face embeddings for an area of a file, based on its cached thumbnail.
+func (w *Faces) areaEmbeddings(net *face.Net, file *entity.File, area crop.Area) (face.Embeddings, error) {
+	thumbName, err := w.fileThumb(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return net.Embeddings(thumbName, area, true)
+}
+
+// fileThumb returns the thumbnail file name used for face detection.
+func (w *Faces) fileThumb(file *entity.File) (string, error) {
+	mediaFile, err := NewMediaFile(FileName(file.FileRoot, file.FileName))
+
+	if err != nil {
+		return "", err
+	}
+
+	var thumbSize thumb.Name
+
+	// Select best thumbnail depending on configured size, see Index.Faces().
+	if w.conf.ThumbSizePrecached() < 1280 {
+		thumbSize = thumb.Fit720
+	} else {
+		thumbSize = thumb.Fit1280
+	}
+
+	thumbName, err := mediaFile.Thumbnail(w.conf.ThumbCachePath(), thumbSize)
+
+	if err != nil {
+		return "", err
+	} else if thumbName == "" {
+		return "", fmt.Errorf("thumb %s not found", thumbSize)
+	}
+
+	return thumbName, nil
+}
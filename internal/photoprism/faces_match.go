@@ -44,7 +44,8 @@ func (w *Faces) Match(opt FacesOptions) (result FacesMatchResult, err error) {
 	matchedAt := entity.TimePointer()
 
 	if opt.Force || unmatchedMarkers > 0 {
-		faces, err := query.Faces(false, false, false, false)
+		// Include hidden faces, so that matching markers don't form new clusters.
+		faces, err := query.Faces(false, false, true, false)
 
 		if err != nil {
 			return result, err
@@ -0,0 +1,146 @@
+package photoprism
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// FacesMigrateResult represents the outcome of Faces.Migrate().
+type FacesMigrateResult struct {
+	Updated int
+	Failed  int
+	Removed int
+}
+
+// Migrate recomputes the embeddings of face markers that were created with a different model and then
+// clusters and matches them again. Manually assigned names are preserved.
+func (w *Faces) Migrate(net *face.Net) (result FacesMigrateResult, err error) {
+	if w.Disabled() {
+		return result, fmt.Errorf("face recognition is disabled")
+	} else if net == nil {
+		return result, fmt.Errorf("face model not initialized")
+	}
+
+	if result, err = w.migrateMarkers(net); err != nil {
+		return result, err
+	}
+
+	// Cluster and match all markers again.
+	return result, w.Start(FacesOptions{Force: true})
+}
+
+// migrateMarkers recomputes the embeddings of outdated face markers.
+func (w *Faces) migrateMarkers(net *face.Net) (result FacesMigrateResult, err error) {
+	if err = mutex.FacesWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.FacesWorker.Stop()
+
+	model := net.Model()
+
+	if model.Name != face.ActiveModel.Name {
+		return result, fmt.Errorf("face model %s is not active", clean.Log(model.Name))
+	}
+
+	start := time.Now()
+
+	// Remove face clusters that cannot be compared with the new embeddings.
+	if result.Removed, err = query.RemoveOutdatedFaceClusters(); err != nil {
+		return result, fmt.Errorf("faces: %s (remove outdated clusters)", err)
+	} else if result.Removed > 0 {
+		log.Infof("faces: removed %s computed with other models", english.Plural(result.Removed, "cluster", "clusters"))
+	}
+
+	if n := query.CountOutdatedFaceMarkers(); n == 0 {
+		log.Infof("faces: found no markers to be migrated to %s", clean.Log(model.Name))
+		return result, nil
+	} else {
+		log.Infof("faces: migrating %s to %s", english.Plural(n, "marker", "markers"), clean.Log(model.Name))
+	}
+
+	limit := 500
+
+	for {
+		// Markers that have been migrated no longer match, so failed markers are skipped with the offset.
+		markers, err := query.OutdatedFaceMarkers(limit, result.Failed)
+
+		if err != nil {
+			return result, err
+		} else if len(markers) == 0 {
+			break
+		}
+
+		for _, m := range markers {
+			if w.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			if err = w.migrateMarker(net, m); err != nil {
+				log.Warnf("faces: %s (migrate marker %s)", err, clean.Log(m.MarkerUID))
+				result.Failed++
+			} else {
+				result.Updated++
+			}
+		}
+	}
+
+	log.Infof("faces: migrated %s, %d failed [%s]", english.Plural(result.Updated, "marker", "markers"), result.Failed, time.Since(start))
+
+	return result, nil
+}
+
+// migrateMarker recomputes the embeddings of a single face marker.
+func (w *Faces) migrateMarker(net *face.Net, m entity.Marker) error {
+	file, err := query.FileByUID(m.FileUID)
+
+	if err != nil {
+		return err
+	}
+
+	embeddings, err := w.areaEmbeddings(net, file, crop.NewArea("face", m.X, m.Y, m.W, m.H))
+
+	if err != nil {
+		return err
+	}
+
+	m.SetEmbeddings(embeddings)
+
+	values := entity.Values{
+		"EmbeddingsJSON":  m.EmbeddingsJSON,
+		"EmbeddingsModel": m.EmbeddingsModel,
+		"FaceID":          "",
+		"FaceDist":        -1.0,
+		"MatchedAt":       nil,
+	}
+
+	// Only keep names that have been confirmed or added manually.
+	if m.SubjSrc == entity.SrcAuto {
+		values["SubjUID"] = ""
+		values["MarkerName"] = ""
+	}
+
+	if err = m.Updates(values); err != nil {
+		return err
+	}
+
+	m.FaceID = ""
+
+	// Create a new face cluster for subjects with a confirmed name.
+	if m.SubjUID == "" || m.SubjSrc == entity.SrcAuto {
+		return nil
+	} else if f := m.Face(); f != nil {
+		return m.Updates(entity.Values{"FaceID": f.ID, "FaceDist": 0.0})
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import "github.com/photoprism/photoprism/internal/face"
 type FacesOptions struct {
 	Force     bool
 	Threshold int
+	Throttle  bool
 }
 
 // SampleThreshold returns the face embeddings sample threshold for clustering.
@@ -0,0 +1,160 @@
+package photoprism
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clusters"
+)
+
+// PetsDisabled tests if pet and animal face recognition is disabled.
+func (w *Faces) PetsDisabled() bool {
+	return w.conf.DisablePets()
+}
+
+// Pets clusters and matches the faces of pets and other animals, which are kept separate from people.
+func (w *Faces) Pets(opt FacesOptions) (added entity.Faces, result FacesMatchResult, err error) {
+	if w.PetsDisabled() {
+		return added, result, fmt.Errorf("pet recognition is disabled")
+	}
+
+	if added, err = w.clusterPets(opt); err != nil {
+		return added, result, err
+	}
+
+	result, err = w.matchPets(opt)
+
+	return added, result, err
+}
+
+// clusterPets clusters unclustered animal face embeddings.
+func (w *Faces) clusterPets(opt FacesOptions) (added entity.Faces, err error) {
+	embeddings, err := query.MarkerEmbeddings(entity.MarkerPet, false, true, face.ClusterSizeThreshold, face.ClusterScoreThreshold)
+
+	if err != nil {
+		return added, err
+	} else if samples := len(embeddings); samples < opt.SampleThreshold() {
+		log.Debugf("faces: at least %d pet samples needed for clustering", opt.SampleThreshold())
+		return added, nil
+	}
+
+	c, err := clusters.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.Workers(), clusters.EuclideanDist)
+
+	if err != nil {
+		return added, err
+	} else if err = c.Learn(embeddings.Float64()); err != nil {
+		return added, err
+	}
+
+	sizes := c.Sizes()
+	results := make([]face.Embeddings, len(sizes))
+
+	for i, n := range c.Guesses() {
+		if n > 0 {
+			results[n-1] = append(results[n-1], embeddings[i])
+		}
+	}
+
+	for _, cluster := range results {
+		if len(cluster) == 0 {
+			continue
+		}
+
+		f := entity.NewFace("", entity.SrcAuto, cluster)
+		f.FaceKind = int(face.PetFace)
+
+		if f.SkipMatching() {
+			log.Infof("faces: skipped pet cluster %s, embedding not distinct enough", f.ID)
+		} else if err := f.Create(); err == nil {
+			added = append(added, *f)
+			log.Debugf("faces: added pet cluster %s based on %s, radius %f", f.ID, english.Plural(f.Samples, "sample", "samples"), f.SampleRadius)
+		} else if err := f.Updates(entity.Values{"UpdatedAt": entity.TimeStamp()}); err != nil {
+			log.Errorf("faces: %s", err)
+		}
+	}
+
+	if n := len(added); n > 0 {
+		log.Infof("faces: found %s", english.Plural(n, "new pet cluster", "new pet clusters"))
+	}
+
+	return added, nil
+}
+
+// matchPets matches animal face markers with existing pet clusters.
+func (w *Faces) matchPets(opt FacesOptions) (result FacesMatchResult, err error) {
+	faces, err := query.PetFaces(false)
+
+	if err != nil {
+		return result, err
+	} else if len(faces) == 0 {
+		return result, nil
+	}
+
+	var matchedBefore *time.Time
+
+	// Update all markers if enforced, otherwise only unmatched markers.
+	if opt.Force {
+		matchedBefore = &time.Time{}
+	}
+
+	limit := 500
+	offset := 0
+
+	for {
+		markers, err := query.UnmatchedMarkers(entity.MarkerPet, limit, offset, matchedBefore)
+
+		if err != nil {
+			return result, err
+		} else if len(markers) == 0 {
+			break
+		}
+
+		// Processed markers no longer match the query unless all markers are updated.
+		if opt.Force {
+			offset += len(markers)
+		}
+
+		for _, marker := range markers {
+			if w.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			var f *entity.Face
+			var d float64
+
+			// Find the closest pet face.
+			for i, m := range faces {
+				if ok, dist := m.Match(marker.Embeddings()); ok && (f == nil || dist < d) {
+					f = &faces[i]
+					d = dist
+				}
+			}
+
+			if f == nil {
+				result.Unknown++
+			} else if updated, err := marker.SetFace(f, d); err != nil {
+				log.Warnf("faces: %s (match pet)", err)
+			} else if updated {
+				result.Updated++
+
+				if marker.SubjUID != "" {
+					result.Recognized++
+				}
+
+				continue
+			}
+
+			// Update match timestamp so that the marker is not processed again.
+			if err = marker.Matched(); err != nil {
+				log.Warnf("faces: %s (update pet match timestamp)", err)
+			}
+		}
+	}
+
+	return result, nil
+}
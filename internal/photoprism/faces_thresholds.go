@@ -0,0 +1,188 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// FacesThresholdsResult represents the outcome of Faces.Thresholds().
+type FacesThresholdsResult struct {
+	Markers int // Number of automatically detected face markers checked.
+	Removed int // Number of markers that do not meet the new thresholds.
+	Sampled int // Number of files sampled with the new thresholds.
+	Found   int // Number of additional faces found in sampled files.
+	Added   int // Estimated number of markers that would be added.
+	Applied bool
+}
+
+// Thresholds reports how many face markers would be added or removed with different detection thresholds.
+// New faces can only be found by looking at the images again, so this is estimated based on a number of
+// sample files. Markers that do not meet the thresholds are flagged as invalid if apply is true, except
+// those confirmed by a user.
+func (w *Faces) Thresholds(t face.Thresholds, sample int, apply bool) (result FacesThresholdsResult, err error) {
+	if w.Disabled() {
+		return result, fmt.Errorf("face recognition is disabled")
+	} else if t.Size < 20 || t.Score < 1 || t.Overlap < 1 || t.Overlap > 100 {
+		return result, fmt.Errorf("invalid thresholds")
+	}
+
+	removed, err := w.thresholdsRemoved(t, &result)
+
+	if err != nil {
+		return result, err
+	}
+
+	if sample > 0 {
+		if err = w.thresholdsSample(t, sample, &result); err != nil {
+			return result, err
+		}
+	}
+
+	if !apply || len(removed) == 0 {
+		return result, nil
+	}
+
+	files := make(map[string]bool)
+
+	for _, m := range removed {
+		if err = m.Updates(entity.Values{"MarkerInvalid": true, "FaceID": "", "FaceDist": -1.0, "SubjUID": "", "MatchedAt": nil}); err != nil {
+			return result, err
+		}
+
+		files[m.FileUID] = true
+	}
+
+	for fileUID := range files {
+		if file, err := query.FileByUID(fileUID); err != nil {
+			log.Warnf("faces: %s (find file %s)", err, clean.Log(fileUID))
+		} else if _, err = file.UpdatePhotoFaceCount(); err != nil {
+			log.Warnf("faces: %s (update face count)", err)
+		}
+	}
+
+	if err = query.UpdateSubjectCovers(); err != nil {
+		log.Errorf("faces: %s (update covers)", err)
+	}
+
+	if err = entity.UpdateSubjectCounts(); err != nil {
+		log.Errorf("faces: %s (update counts)", err)
+	}
+
+	result.Applied = true
+
+	log.Infof("faces: flagged %s as invalid", english.Plural(len(removed), "marker", "markers"))
+
+	return result, nil
+}
+
+// thresholdsRemoved returns the detected face markers that do not meet the specified thresholds.
+func (w *Faces) thresholdsRemoved(t face.Thresholds, result *FacesThresholdsResult) (removed entity.Markers, err error) {
+	limit := 1000
+	offset := 0
+
+	var kept entity.Markers
+	var fileUID string
+	var markerTime float64
+
+	for {
+		markers, err := query.DetectedFaceMarkers(limit, offset)
+
+		if err != nil {
+			return removed, err
+		} else if len(markers) == 0 {
+			break
+		}
+
+		offset += len(markers)
+
+		for _, m := range markers {
+			result.Markers++
+
+			// Markers are sorted by file, time, and size, so that larger faces take precedence.
+			if m.FileUID != fileUID || m.MarkerTime != markerTime {
+				kept = entity.Markers{}
+				fileUID = m.FileUID
+				markerTime = m.MarkerTime
+			}
+
+			if m.SubjSrc == entity.SrcManual {
+				// Keep markers confirmed by a user.
+			} else if m.Size < t.Size || float32(m.Score) < t.Quality(m.Size) || overlapsAny(kept, m, t.Overlap) {
+				removed = append(removed, m)
+				continue
+			}
+
+			kept = append(kept, m)
+		}
+	}
+
+	result.Removed = len(removed)
+
+	return removed, nil
+}
+
+// thresholdsSample runs the face detection with the specified thresholds on sample files
+// and estimates the number of markers that would be added.
+func (w *Faces) thresholdsSample(t face.Thresholds, sample int, result *FacesThresholdsResult) error {
+	files, err := query.PrimaryImageFiles(sample, 0)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range files {
+		if w.Canceled() {
+			return fmt.Errorf("worker canceled")
+		}
+
+		file := &files[i]
+		thumbName, err := w.fileThumb(file)
+
+		if err != nil {
+			log.Debugf("faces: %s (sample %s)", err, clean.Log(file.FileName))
+			continue
+		}
+
+		faces, err := face.DetectWithThresholds(thumbName, t)
+
+		if err != nil {
+			log.Debugf("faces: %s (sample %s)", err, clean.Log(file.FileName))
+			continue
+		}
+
+		result.Sampled++
+
+		markers := *file.Markers()
+
+		for _, f := range faces {
+			if m := entity.NewMarker(*file, f.CropArea(), "", entity.SrcImage, entity.MarkerFace, f.Size(), f.Score); m == nil {
+				continue
+			} else if !markers.Contains(*m) {
+				result.Found++
+			}
+		}
+	}
+
+	if result.Sampled > 0 {
+		result.Added = result.Found * query.CountPrimaryImageFiles() / result.Sampled
+	}
+
+	return nil
+}
+
+// overlapsAny tests if the marker overlaps any of the other markers by more than the threshold in percent.
+func overlapsAny(markers entity.Markers, m entity.Marker, threshold int) bool {
+	for i := range markers {
+		if markers[i].OverlapPercent(m) > threshold {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+func TestFaces_Thresholds(t *testing.T) {
+	c := config.TestConfig()
+	m := NewFaces(c)
+
+	t.Run("DryRun", func(t *testing.T) {
+		res, err := m.Thresholds(face.Thresholds{Size: 1000, Score: 100, Overlap: 42}, 0, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, res.Applied)
+		assert.LessOrEqual(t, res.Removed, res.Markers)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := m.Thresholds(face.Thresholds{Size: 10, Score: 9, Overlap: 42}, 0, false)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestOverlapsAny(t *testing.T) {
+	a := entity.Marker{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}
+	b := entity.Marker{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}
+	c := entity.Marker{X: 0.6, Y: 0.6, W: 0.2, H: 0.2}
+
+	assert.True(t, overlapsAny(entity.Markers{a}, b, 42))
+	assert.False(t, overlapsAny(entity.Markers{a}, c, 42))
+	assert.False(t, overlapsAny(entity.Markers{}, b, 42))
+}
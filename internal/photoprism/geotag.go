@@ -0,0 +1,145 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/track"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GeotagOptions represents options for geotagging pictures with GPS tracks.
+type GeotagOptions struct {
+	Offset   time.Duration // Time added to the camera clock, e.g. 2m if it was two minutes behind.
+	TimeZone string        // Time zone of the camera clock, used for pictures without a known time zone.
+	MaxGap   time.Duration // Maximum time between two track points, see track.MaxGap.
+	Sidecar  bool          // Update YAML sidecar files.
+}
+
+// GeotagResult represents the outcome of Geotag().
+type GeotagResult struct {
+	Tagged  int
+	Skipped int
+}
+
+// Geotag sets the position of pictures without GPS coordinates whose timestamps fall within the tracks.
+func Geotag(conf *config.Config, tracks track.Tracks, opt GeotagOptions) (result GeotagResult, err error) {
+	if conf == nil {
+		return result, fmt.Errorf("config is nil")
+	} else if tracks.Points() == 0 {
+		return result, fmt.Errorf("no track points found")
+	}
+
+	loc := time.UTC
+
+	if opt.TimeZone != "" {
+		if loc, err = time.LoadLocation(opt.TimeZone); err != nil {
+			return result, fmt.Errorf("invalid time zone %s", clean.Log(opt.TimeZone))
+		}
+	}
+
+	if opt.MaxGap <= 0 {
+		opt.MaxGap = track.MaxGap
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	start := time.Now()
+
+	// Pictures without a known time zone are stored with their local time, which
+	// may differ from UTC by up to 14 hours.
+	pad := 14*time.Hour + opt.Offset
+
+	if opt.Offset < 0 {
+		pad = 14*time.Hour - opt.Offset
+	}
+
+	from, to := tracks.Start().Add(-pad), tracks.End().Add(pad)
+	limit := 500
+
+	for {
+		// Tagged pictures no longer match, so skipped pictures are excluded with the offset.
+		photos, err := query.PhotosWithoutLocation(from, to, limit, result.Skipped)
+
+		if err != nil {
+			return result, err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		for i := range photos {
+			if mutex.MainWorker.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			p := &photos[i]
+
+			if pos, ok := tracks.Position(GeotagTime(*p, loc).Add(opt.Offset), opt.MaxGap); !ok {
+				result.Skipped++
+			} else if err := geotagPhoto(conf, p, pos.Lat, pos.Lng, pos.Altitude, opt.Sidecar); err != nil {
+				log.Warnf("geotag: %s in %s", err, p.String())
+				result.Skipped++
+			} else {
+				log.Debugf("geotag: %s is at %s", p.String(), pos.String())
+				result.Tagged++
+			}
+		}
+	}
+
+	log.Infof("geotag: tagged %s, %d skipped [%s]", english.Plural(result.Tagged, "picture", "pictures"), result.Skipped, time.Since(start))
+
+	return result, nil
+}
+
+// GeotagTime returns the time at which a picture was taken according to the camera clock, in UTC.
+// The local time is interpreted in the specified location if the time zone of the picture is unknown.
+func GeotagTime(p entity.Photo, loc *time.Location) time.Time {
+	if p.TimeZone != "" || p.TakenAtLocal.IsZero() {
+		return p.TakenAt.UTC()
+	}
+
+	t := p.TakenAtLocal
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc).UTC()
+}
+
+// geotagPhoto sets the photo coordinates, updates its location and time zone, and saves it.
+func geotagPhoto(conf *config.Config, p *entity.Photo, lat, lng, altitude float64, sidecar bool) error {
+	p.SetCoordinates(float32(lat), float32(lng), altitude, entity.SrcTrack)
+
+	if !p.HasLatLng() {
+		return fmt.Errorf("position has not been changed")
+	}
+
+	p.UpdateTimeZone(p.GetTimeZone())
+
+	if err := p.SaveLocation(); err != nil {
+		return err
+	}
+
+	if !sidecar {
+		return nil
+	}
+
+	// Update YAML sidecar file.
+	fileName := p.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
+
+	if err := p.SaveAsYaml(fileName); err != nil {
+		log.Errorf("geotag: %s (update yaml)", err)
+	} else {
+		log.Debugf("geotag: updated yaml file %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	return nil
+}
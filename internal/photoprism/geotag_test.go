@@ -0,0 +1,75 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/track"
+)
+
+func TestGeotagTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("KnownTimeZone", func(t *testing.T) {
+		p := entity.Photo{
+			TakenAt:      time.Date(2019, 7, 12, 10, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2019, 7, 12, 12, 0, 0, 0, time.UTC),
+			TimeZone:     "Europe/Berlin",
+		}
+
+		assert.Equal(t, time.Date(2019, 7, 12, 10, 0, 0, 0, time.UTC), GeotagTime(p, time.UTC))
+	})
+	t.Run("UnknownTimeZone", func(t *testing.T) {
+		p := entity.Photo{
+			TakenAt:      time.Date(2019, 7, 12, 12, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2019, 7, 12, 12, 0, 0, 0, time.UTC),
+		}
+
+		assert.Equal(t, time.Date(2019, 7, 12, 12, 0, 0, 0, time.UTC), GeotagTime(p, time.UTC))
+		assert.Equal(t, time.Date(2019, 7, 12, 10, 0, 0, 0, time.UTC), GeotagTime(p, berlin))
+	})
+}
+
+func TestGeotag(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("NoTracks", func(t *testing.T) {
+		_, err := Geotag(conf, track.Tracks{}, GeotagOptions{})
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		tracks, err := track.Read("../track/testdata/hike.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Geotag(conf, tracks, GeotagOptions{TimeZone: "Mars/Olympus"})
+
+		assert.Error(t, err)
+	})
+	t.Run("Success", func(t *testing.T) {
+		tracks, err := track.Read("../track/testdata/hike.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := Geotag(conf, tracks, GeotagOptions{Offset: time.Minute})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Logf("geotag result: %#v", result)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -74,6 +75,18 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		return done
 	}
 
+	// Pause if there is not enough free disk space.
+	if imp.conf.DiskSpaceLow() {
+		event.Error("import: paused because there is not enough free disk space")
+		return done
+	}
+
+	// Check if the destination folder may be changed.
+	if !imp.conf.OriginalsWritable(opt.DestFolder) {
+		event.Error(fmt.Sprintf("import: destination %s is read-only", clean.Log(opt.DestFolder)))
+		return done
+	}
+
 	// Make sure to run import only once, unless otherwise requested.
 	if !opt.NonBlocking {
 		if err := mutex.MainWorker.Start(); err != nil {
@@ -89,6 +102,20 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		return done
 	}
 
+	// Limit the size of imported files if the user has a storage quota.
+	var quota, used int64
+	var quotaExceeded bool
+
+	if u := entity.FindUserByUID(opt.UID); u != nil && u.HasQuota() {
+		var err error
+
+		quota = u.QuotaBytes()
+
+		if used, _, err = u.StorageUsage(); err != nil {
+			log.Warnf("import: %s (get storage usage)", err)
+		}
+	}
+
 	jobs := make(chan ImportJob)
 
 	// Start a fixed number of goroutines to import files.
@@ -104,6 +131,12 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 	filesImported := 0
 
+	var dry importDryRun
+
+	if opt.Dry {
+		log.Infof("import: dry run, no files will be moved, copied, or deleted")
+	}
+
 	settings := imp.conf.Settings()
 	convert := settings.Index.Convert && imp.conf.SidecarWritable()
 	indexOpt := NewIndexOptions("/", true, convert, true, false, false)
@@ -131,7 +164,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 				}
 			}()
 
-			if mutex.MainWorker.Canceled() {
+			if !mutex.MainWorker.Wait() {
 				return errors.New("canceled")
 			}
 
@@ -147,6 +180,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 					directories = append(directories, fileName)
 				}
 
+				// Don't add folders to the index in dry run mode.
+				if opt.Dry {
+					return result
+				}
+
 				folder := entity.NewFolder(entity.RootImport, fs.RelName(fileName, imp.conf.ImportPath()), fs.BirthTime(fileName))
 
 				if err := folder.Create(); err == nil {
@@ -186,6 +224,25 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 				return nil
 			}
 
+			// Skip files that would exceed the storage quota.
+			if quota > 0 {
+				var size int64
+
+				for _, f := range related.Files {
+					if !done[f.FileName()].Processed() {
+						size += f.FileSize()
+					}
+				}
+
+				if used+size > quota {
+					log.Warnf("import: skipped %s, storage quota exceeded", clean.Log(mf.RootRelName()))
+					quotaExceeded = true
+					return nil
+				}
+
+				used += size
+			}
+
 			var files MediaFiles
 
 			for _, f := range related.Files {
@@ -202,7 +259,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 			related.Files = files
 
-			jobs <- ImportJob{
+			job := ImportJob{
 				FileName:  fileName,
 				Related:   related,
 				IndexOpt:  indexOpt,
@@ -210,6 +267,13 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 				Imp:       imp,
 			}
 
+			// Only log the changes in dry run mode.
+			if opt.Dry {
+				dry.Job(job)
+			} else {
+				jobs <- job
+			}
+
 			return nil
 		},
 		Unsorted:            false,
@@ -219,11 +283,27 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	close(jobs)
 	wg.Wait()
 
+	if quotaExceeded {
+		event.Error(i18n.Msg(i18n.ErrQuotaExceeded))
+	}
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
 
-	if opt.RemoveEmptyDirectories {
+	if opt.Dry {
+		// Report hidden .files that would be removed.
+		if opt.RemoveDotFiles {
+			for _, file := range ignore.Hidden() {
+				if fs.FileExists(file) {
+					dry.Deleted++
+					log.Infof("import: %s would be deleted", clean.Log(fs.RelName(file, importPath)))
+				}
+			}
+		}
+
+		dry.Report()
+	} else if opt.RemoveEmptyDirectories {
 		// Remove empty directories from import path.
 		for _, directory := range directories {
 			if fs.DirIsEmpty(directory) {
@@ -236,7 +316,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		}
 	}
 
-	if opt.RemoveDotFiles {
+	if opt.RemoveDotFiles && !opt.Dry {
 		// Remove hidden .files if option is enabled.
 		for _, file := range ignore.Hidden() {
 			if !fs.FileExists(file) {
@@ -253,7 +333,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		log.Error(err.Error())
 	}
 
-	if filesImported > 0 {
+	if filesImported > 0 && !opt.Dry {
 		// Run face recognition if enabled.
 		if w := NewFaces(imp.conf); w.Disabled() {
 			log.Debugf("import: skipping face recognition")
@@ -0,0 +1,58 @@
+package photoprism
+
+import (
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// importDryRun counts the changes that an import would make in dry run mode.
+type importDryRun struct {
+	Imported int
+	Skipped  int
+	Deleted  int
+}
+
+// Job logs which files of the import job would be moved, copied, or deleted without changing anything.
+func (d *importDryRun) Job(job ImportJob) {
+	imp := job.Imp
+	opt := job.ImportOpt
+	src := opt.Path
+	related := job.Related
+
+	if related.Main == nil {
+		log.Warnf("import: %s belongs to no supported media file", clean.Log(fs.RelName(job.FileName, src)))
+		return
+	}
+
+	action := "copied"
+
+	if opt.Move {
+		action = "moved"
+	}
+
+	for _, f := range related.Files {
+		relFileName := f.RelName(src)
+
+		if destFileName, err := imp.DestinationFilename(related.Main, f, opt.DestFolder); err == nil {
+			d.Imported++
+			log.Infof("import: %s file %s would be %s to %s", f.FileType(), clean.Log(relFileName), action, clean.Log(fs.RelName(destFileName, imp.originalsPath())))
+		} else if opt.RemoveExistingFiles {
+			d.Skipped++
+			d.Deleted++
+			log.Infof("import: %s would be deleted, %s", clean.Log(relFileName), err)
+		} else {
+			d.Skipped++
+			log.Infof("import: %s would be skipped, %s", clean.Log(relFileName), err)
+		}
+	}
+}
+
+// Report logs a summary of the changes that the import would make.
+func (d *importDryRun) Report() {
+	log.Infof("import: dry run, would import %s, skip %s, and delete %s",
+		english.Plural(d.Imported, "file", "files"),
+		english.Plural(d.Skipped, "duplicate", "duplicates"),
+		english.Plural(d.Deleted, "file", "files"))
+}
@@ -14,6 +14,7 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	Dry                    bool
 }
 
 // SetUser sets the user who performs the import operation.
@@ -89,7 +89,13 @@ func ImportWorker(jobs <-chan ImportJob) {
 					log.Infof("import: moving related %s file %s to %s", f.FileType(), clean.Log(relFileName), clean.Log(fs.RelName(destFileName, imp.originalsPath())))
 				}
 
-				if opt.Move {
+				if imp.conf.OriginalsHashLayout() {
+					if err := f.Link(destFileName, imp.conf.OriginalsRoot(destFileName).ObjectsPath(), opt.Move); err != nil {
+						logRelName := clean.Log(fs.RelName(destMainFileName, imp.originalsPath()))
+						log.Debugf("import: %s", err.Error())
+						log.Warnf("import: failed linking file to %s, is another import running at the same time?", logRelName)
+					}
+				} else if opt.Move {
 					if err := f.Move(destFileName); err != nil {
 						logRelName := clean.Log(fs.RelName(destMainFileName, imp.originalsPath()))
 						log.Debugf("import: %s", err.Error())
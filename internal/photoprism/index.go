@@ -11,6 +11,8 @@ import (
 
 	"github.com/karrick/godirwalk"
 
+	"github.com/photoprism/photoprism/internal/aesthetic"
+	"github.com/photoprism/photoprism/internal/caption"
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
@@ -19,6 +21,8 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/ocr"
+	"github.com/photoprism/photoprism/internal/semantic"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
@@ -27,20 +31,28 @@ import (
 // Index represents an indexer that indexes files in the originals directory.
 type Index struct {
 	conf         *config.Config
-	tensorFlow   *classify.TensorFlow
+	tensorFlow   classify.Classifier
+	landmarks    *classify.Landmarks
 	nsfwDetector *nsfw.Detector
 	faceNet      *face.Net
+	ocr          *ocr.Tesseract
+	caption      *caption.Client
+	semantic     *semantic.Client
+	quality      *aesthetic.Model
 	convert      *Convert
 	files        *Files
 	photos       *Photos
 	lastRun      time.Time
 	lastFound    int
 	findFaces    bool
+	findPets     bool
+	findVideos   bool
 	findLabels   bool
+	findText     bool
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
+func NewIndex(conf *config.Config, tensorFlow classify.Classifier, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
 	if conf == nil {
 		log.Errorf("index: config is not set")
 		return nil
@@ -55,7 +67,45 @@ func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector
 		files:        files,
 		photos:       photos,
 		findFaces:    !conf.DisableFaces(),
+		findPets:     !conf.DisablePets(),
+		findVideos:   conf.FaceKeyframes() > 0,
 		findLabels:   !conf.DisableClassification(),
+		findText:     !conf.DisableOCR(),
+	}
+
+	if i.findText {
+		i.ocr = ocr.NewTesseract(conf.TesseractBin(), conf.OcrLanguages(), conf.CmdCachePath())
+	}
+
+	if u := conf.LandmarksUrl(); u != "" {
+		i.landmarks = classify.NewLandmarks(classify.NewRemote(u, conf.ClassifyKey(), false))
+
+		if err := i.landmarks.Init(); err != nil {
+			log.Warnf("index: %s (landmarks)", err)
+			i.landmarks = nil
+		}
+	}
+
+	if u := conf.CaptionUrl(); u != "" {
+		i.caption = caption.New(u)
+
+		if err := i.caption.Init(); err != nil {
+			log.Warnf("index: %s", err)
+			i.caption = nil
+		}
+	}
+
+	if u := conf.SemanticUrl(); u != "" {
+		i.semantic = semantic.New(u)
+
+		if err := i.semantic.Init(); err != nil {
+			log.Warnf("index: %s", err)
+			i.semantic = nil
+		}
+	}
+
+	if model := conf.QualityModel(); model != aesthetic.ModelNone {
+		i.quality = aesthetic.New(model, conf.AestheticModelPath())
 	}
 
 	return i
@@ -98,6 +148,9 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 	} else if fs.DirIsEmpty(originalsPath) {
 		event.InfoMsg(i18n.ErrOriginalsEmpty)
 		return found, updated
+	} else if ind.conf.DiskSpaceLow() {
+		event.Error("index: paused because there is not enough free disk space")
+		return found, updated
 	}
 
 	if err := mutex.MainWorker.Start(); err != nil {
@@ -154,7 +207,9 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 				}
 			}()
 
-			if mutex.MainWorker.Canceled() {
+			if !mutex.MainWorker.Wait() {
+				return errors.New("canceled")
+			} else if o.Throttle && !Throttle(ind.conf, "index", mutex.MainWorker.Canceled) {
 				return errors.New("canceled")
 			}
 
@@ -312,6 +367,11 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 		if err := entity.UpdateCounts(); err != nil {
 			log.Warnf("index: %s (update counts)", err)
 		}
+
+		// Update label hierarchy so that searches include more specific labels.
+		if _, err := entity.RebuildLabelClosure(); err != nil {
+			log.Warnf("index: %s (update label hierarchy)", err)
+		}
 	} else {
 		log.Infof("index: found no new or modified files")
 	}
@@ -0,0 +1,39 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Caption generates a description of a JPEG image, e.g. "A dog running on a beach at sunset".
+func (ind *Index) Caption(jpeg *MediaFile) string {
+	if jpeg == nil || ind.caption == nil {
+		return ""
+	}
+
+	start := time.Now()
+
+	filename, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("%s in %s (generate caption)", err, clean.Log(jpeg.BaseName()))
+		return ""
+	}
+
+	result, err := ind.caption.File(filename)
+
+	if err != nil {
+		log.Warnf("index: %s in %s", err, clean.Log(jpeg.BaseName()))
+		return ""
+	}
+
+	text := result.Caption()
+
+	if text != "" {
+		log.Infof("index: generated caption for %s [%s]", clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return text
+}
@@ -12,42 +12,77 @@ import (
 
 // Faces finds faces in JPEG media files and returns them.
 func (ind *Index) Faces(jpeg *MediaFile, expected int) face.Faces {
-	if jpeg == nil {
+	thumbName := ind.facesThumb(jpeg)
+
+	if thumbName == "" {
 		return face.Faces{}
 	}
 
-	var thumbSize thumb.Name
-
-	// Select best thumbnail depending on configured size.
-	if Config().ThumbSizePrecached() < 1280 {
-		thumbSize = thumb.Fit720
-	} else {
-		thumbSize = thumb.Fit1280
-	}
+	start := time.Now()
 
-	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumbSize)
+	faces, err := ind.faceNet.Detect(thumbName, Config().FaceSize(), true, expected)
 
 	if err != nil {
-		log.Debugf("index: %s in %s (faces)", err, clean.Log(jpeg.BaseName()))
-		return face.Faces{}
+		log.Debugf("%s in %s", err, clean.Log(jpeg.BaseName()))
+	}
+
+	if l := len(faces); l > 0 {
+		log.Infof("index: found %s in %s [%s]", english.Plural(l, "face", "faces"), clean.Log(jpeg.BaseName()), time.Since(start))
 	}
 
+	return faces
+}
+
+// Pets finds the faces of pets and other animals in JPEG media files and returns them.
+func (ind *Index) Pets(jpeg *MediaFile, expected int) face.Faces {
+	thumbName := ind.facesThumb(jpeg)
+
 	if thumbName == "" {
-		log.Debugf("index: thumb %s not found in %s (faces)", thumbSize, clean.Log(jpeg.BaseName()))
 		return face.Faces{}
 	}
 
 	start := time.Now()
 
-	faces, err := ind.faceNet.Detect(thumbName, Config().FaceSize(), true, expected)
+	faces, err := ind.faceNet.DetectPets(thumbName, Config().FaceSize(), true, expected)
 
 	if err != nil {
 		log.Debugf("%s in %s", err, clean.Log(jpeg.BaseName()))
 	}
 
 	if l := len(faces); l > 0 {
-		log.Infof("index: found %s in %s [%s]", english.Plural(l, "face", "faces"), clean.Log(jpeg.BaseName()), time.Since(start))
+		log.Infof("index: found %s in %s [%s]", english.Plural(l, "pet", "pets"), clean.Log(jpeg.BaseName()), time.Since(start))
 	}
 
 	return faces
 }
+
+// facesThumb returns the thumbnail file name to be used for face detection.
+func (ind *Index) facesThumb(jpeg *MediaFile) string {
+	if jpeg == nil {
+		return ""
+	}
+
+	thumbSize := ind.facesThumbSize()
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumbSize)
+
+	if err != nil {
+		log.Debugf("index: %s in %s (faces)", err, clean.Log(jpeg.BaseName()))
+		return ""
+	}
+
+	if thumbName == "" {
+		log.Debugf("index: thumb %s not found in %s (faces)", thumbSize, clean.Log(jpeg.BaseName()))
+	}
+
+	return thumbName
+}
+
+// facesThumbSize returns the best thumbnail size for face detection depending on the configured size.
+func (ind *Index) facesThumbSize() thumb.Name {
+	if Config().ThumbSizePrecached() < 1280 {
+		return thumb.Fit720
+	}
+
+	return thumb.Fit1280
+}
@@ -0,0 +1,141 @@
+package photoprism
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Keyframe represents a still image sampled from a video.
+type Keyframe struct {
+	Offset    time.Duration
+	Hash      string
+	ThumbName string
+}
+
+// KeyframeHash returns a unique hash for the still image sampled from a video at the specified time offset.
+func KeyframeHash(fileHash string, offset time.Duration) string {
+	s := sha1.Sum([]byte(fmt.Sprintf("%s:%d", fileHash, offset.Milliseconds())))
+	return hex.EncodeToString(s[:])
+}
+
+// Keyframes samples still images at evenly spaced positions from a video and returns their thumbnails,
+// which are cached so that face crops can be created from them later.
+func (ind *Index) Keyframes(video *MediaFile, fileHash string) (result []Keyframe) {
+	if video == nil || fileHash == "" {
+		return result
+	}
+
+	size := thumb.Sizes[ind.facesThumbSize()]
+	thumbPath := Config().ThumbCachePath()
+
+	for _, offset := range ffmpeg.KeyframeOffsets(video.Duration(), Config().FaceKeyframes()) {
+		hash := KeyframeHash(fileHash, offset)
+
+		// Already sampled?
+		if thumbName, err := size.FromCache(video.FileName(), hash, thumbPath); err == nil {
+			result = append(result, Keyframe{Offset: offset, Hash: hash, ThumbName: thumbName})
+			continue
+		}
+
+		jpegName := filepath.Join(Config().TempPath(), hash+fs.ExtJPEG)
+
+		if err := ind.extractKeyframe(video, offset, jpegName); err != nil {
+			log.Debugf("index: %s in %s at %s (extract keyframe)", err, clean.Log(video.BaseName()), ffmpeg.TimeOffset(offset))
+			continue
+		}
+
+		thumbName, err := size.FromFile(jpegName, hash, thumbPath, 1)
+
+		if err := os.Remove(jpegName); err != nil {
+			log.Debugf("index: %s (remove keyframe)", err)
+		}
+
+		if err != nil {
+			log.Debugf("index: %s in %s (keyframe thumb)", err, clean.Log(video.BaseName()))
+			continue
+		}
+
+		result = append(result, Keyframe{Offset: offset, Hash: hash, ThumbName: thumbName})
+	}
+
+	return result
+}
+
+// extractKeyframe uses ffmpeg to extract a single JPEG still image from a video.
+func (ind *Index) extractKeyframe(video *MediaFile, offset time.Duration, jpegName string) error {
+	if err := os.MkdirAll(filepath.Dir(jpegName), os.ModePerm); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(Config().FFmpegBin(), "-y", "-ss", ffmpeg.TimeOffset(offset), "-i", video.FileName(), "-vframes", "1", jpegName)
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", Config().CmdCachePath())}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	if err := cmd.Run(); err != nil {
+		if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
+			return errors.New(errStr)
+		}
+
+		return err
+	} else if !fs.FileExistsNotEmpty(jpegName) {
+		return fmt.Errorf("no image extracted")
+	}
+
+	return nil
+}
+
+// KeyframeFaces finds faces in still images sampled from a video and adds them as time-coded markers.
+func (ind *Index) KeyframeFaces(video *MediaFile, file *entity.File) (found int) {
+	if video == nil || file == nil || ind.faceNet == nil {
+		return 0
+	}
+
+	markers := file.Markers()
+
+	if markers == nil {
+		return 0
+	}
+
+	start := time.Now()
+
+	for _, k := range ind.Keyframes(video, file.FileHash) {
+		faces, err := ind.faceNet.Detect(k.ThumbName, Config().FaceSize(), true, markers.KeyframeFaceCount(k.Offset))
+
+		if err != nil {
+			log.Debugf("%s in %s at %s", err, clean.Log(video.BaseName()), ffmpeg.TimeOffset(k.Offset))
+			continue
+		} else if len(faces) == 0 {
+			continue
+		}
+
+		found += len(faces)
+		file.AddKeyframeFaces(faces, k.Hash, k.Offset)
+	}
+
+	if found > 0 {
+		log.Infof("index: found %s in %s [%s]", english.Plural(found, "face", "faces"), clean.Log(video.BaseName()), time.Since(start))
+	}
+
+	return found
+}
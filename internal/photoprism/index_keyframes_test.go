@@ -0,0 +1,17 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyframeHash(t *testing.T) {
+	hash := "2a7c9e2f3b1d4e5f6a7b8c9d0e1f2a3b4c5d6e7f"
+
+	assert.Len(t, KeyframeHash(hash, time.Second), 40)
+	assert.Equal(t, KeyframeHash(hash, time.Second), KeyframeHash(hash, time.Second))
+	assert.NotEqual(t, KeyframeHash(hash, time.Second), KeyframeHash(hash, 2*time.Second))
+	assert.NotEqual(t, hash, KeyframeHash(hash, 0))
+}
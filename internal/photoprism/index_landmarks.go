@@ -0,0 +1,36 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Landmarks returns the famous buildings, monuments, and other points of interest recognized in a JPEG image.
+func (ind *Index) Landmarks(jpeg *MediaFile) (results classify.Labels) {
+	if ind.landmarks == nil {
+		return results
+	}
+
+	start := time.Now()
+
+	filename, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("%s in %s", err, clean.Log(jpeg.BaseName()))
+		return results
+	}
+
+	if results, err = ind.landmarks.File(filename); err != nil {
+		log.Warnf("index: %s in %s (recognize landmarks)", err, clean.Log(jpeg.BaseName()))
+		return results
+	}
+
+	if l := len(results); l > 0 {
+		log.Infof("index: recognized %s in %s [%s]", clean.Log(results[0].Name), clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return results
+}
@@ -230,8 +230,10 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		log.Errorf("index: %s in %s (purge duplicate)", err, m.RootRelName())
 	}
 
-	// Create default thumbnails if needed.
-	if err := m.CreateThumbnails(ind.thumbPath(), false); err != nil {
+	// Create default thumbnails if needed, unless they are created by a worker node.
+	if m.IsPreviewImage() && OffloadJob(entity.NodeJobThumbs, m, "", false) {
+		log.Debugf("index: queued thumbnails for %s", clean.Log(m.RootRelName()))
+	} else if err := m.CreateThumbnails(ind.thumbPath(), false); err != nil {
 		result.Status = IndexFailed
 		result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", clean.Log(m.RootRelName()), err.Error())
 		return result
@@ -323,8 +325,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 	// Extra labels to ba added when new files have a photo id.
 	extraLabels := classify.Labels{}
 
+	// Detect faces in videos?
+	videoFaces := ind.findVideos && m.IsVideo()
+
 	// Detect faces in images?
-	if o.FacesOnly && (!photoExists || !fileExists || !file.FilePrimary || file.FileError != "") {
+	if o.FacesOnly && (!photoExists || !fileExists || !(file.FilePrimary || videoFaces) || file.FileError != "") {
 		// New and non-primary files can be skipped when updating faces only.
 		result.Status = IndexSkipped
 		return result
@@ -338,6 +343,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 				file.AddFaces(faces)
 			}
 
+			// Detect pets and other animals.
+			if ind.findPets {
+				if pets := ind.Pets(m, markers.DetectedPetCount()); len(pets) > 0 {
+					file.AddPets(pets)
+				}
+			}
+
 			// Any new markers?
 			if file.UnsavedMarkers() {
 				// Add matching labels.
@@ -353,6 +365,18 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		} else {
 			log.Errorf("index: failed loading markers for %s", logName)
 		}
+	} else if videoFaces {
+		// Detect faces in still images sampled from the video.
+		ind.KeyframeFaces(m, &file)
+
+		if file.UnsavedMarkers() {
+			// Add matching labels.
+			extraLabels = append(extraLabels, file.Markers().Labels()...)
+		} else if o.FacesOnly {
+			// Skip when indexing faces only.
+			result.Status = IndexSkipped
+			return result
+		}
 	}
 
 	// Reset file perceptive diff and chroma percent.
@@ -675,24 +699,48 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 	file.DeletedAt = nil
 	file.FileMissing = false
 
+	var offloadLabels bool
+
 	// Previews files are used for rendering thumbnails and image classification, plus sidecar files if they exist.
 	if file.FilePrimary {
 		primaryFile = file
 
-		// Classify images with TensorFlow?
+		// Classify images with TensorFlow, or queue the classification for a worker node?
 		if ind.findLabels {
-			labels = ind.Labels(m)
+			if offloadLabels = Config().OffloadJobs(); !offloadLabels {
+				labels = ind.Labels(m)
+			}
 
 			// Append labels from other sources such as face detection.
 			if len(extraLabels) > 0 {
 				labels = append(labels, extraLabels...)
 			}
 
-			if !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
-				photo.PhotoPrivate = ind.NSFW(m)
+			if !photoExists && Config().DetectNSFW() {
+				photo.SetNSFW(ind.NSFW(m))
+
+				// Flag pictures that may be offensive as private?
+				if photo.IsNSFW() && Config().Settings().Features.Private {
+					photo.PhotoPrivate = true
+				}
 			}
 		}
 
+		// Recognize famous buildings, monuments, and other landmarks?
+		if ind.landmarks != nil {
+			labels = append(labels, ind.Landmarks(m)...)
+		}
+
+		// Recognize text on scans, screenshots, and signs?
+		if ind.textEnabled() {
+			details.SetText(ind.Text(m), entity.SrcOCR)
+		}
+
+		// Score image quality to flag out-of-focus and poorly exposed shots?
+		if q, ok := ind.Quality(m); ok {
+			photo.SetScore(q.Score, q.Blurry(), q.PoorlyExposed())
+		}
+
 		// Read metadata from embedded Exif and JSON sidecar file, if exists.
 		if metaData := m.MetaData(); metaData.Error == nil {
 			// Update basic metadata.
@@ -722,6 +770,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		photo.SetLens(entity.FirstOrCreateLens(entity.NewLens(m.LensModel(), m.LensMake())), entity.SrcMeta)
 		photo.SetExposure(m.FocalLength(), m.FNumber(), m.Iso(), m.Exposure(), entity.SrcMeta)
 
+		// Generate a description if the picture doesn't have one yet?
+		if ind.caption != nil && photo.NoDescription() {
+			photo.SetCaption(ind.Caption(m))
+		}
+
 		var locLabels classify.Labels
 
 		locKeywords, locLabels = photo.UpdateLocation()
@@ -759,6 +812,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		file.SetColorProfile(m.ColorProfile())
 	}
 
+	// Mark new pictures as favorites if their metadata says so, e.g. when synced from Google Photos.
+	if !photoExists && !photo.HasID() && m.IsMedia() && m.MetaData().Favorite {
+		photo.PhotoFavorite = true
+	}
+
 	// Update existing photo?
 	if photoExists || photo.HasID() {
 		if err := photo.Save(); err != nil {
@@ -775,6 +833,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			photo = *p
 		}
 
+		// Add new pictures to the albums listed in their metadata.
+		if albums := m.MetaData().Albums; len(albums) > 0 && m.IsMedia() {
+			if err := entity.AddPhotoToAlbums(photo.PhotoUID, albums); err != nil {
+				log.Warnf("index: %s in %s (add to albums)", err, logName)
+			}
+		}
+
 		if photo.PhotoPrivate {
 			event.Publish("count.private", event.Data{
 				"count": 1,
@@ -798,7 +863,20 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		event.EntitiesCreated("photos", []entity.Photo{photo})
 	}
 
+	// Add custom labels for matching keywords, see label rules.
+	labels = append(labels, classify.VocabularyLabels(details.Keywords, photo.PhotoTitle, photo.OriginalName, fileName, strings.Join(labels.Keywords(), " "))...)
+
+	// Find geofences that contain the position, e.g. to add pictures taken at the cottage to the "Cottage" album.
+	fences := entity.CachedGeofences().At(float64(photo.PhotoLat), float64(photo.PhotoLng))
+	labels = append(labels, fences.Labels()...)
+
 	photo.AddLabels(labels)
+	fences.AddPhoto(photo.PhotoUID)
+
+	// Labels are added by a worker node once the image has been classified.
+	if offloadLabels {
+		OffloadJob(entity.NodeJobLabels, m, photo.PhotoUID, false)
+	}
 
 	file.PhotoID = photo.ID
 	result.PhotoID = photo.ID
@@ -860,6 +938,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		if err := query.AlbumEntryFound(photo.PhotoUID); err != nil {
 			log.Errorf("index: %s in %s (remove missing flag from album entry)", err, logName)
 		}
+
+		// Store image embedding for semantic search.
+		if ind.semantic != nil {
+			ind.SemanticEmbedding(m, photo.PhotoUID)
+		}
 	} else if err := photo.UpdateQuality(); err != nil {
 		result.Status = IndexFailed
 		result.Err = fmt.Errorf("index: %s in %s (update quality)", err, logName)
@@ -1,29 +1,32 @@
 package photoprism
 
 import (
-	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
-// NSFW returns true if media file might be offensive and detection is enabled.
-func (ind *Index) NSFW(m *MediaFile) bool {
+// NSFW returns a score that indicates how likely the media file is offensive, or 0 if it cannot be detected.
+func (ind *Index) NSFW(m *MediaFile) float32 {
 	filename, err := m.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
 
 	if err != nil {
 		log.Error(err)
-		return false
+		return 0
 	}
 
-	if nsfwLabels, err := ind.nsfwDetector.File(filename); err != nil {
+	nsfwLabels, err := ind.nsfwDetector.File(filename)
+
+	if err != nil {
 		log.Errorf("index: %s in %s (detect nsfw)", err, m.RootRelName())
-		return false
-	} else {
-		if nsfwLabels.NSFW(nsfw.ThresholdHigh) {
-			log.Warnf("index: %s might contain offensive content", clean.Log(m.RelName(Config().OriginalsPath())))
-			return true
-		}
+		return 0
+	}
+
+	score := nsfwLabels.Score()
+
+	if score > entity.NSFWThreshold {
+		log.Warnf("index: %s might contain offensive content", clean.Log(m.RelName(Config().OriginalsPath())))
 	}
 
-	return false
+	return score
 }
@@ -14,6 +14,7 @@ type IndexOptions struct {
 	SkipArchived    bool
 	ByteLimit       int64
 	ResolutionLimit int
+	Throttle        bool
 }
 
 // NewIndexOptions returns new index options instance.
@@ -0,0 +1,39 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/aesthetic"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// qualityEnabled tests if the image quality should be scored.
+func (ind *Index) qualityEnabled() bool {
+	return ind.quality != nil && !ind.quality.Disabled()
+}
+
+// Quality scores the image quality of a JPEG and flags out-of-focus and poorly exposed shots.
+func (ind *Index) Quality(jpeg *MediaFile) (result aesthetic.Result, ok bool) {
+	if jpeg == nil || !ind.qualityEnabled() {
+		return result, false
+	}
+
+	filename, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("%s in %s (score quality)", err, clean.Log(jpeg.BaseName()))
+		return result, false
+	}
+
+	if result, err = ind.quality.File(filename); err != nil {
+		log.Debugf("%s in %s (score quality)", err, clean.Log(jpeg.BaseName()))
+		return result, false
+	}
+
+	if result.Blurry() {
+		log.Debugf("index: %s seems to be out of focus", clean.Log(jpeg.BaseName()))
+	} else if result.PoorlyExposed() {
+		log.Debugf("index: %s seems to be poorly exposed", clean.Log(jpeg.BaseName()))
+	}
+
+	return result, true
+}
@@ -0,0 +1,41 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/semantic"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SemanticEmbedding computes and stores the image embedding of a JPEG for semantic search, unless it already exists.
+func (ind *Index) SemanticEmbedding(jpeg *MediaFile, photoUID string) {
+	if jpeg == nil || ind.semantic == nil || photoUID == "" {
+		return
+	} else if entity.FindPhotoEmbedding(photoUID, semantic.Model) != nil {
+		return
+	}
+
+	start := time.Now()
+
+	filename, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("%s in %s (compute embedding)", err, clean.Log(jpeg.BaseName()))
+		return
+	}
+
+	embedding, err := ind.semantic.File(filename)
+
+	if err != nil {
+		log.Warnf("index: %s in %s", err, clean.Log(jpeg.BaseName()))
+		return
+	}
+
+	if err = entity.NewPhotoEmbedding(photoUID, semantic.Model, embedding).Save(); err != nil {
+		log.Errorf("index: %s in %s (save embedding)", err, clean.Log(jpeg.BaseName()))
+	} else {
+		log.Debugf("index: computed embedding of %s [%s]", clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+}
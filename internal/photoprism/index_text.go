@@ -0,0 +1,40 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// textEnabled tests if text recognition is enabled for the library.
+func (ind *Index) textEnabled() bool {
+	return ind.findText && ind.ocr != nil && ind.conf.Settings().Index.OCR
+}
+
+// Text recognizes text in a JPEG image, e.g. on scans, screenshots, and signs.
+func (ind *Index) Text(jpeg *MediaFile) string {
+	if jpeg == nil || ind.ocr == nil {
+		return ""
+	}
+
+	start := time.Now()
+
+	filename, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit1920)
+
+	if err != nil {
+		log.Debugf("%s in %s (recognize text)", err, clean.Log(jpeg.BaseName()))
+		return ""
+	}
+
+	text, err := ind.ocr.File(filename)
+
+	if err != nil {
+		log.Debugf("%s in %s (recognize text)", err, clean.Log(jpeg.BaseName()))
+		return ""
+	} else if text != "" {
+		log.Infof("index: recognized text in %s [%s]", clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return text
+}
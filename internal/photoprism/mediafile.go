@@ -27,6 +27,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/vfs"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
@@ -525,7 +526,7 @@ func (m *MediaFile) Exists() bool {
 
 // Remove permanently removes a media file.
 func (m *MediaFile) Remove() error {
-	return os.Remove(m.FileName())
+	return vfs.Remove(m.FileName())
 }
 
 // HasSameName compares a media file with another media file and returns if
@@ -544,7 +545,9 @@ func (m *MediaFile) Move(dest string) error {
 		return err
 	}
 
-	if err := os.Rename(m.fileName, dest); err != nil {
+	if err := vfs.CheckWrite(m.fileName); err != nil {
+		return err
+	} else if err = os.Rename(m.fileName, dest); err != nil {
 		log.Debugf("failed renaming file, fallback to copy and delete: %s", err.Error())
 	} else {
 		m.SetFileName(dest)
@@ -556,7 +559,7 @@ func (m *MediaFile) Move(dest string) error {
 		return err
 	}
 
-	if err := os.Remove(m.fileName); err != nil {
+	if err := vfs.Remove(m.fileName); err != nil {
 		return err
 	}
 
@@ -583,7 +586,7 @@ func (m *MediaFile) Copy(dest string) error {
 
 	defer thisFile.Close()
 
-	destFile, err := os.OpenFile(dest, os.O_RDWR|os.O_CREATE, fs.ModeFile)
+	destFile, err := vfs.OpenFile(dest, os.O_RDWR|os.O_CREATE, fs.ModeFile)
 
 	if err != nil {
 		log.Error(err.Error())
@@ -602,6 +605,30 @@ func (m *MediaFile) Copy(dest string) error {
 	return nil
 }
 
+// Link stores the file by content hash in the objects path and creates a hard link with the destination filename,
+// so that identical files and renames do not use additional storage. The original file is removed if move is true.
+func (m *MediaFile) Link(dest, objectsPath string, move bool) error {
+	if err := vfs.CheckWrite(dest); err != nil {
+		return err
+	} else if move {
+		if err = vfs.CheckWrite(m.fileName); err != nil {
+			return err
+		}
+	}
+
+	m.fileMutex.Lock()
+	err := fs.LinkObject(m.fileName, dest, objectsPath, m.Hash(), move)
+	m.fileMutex.Unlock()
+
+	if err != nil {
+		return err
+	} else if move {
+		m.SetFileName(dest)
+	}
+
+	return nil
+}
+
 // Extension returns the filename extension of this media file.
 func (m *MediaFile) Extension() string {
 	return strings.ToLower(filepath.Ext(m.fileName))
@@ -67,6 +67,8 @@ func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
 	if !m.IsPreviewImage() {
 		// Skip.
 		return
+	} else if Config().DiskSpaceLow() {
+		return fmt.Errorf("media: thumbnail generation paused because there is not enough free disk space")
 	}
 
 	count := 0
@@ -101,6 +103,10 @@ func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
 		} else if force || !fs.FileExists(fileName) {
 			// Open original if needed.
 			if original == nil {
+				// Wait until enough memory is available.
+				memSize, release := thumb.ReserveFileMemory(m.FileName())
+				defer release()
+
 				img, err := thumb.Open(m.FileName(), m.Orientation())
 
 				// Try to fix broken JPEGs if possible, fail otherwise.
@@ -122,6 +128,14 @@ func (m *MediaFile) CreateThumbnails(thumbPath string, force bool) (err error) {
 				original = img
 
 				log.Debugf("media: opened %s [%s]", clean.Log(m.RootRelName()), thumb.MemSize(original).String())
+
+				// Reduce memory usage if the image exceeds the budget.
+				if thumb.ExceedsMemory(memSize) {
+					if resized := thumb.Downsize(original); resized != original {
+						original = resized
+						log.Infof("media: downsized %s to %dx%d, as it exceeds the memory budget", clean.Log(m.RootRelName()), original.Bounds().Dx(), original.Bounds().Dy())
+					}
+				}
 			}
 
 			// Thumb size too large
@@ -46,6 +46,29 @@ func (w *Moments) MigrateSlug(m query.Moment, albumType string) {
 
 // Start creates albums based on popular locations, dates and categories.
 func (w *Moments) Start() (err error) {
+	return w.start(false)
+}
+
+// Rebuild removes generated albums that no longer match the current settings and thresholds,
+// and then creates missing albums, without having to index the library again.
+func (w *Moments) Rebuild() (err error) {
+	return w.start(true)
+}
+
+// Threshold returns the minimum number of pictures required for country and label moments.
+func (w *Moments) Threshold(indexSize int) int {
+	if threshold := w.conf.Settings().Moments.Threshold; threshold > 0 {
+		return threshold
+	} else if indexSize > 4 {
+		return int(math.Log2(float64(indexSize))) + 1
+	}
+
+	return 3
+}
+
+// start creates albums based on popular locations, dates and categories, and optionally
+// removes those that are no longer generated.
+func (w *Moments) start(rebuild bool) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("%s (panic)\nstack: %s", r, debug.Stack())
@@ -70,16 +93,16 @@ func (w *Moments) Start() (err error) {
 	counts.Refresh()
 
 	indexSize := counts.Photos + counts.Videos
+	threshold := w.Threshold(indexSize)
+	settings := w.conf.Settings().Moments
 
-	threshold := 3
-
-	if indexSize > 4 {
-		threshold = int(math.Log2(float64(indexSize))) + 1
-	}
+	// Remember the generated albums so that others can be removed when rebuilding,
+	// which is skipped if the albums could not be determined due to an error.
+	keep := make(map[string]bool)
 
 	log.Debugf("moments: analyzing %d photos and %d videos, with threshold %d", counts.Photos, counts.Videos, threshold)
 
-	if indexSize < threshold {
+	if settings.Threshold <= 0 && indexSize < threshold {
 		log.Debugf("moments: not enough files")
 
 		return nil
@@ -114,17 +137,23 @@ func (w *Moments) Start() (err error) {
 					log.Errorf("moments: %s (create folder)", err)
 				} else {
 					log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+					keep[a.AlbumUID] = true
 				}
 			}
 		}
 	}
 
 	// Create an album for each month and year.
-	if results, err := query.MomentsTime(1, w.conf.Settings().Features.Private); err != nil {
+	if !settings.Calendar {
+		// Disabled.
+	} else if results, err := query.MomentsTime(1, w.conf.Settings().Features.Private); err != nil {
 		log.Errorf("moments: %s", err.Error())
+		rebuild = false
 	} else {
 		for _, mom := range results {
 			if a := entity.FindMonthAlbum(mom.Year, mom.Month); a != nil {
+				keep[a.AlbumUID] = true
+
 				if err := a.UpdateTitleAndLocation(mom.Title(), "", "", "", mom.Slug()); err != nil {
 					log.Errorf("moments: %s (update slug)", err.Error())
 				}
@@ -141,14 +170,18 @@ func (w *Moments) Start() (err error) {
 					log.Errorf("moments: %s", err)
 				} else {
 					log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+					keep[a.AlbumUID] = true
 				}
 			}
 		}
 	}
 
 	// Create moments based on country and year.
-	if results, err := query.MomentsCountries(threshold, w.conf.Settings().Features.Private); err != nil {
+	if !settings.Countries {
+		// Disabled.
+	} else if results, err := query.MomentsCountries(threshold, w.conf.Settings().Features.Private); err != nil {
 		log.Errorf("moments: %s", err.Error())
+		rebuild = false
 	} else {
 		for _, mom := range results {
 			f := form.SearchPhotos{
@@ -158,6 +191,8 @@ func (w *Moments) Start() (err error) {
 			}
 
 			if a := entity.FindAlbumByAttr(S{mom.Slug(), mom.TitleSlug()}, S{f.Serialize()}, entity.AlbumMoment); a != nil {
+				keep[a.AlbumUID] = true
+
 				if err := a.UpdateTitleAndLocation(mom.Title(), "", mom.State, mom.Country, mom.Slug()); err != nil {
 					log.Errorf("moments: %s (update slug)", err.Error())
 				}
@@ -176,14 +211,18 @@ func (w *Moments) Start() (err error) {
 					log.Errorf("moments: %s", err)
 				} else {
 					log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+					keep[a.AlbumUID] = true
 				}
 			}
 		}
 	}
 
 	// Create moments based on states and countries.
-	if results, err := query.MomentsStates(1, w.conf.Settings().Features.Private); err != nil {
+	if !settings.States {
+		// Disabled.
+	} else if results, err := query.MomentsStates(1, w.conf.Settings().Features.Private); err != nil {
 		log.Errorf("moments: %s", err.Error())
+		rebuild = false
 	} else {
 		for _, mom := range results {
 			f := form.SearchPhotos{
@@ -193,6 +232,8 @@ func (w *Moments) Start() (err error) {
 			}
 
 			if a := entity.FindAlbumByAttr(S{mom.Slug(), mom.TitleSlug()}, S{f.Serialize()}, entity.AlbumState); a != nil {
+				keep[a.AlbumUID] = true
+
 				if err := a.UpdateTitleAndState(mom.Title(), mom.Slug(), mom.State, mom.Country); err != nil {
 					log.Errorf("moments: %s (update state)", err.Error())
 				}
@@ -211,14 +252,18 @@ func (w *Moments) Start() (err error) {
 					log.Errorf("moments: %s", err)
 				} else {
 					log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+					keep[a.AlbumUID] = true
 				}
 			}
 		}
 	}
 
 	// Create moments based on related image classifications.
-	if results, err := query.MomentsLabels(threshold, w.conf.Settings().Features.Private); err != nil {
+	if !settings.Labels {
+		// Disabled.
+	} else if results, err := query.MomentsLabels(threshold, w.conf.Settings().Features.Private); err != nil {
 		log.Errorf("moments: %s", err.Error())
+		rebuild = false
 	} else {
 		for _, mom := range results {
 			w.MigrateSlug(mom, entity.AlbumMoment)
@@ -229,6 +274,8 @@ func (w *Moments) Start() (err error) {
 			}
 
 			if a := entity.FindAlbumByAttr(S{mom.Slug(), mom.TitleSlug()}, S{f.Serialize()}, entity.AlbumMoment); a != nil {
+				keep[a.AlbumUID] = true
+
 				if err := a.UpdateTitleAndLocation(mom.Title(), "", "", "", mom.Slug()); err != nil {
 					log.Errorf("moments: %s (update slug)", err.Error())
 				}
@@ -248,6 +295,7 @@ func (w *Moments) Start() (err error) {
 					log.Errorf("moments: %s", err.Error())
 				} else {
 					log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+					keep[a.AlbumUID] = true
 				}
 			} else {
 				log.Errorf("moments: failed to create new moment %s (%s)", mom.Title(), f.Serialize())
@@ -255,11 +303,66 @@ func (w *Moments) Start() (err error) {
 		}
 	}
 
+	// Create moments based on trips away from home.
+	if !settings.Trips {
+		// Disabled.
+	} else if results, err := query.MomentsTrips(threshold, w.conf.Settings().Features.Private); err != nil {
+		log.Errorf("moments: %s", err.Error())
+		rebuild = false
+	} else {
+		for _, trip := range results {
+			f := form.SearchPhotos{
+				After:  trip.StartDate(),
+				Before: trip.EndDate().AddDate(0, 0, 1),
+				Public: true,
+			}
+
+			if a := entity.FindAlbumByAttr(S{trip.Slug()}, S{f.Serialize()}, entity.AlbumMoment); a != nil {
+				keep[a.AlbumUID] = true
+
+				if a.DeletedAt != nil {
+					// Nothing to do.
+					log.Tracef("moments: %s was deleted (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+				} else {
+					log.Tracef("moments: %s already exists (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+				}
+			} else if a := entity.NewMomentsAlbum(trip.Title(), trip.Slug(), f.Serialize()); a != nil {
+				a.AlbumCategory = query.TripCategory
+				a.AlbumDescription = trip.Dates()
+				a.AlbumYear = trip.Start.Year()
+				a.AlbumMonth = int(trip.Start.Month())
+				a.AlbumDay = trip.Start.Day()
+				a.SetLocation(trip.Location(), "", trip.Country)
+
+				if err := a.Create(); err != nil {
+					log.Errorf("moments: %s", err)
+				} else {
+					log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+					keep[a.AlbumUID] = true
+				}
+			}
+		}
+	}
+
+	// Remove generated albums that no longer match the current settings.
+	if rebuild {
+		if removed, err := query.RemoveMoments(keep); err != nil {
+			log.Errorf("moments: %s (rebuild)", err)
+		} else if removed > 0 {
+			log.Infof("moments: removed %s", english.Plural(removed, "album", "albums"))
+		}
+	}
+
 	// UpdateFolderDates updates folder year, month and day based on indexed photo metadata.
 	if err := query.UpdateFolderDates(); err != nil {
 		log.Errorf("moments: %s (update folder dates)", err.Error())
 	}
 
+	// UpdateSmartAlbums adds newly indexed pictures to smart albums if they match the album filter.
+	if err := query.UpdateSmartAlbums(); err != nil {
+		log.Errorf("moments: %s (update smart albums)", err.Error())
+	}
+
 	// UpdateAlbumDates updates the year, month and day of the album based on the indexed photo metadata.
 	if err := query.UpdateAlbumDates(); err != nil {
 		log.Errorf("moments: %s (update album dates)", err.Error())
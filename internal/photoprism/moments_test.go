@@ -3,6 +3,8 @@ package photoprism
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/photoprism/photoprism/internal/config"
 )
 
@@ -16,3 +18,14 @@ func TestMoments_Start(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestMoments_Threshold(t *testing.T) {
+	conf := config.TestConfig()
+
+	m := NewMoments(conf)
+
+	assert.Equal(t, 3, m.Threshold(0))
+	assert.Equal(t, 3, m.Threshold(4))
+	assert.Equal(t, 5, m.Threshold(16))
+	assert.Equal(t, 11, m.Threshold(1024))
+}
@@ -0,0 +1,123 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// OffloadJob queues a CPU-heavy job for worker nodes if this is enabled,
+// and returns true if the job does not have to be executed locally.
+func OffloadJob(jobType string, f *MediaFile, photoUID string, force bool) bool {
+	if f == nil || !Config().OffloadJobs() {
+		return false
+	}
+
+	job := entity.NewNodeJob(jobType, f.Root(), f.RootRelName(), force)
+	job.PhotoUID = photoUID
+
+	if err := job.Create(); err != nil {
+		log.Warnf("jobs: %s (queue %s)", err, jobType)
+		return false
+	}
+
+	log.Debugf("jobs: queued %s for %s", jobType, clean.Log(f.RootRelName()))
+
+	return true
+}
+
+// NodeWorker executes jobs from the shared database queue on a worker node.
+type NodeWorker struct {
+	conf    *config.Config
+	index   *Index
+	convert *Convert
+}
+
+// NewNodeWorker returns a new worker node job runner. The index is only required for classification jobs.
+func NewNodeWorker(conf *config.Config, index *Index, convert *Convert) *NodeWorker {
+	return &NodeWorker{conf: conf, index: index, convert: convert}
+}
+
+// Next claims and executes the next queued job of the specified types,
+// and returns false if the queue is empty.
+func (w *NodeWorker) Next(jobTypes []string) (bool, error) {
+	job, err := entity.ClaimNodeJob(w.conf.NodeName(), jobTypes)
+
+	if err != nil {
+		return false, err
+	} else if job == nil {
+		return false, nil
+	}
+
+	err = w.Run(job)
+
+	if err != nil {
+		log.Errorf("jobs: %s %s (%s)", job.JobType, clean.Log(job.FileName), err)
+	} else {
+		log.Infof("jobs: completed %s %s", job.JobType, clean.Log(job.FileName))
+	}
+
+	return true, job.Finish(err)
+}
+
+// Run executes the job.
+func (w *NodeWorker) Run(job *entity.NodeJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s (panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if job == nil {
+		return errors.New("job is nil")
+	}
+
+	fileName := FileName(job.FileRoot, job.FileName)
+
+	if !fs.FileExists(fileName) {
+		return fmt.Errorf("%s not found, check if the storage is shared with this node", clean.Log(job.FileName))
+	}
+
+	f, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	switch job.JobType {
+	case entity.NodeJobThumbs:
+		return f.CreateThumbnails(w.conf.ThumbCachePath(), job.JobForce)
+	case entity.NodeJobTranscode:
+		if w.convert == nil {
+			return errors.New("transcoding is not supported")
+		}
+
+		_, err = w.convert.ToAvc(f, w.conf.FFmpegEncoder(), false, job.JobForce)
+
+		return err
+	case entity.NodeJobLabels:
+		if w.index == nil {
+			return errors.New("classification is not supported")
+		}
+
+		photo := entity.FindPhoto(entity.Photo{PhotoUID: job.PhotoUID})
+
+		if photo == nil {
+			return fmt.Errorf("photo %s not found", clean.Log(job.PhotoUID))
+		}
+
+		if labels := w.index.Labels(f); len(labels) > 0 {
+			photo.AddLabels(labels)
+			return photo.SaveLabels()
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown job type %s", clean.Log(job.JobType))
+	}
+}
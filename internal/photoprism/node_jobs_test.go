@@ -0,0 +1,33 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestOffloadJob(t *testing.T) {
+	mf, err := NewMediaFile("testdata/flash.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Offloading requires a database server.
+	assert.False(t, OffloadJob(entity.NodeJobThumbs, mf, "", false))
+	assert.False(t, OffloadJob(entity.NodeJobThumbs, nil, "", false))
+}
+
+func TestNodeWorker_Run(t *testing.T) {
+	w := NewNodeWorker(config.TestConfig(), nil, nil)
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.Error(t, w.Run(nil))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Error(t, w.Run(entity.NewNodeJob(entity.NodeJobThumbs, entity.RootOriginals, "missing.jpg", false)))
+	})
+}
@@ -0,0 +1,112 @@
+package photoprism
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// NSFWResult represents the outcome of DetectNSFW().
+type NSFWResult struct {
+	Checked int
+	Flagged int
+	Failed  int
+}
+
+// DetectNSFW runs the detection of offensive content again on the primary image files of all pictures
+// and updates their scores, so that a new model or threshold also applies to existing pictures. Pictures
+// above the threshold are additionally flagged as private if flagPrivate is true.
+func DetectNSFW(conf *config.Config, detector *nsfw.Detector, flagPrivate bool) (result NSFWResult, err error) {
+	if conf == nil {
+		return result, fmt.Errorf("config is nil")
+	} else if detector == nil {
+		return result, fmt.Errorf("nsfw detector not initialized")
+	} else if conf.DisableTensorFlow() {
+		return result, fmt.Errorf("tensorflow is disabled")
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	start := time.Now()
+	limit := 500
+
+	for {
+		files, err := query.PrimaryImageFiles(limit, result.Checked+result.Failed)
+
+		if err != nil {
+			return result, err
+		} else if len(files) == 0 {
+			break
+		}
+
+		for i := range files {
+			if mutex.MainWorker.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			file := &files[i]
+
+			if flagged, err := detectFileNSFW(conf, detector, file, flagPrivate); err != nil {
+				log.Warnf("nsfw: %s in %s", err, clean.Log(file.FileName))
+				result.Failed++
+			} else {
+				result.Checked++
+
+				if flagged {
+					result.Flagged++
+				}
+			}
+		}
+	}
+
+	log.Infof("nsfw: checked %s, %d flagged, %d failed [%s]", english.Plural(result.Checked, "picture", "pictures"), result.Flagged, result.Failed, time.Since(start))
+
+	return result, nil
+}
+
+// detectFileNSFW updates the score of the picture the file belongs to and returns true if it is above the threshold.
+func detectFileNSFW(conf *config.Config, detector *nsfw.Detector, file *entity.File, flagPrivate bool) (bool, error) {
+	mediaFile, err := NewMediaFile(FileName(file.FileRoot, file.FileName))
+
+	if err != nil {
+		return false, err
+	}
+
+	thumbName, err := mediaFile.Thumbnail(conf.ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		return false, err
+	}
+
+	labels, err := detector.File(thumbName)
+
+	if err != nil {
+		return false, err
+	}
+
+	photo := &entity.Photo{ID: file.PhotoID}
+	photo.SetNSFW(labels.Score())
+
+	values := entity.Values{"PhotoNSFW": photo.PhotoNSFW}
+
+	if !photo.IsNSFW() {
+		return false, photo.Updates(values)
+	} else if flagPrivate {
+		values["PhotoPrivate"] = true
+	}
+
+	return true, photo.Updates(values)
+}
@@ -0,0 +1,177 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BackupPeople creates a YAML file backup of all people.
+func BackupPeople(backupPath string, force bool) (count int, result error) {
+	c := Config()
+
+	if !c.BackupYaml() && !force {
+		log.Debugf("backup: people yaml files disabled")
+		return count, nil
+	}
+
+	subjects, err := query.Subjects(1000000, 0)
+
+	if err != nil {
+		return count, err
+	}
+
+	if !fs.PathExists(backupPath) {
+		backupPath = c.PeoplePath()
+	}
+
+	for _, m := range subjects {
+		if !m.IsPerson() {
+			continue
+		}
+
+		fileName := m.YamlFileName(backupPath)
+
+		if err := m.SaveAsYaml(fileName); err != nil {
+			log.Errorf("people: %s (update yaml)", err)
+			result = err
+		} else {
+			log.Tracef("backup: saved people yaml file %s", clean.Log(filepath.Base(fileName)))
+			count++
+		}
+	}
+
+	return count, result
+}
+
+// RestorePeopleFiles restores people YAML file backups and returns the changes. Existing people are skipped
+// unless opt.Merge is set, in which case empty fields are restored from the backup and people flagged as
+// deleted are restored. With opt.Dry, the changes are reported without updating the index.
+func RestorePeopleFiles(backupPath string, opt RestoreOptions) (changes RestoreChanges, err error) {
+	if !fs.PathExists(backupPath) {
+		backupPath = Config().PeoplePath()
+	}
+
+	files, err := filepath.Glob(regexp.QuoteMeta(backupPath) + "/*.yml")
+
+	if err != nil {
+		return changes, err
+	}
+
+	for _, fileName := range files {
+		changes = append(changes, restorePersonFile(fileName, opt))
+	}
+
+	return changes, nil
+}
+
+// restorePersonFile restores a single people YAML file.
+func restorePersonFile(fileName string, opt RestoreOptions) (change RestoreChange) {
+	m := entity.Subject{}
+
+	if err := m.LoadFromYaml(fileName); err != nil {
+		return RestoreChange{Type: entity.SubjPerson, Name: filepath.Base(fileName), Action: RestoreFailed, Details: err.Error()}
+	}
+
+	change = RestoreChange{Type: m.SubjType, UID: m.SubjUID, Name: m.SubjName}
+
+	if m.SubjName = clean.Name(m.SubjName); m.SubjName == "" {
+		change.Action, change.Details = RestoreSkipped, "no name"
+		return change
+	}
+
+	found := entity.FindSubject(m.SubjUID)
+
+	if found == nil {
+		found = &entity.Subject{}
+
+		if err := entity.UnscopedDb().Where(fmt.Sprintf("subj_name %s ?", entity.LikeOp()), m.SubjName).First(found).Error; err != nil {
+			found = nil
+		}
+	}
+
+	if found == nil {
+		change.Action = RestoreAdded
+
+		if opt.Dry {
+			return change
+		}
+
+		if m.SubjType == "" {
+			m.SubjType = entity.SubjPerson
+		}
+
+		m.SubjSlug = txt.Slug(m.SubjName)
+
+		if entity.FirstOrCreateSubject(&m) == nil {
+			change.Action, change.Details = RestoreFailed, "could not be added"
+		}
+
+		return change
+	}
+
+	change.UID, change.Name = found.SubjUID, found.SubjName
+
+	if !opt.Merge {
+		change.Action, change.Details = RestoreSkipped, "already exists"
+		return change
+	}
+
+	// Restore empty fields.
+	values := entity.Values{}
+	var details []string
+
+	for _, f := range []struct{ name, col, current, backup string }{
+		{"alias", "subj_alias", found.SubjAlias, m.SubjAlias},
+		{"about", "subj_about", found.SubjAbout, m.SubjAbout},
+		{"bio", "subj_bio", found.SubjBio, m.SubjBio},
+		{"notes", "subj_notes", found.SubjNotes, m.SubjNotes},
+	} {
+		if f.current == "" && f.backup != "" {
+			values[f.col] = f.backup
+			details = append(details, f.name)
+		}
+	}
+
+	if found.SubjBirthYear == 0 && found.SubjBirthMonth == 0 && found.SubjBirthDay == 0 && (m.SubjBirthYear > 0 || m.SubjBirthMonth > 0 || m.SubjBirthDay > 0) {
+		values["subj_birth_year"] = m.SubjBirthYear
+		values["subj_birth_month"] = m.SubjBirthMonth
+		values["subj_birth_day"] = m.SubjBirthDay
+		details = append(details, "birthday")
+	}
+
+	if found.Deleted() {
+		details = append(details, "restored")
+	}
+
+	if len(details) == 0 {
+		change.Action, change.Details = RestoreSkipped, "no changes"
+		return change
+	}
+
+	change.Action, change.Details = RestoreMerged, strings.Join(details, ", ")
+
+	if opt.Dry {
+		return change
+	}
+
+	if len(values) > 0 {
+		if err := found.Updates(values); err != nil {
+			change.Action, change.Details = RestoreFailed, err.Error()
+			return change
+		}
+	}
+
+	if err := found.Restore(); err != nil {
+		change.Action, change.Details = RestoreFailed, err.Error()
+	}
+
+	return change
+}
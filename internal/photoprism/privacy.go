@@ -0,0 +1,60 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// PrivacyCopy creates a temporary copy of a media file without GPS metadata, so that pictures taken in
+// privacy zones can be downloaded without revealing their position. The caller must remove the copy.
+func PrivacyCopy(conf *config.Config, fileName string) (copyName string, err error) {
+	if conf == nil {
+		return "", fmt.Errorf("config is nil")
+	} else if conf.ExifToolBin() == "" {
+		return "", fmt.Errorf("exiftool is required to remove gps metadata")
+	}
+
+	dir := filepath.Join(conf.TempPath(), "privacy")
+
+	if err = os.MkdirAll(dir, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	// Keep the file extension, so that exiftool can detect the file type.
+	copyName = filepath.Join(dir, rnd.Base36(12)+filepath.Ext(fileName))
+
+	if err = fs.Copy(fileName, copyName); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(conf.ExifToolBin(), "-q", "-m", "-overwrite_original",
+		"-gps:all=", "-xmp:gps*=", "-quicktime:gpscoordinates=", "-keys:gpscoordinates=", copyName)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", conf.CmdCachePath())}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	if err = cmd.Run(); err != nil {
+		_ = os.Remove(copyName)
+
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		return "", fmt.Errorf("exiftool: %s (remove gps metadata from %s)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	return copyName, nil
+}
@@ -71,11 +71,23 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 	updatedDuplicates := 0
 	updatedPhotos := 0
 
+	// Count changes that would be made in dry run mode.
+	dryFound := 0
+	dryMissing := 0
+	dryDuplicates := 0
+
 	// Total number of updates.
 	updates := func() int {
 		return updatedFiles + updatedDuplicates + updatedPhotos
 	}
 
+	// Originals in cold storage are not missing.
+	cold, err := query.ColdFileIDs()
+
+	if err != nil {
+		return purgedFiles, purgedPhotos, updates(), err
+	}
+
 	// Check files.
 	startFiles := time.Now()
 	limit := 10000
@@ -105,7 +117,8 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 			if file.FileMissing {
 				if fs.FileExists(fileName) {
 					if opt.Dry {
-						log.Infof("purge: found %s", clean.Log(file.FileName))
+						dryFound++
+						log.Infof("purge: file %s would be flagged as found", clean.Log(file.FileName))
 						continue
 					}
 
@@ -117,8 +130,9 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 						log.Infof("purge: found %s", clean.Log(file.FileName))
 					}
 				}
-			} else if !fs.FileExists(fileName) {
+			} else if !fs.FileExists(fileName) && !cold[file.ID] {
 				if opt.Dry {
+					dryMissing++
 					purgedFiles[fileName] = true
 					log.Infof("purge: file %s would be flagged as missing", clean.Log(file.FileName))
 					continue
@@ -184,6 +198,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 			if !fs.FileExists(fileName) {
 				if opt.Dry {
+					dryDuplicates++
 					purgedFiles[fileName] = true
 					log.Infof("purge: duplicate %s would be removed from index", clean.Log(file.FileName))
 					continue
@@ -236,7 +251,13 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 			if opt.Dry {
 				purgedPhotos[photo.PhotoUID] = true
-				log.Infof("purge: %s would be removed", photo.String())
+
+				if opt.Hard {
+					log.Infof("purge: %s would be permanently removed", photo.String())
+				} else {
+					log.Infof("purge: %s would be flagged as deleted", photo.String())
+				}
+
 				continue
 			}
 
@@ -269,6 +290,30 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 	log.Debugf("purge: updated %s [%s]", english.Plural(updatedPhotos, "photo", "photos"), time.Since(startPhotos))
 
+	// Only report orphans and summarize the changes in dry run mode, without updating the index.
+	if opt.Dry {
+		orphans := 0
+
+		if files, err := query.OrphanFiles(); err != nil {
+			log.Errorf("index: %s (find orphan files)", err)
+		} else {
+			orphans = len(files)
+
+			for _, file := range files {
+				log.Infof("purge: orphan file %s would be removed from index", clean.Log(file.FileName))
+			}
+		}
+
+		log.Infof("purge: dry run, would flag %s as missing and %s as found, remove %s and %s, and %s",
+			english.Plural(dryMissing, "file", "files"),
+			english.Plural(dryFound, "file", "files"),
+			english.Plural(dryDuplicates, "duplicate", "duplicates"),
+			english.Plural(orphans, "orphan file", "orphan files"),
+			english.Plural(len(purgedPhotos), "photo", "photos"))
+
+		return purgedFiles, purgedPhotos, updates(), nil
+	}
+
 	// Skip the index update if there are no changes.
 	if !opt.Force && updates() == 0 {
 		return purgedFiles, purgedPhotos, updates(), nil
@@ -286,23 +331,13 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 	}
 
 	// Remove orphan index entries.
-	if opt.Dry {
-		if files, err := query.OrphanFiles(); err != nil {
-			log.Errorf("index: %s (find orphan files)", err)
-		} else if l := len(files); l > 0 {
-			log.Infof("index: found %s", english.Plural(l, "orphan file", "orphan files"))
-		} else {
-			log.Infof("index: found no orphan files")
-		}
-	} else {
-		if err = query.PurgeOrphans(); err != nil {
-			log.Errorf("index: %s (purge orphans)", err)
-		}
-
-		// Regenerate search index columns.
-		entity.File{}.RegenerateIndex()
+	if err = query.PurgeOrphans(); err != nil {
+		log.Errorf("index: %s (purge orphans)", err)
 	}
 
+	// Regenerate search index columns.
+	entity.File{}.RegenerateIndex()
+
 	// Hide missing album contents.
 	if err = query.UpdateMissingAlbumEntries(); err != nil {
 		log.Errorf("index: %s (update album entries)", err)
@@ -0,0 +1,38 @@
+package photoprism
+
+// RestoreOptions specifies how album and people YAML files are restored.
+type RestoreOptions struct {
+	Merge bool // Merge backups into existing albums and people instead of skipping them.
+	Dry   bool // Only report what would change without updating the index.
+}
+
+// Restore actions.
+const (
+	RestoreAdded   = "added"
+	RestoreMerged  = "merged"
+	RestoreSkipped = "skipped"
+	RestoreFailed  = "failed"
+)
+
+// RestoreChange represents a change made by restoring a YAML file, or the change that would be made in a dry run.
+type RestoreChange struct {
+	Type    string `json:"Type"`
+	UID     string `json:"UID"`
+	Name    string `json:"Name"`
+	Action  string `json:"Action"`
+	Details string `json:"Details,omitempty"`
+}
+
+// RestoreChanges represents a list of restore changes.
+type RestoreChanges []RestoreChange
+
+// Count returns the number of changes with the specified action.
+func (c RestoreChanges) Count(action string) (count int) {
+	for _, change := range c {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,195 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// saveRestoreTestYaml writes the value as YAML file without reloading associations from the index.
+func saveRestoreTestYaml(t *testing.T, fileName string, v interface{}) {
+	data, err := yaml.Marshal(v)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(fileName, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// findRestoreChange returns the change with the specified UID.
+func findRestoreChange(changes RestoreChanges, uid string) RestoreChange {
+	for _, c := range changes {
+		if c.UID == uid {
+			return c
+		}
+	}
+
+	return RestoreChange{}
+}
+
+func TestRestoreAlbumFiles(t *testing.T) {
+	album := entity.NewAlbum("Restore Merge Test", entity.AlbumManual)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(entity.PhotoAlbum{}, "album_uid = ?", album.AlbumUID)
+	defer entity.UnscopedDb().Delete(album)
+
+	album.AddPhotos([]string{"pt9jtdre2lvl0yh7"})
+
+	backupPath := t.TempDir()
+
+	// The backup contains notes and an additional picture.
+	backup := *album
+	backup.AlbumNotes = "Restored Notes"
+	backup.Photos = entity.PhotoAlbums{
+		{PhotoUID: "pt9jtdre2lvl0yh7"},
+		{PhotoUID: "pt9jtdre2lvl0yh0"},
+		{PhotoUID: "pt9jtdre2lvl0y99"},
+	}
+
+	saveRestoreTestYaml(t, backup.YamlFileName(backupPath), backup)
+
+	// A new album that does not exist in the index yet.
+	added := entity.NewAlbum("Restore Add Test", entity.AlbumManual)
+	added.AlbumUID = "as6sg6bxpogaaba9"
+	added.Photos = entity.PhotoAlbums{{PhotoUID: "pt9jtdre2lvl0yh0"}}
+
+	saveRestoreTestYaml(t, added.YamlFileName(backupPath), added)
+
+	defer entity.UnscopedDb().Delete(entity.PhotoAlbum{}, "album_uid = ?", added.AlbumUID)
+	defer entity.UnscopedDb().Delete(entity.Album{}, "album_uid = ?", added.AlbumUID)
+
+	t.Run("Skip", func(t *testing.T) {
+		changes, err := RestoreAlbumFiles(backupPath, RestoreOptions{Dry: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RestoreSkipped, findRestoreChange(changes, album.AlbumUID).Action)
+		assert.Equal(t, RestoreAdded, findRestoreChange(changes, added.AlbumUID).Action)
+		assert.Nil(t, entity.FindAlbum(entity.Album{AlbumUID: added.AlbumUID}))
+	})
+	t.Run("DryMerge", func(t *testing.T) {
+		changes, err := RestoreAlbumFiles(backupPath, RestoreOptions{Merge: true, Dry: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		change := findRestoreChange(changes, album.AlbumUID)
+
+		assert.Equal(t, RestoreMerged, change.Action)
+		assert.Equal(t, "notes, 1 picture", change.Details)
+
+		if found := entity.FindAlbum(entity.Album{AlbumUID: album.AlbumUID}); found == nil {
+			t.Fatal("album should exist")
+		} else {
+			assert.Equal(t, "", found.AlbumNotes)
+		}
+	})
+	t.Run("Merge", func(t *testing.T) {
+		changes, err := RestoreAlbumFiles(backupPath, RestoreOptions{Merge: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RestoreMerged, findRestoreChange(changes, album.AlbumUID).Action)
+		assert.Equal(t, RestoreAdded, findRestoreChange(changes, added.AlbumUID).Action)
+		assert.Equal(t, 0, changes.Count(RestoreFailed))
+
+		if found := entity.FindAlbum(entity.Album{AlbumUID: album.AlbumUID}); found == nil {
+			t.Fatal("album should exist")
+		} else {
+			assert.Equal(t, "Restored Notes", found.AlbumNotes)
+			var count int
+			entity.Db().Model(&entity.PhotoAlbum{}).Where("album_uid = ? AND hidden = 0", found.AlbumUID).Count(&count)
+			assert.Equal(t, 2, count)
+		}
+
+		assert.NotNil(t, entity.FindAlbum(entity.Album{AlbumUID: added.AlbumUID}))
+
+		// Nothing left to merge.
+		changes, err = RestoreAlbumFiles(backupPath, RestoreOptions{Merge: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RestoreSkipped, findRestoreChange(changes, album.AlbumUID).Action)
+		assert.Equal(t, "no changes", findRestoreChange(changes, album.AlbumUID).Details)
+	})
+}
+
+func TestRestorePeopleFiles(t *testing.T) {
+	backupPath := t.TempDir()
+
+	t.Run("Backup", func(t *testing.T) {
+		count, err := BackupPeople(backupPath, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, count, 1)
+		assert.FileExists(t, filepath.Join(backupPath, "jqu0xs11qekk9jx8.yml"))
+	})
+	t.Run("DryMerge", func(t *testing.T) {
+		person := entity.Subject{SubjUID: "jqy3y652h8njw0sx", SubjName: "Joe Biden", SubjType: entity.SubjPerson, SubjBio: "Restored Bio"}
+		saveRestoreTestYaml(t, filepath.Join(backupPath, "jqy3y652h8njw0sx.yml"), person)
+
+		newPerson := entity.Subject{SubjUID: "js6sg6b1h1njaaab", SubjName: "Restore Test", SubjType: entity.SubjPerson}
+		saveRestoreTestYaml(t, filepath.Join(backupPath, "js6sg6b1h1njaaab.yml"), newPerson)
+
+		changes, err := RestorePeopleFiles(backupPath, RestoreOptions{Merge: true, Dry: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, changes.Count(RestoreFailed))
+		assert.Equal(t, RestoreAdded, findRestoreChange(changes, "js6sg6b1h1njaaab").Action)
+
+		assert.Equal(t, RestoreMerged, findRestoreChange(changes, "jqy3y652h8njw0sx").Action)
+		assert.Equal(t, "bio", findRestoreChange(changes, "jqy3y652h8njw0sx").Details)
+		assert.Equal(t, RestoreSkipped, findRestoreChange(changes, "jqu0xs11qekk9jx8").Action)
+		assert.Nil(t, entity.FindSubject("js6sg6b1h1njaaab"))
+
+		if found := entity.FindSubject("jqy3y652h8njw0sx"); found == nil {
+			t.Fatal("person should exist")
+		} else {
+			assert.Equal(t, "", found.SubjBio)
+		}
+	})
+	t.Run("Add", func(t *testing.T) {
+		changes, err := RestorePeopleFiles(backupPath, RestoreOptions{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(entity.Subject{}, "subj_uid = ?", "js6sg6b1h1njaaab")
+
+		assert.Equal(t, RestoreAdded, findRestoreChange(changes, "js6sg6b1h1njaaab").Action)
+		assert.Equal(t, RestoreSkipped, findRestoreChange(changes, "jqy3y652h8njw0sx").Action)
+
+		if found := entity.FindSubject("js6sg6b1h1njaaab"); found == nil {
+			t.Fatal("person should exist")
+		} else {
+			assert.Equal(t, "Restore Test", found.SubjName)
+			assert.Equal(t, "restore-test", found.SubjSlug)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package photoprism
+
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+var storage *vfs.Mirror
+
+// SetStorage sets the object storage mirror in which deleted files are removed as well.
+func SetStorage(m *vfs.Mirror) {
+	storage = m
+}
+
+// Storage returns the object storage mirror, or nil if files are only stored locally.
+func Storage() *vfs.Mirror {
+	return storage
+}
+
+// removeStored deletes a file from the object storage, if configured, even if it does not exist locally.
+func removeStored(fileName string) {
+	if err := storage.Remove(fileName); err != nil {
+		log.Warnf("storage: %s while deleting %s", err, clean.Log(filepath.Base(fileName)))
+	}
+}
+
+// removeStoredPrefix deletes all files with the prefix from the object storage, if configured.
+func removeStoredPrefix(prefix string) {
+	if _, err := storage.RemovePrefix(prefix); err != nil {
+		log.Warnf("storage: %s while deleting %s*", err, clean.Log(filepath.Base(prefix)))
+	}
+}
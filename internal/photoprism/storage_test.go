@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestDeleteFiles_Storage(t *testing.T) {
+	c := config.TestConfig()
+	b, err := vfs.NewLocal(t.TempDir())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetStorage(vfs.NewMirror(b,
+		vfs.Root{Name: vfs.RootOriginals, Path: c.OriginalsPath()},
+		vfs.Root{Name: vfs.RootSidecar, Path: c.SidecarPath()},
+	))
+
+	defer SetStorage(nil)
+
+	// Add files that only exist in the object storage.
+	for _, key := range []string{
+		"originals/2023/storage-delete.jpg",
+		"originals/2023/storage-delete.jpg.json",
+		"sidecar/2023/storage-delete.xmp",
+		"sidecar/2023/storage-delete.jpg.json",
+		"sidecar/2023/storage-keep.xmp",
+	} {
+		if err = b.Put(key, strings.NewReader("data"), 4); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("KeepOriginals", func(t *testing.T) {
+		files := entity.Files{{FileRoot: entity.RootOriginals, FileName: "2023/storage-delete.jpg"}}
+
+		assert.Equal(t, 0, DeleteFiles(files, false))
+
+		list, err := b.List("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{
+			"originals/2023/storage-delete.jpg":      4,
+			"originals/2023/storage-delete.jpg.json": 4,
+			"sidecar/2023/storage-keep.xmp":          4,
+		}, list.Keys())
+	})
+	t.Run("DeleteOriginals", func(t *testing.T) {
+		files := entity.Files{{FileRoot: entity.RootOriginals, FileName: "2023/storage-delete.jpg"}}
+
+		assert.Equal(t, 0, DeleteFiles(files, true))
+
+		list, err := b.List("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"sidecar/2023/storage-keep.xmp": 4}, list.Keys())
+	})
+}
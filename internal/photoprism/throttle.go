@@ -0,0 +1,87 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// ThrottleInterval specifies how often paused background workers check if they may resume.
+var ThrottleInterval = 15 * time.Second
+
+// LoadAverage returns the system load average of the last minute per CPU core, or 0 if it is unknown.
+func LoadAverage() float64 {
+	data, err := os.ReadFile("/proc/loadavg")
+
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(data))
+
+	if len(fields) == 0 {
+		return 0
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+
+	if err != nil {
+		return 0
+	}
+
+	return load / float64(runtime.NumCPU())
+}
+
+// Throttled returns the reason why heavy background workers should pause, or an empty string if they may run.
+func Throttled(c *config.Config, now time.Time) string {
+	if !c.InMaintenanceWindow(now) {
+		return fmt.Sprintf("outside maintenance window %s", c.MaintenanceWindow())
+	}
+
+	if max := c.MaxRequests(); max > 0 {
+		if n := int(mutex.Requests.Load()); n > max {
+			return fmt.Sprintf("%d requests in progress", n)
+		}
+	}
+
+	if max := c.MaxLoad(); max > 0 {
+		if load := LoadAverage(); load > max {
+			return fmt.Sprintf("load average %.2f per core", load)
+		}
+	}
+
+	return ""
+}
+
+// Throttle waits while heavy background workers should pause and returns false if the worker was canceled.
+func Throttle(c *config.Config, worker string, canceled func() bool) bool {
+	reason := Throttled(c, time.Now())
+
+	if reason == "" {
+		return !canceled()
+	}
+
+	log.Infof("%s: paused, %s", worker, reason)
+
+	start := time.Now()
+
+	for reason != "" {
+		if canceled() {
+			return false
+		}
+
+		time.Sleep(ThrottleInterval)
+
+		reason = Throttled(c, time.Now())
+	}
+
+	log.Infof("%s: resumed after %s", worker, time.Since(start).Truncate(time.Second))
+
+	return !canceled()
+}
@@ -0,0 +1,27 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestLoadAverage(t *testing.T) {
+	assert.GreaterOrEqual(t, LoadAverage(), 0.0)
+}
+
+func TestThrottled(t *testing.T) {
+	c := config.TestConfig()
+
+	assert.Equal(t, "", Throttled(c, time.Now()))
+}
+
+func TestThrottle(t *testing.T) {
+	c := config.TestConfig()
+
+	assert.True(t, Throttle(c, "test", func() bool { return false }))
+	assert.False(t, Throttle(c, "test", func() bool { return true }))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/karrick/godirwalk"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -115,6 +116,11 @@ func (w *Thumbs) Dir(dir string, force bool) (fs.Done, error) {
 
 		done[fileName] = fs.Processed
 
+		// Create thumbnails on a worker node?
+		if OffloadJob(entity.NodeJobThumbs, mf, "", force) {
+			return nil
+		}
+
 		relativeName := mf.RelName(dir)
 
 		event.Publish("index.thumbnails", event.Data{
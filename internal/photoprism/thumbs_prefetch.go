@@ -0,0 +1,141 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// PrefetchQueueSize specifies how many files can wait for their thumbnails to be created in advance.
+var PrefetchQueueSize = 1000
+
+// PrefetchSizes are the thumbnail sizes displayed in search results, so that files are only
+// opened if at least one of them is missing.
+var PrefetchSizes = []thumb.Name{thumb.Tile500, thumb.Tile224}
+
+// prefetchJob represents a file for which thumbnails should be created in advance.
+type prefetchJob struct {
+	root string
+	name string
+	hash string
+}
+
+// Prefetch creates missing thumbnails in a low-priority background queue, e.g. for the
+// next result pages while a user is browsing, so that they are displayed without delay.
+type Prefetch struct {
+	conf    *config.Config
+	jobs    chan prefetchJob
+	pending map[string]struct{}
+	once    sync.Once
+	mutex   sync.Mutex
+}
+
+// NewPrefetch returns a new thumbnail prefetch queue and expects the config as argument.
+func NewPrefetch(conf *config.Config) *Prefetch {
+	return &Prefetch{
+		conf:    conf,
+		jobs:    make(chan prefetchJob, PrefetchQueueSize),
+		pending: make(map[string]struct{}),
+	}
+}
+
+// Enabled checks if thumbnails should be created in advance.
+func (w *Prefetch) Enabled() bool {
+	return w.conf.ThumbPrefetchWorkers() > 0
+}
+
+// Add queues a file for creating its thumbnails in advance and returns true if it was added.
+// Files are skipped if prefetching is disabled, they are already queued, or the queue is full.
+func (w *Prefetch) Add(fileRoot, fileName, fileHash string) bool {
+	if fileName == "" || fileHash == "" || !w.Enabled() {
+		return false
+	}
+
+	w.once.Do(w.start)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, found := w.pending[fileHash]; found {
+		return false
+	}
+
+	select {
+	case w.jobs <- prefetchJob{root: fileRoot, name: fileName, hash: fileHash}:
+		w.pending[fileHash] = struct{}{}
+		return true
+	default:
+		return false
+	}
+}
+
+// Pending returns the number of queued files.
+func (w *Prefetch) Pending() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return len(w.pending)
+}
+
+// start starts the configured number of background workers.
+func (w *Prefetch) start() {
+	for i := 0; i < w.conf.ThumbPrefetchWorkers(); i++ {
+		go w.worker()
+	}
+}
+
+// worker creates the thumbnails for queued files.
+func (w *Prefetch) worker() {
+	for job := range w.jobs {
+		// Indexing creates thumbnails anyway and has priority.
+		if !mutex.IndexWorkersRunning() {
+			w.create(job)
+		}
+
+		w.mutex.Lock()
+		delete(w.pending, job.hash)
+		w.mutex.Unlock()
+	}
+}
+
+// create creates the thumbnails for a file if any of the displayed sizes are missing.
+func (w *Prefetch) create(job prefetchJob) {
+	thumbPath := w.conf.ThumbCachePath()
+
+	if !w.missing(job.hash, thumbPath) {
+		return
+	}
+
+	fileName := FileName(job.root, job.name)
+
+	if !fs.FileExists(fileName) {
+		log.Debugf("thumbs: %s not found (prefetch)", clean.Log(job.name))
+		return
+	}
+
+	f, err := NewMediaFile(fileName)
+
+	if err != nil {
+		log.Debugf("thumbs: %s (prefetch)", err)
+		return
+	}
+
+	if err = f.CreateThumbnails(thumbPath, false); err != nil {
+		log.Debugf("thumbs: %s (prefetch)", err)
+	}
+}
+
+// missing checks if any of the displayed thumbnail sizes do not exist yet.
+func (w *Prefetch) missing(hash, thumbPath string) bool {
+	for _, name := range PrefetchSizes {
+		if fileName, err := thumb.Sizes[name].FileName(hash, thumbPath); err != nil || !fs.FileExists(fileName) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestPrefetch_Add(t *testing.T) {
+	c := config.TestConfig()
+	opt := *c.Options()
+
+	defer func() {
+		c.Options().ThumbPrefetch = opt.ThumbPrefetch
+		c.Options().ThumbPrefetchWorkers = opt.ThumbPrefetchWorkers
+	}()
+
+	t.Run("Disabled", func(t *testing.T) {
+		c.Options().ThumbPrefetch = 0
+
+		w := NewPrefetch(c)
+
+		assert.False(t, w.Enabled())
+		assert.False(t, w.Add("", "2018/01/foo.jpg", "ca9f1a1d9e3bd1a9bdc5b5805562ad2bf7a4a8fe"))
+		assert.Equal(t, 0, w.Pending())
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		c.Options().ThumbPrefetch = 2
+		c.Options().ThumbPrefetchWorkers = 1
+
+		w := NewPrefetch(c)
+
+		// Don't start the workers, so that files remain in the queue.
+		w.once.Do(func() {})
+
+		assert.True(t, w.Enabled())
+		assert.True(t, w.Add("", "2018/01/foo.jpg", "ca9f1a1d9e3bd1a9bdc5b5805562ad2bf7a4a8fe"))
+		assert.False(t, w.Add("", "2018/01/foo.jpg", "ca9f1a1d9e3bd1a9bdc5b5805562ad2bf7a4a8fe"))
+		assert.False(t, w.Add("", "2018/01/bar.jpg", ""))
+		assert.Equal(t, 1, w.Pending())
+	})
+	t.Run("Worker", func(t *testing.T) {
+		c.Options().ThumbPrefetch = 2
+		c.Options().ThumbPrefetchWorkers = 1
+
+		w := NewPrefetch(c)
+
+		assert.True(t, w.Add("", "2018/01/missing.jpg", "ca9f1a1d9e3bd1a9bdc5b5805562ad2bf7a4a8fe"))
+		assert.Eventually(t, func() bool { return w.Pending() == 0 }, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestPrefetch_Missing(t *testing.T) {
+	w := NewPrefetch(config.TestConfig())
+
+	assert.True(t, w.missing("ca9f1a1d9e3bd1a9bdc5b5805562ad2bf7a4a8fe", t.TempDir()))
+}
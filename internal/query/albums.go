@@ -3,6 +3,7 @@ package query
 import (
 	"fmt"
 
+	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/mutex"
@@ -42,7 +43,16 @@ func AlbumCoverByUID(uid string, public bool) (file entity.File, err error) {
 		return file, err
 	} else if !a.HasID() {
 		return file, fmt.Errorf("album uid %s is invalid", clean.Log(uid))
-	} else if a.AlbumType != entity.AlbumManual { // TODO: Optimize
+	} else if a.HasCustomCover() {
+		// Use the picture that has been set manually as cover, if it still exists.
+		if fileHash, _ := crop.ParseThumb(a.Thumb); fileHash != "" {
+			if err = Db().Where("file_hash = ? AND file_missing = 0 AND deleted_at IS NULL", fileHash).First(&file).Error; err == nil {
+				return file, nil
+			}
+		}
+	}
+
+	if a.AlbumType != entity.AlbumManual { // TODO: Optimize
 		if a.AlbumFilter == "" {
 			return file, fmt.Errorf("smart album %s has no filter specified", a.AlbumUID)
 		}
@@ -96,7 +106,7 @@ func AlbumCoverByUID(uid string, public bool) (file entity.File, err error) {
 	}
 
 	// Find first picture.
-	if err = stmt.Order("photos.photo_quality DESC, photos.taken_at DESC").
+	if err = stmt.Order(coverOrder).
 		First(&file).Error; err != nil {
 		return file, err
 	}
@@ -118,6 +128,13 @@ func UpdateAlbumDates() error {
 	    ) AS p ON albums.album_path = p.photo_path
 		SET albums.album_year = YEAR(taken_max), albums.album_month = MONTH(taken_max), albums.album_day = DAY(taken_max)
 		WHERE albums.album_type = 'folder' AND albums.album_path IS NOT NULL AND p.taken_max IS NOT NULL`).Error
+	case Postgres:
+		return UnscopedDb().Exec(`UPDATE albums
+		SET album_year = EXTRACT(YEAR FROM p.taken_max), album_month = EXTRACT(MONTH FROM p.taken_max), album_day = EXTRACT(DAY FROM p.taken_max)
+		FROM (SELECT photo_path, MAX(taken_at_local) AS taken_max
+			FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
+			GROUP BY photo_path) AS p
+		WHERE albums.album_path = p.photo_path AND albums.album_type = 'folder' AND albums.album_path IS NOT NULL AND p.taken_max IS NOT NULL`).Error
 	default:
 		return nil
 	}
@@ -0,0 +1,35 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// AlbumContributor represents a user who added pictures to an album.
+type AlbumContributor struct {
+	UserUID     string `json:"UserUID"`
+	UserName    string `json:"UserName"`
+	DisplayName string `json:"DisplayName"`
+	PhotoCount  int    `json:"PhotoCount"`
+}
+
+// AlbumContributors returns the users who added pictures to the specified album, with the most active first.
+func AlbumContributors(albumUid string) (results []AlbumContributor, err error) {
+	results = []AlbumContributor{}
+
+	if rnd.InvalidUID(albumUid, entity.AlbumUID) {
+		return results, fmt.Errorf("invalid album uid")
+	}
+
+	err = UnscopedDb().Table(entity.PhotoAlbum{}.TableName()).
+		Select("photos_albums.created_by AS user_uid, auth_users.user_name, auth_users.display_name, COUNT(*) AS photo_count").
+		Joins("LEFT JOIN auth_users ON auth_users.user_uid = photos_albums.created_by").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0 AND photos_albums.created_by <> ''", albumUid).
+		Group("photos_albums.created_by, auth_users.user_name, auth_users.display_name").
+		Order("photo_count DESC, auth_users.user_name").
+		Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,20 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumContributors(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		results, err := AlbumContributors("at9lxuqxpogaaba9")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, results)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		_, err := AlbumContributors("xxx")
+		assert.Error(t, err)
+	})
+}
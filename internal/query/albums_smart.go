@@ -0,0 +1,147 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/sortby"
+)
+
+// smartAlbumBatch is the maximum number of search results processed at once when updating smart albums.
+var smartAlbumBatch = search.MaxResults
+
+// SmartAlbums returns the manually created albums whose pictures are defined by a search filter.
+func SmartAlbums() (results entity.Albums, err error) {
+	err = Db().Where("album_type = ? AND album_filter <> ''", entity.AlbumManual).Find(&results).Error
+	return results, err
+}
+
+// UpdateSmartAlbums updates the album entries of all smart albums so that they can be shared like regular albums.
+// If photo UIDs are specified, only the entries of these pictures are updated, e.g. after they have been edited.
+func UpdateSmartAlbums(photoUIDs ...string) error {
+	albums, err := SmartAlbums()
+
+	if err != nil {
+		return err
+	}
+
+	for _, a := range albums {
+		if added, removed, err := UpdateSmartAlbum(a, photoUIDs); err != nil {
+			log.Warnf("albums: %s (update smart album %s)", err, a.AlbumUID)
+		} else if added > 0 || removed > 0 {
+			log.Debugf("albums: added %d and removed %d pictures in smart album %s", added, removed, a.AlbumUID)
+		}
+	}
+
+	return nil
+}
+
+// UpdateSmartAlbum adds the pictures matching the album filter and removes those that no longer match.
+// Pictures that have been removed from the album manually remain hidden.
+func UpdateSmartAlbum(a entity.Album, photoUIDs []string) (added, removed int, err error) {
+	if !a.IsSmart() {
+		return 0, 0, nil
+	}
+
+	// Find the pictures matching the album filter.
+	var f form.SearchPhotos
+
+	if err = form.Unserialize(&f, a.AlbumFilter); err != nil {
+		return 0, 0, err
+	}
+
+	// Keep the filter so that it is not skipped when searching for specific UIDs only.
+	f.Filter = a.AlbumFilter
+	f.Order = sortby.Added
+	f.Count = smartAlbumBatch
+	f.Offset = 0
+
+	if len(photoUIDs) > 0 {
+		f.UID = strings.Join(photoUIDs, "|")
+	}
+
+	// Evaluate the filter with the permissions of the album owner, so that the album
+	// does not contain pictures the owner would not be allowed to see.
+	var sess *entity.Session
+
+	if a.CreatedBy != "" {
+		if owner := entity.FindUserByUID(a.CreatedBy); owner == nil {
+			return 0, 0, fmt.Errorf("owner %s not found", clean.Log(a.CreatedBy))
+		} else {
+			sess = entity.NewSession(0, 0).SetUser(owner)
+		}
+	}
+
+	matches := make(map[string]bool)
+
+	// Page through the results, as entries that are not found would otherwise be removed.
+	for {
+		photos, _, err := search.UserPhotoIds(f, sess)
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		for _, p := range photos {
+			matches[p.PhotoUID] = true
+		}
+
+		if len(photos) < f.Count {
+			break
+		}
+
+		f.Offset += len(photos)
+	}
+
+	// Find existing album entries.
+	var entries entity.PhotoAlbums
+
+	stmt := Db().Where("album_uid = ?", a.AlbumUID)
+
+	if len(photoUIDs) > 0 {
+		stmt = stmt.Where("photo_uid IN (?)", photoUIDs)
+	}
+
+	if err = stmt.Find(&entries).Error; err != nil {
+		return 0, 0, err
+	}
+
+	existing := make(map[string]bool, len(entries))
+
+	for _, entry := range entries {
+		existing[entry.PhotoUID] = true
+
+		// Remove visible entries that no longer match the filter.
+		if !entry.Hidden && !matches[entry.PhotoUID] {
+			if err = Db().Delete(entity.PhotoAlbum{}, "album_uid = ? AND photo_uid = ?", entry.AlbumUID, entry.PhotoUID).Error; err != nil {
+				return added, removed, err
+			}
+
+			removed++
+		}
+	}
+
+	// Add new matches.
+	order := a.NextPhotoOrder()
+
+	for uid := range matches {
+		if existing[uid] {
+			continue
+		}
+
+		entry := entity.NewPhotoAlbum(uid, a.AlbumUID)
+		entry.Order = order + added
+
+		if err = entry.Create(); err != nil {
+			return added, removed, err
+		}
+
+		added++
+	}
+
+	return added, removed, nil
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestAlbumByUID(t *testing.T) {
@@ -104,3 +106,124 @@ func TestGetAlbums(t *testing.T) {
 		assert.Equal(t, 3, len(r))
 	})
 }
+
+func TestSmartAlbums(t *testing.T) {
+	results, err := SmartAlbums()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, a := range results {
+		assert.True(t, a.IsSmart())
+	}
+}
+
+func TestUpdateSmartAlbum(t *testing.T) {
+	t.Run("NotSmart", func(t *testing.T) {
+		added, removed, err := UpdateSmartAlbum(entity.AlbumFixtures.Get("christmas2030"), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, added)
+		assert.Equal(t, 0, removed)
+	})
+	t.Run("Filter", func(t *testing.T) {
+		a := entity.NewAlbum("Smart Album Test", entity.AlbumManual)
+		a.AlbumFilter = "public:true year:2016"
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		added, removed, err := UpdateSmartAlbum(*a, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+
+		var count int
+
+		if err = Db().Model(entity.PhotoAlbum{}).Where("album_uid = ?", a.AlbumUID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, added, count)
+
+		// Updating the album again should not change anything.
+		added, removed, err = UpdateSmartAlbum(*a, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, added)
+		assert.Equal(t, 0, removed)
+
+		if err = a.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Batches", func(t *testing.T) {
+		a := entity.NewAlbum("Smart Album Batches", entity.AlbumManual)
+		a.AlbumFilter = "public:true"
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer a.DeletePermanently()
+
+		added, _, err := UpdateSmartAlbum(*a, nil)
+
+		assert.NoError(t, err)
+		assert.Greater(t, added, 2)
+
+		// Entries must not be removed if there are more matches than can be processed at once.
+		batch := smartAlbumBatch
+		smartAlbumBatch = 2
+		defer func() { smartAlbumBatch = batch }()
+
+		added, removed, err := UpdateSmartAlbum(*a, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, added)
+		assert.Equal(t, 0, removed)
+	})
+	t.Run("Owner", func(t *testing.T) {
+		role, err := entity.NewRole("smart", "shared")
+
+		if err != nil {
+			t.Fatal(err)
+		} else if err = role.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer role.Delete()
+
+		user := entity.NewUser()
+		user.UserName = "smart"
+		user.UserRole = role.RoleName
+
+		if err = user.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer user.Delete()
+
+		a := entity.NewUserAlbum("Smart Album Owner", entity.AlbumManual, user.UserUID)
+		a.AlbumFilter = "public:true"
+
+		if err = a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer a.DeletePermanently()
+
+		// The album must only contain pictures the owner is allowed to see.
+		added, removed, err := UpdateSmartAlbum(*a, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, added)
+		assert.Equal(t, 0, removed)
+	})
+}
+
+func TestUpdateSmartAlbums(t *testing.T) {
+	assert.NoError(t, UpdateSmartAlbums("ps6sg6be2lvl0yh7"))
+}
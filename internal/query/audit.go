@@ -0,0 +1,59 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// auditLike escapes wildcards in search queries so that they match literally,
+// using "!" as escape character since backslashes are handled differently by each dialect.
+var auditLike = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// AuditEvents returns the audit log filtered by the search form, with the most recent events first.
+// A count of zero returns all matching events, e.g. for exporting them.
+func AuditEvents(f form.SearchAudit) (results entity.AuditEvents, err error) {
+	stmt := Db()
+
+	if level := strings.ToLower(strings.TrimSpace(f.Level)); level != "" {
+		stmt = stmt.Where("event_level = ?", level)
+	}
+
+	if ip := strings.TrimSpace(f.IP); ip != "" {
+		stmt = stmt.Where("client_ip = ?", ip)
+	}
+
+	if user := strings.TrimSpace(f.User); user == "" {
+		// Don't filter by user.
+	} else if rnd.IsUID(user, entity.UserUID) {
+		stmt = stmt.Where("user_uid = ?", user)
+	} else {
+		stmt = stmt.Where("user_name = ?", clean.Username(user))
+	}
+
+	if !f.Since.IsZero() {
+		stmt = stmt.Where("event_time >= ?", f.Since.UTC())
+	}
+
+	if !f.Until.IsZero() {
+		stmt = stmt.Where("event_time < ?", f.Until.UTC().AddDate(0, 0, 1))
+	}
+
+	if search := strings.TrimSpace(f.Query); len(search) >= 3 {
+		stmt = stmt.Where(fmt.Sprintf("message %s ? ESCAPE '!'", entity.LikeOp()), "%"+auditLike.Replace(search)+"%")
+	}
+
+	stmt = stmt.Order("event_time DESC, id DESC")
+
+	if f.Count > 0 {
+		stmt = stmt.Limit(f.Count).Offset(f.Offset)
+	}
+
+	err = stmt.Find(&results).Error
+
+	return results, err
+}
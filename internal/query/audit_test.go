@@ -0,0 +1,67 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestAuditEvents(t *testing.T) {
+	t.Run("NotExisting", func(t *testing.T) {
+		results, err := AuditEvents(form.SearchAudit{Query: "notexistingAuditMessage", Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("Wildcards", func(t *testing.T) {
+		m := entity.AuditEvent{EventTime: time.Now().UTC(), EventLevel: "info", Message: "quota at 100%_of limit!"}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.Db().Delete(&m)
+
+		results, err := AuditEvents(form.SearchAudit{Query: "100%_of limit!", Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+
+		for _, q := range []string{"%%%", "___", "100%of", "at_100"} {
+			results, err = AuditEvents(form.SearchAudit{Query: q, Count: 100})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Empty(t, results, q)
+		}
+	})
+	t.Run("Filters", func(t *testing.T) {
+		f := form.SearchAudit{
+			Level: "warning",
+			IP:    "192.0.2.1",
+			User:  "alice",
+			Since: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+		}
+
+		results, err := AuditEvents(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,20 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// EntityChanges returns the recorded changes of an entity, with the most recent changes first.
+func EntityChanges(entityType, entityUID string, limit, offset int) (results entity.Changes, err error) {
+	stmt := Db().
+		Where("entity_type = ? AND entity_uid = ?", entityType, entityUID).
+		Order("id DESC")
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit).Offset(offset)
+	}
+
+	err = stmt.Find(&results).Error
+
+	return results, err
+}
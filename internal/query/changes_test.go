@@ -0,0 +1,30 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestEntityChanges(t *testing.T) {
+	before := form.Album{AlbumTitle: "Changes Before"}
+	after := form.Album{AlbumTitle: "Changes After"}
+
+	change := entity.NewChange(entity.ChangeAlbum, "aqzih0kn0idqqx7y", entity.ChangeActionUpdate, before, after)
+
+	if err := change.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := EntityChanges(entity.ChangeAlbum, "aqzih0kn0idqqx7y", 10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, []string{"Title"}, results[0].Fields())
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// AlbumComments returns the comments on an album and the pictures in it, with the oldest comments first.
+// Comments on a single picture are returned if a photo UID is specified, and hidden comments only if requested.
+func AlbumComments(albumUid, photoUid string, hidden bool, limit, offset int) (results entity.Comments, err error) {
+	stmt := Db().Where("album_uid = ?", albumUid)
+
+	if photoUid != "" {
+		stmt = stmt.Where("photo_uid = ?", photoUid)
+	}
+
+	if !hidden {
+		stmt = stmt.Where("hidden = 0")
+	}
+
+	stmt = stmt.Order("created_at, comment_uid")
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit).Offset(offset)
+	}
+
+	err = stmt.Find(&results).Error
+
+	return results, err
+}
+
+// AlbumReactions returns the reactions to an album and the pictures in it.
+func AlbumReactions(albumUid string) (results entity.Reactions, err error) {
+	err = Db().
+		Where("uid = ? OR uid IN (SELECT photo_uid FROM photos_albums WHERE album_uid = ? AND hidden = 0)", albumUid, albumUid).
+		Order("reacted_at").
+		Find(&results).Error
+
+	return results, err
+}
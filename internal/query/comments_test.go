@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestAlbumComments(t *testing.T) {
+	visible := entity.NewComment("aqzih0kn0idqqx7y", "", "Nice album!")
+	hidden := entity.NewComment("aqzih0kn0idqqx7y", "", "Spam")
+	hidden.Hidden = true
+
+	if err := visible.Create(); err != nil {
+		t.Fatal(err)
+	} else if err = hidden.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.DeleteAlbumComments("aqzih0kn0idqqx7y")
+
+	t.Run("Visible", func(t *testing.T) {
+		results, err := AlbumComments("aqzih0kn0idqqx7y", "", false, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Nice album!", results[0].CommentText)
+	})
+	t.Run("Hidden", func(t *testing.T) {
+		results, err := AlbumComments("aqzih0kn0idqqx7y", "", true, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+	})
+	t.Run("Photo", func(t *testing.T) {
+		results, err := AlbumComments("aqzih0kn0idqqx7y", "ps6sg6be2lvl0yh7", true, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+}
+
+func TestAlbumReactions(t *testing.T) {
+	results, err := AlbumReactions("at9lxuqxpogaaba8")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, results)
+}
@@ -13,6 +13,10 @@ import (
 	"github.com/photoprism/photoprism/pkg/media"
 )
 
+// coverOrder specifies the order in which pictures are considered as automatic album cover, so that
+// pictures with a high quality score and people are preferred over the most recent ones.
+const coverOrder = "photos.photo_quality DESC, photos.photo_faces > 0 DESC, photos.taken_at DESC"
+
 // UpdateAlbumDefaultCovers updates default album cover thumbs.
 func UpdateAlbumDefaultCovers() (err error) {
 	mutex.Index.Lock()
@@ -25,24 +29,15 @@ func UpdateAlbumDefaultCovers() (err error) {
 	condition := gorm.Expr("album_type = ? AND thumb_src = ?", entity.AlbumManual, entity.SrcAuto)
 
 	switch DbDialect() {
-	case MySQL:
-		res = Db().Exec(`UPDATE albums LEFT JOIN (
-    	SELECT p2.album_uid, f.file_hash FROM files f, (
-        	SELECT pa.album_uid, max(p.id) AS photo_id FROM photos p
-            JOIN photos_albums pa ON pa.photo_uid = p.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-        	WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
-        	GROUP BY pa.album_uid) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
-			) b ON b.album_uid = albums.album_uid
-		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case MySQL, SQLite3, Postgres:
 		res = Db().Table(entity.Album{}.TableName()).
 			UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
 			JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = f.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			JOIN photos ON photos.id = f.photo_id AND photos.photo_private = 0 AND photos.deleted_at IS NULL AND photos.photo_quality > 0
 			WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND f.file_hash <> '' AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
-			ORDER BY p.taken_at DESC LIMIT 1
-		) WHERE ?`, media.PreviewExpr, condition))
+			ORDER BY ? LIMIT 1
+		) WHERE ?`, media.PreviewExpr, gorm.Expr(coverOrder), condition))
 	default:
 		log.Warnf("sql: unsupported dialect %s", DbDialect())
 		return nil
@@ -81,7 +76,7 @@ func UpdateAlbumFolderCovers() (err error) {
 			GROUP BY p.photo_path) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.photo_path = albums.album_path
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_path, max(p.id) AS photo_id FROM photos p
@@ -129,7 +124,7 @@ func UpdateAlbumMonthCovers() (err error) {
 			GROUP BY p.photo_year, p.photo_month) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.photo_year = albums.album_year AND b.photo_month = albums.album_month
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_year, p.photo_month, max(p.id) AS photo_id FROM photos p
@@ -205,7 +200,7 @@ func UpdateLabelCovers() (err error) {
 			) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?) AND f.file_missing = 0
 		) b ON b.label_id = labels.id
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Label{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.label_id = labels.id AND pl.photo_id = f.photo_id AND pl.uncertainty < 100
@@ -257,8 +252,8 @@ func UpdateSubjectCovers() (err error) {
 	markerTable := entity.Marker{}.TableName()
 
 	condition := gorm.Expr(
-		fmt.Sprintf("%s.subj_type = ? AND thumb_src = ?", subjTable),
-		entity.SubjPerson, entity.SrcAuto)
+		fmt.Sprintf("%s.subj_type IN (?, ?) AND thumb_src = ?", subjTable),
+		entity.SubjPerson, entity.SubjPet, entity.SrcAuto)
 
 	// TODO: Avoid using private photos as subject covers.
 	// See https://github.com/photoprism/photoprism/issues/2570#issuecomment-1231690056
@@ -271,7 +266,7 @@ func UpdateSubjectCovers() (err error) {
 			GROUP BY m.subj_uid, m.q
 			) b ON b.subj_uid = subjects.subj_uid
 		SET thumb = marker_thumb WHERE ?`, gorm.Expr(subjTable), gorm.Expr(markerTable), condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		from := gorm.Expr(fmt.Sprintf("%s m WHERE m.subj_uid = %s.subj_uid ", markerTable, subjTable))
 		res = Db().Table(entity.Subject{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT m.thumb FROM ? AND m.thumb <> '' ORDER BY m.subj_src DESC, m.q DESC LIMIT 1
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -15,7 +16,7 @@ func Duplicates(limit, offset int, pathName string) (files entity.Duplicates, er
 	stmt := Db()
 
 	if pathName != "" {
-		stmt = stmt.Where("file_name LIKE ?", pathName+"/%")
+		stmt = stmt.Where(fmt.Sprintf("file_name %s ?", entity.LikeOp()), pathName+"/%")
 	}
 
 	err = stmt.Order("file_name").Limit(limit).Offset(offset).Find(&files).Error
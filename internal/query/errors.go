@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -17,7 +18,7 @@ func Errors(limit, offset int, search string) (results entity.Errors, err error)
 	} else if search == "warning" || search == "warnings" {
 		stmt = stmt.Where("error_level = 'warning'")
 	} else if len(search) >= 3 {
-		stmt = stmt.Where("error_message LIKE ?", "%"+search+"%")
+		stmt = stmt.Where(fmt.Sprintf("error_message %s ?", entity.LikeOp()), "%"+search+"%")
 	}
 
 	err = stmt.Order("error_time DESC").Limit(limit).Offset(offset).Find(&results).Error
@@ -38,7 +38,8 @@ func FacesByID(knownOnly, unmatchedOnly, hidden, ignored bool) (FaceMap, IDs, er
 
 // Faces returns all (known / unmatched) faces from the index.
 func Faces(knownOnly, unmatchedOnly, hidden, ignored bool) (result entity.Faces, err error) {
-	stmt := Db()
+	stmt := Db().Where("face_model = ?", face.ActiveModel.Name).
+		Where("face_kind <> ?", int(face.PetFace))
 
 	if knownOnly {
 		stmt = stmt.Where("subj_uid <> ''")
@@ -61,11 +62,40 @@ func Faces(knownOnly, unmatchedOnly, hidden, ignored bool) (result entity.Faces,
 	return result, err
 }
 
-// ManuallyAddedFaces returns all manually added face clusters.
+// PetFaces returns the face clusters of pets and other animals.
+func PetFaces(unmatchedOnly bool) (result entity.Faces, err error) {
+	stmt := Db().
+		Where("face_model = ?", face.ActiveModel.Name).
+		Where("face_kind = ?", int(face.PetFace)).
+		Where("face_hidden = ?", false)
+
+	if unmatchedOnly {
+		stmt = stmt.Where("matched_at IS NULL")
+	}
+
+	err = stmt.Order("subj_uid, samples DESC").Find(&result).Error
+
+	return result, err
+}
+
+// IgnoredFaces returns hidden face clusters and the faces of ignored subjects, which must not resurface.
+func IgnoredFaces() (result entity.Faces, err error) {
+	err = Db().
+		Where("face_model = ?", face.ActiveModel.Name).
+		Where(fmt.Sprintf("face_hidden = 1 OR subj_uid IN (SELECT subj_uid FROM %s WHERE subj_ignored = 1)", entity.Subject{}.TableName())).
+		Order("subj_uid, samples DESC").
+		Find(&result).Error
+
+	return result, err
+}
+
+// ManuallyAddedFaces returns all manually added face clusters, except those covering a certain time period.
 func ManuallyAddedFaces(hidden, ignored bool) (result entity.Faces, err error) {
 	stmt := Db().
 		Where("face_hidden = ?", hidden).
 		Where("face_src = ?", entity.SrcManual).
+		Where("face_model = ?", face.ActiveModel.Name).
+		Where("face_year = 0").
 		Where("subj_uid <> ''")
 
 	if !ignored {
@@ -117,6 +147,14 @@ func RemoveAutoFaceClusters() (removed int, err error) {
 	return int(res.RowsAffected), res.Error
 }
 
+// RemoveOutdatedFaceClusters removes face clusters computed by a different model than the active one.
+func RemoveOutdatedFaceClusters() (removed int, err error) {
+	res := UnscopedDb().
+		Delete(entity.Face{}, "face_model <> ?", face.ActiveModel.Name)
+
+	return int(res.RowsAffected), res.Error
+}
+
 // CountNewFaceMarkers counts the number of new face markers in the index.
 func CountNewFaceMarkers(size, score int) (n int) {
 	var f entity.Face
@@ -128,7 +166,8 @@ func CountNewFaceMarkers(size, score int) (n int) {
 
 	q := Db().Model(&entity.Markers{}).
 		Where("marker_type = ?", entity.MarkerFace).
-		Where("face_id = '' AND marker_invalid = 0 AND embeddings_json <> ''")
+		Where("face_id = '' AND marker_invalid = 0 AND embeddings_json <> ''").
+		Where("embeddings_model = ?", face.ActiveModel.Name)
 
 	if size > 0 {
 		q = q.Where("size >= ?", size)
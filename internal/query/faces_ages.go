@@ -0,0 +1,59 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+// FaceAgeSample represents a manually confirmed face marker and the year in which the picture was taken.
+type FaceAgeSample struct {
+	MarkerUID      string
+	SubjUID        string
+	PhotoYear      int
+	EmbeddingsJSON string
+}
+
+// Embeddings returns the face embeddings of the sample.
+func (m FaceAgeSample) Embeddings() face.Embeddings {
+	if m.EmbeddingsJSON == "" {
+		return face.Embeddings{}
+	} else if embeddings, err := face.UnmarshalEmbeddings(m.EmbeddingsJSON); err != nil {
+		log.Warnf("faces: %s", err)
+		return face.Embeddings{}
+	} else {
+		return embeddings
+	}
+}
+
+// FaceAgeSamples returns manually confirmed face markers of pictures with a known year, sorted by subject.
+func FaceAgeSamples() (result []FaceAgeSample, err error) {
+	err = UnscopedDb().Table(entity.Marker{}.TableName()).
+		Select("markers.marker_uid, markers.subj_uid, photos.photo_year, markers.embeddings_json").
+		Joins("JOIN files ON files.file_uid = markers.file_uid").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Where("markers.marker_type = ?", entity.MarkerFace).
+		Where("markers.subj_src = ?", entity.SrcManual).
+		Where("markers.subj_uid <> ''").
+		Where("markers.marker_invalid = 0").
+		Where("markers.embeddings_json <> ''").
+		Where("markers.embeddings_model = ?", face.ActiveModel.Name).
+		Where("photos.photo_year > 0 AND photos.deleted_at IS NULL").
+		Order("markers.subj_uid, photos.photo_year, markers.marker_uid").
+		Scan(&result).Error
+
+	return result, err
+}
+
+// SetMarkerFace assigns the specified markers to a face cluster.
+func SetMarkerFace(markerUIDs []string, faceID string) (affected int64, err error) {
+	if len(markerUIDs) == 0 || faceID == "" {
+		return 0, nil
+	}
+
+	res := Db().
+		Model(&entity.Marker{}).
+		Where("marker_uid IN (?)", markerUIDs).
+		UpdateColumns(entity.Values{"face_id": faceID, "face_dist": 0.0})
+
+	return res.RowsAffected, res.Error
+}
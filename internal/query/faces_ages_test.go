@@ -0,0 +1,31 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaceAgeSamples(t *testing.T) {
+	results, err := FaceAgeSamples()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range results {
+		assert.NotEmpty(t, r.MarkerUID)
+		assert.NotEmpty(t, r.SubjUID)
+		assert.Greater(t, r.PhotoYear, 0)
+		assert.False(t, r.Embeddings().Empty())
+	}
+}
+
+func TestSetMarkerFace(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		affected, err := SetMarkerFace(nil, "PN6QO5INYTUSAATOFL43LL2ABAV5ACZK")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), affected)
+	})
+}
@@ -243,3 +243,25 @@ func TestRemoveAutoFaceClusters(t *testing.T) {
 
 	assert.LessOrEqual(t, 3, removed)
 }
+
+func TestRemoveOutdatedFaceClusters(t *testing.T) {
+	removed, err := RemoveOutdatedFaceClusters()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, removed)
+}
+
+func TestIgnoredFaces(t *testing.T) {
+	results, err := IgnoredFaces()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range results {
+		assert.True(t, f.FaceHidden || f.SubjUID != "")
+	}
+}
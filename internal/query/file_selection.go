@@ -25,6 +25,7 @@ type FileSelection struct {
 	Hidden    bool
 	Private   bool
 	Archived  bool
+	Locked    entity.UIDs
 }
 
 // DownloadSelection selects files to download.
@@ -101,7 +102,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 	switch DbDialect() {
 	case MySQL:
 		concat = "CONCAT(a.path, '/%')"
-	case SQLite3:
+	case SQLite3, Postgres:
 		concat = "a.path || '/%'"
 	default:
 		return results, fmt.Errorf("unknown sql dialect: %s", DbDialect())
@@ -113,12 +114,12 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		OR photos.photo_uid IN (SELECT photo_uid FROM files WHERE file_uid IN (?))
 		OR photos.photo_path IN (
 			SELECT a.path FROM folders a WHERE a.folder_uid IN (?) UNION
-			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
+			SELECT b.path FROM folders a JOIN folders b ON b.path %s %s WHERE a.folder_uid IN (?))
 		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
 		OR files.file_uid IN (SELECT file_uid FROM %s m WHERE m.subj_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND pl.uncertainty < 100 AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id AND pl.uncertainty < 100 JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
-		concat, entity.Marker{}.TableName())
+		entity.LikeOp(), concat, entity.Marker{}.TableName())
 
 	// Build search query.
 	s := UnscopedDb().Table("files").
@@ -178,6 +179,11 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		s = s.Where("photos.deleted_at IS NULL")
 	}
 
+	// Exclude pictures in locked albums?
+	if len(o.Locked) > 0 {
+		s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))", o.Locked)
+	}
+
 	// Find and return.
 	if result := s.Scan(&results); result.Error != nil {
 		return results, result.Error
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 )
 
@@ -118,4 +119,22 @@ func TestFileSelection(t *testing.T) {
 			assert.Len(t, results, 3)
 		}
 	})
+	t.Run("DownloadSelectionLocked", func(t *testing.T) {
+		sel := DownloadSelection(true, true, false)
+		sel.Locked = entity.UIDs{"at9lxuqxpogaaba9"}
+
+		if results, err := SelectedFiles(one, sel); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Empty(t, results)
+		}
+
+		if results, err := SelectedFiles(albums, sel); err != nil {
+			t.Fatal(err)
+		} else {
+			for _, file := range results {
+				assert.NotEqual(t, "pt9jtdre2lvl0yh8", file.PhotoUID)
+			}
+		}
+	})
 }
@@ -30,3 +30,14 @@ func FileSyncs(accountId uint, status string, limit int) (result []entity.FileSy
 
 	return result, nil
 }
+
+// SyncedFiles returns the files that were synced in both directions with the specified account.
+func SyncedFiles(accountId uint) (result []entity.FileSync, err error) {
+	err = Db().Where("service_id = ? AND local_name <> ''", accountId).
+		Where("status IN (?)", []string{entity.FileSyncDownloaded, entity.FileSyncUploaded, entity.FileSyncExists}).
+		Order("remote_name ASC").
+		Preload("File").
+		Find(&result).Error
+
+	return result, err
+}
@@ -31,3 +31,15 @@ func TestFileSyncs(t *testing.T) {
 		}
 	})
 }
+
+func TestSyncedFiles(t *testing.T) {
+	r, err := SyncedFiles(uint(1000001))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range r {
+		assert.NotEmpty(t, f.LocalName)
+	}
+}
@@ -46,7 +46,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	}
 
 	if pathName != "" {
-		stmt = stmt.Where("files.file_name LIKE ?", pathName+"/%")
+		stmt = stmt.Where(fmt.Sprintf("files.file_name %s ?", entity.LikeOp()), pathName+"/%")
 	}
 
 	err = stmt.Order("id").Limit(limit).Offset(offset).Find(&files).Error
@@ -54,6 +54,38 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	return files, err
 }
 
+// OriginalFiles returns the indexed original files of the specified photos, optionally
+// limited to certain file types. Sidecar, missing, and deleted files are excluded.
+func OriginalFiles(photoUIDs []string, fileTypes []string) (files entity.Files, err error) {
+	const batchSize = 500
+
+	for i := 0; i < len(photoUIDs); i += batchSize {
+		j := i + batchSize
+
+		if j > len(photoUIDs) {
+			j = len(photoUIDs)
+		}
+
+		var batch entity.Files
+
+		stmt := UnscopedDb().
+			Where("photo_uid IN (?) AND file_root = ?", photoUIDs[i:j], entity.RootOriginals).
+			Where("file_sidecar = 0 AND file_missing = 0 AND deleted_at IS NULL")
+
+		if len(fileTypes) > 0 {
+			stmt = stmt.Where("file_type IN (?)", fileTypes)
+		}
+
+		if err = stmt.Order("file_name").Find(&batch).Error; err != nil {
+			return files, err
+		}
+
+		files = append(files, batch...)
+	}
+
+	return files, nil
+}
+
 // FilesByUID finds files for the given UIDs.
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
 	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
@@ -216,3 +248,43 @@ func OrphanFiles() (files entity.Files, err error) {
 
 	return files, err
 }
+
+// PrimaryImageFiles returns primary image files of photos that have not been deleted, in pseudo-random order.
+func PrimaryImageFiles(limit, offset int) (files entity.Files, err error) {
+	err = UnscopedDb().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.file_error = '' AND files.deleted_at IS NULL").
+		Where("files.file_type IN (?)", media.PreviewExpr).
+		Order("files.file_hash").Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
+// CountPrimaryImageFiles returns the number of primary image files of photos that have not been deleted.
+func CountPrimaryImageFiles() (n int) {
+	if err := UnscopedDb().
+		Table("files").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.file_error = '' AND files.deleted_at IS NULL").
+		Where("files.file_type IN (?)", media.PreviewExpr).
+		Count(&n).Error; err != nil {
+		log.Errorf("files: %s (count primary images)", err)
+	}
+
+	return n
+}
+
+// FileByName finds an indexed file by root and name.
+func FileByName(fileRoot, fileName string) (*entity.File, error) {
+	f := entity.File{}
+
+	if fileName == "" {
+		return &f, fmt.Errorf("file name required")
+	}
+
+	err := Db().Where("file_root = ? AND file_name = ?", fileRoot, fileName).First(&f).Error
+
+	return &f, err
+}
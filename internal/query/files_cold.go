@@ -0,0 +1,68 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// ColdFiles returns archived files, optionally filtered by status and path, ordered by file id.
+func ColdFiles(status, pathName string, limit, offset int) (result entity.FileColds, err error) {
+	stmt := Db()
+
+	if status != "" {
+		stmt = stmt.Where("status = ?", status)
+	}
+
+	if pathName = strings.Trim(pathName, "/"); pathName != "" {
+		stmt = stmt.Where(fmt.Sprintf("file_name %s ?", entity.LikeOp()), pathName+"/%")
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit).Offset(offset)
+	}
+
+	err = stmt.Order("file_id").Find(&result).Error
+
+	return result, err
+}
+
+// ColdFileIDs returns the ids of all files that were moved to cold storage.
+func ColdFileIDs() (result map[uint]bool, err error) {
+	var ids []uint
+
+	if err = UnscopedDb().Model(entity.FileCold{}).Pluck("file_id", &ids).Error; err != nil {
+		return result, err
+	}
+
+	result = make(map[uint]bool, len(ids))
+
+	for _, id := range ids {
+		result[id] = true
+	}
+
+	return result, nil
+}
+
+// ColdCandidates returns originals with an id greater than afterID that can be moved to cold storage,
+// optionally filtered by path and the date on which the pictures were taken.
+func ColdCandidates(pathName string, takenBefore time.Time, afterID uint, limit int) (files entity.Files, err error) {
+	stmt := Db().Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Where("files.id > ? AND files.file_root = ? AND files.file_missing = 0 AND files.deleted_at IS NULL", afterID, entity.RootOriginals).
+		Where("files.id NOT IN (SELECT file_id FROM files_cold)")
+
+	if pathName = strings.Trim(pathName, "/"); pathName != "" {
+		stmt = stmt.Where(fmt.Sprintf("files.file_name %s ?", entity.LikeOp()), pathName+"/%")
+	}
+
+	if !takenBefore.IsZero() {
+		stmt = stmt.Where("photos.taken_at < ?", takenBefore)
+	}
+
+	err = stmt.Order("files.id").Limit(limit).Find(&files).Error
+
+	return files, err
+}
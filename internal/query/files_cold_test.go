@@ -0,0 +1,71 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestColdFiles(t *testing.T) {
+	m := entity.NewFileCold(&entity.File{ID: 1234600, FileName: "cold/query.jpg", FileSize: 1024}, "originals/cold/query.jpg")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	t.Run("Path", func(t *testing.T) {
+		result, err := ColdFiles("", "/cold/", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+	})
+	t.Run("Restoring", func(t *testing.T) {
+		result, err := ColdFiles(entity.FileColdRestoring, "cold", 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("IDs", func(t *testing.T) {
+		result, err := ColdFileIDs()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, result[1234600])
+	})
+}
+
+func TestColdCandidates(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, err := ColdCandidates("", time.Time{}, 0, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range result {
+			assert.Equal(t, entity.RootOriginals, f.FileRoot)
+		}
+	})
+	t.Run("PathAndDate", func(t *testing.T) {
+		result, err := ColdCandidates("does-not-exist", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), 0, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+}
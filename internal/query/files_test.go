@@ -116,6 +116,38 @@ func TestFilesByUID(t *testing.T) {
 	})
 }
 
+func TestOriginalFiles(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		files, err := OriginalFiles([]string{"pt9jtdre2lvl0y11"}, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, files)
+
+		for _, f := range files {
+			assert.Equal(t, entity.RootOriginals, f.FileRoot)
+			assert.False(t, f.FileSidecar)
+		}
+	})
+	t.Run("FileType", func(t *testing.T) {
+		files, err := OriginalFiles([]string{"pt9jtdre2lvl0y11"}, []string{"heic"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+	t.Run("None", func(t *testing.T) {
+		files, err := OriginalFiles(nil, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
 func TestFileByPhotoUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByPhotoUID("pt9jtdre2lvl0y11")
@@ -175,6 +207,24 @@ func TestFileByUID(t *testing.T) {
 	})
 }
 
+func TestFileByName(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		file, err := FileByName(entity.RootOriginals, "2790/07/27900704_070228_D6D51B6C.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "ft8es39w45bnlqdw", file.FileUID)
+	})
+
+	t.Run("no files found", func(t *testing.T) {
+		_, err := FileByName(entity.RootSidecar, "2790/07/27900704_070228_D6D51B6C.jpg")
+
+		assert.Error(t, err)
+	})
+}
+
 func TestFileByHash(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByHash("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
@@ -287,3 +337,21 @@ func TestOrphanFiles(t *testing.T) {
 
 	assert.IsType(t, entity.Files{}, files)
 }
+
+func TestPrimaryImageFiles(t *testing.T) {
+	files, err := PrimaryImageFiles(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, len(files), 10)
+
+	for _, f := range files {
+		assert.True(t, f.FilePrimary)
+	}
+}
+
+func TestCountPrimaryImageFiles(t *testing.T) {
+	assert.GreaterOrEqual(t, CountPrimaryImageFiles(), 1)
+}
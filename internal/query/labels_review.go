@@ -0,0 +1,40 @@
+package query
+
+import (
+	"math"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// LabelReview represents an automatically assigned photo label that should be reviewed.
+type LabelReview struct {
+	PhotoUID       string  `json:"PhotoUID"`
+	PhotoTitle     string  `json:"PhotoTitle"`
+	FileHash       string  `json:"Hash"`
+	LabelUID       string  `json:"LabelUID"`
+	LabelName      string  `json:"LabelName"`
+	LabelSrc       string  `json:"LabelSrc"`
+	LabelThreshold float32 `json:"Threshold"`
+	Uncertainty    int     `json:"Uncertainty"`
+	Confidence     float32 `json:"Confidence"`
+}
+
+// ReviewLabels finds automatically assigned photo labels that should be reviewed because their
+// confidence is below minConfidence, starting with the least confident ones.
+func ReviewLabels(limit, offset int, minConfidence float32) (results []LabelReview, err error) {
+	uncertainty := 100 - int(math.Round(float64(minConfidence)*100))
+
+	err = UnscopedDb().Table("photos_labels").
+		Select("photos.photo_uid, photos.photo_title, files.file_hash, labels.label_uid, labels.label_name, "+
+			"labels.label_threshold, photos_labels.label_src, photos_labels.uncertainty, photos_labels.confidence").
+		Joins("JOIN photos ON photos.id = photos_labels.photo_id AND photos.deleted_at IS NULL").
+		Joins("JOIN labels ON labels.id = photos_labels.label_id AND labels.deleted_at IS NULL").
+		Joins("LEFT JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("photos_labels.label_src = ?", entity.SrcImage).
+		Where("photos_labels.uncertainty > ? AND photos_labels.uncertainty < 100", uncertainty).
+		Order("photos_labels.uncertainty DESC, photos.photo_uid, labels.label_uid").
+		Limit(limit).Offset(offset).
+		Scan(&results).Error
+
+	return results, err
+}
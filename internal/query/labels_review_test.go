@@ -0,0 +1,36 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestReviewLabels(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		results, err := ReviewLabels(100, 0, 0.7)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.Equal(t, entity.SrcImage, r.LabelSrc)
+			assert.Greater(t, r.Uncertainty, 30)
+			assert.Less(t, r.Uncertainty, 100)
+		}
+	})
+	t.Run("None", func(t *testing.T) {
+		results, err := ReviewLabels(100, 0, 0.01)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
@@ -48,10 +48,16 @@ func Markers(limit, offset int, markerType string, embeddings, subjects bool, ma
 
 // UnmatchedFaceMarkers finds all currently unmatched face markers.
 func UnmatchedFaceMarkers(limit, offset int, matchedBefore *time.Time) (result entity.Markers, err error) {
+	return UnmatchedMarkers(entity.MarkerFace, limit, offset, matchedBefore)
+}
+
+// UnmatchedMarkers finds all currently unmatched markers of the specified type, e.g. faces or pets.
+func UnmatchedMarkers(markerType string, limit, offset int, matchedBefore *time.Time) (result entity.Markers, err error) {
 	db := Db().
-		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_type = ?", markerType).
 		Where("marker_invalid = 0").
-		Where("embeddings_json <> ''")
+		Where("embeddings_json <> ''").
+		Where("embeddings_model = ?", face.ActiveModel.Name)
 
 	if matchedBefore == nil {
 		db = db.Where("matched_at IS NULL")
@@ -78,13 +84,19 @@ func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 
 // Embeddings returns existing face embeddings.
 func Embeddings(single, unclustered bool, size, score int) (result face.Embeddings, err error) {
+	return MarkerEmbeddings(entity.MarkerFace, single, unclustered, size, score)
+}
+
+// MarkerEmbeddings returns existing embeddings of markers with the specified type, e.g. faces or pets.
+func MarkerEmbeddings(markerType string, single, unclustered bool, size, score int) (result face.Embeddings, err error) {
 	var col []string
 
 	stmt := Db().
 		Model(&entity.Marker{}).
-		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_type = ?", markerType).
 		Where("marker_invalid = 0").
 		Where("embeddings_json <> ''").
+		Where("embeddings_model = ?", face.ActiveModel.Name).
 		Order("marker_uid")
 
 	if size > 0 {
@@ -220,6 +232,55 @@ func ResetFaceMarkerMatches() (removed int64, err error) {
 	return res.RowsAffected, res.Error
 }
 
+// OutdatedFaceMarkers finds face markers with embeddings computed by a different model than the active one.
+func OutdatedFaceMarkers(limit, offset int) (result entity.Markers, err error) {
+	err = Db().
+		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0").
+		Where("embeddings_model <> ?", face.ActiveModel.Name).
+		Order("marker_uid").Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, err
+}
+
+// CountOutdatedFaceMarkers counts the face markers with embeddings computed by a different model than the active one.
+func CountOutdatedFaceMarkers() (n int) {
+	q := Db().Model(&entity.Markers{}).
+		Where("marker_type = ? AND marker_invalid = 0", entity.MarkerFace).
+		Where("embeddings_model <> ?", face.ActiveModel.Name)
+
+	if err := q.Count(&n).Error; err != nil {
+		log.Errorf("faces: %s (count outdated markers)", err)
+	}
+
+	return n
+}
+
+// DetectedFaceMarkers returns valid, automatically detected face markers sorted by file, video time, and size.
+func DetectedFaceMarkers(limit, offset int) (result entity.Markers, err error) {
+	err = Db().
+		Where("marker_type = ? AND marker_src = ?", entity.MarkerFace, entity.SrcImage).
+		Where("marker_invalid = 0").
+		Order("file_uid, marker_time, size DESC, marker_uid").Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, err
+}
+
+// ReviewMarkers finds automatically matched face and pet markers that should be reviewed, either because
+// they have been flagged or because the distance to the matching face is at least minDist.
+func ReviewMarkers(limit, offset int, minDist float64) (result entity.Markers, err error) {
+	err = Db().
+		Where("marker_type IN (?, ?)", entity.MarkerFace, entity.MarkerPet).
+		Where("marker_invalid = 0 AND subj_uid <> '' AND subj_src = ?", entity.SrcAuto).
+		Where("marker_review = 1 OR face_dist >= ?", minDist).
+		Order("face_dist DESC, marker_uid").Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, err
+}
+
 // CountUnmatchedFaceMarkers counts the number of unmatched face markers in the index.
 func CountUnmatchedFaceMarkers() (n int) {
 	q := Db().Model(&entity.Markers{}).
@@ -207,3 +207,41 @@ func TestCountMarkers(t *testing.T) {
 
 	assert.GreaterOrEqual(t, n, 1)
 }
+
+func TestOutdatedFaceMarkers(t *testing.T) {
+	results, err := OutdatedFaceMarkers(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, results)
+	assert.Equal(t, 0, CountOutdatedFaceMarkers())
+}
+
+func TestDetectedFaceMarkers(t *testing.T) {
+	results, err := DetectedFaceMarkers(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range results {
+		assert.True(t, m.DetectedFace())
+		assert.False(t, m.MarkerInvalid)
+	}
+}
+
+func TestReviewMarkers(t *testing.T) {
+	results, err := ReviewMarkers(100, 0, 0.9)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range results {
+		assert.NotEmpty(t, m.SubjUID)
+		assert.Equal(t, entity.SrcAuto, m.SubjSrc)
+		assert.True(t, m.MarkerReview || m.FaceDist >= 0.9)
+	}
+}
@@ -318,3 +318,28 @@ func RemoveDuplicateMoments() (removed int, err error) {
 
 	return removed, nil
 }
+
+// RemoveMoments permanently deletes the generated moment, month, and state albums that are not
+// contained in the keep list. Albums that have been deleted, marked as favorite, or shared are preserved.
+func RemoveMoments(keep map[string]bool) (removed int, err error) {
+	var albums entity.Albums
+
+	if err = UnscopedDb().
+		Where("album_type IN (?) AND deleted_at IS NULL AND album_favorite = 0", []string{entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState}).
+		Where("album_uid NOT IN (SELECT share_uid FROM links)").
+		Find(&albums).Error; err != nil {
+		return removed, err
+	}
+
+	for _, a := range albums {
+		if keep[a.AlbumUID] {
+			continue
+		} else if err = a.DeletePermanently(); err != nil {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/dustin/go-humanize/english"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestMomentsTime(t *testing.T) {
@@ -333,3 +335,25 @@ func TestRemoveDuplicateMoments(t *testing.T) {
 		}
 	})
 }
+
+func TestRemoveMoments(t *testing.T) {
+	t.Run("KeepAll", func(t *testing.T) {
+		var albums entity.Albums
+
+		if err := UnscopedDb().Where("album_type IN (?)", []string{entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState}).Find(&albums).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		keep := make(map[string]bool, len(albums))
+
+		for _, a := range albums {
+			keep[a.AlbumUID] = true
+		}
+
+		if removed, err := RemoveMoments(keep); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, 0, removed)
+		}
+	})
+}
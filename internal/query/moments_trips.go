@@ -0,0 +1,234 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Trip detection defaults.
+const (
+	TripCategory    = "Trips"
+	TripMinDistance = 100.0          // Minimum distance from home in km.
+	TripMaxGap      = 48 * time.Hour // Maximum time between two pictures of the same trip.
+	TripMaxPlaces   = 3              // Maximum number of places in the title.
+)
+
+// TripPhoto contains the time and location of a picture for detecting trips.
+type TripPhoto struct {
+	TakenAt      time.Time
+	PhotoLat     float64
+	PhotoLng     float64
+	PhotoCountry string
+	PlaceCity    string
+	PlaceState   string
+}
+
+// Place returns the name of the place where the picture was taken, if known.
+func (m TripPhoto) Place() string {
+	if m.PlaceCity != "" && m.PlaceCity != "Unknown" {
+		return m.PlaceCity
+	} else if m.PlaceState != "" && m.PlaceState != "Unknown" {
+		return m.PlaceState
+	}
+
+	return ""
+}
+
+// Position returns the geographic position of the picture.
+func (m TripPhoto) Position() geo.Position {
+	return geo.Position{Lat: m.PhotoLat, Lng: m.PhotoLng}
+}
+
+// Trip represents a sequence of pictures taken on multiple days away from home.
+type Trip struct {
+	Start      time.Time `json:"Start"`
+	End        time.Time `json:"End"`
+	Country    string    `json:"Country"`
+	Places     []string  `json:"Places"`
+	PhotoCount int       `json:"PhotoCount"`
+}
+
+// Trips represents a list of trips.
+type Trips []Trip
+
+// Days returns the number of calendar days of the trip.
+func (m Trip) Days() int {
+	return int(m.EndDate().Sub(m.StartDate()).Hours()/24) + 1
+}
+
+// StartDate returns the day on which the trip started.
+func (m Trip) StartDate() time.Time {
+	return time.Date(m.Start.Year(), m.Start.Month(), m.Start.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// EndDate returns the day on which the trip ended.
+func (m Trip) EndDate() time.Time {
+	return time.Date(m.End.Year(), m.End.Month(), m.End.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CountryName returns the name of the country that was visited most, if any.
+func (m Trip) CountryName() string {
+	if m.Country == "" || m.Country == "zz" {
+		return ""
+	}
+
+	return maps.CountryName(m.Country)
+}
+
+// Location returns the names of the visited places.
+func (m Trip) Location() string {
+	return strings.Join(m.Places, ", ")
+}
+
+// Title returns an english title for the trip.
+func (m Trip) Title() string {
+	name := m.CountryName()
+
+	if n := len(m.Places); n > 0 {
+		if n > TripMaxPlaces {
+			n = TripMaxPlaces
+		}
+
+		if n == 1 {
+			name = m.Places[0]
+		} else {
+			name = fmt.Sprintf("%s & %s", strings.Join(m.Places[:n-1], ", "), m.Places[n-1])
+		}
+	}
+
+	if name == "" {
+		return m.StartDate().Format("January 2006")
+	}
+
+	return fmt.Sprintf("%s / %s", name, m.StartDate().Format("January 2006"))
+}
+
+// Slug returns an identifier string for the trip.
+func (m Trip) Slug() string {
+	return txt.Slug(fmt.Sprintf("trip-%s-%s", m.StartDate().Format("2006-01-02"), m.EndDate().Format("2006-01-02")))
+}
+
+// Dates returns the start and end date of the trip as string.
+func (m Trip) Dates() string {
+	return fmt.Sprintf("%s – %s", m.StartDate().Format("January 2, 2006"), m.EndDate().Format("January 2, 2006"))
+}
+
+// MomentsTrips finds trips with at least the specified number of pictures.
+func MomentsTrips(threshold int, public bool) (results Trips, err error) {
+	var photos []TripPhoto
+
+	stmt := UnscopedDb().Table("photos").
+		Select("photos.taken_at, photos.photo_lat, photos.photo_lng, photos.photo_country, places.place_city, places.place_state").
+		Joins("LEFT JOIN places ON photos.place_id = places.id").
+		Where("photos.photo_quality >= 3 AND photos.deleted_at IS NULL AND photos.taken_src <> ''").
+		Where("photos.photo_lat <> 0 OR photos.photo_lng <> 0")
+
+	// Ignore private pictures?
+	if public {
+		stmt = stmt.Where("photos.photo_private = 0")
+	}
+
+	if err = stmt.Order("photos.taken_at").Scan(&photos).Error; err != nil {
+		return results, err
+	}
+
+	return FindTrips(photos, threshold), nil
+}
+
+// TripHome returns the approximate location where most pictures were taken.
+func TripHome(photos []TripPhoto) (home geo.Position) {
+	counts := make(map[[2]float64]int)
+	most := 0
+
+	for _, p := range photos {
+		// Round to about 10 km.
+		cell := [2]float64{math.Round(p.PhotoLat*10) / 10, math.Round(p.PhotoLng*10) / 10}
+		counts[cell]++
+
+		if counts[cell] > most {
+			most = counts[cell]
+			home = geo.Position{Lat: cell[0], Lng: cell[1]}
+		}
+	}
+
+	return home
+}
+
+// FindTrips groups pictures ordered by time into trips, i.e. multi-day sequences of pictures
+// taken far away from home, and returns those with at least the specified number of pictures.
+func FindTrips(photos []TripPhoto, threshold int) (results Trips) {
+	results = Trips{}
+
+	if len(photos) == 0 {
+		return results
+	}
+
+	home := TripHome(photos)
+
+	var trip *Trip
+
+	places := make(map[string]bool)
+	countries := make(map[string]int)
+
+	done := func() {
+		if trip == nil {
+			return
+		}
+
+		for country, n := range countries {
+			if n > countries[trip.Country] || n == countries[trip.Country] && country < trip.Country {
+				trip.Country = country
+			}
+		}
+
+		if trip.Days() > 1 && trip.PhotoCount >= threshold {
+			results = append(results, *trip)
+		}
+
+		trip = nil
+		places = make(map[string]bool)
+		countries = make(map[string]int)
+	}
+
+	for _, p := range photos {
+		if geo.Km(home, p.Position()) < TripMinDistance {
+			// Back home.
+			done()
+			continue
+		} else if trip != nil && p.TakenAt.Sub(trip.End) > TripMaxGap {
+			// Too much time has passed since the last picture.
+			done()
+		}
+
+		if trip == nil {
+			trip = &Trip{Start: p.TakenAt, Places: []string{}}
+		}
+
+		trip.End = p.TakenAt
+		trip.PhotoCount++
+
+		if place := p.Place(); place != "" && !places[place] {
+			places[place] = true
+			trip.Places = append(trip.Places, place)
+		}
+
+		if p.PhotoCountry != "" && p.PhotoCountry != "zz" {
+			countries[p.PhotoCountry]++
+		}
+	}
+
+	done()
+
+	// Show the most recent trips first.
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+
+	return results
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTrips(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2021, 6, d, h, 0, 0, 0, time.UTC)
+	}
+
+	home := func(d, h int) TripPhoto {
+		return TripPhoto{TakenAt: day(d, h), PhotoLat: 52.52, PhotoLng: 13.40, PhotoCountry: "de", PlaceCity: "Berlin"}
+	}
+
+	paris := func(d, h int) TripPhoto {
+		return TripPhoto{TakenAt: day(d, h), PhotoLat: 48.85, PhotoLng: 2.35, PhotoCountry: "fr", PlaceCity: "Paris"}
+	}
+
+	lyon := func(d, h int) TripPhoto {
+		return TripPhoto{TakenAt: day(d, h), PhotoLat: 45.76, PhotoLng: 4.83, PhotoCountry: "fr", PlaceCity: "Lyon"}
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Len(t, FindTrips(nil, 1), 0)
+	})
+	t.Run("MultiDay", func(t *testing.T) {
+		photos := []TripPhoto{
+			home(1, 10), home(1, 12), home(2, 10), home(2, 11), home(3, 9),
+			paris(4, 10), paris(4, 15), lyon(5, 12), lyon(6, 18),
+			home(7, 10), home(8, 10),
+		}
+
+		result := FindTrips(photos, 3)
+
+		if assert.Len(t, result, 1) {
+			trip := result[0]
+			assert.Equal(t, day(4, 10), trip.Start)
+			assert.Equal(t, day(6, 18), trip.End)
+			assert.Equal(t, 3, trip.Days())
+			assert.Equal(t, 4, trip.PhotoCount)
+			assert.Equal(t, "fr", trip.Country)
+			assert.Equal(t, []string{"Paris", "Lyon"}, trip.Places)
+			assert.Equal(t, "Paris, Lyon", trip.Location())
+			assert.Equal(t, "Paris & Lyon / June 2021", trip.Title())
+			assert.Equal(t, "trip-2021-06-04-2021-06-06", trip.Slug())
+		}
+	})
+	t.Run("SingleDay", func(t *testing.T) {
+		photos := []TripPhoto{
+			home(1, 10), home(2, 10), home(3, 10),
+			paris(4, 10), paris(4, 12), paris(4, 15),
+			home(5, 10),
+		}
+
+		assert.Len(t, FindTrips(photos, 1), 0)
+	})
+	t.Run("Gap", func(t *testing.T) {
+		photos := []TripPhoto{
+			home(1, 10), home(2, 10), home(3, 10), home(3, 12), home(3, 14),
+			paris(10, 10), paris(11, 10),
+			paris(20, 10), paris(21, 10),
+		}
+
+		result := FindTrips(photos, 2)
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, day(20, 10), result[0].Start)
+			assert.Equal(t, day(10, 10), result[1].Start)
+		}
+	})
+	t.Run("Threshold", func(t *testing.T) {
+		photos := []TripPhoto{
+			home(1, 10), home(2, 10), home(3, 10),
+			paris(4, 10), paris(5, 10),
+		}
+
+		assert.Len(t, FindTrips(photos, 3), 0)
+	})
+}
+
+func TestMomentsTrips(t *testing.T) {
+	t.Run("PublicOnly", func(t *testing.T) {
+		if results, err := MomentsTrips(1, true); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.IsType(t, Trips{}, results)
+		}
+	})
+}
@@ -104,6 +104,33 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (ent
 	return entities, err
 }
 
+// PhotosWithoutLocation returns photos without GPS coordinates that were taken within the specified time range,
+// ignoring pictures for which no time was found in the metadata.
+func PhotosWithoutLocation(from, to time.Time, limit, offset int) (entities entity.Photos, err error) {
+	err = Db().
+		Preload("Details").
+		Preload("Place").
+		Preload("Cell").
+		Preload("Cell.Place").
+		Where("photo_lat = 0 AND photo_lng = 0").
+		Where("taken_src <> ? AND place_src <> ?", entity.SrcAuto, entity.SrcManual).
+		Where("taken_at BETWEEN ? AND ?", from, to).
+		Order("taken_at, photos.id").Limit(limit).Offset(offset).Find(&entities).Error
+
+	return entities, err
+}
+
+// PhotosWithoutAltitude returns photos with GPS coordinates but no altitude, sorted by S2 cell,
+// so that pictures taken close to each other are processed together.
+func PhotosWithoutAltitude(limit, offset int) (entities entity.Photos, err error) {
+	err = Db().
+		Where("photo_altitude = 0").
+		Where("photo_lat <> 0 OR photo_lng <> 0").
+		Order("cell_id, photos.id").Limit(limit).Offset(offset).Find(&entities).Error
+
+	return entities, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
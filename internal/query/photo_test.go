@@ -78,6 +78,34 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosWithoutLocation(t *testing.T) {
+	result, err := PhotosWithoutLocation(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), time.Now(), 100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range result {
+		assert.False(t, p.HasLatLng())
+		assert.NotEqual(t, entity.SrcAuto, p.TakenSrc)
+	}
+}
+
+func TestPhotosWithoutAltitude(t *testing.T) {
+	result, err := PhotosWithoutAltitude(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(result), 1)
+
+	for _, p := range result {
+		assert.True(t, p.HasLatLng())
+		assert.Equal(t, 0, p.PhotoAltitude)
+	}
+}
+
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
 
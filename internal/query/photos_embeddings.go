@@ -0,0 +1,58 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+// MarkerEmbedding represents the face embedding of a marker and the photo it belongs to.
+type MarkerEmbedding struct {
+	PhotoUID        string
+	MarkerUID       string
+	EmbeddingsModel string
+	EmbeddingsJSON  string
+}
+
+// Embedding returns the first face embedding of the marker.
+func (m MarkerEmbedding) Embedding() face.Embedding {
+	if m.EmbeddingsJSON == "" {
+		return face.Embedding{}
+	} else if embeddings, err := face.UnmarshalEmbeddings(m.EmbeddingsJSON); err != nil {
+		log.Warnf("faces: %s", err)
+		return face.Embedding{}
+	} else {
+		return embeddings.First()
+	}
+}
+
+// PhotoEmbeddings returns stored photo embeddings computed with the specified model, or all models if empty.
+func PhotoEmbeddings(model string, limit, offset int) (result entity.PhotoEmbeddings, err error) {
+	stmt := UnscopedDb().
+		Where("photo_uid IN (SELECT photo_uid FROM photos WHERE deleted_at IS NULL)")
+
+	if model = strings.ToLower(strings.TrimSpace(model)); model != "" {
+		stmt = stmt.Where("model = ?", model)
+	}
+
+	err = stmt.Order("photo_uid, model").Limit(limit).Offset(offset).Find(&result).Error
+
+	return result, err
+}
+
+// MarkerEmbeddingsByModel returns the face embeddings of valid markers computed with the specified model.
+func MarkerEmbeddingsByModel(model string, limit, offset int) (result []MarkerEmbedding, err error) {
+	err = UnscopedDb().Table(entity.Marker{}.TableName()).
+		Select("files.photo_uid, markers.marker_uid, markers.embeddings_model, markers.embeddings_json").
+		Joins("JOIN files ON files.file_uid = markers.file_uid").
+		Where("markers.marker_type = ?", entity.MarkerFace).
+		Where("markers.marker_invalid = 0").
+		Where("markers.embeddings_json <> ''").
+		Where("markers.embeddings_model = ?", strings.ToLower(strings.TrimSpace(model))).
+		Order("markers.marker_uid").
+		Limit(limit).Offset(offset).
+		Scan(&result).Error
+
+	return result, err
+}
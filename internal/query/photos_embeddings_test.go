@@ -0,0 +1,65 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+func TestPhotoEmbeddings(t *testing.T) {
+	m := entity.NewPhotoEmbedding("pt9jtdre2lvl0yh8", "query-test", []float64{0.1, 0.2})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Model", func(t *testing.T) {
+		results, err := PhotoEmbeddings("Query-Test", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", results[0].PhotoUID)
+		assert.Equal(t, []float64{0.1, 0.2}, results[0].Embedding())
+	})
+	t.Run("All", func(t *testing.T) {
+		results, err := PhotoEmbeddings("", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+	})
+}
+
+func TestMarkerEmbeddingsByModel(t *testing.T) {
+	t.Run("FaceNet", func(t *testing.T) {
+		results, err := MarkerEmbeddingsByModel(face.FaceNet.Name, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.PhotoUID)
+			assert.NotEmpty(t, r.Embedding())
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		results, err := MarkerEmbeddingsByModel("unknown", 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+}
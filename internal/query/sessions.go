@@ -15,7 +15,7 @@ func Session(id string) (result entity.Session, err error) {
 	} else if rnd.IsRefID(id) {
 		err = Db().Where("ref_id = ?", id).First(&result).Error
 	} else {
-		err = Db().Where("id LIKE ?", id).First(&result).Error
+		err = Db().Where(fmt.Sprintf("id %s ?", entity.LikeOp()), id).First(&result).Error
 	}
 
 	return result, err
@@ -35,7 +35,7 @@ func Sessions(limit, offset int, sortOrder, search string) (result entity.Sessio
 	} else if rnd.IsUID(search, entity.UserUID) {
 		stmt = stmt.Where("user_uid = ?", search)
 	} else if search != "" {
-		stmt = stmt.Where("user_name LIKE ? OR auth_provider LIKE ?", search+"%", search+"%")
+		stmt = stmt.Where(fmt.Sprintf("user_name %[1]s ? OR auth_provider %[1]s ?", entity.LikeOp()), search+"%", search+"%")
 	}
 
 	if sortOrder == "" {
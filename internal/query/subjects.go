@@ -12,7 +12,7 @@ func People() (people entity.People, err error) {
 	err = UnscopedDb().
 		Table(entity.Subject{}.TableName()).
 		Select("subj_uid, subj_name, subj_alias, subj_favorite, subj_hidden").
-		Where("deleted_at IS NULL AND subj_type = ?", entity.SubjPerson).
+		Where("deleted_at IS NULL AND subj_ignored = 0 AND subj_type = ?", entity.SubjPerson).
 		Order("subj_name").
 		Limit(2000).Offset(0).
 		Scan(&people).Error
@@ -25,7 +25,7 @@ func PeopleCount() (count int, err error) {
 	err = Db().
 		Table(entity.Subject{}.TableName()).
 		Where("deleted_at IS NULL").
-		Where("subj_hidden = 0").
+		Where("subj_hidden = 0 AND subj_ignored = 0").
 		Where("subj_type = ?", entity.SubjPerson).
 		Count(&count).Error
 
@@ -78,7 +78,7 @@ func CreateMarkerSubjects() (affected int64, err error) {
 
 	if err := Db().
 		Where("subj_uid = '' AND marker_name <> '' AND subj_src <> ?", entity.SrcAuto).
-		Where("marker_invalid = 0 AND marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0 AND marker_type IN (?)", []string{entity.MarkerFace, entity.MarkerPet}).
 		Order("marker_name").
 		Find(&markers).Error; err != nil {
 		return affected, err
@@ -92,7 +92,7 @@ func CreateMarkerSubjects() (affected int64, err error) {
 	for _, m := range markers {
 		if name == m.MarkerName && subj != nil {
 			// Do nothing.
-		} else if subj = entity.NewSubject(m.MarkerName, entity.SubjPerson, entity.SrcMarker); subj == nil {
+		} else if subj = entity.NewSubject(m.MarkerName, m.SubjType(), entity.SrcMarker); subj == nil {
 			log.Errorf("faces: invalid subject %s", clean.Log(m.MarkerName))
 			continue
 		} else if subj = entity.FirstOrCreateSubject(subj); subj == nil {
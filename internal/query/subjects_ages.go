@@ -0,0 +1,45 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// SubjectAge represents the number of pictures showing a subject at a certain age.
+type SubjectAge struct {
+	Age        int    `json:"Age"`
+	PhotoCount int    `json:"PhotoCount"`
+	PhotoUID   string `json:"PhotoUID"`
+}
+
+// SubjectAges represents the pictures of a subject grouped by age.
+type SubjectAges []SubjectAge
+
+// SubjectAgeGroups returns the number of pictures per age for the subject with the specified UID,
+// provided that the date of birth is known.
+func SubjectAgeGroups(subjUID string) (result SubjectAges, err error) {
+	result = SubjectAges{}
+
+	if subjUID == "" {
+		return result, fmt.Errorf("subject uid is empty")
+	}
+
+	age := search.AgeSql("p", "s")
+
+	err = UnscopedDb().
+		Table(fmt.Sprintf("%s m", entity.Marker{}.TableName())).
+		Select(fmt.Sprintf("%s AS age, COUNT(DISTINCT p.id) AS photo_count, MIN(p.photo_uid) AS photo_uid", age)).
+		Joins(fmt.Sprintf("JOIN %s s ON s.subj_uid = m.subj_uid", entity.Subject{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s f ON f.file_uid = m.file_uid", entity.File{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s p ON p.id = f.photo_id AND p.deleted_at IS NULL", entity.Photo{}.TableName())).
+		Where("m.subj_uid = ? AND m.marker_invalid = 0", subjUID).
+		Where("s.subj_birth_year > 0 AND p.photo_year > 0").
+		Where(fmt.Sprintf("%s >= 0", age)).
+		Group(age).
+		Order("age").
+		Scan(&result).Error
+
+	return result, err
+}
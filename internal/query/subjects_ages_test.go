@@ -0,0 +1,24 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubjectAgeGroups(t *testing.T) {
+	t.Run("NoBirthday", func(t *testing.T) {
+		results, err := SubjectAgeGroups("jqy1y111h1njaaac")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		_, err := SubjectAgeGroups("")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SubjectPair represents two people appearing together and the number of pictures they share.
+type SubjectPair struct {
+	SubjUID    string `json:"SubjUID"`
+	SubjName   string `json:"SubjName"`
+	OtherUID   string `json:"OtherUID"`
+	OtherName  string `json:"OtherName"`
+	PhotoCount int    `json:"PhotoCount"`
+}
+
+// SubjectPairs represents a list of people appearing together.
+type SubjectPairs []SubjectPair
+
+// PeoplePairs returns people who appear together in at least the specified number of pictures, sorted by count.
+// If a subject UID is passed, only pairs including this subject are returned.
+func PeoplePairs(subjUID string, minCount, limit, offset int) (result SubjectPairs, err error) {
+	result = SubjectPairs{}
+
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	stmt := UnscopedDb().
+		Table(fmt.Sprintf("%s a", entity.Marker{}.TableName())).
+		Select("a.subj_uid, sa.subj_name, b.subj_uid AS other_uid, sb.subj_name AS other_name, COUNT(DISTINCT f.photo_id) AS photo_count").
+		Joins(fmt.Sprintf("JOIN %s b ON b.file_uid = a.file_uid AND b.subj_uid > a.subj_uid AND b.marker_invalid = 0", entity.Marker{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s f ON f.file_uid = a.file_uid", entity.File{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s p ON p.id = f.photo_id AND p.deleted_at IS NULL", entity.Photo{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s sa ON sa.subj_uid = a.subj_uid", entity.Subject{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s sb ON sb.subj_uid = b.subj_uid", entity.Subject{}.TableName())).
+		Where("a.marker_invalid = 0 AND a.subj_uid <> ''").
+		Where("sa.subj_type = ? AND sb.subj_type = ?", entity.SubjPerson, entity.SubjPerson).
+		Where("sa.deleted_at IS NULL AND sa.subj_hidden = 0 AND sa.subj_ignored = 0").
+		Where("sb.deleted_at IS NULL AND sb.subj_hidden = 0 AND sb.subj_ignored = 0")
+
+	if subjUID != "" {
+		stmt = stmt.Where("a.subj_uid = ? OR b.subj_uid = ?", subjUID, subjUID)
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit).Offset(offset)
+	}
+
+	err = stmt.Group("a.subj_uid, sa.subj_name, b.subj_uid, sb.subj_name").
+		Having("COUNT(DISTINCT f.photo_id) >= ?", minCount).
+		Order("photo_count DESC, sa.subj_name, sb.subj_name").
+		Scan(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeoplePairs(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		results, err := PeoplePairs("", 1, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.SubjUID)
+			assert.NotEmpty(t, r.OtherUID)
+			assert.Less(t, r.SubjUID, r.OtherUID)
+			assert.GreaterOrEqual(t, r.PhotoCount, 1)
+		}
+	})
+	t.Run("Subject", func(t *testing.T) {
+		results, err := PeoplePairs("jqy1y111h1njaaac", 1, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.True(t, r.SubjUID == "jqy1y111h1njaaac" || r.OtherUID == "jqy1y111h1njaaac")
+		}
+	})
+	t.Run("MinCount", func(t *testing.T) {
+		results, err := PeoplePairs("", 100000, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
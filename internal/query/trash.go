@@ -0,0 +1,46 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// TrashUsage represents the number and size of archived pictures owned by a user.
+type TrashUsage struct {
+	UserUID  string `json:"UserUID"`
+	UserName string `json:"UserName"`
+	Photos   int    `json:"Photos"`
+	Files    int    `json:"Files"`
+	Size     int64  `json:"Size"`
+}
+
+// ExpiredPhotos returns pictures that have been archived before the specified time, starting after the specified id.
+func ExpiredPhotos(before time.Time, afterID uint, limit int) (photos entity.Photos, err error) {
+	err = UnscopedDb().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Where("id > ?", afterID).
+		Order("id").Limit(limit).
+		Find(&photos).Error
+
+	return photos, err
+}
+
+// UsersTrashUsage returns the number and size of archived pictures by owner, optionally limited to a single user.
+func UsersTrashUsage(userUID string) (result []TrashUsage, err error) {
+	stmt := UnscopedDb().
+		Table("photos").
+		Select("photos.created_by AS user_uid, COALESCE(MAX(u.user_name), '') AS user_name, " +
+			"COUNT(DISTINCT photos.id) AS photos, COUNT(f.id) AS files, COALESCE(SUM(f.file_size), 0) AS size").
+		Joins("LEFT JOIN files f ON f.photo_id = photos.id").
+		Joins("LEFT JOIN auth_users u ON u.user_uid = photos.created_by").
+		Where("photos.deleted_at IS NOT NULL")
+
+	if userUID != "" {
+		stmt = stmt.Where("photos.created_by = ?", userUID)
+	}
+
+	err = stmt.Group("photos.created_by").Order("size DESC, user_uid").Scan(&result).Error
+
+	return result, err
+}
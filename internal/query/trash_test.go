@@ -0,0 +1,54 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiredPhotos(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		photos, err := ExpiredPhotos(time.Now(), 0, 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.NotNil(t, p.DeletedAt)
+		}
+	})
+	t.Run("NotExpired", func(t *testing.T) {
+		photos, err := ExpiredPhotos(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 0, 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+}
+
+func TestUsersTrashUsage(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, err := UsersTrashUsage("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(result), 1)
+	})
+	t.Run("UnknownUser", func(t *testing.T) {
+		result, err := UsersTrashUsage("uqxc08w3d0ej2283")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+}
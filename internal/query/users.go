@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -31,7 +32,7 @@ func Users(limit, offset int, sortOrder, search string) (result entity.Users, er
 	} else if rnd.IsUID(search, entity.UserUID) {
 		stmt = stmt.Where("user_uid = ?", search)
 	} else if search != "" {
-		stmt = stmt.Where("user_name LIKE ? OR user_email LIKE ? OR display_name LIKE ?", search+"%", search+"%", search+"%")
+		stmt = stmt.Where(fmt.Sprintf("user_name %[1]s ? OR user_email %[1]s ? OR display_name %[1]s ?", entity.LikeOp()), search+"%", search+"%", search+"%")
 	} else {
 		stmt = stmt.Where("id > 0")
 	}
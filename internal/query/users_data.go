@@ -0,0 +1,90 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// UserPhotos returns the pictures added by a user, including archived pictures.
+func UserPhotos(userUid string) (results entity.Photos, err error) {
+	if userUid == "" {
+		return results, nil
+	}
+
+	err = UnscopedDb().
+		Where("created_by = ?", userUid).
+		Order("photo_path, photo_name, id").
+		Find(&results).Error
+
+	return results, err
+}
+
+// UserFiles returns the files of the pictures added by a user, including pictures in the archive.
+func UserFiles(userUid string) (results entity.Files, err error) {
+	if userUid == "" {
+		return results, nil
+	}
+
+	err = UnscopedDb().Table(entity.File{}.TableName()).Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Where("photos.created_by = ? AND files.deleted_at IS NULL", userUid).
+		Order("files.file_root, files.file_name, files.id").
+		Find(&results).Error
+
+	return results, err
+}
+
+// UserAlbums returns the albums created by a user.
+func UserAlbums(userUid string) (results entity.Albums, err error) {
+	if userUid == "" {
+		return results, nil
+	}
+
+	err = Db().
+		Where("created_by = ?", userUid).
+		Order("album_type, album_uid").
+		Find(&results).Error
+
+	return results, err
+}
+
+// UserLinks returns the share links created by a user.
+func UserLinks(userUid string) (results entity.Links, err error) {
+	if userUid == "" {
+		return results, nil
+	}
+
+	err = Db().
+		Where("created_by = ?", userUid).
+		Order("created_at, link_uid").
+		Find(&results).Error
+
+	return results, err
+}
+
+// UserComments returns the comments written by a user, with the oldest comments first.
+func UserComments(userUid string) (results entity.Comments, err error) {
+	if userUid == "" {
+		return results, nil
+	}
+
+	err = Db().
+		Where("user_uid = ?", userUid).
+		Order("created_at, comment_uid").
+		Find(&results).Error
+
+	return results, err
+}
+
+// UserReactions returns the reactions of a user to albums and pictures.
+func UserReactions(userUid string) (results entity.Reactions, err error) {
+	if userUid == "" {
+		return results, nil
+	}
+
+	err = Db().
+		Where("user_uid = ?", userUid).
+		Order("reacted_at").
+		Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestUserPhotos(t *testing.T) {
+	t.Run("Alice", func(t *testing.T) {
+		results, err := UserPhotos(entity.UserFixtures.Get("alice").UserUID)
+
+		assert.NoError(t, err)
+
+		for _, r := range results {
+			assert.Equal(t, entity.UserFixtures.Get("alice").UserUID, r.CreatedBy)
+		}
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		results, err := UserPhotos("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestUserFiles(t *testing.T) {
+	t.Run("Alice", func(t *testing.T) {
+		_, err := UserFiles(entity.UserFixtures.Get("alice").UserUID)
+		assert.NoError(t, err)
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		results, err := UserFiles("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestUserAlbums(t *testing.T) {
+	t.Run("Alice", func(t *testing.T) {
+		_, err := UserAlbums(entity.UserFixtures.Get("alice").UserUID)
+		assert.NoError(t, err)
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		results, err := UserAlbums("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestUserLinks(t *testing.T) {
+	t.Run("EmptyUID", func(t *testing.T) {
+		results, err := UserLinks("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestUserComments(t *testing.T) {
+	c := entity.NewComment("aqzih0kn0idqqx7y", "", "Exported comment")
+	c.UserUID = entity.UserFixtures.Get("bob").UserUID
+
+	if err := c.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Delete()
+
+	results, err := UserComments(entity.UserFixtures.Get("bob").UserUID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results)
+
+	for _, r := range results {
+		assert.Equal(t, entity.UserFixtures.Get("bob").UserUID, r.UserUID)
+	}
+}
+
+func TestUserReactions(t *testing.T) {
+	results, err := UserReactions(entity.UserFixtures.Get("alice").UserUID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results)
+
+	for _, r := range results {
+		assert.Equal(t, entity.UserFixtures.Get("alice").UserUID, r.UserUID)
+	}
+}
@@ -46,6 +46,17 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		u.User = url.UserPassword(result.AccUser, result.AccPass)
 	}
 
+	// SFTP servers and SMB/CIFS network shares cannot be discovered over HTTP.
+	if u.Scheme == ServiceSFTP || u.Scheme == ServiceSMB {
+		u.User = nil
+
+		result.AccName = u.Hostname()
+		result.AccType = u.Scheme
+		result.AccURL = u.String()
+
+		return result, nil
+	}
+
 	// Set default scheme
 	if u.Scheme == "" {
 		u.Scheme = "https"
@@ -0,0 +1,348 @@
+package gphotos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client represents a Google Photos Library API client.
+type Client struct {
+	clientId     string
+	clientSecret string
+	refreshToken string
+	accessToken  string
+	expires      time.Time
+	http         *http.Client
+	once         sync.Once
+	favorites    map[string]bool
+	albums       map[string][]string
+	mutex        sync.Mutex
+}
+
+// apiError represents an error response of the Library API.
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// NewClient returns a new client for the account with the specified OAuth refresh token.
+func NewClient(clientId, clientSecret, refreshToken string, timeout time.Duration) (*Client, error) {
+	if clientId == "" || clientSecret == "" {
+		return nil, fmt.Errorf("gphotos: client id and secret are required")
+	} else if refreshToken == "" {
+		return nil, fmt.Errorf("gphotos: account is not authorized")
+	}
+
+	c := &Client{
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		refreshToken: refreshToken,
+		http:         &http.Client{Timeout: timeout},
+	}
+
+	if err := c.authorize(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// authorize fetches a new access token if needed.
+func (c *Client) authorize() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expires) {
+		return nil
+	}
+
+	t, err := Refresh(c.clientId, c.clientSecret, c.refreshToken)
+
+	if err != nil {
+		return err
+	}
+
+	c.accessToken = t.AccessToken
+	c.expires = t.Expires()
+
+	return nil
+}
+
+// request sends an API request and decodes the JSON response into result.
+func (c *Client) request(method, endpoint string, query url.Values, body, result interface{}) error {
+	if err := c.authorize(); err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+
+	if body != nil {
+		if data, err := json.Marshal(body); err != nil {
+			return err
+		} else {
+			reqBody = bytes.NewReader(data)
+		}
+	}
+
+	reqUrl := ApiEndpoint + endpoint
+
+	if len(query) > 0 {
+		reqUrl += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, reqUrl, reqBody)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e apiError
+
+		if err = json.NewDecoder(resp.Body).Decode(&e); err == nil && e.Error.Message != "" {
+			return fmt.Errorf("gphotos: %s", e.Error.Message)
+		}
+
+		return fmt.Errorf("gphotos: request failed with status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// mediaItemsPage represents a page of media items.
+type mediaItemsPage struct {
+	MediaItems    []MediaItem `json:"mediaItems"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+// MediaItems returns all media items in the library.
+func (c *Client) MediaItems() (result []MediaItem, err error) {
+	query := url.Values{"pageSize": {fmt.Sprintf("%d", PageSize)}}
+
+	for {
+		var page mediaItemsPage
+
+		if err = c.request(http.MethodGet, "/mediaItems", query, nil, &page); err != nil {
+			return result, err
+		}
+
+		result = append(result, page.MediaItems...)
+
+		if page.NextPageToken == "" {
+			return result, nil
+		}
+
+		query.Set("pageToken", page.NextPageToken)
+	}
+}
+
+// MediaItem returns the media item with the specified ID, including a new base URL for downloads.
+func (c *Client) MediaItem(id string) (result MediaItem, err error) {
+	err = c.request(http.MethodGet, "/mediaItems/"+url.PathEscape(id), nil, nil, &result)
+
+	return result, err
+}
+
+// search returns the IDs of all media items that match the search filter.
+func (c *Client) search(filter map[string]interface{}) (result []string, err error) {
+	filter["pageSize"] = PageSize
+
+	for {
+		var page mediaItemsPage
+
+		if err = c.request(http.MethodPost, "/mediaItems:search", nil, filter, &page); err != nil {
+			return result, err
+		}
+
+		for _, m := range page.MediaItems {
+			result = append(result, m.ID)
+		}
+
+		if page.NextPageToken == "" {
+			return result, nil
+		}
+
+		filter["pageToken"] = page.NextPageToken
+	}
+}
+
+// albumsPage represents a page of albums.
+type albumsPage struct {
+	Albums        []Album `json:"albums"`
+	NextPageToken string  `json:"nextPageToken"`
+}
+
+// Albums returns all albums in the library.
+func (c *Client) Albums() (result []Album, err error) {
+	query := url.Values{"pageSize": {"50"}}
+
+	for {
+		var page albumsPage
+
+		if err = c.request(http.MethodGet, "/albums", query, nil, &page); err != nil {
+			return result, err
+		}
+
+		result = append(result, page.Albums...)
+
+		if page.NextPageToken == "" {
+			return result, nil
+		}
+
+		query.Set("pageToken", page.NextPageToken)
+	}
+}
+
+// Favorites returns the IDs of all media items that are marked as favorites.
+func (c *Client) Favorites() ([]string, error) {
+	return c.search(map[string]interface{}{
+		"filters": map[string]interface{}{
+			"featureFilter": map[string]interface{}{"includedFeatures": []string{"FAVORITES"}},
+		},
+	})
+}
+
+// AlbumItems returns the IDs of all media items in an album.
+func (c *Client) AlbumItems(albumId string) ([]string, error) {
+	return c.search(map[string]interface{}{"albumId": albumId})
+}
+
+// loadMetadata loads favorites and album membership once, so that they can be added to sidecar files.
+func (c *Client) loadMetadata() {
+	c.once.Do(func() {
+		c.favorites = make(map[string]bool)
+		c.albums = make(map[string][]string)
+
+		if ids, err := c.Favorites(); err != nil {
+			log.Warnf("gphotos: %s (find favorites)", err)
+		} else {
+			for _, id := range ids {
+				c.favorites[id] = true
+			}
+		}
+
+		albums, err := c.Albums()
+
+		if err != nil {
+			log.Warnf("gphotos: %s (find albums)", err)
+			return
+		}
+
+		for _, a := range albums {
+			if ids, err := c.AlbumItems(a.ID); err != nil {
+				log.Warnf("gphotos: %s (find items in album %s)", err, clean.Log(a.Title))
+			} else {
+				for _, id := range ids {
+					c.albums[id] = append(c.albums[id], a.Title)
+				}
+			}
+		}
+	})
+}
+
+// Files returns all media items in the library. The directory is ignored, as the library has no folders.
+func (c *Client) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	items, err := c.MediaItems()
+
+	for _, m := range items {
+		if m.ID != "" {
+			result = append(result, m.FileInfo())
+		}
+	}
+
+	return result, err
+}
+
+// Directories returns an empty list, as the library has no folders.
+func (c *Client) Directories(dir string, recursive bool, timeout time.Duration) (fs.FileInfos, error) {
+	return fs.FileInfos{}, nil
+}
+
+// Download downloads the original file of a media item and creates a JSON sidecar file with its metadata,
+// including the description, favorite flag, and album titles.
+func (c *Client) Download(src, dest string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("gphotos: download skipped, %s already exists", clean.Log(dest))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), fs.ModeDir); err != nil {
+		return fmt.Errorf("gphotos: cannot create folder %s (%s)", clean.Log(filepath.Dir(dest)), err)
+	}
+
+	// Base URLs expire after 60 minutes, so the media item is fetched again.
+	m, err := c.MediaItem(MediaItemID(src))
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Get(m.DownloadUrl())
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gphotos: download failed with status %d", resp.StatusCode)
+	}
+
+	// Download to a temporary file first, so that incomplete files are never indexed.
+	tmp := dest + ".download"
+
+	f, err := os.OpenFile(tmp, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		return fmt.Errorf("gphotos: failed to create %s", clean.Log(filepath.Base(dest)))
+	}
+
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	} else if err = f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	// Create sidecar file with metadata, see meta.GPhoto.
+	c.loadMetadata()
+
+	if data, err := json.MarshalIndent(NewSidecar(m, c.favorites[m.ID], c.albums[m.ID]), "", "  "); err != nil {
+		log.Warnf("gphotos: %s (create sidecar)", err)
+	} else if err = os.WriteFile(dest+".json", data, fs.ModeFile); err != nil {
+		log.Warnf("gphotos: %s (create sidecar)", err)
+	}
+
+	return os.Rename(tmp, dest)
+}
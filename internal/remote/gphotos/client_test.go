@@ -0,0 +1,209 @@
+package gphotos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer starts a fake OAuth and Library API server with two pages of media items.
+func newTestServer(t *testing.T) *httptest.Server {
+	var s *httptest.Server
+
+	item := func(id, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":            id,
+			"filename":      name,
+			"description":   "Description of " + id,
+			"productUrl":    "https://photos.google.com/lr/photo/" + id,
+			"baseUrl":       s.URL + "/media/" + id,
+			"mimeType":      "image/jpeg",
+			"mediaMetadata": map[string]interface{}{"creationTime": "2020-06-13T17:30:00Z"},
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		if r.Form.Get("refresh_token") != "refresh" && r.Form.Get("code") != "code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "Bad Request"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token": "access", "refresh_token": "refresh", "expires_in": 3599}`))
+	})
+
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"code": 401, "message": "Invalid credentials"}}`))
+			return
+		}
+
+		var result interface{}
+
+		switch r.URL.Path {
+		case "/v1/mediaItems":
+			if r.URL.Query().Get("pageToken") == "" {
+				result = map[string]interface{}{"mediaItems": []interface{}{item("a1", "IMG_0001.jpg")}, "nextPageToken": "next"}
+			} else {
+				result = map[string]interface{}{"mediaItems": []interface{}{item("b2", "IMG_0002.jpg")}}
+			}
+		case "/v1/mediaItems/a1":
+			result = item("a1", "IMG_0001.jpg")
+		case "/v1/mediaItems:search":
+			var filter map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&filter)
+
+			if filter["albumId"] == "holiday" || filter["filters"] != nil {
+				result = map[string]interface{}{"mediaItems": []interface{}{item("a1", "IMG_0001.jpg")}}
+			} else {
+				result = map[string]interface{}{}
+			}
+		case "/v1/albums":
+			result = map[string]interface{}{"albums": []interface{}{
+				map[string]string{"id": "holiday", "title": "Holiday"},
+				map[string]string{"id": "empty", "title": "Empty"},
+			}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(result)
+	})
+
+	mux.HandleFunc("/media/a1=d", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("jpeg"))
+	})
+
+	s = httptest.NewServer(mux)
+
+	prevToken, prevApi := TokenEndpoint, ApiEndpoint
+	TokenEndpoint, ApiEndpoint = s.URL+"/token", s.URL+"/v1"
+
+	t.Cleanup(func() {
+		TokenEndpoint, ApiEndpoint = prevToken, prevApi
+		s.Close()
+	})
+
+	return s
+}
+
+func TestAuthURL(t *testing.T) {
+	u, err := url.Parse(AuthURL("id", "https://demo.photoprism.app/api/v1/oauth/gphotos", "state"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasPrefix(u.String(), AuthEndpoint))
+	assert.Equal(t, "id", u.Query().Get("client_id"))
+	assert.Equal(t, "offline", u.Query().Get("access_type"))
+	assert.Equal(t, Scope, u.Query().Get("scope"))
+	assert.Equal(t, "state", u.Query().Get("state"))
+}
+
+func TestExchange(t *testing.T) {
+	newTestServer(t)
+
+	token, err := Exchange("id", "secret", "https://localhost/", "code")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.True(t, token.Expires().After(time.Now()))
+
+	_, err = Exchange("id", "secret", "https://localhost/", "invalid")
+
+	assert.EqualError(t, err, "gphotos: invalid_grant Bad Request")
+}
+
+func TestNewClient(t *testing.T) {
+	newTestServer(t)
+
+	_, err := NewClient("", "", "refresh", time.Second)
+	assert.Error(t, err)
+
+	_, err = NewClient("id", "secret", "", time.Second)
+	assert.Error(t, err)
+
+	_, err = NewClient("id", "secret", "invalid", time.Second)
+	assert.Error(t, err)
+
+	c, err := NewClient("id", "secret", "refresh", time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access", c.accessToken)
+}
+
+func TestClient(t *testing.T) {
+	newTestServer(t)
+
+	c, err := NewClient("id", "secret", "refresh", time.Second)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Files", func(t *testing.T) {
+		files, err := c.Files("/", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/a1/IMG_0001.jpg", "/b2/IMG_0002.jpg"}, files.Abs())
+		assert.Equal(t, time.Date(2020, 6, 13, 17, 30, 0, 0, time.UTC), files[0].Date)
+
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Len(t, dirs, 0)
+	})
+	t.Run("Download", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "a1", "IMG_0001.jpg")
+
+		assert.NoError(t, c.Download("/a1/IMG_0001.jpg", dest, false))
+
+		data, err := os.ReadFile(dest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", string(data))
+
+		data, err = os.ReadFile(dest + ".json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var sidecar Sidecar
+
+		assert.NoError(t, json.Unmarshal(data, &sidecar))
+		assert.Equal(t, "Description of a1", sidecar.Description)
+		assert.Equal(t, "1592069400", sidecar.TakenAt.Timestamp)
+		assert.True(t, sidecar.Favorited)
+		assert.Equal(t, []string{"Holiday"}, sidecar.Albums)
+
+		assert.Error(t, c.Download("/a1/IMG_0001.jpg", dest, false))
+		assert.Error(t, c.Download("/missing/IMG_0003.jpg", dest, true))
+	})
+}
+
+func TestMediaItem(t *testing.T) {
+	m := MediaItem{ID: "x9", Filename: "../VID_0001.mp4", MimeType: "video/mp4", BaseUrl: "https://lh3.googleusercontent.com/x9"}
+
+	assert.True(t, m.Video())
+	assert.Equal(t, "https://lh3.googleusercontent.com/x9=dv", m.DownloadUrl())
+	assert.Equal(t, "VID_0001.mp4", m.FileName())
+	assert.Equal(t, "/x9/VID_0001.mp4", m.RemoteName())
+	assert.Equal(t, "x9", MediaItemID(m.RemoteName()))
+	assert.Equal(t, "x9", MediaItem{ID: "x9", Filename: ".hidden"}.FileName())
+}
@@ -0,0 +1,45 @@
+/*
+Package gphotos provides downloads from Google Photos using the Library API.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package gphotos
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// Global log instance.
+var log = event.Log
+
+// Endpoints of the Google OAuth 2.0 and Photos Library APIs, see https://developers.google.com/photos/library/guides/overview.
+var (
+	AuthEndpoint  = "https://accounts.google.com/o/oauth2/v2/auth"
+	TokenEndpoint = "https://oauth2.googleapis.com/token"
+	ApiEndpoint   = "https://photoslibrary.googleapis.com/v1"
+)
+
+// Scope is the OAuth scope for read-only access to the photo library.
+const Scope = "https://www.googleapis.com/auth/photoslibrary.readonly"
+
+// PageSize is the maximum number of results per request.
+const PageSize = 100
@@ -0,0 +1,118 @@
+package gphotos
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MediaItem represents a photo or video in the library.
+type MediaItem struct {
+	ID          string        `json:"id"`
+	Description string        `json:"description"`
+	ProductUrl  string        `json:"productUrl"`
+	BaseUrl     string        `json:"baseUrl"`
+	MimeType    string        `json:"mimeType"`
+	Filename    string        `json:"filename"`
+	Metadata    MediaMetadata `json:"mediaMetadata"`
+}
+
+// MediaMetadata represents the metadata of a media item.
+type MediaMetadata struct {
+	CreationTime time.Time `json:"creationTime"`
+	Width        string    `json:"width"`
+	Height       string    `json:"height"`
+	Video        *struct{} `json:"video,omitempty"`
+}
+
+// Video checks if the media item is a video.
+func (m MediaItem) Video() bool {
+	return m.Metadata.Video != nil || strings.HasPrefix(m.MimeType, "video/")
+}
+
+// DownloadUrl returns the URL for downloading the original file, see
+// https://developers.google.com/photos/library/guides/access-media-items#base-urls.
+func (m MediaItem) DownloadUrl() string {
+	if m.Video() {
+		return m.BaseUrl + "=dv"
+	}
+
+	return m.BaseUrl + "=d"
+}
+
+// FileName returns a safe file name for the media item.
+func (m MediaItem) FileName() string {
+	if name := path.Base("/" + strings.ReplaceAll(m.Filename, "\\", "/")); name != "/" && name != "." && !strings.HasPrefix(name, ".") {
+		return name
+	}
+
+	return m.ID
+}
+
+// RemoteName returns the unique remote file name, consisting of the media item ID and the file name.
+func (m MediaItem) RemoteName() string {
+	return "/" + m.ID + "/" + m.FileName()
+}
+
+// FileInfo returns the remote file info of the media item. The file size is unknown.
+func (m MediaItem) FileInfo() fs.FileInfo {
+	return fs.FileInfo{
+		Name: m.FileName(),
+		Abs:  m.RemoteName(),
+		Date: m.Metadata.CreationTime.UTC(),
+	}
+}
+
+// MediaItemID returns the media item ID of a remote file name.
+func MediaItemID(remoteName string) string {
+	parts := strings.Split(strings.Trim(remoteName, "/"), "/")
+
+	return parts[0]
+}
+
+// Album represents an album in the library.
+type Album struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Count string `json:"mediaItemsCount"`
+}
+
+// Sidecar represents a JSON sidecar file in the format of Google Takeout archives,
+// extended with the titles of the albums that contain the media item.
+type Sidecar struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	TakenAt     *SidecarTime `json:"photoTakenTime,omitempty"`
+	Favorited   bool         `json:"favorited,omitempty"`
+	Albums      []string     `json:"albums,omitempty"`
+	Url         string       `json:"url,omitempty"`
+}
+
+// SidecarTime represents a timestamp in a sidecar file.
+type SidecarTime struct {
+	Timestamp string `json:"timestamp"`
+	Formatted string `json:"formatted"`
+}
+
+// NewSidecar returns the sidecar data of a media item.
+func NewSidecar(m MediaItem, favorite bool, albums []string) Sidecar {
+	result := Sidecar{
+		Title:       m.Filename,
+		Description: m.Description,
+		Favorited:   favorite,
+		Albums:      albums,
+		Url:         m.ProductUrl,
+	}
+
+	if t := m.Metadata.CreationTime; !t.IsZero() {
+		result.TakenAt = &SidecarTime{
+			Timestamp: strconv.FormatInt(t.Unix(), 10),
+			Formatted: t.UTC().Format("Jan 2, 2006, 3:04:05 PM UTC"),
+		}
+	}
+
+	return result
+}
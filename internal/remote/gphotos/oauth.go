@@ -0,0 +1,86 @@
+package gphotos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Token represents an OAuth 2.0 token response.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// Expires returns the time when the access token expires, with a safety margin of one minute.
+func (t Token) Expires() time.Time {
+	return time.Now().Add(time.Duration(t.ExpiresIn)*time.Second - time.Minute)
+}
+
+// AuthURL returns the consent page URL to which the user is redirected to authorize access.
+func AuthURL(clientId, redirectUrl, state string) string {
+	q := url.Values{
+		"client_id":     {clientId},
+		"redirect_uri":  {redirectUrl},
+		"response_type": {"code"},
+		"scope":         {Scope},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+		"state":         {state},
+	}
+
+	return AuthEndpoint + "?" + q.Encode()
+}
+
+// Exchange returns a new token for the authorization code that was passed to the redirect URL.
+func Exchange(clientId, clientSecret, redirectUrl, code string) (Token, error) {
+	return requestToken(url.Values{
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+		"redirect_uri":  {redirectUrl},
+		"code":          {code},
+		"grant_type":    {"authorization_code"},
+	})
+}
+
+// Refresh returns a new access token for the refresh token.
+func Refresh(clientId, clientSecret, refreshToken string) (Token, error) {
+	return requestToken(url.Values{
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+		"refresh_token": {refreshToken},
+		"grant_type":    {"refresh_token"},
+	})
+}
+
+// requestToken sends a token request and returns the response.
+func requestToken(data url.Values) (t Token, err error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	resp, err := client.Post(TokenEndpoint, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+
+	if err != nil {
+		return t, err
+	}
+
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(resp.Body).Decode(&t); err != nil && resp.StatusCode == http.StatusOK {
+		return t, fmt.Errorf("gphotos: invalid token response (%s)", err)
+	} else if t.Error != "" {
+		return t, fmt.Errorf("gphotos: %s", strings.TrimSpace(t.Error+" "+t.Description))
+	} else if resp.StatusCode != http.StatusOK {
+		return t, fmt.Errorf("gphotos: token request failed with status %d", resp.StatusCode)
+	} else if t.AccessToken == "" {
+		return t, fmt.Errorf("gphotos: missing access token")
+	}
+
+	return t, nil
+}
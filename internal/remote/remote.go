@@ -31,6 +31,8 @@ import (
 
 const (
 	ServiceWebDAV    = "webdav"
+	ServiceSFTP      = "sftp"
+	ServiceSMB       = "smb"
 	ServiceFacebook  = "facebook"
 	ServiceTwitter   = "twitter"
 	ServiceFlickr    = "flickr"
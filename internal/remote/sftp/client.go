@@ -0,0 +1,416 @@
+package sftp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// DefaultPort is used if the server URL does not contain a port.
+const DefaultPort = "22"
+
+// Client represents an SFTP client.
+type Client struct {
+	conn    *ssh.Client
+	session *ssh.Session
+	w       io.WriteCloser
+	r       io.Reader
+	base    string
+	hostKey string
+	id      uint32
+	mutex   sync.Mutex
+}
+
+// NewClient connects to the SFTP server with the specified URL, e.g. sftp://camera.local/upload.
+// If hostKey is not empty, it must match the SHA256 fingerprint of the server key. Otherwise, the key is
+// trusted on first use and its fingerprint should be stored, see HostKey.
+func NewClient(serverUrl, user, pass, hostKey string, timeout time.Duration) (*Client, error) {
+	u, err := url.Parse(serverUrl)
+
+	if err != nil {
+		return nil, err
+	} else if u.Host == "" {
+		return nil, fmt.Errorf("invalid server url")
+	}
+
+	if user == "" {
+		user = u.User.Username()
+	}
+
+	if pass == "" {
+		pass, _ = u.User.Password()
+	}
+
+	addr := u.Host
+
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+
+	config := &ssh.ClientConfig{
+		User: user,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(pass),
+			ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+
+				for i := range answers {
+					answers[i] = pass
+				}
+
+				return answers, nil
+			}),
+		},
+		HostKeyCallback: hostKeyCallback(hostKey, &hostKey),
+		Timeout:         timeout,
+	}
+
+	log.Debugf("sftp: connecting to %s", clean.Log(addr))
+
+	conn, err := ssh.Dial("tcp", addr, config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{conn: conn, base: u.Path, hostKey: hostKey}
+
+	if c.base == "" {
+		c.base = "."
+	}
+
+	if err = c.init(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// hostKeyCallback verifies the server key if a fingerprint is given and otherwise trusts it on first use.
+// The fingerprint of the accepted key is returned in result.
+func hostKeyCallback(fingerprint string, result *string) ssh.HostKeyCallback {
+	fingerprint = strings.TrimSpace(fingerprint)
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if found := ssh.FingerprintSHA256(key); fingerprint == "" {
+			log.Infof("sftp: trusting host key %s of %s on first use", found, clean.Log(hostname))
+			*result = found
+		} else if found != fingerprint && strings.TrimPrefix(found, "SHA256:") != fingerprint {
+			return fmt.Errorf("sftp: host key %s of %s does not match", found, clean.Log(hostname))
+		} else {
+			*result = found
+		}
+
+		return nil
+	}
+}
+
+// HostKey returns the SHA256 fingerprint of the server key, which must be stored so that it is verified
+// when connecting again, as otherwise any key is accepted.
+func (c *Client) HostKey() string {
+	return c.hostKey
+}
+
+// init starts the sftp subsystem and negotiates the protocol version.
+func (c *Client) init() (err error) {
+	if c.session, err = c.conn.NewSession(); err != nil {
+		return err
+	} else if c.w, err = c.session.StdinPipe(); err != nil {
+		return err
+	} else if c.r, err = c.session.StdoutPipe(); err != nil {
+		return err
+	} else if err = c.session.RequestSubsystem("sftp"); err != nil {
+		return err
+	}
+
+	if err = writePacket(c.w, fxpInit, make(packet, 0, 4).uint32(protocolVersion)); err != nil {
+		return err
+	}
+
+	typ, payload, err := readPacket(c.r)
+
+	if err != nil {
+		return err
+	} else if typ != fxpVersion {
+		return fmt.Errorf("sftp: unexpected packet type %d", typ)
+	} else if d := (&decoder{b: payload}); d.uint32() < protocolVersion {
+		return fmt.Errorf("sftp: unsupported protocol version")
+	}
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	if c.session != nil {
+		_ = c.session.Close()
+	}
+
+	return c.conn.Close()
+}
+
+// request sends a request and returns the response type and payload.
+func (c *Client) request(typ byte, payload packet) (byte, *decoder, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.id++
+	id := c.id
+
+	if err := writePacket(c.w, typ, append(make(packet, 0, len(payload)+4).uint32(id), payload...)); err != nil {
+		return 0, nil, err
+	}
+
+	respType, resp, err := readPacket(c.r)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	d := &decoder{b: resp}
+
+	if d.uint32() != id {
+		return 0, nil, errors.New("sftp: unexpected response id")
+	} else if respType == fxpStatus {
+		return respType, d, d.status()
+	}
+
+	return respType, d, d.err
+}
+
+// handle sends a request that returns a file or directory handle.
+func (c *Client) handle(typ byte, payload packet) (string, error) {
+	respType, d, err := c.request(typ, payload)
+
+	if err != nil {
+		return "", err
+	} else if respType != fxpHandle {
+		return "", fmt.Errorf("sftp: unexpected packet type %d", respType)
+	}
+
+	return d.string(), d.err
+}
+
+// closeHandle releases a file or directory handle.
+func (c *Client) closeHandle(h string) {
+	if _, _, err := c.request(fxpClose, packet{}.string(h)); err != nil {
+		log.Debugf("sftp: %s while closing handle", err)
+	}
+}
+
+// remotePath returns the server path of a name relative to the base path.
+func (c *Client) remotePath(name string) string {
+	return path.Join(c.base, "/"+strings.Trim(name, "/"))
+}
+
+// fileInfo returns the file info with a path relative to the base path.
+func (c *Client) fileInfo(dir, name string, a attrs) fs.FileInfo {
+	rel := strings.TrimPrefix(path.Join("/"+strings.Trim(dir, "/"), name), "/")
+
+	return fs.FileInfo{
+		Name: name,
+		Abs:  "/" + rel,
+		Size: int64(a.Size),
+		Date: time.Unix(int64(a.Mtime), 0).UTC(),
+		Dir:  a.dir(),
+	}
+}
+
+// readDir returns the contents of a directory, relative to the base path.
+func (c *Client) readDir(dir string) (result fs.FileInfos, err error) {
+	h, err := c.handle(fxpOpendir, packet{}.string(c.remotePath(dir)))
+
+	if err != nil {
+		return result, err
+	}
+
+	defer c.closeHandle(h)
+
+	for {
+		respType, d, err := c.request(fxpReaddir, packet{}.string(h))
+
+		if err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return result, err
+		} else if respType != fxpName {
+			return result, fmt.Errorf("sftp: unexpected packet type %d", respType)
+		}
+
+		for n := d.uint32(); n > 0 && d.err == nil; n-- {
+			name := d.string()
+			_ = d.string() // Long name.
+			a := d.attrs()
+
+			// Skip hidden files and folders.
+			if name == "" || strings.HasPrefix(name, ".") {
+				continue
+			} else if !a.dir() && !a.regular() {
+				continue
+			}
+
+			result = append(result, c.fileInfo(dir, name, a))
+		}
+
+		if d.err != nil {
+			return result, d.err
+		}
+	}
+}
+
+// walk returns the contents of a directory, optionally including subdirectories.
+func (c *Client) walk(dir string, recursive bool, deadline time.Time) (result fs.FileInfos, err error) {
+	found, err := c.readDir(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, f := range found {
+		result = append(result, f)
+
+		if !f.Dir || !recursive {
+			continue
+		} else if !deadline.IsZero() && time.Now().After(deadline) {
+			return result, fmt.Errorf("sftp: timeout while listing %s", clean.Log(dir))
+		}
+
+		sub, err := c.walk(f.Abs, true, deadline)
+
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, sub...)
+	}
+
+	return result, nil
+}
+
+// Files returns information about files in a directory, optionally recursively.
+func (c *Client) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	found, err := c.walk(dir, recursive, time.Time{})
+
+	for _, f := range found {
+		if !f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	return result, err
+}
+
+// Directories returns all subdirectories in a path, optionally within the specified time limit.
+func (c *Client) Directories(dir string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	var deadline time.Time
+
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	found, err := c.walk(dir, recursive, deadline)
+
+	for _, f := range found {
+		if f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	return result, err
+}
+
+// Stat returns information about a remote file.
+func (c *Client) Stat(name string) (result fs.FileInfo, err error) {
+	respType, d, err := c.request(fxpStat, packet{}.string(c.remotePath(name)))
+
+	if err != nil {
+		return result, err
+	} else if respType != fxpAttrs {
+		return result, fmt.Errorf("sftp: unexpected packet type %d", respType)
+	}
+
+	a := d.attrs()
+
+	return c.fileInfo(path.Dir("/"+strings.Trim(name, "/")), path.Base(name), a), d.err
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(src, dest string, force bool) (err error) {
+	// Skip if file already exists.
+	if _, err = os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("sftp: download skipped, %s already exists", clean.Log(dest))
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dest), fs.ModeDir); err != nil {
+		return fmt.Errorf("sftp: cannot create folder %s (%s)", clean.Log(filepath.Dir(dest)), err)
+	}
+
+	h, err := c.handle(fxpOpen, packet{}.string(c.remotePath(src)).uint32(openRead).attrs(attrs{}))
+
+	if err != nil {
+		return err
+	}
+
+	defer c.closeHandle(h)
+
+	// Download to a temporary file first, so that incomplete files are never indexed.
+	tmp := dest + ".download"
+
+	f, err := os.OpenFile(tmp, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		return fmt.Errorf("sftp: failed to create %s", clean.Log(filepath.Base(dest)))
+	}
+
+	var offset uint64
+
+	for {
+		respType, d, err := c.request(fxpRead, packet{}.string(h).uint64(offset).uint32(chunkSize))
+
+		if err == io.EOF {
+			break
+		} else if err == nil && respType != fxpData {
+			err = fmt.Errorf("sftp: unexpected packet type %d", respType)
+		}
+
+		var data string
+
+		if err == nil {
+			data = d.string()
+			err = d.err
+		}
+
+		if err == nil {
+			_, err = f.WriteString(data)
+		}
+
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return err
+		}
+
+		offset += uint64(len(data))
+	}
+
+	if err = f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, dest)
+}
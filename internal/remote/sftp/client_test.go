@@ -0,0 +1,314 @@
+package sftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is a minimal SFTP server that provides read-only access to a local directory.
+type testServer struct {
+	root   string
+	addr   string
+	signer ssh.Signer
+}
+
+func newTestServer(t *testing.T) *testServer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == "camera" && string(pass) == "secret" {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("access denied")
+		},
+	}
+
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	s := &testServer{root: t.TempDir(), addr: l.Addr().String(), signer: signer}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go s.serveConn(conn, config)
+		}
+	}()
+
+	return s
+}
+
+func (s *testServer) serveConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+
+	if err != nil {
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for ch := range chans {
+		channel, requests, err := ch.Accept()
+
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			for req := range requests {
+				ok := req.Type == "subsystem" && strings.HasSuffix(string(req.Payload), "sftp")
+				_ = req.Reply(ok, nil)
+
+				if ok {
+					go s.serve(channel)
+				}
+			}
+		}()
+	}
+}
+
+func (s *testServer) attrs(info os.FileInfo) attrs {
+	mode := uint32(modeRegular)
+
+	if info.IsDir() {
+		mode = modeDir
+	}
+
+	return attrs{Flags: attrSize | attrPermissions | attrACModTime, Size: uint64(info.Size()), Mode: mode | 0o644, Mtime: uint32(info.ModTime().Unix())}
+}
+
+func (s *testServer) serve(rw io.ReadWriteCloser) {
+	defer rw.Close()
+
+	handles := make(map[string]string)
+	listed := make(map[string]bool)
+
+	status := func(id, code uint32) error {
+		return writePacket(rw, fxpStatus, packet{}.uint32(id).uint32(code).string("").string(""))
+	}
+
+	for {
+		typ, payload, err := readPacket(rw)
+
+		if err != nil {
+			return
+		}
+
+		d := &decoder{b: payload}
+
+		if typ == fxpInit {
+			_ = writePacket(rw, fxpVersion, packet{}.uint32(protocolVersion))
+			continue
+		}
+
+		id := d.uint32()
+
+		switch typ {
+		case fxpOpendir, fxpOpen:
+			name := filepath.Join(s.root, filepath.FromSlash(d.string()))
+
+			if _, err := os.Stat(name); err != nil {
+				_ = status(id, fxNoSuchFile)
+			} else {
+				h := fmt.Sprintf("h%d", id)
+				handles[h] = name
+				_ = writePacket(rw, fxpHandle, packet{}.uint32(id).string(h))
+			}
+		case fxpReaddir:
+			h := d.string()
+
+			if listed[h] {
+				_ = status(id, fxEOF)
+				continue
+			}
+
+			listed[h] = true
+			entries, _ := os.ReadDir(handles[h])
+			p := packet{}.uint32(id).uint32(uint32(len(entries)))
+
+			for _, e := range entries {
+				info, _ := e.Info()
+				p = p.string(e.Name()).string(e.Name()).attrs(s.attrs(info))
+			}
+
+			_ = writePacket(rw, fxpName, p)
+		case fxpRead:
+			h := d.string()
+			offset := d.uint64()
+			size := d.uint32()
+			data, _ := os.ReadFile(handles[h])
+
+			if offset >= uint64(len(data)) {
+				_ = status(id, fxEOF)
+			} else {
+				end := offset + uint64(size)
+
+				if end > uint64(len(data)) {
+					end = uint64(len(data))
+				}
+
+				_ = writePacket(rw, fxpData, packet{}.uint32(id).string(string(data[offset:end])))
+			}
+		case fxpStat:
+			if info, err := os.Stat(filepath.Join(s.root, filepath.FromSlash(d.string()))); err != nil {
+				_ = status(id, fxNoSuchFile)
+			} else {
+				_ = writePacket(rw, fxpAttrs, packet{}.uint32(id).attrs(s.attrs(info)))
+			}
+		case fxpClose:
+			delete(handles, d.string())
+			_ = status(id, fxOk)
+		default:
+			_ = status(id, 8)
+		}
+	}
+}
+
+func (s *testServer) writeFile(t *testing.T, name string, data []byte) {
+	fileName := filepath.Join(s.root, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(fileName, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		_, err := NewClient("sftp:///upload", "camera", "secret", "", time.Second)
+		assert.Error(t, err)
+	})
+	t.Run("WrongPassword", func(t *testing.T) {
+		_, err := NewClient("sftp://"+s.addr+"/upload", "camera", "wrong", "", time.Second)
+		assert.Error(t, err)
+	})
+	t.Run("WrongHostKey", func(t *testing.T) {
+		_, err := NewClient("sftp://"+s.addr+"/upload", "camera", "secret", "SHA256:invalid", time.Second)
+		assert.Error(t, err)
+	})
+	t.Run("HostKey", func(t *testing.T) {
+		c, err := NewClient("sftp://"+s.addr+"/", "camera", "secret", ssh.FingerprintSHA256(s.signer.PublicKey()), time.Second)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ssh.FingerprintSHA256(s.signer.PublicKey()), c.HostKey())
+		assert.NoError(t, c.Close())
+	})
+	t.Run("TrustOnFirstUse", func(t *testing.T) {
+		c, err := NewClient("sftp://"+s.addr+"/", "camera", "secret", "", time.Second)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hostKey := c.HostKey()
+
+		assert.Equal(t, ssh.FingerprintSHA256(s.signer.PublicKey()), hostKey)
+		assert.NoError(t, c.Close())
+
+		// The pinned key of another server does not match.
+		_, err = NewClient("sftp://"+newTestServer(t).addr+"/", "camera", "secret", hostKey, time.Second)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient(t *testing.T) {
+	s := newTestServer(t)
+	s.writeFile(t, "upload/IMG_0001.jpg", []byte("first"))
+	s.writeFile(t, "upload/2023/IMG_0002.jpg", []byte(strings.Repeat("x", chunkSize+100)))
+	s.writeFile(t, "upload/.hidden/IMG_0003.jpg", []byte("hidden"))
+
+	c, err := NewClient("sftp://camera:secret@"+s.addr+"/upload", "", "", "", time.Second)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	t.Run("Directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/2023"}, dirs.Abs())
+	})
+	t.Run("Files", func(t *testing.T) {
+		files, err := c.Files("/", true)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"/IMG_0001.jpg", "/2023/IMG_0002.jpg"}, files.Abs())
+
+		files, err = c.Files("/2023", false)
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, files, 1) {
+			assert.Equal(t, "IMG_0002.jpg", files[0].Name)
+			assert.Equal(t, int64(chunkSize+100), files[0].Size)
+		}
+	})
+	t.Run("Stat", func(t *testing.T) {
+		info, err := c.Stat("/IMG_0001.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/IMG_0001.jpg", info.Abs)
+		assert.Equal(t, int64(5), info.Size)
+
+		_, err = c.Stat("/missing.jpg")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Download", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "2023", "IMG_0002.jpg")
+
+		assert.NoError(t, c.Download("/2023/IMG_0002.jpg", dest, false))
+
+		data, err := os.ReadFile(dest)
+
+		assert.NoError(t, err)
+		assert.Len(t, data, chunkSize+100)
+		assert.Error(t, c.Download("/2023/IMG_0002.jpg", dest, false))
+		assert.NoError(t, c.Download("/IMG_0001.jpg", dest, true))
+		assert.Error(t, c.Download("/missing.jpg", dest, true))
+	})
+}
@@ -0,0 +1,256 @@
+package sftp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Packet types of the SSH File Transfer Protocol version 3, as supported by OpenSSH,
+// see https://datatracker.ietf.org/doc/html/draft-ietf-secsh-filexfer-02.
+const (
+	fxpInit    = 1
+	fxpVersion = 2
+	fxpOpen    = 3
+	fxpClose   = 4
+	fxpRead    = 5
+	fxpOpendir = 11
+	fxpReaddir = 12
+	fxpStat    = 17
+	fxpStatus  = 101
+	fxpHandle  = 102
+	fxpData    = 103
+	fxpName    = 104
+	fxpAttrs   = 105
+)
+
+// Status codes.
+const (
+	fxOk         = 0
+	fxEOF        = 1
+	fxNoSuchFile = 2
+)
+
+// File attribute flags.
+const (
+	attrSize        = 0x00000001
+	attrUIDGID      = 0x00000002
+	attrPermissions = 0x00000004
+	attrACModTime   = 0x00000008
+	attrExtended    = 0x80000000
+)
+
+// File type bits of the permissions attribute.
+const (
+	modeType    = 0o170000
+	modeDir     = 0o040000
+	modeRegular = 0o100000
+)
+
+const (
+	protocolVersion = 3
+	openRead        = 0x00000001
+	maxPacket       = 1024 * 1024
+	chunkSize       = 32 * 1024
+)
+
+// ErrNotFound is returned if the remote file does not exist.
+var ErrNotFound = errors.New("file not found")
+
+// statusError represents an error status returned by the server.
+type statusError struct {
+	Code    uint32
+	Message string
+}
+
+// Error returns the error message.
+func (e *statusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("sftp: request failed with status %d", e.Code)
+	}
+
+	return fmt.Sprintf("sftp: %s (status %d)", e.Message, e.Code)
+}
+
+// attrs represents the attributes of a remote file.
+type attrs struct {
+	Flags uint32
+	Size  uint64
+	Mode  uint32
+	Mtime uint32
+}
+
+// dir checks if the attributes belong to a directory.
+func (a attrs) dir() bool {
+	return a.Flags&attrPermissions != 0 && a.Mode&modeType == modeDir
+}
+
+// regular checks if the attributes belong to a regular file, which is assumed if the permissions are unknown.
+func (a attrs) regular() bool {
+	return a.Flags&attrPermissions == 0 || a.Mode&modeType == modeRegular
+}
+
+// packet represents an encoded packet payload.
+type packet []byte
+
+func (p packet) byte(v byte) packet {
+	return append(p, v)
+}
+
+func (p packet) uint32(v uint32) packet {
+	return append(p, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (p packet) uint64(v uint64) packet {
+	return p.uint32(uint32(v >> 32)).uint32(uint32(v))
+}
+
+func (p packet) string(s string) packet {
+	return append(p.uint32(uint32(len(s))), s...)
+}
+
+func (p packet) attrs(a attrs) packet {
+	p = p.uint32(a.Flags)
+
+	if a.Flags&attrSize != 0 {
+		p = p.uint64(a.Size)
+	}
+
+	if a.Flags&attrPermissions != 0 {
+		p = p.uint32(a.Mode)
+	}
+
+	if a.Flags&attrACModTime != 0 {
+		p = p.uint32(a.Mtime).uint32(a.Mtime)
+	}
+
+	return p
+}
+
+// decoder reads values from a packet payload.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	} else if n < 0 || len(d.b) < n {
+		d.err = errors.New("sftp: packet too short")
+		return nil
+	}
+
+	v := d.b[:n]
+	d.b = d.b[n:]
+
+	return v
+}
+
+func (d *decoder) byte() byte {
+	if v := d.next(1); v != nil {
+		return v[0]
+	}
+
+	return 0
+}
+
+func (d *decoder) uint32() uint32 {
+	if v := d.next(4); v != nil {
+		return binary.BigEndian.Uint32(v)
+	}
+
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if v := d.next(8); v != nil {
+		return binary.BigEndian.Uint64(v)
+	}
+
+	return 0
+}
+
+func (d *decoder) string() string {
+	return string(d.next(int(d.uint32())))
+}
+
+func (d *decoder) attrs() (a attrs) {
+	a.Flags = d.uint32()
+
+	if a.Flags&attrSize != 0 {
+		a.Size = d.uint64()
+	}
+
+	if a.Flags&attrUIDGID != 0 {
+		d.uint32()
+		d.uint32()
+	}
+
+	if a.Flags&attrPermissions != 0 {
+		a.Mode = d.uint32()
+	}
+
+	if a.Flags&attrACModTime != 0 {
+		d.uint32()
+		a.Mtime = d.uint32()
+	}
+
+	if a.Flags&attrExtended != 0 {
+		for n := d.uint32(); n > 0 && d.err == nil; n-- {
+			d.string()
+			d.string()
+		}
+	}
+
+	return a
+}
+
+// status returns the error of a status packet, or nil if the status is ok.
+func (d *decoder) status() error {
+	code := d.uint32()
+	msg := d.string()
+
+	switch code {
+	case fxOk:
+		return nil
+	case fxEOF:
+		return io.EOF
+	case fxNoSuchFile:
+		return ErrNotFound
+	default:
+		return &statusError{Code: code, Message: msg}
+	}
+}
+
+// writePacket sends a packet with the specified type and payload.
+func writePacket(w io.Writer, typ byte, payload []byte) error {
+	b := make(packet, 0, len(payload)+5).uint32(uint32(len(payload) + 1)).byte(typ)
+	_, err := w.Write(append(b, payload...))
+
+	return err
+}
+
+// readPacket receives the next packet and returns its type and payload.
+func readPacket(r io.Reader) (typ byte, payload []byte, err error) {
+	var size [4]byte
+
+	if _, err = io.ReadFull(r, size[:]); err != nil {
+		return 0, nil, err
+	}
+
+	n := binary.BigEndian.Uint32(size[:])
+
+	if n == 0 || n > maxPacket {
+		return 0, nil, fmt.Errorf("sftp: invalid packet size %d", n)
+	}
+
+	b := make([]byte, n)
+
+	if _, err = io.ReadFull(r, b); err != nil {
+		return 0, nil, err
+	}
+
+	return b[0], b[1:], nil
+}
@@ -0,0 +1,260 @@
+package smb
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Bin is the smbclient command, see https://www.samba.org/samba/docs/current/man-html/smbclient.1.html.
+var Bin = "smbclient"
+
+// entryRegexp matches a file or folder in the output of the smbclient "ls" command.
+var entryRegexp = regexp.MustCompile(`^  (.+?)\s+([A-Z]*)\s+(\d+)\s+(\w{3} \w{3} [ \d]\d \d{2}:\d{2}:\d{2} \d{4})$`)
+
+// timeLayout is the date format of the smbclient "ls" command.
+const timeLayout = "Mon Jan _2 15:04:05 2006"
+
+// Client represents an SMB/CIFS client.
+type Client struct {
+	share   string
+	port    string
+	base    string
+	user    string
+	pass    string
+	timeout time.Duration
+}
+
+// NewClient creates a new client for the network share with the specified URL, e.g. smb://nas.local/photos/upload.
+func NewClient(serverUrl, user, pass string, timeout time.Duration) (*Client, error) {
+	u, err := url.Parse(serverUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)
+
+	if u.Hostname() == "" || parts[0] == "" {
+		return nil, fmt.Errorf("invalid share url")
+	}
+
+	c := &Client{
+		share:   fmt.Sprintf("//%s/%s", u.Hostname(), parts[0]),
+		port:    u.Port(),
+		user:    user,
+		pass:    pass,
+		timeout: timeout,
+	}
+
+	if len(parts) > 1 {
+		c.base = strings.Trim(parts[1], "/")
+	}
+
+	if c.user == "" {
+		c.user = u.User.Username()
+	}
+
+	if c.pass == "" {
+		c.pass, _ = u.User.Password()
+	}
+
+	return c, nil
+}
+
+// remotePath returns the path of a name relative to the base path, as used by smbclient.
+func (c *Client) remotePath(name string) string {
+	return strings.Trim(path.Join(c.base, "/"+strings.Trim(name, "/")), "/")
+}
+
+// args returns the smbclient arguments for running the commands in the specified directory.
+func (c *Client) args(dir, commands string) []string {
+	args := []string{c.share}
+
+	if c.port != "" {
+		args = append(args, "-p", c.port)
+	}
+
+	if c.user != "" {
+		args = append(args, "-U", c.user)
+	} else {
+		args = append(args, "-N")
+	}
+
+	if c.timeout > 0 {
+		args = append(args, "-t", strconv.Itoa(int(c.timeout.Seconds())))
+	}
+
+	if dir != "" {
+		args = append(args, "-D", strings.ReplaceAll(dir, "/", `\`))
+	}
+
+	return append(args, "-c", commands)
+}
+
+// run executes the smbclient commands in the specified directory and returns the output.
+func (c *Client) run(dir, commands string) ([]byte, error) {
+	cmd := exec.Command(Bin, c.args(dir, commands)...)
+
+	// Pass the password as environment variable, so that it is not visible in the process list.
+	cmd.Env = append(os.Environ(), "PASSWD="+c.pass)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("smb: %s", commandError(stdout.String()+stderr.String(), err))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// commandError returns the most relevant error message of a failed command.
+func commandError(output string, err error) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "NT_STATUS_") {
+			return strings.TrimSpace(line)
+		}
+	}
+
+	return err.Error()
+}
+
+// quote returns the argument of an smbclient command in double quotes. Names containing semicolons are
+// rejected, as smbclient splits commands at semicolons even if they are quoted, which would otherwise allow
+// remote file names to run other commands, including shell commands with "!".
+func quote(s string) (string, error) {
+	if strings.ContainsAny(s, "\";\r\n") {
+		return "", fmt.Errorf("smb: unsupported file name %s", clean.Log(s))
+	}
+
+	return `"` + s + `"`, nil
+}
+
+// parseList returns the files and folders listed by the smbclient "ls" command, relative to dir.
+func parseList(output []byte, dir string) (result fs.FileInfos) {
+	dir = "/" + strings.Trim(dir, "/")
+	current := dir
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		// Recursive listings contain a header for each subdirectory.
+		if strings.HasPrefix(line, `\`) {
+			current = path.Join(dir, strings.ReplaceAll(line, `\`, "/"))
+			continue
+		}
+
+		m := entryRegexp.FindStringSubmatch(line)
+
+		if m == nil || m[1] == "." || m[1] == ".." || strings.HasPrefix(m[1], ".") {
+			continue
+		}
+
+		size, _ := strconv.ParseInt(m[3], 10, 64)
+		date, _ := time.ParseInLocation(timeLayout, m[4], time.Local)
+
+		result = append(result, fs.FileInfo{
+			Name: m[1],
+			Abs:  path.Join(current, m[1]),
+			Size: size,
+			Date: date,
+			Dir:  strings.Contains(m[2], "D"),
+		})
+	}
+
+	return result
+}
+
+// list returns the contents of a directory, optionally including subdirectories.
+func (c *Client) list(dir string, recursive bool) (fs.FileInfos, error) {
+	commands := "ls"
+
+	if recursive {
+		commands = "recurse ON; ls"
+	}
+
+	output, err := c.run(c.remotePath(dir), commands)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseList(output, dir), nil
+}
+
+// Files returns information about files in a directory, optionally recursively.
+func (c *Client) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	found, err := c.list(dir, recursive)
+
+	for _, f := range found {
+		if !f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	return result, err
+}
+
+// Directories returns all subdirectories in a path. The timeout is ignored, as each command has its own time limit.
+func (c *Client) Directories(dir string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	found, err := c.list(dir, recursive)
+
+	for _, f := range found {
+		if f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	return result, err
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(src, dest string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("smb: download skipped, %s already exists", clean.Log(dest))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), fs.ModeDir); err != nil {
+		return fmt.Errorf("smb: cannot create folder %s (%s)", clean.Log(filepath.Dir(dest)), err)
+	}
+
+	// Download to a temporary file first, so that incomplete files are never indexed.
+	tmp := dest + ".download"
+
+	remoteName, err := quote(strings.ReplaceAll(c.remotePath(src), "/", `\`))
+
+	if err != nil {
+		return err
+	}
+
+	localName, err := quote(tmp)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.run("", fmt.Sprintf("get %s %s", remoteName, localName)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, dest)
+}
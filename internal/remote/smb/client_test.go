@@ -0,0 +1,144 @@
+package smb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testList = `  .                                   D        0  Mon May 15 10:00:00 2023
+  ..                                  D        0  Mon May 15 10:00:00 2023
+  2023                                D        0  Mon May 15 10:00:00 2023
+  .hidden                            DH        0  Mon May 15 10:00:00 2023
+  IMG_0001.jpg                        A     1234  Mon May  1 09:30:00 2023
+  My Holiday.jpg                      N      567  Mon May 15 10:00:00 2023
+
+\2023
+  .                                   D        0  Mon May 15 10:00:00 2023
+  ..                                  D        0  Mon May 15 10:00:00 2023
+  IMG_0002.jpg                        A     5678  Mon May 15 10:00:00 2023
+
+		61202244 blocks of size 1024. 16312256 blocks available
+`
+
+// fakeBin replaces the smbclient command with a shell script for testing.
+func fakeBin(t *testing.T, script string) {
+	bin := filepath.Join(t.TempDir(), "smbclient")
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	prev := Bin
+	Bin = bin
+
+	t.Cleanup(func() { Bin = prev })
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Share", func(t *testing.T) {
+		c, err := NewClient("smb://guest@nas.local:1445/photos/upload/", "", "", 10*time.Second)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "//nas.local/photos", c.share)
+		assert.Equal(t, "upload", c.base)
+		assert.Equal(t, "upload/2023", c.remotePath("/2023/"))
+		assert.Equal(t, []string{"//nas.local/photos", "-p", "1445", "-U", "guest", "-t", "10", "-D", `upload\2023`, "-c", "ls"}, c.args("upload/2023", "ls"))
+	})
+	t.Run("Anonymous", func(t *testing.T) {
+		c, err := NewClient("smb://nas.local/photos", "", "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", c.base)
+		assert.Equal(t, []string{"//nas.local/photos", "-N", "-c", "ls"}, c.args("", "ls"))
+	})
+	t.Run("NoShare", func(t *testing.T) {
+		_, err := NewClient("smb://nas.local/", "", "", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseList(t *testing.T) {
+	result := parseList([]byte(testList), "/")
+
+	assert.Equal(t, []string{"/2023", "/IMG_0001.jpg", "/My Holiday.jpg", "/2023/IMG_0002.jpg"}, result.Abs())
+
+	if assert.Len(t, result, 4) {
+		assert.True(t, result[0].Dir)
+		assert.False(t, result[1].Dir)
+		assert.Equal(t, int64(1234), result[1].Size)
+		assert.Equal(t, time.Date(2023, 5, 1, 9, 30, 0, 0, time.Local), result[1].Date)
+		assert.Equal(t, "My Holiday.jpg", result[2].Name)
+	}
+
+	assert.Equal(t, []string{"/upload/2023", "/upload/IMG_0001.jpg", "/upload/My Holiday.jpg", "/upload/2023/IMG_0002.jpg"}, parseList([]byte(testList), "upload").Abs())
+}
+
+func TestClient(t *testing.T) {
+	c, err := NewClient("smb://nas.local/photos", "camera", "secret", time.Second)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Files", func(t *testing.T) {
+		fakeBin(t, "cat <<'EOF'\n"+testList+"EOF\n")
+
+		files, err := c.Files("/", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/IMG_0001.jpg", "/My Holiday.jpg", "/2023/IMG_0002.jpg"}, files.Abs())
+
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/2023"}, dirs.Abs())
+	})
+	t.Run("Error", func(t *testing.T) {
+		fakeBin(t, "echo 'session setup failed: NT_STATUS_LOGON_FAILURE' >&2\nexit 1\n")
+
+		_, err := c.Files("/", true)
+
+		assert.EqualError(t, err, "smb: session setup failed: NT_STATUS_LOGON_FAILURE")
+	})
+	t.Run("Download", func(t *testing.T) {
+		// Writes the password to the local file name of the "get" command.
+		fakeBin(t, "for a; do cmd=\"$a\"; done\nlocal=$(echo \"$cmd\" | sed 's/^get \".*\" \"\\(.*\\)\"$/\\1/')\nprintf '%s' \"$PASSWD\" > \"$local\"\n")
+
+		dest := filepath.Join(t.TempDir(), "2023", "IMG_0002.jpg")
+
+		assert.NoError(t, c.Download("/2023/IMG_0002.jpg", dest, false))
+
+		data, err := os.ReadFile(dest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", string(data))
+		assert.Error(t, c.Download("/2023/IMG_0002.jpg", dest, false))
+		assert.Error(t, c.Download(`/2023/IMG"0002.jpg`, dest, true))
+		assert.Error(t, c.Download(`/2023/a;!touch pwned.jpg`, dest, true))
+	})
+}
+
+func TestQuote(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		s, err := quote(`2023\My Holiday!.jpg`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `"2023\My Holiday!.jpg"`, s)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		for _, name := range []string{`IMG"0002.jpg`, "a;!id", "a;ls", "IMG\n0002.jpg", "IMG\r0002.jpg"} {
+			_, err := quote(name)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -341,3 +341,30 @@ func (c *Client) Delete(dir string) error {
 	dir = trimPath(dir)
 	return c.client.RemoveAll(dir)
 }
+
+// Stat returns information about a single remote file.
+func (c *Client) Stat(name string) (result fs.FileInfo, err error) {
+	name = trimPath(name)
+
+	info, err := c.client.Stat(name)
+
+	if err != nil {
+		return result, err
+	} else if info == nil {
+		return result, fmt.Errorf("webdav: file %s not found", clean.Log(name))
+	}
+
+	return fs.WebFileInfo(*info, c.endpoint.Path), nil
+}
+
+// Move renames a single file or directory on a remote server.
+func (c *Client) Move(src, dest string) error {
+	src = trimPath(src)
+	dest = trimPath(dest)
+
+	if err := c.MkdirAll(path.Dir(dest)); err != nil {
+		log.Debugf("webdav: %s", err)
+	}
+
+	return c.client.MoveAll(src, dest, false)
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -68,7 +69,7 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 			if basePath := user.GetBasePath(); basePath == "" {
 				s = s.Where("albums.album_uid IN (?) OR albums.created_by = ? OR albums.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.TimeStamp())
 			} else {
-				s = s.Where("albums.album_uid IN (?) OR albums.created_by = ? OR albums.published_at > ? OR albums.album_type = ? AND (albums.album_path = ? OR albums.album_path LIKE ?)",
+				s = s.Where(fmt.Sprintf("albums.album_uid IN (?) OR albums.created_by = ? OR albums.published_at > ? OR albums.album_type = ? AND (albums.album_path = ? OR albums.album_path %s ?)", entity.LikeOp()),
 					sess.SharedUIDs(), user.UserUID, entity.TimeStamp(), entity.AlbumFolder, basePath, basePath+"/%")
 			}
 		}
@@ -149,7 +150,7 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 	if txt.NotEmpty(f.Query) {
 		if f.Type != entity.AlbumFolder {
 			likeString := "%" + f.Query + "%"
-			s = s.Where("albums.album_title LIKE ? OR albums.album_location LIKE ?", likeString, likeString)
+			s = s.Where(fmt.Sprintf("albums.album_title %[1]s ? OR albums.album_location %[1]s ?", entity.LikeOp()), likeString, likeString)
 		} else {
 			searchQuery := strings.Trim(strings.ReplaceAll(f.Query, "\\", "/"), "/")
 			for _, where := range LikeAllNames(Cols{"albums.album_title", "albums.album_location", "albums.album_path"}, searchQuery) {
@@ -173,6 +174,15 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 		s = s.Where("albums.album_category IN (?)", strings.Split(f.Category, txt.Or))
 	}
 
+	// Filter by parent album?
+	if f.Parent == "root" {
+		s = s.Where("albums.parent_uid = ''")
+	} else if rnd.IsUID(f.Parent, entity.AlbumUID) {
+		s = s.Where("albums.parent_uid = ?", f.Parent)
+	} else if txt.NotEmpty(f.Parent) {
+		return AlbumResults{}, ErrInvalidId
+	}
+
 	if txt.NotEmpty(f.Location) {
 		s = s.Where("albums.album_location IN (?)", strings.Split(f.Location, txt.Or))
 	}
@@ -221,6 +231,21 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 		return results, result.Error
 	}
 
+	// Add sub-album counters and covers to parent albums.
+	if err = nestedAlbums(results, sess); err != nil {
+		log.Warnf("albums: %s (nested albums)", err)
+	}
+
+	// Hide the covers of locked albums unless they have been unlocked in the session.
+	if sess != nil {
+		for i := range results {
+			if results[i].AlbumLocked && !sess.HasUnlocked(results[i].AlbumUID) {
+				results[i].Thumb = ""
+				results[i].ThumbSrc = ""
+			}
+		}
+	}
+
 	// Log number of results.
 	log.Debugf("albums: found %s [%s]", english.Plural(len(results), "result", "results"), time.Since(start))
 
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// albumNode represents a manually created album in the album tree.
+type albumNode struct {
+	AlbumUID    string
+	ParentUID   string
+	Thumb       string
+	ThumbSrc    string
+	AlbumLocked bool
+	PhotoCount  int
+}
+
+// nestedAlbums adds the number of sub-albums and the total number of pictures including sub-albums
+// to the results, and uses a sub-album cover for parent albums that do not have one themselves.
+func nestedAlbums(results AlbumResults, sess *entity.Session) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	// Check if the results contain manually created albums.
+	found := false
+
+	for i := range results {
+		results[i].TotalCount = results[i].PhotoCount
+
+		if results[i].AlbumType == entity.AlbumManual {
+			found = true
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	var nodes []albumNode
+
+	if err := UnscopedDb().Table("albums").
+		Select("albums.album_uid, albums.parent_uid, albums.thumb, albums.thumb_src, albums.album_locked, cp.photo_count").
+		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = 0 AND missing = 0 GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
+		Where("albums.deleted_at IS NULL AND albums.album_type = ?", entity.AlbumManual).
+		Scan(&nodes).Error; err != nil {
+		return err
+	}
+
+	children := make(map[string][]*albumNode)
+
+	for i := range nodes {
+		if nodes[i].ParentUID != "" {
+			children[nodes[i].ParentUID] = append(children[nodes[i].ParentUID], &nodes[i])
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	for i := range results {
+		if results[i].AlbumType != entity.AlbumManual {
+			continue
+		}
+
+		uid := results[i].AlbumUID
+		results[i].ChildCount = len(children[uid])
+
+		if results[i].ChildCount == 0 {
+			continue
+		}
+
+		// Walk the sub-albums breadth-first to aggregate the counts and find a cover.
+		visited := map[string]bool{uid: true}
+		queue := children[uid]
+
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+
+			if visited[node.AlbumUID] {
+				continue
+			}
+
+			visited[node.AlbumUID] = true
+
+			// Don't reveal anything about the contents of locked albums.
+			if node.AlbumLocked && (sess == nil || !sess.HasUnlocked(node.AlbumUID)) {
+				continue
+			}
+
+			results[i].TotalCount += node.PhotoCount
+
+			if results[i].Thumb == "" && node.Thumb != "" {
+				results[i].Thumb = node.Thumb
+				results[i].ThumbSrc = node.ThumbSrc
+			}
+
+			queue = append(queue, children[node.AlbumUID]...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestAlbums_Nested(t *testing.T) {
+	parent := entity.NewAlbum("Nested Parent", entity.AlbumManual)
+
+	if err := parent.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	child := entity.AlbumFixtures.Get("berlin-2019")
+
+	if err := child.SetParent(parent.AlbumUID); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = child.SetParent("")
+		_ = parent.DeletePermanently()
+	}()
+
+	t.Run("Parent", func(t *testing.T) {
+		f := form.SearchAlbums{Parent: parent.AlbumUID, Count: 10}
+		results, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, child.AlbumUID, results[0].AlbumUID)
+		}
+	})
+	t.Run("Counters", func(t *testing.T) {
+		f := form.SearchAlbums{UID: parent.AlbumUID, Count: 10}
+		results, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, 1, results[0].ChildCount)
+			assert.GreaterOrEqual(t, results[0].TotalCount, results[0].PhotoCount)
+		}
+	})
+	t.Run("InvalidParent", func(t *testing.T) {
+		_, err := Albums(form.SearchAlbums{Parent: "xxx", Count: 10})
+		assert.Error(t, err)
+	})
+}
@@ -30,7 +30,10 @@ type Album struct {
 	AlbumDay         int       `json:"Day"`
 	AlbumFavorite    bool      `json:"Favorite"`
 	AlbumPrivate     bool      `json:"Private"`
+	AlbumLocked      bool      `json:"Locked"`
 	PhotoCount       int       `json:"PhotoCount"`
+	ChildCount       int       `json:"ChildCount"`
+	TotalCount       int       `json:"TotalCount"`
 	LinkCount        int       `json:"LinkCount"`
 	CreatedAt        time.Time `json:"CreatedAt"`
 	UpdatedAt        time.Time `json:"UpdatedAt"`
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -49,9 +50,9 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 
 		for _, w := range words {
 			if wildcardThreshold > 0 && len(w) >= wildcardThreshold {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s%%'", col, Like(w)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s%%'", col, entity.LikeOp(), Like(w)))
 			} else {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s'", col, Like(w)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s'", col, entity.LikeOp(), Like(w)))
 			}
 
 			if !keywords || !txt.ContainsASCIILetters(w) {
@@ -61,7 +62,7 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 			singular := inflection.Singular(w)
 
 			if singular != w {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s'", col, Like(singular)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s'", col, entity.LikeOp(), Like(singular)))
 			}
 		}
 
@@ -108,9 +109,9 @@ func LikeAll(col, s string, keywords, exact bool) (wheres []string) {
 
 	for _, w := range words {
 		if wildcardThreshold > 0 && len(w) >= wildcardThreshold {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s%%'", col, Like(w)))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s%%'", col, entity.LikeOp(), Like(w)))
 		} else {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s'", col, Like(w)))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s'", col, entity.LikeOp(), Like(w)))
 		}
 	}
 
@@ -145,9 +146,9 @@ func LikeAllNames(cols Cols, s string) (wheres []string) {
 
 			for _, c := range cols {
 				if strings.Contains(w, txt.Space) {
-					orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s%%'", c, Like(w)))
+					orWheres = append(orWheres, fmt.Sprintf("%s %s '%s%%'", c, entity.LikeOp(), Like(w)))
 				} else {
-					orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%%%s%%'", c, Like(w)))
+					orWheres = append(orWheres, fmt.Sprintf("%s %s '%%%s%%'", c, entity.LikeOp(), Like(w)))
 				}
 			}
 		}
@@ -256,7 +257,7 @@ func OrLike(col, s string) (where string, values []interface{}) {
 		}
 	}
 
-	like := fmt.Sprintf("%s LIKE ?", col)
+	like := fmt.Sprintf("%s %s ?", col, entity.LikeOp())
 	where = like + strings.Repeat(" OR "+like, len(terms)-1)
 
 	return where, values
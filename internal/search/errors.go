@@ -12,4 +12,5 @@ var (
 	ErrBadSortOrder = fmt.Errorf("invalid sort order")
 	ErrBadFilter    = fmt.Errorf("invalid search filter")
 	ErrInvalidId    = fmt.Errorf("invalid ID specified")
+	ErrSemantic     = fmt.Errorf("semantic search is not enabled")
 )
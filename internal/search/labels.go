@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -61,7 +62,7 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		if result := Db().First(&label, "label_slug = ? OR custom_slug = ?", slugString, slugString); result.Error != nil {
 			log.Infof("search: label %s not found", clean.Log(f.Query))
 
-			s = s.Where("labels.label_name LIKE ?", likeString)
+			s = s.Where(fmt.Sprintf("labels.label_name %s ?", entity.LikeOp()), likeString)
 		} else {
 			labelIds = append(labelIds, label.ID)
 
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Links finds share links, with the most recently created links first. The search query
+// matches the link comment, slug, or token, so that scripts can find the links they have created.
+func Links(f form.SearchLinks) (result entity.Links, err error) {
+	result = entity.Links{}
+	stmt := Db()
+
+	search := strings.TrimSpace(f.Query)
+	share := strings.TrimSpace(f.Share)
+	limit := f.Count
+	offset := f.Offset
+
+	if rnd.IsUID(share, 0) {
+		stmt = stmt.Where("share_uid = ?", share)
+	} else if share != "" {
+		stmt = stmt.Where("share_slug = ?", clean.Token(share))
+	}
+
+	if search != "" {
+		stmt = stmt.Where(fmt.Sprintf("comment %[1]s ? OR share_slug %[1]s ? OR link_token = ?", entity.LikeOp()), "%"+search+"%", search+"%", search)
+	}
+
+	if !f.Before.IsZero() {
+		stmt = stmt.Where("created_at < ?", f.Before)
+	}
+
+	if f.CreatedBy != "" {
+		stmt = stmt.Where("created_by = ?", f.CreatedBy)
+	}
+
+	stmt = stmt.Order("created_at DESC, link_uid")
+
+	// Whether a link has expired depends on its settings and the number of views, so the
+	// results must be filtered before limit and offset can be applied.
+	if !f.Expired {
+		if limit > 0 {
+			stmt = stmt.Limit(limit)
+
+			if offset > 0 {
+				stmt = stmt.Offset(offset)
+			}
+		}
+
+		err = stmt.Find(&result).Error
+
+		return result, err
+	}
+
+	var found entity.Links
+
+	if err = stmt.Find(&found).Error; err != nil {
+		return result, err
+	}
+
+	for i := range found {
+		if found[i].Expired() {
+			result = append(result, found[i])
+		}
+	}
+
+	if offset >= len(result) {
+		return entity.Links{}, nil
+	} else if offset > 0 {
+		result = result[offset:]
+	}
+
+	if limit > 0 && limit < len(result) {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestLinks(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		if results, err := Links(form.SearchLinks{}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 2, len(results))
+		}
+	})
+	t.Run("Limit", func(t *testing.T) {
+		if results, err := Links(form.SearchLinks{Count: 1}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, results, 1)
+		}
+	})
+	t.Run("Share", func(t *testing.T) {
+		if results, err := Links(form.SearchLinks{Share: "at9lxuqxpogaaba8"}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 1, len(results))
+
+			for _, link := range results {
+				assert.Equal(t, "at9lxuqxpogaaba8", link.ShareUID)
+			}
+		}
+	})
+	t.Run("Slug", func(t *testing.T) {
+		if results, err := Links(form.SearchLinks{Share: "holiday-2030"}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 1, len(results))
+		}
+	})
+	t.Run("Before", func(t *testing.T) {
+		if results, err := Links(form.SearchLinks{Before: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, results, 0)
+		}
+	})
+	t.Run("Expired", func(t *testing.T) {
+		if results, err := Links(form.SearchLinks{Expired: true}); err != nil {
+			t.Fatal(err)
+		} else {
+			for _, link := range results {
+				assert.True(t, link.Expired())
+			}
+		}
+	})
+}
@@ -45,6 +45,13 @@ func PhotoIds(f form.SearchPhotos) (files PhotoResults, count int, err error) {
 	return searchPhotos(f, nil, "photos.id, photos.photo_uid, files.file_uid")
 }
 
+// UserPhotoIds finds photo and file ids based on the search form and user session.
+func UserPhotoIds(f form.SearchPhotos, sess *entity.Session) (files PhotoResults, count int, err error) {
+	f.Merged = false
+	f.Primary = true
+	return searchPhotos(f, sess, "photos.id, photos.photo_uid, files.file_uid")
+}
+
 // searchPhotos finds photos based on the search form and user session then returns them as PhotoResults.
 func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string) (results PhotoResults, count int, err error) {
 	start := time.Now()
@@ -71,6 +78,9 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		f.Album = ""
 	}
 
+	// Pictures in manually created albums can be sorted in a custom order.
+	customOrder := false
+
 	// Limit search results to a specific UID scope, e.g. when sharing.
 	if txt.NotEmpty(f.Scope) {
 		f.Scope = strings.ToLower(f.Scope)
@@ -80,8 +90,11 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		} else if a, err := entity.CachedAlbumByUID(f.Scope); err != nil || a.AlbumUID == "" {
 			return PhotoResults{}, 0, ErrInvalidId
 		} else if a.AlbumFilter == "" {
-			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = files.photo_uid").
+			// Also return the user who added each picture and its album-specific caption.
+			s = s.Select(resultCols+", photos_albums.created_by AS added_by, photos_albums.caption AS album_caption").
+				Joins("JOIN photos_albums ON photos_albums.photo_uid = files.photo_uid").
 				Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", a.AlbumUID)
+			customOrder = true
 		} else if err = form.Unserialize(&f, a.AlbumFilter); err != nil {
 			return PhotoResults{}, 0, ErrBadFilter
 		} else {
@@ -123,15 +136,44 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 
 		// Limit results for external users.
 		if f.Scope == "" && acl.Resources.DenyAll(acl.ResourcePhotos, aclRole, acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
-			sharedAlbums := "photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN (?)) OR "
+			shared, values := sharedPhotos(sess.SharedUIDs())
 
 			if sess.IsVisitor() || sess.NotRegistered() {
-				s = s.Where(sharedAlbums+"photos.published_at > ?", sess.SharedUIDs(), entity.TimeStamp())
+				s = s.Where(shared+"photos.published_at > ?", append(values, entity.TimeStamp())...)
 			} else if basePath := user.GetBasePath(); basePath == "" {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.TimeStamp())
+				s = s.Where(shared+"photos.created_by = ? OR photos.published_at > ?", append(values, user.UserUID, entity.TimeStamp())...)
 			} else {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
-					sess.SharedUIDs(), user.UserUID, entity.TimeStamp(), basePath, basePath+"/%")
+				s = s.Where(shared+fmt.Sprintf("photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path %s ?", entity.LikeOp()),
+					append(values, user.UserUID, entity.TimeStamp(), basePath, basePath+"/%")...)
+			}
+		}
+	}
+
+	// Exclude photos in locked albums unless they have been unlocked in the session.
+	if sess != nil {
+		if where, values := lockedPhotos(sess, "files.photo_uid"); where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
+	// Exclude pictures that may be offensive if the user policy requires it.
+	if sess != nil {
+		if where, values := nsfwPhotos(sess, "photos.photo_nsfw"); where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
+	// Find pictures that match a free-text description, most similar first if sorted by relevance.
+	if txt.NotEmpty(f.Semantic) {
+		if uids, err := semanticPhotos(f.Semantic); err != nil {
+			return PhotoResults{}, 0, err
+		} else if len(uids) == 0 {
+			return PhotoResults{}, 0, nil
+		} else {
+			s = s.Where("photos.photo_uid IN (?)", uids)
+
+			if f.Order == sortby.Relevance {
+				s = s.Order(semanticOrder("photos.photo_uid", uids))
 			}
 		}
 	}
@@ -146,6 +188,8 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		} else {
 			s = s.Order("photos.photo_quality DESC, files.time_index")
 		}
+	case sortby.Worst:
+		s = s.Order("photos.photo_score <= 0, photos.photo_score, photos.photo_quality, files.time_index")
 	case sortby.Duration:
 		s = s.Order("photos.photo_duration DESC, files.time_index")
 	case sortby.Size:
@@ -161,6 +205,12 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Order("photos.photo_path, photos.photo_name, files.time_index")
 	case sortby.Random:
 		s = s.Order(sortby.RandomExpr(s.Dialect()))
+	case sortby.Custom:
+		if customOrder {
+			s = s.Order(fmt.Sprintf("photos_albums.%s, files.media_id", s.Dialect().Quote("order")))
+		} else {
+			s = s.Order("files.media_id")
+		}
 	case sortby.Default, sortby.Imported, sortby.Added:
 		s = s.Order("files.media_id")
 	default:
@@ -249,8 +299,17 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 				}
 			}
 
-			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = files.photo_id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
-				Group("photos.id, files.id")
+			// Include more specific labels, e.g. beagle when searching for animal.
+			labelIds = entity.LabelDescendantIDs(labelIds)
+
+			// Avoid grouping the results, which requires a temporary table with large libraries.
+			if f.Order == sortby.Relevance {
+				// Join one row per picture with the lowest uncertainty for sorting by relevance.
+				s = s.Joins("JOIN (SELECT pl.photo_id, MIN(pl.uncertainty) AS uncertainty FROM photos_labels pl "+
+					"WHERE pl.uncertainty < 100 AND pl.label_id IN (?) GROUP BY pl.photo_id) photos_labels ON photos_labels.photo_id = files.photo_id", labelIds)
+			} else {
+				s = s.Where("EXISTS (SELECT 1 FROM photos_labels pl WHERE pl.photo_id = files.photo_id AND pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
+			}
 		}
 	}
 
@@ -346,6 +405,9 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 				}
 			}
 
+			// Include more specific labels, e.g. beagle when searching for animal.
+			labelIds = entity.LabelDescendantIDs(labelIds)
+
 			if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
 				for _, where := range wheres {
 					s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
@@ -383,20 +445,16 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		// Do nothing.
 	} else if len(f.Face) >= 32 {
 		for _, f := range SplitAnd(strings.ToUpper(f.Face)) {
-			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE face_id IN (?))",
-				entity.Marker{}.TableName()), SplitOr(f))
+			s = s.Where(markerPhotos("files.photo_id", "", "m.face_id IN (?)"), SplitOr(f))
 		}
 	} else if txt.New(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(markerPhotos("files.photo_id", "", "m.marker_type = ? AND (m.subj_uid IS NULL OR m.subj_uid = '')"), entity.MarkerFace)
 	} else if txt.No(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NULL OR face_id = '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(markerPhotos("files.photo_id", "", "m.marker_type = ? AND (m.face_id IS NULL OR m.face_id = '')"), entity.MarkerFace)
 	} else if txt.Yes(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NOT NULL AND face_id <> '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(markerPhotos("files.photo_id", "", "m.marker_type = ? AND m.face_id IS NOT NULL AND m.face_id <> ''"), entity.MarkerFace)
 	} else if txt.IsUInt(f.Face) {
-		s = s.Where("files.photo_id IN (SELECT photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? JOIN faces ON faces.id = m.face_id WHERE m.face_id IS NOT NULL AND m.face_id <> '' AND faces.face_kind = ?)",
+		s = s.Where(markerPhotos("files.photo_id", "JOIN faces ON faces.id = m.face_id", "m.marker_type = ? AND m.face_id IS NOT NULL AND m.face_id <> '' AND faces.face_kind = ?"),
 			entity.MarkerFace, txt.Int(f.Face))
 	}
 
@@ -404,20 +462,31 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	if txt.NotEmpty(f.Subject) {
 		for _, subj := range SplitAnd(strings.ToLower(f.Subject)) {
 			if subjects := SplitOr(subj); rnd.ContainsUID(subjects, 'j') {
-				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE subj_uid IN (?))",
-					entity.Marker{}.TableName()), subjects)
+				s = s.Where(markerPhotos("files.photo_id", "", "m.subj_uid IN (?)"), subjects)
 			} else {
-				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
-					entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
+				s = s.Where(markerPhotos("files.photo_id", subjectsJoin(), "?"), gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
 			}
 		}
 	} else if txt.NotEmpty(f.Subjects) {
 		for _, where := range LikeAllNames(Cols{"subj_name", "subj_alias"}, f.Subjects) {
-			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
-				entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(where))
+			s = s.Where(markerPhotos("files.photo_id", subjectsJoin(), "?"), gorm.Expr(where))
 		}
 	}
 
+	// Filter by the age of the people shown, e.g. <5 or 3-5.
+	ageJoins := subjectsJoin() + " JOIN photos p ON p.id = f.photo_id"
+
+	if where := AgeCondition(AgeSql("p", "s"), f.Age); where == "" {
+		// Do nothing.
+	} else if subjects := SplitOr(strings.ReplaceAll(strings.ToLower(f.Subject), txt.And, txt.Or)); len(subjects) == 0 {
+		s = s.Where(markerPhotos("files.photo_id", ageJoins, "p.photo_year > 0 AND s.subj_birth_year > 0 AND "+where))
+	} else if rnd.ContainsUID(subjects, 'j') {
+		s = s.Where(markerPhotos("files.photo_id", ageJoins, "p.photo_year > 0 AND s.subj_birth_year > 0 AND m.subj_uid IN (?) AND "+where), subjects)
+	} else {
+		s = s.Where(markerPhotos("files.photo_id", ageJoins, "p.photo_year > 0 AND s.subj_birth_year > 0 AND (?) AND "+where),
+			gorm.Expr(AnySlug("s.subj_slug", strings.Join(subjects, txt.Or), txt.Or)))
+	}
+
 	// Filter by status.
 	if f.Hidden {
 		s = s.Where("photos.photo_quality = -1")
@@ -441,12 +510,34 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		}
 	}
 
+	// Filter by image quality score, e.g. <0.3 or 0.5-1.
+	if where := ScoreCondition("photos.photo_score", f.Score); where != "" {
+		s = s.Where("photos.photo_score > 0").Where(where)
+	}
+
+	// Filter by altitude in meters, e.g. >1500 or 1000-2500.
+	if where := AltitudeCondition("photos.photo_altitude", f.Alt); where != "" {
+		s = s.Where("photos.photo_altitude <> 0").Where(where)
+	}
+
+	// Filter by natural light, e.g. goldenhour or sunset.
+	if txt.NotEmpty(f.Light) {
+		if where, values := lightCondition(SplitOr(f.Light)); where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
+	// Filter by moon phase, e.g. full.
+	if txt.NotEmpty(f.Moon) {
+		s = s.Where("photos.photo_moon IN (?)", SplitOr(strings.ToLower(f.Moon)))
+	}
+
 	// Filter by camera id or name.
 	if txt.IsPosInt(f.Camera) {
 		s = s.Where("photos.camera_id = ?", txt.UInt(f.Camera))
 	} else if txt.NotEmpty(f.Camera) {
 		v := strings.Trim(f.Camera, "*%") + "%"
-		s = s.Where("cameras.camera_name LIKE ? OR cameras.camera_model LIKE ? OR cameras.camera_slug LIKE ?", v, v, v)
+		s = s.Where(fmt.Sprintf("cameras.camera_name %[1]s ? OR cameras.camera_model %[1]s ? OR cameras.camera_slug %[1]s ?", entity.LikeOp()), v, v, v)
 	}
 
 	// Filter by lens id or name.
@@ -454,7 +545,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.lens_id = ?", txt.UInt(f.Lens))
 	} else if txt.NotEmpty(f.Lens) {
 		v := strings.Trim(f.Lens, "*%") + "%"
-		s = s.Where("lenses.lens_name LIKE ? OR lenses.lens_model LIKE ? OR lenses.lens_slug LIKE ?", v, v, v)
+		s = s.Where(fmt.Sprintf("lenses.lens_name %[1]s ? OR lenses.lens_model %[1]s ? OR lenses.lens_slug %[1]s ?", entity.LikeOp()), v, v, v)
 	}
 
 	// Filter by year.
@@ -492,6 +583,11 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.photo_panorama = 1")
 	}
 
+	// Find blurry and poorly exposed pictures only.
+	if f.Blurry {
+		s = s.Where("photos.photo_blurry = 1 OR photos.photo_bad_exposure = 1")
+	}
+
 	// Find portrait/landscape/square pictures only.
 	if f.Portrait {
 		s = s.Where("files.file_portrait = 1")
@@ -522,6 +618,12 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("places.place_city IN (?)", SplitOr(f.City))
 	}
 
+	// Filter by custom place name or place label.
+	if txt.NotEmpty(f.Place) {
+		where, values := placeCondition(SplitOr(f.Place))
+		s = s.Where(where, values...)
+	}
+
 	// Filter by location category.
 	if txt.NotEmpty(f.Category) {
 		s = s.Joins("JOIN cells ON photos.cell_id = cells.id").
@@ -654,7 +756,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid WHERE pa.hidden = 0 AND a.deleted_at IS NULL)")
 		} else if txt.NotEmpty(f.Album) {
 			v := strings.Trim(f.Album, "*%") + "%"
-			s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (a.album_title LIKE ? OR a.album_slug LIKE ?))", v, v)
+			s = s.Where(fmt.Sprintf("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (a.album_title %[1]s ? OR a.album_slug %[1]s ?))", entity.LikeOp()), v, v)
 		} else if txt.NotEmpty(f.Albums) {
 			for _, where := range LikeAnyWord("a.album_title", f.Albums) {
 				s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (?))", gorm.Expr(where))
@@ -674,6 +776,19 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		return results, 0, err
 	}
 
+	// Blur pictures that may be offensive if the user policy requires it.
+	if nsfwPolicy(sess) == entity.NSFWBlur {
+		results.blurNSFW()
+	}
+
+	// Show custom place names instead of reverse-geocoded labels.
+	results.placeAliases()
+
+	// Hide the exact position of pictures taken in privacy zones.
+	if sess.PrivacyFenced() {
+		results.fencePrivacy()
+	}
+
 	// Log number of results.
 	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
 
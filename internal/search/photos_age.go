@@ -0,0 +1,56 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AgeSql returns an SQL expression for the age of a subject in years when a picture was taken,
+// based on the photo and subject table aliases.
+func AgeSql(photos, subjects string) string {
+	return fmt.Sprintf("(%[1]s.photo_year - %[2]s.subj_birth_year - "+
+		"CASE WHEN %[1]s.photo_month > 0 AND %[2]s.subj_birth_month > 0 AND "+
+		"(%[1]s.photo_month < %[2]s.subj_birth_month OR %[1]s.photo_month = %[2]s.subj_birth_month AND %[1]s.photo_day > 0 AND %[1]s.photo_day < %[2]s.subj_birth_day) "+
+		"THEN 1 ELSE 0 END)", photos, subjects)
+}
+
+// AgeCondition returns a where condition that compares the expression with an age,
+// e.g. "5", "<5", "<=5", ">18", ">=18", or "3-5".
+func AgeCondition(expr, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if expr == "" || s == "" {
+		return ""
+	}
+
+	// Range, e.g. 3-5?
+	if i := strings.Index(s, "-"); i > 0 {
+		min, errMin := strconv.Atoi(strings.TrimSpace(s[:i]))
+		max, errMax := strconv.Atoi(strings.TrimSpace(s[i+1:]))
+
+		if errMin != nil || errMax != nil || min < 0 || max < min {
+			return ""
+		}
+
+		return fmt.Sprintf("%s BETWEEN %d AND %d", expr, min, max)
+	}
+
+	op := "="
+
+	for _, prefix := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			s = strings.TrimSpace(s[len(prefix):])
+			break
+		}
+	}
+
+	age, err := strconv.Atoi(s)
+
+	if err != nil || age < 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s %d", expr, op, age)
+}
@@ -0,0 +1,27 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgeSql(t *testing.T) {
+	result := AgeSql("p", "s")
+	assert.Contains(t, result, "p.photo_year - s.subj_birth_year")
+	assert.Contains(t, result, "p.photo_day < s.subj_birth_day")
+}
+
+func TestAgeCondition(t *testing.T) {
+	assert.Equal(t, "age = 5", AgeCondition("age", "5"))
+	assert.Equal(t, "age < 5", AgeCondition("age", "<5"))
+	assert.Equal(t, "age <= 5", AgeCondition("age", "<= 5"))
+	assert.Equal(t, "age > 18", AgeCondition("age", ">18"))
+	assert.Equal(t, "age >= 18", AgeCondition("age", ">=18"))
+	assert.Equal(t, "age BETWEEN 3 AND 5", AgeCondition("age", "3-5"))
+	assert.Equal(t, "", AgeCondition("age", "5-3"))
+	assert.Equal(t, "", AgeCondition("age", "<abc"))
+	assert.Equal(t, "", AgeCondition("age", "-3"))
+	assert.Equal(t, "", AgeCondition("age", ""))
+	assert.Equal(t, "", AgeCondition("", "5"))
+}
@@ -0,0 +1,51 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AltitudeCondition returns a where condition that compares the expression with an altitude in meters,
+// e.g. "1500", "<0", "<=500", ">2000", ">=2000", or "1000-2500". Altitudes below sea level may be
+// negative, so that "-400-0" is also a valid range. A single altitude without operator finds values
+// greater than or equal to it.
+func AltitudeCondition(expr, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if expr == "" || s == "" {
+		return ""
+	}
+
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(s, op) {
+			if alt, err := strconv.Atoi(strings.TrimSpace(s[len(op):])); err == nil {
+				return fmt.Sprintf("%s %s %d", expr, op, alt)
+			}
+
+			return ""
+		}
+	}
+
+	// Range, e.g. 1000-2500? The first character may be the sign of a negative minimum.
+	if i := strings.Index(s[1:], "-"); i >= 0 {
+		i++
+
+		min, errMin := strconv.Atoi(strings.TrimSpace(s[:i]))
+		max, errMax := strconv.Atoi(strings.TrimSpace(s[i+1:]))
+
+		if errMin != nil || errMax != nil || max < min {
+			return ""
+		}
+
+		return fmt.Sprintf("%s BETWEEN %d AND %d", expr, min, max)
+	}
+
+	alt, err := strconv.Atoi(s)
+
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s >= %d", expr, alt)
+}
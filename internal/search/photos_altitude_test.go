@@ -0,0 +1,24 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAltitudeCondition(t *testing.T) {
+	assert.Equal(t, "alt >= 1500", AltitudeCondition("alt", "1500"))
+	assert.Equal(t, "alt < 0", AltitudeCondition("alt", "<0"))
+	assert.Equal(t, "alt <= 500", AltitudeCondition("alt", "<= 500"))
+	assert.Equal(t, "alt > 2000", AltitudeCondition("alt", ">2000"))
+	assert.Equal(t, "alt >= -10", AltitudeCondition("alt", ">=-10"))
+	assert.Equal(t, "alt >= -20", AltitudeCondition("alt", "-20"))
+	assert.Equal(t, "alt BETWEEN 1000 AND 2500", AltitudeCondition("alt", "1000-2500"))
+	assert.Equal(t, "alt BETWEEN -400 AND 0", AltitudeCondition("alt", "-400-0"))
+	assert.Equal(t, "alt BETWEEN -400 AND -100", AltitudeCondition("alt", "-400--100"))
+	assert.Equal(t, "", AltitudeCondition("alt", "2500-1000"))
+	assert.Equal(t, "", AltitudeCondition("alt", "<abc"))
+	assert.Equal(t, "", AltitudeCondition("alt", "1.5"))
+	assert.Equal(t, "", AltitudeCondition("alt", ""))
+	assert.Equal(t, "", AltitudeCondition("", "1500"))
+}
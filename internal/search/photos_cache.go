@@ -0,0 +1,41 @@
+package search
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// PhotosCacheKey returns a key that identifies the results of a photo search, so that they can be cached.
+// Besides the search form, it includes all session properties that affect the results, such as the user
+// role, shared albums, unlocked albums, and the NSFW policy.
+func PhotosCacheKey(f form.SearchPhotos, sess *entity.Session) string {
+	j, err := json.Marshal(f)
+
+	if err != nil {
+		return ""
+	}
+
+	h := sha1.New()
+	h.Write(j)
+
+	if sess != nil {
+		user := sess.User()
+
+		h.Write([]byte(strings.Join([]string{
+			user.UserUID,
+			user.AclRole().String(),
+			user.GetBasePath(),
+			sess.AuthScope,
+			strings.Join(sess.SharedUIDs(), ","),
+			strings.Join(LockedAlbums(sess), ","),
+			nsfwPolicy(sess),
+		}, "\n")))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
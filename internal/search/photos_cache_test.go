@@ -0,0 +1,26 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosCacheKey(t *testing.T) {
+	f := form.SearchPhotos{Count: 10, Order: "newest"}
+
+	alice := PhotosCacheKey(f, entity.SessionFixtures.Pointer("alice"))
+	bob := PhotosCacheKey(f, entity.SessionFixtures.Pointer("bob"))
+
+	assert.Len(t, alice, 40)
+	assert.Equal(t, alice, PhotosCacheKey(f, entity.SessionFixtures.Pointer("alice")))
+	assert.NotEqual(t, alice, bob)
+	assert.NotEqual(t, alice, PhotosCacheKey(f, nil))
+
+	f.Favorite = true
+
+	assert.NotEqual(t, alice, PhotosCacheKey(f, entity.SessionFixtures.Pointer("alice")))
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterAge(t *testing.T) {
+	t.Run("LessThan", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Age = "<5"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Age = "<abc"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+}
+
+func TestPhotosQueryAge(t *testing.T) {
+	t.Run("PersonAndAge", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "person:\"Actress A\" age:3-5"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+	t.Run("SubjectUID", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "subject:jqy1y111h1njaaac age:>=18"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterAlt(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Alt = "1-10"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoAltitude, 1)
+			assert.LessOrEqual(t, p.PhotoAltitude, 10)
+		}
+	})
+	t.Run("GreaterThan", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Alt = ">1500"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+	t.Run("QueryBelowSeaLevel", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "alt:<0"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Alt = "<abc"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+}
+
+func TestPhotosGeoFilterAlt(t *testing.T) {
+	var f form.SearchPhotosGeo
+
+	f.Alt = "1-10"
+
+	photos, err := PhotosGeo(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(photos), 1)
+}
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterLight(t *testing.T) {
+	t.Run("GoldenHour", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Light = "goldenhour|sunset"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Contains(t, []string{"goldenhour", "bluehour"}, p.PhotoLight)
+		}
+	})
+	t.Run("QueryMoon", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "moon:full"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Equal(t, "full", p.PhotoMoon)
+		}
+	})
+}
+
+func TestPhotosGeoFilterLight(t *testing.T) {
+	var f form.SearchPhotosGeo
+
+	f.Light = "night"
+	f.Moon = "new|full"
+
+	if _, err := PhotosGeo(f); err != nil {
+		t.Fatal(err)
+	}
+}
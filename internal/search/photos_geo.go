@@ -32,10 +32,43 @@ func PhotosGeo(f form.SearchPhotosGeo) (results GeoResults, err error) {
 func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoResults, err error) {
 	start := time.Now()
 
+	s, uidOnly, err := geoQuery(&f, sess, GeoCols)
+
+	if err != nil {
+		return GeoResults{}, err
+	}
+
+	// Limit offset and count.
+	if uidOnly {
+		// Find UIDs only to improve performance.
+	} else if f.Count > 0 {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(1000000).Offset(f.Offset)
+	}
+
+	// Fetch results.
+	if result := s.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	// Hide the exact position of pictures taken in privacy zones.
+	if sess.PrivacyFenced() {
+		results = results.fencePrivacy()
+	}
+
+	log.Debugf("places: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// geoQuery returns a query for pictures with a location that match the search form and session permissions.
+// If uidOnly is true, the remaining search filters have been skipped to improve performance.
+func geoQuery(f *form.SearchPhotosGeo, sess *entity.Session, resultCols string) (s *gorm.DB, uidOnly bool, err error) {
 	// Parse query string and filter.
 	if err = f.ParseQueryString(); err != nil {
 		log.Debugf("search: %s", err)
-		return GeoResults{}, ErrBadRequest
+		return nil, false, ErrBadRequest
 	}
 
 	S2Levels := 7
@@ -46,7 +79,7 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 
 		// Find photo to get location.
 		if err = Db().First(&photo, "photo_uid = ?", f.Near).Error; err != nil {
-			return GeoResults{}, err
+			return nil, false, err
 		}
 
 		f.S2 = photo.CellID
@@ -57,7 +90,7 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 	}
 
 	// Specify table names and joins.
-	s := UnscopedDb().Table(entity.Photo{}.TableName()).Select(GeoCols).
+	s = UnscopedDb().Table(entity.Photo{}.TableName()).Select(resultCols).
 		Joins(`JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.media_id IS NOT NULL`).
 		Joins("LEFT JOIN places ON photos.place_id = places.id").
 		Where("photos.deleted_at IS NULL").
@@ -77,14 +110,14 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		f.Scope = strings.ToLower(f.Scope)
 
 		if idType, idPrefix := rnd.IdType(f.Scope); idType != rnd.TypeUID || idPrefix != entity.AlbumUID {
-			return GeoResults{}, ErrInvalidId
+			return nil, false, ErrInvalidId
 		} else if a, err := entity.CachedAlbumByUID(f.Scope); err != nil || a.AlbumUID == "" {
-			return GeoResults{}, ErrInvalidId
+			return nil, false, ErrInvalidId
 		} else if a.AlbumFilter == "" {
 			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = files.photo_uid").
 				Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", a.AlbumUID)
-		} else if err = form.Unserialize(&f, a.AlbumFilter); err != nil {
-			return GeoResults{}, ErrBadFilter
+		} else if err = form.Unserialize(f, a.AlbumFilter); err != nil {
+			return nil, false, ErrBadFilter
 		} else {
 			f.Filter = a.AlbumFilter
 			s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", a.AlbumUID)
@@ -116,24 +149,38 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		if f.Scope != "" && !sess.HasShare(f.Scope) && (sess.IsVisitor() || sess.NotRegistered()) ||
 			f.Scope == "" && acl.Resources.Deny(acl.ResourcePlaces, aclRole, acl.ActionSearch) {
 			event.AuditErr([]string{sess.IP(), "session %s", "%s %s as %s", "denied"}, sess.RefID, acl.ActionSearch.String(), string(acl.ResourcePlaces), aclRole)
-			return GeoResults{}, ErrForbidden
+			return nil, false, ErrForbidden
 		}
 
 		// Limit results for external users.
 		if f.Scope == "" && acl.Resources.DenyAll(acl.ResourcePlaces, aclRole, acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
-			sharedAlbums := "photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN (?)) OR "
+			shared, values := sharedPhotos(sess.SharedUIDs())
 
 			if sess.IsVisitor() || sess.NotRegistered() {
-				s = s.Where(sharedAlbums+"photos.published_at > ?", sess.SharedUIDs(), entity.TimeStamp())
+				s = s.Where(shared+"photos.published_at > ?", append(values, entity.TimeStamp())...)
 			} else if basePath := user.GetBasePath(); basePath == "" {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.TimeStamp())
+				s = s.Where(shared+"photos.created_by = ? OR photos.published_at > ?", append(values, user.UserUID, entity.TimeStamp())...)
 			} else {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
-					sess.SharedUIDs(), user.UserUID, entity.TimeStamp(), basePath, basePath+"/%")
+				s = s.Where(shared+fmt.Sprintf("photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path %s ?", entity.LikeOp()),
+					append(values, user.UserUID, entity.TimeStamp(), basePath, basePath+"/%")...)
 			}
 		}
 	}
 
+	// Exclude photos in locked albums unless they have been unlocked in the session.
+	if sess != nil {
+		if where, values := lockedPhotos(sess, "photos.photo_uid"); where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
+	// Exclude pictures that may be offensive if the user policy requires it.
+	if sess != nil {
+		if where, values := nsfwPhotos(sess, "photos.photo_nsfw"); where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
 	// Set sort order.
 	if f.Near == "" {
 		s = s.Order("taken_at, photos.photo_uid")
@@ -148,7 +195,7 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		idType, prefix := rnd.ContainsType(ids)
 
 		if idType == rnd.TypeUnknown {
-			return GeoResults{}, fmt.Errorf("%s ids specified", idType)
+			return nil, false, fmt.Errorf("%s ids specified", idType)
 		} else if idType.SHA() {
 			s = s.Where("files.file_hash IN (?)", ids)
 		} else if idType == rnd.TypeUID {
@@ -158,20 +205,13 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 			case entity.FileUID:
 				s = s.Where("files.file_uid IN (?)", ids)
 			default:
-				return GeoResults{}, fmt.Errorf("invalid ids specified")
+				return nil, false, fmt.Errorf("invalid ids specified")
 			}
 		}
 
 		// Find UIDs only to improve performance.
 		if sess == nil && f.FindUidOnly() {
-			// Fetch results.
-			if result := s.Scan(&results); result.Error != nil {
-				return results, result.Error
-			}
-
-			log.Debugf("places: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
-
-			return results, nil
+			return s, true, nil
 		}
 	}
 
@@ -270,6 +310,9 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 				}
 			}
 
+			// Include more specific labels, e.g. beagle when searching for animal.
+			labelIds = entity.LabelDescendantIDs(labelIds)
+
 			if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
 				for _, where := range wheres {
 					s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
@@ -307,20 +350,16 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		// Do nothing.
 	} else if len(f.Face) >= 32 {
 		for _, f := range SplitAnd(strings.ToUpper(f.Face)) {
-			s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE face_id IN (?))",
-				entity.Marker{}.TableName()), SplitOr(f))
+			s = s.Where(markerPhotos("photos.id", "", "m.face_id IN (?)"), SplitOr(f))
 		}
 	} else if txt.New(f.Face) {
-		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(markerPhotos("photos.id", "", "m.marker_type = ? AND (m.subj_uid IS NULL OR m.subj_uid = '')"), entity.MarkerFace)
 	} else if txt.No(f.Face) {
-		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NULL OR face_id = '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(markerPhotos("photos.id", "", "m.marker_type = ? AND (m.face_id IS NULL OR m.face_id = '')"), entity.MarkerFace)
 	} else if txt.Yes(f.Face) {
-		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NOT NULL AND face_id <> '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(markerPhotos("photos.id", "", "m.marker_type = ? AND m.face_id IS NOT NULL AND m.face_id <> ''"), entity.MarkerFace)
 	} else if txt.IsUInt(f.Face) {
-		s = s.Where("files.photo_id IN (SELECT photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? JOIN faces ON faces.id = m.face_id WHERE m.face_id IS NOT NULL AND m.face_id <> '' AND faces.face_kind = ?)",
+		s = s.Where(markerPhotos("photos.id", "JOIN faces ON faces.id = m.face_id", "m.marker_type = ? AND m.face_id IS NOT NULL AND m.face_id <> '' AND faces.face_kind = ?"),
 			entity.MarkerFace, txt.Int(f.Face))
 	}
 
@@ -328,17 +367,14 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 	if f.Subject != "" {
 		for _, subj := range SplitAnd(strings.ToLower(f.Subject)) {
 			if subjects := SplitOr(subj); rnd.ContainsUID(subjects, 'j') {
-				s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE subj_uid IN (?))",
-					entity.Marker{}.TableName()), subjects)
+				s = s.Where(markerPhotos("photos.id", "", "m.subj_uid IN (?)"), subjects)
 			} else {
-				s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
-					entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
+				s = s.Where(markerPhotos("photos.id", subjectsJoin(), "?"), gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
 			}
 		}
 	} else if f.Subjects != "" {
 		for _, where := range LikeAllNames(Cols{"subj_name", "subj_alias"}, f.Subjects) {
-			s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
-				entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(where))
+			s = s.Where(markerPhotos("photos.id", subjectsJoin(), "?"), gorm.Expr(where))
 		}
 	}
 
@@ -348,7 +384,7 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid WHERE pa.hidden = 0 AND a.deleted_at IS NULL)")
 		} else if txt.NotEmpty(f.Album) {
 			v := strings.Trim(f.Album, "*%") + "%"
-			s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (a.album_title LIKE ? OR a.album_slug LIKE ?))", v, v)
+			s = s.Where(fmt.Sprintf("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (a.album_title %[1]s ? OR a.album_slug %[1]s ?))", entity.LikeOp()), v, v)
 		} else if txt.NotEmpty(f.Albums) {
 			for _, where := range LikeAnyWord("a.album_title", f.Albums) {
 				s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (?))", gorm.Expr(where))
@@ -401,6 +437,11 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		s = s.Where("photos.photo_panorama = 1")
 	}
 
+	// Find blurry and poorly exposed pictures only.
+	if f.Blurry {
+		s = s.Where("photos.photo_blurry = 1 OR photos.photo_bad_exposure = 1")
+	}
+
 	// Find portrait/landscape/square pictures only.
 	if f.Portrait {
 		s = s.Where("files.file_portrait = 1")
@@ -425,6 +466,12 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		s = s.Where("places.place_city IN (?)", SplitOr(f.City))
 	}
 
+	// Filter by custom place name or place label.
+	if txt.NotEmpty(f.Place) {
+		where, values := placeCondition(SplitOr(f.Place))
+		s = s.Where(where, values...)
+	}
+
 	// Filter by media type.
 	if txt.NotEmpty(f.Type) {
 		s = s.Where("photos.photo_type IN (?)", SplitOr(strings.ToLower(f.Type)))
@@ -496,6 +543,28 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		}
 	}
 
+	// Filter by image quality score, e.g. <0.3 or 0.5-1.
+	if where := ScoreCondition("photos.photo_score", f.Score); where != "" {
+		s = s.Where("photos.photo_score > 0").Where(where)
+	}
+
+	// Filter by altitude in meters, e.g. >1500 or 1000-2500.
+	if where := AltitudeCondition("photos.photo_altitude", f.Alt); where != "" {
+		s = s.Where("photos.photo_altitude <> 0").Where(where)
+	}
+
+	// Filter by natural light, e.g. goldenhour or sunset.
+	if txt.NotEmpty(f.Light) {
+		if where, values := lightCondition(SplitOr(f.Light)); where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
+	// Filter by moon phase, e.g. full.
+	if txt.NotEmpty(f.Moon) {
+		s = s.Where("photos.photo_moon IN (?)", SplitOr(strings.ToLower(f.Moon)))
+	}
+
 	// Filter by chroma.
 	if f.Mono {
 		s = s.Where("files.file_chroma = 0")
@@ -525,6 +594,16 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		}
 	}
 
+	// Filter by bounding box, e.g. the visible map area.
+	if f.BBox != "" {
+		if box, ok := ParseBBox(f.BBox); !ok {
+			return nil, false, ErrBadFilter
+		} else {
+			where, values := box.Where("photos.photo_lat", "photos.photo_lng")
+			s = s.Where(where, values...)
+		}
+	}
+
 	// Find photos taken before date.
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
@@ -535,19 +614,5 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
 	}
 
-	// Limit offset and count.
-	if f.Count > 0 {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	} else {
-		s = s.Limit(1000000).Offset(f.Offset)
-	}
-
-	// Fetch results.
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	log.Debugf("places: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
-
-	return results, nil
+	return s, false, nil
 }
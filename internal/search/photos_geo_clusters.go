@@ -0,0 +1,208 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	geojson "github.com/paulmach/go.geojson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// GeoClusterMaxZoom is the highest map zoom level supported for clustering.
+const GeoClusterMaxZoom = 20
+
+// GeoClusterCols specifies the columns of the subquery that is aggregated into clusters.
+var GeoClusterCols = "photos.photo_uid, photos.photo_lat, photos.photo_lng, photos.cell_id"
+
+// BBox represents a geographic bounding box in degrees.
+type BBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+// ParseBBox parses a bounding box string in the format "west,south,east,north".
+func ParseBBox(s string) (box BBox, ok bool) {
+	values := strings.Split(s, ",")
+
+	if len(values) != 4 {
+		return box, false
+	}
+
+	var v [4]float64
+
+	for i := range values {
+		f, err := strconv.ParseFloat(strings.TrimSpace(values[i]), 64)
+
+		if err != nil {
+			return box, false
+		}
+
+		v[i] = f
+	}
+
+	box = BBox{West: v[0], South: v[1], East: v[2], North: v[3]}
+
+	if box.South < -90 || box.North > 90 || box.South > box.North ||
+		box.West < -180 || box.West > 180 || box.East < -180 || box.East > 180 {
+		return BBox{}, false
+	}
+
+	return box, true
+}
+
+// Where returns an SQL condition with values that matches coordinates within the bounding box.
+// Boxes that cross the antimeridian have a western longitude greater than the eastern longitude.
+func (b BBox) Where(latCol, lngCol string) (where string, values []interface{}) {
+	if b.West <= b.East {
+		return fmt.Sprintf("%s BETWEEN ? AND ? AND %s BETWEEN ? AND ?", latCol, lngCol), []interface{}{b.South, b.North, b.West, b.East}
+	}
+
+	return fmt.Sprintf("%s BETWEEN ? AND ? AND (%s >= ? OR %s <= ?)", latCol, lngCol, lngCol), []interface{}{b.South, b.North, b.West, b.East}
+}
+
+// GeoCluster represents the number of pictures in a map area, based on S2 cells.
+type GeoCluster struct {
+	Cell     string  `json:"ID"`
+	Count    int     `json:"Count"`
+	Lat      float64 `json:"Lat"`
+	Lng      float64 `json:"Lng"`
+	MinLat   float64 `json:"-"`
+	MaxLat   float64 `json:"-"`
+	MinLng   float64 `json:"-"`
+	MaxLng   float64 `json:"-"`
+	PhotoUID string  `json:"UID"`
+}
+
+// GeoClusters represents a list of clusters.
+type GeoClusters []GeoCluster
+
+// GeoClusterPrefix returns the number of characters of a prefixed S2 cell token that are compared for clustering
+// at the specified map zoom level. Higher zoom levels result in smaller clusters.
+func GeoClusterPrefix(zoom int) int {
+	if zoom < 0 {
+		zoom = 0
+	} else if zoom > GeoClusterMaxZoom {
+		zoom = GeoClusterMaxZoom
+	}
+
+	// Each hex character of a token encodes two S2 levels, and clusters should be
+	// about a quarter of a map tile in size, i.e. two levels below the zoom level.
+	return len(s2.TokenPrefix) + (zoom+2)/2 + 1
+}
+
+// PhotosGeoClusters finds GeoClusters based on the search form without checking rights or permissions.
+func PhotosGeoClusters(f form.SearchPhotosGeo) (results GeoClusters, err error) {
+	return UserPhotosGeoClusters(f, nil)
+}
+
+// UserPhotosGeoClusters aggregates the pictures that match the search form and session permissions into clusters
+// depending on the map zoom level, so that large libraries can be displayed on a map without returning every picture.
+func UserPhotosGeoClusters(f form.SearchPhotosGeo, sess *entity.Session) (results GeoClusters, err error) {
+	start := time.Now()
+
+	// Keep the unparsed form for finding pictures in privacy zones.
+	orig := f
+
+	s, _, err := geoQuery(&f, sess, GeoClusterCols)
+
+	if err != nil {
+		return GeoClusters{}, err
+	}
+
+	// Pictures taken in privacy zones must not reveal their position.
+	fenced := sess.PrivacyFenced()
+
+	if fenced {
+		where, values := privacyZonesWhere("photos.photo_lat", "photos.photo_lng")
+		s = s.Where("NOT ("+where+")", values...)
+	}
+
+	if results, err = geoClusters(s, GeoClusterPrefix(f.Zoom)); err != nil {
+		return GeoClusters{}, err
+	}
+
+	if fenced {
+		if clusters, err := fencedGeoClusters(orig, sess); err != nil {
+			return GeoClusters{}, err
+		} else {
+			results = append(results, clusters...)
+		}
+	}
+
+	log.Debugf("places: found %s for %s [%s]", english.Plural(len(results), "cluster", "clusters"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// geoClusters aggregates the pictures found with the query by the specified number of S2 cell token characters.
+func geoClusters(s *gorm.DB, prefix int) (results GeoClusters, err error) {
+	stmt := UnscopedDb().Raw(fmt.Sprintf(`SELECT SUBSTR(g.cell_id, 1, %d) AS cell, COUNT(*) AS count,
+		AVG(g.photo_lat) AS lat, AVG(g.photo_lng) AS lng,
+		MIN(g.photo_lat) AS min_lat, MAX(g.photo_lat) AS max_lat,
+		MIN(g.photo_lng) AS min_lng, MAX(g.photo_lng) AS max_lng,
+		MIN(g.photo_uid) AS photo_uid
+		FROM ? g WHERE g.cell_id %s ? GROUP BY cell ORDER BY count DESC, cell`, prefix, entity.LikeOp()), s.SubQuery(), s2.TokenPrefix+"%")
+
+	if err = stmt.Scan(&results).Error; err != nil {
+		return GeoClusters{}, err
+	}
+
+	return results, nil
+}
+
+// Photos returns the total number of pictures in all clusters.
+func (clusters GeoClusters) Photos() (n int) {
+	for _, c := range clusters {
+		n += c.Count
+	}
+
+	return n
+}
+
+// GeoJSON returns the clusters as point features, as specified on https://geojson.org/.
+func (clusters GeoClusters) GeoJSON() ([]byte, error) {
+	fc := geojson.NewFeatureCollection()
+
+	bbox := make([]float64, 4)
+
+	for i, c := range clusters {
+		if i == 0 || c.MinLng < bbox[0] {
+			bbox[0] = c.MinLng
+		}
+
+		if i == 0 || c.MinLat < bbox[1] {
+			bbox[1] = c.MinLat
+		}
+
+		if i == 0 || c.MaxLng > bbox[2] {
+			bbox[2] = c.MaxLng
+		}
+
+		if i == 0 || c.MaxLat > bbox[3] {
+			bbox[3] = c.MaxLat
+		}
+
+		feat := geojson.NewPointFeature([]float64{c.Lng, c.Lat})
+		feat.ID = c.Cell
+		feat.BoundingBox = []float64{c.MinLng, c.MinLat, c.MaxLng, c.MaxLat}
+		feat.Properties = gin.H{
+			"Count": c.Count,
+			"UID":   c.PhotoUID,
+		}
+		fc.AddFeature(feat)
+	}
+
+	fc.BoundingBox = bbox
+
+	return fc.MarshalJSON()
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestParseBBox(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		box, ok := ParseBBox("13.1, 52.3, 13.7, 52.7")
+
+		assert.True(t, ok)
+		assert.Equal(t, BBox{West: 13.1, South: 52.3, East: 13.7, North: 52.7}, box)
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		box, ok := ParseBBox("170,-20,-170,10")
+
+		assert.True(t, ok)
+		assert.Equal(t, 170.0, box.West)
+		assert.Equal(t, -170.0, box.East)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{"", "13.1,52.3,13.7", "a,b,c,d", "13.1,52.7,13.7,52.3", "13.1,-91,13.7,52.7", "-181,52.3,13.7,52.7"} {
+			_, ok := ParseBBox(s)
+			assert.False(t, ok, s)
+		}
+	})
+}
+
+func TestBBox_Where(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		where, values := BBox{West: 13.1, South: 52.3, East: 13.7, North: 52.7}.Where("lat", "lng")
+
+		assert.Equal(t, "lat BETWEEN ? AND ? AND lng BETWEEN ? AND ?", where)
+		assert.Equal(t, []interface{}{52.3, 52.7, 13.1, 13.7}, values)
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		where, values := BBox{West: 170, South: -20, East: -170, North: 10}.Where("lat", "lng")
+
+		assert.Equal(t, "lat BETWEEN ? AND ? AND (lng >= ? OR lng <= ?)", where)
+		assert.Equal(t, []interface{}{-20.0, 10.0, 170.0, -170.0}, values)
+	})
+}
+
+func TestGeoClusterPrefix(t *testing.T) {
+	assert.Equal(t, 5, GeoClusterPrefix(-1))
+	assert.Equal(t, 5, GeoClusterPrefix(0))
+	assert.Equal(t, 8, GeoClusterPrefix(6))
+	assert.Equal(t, 15, GeoClusterPrefix(20))
+	assert.Equal(t, 15, GeoClusterPrefix(25))
+}
+
+func TestPhotosGeoClusters(t *testing.T) {
+	t.Run("World", func(t *testing.T) {
+		results, err := PhotosGeoClusters(form.SearchPhotosGeo{Zoom: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(results), results.Photos())
+
+		if len(results) > 0 {
+			assert.NotEmpty(t, results[0].PhotoUID)
+			assert.GreaterOrEqual(t, results[0].Count, 1)
+		}
+	})
+	t.Run("BBox", func(t *testing.T) {
+		all, err := PhotosGeo(form.SearchPhotosGeo{BBox: "-180,-90,180,90"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := PhotosGeoClusters(form.SearchPhotosGeo{BBox: "-180,-90,180,90", Zoom: 20})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, results.Photos(), len(all))
+	})
+	t.Run("InvalidBBox", func(t *testing.T) {
+		_, err := PhotosGeoClusters(form.SearchPhotosGeo{BBox: "1,2,3"})
+
+		assert.Equal(t, ErrBadFilter, err)
+	})
+}
+
+func TestGeoClusters_GeoJSON(t *testing.T) {
+	clusters := GeoClusters{
+		{Cell: "s2:47a8", Count: 3, Lat: 52.5, Lng: 13.4, MinLat: 52.4, MaxLat: 52.6, MinLng: 13.3, MaxLng: 13.5, PhotoUID: "pq9jtd41e4wcjbzt"},
+		{Cell: "s2:1477", Count: 1, Lat: 40.7, Lng: -74, MinLat: 40.7, MaxLat: 40.7, MinLng: -74, MaxLng: -74, PhotoUID: "pq9jtd41e4wcjbzu"},
+	}
+
+	b, err := clusters.GeoJSON()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 4, clusters.Photos())
+	assert.Contains(t, string(b), `"bbox":[-74,40.7,13.5,52.6]`)
+	assert.Contains(t, string(b), `"Count":3`)
+	assert.Contains(t, string(b), `"id":"s2:47a8"`)
+}
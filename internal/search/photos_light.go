@@ -0,0 +1,43 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/astro"
+)
+
+// Light filter values that match the golden and blue hour in the morning or evening.
+const (
+	LightSunrise = "sunrise"
+	LightSunset  = "sunset"
+)
+
+// lightCondition returns an SQL condition with values that matches pictures taken in one of the
+// specified types of natural light, e.g. "goldenhour" or "sunset".
+func lightCondition(values []string) (where string, args []interface{}) {
+	var conditions []string
+
+	twilight := []string{astro.LightGoldenHour, astro.LightBlueHour}
+
+	for _, v := range values {
+		switch v = strings.ToLower(strings.TrimSpace(v)); v {
+		case "":
+			continue
+		case LightSunrise:
+			conditions = append(conditions, "photos.photo_light IN (?) AND photos.photo_sun = ?")
+			args = append(args, twilight, astro.SunRising)
+		case LightSunset:
+			conditions = append(conditions, "photos.photo_light IN (?) AND photos.photo_sun = ?")
+			args = append(args, twilight, astro.SunSetting)
+		default:
+			conditions = append(conditions, "photos.photo_light = ?")
+			args = append(args, strings.ReplaceAll(v, " ", ""))
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "(" + strings.Join(conditions, ") OR (") + ")", args
+}
@@ -0,0 +1,30 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/astro"
+)
+
+func TestLightCondition(t *testing.T) {
+	t.Run("GoldenHour", func(t *testing.T) {
+		where, args := lightCondition([]string{"GoldenHour"})
+
+		assert.Equal(t, "(photos.photo_light = ?)", where)
+		assert.Equal(t, []interface{}{astro.LightGoldenHour}, args)
+	})
+	t.Run("SunsetOrBlueHour", func(t *testing.T) {
+		where, args := lightCondition([]string{"sunset", "blue hour"})
+
+		assert.Equal(t, "(photos.photo_light IN (?) AND photos.photo_sun = ?) OR (photos.photo_light = ?)", where)
+		assert.Equal(t, []interface{}{[]string{astro.LightGoldenHour, astro.LightBlueHour}, astro.SunSetting, astro.LightBlueHour}, args)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		where, args := lightCondition([]string{"", " "})
+
+		assert.Equal(t, "", where)
+		assert.Nil(t, args)
+	})
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// LockedAlbums returns the UIDs of albums locked by the session user that have not been unlocked in the session.
+func LockedAlbums(sess *entity.Session) (uids entity.UIDs) {
+	if sess == nil {
+		return uids
+	}
+
+	locked := entity.LockedAlbumUIDs(sess.UserUID)
+
+	if len(locked) == 0 {
+		return uids
+	}
+
+	for _, uid := range locked {
+		if !sess.HasUnlocked(uid) {
+			uids = append(uids, uid)
+		}
+	}
+
+	return uids
+}
+
+// lockedPhotos returns an SQL condition with values that excludes photos in locked albums
+// that have not been unlocked in the session, or an empty string if there are none.
+func lockedPhotos(sess *entity.Session, col string) (where string, values []interface{}) {
+	uids := LockedAlbums(sess)
+
+	if len(uids) == 0 {
+		return "", nil
+	}
+
+	return col + " NOT IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))", []interface{}{uids}
+}
+
+// PhotoLocked checks if the photo is in a locked album that has not been unlocked in the session.
+func PhotoLocked(photoUID string, sess *entity.Session) bool {
+	uids := LockedAlbums(sess)
+
+	if len(uids) == 0 || photoUID == "" {
+		return false
+	}
+
+	var count int
+
+	if err := UnscopedDb().Table(entity.PhotoAlbum{}.TableName()).
+		Where("photo_uid = ? AND hidden = 0 AND album_uid IN (?)", photoUID, uids).
+		Count(&count).Error; err != nil {
+		log.Errorf("search: %s (check locked albums)", err)
+		return true
+	}
+
+	return count > 0
+}
+
+// FileLocked checks if the file with the specified hash belongs to a photo in a locked album
+// that has not been unlocked in the session.
+func FileLocked(fileHash string, sess *entity.Session) bool {
+	uids := LockedAlbums(sess)
+
+	if len(uids) == 0 || fileHash == "" {
+		return false
+	}
+
+	var count int
+
+	if err := UnscopedDb().Table(entity.PhotoAlbum{}.TableName()).
+		Where("hidden = 0 AND album_uid IN (?)", uids).
+		Where("photo_uid IN (SELECT photo_uid FROM files WHERE file_hash = ?)", fileHash).
+		Count(&count).Error; err != nil {
+		log.Errorf("search: %s (check locked albums)", err)
+		return true
+	}
+
+	return count > 0
+}
+
+// AlbumLocked checks if the album is locked and has not been unlocked in the session.
+func AlbumLocked(albumUID string, sess *entity.Session) bool {
+	for _, uid := range LockedAlbums(sess) {
+		if uid == albumUID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestLockedPhotos(t *testing.T) {
+	t.Run("NoLockedAlbums", func(t *testing.T) {
+		where, values := lockedPhotos(entity.SessionFixtures.Pointer("alice"), "files.photo_uid")
+		assert.Equal(t, "", where)
+		assert.Nil(t, values)
+	})
+	t.Run("Locked", func(t *testing.T) {
+		sess := entity.SessionFixtures.Pointer("alice")
+		photo := entity.PhotoFixtures.Pointer("Photo01")
+		fileHash := entity.FileFixtures.Pointer("exampleDNGFile.dng").FileHash
+
+		a := entity.NewUserAlbum("Locked Photos", entity.AlbumManual, sess.UserUID)
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			assert.NoError(t, entity.UnscopedDb().Delete(entity.PhotoAlbum{}, "album_uid = ?", a.AlbumUID).Error)
+			assert.NoError(t, a.DeletePermanently())
+		}()
+
+		a.AddPhotos([]string{photo.PhotoUID})
+
+		if err := a.Lock("1234"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, AlbumLocked(a.AlbumUID, sess))
+		assert.True(t, PhotoLocked(photo.PhotoUID, sess))
+		assert.True(t, FileLocked(fileHash, sess))
+
+		where, values := lockedPhotos(sess, "files.photo_uid")
+		assert.Contains(t, where, "NOT IN")
+		assert.Len(t, values, 1)
+
+		results, _, err := UserPhotos(form.SearchPhotos{Scope: a.AlbumUID, Count: 10}, sess)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+
+		// Locks only apply to the album owner.
+		other := entity.SessionFixtures.Pointer("bob")
+		assert.False(t, AlbumLocked(a.AlbumUID, other))
+		assert.False(t, PhotoLocked(photo.PhotoUID, other))
+		assert.False(t, FileLocked(fileHash, other))
+		assert.False(t, FileLocked(fileHash, nil))
+	})
+}
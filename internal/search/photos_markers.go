@@ -0,0 +1,24 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// markerPhotos returns a condition that matches pictures with valid markers. The subquery starts with the
+// markers table, so that the composite indexes on subj_uid and face_id cover the lookup of the file UIDs,
+// and the files index on file_uid covers the photo IDs, without reading the table rows.
+func markerPhotos(col, joins, where string) string {
+	if joins != "" {
+		joins = " " + joins
+	}
+
+	return fmt.Sprintf("%s IN (SELECT f.photo_id FROM %s m JOIN %s f ON f.file_uid = m.file_uid%s WHERE m.marker_invalid = 0 AND (%s))",
+		col, entity.Marker{}.TableName(), entity.File{}.TableName(), joins, where)
+}
+
+// subjectsJoin returns the join clause for filtering markers by subject properties.
+func subjectsJoin() string {
+	return fmt.Sprintf("JOIN %s s ON s.subj_uid = m.subj_uid", entity.Subject{}.TableName())
+}
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// nsfwPolicy returns the policy for pictures that may be offensive that applies to the session user.
+func nsfwPolicy(sess *entity.Session) string {
+	if sess == nil {
+		return entity.NSFWShow
+	}
+
+	return sess.User().NSFWPolicy()
+}
+
+// nsfwPhotos returns an SQL condition with values that excludes pictures that may be offensive
+// if they should be hidden from the session user, or an empty string otherwise.
+func nsfwPhotos(sess *entity.Session, col string) (where string, values []interface{}) {
+	if nsfwPolicy(sess) != entity.NSFWHide {
+		return "", nil
+	}
+
+	return col + " <= ?", []interface{}{entity.NSFWThreshold}
+}
+
+// blurNSFW marks the results that may be offensive so that previews can be blurred.
+func (m PhotoResults) blurNSFW() {
+	for i := range m {
+		m[i].Blur = m[i].PhotoNSFW > entity.NSFWThreshold
+	}
+}
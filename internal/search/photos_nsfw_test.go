@@ -0,0 +1,39 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestNSFWPhotos(t *testing.T) {
+	t.Run("NoSession", func(t *testing.T) {
+		where, values := nsfwPhotos(nil, "photos.photo_nsfw")
+		assert.Equal(t, "", where)
+		assert.Nil(t, values)
+	})
+	t.Run("Show", func(t *testing.T) {
+		where, values := nsfwPhotos(entity.SessionFixtures.Pointer("alice"), "photos.photo_nsfw")
+		assert.Equal(t, "", where)
+		assert.Nil(t, values)
+	})
+	t.Run("Hide", func(t *testing.T) {
+		entity.DefaultNSFWPolicy = entity.NSFWHide
+		defer func() { entity.DefaultNSFWPolicy = entity.NSFWShow }()
+
+		where, values := nsfwPhotos(entity.SessionFixtures.Pointer("alice"), "photos.photo_nsfw")
+		assert.Equal(t, "photos.photo_nsfw <= ?", where)
+		assert.Equal(t, []interface{}{entity.NSFWThreshold}, values)
+	})
+}
+
+func TestPhotoResults_BlurNSFW(t *testing.T) {
+	results := PhotoResults{{PhotoNSFW: 0.99}, {PhotoNSFW: 0.5}, {}}
+	results.blurNSFW()
+
+	assert.True(t, results[0].Blur)
+	assert.False(t, results[1].Blur)
+	assert.False(t, results[2].Blur)
+}
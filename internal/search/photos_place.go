@@ -0,0 +1,52 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// placeCondition returns an SQL condition with values that matches pictures taken at one of the specified places.
+// Custom places take precedence, so the reverse-geocoded place label is only searched for names that
+// do not match a custom place.
+func placeCondition(names []string) (string, []interface{}) {
+	var where []string
+	var values []interface{}
+
+	aliases := entity.CachedPlaceAliases()
+
+	for _, name := range names {
+		if matches := aliases.Named(name); len(matches) > 0 {
+			for _, m := range matches {
+				latMin, latMax, lngMin, lngMax := m.Bounds()
+				where = append(where, "photos.photo_lat BETWEEN ? AND ? AND photos.photo_lng BETWEEN ? AND ?")
+				values = append(values, latMin, latMax, lngMin, lngMax)
+			}
+		} else if name = strings.TrimSpace(name); name != "" {
+			where = append(where, fmt.Sprintf("places.place_label %s ?", entity.LikeOp()))
+			values = append(values, "%"+name+"%")
+		}
+	}
+
+	if len(where) == 0 {
+		return "1 = 0", nil
+	}
+
+	return "(" + strings.Join(where, ") OR (") + ")", values
+}
+
+// placeAliases replaces the reverse-geocoded place labels with the names of matching custom places.
+func (m PhotoResults) placeAliases() {
+	aliases := entity.CachedPlaceAliases()
+
+	if len(aliases) == 0 {
+		return
+	}
+
+	for i := range m {
+		if a := aliases.At(float64(m[i].PhotoLat), float64(m[i].PhotoLng)); a != nil {
+			m[i].PlaceLabel = a.AliasName
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPlaceCondition(t *testing.T) {
+	t.Run("Label", func(t *testing.T) {
+		where, values := placeCondition([]string{"Berlin", " "})
+
+		assert.Equal(t, "(places.place_label LIKE ?)", where)
+		assert.Equal(t, []interface{}{"%Berlin%"}, values)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		where, values := placeCondition(nil)
+
+		assert.Equal(t, "1 = 0", where)
+		assert.Empty(t, values)
+	})
+}
+
+func TestPhotosPlaceAlias(t *testing.T) {
+	m := entity.NewPlaceAlias("Grandma's House", 48.519234, 9.057997, 500)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = m.Delete()
+	}()
+
+	where, values := placeCondition([]string{"grandma"})
+
+	assert.Equal(t, "(photos.photo_lat BETWEEN ? AND ? AND photos.photo_lng BETWEEN ? AND ?)", where)
+	assert.Len(t, values, 4)
+
+	photos, _, err := Photos(form.SearchPhotos{Query: "place:\"Grandma's House\"", Count: 10})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range photos {
+		assert.Equal(t, "Grandma's House", p.PlaceLabel)
+	}
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// fencePrivacy fuzzes or strips the coordinates of pictures taken in privacy zones.
+func (m PhotoResults) fencePrivacy() {
+	for i := range m {
+		if !entity.PrivacyFenced(float64(m[i].PhotoLat), float64(m[i].PhotoLng)) {
+			continue
+		}
+
+		lat, lng, cellID := entity.PrivacyPosition(float64(m[i].PhotoLat), float64(m[i].PhotoLng))
+
+		m[i].PhotoLat = float32(lat)
+		m[i].PhotoLng = float32(lng)
+		m[i].PhotoAltitude = 0
+		m[i].CellID = cellID
+		m[i].CellAccuracy = 0
+	}
+}
+
+// fencePrivacy fuzzes the coordinates of pictures taken in privacy zones, or removes them from the results
+// if coordinates must be stripped, as they cannot be displayed on a map without.
+func (m GeoResults) fencePrivacy() GeoResults {
+	results := make(GeoResults, 0, len(m))
+
+	for _, p := range m {
+		if !entity.PrivacyFenced(p.Lat(), p.Lng()) {
+			results = append(results, p)
+		} else if lat, lng, cellID := entity.PrivacyPosition(p.Lat(), p.Lng()); cellID != entity.UnknownID {
+			p.PhotoLat = float32(lat)
+			p.PhotoLng = float32(lng)
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
+
+// privacyZonesWhere returns an SQL condition with values that matches coordinates within the bounds of privacy zones.
+func privacyZonesWhere(latCol, lngCol string) (where string, values []interface{}) {
+	if len(entity.PrivacyZones) == 0 {
+		return "", nil
+	}
+
+	conditions := make([]string, len(entity.PrivacyZones))
+
+	for i, z := range entity.PrivacyZones {
+		latMin, latMax, lngMin, lngMax := z.Bounds()
+		conditions[i] = fmt.Sprintf("%s BETWEEN ? AND ? AND %s BETWEEN ? AND ?", latCol, lngCol)
+		values = append(values, latMin, latMax, lngMin, lngMax)
+	}
+
+	return "(" + strings.Join(conditions, ") OR (") + ")", values
+}
+
+// fencedGeoClusters returns the pictures taken in privacy zones as clusters at their fuzzed positions,
+// or no clusters if coordinates must be stripped.
+func fencedGeoClusters(f form.SearchPhotosGeo, sess *entity.Session) (results GeoClusters, err error) {
+	if entity.PrivacyFence == entity.PrivacyStrip {
+		return results, nil
+	}
+
+	for _, z := range entity.PrivacyZones {
+		zf := f
+		s, _, err := geoQuery(&zf, sess, GeoClusterCols)
+
+		if err != nil {
+			return results, err
+		}
+
+		latMin, latMax, lngMin, lngMax := z.Bounds()
+		s = s.Where("photos.photo_lat BETWEEN ? AND ? AND photos.photo_lng BETWEEN ? AND ?", latMin, latMax, lngMin, lngMax)
+
+		// Aggregate all pictures in the zone into a single cluster.
+		clusters, err := geoClusters(s, 0)
+
+		if err != nil {
+			return results, err
+		}
+
+		for _, c := range clusters {
+			lat, lng, cellID := entity.PrivacyPosition(z.Lat, z.Lng)
+			results = append(results, GeoCluster{
+				Cell:     cellID,
+				Count:    c.Count,
+				Lat:      lat,
+				Lng:      lng,
+				MinLat:   lat,
+				MaxLat:   lat,
+				MinLng:   lng,
+				MaxLng:   lng,
+				PhotoUID: c.PhotoUID,
+			})
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestPhotoResults_FencePrivacy(t *testing.T) {
+	entity.PrivacyZones = geo.Zones{{Lat: 52.5208, Lng: 13.4094, Radius: 500}}
+	defer func() { entity.PrivacyZones = nil }()
+
+	results := PhotoResults{
+		{PhotoUID: "pt9jtdre2lvl0yh7", PhotoLat: 52.5209, PhotoLng: 13.4095, PhotoAltitude: 35, CellID: "s2:47a851e1a3b4", CellAccuracy: 5},
+		{PhotoUID: "pt9jtdre2lvl0yh8", PhotoLat: 48.1351, PhotoLng: 11.5820, CellID: "s2:479e7f"},
+	}
+
+	results.fencePrivacy()
+
+	assert.NotEqual(t, float32(52.5209), results[0].PhotoLat)
+	assert.Equal(t, "s2:47a84e1c", results[0].CellID)
+	assert.Equal(t, 0, results[0].PhotoAltitude)
+	assert.Equal(t, float32(48.1351), results[1].PhotoLat)
+	assert.Equal(t, "s2:479e7f", results[1].CellID)
+}
+
+func TestGeoResults_FencePrivacy(t *testing.T) {
+	entity.PrivacyZones = geo.Zones{{Lat: 52.5208, Lng: 13.4094, Radius: 500}}
+	defer func() { entity.PrivacyZones = nil }()
+
+	results := GeoResults{
+		{PhotoUID: "pt9jtdre2lvl0yh7", PhotoLat: 52.5209, PhotoLng: 13.4095},
+		{PhotoUID: "pt9jtdre2lvl0yh8", PhotoLat: 48.1351, PhotoLng: 11.5820},
+	}
+
+	t.Run("Fuzz", func(t *testing.T) {
+		fenced := results.fencePrivacy()
+
+		assert.Len(t, fenced, 2)
+		assert.NotEqual(t, float32(52.5209), fenced[0].PhotoLat)
+		assert.Equal(t, float32(52.5209), results[0].PhotoLat)
+	})
+	t.Run("Strip", func(t *testing.T) {
+		entity.PrivacyFence = entity.PrivacyStrip
+		defer func() { entity.PrivacyFence = entity.PrivacyFuzz }()
+
+		fenced := results.fencePrivacy()
+
+		assert.Len(t, fenced, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", fenced[0].PhotoUID)
+	})
+}
+
+func TestPrivacyZonesWhere(t *testing.T) {
+	where, values := privacyZonesWhere("lat", "lng")
+
+	assert.Equal(t, "", where)
+	assert.Empty(t, values)
+
+	entity.PrivacyZones = geo.Zones{{Lat: 52.5208, Lng: 13.4094, Radius: 500}, {Lat: 48.1351, Lng: 11.5820, Radius: 100}}
+	defer func() { entity.PrivacyZones = nil }()
+
+	where, values = privacyZonesWhere("lat", "lng")
+
+	assert.Equal(t, "(lat BETWEEN ? AND ? AND lng BETWEEN ? AND ?) OR (lat BETWEEN ? AND ? AND lng BETWEEN ? AND ?)", where)
+	assert.Len(t, values, 8)
+}
@@ -41,6 +41,10 @@ type Photo struct {
 	PhotoExposure    string        `json:"Exposure" select:"photos.photo_exposure"`
 	PhotoFaces       int           `json:"Faces,omitempty" select:"photos.photo_faces"`
 	PhotoQuality     int           `json:"Quality" select:"photos.photo_quality"`
+	PhotoScore       float32       `json:"Score" select:"photos.photo_score"`
+	PhotoBlurry      bool          `json:"Blurry" select:"photos.photo_blurry"`
+	PhotoBadExposure bool          `json:"BadExposure" select:"photos.photo_bad_exposure"`
+	PhotoNSFW        float32       `json:"NSFW" select:"photos.photo_nsfw"`
 	PhotoResolution  int           `json:"Resolution" select:"photos.photo_resolution"`
 	PhotoDuration    time.Duration `json:"Duration,omitempty" yaml:"photos.photo_duration"`
 	PhotoColor       int16         `json:"Color" select:"photos.photo_color"`
@@ -57,6 +61,9 @@ type Photo struct {
 	PhotoAltitude    int           `json:"Altitude,omitempty" select:"photos.photo_altitude"`
 	PhotoLat         float32       `json:"Lat" select:"photos.photo_lat"`
 	PhotoLng         float32       `json:"Lng" select:"photos.photo_lng"`
+	PhotoLight       string        `json:"Light,omitempty" select:"photos.photo_light"`
+	PhotoSun         string        `json:"Sun,omitempty" select:"photos.photo_sun"`
+	PhotoMoon        string        `json:"Moon,omitempty" select:"photos.photo_moon"`
 	CellID           string        `json:"CellID" select:"photos.cell_id"` // Cell
 	CellAccuracy     int           `json:"CellAccuracy,omitempty" select:"photos.cell_accuracy"`
 	PlaceID          string        `json:"PlaceID" select:"photos.place_id"`
@@ -94,6 +101,9 @@ type Photo struct {
 	FileChroma       int16         `json:"-" select:"files.file_chroma"`
 	FileLuminance    string        `json:"-" select:"files.file_luminance"`
 	Merged           bool          `json:"Merged" select:"-"`
+	Blur             bool          `json:"Blur,omitempty" select:"-"`
+	AddedBy          string        `json:"AddedBy,omitempty" select:"-"`
+	AlbumCaption     string        `json:"AlbumCaption,omitempty" select:"-"`
 	CreatedAt        time.Time     `json:"CreatedAt" select:"photos.created_at"`
 	UpdatedAt        time.Time     `json:"UpdatedAt" select:"photos.updated_at"`
 	EditedAt         time.Time     `json:"EditedAt,omitempty" select:"photos.edited_at"`
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScoreCondition returns a where condition that compares the expression with an image quality score
+// from 0 to 1, e.g. "0.5", "<0.3", "<=0.3", ">0.7", ">=0.7", or "0.2-0.5". A single score without
+// operator finds values greater than or equal to it.
+func ScoreCondition(expr, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if expr == "" || s == "" {
+		return ""
+	}
+
+	// Range, e.g. 0.2-0.5?
+	if i := strings.Index(s, "-"); i > 0 {
+		min, errMin := strconv.ParseFloat(strings.TrimSpace(s[:i]), 32)
+		max, errMax := strconv.ParseFloat(strings.TrimSpace(s[i+1:]), 32)
+
+		if errMin != nil || errMax != nil || min < 0 || max < min {
+			return ""
+		}
+
+		return fmt.Sprintf("%s BETWEEN %s AND %s", expr, formatScore(min), formatScore(max))
+	}
+
+	op := ">="
+
+	for _, prefix := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			s = strings.TrimSpace(s[len(prefix):])
+			break
+		}
+	}
+
+	score, err := strconv.ParseFloat(s, 32)
+
+	if err != nil || score < 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s %s", expr, op, formatScore(score))
+}
+
+// formatScore returns the score as SQL number literal.
+func formatScore(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 32)
+}
@@ -0,0 +1,21 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreCondition(t *testing.T) {
+	assert.Equal(t, "score >= 0.5", ScoreCondition("score", "0.5"))
+	assert.Equal(t, "score < 0.3", ScoreCondition("score", "<0.3"))
+	assert.Equal(t, "score <= 0.3", ScoreCondition("score", "<= 0.3"))
+	assert.Equal(t, "score > 0.7", ScoreCondition("score", ">.7"))
+	assert.Equal(t, "score >= 1", ScoreCondition("score", ">=1"))
+	assert.Equal(t, "score BETWEEN 0.2 AND 0.5", ScoreCondition("score", "0.2-0.5"))
+	assert.Equal(t, "", ScoreCondition("score", "0.5-0.2"))
+	assert.Equal(t, "", ScoreCondition("score", "<abc"))
+	assert.Equal(t, "", ScoreCondition("score", "-0.3"))
+	assert.Equal(t, "", ScoreCondition("score", ""))
+	assert.Equal(t, "", ScoreCondition("", "0.5"))
+}
@@ -0,0 +1,124 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/semantic"
+)
+
+// SemanticLimit is the maximum number of pictures found with a semantic query.
+var SemanticLimit = 1000
+
+var semanticIndex *semantic.Index
+var semanticState string
+var semanticMutex = sync.Mutex{}
+
+// semanticEmbeddings returns a nearest neighbor index of the stored image embeddings,
+// which is rebuilt whenever embeddings have been added or updated.
+func semanticEmbeddings() (*semantic.Index, error) {
+	semanticMutex.Lock()
+	defer semanticMutex.Unlock()
+
+	model := strings.ToLower(semantic.Model)
+
+	var count int
+	var latest entity.PhotoEmbedding
+
+	if err := UnscopedDb().Model(&entity.PhotoEmbedding{}).Where("model = ?", model).Count(&count).Error; err != nil {
+		return nil, err
+	} else if count == 0 {
+		return nil, nil
+	} else if err = UnscopedDb().Where("model = ?", model).Order("updated_at DESC").First(&latest).Error; err != nil {
+		return nil, err
+	}
+
+	// Keep using the current index if nothing has changed.
+	if state := fmt.Sprintf("%s/%d/%d", model, count, latest.UpdatedAt.UnixNano()); semanticIndex != nil && state == semanticState {
+		return semanticIndex, nil
+	} else {
+		semanticState = state
+	}
+
+	var idx *semantic.Index
+
+	limit := 1000
+
+	for offset := 0; ; offset += limit {
+		var embeddings entity.PhotoEmbeddings
+
+		if err := UnscopedDb().Where("model = ?", model).Order("photo_uid").Limit(limit).Offset(offset).Find(&embeddings).Error; err != nil {
+			return nil, err
+		} else if len(embeddings) == 0 {
+			break
+		}
+
+		for _, m := range embeddings {
+			e := m.Embedding()
+
+			if len(e) == 0 {
+				continue
+			} else if idx == nil {
+				idx = semantic.NewIndex(len(e))
+			}
+
+			if err := idx.Add(m.PhotoUID, e); err != nil {
+				log.Debugf("search: %s (add embedding of %s)", err, m.PhotoUID)
+			}
+		}
+	}
+
+	semanticIndex = idx
+
+	return idx, nil
+}
+
+// semanticPhotos returns the UIDs of pictures matching a free-text description, most similar first.
+func semanticPhotos(text string) (uids []string, err error) {
+	if !semantic.Enabled() {
+		return uids, ErrSemantic
+	}
+
+	q, err := semantic.New(semantic.ServiceUrl).Text(text)
+
+	if err != nil {
+		return uids, err
+	}
+
+	idx, err := semanticEmbeddings()
+
+	if err != nil || idx == nil {
+		return uids, err
+	}
+
+	matches, err := idx.Search(q, SemanticLimit, semantic.MinSimilarity)
+
+	if err != nil {
+		return uids, err
+	}
+
+	return matches.IDs(), nil
+}
+
+// semanticOrder returns an SQL expression that sorts the column values in the order of the specified UIDs.
+func semanticOrder(col string, uids []string) *gorm.SqlExpr {
+	var b strings.Builder
+
+	values := make([]interface{}, len(uids))
+
+	b.WriteString("CASE ")
+	b.WriteString(col)
+
+	for i, uid := range uids {
+		b.WriteString(fmt.Sprintf(" WHEN ? THEN %d", i))
+		values[i] = uid
+	}
+
+	b.WriteString(fmt.Sprintf(" ELSE %d END", len(uids)))
+
+	return gorm.Expr(b.String(), values...)
+}
@@ -0,0 +1,25 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/semantic"
+)
+
+func TestPhotosSemantic(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		semantic.ServiceUrl = ""
+
+		var f form.SearchPhotos
+
+		f.Semantic = "birthday cake with candles"
+		f.Count = 10
+
+		_, _, err := Photos(f)
+
+		assert.Equal(t, ErrSemantic, err)
+	})
+}
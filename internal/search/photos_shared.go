@@ -0,0 +1,21 @@
+package search
+
+import (
+	"fmt"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// sharedPhotos returns an SQL condition with values that matches photos in the shared albums and folders,
+// followed by "OR " so that further conditions can be appended.
+func sharedPhotos(uids entity.UIDs) (where string, values []interface{}) {
+	where = "photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN (?)) OR "
+	values = []interface{}{uids}
+
+	// Folders are matched by path, as their photos are not stored in photos_albums.
+	for _, folderPath := range entity.SharedFolderPaths(uids) {
+		where += fmt.Sprintf("photos.photo_path = ? OR photos.photo_path %s ? OR ", entity.LikeOp())
+		values = append(values, folderPath, folderPath+"/%")
+	}
+
+	return where, values
+}
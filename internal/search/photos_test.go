@@ -1779,3 +1779,35 @@ func TestPhotos(t *testing.T) {
 		assert.Equal(t, photos[0].PhotoTitle, "Neckarbrücke")
 	})
 }
+
+func TestPhotosCustomOrder(t *testing.T) {
+	t.Run("Album", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Scope = "at9lxuqxpogaaba9"
+		f.Order = sortby.Custom
+		f.Count = 10
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("NoAlbum", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Order = sortby.Custom
+		f.Count = 10
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+}
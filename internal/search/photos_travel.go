@@ -0,0 +1,145 @@
+package search
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/maps"
+)
+
+// TravelCols specifies the columns of the subquery that is aggregated into travel statistics.
+var TravelCols = "photos.photo_uid, photos.photo_country, places.place_state, photos.photo_year, photos.photo_month, photos.photo_day"
+
+// travelDate returns an SQL expression that converts the date on which a picture was taken into an
+// integer like 20230514, or NULL if the date is unknown.
+const travelDate = "CASE WHEN g.photo_year > 0 AND g.photo_month > 0 AND g.photo_day > 0 THEN g.photo_year * 10000 + g.photo_month * 100 + g.photo_day END"
+
+// TravelStat represents the number of pictures taken in a country or region, and when it was visited.
+type TravelStat struct {
+	Country    string `json:"Country"`
+	State      string `json:"State,omitempty"`
+	Name       string `json:"Name"`
+	Photos     int    `json:"Photos"`
+	Days       int    `json:"Days"`
+	FirstDate  int    `json:"-"`
+	LastDate   int    `json:"-"`
+	FirstVisit string `json:"FirstVisit"`
+	LastVisit  string `json:"LastVisit"`
+}
+
+// TravelStats represents a list of countries or regions.
+type TravelStats []TravelStat
+
+// HeatmapPoint represents the latitude, longitude, and number of pictures of a heatmap grid cell.
+type HeatmapPoint [3]float64
+
+// Heatmap represents the density of pictures on a map.
+type Heatmap struct {
+	Points []HeatmapPoint `json:"Points"`
+	Max    int            `json:"Max"`
+	Photos int            `json:"Photos"`
+}
+
+// TravelCountries returns the number of pictures and visits per country that match the search form
+// and session permissions, most visited first.
+func TravelCountries(f form.SearchPhotosGeo, sess *entity.Session) (results TravelStats, err error) {
+	start := time.Now()
+
+	if results, err = travelStats(&f, sess, false); err != nil {
+		return TravelStats{}, err
+	}
+
+	for i := range results {
+		results[i].Name = maps.CountryName(results[i].Country)
+	}
+
+	log.Debugf("places: found %s for %s [%s]", english.Plural(len(results), "country", "countries"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// TravelRegions returns the number of pictures and visits per state or region that match the search form
+// and session permissions, most visited first.
+func TravelRegions(f form.SearchPhotosGeo, sess *entity.Session) (results TravelStats, err error) {
+	start := time.Now()
+
+	if results, err = travelStats(&f, sess, true); err != nil {
+		return TravelStats{}, err
+	}
+
+	for i := range results {
+		results[i].Name = results[i].State
+	}
+
+	log.Debugf("places: found %s for %s [%s]", english.Plural(len(results), "region", "regions"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// travelStats aggregates the pictures that match the search form by country, or by country and state.
+func travelStats(f *form.SearchPhotosGeo, sess *entity.Session, regions bool) (results TravelStats, err error) {
+	s, _, err := geoQuery(f, sess, TravelCols)
+
+	if err != nil {
+		return results, err
+	}
+
+	cols, where, groupBy := "g.photo_country AS country", "g.photo_country <> ?", "g.photo_country"
+	values := []interface{}{s.SubQuery(), entity.UnknownCountry.ID}
+
+	if regions {
+		cols, where, groupBy = cols+", g.place_state AS state", where+" AND g.place_state <> '' AND g.place_state <> ?", groupBy+", g.place_state"
+		values = append(values, entity.UnknownPlace.PlaceState)
+	}
+
+	stmt := UnscopedDb().Raw(fmt.Sprintf(`SELECT %[2]s, COUNT(*) AS photos, COUNT(DISTINCT %[1]s) AS days,
+		COALESCE(MIN(%[1]s), 0) AS first_date, COALESCE(MAX(%[1]s), 0) AS last_date
+		FROM ? g WHERE %[3]s GROUP BY %[4]s ORDER BY photos DESC, %[4]s`, travelDate, cols, where, groupBy), values...)
+
+	if err = stmt.Scan(&results).Error; err != nil {
+		return TravelStats{}, err
+	}
+
+	for i := range results {
+		results[i].FirstVisit = travelDateString(results[i].FirstDate)
+		results[i].LastVisit = travelDateString(results[i].LastDate)
+	}
+
+	return results, nil
+}
+
+// travelDateString formats an integer date like 20230514 as "2023-05-14", or returns an empty string if it is unknown.
+func travelDateString(d int) string {
+	if d <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", d/10000, d/100%100, d%100)
+}
+
+// UserPhotosHeatmap returns the density of pictures that match the search form and session permissions
+// as a grid of points, with the cell size depending on the map zoom level.
+func UserPhotosHeatmap(f form.SearchPhotosGeo, sess *entity.Session) (result Heatmap, err error) {
+	clusters, err := UserPhotosGeoClusters(f, sess)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Points = make([]HeatmapPoint, len(clusters))
+
+	for i, c := range clusters {
+		result.Points[i] = HeatmapPoint{c.Lat, c.Lng, float64(c.Count)}
+		result.Photos += c.Count
+
+		if c.Count > result.Max {
+			result.Max = c.Count
+		}
+	}
+
+	return result, nil
+}
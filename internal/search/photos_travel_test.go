@@ -0,0 +1,62 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestTravelCountries(t *testing.T) {
+	results, err := TravelCountries(form.SearchPhotosGeo{}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, results)
+
+	for _, r := range results {
+		assert.NotEqual(t, "zz", r.Country)
+		assert.NotEmpty(t, r.Name)
+		assert.Empty(t, r.State)
+		assert.GreaterOrEqual(t, r.Photos, r.Days)
+		assert.LessOrEqual(t, r.FirstVisit, r.LastVisit)
+	}
+}
+
+func TestTravelRegions(t *testing.T) {
+	results, err := TravelRegions(form.SearchPhotosGeo{}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range results {
+		assert.NotEmpty(t, r.State)
+		assert.Equal(t, r.State, r.Name)
+		assert.NotEqual(t, "Unknown", r.State)
+	}
+}
+
+func TestTravelDateString(t *testing.T) {
+	assert.Equal(t, "2023-05-14", travelDateString(20230514))
+	assert.Equal(t, "0999-01-02", travelDateString(9990102))
+	assert.Equal(t, "", travelDateString(0))
+}
+
+func TestUserPhotosHeatmap(t *testing.T) {
+	result, err := UserPhotosHeatmap(form.SearchPhotosGeo{Zoom: 4}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result.Points)
+	assert.GreaterOrEqual(t, result.Photos, result.Max)
+
+	for _, p := range result.Points {
+		assert.LessOrEqual(t, p[2], float64(result.Max))
+	}
+}
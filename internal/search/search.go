@@ -49,14 +49,14 @@ type Count struct {
 	Total int
 }
 
-// Db returns a database connection instance.
+// Db returns a database connection instance, which may be a read-only replica.
 func Db() *gorm.DB {
-	return entity.Db()
+	return entity.ReadDb()
 }
 
-// UnscopedDb returns an unscoped database connection instance.
+// UnscopedDb returns an unscoped database connection instance, which may be a read-only replica.
 func UnscopedDb() *gorm.DB {
-	return entity.Db().Unscoped()
+	return entity.ReadDb().Unscoped()
 }
 
 // Log logs the error if any and keeps quiet otherwise.
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -22,9 +23,9 @@ func Sessions(f form.SearchSessions) (result entity.Sessions, err error) {
 	if search == "all" {
 		// Don't filter.
 	} else if rnd.IsUID(uid, entity.UserUID) {
-		stmt = stmt.Where("user_uid = ?", search)
+		stmt = stmt.Where("user_uid = ?", uid)
 	} else if search != "" {
-		stmt = stmt.Where("user_name LIKE ? OR auth_provider LIKE ?", search+"%", search+"%")
+		stmt = stmt.Where(fmt.Sprintf("user_name %[1]s ? OR auth_provider %[1]s ?", entity.LikeOp()), search+"%", search+"%")
 	}
 
 	if sortOrder == "" {
@@ -96,6 +96,12 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 		} else if txt.No(f.Excluded) {
 			s = s.Where("subj_excluded = 0")
 		}
+
+		if txt.Yes(f.Ignored) {
+			s = s.Where("subj_ignored = 1")
+		} else {
+			s = s.Where("subj_ignored = 0")
+		}
 	}
 
 	// Omit deleted rows.
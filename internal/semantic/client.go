@@ -0,0 +1,136 @@
+package semantic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ServiceUrl is the URL of the embedding model service used for semantic search, if any.
+var ServiceUrl = ""
+
+// Model is the name under which image embeddings are stored, so that they can be distinguished from
+// embeddings computed with other models.
+var Model = "clip"
+
+// MinSimilarity is the minimum cosine similarity of pictures that match a semantic query.
+var MinSimilarity = 0.2
+
+// Timeout is the time limit for embedding requests.
+var Timeout = 30 * time.Second
+
+// Result represents the response of the embedding model service.
+type Result struct {
+	Embedding Embedding `json:"embedding"`
+}
+
+// Client computes text and image embeddings with a CLIP-style model served over HTTP, so that both
+// can be compared in the same vector space.
+//
+// Text is sent as text/plain and images as image/jpeg in the POST request body. The service is
+// expected to respond with a JSON object like {"embedding": [0.021, -0.143, ...]} in both cases.
+type Client struct {
+	serviceUrl string
+}
+
+// New returns a new embedding client for the specified service URL.
+func New(serviceUrl string) *Client {
+	return &Client{serviceUrl: serviceUrl}
+}
+
+// Enabled tests if a service URL has been configured for semantic search.
+func Enabled() bool {
+	return ServiceUrl != ""
+}
+
+// Init validates the service URL.
+func (c *Client) Init() error {
+	if u, err := url.Parse(c.serviceUrl); err != nil {
+		return fmt.Errorf("semantic: invalid service url (%s)", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("semantic: invalid service url %s", clean.Log(c.serviceUrl))
+	}
+
+	return nil
+}
+
+// Text returns the embedding of a free-text query like "birthday cake with candles".
+func (c *Client) Text(text string) (Embedding, error) {
+	text = strings.Join(strings.Fields(text), " ")
+
+	if text == "" {
+		return nil, fmt.Errorf("semantic: text is empty")
+	}
+
+	return c.request("text/plain; charset=utf-8", []byte(text))
+}
+
+// File returns the embedding of a jpeg media file.
+func (c *Client) File(fileName string) (Embedding, error) {
+	img, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Image(img)
+}
+
+// Image returns the embedding of a jpeg image.
+func (c *Client) Image(img []byte) (Embedding, error) {
+	if len(img) == 0 {
+		return nil, fmt.Errorf("semantic: image is empty")
+	}
+
+	return c.request("image/jpeg", img)
+}
+
+// request sends data to the service and returns the embedding.
+func (c *Client) request(contentType string, data []byte) (Embedding, error) {
+	req, err := http.NewRequest(http.MethodPost, c.serviceUrl, bytes.NewReader(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("semantic: %s (create request)", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: Timeout}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("semantic: %s (request)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("semantic: service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("semantic: %s (read response)", err)
+	}
+
+	var result Result
+
+	if err = json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("semantic: %s (parse response)", err)
+	} else if len(result.Embedding) == 0 {
+		return nil, fmt.Errorf("semantic: service returned an empty embedding")
+	}
+
+	return result.Embedding, nil
+}
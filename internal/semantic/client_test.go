@@ -0,0 +1,84 @@
+package semantic
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Init(t *testing.T) {
+	assert.NoError(t, New("http://localhost:5000/embed").Init())
+	assert.Error(t, New("localhost").Init())
+	assert.Error(t, New("ftp://localhost/").Init())
+}
+
+func TestClient_Text(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "text/plain; charset=utf-8", r.Header.Get("Content-Type"))
+			assert.Equal(t, "birthday cake with candles", string(body))
+			_, _ = w.Write([]byte(`{"embedding": [0.5, -0.25, 1]}`))
+		}))
+
+		defer srv.Close()
+
+		result, err := New(srv.URL).Text(" birthday cake  with candles ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Embedding{0.5, -0.25, 1}, result)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := New("http://localhost:5000/embed").Text("  ")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Image(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+			_, _ = w.Write([]byte(`{"embedding": [0.1, 0.2]}`))
+		}))
+
+		defer srv.Close()
+
+		result, err := New(srv.URL).Image([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+	})
+	t.Run("EmptyEmbedding", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"embedding": []}`))
+		}))
+
+		defer srv.Close()
+
+		_, err := New(srv.URL).Image([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+	t.Run("Error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		defer srv.Close()
+
+		_, err := New(srv.URL).Image([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+}
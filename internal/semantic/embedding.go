@@ -0,0 +1,60 @@
+package semantic
+
+import (
+	"math"
+)
+
+// Embedding represents a text or image embedding vector.
+type Embedding []float64
+
+// Norm returns the euclidean length of the vector.
+func (e Embedding) Norm() float64 {
+	var sum float64
+
+	for _, v := range e {
+		sum += v * v
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Normalized returns a copy of the vector with unit length, or nil if it is empty or zero.
+func (e Embedding) Normalized() Embedding {
+	n := e.Norm()
+
+	if n == 0 {
+		return nil
+	}
+
+	result := make(Embedding, len(e))
+
+	for i, v := range e {
+		result[i] = v / n
+	}
+
+	return result
+}
+
+// Dot returns the dot product of two vectors, or 0 if their dimensions differ.
+func (e Embedding) Dot(other Embedding) float64 {
+	if len(e) != len(other) {
+		return 0
+	}
+
+	var sum float64
+
+	for i := range e {
+		sum += e[i] * other[i]
+	}
+
+	return sum
+}
+
+// Similarity returns the cosine similarity of two vectors, ranging from -1 to 1.
+func (e Embedding) Similarity(other Embedding) float64 {
+	if n := e.Norm() * other.Norm(); n == 0 {
+		return 0
+	} else {
+		return e.Dot(other) / n
+	}
+}
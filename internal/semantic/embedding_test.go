@@ -0,0 +1,20 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedding_Normalized(t *testing.T) {
+	assert.Equal(t, Embedding{0.6, 0.8}, Embedding{3, 4}.Normalized())
+	assert.Nil(t, Embedding{0, 0}.Normalized())
+	assert.Nil(t, Embedding{}.Normalized())
+}
+
+func TestEmbedding_Similarity(t *testing.T) {
+	assert.InDelta(t, 1, Embedding{1, 1}.Similarity(Embedding{2, 2}), 0.0001)
+	assert.InDelta(t, 0, Embedding{1, 0}.Similarity(Embedding{0, 1}), 0.0001)
+	assert.InDelta(t, -1, Embedding{1, 0}.Similarity(Embedding{-1, 0}), 0.0001)
+	assert.Equal(t, float64(0), Embedding{1, 0}.Similarity(Embedding{1, 0, 0}))
+}
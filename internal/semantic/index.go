@@ -0,0 +1,213 @@
+package semantic
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Parameters of the approximate nearest neighbor index.
+var (
+	IndexTables    = 8    // Number of hash tables.
+	IndexBits      = 12   // Number of random hyperplanes per table.
+	ExactSearchMax = 2000 // Indexes with fewer vectors are always searched exhaustively.
+)
+
+// Match represents a search result with its cosine similarity.
+type Match struct {
+	ID         string
+	Similarity float64
+}
+
+// Matches represents a list of search results, ordered by similarity.
+type Matches []Match
+
+// IDs returns the result IDs in order.
+func (m Matches) IDs() []string {
+	result := make([]string, len(m))
+
+	for i := range m {
+		result[i] = m[i].ID
+	}
+
+	return result
+}
+
+// Index is an approximate nearest neighbor index for normalized embeddings. It uses locality-sensitive
+// hashing with random hyperplanes, so that vectors pointing in similar directions end up in the same buckets,
+// and probes neighboring buckets to improve recall.
+type Index struct {
+	mutex   sync.RWMutex
+	dim     int
+	planes  [][]Embedding
+	tables  []map[uint64][]int
+	ids     []string
+	vectors []Embedding
+	pos     map[string]int
+}
+
+// NewIndex returns a new index for vectors with the specified number of dimensions.
+func NewIndex(dim int) *Index {
+	idx := &Index{
+		dim:    dim,
+		planes: make([][]Embedding, IndexTables),
+		tables: make([]map[uint64][]int, IndexTables),
+		pos:    make(map[string]int),
+	}
+
+	// Use a fixed seed so that search results are reproducible.
+	r := rand.New(rand.NewSource(int64(dim)))
+
+	for t := range idx.planes {
+		idx.planes[t] = make([]Embedding, IndexBits)
+		idx.tables[t] = make(map[uint64][]int)
+
+		for b := range idx.planes[t] {
+			plane := make(Embedding, dim)
+
+			for i := range plane {
+				plane[i] = r.NormFloat64()
+			}
+
+			idx.planes[t][b] = plane
+		}
+	}
+
+	return idx
+}
+
+// Dim returns the number of dimensions.
+func (idx *Index) Dim() int {
+	return idx.dim
+}
+
+// Len returns the number of indexed vectors.
+func (idx *Index) Len() int {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	return len(idx.ids)
+}
+
+// hash returns the bucket of a vector in the specified table.
+func (idx *Index) hash(t int, e Embedding) (h uint64) {
+	for b, plane := range idx.planes[t] {
+		if plane.Dot(e) >= 0 {
+			h |= 1 << uint(b)
+		}
+	}
+
+	return h
+}
+
+// Add adds a vector to the index, or replaces it if the ID already exists.
+func (idx *Index) Add(id string, e Embedding) error {
+	if id == "" {
+		return fmt.Errorf("semantic: missing id")
+	} else if len(e) != idx.dim {
+		return fmt.Errorf("semantic: expected %d dimensions, got %d", idx.dim, len(e))
+	}
+
+	e = e.Normalized()
+
+	if e == nil {
+		return fmt.Errorf("semantic: vector of %s is zero", id)
+	}
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if i, ok := idx.pos[id]; ok {
+		// Stale bucket entries are harmless, since candidates are compared with the current vector.
+		idx.vectors[i] = e
+
+		for t := range idx.tables {
+			h := idx.hash(t, e)
+			idx.tables[t][h] = append(idx.tables[t][h], i)
+		}
+
+		return nil
+	}
+
+	i := len(idx.ids)
+
+	idx.ids = append(idx.ids, id)
+	idx.vectors = append(idx.vectors, e)
+	idx.pos[id] = i
+
+	for t := range idx.tables {
+		h := idx.hash(t, e)
+		idx.tables[t][h] = append(idx.tables[t][h], i)
+	}
+
+	return nil
+}
+
+// candidates returns the positions of vectors in the same or a neighboring bucket as the query.
+func (idx *Index) candidates(q Embedding) map[int]bool {
+	result := make(map[int]bool)
+
+	for t := range idx.tables {
+		h := idx.hash(t, q)
+
+		for _, i := range idx.tables[t][h] {
+			result[i] = true
+		}
+
+		// Probe buckets that differ in one bit.
+		for b := 0; b < IndexBits; b++ {
+			for _, i := range idx.tables[t][h^(1<<uint(b))] {
+				result[i] = true
+			}
+		}
+	}
+
+	return result
+}
+
+// Search returns up to limit vectors with a cosine similarity of at least minSim, most similar first.
+func (idx *Index) Search(q Embedding, limit int, minSim float64) (result Matches, err error) {
+	result = Matches{}
+
+	if len(q) != idx.dim {
+		return result, fmt.Errorf("semantic: expected %d dimensions, got %d", idx.dim, len(q))
+	} else if q = q.Normalized(); q == nil {
+		return result, fmt.Errorf("semantic: query vector is zero")
+	} else if limit <= 0 {
+		return result, nil
+	}
+
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	add := func(i int) {
+		if sim := q.Dot(idx.vectors[i]); sim >= minSim {
+			result = append(result, Match{ID: idx.ids[i], Similarity: sim})
+		}
+	}
+
+	if len(idx.ids) <= ExactSearchMax {
+		for i := range idx.ids {
+			add(i)
+		}
+	} else {
+		for i := range idx.candidates(q) {
+			add(i)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Similarity == result[j].Similarity {
+			return result[i].ID < result[j].ID
+		}
+
+		return result[i].Similarity > result[j].Similarity
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
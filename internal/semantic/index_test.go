@@ -0,0 +1,85 @@
+package semantic
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_Add(t *testing.T) {
+	idx := NewIndex(3)
+
+	assert.NoError(t, idx.Add("a", Embedding{1, 0, 0}))
+	assert.NoError(t, idx.Add("a", Embedding{0, 1, 0}))
+	assert.Error(t, idx.Add("b", Embedding{1, 0}))
+	assert.Error(t, idx.Add("c", Embedding{0, 0, 0}))
+	assert.Error(t, idx.Add("", Embedding{1, 0, 0}))
+	assert.Equal(t, 1, idx.Len())
+	assert.Equal(t, 3, idx.Dim())
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Run("Exact", func(t *testing.T) {
+		idx := NewIndex(3)
+
+		_ = idx.Add("cake", Embedding{0.9, 0.1, 0})
+		_ = idx.Add("candles", Embedding{0.7, 0.7, 0})
+		_ = idx.Add("beach", Embedding{0, 0, 1})
+
+		result, err := idx.Search(Embedding{1, 0, 0}, 10, 0.5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"cake", "candles"}, result.IDs())
+
+		result, err = idx.Search(Embedding{1, 0, 0}, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cake"}, result.IDs())
+
+		_, err = idx.Search(Embedding{1, 0}, 1, 0)
+
+		assert.Error(t, err)
+	})
+	t.Run("Approximate", func(t *testing.T) {
+		dim := 64
+		idx := NewIndex(dim)
+		r := rand.New(rand.NewSource(1))
+
+		var query Embedding
+
+		for i := 0; i < ExactSearchMax+500; i++ {
+			e := make(Embedding, dim)
+
+			for j := range e {
+				e[j] = r.NormFloat64()
+			}
+
+			if i == 42 {
+				query = e
+			}
+
+			assert.NoError(t, idx.Add(fmt.Sprintf("id%d", i), e))
+		}
+
+		// A slightly different query vector must still find its nearest neighbor.
+		q := make(Embedding, dim)
+		copy(q, query)
+		q[0] += 0.1
+
+		result, err := idx.Search(q, 5, 0.5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotEmpty(t, result) {
+			assert.Equal(t, "id42", result[0].ID)
+			assert.Greater(t, result[0].Similarity, 0.99)
+		}
+	})
+}
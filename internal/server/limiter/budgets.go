@@ -0,0 +1,45 @@
+package limiter
+
+import (
+	"golang.org/x/time/rate"
+)
+
+// Request budget names.
+const (
+	BudgetDefault = "default"
+	BudgetSearch  = "search"
+	BudgetThumbs  = "thumbs"
+	BudgetAuth    = "auth"
+)
+
+// Budgets maps budget names to request rate limits.
+type Budgets map[string]*Limit
+
+// Add adds a request budget if the rate limit is greater than zero.
+func (b Budgets) Add(name string, r float64, burst int) Budgets {
+	if r <= 0 {
+		return b
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	b[name] = NewLimit(rate.Limit(r), burst)
+
+	return b
+}
+
+// Find returns the request budget with the specified name, the default budget, or nil if there is none.
+func (b Budgets) Find(name string) *Limit {
+	if l, ok := b[name]; ok {
+		return l
+	}
+
+	return b[BudgetDefault]
+}
+
+// Empty checks if no budgets have been configured.
+func (b Budgets) Empty() bool {
+	return len(b) == 0
+}
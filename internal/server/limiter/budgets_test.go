@@ -0,0 +1,26 @@
+package limiter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgets(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		b := Budgets{}.Add(BudgetDefault, 0, 10)
+		assert.True(t, b.Empty())
+		assert.Nil(t, b.Find(BudgetSearch))
+	})
+	t.Run("Default", func(t *testing.T) {
+		b := Budgets{}.Add(BudgetDefault, 10, 20).Add(BudgetThumbs, 100, 200)
+		assert.False(t, b.Empty())
+		assert.Equal(t, 20, b.Find(BudgetSearch).Burst())
+		assert.Equal(t, 200, b.Find(BudgetThumbs).Burst())
+		assert.Equal(t, float64(100), float64(b.Find(BudgetThumbs).Rate()))
+	})
+	t.Run("MinBurst", func(t *testing.T) {
+		b := Budgets{}.Add(BudgetAuth, 1, 0)
+		assert.Equal(t, 1, b.Find(BudgetAuth).Burst())
+	})
+}
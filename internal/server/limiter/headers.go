@@ -0,0 +1,45 @@
+package limiter
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// Standard rate limit response headers, see https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/.
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// SetHeaders adds the standard rate limit headers to the response.
+func SetHeaders(c *gin.Context, l *Limit, r *rate.Limiter) {
+	if c == nil || l == nil || r == nil {
+		return
+	}
+
+	tokens := math.Max(0, r.TokensAt(time.Now()))
+
+	c.Header(HeaderLimit, strconv.Itoa(l.Burst()))
+	c.Header(HeaderRemaining, strconv.Itoa(int(math.Floor(tokens))))
+	c.Header(HeaderReset, strconv.Itoa(wait(l, float64(l.Burst())-tokens)))
+
+	// Tell the client when to retry if no requests are remaining.
+	if tokens < 1 {
+		c.Header(HeaderRetryAfter, strconv.Itoa(wait(l, 1-tokens)))
+	}
+}
+
+// wait returns the number of seconds until the specified number of tokens becomes available.
+func wait(l *Limit, tokens float64) int {
+	if tokens <= 0 || l.Rate() <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(tokens / float64(l.Rate())))
+}
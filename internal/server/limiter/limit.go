@@ -2,16 +2,21 @@ package limiter
 
 import (
 	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
 
+// PruneInterval specifies how often rate limiters of idle clients are removed.
+var PruneInterval = time.Minute
+
 // Limit represents an IP request rate limit.
 type Limit struct {
 	limiters  map[string]*rate.Limiter
 	mu        *sync.RWMutex
 	rateLimit rate.Limit
 	burstSize int
+	pruned    time.Time
 }
 
 // NewLimit returns a new Limit with the specified request and burst rate limit per second.
@@ -21,6 +26,7 @@ func NewLimit(r rate.Limit, b int) *Limit {
 		mu:        &sync.RWMutex{},
 		rateLimit: r,
 		burstSize: b,
+		pruned:    time.Now(),
 	}
 
 	return i
@@ -41,6 +47,11 @@ func (i *Limit) AddIP(ip string) *rate.Limiter {
 // IP returns the rate limiter for the specified IP address.
 func (i *Limit) IP(ip string) *rate.Limiter {
 	i.mu.Lock()
+
+	if now := time.Now(); now.Sub(i.pruned) >= PruneInterval {
+		i.prune(now)
+	}
+
 	limiter, exists := i.limiters[ip]
 
 	if !exists {
@@ -53,6 +64,26 @@ func (i *Limit) IP(ip string) *rate.Limiter {
 	return limiter
 }
 
+// prune removes the rate limiters of clients that have not sent requests long enough for all tokens
+// to be restored, as they do not differ from new limiters. The caller must hold the lock.
+func (i *Limit) prune(now time.Time) {
+	for key, limiter := range i.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(i.limiters, key)
+		}
+	}
+
+	i.pruned = now
+}
+
+// Len returns the number of clients with a rate limiter.
+func (i *Limit) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.limiters)
+}
+
 // Allow reports whether the request is allowed at this time and increments the request counter.
 func (i *Limit) Allow(ip string) bool {
 	return i.IP(ip).Allow()
@@ -67,3 +98,18 @@ func (i *Limit) Reserve(ip string) *rate.Reservation {
 func (i *Limit) Reject(ip string) bool {
 	return i.IP(ip).Tokens() < 1
 }
+
+// Key returns the rate limiter for the specified client key, e.g. an IP address or access token.
+func (i *Limit) Key(key string) *rate.Limiter {
+	return i.IP(key)
+}
+
+// Rate returns the number of requests per second that can be performed on average.
+func (i *Limit) Rate() rate.Limit {
+	return i.rateLimit
+}
+
+// Burst returns the maximum number of requests that can be performed at once.
+func (i *Limit) Burst() int {
+	return i.burstSize
+}
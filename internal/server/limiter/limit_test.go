@@ -3,8 +3,10 @@ package limiter
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
 )
 
 func TestNewLimit(t *testing.T) {
@@ -73,4 +75,27 @@ func TestNewLimit(t *testing.T) {
 			assert.True(t, l.Reject(clientIp))
 		}
 	})
+	t.Run("Prune", func(t *testing.T) {
+		interval := PruneInterval
+		PruneInterval = time.Hour
+		defer func() { PruneInterval = interval }()
+
+		// 1000 per second.
+		l := NewLimit(1000, 1)
+		for i := 0; i < 100; i++ {
+			assert.True(t, l.Allow(fmt.Sprintf("192.0.2.%d", i)))
+		}
+		assert.Equal(t, 100, l.Len())
+
+		// Clients that have not exceeded their limit are kept.
+		l.limiters[clientIp] = rate.NewLimiter(0.166, 10)
+		assert.True(t, l.Allow(clientIp))
+
+		time.Sleep(5 * time.Millisecond)
+		PruneInterval = 0
+
+		assert.True(t, l.Allow("192.0.2.200"))
+		assert.Equal(t, 2, l.Len())
+		assert.False(t, l.Reject(clientIp))
+	})
 }
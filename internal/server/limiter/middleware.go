@@ -15,3 +15,25 @@ func Middleware(ip *Limit) gin.HandlerFunc {
 		}
 	}
 }
+
+// Budget registers a request rate limiter middleware that uses the request budget and client key
+// returned by the specified functions, and responds with the standard rate limit headers.
+func Budget(budgets Budgets, budget func(c *gin.Context) string, key func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		l := budgets.Find(budget(c))
+
+		if l == nil {
+			return
+		}
+
+		r := l.Key(key(c))
+		allowed := r.Allow()
+
+		SetHeaders(c, l, r)
+
+		if !allowed {
+			AbortJSON(c)
+			return
+		}
+	}
+}
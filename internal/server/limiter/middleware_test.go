@@ -0,0 +1,45 @@
+package limiter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	budgets := Budgets{}.Add(BudgetDefault, 0.1, 2)
+	app := gin.New()
+	app.Use(Budget(budgets, func(c *gin.Context) string { return BudgetDefault }, func(c *gin.Context) string { return c.GetHeader("X-Client") }))
+	app.GET("/foo", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	request := func(client string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/foo", nil)
+		req.Header.Set("X-Client", client)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	r := request("a")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "2", r.Header().Get(HeaderLimit))
+	assert.Equal(t, "1", r.Header().Get(HeaderRemaining))
+	assert.Equal(t, "", r.Header().Get(HeaderRetryAfter))
+
+	r = request("a")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "0", r.Header().Get(HeaderRemaining))
+	assert.Equal(t, "10", r.Header().Get(HeaderRetryAfter))
+
+	r = request("a")
+	assert.Equal(t, http.StatusTooManyRequests, r.Code)
+
+	// Other clients have their own budget.
+	r = request("b")
+	assert.Equal(t, http.StatusOK, r.Code)
+}
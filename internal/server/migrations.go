@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/migrate"
+)
+
+// MigrationsRetryAfter is the number of seconds after which clients should retry while the database is being migrated.
+const MigrationsRetryAfter = 5
+
+// migrationsPage is the status page shown while the database schema is being migrated.
+var migrationsPage = template.Must(template.New("migrations").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{ .Refresh }}">
+<title>{{ .Name }}</title>
+<style>body { font-family: sans-serif; margin: 3em; color: #333; } small { color: #777; }</style>
+</head>
+<body>
+<h1>{{ .Name }} is starting</h1>
+{{- if .Progress.Running }}
+<p>Upgrading the database schema, migration {{ .Progress.ID }} ({{ .Current }} of {{ .Progress.Total }}).</p>
+<p><small>Statement {{ .Progress.Statement }} of {{ .Progress.Statements }}{{ if .Progress.Rows }}, {{ .Progress.Rows }} rows updated{{ end }}. This may take a while with large libraries, please do not stop the server.</small></p>
+{{- else }}
+<p>Initializing the database, please wait.</p>
+{{- end }}
+</body>
+</html>
+`))
+
+// MigrationsHandler returns an HTTP handler that reports the progress of schema migrations, so that users
+// and health checks can see that the server is starting.
+func MigrationsHandler(conf *config.Config) http.Handler {
+	apiUri := conf.BaseUri(config.ApiUri)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := migrate.Current()
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Retry-After", strconv.Itoa(MigrationsRetryAfter))
+
+		// Respond with JSON to API requests, e.g. to check the server status.
+		if strings.HasPrefix(r.URL.Path, apiUri+"/") {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": "migrating", "progress": p})
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		_ = migrationsPage.Execute(w, struct {
+			Name     string
+			Refresh  int
+			Current  int
+			Progress migrate.Progress
+		}{
+			Name:     conf.Name(),
+			Refresh:  MigrationsRetryAfter,
+			Current:  p.Done + 1,
+			Progress: p,
+		})
+	})
+}
+
+// StartMigrations serves the migration status page on the configured HTTP port until the returned
+// function is called, so that the port can then be used by the web server.
+func StartMigrations(conf *config.Config) (stop func()) {
+	// Skip if certificates are managed automatically, since this requires the web server.
+	if _, err := AutoTLS(conf); err == nil {
+		return func() {}
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", conf.HttpHost(), conf.HttpPort()))
+
+	if err != nil {
+		log.Debugf("server: %s (migration status)", err)
+		return func() {}
+	}
+
+	server := &http.Server{Handler: MigrationsHandler(conf)}
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		var err error
+
+		if publicCert, privateKey := conf.TLS(); publicCert != "" && privateKey != "" {
+			err = server.ServeTLS(listener, publicCert, privateKey)
+		} else {
+			err = server.Serve(listener)
+		}
+
+		if err != nil && err != http.ErrServerClosed {
+			log.Warnf("server: %s (migration status)", err)
+		}
+	}()
+
+	return func() {
+		if err := server.Close(); err != nil {
+			log.Warnf("server: %s (stop migration status)", err)
+		}
+
+		<-done
+	}
+}
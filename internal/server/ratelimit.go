@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+)
+
+// searchRoutes specifies the API routes that are subject to the search request budget.
+var searchRoutes = map[string]bool{
+	"/photos":       true,
+	"/photos/view":  true,
+	"/geo":          true,
+	"/geo/:format":  true,
+	"/albums":       true,
+	"/labels":       true,
+	"/subjects":     true,
+	"/faces":        true,
+	"/moments/time": true,
+	"/services":     true,
+	"/selections":   true,
+}
+
+// RateLimit returns a middleware that limits the number of API requests per client, or nil if it is disabled.
+func RateLimit(conf *config.Config) gin.HandlerFunc {
+	budgets := limiter.Budgets{}.
+		Add(limiter.BudgetDefault, conf.RateLimit(), conf.RateBurst()).
+		Add(limiter.BudgetSearch, conf.RateLimitSearch(), conf.RateBurstSearch()).
+		Add(limiter.BudgetThumbs, conf.RateLimitThumbs(), conf.RateBurstThumbs()).
+		Add(limiter.BudgetAuth, conf.RateLimitAuth(), conf.RateBurstAuth())
+
+	if budgets.Empty() {
+		return nil
+	}
+
+	// Strip the API base URI from route paths.
+	apiUri := conf.BaseUri(config.ApiUri)
+
+	budget := func(c *gin.Context) string {
+		return rateBudget(c.Request.Method, strings.TrimPrefix(c.FullPath(), apiUri))
+	}
+
+	log.Infof("server: enabled api rate limiting")
+
+	return limiter.Budget(budgets, budget, rateKey)
+}
+
+// rateBudget returns the name of the request budget for the specified method and route.
+func rateBudget(method, route string) string {
+	switch {
+	case strings.Contains(route, "/t/"), strings.HasPrefix(route, "/videos/"), strings.HasPrefix(route, "/svg/"):
+		return limiter.BudgetThumbs
+	case strings.HasPrefix(route, "/session"), strings.HasSuffix(route, "/password"):
+		return limiter.BudgetAuth
+	case method == http.MethodGet && (searchRoutes[route] || strings.HasPrefix(route, "/folders/")):
+		return limiter.BudgetSearch
+	default:
+		return limiter.BudgetDefault
+	}
+}
+
+// rateKey returns the client key for rate limiting, i.e. the session or preview token if valid, or the client IP.
+// Unknown session IDs and tokens are not used as key, so that random values cannot bypass the limits.
+func rateKey(c *gin.Context) string {
+	if sessId := api.SessionID(c); sessId != "" {
+		if _, err := entity.FindSession(sessId); err == nil {
+			return "sess:" + sessId
+		}
+	} else if token := c.Param("token"); token != "" && entity.CheckTokens && !entity.InvalidPreviewToken(token) {
+		return "token:" + token
+	}
+
+	return "ip:" + api.ClientIP(c)
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestRateBudget(t *testing.T) {
+	assert.Equal(t, limiter.BudgetThumbs, rateBudget(http.MethodGet, "/t/:thumb/:token/:size"))
+	assert.Equal(t, limiter.BudgetThumbs, rateBudget(http.MethodGet, "/albums/:uid/t/:token/:size"))
+	assert.Equal(t, limiter.BudgetThumbs, rateBudget(http.MethodGet, "/videos/:hash/:token/:format"))
+	assert.Equal(t, limiter.BudgetAuth, rateBudget(http.MethodPost, "/session"))
+	assert.Equal(t, limiter.BudgetAuth, rateBudget(http.MethodPut, "/users/:uid/password"))
+	assert.Equal(t, limiter.BudgetSearch, rateBudget(http.MethodGet, "/photos"))
+	assert.Equal(t, limiter.BudgetSearch, rateBudget(http.MethodGet, "/folders/originals/*path"))
+	assert.Equal(t, limiter.BudgetDefault, rateBudget(http.MethodPost, "/albums"))
+	assert.Equal(t, limiter.BudgetDefault, rateBudget(http.MethodGet, "/photos/:uid"))
+}
+
+func TestRateKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	checkTokens := entity.CheckTokens
+	entity.CheckTokens = true
+	defer func() { entity.CheckTokens = checkTokens }()
+
+	newContext := func(sessId, token string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/photos", nil)
+		c.Request.RemoteAddr = "192.0.2.1:1234"
+
+		if sessId != "" {
+			c.Request.Header.Set(session.Header, sessId)
+		}
+
+		if token != "" {
+			c.Params = gin.Params{{Key: "token", Value: token}}
+		}
+
+		return c
+	}
+
+	t.Run("ClientIP", func(t *testing.T) {
+		assert.Equal(t, "ip:192.0.2.1", rateKey(newContext("", "")))
+	})
+	t.Run("UnknownSession", func(t *testing.T) {
+		assert.Equal(t, "ip:192.0.2.1", rateKey(newContext("foo", "")))
+	})
+	t.Run("PreviewToken", func(t *testing.T) {
+		entity.PreviewToken.Set("ratekey1", entity.TokenConfig)
+		defer entity.PreviewToken.Unset("ratekey1")
+
+		assert.Equal(t, "token:ratekey1", rateKey(newContext("", "ratekey1")))
+	})
+	t.Run("UnknownToken", func(t *testing.T) {
+		assert.Equal(t, "ip:192.0.2.1", rateKey(newContext("", "random")))
+	})
+}
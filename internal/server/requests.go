@@ -0,0 +1,23 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// CountRequests returns a middleware that counts the API requests in progress, so that heavy background
+// workers can pause while the instance is busy, or nil if this is disabled.
+func CountRequests(conf *config.Config) gin.HandlerFunc {
+	if conf.MaxRequests() <= 0 {
+		return nil
+	}
+
+	return func(c *gin.Context) {
+		mutex.Requests.Add(1)
+		defer mutex.Requests.Add(-1)
+
+		c.Next()
+	}
+}
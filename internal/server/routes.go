@@ -32,10 +32,15 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.CreateSession(APIv1)
 	api.GetSession(APIv1)
 	api.DeleteSession(APIv1)
+	api.PasskeyLoginOptions(APIv1)
+	api.CreatePasskeySession(APIv1)
+	api.OIDCLogin(APIv1)
+	api.OIDCRedirect(APIv1)
 
 	// Server Config.
 	api.GetConfigOptions(APIv1)
 	api.SaveConfigOptions(APIv1)
+	api.GetConfigRoots(APIv1)
 	api.StopServer(APIv1)
 
 	// Custom Settings.
@@ -43,17 +48,59 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.GetSettings(APIv1)
 	api.SaveSettings(APIv1)
 
+	// Embedded Galleries.
+	api.GetOEmbed(APIv1)
+
 	// Profile and Uploads.
 	api.UploadUserFiles(APIv1)
 	api.ProcessUserUpload(APIv1)
+	api.UploadShareFiles(APIv1)
 	api.UploadUserAvatar(APIv1)
 	api.UpdateUserPassword(APIv1)
 	api.UpdateUser(APIv1)
+	api.CreateUserAccessToken(APIv1)
+	api.SearchUserPasskeys(APIv1)
+	api.UserPasskeyOptions(APIv1)
+	api.CreateUserPasskey(APIv1)
+	api.DeleteUserPasskey(APIv1)
+	api.SearchUserSessions(APIv1)
+	api.UpdateUserSession(APIv1)
+	api.DeleteUserSession(APIv1)
+	api.DeleteUserSessions(APIv1)
+	api.GetUserUsage(APIv1)
+	api.ExportUserData(APIv1)
+
+	// User Groups.
+	api.SearchGroups(APIv1)
+	api.GetGroup(APIv1)
+	api.CreateGroup(APIv1)
+	api.UpdateGroup(APIv1)
+	api.DeleteGroup(APIv1)
+	api.AddGroupMembers(APIv1)
+	api.RemoveGroupMembers(APIv1)
+
+	// SCIM Provisioning.
+	api.GetScimConfig(APIv1)
+	api.SearchScimUsers(APIv1)
+	api.GetScimUser(APIv1)
+	api.CreateScimUser(APIv1)
+	api.UpdateScimUser(APIv1)
+	api.PatchScimUser(APIv1)
+	api.DeleteScimUser(APIv1)
+	api.SearchScimGroups(APIv1)
+	api.GetScimGroup(APIv1)
+	api.CreateScimGroup(APIv1)
+	api.UpdateScimGroup(APIv1)
+	api.PatchScimGroup(APIv1)
+	api.DeleteScimGroup(APIv1)
 
 	// Service Accounts.
 	api.SearchServices(APIv1)
 	api.GetService(APIv1)
 	api.GetServiceFolders(APIv1)
+	api.GetServiceJournal(APIv1)
+	api.AuthorizeService(APIv1)
+	api.GPhotosCallback(APIv1)
 	api.UploadToService(APIv1)
 	api.AddService(APIv1)
 	api.DeleteService(APIv1)
@@ -75,13 +122,40 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.CancelImport(APIv1)
 	api.StartIndexing(APIv1)
 	api.CancelIndexing(APIv1)
+	api.StartPurge(APIv1)
+	api.StartCleanUp(APIv1)
+	api.GetSchedulerTasks(APIv1)
+	api.RunSchedulerTask(APIv1)
+	api.SkipSchedulerTask(APIv1)
+	api.UnskipSchedulerTask(APIv1)
+	api.GetJobs(APIv1)
+	api.PauseJob(APIv1)
+	api.ResumeJob(APIv1)
+	api.CancelJob(APIv1)
+	api.GetMigrations(APIv1)
+	api.GetTrash(APIv1)
 
 	// Photo Search and Organization.
 	api.SearchPhotos(APIv1)
 	api.SearchGeo(APIv1)
+	api.GetPlaceAliases(APIv1)
+	api.CreatePlaceAlias(APIv1)
+	api.UpdatePlaceAlias(APIv1)
+	api.DeletePlaceAlias(APIv1)
+	api.GetGeofences(APIv1)
+	api.CreateGeofence(APIv1)
+	api.UpdateGeofence(APIv1)
+	api.DeleteGeofence(APIv1)
+	api.GetMapTilesInfo(APIv1)
+	api.GetMapTile(APIv1)
+	api.GetTravelCountries(APIv1)
+	api.GetTravelRegions(APIv1)
+	api.GetHeatmap(APIv1)
 	api.GetPhoto(APIv1)
 	api.GetPhotoYaml(APIv1)
 	api.UpdatePhoto(APIv1)
+	api.GetPhotoChanges(APIv1)
+	api.UndoPhotoChange(APIv1)
 	api.GetPhotoDownload(APIv1)
 	// api.GetPhotoLinks(APIv1)
 	// api.CreatePhotoLink(APIv1)
@@ -94,11 +168,15 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.RemovePhotoLabel(APIv1)
 	api.UpdatePhotoLabel(APIv1)
 	api.GetMomentsTime(APIv1)
+	api.UpdateMoments(APIv1)
 	api.GetFile(APIv1)
 	api.DeleteFile(APIv1)
 	api.ChangeFileOrientation(APIv1)
+	api.CreateMarker(APIv1)
 	api.UpdateMarker(APIv1)
 	api.ClearMarkerSubject(APIv1)
+	api.GetMarkersReview(APIv1)
+	api.UpdateMarkersReview(APIv1)
 	api.PhotoPrimary(APIv1)
 	api.PhotoUnstack(APIv1)
 
@@ -107,23 +185,61 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.GetAlbum(APIv1)
 	api.AlbumCover(APIv1)
 	api.CreateAlbum(APIv1)
+	api.GetAlbumTemplates(APIv1)
+	api.CreateTemplateAlbum(APIv1)
 	api.UpdateAlbum(APIv1)
+	api.UndoAlbumChange(APIv1)
 	api.DeleteAlbum(APIv1)
 	api.DownloadAlbum(APIv1)
 	api.GetAlbumLinks(APIv1)
 	api.CreateAlbumLink(APIv1)
 	api.UpdateAlbumLink(APIv1)
 	api.DeleteAlbumLink(APIv1)
+	api.SendAlbumLink(APIv1)
+	api.GetShareUploads(APIv1)
+	api.ApproveShareUpload(APIv1)
+	api.RejectShareUpload(APIv1)
+	api.GetAlbumGrants(APIv1)
+	api.GrantAlbumAccess(APIv1)
+	api.RevokeAlbumAccess(APIv1)
+	api.GetAlbumContributors(APIv1)
+	api.GetAlbumComments(APIv1)
+	api.AddAlbumComment(APIv1)
+	api.ModerateAlbumComment(APIv1)
+	api.DeleteAlbumComment(APIv1)
+	api.GetAlbumReactions(APIv1)
+	api.ReactToAlbum(APIv1)
 	api.LikeAlbum(APIv1)
 	api.DislikeAlbum(APIv1)
+	api.LockAlbum(APIv1)
+	api.RemoveAlbumLock(APIv1)
+	api.UnlockAlbum(APIv1)
+	api.MoveAlbum(APIv1)
+	api.SortAlbumPhotos(APIv1)
 	api.CloneAlbums(APIv1)
 	api.AddPhotosToAlbum(APIv1)
 	api.RemovePhotosFromAlbum(APIv1)
+	api.UpdateAlbumCaption(APIv1)
+	api.SetAlbumCover(APIv1)
+	api.ResetAlbumCover(APIv1)
+
+	// Share Links.
+	api.SearchLinks(APIv1)
+	api.GetLink(APIv1)
+	api.CreateShareLink(APIv1)
+	api.UpdateShareLink(APIv1)
+	api.DeleteShareLink(APIv1)
+	api.RevokeShareLinks(APIv1)
 
 	// Photo Labels.
 	api.SearchLabels(APIv1)
+	api.GetLabelsReview(APIv1)
+	api.UpdateLabelsReview(APIv1)
 	api.LabelCover(APIv1)
 	api.UpdateLabel(APIv1)
+	api.GetLabelParents(APIv1)
+	api.UpdateLabelParent(APIv1)
+	api.RebuildLabelHierarchy(APIv1)
 	// api.GetLabelLinks(APIv1)
 	// api.CreateLabelLink(APIv1)
 	// api.UpdateLabelLink(APIv1)
@@ -140,19 +256,33 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.SearchSubjects(APIv1)
 	api.GetSubject(APIv1)
 	api.UpdateSubject(APIv1)
+	api.UndoSubjectChange(APIv1)
 	api.LikeSubject(APIv1)
 	api.DislikeSubject(APIv1)
+	api.MergeSubjects(APIv1)
+	api.GetPeoplePairs(APIv1)
+	api.GetSubjectPairs(APIv1)
+	api.GetSubjectAges(APIv1)
 
 	// Faces.
 	api.SearchFaces(APIv1)
 	api.GetFace(APIv1)
 	api.UpdateFace(APIv1)
+	api.SplitFace(APIv1)
+
+	// Selection Sets.
+	api.SearchSelections(APIv1)
+	api.GetSelection(APIv1)
+	api.AddToSelection(APIv1)
+	api.RemoveFromSelection(APIv1)
+	api.DeleteSelection(APIv1)
 
 	// Batch Operations.
 	api.BatchPhotosApprove(APIv1)
 	api.BatchPhotosArchive(APIv1)
 	api.BatchPhotosRestore(APIv1)
 	api.BatchPhotosPrivate(APIv1)
+	api.BatchPhotosUndo(APIv1)
 	api.BatchPhotosDelete(APIv1)
 	api.BatchAlbumsDelete(APIv1)
 	api.BatchLabelsDelete(APIv1)
@@ -160,8 +290,16 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// Technical Endpoints.
 	api.GetSvg(APIv1)
 	api.GetStatus(APIv1)
+	api.GetReady(APIv1)
+	api.GetDiskStatus(APIv1)
+	api.GetMetrics(APIv1)
 	api.GetErrors(APIv1)
 	api.DeleteErrors(APIv1)
+	api.SearchAudit(APIv1)
+	api.ExportAudit(APIv1)
+	api.ExportEmbeddings(APIv1)
+	api.ImportEmbeddings(APIv1)
+	api.Geotag(APIv1)
 	api.SendFeedback(APIv1)
 	api.Connect(APIv1)
 	api.WebSocket(APIv1)
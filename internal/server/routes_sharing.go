@@ -13,5 +13,9 @@ func registerSharingRoutes(router *gin.Engine, conf *config.Config) {
 	{
 		api.Shares(s)
 		api.SharePreview(s)
+		api.ShareFeedJson(s)
+		api.ShareFeedAtom(s)
+		api.ShareEmbed(s)
+		api.ShareThumb(s)
 	}
 }
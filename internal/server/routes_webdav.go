@@ -27,6 +27,8 @@ func registerWebDAVRoutes(router *gin.Engine, conf *config.Config) {
 		var info string
 		if conf.ReadOnly() {
 			info = " in read-only mode"
+		} else if conf.OriginalsWriteOnce() {
+			info = " in write-once mode"
 		} else {
 			info = ""
 		}
@@ -58,7 +60,12 @@ func WebDAV(filePath string, router *gin.RouterGroup, conf *config.Config) {
 	}
 
 	// Native file system restricted to a specific directory.
-	fileSystem := webdav.Dir(filePath)
+	var fileSystem webdav.FileSystem = webdav.Dir(filePath)
+
+	// Existing originals must not be changed or removed in write-once mode.
+	if conf.OriginalsWriteOnce() {
+		fileSystem = WriteOnceDir{Dir: webdav.Dir(filePath)}
+	}
 
 	// Request logger function.
 	loggerFunc := func(r *http.Request, err error) {
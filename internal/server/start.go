@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
 	"golang.org/x/crypto/acme/autocert"
@@ -46,6 +47,16 @@ func Start(ctx context.Context, conf *config.Config) {
 	// Create REST API router group.
 	APIv1 = router.Group(conf.BaseUri(config.ApiUri))
 
+	// Limit the number of API requests per client, if enabled.
+	if rateLimit := RateLimit(conf); rateLimit != nil {
+		APIv1.Use(rateLimit)
+	}
+
+	// Count API requests in progress to throttle background workers, if enabled.
+	if countRequests := CountRequests(conf); countRequests != nil {
+		APIv1.Use(countRequests)
+	}
+
 	// Initialize package extensions.
 	Ext().Init(router, conf)
 
@@ -61,6 +72,11 @@ func Start(ctx context.Context, conf *config.Config) {
 				conf.BaseUri(config.ApiUri + "/albums"),
 				conf.BaseUri(config.ApiUri + "/labels"),
 				conf.BaseUri(config.ApiUri + "/videos"),
+				conf.BaseUri(config.ApiUri + "/dl"),
+			}),
+			// Originals are sent with sendfile() and support range requests.
+			gzip.WithExcludedPathsRegexs([]string{
+				regexp.QuoteMeta(conf.BaseUri(config.ApiUri+"/photos/")) + `[^/]+/dl`,
 			})))
 		log.Infof("server: enabled gzip compression")
 	}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/net/webdav"
+
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+// WriteOnceDir is a WebDAV file system restricted to a specific directory that
+// does not allow existing write-once protected files to be changed or removed.
+type WriteOnceDir struct {
+	webdav.Dir
+}
+
+// fileName returns the native file name of a WebDAV resource.
+func (d WriteOnceDir) fileName(name string) string {
+	return filepath.Join(string(d.Dir), filepath.FromSlash(path.Clean("/"+name)))
+}
+
+// OpenFile opens the named file, see webdav.Dir.
+func (d WriteOnceDir) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_TRUNC) != 0 {
+		if err := vfs.CheckWrite(d.fileName(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.Dir.OpenFile(ctx, name, flag, perm)
+}
+
+// RemoveAll removes the named file or directory, see webdav.Dir.
+func (d WriteOnceDir) RemoveAll(ctx context.Context, name string) error {
+	if err := vfs.CheckWrite(d.fileName(name)); err != nil {
+		return err
+	}
+
+	return d.Dir.RemoveAll(ctx, name)
+}
+
+// Rename renames a file or directory, see webdav.Dir.
+func (d WriteOnceDir) Rename(ctx context.Context, oldName, newName string) error {
+	if err := vfs.CheckWrite(d.fileName(oldName)); err != nil {
+		return err
+	} else if err = vfs.CheckWrite(d.fileName(newName)); err != nil {
+		return err
+	}
+
+	return d.Dir.Rename(ctx, oldName, newName)
+}
@@ -2,3 +2,9 @@ package session
 
 // Header specifies the name of the session HTTP header.
 var Header = "X-Session-ID"
+
+// AuthHeader specifies the name of the HTTP header that may contain a bearer access token.
+var AuthHeader = "Authorization"
+
+// AuthBearer specifies the prefix of bearer access tokens.
+var AuthBearer = "Bearer "
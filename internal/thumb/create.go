@@ -101,6 +101,10 @@ func FromFile(imageFilename, hash, thumbPath string, width, height, orientation
 		return "", err
 	}
 
+	// Wait until enough memory is available.
+	_, release := ReserveFileMemory(imageFilename)
+	defer release()
+
 	// Load image from storage.
 	img, err := Open(imageFilename, orientation)
 
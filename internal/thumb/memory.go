@@ -0,0 +1,104 @@
+package thumb
+
+import (
+	"image"
+	"os"
+	"sync"
+
+	"github.com/disintegration/imaging"
+)
+
+// MemoryBudget limits the estimated memory in bytes used for decoding images at the same time,
+// so that indexing very large originals does not exceed container memory limits (0 for unlimited).
+var MemoryBudget Bytes
+
+var memoryUsed Bytes
+var memoryCond = sync.NewCond(&sync.Mutex{})
+
+// FileMemSize returns the estimated memory in bytes required to decode and transform an image,
+// based on the dimensions and color model in its file header.
+func FileMemSize(fileName string) (Bytes, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+
+	if err != nil {
+		return 0, err
+	}
+
+	pixels := Bytes(cfg.Width) * Bytes(cfg.Height)
+
+	// Add an RGBA copy, as rotating and resampling create new images.
+	return pixels*Bytes(bytesPerPixel(cfg.ColorModel)) + pixels*4, nil
+}
+
+// MemoryUsed returns the estimated memory in bytes currently reserved for decoding images.
+func MemoryUsed() Bytes {
+	memoryCond.L.Lock()
+	defer memoryCond.L.Unlock()
+
+	return memoryUsed
+}
+
+// ExceedsMemory checks if the estimated memory size exceeds the whole budget.
+func ExceedsMemory(size Bytes) bool {
+	return MemoryBudget > 0 && size > MemoryBudget
+}
+
+// ReserveMemory waits until the estimated memory size is available within the budget and returns
+// a function that releases it. Images that exceed the whole budget are decoded one at a time.
+func ReserveMemory(size Bytes) (release func()) {
+	if MemoryBudget == 0 || size == 0 {
+		return func() {}
+	}
+
+	memoryCond.L.Lock()
+
+	for memoryUsed > 0 && memoryUsed+size > MemoryBudget {
+		memoryCond.Wait()
+	}
+
+	memoryUsed += size
+
+	memoryCond.L.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			memoryCond.L.Lock()
+			memoryUsed -= size
+			memoryCond.L.Unlock()
+			memoryCond.Broadcast()
+		})
+	}
+}
+
+// ReserveFileMemory waits until the memory for decoding the image file is available within the budget
+// and returns the estimated size along with a function that releases it.
+func ReserveFileMemory(fileName string) (size Bytes, release func()) {
+	if MemoryBudget == 0 {
+		return 0, func() {}
+	}
+
+	// Files with unknown dimensions are not accounted for.
+	size, _ = FileMemSize(fileName)
+
+	return size, ReserveMemory(size)
+}
+
+// Downsize reduces the image to the maximum size of pre-cached thumbnails, so that less memory
+// is used for creating them from very large images.
+func Downsize(img image.Image) image.Image {
+	if b := img.Bounds(); b.Dx() <= SizePrecached && b.Dy() <= SizePrecached {
+		return img
+	}
+
+	return imaging.Fit(img, SizePrecached, SizePrecached, Filter.Imaging())
+}
@@ -0,0 +1,96 @@
+package thumb
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMemSize(t *testing.T) {
+	t.Run("Jpeg", func(t *testing.T) {
+		size, err := FileMemSize("testdata/example.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Bytes(750*500*8), size)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		size, err := FileMemSize("testdata/missing.jpg")
+
+		assert.Error(t, err)
+		assert.Equal(t, Bytes(0), size)
+	})
+}
+
+func TestReserveMemory(t *testing.T) {
+	budget := MemoryBudget
+	defer func() { MemoryBudget = budget }()
+
+	t.Run("Unlimited", func(t *testing.T) {
+		MemoryBudget = 0
+
+		release := ReserveMemory(100 * MB)
+
+		assert.Equal(t, Bytes(0), MemoryUsed())
+		assert.False(t, ExceedsMemory(100*MB))
+
+		release()
+	})
+	t.Run("Budget", func(t *testing.T) {
+		MemoryBudget = 100 * MB
+
+		first := ReserveMemory(60 * MB)
+		assert.Equal(t, Bytes(60*MB), MemoryUsed())
+
+		reserved := make(chan func())
+
+		go func() {
+			reserved <- ReserveMemory(60 * MB)
+		}()
+
+		// The second reservation must wait for the first to be released.
+		select {
+		case <-reserved:
+			t.Fatal("memory budget exceeded")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		first()
+		first()
+
+		second := <-reserved
+		assert.Equal(t, Bytes(60*MB), MemoryUsed())
+
+		second()
+		assert.Equal(t, Bytes(0), MemoryUsed())
+	})
+	t.Run("ExceedsBudget", func(t *testing.T) {
+		MemoryBudget = 100 * MB
+
+		assert.True(t, ExceedsMemory(150*MB))
+
+		// Images that exceed the budget are processed one at a time.
+		release := ReserveMemory(150 * MB)
+		assert.Equal(t, Bytes(150*MB), MemoryUsed())
+
+		release()
+		assert.Equal(t, Bytes(0), MemoryUsed())
+	})
+}
+
+func TestDownsize(t *testing.T) {
+	t.Run("Small", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 640, 480))
+
+		assert.Same(t, img, Downsize(img))
+	})
+	t.Run("Large", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, SizePrecached*2, SizePrecached))
+
+		result := Downsize(img)
+
+		assert.Equal(t, SizePrecached, result.Bounds().Dx())
+		assert.Equal(t, SizePrecached/2, result.Bounds().Dy())
+	})
+}
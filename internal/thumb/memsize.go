@@ -42,20 +42,24 @@ func MemSize(img image.Image) Bytes {
 	r := img.Bounds()
 
 	pixels := r.Dx() * r.Dy()
-	bytesPerPixel := 4
 
+	return Bytes(pixels * bytesPerPixel(img.ColorModel()))
+}
+
+// bytesPerPixel returns the number of bytes per pixel used to represent images with the color model.
+func bytesPerPixel(model color.Model) int {
 	// Image representation in a computer memory:
 	// https://medium.com/@oleg.shipitko/what-does-stride-mean-in-image-processing-bba158a72bcd
-	switch img.ColorModel() {
+	switch model {
 	case color.AlphaModel, color.GrayModel:
-		bytesPerPixel = 1
+		return 1
 	case color.Alpha16Model, color.Gray16Model:
-		bytesPerPixel = 2
+		return 2
 	case color.RGBAModel, color.NRGBAModel:
-		bytesPerPixel = 4
+		return 4
 	case color.RGBA64Model, color.NRGBA64Model:
-		bytesPerPixel = 8
+		return 8
 	}
 
-	return Bytes(pixels * bytesPerPixel)
+	return 4
 }
@@ -19,6 +19,10 @@ func Png(srcFile, pngFile string, orientation int) (img image.Image, err error)
 		return img, err
 	}
 
+	// Wait until enough memory is available.
+	_, release := ReserveFileMemory(srcFile)
+	defer release()
+
 	// Open source image.
 	img, err = imaging.Open(srcFile)
 
@@ -0,0 +1,262 @@
+package thumb
+
+import (
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Watermark positions.
+const (
+	WatermarkTopLeft     = "top-left"
+	WatermarkTopRight    = "top-right"
+	WatermarkBottomLeft  = "bottom-left"
+	WatermarkBottomRight = "bottom-right"
+	WatermarkCenter      = "center"
+)
+
+// WatermarkPositions lists the supported watermark positions.
+var WatermarkPositions = []string{
+	WatermarkTopLeft,
+	WatermarkTopRight,
+	WatermarkBottomLeft,
+	WatermarkBottomRight,
+	WatermarkCenter,
+}
+
+// DefaultWatermarkOpacity is the default watermark opacity in percent.
+const DefaultWatermarkOpacity = 50
+
+var watermarkFont *opentype.Font
+var watermarkFontOnce sync.Once
+
+// Watermark represents a text and/or logo overlay that is drawn on shared images.
+type Watermark struct {
+	Text     string // Overlay text.
+	Logo     string // Logo image file name.
+	Position string // See WatermarkPositions.
+	Opacity  int    // Opacity in percent (1-100).
+}
+
+// Empty checks if the watermark has neither a text nor a logo.
+func (w Watermark) Empty() bool {
+	return w.Text == "" && w.Logo == ""
+}
+
+// Key returns a short checksum of the watermark settings, so that cached images are updated if they change.
+func (w Watermark) Key() string {
+	s := fmt.Sprintf("%s|%s|%s|%d", w.Text, w.Logo, w.Position, w.Opacity)
+
+	if info, err := os.Stat(w.Logo); err == nil {
+		s = fmt.Sprintf("%s|%d", s, info.ModTime().Unix())
+	}
+
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(s)))
+}
+
+// FileName returns the cache file name of a watermarked image.
+func (w Watermark) FileName(cachePath, name string) string {
+	return filepath.Join(cachePath, fmt.Sprintf("%s_%s%s", strings.TrimSuffix(name, filepath.Ext(name)), w.Key(), fs.ExtJPEG))
+}
+
+// FromFile draws the watermark on a copy of the image and saves it as JPEG,
+// unless a watermarked file with the same name already exists.
+func (w Watermark) FromFile(srcName, dstName string, orientation int) (string, error) {
+	if fs.FileExists(dstName) {
+		return dstName, nil
+	}
+
+	img, err := Open(srcName, orientation)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dstName), fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	if img, err = w.Apply(img); err != nil {
+		return "", err
+	}
+
+	if err = imaging.Save(img, dstName, JpegQuality.EncodeOption()); err != nil {
+		log.Debugf("watermark: failed to save %s", clean.Log(filepath.Base(dstName)))
+		return "", err
+	}
+
+	return dstName, nil
+}
+
+// Apply draws the watermark on a copy of the image and returns it.
+func (w Watermark) Apply(img image.Image) (image.Image, error) {
+	if w.Empty() {
+		return img, nil
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width == 0 || height == 0 {
+		return img, fmt.Errorf("watermark: image has no pixels")
+	}
+
+	mark, err := w.mark(width, height)
+
+	if err != nil {
+		return img, err
+	} else if mark == nil {
+		return img, nil
+	}
+
+	opacity := w.Opacity
+
+	if opacity <= 0 || opacity > 100 {
+		opacity = DefaultWatermarkOpacity
+	}
+
+	// Keep a margin of 2% of the longest side.
+	margin := width
+
+	if height > margin {
+		margin = height
+	}
+
+	margin = margin / 50
+
+	size := mark.Bounds().Size()
+	right, bottom := width-size.X-margin, height-size.Y-margin
+
+	var pos image.Point
+
+	switch w.Position {
+	case WatermarkTopLeft:
+		pos = image.Pt(margin, margin)
+	case WatermarkTopRight:
+		pos = image.Pt(right, margin)
+	case WatermarkBottomLeft:
+		pos = image.Pt(margin, bottom)
+	case WatermarkCenter:
+		pos = image.Pt((width-size.X)/2, (height-size.Y)/2)
+	default:
+		pos = image.Pt(right, bottom)
+	}
+
+	return imaging.Overlay(img, mark, bounds.Min.Add(pos), float64(opacity)/100), nil
+}
+
+// mark renders the logo and text of the watermark, scaled to the image size.
+func (w Watermark) mark(width, height int) (*image.NRGBA, error) {
+	var logo, text *image.NRGBA
+
+	// The logo must not be wider than a quarter of the image.
+	if w.Logo != "" {
+		src, err := imaging.Open(w.Logo)
+
+		if err != nil {
+			return nil, fmt.Errorf("watermark: %s (open logo)", err)
+		}
+
+		if maxWidth := width / 4; maxWidth > 0 && src.Bounds().Dx() > maxWidth {
+			logo = imaging.Resize(src, maxWidth, 0, imaging.Lanczos)
+		} else {
+			logo = imaging.Clone(src)
+		}
+	}
+
+	// The font size depends on the shortest side.
+	if w.Text != "" {
+		fontSize := width
+
+		if height < fontSize {
+			fontSize = height
+		}
+
+		fontSize = fontSize / 25
+
+		if fontSize < 8 {
+			fontSize = 8
+		}
+
+		var err error
+
+		if text, err = renderText(w.Text, float64(fontSize)); err != nil {
+			return nil, err
+		}
+	}
+
+	if logo == nil {
+		return text, nil
+	} else if text == nil {
+		return logo, nil
+	}
+
+	// Place the text below the logo.
+	logoSize, textSize := logo.Bounds().Size(), text.Bounds().Size()
+	markWidth := logoSize.X
+
+	if textSize.X > markWidth {
+		markWidth = textSize.X
+	}
+
+	result := image.NewNRGBA(image.Rect(0, 0, markWidth, logoSize.Y+textSize.Y))
+	result = imaging.Paste(result, logo, image.Pt((markWidth-logoSize.X)/2, 0))
+	result = imaging.Overlay(result, text, image.Pt((markWidth-textSize.X)/2, logoSize.Y), 1)
+
+	return result, nil
+}
+
+// renderText draws white text with a dark shadow on a transparent background.
+func renderText(s string, size float64) (*image.NRGBA, error) {
+	var err error
+
+	watermarkFontOnce.Do(func() {
+		watermarkFont, err = opentype.Parse(gobold.TTF)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("watermark: %s (parse font)", err)
+	} else if watermarkFont == nil {
+		return nil, fmt.Errorf("watermark: font not found")
+	}
+
+	face, err := opentype.NewFace(watermarkFont, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+
+	if err != nil {
+		return nil, fmt.Errorf("watermark: %s (font face)", err)
+	}
+
+	defer face.Close()
+
+	metrics := face.Metrics()
+	shadow := int(size/16) + 1
+
+	d := &font.Drawer{Face: face}
+	textWidth := d.MeasureString(s).Ceil()
+	textHeight := (metrics.Ascent + metrics.Descent).Ceil()
+
+	d.Dst = image.NewNRGBA(image.Rect(0, 0, textWidth+shadow, textHeight+shadow))
+	d.Src = image.NewUniform(color.NRGBA{A: 160})
+	d.Dot = fixed.P(shadow, metrics.Ascent.Ceil()+shadow)
+	d.DrawString(s)
+
+	d.Src = image.White
+	d.Dot = fixed.P(0, metrics.Ascent.Ceil())
+	d.DrawString(s)
+
+	return d.Dst.(*image.NRGBA), nil
+}
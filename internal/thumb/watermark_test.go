@@ -0,0 +1,103 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatermark_Empty(t *testing.T) {
+	assert.True(t, Watermark{}.Empty())
+	assert.True(t, Watermark{Position: WatermarkCenter, Opacity: 80}.Empty())
+	assert.False(t, Watermark{Text: "© Example"}.Empty())
+	assert.False(t, Watermark{Logo: "testdata/example.png"}.Empty())
+}
+
+func TestWatermark_Key(t *testing.T) {
+	w := Watermark{Text: "© Example", Position: WatermarkBottomRight, Opacity: 50}
+
+	assert.Len(t, w.Key(), 8)
+	assert.Equal(t, w.Key(), Watermark{Text: "© Example", Position: WatermarkBottomRight, Opacity: 50}.Key())
+	assert.NotEqual(t, w.Key(), Watermark{Text: "© Example", Position: WatermarkTopLeft, Opacity: 50}.Key())
+}
+
+func TestWatermark_FileName(t *testing.T) {
+	w := Watermark{Text: "© Example"}
+
+	assert.Equal(t, filepath.Join("cache", "abc_"+w.Key()+".jpg"), w.FileName("cache", "abc.png"))
+}
+
+func TestWatermark_Apply(t *testing.T) {
+	src := imaging.New(400, 300, color.NRGBA{A: 255})
+
+	t.Run("Empty", func(t *testing.T) {
+		result, err := Watermark{}.Apply(src)
+
+		assert.NoError(t, err)
+		assert.Equal(t, image.Image(src), result)
+	})
+	t.Run("Text", func(t *testing.T) {
+		result, err := Watermark{Text: "© Example", Position: WatermarkBottomRight, Opacity: 100}.Apply(src)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, src.Bounds(), result.Bounds())
+
+		// Top left corner must remain unchanged.
+		r, g, b, _ := result.At(0, 0).RGBA()
+		assert.Equal(t, uint32(0), r+g+b)
+
+		// The text must have brightened some pixels in the bottom right corner.
+		var bright int
+
+		for x := 200; x < 400; x++ {
+			for y := 250; y < 300; y++ {
+				if r, _, _, _ := result.At(x, y).RGBA(); r > 0x8000 {
+					bright++
+				}
+			}
+		}
+
+		assert.Greater(t, bright, 0)
+	})
+	t.Run("Logo", func(t *testing.T) {
+		result, err := Watermark{Logo: "testdata/example.png", Text: "Example", Position: WatermarkTopLeft}.Apply(src)
+
+		assert.NoError(t, err)
+		assert.Equal(t, src.Bounds(), result.Bounds())
+	})
+	t.Run("LogoMissing", func(t *testing.T) {
+		_, err := Watermark{Logo: "testdata/missing.png"}.Apply(src)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWatermark_FromFile(t *testing.T) {
+	w := Watermark{Text: "© Example", Position: WatermarkCenter}
+	dst := w.FileName(filepath.Join("testdata", "watermarks"), "example.jpg")
+
+	defer os.RemoveAll(filepath.Join("testdata", "watermarks"))
+
+	fileName, err := w.FromFile("testdata/example.jpg", dst, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, dst, fileName)
+	assert.FileExists(t, fileName)
+
+	// Existing files are returned as they are.
+	fileName, err = w.FromFile("testdata/missing.jpg", dst, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dst, fileName)
+}
@@ -0,0 +1,144 @@
+package tiles
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MBTiles represents a read-only SQLite database with prerendered map tiles,
+// see https://github.com/mapbox/mbtiles-spec.
+type MBTiles struct {
+	db   *sql.DB
+	info Info
+}
+
+// OpenMBTiles opens an MBTiles file and reads its metadata.
+func OpenMBTiles(fileName string) (*MBTiles, error) {
+	if !fs.FileExists(fileName) {
+		return nil, fmt.Errorf("%s not found", clean.Log(filepath.Base(fileName)))
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+fileName+"?mode=ro")
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MBTiles{db: db, info: Info{Name: strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName)), MaxZoom: MaxZoom}}
+
+	if err = m.readMetadata(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%s in %s", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	return m, nil
+}
+
+// readMetadata reads the name, format, zoom levels, bounds, and attribution from the metadata table.
+func (m *MBTiles) readMetadata() error {
+	rows, err := m.db.Query("SELECT name, value FROM metadata")
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, value string
+
+		if err = rows.Scan(&name, &value); err != nil {
+			return err
+		}
+
+		switch name {
+		case "name":
+			if value != "" {
+				m.info.Name = value
+			}
+		case "format":
+			m.info.Format = ParseFormat(value)
+		case "minzoom":
+			m.info.MinZoom, _ = strconv.Atoi(value)
+		case "maxzoom":
+			if z, err := strconv.Atoi(value); err == nil {
+				m.info.MaxZoom = z
+			}
+		case "bounds":
+			m.info.Bounds = parseBounds(value)
+		case "attribution":
+			m.info.Attribution = value
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	} else if m.info.Format == "" {
+		return fmt.Errorf("unsupported tile format")
+	}
+
+	return nil
+}
+
+// Info returns the tile source metadata.
+func (m *MBTiles) Info() Info {
+	return m.info
+}
+
+// Tile returns the tile with the specified XYZ coordinates.
+func (m *MBTiles) Tile(z, x, y int) (*Tile, error) {
+	if err := ValidTile(z, x, y); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+
+	// MBTiles uses the TMS tiling scheme, in which rows are numbered from south to north.
+	row := (1 << z) - 1 - y
+
+	err := m.db.QueryRow("SELECT tile_data FROM tiles WHERE zoom_level = ? AND tile_column = ? AND tile_row = ?", z, x, row).Scan(&data)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &Tile{Data: data, Format: m.info.Format}, nil
+}
+
+// Close closes the database.
+func (m *MBTiles) Close() error {
+	return m.db.Close()
+}
+
+// parseBounds parses bounds in the format "west,south,east,north", or returns nil if they are invalid.
+func parseBounds(s string) []float64 {
+	values := strings.Split(s, ",")
+
+	if len(values) != 4 {
+		return nil
+	}
+
+	result := make([]float64, 4)
+
+	for i := range values {
+		f, err := strconv.ParseFloat(strings.TrimSpace(values[i]), 64)
+
+		if err != nil {
+			return nil
+		}
+
+		result[i] = f
+	}
+
+	return result
+}
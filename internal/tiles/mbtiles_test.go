@@ -0,0 +1,121 @@
+package tiles
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createMBTiles creates an MBTiles file with a single gzip compressed vector tile at 1/0/0.
+func createMBTiles(t *testing.T, format string) string {
+	fileName := filepath.Join(t.TempDir(), "world.mbtiles")
+
+	db, err := sql.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	for _, stmt := range []string{
+		"CREATE TABLE metadata (name TEXT, value TEXT)",
+		"CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)",
+	} {
+		if _, err = db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, value := range map[string]string{
+		"name":        "World",
+		"format":      format,
+		"minzoom":     "0",
+		"maxzoom":     "14",
+		"bounds":      "-180,-85.0511,180,85.0511",
+		"attribution": "© OpenStreetMap contributors",
+	} {
+		if _, err = db.Exec("INSERT INTO metadata (name, value) VALUES (?, ?)", name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Row 1 at zoom level 1 is the northern row 0 in the XYZ scheme.
+	if _, err = db.Exec("INSERT INTO tiles VALUES (1, 0, 1, ?)", []byte{0x1f, 0x8b, 0x08, 0x00}); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestOpenMBTiles(t *testing.T) {
+	t.Run("Vector", func(t *testing.T) {
+		m, err := OpenMBTiles(createMBTiles(t, "pbf"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Close()
+
+		info := m.Info()
+
+		assert.Equal(t, "World", info.Name)
+		assert.Equal(t, FormatPbf, info.Format)
+		assert.True(t, info.Vector())
+		assert.Equal(t, 0, info.MinZoom)
+		assert.Equal(t, 14, info.MaxZoom)
+		assert.Equal(t, []float64{-180, -85.0511, 180, 85.0511}, info.Bounds)
+		assert.Equal(t, "© OpenStreetMap contributors", info.Attribution)
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := OpenMBTiles(createMBTiles(t, "gif"))
+
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := OpenMBTiles(filepath.Join(t.TempDir(), "missing.mbtiles"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMBTiles_Tile(t *testing.T) {
+	m, err := OpenMBTiles(createMBTiles(t, "pbf"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Close()
+
+	t.Run("Found", func(t *testing.T) {
+		tile, err := m.Tile(1, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, FormatPbf, tile.Format)
+		assert.True(t, tile.Gzip())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := m.Tile(1, 0, 1)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := m.Tile(1, 2, 0)
+
+		assert.Error(t, err)
+		assert.NotEqual(t, ErrNotFound, err)
+	})
+}
+
+func TestParseBounds(t *testing.T) {
+	assert.Equal(t, []float64{13.1, 52.3, 13.7, 52.7}, parseBounds("13.1, 52.3, 13.7, 52.7"))
+	assert.Nil(t, parseBounds("13.1,52.3,13.7"))
+	assert.Nil(t, parseBounds("a,b,c,d"))
+}
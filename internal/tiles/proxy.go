@@ -0,0 +1,158 @@
+package tiles
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ProxyTimeout is the maximum time to wait for a tile server response.
+var ProxyTimeout = 15 * time.Second
+
+// ProxyMaxAge is the time after which cached tiles are downloaded again.
+var ProxyMaxAge = 30 * 24 * time.Hour
+
+// ProxyUserAgent is sent to the tile server, as many providers require clients to identify themselves.
+var ProxyUserAgent = "PhotoPrism/1.0"
+
+// Proxy downloads map tiles from a tile server and caches them on disk, so that
+// each tile is only requested once and remains available when the server is offline.
+type Proxy struct {
+	url       string
+	cachePath string
+	info      Info
+	client    *http.Client
+}
+
+// NewProxy returns a new caching proxy for a tile server URL template with {z}, {x}, and {y} placeholders,
+// e.g. https://tile.openstreetmap.org/{z}/{x}/{y}.png.
+func NewProxy(urlTemplate, cachePath string) (*Proxy, error) {
+	u, err := url.Parse(urlTemplate)
+
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid tile server url %s", clean.Log(urlTemplate))
+	}
+
+	for _, s := range []string{"{z}", "{x}", "{y}"} {
+		if !strings.Contains(urlTemplate, s) {
+			return nil, fmt.Errorf("tile server url must contain %s", s)
+		}
+	}
+
+	format := ParseFormat(path.Ext(u.Path))
+
+	if format == "" {
+		format = FormatPng
+	}
+
+	if cachePath == "" {
+		return nil, fmt.Errorf("tile cache path must not be empty")
+	} else if err = os.MkdirAll(cachePath, fs.ModeDir); err != nil {
+		return nil, err
+	}
+
+	return &Proxy{
+		url:       urlTemplate,
+		cachePath: cachePath,
+		info:      Info{Name: u.Host, Format: format, MaxZoom: MaxZoom},
+		client:    &http.Client{Timeout: ProxyTimeout},
+	}, nil
+}
+
+// Info returns the tile source metadata.
+func (p *Proxy) Info() Info {
+	return p.info
+}
+
+// Tile returns the tile with the specified XYZ coordinates from the cache,
+// or downloads it from the tile server if needed.
+func (p *Proxy) Tile(z, x, y int) (*Tile, error) {
+	if err := ValidTile(z, x, y); err != nil {
+		return nil, err
+	}
+
+	fileName := filepath.Join(p.cachePath, strconv.Itoa(z), strconv.Itoa(x), fmt.Sprintf("%d.%s", y, p.info.Format))
+
+	// Return cached tile?
+	if info, err := os.Stat(fileName); err == nil && time.Since(info.ModTime()) < ProxyMaxAge {
+		if data, err := os.ReadFile(fileName); err == nil {
+			return &Tile{Data: data, Format: p.info.Format}, nil
+		}
+	}
+
+	data, err := p.download(z, x, y)
+
+	if err == ErrNotFound {
+		return nil, err
+	} else if err != nil {
+		// Use outdated tile if the server is not available.
+		if cached, readErr := os.ReadFile(fileName); readErr == nil {
+			log.Debugf("tiles: %s, using cached tile %d/%d/%d", err, z, x, y)
+			return &Tile{Data: cached, Format: p.info.Format}, nil
+		}
+
+		return nil, err
+	}
+
+	if err = p.cache(fileName, data); err != nil {
+		log.Warnf("tiles: %s (cache tile %d/%d/%d)", err, z, x, y)
+	}
+
+	return &Tile{Data: data, Format: p.info.Format}, nil
+}
+
+// download requests a tile from the tile server.
+func (p *Proxy) download(z, x, y int) ([]byte, error) {
+	tileUrl := strings.NewReplacer("{z}", strconv.Itoa(z), "{x}", strconv.Itoa(x), "{y}", strconv.Itoa(y)).Replace(p.url)
+
+	req, err := http.NewRequest(http.MethodGet, tileUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", ProxyUserAgent)
+
+	resp, err := p.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("tile server returned status %d", resp.StatusCode)
+	}
+}
+
+// cache saves a tile in the cache directory.
+func (p *Proxy) cache(fileName string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that concurrent requests never read partial tiles.
+	tmpName := fileName + ".tmp" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	if err := os.WriteFile(tmpName, data, fs.ModeFile); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, fileName)
+}
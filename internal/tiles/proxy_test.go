@@ -0,0 +1,132 @@
+package tiles
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProxy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		p, err := NewProxy("https://tile.openstreetmap.org/{z}/{x}/{y}.png", t.TempDir())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "tile.openstreetmap.org", p.Info().Name)
+		assert.Equal(t, FormatPng, p.Info().Format)
+		assert.Equal(t, MaxZoom, p.Info().MaxZoom)
+	})
+	t.Run("MissingPlaceholder", func(t *testing.T) {
+		_, err := NewProxy("https://tile.openstreetmap.org/{z}/{x}.png", t.TempDir())
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidUrl", func(t *testing.T) {
+		_, err := NewProxy("file:///tiles/{z}/{x}/{y}.png", t.TempDir())
+
+		assert.Error(t, err)
+	})
+	t.Run("NoCachePath", func(t *testing.T) {
+		_, err := NewProxy("https://tile.openstreetmap.org/{z}/{x}/{y}.png", "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestProxy_Tile(t *testing.T) {
+	requests := 0
+	online := true
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if !online {
+			w.WriteHeader(http.StatusBadGateway)
+		} else if r.URL.Path == "/2/1/3.png" {
+			assert.Equal(t, ProxyUserAgent, r.Header.Get("User-Agent"))
+			_, _ = w.Write([]byte("tile"))
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer srv.Close()
+
+	cachePath := t.TempDir()
+
+	p, err := NewProxy(srv.URL+"/{z}/{x}/{y}.png", cachePath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Download", func(t *testing.T) {
+		tile, err := p.Tile(2, 1, 3)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []byte("tile"), tile.Data)
+		assert.Equal(t, FormatPng, tile.Format)
+		assert.FileExists(t, filepath.Join(cachePath, "2", "1", "3.png"))
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("Cached", func(t *testing.T) {
+		tile, err := p.Tile(2, 1, 3)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []byte("tile"), tile.Data)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := p.Tile(2, 0, 0)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Offline", func(t *testing.T) {
+		online = false
+		defer func() { online = true }()
+
+		// Outdated tiles are used if the server is not available.
+		maxAge := ProxyMaxAge
+		ProxyMaxAge = 0
+		defer func() { ProxyMaxAge = maxAge }()
+
+		tile, err := p.Tile(2, 1, 3)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []byte("tile"), tile.Data)
+
+		_, err = p.Tile(2, 2, 2)
+
+		assert.Error(t, err)
+		assert.NotEqual(t, ErrNotFound, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := p.Tile(2, 4, 0)
+
+		assert.Error(t, err)
+	})
+
+	entries, err := os.ReadDir(filepath.Join(cachePath, "2", "1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Temporary files must not remain in the cache.
+	assert.Len(t, entries, 1)
+}
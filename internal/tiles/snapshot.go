@@ -0,0 +1,180 @@
+package tiles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+)
+
+// TileSize is the size of raster tiles in pixels.
+const TileSize = 256
+
+// SnapshotMaxZoom is the maximum zoom level of map snapshots, so that single locations are shown with context.
+var SnapshotMaxZoom = 15
+
+// ErrVectorTiles is returned if a map snapshot cannot be rendered because the source provides vector tiles.
+var ErrVectorTiles = errors.New("vector tiles are not supported")
+
+// Snapshot marker colors.
+var (
+	MarkerColor  = color.RGBA{R: 0xd3, G: 0x2f, B: 0x2f, A: 0xff}
+	MarkerBorder = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// Point represents a geographic location on a map.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Snapshot renders a static map image with the specified size and a marker for each point, using raster tiles
+// from the source. The zoom level is chosen so that all points are visible.
+func Snapshot(src Source, points []Point, width, height int) (image.Image, error) {
+	if src == nil {
+		return nil, errors.New("no map tile source")
+	} else if src.Info().Vector() {
+		return nil, ErrVectorTiles
+	} else if len(points) == 0 {
+		return nil, errors.New("no locations")
+	} else if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid snapshot size %dx%d", width, height)
+	}
+
+	info := src.Info()
+	zoom := snapshotZoom(points, width, height, info.MinZoom, info.MaxZoom)
+
+	// Find the center of all points in world pixel coordinates.
+	minX, minY, maxX, maxY := pixelBounds(points, zoom)
+	left := int(math.Round((minX+maxX)/2)) - width/2
+	top := int(math.Round((minY+maxY)/2)) - height/2
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{R: 0xe5, G: 0xe3, B: 0xdf, A: 0xff}}, image.Point{}, draw.Src)
+
+	n := 1 << zoom
+	found := 0
+
+	var lastErr error
+
+	for ty := floorDiv(top, TileSize); ty <= floorDiv(top+height-1, TileSize); ty++ {
+		if ty < 0 || ty >= n {
+			continue
+		}
+
+		for tx := floorDiv(left, TileSize); tx <= floorDiv(left+width-1, TileSize); tx++ {
+			tile, err := src.Tile(zoom, ((tx%n)+n)%n, ty)
+
+			if errors.Is(err, ErrNotFound) {
+				continue
+			} else if err != nil {
+				lastErr = err
+				continue
+			}
+
+			tileImg, _, err := image.Decode(bytes.NewReader(tile.Data))
+
+			if err != nil {
+				lastErr = err
+				continue
+			}
+
+			// Scale high-resolution tiles to the default size.
+			if b := tileImg.Bounds(); b.Dx() != TileSize || b.Dy() != TileSize {
+				tileImg = imaging.Resize(tileImg, TileSize, TileSize, imaging.Lanczos)
+			}
+
+			pos := image.Pt(tx*TileSize-left, ty*TileSize-top)
+			draw.Draw(img, image.Rectangle{Min: pos, Max: pos.Add(image.Pt(TileSize, TileSize))}, tileImg, tileImg.Bounds().Min, draw.Src)
+			found++
+		}
+	}
+
+	if found == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	// Draw markers.
+	for _, p := range points {
+		x, y := pixel(p, zoom)
+		drawMarker(img, int(math.Round(x))-left, int(math.Round(y))-top)
+	}
+
+	return img, nil
+}
+
+// snapshotZoom returns the highest zoom level at which all points fit into the image with some padding.
+func snapshotZoom(points []Point, width, height, minZoom, maxZoom int) int {
+	if maxZoom <= 0 || maxZoom > SnapshotMaxZoom {
+		maxZoom = SnapshotMaxZoom
+	}
+
+	padding := 2 * 24
+
+	for z := maxZoom; z > minZoom; z-- {
+		minX, minY, maxX, maxY := pixelBounds(points, z)
+
+		if maxX-minX+float64(padding) <= float64(width) && maxY-minY+float64(padding) <= float64(height) {
+			return z
+		}
+	}
+
+	return minZoom
+}
+
+// pixelBounds returns the bounding box of the points in world pixel coordinates.
+func pixelBounds(points []Point, zoom int) (minX, minY, maxX, maxY float64) {
+	minX, minY = math.MaxFloat64, math.MaxFloat64
+	maxX, maxY = -math.MaxFloat64, -math.MaxFloat64
+
+	for _, p := range points {
+		x, y := pixel(p, zoom)
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+
+	return minX, minY, maxX, maxY
+}
+
+// pixel returns the world pixel coordinates of a point in the Web Mercator projection.
+func pixel(p Point, zoom int) (x, y float64) {
+	lat := math.Max(-85.05112878, math.Min(85.05112878, p.Lat)) * math.Pi / 180
+	size := float64(TileSize) * float64(int(1)<<zoom)
+
+	x = (p.Lng + 180) / 360 * size
+	y = (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * size
+
+	return x, y
+}
+
+// drawMarker draws a round marker with a border.
+func drawMarker(img *image.RGBA, cx, cy int) {
+	const radius, border = 7, 2
+
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if d := x*x + y*y; d <= (radius-border)*(radius-border) {
+				img.Set(cx+x, cy+y, MarkerColor)
+			} else if d <= radius*radius {
+				img.Set(cx+x, cy+y, MarkerBorder)
+			}
+		}
+	}
+}
+
+// floorDiv returns a divided by b, rounded towards negative infinity.
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+
+	return a / b
+}
@@ -0,0 +1,95 @@
+package tiles
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSource returns plain blue raster tiles and counts the requests.
+type testSource struct {
+	format   string
+	requests int
+}
+
+func (s *testSource) Info() Info {
+	return Info{Name: "test", Format: s.format, MaxZoom: 18}
+}
+
+func (s *testSource) Tile(z, x, y int) (*Tile, error) {
+	if err := ValidTile(z, x, y); err != nil {
+		return nil, err
+	}
+
+	s.requests++
+
+	img := image.NewRGBA(image.Rect(0, 0, TileSize, TileSize))
+
+	for i := range img.Pix {
+		if i%4 == 2 || i%4 == 3 {
+			img.Pix[i] = 0xff
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return &Tile{Data: buf.Bytes(), Format: FormatPng}, nil
+}
+
+func TestSnapshot(t *testing.T) {
+	berlin := Point{Lat: 52.5200, Lng: 13.4050}
+	potsdam := Point{Lat: 52.3906, Lng: 13.0645}
+
+	t.Run("SinglePoint", func(t *testing.T) {
+		src := &testSource{format: FormatPng}
+		img, err := Snapshot(src, []Point{berlin}, 400, 300)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 400, 300), img.Bounds())
+		assert.Equal(t, MarkerColor, color.RGBAModel.Convert(img.At(200, 150)))
+		assert.Equal(t, color.RGBA{B: 0xff, A: 0xff}, color.RGBAModel.Convert(img.At(10, 10)))
+		assert.GreaterOrEqual(t, src.requests, 4)
+	})
+	t.Run("MultiplePoints", func(t *testing.T) {
+		src := &testSource{format: FormatPng}
+		img, err := Snapshot(src, []Point{berlin, potsdam}, 400, 300)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 400, 300), img.Bounds())
+	})
+	t.Run("Vector", func(t *testing.T) {
+		_, err := Snapshot(&testSource{format: FormatPbf}, []Point{berlin}, 400, 300)
+		assert.ErrorIs(t, err, ErrVectorTiles)
+	})
+	t.Run("NoSource", func(t *testing.T) {
+		_, err := Snapshot(nil, []Point{berlin}, 400, 300)
+		assert.Error(t, err)
+	})
+	t.Run("NoPoints", func(t *testing.T) {
+		_, err := Snapshot(&testSource{format: FormatPng}, nil, 400, 300)
+		assert.Error(t, err)
+	})
+}
+
+func TestSnapshotZoom(t *testing.T) {
+	berlin := Point{Lat: 52.5200, Lng: 13.4050}
+	newYork := Point{Lat: 40.7128, Lng: -74.0060}
+
+	assert.Equal(t, SnapshotMaxZoom, snapshotZoom([]Point{berlin}, 400, 300, 0, 18))
+	assert.Equal(t, 10, snapshotZoom([]Point{berlin}, 400, 300, 0, 10))
+	assert.Less(t, snapshotZoom([]Point{berlin, newYork}, 400, 300, 0, 18), 3)
+}
@@ -0,0 +1,143 @@
+/*
+Package tiles serves map tiles from local MBTiles files or a caching proxy, so that maps work without external tile providers.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package tiles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// ErrNotFound is returned if a tile does not exist, e.g. above the ocean.
+var ErrNotFound = errors.New("tile not found")
+
+// MaxZoom is the default maximum zoom level.
+var MaxZoom = 18
+
+// Tile formats.
+const (
+	FormatPbf  = "pbf"
+	FormatPng  = "png"
+	FormatJpg  = "jpg"
+	FormatWebp = "webp"
+)
+
+// Source represents a map tile provider.
+type Source interface {
+	Tile(z, x, y int) (*Tile, error)
+	Info() Info
+}
+
+// Info represents the metadata of a tile source.
+type Info struct {
+	Name        string    `json:"name"`
+	Format      string    `json:"format"`
+	MinZoom     int       `json:"minzoom"`
+	MaxZoom     int       `json:"maxzoom"`
+	Bounds      []float64 `json:"bounds,omitempty"`
+	Attribution string    `json:"attribution,omitempty"`
+}
+
+// Vector tests if the source provides vector tiles.
+func (info Info) Vector() bool {
+	return info.Format == FormatPbf
+}
+
+// Tile represents a map tile.
+type Tile struct {
+	Data   []byte
+	Format string
+}
+
+// ContentType returns the HTTP content type of the tile.
+func (t *Tile) ContentType() string {
+	switch t.Format {
+	case FormatPbf:
+		return "application/x-protobuf"
+	case FormatJpg:
+		return "image/jpeg"
+	case FormatWebp:
+		return "image/webp"
+	default:
+		return "image/png"
+	}
+}
+
+// Gzip tests if the tile data is gzip compressed, as is common for vector tiles.
+func (t *Tile) Gzip() bool {
+	return bytes.HasPrefix(t.Data, []byte{0x1f, 0x8b})
+}
+
+// ParseFormat returns the normalized tile format, or an empty string if it is not supported.
+func ParseFormat(s string) string {
+	switch s = strings.ToLower(strings.Trim(s, ". ")); s {
+	case FormatPbf, "mvt":
+		return FormatPbf
+	case FormatPng:
+		return FormatPng
+	case FormatJpg, "jpeg":
+		return FormatJpg
+	case FormatWebp:
+		return FormatWebp
+	default:
+		return ""
+	}
+}
+
+// ValidTile returns an error if the tile coordinates are outside the range of the zoom level.
+func ValidTile(z, x, y int) error {
+	if z < 0 || z > 24 {
+		return fmt.Errorf("invalid zoom level %d", z)
+	} else if n := 1 << z; x < 0 || x >= n || y < 0 || y >= n {
+		return fmt.Errorf("invalid tile %d/%d/%d", z, x, y)
+	}
+
+	return nil
+}
+
+// New returns the tile source for a local MBTiles file or, if no file is specified, a caching proxy
+// for the tile server URL. It returns nil if neither is configured.
+func New(fileName, url, cachePath string) (Source, error) {
+	if fileName != "" {
+		if m, err := OpenMBTiles(fileName); err != nil {
+			return nil, err
+		} else {
+			return m, nil
+		}
+	} else if url != "" {
+		if p, err := NewProxy(url, cachePath); err != nil {
+			return nil, err
+		} else {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package tiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	assert.Equal(t, FormatPbf, ParseFormat("pbf"))
+	assert.Equal(t, FormatPbf, ParseFormat(".MVT"))
+	assert.Equal(t, FormatJpg, ParseFormat("jpeg"))
+	assert.Equal(t, FormatWebp, ParseFormat("webp"))
+	assert.Equal(t, FormatPng, ParseFormat(".png"))
+	assert.Equal(t, "", ParseFormat("gif"))
+}
+
+func TestValidTile(t *testing.T) {
+	assert.NoError(t, ValidTile(0, 0, 0))
+	assert.NoError(t, ValidTile(3, 7, 7))
+	assert.Error(t, ValidTile(3, 8, 0))
+	assert.Error(t, ValidTile(3, 0, -1))
+	assert.Error(t, ValidTile(-1, 0, 0))
+	assert.Error(t, ValidTile(25, 0, 0))
+}
+
+func TestTile(t *testing.T) {
+	t.Run("Vector", func(t *testing.T) {
+		tile := &Tile{Data: []byte{0x1f, 0x8b, 0x08}, Format: FormatPbf}
+
+		assert.Equal(t, "application/x-protobuf", tile.ContentType())
+		assert.True(t, tile.Gzip())
+	})
+	t.Run("Raster", func(t *testing.T) {
+		tile := &Tile{Data: []byte{0x89, 'P', 'N', 'G'}, Format: FormatPng}
+
+		assert.Equal(t, "image/png", tile.ContentType())
+		assert.False(t, tile.Gzip())
+		assert.Equal(t, "image/jpeg", (&Tile{Format: FormatJpg}).ContentType())
+		assert.Equal(t, "image/webp", (&Tile{Format: FormatWebp}).ContentType())
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		src, err := New("", "", "")
+
+		assert.NoError(t, err)
+		assert.Nil(t, src)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		src, err := New("/does/not/exist.mbtiles", "", "")
+
+		assert.Error(t, err)
+		assert.Nil(t, src)
+	})
+	t.Run("Proxy", func(t *testing.T) {
+		src, err := New("", "https://tile.example.com/{z}/{x}/{y}.webp", t.TempDir())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &Proxy{}, src)
+		assert.Equal(t, FormatWebp, src.Info().Format)
+		assert.False(t, src.Info().Vector())
+	})
+}
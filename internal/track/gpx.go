@@ -0,0 +1,56 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type gpxFile struct {
+	Tracks []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lng  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+// ParseGPX returns the tracks in GPX data, one for each track segment.
+func ParseGPX(data []byte) (result Tracks, err error) {
+	var doc gpxFile
+
+	if err = xml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return result, fmt.Errorf("track: %s (parse gpx)", err)
+	}
+
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			t := Track{Name: strings.TrimSpace(trk.Name), Points: make([]Point, 0, len(seg.Points))}
+
+			for _, p := range seg.Points {
+				if ts, err := time.Parse(time.RFC3339, strings.TrimSpace(p.Time)); err == nil {
+					t.Points = append(t.Points, Point{Time: ts, Lat: p.Lat, Lng: p.Lng, Altitude: p.Ele})
+				}
+			}
+
+			if t.Sort(); !t.Empty() {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result, nil
+}
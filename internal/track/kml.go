@@ -0,0 +1,99 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kmlTrack represents a gx:Track element with timestamps and coordinates.
+type kmlTrack struct {
+	When  []string `xml:"when"`
+	Coord []string `xml:"coord"`
+}
+
+// ParseKML returns the tracks in KML data. Only gx:Track elements contain timestamps,
+// so that other geometries like LineString are ignored.
+func ParseKML(data []byte) (result Tracks, err error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var name string
+
+	for {
+		token, err := dec.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return result, fmt.Errorf("track: %s (parse kml)", err)
+		}
+
+		el, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		switch el.Name.Local {
+		case "name":
+			// Use the most recent placemark or document name for the next track.
+			if err = dec.DecodeElement(&name, &el); err != nil {
+				return result, fmt.Errorf("track: %s (parse kml)", err)
+			}
+		case "Track":
+			var trk kmlTrack
+
+			if err = dec.DecodeElement(&trk, &el); err != nil {
+				return result, fmt.Errorf("track: %s (parse kml)", err)
+			}
+
+			if t := trk.track(strings.TrimSpace(name)); !t.Empty() {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// track returns the track points, skipping entries that cannot be parsed.
+func (k kmlTrack) track(name string) Track {
+	t := Track{Name: name}
+
+	for i := 0; i < len(k.When) && i < len(k.Coord); i++ {
+		ts, err := time.Parse(time.RFC3339, strings.TrimSpace(k.When[i]))
+
+		if err != nil {
+			continue
+		}
+
+		// Coordinates are separated by spaces, with longitude first.
+		values := strings.Fields(k.Coord[i])
+
+		if len(values) < 2 {
+			continue
+		}
+
+		p := Point{Time: ts}
+
+		if p.Lng, err = strconv.ParseFloat(values[0], 64); err != nil {
+			continue
+		} else if p.Lat, err = strconv.ParseFloat(values[1], 64); err != nil {
+			continue
+		}
+
+		if len(values) > 2 {
+			p.Altitude, _ = strconv.ParseFloat(values[2], 64)
+		}
+
+		t.Points = append(t.Points, p)
+	}
+
+	t.Sort()
+
+	return t
+}
@@ -0,0 +1,154 @@
+package track
+
+import (
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// MaxGap is the default maximum time between two track points for interpolating a position.
+var MaxGap = 5 * time.Minute
+
+// Point represents a recorded GPS position.
+type Point struct {
+	Time     time.Time
+	Lat      float64
+	Lng      float64
+	Altitude float64
+}
+
+// Track represents a named sequence of GPS positions ordered by time.
+type Track struct {
+	Name   string
+	Points []Point
+}
+
+// Tracks represents a list of GPS tracks.
+type Tracks []Track
+
+// Sort orders the track points by time and removes points without time or coordinates.
+func (t *Track) Sort() {
+	points := make([]Point, 0, len(t.Points))
+
+	for _, p := range t.Points {
+		if p.Time.IsZero() || p.Lat == 0 && p.Lng == 0 {
+			continue
+		}
+
+		p.Time = p.Time.UTC()
+		points = append(points, p)
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+
+	t.Points = points
+}
+
+// Empty tests if the track has no points.
+func (t Track) Empty() bool {
+	return len(t.Points) == 0
+}
+
+// Start returns the time of the first track point.
+func (t Track) Start() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t.Points[0].Time
+}
+
+// End returns the time of the last track point.
+func (t Track) End() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t.Points[len(t.Points)-1].Time
+}
+
+// Position returns the interpolated position at the specified time, if it is within the track and the
+// surrounding points were recorded no more than maxGap apart.
+func (t Track) Position(at time.Time, maxGap time.Duration) (pos geo.Position, ok bool) {
+	if t.Empty() || at.Before(t.Start()) || at.After(t.End()) {
+		return pos, false
+	}
+
+	at = at.UTC()
+
+	// Find the first point that is not before the specified time.
+	i := sort.Search(len(t.Points), func(i int) bool {
+		return !t.Points[i].Time.Before(at)
+	})
+
+	next := t.Points[i]
+
+	if next.Time.Equal(at) || i == 0 {
+		return position(t.Name, at, next, next, 0), true
+	}
+
+	prev := t.Points[i-1]
+	gap := next.Time.Sub(prev.Time)
+
+	if maxGap > 0 && gap > maxGap {
+		return pos, false
+	}
+
+	return position(t.Name, at, prev, next, float64(at.Sub(prev.Time))/float64(gap)), true
+}
+
+// position returns the position between two points, with f being the fraction of the distance.
+func position(name string, at time.Time, a, b Point, f float64) geo.Position {
+	return geo.Position{
+		Name:     name,
+		Time:     at,
+		Lat:      a.Lat + (b.Lat-a.Lat)*f,
+		Lng:      a.Lng + (b.Lng-a.Lng)*f,
+		Altitude: a.Altitude + (b.Altitude-a.Altitude)*f,
+	}
+}
+
+// Start returns the time of the first track point of all tracks.
+func (t Tracks) Start() (start time.Time) {
+	for _, track := range t {
+		if s := track.Start(); !s.IsZero() && (start.IsZero() || s.Before(start)) {
+			start = s
+		}
+	}
+
+	return start
+}
+
+// End returns the time of the last track point of all tracks.
+func (t Tracks) End() (end time.Time) {
+	for _, track := range t {
+		if e := track.End(); e.After(end) {
+			end = e
+		}
+	}
+
+	return end
+}
+
+// Points returns the total number of track points.
+func (t Tracks) Points() (n int) {
+	for _, track := range t {
+		n += len(track.Points)
+	}
+
+	return n
+}
+
+// Position returns the interpolated position at the specified time from the first matching track.
+func (t Tracks) Position(at time.Time, maxGap time.Duration) (pos geo.Position, ok bool) {
+	for _, track := range t {
+		if pos, ok = track.Position(at, maxGap); ok {
+			return pos, true
+		}
+	}
+
+	return pos, false
+}
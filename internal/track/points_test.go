@@ -0,0 +1,75 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack_Position(t *testing.T) {
+	tracks, err := Read("testdata/hike.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hike := tracks[0]
+
+	t.Run("Interpolated", func(t *testing.T) {
+		pos, ok := hike.Position(time.Date(2019, 7, 12, 10, 1, 0, 0, time.UTC), MaxGap)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 47.55, pos.Lat, 0.00001)
+		assert.InDelta(t, 9.05, pos.Lng, 0.00001)
+		assert.InDelta(t, 450, pos.Altitude, 0.00001)
+		assert.Equal(t, "Hike", pos.Name)
+	})
+	t.Run("Exact", func(t *testing.T) {
+		pos, ok := hike.Position(time.Date(2019, 7, 12, 10, 0, 0, 0, time.UTC), MaxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 47.5, pos.Lat)
+	})
+	t.Run("LocalTime", func(t *testing.T) {
+		loc, _ := time.LoadLocation("Europe/Berlin")
+		pos, ok := hike.Position(time.Date(2019, 7, 12, 12, 30, 0, 0, loc), MaxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 47.7, pos.Lat)
+	})
+	t.Run("GapTooLarge", func(t *testing.T) {
+		_, ok := hike.Position(time.Date(2019, 7, 12, 10, 15, 0, 0, time.UTC), MaxGap)
+
+		assert.False(t, ok)
+
+		_, ok = hike.Position(time.Date(2019, 7, 12, 10, 15, 0, 0, time.UTC), time.Hour)
+
+		assert.True(t, ok)
+	})
+	t.Run("OutsideTrack", func(t *testing.T) {
+		_, ok := hike.Position(time.Date(2019, 7, 12, 9, 59, 0, 0, time.UTC), MaxGap)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestTracks_Position(t *testing.T) {
+	tracks, err := Read("testdata/hike.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, time.Date(2019, 7, 12, 10, 0, 0, 0, time.UTC), tracks.Start())
+	assert.Equal(t, time.Date(2019, 7, 12, 12, 1, 0, 0, time.UTC), tracks.End())
+
+	pos, ok := tracks.Position(time.Date(2019, 7, 12, 12, 0, 30, 0, time.UTC), MaxGap)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 48.0005, pos.Lat, 0.00001)
+
+	_, ok = tracks.Position(time.Date(2019, 7, 12, 11, 0, 0, 0, time.UTC), MaxGap)
+
+	assert.False(t, ok)
+}
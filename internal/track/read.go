@@ -0,0 +1,63 @@
+package track
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Supported track file formats.
+const (
+	FormatGPX = "gpx"
+	FormatKML = "kml"
+)
+
+// Format returns the track file format based on the file content.
+func Format(data []byte) string {
+	// Check the beginning of the file only.
+	if len(data) > 4096 {
+		data = data[:4096]
+	}
+
+	s := bytes.ToLower(data)
+
+	switch {
+	case bytes.Contains(s, []byte("<gpx")):
+		return FormatGPX
+	case bytes.Contains(s, []byte("<kml")):
+		return FormatKML
+	default:
+		return ""
+	}
+}
+
+// Parse returns the tracks in GPX or KML data.
+func Parse(data []byte) (Tracks, error) {
+	switch Format(data) {
+	case FormatGPX:
+		return ParseGPX(data)
+	case FormatKML:
+		return ParseKML(data)
+	default:
+		return nil, fmt.Errorf("track: unsupported file format")
+	}
+}
+
+// Read returns the tracks in a GPX or KML file.
+func Read(fileName string) (Tracks, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".gpx", ".kml":
+	default:
+		return nil, fmt.Errorf("track: unsupported file extension %s", filepath.Ext(fileName))
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
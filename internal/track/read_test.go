@@ -0,0 +1,60 @@
+package track
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, FormatGPX, Format([]byte(`<?xml version="1.0"?><gpx version="1.1">`)))
+	assert.Equal(t, FormatKML, Format([]byte(`<?xml version="1.0"?><kml xmlns="http://www.opengis.net/kml/2.2">`)))
+	assert.Equal(t, "", Format([]byte(`{"type": "FeatureCollection"}`)))
+}
+
+func TestRead(t *testing.T) {
+	t.Run("GPX", func(t *testing.T) {
+		tracks, err := Read("testdata/hike.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, tracks, 2)
+		assert.Equal(t, "Hike", tracks[0].Name)
+		assert.Len(t, tracks[0].Points, 3)
+		assert.Equal(t, 47.5, tracks[0].Points[0].Lat)
+		assert.Equal(t, float64(500), tracks[0].Points[0].Altitude)
+		assert.Equal(t, 5, tracks.Points())
+	})
+	t.Run("KML", func(t *testing.T) {
+		tracks, err := Read("testdata/drive.kml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, tracks, 1)
+		assert.Equal(t, "Morning", tracks[0].Name)
+		assert.Len(t, tracks[0].Points, 2)
+		assert.Equal(t, 52.51, tracks[0].Points[1].Lat)
+		assert.Equal(t, 13.41, tracks[0].Points[1].Lng)
+		assert.Equal(t, float64(40), tracks[0].Points[1].Altitude)
+	})
+	t.Run("UnsupportedExtension", func(t *testing.T) {
+		_, err := Read("testdata/hike.json")
+
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Read("testdata/missing.gpx")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("foo"))
+
+	assert.Error(t, err)
+}
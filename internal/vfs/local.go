@@ -0,0 +1,174 @@
+package vfs
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	ppfs "github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Local is a storage backend that keeps files in a local directory, e.g. on an external disk.
+type Local struct {
+	root string
+}
+
+// NewLocal returns a new storage backend for the specified directory, which is created if needed.
+func NewLocal(root string) (*Local, error) {
+	if root == "" {
+		return nil, fmt.Errorf("storage path must not be empty")
+	} else if err := os.MkdirAll(root, ppfs.ModeDir); err != nil {
+		return nil, err
+	}
+
+	return &Local{root: filepath.Clean(root)}, nil
+}
+
+// String returns the storage path.
+func (b *Local) String() string {
+	return b.root
+}
+
+// fileName returns the absolute file name for a storage key.
+func (b *Local) fileName(key string) (string, error) {
+	if key = Key(key); key == "" {
+		return "", fmt.Errorf("invalid key %s", clean.Log(key))
+	}
+
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
+
+// Stat returns information about the file with the specified key.
+func (b *Local) Stat(key string) (result FileInfo, err error) {
+	fileName, err := b.fileName(key)
+
+	if err != nil {
+		return result, err
+	}
+
+	info, err := os.Stat(fileName)
+
+	if os.IsNotExist(err) || err == nil && info.IsDir() {
+		return result, ErrNotFound
+	} else if err != nil {
+		return result, err
+	}
+
+	return FileInfo{Key: Key(key), Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Open returns a reader for the file with the specified key.
+func (b *Local) Open(key string) (io.ReadCloser, error) {
+	fileName, err := b.fileName(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(fileName)
+
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return f, err
+}
+
+// Put saves the data read from r as file with the specified key.
+func (b *Local) Put(key string, r io.Reader, size int64) error {
+	fileName, err := b.fileName(key)
+
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(fileName, r)
+}
+
+// Remove deletes the file with the specified key.
+func (b *Local) Remove(key string) error {
+	fileName, err := b.fileName(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(fileName); os.IsNotExist(err) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// List returns all files with keys starting with the specified prefix.
+func (b *Local) List(prefix string) (result Files, err error) {
+	prefix = Key(prefix)
+
+	err = filepath.WalkDir(b.root, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+
+		if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+"/") {
+			return nil
+		}
+
+		info, err := d.Info()
+
+		if err != nil {
+			return err
+		}
+
+		result = append(result, FileInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+
+		return nil
+	})
+
+	return result, err
+}
+
+// WriteFile atomically creates a file with the data read from r, so that
+// incomplete files are never visible under their final name.
+func WriteFile(fileName string, r io.Reader) error {
+	if err := CheckWrite(fileName); err != nil {
+		return err
+	} else if err = os.MkdirAll(filepath.Dir(fileName), ppfs.ModeDir); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fileName), "."+filepath.Base(fileName)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	tmpName := tmp.Name()
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	} else if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	} else if err = os.Chmod(tmpName, ppfs.ModeFile); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, fileName)
+}
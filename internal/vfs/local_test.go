@@ -0,0 +1,98 @@
+package vfs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocal(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "storage")
+		b, err := NewLocal(dir)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, dir, b.String())
+		assert.DirExists(t, dir)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := NewLocal("")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewLocal(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Put", func(t *testing.T) {
+		assert.NoError(t, b.Put("2023/photo.jpg", strings.NewReader("jpeg"), 4))
+		assert.NoError(t, b.Put("2023/05/photo.heic", strings.NewReader("heic!"), 5))
+		assert.NoError(t, b.Put("other.txt", strings.NewReader("txt"), 3))
+		assert.FileExists(t, filepath.Join(dir, "2023", "photo.jpg"))
+		assert.Error(t, b.Put("", strings.NewReader("txt"), 3))
+	})
+	t.Run("Stat", func(t *testing.T) {
+		info, err := b.Stat("2023/photo.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2023/photo.jpg", info.Key)
+		assert.Equal(t, int64(4), info.Size)
+		assert.False(t, info.ModTime.IsZero())
+
+		_, err = b.Stat("2023")
+		assert.Equal(t, ErrNotFound, err)
+
+		_, err = b.Stat("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Open", func(t *testing.T) {
+		r, err := b.Open("2023/05/photo.heic")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(r)
+		r.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "heic!", string(data))
+
+		_, err = b.Open("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("List", func(t *testing.T) {
+		all, err := b.List("")
+
+		assert.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		list, err := b.List("2023")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"2023/photo.jpg": 4, "2023/05/photo.heic": 5}, list.Keys())
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, b.Remove("other.txt"))
+		assert.Equal(t, ErrNotFound, b.Remove("other.txt"))
+
+		_, err := os.Stat(filepath.Join(dir, "other.txt"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
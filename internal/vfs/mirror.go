@@ -0,0 +1,367 @@
+package vfs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	ppfs "github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Storage roots.
+const (
+	RootOriginals = "originals"
+	RootSidecar   = "sidecar"
+	RootThumbs    = "thumbnails"
+)
+
+// Root represents a local directory that is mirrored to a storage backend. The local files in cache
+// directories may be evicted, as they are downloaded again with Fetch when they are needed.
+type Root struct {
+	Name  string
+	Path  string
+	Cache bool
+}
+
+// MirrorResult represents the number of files that were transferred or skipped.
+type MirrorResult struct {
+	Transferred int
+	Skipped     int
+	Failed      int
+}
+
+// Mirror keeps local directories in sync with a storage backend, so that the local
+// files only serve as a cache and can be restored when they are missing.
+type Mirror struct {
+	backend Backend
+	roots   []Root
+	mutex   sync.Mutex
+}
+
+// NewMirror returns a new mirror of the local directories in the storage backend.
+func NewMirror(backend Backend, roots ...Root) *Mirror {
+	m := &Mirror{backend: backend}
+
+	for _, r := range roots {
+		if r.Name == "" || r.Path == "" {
+			continue
+		}
+
+		m.roots = append(m.roots, Root{Name: Key(r.Name), Path: filepath.Clean(r.Path), Cache: r.Cache})
+	}
+
+	return m
+}
+
+// Backend returns the storage backend.
+func (m *Mirror) Backend() Backend {
+	if m == nil {
+		return nil
+	}
+
+	return m.backend
+}
+
+// Key returns the storage key of a local file, or an empty string if it is not in a mirrored directory.
+func (m *Mirror) Key(fileName string) string {
+	if m == nil || fileName == "" {
+		return ""
+	}
+
+	fileName = filepath.Clean(fileName)
+
+	for _, r := range m.roots {
+		if rel, err := filepath.Rel(r.Path, fileName); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		} else {
+			return Key(r.Name, filepath.ToSlash(rel))
+		}
+	}
+
+	return ""
+}
+
+// Fetch downloads the file from the storage backend if it does not exist locally.
+// It does nothing if the file is not in a mirrored directory or the mirror is nil.
+func (m *Mirror) Fetch(fileName string) error {
+	key := m.Key(fileName)
+
+	if key == "" || ppfs.FileExists(fileName) {
+		return nil
+	}
+
+	r, err := m.backend.Open(key)
+
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	if err = WriteFile(fileName, r); err != nil {
+		return err
+	}
+
+	log.Debugf("storage: downloaded %s", clean.Log(key))
+
+	return nil
+}
+
+// Store uploads the local file to the storage backend.
+// It does nothing if the file is not in a mirrored directory or the mirror is nil.
+func (m *Mirror) Store(fileName string) error {
+	key := m.Key(fileName)
+
+	if key == "" {
+		return nil
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return err
+	} else if info.IsDir() {
+		return fmt.Errorf("%s is a directory", clean.Log(key))
+	}
+
+	if err = m.backend.Put(key, f, info.Size()); err != nil {
+		return err
+	}
+
+	log.Debugf("storage: uploaded %s", clean.Log(key))
+
+	return nil
+}
+
+// Remove deletes the file from the storage backend, e.g. after it has been deleted locally.
+func (m *Mirror) Remove(fileName string) error {
+	key := m.Key(fileName)
+
+	if key == "" {
+		return nil
+	}
+
+	if err := m.backend.Remove(key); err != nil && err != ErrNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// RemovePrefix deletes all files from the storage backend whose local names start with the prefix,
+// e.g. the sidecar files of an original. It returns the number of deleted files.
+func (m *Mirror) RemovePrefix(prefix string) (numFiles int, err error) {
+	key := m.Key(prefix)
+
+	if key == "" {
+		return 0, nil
+	}
+
+	remote, err := m.backend.List(path.Dir(key))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, f := range remote {
+		if !strings.HasPrefix(f.Key, key) || strings.Contains(strings.TrimPrefix(f.Key, key), "/") {
+			continue
+		} else if err = m.backend.Remove(f.Key); err != nil && err != ErrNotFound {
+			return numFiles, err
+		}
+
+		numFiles++
+	}
+
+	return numFiles, nil
+}
+
+// Push uploads all local files that are missing in the storage backend, have a different size,
+// or have been modified after they were uploaded.
+func (m *Mirror) Push() (result MirrorResult, err error) {
+	if m == nil {
+		return result, nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, r := range m.roots {
+		remote, err := m.backend.List(r.Name)
+
+		if err != nil {
+			return result, err
+		}
+
+		stored := remote.Map()
+
+		err = walkFiles(r.Path, func(fileName string, info fs.FileInfo) {
+			if isStored(stored[m.Key(fileName)], info) {
+				result.Skipped++
+			} else if err := m.Store(fileName); err != nil {
+				log.Warnf("storage: %s while uploading %s", err, clean.Log(m.Key(fileName)))
+				result.Failed++
+			} else {
+				result.Transferred++
+			}
+		})
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Evict deletes the least recently modified local files in cache directories that are stored in the
+// backend, until their total size no longer exceeds the limit in bytes. It returns the number of
+// deleted files and does nothing if the limit is not positive.
+func (m *Mirror) Evict(limit int64) (numFiles int, err error) {
+	if m == nil || limit <= 0 {
+		return 0, nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	type cached struct {
+		fileName string
+		info     fs.FileInfo
+	}
+
+	var total int64
+	var evictable []cached
+
+	for _, r := range m.roots {
+		if !r.Cache {
+			continue
+		}
+
+		remote, err := m.backend.List(r.Name)
+
+		if err != nil {
+			return 0, err
+		}
+
+		stored := remote.Map()
+
+		err = walkFiles(r.Path, func(fileName string, info fs.FileInfo) {
+			total += info.Size()
+
+			if isStored(stored[m.Key(fileName)], info) {
+				evictable = append(evictable, cached{fileName: fileName, info: info})
+			}
+		})
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if total <= limit {
+		return 0, nil
+	}
+
+	sort.Slice(evictable, func(i, j int) bool {
+		return evictable[i].info.ModTime().Before(evictable[j].info.ModTime())
+	})
+
+	for _, f := range evictable {
+		if total <= limit {
+			break
+		} else if err = os.Remove(f.fileName); err != nil {
+			log.Warnf("storage: %s while evicting %s", err, clean.Log(m.Key(f.fileName)))
+			continue
+		}
+
+		total -= f.info.Size()
+		numFiles++
+	}
+
+	return numFiles, nil
+}
+
+// isStored checks if the local file has been uploaded to the storage backend and not changed since.
+// Modification times are compared in seconds, as object storages do not return fractions.
+func isStored(f FileInfo, info fs.FileInfo) bool {
+	return f.Key != "" && f.Size == info.Size() && !info.ModTime().Truncate(time.Second).After(f.ModTime)
+}
+
+// walkFiles calls the function for each regular file in the directory, except hidden files and folders.
+func walkFiles(dir string, fn func(fileName string, info fs.FileInfo)) error {
+	return filepath.WalkDir(dir, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		} else if hidden := strings.HasPrefix(d.Name(), "."); d.IsDir() && hidden && fileName != dir {
+			return fs.SkipDir
+		} else if d.IsDir() || hidden {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			fn(fileName, info)
+		}
+
+		return nil
+	})
+}
+
+// Pull downloads all files from the storage backend that are missing locally or have a different size.
+func (m *Mirror) Pull() (result MirrorResult, err error) {
+	if m == nil {
+		return result, nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, r := range m.roots {
+		remote, err := m.backend.List(r.Name)
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, f := range remote {
+			rel := strings.TrimPrefix(f.Key, r.Name+"/")
+			fileName := filepath.Join(r.Path, filepath.FromSlash(rel))
+
+			if info, err := os.Stat(fileName); err == nil && info.Size() == f.Size {
+				result.Skipped++
+				continue
+			} else if err == nil {
+				// Replace local file with a different size.
+				_ = os.Remove(fileName)
+			}
+
+			if err = m.Fetch(fileName); err != nil {
+				log.Warnf("storage: %s while downloading %s", err, clean.Log(f.Key))
+				result.Failed++
+			} else {
+				result.Transferred++
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,216 @@
+package vfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMirror(t *testing.T) (*Mirror, string) {
+	dir := t.TempDir()
+	b, err := NewLocal(filepath.Join(dir, "storage"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMirror(b,
+		Root{Name: RootOriginals, Path: filepath.Join(dir, "originals")},
+		Root{Name: RootSidecar, Path: filepath.Join(dir, "sidecar")},
+		Root{Name: RootThumbs, Path: ""},
+	)
+
+	return m, dir
+}
+
+func writeTestFile(t *testing.T, fileName, data string) {
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(fileName, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMirror_Key(t *testing.T) {
+	m, dir := newTestMirror(t)
+
+	assert.Equal(t, "originals/2023/photo.jpg", m.Key(filepath.Join(dir, "originals", "2023", "photo.jpg")))
+	assert.Equal(t, "sidecar/2023/photo.yml", m.Key(filepath.Join(dir, "sidecar", "2023", "photo.yml")))
+	assert.Equal(t, "", m.Key(filepath.Join(dir, "originals")))
+	assert.Equal(t, "", m.Key(filepath.Join(dir, "import", "photo.jpg")))
+	assert.Equal(t, "", m.Key(""))
+
+	var nilMirror *Mirror
+
+	assert.Equal(t, "", nilMirror.Key(filepath.Join(dir, "originals", "photo.jpg")))
+	assert.NoError(t, nilMirror.Fetch(filepath.Join(dir, "originals", "photo.jpg")))
+	assert.NoError(t, nilMirror.Store(filepath.Join(dir, "originals", "photo.jpg")))
+}
+
+func TestMirror_Push(t *testing.T) {
+	m, dir := newTestMirror(t)
+
+	writeTestFile(t, filepath.Join(dir, "originals", "2023", "photo.jpg"), "jpeg")
+	writeTestFile(t, filepath.Join(dir, "originals", ".photoprism", "index.db"), "ignored")
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo.yml"), "yml")
+
+	result, err := m.Push()
+
+	assert.NoError(t, err)
+	assert.Equal(t, MirrorResult{Transferred: 2}, result)
+	assert.FileExists(t, filepath.Join(dir, "storage", "originals", "2023", "photo.jpg"))
+	assert.FileExists(t, filepath.Join(dir, "storage", "sidecar", "2023", "photo.yml"))
+
+	// Only upload changed files.
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo.yml"), "yaml")
+
+	result, err = m.Push()
+
+	assert.NoError(t, err)
+	assert.Equal(t, MirrorResult{Transferred: 1, Skipped: 1}, result)
+
+	// Upload files that were changed without changing their size.
+	fileName := filepath.Join(dir, "originals", "2023", "photo.jpg")
+	modTime := time.Now().Add(time.Hour)
+	writeTestFile(t, fileName, "JPEG")
+	assert.NoError(t, os.Chtimes(fileName, modTime, modTime))
+
+	result, err = m.Push()
+
+	assert.NoError(t, err)
+	assert.Equal(t, MirrorResult{Transferred: 1, Skipped: 1}, result)
+
+	data, err := os.ReadFile(filepath.Join(dir, "storage", "originals", "2023", "photo.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "JPEG", string(data))
+}
+
+func TestMirror_Pull(t *testing.T) {
+	m, dir := newTestMirror(t)
+
+	fileName := filepath.Join(dir, "originals", "2023", "photo.jpg")
+	writeTestFile(t, fileName, "jpeg")
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo.yml"), "yml")
+
+	if _, err := m.Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "originals")))
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo.yml"), "changed")
+
+	result, err := m.Pull()
+
+	assert.NoError(t, err)
+	assert.Equal(t, MirrorResult{Transferred: 2}, result)
+
+	data, err := os.ReadFile(filepath.Join(dir, "sidecar", "2023", "photo.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "yml", string(data))
+	assert.FileExists(t, fileName)
+}
+
+func TestMirror_Fetch(t *testing.T) {
+	m, dir := newTestMirror(t)
+
+	fileName := filepath.Join(dir, "originals", "photo.jpg")
+	writeTestFile(t, fileName, "jpeg")
+
+	assert.NoError(t, m.Store(fileName))
+	assert.NoError(t, os.Remove(fileName))
+	assert.NoError(t, m.Fetch(fileName))
+	assert.FileExists(t, fileName)
+
+	assert.Equal(t, ErrNotFound, m.Fetch(filepath.Join(dir, "originals", "missing.jpg")))
+
+	assert.NoError(t, m.Remove(fileName))
+	assert.NoError(t, m.Remove(fileName))
+	assert.NoFileExists(t, filepath.Join(dir, "storage", "originals", "photo.jpg"))
+}
+
+func TestMirror_RemovePrefix(t *testing.T) {
+	m, dir := newTestMirror(t)
+
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo.jpg.json"), "json")
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo.xmp"), "xmp")
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "other.xmp"), "xmp")
+	writeTestFile(t, filepath.Join(dir, "sidecar", "2023", "photo", "nested.xmp"), "xmp")
+
+	if _, err := m.Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	numFiles, err := m.RemovePrefix(filepath.Join(dir, "sidecar", "2023", "photo."))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, numFiles)
+	assert.NoFileExists(t, filepath.Join(dir, "storage", "sidecar", "2023", "photo.jpg.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "storage", "sidecar", "2023", "photo.xmp"))
+	assert.FileExists(t, filepath.Join(dir, "storage", "sidecar", "2023", "other.xmp"))
+	assert.FileExists(t, filepath.Join(dir, "storage", "sidecar", "2023", "photo", "nested.xmp"))
+
+	numFiles, err = m.RemovePrefix(filepath.Join(dir, "import", "photo."))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numFiles)
+}
+
+func TestMirror_Evict(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewLocal(filepath.Join(dir, "storage"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMirror(b,
+		Root{Name: RootOriginals, Path: filepath.Join(dir, "originals")},
+		Root{Name: RootThumbs, Path: filepath.Join(dir, "thumbnails"), Cache: true},
+	)
+
+	oldThumb := filepath.Join(dir, "thumbnails", "a", "old.jpg")
+	newThumb := filepath.Join(dir, "thumbnails", "b", "new.jpg")
+	original := filepath.Join(dir, "originals", "photo.jpg")
+
+	writeTestFile(t, oldThumb, "0123456789")
+	writeTestFile(t, newThumb, "0123456789")
+	writeTestFile(t, original, "0123456789")
+
+	if _, err = m.Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	oldTime := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(oldThumb, oldTime, oldTime))
+
+	// Files that have not been uploaded yet must be kept.
+	writeTestFile(t, filepath.Join(dir, "thumbnails", "c", "pending.jpg"), "0123456789")
+
+	numFiles, err := m.Evict(25)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numFiles)
+	assert.NoFileExists(t, oldThumb)
+	assert.FileExists(t, newThumb)
+	assert.FileExists(t, original)
+
+	numFiles, err = m.Evict(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numFiles)
+	assert.NoFileExists(t, newThumb)
+	assert.FileExists(t, filepath.Join(dir, "thumbnails", "c", "pending.jpg"))
+	assert.FileExists(t, original)
+
+	// Evicted files are downloaded again when needed.
+	assert.NoError(t, m.Fetch(oldThumb))
+	assert.FileExists(t, oldThumb)
+
+	numFiles, err = m.Evict(0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numFiles)
+}
@@ -0,0 +1,387 @@
+package vfs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// S3Timeout is the maximum time to wait for a response header from the object storage.
+var S3Timeout = 60 * time.Second
+
+// S3DefaultRegion is used if no region is configured, as required for signing requests.
+const S3DefaultRegion = "us-east-1"
+
+// S3RestoreDays is the default number of days for which restored copies of archived objects are kept.
+const S3RestoreDays = 7
+
+// S3Options represents the connection settings of an S3-compatible object storage like AWS S3 or MinIO.
+type S3Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+	Insecure  bool
+
+	// StorageClass of new objects, e.g. "GLACIER" or "DEEP_ARCHIVE" for cold storage.
+	StorageClass string
+	RestoreDays  int
+}
+
+// S3 is a storage backend for S3-compatible object storage.
+type S3 struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	prefix    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	class     string
+	days      int
+	client    *http.Client
+}
+
+// NewS3 returns a new object storage backend. Buckets are addressed by path unless the
+// endpoint is empty, in which case virtual-hosted requests are sent to AWS.
+func NewS3(opt S3Options) (*S3, error) {
+	if opt.Bucket == "" {
+		return nil, fmt.Errorf("bucket name must not be empty")
+	} else if opt.AccessKey == "" || opt.SecretKey == "" {
+		return nil, fmt.Errorf("access key and secret key must not be empty")
+	}
+
+	if opt.Region == "" {
+		opt.Region = S3DefaultRegion
+	}
+
+	if opt.RestoreDays <= 0 {
+		opt.RestoreDays = S3RestoreDays
+	}
+
+	b := &S3{
+		region:    opt.Region,
+		bucket:    opt.Bucket,
+		prefix:    Key(opt.Prefix),
+		accessKey: opt.AccessKey,
+		secretKey: opt.SecretKey,
+		pathStyle: opt.Endpoint != "",
+		class:     strings.ToUpper(strings.TrimSpace(opt.StorageClass)),
+		days:      opt.RestoreDays,
+		client:    &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, ResponseHeaderTimeout: S3Timeout}},
+	}
+
+	scheme := "https"
+
+	if opt.Insecure {
+		scheme = "http"
+	}
+
+	endpoint := opt.Endpoint
+
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("%s.s3.%s.amazonaws.com", opt.Bucket, opt.Region)
+	}
+
+	if !strings.Contains(endpoint, "://") {
+		endpoint = scheme + "://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %s", clean.Log(opt.Endpoint))
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	b.endpoint = u
+
+	return b, nil
+}
+
+// String returns the bucket URL.
+func (b *S3) String() string {
+	if b.pathStyle {
+		return fmt.Sprintf("%s/%s/%s", b.endpoint.String(), b.bucket, b.prefix)
+	}
+
+	return fmt.Sprintf("%s/%s", b.endpoint.String(), b.prefix)
+}
+
+// objectKey returns the object key for a storage key, including the configured prefix.
+func (b *S3) objectKey(key string) (string, error) {
+	if key = Key(key); key == "" {
+		return "", fmt.Errorf("invalid key %s", clean.Log(key))
+	} else if b.prefix != "" {
+		return b.prefix + "/" + key, nil
+	}
+
+	return key, nil
+}
+
+// request sends a signed request for the specified object key and query parameters.
+func (b *S3) request(method, objectKey string, query url.Values, body io.Reader, size int64, header ...string) (*http.Response, error) {
+	u := *b.endpoint
+
+	if b.pathStyle {
+		u.Path += "/" + b.bucket
+	}
+
+	if objectKey != "" {
+		u.Path += "/" + objectKey
+	} else {
+		u.Path += "/"
+	}
+
+	u.RawPath = s3EscapePath(u.Path)
+	u.RawQuery = s3EscapeQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+
+	payloadHash := s3EmptyHash
+
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = s3UnsignedPayload
+	}
+
+	b.sign(req, payloadHash, time.Now())
+
+	resp, err := b.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	return nil, s3Error(resp)
+}
+
+// Stat returns information about the object with the specified key.
+func (b *S3) Stat(key string) (result FileInfo, err error) {
+	objectKey, err := b.objectKey(key)
+
+	if err != nil {
+		return result, err
+	}
+
+	resp, err := b.request(http.MethodHead, objectKey, nil, nil, 0)
+
+	if err != nil {
+		return result, err
+	}
+
+	resp.Body.Close()
+
+	result = FileInfo{Key: Key(key), Size: resp.ContentLength}
+
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		result.ModTime = t
+	}
+
+	return result, nil
+}
+
+// Open returns a reader for the object with the specified key.
+func (b *S3) Open(key string) (io.ReadCloser, error) {
+	objectKey, err := b.objectKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.request(http.MethodGet, objectKey, nil, nil, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// Put uploads the data read from r as object with the specified key. The size must be known in advance.
+func (b *S3) Put(key string, r io.Reader, size int64) error {
+	objectKey, err := b.objectKey(key)
+
+	if err != nil {
+		return err
+	} else if size < 0 {
+		return fmt.Errorf("unknown size of %s", clean.Log(key))
+	}
+
+	var header []string
+
+	if b.class != "" {
+		header = []string{"X-Amz-Storage-Class", b.class}
+	}
+
+	resp, err := b.request(http.MethodPut, objectKey, nil, io.NopCloser(r), size, header...)
+
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// Remove deletes the object with the specified key.
+func (b *S3) Remove(key string) error {
+	objectKey, err := b.objectKey(key)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := b.request(http.MethodDelete, objectKey, nil, nil, 0)
+
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// Restore requests a temporary copy of an archived object, e.g. in S3 Glacier, and returns true
+// once it can be read. Objects in other storage classes are ready immediately.
+func (b *S3) Restore(key string) (ready bool, err error) {
+	objectKey, err := b.objectKey(key)
+
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := b.request(http.MethodHead, objectKey, nil, nil, 0)
+
+	if err != nil {
+		return false, err
+	}
+
+	resp.Body.Close()
+
+	switch restore := resp.Header.Get("X-Amz-Restore"); {
+	case strings.Contains(restore, `ongoing-request="true"`):
+		return false, nil
+	case strings.Contains(restore, `ongoing-request="false"`):
+		return true, nil
+	}
+
+	switch resp.Header.Get("X-Amz-Storage-Class") {
+	case "GLACIER", "DEEP_ARCHIVE":
+	default:
+		return true, nil
+	}
+
+	body := fmt.Sprintf("<RestoreRequest><Days>%d</Days><GlacierJobParameters><Tier>Standard</Tier></GlacierJobParameters></RestoreRequest>", b.days)
+
+	resp, err = b.request(http.MethodPost, objectKey, url.Values{"restore": []string{""}}, strings.NewReader(body), int64(len(body)))
+
+	if err != nil && strings.Contains(err.Error(), "RestoreAlreadyInProgress") {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	resp.Body.Close()
+
+	log.Debugf("storage: requested restore of %s", clean.Log(key))
+
+	return false, nil
+}
+
+// s3ListResult represents the response of a ListObjectsV2 request.
+type s3ListResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// List returns all objects with keys starting with the specified prefix.
+func (b *S3) List(prefix string) (result Files, err error) {
+	listPrefix := b.prefix
+
+	if prefix = Key(prefix); prefix != "" {
+		listPrefix = Key(b.prefix, prefix) + "/"
+	} else if listPrefix != "" {
+		listPrefix += "/"
+	}
+
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", listPrefix)
+
+	for {
+		resp, err := b.request(http.MethodGet, "", query, nil, 0)
+
+		if err != nil {
+			return result, err
+		}
+
+		var list s3ListResult
+
+		err = xml.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, obj := range list.Contents {
+			if strings.HasSuffix(obj.Key, "/") {
+				continue
+			}
+
+			key := strings.TrimPrefix(obj.Key, b.prefix)
+			result = append(result, FileInfo{Key: Key(key), Size: obj.Size, ModTime: obj.LastModified})
+		}
+
+		if !list.IsTruncated || list.NextContinuationToken == "" {
+			return result, nil
+		}
+
+		query.Set("continuation-token", list.NextContinuationToken)
+	}
+}
+
+// s3Error returns an error based on the XML error response body.
+func s3Error(resp *http.Response) error {
+	var e struct {
+		Code    string
+		Message string
+	}
+
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&e); err != nil || e.Code == "" {
+		return fmt.Errorf("request failed with status %s", strconv.Itoa(resp.StatusCode))
+	} else if e.Message == "" {
+		return fmt.Errorf("request failed with code %s", e.Code)
+	}
+
+	return fmt.Errorf("%s (%s)", strings.ToLower(strings.TrimRight(e.Message, ".")), e.Code)
+}
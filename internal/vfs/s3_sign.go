@@ -0,0 +1,142 @@
+package vfs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3EmptyHash       = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	s3DateFormat      = "20060102"
+	s3TimeFormat      = "20060102T150405Z"
+)
+
+// sign adds an AWS Signature Version 4 authorization header to the request,
+// see https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+func (b *S3) sign(req *http.Request, payloadHash string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(s3TimeFormat)
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", t.Format(s3DateFormat), b.region, s3Service)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// Sign the host and all x-amz-* headers, as required by the specification.
+	names := []string{"host"}
+	values := map[string]string{"host": req.URL.Host}
+
+	for k, v := range req.Header {
+		if k = strings.ToLower(k); strings.HasPrefix(k, "x-amz-") {
+			names = append(names, k)
+			values[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + values[k] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, s3Hash(canonicalRequest)}, "\n")
+
+	signature := hex.EncodeToString(s3HMAC(s3SigningKey(b.secretKey, t.Format(s3DateFormat), b.region, s3Service), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, b.accessKey, scope, signedHeaders, signature))
+}
+
+// s3SigningKey derives the key for signing requests on the specified date, in the region, and for the service.
+func s3SigningKey(secretKey, date, region, service string) []byte {
+	k := s3HMAC([]byte("AWS4"+secretKey), date)
+	k = s3HMAC(k, region)
+	k = s3HMAC(k, service)
+
+	return s3HMAC(k, "aws4_request")
+}
+
+// s3HMAC returns the HMAC-SHA256 of the data.
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+// s3Hash returns the hex encoded SHA256 hash of the data.
+func s3Hash(data string) string {
+	h := sha256.Sum256([]byte(data))
+
+	return hex.EncodeToString(h[:])
+}
+
+// s3Escape percent-encodes all characters except the unreserved characters specified in RFC 3986.
+func s3Escape(s string, keepSlash bool) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || keepSlash && c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// s3EscapePath returns the URI-encoded request path.
+func s3EscapePath(p string) string {
+	return s3Escape(p, true)
+}
+
+// s3EscapeQuery returns the canonical query string with parameters sorted by name.
+func s3EscapeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(query))
+
+	for k := range query {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		for _, v := range query[k] {
+			params = append(params, s3Escape(k, false)+"="+s3Escape(v, false))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
@@ -0,0 +1,42 @@
+package vfs
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3SigningKey(t *testing.T) {
+	// Example from https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html.
+	key := s3SigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20150830", "us-east-1", "iam")
+
+	assert.Equal(t, "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9", hex.EncodeToString(key))
+}
+
+func TestS3_Sign(t *testing.T) {
+	b, err := NewS3(S3Options{Endpoint: "minio:9000", Bucket: "photos", AccessKey: "access", SecretKey: "secret"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://minio:9000/photos/photo.jpg", nil)
+	b.sign(req, s3EmptyHash, time.Date(2023, 5, 14, 12, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, "20230514T123000Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, s3EmptyHash, req.Header.Get("X-Amz-Content-Sha256"))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=access/20230514/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+}
+
+func TestS3Escape(t *testing.T) {
+	assert.Equal(t, "/photos/2023/photo%20one%2B%C3%BC.jpg", s3EscapePath("/photos/2023/photo one+ü.jpg"))
+	assert.Equal(t, "a%2Fb", s3Escape("a/b", false))
+	assert.Equal(t, "", s3EscapeQuery(nil))
+	assert.Equal(t, "list-type=2&prefix=originals%2F", s3EscapeQuery(url.Values{"prefix": {"originals/"}, "list-type": {"2"}}))
+}
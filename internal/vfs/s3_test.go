@@ -0,0 +1,307 @@
+package vfs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testS3 is a minimal S3-compatible server that verifies request signatures.
+type testS3 struct {
+	objects  map[string][]byte
+	classes  map[string]string
+	restores map[string]string
+	mutex    sync.Mutex
+	backend  *S3
+}
+
+func (s *testS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.verify(r) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<Error><Code>SignatureDoesNotMatch</Code><Message>The request signature we calculated does not match.</Message></Error>`))
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/bucket/":
+		s.list(w, r)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.objects[key] = data
+		s.classes[key] = r.Header.Get("X-Amz-Storage-Class")
+	case r.Method == http.MethodPost && r.URL.Query().Has("restore"):
+		if _, ok := s.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			s.restores[key] = `ongoing-request="true"`
+			w.WriteHeader(http.StatusAccepted)
+		}
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		if data, ok := s.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			if s.classes[key] != "" {
+				w.Header().Set("X-Amz-Storage-Class", s.classes[key])
+			}
+
+			if s.restores[key] != "" {
+				w.Header().Set("X-Amz-Restore", s.restores[key])
+			}
+
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+			w.Header().Set("Last-Modified", time.Date(2023, 5, 14, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+			_, _ = w.Write(data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// verify tests if the request was signed with the expected credentials.
+func (s *testS3) verify(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	t, err := time.Parse(s3TimeFormat, r.Header.Get("X-Amz-Date"))
+
+	if err != nil || !strings.HasPrefix(auth, s3Algorithm+" Credential=access/") {
+		return false
+	}
+
+	req, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+
+	for k, v := range r.Header {
+		if strings.HasPrefix(k, "X-Amz-") {
+			req.Header[k] = v
+		}
+	}
+
+	s.backend.sign(req, r.Header.Get("X-Amz-Content-Sha256"), t)
+
+	return req.Header.Get("Authorization") == auth
+}
+
+// list returns the objects with the requested prefix, two per page.
+func (s *testS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	keys := make([]string, 0, len(s.objects))
+
+	for k := range s.objects {
+		if strings.HasPrefix(k, prefix) && k > r.URL.Query().Get("continuation-token") {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	type object struct {
+		Key          string
+		Size         int
+		LastModified string
+	}
+
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Contents              []object
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+	}{}
+
+	for i, k := range keys {
+		if i == 2 {
+			result.IsTruncated = true
+			result.NextContinuationToken = keys[i-1]
+			break
+		}
+
+		result.Contents = append(result.Contents, object{Key: k, Size: len(s.objects[k]), LastModified: "2023-05-14T12:00:00.000Z"})
+	}
+
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func newTestS3(t *testing.T, prefix string) (*S3, *testS3) {
+	srv := &testS3{objects: make(map[string][]byte), classes: make(map[string]string), restores: make(map[string]string)}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	opt := S3Options{Endpoint: ts.URL, Bucket: "bucket", Prefix: prefix, AccessKey: "access", SecretKey: "secret"}
+	b, err := NewS3(opt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Use a separate instance for verifying signatures with the expected credentials.
+	if srv.backend, err = NewS3(opt); err != nil {
+		t.Fatal(err)
+	}
+
+	return b, srv
+}
+
+func TestNewS3(t *testing.T) {
+	t.Run("MinIO", func(t *testing.T) {
+		b, err := NewS3(S3Options{Endpoint: "minio:9000", Bucket: "photos", Prefix: "/library/", AccessKey: "access", SecretKey: "secret", Insecure: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "http://minio:9000/photos/library", b.String())
+		assert.Equal(t, S3DefaultRegion, b.region)
+		assert.True(t, b.pathStyle)
+	})
+	t.Run("AWS", func(t *testing.T) {
+		b, err := NewS3(S3Options{Region: "eu-central-1", Bucket: "photos", AccessKey: "access", SecretKey: "secret"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "https://photos.s3.eu-central-1.amazonaws.com/", b.String())
+		assert.False(t, b.pathStyle)
+	})
+	t.Run("NoBucket", func(t *testing.T) {
+		_, err := NewS3(S3Options{AccessKey: "access", SecretKey: "secret"})
+
+		assert.Error(t, err)
+	})
+	t.Run("NoCredentials", func(t *testing.T) {
+		_, err := NewS3(S3Options{Bucket: "photos"})
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		_, err := NewS3(S3Options{Endpoint: "ftp://minio", Bucket: "photos", AccessKey: "access", SecretKey: "secret"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestS3(t *testing.T) {
+	b, srv := newTestS3(t, "library")
+
+	t.Run("Put", func(t *testing.T) {
+		assert.NoError(t, b.Put("originals/2023/photo one.jpg", strings.NewReader("jpeg"), 4))
+		assert.NoError(t, b.Put("originals/2023/photo+two.jpg", strings.NewReader("jpeg!"), 5))
+		assert.NoError(t, b.Put("originals/ümlaut.jpg", strings.NewReader("jpg"), 3))
+		assert.NoError(t, b.Put("sidecar/2023/photo one.yml", strings.NewReader("yml"), 3))
+		assert.Contains(t, srv.objects, "library/originals/2023/photo one.jpg")
+		assert.Error(t, b.Put("sidecar/unknown.yml", strings.NewReader("yml"), -1))
+	})
+	t.Run("Stat", func(t *testing.T) {
+		info, err := b.Stat("originals/2023/photo+two.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "originals/2023/photo+two.jpg", info.Key)
+		assert.Equal(t, int64(5), info.Size)
+		assert.Equal(t, 2023, info.ModTime.Year())
+
+		_, err = b.Stat("originals/missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Open", func(t *testing.T) {
+		r, err := b.Open("originals/ümlaut.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(r)
+		r.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jpg", string(data))
+	})
+	t.Run("List", func(t *testing.T) {
+		list, err := b.List("originals")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{
+			"originals/2023/photo one.jpg": 4,
+			"originals/2023/photo+two.jpg": 5,
+			"originals/ümlaut.jpg":         3,
+		}, list.Keys())
+
+		all, err := b.List("")
+
+		assert.NoError(t, err)
+		assert.Len(t, all, 4)
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, b.Remove("sidecar/2023/photo one.yml"))
+		assert.NotContains(t, srv.objects, "library/sidecar/2023/photo one.yml")
+	})
+	t.Run("InvalidSignature", func(t *testing.T) {
+		b.secretKey = "invalid"
+		defer func() { b.secretKey = "secret" }()
+
+		err := b.Put("originals/photo.jpg", strings.NewReader("jpeg"), 4)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "the request signature we calculated does not match (SignatureDoesNotMatch)", err.Error())
+		}
+	})
+}
+
+func TestS3_Restore(t *testing.T) {
+	b, srv := newTestS3(t, "")
+
+	t.Run("Standard", func(t *testing.T) {
+		assert.NoError(t, b.Put("standard.jpg", strings.NewReader("data"), 4))
+
+		ready, err := b.Restore("standard.jpg")
+
+		assert.NoError(t, err)
+		assert.True(t, ready)
+	})
+	t.Run("Glacier", func(t *testing.T) {
+		b.class = "GLACIER"
+		defer func() { b.class = "" }()
+
+		assert.NoError(t, b.Put("archived.jpg", strings.NewReader("data"), 4))
+		assert.Equal(t, "GLACIER", srv.classes["archived.jpg"])
+
+		ready, err := b.Restore("archived.jpg")
+
+		assert.NoError(t, err)
+		assert.False(t, ready)
+		assert.Equal(t, `ongoing-request="true"`, srv.restores["archived.jpg"])
+
+		ready, err = b.Restore("archived.jpg")
+
+		assert.NoError(t, err)
+		assert.False(t, ready)
+
+		srv.mutex.Lock()
+		srv.restores["archived.jpg"] = `ongoing-request="false", expiry-date="Fri, 21 Dec 2029 00:00:00 GMT"`
+		srv.mutex.Unlock()
+
+		ready, err = b.Restore("archived.jpg")
+
+		assert.NoError(t, err)
+		assert.True(t, ready)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := b.Restore("missing.jpg")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Package vfs provides storage backends like S3-compatible object storage, so that originals, sidecar files,
+and thumbnails can be kept outside the container and are cached locally when needed.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package vfs
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// ErrNotFound is returned if a file does not exist in the storage backend.
+var ErrNotFound = errors.New("file not found")
+
+// Backend represents a storage backend in which files are identified by slash-separated keys.
+type Backend interface {
+	String() string
+	Stat(key string) (FileInfo, error)
+	Open(key string) (io.ReadCloser, error)
+	Put(key string, r io.Reader, size int64) error
+	Remove(key string) error
+	List(prefix string) (Files, error)
+}
+
+// Restorer is implemented by storage backends with an archive tier like S3 Glacier, in which
+// files must be restored before they can be read.
+type Restorer interface {
+	Restore(key string) (ready bool, err error)
+}
+
+// FileInfo represents a file in a storage backend.
+type FileInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Files represents a list of files in a storage backend.
+type Files []FileInfo
+
+// Keys returns the files as a map of keys to file sizes.
+func (list Files) Keys() map[string]int64 {
+	result := make(map[string]int64, len(list))
+
+	for _, f := range list {
+		result[f.Key] = f.Size
+	}
+
+	return result
+}
+
+// Map returns the files as a map of keys to file infos.
+func (list Files) Map() map[string]FileInfo {
+	result := make(map[string]FileInfo, len(list))
+
+	for _, f := range list {
+		result[f.Key] = f
+	}
+
+	return result
+}
+
+// Key returns a normalized storage key for the specified path elements.
+func Key(elem ...string) string {
+	return strings.Trim(path.Clean("/"+path.Join(elem...)), "/")
+}
@@ -0,0 +1,27 @@
+package vfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "originals/2023/photo.jpg", Key("originals", "2023/photo.jpg"))
+	assert.Equal(t, "originals/photo.jpg", Key("/originals/", "/photo.jpg"))
+	assert.Equal(t, "photo.jpg", Key("../../photo.jpg"))
+	assert.Equal(t, "", Key(""))
+	assert.Equal(t, "", Key("/"))
+}
+
+func TestFiles_Keys(t *testing.T) {
+	list := Files{{Key: "a.jpg", Size: 10}, {Key: "b/c.jpg", Size: 20}}
+
+	assert.Equal(t, map[string]int64{"a.jpg": 10, "b/c.jpg": 20}, list.Keys())
+}
+
+func TestFiles_Map(t *testing.T) {
+	list := Files{{Key: "a.jpg", Size: 10}, {Key: "b/c.jpg", Size: 20}}
+
+	assert.Equal(t, map[string]FileInfo{"a.jpg": list[0], "b/c.jpg": list[1]}, list.Map())
+}
@@ -0,0 +1,189 @@
+package vfs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-webdav"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// WebDAVTimeout is the maximum time to wait for a response header from the WebDAV server.
+var WebDAVTimeout = 60 * time.Second
+
+// WebDAV is a storage backend for WebDAV servers like Nextcloud.
+type WebDAV struct {
+	endpoint *url.URL
+	client   *webdav.Client
+	mkdir    map[string]bool
+}
+
+// NewWebDAV returns a new storage backend for the WebDAV folder at the specified URL,
+// which may include the username and password.
+func NewWebDAV(serverUrl string) (*WebDAV, error) {
+	u, err := url.Parse(serverUrl)
+
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid webdav url %s", clean.Log(serverUrl))
+	}
+
+	httpClient := webdav.HTTPClient(&http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, ResponseHeaderTimeout: WebDAVTimeout}})
+
+	if u.User != nil {
+		pass, _ := u.User.Password()
+		httpClient = webdav.HTTPClientWithBasicAuth(httpClient, u.User.Username(), pass)
+		u.User = nil
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/") + "/"
+
+	client, err := webdav.NewClient(httpClient, u.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebDAV{endpoint: u, client: client, mkdir: make(map[string]bool)}, nil
+}
+
+// String returns the server URL without credentials.
+func (b *WebDAV) String() string {
+	return b.endpoint.String()
+}
+
+// Stat returns information about the file with the specified key.
+func (b *WebDAV) Stat(key string) (result FileInfo, err error) {
+	if key = Key(key); key == "" {
+		return result, fmt.Errorf("invalid key %s", clean.Log(key))
+	}
+
+	info, err := b.client.Stat(key)
+
+	if err != nil {
+		return result, webdavError(err)
+	} else if info.IsDir {
+		return result, ErrNotFound
+	}
+
+	return FileInfo{Key: key, Size: info.Size, ModTime: info.ModTime}, nil
+}
+
+// Open returns a reader for the file with the specified key.
+func (b *WebDAV) Open(key string) (io.ReadCloser, error) {
+	if key = Key(key); key == "" {
+		return nil, fmt.Errorf("invalid key %s", clean.Log(key))
+	}
+
+	r, err := b.client.Open(key)
+
+	if err != nil {
+		return nil, webdavError(err)
+	}
+
+	return r, nil
+}
+
+// Put uploads the data read from r as file with the specified key, creating parent folders as needed.
+func (b *WebDAV) Put(key string, r io.Reader, size int64) error {
+	if key = Key(key); key == "" {
+		return fmt.Errorf("invalid key %s", clean.Log(key))
+	} else if err := b.mkdirAll(path.Dir(key)); err != nil {
+		return err
+	}
+
+	w, err := b.client.Create(key)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(w, r); err != nil {
+		_ = w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+// mkdirAll creates the folder and its parents if they do not exist yet.
+func (b *WebDAV) mkdirAll(dir string) error {
+	if dir == "." || dir == "" || b.mkdir[dir] {
+		return nil
+	} else if err := b.mkdirAll(path.Dir(dir)); err != nil {
+		return err
+	}
+
+	if info, err := b.client.Stat(dir); err == nil && info.IsDir {
+		b.mkdir[dir] = true
+		return nil
+	} else if err = b.client.Mkdir(dir); err != nil {
+		return fmt.Errorf("failed to create folder %s (%s)", clean.Log(dir), err)
+	}
+
+	b.mkdir[dir] = true
+
+	return nil
+}
+
+// Remove deletes the file with the specified key.
+func (b *WebDAV) Remove(key string) error {
+	if key = Key(key); key == "" {
+		return fmt.Errorf("invalid key %s", clean.Log(key))
+	}
+
+	return webdavError(b.client.RemoveAll(key))
+}
+
+// List returns all files with keys starting with the specified prefix.
+func (b *WebDAV) List(prefix string) (result Files, err error) {
+	prefix = Key(prefix)
+	dir := prefix
+
+	if dir == "" {
+		dir = "."
+	}
+
+	files, err := b.client.Readdir(dir, true)
+
+	if err != nil {
+		if err = webdavError(err); err == ErrNotFound {
+			return result, nil
+		}
+
+		return result, err
+	}
+
+	for _, f := range files {
+		if f.IsDir {
+			continue
+		}
+
+		key := Key(strings.TrimPrefix(f.Path, b.endpoint.Path))
+
+		if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+"/") {
+			continue
+		}
+
+		result = append(result, FileInfo{Key: key, Size: f.Size, ModTime: f.ModTime})
+	}
+
+	return result, nil
+}
+
+// webdavError returns ErrNotFound if the server responded with status 404.
+func webdavError(err error) error {
+	if err == nil {
+		return nil
+	} else if os.IsNotExist(err) || strings.Contains(err.Error(), "404") {
+		return ErrNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,150 @@
+package vfs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ErrWriteOnce is returned if an existing file in a write-once protected directory should be changed or removed.
+var ErrWriteOnce = errors.New("file is write-once protected")
+
+// writeOnce contains the write-once protected directories and the paths excluded from protection.
+var writeOnce = struct {
+	sync.RWMutex
+	dirs   []string
+	except []string
+}{}
+
+// WriteOnce protects existing files in the specified directories from modification and deletion
+// (write once, read many), except for files in the excluded paths. New files can still be added.
+func WriteOnce(dirs, except []string) {
+	writeOnce.Lock()
+	defer writeOnce.Unlock()
+
+	writeOnce.dirs = absPaths(dirs)
+	writeOnce.except = writeOnce.except[:0]
+
+	// Ignore exceptions that would disable protection for a whole directory,
+	// e.g. if the sidecar path is the same as the originals path.
+	for _, p := range absPaths(except) {
+		covers := false
+
+		for _, dir := range writeOnce.dirs {
+			if inPath(dir, p) {
+				covers = true
+				break
+			}
+		}
+
+		if !covers {
+			writeOnce.except = append(writeOnce.except, p)
+		}
+	}
+}
+
+// Protected checks if the file or directory name is in a write-once protected directory.
+func Protected(fileName string) bool {
+	writeOnce.RLock()
+	defer writeOnce.RUnlock()
+
+	if len(writeOnce.dirs) == 0 || fileName == "" {
+		return false
+	}
+
+	fileName, err := filepath.Abs(fileName)
+
+	if err != nil {
+		return true
+	}
+
+	for _, dir := range writeOnce.except {
+		if inPath(fileName, dir) {
+			return false
+		}
+	}
+
+	for _, dir := range writeOnce.dirs {
+		if inPath(fileName, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckWrite returns ErrWriteOnce if the file exists and must not be changed or removed.
+func CheckWrite(fileName string) error {
+	if !Protected(fileName) {
+		return nil
+	} else if _, err := os.Lstat(fileName); err != nil {
+		return nil
+	}
+
+	log.Warnf("vfs: %s is write-once protected", clean.Log(filepath.Base(fileName)))
+
+	return ErrWriteOnce
+}
+
+// Remove removes the file or empty directory, unless it is write-once protected.
+func Remove(fileName string) error {
+	if err := CheckWrite(fileName); err != nil {
+		return err
+	}
+
+	return os.Remove(fileName)
+}
+
+// RemoveAll removes the path and any children it contains, unless it is write-once protected.
+func RemoveAll(fileName string) error {
+	if err := CheckWrite(fileName); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(fileName)
+}
+
+// Rename renames the file, unless the source or an existing destination file is write-once protected.
+func Rename(src, dest string) error {
+	if err := CheckWrite(src); err != nil {
+		return err
+	} else if err = CheckWrite(dest); err != nil {
+		return err
+	}
+
+	return os.Rename(src, dest)
+}
+
+// OpenFile opens the named file like os.OpenFile, but fails with ErrWriteOnce
+// if an existing write-once protected file should be opened for writing.
+func OpenFile(fileName string, flag int, perm os.FileMode) (*os.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_TRUNC) != 0 {
+		if err := CheckWrite(fileName); err != nil {
+			return nil, err
+		}
+	}
+
+	return os.OpenFile(fileName, flag, perm)
+}
+
+// absPaths returns the absolute and cleaned paths, without empty values.
+func absPaths(paths []string) (result []string) {
+	for _, p := range paths {
+		if p == "" {
+			continue
+		} else if abs, err := filepath.Abs(p); err == nil {
+			result = append(result, abs)
+		}
+	}
+
+	return result
+}
+
+// inPath checks if the file name is the directory or inside it.
+func inPath(fileName, dir string) bool {
+	return fileName == dir || strings.HasPrefix(fileName, dir+string(os.PathSeparator))
+}
@@ -0,0 +1,77 @@
+package vfs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteOnce(t *testing.T) {
+	dir := t.TempDir()
+	originals := filepath.Join(dir, "originals")
+	sidecar := filepath.Join(originals, ".photoprism")
+	existing := filepath.Join(originals, "2020", "IMG_0001.jpg")
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(existing), 0o755))
+	assert.NoError(t, os.MkdirAll(sidecar, 0o755))
+	assert.NoError(t, os.WriteFile(existing, []byte("jpeg"), 0o644))
+
+	WriteOnce([]string{originals}, []string{sidecar})
+	defer WriteOnce(nil, nil)
+
+	t.Run("Protected", func(t *testing.T) {
+		assert.True(t, Protected(existing))
+		assert.True(t, Protected(originals))
+		assert.False(t, Protected(filepath.Join(sidecar, "IMG_0001.yml")))
+		assert.False(t, Protected(filepath.Join(dir, "originals.jpg")))
+		assert.False(t, Protected(""))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.ErrorIs(t, Remove(existing), ErrWriteOnce)
+		assert.ErrorIs(t, RemoveAll(filepath.Dir(existing)), ErrWriteOnce)
+		assert.FileExists(t, existing)
+	})
+	t.Run("Rename", func(t *testing.T) {
+		assert.ErrorIs(t, Rename(existing, filepath.Join(originals, "moved.jpg")), ErrWriteOnce)
+
+		src := filepath.Join(dir, "import.jpg")
+		assert.NoError(t, os.WriteFile(src, []byte("new"), 0o644))
+		assert.ErrorIs(t, Rename(src, existing), ErrWriteOnce)
+		assert.NoError(t, Rename(src, filepath.Join(originals, "2020", "IMG_0002.jpg")))
+	})
+	t.Run("Write", func(t *testing.T) {
+		assert.ErrorIs(t, WriteFile(existing, strings.NewReader("changed")), ErrWriteOnce)
+
+		_, err := OpenFile(existing, os.O_RDWR, 0o644)
+		assert.ErrorIs(t, err, ErrWriteOnce)
+
+		f, err := OpenFile(existing, os.O_RDONLY, 0)
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		data, _ := os.ReadFile(existing)
+		assert.Equal(t, "jpeg", string(data))
+
+		newFile := filepath.Join(originals, "2020", "IMG_0003.jpg")
+		assert.NoError(t, WriteFile(newFile, strings.NewReader("new")))
+		assert.ErrorIs(t, WriteFile(newFile, strings.NewReader("changed")), ErrWriteOnce)
+
+		yamlFile := filepath.Join(sidecar, "IMG_0001.yml")
+		assert.NoError(t, WriteFile(yamlFile, strings.NewReader("a")))
+		assert.NoError(t, WriteFile(yamlFile, strings.NewReader("b")))
+		assert.NoError(t, Remove(yamlFile))
+	})
+	t.Run("Except", func(t *testing.T) {
+		WriteOnce([]string{originals}, []string{originals, sidecar})
+		assert.True(t, Protected(existing))
+		assert.False(t, Protected(filepath.Join(sidecar, "IMG_0001.yml")))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		WriteOnce(nil, nil)
+		assert.False(t, Protected(existing))
+		assert.NoError(t, CheckWrite(existing))
+	})
+}
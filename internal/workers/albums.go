@@ -0,0 +1,77 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Albums represents a worker that creates recurring albums from templates.
+type Albums struct {
+	conf *config.Config
+}
+
+// NewAlbums returns a new albums worker.
+func NewAlbums(conf *config.Config) *Albums {
+	return &Albums{conf: conf}
+}
+
+// Start creates the albums for the specified date from the configured templates.
+func (w *Albums) Start(date time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("albums: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err = mutex.AlbumsWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.AlbumsWorker.Stop()
+
+	for _, name := range w.conf.AlbumTemplates() {
+		if mutex.AlbumsWorker.Canceled() {
+			return nil
+		}
+
+		t, err := entity.FindAlbumTemplate(name)
+
+		if err != nil {
+			log.Warnf("albums: %s", err)
+			continue
+		}
+
+		a, changed, err := t.CreateAlbum(date, entity.OwnerUnknown)
+
+		if err != nil {
+			log.Warnf("albums: %s (create from template %s)", err, clean.Log(name))
+			continue
+		} else if !changed {
+			continue
+		}
+
+		log.Infof("albums: updated %s from template %s", clean.Log(a.AlbumTitle), clean.Log(name))
+
+		// Add the pictures matching the album filter.
+		if _, _, err = query.UpdateSmartAlbum(*a, nil); err != nil {
+			log.Warnf("albums: %s (update %s)", err, clean.Log(a.AlbumTitle))
+		}
+
+		// Update album YAML backup.
+		if w.conf.BackupYaml() {
+			if err = a.SaveAsYaml(a.YamlFileName(w.conf.AlbumsPath())); err != nil {
+				log.Warnf("albums: %s (update yaml)", err)
+			}
+		}
+	}
+
+	return nil
+}
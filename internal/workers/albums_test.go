@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestNewAlbums(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewAlbums(conf)
+
+	assert.IsType(t, &Albums{}, worker)
+}
+
+func TestAlbums_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewAlbums(conf)
+
+	if err := mutex.AlbumsWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := worker.Start(time.Now()); err == nil {
+		t.Fatal("error expected")
+	}
+
+	mutex.AlbumsWorker.Stop()
+
+	if err := worker.Start(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mail"
+)
+
+// sendBackupAlert notifies the configured alert email addresses that a backup has failed.
+func sendBackupAlert(conf *config.Config, errs []string) {
+	if len(errs) == 0 || !conf.MailEnabled() {
+		return
+	}
+
+	to := conf.AlertEmail()
+
+	if len(to) == 0 {
+		return
+	}
+
+	data := conf.MailData()
+	data.Error = strings.Join(errs, "\n")
+
+	if msg, err := mail.Render(mail.TemplateBackupFailed, data, to...); err != nil {
+		log.Errorf("backup: %s (alert)", err)
+	} else if err = conf.Mailer().Send(msg); err != nil {
+		log.Errorf("backup: %s (alert)", err)
+	}
+}
@@ -0,0 +1,18 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestSendBackupAlert(t *testing.T) {
+	conf := config.TestConfig()
+
+	// Sending emails is disabled by default.
+	assert.False(t, conf.MailEnabled())
+	sendBackupAlert(conf, []string{"disk full"})
+	sendBackupAlert(conf, nil)
+}
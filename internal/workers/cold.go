@@ -0,0 +1,56 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// Cold represents a worker that restores archived originals that were requested by users.
+type Cold struct {
+	conf *config.Config
+	cold *photoprism.Cold
+}
+
+// NewCold returns a new cold storage worker.
+func NewCold(conf *config.Config, cold *photoprism.Cold) *Cold {
+	return &Cold{conf: conf, cold: cold}
+}
+
+// Start processes the restore queue.
+func (w *Cold) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cold: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w.cold == nil {
+		return nil
+	} else if err = mutex.ColdWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.ColdWorker.Stop()
+
+	start := time.Now()
+
+	result, err := w.cold.RestorePending()
+
+	if err != nil {
+		return err
+	}
+
+	if result.Files > 0 || result.Failed > 0 {
+		log.Infof("cold: restored %s, %d failed [%s]", english.Plural(result.Files, "file", "files"), result.Failed, time.Since(start))
+	}
+
+	return nil
+}
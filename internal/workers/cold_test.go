@@ -0,0 +1,18 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestCold_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewCold(conf, nil)
+
+	assert.IsType(t, &Cold{}, worker)
+	assert.NoError(t, worker.Start())
+}
@@ -0,0 +1,114 @@
+package workers
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// JobsAll can be used instead of a job name to apply an action to all background workers.
+const JobsAll = "all"
+
+// Job states.
+const (
+	JobRunning  = "running"
+	JobCanceled = "canceled"
+	JobPaused   = "paused"
+	JobFailed   = "failed"
+	JobQueued   = "queued"
+	JobIdle     = "idle"
+)
+
+// ErrJobNotFound is returned if there is no background worker with the specified name.
+var ErrJobNotFound = errors.New("job not found")
+
+// JobInfo represents the state of a background worker and its next scheduled run.
+type JobInfo struct {
+	Name   string    `json:"Name"`
+	Status string    `json:"Status"`
+	Next   time.Time `json:"Next,omitempty"`
+	mutex.ActivityInfo
+}
+
+// Jobs returns the state of all background workers, sorted by name.
+func Jobs() []JobInfo {
+	s := GetScheduler()
+	result := make([]JobInfo, 0, len(mutex.Workers))
+
+	for name, activity := range mutex.Workers {
+		job := JobInfo{Name: name, ActivityInfo: activity.Info()}
+
+		if s != nil {
+			job.Next = s.Next(activity)
+		}
+
+		switch {
+		case job.Canceled:
+			job.Status = JobCanceled
+		case job.Paused:
+			job.Status = JobPaused
+		case job.Running:
+			job.Status = JobRunning
+		case job.Error != "":
+			job.Status = JobFailed
+		case !job.Next.IsZero():
+			job.Status = JobQueued
+		default:
+			job.Status = JobIdle
+		}
+
+		result = append(result, job)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// PauseJob pauses the specified background worker, or all workers, until it is resumed.
+func PauseJob(name string) error {
+	return updateJobs(name, func(activity *mutex.Activity) {
+		activity.Pause()
+	})
+}
+
+// ResumeJob resumes the specified background worker, or all workers.
+func ResumeJob(name string) error {
+	return updateJobs(name, func(activity *mutex.Activity) {
+		activity.Resume()
+	})
+}
+
+// CancelJob cancels the current run of the specified background worker, or of all workers.
+func CancelJob(name string) error {
+	return updateJobs(name, func(activity *mutex.Activity) {
+		activity.Cancel()
+	})
+}
+
+// updateJobs applies the change to the specified background worker, or to all workers.
+func updateJobs(name string, update func(activity *mutex.Activity)) error {
+	if name == JobsAll {
+		for _, activity := range mutex.Workers {
+			update(activity)
+		}
+
+		return nil
+	}
+
+	activity, ok := mutex.Workers[name]
+
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	update(activity)
+
+	log.Debugf("jobs: updated %s worker", name)
+
+	return nil
+}
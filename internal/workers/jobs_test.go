@@ -0,0 +1,36 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestJobs(t *testing.T) {
+	jobs := Jobs()
+
+	assert.Len(t, jobs, len(mutex.Workers))
+	assert.Equal(t, "albums", jobs[0].Name)
+}
+
+func TestPauseJob(t *testing.T) {
+	assert.NoError(t, PauseJob("backup"))
+	assert.True(t, mutex.BackupWorker.Paused())
+
+	for _, job := range Jobs() {
+		if job.Name == "backup" {
+			assert.Equal(t, JobPaused, job.Status)
+		}
+	}
+
+	assert.NoError(t, ResumeJob("backup"))
+	assert.False(t, mutex.BackupWorker.Paused())
+	assert.Equal(t, ErrJobNotFound, PauseJob("foo"))
+}
+
+func TestCancelJob(t *testing.T) {
+	assert.NoError(t, CancelJob(JobsAll))
+	assert.Equal(t, ErrJobNotFound, CancelJob("foo"))
+}
@@ -50,12 +50,14 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 	// Check time when worker was last executed.
 	updateIndex := force || w.lastRun.Before(time.Now().Add(-1*entity.IndexUpdateInterval))
 
-	// Run faces worker if needed.
-	if updateIndex || entity.UpdateFaces.Load() {
+	// Run faces worker if needed, unless it has a separate schedule.
+	if w.conf.FacesSchedule() != "" {
+		log.Debugf("index: face recognition is scheduled separately")
+	} else if updateIndex || entity.UpdateFaces.Load() {
 		log.Debugf("index: running face recognition")
 		if faces := photoprism.NewFaces(w.conf); faces.Disabled() {
 			log.Debugf("index: skipping face recognition")
-		} else if err := faces.Start(photoprism.FacesOptions{}); err != nil {
+		} else if err := faces.Start(photoprism.FacesOptions{Throttle: true}); err != nil {
 			log.Warn(err)
 		}
 	}
@@ -107,7 +109,7 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 			}
 		}
 
-		if mutex.MetaWorker.Canceled() {
+		if !mutex.MetaWorker.Wait() {
 			return errors.New("index: metadata update canceled")
 		}
 
@@ -0,0 +1,232 @@
+package workers
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/cron"
+)
+
+// SchedulerTick specifies how often the scheduler checks for tasks that are due.
+var SchedulerTick = 10 * time.Second
+
+// ErrTaskNotFound is returned if there is no scheduled task with the specified name.
+var ErrTaskNotFound = errors.New("task not found")
+
+// Task represents a background task that runs on a schedule.
+type Task struct {
+	Name     string
+	Schedule *cron.Schedule
+	Run      func(conf *config.Config)
+	Activity *mutex.Activity
+	next     time.Time
+	last     time.Time
+	skip     bool
+}
+
+// TaskInfo represents the status of a scheduled task.
+type TaskInfo struct {
+	Name     string    `json:"Name"`
+	Schedule string    `json:"Schedule"`
+	Next     time.Time `json:"Next"`
+	Last     time.Time `json:"Last,omitempty"`
+	Skip     bool      `json:"Skip"`
+	Running  bool      `json:"Running"`
+}
+
+// Scheduler runs background tasks according to their cron expressions.
+type Scheduler struct {
+	mutex sync.Mutex
+	conf  *config.Config
+	tasks []*Task
+}
+
+// NewScheduler returns a new scheduler with the tasks enabled in the config.
+func NewScheduler(conf *config.Config) *Scheduler {
+	s := &Scheduler{conf: conf}
+
+	wakeup := conf.WakeupSchedule()
+
+	s.Add("meta", wakeup, RunMeta, &mutex.MetaWorker)
+	s.Add("share", wakeup, RunShare, &mutex.ShareWorker)
+	s.Add("sync", conf.SyncSchedule(), RunSync, &mutex.SyncWorker)
+	s.Add("albums", wakeup, RunAlbums, &mutex.AlbumsWorker)
+	s.Add("storage", wakeup, RunStorage, &mutex.StorageWorker)
+	s.Add("cold", wakeup, RunCold, &mutex.ColdWorker)
+	s.Add("index", conf.IndexSchedule(), RunIndex, &mutex.MainWorker)
+	s.Add("backup", conf.BackupSchedule(), RunBackup, &mutex.BackupWorker)
+	s.Add("cleanup", conf.CleanupSchedule(), RunCleanUp, &mutex.MainWorker)
+	s.Add("faces", conf.FacesSchedule(), RunFaces, &mutex.FacesWorker)
+	s.Add("trash", conf.TrashSchedule(), RunTrash, &mutex.TrashWorker)
+
+	return s
+}
+
+// Add adds a task with the specified cron expression, unless it is empty or invalid.
+// Scheduled runs are skipped while the activity of the task is paused.
+func (s *Scheduler) Add(name, spec string, run func(conf *config.Config), activity *mutex.Activity) {
+	if spec == "" {
+		return
+	}
+
+	schedule, err := cron.Parse(spec)
+
+	if err != nil {
+		log.Warnf("scheduler: %s, %s task disabled", err, clean.Log(name))
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tasks = append(s.tasks, &Task{
+		Name:     name,
+		Schedule: schedule,
+		Run:      run,
+		Activity: activity,
+		next:     schedule.Next(time.Now()),
+	})
+
+	log.Debugf("scheduler: %s task runs %s", name, schedule.String())
+}
+
+// Empty checks if no tasks are scheduled.
+func (s *Scheduler) Empty() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.tasks) == 0
+}
+
+// Tick runs all tasks that are due at the specified time.
+func (s *Scheduler) Tick(now time.Time) {
+	var due []*Task
+
+	s.mutex.Lock()
+
+	for _, t := range s.tasks {
+		if t.next.IsZero() || now.Before(t.next) {
+			continue
+		}
+
+		t.next = t.Schedule.Next(now)
+
+		if t.skip {
+			t.skip = false
+			log.Infof("scheduler: skipped %s task", t.Name)
+			continue
+		} else if t.Activity != nil && t.Activity.Paused() {
+			log.Debugf("scheduler: %s task is paused", t.Name)
+			continue
+		}
+
+		t.last = now
+		due = append(due, t)
+	}
+
+	s.mutex.Unlock()
+
+	// Run tasks in the order in which they were added, like the previous fixed interval worker.
+	for _, t := range due {
+		t.Run(s.conf)
+	}
+}
+
+// Tasks returns the status of all scheduled tasks, sorted by their next run time.
+func (s *Scheduler) Tasks() []TaskInfo {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := make([]TaskInfo, 0, len(s.tasks))
+
+	for _, t := range s.tasks {
+		info := TaskInfo{
+			Name:     t.Name,
+			Schedule: t.Schedule.String(),
+			Next:     t.next,
+			Last:     t.last,
+			Skip:     t.skip,
+		}
+
+		if t.Activity != nil {
+			info.Running = t.Activity.Running()
+		}
+
+		result = append(result, info)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Next.Before(result[j].Next)
+	})
+
+	return result
+}
+
+// Trigger runs the specified task now, without changing its next scheduled run.
+func (s *Scheduler) Trigger(name string) error {
+	t := s.task(name)
+
+	if t == nil {
+		return ErrTaskNotFound
+	}
+
+	s.mutex.Lock()
+	t.last = time.Now()
+	s.mutex.Unlock()
+
+	log.Infof("scheduler: running %s task", t.Name)
+
+	t.Run(s.conf)
+
+	return nil
+}
+
+// Skip skips the next scheduled run of the specified task, or cancels a previous skip request.
+func (s *Scheduler) Skip(name string, skip bool) error {
+	t := s.task(name)
+
+	if t == nil {
+		return ErrTaskNotFound
+	}
+
+	s.mutex.Lock()
+	t.skip = skip
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// Next returns the next scheduled run of a task with the specified activity, or the zero time if there is none.
+func (s *Scheduler) Next(activity *mutex.Activity) (next time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, t := range s.tasks {
+		if t.Activity != activity || t.next.IsZero() {
+			continue
+		} else if next.IsZero() || t.next.Before(next) {
+			next = t.next
+		}
+	}
+
+	return next
+}
+
+// task finds a scheduled task by name.
+func (s *Scheduler) task(name string) *Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, t := range s.tasks {
+		if t.Name == name {
+			return t
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Run("Tick", func(t *testing.T) {
+		s := &Scheduler{}
+		runs := 0
+
+		s.Add("test", "@every 1m", func(conf *config.Config) { runs++ }, nil)
+		s.Add("invalid", "61 * * * *", func(conf *config.Config) { runs++ }, nil)
+		s.Add("disabled", "", func(conf *config.Config) { runs++ }, nil)
+
+		assert.False(t, s.Empty())
+		assert.Len(t, s.Tasks(), 1)
+
+		s.Tick(time.Now())
+		assert.Equal(t, 0, runs)
+
+		s.Tick(time.Now().Add(2 * time.Minute))
+		assert.Equal(t, 1, runs)
+	})
+	t.Run("Skip", func(t *testing.T) {
+		s := &Scheduler{}
+		runs := 0
+
+		s.Add("test", "@every 1m", func(conf *config.Config) { runs++ }, nil)
+
+		assert.NoError(t, s.Skip("test", true))
+		assert.True(t, s.Tasks()[0].Skip)
+
+		s.Tick(time.Now().Add(2 * time.Minute))
+		assert.Equal(t, 0, runs)
+		assert.False(t, s.Tasks()[0].Skip)
+
+		s.Tick(time.Now().Add(4 * time.Minute))
+		assert.Equal(t, 1, runs)
+
+		assert.Equal(t, ErrTaskNotFound, s.Skip("foo", true))
+	})
+	t.Run("Paused", func(t *testing.T) {
+		s := &Scheduler{}
+		runs := 0
+		activity := &mutex.Activity{}
+
+		s.Add("test", "@every 1m", func(conf *config.Config) { runs++ }, activity)
+
+		activity.Pause()
+		s.Tick(time.Now().Add(2 * time.Minute))
+		assert.Equal(t, 0, runs)
+
+		activity.Resume()
+		s.Tick(time.Now().Add(4 * time.Minute))
+		assert.Equal(t, 1, runs)
+	})
+	t.Run("Trigger", func(t *testing.T) {
+		s := &Scheduler{}
+		runs := 0
+
+		s.Add("test", "@daily", func(conf *config.Config) { runs++ }, &mutex.Activity{})
+
+		assert.NoError(t, s.Trigger("test"))
+		assert.Equal(t, 1, runs)
+		assert.False(t, s.Tasks()[0].Running)
+		assert.False(t, s.Tasks()[0].Last.IsZero())
+
+		assert.Equal(t, ErrTaskNotFound, s.Trigger("foo"))
+	})
+}
@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+// Storage represents a worker that uploads new and changed files to the object storage.
+type Storage struct {
+	conf   *config.Config
+	mirror *vfs.Mirror
+}
+
+// NewStorage returns a new storage worker.
+func NewStorage(conf *config.Config, mirror *vfs.Mirror) *Storage {
+	return &Storage{conf: conf, mirror: mirror}
+}
+
+// Start starts the storage worker.
+func (w *Storage) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("storage: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w.mirror == nil {
+		return nil
+	} else if err = mutex.StorageWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.StorageWorker.Stop()
+
+	start := time.Now()
+
+	result, err := w.mirror.Push()
+
+	if err != nil {
+		return err
+	}
+
+	if result.Transferred > 0 || result.Failed > 0 {
+		log.Infof("storage: uploaded %d files to %s, %d failed [%s]", result.Transferred, w.mirror.Backend(), result.Failed, time.Since(start))
+	}
+
+	// Remove uploaded thumbnails from the local cache if it exceeds the size limit.
+	if n, err := w.mirror.Evict(w.conf.S3CacheByteLimit()); err != nil {
+		log.Warnf("storage: %s while evicting cached files", err)
+	} else if n > 0 {
+		log.Infof("storage: evicted %d cached files", n)
+	}
+
+	return nil
+}
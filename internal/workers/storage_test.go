@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/vfs"
+)
+
+func TestStorage_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Disabled", func(t *testing.T) {
+		worker := NewStorage(conf, nil)
+
+		assert.IsType(t, &Storage{}, worker)
+		assert.NoError(t, worker.Start())
+	})
+	t.Run("Local", func(t *testing.T) {
+		dir := t.TempDir()
+		backend, err := vfs.NewLocal(filepath.Join(dir, "storage"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		originals := filepath.Join(dir, "originals")
+
+		if err = os.MkdirAll(originals, os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(filepath.Join(originals, "photo.jpg"), []byte("jpeg"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		worker := NewStorage(conf, vfs.NewMirror(backend, vfs.Root{Name: vfs.RootOriginals, Path: originals}))
+
+		assert.NoError(t, worker.Start())
+		assert.FileExists(t, filepath.Join(dir, "storage", "originals", "photo.jpg"))
+	})
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/mutex"
-	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/search"
 )
 
@@ -62,7 +61,7 @@ func (w *Sync) Start() (err error) {
 	accounts, err := search.Accounts(f)
 
 	for _, a := range accounts {
-		if a.AccType != remote.ServiceWebDAV {
+		if !syncSupported(a.AccType) {
 			continue
 		}
 
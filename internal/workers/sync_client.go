@@ -0,0 +1,78 @@
+package workers
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/gphotos"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/internal/remote/smb"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// syncClient provides access to the files of a remote account.
+type syncClient interface {
+	Directories(dir string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	Files(dir string, recursive bool) (fs.FileInfos, error)
+	Download(src, dest string, force bool) error
+}
+
+// syncSupported checks if files can be synced with the remote account type.
+func syncSupported(accType string) bool {
+	switch accType {
+	case remote.ServiceWebDAV, remote.ServiceSFTP, remote.ServiceSMB, remote.ServiceGPhotos:
+		return true
+	default:
+		return false
+	}
+}
+
+// client returns a new client for the remote account. Clients that implement io.Closer must be closed after use.
+func (w *Sync) client(a entity.Service) (syncClient, error) {
+	timeout := webdav.Durations[webdav.Timeout(a.AccTimeout)]
+
+	switch a.AccType {
+	case remote.ServiceWebDAV:
+		if c, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout)); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	case remote.ServiceSFTP:
+		if c, err := sftp.NewClient(a.AccURL, a.AccUser, a.AccPass, a.AccKey, timeout); err != nil {
+			return nil, err
+		} else {
+			entity.Log("sync", "pin host key", a.PinHostKey(c.HostKey()))
+			return c, nil
+		}
+	case remote.ServiceSMB:
+		if c, err := smb.NewClient(a.AccURL, a.AccUser, a.AccPass, timeout); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	case remote.ServiceGPhotos:
+		// The account key contains the OAuth refresh token, see api.GPhotosCallback.
+		if c, err := gphotos.NewClient(w.conf.GPhotosClientID(), w.conf.GPhotosClientSecret(), a.AccKey, timeout); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	default:
+		return nil, fmt.Errorf("sync: %s accounts are not supported", clean.Log(a.AccType))
+	}
+}
+
+// closeClient closes the connection of a client, if needed.
+func closeClient(c syncClient) {
+	if closer, ok := c.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Debugf("sync: %s", err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/smb"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+)
+
+func TestSyncSupported(t *testing.T) {
+	assert.True(t, syncSupported(remote.ServiceWebDAV))
+	assert.True(t, syncSupported(remote.ServiceSFTP))
+	assert.True(t, syncSupported(remote.ServiceSMB))
+	assert.True(t, syncSupported(remote.ServiceGPhotos))
+	assert.False(t, syncSupported(remote.ServiceFacebook))
+	assert.False(t, syncSupported(""))
+}
+
+func TestSync_client(t *testing.T) {
+	worker := NewSync(config.TestConfig())
+
+	t.Run("WebDAV", func(t *testing.T) {
+		c, err := worker.client(entity.ServiceFixtureWebdavDummy)
+
+		assert.NoError(t, err)
+		assert.IsType(t, &webdav.Client{}, c)
+	})
+	t.Run("SMB", func(t *testing.T) {
+		c, err := worker.client(entity.Service{AccType: remote.ServiceSMB, AccURL: "smb://nas.local/photos"})
+
+		assert.NoError(t, err)
+		assert.IsType(t, &smb.Client{}, c)
+	})
+	t.Run("SFTP", func(t *testing.T) {
+		_, err := worker.client(entity.Service{AccType: remote.ServiceSFTP, AccURL: "sftp:///upload"})
+
+		assert.Error(t, err)
+	})
+	t.Run("GPhotos", func(t *testing.T) {
+		_, err := worker.client(entity.Service{AccType: remote.ServiceGPhotos, AccURL: "https://photos.google.com/"})
+
+		assert.Error(t, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := worker.client(entity.Service{AccType: remote.ServiceFacebook})
+
+		assert.Error(t, err)
+	})
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/vfs"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -36,6 +36,15 @@ func (w *Sync) relatedDownloads(a entity.Service) (result Downloads, err error)
 		return result, err
 	}
 
+	// Download remote changes in two-way sync.
+	if a.SyncTwoWay() {
+		if modified, err := query.FileSyncs(a.ID, entity.FileSyncModified, maxResults); err != nil {
+			return result, err
+		} else {
+			files = append(files, modified...)
+		}
+	}
+
 	// Group results by directory and base name
 	for i, file := range files {
 		k := fs.AbsPrefix(file.RemoteName, w.conf.Settings().StackSequences())
@@ -88,12 +97,14 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	// Display log message.
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := w.client(a)
 
 	if err != nil {
 		return false, err
 	}
 
+	defer closeClient(client)
+
 	var baseDir string
 
 	if a.SyncFilenames {
@@ -103,6 +114,7 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	}
 
 	done := make(map[string]bool)
+	twoWay := a.SyncTwoWay()
 
 	for _, files := range relatedFiles {
 		for i, file := range files {
@@ -118,13 +130,30 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 
 			localName := baseDir + file.RemoteName
 
-			if _, err := os.Stat(localName); err == nil {
+			// In two-way sync, remote names are relative to the sync folder,
+			// so that uploaded files are downloaded to the same location.
+			if twoWay {
+				localName = w.localFileName(a.SyncLocalName(file.RemoteName))
+			}
+
+			// Skip files in read-only originals roots.
+			if !w.conf.OriginalsWritable(localName) || vfs.Protected(localName) && fs.FileExists(localName) {
+				log.Debugf("sync: download skipped, %s is read-only", clean.Log(localName))
+				continue
+			}
+
+			if info, err := os.Stat(localName); err == nil && file.Status != entity.FileSyncModified {
 				log.Warnf("sync: download skipped, %s already exists", localName)
 				file.Status = entity.FileSyncExists
 				file.Error = ""
 				file.Errors = 0
+
+				if twoWay {
+					file.SetLocal(a.SyncLocalName(file.RemoteName), info)
+				}
 			} else {
-				if err := client.Download(file.RemoteName, localName, false); err != nil {
+				// Existing files are only overwritten with remote changes in two-way sync.
+				if err := client.Download(file.RemoteName, localName, twoWay); err != nil {
 					file.Errors++
 					file.Error = err.Error()
 				} else {
@@ -132,6 +161,13 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 					file.Status = entity.FileSyncDownloaded
 					file.Error = ""
 					file.Errors = 0
+
+					if !twoWay {
+						entity.AddSyncJournal(a.ID, entity.SyncActionDownload, file.RemoteName, "", "")
+					} else if info, err := os.Stat(localName); err == nil {
+						file.SetLocal(a.SyncLocalName(file.RemoteName), info)
+						entity.AddSyncJournal(a.ID, entity.SyncActionDownload, file.RemoteName, file.LocalName, "")
+					}
 				}
 
 				if mutex.SyncWorker.Canceled() {
@@ -151,7 +187,13 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 				continue
 			}
 
-			mf, err := photoprism.NewMediaFile(baseDir + file.RemoteName)
+			localName := baseDir + file.RemoteName
+
+			if twoWay {
+				localName = w.localFileName(a.SyncLocalName(file.RemoteName))
+			}
+
+			mf, err := photoprism.NewMediaFile(localName)
 
 			if err != nil || !mf.IsMedia() || mf.Empty() {
 				continue
@@ -3,26 +3,31 @@ package workers
 import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
-	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/media"
 )
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !syncSupported(a.AccType) {
 		return false, nil
 	}
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := w.client(a)
 
 	if err != nil {
 		return false, err
 	}
 
-	// Ensure remote folder exists.
-	if err = client.MkdirAll(a.SyncPath); err != nil {
-		log.Debugf("sync: %s", err)
+	defer closeClient(client)
+
+	// Ensure remote folder exists, uploads and two-way sync are only supported with WebDAV.
+	dav, _ := client.(*webdav.Client)
+
+	if dav != nil {
+		if err = dav.MkdirAll(a.SyncPath); err != nil {
+			log.Debugf("sync: %s", err)
+		}
 	}
 
 	subDirs, err := client.Directories(a.SyncPath, true, webdav.MaxRequestDuration)
@@ -34,6 +39,15 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 
 	dirs := append(subDirs.Abs(), a.SyncPath)
 
+	// Remember remote files to detect changes and renames in two-way sync.
+	twoWay := a.SyncTwoWay() && dav != nil
+	seen := make(map[string]bool)
+	var added []*entity.FileSync
+
+	if twoWay {
+		w.logWarn(entity.PruneSyncJournal(a.ID))
+	}
+
 	for _, dir := range dirs {
 		if mutex.SyncWorker.Canceled() {
 			return false, nil
@@ -68,18 +82,30 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 				}
 			}
 
-			f = entity.FirstOrCreateFileSync(f)
+			created := entity.FirstOrCreateFileSync(f)
 
-			if f == nil {
+			if created == nil {
 				log.Errorf("sync: file sync entity should not be nil - possible bug")
 				continue
+			} else if created == f && f.Status == entity.FileSyncNew {
+				added = append(added, f)
 			}
 
+			f = created
+			seen[f.RemoteName] = true
+
 			if f.Status == entity.FileSyncIgnore && a.SyncRaw && (content == media.Raw || content == media.Video) {
 				w.logError(f.Update("Status", entity.FileSyncNew))
 			}
 
-			if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
+			if twoWay {
+				switch f.Status {
+				case entity.FileSyncDownloaded, entity.FileSyncUploaded, entity.FileSyncExists:
+					if f.LocalName != "" && f.RemoteChanged(file.Date, file.Size) {
+						w.logError(w.remoteChange(a, dav, f, file))
+					}
+				}
+			} else if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
 				w.logError(f.Updates(map[string]interface{}{
 					"Status":     entity.FileSyncNew,
 					"RemoteDate": file.Date,
@@ -89,5 +115,9 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 		}
 	}
 
+	if twoWay {
+		w.trackRenames(a, seen, added)
+	}
+
 	return true, nil
 }
@@ -0,0 +1,224 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// syncConflictName returns the name under which the local version of a file changed on both sides
+// is kept, e.g. "/2023/photo-conflict-20230514-120000.jpg".
+func syncConflictName(name string, modTime time.Time) string {
+	ext := path.Ext(name)
+
+	return fmt.Sprintf("%s-conflict-%s%s", strings.TrimSuffix(name, ext), modTime.UTC().Format("20060102-150405"), ext)
+}
+
+// localFileName returns the absolute originals file name of a synced file.
+func (w *Sync) localFileName(localName string) string {
+	return filepath.Join(w.conf.OriginalsPath(), filepath.FromSlash(localName))
+}
+
+// remoteChange handles a remote file that was changed since it was last synced in both directions.
+// If the local file was changed as well, the conflict is resolved according to the account policy.
+func (w *Sync) remoteChange(a entity.Service, client *webdav.Client, f *entity.FileSync, file fs.FileInfo) error {
+	info, err := os.Stat(w.localFileName(f.LocalName))
+
+	// Download remote changes if the local file was not changed.
+	if f.LocalName == "" || err != nil || !f.LocalChanged(info) {
+		return f.Updates(entity.Values{"Status": entity.FileSyncModified, "RemoteDate": file.Date, "RemoteSize": file.Size})
+	}
+
+	switch a.SyncConflict {
+	case entity.SyncConflictKeep:
+		// Upload the local version under a new name, so that both versions are synced.
+		conflictName := syncConflictName(f.RemoteName, info.ModTime())
+
+		if err = client.Upload(w.localFileName(f.LocalName), conflictName); err != nil {
+			return err
+		}
+
+		entity.FirstOrCreateFileSync(entity.NewFileSync(a.ID, conflictName))
+		entity.AddSyncJournal(a.ID, entity.SyncActionConflict, f.RemoteName, f.LocalName,
+			fmt.Sprintf("changed on both sides, local version uploaded as %s", conflictName))
+
+		return f.Updates(entity.Values{"Status": entity.FileSyncModified, "RemoteDate": file.Date, "RemoteSize": file.Size})
+	default:
+		if file.Date.After(info.ModTime()) {
+			entity.AddSyncJournal(a.ID, entity.SyncActionConflict, f.RemoteName, f.LocalName, "changed on both sides, remote version is newer")
+
+			return f.Updates(entity.Values{"Status": entity.FileSyncModified, "RemoteDate": file.Date, "RemoteSize": file.Size})
+		}
+
+		entity.AddSyncJournal(a.ID, entity.SyncActionConflict, f.RemoteName, f.LocalName, "changed on both sides, local version is newer")
+
+		// Keep the local version, which is uploaded in the next step.
+		return f.Updates(entity.Values{"RemoteDate": file.Date, "RemoteSize": file.Size})
+	}
+}
+
+// trackRenames finds remote files that were renamed, so that they are not downloaded again.
+// Files are considered renamed if a new file has the same size and modification time as a file that has disappeared.
+func (w *Sync) trackRenames(a entity.Service, seen map[string]bool, added []*entity.FileSync) {
+	if len(added) == 0 {
+		return
+	}
+
+	synced, err := query.SyncedFiles(a.ID)
+
+	if err != nil {
+		w.logError(err)
+		return
+	}
+
+	for i := range synced {
+		f := &synced[i]
+
+		if seen[f.RemoteName] {
+			continue
+		}
+
+		for j, n := range added {
+			if n == nil || n.RemoteSize != f.RemoteSize || !n.RemoteDate.Equal(f.RemoteDate) {
+				continue
+			}
+
+			added[j] = nil
+			oldName := f.RemoteName
+
+			if err = n.Delete(); err != nil {
+				w.logError(err)
+				break
+			} else if err = f.Rename(n.RemoteName); err != nil {
+				w.logError(err)
+				break
+			}
+
+			// Move the local file accordingly.
+			localName := a.SyncLocalName(n.RemoteName)
+			src, dest := w.localFileName(f.LocalName), w.localFileName(localName)
+
+			if !fs.FileExists(src) || fs.FileExists(dest) {
+				log.Debugf("sync: %s was renamed to %s, keeping local file name", clean.Log(oldName), clean.Log(n.RemoteName))
+			} else if err = os.MkdirAll(filepath.Dir(dest), fs.ModeDir); err != nil {
+				w.logError(err)
+			} else if err = vfs.Rename(src, dest); err != nil {
+				w.logError(err)
+			} else {
+				w.logError(query.RenameFile(entity.RootOriginals, f.LocalName, entity.RootOriginals, localName))
+				w.logError(f.Update("LocalName", localName))
+			}
+
+			log.Infof("sync: %s was renamed to %s on %s", clean.Log(oldName), clean.Log(n.RemoteName), clean.Log(a.AccName))
+			entity.AddSyncJournal(a.ID, entity.SyncActionRename, n.RemoteName, localName, fmt.Sprintf("renamed from %s", oldName))
+
+			break
+		}
+	}
+}
+
+// uploadChanges uploads files that were renamed or changed locally since they were last synced.
+func (w *Sync) uploadChanges(a entity.Service) error {
+	files, err := query.SyncedFiles(a.ID)
+
+	if err != nil || len(files) == 0 {
+		return err
+	}
+
+	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+
+	if err != nil {
+		return err
+	}
+
+	for i := range files {
+		if mutex.SyncWorker.Canceled() {
+			return nil
+		}
+
+		f := &files[i]
+
+		// Link downloaded files to the index, so that they are not uploaded as new files.
+		if f.FileID == 0 {
+			if file, err := query.FileByName(entity.RootOriginals, f.LocalName); err != nil {
+				continue
+			} else if err = f.Update("FileID", file.ID); err != nil {
+				w.logError(err)
+				continue
+			} else {
+				f.FileID = file.ID
+				f.File = file
+			}
+		}
+
+		if f.File == nil || f.File.FileMissing || f.File.FileRoot != entity.RootOriginals {
+			continue
+		}
+
+		// Rename the remote file if the local file was renamed.
+		if f.File.FileName != f.LocalName {
+			oldName, remoteName := f.RemoteName, a.SyncRemoteName(f.File.FileName)
+
+			if err = client.Move(oldName, remoteName); err != nil {
+				w.logError(err)
+				continue
+			} else if err = f.Rename(remoteName); err != nil {
+				w.logError(err)
+				continue
+			}
+
+			w.logError(f.Update("LocalName", f.File.FileName))
+			f.LocalName = f.File.FileName
+
+			log.Infof("sync: renamed %s to %s on %s", clean.Log(oldName), clean.Log(remoteName), clean.Log(a.AccName))
+			entity.AddSyncJournal(a.ID, entity.SyncActionRename, remoteName, f.LocalName, fmt.Sprintf("renamed from %s", oldName))
+		}
+
+		// Upload local changes.
+		fileName := photoprism.FileName(f.File.FileRoot, f.File.FileName)
+		info, err := os.Stat(fileName)
+
+		if err != nil || !f.LocalChanged(info) {
+			continue
+		}
+
+		if err = client.Upload(fileName, f.RemoteName); err != nil {
+			w.logError(err)
+			continue
+		}
+
+		f.SetLocal(f.File.FileName, info)
+		f.RemoteDate, f.RemoteSize = time.Now(), info.Size()
+
+		if remoteFile, err := client.Stat(f.RemoteName); err == nil {
+			f.RemoteDate, f.RemoteSize = remoteFile.Date, remoteFile.Size
+		}
+
+		if err = f.Updates(entity.Values{
+			"LocalName":  f.LocalName,
+			"LocalDate":  f.LocalDate,
+			"LocalSize":  f.LocalSize,
+			"RemoteDate": f.RemoteDate,
+			"RemoteSize": f.RemoteSize,
+		}); err != nil {
+			w.logError(err)
+		}
+
+		log.Infof("sync: uploaded changes of %s to %s", clean.Log(f.LocalName), clean.Log(a.AccName))
+		entity.AddSyncJournal(a.ID, entity.SyncActionUpload, f.RemoteName, f.LocalName, "uploaded local changes")
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncConflictName(t *testing.T) {
+	modTime := time.Date(2023, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "/2023/photo-conflict-20230514-120000.jpg", syncConflictName("/2023/photo.jpg", modTime))
+	assert.Equal(t, "/2023/README-conflict-20230514-120000", syncConflictName("/2023/README", modTime))
+}
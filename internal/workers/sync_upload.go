@@ -1,6 +1,7 @@
 package workers
 
 import (
+	"os"
 	"path"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
@@ -17,6 +19,18 @@ import (
 func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 	maxResults := 250
 
+	// Uploads are only supported with WebDAV.
+	if a.AccType != remote.ServiceWebDAV {
+		return true, nil
+	}
+
+	// Upload renamed and changed files in two-way sync.
+	if a.SyncTwoWay() {
+		if err = w.uploadChanges(a); err != nil {
+			return false, err
+		}
+	}
+
 	// Get upload file list from database
 	files, err := query.AccountUploads(a, maxResults)
 
@@ -65,6 +79,20 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 		fileSync.Error = ""
 		fileSync.Errors = 0
 
+		// Remember the synced state to detect changes in two-way sync.
+		if a.SyncTwoWay() {
+			if info, err := os.Stat(fileName); err == nil {
+				fileSync.SetLocal(file.FileName, info)
+			}
+
+			if remoteFile, err := client.Stat(remoteName); err == nil {
+				fileSync.RemoteDate = remoteFile.Date
+				fileSync.RemoteSize = remoteFile.Size
+			}
+		}
+
+		entity.AddSyncJournal(a.ID, entity.SyncActionUpload, remoteName, file.FileName, "")
+
 		if mutex.SyncWorker.Canceled() {
 			return false, nil
 		}
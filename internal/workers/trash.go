@@ -0,0 +1,103 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Trash represents a worker that permanently deletes pictures that have been archived for longer
+// than the configured retention period, including their files.
+type Trash struct {
+	conf *config.Config
+}
+
+// NewTrash returns a new trash worker.
+func NewTrash(conf *config.Config) *Trash {
+	return &Trash{conf: conf}
+}
+
+// Start permanently deletes expired archived pictures.
+func (w *Trash) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("trash: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	retention := w.conf.TrashRetention()
+
+	if retention <= 0 || w.conf.ReadOnly() || !w.conf.Settings().Features.Delete {
+		return nil
+	} else if err = mutex.TrashWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.TrashWorker.Stop()
+
+	start := time.Now()
+	before := start.Add(-1 * retention)
+
+	var afterID uint
+	var deleted []string
+	var numFiles int
+
+	for {
+		photos, err := query.ExpiredPhotos(before, afterID, 100)
+
+		if err != nil {
+			return err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		for _, p := range photos {
+			if mutex.TrashWorker.Canceled() {
+				return errors.New("canceled")
+			}
+
+			afterID = p.ID
+
+			if n, err := photoprism.DeletePhoto(p, true, true); err != nil {
+				log.Errorf("trash: %s (delete %s)", err, clean.Log(p.PhotoUID))
+			} else {
+				numFiles += n
+				deleted = append(deleted, p.PhotoUID)
+			}
+		}
+	}
+
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	// Update precalculated photo and file counts.
+	if err = entity.UpdateCounts(); err != nil {
+		log.Warnf("trash: %s (update counts)", err)
+	}
+
+	// Update album, subject, and label cover thumbs.
+	if err = query.UpdateCovers(); err != nil {
+		log.Warnf("trash: %s (update covers)", err)
+	}
+
+	event.EntitiesDeleted("photos", deleted)
+
+	log.Infof("trash: permanently deleted %s and %s [%s]",
+		english.Plural(len(deleted), "picture", "pictures"),
+		english.Plural(numFiles, "file", "files"), time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestTrash_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewTrash(conf)
+
+	assert.IsType(t, &Trash{}, worker)
+
+	// Disabled by default.
+	assert.NoError(t, worker.Start())
+}
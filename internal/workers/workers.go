@@ -25,28 +25,44 @@ Additional information can be found in our Developer Guide:
 package workers
 
 import (
+	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
+
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
 
-// Start runs the metadata, share & sync background workers at regular intervals.
-func Start(conf *config.Config) {
-	interval := conf.WakeupInterval()
+var scheduler *Scheduler
+var schedulerMutex = sync.RWMutex{}
 
+// Start runs the metadata, share, sync & maintenance background workers according to their schedule.
+func Start(conf *config.Config) {
 	// Disabled in safe mode?
-	if interval.Seconds() <= 0 {
+	if conf.WakeupInterval().Seconds() <= 0 {
 		log.Warnf("config: disabled metadata, share & sync background workers")
+	}
+
+	s := NewScheduler(conf)
+
+	if s.Empty() {
 		return
 	}
 
-	ticker := time.NewTicker(interval)
+	schedulerMutex.Lock()
+	scheduler = s
+	schedulerMutex.Unlock()
+
+	ticker := time.NewTicker(SchedulerTick)
 
 	go func() {
 		for {
@@ -57,16 +73,26 @@ func Start(conf *config.Config) {
 				mutex.MetaWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.AlbumsWorker.Cancel()
+				mutex.StorageWorker.Cancel()
+				mutex.ColdWorker.Cancel()
+				mutex.BackupWorker.Cancel()
 				return
-			case <-ticker.C:
-				RunMeta(conf)
-				RunShare(conf)
-				RunSync(conf)
+			case now := <-ticker.C:
+				s.Tick(now)
 			}
 		}
 	}()
 }
 
+// GetScheduler returns the background task scheduler, or nil if it has not been started.
+func GetScheduler() *Scheduler {
+	schedulerMutex.RLock()
+	defer schedulerMutex.RUnlock()
+
+	return scheduler
+}
+
 // Stop shuts down all service workers.
 func Stop() {
 	stop <- true
@@ -82,6 +108,7 @@ func RunMeta(conf *config.Config) {
 			interval := entity.MetadataUpdateInterval
 
 			if err := worker.Start(delay, interval, false); err != nil {
+				mutex.MetaWorker.Fail(err)
 				log.Warnf("metadata: %s", err)
 			}
 		}()
@@ -94,6 +121,7 @@ func RunShare(conf *config.Config) {
 		go func() {
 			worker := NewShare(conf)
 			if err := worker.Start(); err != nil {
+				mutex.ShareWorker.Fail(err)
 				log.Warnf("share: %s", err)
 			}
 		}()
@@ -106,8 +134,193 @@ func RunSync(conf *config.Config) {
 		go func() {
 			worker := NewSync(conf)
 			if err := worker.Start(); err != nil {
+				mutex.SyncWorker.Fail(err)
 				log.Warnf("sync: %s", err)
 			}
 		}()
 	}
 }
+
+func RunAlbums(conf *config.Config) {
+	if len(conf.AlbumTemplates()) > 0 && !mutex.AlbumsWorker.Running() {
+		go func() {
+			worker := NewAlbums(conf)
+			if err := worker.Start(time.Now()); err != nil {
+				mutex.AlbumsWorker.Fail(err)
+				log.Warnf("albums: %s", err)
+			}
+		}()
+	}
+}
+
+// RunStorage runs the storage worker once if an object storage is configured.
+func RunStorage(conf *config.Config) {
+	if mirror := get.Storage(); mirror != nil && !mutex.StorageWorker.Running() && !mutex.MainWorker.Running() {
+		go func() {
+			worker := NewStorage(conf, mirror)
+			if err := worker.Start(); err != nil {
+				mutex.StorageWorker.Fail(err)
+				log.Warnf("storage: %s", err)
+			}
+		}()
+	}
+}
+
+// RunCold runs the cold storage worker once to restore requested originals.
+func RunCold(conf *config.Config) {
+	if cold := get.Cold(); cold != nil && !mutex.ColdWorker.Running() {
+		go func() {
+			worker := NewCold(conf, cold)
+			if err := worker.Start(); err != nil {
+				mutex.ColdWorker.Fail(err)
+				log.Warnf("cold: %s", err)
+			}
+		}()
+	}
+}
+
+// RunTrash permanently deletes pictures that have been archived for longer than the retention period.
+func RunTrash(conf *config.Config) {
+	if mutex.TrashWorker.Running() {
+		return
+	}
+
+	go func() {
+		worker := NewTrash(conf)
+		if err := worker.Start(); err != nil {
+			mutex.TrashWorker.Fail(err)
+			log.Warnf("trash: %s", err)
+		}
+	}()
+}
+
+// RunIndex indexes all originals once, unless another index or import operation is running.
+func RunIndex(conf *config.Config) {
+	if mutex.MainWorker.Running() {
+		return
+	}
+
+	go func() {
+		start := time.Now()
+		convert := conf.Settings().Index.Convert && conf.SidecarWritable()
+		opt := photoprism.NewIndexOptions(entity.RootPath, false, convert, true, false, true)
+		opt.Action = photoprism.ActionAutoIndex
+		opt.Throttle = true
+
+		found, indexed := get.Index().Start(opt)
+
+		prgOpt := photoprism.PurgeOptions{
+			Path:   filepath.Clean(entity.RootPath),
+			Ignore: found,
+			Force:  indexed > 0,
+		}
+
+		if _, _, _, err := get.Purge().Start(prgOpt); err != nil {
+			mutex.MainWorker.Fail(err)
+			log.Warnf("index: %s (purge)", err)
+		} else if err = get.Moments().Start(); err != nil {
+			log.Warnf("moments: %s", err)
+		}
+
+		log.Infof("index: updated %s [%s]", english.Plural(indexed, "file", "files"), time.Since(start))
+	}()
+}
+
+// RunBackup creates an index backup and album YAML files once.
+func RunBackup(conf *config.Config) {
+	if err := mutex.BackupWorker.Start(); err != nil {
+		return
+	}
+
+	go func() {
+		defer mutex.BackupWorker.Stop()
+
+		start := time.Now()
+		fileName := photoprism.BackupIndexFileName(filepath.Join(conf.BackupPath(), conf.DatabaseDriver()))
+
+		var errs []string
+
+		if err := photoprism.BackupIndex(conf, fileName, true); err != nil {
+			mutex.BackupWorker.Fail(err)
+			log.Errorf("backup: %s", err)
+			errs = append(errs, err.Error())
+			fileName = ""
+		} else if _, err = photoprism.RotateIndexBackups(filepath.Dir(fileName), conf.BackupRetain()); err != nil {
+			log.Warnf("backup: %s (rotate)", err)
+		}
+
+		if conf.BackupYaml() {
+			if count, err := photoprism.BackupAlbums(conf.AlbumsPath(), false); err != nil {
+				mutex.BackupWorker.Fail(err)
+				log.Errorf("backup: %s (albums)", err)
+				errs = append(errs, err.Error()+" (albums)")
+			} else if count > 0 {
+				log.Infof("backup: saved %s", english.Plural(count, "album file", "album files"))
+			}
+
+			if count, err := photoprism.BackupPeople(conf.PeoplePath(), false); err != nil {
+				mutex.BackupWorker.Fail(err)
+				log.Errorf("backup: %s (people)", err)
+				errs = append(errs, err.Error()+" (people)")
+			} else if count > 0 {
+				log.Infof("backup: saved %s", english.Plural(count, "people file", "people files"))
+			}
+		}
+
+		// Upload the backup to an object storage bucket or WebDAV folder, if configured.
+		if remote := get.RemoteBackup(); remote != nil {
+			if _, err := remote.Upload(fileName, conf.BackupYaml()); err != nil {
+				mutex.BackupWorker.Fail(err)
+				log.Errorf("backup: %s (upload)", err)
+				errs = append(errs, err.Error()+" (upload)")
+			} else if !conf.BackupOriginals() {
+				// Do nothing.
+			} else if _, err = remote.BackupOriginals(); err != nil {
+				mutex.BackupWorker.Fail(err)
+				log.Errorf("backup: %s (originals)", err)
+				errs = append(errs, err.Error()+" (originals)")
+			}
+
+			if _, err := remote.Rotate(conf.BackupRetain()); err != nil {
+				log.Warnf("backup: %s (rotate remote)", err)
+			}
+		} else if conf.BackupRemote() {
+			errs = append(errs, "remote backup could not be initialized")
+		}
+
+		// Notify administrators if the backup has failed.
+		sendBackupAlert(conf, errs)
+
+		log.Infof("backup: completed in %s", time.Since(start))
+	}()
+}
+
+// RunCleanUp removes orphaned index entries, sidecar and thumbnail files once.
+func RunCleanUp(conf *config.Config) {
+	if mutex.MainWorker.Running() {
+		return
+	}
+
+	go func() {
+		if thumbs, orphans, sidecars, err := get.CleanUp().Start(photoprism.CleanUpOptions{}); err != nil {
+			mutex.MainWorker.Fail(err)
+			log.Warnf("cleanup: %s", err)
+		} else if total := thumbs + orphans + sidecars; total > 0 {
+			log.Infof("cleanup: removed %s", english.Plural(total, "index entry and file", "index entries and files"))
+		}
+	}()
+}
+
+// RunFaces runs face recognition once, if it is scheduled separately from the metadata worker.
+func RunFaces(conf *config.Config) {
+	if mutex.FacesWorker.Running() || mutex.MainWorker.Running() {
+		return
+	}
+
+	go func() {
+		if err := photoprism.NewFaces(conf).Start(photoprism.FacesOptions{Throttle: true}); err != nil {
+			mutex.FacesWorker.Fail(err)
+			log.Warnf("faces: %s", err)
+		}
+	}()
+}
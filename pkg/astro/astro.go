@@ -0,0 +1,48 @@
+/*
+Package astro calculates the position of the sun and the phase of the moon, e.g. to find pictures taken at golden hour.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// J2000 is the Julian date of 2000-01-01 12:00 UTC.
+const J2000 = 2451545.0
+
+// julianDays returns the number of days since J2000.
+func julianDays(t time.Time) float64 {
+	return float64(t.UTC().UnixNano())/float64(24*time.Hour) + 2440587.5 - J2000
+}
+
+// rad converts degrees to radians.
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// deg converts radians to degrees.
+func deg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
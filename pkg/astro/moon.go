@@ -0,0 +1,66 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// SynodicMonth is the average number of days from new moon to new moon.
+const SynodicMonth = 29.530588853
+
+// A known new moon on 2000-01-06 18:14 UTC, as Julian days since J2000.
+const newMoon = 5.2597
+
+// Moon phase names.
+const (
+	MoonNew            = "new"
+	MoonWaxingCrescent = "waxing-crescent"
+	MoonFirstQuarter   = "first-quarter"
+	MoonWaxingGibbous  = "waxing-gibbous"
+	MoonFull           = "full"
+	MoonWaningGibbous  = "waning-gibbous"
+	MoonLastQuarter    = "last-quarter"
+	MoonWaningCrescent = "waning-crescent"
+)
+
+// MoonPhases lists the phase names in order, starting with the new moon.
+var MoonPhases = []string{
+	MoonNew,
+	MoonWaxingCrescent,
+	MoonFirstQuarter,
+	MoonWaxingGibbous,
+	MoonFull,
+	MoonWaningGibbous,
+	MoonLastQuarter,
+	MoonWaningCrescent,
+}
+
+// MoonPhase represents the phase of the moon at a given time.
+type MoonPhase struct {
+	Age          float64 // Days since the last new moon.
+	Fraction     float64 // Position in the lunar cycle from 0 to 1, with 0.5 being the full moon.
+	Illumination float64 // Illuminated fraction of the disk from 0 to 1.
+}
+
+// Moon returns the phase of the moon at the specified time, based on the mean synodic month,
+// so that it may differ from the exact phase by a few hours.
+func Moon(t time.Time) MoonPhase {
+	age := math.Mod(julianDays(t)-newMoon, SynodicMonth)
+
+	if age < 0 {
+		age += SynodicMonth
+	}
+
+	f := age / SynodicMonth
+
+	return MoonPhase{
+		Age:          age,
+		Fraction:     f,
+		Illumination: (1 - math.Cos(2*math.Pi*f)) / 2,
+	}
+}
+
+// Name returns the name of the moon phase, e.g. "full".
+func (m MoonPhase) Name() string {
+	return MoonPhases[int(math.Floor(m.Fraction*8+0.5))%8]
+}
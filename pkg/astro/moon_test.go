@@ -0,0 +1,43 @@
+package astro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoon(t *testing.T) {
+	t.Run("Full", func(t *testing.T) {
+		m := Moon(time.Date(2023, 8, 31, 1, 35, 0, 0, time.UTC))
+
+		assert.Equal(t, MoonFull, m.Name())
+		assert.InDelta(t, 1, m.Illumination, 0.01)
+	})
+	t.Run("New", func(t *testing.T) {
+		m := Moon(time.Date(2023, 8, 16, 9, 38, 0, 0, time.UTC))
+
+		assert.Equal(t, MoonNew, m.Name())
+		assert.InDelta(t, 0, m.Illumination, 0.01)
+	})
+	t.Run("FirstQuarter", func(t *testing.T) {
+		m := Moon(time.Date(2023, 8, 24, 9, 57, 0, 0, time.UTC))
+
+		assert.Equal(t, MoonFirstQuarter, m.Name())
+		assert.InDelta(t, 0.5, m.Illumination, 0.05)
+	})
+	t.Run("LastQuarter", func(t *testing.T) {
+		assert.Equal(t, MoonLastQuarter, Moon(time.Date(2023, 9, 6, 22, 21, 0, 0, time.UTC)).Name())
+	})
+	t.Run("BeforeJ2000", func(t *testing.T) {
+		assert.Equal(t, MoonFull, Moon(time.Date(1999, 12, 22, 17, 31, 0, 0, time.UTC)).Name())
+	})
+}
+
+func TestMoonPhase_Name(t *testing.T) {
+	assert.Equal(t, MoonNew, MoonPhase{Fraction: 0.97}.Name())
+	assert.Equal(t, MoonWaxingCrescent, MoonPhase{Fraction: 0.1}.Name())
+	assert.Equal(t, MoonWaxingGibbous, MoonPhase{Fraction: 0.4}.Name())
+	assert.Equal(t, MoonWaningGibbous, MoonPhase{Fraction: 0.6}.Name())
+	assert.Equal(t, MoonWaningCrescent, MoonPhase{Fraction: 0.9}.Name())
+}
@@ -0,0 +1,96 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// Light types based on the altitude of the sun.
+const (
+	LightDay        = "day"
+	LightGoldenHour = "goldenhour"
+	LightBlueHour   = "bluehour"
+	LightNight      = "night"
+)
+
+// Sun directions, i.e. whether the sun rises in the morning or sets in the afternoon.
+const (
+	SunRising  = "rising"
+	SunSetting = "setting"
+)
+
+// Sun altitudes in degrees between which the light is considered golden or blue.
+const (
+	GoldenHourMax = 6.0
+	BlueHourMax   = -4.0
+	BlueHourMin   = -6.0
+)
+
+// Earth's axial tilt in radians.
+var obliquity = rad(23.4397)
+
+// SunPosition represents the position of the sun in the sky at a given time and place.
+type SunPosition struct {
+	Altitude float64 // Degrees above the horizon, negative if below.
+	Azimuth  float64 // Degrees clockwise from north.
+	Rising   bool    // True before solar noon.
+}
+
+// Sun returns the position of the sun at the specified time and coordinates, based on the
+// simplified formulas from "Astronomical Algorithms" by Jean Meeus, which are accurate
+// to well within a degree for photographic purposes.
+func Sun(t time.Time, lat, lng float64) SunPosition {
+	d := julianDays(t)
+
+	// Solar mean anomaly, equation of center, and ecliptic longitude.
+	m := rad(357.5291 + 0.98560028*d)
+	c := rad(1.9148*math.Sin(m) + 0.02*math.Sin(2*m) + 0.0003*math.Sin(3*m))
+	l := m + c + rad(102.9372) + math.Pi
+
+	// Declination and right ascension.
+	dec := math.Asin(math.Sin(obliquity) * math.Sin(l))
+	ra := math.Atan2(math.Sin(l)*math.Cos(obliquity), math.Cos(l))
+
+	// Local hour angle, normalized to -π..π, with negative values before solar noon.
+	h := math.Mod(rad(280.16+360.9856235*d)+rad(lng)-ra, 2*math.Pi)
+
+	if h > math.Pi {
+		h -= 2 * math.Pi
+	} else if h < -math.Pi {
+		h += 2 * math.Pi
+	}
+
+	phi := rad(lat)
+
+	alt := math.Asin(math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(h))
+	az := math.Atan2(math.Sin(h), math.Cos(h)*math.Sin(phi)-math.Tan(dec)*math.Cos(phi))
+
+	return SunPosition{
+		Altitude: deg(alt),
+		Azimuth:  math.Mod(deg(az)+180, 360),
+		Rising:   h < 0,
+	}
+}
+
+// Light returns the type of natural light based on the altitude of the sun.
+func (p SunPosition) Light() string {
+	switch {
+	case p.Altitude > GoldenHourMax:
+		return LightDay
+	case p.Altitude > BlueHourMax:
+		return LightGoldenHour
+	case p.Altitude >= BlueHourMin:
+		return LightBlueHour
+	default:
+		return LightNight
+	}
+}
+
+// Direction returns whether the sun is rising or setting.
+func (p SunPosition) Direction() string {
+	if p.Rising {
+		return SunRising
+	}
+
+	return SunSetting
+}
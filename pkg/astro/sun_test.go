@@ -0,0 +1,60 @@
+package astro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSun(t *testing.T) {
+	// Berlin on the summer solstice.
+	lat, lng := 52.52, 13.405
+
+	t.Run("SolarNoon", func(t *testing.T) {
+		p := Sun(time.Date(2023, 6, 21, 11, 8, 0, 0, time.UTC), lat, lng)
+
+		assert.InDelta(t, 60.9, p.Altitude, 0.2)
+		assert.InDelta(t, 180, p.Azimuth, 1)
+		assert.Equal(t, LightDay, p.Light())
+	})
+	t.Run("Sunrise", func(t *testing.T) {
+		p := Sun(time.Date(2023, 6, 21, 2, 43, 0, 0, time.UTC), lat, lng)
+
+		assert.InDelta(t, -0.83, p.Altitude, 0.2)
+		assert.InDelta(t, 47.7, p.Azimuth, 1)
+		assert.True(t, p.Rising)
+		assert.Equal(t, LightGoldenHour, p.Light())
+		assert.Equal(t, SunRising, p.Direction())
+	})
+	t.Run("Sunset", func(t *testing.T) {
+		p := Sun(time.Date(2023, 6, 21, 19, 33, 0, 0, time.UTC), lat, lng)
+
+		assert.InDelta(t, -0.83, p.Altitude, 0.2)
+		assert.False(t, p.Rising)
+		assert.Equal(t, LightGoldenHour, p.Light())
+		assert.Equal(t, SunSetting, p.Direction())
+	})
+	t.Run("Night", func(t *testing.T) {
+		p := Sun(time.Date(2023, 6, 21, 23, 0, 0, 0, time.UTC), lat, lng)
+
+		assert.Less(t, p.Altitude, BlueHourMin)
+		assert.Equal(t, LightNight, p.Light())
+	})
+	t.Run("SouthernHemisphere", func(t *testing.T) {
+		// Sydney at noon in winter, with the sun in the north.
+		p := Sun(time.Date(2023, 6, 21, 2, 0, 0, 0, time.UTC), -33.87, 151.21)
+
+		assert.InDelta(t, 32.7, p.Altitude, 1)
+		assert.True(t, p.Azimuth > 350 || p.Azimuth < 10)
+	})
+}
+
+func TestSunPosition_Light(t *testing.T) {
+	assert.Equal(t, LightDay, SunPosition{Altitude: 6.1}.Light())
+	assert.Equal(t, LightGoldenHour, SunPosition{Altitude: 6}.Light())
+	assert.Equal(t, LightGoldenHour, SunPosition{Altitude: -3.9}.Light())
+	assert.Equal(t, LightBlueHour, SunPosition{Altitude: -4}.Light())
+	assert.Equal(t, LightBlueHour, SunPosition{Altitude: -6}.Light())
+	assert.Equal(t, LightNight, SunPosition{Altitude: -6.1}.Light())
+}
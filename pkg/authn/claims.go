@@ -0,0 +1,70 @@
+package authn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Claims represents the claims of an identity token, e.g. as provided by an OpenID Connect provider.
+type Claims map[string]interface{}
+
+// value returns the raw value of the specified claim. Nested claims can be
+// selected with a dot-separated path, e.g. "realm_access.roles".
+func (c Claims) value(name string) interface{} {
+	if len(c) == 0 || name == "" {
+		return nil
+	}
+
+	var value interface{} = map[string]interface{}(c)
+
+	for _, key := range strings.Split(name, ".") {
+		switch m := value.(type) {
+		case map[string]interface{}:
+			value = m[key]
+		case Claims:
+			value = m[key]
+		default:
+			return nil
+		}
+	}
+
+	return value
+}
+
+// Values returns the string values of the specified claim. Nested claims can be
+// selected with a dot-separated path, e.g. "realm_access.roles".
+func (c Claims) Values(name string) (result []string) {
+	switch v := c.value(name).(type) {
+	case nil:
+		return result
+	case string:
+		// Some providers return space-separated lists.
+		return strings.Fields(v)
+	case []string:
+		return v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			} else if item != nil {
+				result = append(result, fmt.Sprint(item))
+			}
+		}
+	case bool, float64, int, int64:
+		return []string{fmt.Sprint(v)}
+	}
+
+	return result
+}
+
+// String returns the value of the specified claim if it is a string, the first value if it is a list,
+// or an empty string. Strings are not split, so that names with spaces are returned in full.
+func (c Claims) String(name string) string {
+	if s, ok := c.value(name).(string); ok {
+		return strings.TrimSpace(s)
+	} else if values := c.Values(name); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
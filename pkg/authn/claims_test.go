@@ -0,0 +1,31 @@
+package authn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaims_Values(t *testing.T) {
+	claims := Claims{
+		"sub":                "248289761001",
+		"preferred_username": "jane",
+		"groups":             []interface{}{"photoprism-admins", "family"},
+		"scope":              "openid profile",
+		"realm_access":       map[string]interface{}{"roles": []interface{}{"viewer"}},
+		"level":              float64(3),
+		"name":               " Jane Doe ",
+	}
+
+	assert.Equal(t, []string{"photoprism-admins", "family"}, claims.Values("groups"))
+	assert.Equal(t, []string{"openid", "profile"}, claims.Values("scope"))
+	assert.Equal(t, []string{"viewer"}, claims.Values("realm_access.roles"))
+	assert.Equal(t, []string{"3"}, claims.Values("level"))
+	assert.Empty(t, claims.Values("realm_access.groups"))
+	assert.Empty(t, claims.Values("sub.foo"))
+	assert.Empty(t, claims.Values(""))
+	assert.Equal(t, "jane", claims.String("preferred_username"))
+	assert.Equal(t, "", claims.String("email"))
+	assert.Equal(t, "Jane Doe", claims.String("name"))
+	assert.Equal(t, "photoprism-admins", claims.String("groups"))
+}
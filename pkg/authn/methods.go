@@ -0,0 +1,41 @@
+package authn
+
+import (
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// MethodType represents an authentication method.
+type MethodType string
+
+// Authentication methods.
+const (
+	MethodDefault     MethodType = "default"
+	MethodSession     MethodType = "session"
+	MethodAccessToken MethodType = "access_token"
+	MethodPasskey     MethodType = "passkey"
+	MethodUnknown     MethodType = ""
+)
+
+// IsDefault checks if this is the default method.
+func (t MethodType) IsDefault() bool {
+	return t.String() == MethodDefault.String()
+}
+
+// String returns the authentication method as a string.
+func (t MethodType) String() string {
+	switch t {
+	case "":
+		return string(MethodDefault)
+	case "token", "access-token", "app":
+		return string(MethodAccessToken)
+	case "webauthn", "passkeys":
+		return string(MethodPasskey)
+	default:
+		return string(t)
+	}
+}
+
+// Method casts a string to a normalized method type.
+func Method(s string) MethodType {
+	return MethodType(clean.TypeLower(s))
+}
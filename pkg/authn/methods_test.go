@@ -0,0 +1,23 @@
+package authn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodType_String(t *testing.T) {
+	assert.Equal(t, "default", MethodUnknown.String())
+	assert.Equal(t, "default", MethodDefault.String())
+	assert.Equal(t, "session", MethodSession.String())
+	assert.Equal(t, "access_token", MethodAccessToken.String())
+	assert.Equal(t, "access_token", MethodType("token").String())
+	assert.Equal(t, "passkey", MethodPasskey.String())
+	assert.Equal(t, "passkey", MethodType("webauthn").String())
+}
+
+func TestMethod(t *testing.T) {
+	assert.Equal(t, MethodAccessToken, Method("Access_Token"))
+	assert.Equal(t, MethodUnknown, Method(""))
+	assert.True(t, Method("").IsDefault())
+}
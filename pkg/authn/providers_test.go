@@ -13,3 +13,9 @@ func TestProviderType_String(t *testing.T) {
 	assert.Equal(t, "local", ProviderLocal.String())
 	assert.Equal(t, "ldap", ProviderLDAP.String())
 }
+
+func TestProviderOIDC(t *testing.T) {
+	assert.Equal(t, ProviderOIDC, Provider("openid"))
+	assert.True(t, ProviderOIDC.IsRemote())
+	assert.Equal(t, "OpenID Connect", ProviderOIDC.Pretty())
+}
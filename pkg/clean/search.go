@@ -2,6 +2,7 @@ package clean
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -26,6 +27,13 @@ func SearchString(s string) string {
 	s = strings.ReplaceAll(s, "%", "*")
 	s = strings.ReplaceAll(s, "**", "*")
 
+	// Keep comparison operators in front of numbers, e.g. ">1500" or "<0".
+	if n := strings.TrimLeft(s, "<>="); n != s && len(s)-len(n) <= 2 {
+		if _, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+			return strings.TrimSpace(s)
+		}
+	}
+
 	// Trim.
 	return strings.Trim(s, "|\\<>\n\r\t")
 }
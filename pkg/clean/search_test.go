@@ -19,6 +19,12 @@ func TestSearchString(t *testing.T) {
 		q := SearchString(" Flowers in the Park ")
 		assert.Equal(t, " Flowers in the Park ", q)
 	})
+	t.Run("Operator", func(t *testing.T) {
+		assert.Equal(t, ">1500", SearchString(">1500"))
+		assert.Equal(t, "<=0", SearchString("<=0"))
+		assert.Equal(t, "<-10", SearchString("<-10"))
+		assert.Equal(t, "foo", SearchString("<foo>"))
+	})
 }
 
 func TestSearchQuery(t *testing.T) {
@@ -0,0 +1,292 @@
+/*
+Package cron provides parsing of cron expressions and calculation of their next run times.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinInterval is the shortest interval that can be specified with @every.
+const MinInterval = time.Minute
+
+// Descriptors maps predefined schedules to the equivalent cron expressions.
+var Descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule represents a parsed cron expression with minute, hour, day of month, month, and day of week fields.
+type Schedule struct {
+	spec   string
+	every  time.Duration
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+}
+
+// field specifies the valid range and names of a cron expression field.
+type field struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	dowField    = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// Parse parses a standard cron expression like "30 2 * * 1-5", a descriptor like "@daily",
+// or a fixed interval like "@every 15m".
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	s := &Schedule{spec: spec}
+
+	if spec == "" {
+		return nil, fmt.Errorf("cron: empty expression")
+	}
+
+	// Fixed interval, e.g. "@every 1h30m"?
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+
+		if err != nil {
+			return nil, fmt.Errorf("cron: invalid interval in %q", spec)
+		} else if d < MinInterval {
+			return nil, fmt.Errorf("cron: interval in %q must be at least %s", spec, MinInterval)
+		}
+
+		s.every = d
+
+		return s, nil
+	}
+
+	expr := spec
+
+	if strings.HasPrefix(expr, "@") {
+		if e, ok := Descriptors[strings.ToLower(expr)]; ok {
+			expr = e
+		} else {
+			return nil, fmt.Errorf("cron: unknown descriptor %q", spec)
+		}
+	}
+
+	fields := strings.Fields(expr)
+
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields in %q, found %d", spec, len(fields))
+	}
+
+	var err error
+
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	} else if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	} else if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	} else if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	} else if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	// Sunday may be specified as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	s.anyDom = strings.HasPrefix(fields[2], "*") || fields[2] == "?"
+	s.anyDow = strings.HasPrefix(fields[4], "*") || fields[4] == "?"
+
+	return s, nil
+}
+
+// MustParse parses the cron expression and panics if it is invalid.
+func MustParse(spec string) *Schedule {
+	s, err := Parse(spec)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Every returns a schedule that runs at a fixed interval.
+func Every(d time.Duration) *Schedule {
+	if d < MinInterval {
+		d = MinInterval
+	}
+
+	return &Schedule{spec: "@every " + d.String(), every: d}
+}
+
+// String returns the cron expression.
+func (s *Schedule) String() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.spec
+}
+
+// Next returns the next run time after t, or the zero time if there is none within five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s == nil {
+		return time.Time{}
+	} else if s.every > 0 {
+		return t.Add(s.every).Truncate(time.Second)
+	}
+
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay checks if the day matches the day of month and day of week fields. If both fields are restricted,
+// either may match, as with the standard cron implementation.
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.anyDom || s.anyDow {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// parse returns the bit set of values matching a comma separated list of values, ranges, and steps.
+func (f field) parse(expr string) (bits uint64, err error) {
+	for _, item := range strings.Split(expr, ",") {
+		step := 1
+		lo, hi := f.min, f.max
+		hasStep := false
+
+		if i := strings.Index(item, "/"); i >= 0 {
+			hasStep = true
+
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("cron: invalid step in %s field %q", f.name, expr)
+			}
+
+			item = item[:i]
+		}
+
+		switch {
+		case item == "*" || item == "?":
+			// Full range.
+		case strings.Contains(item, "-"):
+			i := strings.Index(item, "-")
+
+			if lo, err = f.value(item[:i]); err != nil {
+				return 0, err
+			} else if hi, err = f.value(item[i+1:]); err != nil {
+				return 0, err
+			}
+		default:
+			if lo, err = f.value(item); err != nil {
+				return 0, err
+			}
+
+			// A single value with a step, e.g. "5/15", runs until the end of the range.
+			if !hasStep {
+				hi = lo
+			}
+		}
+
+		if lo > hi {
+			return 0, fmt.Errorf("cron: invalid range in %s field %q", f.name, expr)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// value parses a single number or name and checks if it is within the valid range.
+func (f field) value(s string) (int, error) {
+	s = strings.ToLower(s)
+
+	for i, name := range f.names {
+		if name != "" && name == s {
+			return i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("cron: invalid %s %q", f.name, s)
+	}
+
+	return v, nil
+}
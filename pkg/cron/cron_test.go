@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		for _, spec := range []string{"* * * * *", "30 2 * * 1-5", "*/15 * * * *", "0 0 1,15 * *", "0 12 * JAN-MAR sun", "5/10 * * * 7", "@daily", "@Hourly", "@every 90m"} {
+			s, err := Parse(spec)
+
+			if assert.NoError(t, err, spec) {
+				assert.Equal(t, spec, s.String())
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "@often", "@every 10s", "@every soon"} {
+			s, err := Parse(spec)
+
+			assert.Error(t, err, spec)
+			assert.Nil(t, s)
+		}
+	})
+}
+
+func TestSchedule_Next(t *testing.T) {
+	start := time.Date(2023, 3, 15, 10, 7, 30, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2023, 3, 15, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2023, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2023, 3, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * sat", time.Date(2023, 3, 18, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2023, 3, 19, 12, 0, 0, 0, time.UTC)},
+		{"0 12 1 * mon", time.Date(2023, 3, 20, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2023, 3, 19, 0, 0, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2023, 3, 15, 11, 7, 30, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.next, MustParse(tt.spec).Next(start), tt.spec)
+	}
+
+	t.Run("Impossible", func(t *testing.T) {
+		assert.True(t, MustParse("0 0 31 2 *").Next(start).IsZero())
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var s *Schedule
+		assert.True(t, s.Next(start).IsZero())
+		assert.Equal(t, "", s.String())
+	})
+}
+
+func TestEvery(t *testing.T) {
+	assert.Equal(t, "@every 15m0s", Every(15*time.Minute).String())
+	assert.Equal(t, "@every 1m0s", Every(time.Second).String())
+}
@@ -0,0 +1,72 @@
+package crypt
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// EncryptFile encrypts the source file and saves the result in the destination file.
+func EncryptFile(src, dst, passphrase string) error {
+	return convertFile(src, dst, passphrase, Encrypt)
+}
+
+// DecryptFile decrypts the source file and saves the plaintext in the destination file.
+func DecryptFile(src, dst, passphrase string) error {
+	return convertFile(src, dst, passphrase, Decrypt)
+}
+
+// FileEncrypted checks if the file starts with the header of encrypted data.
+func FileEncrypted(fileName string) bool {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return false
+	}
+
+	defer f.Close()
+
+	header := make([]byte, len(Magic))
+
+	if _, err = io.ReadFull(f, header); err != nil {
+		return false
+	}
+
+	return Encrypted(header)
+}
+
+// convertFile writes the result to a temporary file first, so that the destination file
+// is not replaced with incomplete data if the conversion fails.
+func convertFile(src, dst, passphrase string, convert func(io.Writer, io.Reader, string) error) (err error) {
+	in, err := os.Open(src)
+
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	if err = os.MkdirAll(filepath.Dir(dst), fs.ModeDir); err != nil {
+		return err
+	}
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fs.ModeFile)
+
+	if err != nil {
+		return err
+	}
+
+	if err = convert(out, in, passphrase); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return err
+	} else if err = out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, dst)
+}
@@ -0,0 +1,44 @@
+package crypt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "backup.sql")
+	enc := src + Ext
+	dec := filepath.Join(dir, "restored", "backup.sql")
+
+	if err := os.WriteFile(src, []byte("CREATE TABLE photos;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EncryptFile(src, enc, "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, FileEncrypted(enc))
+	assert.False(t, FileEncrypted(src))
+	assert.False(t, FileEncrypted(filepath.Join(dir, "missing.enc")))
+
+	assert.Error(t, DecryptFile(enc, dec, "wrong"))
+	assert.NoFileExists(t, dec)
+	assert.NoFileExists(t, dec+".tmp")
+
+	if err := DecryptFile(enc, dec, "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(dec)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "CREATE TABLE photos;", string(data))
+}
@@ -0,0 +1,179 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Magic is written at the beginning of encrypted data, followed by the salt.
+var Magic = []byte("photoprism-enc/v1\n")
+
+// ChunkSize is the size of the plaintext chunks that are encrypted and authenticated separately,
+// so that large files can be processed without reading them into memory.
+const ChunkSize = 64 * 1024
+
+// SaltSize is the size of the random salt used to derive the key from the passphrase.
+const SaltSize = 16
+
+// Key derivation parameters, see https://pkg.go.dev/golang.org/x/crypto/scrypt.
+const (
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+	keySize = 32
+)
+
+// newAEAD returns an AES-256-GCM cipher with a key derived from the passphrase and salt.
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, ErrEmpty
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of a chunk, which consists of the chunk counter and a flag that marks the
+// last chunk, so that reordered, duplicated, or truncated chunks cannot be decrypted. Reusing nonces is
+// not a problem because every file is encrypted with a different key.
+func chunkNonce(size int, counter uint64, last bool) []byte {
+	nonce := make([]byte, size)
+	binary.BigEndian.PutUint64(nonce[size-9:size-1], counter)
+
+	if last {
+		nonce[size-1] = 1
+	}
+
+	return nonce
+}
+
+// Encrypt reads plaintext from src and writes it to dst, encrypted with a key derived from the passphrase.
+func Encrypt(dst io.Writer, src io.Reader, passphrase string) error {
+	salt := make([]byte, SaltSize)
+
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(passphrase, salt)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = dst.Write(Magic); err != nil {
+		return err
+	} else if _, err = dst.Write(salt); err != nil {
+		return err
+	}
+
+	buf := make([]byte, ChunkSize)
+	next := make([]byte, ChunkSize)
+	out := make([]byte, 0, ChunkSize+aead.Overhead())
+
+	n, readErr := io.ReadFull(src, buf)
+
+	for counter := uint64(0); ; counter++ {
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return readErr
+		}
+
+		// Read ahead to find out if this is the last chunk.
+		last := readErr != nil
+		nextN, nextErr := 0, error(nil)
+
+		if !last {
+			if nextN, nextErr = io.ReadFull(src, next); nextN == 0 && nextErr == io.EOF {
+				last = true
+			}
+		}
+
+		out = aead.Seal(out[:0], chunkNonce(aead.NonceSize(), counter, last), buf[:n], nil)
+
+		if _, err = dst.Write(out); err != nil {
+			return err
+		} else if last {
+			return nil
+		}
+
+		buf, next = next, buf
+		n, readErr = nextN, nextErr
+	}
+}
+
+// Decrypt reads encrypted data from src and writes the plaintext to dst.
+func Decrypt(dst io.Writer, src io.Reader, passphrase string) error {
+	header := make([]byte, len(Magic)+SaltSize)
+
+	if _, err := io.ReadFull(src, header); err != nil {
+		return ErrFormat
+	} else if !bytes.Equal(header[:len(Magic)], Magic) {
+		return ErrFormat
+	}
+
+	aead, err := newAEAD(passphrase, header[len(Magic):])
+
+	if err != nil {
+		return err
+	}
+
+	size := ChunkSize + aead.Overhead()
+	buf := make([]byte, size)
+	next := make([]byte, size)
+	out := make([]byte, 0, ChunkSize)
+
+	n, readErr := io.ReadFull(src, buf)
+
+	for counter := uint64(0); ; counter++ {
+		if readErr == io.EOF {
+			return ErrTruncated
+		} else if readErr != nil && readErr != io.ErrUnexpectedEOF {
+			return readErr
+		}
+
+		// Read ahead to find out if this is the last chunk.
+		last := readErr != nil
+		nextN, nextErr := 0, error(nil)
+
+		if !last {
+			if nextN, nextErr = io.ReadFull(src, next); nextN == 0 && nextErr == io.EOF {
+				last = true
+			}
+		}
+
+		if out, err = aead.Open(out[:0], chunkNonce(aead.NonceSize(), counter, last), buf[:n], nil); err != nil {
+			return ErrPassphrase
+		}
+
+		if _, err = dst.Write(out); err != nil {
+			return err
+		} else if last {
+			return nil
+		}
+
+		buf, next = next, buf
+		n, readErr = nextN, nextErr
+	}
+}
+
+// Encrypted checks if the data starts with the header of encrypted data.
+func Encrypted(data []byte) bool {
+	return bytes.HasPrefix(data, Magic)
+}
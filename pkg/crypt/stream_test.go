@@ -0,0 +1,75 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	sizes := []int{0, 1, 1000, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3 * ChunkSize}
+
+	for _, size := range sizes {
+		plain := make([]byte, size)
+
+		if _, err := rand.Read(plain); err != nil {
+			t.Fatal(err)
+		}
+
+		var enc bytes.Buffer
+
+		if err := Encrypt(&enc, bytes.NewReader(plain), "secret"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, Encrypted(enc.Bytes()))
+
+		// Short inputs may appear in the ciphertext by chance.
+		if size >= 16 {
+			assert.False(t, bytes.Contains(enc.Bytes(), plain), "size %d", size)
+		}
+
+		var dec bytes.Buffer
+
+		if err := Decrypt(&dec, bytes.NewReader(enc.Bytes()), "secret"); err != nil {
+			t.Fatalf("size %d: %s", size, err)
+		}
+
+		assert.True(t, bytes.Equal(plain, dec.Bytes()), "size %d", size)
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	plain := bytes.Repeat([]byte("photoprism"), ChunkSize/5)
+
+	var enc bytes.Buffer
+
+	if err := Encrypt(&enc, bytes.NewReader(plain), "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	data := enc.Bytes()
+
+	t.Run("WrongPassphrase", func(t *testing.T) {
+		assert.Equal(t, ErrPassphrase, Decrypt(&bytes.Buffer{}, bytes.NewReader(data), "wrong"))
+	})
+	t.Run("EmptyPassphrase", func(t *testing.T) {
+		assert.Equal(t, ErrEmpty, Decrypt(&bytes.Buffer{}, bytes.NewReader(data), ""))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		header := len(Magic) + SaltSize
+		assert.Equal(t, ErrTruncated, Decrypt(&bytes.Buffer{}, bytes.NewReader(data[:header]), "secret"))
+		assert.Error(t, Decrypt(&bytes.Buffer{}, bytes.NewReader(data[:header+ChunkSize+16]), "secret"))
+		assert.Error(t, Decrypt(&bytes.Buffer{}, bytes.NewReader(data[:len(data)-1]), "secret"))
+	})
+	t.Run("Modified", func(t *testing.T) {
+		modified := append([]byte{}, data...)
+		modified[len(modified)-20] ^= 1
+		assert.Equal(t, ErrPassphrase, Decrypt(&bytes.Buffer{}, bytes.NewReader(modified), "secret"))
+	})
+	t.Run("Plaintext", func(t *testing.T) {
+		assert.Equal(t, ErrFormat, Decrypt(&bytes.Buffer{}, bytes.NewReader(plain), "secret"))
+	})
+}
@@ -0,0 +1,16 @@
+package fs
+
+// DiskSpace represents the free and total space of a file system in bytes.
+type DiskSpace struct {
+	Free  uint64 `json:"free"`
+	Total uint64 `json:"total"`
+}
+
+// Used returns the used space in bytes.
+func (d DiskSpace) Used() uint64 {
+	if d.Free > d.Total {
+		return 0
+	}
+
+	return d.Total - d.Free
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd
+
+package fs
+
+import (
+	"fmt"
+)
+
+// Space returns the free and total space of the file system that contains the specified path,
+// which is not supported on this operating system.
+func Space(path string) (result DiskSpace, err error) {
+	return result, fmt.Errorf("disk space of %s is unknown", path)
+}
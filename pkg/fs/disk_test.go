@@ -0,0 +1,23 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpace(t *testing.T) {
+	t.Run("TempDir", func(t *testing.T) {
+		result, err := Space(t.TempDir())
+
+		assert.NoError(t, err)
+		assert.Greater(t, result.Total, uint64(0))
+		assert.LessOrEqual(t, result.Free, result.Total)
+		assert.Equal(t, result.Total-result.Free, result.Used())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Space("testdata/xxx")
+
+		assert.Error(t, err)
+	})
+}
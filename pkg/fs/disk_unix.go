@@ -0,0 +1,21 @@
+//go:build linux || darwin || freebsd
+
+package fs
+
+import (
+	"syscall"
+)
+
+// Space returns the free and total space of the file system that contains the specified path.
+func Space(path string) (result DiskSpace, err error) {
+	var stat syscall.Statfs_t
+
+	if err = syscall.Statfs(path, &stat); err != nil {
+		return result, err
+	}
+
+	result.Free = uint64(stat.Bavail) * uint64(stat.Bsize)
+	result.Total = uint64(stat.Blocks) * uint64(stat.Bsize)
+
+	return result, nil
+}
@@ -16,3 +16,14 @@ func (s Status) Exists() bool {
 func (s Status) Processed() bool {
 	return s >= Processed
 }
+
+// Processed returns the number of processed files.
+func (d Done) Processed() (count int) {
+	for _, s := range d {
+		if s.Processed() {
+			count++
+		}
+	}
+
+	return count
+}
@@ -12,3 +12,10 @@ func TestProcessed(t *testing.T) {
 		assert.False(t, Found.Processed())
 	})
 }
+
+func TestDone_Processed(t *testing.T) {
+	done := Done{"a.jpg": Found, "b.jpg": Processed, "c.jpg": Processed}
+
+	assert.Equal(t, 2, done.Processed())
+	assert.Equal(t, 0, Done{}.Processed())
+}
@@ -3,45 +3,136 @@ package fs
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 )
 
 type IgnoreLogFunc func(fileName string)
 
-// IgnoreItem represents a file name pattern to be ignored.
+// IgnoreItem represents a file name pattern to be ignored, see https://git-scm.com/docs/gitignore.
+//
+// Patterns starting with "!" include files that were excluded by previous patterns, patterns ending
+// with "/" only match directories, and patterns starting with "/" or containing "/" are relative to the
+// directory of the ignore file. Files can optionally be filtered by size and type, e.g. "*.mp4 size>2GB".
 type IgnoreItem struct {
 	Dir     string
 	Pattern string
+	Negate  bool
+	DirOnly bool
+	Rel     bool
+	MinSize int64
+	MaxSize int64
+	Types   []string
 }
 
 // NewIgnoreItem returns a pointer to a new IgnoreItem instance.
 func NewIgnoreItem(dir, pattern string, caseSensitive bool) IgnoreItem {
-	if caseSensitive {
-		return IgnoreItem{Dir: dir + PathSeparator, Pattern: pattern}
-	} else {
-		return IgnoreItem{Dir: strings.ToLower(dir) + PathSeparator, Pattern: strings.ToLower(pattern)}
+	i := IgnoreItem{Dir: dir + PathSeparator, MinSize: -1, MaxSize: -1}
+
+	// Parse optional size and type conditions at the end of the line.
+	pattern = i.parseConditions(strings.TrimSpace(pattern))
+
+	if strings.HasPrefix(pattern, "!") {
+		i.Negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, "\\!") || strings.HasPrefix(pattern, "\\#") {
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		i.DirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	if strings.HasPrefix(pattern, "/") {
+		i.Rel = true
+		pattern = strings.TrimLeft(pattern, "/")
+	} else if strings.Contains(pattern, "/") {
+		i.Rel = true
 	}
+
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	i.Pattern = filepath.FromSlash(pattern)
+
+	if !caseSensitive {
+		i.Dir = strings.ToLower(i.Dir)
+		i.Pattern = strings.ToLower(i.Pattern)
+	}
+
+	return i
 }
 
-// Ignore returns true if the file name "base" in the directory "dir" should be ignored.
+// Ignore returns true if the file name "base" in the directory "dir" matches the pattern.
 func (i IgnoreItem) Ignore(dir, base string) bool {
 	if !strings.HasPrefix(dir+PathSeparator, i.Dir) {
 		// different directory prefix: don't look any further
 		return false
-	} else if i.Pattern == base {
+	} else if i.Pattern == base && !i.Rel {
 		// file name is the same as pattern (no wildcard)
 		return true
 	}
 
-	if strings.ContainsRune(i.Pattern, filepath.Separator) {
-		base = filepath.Join(RelName(dir, i.Dir), base)
+	if i.Rel {
+		base = filepath.Join(strings.TrimPrefix(dir+PathSeparator, i.Dir), base)
 	}
 
-	if ignore, err := filepath.Match(i.Pattern, base); ignore && err == nil {
+	return MatchPattern(i.Pattern, base)
+}
+
+// Conditional checks if the item has conditions that require file information.
+func (i IgnoreItem) Conditional() bool {
+	return i.DirOnly || i.MinSize >= 0 || i.MaxSize >= 0 || len(i.Types) > 0
+}
+
+// Applies checks if the file meets the size, type, and directory conditions of the item.
+func (i IgnoreItem) Applies(fileName string) bool {
+	if !i.Conditional() {
 		return true
 	}
 
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return false
+	} else if info.IsDir() {
+		return i.DirOnly && i.MinSize < 0 && i.MaxSize < 0 && len(i.Types) == 0
+	} else if i.DirOnly {
+		return false
+	}
+
+	if i.MinSize >= 0 && info.Size() <= i.MinSize {
+		return false
+	} else if i.MaxSize >= 0 && info.Size() >= i.MaxSize {
+		return false
+	}
+
+	if len(i.Types) == 0 {
+		return true
+	}
+
+	fileType := FileType(fileName).String()
+	mimeType := ""
+
+	for _, t := range i.Types {
+		if t == fileType {
+			return true
+		} else if !strings.Contains(t, "/") {
+			if mimeType == "" {
+				mimeType = MimeType(fileName)
+			}
+
+			if strings.HasPrefix(mimeType, t+"/") {
+				return true
+			}
+		} else if t == MimeType(fileName) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -144,16 +235,23 @@ func (l *IgnoreList) Ignore(fileName string) bool {
 		return true
 	}
 
+	// The last matching pattern decides, so that files can be included again with "!".
+	ignored := false
+
 	for _, item := range l.items {
-		if item.Ignore(dir, base) {
-			l.ignoredFiles = append(l.ignoredFiles, fileName)
+		if ignored == item.Negate && item.Ignore(dir, base) && item.Applies(fileName) {
+			ignored = !item.Negate
+		}
+	}
 
-			if l.Log != nil {
-				l.Log(fileName)
-			}
+	if ignored {
+		l.ignoredFiles = append(l.ignoredFiles, fileName)
 
-			return true
+		if l.Log != nil {
+			l.Log(fileName)
 		}
+
+		return true
 	}
 
 	if l.ignoreHidden && FileNameHidden(fileName) {
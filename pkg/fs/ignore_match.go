@@ -0,0 +1,113 @@
+package fs
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// MatchPattern checks if the name matches the shell file name pattern, where "**" matches any number of folders.
+func MatchPattern(pattern, name string) bool {
+	if !strings.Contains(pattern, "**") {
+		match, err := filepath.Match(pattern, name)
+		return match && err == nil
+	}
+
+	return matchSegments(strings.Split(pattern, PathSeparator), strings.Split(name, PathSeparator))
+}
+
+// matchSegments matches the path segments of a name against the pattern segments.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+
+			if len(pattern) == 0 {
+				return true
+			}
+
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+
+			return false
+		} else if len(name) == 0 {
+			return false
+		} else if match, err := filepath.Match(pattern[0], name[0]); !match || err != nil {
+			return false
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// parseConditions removes size and type conditions such as "size>2GB" and "type:video,raw"
+// from the end of the pattern and adds them to the item.
+func (i *IgnoreItem) parseConditions(pattern string) string {
+	for pattern != "" {
+		pos := strings.LastIndexAny(pattern, " \t")
+		token := pattern[pos+1:]
+
+		if !i.parseCondition(token) {
+			break
+		} else if pos < 0 {
+			return ""
+		}
+
+		pattern = strings.TrimSpace(pattern[:pos])
+	}
+
+	return pattern
+}
+
+// parseCondition adds a single size or type condition to the item and returns false if it is invalid.
+func (i *IgnoreItem) parseCondition(token string) bool {
+	token = strings.ToLower(token)
+
+	if strings.HasPrefix(token, "type:") {
+		for _, t := range strings.Split(token[5:], ",") {
+			if t = strings.TrimPrefix(strings.TrimSpace(t), "."); t != "" {
+				i.Types = append(i.Types, t)
+			}
+		}
+
+		return len(i.Types) > 0
+	} else if !strings.HasPrefix(token, "size") {
+		return false
+	}
+
+	op := token[4:]
+
+	for _, prefix := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(op, prefix) {
+			continue
+		}
+
+		size, err := humanize.ParseBytes(op[len(prefix):])
+
+		if err != nil {
+			return false
+		}
+
+		switch prefix {
+		case ">=":
+			i.MinSize = int64(size) - 1
+		case ">":
+			i.MinSize = int64(size)
+		case "<=":
+			i.MaxSize = int64(size) + 1
+		case "<":
+			i.MaxSize = int64(size)
+		}
+
+		return true
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -171,3 +172,103 @@ func TestIgnoreList_Dir(t *testing.T) {
 		assert.Nil(t, ignoreList.Dir("./testdata/directory"))
 	})
 }
+
+func TestNewIgnoreItem_Rules(t *testing.T) {
+	t.Run("Negate", func(t *testing.T) {
+		item := NewIgnoreItem("testdata", "!Keep.jpg", false)
+		assert.True(t, item.Negate)
+		assert.Equal(t, "keep.jpg", item.Pattern)
+	})
+	t.Run("Escaped", func(t *testing.T) {
+		item := NewIgnoreItem("testdata", "\\!important.jpg", true)
+		assert.False(t, item.Negate)
+		assert.Equal(t, "!important.jpg", item.Pattern)
+	})
+	t.Run("DirOnly", func(t *testing.T) {
+		item := NewIgnoreItem("testdata", "/export/", true)
+		assert.True(t, item.DirOnly)
+		assert.True(t, item.Rel)
+		assert.Equal(t, "export", item.Pattern)
+	})
+	t.Run("Conditions", func(t *testing.T) {
+		item := NewIgnoreItem("testdata", "Video Dumps/*.MP4 size>=2GB type:mp4,.mov", false)
+		assert.Equal(t, filepath.FromSlash("video dumps/*.mp4"), item.Pattern)
+		assert.True(t, item.Rel)
+		assert.Equal(t, int64(1999999999), item.MinSize)
+		assert.Equal(t, int64(-1), item.MaxSize)
+		assert.Equal(t, []string{"mp4", "mov"}, item.Types)
+	})
+	t.Run("OnlyConditions", func(t *testing.T) {
+		item := NewIgnoreItem("testdata", "size<1KiB", true)
+		assert.Equal(t, "*", item.Pattern)
+		assert.Equal(t, int64(1024), item.MaxSize)
+	})
+	t.Run("InvalidCondition", func(t *testing.T) {
+		item := NewIgnoreItem("testdata", "foo size>lots", true)
+		assert.Equal(t, "foo size>lots", item.Pattern)
+		assert.False(t, item.Conditional())
+	})
+}
+
+func TestMatchPattern(t *testing.T) {
+	assert.True(t, MatchPattern("*.txt", "foo.txt"))
+	assert.False(t, MatchPattern("*.txt", "foo.jpg"))
+	assert.True(t, MatchPattern(filepath.FromSlash("**/tmp"), "tmp"))
+	assert.True(t, MatchPattern(filepath.FromSlash("**/tmp"), filepath.FromSlash("a/b/tmp")))
+	assert.True(t, MatchPattern(filepath.FromSlash("export/**"), filepath.FromSlash("export/a/b.jpg")))
+	assert.True(t, MatchPattern(filepath.FromSlash("a/**/*.jpg"), filepath.FromSlash("a/x/y/z.jpg")))
+	assert.False(t, MatchPattern(filepath.FromSlash("a/**/*.jpg"), filepath.FromSlash("b/x/z.jpg")))
+	assert.False(t, MatchPattern("[", "["))
+}
+
+func TestIgnoreList_Rules(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]int{
+		"export/photo.jpg":      10,
+		"videos/small.mp4":      10,
+		"videos/huge.mp4":       4096,
+		"videos/huge-keep.mp4":  4096,
+		"photos/tmp/a.jpg":      10,
+		"photos/2020/b.jpg":     10,
+		"photos/2020/b.jpg.tmp": 10,
+		"photos/2020/keep.tmp":  10,
+		"notes.txt":             5000,
+	}
+
+	for name, size := range files {
+		fileName := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(fileName), ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, make([]byte, size), ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rules := "# Temporary exports.\n/export/\n**/tmp/\n*.tmp\n!keep.tmp\n*.mp4 size>2KiB\n!*-keep.mp4\nsize>4KB type:txt\n"
+
+	if err := os.WriteFile(filepath.Join(dir, IgnoreFile), []byte(rules), ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	list := NewIgnoreList(IgnoreFile, true, true)
+
+	assert.NoError(t, list.Dir(dir))
+
+	ignored := func(name string) bool {
+		return list.Ignore(filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	assert.True(t, ignored("export"))
+	assert.False(t, ignored("export.jpg"))
+	assert.False(t, ignored("videos"))
+	assert.False(t, ignored("videos/small.mp4"))
+	assert.True(t, ignored("videos/huge.mp4"))
+	assert.False(t, ignored("videos/huge-keep.mp4"))
+	assert.True(t, ignored("photos/tmp"))
+	assert.False(t, ignored("photos/2020/b.jpg"))
+	assert.True(t, ignored("photos/2020/b.jpg.tmp"))
+	assert.False(t, ignored("photos/2020/keep.tmp"))
+	assert.True(t, ignored("notes.txt"))
+}
@@ -0,0 +1,116 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ObjectName returns the file name of a content-addressed object, e.g. "objects/ab/cd/abcdef0123...".
+func ObjectName(dir, hash string) string {
+	hash = strings.ToLower(hash)
+
+	if len(hash) < 4 {
+		return filepath.Join(dir, hash)
+	}
+
+	return filepath.Join(dir, hash[0:2], hash[2:4], hash)
+}
+
+// LinkObject stores a file as content-addressed object in dir, unless an object with the same hash
+// already exists, and replaces dest with a hard link to it. If move is true, the source file is removed.
+func LinkObject(src, dest, dir, hash string, move bool) (err error) {
+	if hash == "" {
+		return fmt.Errorf("link: unknown hash of %s", filepath.Base(src))
+	}
+
+	obj := ObjectName(dir, hash)
+
+	if err = os.MkdirAll(filepath.Dir(obj), ModeDir); err != nil {
+		return err
+	}
+
+	// Add the file to the object store, without copying it if possible.
+	if !FileExists(obj) {
+		if err = os.Link(src, obj); err == nil {
+			// Done.
+		} else if err = Copy(src, obj); err != nil {
+			_ = os.Remove(obj)
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dest), ModeDir); err != nil {
+		return err
+	}
+
+	// Replace the destination, unless it is already a link to the object.
+	if !sameFile(dest, obj) {
+		tmp := filepath.Join(filepath.Dir(dest), "."+filepath.Base(dest)+".link")
+
+		_ = os.Remove(tmp)
+
+		// Fall back to a copy if the destination is on a different file system.
+		if err = os.Link(obj, tmp); err == nil {
+			// Done.
+		} else if err = Copy(obj, tmp); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+
+		if err = os.Rename(tmp, dest); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+
+	if move && src != dest {
+		return os.Remove(src)
+	}
+
+	return nil
+}
+
+// PruneObjects removes content-addressed objects that are no longer linked to any other file name.
+func PruneObjects(dir string) (removed int, size int64, err error) {
+	if !PathExists(dir) {
+		return 0, 0, nil
+	}
+
+	err = filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !info.Mode().IsRegular() {
+			return err
+		}
+
+		if n, linkErr := LinkCount(fileName); linkErr != nil || n != 1 {
+			return linkErr
+		} else if err = os.Remove(fileName); err != nil {
+			return err
+		}
+
+		removed++
+		size += info.Size()
+
+		return nil
+	})
+
+	return removed, size, err
+}
+
+// sameFile checks if both file names refer to the same file.
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+
+	if err != nil {
+		return false
+	}
+
+	infoB, err := os.Stat(b)
+
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(infoA, infoB)
+}
@@ -0,0 +1,105 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectName(t *testing.T) {
+	t.Run("Hash", func(t *testing.T) {
+		assert.Equal(t, filepath.Join("objects", "51", "6c", "516cb1fefbfd9fa66f1db50b94503a480cee30db"), ObjectName("objects", "516CB1FEFBFD9FA66F1DB50B94503A480CEE30DB"))
+	})
+	t.Run("Short", func(t *testing.T) {
+		assert.Equal(t, filepath.Join("objects", "51"), ObjectName("objects", "51"))
+	})
+}
+
+func TestLinkObject(t *testing.T) {
+	dir := t.TempDir()
+	objects := filepath.Join(dir, ".photoprism", "objects")
+	src := filepath.Join(dir, "import", "test.jpg")
+	hash := Hash("testdata/test.jpg")
+
+	if err := Copy("testdata/test.jpg", src); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Copy", func(t *testing.T) {
+		dest := filepath.Join(dir, "2020", "test.jpg")
+
+		assert.NoError(t, LinkObject(src, dest, objects, hash, false))
+		assert.True(t, FileExists(src))
+		assert.True(t, sameFile(dest, ObjectName(objects, hash)))
+
+		n, err := LinkCount(dest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+	t.Run("Duplicate", func(t *testing.T) {
+		dest := filepath.Join(dir, "2021", "copy.jpg")
+
+		assert.NoError(t, LinkObject(src, dest, objects, hash, false))
+		assert.True(t, sameFile(dest, filepath.Join(dir, "2020", "test.jpg")))
+	})
+	t.Run("Move", func(t *testing.T) {
+		dest := filepath.Join(dir, "moved.jpg")
+
+		assert.NoError(t, LinkObject(src, dest, objects, hash, true))
+		assert.False(t, FileExists(src))
+		assert.Equal(t, hash, Hash(dest))
+	})
+	t.Run("InPlace", func(t *testing.T) {
+		dest := filepath.Join(dir, "inplace.jpg")
+
+		if err := Copy("testdata/test.jpg", dest); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, sameFile(dest, ObjectName(objects, hash)))
+		assert.NoError(t, LinkObject(dest, dest, objects, hash, true))
+		assert.True(t, FileExists(dest))
+		assert.True(t, sameFile(dest, ObjectName(objects, hash)))
+	})
+	t.Run("UnknownHash", func(t *testing.T) {
+		assert.Error(t, LinkObject(src, filepath.Join(dir, "error.jpg"), objects, "", false))
+	})
+}
+
+func TestPruneObjects(t *testing.T) {
+	dir := t.TempDir()
+	objects := filepath.Join(dir, "objects")
+	dest := filepath.Join(dir, "test.jpg")
+	hash := Hash("testdata/test.jpg")
+
+	if err := Copy("testdata/test.jpg", dest); err != nil {
+		t.Fatal(err)
+	} else if err = LinkObject(dest, dest, objects, hash, false); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, size, err := PruneObjects(objects)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	assert.Equal(t, int64(0), size)
+
+	if err = os.Remove(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, size, err = PruneObjects(objects)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Greater(t, size, int64(0))
+	assert.False(t, FileExists(ObjectName(objects, hash)))
+
+	removed, _, err = PruneObjects(filepath.Join(dir, "missing"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
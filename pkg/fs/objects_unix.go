@@ -0,0 +1,24 @@
+//go:build !windows
+
+package fs
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// LinkCount returns the number of hard links to a file.
+func LinkCount(fileName string) (int, error) {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int(stat.Nlink), nil
+	}
+
+	return 0, fmt.Errorf("link count of %s is unknown", fileName)
+}
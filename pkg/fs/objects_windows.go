@@ -0,0 +1,12 @@
+//go:build windows
+
+package fs
+
+import (
+	"fmt"
+)
+
+// LinkCount returns the number of hard links to a file, which is not supported on Windows.
+func LinkCount(fileName string) (int, error) {
+	return 0, fmt.Errorf("link count of %s is unknown", fileName)
+}
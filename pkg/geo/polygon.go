@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Polygon represents an area enclosed by a list of positions, e.g. the outline of a property.
+type Polygon []Position
+
+// ParsePolygon parses a polygon in the format "lat,lng;lat,lng;lat,lng" with at least three positions.
+func ParsePolygon(s string) (p Polygon, err error) {
+	for _, pos := range strings.Split(strings.TrimSpace(s), ";") {
+		if pos = strings.TrimSpace(pos); pos == "" {
+			continue
+		}
+
+		values := strings.Split(pos, ",")
+
+		if len(values) != 2 {
+			return Polygon{}, fmt.Errorf("invalid polygon position %s", strconv.Quote(pos))
+		}
+
+		lat, errLat := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+		lng, errLng := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+		if errLat != nil || errLng != nil || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return Polygon{}, fmt.Errorf("invalid polygon position %s", strconv.Quote(pos))
+		}
+
+		p = append(p, Position{Lat: lat, Lng: lng})
+	}
+
+	if len(p) < 3 {
+		return Polygon{}, fmt.Errorf("polygon must have at least three positions")
+	}
+
+	return p, nil
+}
+
+// Contains tests if the coordinates are within the polygon, based on the even-odd rule.
+func (p Polygon) Contains(lat, lng float64) bool {
+	if len(p) < 3 || lat == 0 && lng == 0 {
+		return false
+	}
+
+	inside := false
+
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+
+		if (a.Lat > lat) != (b.Lat > lat) && lng < (b.Lng-a.Lng)*(lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// Bounds returns the minimum and maximum coordinates of a box that contains the polygon.
+func (p Polygon) Bounds() (latMin, latMax, lngMin, lngMax float64) {
+	for i, pos := range p {
+		if i == 0 || pos.Lat < latMin {
+			latMin = pos.Lat
+		}
+
+		if i == 0 || pos.Lat > latMax {
+			latMax = pos.Lat
+		}
+
+		if i == 0 || pos.Lng < lngMin {
+			lngMin = pos.Lng
+		}
+
+		if i == 0 || pos.Lng > lngMax {
+			lngMax = pos.Lng
+		}
+	}
+
+	return latMin, latMax, lngMin, lngMax
+}
+
+// String returns the polygon as string in the format accepted by ParsePolygon.
+func (p Polygon) String() string {
+	values := make([]string, len(p))
+
+	for i, pos := range p {
+		values[i] = fmt.Sprintf("%f,%f", pos.Lat, pos.Lng)
+	}
+
+	return strings.Join(values, ";")
+}
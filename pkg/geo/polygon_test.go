@@ -0,0 +1,69 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolygon(t *testing.T) {
+	t.Run("Triangle", func(t *testing.T) {
+		p, err := ParsePolygon("52.5, 13.3; 52.6,13.4;52.5,13.5;")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, p, 3)
+		assert.Equal(t, 52.6, p[1].Lat)
+		assert.Equal(t, 13.4, p[1].Lng)
+		assert.Equal(t, "52.500000,13.300000;52.600000,13.400000;52.500000,13.500000", p.String())
+	})
+	t.Run("TooFewPositions", func(t *testing.T) {
+		_, err := ParsePolygon("52.5,13.3;52.6,13.4")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidPosition", func(t *testing.T) {
+		_, err := ParsePolygon("52.5,13.3;52.6;52.5,13.5")
+		assert.Error(t, err)
+
+		_, err = ParsePolygon("52.5,13.3;95,13.4;52.5,13.5")
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParsePolygon("")
+		assert.Error(t, err)
+	})
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	// L-shaped area, so that the bounding box contains positions outside the polygon.
+	p, err := ParsePolygon("50,10;50,12;51,12;51,11;52,11;52,10")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, p.Contains(50.5, 10.5))
+	assert.True(t, p.Contains(50.5, 11.5))
+	assert.True(t, p.Contains(51.5, 10.5))
+	assert.False(t, p.Contains(51.5, 11.5))
+	assert.False(t, p.Contains(49.5, 10.5))
+	assert.False(t, p.Contains(0, 0))
+	assert.False(t, Polygon{}.Contains(50.5, 10.5))
+}
+
+func TestPolygon_Bounds(t *testing.T) {
+	p, err := ParsePolygon("50,10;50,12;51,12;51,11;52,11;52,10")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latMin, latMax, lngMin, lngMax := p.Bounds()
+
+	assert.Equal(t, 50.0, latMin)
+	assert.Equal(t, 52.0, latMax)
+	assert.Equal(t, 10.0, lngMin)
+	assert.Equal(t, 12.0, lngMax)
+}
@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ZoneRadius is the default zone radius in meters.
+var ZoneRadius = 250.0
+
+// Zone represents a circular area around a position, e.g. the home of a user.
+type Zone struct {
+	Lat    float64
+	Lng    float64
+	Radius float64 // Radius in meters.
+}
+
+// Zones represents a list of zones.
+type Zones []Zone
+
+// ParseZone parses a zone in the format "lat,lng" or "lat,lng,radius", with the radius in meters.
+func ParseZone(s string) (z Zone, err error) {
+	values := strings.Split(strings.TrimSpace(s), ",")
+
+	if len(values) < 2 || len(values) > 3 {
+		return z, fmt.Errorf("invalid zone %s", strconv.Quote(s))
+	}
+
+	var v [3]float64
+
+	for i := range values {
+		if v[i], err = strconv.ParseFloat(strings.TrimSpace(values[i]), 64); err != nil {
+			return z, fmt.Errorf("invalid zone %s", strconv.Quote(s))
+		}
+	}
+
+	z = Zone{Lat: v[0], Lng: v[1], Radius: v[2]}
+
+	if z.Lat < -90 || z.Lat > 90 || z.Lng < -180 || z.Lng > 180 || z.Lat == 0 && z.Lng == 0 {
+		return Zone{}, fmt.Errorf("invalid zone position %s", strconv.Quote(s))
+	} else if z.Radius < 0 {
+		return Zone{}, fmt.Errorf("invalid zone radius %s", strconv.Quote(s))
+	} else if z.Radius == 0 {
+		z.Radius = ZoneRadius
+	}
+
+	return z, nil
+}
+
+// ParseZones parses a list of zones and returns an error if one of them is invalid.
+func ParseZones(list []string) (zones Zones, err error) {
+	for _, s := range list {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+
+		z, err := ParseZone(s)
+
+		if err != nil {
+			return zones, err
+		}
+
+		zones = append(zones, z)
+	}
+
+	return zones, nil
+}
+
+// Contains tests if the coordinates are within the zone.
+func (z Zone) Contains(lat, lng float64) bool {
+	if lat == 0 && lng == 0 {
+		return false
+	}
+
+	return Km(Position{Lat: z.Lat, Lng: z.Lng}, Position{Lat: lat, Lng: lng})*1000 <= z.Radius
+}
+
+// Bounds returns the minimum and maximum coordinates of a box that contains the zone.
+func (z Zone) Bounds() (latMin, latMax, lngMin, lngMax float64) {
+	lat := z.Radius / 1000 / EarthRadiusKm * 180 / math.Pi
+	lng := 180.0
+
+	// Degrees of longitude become shorter towards the poles.
+	if c := math.Cos(DegToRad(z.Lat)); c > 0.01 {
+		lng = math.Min(lat/c, 180)
+	}
+
+	return z.Lat - lat, z.Lat + lat, z.Lng - lng, z.Lng + lng
+}
+
+// String returns the zone as string.
+func (z Zone) String() string {
+	return fmt.Sprintf("%f,%f,%d", z.Lat, z.Lng, int(z.Radius))
+}
+
+// Contains tests if the coordinates are within one of the zones.
+func (zones Zones) Contains(lat, lng float64) bool {
+	for _, z := range zones {
+		if z.Contains(lat, lng) {
+			return true
+		}
+	}
+
+	return false
+}
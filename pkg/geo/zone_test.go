@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseZone(t *testing.T) {
+	t.Run("Radius", func(t *testing.T) {
+		z, err := ParseZone(" 52.5208, 13.4094, 500 ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Zone{Lat: 52.5208, Lng: 13.4094, Radius: 500}, z)
+		assert.Equal(t, "52.520800,13.409400,500", z.String())
+	})
+	t.Run("DefaultRadius", func(t *testing.T) {
+		z, err := ParseZone("52.5208,13.4094")
+
+		assert.NoError(t, err)
+		assert.Equal(t, ZoneRadius, z.Radius)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{"", "52.5208", "a,b", "95,13.4094", "0,0", "52.5208,13.4094,-1", "1,2,3,4"} {
+			_, err := ParseZone(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestParseZones(t *testing.T) {
+	zones, err := ParseZones([]string{"52.5208,13.4094,500", "", "48.1351,11.5820"})
+
+	assert.NoError(t, err)
+	assert.Len(t, zones, 2)
+
+	_, err = ParseZones([]string{"52.5208,13.4094,500", "foo"})
+
+	assert.Error(t, err)
+}
+
+func TestZones_Contains(t *testing.T) {
+	zones := Zones{{Lat: 52.5208, Lng: 13.4094, Radius: 500}, {Lat: 48.1351, Lng: 11.5820, Radius: 100}}
+
+	assert.True(t, zones.Contains(52.5208, 13.4094))
+	assert.True(t, zones.Contains(52.5240, 13.4094))
+	assert.False(t, zones.Contains(52.5260, 13.4094))
+	assert.True(t, zones.Contains(48.1355, 11.5822))
+	assert.False(t, zones.Contains(48.1400, 11.5820))
+	assert.False(t, zones.Contains(0, 0))
+	assert.False(t, Zones{}.Contains(52.5208, 13.4094))
+}
+
+func TestZone_Bounds(t *testing.T) {
+	z := Zone{Lat: 52.5208, Lng: 13.4094, Radius: 500}
+
+	latMin, latMax, lngMin, lngMax := z.Bounds()
+
+	assert.InDelta(t, 52.5163, latMin, 0.0001)
+	assert.InDelta(t, 52.5253, latMax, 0.0001)
+	assert.InDelta(t, 13.4020, lngMin, 0.0001)
+	assert.InDelta(t, 13.4168, lngMax, 0.0001)
+	assert.True(t, z.Contains(latMax-0.0001, z.Lng))
+	assert.False(t, z.Contains(latMax+0.0001, z.Lng))
+}
@@ -0,0 +1,24 @@
+package list
+
+// Intersect returns the strings of the first list that are also contained in the second list.
+func Intersect(l, s []string) (result []string) {
+	result = []string{}
+
+	if len(l) == 0 || len(s) == 0 {
+		return result
+	}
+
+	found := make(map[string]bool, len(s))
+
+	for i := range s {
+		found[s[i]] = true
+	}
+
+	for i := range l {
+		if found[l[i]] {
+			result = append(result, l[i])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,20 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntersect(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		assert.Equal(t, []string{"b", "c"}, Intersect([]string{"a", "b", "c"}, []string{"c", "b", "d"}))
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		assert.Equal(t, []string{}, Intersect([]string{"a", "b"}, []string{"c"}))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, []string{}, Intersect(nil, []string{"c"}))
+		assert.Equal(t, []string{}, Intersect([]string{"a"}, nil))
+	})
+}
@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// Claims validates the ID token and returns its claims, supplemented with the claims returned by the
+// userinfo endpoint of the provider, if any, e.g. because groups are not included in the ID token.
+func (c *Client) Claims(t Token, nonce string) (authn.Claims, error) {
+	claims, err := c.idTokenClaims(t.IDToken, nonce)
+
+	if err != nil {
+		return nil, err
+	} else if c.Provider.UserInfoEndpoint == "" || t.AccessToken == "" {
+		return claims, nil
+	}
+
+	info, err := c.userInfo(t.AccessToken)
+
+	if err != nil {
+		return nil, err
+	} else if info.String("sub") != claims.String("sub") {
+		return nil, ErrInvalidSubject
+	}
+
+	for k, v := range info {
+		if _, found := claims[k]; !found {
+			claims[k] = v
+		}
+	}
+
+	return claims, nil
+}
+
+// idTokenClaims decodes the ID token and validates its issuer, audience, expiration, and nonce.
+func (c *Client) idTokenClaims(idToken, nonce string) (claims authn.Claims, err error) {
+	parts := strings.Split(idToken, ".")
+
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	} else if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	exp, _ := claims["exp"].(float64)
+
+	if strings.TrimRight(claims.String("iss"), "/") != strings.TrimRight(c.Provider.Issuer, "/") {
+		return nil, ErrInvalidIssuer
+	} else if !list.Contains(claims.Values("aud"), c.ClientID) {
+		return nil, ErrInvalidAudience
+	} else if time.Now().Unix() > int64(exp) {
+		return nil, ErrTokenExpired
+	} else if nonce == "" || claims.String("nonce") != nonce {
+		return nil, ErrInvalidNonce
+	} else if claims.String("sub") == "" {
+		return nil, ErrInvalidSubject
+	}
+
+	return claims, nil
+}
+
+// userInfo returns the claims provided by the userinfo endpoint for the access token.
+func (c *Client) userInfo(accessToken string) (claims authn.Claims, err error) {
+	req, err := http.NewRequest(http.MethodGet, c.Provider.UserInfoEndpoint, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: userinfo request failed with status %d", resp.StatusCode)
+	} else if err = json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("oidc: invalid userinfo response (%s)", err)
+	}
+
+	return claims, nil
+}
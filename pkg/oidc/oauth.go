@@ -0,0 +1,97 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Token represents an OpenID Connect token response.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// NewVerifier returns a new random PKCE code verifier.
+func NewVerifier() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// challenge returns the S256 PKCE code challenge for the verifier.
+func challenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// AuthURL returns the URL of the login page to which the user is redirected by the client.
+func (c *Client) AuthURL(state, nonce, verifier string) string {
+	q := url.Values{
+		"client_id":             {c.ClientID},
+		"redirect_uri":          {c.RedirectUrl},
+		"response_type":         {"code"},
+		"scope":                 {Scope},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	if strings.Contains(c.Provider.AuthEndpoint, "?") {
+		return c.Provider.AuthEndpoint + "&" + q.Encode()
+	}
+
+	return c.Provider.AuthEndpoint + "?" + q.Encode()
+}
+
+// Exchange returns the tokens for the authorization code that was passed to the redirect URL.
+func (c *Client) Exchange(code, verifier string) (t Token, err error) {
+	data := url.Values{
+		"redirect_uri":  {c.RedirectUrl},
+		"code":          {code},
+		"code_verifier": {verifier},
+		"grant_type":    {"authorization_code"},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.Provider.TokenEndpoint, strings.NewReader(data.Encode()))
+
+	if err != nil {
+		return t, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return t, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(resp.Body).Decode(&t); err != nil && resp.StatusCode == http.StatusOK {
+		return t, fmt.Errorf("oidc: invalid token response (%s)", err)
+	} else if t.Error != "" {
+		return t, fmt.Errorf("oidc: %s", strings.TrimSpace(t.Error+" "+t.Description))
+	} else if resp.StatusCode != http.StatusOK {
+		return t, fmt.Errorf("oidc: token request failed with status %d", resp.StatusCode)
+	} else if t.IDToken == "" {
+		return t, fmt.Errorf("oidc: missing id token")
+	}
+
+	return t, nil
+}
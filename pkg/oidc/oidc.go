@@ -0,0 +1,120 @@
+/*
+Package oidc provides an OpenID Connect client for logging in with an external identity provider
+using the authorization code flow.
+
+ID tokens are received directly from the token endpoint of the provider, so that their claims
+are validated, but their signatures are not verified, as permitted by OpenID Connect Core 1.0,
+Section 3.1.3.7. Providers must therefore be accessed via HTTPS unless insecure mode is enabled.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package oidc
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Scope specifies the scopes requested from the identity provider.
+const Scope = "openid email profile"
+
+var (
+	ErrInsecureIssuer  = errors.New("issuer must use https")
+	ErrInvalidIssuer   = errors.New("issuer does not match")
+	ErrInvalidAudience = errors.New("audience does not match")
+	ErrInvalidNonce    = errors.New("nonce does not match")
+	ErrInvalidSubject  = errors.New("subject does not match")
+	ErrTokenExpired    = errors.New("id token has expired")
+	ErrInvalidToken    = errors.New("invalid id token")
+)
+
+// Provider represents the metadata of an identity provider as returned by OpenID Connect Discovery.
+type Provider struct {
+	Issuer           string `json:"issuer"`
+	AuthEndpoint     string `json:"authorization_endpoint"`
+	TokenEndpoint    string `json:"token_endpoint"`
+	UserInfoEndpoint string `json:"userinfo_endpoint"`
+}
+
+// Client represents an OpenID Connect client registered with an identity provider.
+type Client struct {
+	Provider     Provider
+	ClientID     string
+	ClientSecret string
+	RedirectUrl  string
+	http         *http.Client
+}
+
+// NewClient discovers the identity provider with the specified issuer URI and returns a new client.
+// If insecure is true, the issuer may use plain HTTP and its TLS certificate is not verified.
+func NewClient(issuer, clientId, clientSecret, redirectUrl string, insecure bool) (*Client, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	if !insecure && !strings.HasPrefix(issuer, "https://") {
+		return nil, ErrInsecureIssuer
+	}
+
+	c := &Client{
+		ClientID:     clientId,
+		ClientSecret: clientSecret,
+		RedirectUrl:  redirectUrl,
+		http:         &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if insecure {
+		c.http.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	if err := c.discover(issuer); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// discover fetches the provider metadata from the well-known configuration URL of the issuer.
+func (c *Client) discover(issuer string) error {
+	resp, err := c.http.Get(issuer + "/.well-known/openid-configuration")
+
+	if err != nil {
+		return fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: discovery failed with status %d", resp.StatusCode)
+	} else if err = json.NewDecoder(resp.Body).Decode(&c.Provider); err != nil {
+		return fmt.Errorf("oidc: invalid discovery response (%s)", err)
+	} else if strings.TrimRight(c.Provider.Issuer, "/") != issuer {
+		return ErrInvalidIssuer
+	} else if c.Provider.AuthEndpoint == "" || c.Provider.TokenEndpoint == "" {
+		return fmt.Errorf("oidc: provider endpoints are missing")
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testProvider returns a dummy identity provider that issues an ID token with the specified claims.
+func testProvider(t *testing.T, claims map[string]interface{}) *httptest.Server {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Provider{
+			Issuer:           srv.URL,
+			AuthEndpoint:     srv.URL + "/auth",
+			TokenEndpoint:    srv.URL + "/token",
+			UserInfoEndpoint: srv.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, _ := r.BasicAuth(); id != "photoprism" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		} else if r.FormValue("code") != "code" || r.FormValue("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+
+		payload, _ := json.Marshal(claims)
+		idToken := "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2ln"
+
+		_ = json.NewEncoder(w).Encode(Token{AccessToken: "access", IDToken: idToken, TokenType: "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"sub": claims["sub"], "groups": []string{"photoprism-admins"}})
+	})
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Insecure", func(t *testing.T) {
+		srv := testProvider(t, nil)
+
+		_, err := NewClient(srv.URL, "photoprism", "secret", "https://app.localssl.dev/api/v1/oidc/redirect", false)
+		assert.ErrorIs(t, err, ErrInsecureIssuer)
+
+		c, err := NewClient(srv.URL+"/", "photoprism", "secret", "https://app.localssl.dev/api/v1/oidc/redirect", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, srv.URL+"/token", c.Provider.TokenEndpoint)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		srv := testProvider(t, nil)
+
+		_, err := NewClient(srv.URL+"/realms/master", "photoprism", "secret", "", true)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_AuthURL(t *testing.T) {
+	srv := testProvider(t, nil)
+	c, err := NewClient(srv.URL, "photoprism", "secret", "https://app.localssl.dev/api/v1/oidc/redirect", true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(c.AuthURL("state", "nonce", "verifier"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := u.Query()
+
+	assert.Equal(t, srv.URL+"/auth", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "photoprism", q.Get("client_id"))
+	assert.Equal(t, "https://app.localssl.dev/api/v1/oidc/redirect", q.Get("redirect_uri"))
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, Scope, q.Get("scope"))
+	assert.Equal(t, "state", q.Get("state"))
+	assert.Equal(t, "nonce", q.Get("nonce"))
+	assert.Equal(t, challenge("verifier"), q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+}
+
+func TestClient_Claims(t *testing.T) {
+	claims := map[string]interface{}{
+		"sub":   "248289761001",
+		"aud":   "photoprism",
+		"nonce": "nonce",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"name":  "Jane Doe",
+	}
+
+	srv := testProvider(t, claims)
+	claims["iss"] = srv.URL
+
+	c, err := NewClient(srv.URL, "photoprism", "secret", "", true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := NewVerifier()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		token, err := c.Exchange("code", verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := c.Claims(token, "nonce")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "248289761001", result.String("sub"))
+		assert.Equal(t, "Jane Doe", result.String("name"))
+		assert.Equal(t, []string{"photoprism-admins"}, result.Values("groups"))
+	})
+	t.Run("InvalidCode", func(t *testing.T) {
+		_, err := c.Exchange("foo", verifier)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidNonce", func(t *testing.T) {
+		token, err := c.Exchange("code", verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Claims(token, "other")
+		assert.ErrorIs(t, err, ErrInvalidNonce)
+	})
+	t.Run("InvalidAudience", func(t *testing.T) {
+		claims["aud"] = []string{"other"}
+		defer func() { claims["aud"] = "photoprism" }()
+
+		token, err := c.Exchange("code", verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Claims(token, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidAudience)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+		defer func() { claims["exp"] = time.Now().Add(time.Hour).Unix() }()
+
+		token, err := c.Exchange("code", verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Claims(token, "nonce")
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		_, err := c.Claims(Token{IDToken: "foo"}, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
@@ -0,0 +1,128 @@
+package redis
+
+import (
+	"strings"
+	"time"
+)
+
+// ScanCount specifies the number of keys to scan per iteration.
+var ScanCount = 500
+
+// Ping checks if the server is reachable.
+func (c *Client) Ping() error {
+	_, err := c.Do("PING")
+	return err
+}
+
+// Get returns the value of a key, or nil if it does not exist.
+func (c *Client) Get(key string) ([]byte, error) {
+	reply, err := c.Do("GET", c.Prefix+key)
+
+	if err != nil || reply == nil {
+		return nil, err
+	} else if b, ok := reply.([]byte); ok {
+		return b, nil
+	}
+
+	return nil, ErrProtocol
+}
+
+// Set sets the value of a key, which expires after the specified duration unless it is zero.
+func (c *Client) Set(key string, value []byte, expiration time.Duration) (err error) {
+	if ms := expiration.Milliseconds(); ms > 0 {
+		_, err = c.Do("SET", c.Prefix+key, value, "PX", ms)
+	} else {
+		_, err = c.Do("SET", c.Prefix+key, value)
+	}
+
+	return err
+}
+
+// Del deletes the specified keys.
+func (c *Client) Del(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(keys)+1)
+	args[0] = "DEL"
+
+	for i, key := range keys {
+		args[i+1] = c.Prefix + key
+	}
+
+	_, err := c.Do(args...)
+
+	return err
+}
+
+// Keys returns all keys that start with the specified prefix.
+func (c *Client) Keys(prefix string) (keys []string, err error) {
+	match := escapePattern(c.Prefix+prefix) + "*"
+	cursor := "0"
+
+	for {
+		reply, err := c.Do("SCAN", cursor, "MATCH", match, "COUNT", ScanCount)
+
+		if err != nil {
+			return keys, err
+		}
+
+		result, ok := reply.([]interface{})
+
+		if !ok || len(result) != 2 {
+			return keys, ErrProtocol
+		}
+
+		next, ok := result[0].([]byte)
+
+		if !ok {
+			return keys, ErrProtocol
+		}
+
+		found, _ := result[1].([]interface{})
+
+		for _, k := range found {
+			if b, ok := k.([]byte); ok {
+				keys = append(keys, strings.TrimPrefix(string(b), c.Prefix))
+			}
+		}
+
+		if cursor = string(next); cursor == "0" {
+			return keys, nil
+		}
+	}
+}
+
+// DelPrefix deletes all keys that start with the specified prefix.
+func (c *Client) DelPrefix(prefix string) error {
+	keys, err := c.Keys(prefix)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Del(keys...)
+}
+
+// Publish sends a message to all subscribers of a channel.
+func (c *Client) Publish(channel string, message []byte) error {
+	_, err := c.Do("PUBLISH", c.Prefix+channel, message)
+	return err
+}
+
+// escapePattern escapes special glob-style pattern characters.
+func escapePattern(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
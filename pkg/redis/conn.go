@@ -0,0 +1,153 @@
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Error represents an error reply sent by the server.
+type Error string
+
+// Error returns the error message.
+func (e Error) Error() string {
+	return string(e)
+}
+
+// ErrProtocol is returned if the server sends an invalid reply.
+var ErrProtocol = errors.New("invalid reply")
+
+// noDeadline disables network timeouts.
+var noDeadline = time.Time{}
+
+// conn represents a single server connection.
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// newConn wraps a network connection.
+func newConn(nc net.Conn) *conn {
+	return &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+}
+
+// do sends a command and reads the reply.
+func (cn *conn) do(timeout time.Duration, args ...interface{}) (interface{}, error) {
+	if timeout > 0 {
+		_ = cn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	if err := cn.send(args...); err != nil {
+		return nil, err
+	}
+
+	return cn.receive()
+}
+
+// send writes a command as array of bulk strings.
+func (cn *conn) send(args ...interface{}) error {
+	if len(args) == 0 {
+		return errors.New("missing command")
+	}
+
+	if _, err := fmt.Fprintf(cn.w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		var b []byte
+
+		switch v := arg.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		case int:
+			b = strconv.AppendInt(nil, int64(v), 10)
+		case int64:
+			b = strconv.AppendInt(nil, v, 10)
+		default:
+			return fmt.Errorf("unsupported argument type %T", arg)
+		}
+
+		if _, err := fmt.Fprintf(cn.w, "$%d\r\n", len(b)); err != nil {
+			return err
+		} else if _, err = cn.w.Write(b); err != nil {
+			return err
+		} else if _, err = cn.w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+
+	return cn.w.Flush()
+}
+
+// receive reads the next reply.
+func (cn *conn) receive() (interface{}, error) {
+	line, err := cn.r.ReadSlice('\n')
+
+	if err != nil {
+		return nil, err
+	} else if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, ErrProtocol
+	}
+
+	kind, data := line[0], string(line[1:len(line)-2])
+
+	switch kind {
+	case '+':
+		return data, nil
+	case '-':
+		return nil, Error(data)
+	case ':':
+		return strconv.ParseInt(data, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(data)
+
+		if err != nil {
+			return nil, ErrProtocol
+		} else if n < 0 {
+			return nil, nil
+		}
+
+		b := make([]byte, n+2)
+
+		if _, err = io.ReadFull(cn.r, b); err != nil {
+			return nil, err
+		}
+
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(data)
+
+		if err != nil {
+			return nil, ErrProtocol
+		} else if n < 0 {
+			return nil, nil
+		}
+
+		result := make([]interface{}, n)
+
+		for i := range result {
+			// Error replies within arrays are returned as values.
+			if result[i], err = cn.receive(); err != nil {
+				var replyErr Error
+
+				if !errors.As(err, &replyErr) {
+					return nil, err
+				}
+
+				result[i] = replyErr
+			}
+		}
+
+		return result, nil
+	default:
+		return nil, ErrProtocol
+	}
+}
@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"strings"
+	"sync"
+)
+
+// Message represents a message received from a pub/sub channel.
+type Message struct {
+	Channel string
+	Data    []byte
+}
+
+// Subscription represents a dedicated connection for receiving pub/sub messages.
+type Subscription struct {
+	client *Client
+	cn     *conn
+	prefix string
+	once   sync.Once
+}
+
+// Subscribe opens a new connection and subscribes to the specified channels.
+func (c *Client) Subscribe(channels ...string) (*Subscription, error) {
+	cn, err := c.dial()
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, len(channels)+1)
+	args[0] = "SUBSCRIBE"
+
+	for i, ch := range channels {
+		args[i+1] = c.Prefix + ch
+	}
+
+	if err = cn.send(args...); err != nil {
+		_ = cn.Close()
+		return nil, err
+	}
+
+	// Wait for the server to confirm each subscription.
+	for range channels {
+		if _, err = cn.receive(); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+
+	// Messages are received without timeout.
+	_ = cn.SetDeadline(noDeadline)
+
+	sub := &Subscription{client: c, cn: cn, prefix: c.Prefix}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		_ = cn.Close()
+		return nil, ErrClosed
+	} else if c.subs == nil {
+		c.subs = make(map[*Subscription]struct{})
+	}
+
+	c.subs[sub] = struct{}{}
+
+	return sub, nil
+}
+
+// Receive waits for the next message. It returns an error once the connection has been closed.
+func (s *Subscription) Receive() (msg Message, err error) {
+	for {
+		reply, err := s.cn.receive()
+
+		if err != nil {
+			return msg, err
+		}
+
+		// Skip other pushes, such as subscription confirmations.
+		if v, ok := reply.([]interface{}); !ok || len(v) != 3 {
+			continue
+		} else if kind, _ := v[0].([]byte); string(kind) != "message" {
+			continue
+		} else if ch, ok := v[1].([]byte); !ok {
+			continue
+		} else if data, ok := v[2].([]byte); !ok {
+			continue
+		} else {
+			return Message{Channel: strings.TrimPrefix(string(ch), s.prefix), Data: data}, nil
+		}
+	}
+}
+
+// Close closes the subscription connection.
+func (s *Subscription) Close() (err error) {
+	s.once.Do(func() {
+		s.client.mutex.Lock()
+		delete(s.client.subs, s)
+		s.client.mutex.Unlock()
+
+		err = s.cn.Close()
+	})
+
+	return err
+}
@@ -0,0 +1,237 @@
+/*
+Package redis provides a minimal Redis client for sharing cached values and events between multiple instances.
+
+Only the commands required for caching and pub/sub messaging are implemented, see https://redis.io/docs/reference/protocol-spec/.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPort is the default Redis server port.
+const DefaultPort = "6379"
+
+// DefaultTimeout specifies how long to wait for the server when connecting and sending commands.
+var DefaultTimeout = 5 * time.Second
+
+// MaxIdleConns specifies the maximum number of idle connections that are kept open.
+var MaxIdleConns = 8
+
+// Client represents a Redis server connection pool.
+type Client struct {
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	TLS      bool
+	Prefix   string
+	Timeout  time.Duration
+	idle     []*conn
+	subs     map[*Subscription]struct{}
+	closed   bool
+	mutex    sync.Mutex
+}
+
+// ErrClosed is returned if the client has been closed.
+var ErrClosed = errors.New("client closed")
+
+// New returns a new client for the specified server URL, e.g. redis://:password@redis:6379/0.
+// Use the rediss:// scheme to connect over TLS.
+func New(serverUrl string) (*Client, error) {
+	u, err := url.Parse(strings.TrimSpace(serverUrl))
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{Timeout: DefaultTimeout}
+
+	switch strings.ToLower(u.Scheme) {
+	case "redis":
+	case "rediss":
+		c.TLS = true
+	default:
+		return nil, fmt.Errorf("unsupported url scheme %s", strconv.Quote(u.Scheme))
+	}
+
+	if u.Hostname() == "" {
+		return nil, errors.New("missing hostname")
+	} else if u.Port() == "" {
+		c.Addr = net.JoinHostPort(u.Hostname(), DefaultPort)
+	} else {
+		c.Addr = u.Host
+	}
+
+	if u.User != nil {
+		c.Username = u.User.Username()
+		c.Password, _ = u.User.Password()
+	}
+
+	if db := strings.Trim(u.Path, "/"); db == "" {
+		// Use default database.
+	} else if c.DB, err = strconv.Atoi(db); err != nil || c.DB < 0 {
+		return nil, fmt.Errorf("invalid database number %s", strconv.Quote(db))
+	}
+
+	return c, nil
+}
+
+// Do sends a command to the server and returns the reply, which is either nil, a string, an int64,
+// a []byte, or a []interface{} with further replies. Error replies are returned as Error.
+func (c *Client) Do(args ...interface{}) (interface{}, error) {
+	cn, err := c.conn()
+
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := cn.do(c.timeout(), args...)
+
+	c.release(cn, err)
+
+	return reply, err
+}
+
+// Close closes all connections, including subscriptions.
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	idle, subs := c.idle, c.subs
+	c.idle, c.subs, c.closed = nil, nil, true
+	c.mutex.Unlock()
+
+	for _, cn := range idle {
+		_ = cn.Close()
+	}
+
+	for sub := range subs {
+		_ = sub.Close()
+	}
+
+	return nil
+}
+
+// Closed checks if the client has been closed.
+func (c *Client) Closed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.closed
+}
+
+// timeout returns the network timeout.
+func (c *Client) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+
+	return c.Timeout
+}
+
+// conn returns an idle connection or opens a new one.
+func (c *Client) conn() (*conn, error) {
+	c.mutex.Lock()
+
+	if c.closed {
+		c.mutex.Unlock()
+		return nil, ErrClosed
+	} else if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mutex.Unlock()
+		return cn, nil
+	}
+
+	c.mutex.Unlock()
+
+	return c.dial()
+}
+
+// release returns the connection to the pool, unless it failed or the pool is full.
+func (c *Client) release(cn *conn, err error) {
+	var replyErr Error
+
+	if err != nil && !errors.As(err, &replyErr) {
+		_ = cn.Close()
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed || len(c.idle) >= MaxIdleConns {
+		_ = cn.Close()
+		return
+	}
+
+	c.idle = append(c.idle, cn)
+}
+
+// dial opens a new connection and authenticates, if needed.
+func (c *Client) dial() (*conn, error) {
+	var nc net.Conn
+	var err error
+
+	dialer := &net.Dialer{Timeout: c.timeout()}
+
+	if c.TLS {
+		host, _, _ := net.SplitHostPort(c.Addr)
+		nc, err = tls.DialWithDialer(dialer, "tcp", c.Addr, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	} else {
+		nc, err = dialer.Dial("tcp", c.Addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cn := newConn(nc)
+
+	if c.Password == "" {
+		// No authentication required.
+	} else if c.Username != "" {
+		_, err = cn.do(c.timeout(), "AUTH", c.Username, c.Password)
+	} else {
+		_, err = cn.do(c.timeout(), "AUTH", c.Password)
+	}
+
+	if err == nil && c.DB > 0 {
+		_, err = cn.do(c.timeout(), "SELECT", c.DB)
+	}
+
+	if err != nil {
+		_ = cn.Close()
+		return nil, err
+	}
+
+	return cn, nil
+}
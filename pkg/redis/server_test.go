@@ -0,0 +1,208 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testServer implements the commands used by the client with an in-memory store.
+type testServer struct {
+	ln       net.Listener
+	password string
+	values   map[string]testValue
+	subs     map[string][]*testClient
+	mutex    sync.Mutex
+}
+
+// testValue represents a stored value with optional expiration time.
+type testValue struct {
+	data    string
+	expires time.Time
+}
+
+// testClient represents a client connection.
+type testClient struct {
+	conn  net.Conn
+	mutex sync.Mutex
+}
+
+// newTestServer starts a new test server on a random local port.
+func newTestServer(t *testing.T, password string) *testServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testServer{ln: ln, password: password, values: make(map[string]testValue), subs: make(map[string][]*testClient)}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go s.serve(&testClient{conn: conn})
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	return s
+}
+
+// Url returns the server URL.
+func (s *testServer) Url() string {
+	if s.password != "" {
+		return fmt.Sprintf("redis://:%s@%s/1", s.password, s.ln.Addr().String())
+	}
+
+	return fmt.Sprintf("redis://%s", s.ln.Addr().String())
+}
+
+// write sends a raw reply to the client.
+func (c *testClient) write(s string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, _ = io.WriteString(c.conn, s)
+}
+
+// bulk returns a bulk string reply.
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+// serve handles the commands of a client until the connection is closed.
+func (s *testServer) serve(c *testClient) {
+	defer c.conn.Close()
+
+	r := bufio.NewReader(c.conn)
+	authorized := s.password == ""
+
+	for {
+		line, err := r.ReadString('\n')
+
+		if err != nil || !strings.HasPrefix(line, "*") {
+			return
+		}
+
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+
+		for i := range args {
+			if line, err = r.ReadString('\n'); err != nil {
+				return
+			}
+
+			l, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+			b := make([]byte, l+2)
+
+			if _, err = io.ReadFull(r, b); err != nil {
+				return
+			}
+
+			args[i] = string(b[:l])
+		}
+
+		cmd := strings.ToUpper(args[0])
+
+		if cmd == "AUTH" {
+			if args[len(args)-1] == s.password {
+				authorized = true
+				c.write("+OK\r\n")
+			} else {
+				c.write("-WRONGPASS invalid password\r\n")
+			}
+
+			continue
+		} else if !authorized {
+			c.write("-NOAUTH Authentication required.\r\n")
+			continue
+		}
+
+		c.write(s.exec(c, cmd, args[1:]))
+	}
+}
+
+// exec executes a command and returns the reply.
+func (s *testServer) exec(c *testClient, cmd string, args []string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch cmd {
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		if v, ok := s.values[args[0]]; !ok || !v.expires.IsZero() && time.Now().After(v.expires) {
+			return "$-1\r\n"
+		} else {
+			return bulk(v.data)
+		}
+	case "SET":
+		v := testValue{data: args[1]}
+
+		if len(args) == 4 && strings.ToUpper(args[2]) == "PX" {
+			ms, _ := strconv.Atoi(args[3])
+			v.expires = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+
+		s.values[args[0]] = v
+
+		return "+OK\r\n"
+	case "DEL":
+		var n int
+
+		for _, k := range args {
+			if _, ok := s.values[k]; ok {
+				delete(s.values, k)
+				n++
+			}
+		}
+
+		return fmt.Sprintf(":%d\r\n", n)
+	case "SCAN":
+		var keys []string
+
+		for k := range s.values {
+			if ok, _ := path.Match(args[2], k); ok {
+				keys = append(keys, bulk(k))
+			}
+		}
+
+		return fmt.Sprintf("*2\r\n%s*%d\r\n%s", bulk("0"), len(keys), strings.Join(keys, ""))
+	case "PUBLISH":
+		subs := s.subs[args[0]]
+
+		for _, sub := range subs {
+			sub.write("*3\r\n" + bulk("message") + bulk(args[0]) + bulk(args[1]))
+		}
+
+		return fmt.Sprintf(":%d\r\n", len(subs))
+	case "SUBSCRIBE":
+		var reply string
+
+		for i, ch := range args {
+			s.subs[ch] = append(s.subs[ch], c)
+			reply += "*3\r\n" + bulk("subscribe") + bulk(ch) + fmt.Sprintf(":%d\r\n", i+1)
+		}
+
+		return reply
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", cmd)
+	}
+}
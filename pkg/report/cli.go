@@ -4,6 +4,8 @@ import "github.com/urfave/cli"
 
 func CliFormat(ctx *cli.Context) Format {
 	switch {
+	case ctx.Bool("json"), ctx.GlobalBool("json"):
+		return JSON
 	case ctx.Bool("md"), ctx.Bool("markdown"):
 		return Markdown
 	case ctx.Bool("tsv"):
@@ -28,4 +30,8 @@ var CliFlags = []cli.Flag{
 		Name:  "tsv, t",
 		Usage: "export as tab separated values",
 	},
+	cli.BoolFlag{
+		Name:  "json",
+		Usage: "export as machine-readable JSON",
+	},
 }
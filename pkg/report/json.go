@@ -0,0 +1,59 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JsonExport returns the report as JSON array of objects, using the column names as keys.
+func JsonExport(rows [][]string, cols []string) (string, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("[")
+
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		buf.WriteString("\n  {")
+
+		// Keep the column order, which would be lost with a map.
+		for j, col := range cols {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+
+			key, err := json.Marshal(col)
+
+			if err != nil {
+				return "", err
+			}
+
+			var val []byte
+
+			if j < len(row) {
+				val, err = json.Marshal(row[j])
+			} else {
+				val, err = json.Marshal("")
+			}
+
+			if err != nil {
+				return "", err
+			}
+
+			buf.Write(key)
+			buf.WriteString(": ")
+			buf.Write(val)
+		}
+
+		buf.WriteString("}")
+	}
+
+	if len(rows) > 0 {
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("]\n")
+
+	return buf.String(), nil
+}
@@ -12,6 +12,8 @@ func RenderFormat(rows [][]string, cols []string, format Format) (string, error)
 	switch format {
 	case CSV:
 		return Render(rows, cols, Options{Format: CSV})
+	case JSON:
+		return Render(rows, cols, Options{Format: JSON})
 	case TSV:
 		return Render(rows, cols, Options{Format: TSV})
 	case Markdown:
@@ -31,6 +33,8 @@ func Render(rows [][]string, cols []string, opt Options) (string, error) {
 		return CsvExport(rows, cols, ';')
 	case TSV:
 		return CsvExport(rows, cols, '\t')
+	case JSON:
+		return JsonExport(rows, cols)
 	case Markdown:
 		opt.Valid = true
 		return MarkdownTable(rows, cols, opt), nil
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -49,6 +50,27 @@ func TestTable(t *testing.T) {
 
 		assert.Contains(t, result, "Col1\tCol2\nfoo\tbar, abc, abc")
 	})
+	t.Run("JsonExport", func(t *testing.T) {
+		result, err := RenderFormat(rows, cols, JSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var values []map[string]string
+
+		assert.NoError(t, json.Unmarshal([]byte(result), &values))
+		assert.Len(t, values, 2)
+		assert.Equal(t, "foo", values[0]["Col1"])
+		assert.Contains(t, result, "{\"Col1\": \"bar\", \"Col2\": ")
+	})
+	t.Run("JsonEmpty", func(t *testing.T) {
+		result, err := RenderFormat([][]string{}, cols, JSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "[]\n", result)
+	})
 	t.Run("Invalid", func(t *testing.T) {
 		_, err := RenderFormat(rows, cols, Format("invalid"))
 
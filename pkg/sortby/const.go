@@ -20,6 +20,8 @@ const (
 	Slug        = "slug"
 	Category    = "category"
 	Similar     = "similar"
+	Worst       = "worst"
 	Random      = "random"
+	Custom      = "custom"
 	Invalid     = "invalid"
 )
@@ -0,0 +1,91 @@
+package webauthn
+
+import (
+	"crypto/sha256"
+)
+
+// AssertionResponse represents the JSON encoded result of navigator.credentials.get().
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// CredentialID returns the normalized credential id.
+func (r AssertionResponse) CredentialID() string {
+	if id, err := Decode(r.ID); err == nil {
+		return Encode(id)
+	}
+
+	return ""
+}
+
+// UserHandle returns the decoded user handle, which is required for discoverable credentials.
+func (r AssertionResponse) UserHandle() string {
+	if b, err := Decode(r.Response.UserHandle); err == nil {
+		return string(b)
+	}
+
+	return ""
+}
+
+// Challenge returns the challenge from the client data, so that the pending ceremony can be looked up.
+func (r AssertionResponse) Challenge() string {
+	if clientData, _, err := ParseClientData(r.Response.ClientDataJSON); err == nil {
+		return clientData.Challenge
+	}
+
+	return ""
+}
+
+// Verify checks the assertion against the stored credential and returns the new signature counter.
+func (r AssertionResponse) Verify(challenge, origin, rpID string, requireUV bool, cred Credential) (uint32, error) {
+	if r.Type != "public-key" {
+		return 0, ErrInvalidType
+	} else if r.CredentialID() == "" || r.CredentialID() != cred.ID {
+		return 0, ErrInvalidCredential
+	}
+
+	clientData, rawClientData, err := ParseClientData(r.Response.ClientDataJSON)
+
+	if err != nil {
+		return 0, err
+	} else if err = clientData.Verify(TypeGet, challenge, origin); err != nil {
+		return 0, err
+	}
+
+	authData, err := ParseAuthenticatorData(r.Response.AuthenticatorData)
+
+	if err != nil {
+		return 0, err
+	} else if err = authData.Verify(rpID, requireUV); err != nil {
+		return 0, err
+	}
+
+	sig, err := Decode(r.Response.Signature)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// The signature covers the authenticator data and the hash of the client data.
+	clientDataHash := sha256.Sum256(rawClientData)
+	data := append(append([]byte{}, authData.Raw...), clientDataHash[:]...)
+
+	if err = VerifySignature(cred.PublicKey, cred.Algorithm, data, sig); err != nil {
+		return 0, err
+	}
+
+	// Authenticators that support counters must increase them, otherwise the credential may have been cloned.
+	if (authData.SignCount != 0 || cred.SignCount != 0) && authData.SignCount <= cred.SignCount {
+		return 0, ErrCounterRollback
+	}
+
+	return authData.SignCount, nil
+}
@@ -0,0 +1,92 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Authenticator data flags.
+const (
+	FlagUserPresent    byte = 0x01
+	FlagUserVerified   byte = 0x04
+	FlagBackupEligible byte = 0x08
+	FlagBackedUp       byte = 0x10
+	FlagAttestedData   byte = 0x40
+)
+
+// AuthenticatorData represents the data returned by the authenticator, see https://www.w3.org/TR/webauthn-3/#sctn-authenticator-data.
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	Raw          []byte
+}
+
+// ParseAuthenticatorData decodes the base64url encoded authenticator data.
+func ParseAuthenticatorData(s string) (result AuthenticatorData, err error) {
+	raw, err := Decode(s)
+
+	if err != nil {
+		return result, err
+	} else if len(raw) < 37 {
+		return result, ErrInvalidAuthData
+	}
+
+	result = AuthenticatorData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+		Raw:       raw,
+	}
+
+	// Attested credential data is only included in registration responses.
+	if result.Flags&FlagAttestedData == 0 {
+		return result, nil
+	} else if len(raw) < 55 {
+		return result, ErrInvalidAuthData
+	}
+
+	result.AAGUID = raw[37:53]
+	idLen := int(binary.BigEndian.Uint16(raw[53:55]))
+
+	if len(raw) < 55+idLen {
+		return result, ErrInvalidAuthData
+	}
+
+	result.CredentialID = raw[55 : 55+idLen]
+
+	return result, nil
+}
+
+// UserPresent checks if the user has been present.
+func (d AuthenticatorData) UserPresent() bool {
+	return d.Flags&FlagUserPresent != 0
+}
+
+// UserVerified checks if the user has been verified, e.g. with a biometric or PIN.
+func (d AuthenticatorData) UserVerified() bool {
+	return d.Flags&FlagUserVerified != 0
+}
+
+// BackupEligible checks if the credential can be synced, i.e. it is a multi-device passkey.
+func (d AuthenticatorData) BackupEligible() bool {
+	return d.Flags&FlagBackupEligible != 0
+}
+
+// Verify checks the relying party id hash and the user presence and verification flags.
+func (d AuthenticatorData) Verify(rpID string, requireUV bool) error {
+	hash := sha256.Sum256([]byte(rpID))
+
+	if !bytes.Equal(d.RPIDHash, hash[:]) {
+		return ErrInvalidRPID
+	} else if !d.UserPresent() {
+		return ErrUserNotPresent
+	} else if requireUV && !d.UserVerified() {
+		return ErrUserNotVerified
+	}
+
+	return nil
+}
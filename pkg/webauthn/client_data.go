@@ -0,0 +1,44 @@
+package webauthn
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+)
+
+// Ceremony types as specified in the client data.
+const (
+	TypeCreate = "webauthn.create"
+	TypeGet    = "webauthn.get"
+)
+
+// ClientData represents the client data collected by the browser during a ceremony.
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+// ParseClientData decodes the base64url encoded client data JSON and returns it together with the raw bytes.
+func ParseClientData(s string) (result ClientData, raw []byte, err error) {
+	if raw, err = Decode(s); err != nil {
+		return result, raw, err
+	} else if err = json.Unmarshal(raw, &result); err != nil {
+		return result, raw, ErrInvalidEncoding
+	}
+
+	return result, raw, nil
+}
+
+// Verify checks the ceremony type, challenge, and origin.
+func (d ClientData) Verify(ceremony, challenge, origin string) error {
+	if d.Type != ceremony {
+		return ErrInvalidType
+	} else if challenge == "" || subtle.ConstantTimeCompare([]byte(d.Challenge), []byte(challenge)) != 1 {
+		return ErrInvalidChallenge
+	} else if d.Origin != origin || d.CrossOrigin {
+		return ErrInvalidOrigin
+	}
+
+	return nil
+}
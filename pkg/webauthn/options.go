@@ -0,0 +1,111 @@
+package webauthn
+
+// DefaultTimeout specifies the ceremony timeout in milliseconds.
+const DefaultTimeout = 300000
+
+// RelyingParty represents the relying party entity.
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// User represents the user account entity for registration.
+type User struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// Parameter represents a supported public key algorithm.
+type Parameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// Descriptor references an existing credential.
+type Descriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection specifies the requirements for authenticators.
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// CreationOptions represents the JSON encoded options for navigator.credentials.create().
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingParty           `json:"rp"`
+	User                   User                   `json:"user"`
+	PubKeyCredParams       []Parameter            `json:"pubKeyCredParams"`
+	Timeout                int                    `json:"timeout"`
+	ExcludeCredentials     []Descriptor           `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions represents the JSON encoded options for navigator.credentials.get().
+type RequestOptions struct {
+	Challenge        string       `json:"challenge"`
+	RPID             string       `json:"rpId"`
+	Timeout          int          `json:"timeout"`
+	AllowCredentials []Descriptor `json:"allowCredentials"`
+	UserVerification string       `json:"userVerification"`
+}
+
+// userVerification returns the user verification requirement.
+func userVerification(requireUV bool) string {
+	if requireUV {
+		return "required"
+	}
+
+	return "preferred"
+}
+
+// NewCreationOptions returns the options for registering a new discoverable credential.
+func NewCreationOptions(challenge string, rp RelyingParty, user User, exclude []Descriptor, requireUV bool) CreationOptions {
+	params := make([]Parameter, len(Algorithms))
+
+	for i, alg := range Algorithms {
+		params[i] = Parameter{Type: "public-key", Alg: alg}
+	}
+
+	if exclude == nil {
+		exclude = []Descriptor{}
+	}
+
+	return CreationOptions{
+		Challenge:          challenge,
+		RP:                 rp,
+		User:               user,
+		PubKeyCredParams:   params,
+		Timeout:            DefaultTimeout,
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   userVerification(requireUV),
+		},
+		Attestation: "none",
+	}
+}
+
+// NewRequestOptions returns the options for authentication. An empty list of allowed
+// credentials lets the user choose from the discoverable credentials stored for the relying party.
+func NewRequestOptions(challenge, rpID string, allow []Descriptor, requireUV bool) RequestOptions {
+	if allow == nil {
+		allow = []Descriptor{}
+	}
+
+	return RequestOptions{
+		Challenge:        challenge,
+		RPID:             rpID,
+		Timeout:          DefaultTimeout,
+		AllowCredentials: allow,
+		UserVerification: userVerification(requireUV),
+	}
+}
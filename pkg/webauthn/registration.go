@@ -0,0 +1,95 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/x509"
+)
+
+// Credential represents a verified public key credential.
+type Credential struct {
+	ID             string
+	PublicKey      []byte
+	Algorithm      int
+	SignCount      uint32
+	AAGUID         []byte
+	BackupEligible bool
+	Transports     []string
+}
+
+// RegistrationResponse represents the JSON encoded result of navigator.credentials.create().
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON     string   `json:"clientDataJSON"`
+		AuthenticatorData  string   `json:"authenticatorData"`
+		PublicKey          string   `json:"publicKey"`
+		PublicKeyAlgorithm int      `json:"publicKeyAlgorithm"`
+		Transports         []string `json:"transports"`
+	} `json:"response"`
+}
+
+// Challenge returns the challenge from the client data, so that the pending ceremony can be looked up.
+func (r RegistrationResponse) Challenge() string {
+	if clientData, _, err := ParseClientData(r.Response.ClientDataJSON); err == nil {
+		return clientData.Challenge
+	}
+
+	return ""
+}
+
+// Verify checks the registration response and returns the new credential if it is valid.
+func (r RegistrationResponse) Verify(challenge, origin, rpID string, requireUV bool) (*Credential, error) {
+	if r.Type != "public-key" {
+		return nil, ErrInvalidType
+	}
+
+	clientData, _, err := ParseClientData(r.Response.ClientDataJSON)
+
+	if err != nil {
+		return nil, err
+	} else if err = clientData.Verify(TypeCreate, challenge, origin); err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseAuthenticatorData(r.Response.AuthenticatorData)
+
+	if err != nil {
+		return nil, err
+	} else if err = authData.Verify(rpID, requireUV); err != nil {
+		return nil, err
+	}
+
+	// The credential id must match the attested credential data.
+	id, err := Decode(r.ID)
+
+	if err != nil {
+		return nil, err
+	} else if len(authData.CredentialID) == 0 || !bytes.Equal(id, authData.CredentialID) {
+		return nil, ErrInvalidCredential
+	}
+
+	// Check the public key.
+	if !SupportedAlgorithm(r.Response.PublicKeyAlgorithm) {
+		return nil, ErrUnsupportedKey
+	}
+
+	publicKey, err := Decode(r.Response.PublicKey)
+
+	if err != nil {
+		return nil, err
+	} else if _, err = x509.ParsePKIXPublicKey(publicKey); err != nil {
+		return nil, ErrUnsupportedKey
+	}
+
+	return &Credential{
+		ID:             Encode(id),
+		PublicKey:      publicKey,
+		Algorithm:      r.Response.PublicKeyAlgorithm,
+		SignCount:      authData.SignCount,
+		AAGUID:         authData.AAGUID,
+		BackupEligible: authData.BackupEligible(),
+		Transports:     r.Response.Transports,
+	}, nil
+}
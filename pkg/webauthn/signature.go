@@ -0,0 +1,67 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+)
+
+// COSE algorithm identifiers, see https://www.iana.org/assignments/cose/cose.xhtml#algorithms.
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// Algorithms lists the supported public key algorithms in order of preference.
+var Algorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+// SupportedAlgorithm checks if the public key algorithm is supported.
+func SupportedAlgorithm(alg int) bool {
+	for _, a := range Algorithms {
+		if a == alg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VerifySignature checks the signature of the data with the DER encoded public key.
+func VerifySignature(publicKey []byte, alg int, data, sig []byte) error {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+
+	if err != nil {
+		return ErrUnsupportedKey
+	}
+
+	hash := sha256.Sum256(data)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if alg != AlgES256 {
+			return ErrUnsupportedKey
+		} else if !ecdsa.VerifyASN1(k, hash[:], sig) {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if alg != AlgEdDSA {
+			return ErrUnsupportedKey
+		} else if !ed25519.Verify(k, data, sig) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		if alg != AlgRS256 {
+			return ErrUnsupportedKey
+		} else if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedKey
+	}
+
+	return nil
+}
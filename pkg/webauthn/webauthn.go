@@ -0,0 +1,81 @@
+/*
+Package webauthn provides the server-side verification of WebAuthn registration and authentication ceremonies.
+
+Registration responses must include the public key and algorithm in the JSON format defined by WebAuthn Level 3,
+as returned by PublicKeyCredential.toJSON() or AuthenticatorAttestationResponse.getPublicKey(), so that no CBOR
+decoding is required. Attestation statements are not verified.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package webauthn
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ChallengeLength specifies the number of random challenge bytes.
+const ChallengeLength = 32
+
+var (
+	ErrInvalidEncoding   = errors.New("invalid encoding")
+	ErrInvalidType       = errors.New("invalid ceremony type")
+	ErrInvalidChallenge  = errors.New("challenge does not match")
+	ErrInvalidOrigin     = errors.New("origin does not match")
+	ErrInvalidRPID       = errors.New("relying party id does not match")
+	ErrUserNotPresent    = errors.New("user not present")
+	ErrUserNotVerified   = errors.New("user not verified")
+	ErrInvalidAuthData   = errors.New("invalid authenticator data")
+	ErrInvalidCredential = errors.New("credential id does not match")
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrUnsupportedKey    = errors.New("unsupported public key algorithm")
+	ErrCounterRollback   = errors.New("signature counter did not increase")
+)
+
+// NewChallenge returns a new random challenge encoded as base64url string.
+func NewChallenge() (string, error) {
+	b := make([]byte, ChallengeLength)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return Encode(b), nil
+}
+
+// Encode returns the data as base64url string without padding.
+func Encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode decodes a base64url string with or without padding.
+func Decode(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "=")); err == nil {
+		return b, nil
+	} else if b, err = base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+
+	return nil, ErrInvalidEncoding
+}
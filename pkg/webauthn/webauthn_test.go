@@ -0,0 +1,158 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRPID   = "photos.example.com"
+	testOrigin = "https://photos.example.com"
+)
+
+// testAuthData returns authenticator data with the specified flags, counter and credential id.
+func testAuthData(rpID string, flags byte, count uint32, credID []byte) []byte {
+	hash := sha256.Sum256([]byte(rpID))
+	b := append([]byte{}, hash[:]...)
+	b = append(b, flags)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[len(b)-4:], count)
+
+	if len(credID) > 0 {
+		b = append(b, make([]byte, 16)...)
+		b = append(b, 0, 0)
+		binary.BigEndian.PutUint16(b[len(b)-2:], uint16(len(credID)))
+		b = append(b, credID...)
+	}
+
+	return b
+}
+
+// testClientData returns the JSON encoded client data.
+func testClientData(ceremony, challenge, origin string) []byte {
+	b, _ := json.Marshal(ClientData{Type: ceremony, Challenge: challenge, Origin: origin})
+	return b
+}
+
+func TestNewChallenge(t *testing.T) {
+	c, err := NewChallenge()
+	assert.NoError(t, err)
+
+	b, err := Decode(c)
+	assert.NoError(t, err)
+	assert.Len(t, b, ChallengeLength)
+}
+
+func TestDecode(t *testing.T) {
+	b, err := Decode("aGVsbG8")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	b, err = Decode("aGVsbG8=")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	_, err = Decode("!!!")
+	assert.Error(t, err)
+}
+
+func TestCeremony(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credID := []byte("credential-1234")
+	challenge, _ := NewChallenge()
+
+	var reg RegistrationResponse
+	reg.ID = Encode(credID)
+	reg.RawID = reg.ID
+	reg.Type = "public-key"
+	reg.Response.ClientDataJSON = Encode(testClientData(TypeCreate, challenge, testOrigin))
+	reg.Response.AuthenticatorData = Encode(testAuthData(testRPID, FlagUserPresent|FlagUserVerified|FlagBackupEligible|FlagAttestedData, 0, credID))
+	reg.Response.PublicKey = Encode(publicKey)
+	reg.Response.PublicKeyAlgorithm = AlgES256
+
+	t.Run("Register", func(t *testing.T) {
+		_, err = reg.Verify(challenge, "https://evil.example.com", testRPID, true)
+		assert.ErrorIs(t, err, ErrInvalidOrigin)
+
+		_, err = reg.Verify("other", testOrigin, testRPID, true)
+		assert.ErrorIs(t, err, ErrInvalidChallenge)
+
+		_, err = reg.Verify(challenge, testOrigin, "example.com", true)
+		assert.ErrorIs(t, err, ErrInvalidRPID)
+	})
+
+	assert.Equal(t, challenge, reg.Challenge())
+
+	cred, err := reg.Verify(challenge, testOrigin, testRPID, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, Encode(credID), cred.ID)
+	assert.True(t, cred.BackupEligible)
+
+	// assertion returns a signed authentication response.
+	assertion := func(count uint32, flags byte) AssertionResponse {
+		var r AssertionResponse
+		authData := testAuthData(testRPID, flags, count, nil)
+		clientData := testClientData(TypeGet, challenge, testOrigin)
+		hash := sha256.Sum256(clientData)
+		digest := sha256.Sum256(append(append([]byte{}, authData...), hash[:]...))
+		sig, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+
+		r.ID = Encode(credID)
+		r.Type = "public-key"
+		r.Response.ClientDataJSON = Encode(clientData)
+		r.Response.AuthenticatorData = Encode(authData)
+		r.Response.Signature = Encode(sig)
+		r.Response.UserHandle = Encode([]byte("uqxetse3cy5eo9z2"))
+
+		return r
+	}
+
+	t.Run("Login", func(t *testing.T) {
+		r := assertion(1, FlagUserPresent|FlagUserVerified)
+		assert.Equal(t, "uqxetse3cy5eo9z2", r.UserHandle())
+		assert.Equal(t, challenge, r.Challenge())
+
+		count, err := r.Verify(challenge, testOrigin, testRPID, true, *cred)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), count)
+	})
+	t.Run("NotVerified", func(t *testing.T) {
+		_, err := assertion(2, FlagUserPresent).Verify(challenge, testOrigin, testRPID, true, *cred)
+		assert.ErrorIs(t, err, ErrUserNotVerified)
+	})
+	t.Run("Counter", func(t *testing.T) {
+		c := *cred
+		c.SignCount = 5
+		_, err := assertion(5, FlagUserPresent|FlagUserVerified).Verify(challenge, testOrigin, testRPID, true, c)
+		assert.ErrorIs(t, err, ErrCounterRollback)
+	})
+	t.Run("Signature", func(t *testing.T) {
+		r := assertion(1, FlagUserPresent|FlagUserVerified)
+		r.Response.Signature = Encode([]byte("invalid"))
+		_, err := r.Verify(challenge, testOrigin, testRPID, true, *cred)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}